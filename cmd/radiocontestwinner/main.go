@@ -1,27 +1,142 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"syscall"
 	"time"
 
 	"go.uber.org/zap"
 
+	"radiocontestwinner/internal/api"
 	"radiocontestwinner/internal/app"
+	"radiocontestwinner/internal/bootstrap"
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/health"
+	"radiocontestwinner/internal/logger"
+	"radiocontestwinner/internal/parser"
+	"radiocontestwinner/internal/search"
+	"radiocontestwinner/internal/suggest"
+	"radiocontestwinner/internal/support"
+	"radiocontestwinner/internal/transcriber"
+	"radiocontestwinner/internal/tui"
+	"radiocontestwinner/internal/usage"
+	"radiocontestwinner/internal/version"
 )
 
 // main is the application entry point and orchestrator setup
 func main() {
+	// Scaffold a config directory for running without Docker
+	if len(os.Args) > 1 && os.Args[1] == "init" {
+		if err := runInit(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Init error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Print the cue record JSON Schema or validate cue output against it
+	if len(os.Args) > 1 && os.Args[1] == "schema" {
+		if err := runSchema(os.Args[2:], os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Schema error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Encrypt a secret for the config file
+	if len(os.Args) > 1 && os.Args[1] == "encrypt" {
+		if err := runEncrypt(os.Args[2:], os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Encrypt error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Collect diagnostics to attach to a bug report
+	if len(os.Args) > 1 && os.Args[1] == "support-bundle" {
+		if err := runSupportBundle(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Support bundle error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Search stored transcriptions and cues
+	if len(os.Args) > 1 && os.Args[1] == "search" {
+		if err := runSearch(os.Args[2:], os.Stdout, time.Now()); err != nil {
+			fmt.Fprintf(os.Stderr, "Search error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Suggest allowlist entries for contest numbers heard but not allowlisted
+	if len(os.Args) > 1 && os.Args[1] == "suggest" {
+		if err := runSuggest(os.Args[2:], os.Stdout, time.Now()); err != nil {
+			fmt.Fprintf(os.Stderr, "Suggest error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Report audio transcribed per day and backend
+	if len(os.Args) > 1 && os.Args[1] == "usage" {
+		if err := runUsage(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Usage error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Check the Whisper model file, or download it again
+	if len(os.Args) > 1 && os.Args[1] == "model" {
+		if err := runModel(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Model error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Stream live cues and transcriptions from the running instance
+	if len(os.Args) > 1 && os.Args[1] == "tail" {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		err := runTail(ctx, os.Args[2:], os.Stdout)
+		stop()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Tail error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Pause or resume the running instance
+	if len(os.Args) > 1 && (os.Args[1] == "pause" || os.Args[1] == "resume" || os.Args[1] == "promote") {
+		if err := runControl(os.Args[1], healthFilePath, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Control error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Parse command line flags
 	var (
 		helpFlag    = flag.Bool("help", false, "Show help message")
 		versionFlag = flag.Bool("version", false, "Show version information")
 		healthFlag  = flag.Bool("health", false, "Check application health status")
+		configFlag  = flag.String("config", "", "Path to a config file (same as CONFIG_PATH)")
+		tuiFlag     = flag.Bool("tui", false, "Show the operator console instead of log output")
+		tuiLogFlag  = flag.String("tui-log", "radiocontestwinner.log", "File application logs are written to in -tui mode")
 	)
 	flag.Parse()
 
@@ -40,6 +155,19 @@ func main() {
 		os.Exit(exitCode)
 	}
 
+	if *configFlag != "" {
+		os.Setenv("CONFIG_PATH", *configFlag)
+	}
+
+	// Run with the operator console in place of log output
+	if *tuiFlag {
+		if err := runConsole(*tuiLogFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Application error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Run the main application logic
 	if err := runApplication(); err != nil {
 		fmt.Fprintf(os.Stderr, "Application error: %v\n", err)
@@ -57,12 +185,15 @@ func runApplication() error {
 	defer logger.Sync()
 
 	// Log application startup
+	build := version.Get()
 	logger.Info("Radio Contest Winner starting up",
 		zap.String("component", "main"),
-		zap.String("version", "3.1"))
+		zap.String("version", build.Version),
+		zap.String("commit", build.Commit),
+		zap.String("build_date", build.BuildDate))
 
-	// Create application instance using orchestrator
-	application, err := app.NewApplication()
+	// Create application instance using orchestrator, or one per tenant
+	application, err := newRunner()
 	if err != nil {
 		logger.Error("Failed to create application",
 			zap.Error(err),
@@ -87,6 +218,9 @@ func runApplication() error {
 		cancel()
 	}()
 
+	// Pause and resume on SIGUSR1 and SIGUSR2
+	go application.HandleControlSignals(ctx)
+
 	// Run the application
 	logger.Info("Starting application lifecycle",
 		zap.String("component", "main"))
@@ -114,20 +248,98 @@ func runApplication() error {
 	return nil
 }
 
+// runner is the lifecycle runApplication drives: a single application, or every tenant of a
+// multi-tenant deployment
+type runner interface {
+	Run(ctx context.Context) error
+	HandleControlSignals(ctx context.Context)
+	Shutdown() error
+}
+
+// newRunner creates the application, or one application per tenant when the config defines tenants
+func newRunner() (runner, error) {
+	cfg, err := app.LoadConfiguration()
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.GetTenantNames()) == 0 {
+		return app.NewApplicationWithConfig(cfg)
+	}
+	tenants, err := app.NewTenants(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return tenants, nil
+}
+
 // printHelp displays command line usage information
 func printHelp() {
 	fmt.Println("Radio Contest Winner - Audio Stream Transcription and Contest Detection")
 	fmt.Println()
 	fmt.Println("USAGE:")
 	fmt.Println("    radiocontestwinner [OPTIONS]")
+	fmt.Println("    radiocontestwinner init [-dir DIR] [-model NAME] [-download] [-force]")
+	fmt.Println("    radiocontestwinner schema [-version VERSION] [-validate FILE]")
+	fmt.Println("    radiocontestwinner pause | resume | promote")
+	fmt.Println("    radiocontestwinner encrypt [-key-file FILE] [-generate-key] < VALUE")
+	fmt.Println("    radiocontestwinner support-bundle [-o FILE] [-log FILE]... [-lines N] [-transcripts N]")
+	fmt.Println("    radiocontestwinner search [-since TIME] [-until TIME] [-i] [-C N] [-file FILE]... PATTERN")
+	fmt.Println("    radiocontestwinner suggest [-since TIME] [-until TIME] [-min N] [-file FILE]...")
+	fmt.Println("    radiocontestwinner usage [-days N] [-file FILE]")
+	fmt.Println("    radiocontestwinner model verify | download [-model NAME] [-force]")
+	fmt.Println("    radiocontestwinner tail [-cues] [-transcripts] [-json] [-tenant NAME] [-address ADDR]")
 	fmt.Println()
 	fmt.Println("OPTIONS:")
 	fmt.Println("    -help      Show this help message")
 	fmt.Println("    -version   Show version information")
 	fmt.Println("    -health    Check application health status")
+	fmt.Println("    -config    Path to a config file (same as CONFIG_PATH)")
+	fmt.Println("    -tui       Show the operator console: live transcription, cues, health gauges")
+	fmt.Println("               (hotkeys: a acknowledge cue, A acknowledge all, d toggle debug,")
+	fmt.Println("               m toggle maintenance mode, p pause/resume, q quit)")
+	fmt.Println("    -tui-log   File logs are written to in -tui mode (default radiocontestwinner.log)")
+	fmt.Println()
+	fmt.Println("COMMANDS:")
+	fmt.Println("    init       Create a config directory with the bundled example config,")
+	fmt.Println("               optionally download a Whisper model, and check for FFmpeg")
+	fmt.Println("    schema     Print the JSON Schema of cue records, or validate a JSON cue")
+	fmt.Println("               log (- for stdin) against the schema version of each record")
+	fmt.Println("    pause      Stop transcribing in the running instance, keeping the stream")
+	fmt.Println("               connected (same as sending it SIGUSR1)")
+	fmt.Println("    resume     Resume transcription (same as sending it SIGUSR2)")
+	fmt.Println("    promote    Take a warm standby (coordination.standby) out of standby and")
+	fmt.Println("               start transcribing (same as resume)")
+	fmt.Println("    encrypt    Encrypt a value read from stdin into an enc: value for the config,")
+	fmt.Println("               decrypted at startup with CONFIG_ENCRYPTION_KEY or")
+	fmt.Println("               CONFIG_ENCRYPTION_KEY_FILE; -generate-key prints a new key")
+	fmt.Println("    support-bundle")
+	fmt.Println("               Write a tar.gz to attach to bug reports: effective config with")
+	fmt.Println("               secrets redacted, version, health, GPU detection, the end of the")
+	fmt.Println("               logs, and recent transcriptions")
+	fmt.Println("    search     Print stored transcriptions (debug_transcripts file) and cues (file")
+	fmt.Println("               log sinks) matching a regular expression, with -C records of")
+	fmt.Println("               context; -since and -until take YYYY-MM-DD [HH:MM], RFC3339,")
+	fmt.Println("               or a duration ago such as 24h, in the configured timezone")
+	fmt.Println("    suggest    Scan stored transcriptions (debug_transcripts file) for \"Text X")
+	fmt.Println("               to N\" patterns whose number is not in the allowlist and print")
+	fmt.Println("               those heard at least -min times (default 3) with their keywords,")
+	fmt.Println("               to spot new contests to allowlist; -since and -until as in search")
+	fmt.Println("    usage      Print the minutes of audio transcribed per day by each backend")
+	fmt.Println("               (binary on GPU or CPU, HTTP service, OpenAI API) for the last")
+	fmt.Println("               -days recorded days (default 7, 0 for all) in the usage.path file")
+	fmt.Println("    model      verify checks the configured Whisper model (or -model NAME in")
+	fmt.Println("               paths.model_dir) for truncation and corruption, and its SHA256 when")
+	fmt.Println("               whisper.download.checksums has one; download fetches it again,")
+	fmt.Println("               replacing a corrupted file (-force replaces a good one too)")
+	fmt.Println("    tail       Stream live cues and transcriptions (both unless -cues or")
+	fmt.Println("               -transcripts is given) from the running instance's API (api.enabled,")
+	fmt.Println("               api.address) until interrupted; -json prints the raw events")
 	fmt.Println()
 	fmt.Println("CONFIGURATION:")
-	fmt.Println("    Configuration is loaded from environment variables.")
+	fmt.Println("    Configuration is loaded from the -config file or CONFIG_PATH if set,")
+	fmt.Println("    otherwise from environment variables.")
+	fmt.Println("    A tenants: block in the config file runs one independent deployment per")
+	fmt.Println("    tenant in this process; -health checks all of them.")
 	fmt.Println("    See config.example.yaml for available options.")
 	fmt.Println()
 	fmt.Println("EXAMPLES:")
@@ -135,77 +347,824 @@ func printHelp() {
 	fmt.Println("    radiocontestwinner -help        # Show this help")
 	fmt.Println("    radiocontestwinner -version     # Show version")
 	fmt.Println("    radiocontestwinner -health      # Check health (for Docker healthcheck)")
+	fmt.Println("    radiocontestwinner init -download       # Set up ./radiocontestwinner without Docker")
+	fmt.Println("    radiocontestwinner -config config.yaml  # Run with a config file")
+	fmt.Println("    radiocontestwinner search -i -since \"2026-10-16 06:00\" -until \"2026-10-16 12:00\" SNOW")
 }
 
 // printVersion displays version and build information
 func printVersion() {
-	fmt.Println("Radio Contest Winner")
-	fmt.Println("Version: 3.1")
-	fmt.Println("Build: Application Orchestrator Implementation")
-	fmt.Println("Architecture: Go 1.24 + FFmpeg + Whisper.cpp")
+	fmt.Print(detectVersions())
+}
+
+// detectVersions returns the build metadata and the versions of the whisper.cpp and FFmpeg
+// binaries found on this host
+func detectVersions() version.Info {
+	whisperBinary, _ := transcriber.FindWhisperBinary()
+	ffmpegBinary, _ := bootstrap.FindFFmpeg()
+	info := version.Detect(context.Background(), whisperBinary, ffmpegBinary)
+	if whisperBinary == "" {
+		info.WhisperCpp = "not found"
+	}
+	if ffmpegBinary == "" {
+		info.FFmpeg = "not found"
+	}
+	return info
 }
 
+// healthFilePath is where the running application writes its health status
+const healthFilePath = health.DefaultFile
+
 // checkHealth checks the application health status by reading the health file
 func checkHealth() int {
-	return checkHealthWithFile("/tmp/radiocontestwinner-health.json")
+	return checkHealthWithFile(healthFilePath)
 }
 
 // checkHealthWithFile checks the application health status by reading the specified health file
 func checkHealthWithFile(healthFile string) int {
-
-	// Check if health file exists
-	if _, err := os.Stat(healthFile); os.IsNotExist(err) {
-		fmt.Printf("UNHEALTHY: Health status file not found (%s)\n", healthFile)
+	result, data := health.CheckFile(healthFile, time.Now())
+	fmt.Println(result.Message)
+	if result.ShowDetails {
+		fmt.Printf("Health details: %s\n", string(data))
+	}
+	if !result.Passed {
 		return 1
 	}
+	return 0
+}
 
-	// Read health file
-	data, err := os.ReadFile(healthFile)
+// runConsole runs the application with the operator console on the terminal. Logs go to
+// logPath so they do not overwrite the console.
+func runConsole(logPath string) error {
+	logFile, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
-		fmt.Printf("UNHEALTHY: Failed to read health file: %v\n", err)
-		return 1
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer logFile.Close()
+
+	// zap loggers write to os.Stderr; point it at the log file before any are created
+	stderr := os.Stderr
+	os.Stderr = logFile
+	defer func() { os.Stderr = stderr }()
+
+	cfg, err := app.LoadConfiguration()
+	if err != nil {
+		return fmt.Errorf("failed to create application: %w", err)
+	}
+	if len(cfg.GetTenantNames()) > 0 {
+		return fmt.Errorf("the operator console shows a single deployment; run tenants without -tui")
+	}
+	application, err := app.NewApplicationWithConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create application: %w", err)
+	}
+	console := tui.NewConsole(application)
+	console.SetLocation(application.DisplayLocation())
+	application.SetObserver(console)
+
+	if width, height, err := tui.Size(os.Stdin); err == nil {
+		console.SetSize(width, height)
+	}
+	if restore, err := tui.EnterRawMode(os.Stdin); err == nil {
+		defer restore()
+	} else {
+		fmt.Fprintf(logFile, "hotkeys need Enter: %v\n", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+	go func() {
+		select {
+		case <-sigChan:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	go application.HandleControlSignals(ctx)
+
+	appErr := make(chan error, 1)
+	go func() {
+		appErr <- application.Run(ctx)
+		cancel()
+	}()
+
+	console.Run(ctx, os.Stdin, os.Stdout, time.Second)
+	cancel()
+
+	runErr := <-appErr
+	if err := application.Shutdown(); err != nil && runErr == nil {
+		runErr = fmt.Errorf("application shutdown error: %w", err)
+	}
+	if runErr != nil {
+		return fmt.Errorf("application runtime error: %w", runErr)
+	}
+	return nil
+}
+
+// runInit scaffolds a config directory for running without Docker
+func runInit(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("init", flag.ContinueOnError)
+	flags.SetOutput(out)
+	var (
+		dir      = flags.String("dir", "radiocontestwinner", "Directory to create")
+		model    = flags.String("model", bootstrap.DefaultModel, "Whisper model name (e.g. tiny.en, base.en, small.en)")
+		download = flags.Bool("download", false, "Download the Whisper model into the models directory")
+		force    = flags.Bool("force", false, "Overwrite an existing config.yaml")
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	result, err := bootstrap.Scaffold(bootstrap.ScaffoldOptions{Dir: *dir, Model: *model, Force: *force})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Created %s\n", result.ConfigPath)
+
+	if *download {
+		cfg, err := config.NewConfigurationFromFile(result.ConfigPath)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		zapLogger, err := zap.NewProduction()
+		if err != nil {
+			return fmt.Errorf("failed to create logger: %w", err)
+		}
+		defer zapLogger.Sync()
+
+		checksum := cfg.GetWhisperModelChecksums()[strings.ToLower(*model)]
+		downloader := transcriber.NewModelDownloaderWithConfig(zapLogger, result.ModelsDir, cfg)
+		if err := downloader.EnsureModelExists(*model, result.ModelPath); err != nil {
+			return err
+		}
+		if err := transcriber.VerifyModelFile(result.ModelPath, *model, checksum); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Whisper model ready at %s\n", result.ModelPath)
+	} else {
+		fmt.Fprintf(out, "Whisper model not downloaded; rerun with -download or place %s in %s\n",
+			bootstrap.ModelFileName(*model), result.ModelsDir)
 	}
 
-	// Parse health status
-	var healthStatus map[string]interface{}
+	if path, err := bootstrap.FindFFmpeg(); err == nil {
+		fmt.Fprintf(out, "FFmpeg found at %s\n", path)
+	} else {
+		fmt.Fprintf(out, "FFmpeg not found. %s\n", bootstrap.FFmpegGuidance())
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Next steps:")
+	fmt.Fprintf(out, "    1. Edit %s (stream.url and allowlist.numbers at least)\n", result.ConfigPath)
+	fmt.Fprintf(out, "    2. cd %s && radiocontestwinner -config config.yaml\n", *dir)
+	return nil
+}
+
+// runSchema prints the JSON Schema of cue records, or validates each line of a JSON cue log
+func runSchema(args []string, stdin io.Reader, out io.Writer) error {
+	flags := flag.NewFlagSet("schema", flag.ContinueOnError)
+	flags.SetOutput(out)
+	var (
+		version  = flags.String("version", logger.CurrentCueSchemaVersion, "Schema version to print")
+		validate = flags.String("validate", "", "JSON cue log to validate instead (- for stdin)")
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *validate == "" {
+		schema, err := logger.CueJSONSchema(*version)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(schema))
+		return nil
+	}
+
+	input := stdin
+	if *validate != "-" {
+		file, err := os.Open(*validate)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	scanner := bufio.NewScanner(input)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	records, invalid := 0, 0
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		records++
+		if err := logger.ValidateCueRecord(scanner.Bytes()); err != nil {
+			invalid++
+			fmt.Fprintf(out, "line %d: %v\n", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%d records, %d invalid\n", records, invalid)
+	if invalid > 0 {
+		return fmt.Errorf("%d invalid cue records", invalid)
+	}
+	return nil
+}
+
+// runControl pauses, resumes, or promotes the running instance, found through the process ID in its
+// health file. Promoting a warm standby resumes it.
+func runControl(command, healthFile string, out io.Writer) error {
+	data, err := os.ReadFile(healthFile)
+	if err != nil {
+		return fmt.Errorf("no running instance found: %w", err)
+	}
+	var healthStatus struct {
+		PID int `json:"pid"`
+	}
 	if err := json.Unmarshal(data, &healthStatus); err != nil {
-		fmt.Printf("UNHEALTHY: Failed to parse health file: %v\n", err)
-		return 1
+		return fmt.Errorf("failed to parse health file: %w", err)
+	}
+	if healthStatus.PID <= 0 {
+		return fmt.Errorf("health file %s has no process ID; wait for the next heartbeat", healthFile)
 	}
 
-	// Check if health check timestamp is recent (within last 90 seconds)
-	timestampStr, ok := healthStatus["health_check_timestamp"].(string)
-	if !ok {
-		fmt.Println("UNHEALTHY: Health file missing timestamp")
-		return 1
+	if err := app.SendControlSignal(healthStatus.PID, command == "pause"); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Sent %s to process %d\n", command, healthStatus.PID)
+	return nil
+}
+
+// runSupportBundle writes a tar.gz of diagnostics to attach to bug reports
+func runSupportBundle(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("support-bundle", flag.ContinueOnError)
+	flags.SetOutput(out)
+	var (
+		output      = flags.String("o", "", "Bundle file to write (default radiocontestwinner-support-TIMESTAMP.tar.gz)")
+		configPath  = flags.String("config", os.Getenv("CONFIG_PATH"), "Path to a config file (same as CONFIG_PATH)")
+		healthFile  = flags.String("health-file", healthFilePath, "Health status file of the running instance")
+		logLines    = flags.Int("lines", 1000, "Lines kept from the end of each log file")
+		transcripts = flags.Int("transcripts", 50, "Recent transcriptions to include from the Redis history")
+		logFiles    []string
+	)
+	flags.Func("log", "Additional log file to include (repeatable)", func(path string) error {
+		logFiles = append(logFiles, path)
+		return nil
+	})
+	if err := flags.Parse(args); err != nil {
+		return err
 	}
 
-	timestamp, err := time.Parse(time.RFC3339, timestampStr)
+	var cfg *config.Configuration
+	var err error
+	if *configPath != "" {
+		cfg, err = config.NewConfigurationFromFile(*configPath)
+	} else {
+		cfg, err = config.NewConfigurationFromEnv()
+	}
 	if err != nil {
-		fmt.Printf("UNHEALTHY: Invalid timestamp format: %v\n", err)
-		return 1
+		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	timeSinceUpdate := time.Since(timestamp)
-	if timeSinceUpdate > 90*time.Second {
-		fmt.Printf("UNHEALTHY: Health file is stale (last update: %v ago)\n", timeSinceUpdate)
-		return 1
+	// The -tui log when there is one, and the cue logs of file sinks
+	if _, err := os.Stat("radiocontestwinner.log"); err == nil {
+		logFiles = append(logFiles, "radiocontestwinner.log")
+	}
+	for _, sink := range cfg.GetLogSinks() {
+		if sink.Type == "file" {
+			logFiles = append(logFiles, sink.Target)
+		}
 	}
 
-	// Check overall health status
-	healthy, ok := healthStatus["healthy"].(bool)
-	if !ok {
-		fmt.Println("UNHEALTHY: Health status missing healthy field")
-		return 1
+	now := time.Now()
+	if *output == "" {
+		*output = "radiocontestwinner-support-" + now.Format("20060102-150405") + ".tar.gz"
+	}
+	file, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	names, err := support.Write(file, support.Options{
+		Config:      cfg,
+		Versions:    detectVersions(),
+		HealthFile:  *healthFile,
+		LogFiles:    logFiles,
+		LogLines:    *logLines,
+		Transcripts: *transcripts,
+		Now:         now,
+	})
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(*output)
+		return err
 	}
 
-	if !healthy {
-		fmt.Println("UNHEALTHY: Application reported unhealthy status")
-		fmt.Printf("Health details: %s\n", string(data))
-		return 1
+	fmt.Fprintf(out, "Wrote %s:\n", *output)
+	for _, name := range names {
+		fmt.Fprintf(out, "    %s\n", name)
 	}
+	fmt.Fprintln(out, "Secrets in the config are redacted; review the logs and transcriptions before sharing.")
+	return nil
+}
 
-	// System is healthy
-	fmt.Printf("HEALTHY: Application is functioning normally (last check: %v ago)\n", timeSinceUpdate)
-	return 0
+// runSearch prints the stored transcriptions and cues matching a pattern within a time range
+func runSearch(args []string, out io.Writer, now time.Time) error {
+	flags := flag.NewFlagSet("search", flag.ContinueOnError)
+	flags.SetOutput(out)
+	var (
+		configPath = flags.String("config", os.Getenv("CONFIG_PATH"), "Path to a config file (same as CONFIG_PATH)")
+		since      = flags.String("since", "", "Only records at or after this time")
+		until      = flags.String("until", "", "Only records before this time")
+		ignoreCase = flags.Bool("i", false, "Match case-insensitively")
+		context    = flags.Int("C", 0, "Records of context to print around each match")
+		files      []string
+	)
+	flags.Func("file", "File to search instead of the configured ones (repeatable)", func(path string) error {
+		files = append(files, path)
+		return nil
+	})
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("expected one pattern to search for")
+	}
+
+	var cfg *config.Configuration
+	var err error
+	if *configPath != "" {
+		cfg, err = config.NewConfigurationFromFile(*configPath)
+	} else {
+		cfg, err = config.NewConfigurationFromEnv()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	location := time.Local
+	if tz := cfg.GetTimezone(); tz != "" {
+		if location, err = time.LoadLocation(tz); err != nil {
+			return fmt.Errorf("invalid timezone: %w", err)
+		}
+	}
+
+	expr := flags.Arg(0)
+	if *ignoreCase {
+		expr = "(?i)" + expr
+	}
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+	opts := search.Options{Pattern: pattern, Context: *context}
+	if *since != "" {
+		if opts.Since, err = search.ParseTime(*since, now, location); err != nil {
+			return err
+		}
+	}
+	if *until != "" {
+		if opts.Until, err = search.ParseTime(*until, now, location); err != nil {
+			return err
+		}
+	}
+	if len(files) == 0 {
+		files = search.Files(cfg)
+	}
+
+	matches, err := search.Search(files, opts)
+	if err != nil {
+		return err
+	}
+	printRecord := func(record search.Record, sep string) {
+		when := "-"
+		if !record.Time.IsZero() {
+			when = record.Time.In(location).Format(parser.HumanTimeLayout)
+		}
+		fmt.Fprintf(out, "%s%s%d%s %s [%s] %s\n", record.File, sep, record.Line, sep, when, record.Kind, record.Text)
+	}
+	for i, match := range matches {
+		if *context > 0 && i > 0 {
+			fmt.Fprintln(out, "--")
+		}
+		for _, record := range match.Before {
+			printRecord(record, "-")
+		}
+		printRecord(match.Record, ":")
+		for _, record := range match.After {
+			printRecord(record, "-")
+		}
+	}
+	fmt.Fprintf(out, "%d matches\n", len(matches))
+	return nil
+}
+
+// runSuggest prints the contest numbers heard in stored transcriptions that the allowlist does
+// not accept, for each tenant when the config has tenants
+func runSuggest(args []string, out io.Writer, now time.Time) error {
+	flags := flag.NewFlagSet("suggest", flag.ContinueOnError)
+	flags.SetOutput(out)
+	var (
+		configPath = flags.String("config", os.Getenv("CONFIG_PATH"), "Path to a config file (same as CONFIG_PATH)")
+		since      = flags.String("since", "", "Only transcriptions at or after this time")
+		until      = flags.String("until", "", "Only transcriptions before this time")
+		minCount   = flags.Int("min", 3, "Times a number must be heard to be suggested")
+		files      []string
+	)
+	flags.Func("file", "Transcription file to scan instead of the configured ones (repeatable)", func(path string) error {
+		files = append(files, path)
+		return nil
+	})
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var cfg *config.Configuration
+	var err error
+	if *configPath != "" {
+		cfg, err = config.NewConfigurationFromFile(*configPath)
+	} else {
+		cfg, err = config.NewConfigurationFromEnv()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	location := time.Local
+	if tz := cfg.GetTimezone(); tz != "" {
+		if location, err = time.LoadLocation(tz); err != nil {
+			return fmt.Errorf("invalid timezone: %w", err)
+		}
+	}
+	opts := suggest.Options{MinCount: *minCount}
+	if *since != "" {
+		if opts.Since, err = search.ParseTime(*since, now, location); err != nil {
+			return err
+		}
+	}
+	if *until != "" {
+		if opts.Until, err = search.ParseTime(*until, now, location); err != nil {
+			return err
+		}
+	}
+
+	// Each tenant has its own allowlist and transcriptions
+	configs, err := cfg.TenantConfigurations()
+	if err != nil {
+		return err
+	}
+	if len(configs) == 0 || len(files) > 0 {
+		configs = []*config.Configuration{cfg}
+	}
+	for i, tenant := range configs {
+		cp, err := newSuggestParser(tenant)
+		if err != nil {
+			return err
+		}
+		scan := files
+		if len(scan) == 0 {
+			scan = search.Files(tenant)
+		}
+		suggestions, err := suggest.Suggest(scan, cp, opts)
+		if err != nil {
+			return err
+		}
+
+		if name := tenant.GetTenantName(); name != "" {
+			if i > 0 {
+				fmt.Fprintln(out)
+			}
+			fmt.Fprintf(out, "Tenant %s:\n", name)
+		}
+		if len(suggestions) == 0 {
+			fmt.Fprintf(out, "No unlisted contest numbers heard %d or more times\n", *minCount)
+			continue
+		}
+		for _, suggestion := range suggestions {
+			line := suggestion.String()
+			if !suggestion.LastSeen.IsZero() {
+				line += fmt.Sprintf(" (first %s, last %s)",
+					suggestion.FirstSeen.In(location).Format(parser.HumanTimeLayout),
+					suggestion.LastSeen.In(location).Format(parser.HumanTimeLayout))
+			}
+			fmt.Fprintln(out, line)
+		}
+	}
+	return nil
+}
+
+// newSuggestParser creates a contest parser matching the way the configured pipeline reads
+// transcriptions: the same allowlist, normalization, substitutions, and keyword filter
+func newSuggestParser(cfg *config.Configuration) (*parser.ContestParser, error) {
+	if err := parser.ValidateAllowlist(cfg.GetAllowlist()); err != nil {
+		return nil, fmt.Errorf("invalid allowlist: %w", err)
+	}
+	cp := parser.NewContestParser(cfg.GetAllowlist())
+	if err := cp.ConfigureNormalization(cfg.GetNormalizationSteps(), cfg.GetNormalizationHomophones()); err != nil {
+		return nil, fmt.Errorf("failed to configure text normalization: %w", err)
+	}
+	substitutions := cfg.GetSubstitutions()
+	if path := cfg.GetSubstitutionFile(); path != "" {
+		fileEntries, err := parser.LoadSubstitutionFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load substitutions: %w", err)
+		}
+		substitutions = parser.MergeSubstitutions(substitutions, fileEntries)
+	}
+	cp.SetSubstitutions(parser.NewSubstitutionDictionary(substitutions))
+	cp.SetKeywordFilter(parser.NewKeywordFilter(cfg.GetKeywordMinLength(), cfg.GetKeywordMaxLength(), cfg.GetKeywordStopWords()))
+	return cp, nil
+}
+
+// runModel verifies the Whisper model file or downloads it again
+func runModel(args []string, out io.Writer) error {
+	if len(args) == 0 || (args[0] != "verify" && args[0] != "download") {
+		return fmt.Errorf("expected model verify or model download")
+	}
+	command := args[0]
+	flags := flag.NewFlagSet("model "+command, flag.ContinueOnError)
+	flags.SetOutput(out)
+	var (
+		configPath = flags.String("config", os.Getenv("CONFIG_PATH"), "Path to a config file (same as CONFIG_PATH)")
+		modelName  = flags.String("model", "", "Model name such as base.en; defaults to the configured model")
+		force      = flags.Bool("force", false, "Download even if the model verifies")
+	)
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	var cfg *config.Configuration
+	var err error
+	if *configPath != "" {
+		cfg, err = config.NewConfigurationFromFile(*configPath)
+	} else {
+		cfg, err = config.NewConfigurationFromEnv()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	modelPath := cfg.GetWhisperModelPath()
+	if *modelName != "" {
+		modelPath = filepath.Join(cfg.GetPathsModelDir(), bootstrap.ModelFileName(*modelName))
+	}
+	name := transcriber.ModelNameFromPath(modelPath)
+	checksum := cfg.GetWhisperModelChecksums()[strings.ToLower(name)]
+
+	verifyErr := transcriber.VerifyModelFile(modelPath, name, checksum)
+	if command == "verify" {
+		if verifyErr != nil {
+			return verifyErr
+		}
+		fmt.Fprintf(out, "Model %s verified\n", modelPath)
+		return nil
+	}
+
+	if verifyErr == nil && !*force {
+		fmt.Fprintf(out, "Model %s verified; use -force to download it anyway\n", modelPath)
+		return nil
+	}
+	if name == "" {
+		return fmt.Errorf("cannot determine model name from path %s; pass -model", modelPath)
+	}
+	// A good model is set aside until its replacement verifies; a corrupted one is discarded
+	if verifyErr == nil {
+		previous := modelPath + ".old"
+		if err := os.Rename(modelPath, previous); err != nil {
+			return fmt.Errorf("failed to set aside model: %w", err)
+		}
+		defer func() {
+			if _, err := os.Stat(modelPath); err == nil {
+				os.Remove(previous)
+			} else {
+				os.Rename(previous, modelPath)
+			}
+		}()
+	} else if err := os.Remove(modelPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove model: %w", err)
+	}
+
+	zapLogger, err := zap.NewProduction()
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+	defer zapLogger.Sync()
+	downloader := transcriber.NewModelDownloaderWithConfig(zapLogger, filepath.Dir(modelPath), cfg)
+	if err := downloader.EnsureModelExists(name, modelPath); err != nil {
+		return err
+	}
+	if err := transcriber.VerifyModelFile(modelPath, name, checksum); err != nil {
+		os.Remove(modelPath)
+		return err
+	}
+	fmt.Fprintf(out, "Model %s downloaded and verified\n", modelPath)
+	return nil
+}
+
+// runUsage prints the audio transcribed per day and backend, for each tenant when the config
+// has tenants
+func runUsage(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("usage", flag.ContinueOnError)
+	flags.SetOutput(out)
+	var (
+		configPath = flags.String("config", os.Getenv("CONFIG_PATH"), "Path to a config file (same as CONFIG_PATH)")
+		days       = flags.Int("days", 7, "Most recent recorded days to report; 0 reports every recorded day")
+		file       = flags.String("file", "", "Usage file to report instead of the configured one")
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	type ledger struct{ tenant, path string }
+	var ledgers []ledger
+	if *file != "" {
+		ledgers = []ledger{{path: *file}}
+	} else {
+		var cfg *config.Configuration
+		var err error
+		if *configPath != "" {
+			cfg, err = config.NewConfigurationFromFile(*configPath)
+		} else {
+			cfg, err = config.NewConfigurationFromEnv()
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		tenants, err := cfg.TenantConfigurations()
+		if err != nil {
+			return err
+		}
+		for _, tenant := range tenants {
+			ledgers = append(ledgers, ledger{tenant: tenant.GetTenantName(), path: tenant.GetUsagePath()})
+		}
+		if len(tenants) == 0 {
+			ledgers = []ledger{{path: cfg.GetUsagePath()}}
+		}
+	}
+
+	for i, l := range ledgers {
+		if l.path == "" {
+			return fmt.Errorf("usage.path is empty, so usage is not recorded")
+		}
+		recorded, err := usage.Load(l.path)
+		if err != nil {
+			return err
+		}
+		if *days > 0 && len(recorded) > *days {
+			recorded = recorded[len(recorded)-*days:]
+		}
+		if l.tenant != "" {
+			if i > 0 {
+				fmt.Fprintln(out)
+			}
+			fmt.Fprintf(out, "Tenant %s:\n", l.tenant)
+		}
+		if err := usage.WriteReport(out, recorded); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runTail prints the live cues and transcriptions of the running instance until ctx is cancelled
+func runTail(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("tail", flag.ContinueOnError)
+	flags.SetOutput(out)
+	var (
+		configPath  = flags.String("config", os.Getenv("CONFIG_PATH"), "Path to a config file (same as CONFIG_PATH)")
+		cues        = flags.Bool("cues", false, "Stream contest cues")
+		transcripts = flags.Bool("transcripts", false, "Stream transcriptions")
+		rawJSON     = flags.Bool("json", false, "Print each event as a JSON line")
+		tenant      = flags.String("tenant", "", "Tenant to follow when the config has tenants")
+		address     = flags.String("address", "", "API address to connect to instead of the configured one")
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var cfg *config.Configuration
+	var err error
+	if *configPath != "" {
+		cfg, err = config.NewConfigurationFromFile(*configPath)
+	} else {
+		cfg, err = config.NewConfigurationFromEnv()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if *tenant != "" {
+		if cfg, err = cfg.ForTenant(*tenant); err != nil {
+			return err
+		}
+	} else if names := cfg.GetTenantNames(); len(names) > 0 && *address == "" {
+		return fmt.Errorf("the config has tenants; choose one with -tenant (%s)", strings.Join(names, ", "))
+	}
+	if *address == "" {
+		if !cfg.GetAPIEnabled() {
+			return fmt.Errorf("api.enabled is false, so the running instance does not serve live output")
+		}
+		*address = cfg.GetAPIAddress()
+	}
+	location := time.Local
+	if tz := cfg.GetTimezone(); tz != "" {
+		if location, err = time.LoadLocation(tz); err != nil {
+			return fmt.Errorf("invalid timezone: %w", err)
+		}
+	}
+
+	kinds := api.Kinds()
+	if *cues != *transcripts {
+		kinds = []string{api.KindCue}
+		if *transcripts {
+			kinds = []string{api.KindTranscript}
+		}
+	}
+	return api.Tail(ctx, *address, cfg.GetAPIToken(), kinds, func(event api.Event) error {
+		if *rawJSON {
+			line, err := json.Marshal(event)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(out, "%s\n", line)
+			return err
+		}
+		_, err := fmt.Fprintln(out, formatLiveEvent(event, location))
+		return err
+	})
+}
+
+// formatLiveEvent returns a live event as one line of terminal output
+func formatLiveEvent(event api.Event, location *time.Location) string {
+	when := event.Time.In(location).Format(parser.HumanTimeLayout)
+	switch event.Kind {
+	case api.KindCue:
+		var cue parser.ContestCue
+		if err := json.Unmarshal(event.Data, &cue); err == nil {
+			return fmt.Sprintf("%s [cue] %s: text %s to %s", when, cue.ContestType, cue.Details.Keyword, cue.Details.Number)
+		}
+	case api.KindTranscript:
+		var segment transcriber.TranscriptionSegment
+		if err := json.Unmarshal(event.Data, &segment); err == nil {
+			return fmt.Sprintf("%s [transcript] %s", when, strings.TrimSpace(segment.Text))
+		}
+	}
+	return fmt.Sprintf("%s [%s] %s", when, event.Kind, event.Data)
+}
+
+// runEncrypt prints the enc: config value of the plaintext read from stdin, or a new key
+func runEncrypt(args []string, stdin io.Reader, out io.Writer) error {
+	flags := flag.NewFlagSet("encrypt", flag.ContinueOnError)
+	flags.SetOutput(out)
+	var (
+		keyFile     = flags.String("key-file", "", "File containing the key (default "+config.EncryptionKeyEnv+" or "+config.EncryptionKeyFileEnv+")")
+		generateKey = flags.Bool("generate-key", false, "Print a new random key instead")
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *generateKey {
+		key, err := config.GenerateEncryptionKey()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, key)
+		return nil
+	}
+
+	var key []byte
+	var err error
+	if *keyFile != "" {
+		key, err = config.ReadEncryptionKeyFile(*keyFile)
+	} else {
+		key, err = config.LoadEncryptionKey()
+	}
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return fmt.Errorf("no key: set %s or %s, or pass -key-file (create one with -generate-key)",
+			config.EncryptionKeyEnv, config.EncryptionKeyFileEnv)
+	}
+
+	plaintext, err := io.ReadAll(stdin)
+	if err != nil {
+		return err
+	}
+	value := strings.TrimRight(string(plaintext), "\r\n")
+	if value == "" {
+		return fmt.Errorf("nothing to encrypt; pass the value on stdin")
+	}
+	encrypted, err := config.EncryptValue(key, value)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, encrypted)
+	return nil
 }
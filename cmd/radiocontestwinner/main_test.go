@@ -1,11 +1,17 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
 	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"syscall"
 	"testing"
@@ -15,7 +21,11 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"radiocontestwinner/internal/api"
 	"radiocontestwinner/internal/app"
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/logger"
+	"radiocontestwinner/internal/parser"
 )
 
 func TestPrintHelp(t *testing.T) {
@@ -224,6 +234,22 @@ func TestCheckHealth(t *testing.T) {
 		exitCode := checkHealthWithFile(healthFile)
 		assert.Equal(t, 0, exitCode)
 	})
+
+	t.Run("should pass with warning when status is degraded", func(t *testing.T) {
+		healthStatus := map[string]interface{}{
+			"healthy":                true,
+			"status":                 "degraded",
+			"health_check_timestamp": time.Now().Format(time.RFC3339),
+		}
+		data, err := json.Marshal(healthStatus)
+		require.NoError(t, err)
+
+		err = os.WriteFile(healthFile, data, 0644)
+		require.NoError(t, err)
+
+		exitCode := checkHealthWithFile(healthFile)
+		assert.Equal(t, 0, exitCode)
+	})
 }
 
 func TestCheckHealthWrapper(t *testing.T) {
@@ -628,3 +654,426 @@ func TestRunApplication(t *testing.T) {
 		// exercise the same components (app.NewApplication(), logger creation, etc.)
 	})
 }
+
+func TestNewRunner(t *testing.T) {
+	t.Run("should run one application per tenant when the config defines tenants", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		path := filepath.Join(dir, "config.yaml")
+		yaml := "log:\n  file_path: " + dir + "/cues.log\n" +
+			"debug_transcripts:\n  path: " + dir + "/transcriptions.log\n" +
+			"notifier:\n  queue:\n    dir: \"\"\n" +
+			"tenants:\n  kiss: {}\n  wxyz: {}\n"
+		require.NoError(t, os.WriteFile(path, []byte(yaml), 0644))
+		t.Setenv("CONFIG_PATH", path)
+
+		// Act
+		r, err := newRunner()
+
+		// Assert
+		require.NoError(t, err)
+		tenants, ok := r.(*app.Tenants)
+		require.True(t, ok)
+		assert.Equal(t, []string{"kiss", "wxyz"}, tenants.Names())
+	})
+
+	t.Run("should run a single application without tenants", func(t *testing.T) {
+		t.Setenv("CONFIG_PATH", "")
+
+		r, err := newRunner()
+
+		require.NoError(t, err)
+		assert.IsType(t, &app.Application{}, r)
+	})
+}
+
+func TestRunInit(t *testing.T) {
+	t.Run("should scaffold a config directory and print next steps", func(t *testing.T) {
+		// Arrange
+		dir := filepath.Join(t.TempDir(), "rcw")
+		var out bytes.Buffer
+
+		// Act
+		err := runInit([]string{"-dir", dir, "-model", "tiny.en"}, &out)
+
+		// Assert
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(dir, "config.yaml"))
+		assert.Contains(t, out.String(), "ggml-tiny.en.bin")
+		assert.Contains(t, out.String(), "Next steps")
+	})
+
+	t.Run("should fail when the config already exists", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		require.NoError(t, runInit([]string{"-dir", dir}, io.Discard))
+
+		// Act
+		err := runInit([]string{"-dir", dir}, io.Discard)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestRunSchema(t *testing.T) {
+	t.Run("should print the JSON Schema of the requested version", func(t *testing.T) {
+		// Arrange
+		var out bytes.Buffer
+
+		// Act
+		err := runSchema([]string{"-version", logger.CueSchemaV1_0}, nil, &out)
+
+		// Assert
+		require.NoError(t, err)
+		var schema map[string]interface{}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &schema))
+		assert.Equal(t, "Contest cue record 1.0", schema["title"])
+	})
+
+	t.Run("should validate a cue log from stdin and report invalid lines", func(t *testing.T) {
+		// Arrange
+		input := strings.NewReader(`{"cue_id":"a","contest_type":"CASH","keyword":"CASH","shortcode":"55555","timestamp":"2025-06-01T12:00:00Z"}
+
+{"schema_version":"1.1","cue_id":"b","contest_type":"CASH","keyword":"CASH","timestamp":"2025-06-01T12:00:00Z"}
+`)
+		var out bytes.Buffer
+
+		// Act
+		err := runSchema([]string{"-validate", "-"}, input, &out)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Contains(t, out.String(), "line 3: cue record is missing required field shortcode")
+		assert.Contains(t, out.String(), "2 records, 1 invalid")
+	})
+}
+
+func TestRunControl(t *testing.T) {
+	t.Run("should fail when no instance is running", func(t *testing.T) {
+		// Arrange
+		var out bytes.Buffer
+
+		// Act
+		err := runControl("pause", filepath.Join(t.TempDir(), "missing.json"), &out)
+
+		// Assert
+		assert.ErrorContains(t, err, "no running instance")
+	})
+
+	t.Run("should fail when the health file has no process ID", func(t *testing.T) {
+		healthFile := filepath.Join(t.TempDir(), "health.json")
+		require.NoError(t, os.WriteFile(healthFile, []byte(`{"healthy":true}`), 0644))
+
+		err := runControl("resume", healthFile, io.Discard)
+
+		assert.ErrorContains(t, err, "no process ID")
+	})
+}
+
+func TestRunSupportBundle(t *testing.T) {
+	t.Run("should write a bundle with the redacted config", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		configFile := filepath.Join(dir, "config.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte("notifier:\n  webhook:\n    secret: \"hunter2\"\n"), 0644))
+		output := filepath.Join(dir, "bundle.tar.gz")
+		var out bytes.Buffer
+
+		// Act
+		err := runSupportBundle([]string{"-o", output, "-config", configFile,
+			"-health-file", filepath.Join(dir, "health.json"), "-transcripts", "0"}, &out)
+
+		// Assert
+		require.NoError(t, err)
+		assert.FileExists(t, output)
+		assert.Contains(t, out.String(), "config.json")
+		assert.Contains(t, out.String(), "errors.txt")
+	})
+
+	t.Run("should fail for a missing config file", func(t *testing.T) {
+		err := runSupportBundle([]string{"-o", filepath.Join(t.TempDir(), "bundle.tar.gz"), "-config", "/nonexistent/config.yaml"}, io.Discard)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestRunEncrypt(t *testing.T) {
+	t.Run("should encrypt stdin into a value the config decrypts", func(t *testing.T) {
+		// Arrange
+		var keyOut bytes.Buffer
+		require.NoError(t, runEncrypt([]string{"-generate-key"}, nil, &keyOut))
+		keyFile := filepath.Join(t.TempDir(), "config.key")
+		require.NoError(t, os.WriteFile(keyFile, keyOut.Bytes(), 0600))
+		var out bytes.Buffer
+
+		// Act
+		err := runEncrypt([]string{"-key-file", keyFile}, strings.NewReader("hunter2\n"), &out)
+
+		// Assert
+		require.NoError(t, err)
+		key, err := config.ReadEncryptionKeyFile(keyFile)
+		require.NoError(t, err)
+		plaintext, err := config.DecryptValue(key, strings.TrimSpace(out.String()))
+		require.NoError(t, err)
+		assert.Equal(t, "hunter2", plaintext)
+	})
+
+	t.Run("should fail without a key", func(t *testing.T) {
+		t.Setenv(config.EncryptionKeyEnv, "")
+		t.Setenv(config.EncryptionKeyFileEnv, "")
+
+		err := runEncrypt(nil, strings.NewReader("hunter2"), io.Discard)
+
+		assert.ErrorContains(t, err, "no key")
+	})
+}
+
+func TestRunSuggest(t *testing.T) {
+	t.Run("should print unlisted numbers heard at least the minimum times", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		configFile := filepath.Join(dir, "config.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte("timezone: \"UTC\"\nallowlist:\n  numbers: [\"55555\"]\n"), 0644))
+		transcripts := filepath.Join(dir, "transcripts.jsonl")
+		require.NoError(t, os.WriteFile(transcripts, []byte(
+			`{"timestamp":"2026-10-16T07:00:00Z","text":"text CASH to 7788"}`+"\n"+
+				`{"timestamp":"2026-10-16T08:00:00Z","text":"text CASH to 7788 or text WIN to 55555"}`+"\n"+
+				`{"timestamp":"2026-10-16T09:00:00Z","text":"text PIZZA to 9999"}`+"\n"), 0644))
+		var out bytes.Buffer
+
+		// Act
+		err := runSuggest([]string{"-config", configFile, "-file", transcripts, "-min", "2"}, &out, time.Now())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "number 7788 seen 2 times with keyword CASH (first 2026-10-16 07:00:00 UTC, last 2026-10-16 08:00:00 UTC)\n", out.String())
+	})
+
+	t.Run("should say when nothing is suggested", func(t *testing.T) {
+		var out bytes.Buffer
+
+		err := runSuggest([]string{"-file", filepath.Join(t.TempDir(), "missing.jsonl")}, &out, time.Now())
+
+		require.NoError(t, err)
+		assert.Equal(t, "No unlisted contest numbers heard 3 or more times\n", out.String())
+	})
+}
+
+// testModel returns a minimal ggml Whisper model that passes verification
+func testModel() []byte {
+	var buf bytes.Buffer
+	buf.Write([]byte{0x6c, 0x6d, 0x67, 0x67})
+	// vocab, audio ctx/state/head/layers, text ctx/state/head/layers, mels, f16
+	binary.Write(&buf, binary.LittleEndian, []int32{100, 1500, 8, 1, 1, 448, 8, 1, 1, 80, 1})
+	buf.Write(make([]byte, (100*8+12*64+16*64)*2))
+	return buf.Bytes()
+}
+
+func TestRunModel(t *testing.T) {
+	writeConfig := func(t *testing.T, mirror string) (string, string) {
+		dir := t.TempDir()
+		configFile := filepath.Join(dir, "config.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte("paths:\n  model_dir: "+dir+
+			"\nwhisper:\n  model_path: "+filepath.Join(dir, "ggml-tiny.en.bin")+
+			"\n  download:\n    mirrors: [\""+mirror+"\"]\n"), 0644))
+		return configFile, filepath.Join(dir, "ggml-tiny.en.bin")
+	}
+
+	t.Run("should report a corrupted model with the command to fix it", func(t *testing.T) {
+		// Arrange
+		configFile, modelPath := writeConfig(t, "")
+		require.NoError(t, os.WriteFile(modelPath, []byte("<html>not found</html>"), 0644))
+		var out bytes.Buffer
+
+		// Act
+		err := runModel([]string{"verify", "-config", configFile}, &out)
+
+		// Assert
+		assert.ErrorContains(t, err, "model corrupted")
+		assert.ErrorContains(t, err, "radiocontestwinner model download -model tiny.en")
+	})
+
+	t.Run("should replace a corrupted model when downloading", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/ggml-tiny.en.bin", r.URL.Path)
+			w.Write(testModel())
+		}))
+		defer server.Close()
+		configFile, modelPath := writeConfig(t, server.URL)
+		require.NoError(t, os.WriteFile(modelPath, testModel()[:100], 0644))
+		var out bytes.Buffer
+
+		// Act
+		err := runModel([]string{"download", "-config", configFile}, &out)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "downloaded and verified")
+		require.NoError(t, runModel([]string{"verify", "-config", configFile}, &out))
+	})
+
+	t.Run("should keep a model that verifies unless forced", func(t *testing.T) {
+		// Arrange
+		configFile, modelPath := writeConfig(t, "")
+		require.NoError(t, os.WriteFile(modelPath, testModel(), 0644))
+		var out bytes.Buffer
+
+		// Act
+		err := runModel([]string{"download", "-config", configFile}, &out)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "use -force")
+	})
+
+	t.Run("should reject an unknown subcommand", func(t *testing.T) {
+		assert.ErrorContains(t, runModel([]string{"fetch"}, io.Discard), "expected model verify or model download")
+	})
+}
+
+func TestRunUsage(t *testing.T) {
+	t.Run("should report the most recent days from the usage file", func(t *testing.T) {
+		// Arrange
+		usageFile := filepath.Join(t.TempDir(), "usage.json")
+		require.NoError(t, os.WriteFile(usageFile, []byte(`{"days":{
+			"2026-10-15":{"binary_gpu":600},
+			"2026-10-16":{"binary_gpu":3600,"api":90},
+			"2026-10-17":{"service":30}}}`), 0644))
+		var out bytes.Buffer
+
+		// Act
+		err := runUsage([]string{"-file", usageFile, "-days", "2"}, &out)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotContains(t, out.String(), "2026-10-15")
+		assert.Contains(t, out.String(), "2026-10-16        60.0         0.0         0.0         1.5        61.5")
+		assert.Contains(t, out.String(), "TOTAL             60.0         0.0         0.5         1.5        62.0")
+	})
+
+	t.Run("should report each tenant's usage file", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		configFile := filepath.Join(dir, "config.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte("usage:\n  path: "+filepath.Join(dir, "usage.json")+
+			"\ntenants:\n  kiss: {}\n  wxyz: {}\n"), 0644))
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "kiss"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "kiss", "usage.json"),
+			[]byte(`{"days":{"2026-10-17":{"binary_cpu":120}}}`), 0644))
+		var out bytes.Buffer
+
+		// Act
+		err := runUsage([]string{"-config", configFile}, &out)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "Tenant kiss:\nDATE")
+		assert.Contains(t, out.String(), "Tenant wxyz:\nNo transcription usage recorded")
+	})
+}
+
+func TestRunSearch(t *testing.T) {
+	t.Run("should print matches within the time range with context", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		configFile := filepath.Join(dir, "config.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte("timezone: \"UTC\"\n"), 0644))
+		transcripts := filepath.Join(dir, "transcripts.jsonl")
+		require.NoError(t, os.WriteFile(transcripts, []byte(
+			`{"timestamp":"2026-10-16T07:00:00Z","text":"good morning"}`+"\n"+
+				`{"timestamp":"2026-10-16T07:01:00Z","text":"text snow to 55555"}`+"\n"+
+				`{"timestamp":"2026-10-16T15:00:00Z","text":"more snow this afternoon"}`+"\n"), 0644))
+		var out bytes.Buffer
+
+		// Act
+		err := runSearch([]string{"-config", configFile, "-file", transcripts, "-i", "-C", "1",
+			"-since", "2026-10-16 06:00", "-until", "2026-10-16 12:00", "SNOW"}, &out, time.Now())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), transcripts+"-1- 2026-10-16 07:00:00 UTC [transcript] good morning")
+		assert.Contains(t, out.String(), transcripts+":2: 2026-10-16 07:01:00 UTC [transcript] text snow to 55555")
+		assert.NotContains(t, out.String(), transcripts+":3:")
+		assert.Contains(t, out.String(), "1 matches")
+	})
+
+	t.Run("should reject an invalid pattern", func(t *testing.T) {
+		err := runSearch([]string{"-file", "/nonexistent", "("}, io.Discard, time.Now())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestRunTail(t *testing.T) {
+	t.Run("should print live cues from the running instance", func(t *testing.T) {
+		// Arrange
+		dir, err := os.MkdirTemp("", "tail")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+		address := "unix:" + filepath.Join(dir, "rcw.sock")
+		configFile := filepath.Join(dir, "config.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte("timezone: \"UTC\"\napi:\n  enabled: true\n  address: "+address+"\n"), 0644))
+		hub := api.NewHub()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go api.NewServer(address, hub, zap.NewNop()).Run(ctx)
+		require.Eventually(t, func() bool {
+			_, err := os.Stat(filepath.Join(dir, "rcw.sock"))
+			return err == nil
+		}, 2*time.Second, 10*time.Millisecond)
+		cue := parser.NewContestCue("TEXT_TO_NUMBER", parser.CueDetails{Keyword: "SNOW", Number: "55555"})
+		go func() {
+			for ctx.Err() == nil {
+				hub.Publish(api.KindCue, cue)
+				time.Sleep(20 * time.Millisecond)
+			}
+		}()
+		out := &lineWriter{lines: make(chan string, 10)}
+
+		// Act
+		tailCtx, stopTail := context.WithTimeout(ctx, 5*time.Second)
+		defer stopTail()
+		done := make(chan error, 1)
+		go func() { done <- runTail(tailCtx, []string{"-config", configFile, "-cues"}, out) }()
+
+		// Assert
+		select {
+		case line := <-out.lines:
+			assert.Regexp(t, `^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2} UTC \[cue\] TEXT_TO_NUMBER: text SNOW to 55555\n$`, line)
+		case err := <-done:
+			t.Fatalf("tail ended before printing a cue: %v", err)
+		}
+		stopTail()
+		assert.NoError(t, <-done)
+	})
+
+	t.Run("should refuse when the API is disabled", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte("api:\n  enabled: false\n"), 0644))
+
+		err := runTail(context.Background(), []string{"-config", configFile}, io.Discard)
+
+		assert.ErrorContains(t, err, "api.enabled is false")
+	})
+
+	t.Run("should require a tenant when the config has tenants", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte("api:\n  enabled: true\ntenants:\n  kiss: {}\n"), 0644))
+
+		err := runTail(context.Background(), []string{"-config", configFile}, io.Discard)
+
+		assert.ErrorContains(t, err, "choose one with -tenant (kiss)")
+	})
+}
+
+// lineWriter passes each write to a channel, for output written from another goroutine
+type lineWriter struct {
+	lines chan string
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.lines <- string(p)
+	return len(p), nil
+}
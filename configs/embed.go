@@ -0,0 +1,10 @@
+// Package configs bundles the example configuration into the binary so a working
+// config can be scaffolded without the source tree
+package configs
+
+import _ "embed"
+
+// Example is the contents of config.example.yaml
+//
+//go:embed config.example.yaml
+var Example []byte
@@ -0,0 +1,242 @@
+// Package adbreak detects commercial breaks in the monitored stream, so contest mentions in
+// ads (often for other stations' contests) are not reported as cues. Breaks are recognized from
+// phrases typical of commercials in the transcription, and optionally from the steady, heavily
+// compressed loudness of ad audio. Station IDs and jingles heard in the transcription end them.
+package adbreak
+
+import (
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// DefaultAdPhrases are phrases heard in commercials but rarely in programming
+var DefaultAdPhrases = []string{
+	"brought to you by",
+	"call now",
+	"terms and conditions",
+	"restrictions apply",
+	"limited time offer",
+	"see store for details",
+	"financing available",
+	"member fdic",
+	"not available in all",
+	"offer ends",
+}
+
+// retention is how long ended breaks are remembered, covering cues from audio transcribed late
+const retention = 10 * time.Minute
+
+// Config configures the detector
+type Config struct {
+	AdPhrases      []string      // Phrases marking audio as a commercial; nil uses DefaultAdPhrases
+	ProgramPhrases []string      // Station IDs, jingles, or presenter phrases marking the return to programming
+	Hold           time.Duration // How long a break lasts after the last ad phrase
+
+	LoudnessDBFS    float64       // Mean level at or above which steady audio looks like a commercial; 0 disables
+	LoudnessRangeDB float64       // Largest level spread within LoudnessWindow still counted as steady
+	LoudnessWindow  time.Duration // How long the loudness signature must hold
+}
+
+// Break is a detected commercial break
+type Break struct {
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`    // When the break ended, or is expected to end without further evidence
+	Reason string    `json:"reason"` // What started the break, such as `phrase "call now"` or "loudness"
+}
+
+// Contains reports whether at falls within the break
+func (b Break) Contains(at time.Time) bool {
+	return !at.Before(b.Start) && at.Before(b.End)
+}
+
+// Status summarizes the detector for health reporting
+type Status struct {
+	InBreak     bool   `json:"in_break"`
+	Current     *Break `json:"current,omitempty"`
+	Breaks      int64  `json:"breaks"`       // Breaks detected since startup
+	FlaggedCues int64  `json:"flagged_cues"` // Cues heard during a break
+}
+
+// levelSample is one audio level measurement
+type levelSample struct {
+	at   time.Time
+	dbfs float64
+}
+
+// Detector tracks commercial breaks from transcribed text and audio levels. It is safe for
+// concurrent use.
+type Detector struct {
+	config         Config
+	adPhrases      []string // Normalized
+	programPhrases []string // Normalized
+	now            func() time.Time
+
+	mu          sync.Mutex
+	breaks      []Break       // Oldest first; only the last may still be extended
+	closedAt    time.Time     // When programming last resumed; evidence before it is ignored
+	levels      []levelSample // Within the loudness window
+	breakCount  int64
+	flaggedCues int64
+}
+
+// NewDetector creates a detector
+func NewDetector(config Config) *Detector {
+	adPhrases := config.AdPhrases
+	if adPhrases == nil {
+		adPhrases = DefaultAdPhrases
+	}
+	return &Detector{
+		config:         config,
+		adPhrases:      normalizePhrases(adPhrases),
+		programPhrases: normalizePhrases(config.ProgramPhrases),
+		now:            time.Now,
+	}
+}
+
+// ObserveText checks text heard at the given time for ad and program phrases. Text with a
+// program phrase ends the current break; text with an ad phrase starts or extends one.
+func (d *Detector) ObserveText(at time.Time, text string) {
+	normalized := " " + normalize(text) + " "
+	if _, ok := findPhrase(normalized, d.programPhrases); ok {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.endBreak(at)
+		return
+	}
+	if phrase, ok := findPhrase(normalized, d.adPhrases); ok {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.addEvidence(at, at.Add(d.config.Hold), `phrase "`+phrase+`"`)
+	}
+}
+
+// ObserveLevel records the audio level measured at the given time. When the level has stayed
+// loud and steady for the whole loudness window, the window is marked as a break.
+func (d *Detector) ObserveLevel(at time.Time, dbfs float64) {
+	if d.config.LoudnessDBFS == 0 || d.config.LoudnessWindow <= 0 {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.levels = append(d.levels, levelSample{at: at, dbfs: dbfs})
+	start := at.Add(-d.config.LoudnessWindow)
+	for len(d.levels) > 0 && d.levels[0].at.Before(start) {
+		d.levels = d.levels[1:]
+	}
+	// Wait until samples cover most of the window
+	if at.Sub(d.levels[0].at) < d.config.LoudnessWindow*9/10 {
+		return
+	}
+
+	lowest, highest, sum := d.levels[0].dbfs, d.levels[0].dbfs, 0.0
+	for _, sample := range d.levels {
+		lowest = min(lowest, sample.dbfs)
+		highest = max(highest, sample.dbfs)
+		sum += sample.dbfs
+	}
+	if sum/float64(len(d.levels)) >= d.config.LoudnessDBFS && highest-lowest <= d.config.LoudnessRangeDB {
+		d.addEvidence(d.levels[0].at, at, "loudness")
+	}
+}
+
+// addEvidence marks start to end as part of a break, extending the current break when they
+// overlap. Callers hold d.mu.
+func (d *Detector) addEvidence(start, end time.Time, reason string) {
+	if start.Before(d.closedAt) {
+		start = d.closedAt
+	}
+	if !end.After(start) {
+		end = start.Add(time.Second)
+	}
+	// A break ended by a program phrase is not extended, even by evidence starting right as it ended
+	if n := len(d.breaks); n > 0 && !start.After(d.breaks[n-1].End) && !d.breaks[n-1].End.Equal(d.closedAt) {
+		if end.After(d.breaks[n-1].End) {
+			d.breaks[n-1].End = end
+		}
+		return
+	}
+	d.breaks = append(d.breaks, Break{Start: start, End: end, Reason: reason})
+	d.breakCount++
+	d.prune(end)
+}
+
+// endBreak ends the break in progress at the given time, when programming resumed. Callers hold d.mu.
+func (d *Detector) endBreak(at time.Time) {
+	if at.After(d.closedAt) {
+		d.closedAt = at
+	}
+	if n := len(d.breaks); n > 0 && d.breaks[n-1].Contains(at) {
+		d.breaks[n-1].End = at
+	}
+	d.levels = d.levels[:0]
+}
+
+// prune forgets breaks that ended long before now. Callers hold d.mu.
+func (d *Detector) prune(now time.Time) {
+	for len(d.breaks) > 1 && now.Sub(d.breaks[0].End) > retention {
+		d.breaks = d.breaks[1:]
+	}
+}
+
+// BreakAt returns the break audio heard at the given time belongs to
+func (d *Detector) BreakAt(at time.Time) (Break, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i := len(d.breaks) - 1; i >= 0; i-- {
+		if d.breaks[i].Contains(at) {
+			return d.breaks[i], true
+		}
+	}
+	return Break{}, false
+}
+
+// NoteFlaggedCue counts a cue heard during a break
+func (d *Detector) NoteFlaggedCue() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.flaggedCues++
+}
+
+// Status returns whether a break is in progress and how many were detected
+func (d *Detector) Status() Status {
+	current, inBreak := d.BreakAt(d.now())
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	status := Status{InBreak: inBreak, Breaks: d.breakCount, FlaggedCues: d.flaggedCues}
+	if inBreak {
+		status.Current = &current
+	}
+	return status
+}
+
+// findPhrase returns the first phrase found as whole words in padded, normalized text
+func findPhrase(text string, phrases []string) (string, bool) {
+	for _, phrase := range phrases {
+		if strings.Contains(text, " "+phrase+" ") {
+			return phrase, true
+		}
+	}
+	return "", false
+}
+
+// normalizePhrases normalizes phrases for matching, dropping empty ones
+func normalizePhrases(phrases []string) []string {
+	result := make([]string, 0, len(phrases))
+	for _, phrase := range phrases {
+		if normalized := normalize(phrase); normalized != "" {
+			result = append(result, normalized)
+		}
+	}
+	return result
+}
+
+// normalize lowercases text and replaces punctuation with spaces, collapsing runs of spaces
+func normalize(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
@@ -0,0 +1,126 @@
+package adbreak
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var base = time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+
+func TestDetector_ObserveText(t *testing.T) {
+	t.Run("should start a break on an ad phrase and hold it", func(t *testing.T) {
+		// Arrange
+		detector := NewDetector(Config{Hold: time.Minute})
+
+		// Act
+		detector.ObserveText(base, "Financing available, see store for details. Call now!")
+
+		// Assert
+		brk, ok := detector.BreakAt(base.Add(30 * time.Second))
+		require.True(t, ok)
+		assert.Equal(t, `phrase "call now"`, brk.Reason)
+		_, ok = detector.BreakAt(base.Add(61 * time.Second))
+		assert.False(t, ok)
+		_, ok = detector.BreakAt(base.Add(-time.Second))
+		assert.False(t, ok)
+	})
+
+	t.Run("should extend the break while ad phrases keep coming", func(t *testing.T) {
+		detector := NewDetector(Config{Hold: time.Minute})
+
+		detector.ObserveText(base, "brought to you by Acme Motors")
+		detector.ObserveText(base.Add(45*time.Second), "restrictions apply")
+
+		_, ok := detector.BreakAt(base.Add(100 * time.Second))
+		assert.True(t, ok)
+		assert.Equal(t, int64(1), detector.Status().Breaks)
+	})
+
+	t.Run("should end the break when a program phrase is heard", func(t *testing.T) {
+		// Arrange
+		detector := NewDetector(Config{Hold: time.Minute, ProgramPhrases: []string{"Kiss 108"}})
+		detector.ObserveText(base, "call now")
+
+		// Act
+		detector.ObserveText(base.Add(20*time.Second), "you're listening to KISS-108, more music now")
+
+		// Assert
+		_, ok := detector.BreakAt(base.Add(10 * time.Second))
+		assert.True(t, ok, "audio before the jingle is still part of the break")
+		_, ok = detector.BreakAt(base.Add(25 * time.Second))
+		assert.False(t, ok)
+	})
+
+	t.Run("should match whole words only", func(t *testing.T) {
+		detector := NewDetector(Config{Hold: time.Minute, AdPhrases: []string{"offer"}})
+
+		detector.ObserveText(base, "our offering today")
+
+		_, ok := detector.BreakAt(base)
+		assert.False(t, ok)
+	})
+}
+
+func TestDetector_ObserveLevel(t *testing.T) {
+	config := Config{LoudnessDBFS: -16, LoudnessRangeDB: 3, LoudnessWindow: 10 * time.Second}
+
+	t.Run("should mark loud, steady audio as a break once the window is covered", func(t *testing.T) {
+		// Arrange
+		detector := NewDetector(config)
+
+		// Act
+		for i := 0; i <= 10; i++ {
+			detector.ObserveLevel(base.Add(time.Duration(i)*time.Second), -14+float64(i%2))
+		}
+
+		// Assert
+		brk, ok := detector.BreakAt(base.Add(5 * time.Second))
+		require.True(t, ok)
+		assert.Equal(t, "loudness", brk.Reason)
+	})
+
+	t.Run("should ignore quiet or dynamic audio", func(t *testing.T) {
+		quiet := NewDetector(config)
+		dynamic := NewDetector(config)
+
+		for i := 0; i <= 10; i++ {
+			at := base.Add(time.Duration(i) * time.Second)
+			quiet.ObserveLevel(at, -24)
+			dynamic.ObserveLevel(at, -14-float64(i%2)*8)
+		}
+
+		_, ok := quiet.BreakAt(base.Add(5 * time.Second))
+		assert.False(t, ok)
+		_, ok = dynamic.BreakAt(base.Add(5 * time.Second))
+		assert.False(t, ok)
+	})
+
+	t.Run("should be disabled without a loudness level", func(t *testing.T) {
+		detector := NewDetector(Config{LoudnessWindow: 10 * time.Second})
+
+		for i := 0; i <= 10; i++ {
+			detector.ObserveLevel(base.Add(time.Duration(i)*time.Second), -10)
+		}
+
+		assert.Equal(t, int64(0), detector.Status().Breaks)
+	})
+}
+
+func TestDetector_Status(t *testing.T) {
+	t.Run("should report the break in progress and flagged cues", func(t *testing.T) {
+		detector := NewDetector(Config{Hold: time.Minute})
+		detector.now = func() time.Time { return base.Add(10 * time.Second) }
+		detector.ObserveText(base, "terms and conditions")
+		detector.NoteFlaggedCue()
+
+		status := detector.Status()
+
+		assert.True(t, status.InBreak)
+		require.NotNil(t, status.Current)
+		assert.Equal(t, base, status.Current.Start)
+		assert.Equal(t, int64(1), status.FlaggedCues)
+	})
+}
@@ -0,0 +1,161 @@
+package anomaly
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateState describes how the current transcription output rate compares to its baseline
+type RateState string
+
+const (
+	// RateStateWarmingUp means not enough history has been collected to build a baseline
+	RateStateWarmingUp RateState = "warming_up"
+	// RateStateNormal means the current rate is within the configured bounds of the baseline
+	RateStateNormal RateState = "normal"
+	// RateStateCollapsed means the current rate dropped far below the baseline
+	RateStateCollapsed RateState = "collapsed"
+	// RateStateExploded means the current rate rose far above the baseline
+	RateStateExploded RateState = "exploded"
+)
+
+// RateDetectorConfig holds the thresholds used by RateDetector
+type RateDetectorConfig struct {
+	Window         time.Duration // Window used to compute the current rate
+	BaselineWindow time.Duration // Window used to compute the rolling baseline
+	LowRatio       float64       // Current/baseline ratio at or below which output is considered collapsed
+	HighRatio      float64       // Current/baseline ratio at or above which output is considered exploded
+	MinBaselineWPM float64       // Baselines below this rate are too quiet to judge against
+}
+
+// RateStatus is a snapshot of the detector's latest evaluation
+type RateStatus struct {
+	State       RateState `json:"state"`
+	CurrentWPM  float64   `json:"current_wpm"`
+	BaselineWPM float64   `json:"baseline_wpm"`
+	Ratio       float64   `json:"ratio"`
+	Since       time.Time `json:"since"`
+}
+
+// Degraded reports whether the status represents an anomalous output rate
+func (rs RateStatus) Degraded() bool {
+	return rs.State == RateStateCollapsed || rs.State == RateStateExploded
+}
+
+type wordSample struct {
+	at    time.Time
+	words int
+}
+
+// RateDetector flags transcription output rates (words per minute) that collapse or
+// explode relative to a rolling baseline, which usually indicates audio corruption
+// or a misbehaving model
+type RateDetector struct {
+	mu        sync.Mutex
+	config    RateDetectorConfig
+	samples   []wordSample
+	startedAt time.Time
+	status    RateStatus
+}
+
+// NewRateDetector creates a new RateDetector with the given configuration
+func NewRateDetector(config RateDetectorConfig) *RateDetector {
+	return &RateDetector{
+		config: config,
+		status: RateStatus{State: RateStateWarmingUp},
+	}
+}
+
+// Record adds the words of a transcribed text observed at the given time
+func (rd *RateDetector) Record(text string, at time.Time) {
+	rd.mu.Lock()
+	defer rd.mu.Unlock()
+
+	if rd.startedAt.IsZero() {
+		rd.startedAt = at
+	}
+	rd.samples = append(rd.samples, wordSample{at: at, words: len(strings.Fields(text))})
+}
+
+// Evaluate recomputes the current and baseline rates at the given time and returns
+// the new status along with whether the state changed since the previous evaluation
+func (rd *RateDetector) Evaluate(now time.Time) (RateStatus, bool) {
+	rd.mu.Lock()
+	defer rd.mu.Unlock()
+
+	rd.prune(now)
+
+	currentStart := now.Add(-rd.config.Window)
+	baselineStart := currentStart.Add(-rd.config.BaselineWindow)
+
+	var currentWords, baselineWords int
+	for _, sample := range rd.samples {
+		if sample.at.After(currentStart) {
+			currentWords += sample.words
+		} else if sample.at.After(baselineStart) {
+			baselineWords += sample.words
+		}
+	}
+
+	current := float64(currentWords) / rd.config.Window.Minutes()
+	baseline := float64(baselineWords) / rd.config.BaselineWindow.Minutes()
+
+	state := RateStateNormal
+	ratio := 0.0
+	switch {
+	case rd.startedAt.IsZero() || now.Sub(rd.startedAt) < rd.config.Window+rd.config.BaselineWindow:
+		state = RateStateWarmingUp
+	case baseline < rd.config.MinBaselineWPM:
+		// Baseline too quiet to judge (e.g. music programming) - treat as normal
+	default:
+		ratio = current / baseline
+		if ratio <= rd.config.LowRatio {
+			state = RateStateCollapsed
+		} else if ratio >= rd.config.HighRatio {
+			state = RateStateExploded
+		}
+	}
+
+	changed := state != rd.status.State
+	since := rd.status.Since
+	if changed || since.IsZero() {
+		since = now
+	}
+
+	rd.status = RateStatus{
+		State:       state,
+		CurrentWPM:  current,
+		BaselineWPM: baseline,
+		Ratio:       ratio,
+		Since:       since,
+	}
+
+	return rd.status, changed
+}
+
+// Reset discards all samples and starts warming up again, e.g. after transcription was paused
+func (rd *RateDetector) Reset() {
+	rd.mu.Lock()
+	defer rd.mu.Unlock()
+	rd.samples = nil
+	rd.startedAt = time.Time{}
+	rd.status = RateStatus{State: RateStateWarmingUp}
+}
+
+// Status returns the result of the most recent evaluation
+func (rd *RateDetector) Status() RateStatus {
+	rd.mu.Lock()
+	defer rd.mu.Unlock()
+	return rd.status
+}
+
+// prune drops samples that have fallen out of both windows
+func (rd *RateDetector) prune(now time.Time) {
+	cutoff := now.Add(-(rd.config.Window + rd.config.BaselineWindow))
+	keep := 0
+	for keep < len(rd.samples) && !rd.samples[keep].at.After(cutoff) {
+		keep++
+	}
+	rd.samples = rd.samples[keep:]
+}
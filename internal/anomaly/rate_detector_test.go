@@ -0,0 +1,137 @@
+package anomaly
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testDetectorConfig() RateDetectorConfig {
+	return RateDetectorConfig{
+		Window:         time.Minute,
+		BaselineWindow: 5 * time.Minute,
+		LowRatio:       0.25,
+		HighRatio:      4.0,
+		MinBaselineWPM: 10,
+	}
+}
+
+// feed records wordsPerMinute words every 10 seconds from start through end
+func feed(rd *RateDetector, start, end time.Time, wordsPerMinute int) {
+	words := strings.Repeat("word ", wordsPerMinute/6)
+	for at := start; !at.After(end); at = at.Add(10 * time.Second) {
+		rd.Record(words, at)
+	}
+}
+
+func TestRateDetector_WarmUp(t *testing.T) {
+	t.Run("should report warming up until both windows are filled", func(t *testing.T) {
+		// Arrange
+		rd := NewRateDetector(testDetectorConfig())
+		start := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+		feed(rd, start, start.Add(3*time.Minute), 120)
+
+		// Act
+		status, _ := rd.Evaluate(start.Add(3 * time.Minute))
+
+		// Assert
+		assert.Equal(t, RateStateWarmingUp, status.State)
+		assert.False(t, status.Degraded())
+	})
+}
+
+func TestRateDetector_Reset(t *testing.T) {
+	t.Run("should warm up again after a reset", func(t *testing.T) {
+		// Arrange
+		rd := NewRateDetector(testDetectorConfig())
+		start := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+		feed(rd, start, start.Add(7*time.Minute), 120)
+		rd.Evaluate(start.Add(7 * time.Minute))
+
+		// Act
+		rd.Reset()
+		status, _ := rd.Evaluate(start.Add(8 * time.Minute))
+
+		// Assert
+		assert.Equal(t, RateStateWarmingUp, status.State)
+	})
+}
+
+func TestRateDetector_Evaluate(t *testing.T) {
+	start := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	t.Run("should report normal for a steady rate", func(t *testing.T) {
+		// Arrange
+		rd := NewRateDetector(testDetectorConfig())
+		feed(rd, start, start.Add(7*time.Minute), 120)
+
+		// Act
+		status, changed := rd.Evaluate(start.Add(7 * time.Minute))
+
+		// Assert
+		assert.Equal(t, RateStateNormal, status.State)
+		assert.True(t, changed)
+		assert.InDelta(t, 120, status.CurrentWPM, 1)
+		assert.InDelta(t, 120, status.BaselineWPM, 1)
+	})
+
+	t.Run("should detect collapsed output", func(t *testing.T) {
+		// Arrange
+		rd := NewRateDetector(testDetectorConfig())
+		feed(rd, start, start.Add(6*time.Minute), 120)
+		rd.Evaluate(start.Add(6 * time.Minute))
+		feed(rd, start.Add(6*time.Minute), start.Add(7*time.Minute), 12)
+
+		// Act
+		status, changed := rd.Evaluate(start.Add(7 * time.Minute))
+
+		// Assert
+		assert.Equal(t, RateStateCollapsed, status.State)
+		assert.True(t, changed)
+		assert.True(t, status.Degraded())
+		assert.Equal(t, start.Add(7*time.Minute), status.Since)
+	})
+
+	t.Run("should detect exploded output", func(t *testing.T) {
+		// Arrange
+		rd := NewRateDetector(testDetectorConfig())
+		feed(rd, start, start.Add(6*time.Minute), 60)
+		feed(rd, start.Add(6*time.Minute), start.Add(7*time.Minute), 600)
+
+		// Act
+		status, _ := rd.Evaluate(start.Add(7 * time.Minute))
+
+		// Assert
+		assert.Equal(t, RateStateExploded, status.State)
+		assert.True(t, status.Degraded())
+	})
+
+	t.Run("should not judge against a baseline below the minimum rate", func(t *testing.T) {
+		// Arrange
+		rd := NewRateDetector(testDetectorConfig())
+		feed(rd, start, start.Add(6*time.Minute), 6)
+
+		// Act
+		status, _ := rd.Evaluate(start.Add(7 * time.Minute))
+
+		// Assert
+		assert.Equal(t, RateStateNormal, status.State)
+	})
+
+	t.Run("should not report a change when the state is stable", func(t *testing.T) {
+		// Arrange
+		rd := NewRateDetector(testDetectorConfig())
+		feed(rd, start, start.Add(8*time.Minute), 120)
+		first, _ := rd.Evaluate(start.Add(7 * time.Minute))
+
+		// Act
+		second, changed := rd.Evaluate(start.Add(8 * time.Minute))
+
+		// Assert
+		assert.False(t, changed)
+		assert.Equal(t, first.Since, second.Since)
+		assert.Equal(t, second, rd.Status())
+	})
+}
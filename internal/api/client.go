@@ -0,0 +1,74 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// NewClient returns an HTTP client reaching the API at address, either host:port or
+// unix:/path/to/socket, and the base URL to request paths on
+func NewClient(address string) (*http.Client, string) {
+	if path, ok := strings.CutPrefix(address, unixPrefix); ok {
+		transport := &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", path)
+			},
+		}
+		return &http.Client{Transport: transport}, "http://unix"
+	}
+	return &http.Client{}, "http://" + address
+}
+
+// Tail streams the live events of kinds from the instance at address, passing each to fn, until
+// ctx is cancelled, fn fails, or the instance goes away. A non-empty token is sent as the
+// bearer token the instance requires.
+func Tail(ctx context.Context, address, token string, kinds []string, fn func(Event) error) error {
+	client, base := NewClient(address)
+	query := url.Values{"kinds": {strings.Join(kinds, ",")}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"/live?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("no running instance reachable at %s: %w", address, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("live stream failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return fmt.Errorf("invalid live event: %w", err)
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("live stream interrupted: %w", err)
+	}
+	return fmt.Errorf("the instance closed the live stream")
+}
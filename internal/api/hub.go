@@ -0,0 +1,139 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Kinds of live events
+const (
+	KindCue        = "cue"
+	KindTranscript = "transcript"
+)
+
+// Kinds returns every kind of live event
+func Kinds() []string {
+	return []string{KindCue, KindTranscript}
+}
+
+// ParseKinds parses a comma-separated list of event kinds; an empty list is every kind
+func ParseKinds(list string) ([]string, error) {
+	var kinds []string
+	for _, kind := range strings.Split(list, ",") {
+		kind = strings.TrimSpace(kind)
+		if kind == "" {
+			continue
+		}
+		if kind != KindCue && kind != KindTranscript {
+			return nil, fmt.Errorf("unknown event kind %q (expected %s)", kind, strings.Join(Kinds(), " or "))
+		}
+		kinds = append(kinds, kind)
+	}
+	if len(kinds) == 0 {
+		return Kinds(), nil
+	}
+	return kinds, nil
+}
+
+// Event is one live pipeline event, streamed to tail clients as one JSON object per line.
+// Data is the cue or transcription segment as JSON.
+type Event struct {
+	Kind string          `json:"kind"`
+	Time time.Time       `json:"time"`
+	Data json.RawMessage `json:"data"`
+}
+
+// subscriberBuffer is how far a subscriber may fall behind before its events are dropped
+const subscriberBuffer = 256
+
+// Hub fans the pipeline's live events out to the connected tail clients. Publishing never
+// blocks the pipeline: a subscriber that falls behind misses events instead.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[*Subscription]struct{}
+	now         func() time.Time
+}
+
+// Subscription receives the live events of the kinds it subscribed to
+type Subscription struct {
+	kinds   map[string]bool
+	events  chan Event
+	dropped int
+	hub     *Hub
+}
+
+// NewHub creates a hub without subscribers
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[*Subscription]struct{}), now: time.Now}
+}
+
+// Subscribe returns a subscription to the events of kinds; Close it when done
+func (h *Hub) Subscribe(kinds []string) *Subscription {
+	sub := &Subscription{kinds: make(map[string]bool, len(kinds)), events: make(chan Event, subscriberBuffer), hub: h}
+	for _, kind := range kinds {
+		sub.kinds[kind] = true
+	}
+	h.mu.Lock()
+	h.subscribers[sub] = struct{}{}
+	h.mu.Unlock()
+	return sub
+}
+
+// Subscribers returns the number of open subscriptions
+func (h *Hub) Subscribers() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subscribers)
+}
+
+// Publish sends payload as an event of kind to the subscribers of kind. It is only marshalled
+// when someone is listening.
+func (h *Hub) Publish(kind string, payload interface{}) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var event *Event
+	for sub := range h.subscribers {
+		if !sub.kinds[kind] {
+			continue
+		}
+		if event == nil {
+			data, err := json.Marshal(payload)
+			if err != nil {
+				return fmt.Errorf("failed to marshal %s event: %w", kind, err)
+			}
+			event = &Event{Kind: kind, Time: h.now().UTC(), Data: data}
+		}
+		select {
+		case sub.events <- *event:
+		default:
+			sub.dropped++
+		}
+	}
+	return nil
+}
+
+// Events returns the channel the subscription's events arrive on; it is closed by Close
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Dropped returns how many events were dropped because the subscriber fell behind
+func (s *Subscription) Dropped() int {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	return s.dropped
+}
+
+// Close ends the subscription
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	if _, ok := s.hub.subscribers[s]; ok {
+		delete(s.hub.subscribers, s)
+		close(s.events)
+	}
+}
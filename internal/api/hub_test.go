@@ -0,0 +1,98 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKinds(t *testing.T) {
+	t.Run("should default to every kind", func(t *testing.T) {
+		kinds, err := ParseKinds("")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{KindCue, KindTranscript}, kinds)
+	})
+
+	t.Run("should parse a comma-separated list", func(t *testing.T) {
+		kinds, err := ParseKinds(" transcript ,")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{KindTranscript}, kinds)
+	})
+
+	t.Run("should reject unknown kinds", func(t *testing.T) {
+		_, err := ParseKinds("cue,audio")
+
+		assert.ErrorContains(t, err, `unknown event kind "audio"`)
+	})
+}
+
+func TestHub(t *testing.T) {
+	t.Run("should deliver events only to subscribers of their kind", func(t *testing.T) {
+		// Arrange
+		hub := NewHub()
+		hub.now = func() time.Time { return time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC) }
+		cues := hub.Subscribe([]string{KindCue})
+		defer cues.Close()
+		all := hub.Subscribe(Kinds())
+		defer all.Close()
+
+		// Act
+		require.NoError(t, hub.Publish(KindTranscript, map[string]string{"text": "hello"}))
+		require.NoError(t, hub.Publish(KindCue, map[string]string{"keyword": "SNOW"}))
+
+		// Assert
+		event := <-cues.Events()
+		assert.Equal(t, KindCue, event.Kind)
+		assert.JSONEq(t, `{"keyword":"SNOW"}`, string(event.Data))
+		assert.Equal(t, KindTranscript, (<-all.Events()).Kind)
+		assert.Equal(t, KindCue, (<-all.Events()).Kind)
+		assert.Empty(t, cues.Events())
+	})
+
+	t.Run("should drop events for a subscriber that falls behind", func(t *testing.T) {
+		// Arrange
+		hub := NewHub()
+		sub := hub.Subscribe(Kinds())
+		defer sub.Close()
+
+		// Act
+		for i := 0; i < subscriberBuffer+3; i++ {
+			require.NoError(t, hub.Publish(KindTranscript, i))
+		}
+
+		// Assert
+		assert.Len(t, sub.Events(), subscriberBuffer)
+		assert.Equal(t, 3, sub.Dropped())
+	})
+
+	t.Run("should close the events channel and forget the subscriber on Close", func(t *testing.T) {
+		// Arrange
+		hub := NewHub()
+		sub := hub.Subscribe(Kinds())
+
+		// Act
+		sub.Close()
+		sub.Close()
+
+		// Assert
+		_, open := <-sub.Events()
+		assert.False(t, open)
+		assert.Equal(t, 0, hub.Subscribers())
+		assert.NoError(t, hub.Publish(KindCue, "ignored"))
+	})
+
+	t.Run("should report payloads that cannot be marshalled", func(t *testing.T) {
+		hub := NewHub()
+		sub := hub.Subscribe(Kinds())
+		defer sub.Close()
+
+		err := hub.Publish(KindCue, json.RawMessage("{"))
+
+		assert.Error(t, err)
+	})
+}
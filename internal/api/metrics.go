@@ -0,0 +1,73 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Metric types in the Prometheus text format
+const (
+	MetricGauge   = "gauge"
+	MetricCounter = "counter"
+	MetricSummary = "summary"
+)
+
+// Metric is one sample served at GET /metrics. Samples sharing a Name form one metric family;
+// a summary's quantiles carry a "quantile" label, and its _sum and _count samples set Suffix.
+type Metric struct {
+	Name   string
+	Help   string
+	Type   string // MetricGauge, MetricCounter, or MetricSummary
+	Suffix string // Appended to Name for this sample, e.g. "_count"
+	Labels map[string]string
+	Value  float64
+}
+
+// ServeMetrics serves the samples returned by collect at GET /metrics in the Prometheus text
+// exposition format
+func (s *Server) ServeMetrics(collect func() []Metric) {
+	s.mux.HandleFunc("GET /metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		writeMetrics(w, collect())
+	})
+}
+
+// writeMetrics writes metrics in the Prometheus text format, introducing each family once
+func writeMetrics(w io.Writer, metrics []Metric) {
+	described := make(map[string]bool)
+	for _, m := range metrics {
+		if !described[m.Name] {
+			described[m.Name] = true
+			if m.Help != "" {
+				fmt.Fprintf(w, "# HELP %s %s\n", m.Name, m.Help)
+			}
+			if m.Type != "" {
+				fmt.Fprintf(w, "# TYPE %s %s\n", m.Name, m.Type)
+			}
+		}
+		fmt.Fprintf(w, "%s%s%s %s\n", m.Name, m.Suffix, formatLabels(m.Labels),
+			strconv.FormatFloat(m.Value, 'g', -1, 64))
+	}
+}
+
+// formatLabels formats labels as {name="value",...} sorted by name; empty without labels
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + "=" + strconv.Quote(labels[name])
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestServer_Metrics(t *testing.T) {
+	t.Run("should serve metrics in the Prometheus text format", func(t *testing.T) {
+		// Arrange
+		server := NewServer("127.0.0.1:0", NewHub(), zap.NewNop())
+		server.ServeMetrics(func() []Metric {
+			return []Metric{
+				{Name: "cue_latency_seconds", Help: "Cue latency.", Type: MetricSummary, Labels: map[string]string{"quantile": "0.5"}, Value: 1.5},
+				{Name: "cue_latency_seconds", Labels: map[string]string{"quantile": "0.99"}, Value: 12},
+				{Name: "cue_latency_seconds", Suffix: "_count", Value: 42},
+				{Name: "cues_total", Type: MetricCounter, Labels: map[string]string{"stream": `a "b"`, "backend": "binary"}, Value: 3},
+			}
+		})
+		rec := httptest.NewRecorder()
+
+		// Act
+		server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain; version=0.0.4")
+		assert.Equal(t, `# HELP cue_latency_seconds Cue latency.
+# TYPE cue_latency_seconds summary
+cue_latency_seconds{quantile="0.5"} 1.5
+cue_latency_seconds{quantile="0.99"} 12
+cue_latency_seconds_count 42
+# TYPE cues_total counter
+cues_total{backend="binary",stream="a \"b\""} 3
+`, rec.Body.String())
+	})
+}
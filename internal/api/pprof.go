@@ -0,0 +1,14 @@
+package api
+
+import "net/http/pprof"
+
+// ServePprof serves the Go runtime profiles under /debug/pprof/, for finding CPU and allocation
+// hotspots in a running instance with go tool pprof. They sit behind the token set by
+// RequireToken like every other route.
+func (s *Server) ServePprof() {
+	s.mux.HandleFunc("/debug/pprof/", pprof.Index)
+	s.mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	s.mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	s.mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	s.mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+}
@@ -0,0 +1,164 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// unixPrefix marks an address as a unix socket path rather than a TCP host:port
+const unixPrefix = "unix:"
+
+// shutdownTimeout bounds how long Run waits for open requests once its context is cancelled
+const shutdownTimeout = 5 * time.Second
+
+// Server serves the API of a running instance on a TCP address or a unix socket. It serves the
+// live event stream at GET /live?kinds=cue,transcript as newline-delimited JSON, and the stored
+// transcriptions at GET /transcripts once ServeTranscripts is called. Once RequireToken is
+// called, every request must carry the token as a bearer token.
+type Server struct {
+	address string
+	hub     *Hub
+	logger  *zap.Logger
+	mux     *http.ServeMux
+	token   string // Bearer token every request must carry; empty leaves the API open
+}
+
+// NewServer creates a server for address, either host:port or unix:/path/to/socket
+func NewServer(address string, hub *Hub, logger *zap.Logger) *Server {
+	s := &Server{address: address, hub: hub, logger: logger, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /live", s.handleLive)
+	return s
+}
+
+// RequireToken makes every request carry token in an Authorization: Bearer header. An empty
+// token leaves the API open to anyone who can reach its address.
+func (s *Server) RequireToken(token string) {
+	s.token = token
+}
+
+// Handler returns the server's request handler
+func (s *Server) Handler() http.Handler {
+	if s.token == "" {
+		return s.mux
+	}
+	return http.HandlerFunc(s.authorize)
+}
+
+// authorize serves the request if it carries the server's bearer token
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+		s.logger.Debug("rejected API request without a valid token", zap.String("path", r.URL.Path), zap.String("remote", r.RemoteAddr))
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "missing or invalid API token", http.StatusUnauthorized)
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// Run serves requests until ctx is cancelled
+func (s *Server) Run(ctx context.Context) error {
+	listener, err := Listen(s.address)
+	if err != nil {
+		return err
+	}
+	// Requests share ctx, so live streams end when the server shuts down
+	server := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			server.Close()
+		}
+	}()
+
+	s.logger.Info("API listening", zap.String("address", s.address))
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	<-done
+	return nil
+}
+
+// Listen listens on address, either host:port or unix:/path/to/socket. A socket file left
+// behind by an instance that did not shut down cleanly is replaced.
+func Listen(address string) (net.Listener, error) {
+	if path, ok := strings.CutPrefix(address, unixPrefix); ok {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create socket directory: %w", err)
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+		listener, err := net.Listen("unix", path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+		}
+		return listener, nil
+	}
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", address, err)
+	}
+	return listener, nil
+}
+
+// handleLive streams live events to the client until it disconnects
+func (s *Server) handleLive(w http.ResponseWriter, r *http.Request) {
+	kinds, err := ParseKinds(r.URL.Query().Get("kinds"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	sub := s.hub.Subscribe(kinds)
+	defer sub.Close()
+	s.logger.Debug("live client connected", zap.Strings("kinds", kinds), zap.String("remote", r.RemoteAddr))
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	encoder := json.NewEncoder(w)
+	for {
+		select {
+		case <-r.Context().Done():
+			if dropped := sub.Dropped(); dropped > 0 {
+				s.logger.Debug("live client fell behind", zap.Int("dropped_events", dropped))
+			}
+			return
+		case event, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			if err := encoder.Encode(event); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// waitForSubscribers waits until n tail clients are subscribed to hub
+func waitForSubscribers(t *testing.T, hub *Hub, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool { return hub.Subscribers() == n }, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestServer_Live(t *testing.T) {
+	t.Run("should stream the requested events to a tail client over a unix socket", func(t *testing.T) {
+		// Arrange
+		hub := NewHub()
+		// Socket paths are limited to about 100 bytes, which t.TempDir() can exceed
+		dir, err := os.MkdirTemp("", "api")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+		address := unixPrefix + filepath.Join(dir, "api", "rcw.sock")
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		serverDone := make(chan error, 1)
+		go func() { serverDone <- NewServer(address, hub, zap.NewNop()).Run(ctx) }()
+
+		var got []Event
+		tailCtx, stopTail := context.WithCancel(ctx)
+		tailDone := make(chan error, 1)
+		require.Eventually(t, func() bool {
+			conn, err := net.Dial("unix", strings.TrimPrefix(address, unixPrefix))
+			if err != nil {
+				return false
+			}
+			conn.Close()
+			return true
+		}, 2*time.Second, 10*time.Millisecond)
+		go func() {
+			tailDone <- Tail(tailCtx, address, "", []string{KindCue}, func(event Event) error {
+				got = append(got, event)
+				stopTail()
+				return nil
+			})
+		}()
+		waitForSubscribers(t, hub, 1)
+
+		// Act
+		require.NoError(t, hub.Publish(KindTranscript, map[string]string{"text": "hello"}))
+		require.NoError(t, hub.Publish(KindCue, map[string]string{"keyword": "SNOW"}))
+
+		// Assert
+		require.NoError(t, <-tailDone)
+		require.Len(t, got, 1)
+		assert.Equal(t, KindCue, got[0].Kind)
+		assert.JSONEq(t, `{"keyword":"SNOW"}`, string(got[0].Data))
+
+		cancel()
+		assert.NoError(t, <-serverDone)
+	})
+
+	t.Run("should reject unknown event kinds", func(t *testing.T) {
+		// Arrange
+		server := NewServer("127.0.0.1:0", NewHub(), zap.NewNop())
+		rec := httptest.NewRecorder()
+
+		// Act
+		server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/live?kinds=audio", nil))
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestServer_RequireToken(t *testing.T) {
+	t.Run("should reject requests without the bearer token", func(t *testing.T) {
+		// Arrange
+		server := NewServer("127.0.0.1:0", NewHub(), zap.NewNop())
+		server.ServeTranscripts(nil, time.UTC)
+		server.RequireToken("s3cret")
+
+		for _, header := range []string{"", "Bearer wrong", "s3cret"} {
+			req := httptest.NewRequest(http.MethodGet, "/transcripts", nil)
+			if header != "" {
+				req.Header.Set("Authorization", header)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			server.Handler().ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, http.StatusUnauthorized, rec.Code, header)
+			assert.Equal(t, "Bearer", rec.Header().Get("WWW-Authenticate"))
+		}
+	})
+
+	t.Run("should serve requests carrying the bearer token", func(t *testing.T) {
+		// Arrange
+		server := NewServer("127.0.0.1:0", NewHub(), zap.NewNop())
+		server.RequireToken("s3cret")
+		req := httptest.NewRequest(http.MethodGet, "/live?kinds=audio", nil)
+		req.Header.Set("Authorization", "Bearer s3cret")
+		rec := httptest.NewRecorder()
+
+		// Act
+		server.Handler().ServeHTTP(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "the request should reach the live handler")
+	})
+
+	t.Run("should let a tail client with the token stream events", func(t *testing.T) {
+		// Arrange
+		hub := NewHub()
+		server := NewServer("127.0.0.1:0", hub, zap.NewNop())
+		server.RequireToken("s3cret")
+		httpServer := httptest.NewServer(server.Handler())
+		defer httpServer.Close()
+		address := strings.TrimPrefix(httpServer.URL, "http://")
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Act
+		unauthorized := Tail(ctx, address, "", Kinds(), func(Event) error { return nil })
+		var got []Event
+		tailDone := make(chan error, 1)
+		go func() {
+			tailDone <- Tail(ctx, address, "s3cret", []string{KindCue}, func(event Event) error {
+				got = append(got, event)
+				cancel()
+				return nil
+			})
+		}()
+		waitForSubscribers(t, hub, 1)
+		require.NoError(t, hub.Publish(KindCue, map[string]string{"keyword": "SNOW"}))
+
+		// Assert
+		assert.ErrorContains(t, unauthorized, "401")
+		require.NoError(t, <-tailDone)
+		require.Len(t, got, 1)
+		assert.JSONEq(t, `{"keyword":"SNOW"}`, string(got[0].Data))
+	})
+}
+
+func TestServer_Pprof(t *testing.T) {
+	t.Run("should serve runtime profiles only once enabled", func(t *testing.T) {
+		// Arrange
+		server := NewServer("127.0.0.1:0", NewHub(), zap.NewNop())
+		before := httptest.NewRecorder()
+		server.Handler().ServeHTTP(before, httptest.NewRequest(http.MethodGet, "/debug/pprof/heap", nil))
+
+		// Act
+		server.ServePprof()
+		after := httptest.NewRecorder()
+		server.Handler().ServeHTTP(after, httptest.NewRequest(http.MethodGet, "/debug/pprof/heap?debug=1", nil))
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, before.Code)
+		assert.Equal(t, http.StatusOK, after.Code)
+		assert.Contains(t, after.Body.String(), "heap profile")
+	})
+
+	t.Run("should require the bearer token for runtime profiles", func(t *testing.T) {
+		// Arrange
+		server := NewServer("127.0.0.1:0", NewHub(), zap.NewNop())
+		server.ServePprof()
+		server.RequireToken("s3cret")
+		authorized := httptest.NewRequest(http.MethodGet, "/debug/pprof/cmdline", nil)
+		authorized.Header.Set("Authorization", "Bearer s3cret")
+
+		// Act
+		without := httptest.NewRecorder()
+		server.Handler().ServeHTTP(without, httptest.NewRequest(http.MethodGet, "/debug/pprof/cmdline", nil))
+		with := httptest.NewRecorder()
+		server.Handler().ServeHTTP(with, authorized)
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, without.Code)
+		assert.Equal(t, "missing or invalid API token\n", without.Body.String())
+		assert.Equal(t, http.StatusOK, with.Code)
+	})
+}
+
+func TestTail(t *testing.T) {
+	t.Run("should report when no instance is listening", func(t *testing.T) {
+		address := "unix:" + filepath.Join(t.TempDir(), "missing.sock")
+
+		err := Tail(context.Background(), address, "", Kinds(), func(Event) error { return nil })
+
+		assert.ErrorContains(t, err, "no running instance reachable at "+address)
+	})
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/search"
+)
+
+// Page sizes of GET /transcripts
+const (
+	defaultTranscriptLimit = 100
+	maxTranscriptLimit     = 1000
+)
+
+// Transcript is one stored transcription returned by GET /transcripts
+type Transcript struct {
+	Time time.Time `json:"time"`
+	Text string    `json:"text"`
+}
+
+// TranscriptPage is the response of GET /transcripts. NextOffset requests the following page
+// and is omitted on the last one.
+type TranscriptPage struct {
+	Since       *time.Time   `json:"since,omitempty"`
+	Until       *time.Time   `json:"until,omitempty"`
+	Query       string       `json:"query,omitempty"`
+	Total       int          `json:"total"`
+	Offset      int          `json:"offset"`
+	Limit       int          `json:"limit"`
+	NextOffset  *int         `json:"next_offset,omitempty"`
+	Transcripts []Transcript `json:"transcripts"`
+}
+
+// ServeTranscripts serves the stored transcriptions in files at GET /transcripts, oldest first.
+// Query parameters:
+//
+//	since, until  time range as in the search command: RFC3339, YYYY-MM-DD [HH:MM[:SS]] in
+//	              location, or a duration ago such as 24h; until is exclusive
+//	q             only transcriptions containing this text, case-insensitively
+//	limit, offset page size (default 100, at most 1000) and the number of matches to skip
+func (s *Server) ServeTranscripts(files []string, location *time.Location) {
+	if location == nil {
+		location = time.Local
+	}
+	s.mux.HandleFunc("GET /transcripts", func(w http.ResponseWriter, r *http.Request) {
+		s.handleTranscripts(w, r, files, location, time.Now())
+	})
+}
+
+// handleTranscripts answers one GET /transcripts request
+func (s *Server) handleTranscripts(w http.ResponseWriter, r *http.Request, files []string, location *time.Location, now time.Time) {
+	query := r.URL.Query()
+	var opts search.Options
+	var page TranscriptPage
+	var err error
+	if since := query.Get("since"); since != "" {
+		if opts.Since, err = search.ParseTime(since, now, location); err != nil {
+			http.Error(w, "since: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		page.Since = &opts.Since
+	}
+	if until := query.Get("until"); until != "" {
+		if opts.Until, err = search.ParseTime(until, now, location); err != nil {
+			http.Error(w, "until: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		page.Until = &opts.Until
+	}
+	if page.Query = query.Get("q"); page.Query != "" {
+		opts.Pattern = regexp.MustCompile("(?i)" + regexp.QuoteMeta(page.Query))
+	}
+	if page.Limit, err = intParam(query.Get("limit"), defaultTranscriptLimit, 1, maxTranscriptLimit); err != nil {
+		http.Error(w, "limit: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if page.Offset, err = intParam(query.Get("offset"), 0, 0, -1); err != nil {
+		http.Error(w, "offset: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	matches, err := search.Search(files, opts)
+	if err != nil {
+		s.logger.Warn("failed to read stored transcriptions", zap.Error(err))
+		http.Error(w, "failed to read stored transcriptions", http.StatusInternalServerError)
+		return
+	}
+	page.Transcripts = []Transcript{}
+	for _, match := range matches {
+		if match.Kind != search.KindTranscript {
+			continue
+		}
+		if page.Total >= page.Offset && len(page.Transcripts) < page.Limit {
+			page.Transcripts = append(page.Transcripts, Transcript{Time: match.Time.UTC(), Text: match.Text})
+		}
+		page.Total++
+	}
+	if next := page.Offset + page.Limit; next < page.Total {
+		page.NextOffset = &next
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+// intParam parses an integer query parameter between min and max (no upper bound when max is
+// negative), using def when it is empty
+func intParam(value string, def, min, max int) (int, error) {
+	if value == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", value)
+	}
+	if n < min || (max >= 0 && n > max) {
+		if max < 0 {
+			return 0, fmt.Errorf("must be at least %d", min)
+		}
+		return 0, fmt.Errorf("must be between %d and %d", min, max)
+	}
+	return n, nil
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// writeTranscripts writes stored transcription lines to a file in a temporary directory
+func writeTranscripts(t *testing.T, name string, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644))
+	return path
+}
+
+// getTranscripts requests target from a server serving files and decodes the page
+func getTranscripts(t *testing.T, files []string, target string) (*httptest.ResponseRecorder, TranscriptPage) {
+	t.Helper()
+	server := NewServer("127.0.0.1:0", NewHub(), zap.NewNop())
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	rec := httptest.NewRecorder()
+	server.handleTranscripts(rec, httptest.NewRequest(http.MethodGet, target, nil), files, time.UTC, now)
+	var page TranscriptPage
+	if rec.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	}
+	return rec, page
+}
+
+func TestServer_Transcripts(t *testing.T) {
+	older := writeTranscripts(t, "transcripts.jsonl.1",
+		`{"timestamp":"2026-10-16T06:59:59Z","text":"before the show"}`,
+		`{"timestamp":"2026-10-16T07:00:00Z","text":"good morning"}`)
+	current := writeTranscripts(t, "transcripts.jsonl",
+		`{"timestamp":"2026-10-16T07:30:00Z","text":"text SNOW to 55555"}`,
+		`{"timestamp":"2026-10-16T08:15:00Z","text":"more snow this afternoon"}`,
+		`{"timestamp":"2026-10-16T09:00:00Z","text":"after the show"}`)
+	files := []string{older, current, filepath.Join(t.TempDir(), "missing.jsonl")}
+
+	t.Run("should return the transcriptions in the time range in order", func(t *testing.T) {
+		// Act
+		rec, page := getTranscripts(t, files, "/transcripts?since=2026-10-16+07:00&until=2026-10-16+09:00")
+
+		// Assert
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.Equal(t, 3, page.Total)
+		require.Len(t, page.Transcripts, 3)
+		assert.Equal(t, "good morning", page.Transcripts[0].Text)
+		assert.Equal(t, time.Date(2026, 10, 16, 7, 0, 0, 0, time.UTC), page.Transcripts[0].Time)
+		assert.Equal(t, "more snow this afternoon", page.Transcripts[2].Text)
+		assert.Nil(t, page.NextOffset)
+	})
+
+	t.Run("should page through the matches", func(t *testing.T) {
+		// Act
+		_, first := getTranscripts(t, files, "/transcripts?limit=2")
+		_, second := getTranscripts(t, files, "/transcripts?limit=2&offset=4")
+
+		// Assert
+		assert.Equal(t, 5, first.Total)
+		require.Len(t, first.Transcripts, 2)
+		assert.Equal(t, "before the show", first.Transcripts[0].Text)
+		require.NotNil(t, first.NextOffset)
+		assert.Equal(t, 2, *first.NextOffset)
+		require.Len(t, second.Transcripts, 1)
+		assert.Equal(t, "after the show", second.Transcripts[0].Text)
+		assert.Nil(t, second.NextOffset)
+	})
+
+	t.Run("should search the text case-insensitively and literally", func(t *testing.T) {
+		_, page := getTranscripts(t, files, "/transcripts?q=SNOW&since=36h")
+
+		assert.Equal(t, 2, page.Total)
+		assert.Equal(t, "SNOW", page.Query)
+
+		_, page = getTranscripts(t, files, "/transcripts?q=.*")
+		assert.Equal(t, 0, page.Total)
+		assert.NotNil(t, page.Transcripts)
+	})
+
+	t.Run("should reject invalid parameters", func(t *testing.T) {
+		for _, target := range []string{
+			"/transcripts?since=yesterday",
+			"/transcripts?until=07:00",
+			"/transcripts?limit=0",
+			"/transcripts?limit=1001",
+			"/transcripts?offset=-1",
+		} {
+			rec, _ := getTranscripts(t, files, target)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+		}
+	})
+
+	t.Run("should be served once registered", func(t *testing.T) {
+		server := NewServer("127.0.0.1:0", NewHub(), zap.NewNop())
+		server.ServeTranscripts(files, nil)
+		rec := httptest.NewRecorder()
+
+		server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/transcripts?q=morning", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"good morning"`)
+	})
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/adbreak"
+	"radiocontestwinner/internal/buffer"
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/parser"
+)
+
+// What happens to cues heard during a commercial break
+const (
+	adBreakSuppress = "suppress" // Drop the cue
+	adBreakDownrank = "downrank" // Log the cue flagged as heard during a break, without notifying
+)
+
+// newAdBreakDetector creates the commercial break detector, or returns nil when it is disabled
+func newAdBreakDetector(cfg *config.Configuration) (*adbreak.Detector, error) {
+	if !cfg.GetAdDetectionEnabled() {
+		return nil, nil
+	}
+	if action := cfg.GetAdDetectionAction(); action != adBreakSuppress && action != adBreakDownrank {
+		return nil, fmt.Errorf("invalid ad_detection.action %q: use %s or %s", action, adBreakSuppress, adBreakDownrank)
+	}
+	return adbreak.NewDetector(adbreak.Config{
+		AdPhrases:       cfg.GetAdDetectionAdPhrases(),
+		ProgramPhrases:  cfg.GetAdDetectionProgramPhrases(),
+		Hold:            time.Duration(cfg.GetAdDetectionHoldSec()) * time.Second,
+		LoudnessDBFS:    cfg.GetAdDetectionLoudnessDBFS(),
+		LoudnessRangeDB: cfg.GetAdDetectionLoudnessRangeDB(),
+		LoudnessWindow:  time.Duration(cfg.GetAdDetectionLoudnessWindowSec()) * time.Second,
+	}), nil
+}
+
+// monitorAdLoudness feeds the measured input level to the break detector every second until ctx is cancelled
+func (app *Application) monitorAdLoudness(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			app.adBreaks.ObserveLevel(now, app.gainControl.Levels().InputDBFS)
+		}
+	}
+}
+
+// observeAdBreakText checks a buffered context for phrases starting or ending a commercial break
+func (app *Application) observeAdBreakText(bc buffer.BufferedContext) {
+	if app.adBreaks == nil {
+		return
+	}
+	at := bc.CapturedAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+	app.adBreaks.ObserveText(at, bc.Text)
+}
+
+// flagAdBreakCue marks a cue heard during a commercial break and reports whether it should still
+// be logged and notified. Downranked cues are logged but not notified.
+func (app *Application) flagAdBreakCue(cue *parser.ContestCue) (keep, notify bool) {
+	if app.adBreaks == nil {
+		return true, true
+	}
+	heardAt := cue.HeardAt()
+	if heardAt.IsZero() {
+		heardAt = time.Now()
+	}
+	brk, ok := app.adBreaks.BreakAt(heardAt)
+	if !ok {
+		return true, true
+	}
+	app.adBreaks.NoteFlaggedCue()
+
+	if app.config.GetAdDetectionAction() == adBreakSuppress {
+		app.zapLogger.Info("suppressing contest cue heard during a commercial break",
+			zap.String("cue_id", cue.CueID),
+			zap.Strings("trace_ids", cue.TraceIDs),
+			zap.String("contest_type", cue.ContestType),
+			zap.String("break_reason", brk.Reason))
+		return false, false
+	}
+	app.zapLogger.Info("contest cue heard during a commercial break; logging without notifying",
+		zap.String("cue_id", cue.CueID),
+		zap.Strings("trace_ids", cue.TraceIDs),
+		zap.String("contest_type", cue.ContestType),
+		zap.String("break_reason", brk.Reason))
+	cue.Details = cue.Details.Clone()
+	cue.Details.Set("ad_break", true)
+	cue.Details.Set("ad_break_reason", brk.Reason)
+	return true, false
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/buffer"
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/parser"
+)
+
+func TestNewAdBreakDetector(t *testing.T) {
+	t.Run("should be nil when ad detection is disabled", func(t *testing.T) {
+		detector, err := newAdBreakDetector(config.NewConfiguration())
+
+		assert.NoError(t, err)
+		assert.Nil(t, detector)
+	})
+
+	t.Run("should reject unknown actions", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetAdDetectionEnabled(true)
+		cfg.SetAdDetectionAction("ignore")
+
+		_, err := newAdBreakDetector(cfg)
+
+		assert.ErrorContains(t, err, `invalid ad_detection.action "ignore"`)
+	})
+}
+
+func TestApplication_AdBreaks(t *testing.T) {
+	newAdBreakApp := func(t *testing.T, action string) *Application {
+		app, err := NewApplication()
+		require.NoError(t, err)
+		app.config.SetAdDetectionEnabled(true)
+		app.config.SetAdDetectionAction(action)
+		app.adBreaks, err = newAdBreakDetector(app.config)
+		require.NoError(t, err)
+		return app
+	}
+	newCue := func(heardAt time.Time) parser.ContestCue {
+		cue := parser.NewContestCue("CASH", parser.CueDetails{Keyword: "CASH", Number: "55555"})
+		cue.Timing = parser.NewCueTiming(heardAt, time.Time{}, time.Now())
+		return *cue
+	}
+
+	t.Run("should log cues heard during a break flagged and without notifying", func(t *testing.T) {
+		// Arrange
+		app := newAdBreakApp(t, adBreakDownrank)
+		heardAt := time.Now().Add(-5 * time.Second)
+		app.observeAdBreakText(buffer.BufferedContext{Text: "Text CASH to 55555 to win! Restrictions apply.", CapturedAt: heardAt})
+		cue := newCue(heardAt)
+
+		// Act
+		keep, notify := app.flagAdBreakCue(&cue)
+
+		// Assert
+		assert.True(t, keep)
+		assert.False(t, notify)
+		assert.Equal(t, true, cue.Details.Extensions["ad_break"])
+		assert.Equal(t, `phrase "restrictions apply"`, cue.Details.Extensions["ad_break_reason"])
+		assert.Equal(t, int64(1), app.adBreaks.Status().FlaggedCues)
+	})
+
+	t.Run("should drop cues heard during a break when suppressing", func(t *testing.T) {
+		app := newAdBreakApp(t, adBreakSuppress)
+		heardAt := time.Now()
+		app.observeAdBreakText(buffer.BufferedContext{Text: "brought to you by Acme", CapturedAt: heardAt})
+		cue := newCue(heardAt)
+
+		keep, _ := app.flagAdBreakCue(&cue)
+
+		assert.False(t, keep)
+	})
+
+	t.Run("should pass cues heard outside breaks unchanged", func(t *testing.T) {
+		app := newAdBreakApp(t, adBreakSuppress)
+		app.observeAdBreakText(buffer.BufferedContext{Text: "call now", CapturedAt: time.Now().Add(-time.Hour)})
+		cue := newCue(time.Now())
+
+		keep, notify := app.flagAdBreakCue(&cue)
+
+		assert.True(t, keep)
+		assert.True(t, notify)
+		assert.NotContains(t, cue.Details.Extensions, "ad_break")
+	})
+}
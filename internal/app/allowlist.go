@@ -0,0 +1,76 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/notifier"
+	"radiocontestwinner/internal/parser"
+)
+
+// checkAllowlistExpiry warns once per station day about allowlist entries that expire within
+// allowlist.expiry.warn_days or have already expired but are still configured, so dead
+// shortcodes get cleaned up. Warnings are logged and, when enabled, sent to the notifiers.
+func (app *Application) checkAllowlistExpiry(now time.Time) {
+	location := app.displayLocation
+	if location == nil {
+		location = time.Local
+	}
+	day := now.In(location).Format("2006-01-02")
+	if day == app.allowlistExpiryDay {
+		return
+	}
+	app.allowlistExpiryDay = day
+
+	expiring := parser.ExpiringAllowlistEntries(allowlistEntries(app.config), now, location, app.config.GetAllowlistExpiryWarnDays())
+	if len(expiring) == 0 {
+		return
+	}
+
+	var expired, soon []string
+	for _, e := range expiring {
+		fields := []zap.Field{
+			zap.String("entry", e.Entry.Value),
+			zap.String("label", e.Entry.Label),
+			zap.String("expires", e.Entry.Expires),
+			zap.String("source", e.Entry.Source),
+		}
+		if e.Expired() {
+			app.zapLogger.Warn("allowlist entry expired but is still configured", fields...)
+			expired = append(expired, describeAllowlistExpiry(e))
+		} else {
+			app.zapLogger.Warn("allowlist entry expires soon", append(fields, zap.Int("days_left", e.DaysLeft))...)
+			soon = append(soon, describeAllowlistExpiry(e))
+		}
+	}
+
+	if !app.config.GetAllowlistExpiryNotify() {
+		return
+	}
+	severity := notifier.SeverityInfo
+	var lines []string
+	if len(expired) > 0 {
+		severity = notifier.SeverityWarning
+		lines = append(lines, "Expired but still configured: "+strings.Join(expired, ", "))
+	}
+	if len(soon) > 0 {
+		lines = append(lines, "Expiring soon: "+strings.Join(soon, ", "))
+	}
+	app.dispatchNotification(notifier.NewAlertNotification(severity,
+		"Allowlist entries expiring",
+		strings.Join(lines, "\n"),
+		map[string]interface{}{"expired": len(expired), "expiring": len(soon)}))
+}
+
+// describeAllowlistExpiry formats an expiring entry for notifications, e.g.
+// "72881 (Morning cash) on 2026-11-30"
+func describeAllowlistExpiry(e parser.AllowlistExpiry) string {
+	description := e.Entry.Value
+	if e.Entry.Label != "" {
+		description += fmt.Sprintf(" (%s)", e.Entry.Label)
+	}
+	return description + " on " + e.Entry.Expires
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/notifier"
+)
+
+func TestApplication_AllowlistExpiry(t *testing.T) {
+	app, err := NewApplication()
+	require.NoError(t, err)
+
+	alerts := &channelNotifier{ch: make(chan notifier.Notification, 10)}
+	app.notifier = notifier.NewDispatcher(nil, alerts)
+	app.displayLocation = time.UTC
+	app.config.SetAllowlistEntries([]config.AllowlistEntry{
+		{Number: "72881", Label: "Morning cash", Expires: "2026-10-31"},
+		{Number: "55555", Expires: "2026-11-10"},
+		{Number: "73"},
+	})
+	app.config.SetAllowlistExpiryWarnDays(14)
+	app.config.SetAllowlistExpiryNotify(true)
+	now := time.Date(2026, 11, 1, 8, 0, 0, 0, time.UTC)
+
+	expectNone := func(t *testing.T) {
+		t.Helper()
+		select {
+		case n := <-alerts.ch:
+			t.Fatalf("unexpected notification %q", n.Title)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	t.Run("should notify about expired and expiring entries", func(t *testing.T) {
+		app.checkAllowlistExpiry(now)
+
+		select {
+		case n := <-alerts.ch:
+			assert.Equal(t, notifier.SeverityWarning, n.Severity)
+			assert.Equal(t, "Allowlist entries expiring", n.Title)
+			assert.Contains(t, n.Message, "Expired but still configured: 72881 (Morning cash) on 2026-10-31")
+			assert.Contains(t, n.Message, "Expiring soon: 55555 on 2026-11-10")
+		case <-time.After(time.Second):
+			t.Fatal("expected an allowlist expiry notification")
+		}
+	})
+
+	t.Run("should check only once a day", func(t *testing.T) {
+		app.checkAllowlistExpiry(now.Add(time.Hour))
+
+		expectNone(t)
+	})
+
+	t.Run("should only log when notifications are disabled", func(t *testing.T) {
+		app.config.SetAllowlistExpiryNotify(false)
+
+		app.checkAllowlistExpiry(now.Add(24 * time.Hour))
+
+		expectNone(t)
+	})
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// startAPI serves the API in the background until ctx is cancelled. A failure to listen, such as
+// an address in use, is logged and leaves the pipeline running without it.
+func (app *Application) startAPI(ctx context.Context) {
+	if app.apiServer == nil {
+		return
+	}
+	go func() {
+		if err := app.apiServer.Run(ctx); err != nil {
+			app.zapLogger.Warn("API stopped", zap.String("address", app.config.GetAPIAddress()), zap.Error(err))
+		}
+	}()
+}
+
+// publishLive sends a cue or transcription segment to the connected tail clients
+func (app *Application) publishLive(kind string, payload interface{}) {
+	if app.live == nil {
+		return
+	}
+	if err := app.live.Publish(kind, payload); err != nil {
+		app.zapLogger.Debug("failed to publish live event", zap.String("kind", kind), zap.Error(err))
+	}
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/api"
+	"radiocontestwinner/internal/parser"
+	"radiocontestwinner/internal/transcriber"
+)
+
+func TestApplication_PublishLive(t *testing.T) {
+	t.Run("should publish transcriptions and cues to tail clients", func(t *testing.T) {
+		// Arrange
+		app, err := NewApplication()
+		require.NoError(t, err)
+		app.live = api.NewHub()
+		sub := app.live.Subscribe(api.Kinds())
+		defer sub.Close()
+
+		segments := make(chan transcriber.TranscriptionSegment, 1)
+		segments <- transcriber.TranscriptionSegment{Text: "text CASH to 55555", StartMS: 0, EndMS: 5000, Confidence: 0.9}
+		close(segments)
+		cues := make(chan parser.ContestCue, 1)
+		cues <- *parser.NewContestCue("CASH", parser.CueDetails{Keyword: "CASH", Number: "55555"})
+		close(cues)
+
+		// Act
+		for range app.wrapTranscriptionChannelWithHealthTracking(segments) {
+		}
+		for range app.wrapContestCueChannelWithHealthTracking(cues) {
+		}
+
+		// Assert
+		transcript := <-sub.Events()
+		assert.Equal(t, api.KindTranscript, transcript.Kind)
+		var segment transcriber.TranscriptionSegment
+		require.NoError(t, json.Unmarshal(transcript.Data, &segment))
+		assert.Equal(t, "text CASH to 55555", segment.Text)
+
+		event := <-sub.Events()
+		assert.Equal(t, api.KindCue, event.Kind)
+		var cue parser.ContestCue
+		require.NoError(t, json.Unmarshal(event.Data, &cue))
+		assert.Equal(t, "55555", cue.Details.Number)
+	})
+
+	t.Run("should not serve the API unless enabled", func(t *testing.T) {
+		app, err := NewApplication()
+
+		require.NoError(t, err)
+		assert.Nil(t, app.live)
+		assert.Nil(t, app.apiServer)
+	})
+}
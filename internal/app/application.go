@@ -4,21 +4,41 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
 
+	"radiocontestwinner/internal/adbreak"
+	"radiocontestwinner/internal/anomaly"
+	"radiocontestwinner/internal/api"
 	"radiocontestwinner/internal/buffer"
+	"radiocontestwinner/internal/calendar"
+	"radiocontestwinner/internal/clock"
 	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/coordination"
+	"radiocontestwinner/internal/correction"
+	"radiocontestwinner/internal/dedup"
+	"radiocontestwinner/internal/feature"
+	"radiocontestwinner/internal/health"
 	"radiocontestwinner/internal/logger"
+	"radiocontestwinner/internal/notifier"
 	"radiocontestwinner/internal/parser"
 	"radiocontestwinner/internal/processor"
+	"radiocontestwinner/internal/program"
+	"radiocontestwinner/internal/redis"
+	"radiocontestwinner/internal/scrub"
+	"radiocontestwinner/internal/search"
 	"radiocontestwinner/internal/stream"
 	"radiocontestwinner/internal/transcriber"
+	"radiocontestwinner/internal/usage"
+	"radiocontestwinner/internal/version"
 )
 
 // PipelineHealth tracks the health status of the audio processing pipeline
@@ -30,15 +50,26 @@ type PipelineHealth struct {
 	streamConnectionActive  bool
 	audioProcessingActive   bool
 	transcriptionActive     bool
+	streamFormat            string // Audio format detected when the stream was last connected
 	totalTranscriptions     int64
 	totalContestCues        int64
+	truncatedContexts       int64         // Buffered contexts whose text was cut to the buffer limits
+	transcriptionGaps       int64         // Gaps in the buffered context stream where no audio was transcribed
+	lastTranscriptionGap    time.Duration // Length of the latest gap
 
 	// Performance tracking for "falling behind" detection
 	processingStartTime  time.Time
-	totalAudioDurationMS int64   // Total duration of audio processed
-	averageLatencyMS     float64 // Moving average of processing latency
-	currentBacklogSize   int
-	isRealTime           bool // Are we processing in real-time?
+	totalAudioDurationMS int64                   // Total duration of audio processed
+	transcriptionLatency health.LatencyHistogram // Processing latency of each transcription
+	cueLatency           health.LatencyHistogram // From audio capture to emission of each cue
+	currentBacklogSize   int                     // Items queued across all pipeline channels
+	channelDepths        map[string]int          // Items queued in each pipeline channel
+	isRealTime           bool                    // Are we processing in real-time?
+
+	pausedAt  time.Time // When transcription was paused; zero while running
+	resumedAt time.Time // When transcription was last resumed
+
+	versions version.Info // Build metadata and tool versions, detected once the model is loaded
 }
 
 // Application represents the main radio contest winner application orchestrator
@@ -47,33 +78,104 @@ type Application struct {
 	logger              *logger.LogOutput
 	zapLogger           *zap.Logger
 	streamConnector     *stream.StreamConnector
+	reconnectThrottle   *stream.ReconnectThrottle // nil when stream reconnects are not throttled
 	audioProcessor      *processor.AudioProcessor
+	processorMu         sync.Mutex // Guards audioProcessor, which the supervisor replaces on FFmpeg restarts
 	transcriptionEngine *transcriber.TranscriptionEngine
 	contestParser       *parser.ContestParser
+	parserShadow        *parserShadow // nil when no candidate parser config is evaluated
+	substitutions       *parser.SubstitutionDictionary
 	logOutput           *logger.LogOutput
+	components          componentRegistry // Pipeline stages stopped and health checked together
 	pipelineHealth      *PipelineHealth
+	rateDetector        *anomaly.RateDetector // nil when anomaly detection is disabled
+	failureBudget       *health.BudgetTracker // nil when failure escalation is disabled
+	allowlistExpiryDay  string                // Station date of the last allowlist expiry check
+	latencySLOMissed    bool                  // Whether the last heartbeat found the cue latency SLO missed
+	clockMonitor        *clock.Monitor        // nil when clock drift checks are disabled
+	corrector           *correction.Corrector // nil when LLM correction is disabled
+	adBreaks            *adbreak.Detector     // nil when ad break detection is disabled
+	calendar            *calendar.Exporter    // nil when calendar export is disabled
+	archive             *nightlyArchive       // nil when the nightly archive export is disabled
+	programs            *program.Schedule     // nil when no program schedule is configured
+	usageLedger         *usage.Ledger         // Audio transcribed per day and backend; nil in tests that build an Application directly
+	debugTranscripts    *debugTranscriptWriter
+	displayLocation     *time.Location // Zone of times in human-facing output; nil when not configured
+	notifier            *notifier.Dispatcher
+	elector             *coordination.Elector // nil when multi-instance coordination is disabled
+	standby             atomic.Bool           // Warm standby: transcription stays paused until promoted
+	redisClient         *redis.Client         // nil when Redis integration is disabled
+	cueDedup            *dedup.Window         // nil when cue deduplication is disabled
+	transcripts         *redis.CappedList     // nil when transcript history is disabled
+	backlog             *backlogMonitor
+	gainControl         *processor.GainControl
+	pcmTee              *processor.PCMTee // nil unless decoded PCM is teed to external consumers
+	supervisor          *Supervisor
+	observer            PipelineObserver // nil when no operator console is attached
+	relays              *relayGuard      // nil when no relay streams are configured
+	live                *api.Hub         // Live events for tail clients; nil when the API is disabled
+	apiServer           *api.Server      // nil when the API is disabled
+	features            *feature.Set
+	scrubber            *scrub.Scrubber  // nil when scrubbing is disabled
+	healthFile          string           // Where the heartbeat writes the health status
+	now                 func() time.Time // Clock for health and heartbeat timing; replaced in tests
 }
 
-// NewApplication creates a new application instance with all components initialized
-func NewApplication() (*Application, error) {
-	// Load configuration from config file if CONFIG_PATH is set, otherwise use environment variables
-	var cfg *config.Configuration
-	var err error
-
+// LoadConfiguration loads the configuration from the file in CONFIG_PATH if set, otherwise from
+// environment variables
+func LoadConfiguration() (*config.Configuration, error) {
 	if configPath := os.Getenv("CONFIG_PATH"); configPath != "" {
-		cfg, err = config.NewConfigurationFromFile(configPath)
+		cfg, err := config.NewConfigurationFromFile(configPath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load config from file %s: %w", configPath, err)
 		}
-	} else {
-		cfg, err = config.NewConfigurationFromEnv()
-		if err != nil {
-			return nil, fmt.Errorf("failed to load config from environment: %w", err)
-		}
+		return cfg, nil
+	}
+	cfg, err := config.NewConfigurationFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config from environment: %w", err)
 	}
+	return cfg, nil
+}
 
-	// Create zap logger - centralized structured logging
+// NewApplication creates a new application instance with all components initialized
+func NewApplication() (*Application, error) {
+	cfg, err := LoadConfiguration()
+	if err != nil {
+		return nil, err
+	}
+	return NewApplicationWithConfig(cfg)
+}
+
+// NewApplicationWithConfig creates an application for the given configuration, such as one
+// tenant's configuration in a multi-tenant deployment
+func NewApplicationWithConfig(cfg *config.Configuration) (*Application, error) {
+	var err error
+
+	// Offline deployments must not depend on integrations that call out
+	if err := cfg.ValidateOfflineMode(); err != nil {
+		return nil, err
+	}
+	// An API reachable from other hosts must not serve transcripts without a token
+	if err := cfg.ValidateAPI(); err != nil {
+		return nil, err
+	}
+
+	// Create zap logger - centralized structured logging, labelled with the tenant if any
 	zapLogger := logger.NewLogger()
+	healthFile := health.DefaultFile
+	if tenant := cfg.GetTenantName(); tenant != "" {
+		zapLogger = zapLogger.With(zap.String("tenant", tenant))
+		healthFile = health.TenantFile(tenant)
+	}
+
+	// Share the machine politely before any worker goroutines or child processes start; for
+	// tenants the process-wide settings are applied once by NewTenants
+	if cfg.GetTenantName() == "" {
+		if err := applyProcessPriority(cfg, zapLogger); err != nil {
+			return nil, err
+		}
+	}
 
 	// Create log output component for contest cues
 	logOutput, err := logger.NewLogOutput(cfg, zapLogger)
@@ -82,18 +184,186 @@ func NewApplication() (*Application, error) {
 	}
 
 	// Create stream connector component
-	streamConnector := stream.NewStreamConnectorWithLogger(cfg.GetStreamURL(), zapLogger)
+	streamConnector := stream.NewStreamConnectorWithFailover(streamURLs(cfg), cfg.GetStreamFailoverThreshold(), zapLogger)
+	streamConnector.SetBackoffJitter(cfg.GetRestartJitter())
+
+	// Protect the stream host from reconnect storms during long outages
+	reconnectThrottle := newReconnectThrottle(cfg, zapLogger)
+	streamConnector.SetReconnectThrottle(reconnectThrottle)
 
 	// Create transcription engine component
 	transcriptionEngine := transcriber.NewTranscriptionEngineWithConfig(zapLogger, cfg)
 
-	// Create contest parser component with configured allowlist
-	contestParser := parser.NewContestParserWithLogger(cfg.GetAllowlist(), zapLogger)
+	// Human-facing output shows times in the configured (or station) time zone
+	var displayLocation *time.Location
+	if tz := cfg.GetTimezone(); tz != "" {
+		if displayLocation, err = time.LoadLocation(tz); err != nil {
+			return nil, fmt.Errorf("invalid timezone: %w", err)
+		}
+	}
+
+	// Name the show each cue was heard in, and relax or tighten matching during scheduled shows
+	programs, err := newProgramSchedule(cfg, displayLocation, zapLogger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load ASR substitution dictionary from inline config and optional substitution file
+	substitutionDict, err := newSubstitutions(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create contest parser component with the configured allowlist, corrections, and patterns
+	contestParser, err := newContestParser(cfg, displayLocation, programs, substitutionDict, zapLogger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Evaluate a candidate parser configuration alongside the active one when configured
+	parserShadow, err := newParserShadow(cfg, displayLocation, programs, substitutionDict, zapLogger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create notification dispatcher for cues and health alerts
+	dispatcher, err := notifier.NewDispatcherFromConfig(cfg, zapLogger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notifier: %w", err)
+	}
+
+	// Create leader elector so only one of several redundant instances sends notifications
+	elector, err := coordination.NewElectorFromConfig(cfg, zapLogger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure coordination: %w", err)
+	}
+
+	// Share the dedup window and recent transcripts through Redis when enabled
+	var redisClient *redis.Client
+	var transcripts *redis.CappedList
+	if cfg.GetRedisEnabled() {
+		redisClient, err = redis.NewClient(redis.Options{
+			Address:  cfg.GetRedisAddress(),
+			Password: cfg.GetRedisPassword(),
+			DB:       cfg.GetRedisDB(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create redis client: %w", err)
+		}
+		if history := cfg.GetRedisTranscriptHistory(); history > 0 {
+			transcripts = redis.NewCappedList(redisClient, cfg.GetRedisKeyPrefix()+":transcripts", history)
+		}
+	}
+	cueDedup := dedup.NewWindowFromConfig(cfg, redisClient, zapLogger)
+
+	// Feature flags from the config; remote ones are fetched once the application runs
+	features, err := newFeatureSet(cfg)
+	if err != nil {
+		return nil, err
+	}
+	transcriptionEngine.SetFeatures(features)
+
+	// Mask personal data and profanity in what is stored and sent out, for compliance
+	scrubber, err := newScrubber(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Drop to sampling through overnight dead air or automation when configured
+	idleMode, err := newIdleMode(cfg, zapLogger)
+	if err != nil {
+		return nil, err
+	}
+	transcriptionEngine.SetIdleMode(idleMode)
+
+	// Create transcription rate anomaly detector
+	var rateDetector *anomaly.RateDetector
+	if cfg.GetAnomalyDetectionEnabled() {
+		rateDetector = anomaly.NewRateDetector(anomaly.RateDetectorConfig{
+			Window:         time.Duration(cfg.GetAnomalyWindowSec()) * time.Second,
+			BaselineWindow: time.Duration(cfg.GetAnomalyBaselineWindowSec()) * time.Second,
+			LowRatio:       cfg.GetAnomalyLowRatio(),
+			HighRatio:      cfg.GetAnomalyHighRatio(),
+			MinBaselineWPM: cfg.GetAnomalyMinBaselineWPM(),
+		})
+	}
+
+	// Create the clock drift monitor auditing cue timestamps against NTP time
+	var clockMonitor *clock.Monitor
+	if cfg.GetNTPEnabled() {
+		clockMonitor = clock.NewMonitor(clock.MonitorConfig{
+			Server:    cfg.GetNTPServer(),
+			MaxOffset: time.Duration(cfg.GetNTPMaxOffsetMS()) * time.Millisecond,
+			Timeout:   time.Duration(cfg.GetNTPTimeoutSec()) * time.Second,
+		})
+	}
+
+	// Create the LLM corrector fixing low-confidence transcriptions before pattern matching
+	var corrector *correction.Corrector
+	if cfg.GetCorrectionEnabled() {
+		corrector = correction.NewCorrector(correction.Config{
+			Endpoint:             cfg.GetCorrectionEndpoint(),
+			Model:                cfg.GetCorrectionModel(),
+			APIKey:               cfg.GetCorrectionAPIKey(),
+			Prompt:               cfg.GetCorrectionPrompt(),
+			ConfidenceThreshold:  float32(cfg.GetCorrectionConfidenceThreshold()),
+			Timeout:              time.Duration(cfg.GetCorrectionTimeoutMS()) * time.Millisecond,
+			MaxRequestsPerMinute: cfg.GetCorrectionMaxRequestsPerMinute(),
+			DailyTokenBudget:     cfg.GetCorrectionDailyTokenBudget(),
+			Location:             displayLocation,
+		})
+	}
+
+	// Create the commercial break detector flagging contest mentions heard in ads
+	adBreaks, err := newAdBreakDetector(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure ad detection: %w", err)
+	}
+
+	// Create channel backlog monitor warning when a pipeline stage falls behind
+	backlog := newBacklogMonitor(cfg.GetChannelHighWatermarkPct(),
+		time.Duration(cfg.GetChannelHighWatermarkSec())*time.Second, zapLogger)
+
+	// Create automatic gain control, which also measures audio levels when disabled
+	gainControl := processor.NewGainControl(processor.GainConfig{
+		Enabled:    cfg.GetAGCEnabled(),
+		TargetDBFS: cfg.GetAGCTargetDBFS(),
+		MaxGainDB:  cfg.GetAGCMaxGainDB(),
+	})
+
+	// Create the tee copying decoded PCM to external consumers such as loudness monitors
+	pcmTee, err := newPCMTee(cfg, zapLogger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the relay guard collapsing byte-identical relays of the monitored stream
+	var relays *relayGuard
+	if streamRelays := cfg.GetStreamRelays(); len(streamRelays) > 0 {
+		relays = newRelayGuard(streamRelays, cfg.GetStreamDuplicateSimilarity(),
+			time.Duration(cfg.GetStreamRelaySampleSec())*time.Second, zapLogger)
+		streamConnector.SetTap(relays.Tap())
+	}
+
+	// Account for the audio each transcription backend transcribes
+	usageLedger := newUsageLedger(cfg, displayLocation, zapLogger)
+	transcriptionEngine.SetUsageRecorder(usageLedger.Record)
+
+	// Create the debug-mode transcription file writer; the file is opened on the first write
+	debugTranscripts := newDebugTranscriptWriter(cfg.GetDebugTranscriptsPath(), cfg.GetDebugTranscriptsSampleEvery(),
+		int64(cfg.GetDebugTranscriptsMaxSizeMB())*1024*1024, cfg.GetDebugTranscriptsMaxBackups(),
+		time.Duration(cfg.GetDebugTranscriptsFlushIntervalSec())*time.Second)
+
+	// Export transcripts, cue logs, and audio snippets to object storage each night
+	nightly, err := newNightlyArchive(cfg, zapLogger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure archive export: %w", err)
+	}
 
 	// Audio processor will be created per connection, so initialize as nil for now
 	var audioProcessor *processor.AudioProcessor
 
-	return &Application{
+	app := &Application{
 		config:              cfg,
 		logger:              logOutput,
 		zapLogger:           zapLogger,
@@ -101,14 +371,61 @@ func NewApplication() (*Application, error) {
 		audioProcessor:      audioProcessor,
 		transcriptionEngine: transcriptionEngine,
 		contestParser:       contestParser,
+		parserShadow:        parserShadow,
+		substitutions:       substitutionDict,
 		logOutput:           logOutput,
 		pipelineHealth:      &PipelineHealth{},
-	}, nil
+		now:                 time.Now,
+		rateDetector:        rateDetector,
+		failureBudget:       newBudgetTracker(cfg, displayLocation),
+		clockMonitor:        clockMonitor,
+		healthFile:          healthFile,
+		corrector:           corrector,
+		adBreaks:            adBreaks,
+		calendar:            newCalendarExporter(cfg, displayLocation),
+		usageLedger:         usageLedger,
+		debugTranscripts:    debugTranscripts,
+		archive:             nightly,
+		programs:            programs,
+		reconnectThrottle:   reconnectThrottle,
+		displayLocation:     displayLocation,
+		notifier:            dispatcher,
+		elector:             elector,
+		redisClient:         redisClient,
+		cueDedup:            cueDedup,
+		transcripts:         transcripts,
+		backlog:             backlog,
+		gainControl:         gainControl,
+		pcmTee:              pcmTee,
+		supervisor:          NewSupervisorFromConfig(cfg, zapLogger),
+		relays:              relays,
+		features:            features,
+		scrubber:            scrubber,
+	}
+	if cfg.GetAPIEnabled() {
+		app.live = api.NewHub()
+		app.apiServer = api.NewServer(cfg.GetAPIAddress(), app.live, zapLogger)
+		app.apiServer.RequireToken(cfg.GetAPIToken())
+		app.apiServer.ServeTranscripts(search.TranscriptFiles(cfg), displayLocation)
+		app.apiServer.ServeMetrics(app.metrics)
+		if cfg.GetAPIPprof() {
+			app.apiServer.ServePprof()
+		}
+	}
+
+	// The audio processor and context buffer join once the pipeline creates them
+	for _, component := range []Component{streamConnector, transcriptionEngine, contestParser, logOutput} {
+		app.components.register(component)
+	}
+	return app, nil
 }
 
 // Run starts the application and runs the main processing pipeline
 func (app *Application) Run(ctx context.Context) error {
 	app.zapLogger.Info("starting Radio Contest Winner application")
+	if app.config.GetOfflineMode() {
+		app.zapLogger.Info("offline mode enabled: model downloads, API and service transcription backends, and NTP checks are disabled")
+	}
 
 	// Check if context is already cancelled
 	select {
@@ -118,11 +435,55 @@ func (app *Application) Run(ctx context.Context) error {
 	default:
 	}
 
+	// Serve the API from the start so tail clients can follow startup too
+	app.startAPI(ctx)
+
+	// Fetch the remote feature flags early and keep them current; transcription checks them each time it starts
+	if url := app.config.GetFeaturesURL(); url != "" {
+		go app.features.RunRefresh(ctx, url, time.Duration(app.config.GetFeaturesRefreshIntervalSec())*time.Second, app.zapLogger)
+	}
+
+	// A missing model is downloaded on first startup, which can take a while; keep the health
+	// file current so the download's progress is visible and the container is not restarted
+	app.transcriptionEngine.SetDownloadProgressCallback(func(progress transcriber.DownloadProgress) {
+		if err := app.writeHealthStatusFile(); err != nil {
+			app.zapLogger.Debug("failed to write health status file during model download", zap.Error(err))
+		}
+	})
+
 	// Load Whisper model
-	if err := app.transcriptionEngine.LoadModel(app.config.GetWhisperModelPath()); err != nil {
+	if !app.config.GetTranscriptionEnabled() {
+		app.zapLogger.Info("transcription is disabled; not loading a Whisper model")
+	} else if err := app.startComponent(ctx, app.transcriptionEngine); err != nil {
+		// Offline, nothing can supply a model or backend later, so running on would only hide the problem
+		if app.config.GetOfflineMode() {
+			app.zapLogger.Error("failed to load Whisper model in offline mode", zap.Error(err))
+			return fmt.Errorf("failed to load Whisper model in offline mode: %w", err)
+		}
 		app.zapLogger.Warn("failed to load Whisper model, continuing without transcription", zap.Error(err))
 	} else {
 		app.zapLogger.Info("Whisper model loaded successfully", zap.String("path", app.config.GetWhisperModelPath()))
+
+		// Fail fast when the backend can't actually transcribe rather than on the first live chunk
+		if err := app.warmUpTranscription(ctx); err != nil {
+			if ctx.Err() != nil {
+				app.zapLogger.Info("context cancelled during transcription warm-up, shutting down")
+				return nil
+			}
+			app.zapLogger.Error("transcription warm-up failed", zap.Error(err))
+			return fmt.Errorf("transcription warm-up failed: %w", err)
+		}
+	}
+
+	// Record which build and which whisper.cpp and FFmpeg versions this run uses
+	app.detectVersions(ctx)
+
+	// A warm standby keeps the stream and FFmpeg running but leaves the transcriber idle
+	app.startStandby()
+
+	// Campaign for leadership; followers keep processing and logging cues but do not notify
+	if app.elector != nil {
+		go app.elector.Run(ctx)
 	}
 
 	// Start the audio processing pipeline
@@ -141,10 +502,10 @@ func (app *Application) Run(ctx context.Context) error {
 				// For timeout cases, check if this was caused by network issues
 				// If the underlying error contains network-related errors, treat as failure
 				if strings.Contains(err.Error(), "connection refused") ||
-				   strings.Contains(err.Error(), "no such host") ||
-				   strings.Contains(err.Error(), "network is unreachable") ||
-				   strings.Contains(err.Error(), "localhost:") ||
-				   strings.Contains(err.Error(), "127.0.0.1:") {
+					strings.Contains(err.Error(), "no such host") ||
+					strings.Contains(err.Error(), "network is unreachable") ||
+					strings.Contains(err.Error(), "localhost:") ||
+					strings.Contains(err.Error(), "127.0.0.1:") {
 					// Network failure to test servers - return error
 					break
 				}
@@ -162,9 +523,15 @@ func (app *Application) Run(ctx context.Context) error {
 		return fmt.Errorf("failed to start pipeline: %w", err)
 	}
 
-	// Wait for shutdown signal
-	<-ctx.Done()
-	app.zapLogger.Info("shutdown signal received, stopping application")
+	// Wait for shutdown signal or a component escalating to a full application restart
+	select {
+	case <-ctx.Done():
+		app.zapLogger.Info("shutdown signal received, stopping application")
+	case component := <-app.supervisor.Escalations():
+		app.zapLogger.Error("component exhausted its restart policy, escalating to application restart",
+			zap.String("component", component))
+		return fmt.Errorf("%w: %s", ErrRestartEscalation, component)
+	}
 
 	return nil
 }
@@ -176,10 +543,102 @@ func (app *Application) startPipeline(ctx context.Context) error {
 		zap.String("stream_url", app.config.GetStreamURL()),
 		zap.Int("buffer_duration_ms", app.config.GetBufferDurationMS()))
 
+	// Transcribe the stream, or replay stored transcriptions when transcription is disabled
+	var transcriptionCh <-chan transcriber.TranscriptionSegment
+	var err error
+	if app.config.GetTranscriptionEnabled() {
+		transcriptionCh, err = app.startTranscriptionStages(ctx)
+	} else {
+		transcriptionCh, err = app.replayTranscriptions(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Wrap transcription channel for health tracking first
+	transcriptionCh = app.wrapTranscriptionChannelWithHealthTracking(transcriptionCh)
+	app.backlog.track("transcription", func() int { return len(transcriptionCh) }, cap(transcriptionCh))
+
+	// Buffer and parse transcriptions for contest cues unless only transcripts are wanted
+	if app.config.GetParserEnabled() {
+		if err := app.startCueStages(ctx, transcriptionCh); err != nil {
+			return err
+		}
+	} else {
+		go drain(transcriptionCh)
+	}
+	go app.monitorChannelBacklog(ctx)
+
+	// Watch for the steady loudness of commercials when configured
+	if app.adBreaks != nil && app.config.GetAdDetectionLoudnessDBFS() != 0 {
+		go app.monitorAdLoudness(ctx)
+	}
+
+	// Start heartbeat monitoring
+	go app.startHeartbeat(ctx)
+
+	// Retry notification deliveries that failed, including any queued before a restart
+	if app.notifier != nil {
+		go app.notifier.RunQueue(ctx, 0)
+	}
+
+	// Flush debug transcriptions periodically and close the file on shutdown
+	go app.debugTranscripts.Run(ctx, func(err error) {
+		app.zapLogger.Warn("debug transcription log error", zap.Error(err))
+	})
+
+	// Save transcription usage periodically and on shutdown
+	if app.usageLedger != nil {
+		go app.usageLedger.Run(ctx, usageSaveInterval, func(err error) {
+			app.zapLogger.Warn("failed to save transcription usage", zap.Error(err))
+		})
+	}
+
+	// Audit the system clock against NTP time so cue timestamps can be trusted
+	if app.clockMonitor != nil {
+		go app.runClockChecks(ctx, time.Duration(app.config.GetNTPCheckIntervalSec())*time.Second)
+	}
+
+	// Send summary digests on their schedule, separately from real-time notifications
+	if app.notifier != nil {
+		go app.notifier.RunDigest(ctx, app.isLeader)
+	}
+
+	// Export to object storage nightly; only the leader uploads when instances share outputs
+	if app.archive != nil {
+		go app.archive.exporter.Run(ctx, app.archive.schedule.Next, app.isLeader)
+	}
+
+	// Pick up changes to the remote program schedule
+	if url := app.config.GetScheduleURL(); url != "" && app.programs != nil {
+		go app.programs.RunRefresh(ctx, url, time.Duration(app.config.GetScheduleRefreshIntervalSec())*time.Second, app.zapLogger)
+	}
+
+	// Hot-reload the substitution file so ASR corrections apply without a restart
+	if path := app.config.GetSubstitutionFile(); path != "" && app.substitutions != nil {
+		go parser.WatchSubstitutionFile(ctx, path, app.config.GetSubstitutions(), app.substitutions,
+			time.Duration(app.config.GetSubstitutionReloadIntervalSec())*time.Second, app.zapLogger)
+	}
+
+	app.zapLogger.Info("audio processing pipeline started successfully",
+		zap.Bool("debug_mode", app.config.GetDebugMode()))
+	return nil
+}
+
+// startTranscriptionStages connects to the stream, starts FFmpeg on it, and starts transcribing
+// the decoded audio, each supervised so runtime failures are restarted
+func (app *Application) startTranscriptionStages(ctx context.Context) (<-chan transcriber.TranscriptionSegment, error) {
 	// Connect to audio stream with automatic retry and exponential backoff
-	if err := app.streamConnector.ConnectWithRetry(ctx); err != nil {
+	if err := app.startComponent(ctx, app.streamConnector); err != nil {
+		app.updateStreamHealth(false)
+		return nil, fmt.Errorf("failed to connect to stream after retries: %w", err)
+	}
+
+	// Fail fast on a stream FFmpeg cannot decode instead of transcribing garbage
+	if err := app.validateStreamFormat(); err != nil {
 		app.updateStreamHealth(false)
-		return fmt.Errorf("failed to connect to stream after retries: %w", err)
+		app.streamConnector.Close()
+		return nil, err
 	}
 
 	app.updateStreamHealth(true)
@@ -187,13 +646,41 @@ func (app *Application) startPipeline(ctx context.Context) error {
 		app.zapLogger.Info("stream connection established successfully")
 	}
 
+	// Switch back to the primary stream URL once it recovers after a failover
+	go app.streamConnector.MonitorPrimary(ctx, time.Duration(app.config.GetStreamPrimaryProbeIntervalSec())*time.Second)
+
+	// Compare relay streams with this one so duplicates get cues without a second pipeline
+	if app.relays != nil {
+		go app.relays.Run(ctx, time.Duration(app.config.GetStreamRelayCheckIntervalSec())*time.Second)
+	}
+
+	// Health check the transcription backend and fail over between binary, service, and API at runtime
+	go app.transcriptionEngine.MonitorBackends(ctx, time.Duration(app.config.GetWhisperHealthCheckIntervalSec())*time.Second)
+
+	// Supervise the stream so runtime disconnects are reconnected according to the restart policy
+	streamReader := &supervisedReader{
+		ctx:        ctx,
+		component:  ComponentStream,
+		supervisor: app.supervisor,
+		source:     func() io.Reader { return app.streamConnector },
+		restart:    app.restartStream,
+	}
+
+	// Start copying decoded PCM to external consumers before the first FFmpeg process produces any
+	if app.pcmTee != nil {
+		if err := app.startComponent(ctx, app.pcmTee); err != nil {
+			return nil, fmt.Errorf("failed to start audio tee: %w", err)
+		}
+	}
+
 	// Create audio processor with stream as input
-	app.audioProcessor = processor.NewAudioProcessor(app.streamConnector, app.zapLogger)
+	audioProcessor := app.newAudioProcessor(streamReader)
+	app.setAudioProcessor(audioProcessor)
 
 	// Start FFmpeg process
-	if err := app.audioProcessor.StartFFmpeg(ctx); err != nil {
+	if err := app.startComponent(ctx, audioProcessor); err != nil {
 		app.updateAudioProcessingHealth(false)
-		return fmt.Errorf("failed to start FFmpeg: %w", err)
+		return nil, fmt.Errorf("failed to start FFmpeg: %w", err)
 	}
 
 	app.updateAudioProcessingHealth(true)
@@ -201,26 +688,46 @@ func (app *Application) startPipeline(ctx context.Context) error {
 		app.zapLogger.Info("FFmpeg audio processor started successfully")
 	}
 
+	// Supervise FFmpeg so a crashed decoder is restarted on the same stream input
+	audioReader := &supervisedReader{
+		ctx:        ctx,
+		component:  ComponentFFmpeg,
+		supervisor: app.supervisor,
+		source:     func() io.Reader { return app.currentAudioProcessor() },
+		restart: func(ctx context.Context) error {
+			return app.restartFFmpeg(ctx, streamReader)
+		},
+	}
+
+	// Measure audio levels and, when enabled, normalize them before transcription
+	pcmReader := app.gainControl.Reader(audioReader)
+
 	// Start transcription processing - returns channel of TranscriptionSegment
-	transcriptionCh, err := app.transcriptionEngine.ProcessAudio(ctx, app.audioProcessor)
+	transcriptionCh, err := app.transcriptionEngine.ProcessAudio(ctx, pcmReader)
 	if err != nil {
-		return fmt.Errorf("failed to start transcription processing: %w", err)
+		return nil, fmt.Errorf("failed to start transcription processing: %w", err)
 	}
+	transcriptionCh = app.superviseTranscription(ctx, pcmReader, transcriptionCh)
 
 	if app.config.GetDebugMode() {
 		app.zapLogger.Info("transcription engine processing started")
 	}
+	return transcriptionCh, nil
+}
 
+// startCueStages buffers transcriptions into contexts, parses them for contest cues, and writes
+// the cues to the log output
+func (app *Application) startCueStages(ctx context.Context, transcriptionCh <-chan transcriber.TranscriptionSegment) error {
 	// Create channels for pipeline
 	bufferedContextCh := make(chan buffer.BufferedContext, 100)
 	contestCueCh := make(chan parser.ContestCue, 100)
 
-	// Wrap transcription channel for health tracking first
-	transcriptionCh = app.wrapTranscriptionChannelWithHealthTracking(transcriptionCh)
-
 	// Create and start context buffer (TranscriptionSegment -> BufferedContext)
 	contextBuffer := buffer.NewContextBuffer(app.config.GetBufferDurationMS(), transcriptionCh, bufferedContextCh)
-	if err := contextBuffer.Start(ctx); err != nil {
+	contextBuffer.SetNoSpeechThreshold(float32(app.config.GetBufferNoSpeechThreshold()))
+	contextBuffer.SetTextLimits(app.config.GetBufferMaxContextBytes(), app.config.GetBufferMaxBufferedBytes())
+	contextBuffer.SetGapThreshold(time.Duration(app.config.GetBufferGapThresholdSec()) * time.Second)
+	if err := app.startComponent(ctx, contextBuffer); err != nil {
 		return fmt.Errorf("failed to start context buffer: %w", err)
 	}
 
@@ -228,24 +735,177 @@ func (app *Application) startPipeline(ctx context.Context) error {
 		app.zapLogger.Info("context buffer started successfully")
 	}
 
+	// Correct low-confidence contexts with the LLM before they reach the parser
+	if app.corrector != nil {
+		correctedCh := make(chan buffer.BufferedContext, 100)
+		go app.corrector.Run(ctx, bufferedContextCh, correctedCh)
+		bufferedContextCh = correctedCh
+	}
+
 	// Wrap channels for health tracking AFTER they're connected to their sources
 	bufferedContextChWrapped := app.wrapBufferedContextChannelWithHealthTracking(bufferedContextCh)
 	contestCueChWrapped := app.wrapContestCueChannelWithHealthTracking(contestCueCh)
 
+	// Evaluate the candidate parser config on the same contexts, logging but never sending its cues
+	if app.parserShadow != nil {
+		if err := app.startComponent(ctx, app.parserShadow); err != nil {
+			return fmt.Errorf("failed to start shadow parser: %w", err)
+		}
+	}
+
 	// Start contest parser processing (BufferedContext -> ContestCue)
-	go app.contestParser.ProcessBufferedContextWithPatternMatching(bufferedContextChWrapped, contestCueCh)
+	app.contestParser.SetChannels(bufferedContextChWrapped, contestCueCh)
+	if err := app.startComponent(ctx, app.contestParser); err != nil {
+		return fmt.Errorf("failed to start contest parser: %w", err)
+	}
 
-	// Start log output processing (ContestCue -> file output)
-	go app.logOutput.ProcessContestCues(contestCueChWrapped)
+	// Start log output processing (ContestCue -> file output); cues are still notified without it
+	if app.config.GetLogOutputEnabled() {
+		app.logOutput.SetInput(contestCueChWrapped)
+		if err := app.startComponent(ctx, app.logOutput); err != nil {
+			return fmt.Errorf("failed to start log output: %w", err)
+		}
+	} else {
+		go drain(contestCueChWrapped)
+	}
 
-	// Start heartbeat monitoring
-	go app.startHeartbeat(ctx)
+	// Measure how far each stage's input channel is backed up
+	app.backlog.track("buffered_context", func() int { return len(bufferedContextChWrapped) }, cap(bufferedContextChWrapped))
+	app.backlog.track("contest_cue", func() int { return len(contestCueChWrapped) }, cap(contestCueChWrapped))
+	return nil
+}
 
-	app.zapLogger.Info("audio processing pipeline started successfully",
-		zap.Bool("debug_mode", app.config.GetDebugMode()))
+// setAudioProcessor replaces the active audio processor
+func (app *Application) setAudioProcessor(audioProcessor *processor.AudioProcessor) {
+	app.processorMu.Lock()
+	defer app.processorMu.Unlock()
+	app.audioProcessor = audioProcessor
+}
+
+// currentAudioProcessor returns the active audio processor
+func (app *Application) currentAudioProcessor() *processor.AudioProcessor {
+	app.processorMu.Lock()
+	defer app.processorMu.Unlock()
+	return app.audioProcessor
+}
+
+// restartStream reconnects the stream connector after a runtime failure
+func (app *Application) restartStream(ctx context.Context) error {
+	app.updateStreamHealth(false)
+	if err := app.streamConnector.Close(); err != nil {
+		app.zapLogger.Debug("error closing failed stream connection", zap.Error(err))
+	}
+
+	if err := app.streamConnector.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to reconnect stream: %w", err)
+	}
+	if err := app.validateStreamFormat(); err != nil {
+		app.streamConnector.Close()
+		return err
+	}
+
+	app.updateStreamHealth(true)
+	return nil
+}
+
+// validateStreamFormat detects the audio format at the start of the connected stream and checks
+// it against the configured expectations. A stream whose format cannot be identified is allowed
+// through with a warning; a mismatch returns a descriptive error.
+func (app *Application) validateStreamFormat() error {
+	format, err := app.streamConnector.ProbeFormat(app.config.GetStreamFormatProbeBytes())
+	if err != nil {
+		app.zapLogger.Warn("unable to probe stream format, continuing without validation",
+			zap.String("url", app.activeStreamURL()),
+			zap.Error(err))
+		return nil
+	}
+
+	app.pipelineHealth.mu.Lock()
+	app.pipelineHealth.streamFormat = format.String()
+	app.pipelineHealth.mu.Unlock()
+
+	if err := stream.ValidateFormat(format, app.config.GetStreamExpectedCodec(), app.config.GetStreamExpectedSampleRate()); err != nil {
+		app.zapLogger.Error("stream format validation failed",
+			zap.String("url", app.activeStreamURL()),
+			zap.String("detected_format", format.String()),
+			zap.String("content_type", format.ContentType),
+			zap.Error(err))
+		return fmt.Errorf("stream format validation failed: %w", err)
+	}
+
+	if format.Codec == stream.CodecUnknown {
+		app.zapLogger.Warn("could not detect stream audio format, continuing without validation",
+			zap.String("url", app.activeStreamURL()),
+			zap.String("content_type", format.ContentType))
+		return nil
+	}
+
+	app.zapLogger.Info("detected stream audio format",
+		zap.String("url", app.activeStreamURL()),
+		zap.String("format", format.String()),
+		zap.String("content_type", format.ContentType))
+	return nil
+}
+
+// restartFFmpeg replaces a failed FFmpeg process with a new one reading the same input
+func (app *Application) restartFFmpeg(ctx context.Context, input io.Reader) error {
+	app.updateAudioProcessingHealth(false)
+	if old := app.currentAudioProcessor(); old != nil {
+		if err := old.Close(); err != nil {
+			app.zapLogger.Debug("error closing failed audio processor", zap.Error(err))
+		}
+	}
+
+	audioProcessor := app.newAudioProcessor(input)
+	if err := app.startComponent(ctx, audioProcessor); err != nil {
+		return fmt.Errorf("failed to restart FFmpeg: %w", err)
+	}
+
+	app.setAudioProcessor(audioProcessor)
+	app.updateAudioProcessingHealth(true)
 	return nil
 }
 
+// superviseTranscription forwards transcription segments and restarts the transcription
+// engine according to its restart policy whenever its output ends unexpectedly
+func (app *Application) superviseTranscription(ctx context.Context, audio io.Reader, initialCh <-chan transcriber.TranscriptionSegment) <-chan transcriber.TranscriptionSegment {
+	outputCh := make(chan transcriber.TranscriptionSegment, 100)
+
+	go func() {
+		defer close(outputCh)
+		currentCh := initialCh
+
+		for {
+			for segment := range currentCh {
+				select {
+				case outputCh <- segment:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			err := app.supervisor.Restart(ctx, ComponentTranscription, func(ctx context.Context) error {
+				ch, err := app.transcriptionEngine.ProcessAudio(ctx, audio)
+				if err != nil {
+					return err
+				}
+				currentCh = ch
+				return nil
+			})
+			if err != nil {
+				app.zapLogger.Error("transcription engine will not be restarted", zap.Error(err))
+				return
+			}
+		}
+	}()
+
+	return outputCh
+}
+
 // updateStreamHealth updates the stream connection health status
 func (app *Application) updateStreamHealth(active bool) {
 	app.pipelineHealth.mu.Lock()
@@ -253,6 +913,14 @@ func (app *Application) updateStreamHealth(active bool) {
 	app.pipelineHealth.streamConnectionActive = active
 }
 
+// currentTime returns the time on the application's clock
+func (app *Application) currentTime() time.Time {
+	if app.now == nil {
+		return time.Now()
+	}
+	return app.now()
+}
+
 // updateAudioProcessingHealth updates the audio processing health status
 func (app *Application) updateAudioProcessingHealth(active bool) {
 	app.pipelineHealth.mu.Lock()
@@ -264,7 +932,7 @@ func (app *Application) updateAudioProcessingHealth(active bool) {
 func (app *Application) updateTranscriptionHealth() {
 	app.pipelineHealth.mu.Lock()
 	defer app.pipelineHealth.mu.Unlock()
-	app.pipelineHealth.lastTranscriptionTime = time.Now()
+	app.pipelineHealth.lastTranscriptionTime = app.currentTime()
 	app.pipelineHealth.transcriptionActive = true
 	app.pipelineHealth.totalTranscriptions++
 }
@@ -274,17 +942,8 @@ func (app *Application) updateTranscriptionPerformance(segment transcriber.Trans
 	app.pipelineHealth.mu.Lock()
 	defer app.pipelineHealth.mu.Unlock()
 
-	// Calculate processing latency
-	processingLatency := time.Since(processingStartTime)
-	latencyMS := float64(processingLatency.Milliseconds())
-
-	// Update moving average latency (simple exponential moving average)
-	alpha := 0.1 // Weight for new value
-	if app.pipelineHealth.averageLatencyMS == 0 {
-		app.pipelineHealth.averageLatencyMS = latencyMS
-	} else {
-		app.pipelineHealth.averageLatencyMS = alpha*latencyMS + (1-alpha)*app.pipelineHealth.averageLatencyMS
-	}
+	// Record processing latency for percentiles
+	app.pipelineHealth.transcriptionLatency.Record(app.currentTime().Sub(processingStartTime))
 
 	// Track total audio duration processed
 	audioDurationMS := int64(segment.EndMS - segment.StartMS)
@@ -294,7 +953,7 @@ func (app *Application) updateTranscriptionPerformance(segment transcriber.Trans
 	if app.pipelineHealth.processingStartTime.IsZero() {
 		app.pipelineHealth.processingStartTime = processingStartTime
 	}
-	totalProcessingTime := time.Since(app.pipelineHealth.processingStartTime)
+	totalProcessingTime := app.currentTime().Sub(app.pipelineHealth.processingStartTime)
 	totalAudioDuration := time.Duration(app.pipelineHealth.totalAudioDurationMS) * time.Millisecond
 
 	// We're real-time if we're processing audio faster than it's generated
@@ -305,14 +964,29 @@ func (app *Application) updateTranscriptionPerformance(segment transcriber.Trans
 func (app *Application) updateBufferedContextHealth() {
 	app.pipelineHealth.mu.Lock()
 	defer app.pipelineHealth.mu.Unlock()
-	app.pipelineHealth.lastBufferedContextTime = time.Now()
+	app.pipelineHealth.lastBufferedContextTime = app.currentTime()
+}
+
+// recordTruncatedContext counts a buffered context whose text was cut to the buffer limits
+func (app *Application) recordTruncatedContext() {
+	app.pipelineHealth.mu.Lock()
+	defer app.pipelineHealth.mu.Unlock()
+	app.pipelineHealth.truncatedContexts++
+}
+
+// recordTranscriptionGap counts a gap in the buffered context stream
+func (app *Application) recordTranscriptionGap(gap time.Duration) {
+	app.pipelineHealth.mu.Lock()
+	defer app.pipelineHealth.mu.Unlock()
+	app.pipelineHealth.transcriptionGaps++
+	app.pipelineHealth.lastTranscriptionGap = gap
 }
 
 // updateContestCueHealth updates contest cue detection metrics
 func (app *Application) updateContestCueHealth() {
 	app.pipelineHealth.mu.Lock()
 	defer app.pipelineHealth.mu.Unlock()
-	app.pipelineHealth.lastContestCueTime = time.Now()
+	app.pipelineHealth.lastContestCueTime = app.currentTime()
 	app.pipelineHealth.totalContestCues++
 }
 
@@ -321,13 +995,18 @@ func (app *Application) getPipelineHealthStatus() map[string]interface{} {
 	app.pipelineHealth.mu.RLock()
 	defer app.pipelineHealth.mu.RUnlock()
 
-	now := time.Now()
+	now := app.currentTime()
 	timeSinceLastTranscription := now.Sub(app.pipelineHealth.lastTranscriptionTime)
 	timeSinceLastBufferedContext := now.Sub(app.pipelineHealth.lastBufferedContextTime)
 	timeSinceLastContestCue := now.Sub(app.pipelineHealth.lastContestCueTime)
 
-	// Consider pipeline unhealthy if no transcription for more than 2 minutes
-	transcriptionHealthy := timeSinceLastTranscription < 2*time.Minute || app.pipelineHealth.lastTranscriptionTime.IsZero()
+	// Consider pipeline unhealthy if no transcription for more than 2 minutes, not counting a pause
+	// or idle mode, which skips silent audio
+	paused := !app.pipelineHealth.pausedAt.IsZero()
+	idle := app.idleStatus()
+	// Replayed transcriptions stop at the end of the replay file, so there is nothing to judge
+	transcriptionHealthy := timeSinceLastTranscription < 2*time.Minute || app.pipelineHealth.lastTranscriptionTime.IsZero() ||
+		paused || idle.Idle || now.Sub(app.pipelineHealth.resumedAt) < 2*time.Minute || !app.config.GetTranscriptionEnabled()
 
 	// Calculate real-time performance ratio
 	var realTimeRatio float64
@@ -339,8 +1018,17 @@ func (app *Application) getPipelineHealthStatus() map[string]interface{} {
 		}
 	}
 
-	return map[string]interface{}{
+	streamStats := app.streamConnector.ListeningStats()
+	audioLevels := app.gainControl.Levels()
+	transcriptionLatency := app.pipelineHealth.transcriptionLatency.Snapshot()
+
+	status := map[string]interface{}{
 		"stream_connected":              app.pipelineHealth.streamConnectionActive,
+		"active_stream_url":             app.activeStreamURL(),
+		"stream_format":                 app.pipelineHealth.streamFormat,
+		"transcription_backend":         app.transcriptionBackend(),
+		"transcription_chunk_sec":       app.transcriptionChunkDurationSec(),
+		"coordination_role":             app.coordinationRole(),
 		"audio_processing_active":       app.pipelineHealth.audioProcessingActive,
 		"transcription_active":          app.pipelineHealth.transcriptionActive,
 		"transcription_healthy":         transcriptionHealthy,
@@ -352,27 +1040,152 @@ func (app *Application) getPipelineHealthStatus() map[string]interface{} {
 		"time_since_last_cue":           timeSinceLastContestCue.String(),
 		"total_transcriptions":          app.pipelineHealth.totalTranscriptions,
 		"total_contest_cues":            app.pipelineHealth.totalContestCues,
+		"truncated_contexts":            app.pipelineHealth.truncatedContexts,
+		"transcription_gaps":            app.pipelineHealth.transcriptionGaps,
+		"last_transcription_gap_sec":    int64(app.pipelineHealth.lastTranscriptionGap.Seconds()),
 
 		// Performance metrics to track "falling behind"
-		"average_latency_ms":      app.pipelineHealth.averageLatencyMS,
+		"average_latency_ms":      float64(transcriptionLatency.Mean.Milliseconds()),
+		"transcription_latency":   latencyStatus(transcriptionLatency),
+		"cue_latency":             app.cueLatencyStatus(),
 		"total_audio_duration_ms": app.pipelineHealth.totalAudioDurationMS,
 		"is_real_time":            app.pipelineHealth.isRealTime,
 		"real_time_ratio":         realTimeRatio, // >1.0 means we're keeping up, <1.0 means falling behind
 		"current_backlog_size":    app.pipelineHealth.currentBacklogSize,
+		"channel_depths":          app.pipelineHealth.channelDepths,
+
+		// Stream reliability since start, for post-incident reports
+		"stream_connected_time_sec": int64(streamStats.ConnectedTime.Seconds()),
+		"stream_disconnects":        streamStats.Disconnects,
+		"stream_longest_outage_sec": int64(streamStats.LongestOutage.Seconds()),
+		"stream_bytes_received":     streamStats.BytesReceived,
+
+		// Audio levels before and after automatic gain control
+		"agc_enabled":             app.gainControl.Enabled(),
+		"audio_input_level_dbfs":  math.Round(audioLevels.InputDBFS*10) / 10,
+		"audio_output_level_dbfs": math.Round(audioLevels.OutputDBFS*10) / 10,
+		"agc_gain_db":             math.Round(audioLevels.GainDB*10) / 10,
+	}
+
+	// Transcription output rate anomaly tracking - an anomalous rate degrades but does not fail health
+	degraded := false
+	if app.rateDetector != nil {
+		rateStatus := app.rateDetector.Status()
+		degraded = rateStatus.Degraded()
+		status["transcription_rate_state"] = string(rateStatus.State)
+		status["transcription_rate_wpm"] = rateStatus.CurrentWPM
+		status["baseline_rate_wpm"] = rateStatus.BaselineWPM
+	}
+	if app.parserShadow != nil {
+		status["parser_shadow"] = app.parserShadow.shadowStatus()
+	}
+	if app.failureBudget != nil {
+		status["failure_budget"] = failureBudgetStatus(app.failureBudget.Status())
+	}
+	// Clock drift makes cue timestamps unreliable, which also degrades health
+	if app.addClockStatus(status) {
+		degraded = true
+	}
+	// Cues held back by a failing log sink have not reached their destination yet
+	if app.logOutput != nil {
+		delivery := app.logOutput.DeliveryStatus()
+		status["log_delivery"] = delivery
+		if delivery.Degraded() {
+			degraded = true
+		}
 	}
+	status["degraded"] = degraded
+
+	if app.supervisor != nil {
+		status["component_restarts"] = app.supervisor.RestartCounts()
+	}
+	status["components"] = app.components.health()
+	if app.relays != nil {
+		status["stream_relays"] = app.relays.States()
+	}
+	app.addProgramStatus(status)
+	app.addReconnectStatus(status)
+	if disabled := app.disabledStages(); len(disabled) > 0 {
+		status["disabled_stages"] = disabled
+	}
+	status["paused"] = paused
+	status["standby"] = app.Standby()
+	if paused {
+		status["paused_since"] = app.pipelineHealth.pausedAt.Format(time.RFC3339)
+	}
+	if app.transcriptionEngine != nil {
+		status["paused_chunks_skipped"] = app.transcriptionEngine.SkippedChunks()
+		if download, ok := app.transcriptionEngine.ModelDownloadProgress(); ok {
+			status["model_download"] = download
+		}
+	}
+	if app.corrector != nil {
+		status["correction"] = app.corrector.Status()
+	}
+	if app.adBreaks != nil {
+		status["ad_break"] = app.adBreaks.Status()
+	}
+	if app.usageLedger != nil {
+		status["transcription_usage"] = app.transcriptionUsage()
+	}
+	if tenant := app.config.GetTenantName(); tenant != "" {
+		status["tenant"] = tenant
+	}
+	if flags := app.features.States(); len(flags) > 0 {
+		status["feature_flags"] = flags
+	}
+	if app.transcriptionEngine != nil && app.transcriptionEngine.IdleMode() != nil {
+		status["idle_mode"] = idleModeStatus(idle)
+	}
+	if app.pcmTee != nil {
+		status["audio_tee"] = teeStatus(app.pcmTee.Stats())
+	}
+	versions := app.pipelineHealth.versions
+	if versions.Version == "" {
+		versions = version.Get()
+	}
+	status["version"] = versions.Version
+	status["commit"] = versions.Commit
+	status["build_date"] = versions.BuildDate
+	if versions.WhisperCpp != "" {
+		status["whisper_cpp_version"] = versions.WhisperCpp
+	}
+	if versions.FFmpeg != "" {
+		status["ffmpeg_version"] = versions.FFmpeg
+	}
+	if app.notifier != nil {
+		status["notifications_queued"] = app.notifier.QueuedDeliveries()
+		status["notifications_suppressed"] = app.notifier.SuppressedNotifications()
+		status["quiet_hours_active"] = app.notifier.InQuietHours()
+		status["maintenance_mode"] = app.notifier.Maintenance()
+	}
+
+	return status
 }
 
-// writeHealthStatusFile writes the current health status to a file for Docker health checks
-func (app *Application) writeHealthStatusFile() error {
+// healthSnapshot returns the health status as written to the health file
+func (app *Application) healthSnapshot() map[string]interface{} {
 	healthStatus := app.getPipelineHealthStatus()
 
 	// Add timestamp for health check validation
-	healthStatus["health_check_timestamp"] = time.Now().Format(time.RFC3339)
+	healthStatus["health_check_timestamp"] = app.currentTime().Format(time.RFC3339)
 	healthStatus["healthy"] = app.isSystemHealthy(healthStatus)
+	healthStatus["status"] = health.State(healthStatus)
+	healthStatus["pid"] = os.Getpid() // Lets the pause and resume commands signal this process
+	return healthStatus
+}
 
-	// Write to health status file
-	healthFile := "/tmp/radiocontestwinner-health.json"
+// writeHealthStatusFile writes the current health status to a file for Docker health checks
+func (app *Application) writeHealthStatusFile() error {
+	healthFile := app.healthFile
+	if healthFile == "" {
+		healthFile = health.DefaultFile
+	}
+	return writeHealthFile(healthFile, app.healthSnapshot())
+}
 
+// writeHealthFile atomically writes a health status to healthFile
+func writeHealthFile(healthFile string, healthStatus map[string]interface{}) error {
 	// Create directory if it doesn't exist
 	dir := filepath.Dir(healthFile)
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -400,49 +1213,237 @@ func (app *Application) writeHealthStatusFile() error {
 
 // isSystemHealthy determines overall system health based on pipeline status
 func (app *Application) isSystemHealthy(healthStatus map[string]interface{}) bool {
-	// System is healthy if:
-	// 1. Stream is connected OR we haven't started processing yet
-	// 2. If transcription has started, it should be healthy
-	// 3. No critical component failures
+	return health.PipelineHealthy(healthStatus)
+}
+
+// streamURLs returns the sources to connect to: the configured sound card or SDR station when
+// one is set, otherwise the stream URLs in failover order
+func streamURLs(cfg *config.Configuration) []string {
+	name := cfg.GetStreamDeviceName()
+	if name == "" {
+		if frequency := cfg.GetStreamSDRFrequency(); frequency != "" {
+			sdr := stream.SDRSource{
+				Frequency:   frequency,
+				Modulation:  cfg.GetStreamSDRModulation(),
+				Gain:        cfg.GetStreamSDRGain(),
+				SampleRate:  cfg.GetStreamSDRSampleRate(),
+				DeviceIndex: cfg.GetStreamSDRDeviceIndex(),
+				PPM:         cfg.GetStreamSDRPPM(),
+			}
+			return []string{sdr.URL()}
+		}
+		return cfg.GetStreamURLs()
+	}
+	driver := cfg.GetStreamDeviceDriver()
+	if driver == "" {
+		driver = stream.DefaultDeviceDriver()
+	}
+	device := stream.DeviceSource{
+		Driver:     driver,
+		Name:       name,
+		SampleRate: cfg.GetStreamDeviceSampleRate(),
+		Channels:   cfg.GetStreamDeviceChannels(),
+	}
+	return []string{device.URL()}
+}
 
-	streamConnected := healthStatus["stream_connected"].(bool)
-	transcriptionHealthy := healthStatus["transcription_healthy"].(bool)
-	totalTranscriptions := healthStatus["total_transcriptions"].(int64)
+// DisplayLocation returns the time zone human-facing output shows times in, or nil when none is configured
+func (app *Application) DisplayLocation() *time.Location {
+	return app.displayLocation
+}
+
+// fanOutCue returns the cue followed by a copy for each relay duplicating the monitored stream
+func (app *Application) fanOutCue(cue parser.ContestCue) []parser.ContestCue {
+	if station := app.config.GetStationMetadata(); !station.IsZero() {
+		cue = withStationMetadata(cue, station)
+	}
+	if tenant := app.config.GetTenantName(); tenant != "" {
+		cue.Details = cue.Details.Clone()
+		cue.Details.Set("tenant", tenant)
+	}
+	if app.relays == nil {
+		return []parser.ContestCue{cue}
+	}
+	return app.relays.fanOut(cue, app.config.GetStreamLabel(), time.Now())
+}
 
-	// If we have started transcribing, transcription must be healthy
-	if totalTranscriptions > 0 && !transcriptionHealthy {
-		return false
+// activeStreamURL returns the stream URL currently in use, which changes after a failover
+func (app *Application) activeStreamURL() string {
+	if app.streamConnector == nil {
+		return ""
 	}
+	return app.streamConnector.ActiveURL()
+}
 
-	// If audio processing started, stream should be connected
-	audioProcessingActive := healthStatus["audio_processing_active"].(bool)
-	if audioProcessingActive && !streamConnected {
-		return false
+// transcriptionBackend returns the transcription backend currently in use
+func (app *Application) transcriptionBackend() string {
+	if app.transcriptionEngine == nil {
+		return ""
 	}
+	return app.transcriptionEngine.ActiveBackend()
+}
+
+// detectVersions logs the build metadata and the versions of the whisper.cpp and FFmpeg
+// binaries in use, and keeps them for the health status
+func (app *Application) detectVersions(ctx context.Context) {
+	info := version.Detect(ctx, app.transcriptionEngine.WhisperBinary(), "ffmpeg")
+	app.zapLogger.Info("build and runtime versions",
+		zap.String("version", info.Version),
+		zap.String("commit", info.Commit),
+		zap.String("build_date", info.BuildDate),
+		zap.String("go_version", info.GoVersion),
+		zap.String("whisper_cpp_version", info.WhisperCpp),
+		zap.String("ffmpeg_version", info.FFmpeg))
 
-	// Overall system is healthy
-	return true
+	app.pipelineHealth.mu.Lock()
+	app.pipelineHealth.versions = info
+	app.pipelineHealth.mu.Unlock()
 }
 
-// writeTranscriptionToDebugFile writes transcriptions to a debug file in debug mode
-func (app *Application) writeTranscriptionToDebugFile(segment transcriber.TranscriptionSegment) {
-	// Create debug transcription log file path
-	debugLogPath := "/app/logs/transcriptions_debug.log"
+// transcriptionChunkDurationSec returns the transcription chunk duration in use, which changes when auto-tuned
+func (app *Application) transcriptionChunkDurationSec() int {
+	if app.transcriptionEngine == nil {
+		return app.config.GetTranscriptionChunkDurationSec()
+	}
+	return app.transcriptionEngine.ChunkDurationSec()
+}
 
-	// Create directory if it doesn't exist
-	dir := filepath.Dir(debugLogPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		app.zapLogger.Error("failed to create debug log directory", zap.Error(err))
+// isLeader reports whether this instance should send notifications; without coordination it always does
+func (app *Application) isLeader() bool {
+	return app.elector == nil || app.elector.IsLeader()
+}
+
+// coordinationRole returns "leader" or "follower", or "standalone" when coordination is disabled
+func (app *Application) coordinationRole() string {
+	if app.elector == nil {
+		return "standalone"
+	}
+	return app.elector.Role()
+}
+
+// checkTranscriptionRate evaluates the transcription output rate against its baseline
+// and notifies when the rate becomes anomalous or recovers
+func (app *Application) checkTranscriptionRate(now time.Time) {
+	if app.rateDetector == nil || app.Paused() {
+		return
+	}
+
+	rateStatus, changed := app.rateDetector.Evaluate(now)
+	if !changed {
+		return
+	}
+
+	fields := map[string]interface{}{
+		"state":        string(rateStatus.State),
+		"current_wpm":  rateStatus.CurrentWPM,
+		"baseline_wpm": rateStatus.BaselineWPM,
+		"ratio":        rateStatus.Ratio,
+	}
+
+	switch {
+	case rateStatus.Degraded():
+		app.zapLogger.Warn("⚠️ TRANSCRIPTION RATE ANOMALY: output rate deviates from baseline",
+			zap.String("state", string(rateStatus.State)),
+			zap.Float64("current_wpm", rateStatus.CurrentWPM),
+			zap.Float64("baseline_wpm", rateStatus.BaselineWPM),
+			zap.Float64("ratio", rateStatus.Ratio))
+		app.dispatchNotification(notifier.NewAlertNotification(notifier.SeverityWarning,
+			"Transcription rate anomaly",
+			fmt.Sprintf("Transcription output %s: %.0f wpm vs baseline %.0f wpm (possible audio corruption or model malfunction)",
+				rateStatus.State, rateStatus.CurrentWPM, rateStatus.BaselineWPM),
+			fields))
+	case rateStatus.State == anomaly.RateStateNormal:
+		app.zapLogger.Info("transcription rate within normal range",
+			zap.Float64("current_wpm", rateStatus.CurrentWPM),
+			zap.Float64("baseline_wpm", rateStatus.BaselineWPM))
+		app.dispatchNotification(notifier.NewAlertNotification(notifier.SeverityInfo,
+			"Transcription rate recovered",
+			fmt.Sprintf("Transcription output back to normal: %.0f wpm vs baseline %.0f wpm",
+				rateStatus.CurrentWPM, rateStatus.BaselineWPM),
+			fields))
+	}
+}
+
+// dispatchNotification delivers a notification in the background so slow notifiers never block the pipeline
+func (app *Application) dispatchNotification(notification notifier.Notification) {
+	if app.notifier == nil || !app.notifier.Enabled() {
+		return
+	}
+	if !app.isLeader() {
+		app.zapLogger.Debug("follower instance, skipping notification",
+			zap.String("kind", string(notification.Kind)),
+			zap.String("title", notification.Title))
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		// Failures are already logged per notifier by the dispatcher
+		_ = app.notifier.Dispatch(ctx, notification)
+	}()
+}
+
+// storeTranscript appends the segment to the shared recent transcript history in the background
+func (app *Application) storeTranscript(segment transcriber.TranscriptionSegment) {
+	if app.transcripts == nil {
+		return
+	}
+
+	go func() {
+		payload, err := json.Marshal(segment)
+		if err != nil {
+			app.zapLogger.Warn("failed to marshal transcript for history", zap.Error(err))
+			return
+		}
+		if err := app.transcripts.Push(string(payload)); err != nil {
+			app.zapLogger.Warn("failed to store transcript history", zap.Error(err))
+		}
+	}()
+}
+
+// dispatchStatus publishes the current pipeline status to status-aware notifiers in the background
+func (app *Application) dispatchStatus(healthStatus map[string]interface{}) {
+	if app.notifier == nil || !app.notifier.Enabled() || !app.isLeader() {
+		return
+	}
+
+	streamConnected, _ := healthStatus["stream_connected"].(bool)
+	state := health.State(healthStatus)
+	status := notifier.Status{
+		StreamConnected: streamConnected,
+		PipelineHealthy: state == "healthy",
+		State:           state,
+		Timestamp:       time.Now().UTC().Format(time.RFC3339),
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		// Failures are already logged per notifier by the dispatcher
+		_ = app.notifier.DispatchStatus(ctx, status)
+	}()
+}
+
+// writeTranscriptionToDebugFile writes a transcription to the debug transcripts file
+func (app *Application) writeTranscriptionToDebugFile(segment transcriber.TranscriptionSegment) {
+	if app.debugTranscripts == nil {
 		return
 	}
 
 	// Format transcription as JSON with timestamp
 	transcriptionData := map[string]interface{}{
-		"timestamp":  time.Now().Format(time.RFC3339),
-		"text":       segment.Text,
-		"start_ms":   segment.StartMS,
-		"end_ms":     segment.EndMS,
-		"confidence": segment.Confidence,
+		"timestamp":      time.Now().Format(time.RFC3339),
+		"text":           segment.Text,
+		"start_ms":       segment.StartMS,
+		"end_ms":         segment.EndMS,
+		"confidence":     segment.Confidence,
+		"no_speech_prob": segment.NoSpeechProb,
+		"language":       segment.Language,
+		"language_prob":  segment.LanguageProb,
+	}
+	if len(segment.Station) > 0 {
+		transcriptionData["station"] = segment.Station
 	}
 
 	jsonData, err := json.Marshal(transcriptionData)
@@ -451,15 +1452,8 @@ func (app *Application) writeTranscriptionToDebugFile(segment transcriber.Transc
 		return
 	}
 
-	// Append to debug log file
-	file, err := os.OpenFile(debugLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		app.zapLogger.Error("failed to open debug transcription log", zap.Error(err))
-		return
-	}
-	defer file.Close()
-
-	if _, err := file.Write(append(jsonData, '\n')); err != nil {
+	// Buffered, sampled, and rotated so long debug sessions don't exhaust disk or IOPS
+	if _, err := app.debugTranscripts.Write(jsonData); err != nil {
 		app.zapLogger.Error("failed to write to debug transcription log", zap.Error(err))
 	}
 }
@@ -474,75 +1468,100 @@ func (app *Application) startHeartbeat(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			// Enhanced heartbeat with actual pipeline health status
-			healthStatus := app.getPipelineHealthStatus()
+			app.heartbeat()
+		}
+	}
+}
 
-			// Write health status file for Docker health checks
-			if err := app.writeHealthStatusFile(); err != nil {
-				app.zapLogger.Error("failed to write health status file", zap.Error(err))
-			}
+// heartbeat runs one heartbeat: it checks the pipeline's health, writes the health file, and
+// publishes and logs the status
+func (app *Application) heartbeat() {
+	now := app.currentTime()
 
-			if app.config.GetDebugMode() {
-				app.zapLogger.Info("pipeline heartbeat with health status",
-					zap.String("timestamp", time.Now().Format(time.RFC3339)),
-					zap.String("stream_url", app.config.GetStreamURL()),
-					zap.Any("health_status", healthStatus))
-			}
+	// Evaluate transcription output rate before reporting health
+	app.checkTranscriptionRate(now)
 
-			// Log warnings for potential issues
-			if !healthStatus["transcription_healthy"].(bool) && healthStatus["total_transcriptions"].(int64) > 0 {
-				app.zapLogger.Warn("transcription pipeline may be unhealthy",
-					zap.String("last_transcription", healthStatus["last_transcription_time"].(string)),
-					zap.String("time_since_last", healthStatus["time_since_last_transcription"].(string)))
-			}
+	// Enhanced heartbeat with actual pipeline health status
+	healthStatus := app.getPipelineHealthStatus()
 
-			if !healthStatus["stream_connected"].(bool) {
-				app.zapLogger.Warn("stream connection inactive")
-			}
+	// Escalate sustained failures and tally the daily failure budget
+	app.checkFailureBudget(now, healthStatus)
 
-			if !healthStatus["audio_processing_active"].(bool) {
-				app.zapLogger.Warn("audio processing inactive")
-			}
+	// Warn about stale allowlist entries once a day
+	app.checkAllowlistExpiry(now)
 
-			// Log performance warnings for "falling behind"
-			realTimeRatio, hasRatio := healthStatus["real_time_ratio"].(float64)
-			if hasRatio && realTimeRatio > 0 {
-				if realTimeRatio < 0.8 { // Processing less than 80% of real-time
-					app.zapLogger.Warn("⚠️ FALLING BEHIND: Processing slower than real-time",
-						zap.Float64("real_time_ratio", realTimeRatio),
-						zap.Float64("average_latency_ms", healthStatus["average_latency_ms"].(float64)),
-						zap.Bool("is_real_time", healthStatus["is_real_time"].(bool)))
-				}
-			}
+	// Write health status file for Docker health checks
+	if err := app.writeHealthStatusFile(); err != nil {
+		app.zapLogger.Error("failed to write health status file", zap.Error(err))
+	}
 
-			avgLatency, hasLatency := healthStatus["average_latency_ms"].(float64)
-			if hasLatency && avgLatency > 10000 { // More than 10 seconds average latency
-				app.zapLogger.Warn("⚠️ HIGH LATENCY: Transcription processing is very slow",
-					zap.Float64("average_latency_ms", avgLatency))
-			}
+	// Publish live status to notifiers such as MQTT / Home Assistant
+	app.dispatchStatus(healthStatus)
+
+	if app.config.GetDebugMode() {
+		app.zapLogger.Info("pipeline heartbeat with health status",
+			zap.String("timestamp", now.Format(time.RFC3339)),
+			zap.String("stream_url", app.config.GetStreamURL()),
+			zap.Any("health_status", healthStatus))
+	}
+
+	// Log warnings for potential issues
+	if !healthStatus["transcription_healthy"].(bool) && healthStatus["total_transcriptions"].(int64) > 0 {
+		app.zapLogger.Warn("transcription pipeline may be unhealthy",
+			zap.String("last_transcription", healthStatus["last_transcription_time"].(string)),
+			zap.String("time_since_last", healthStatus["time_since_last_transcription"].(string)))
+	}
+
+	if !healthStatus["stream_connected"].(bool) {
+		app.zapLogger.Warn("stream connection inactive")
+	}
+
+	if !healthStatus["audio_processing_active"].(bool) {
+		app.zapLogger.Warn("audio processing inactive")
+	}
+
+	// Log performance warnings for "falling behind"
+	realTimeRatio, hasRatio := healthStatus["real_time_ratio"].(float64)
+	if hasRatio && realTimeRatio > 0 {
+		if realTimeRatio < 0.8 { // Processing less than 80% of real-time
+			app.zapLogger.Warn("⚠️ FALLING BEHIND: Processing slower than real-time",
+				zap.Float64("real_time_ratio", realTimeRatio),
+				zap.Float64("average_latency_ms", healthStatus["average_latency_ms"].(float64)),
+				zap.Bool("is_real_time", healthStatus["is_real_time"].(bool)))
 		}
 	}
+
+	p95Latency := app.pipelineHealth.transcriptionLatency.Percentile(95)
+	if p95Latency > 10*time.Second { // One transcription in twenty takes more than 10 seconds
+		app.zapLogger.Warn("⚠️ HIGH LATENCY: Transcription processing is very slow",
+			zap.Int64("p95_latency_ms", p95Latency.Milliseconds()))
+	}
+
+	// Track end-to-end cue latency against the SLO
+	app.checkLatencySLO()
 }
 
 // Shutdown gracefully stops all components in reverse order
 func (app *Application) Shutdown() error {
 	app.zapLogger.Info("shutting down application components")
 
-	// Close transcription engine
-	if err := app.transcriptionEngine.Close(); err != nil {
-		app.zapLogger.Error("error closing transcription engine", zap.Error(err))
-	}
+	// Stop the pipeline components, from the log output back to the stream
+	ctx, cancel := context.WithTimeout(context.Background(), componentStopTimeout)
+	defer cancel()
+	app.components.stopAll(ctx, app.zapLogger)
 
-	// Close audio processor
-	if app.audioProcessor != nil {
-		if err := app.audioProcessor.Close(); err != nil {
-			app.zapLogger.Error("error closing audio processor", zap.Error(err))
+	// Close notifiers holding broker connections
+	if app.notifier != nil {
+		if err := app.notifier.Close(); err != nil {
+			app.zapLogger.Error("error closing notifiers", zap.Error(err))
 		}
 	}
 
-	// Close stream connector
-	if err := app.streamConnector.Close(); err != nil {
-		app.zapLogger.Error("error closing stream connector", zap.Error(err))
+	// Close the Redis connection shared by deduplication and transcript history
+	if app.redisClient != nil {
+		if err := app.redisClient.Close(); err != nil {
+			app.zapLogger.Error("error closing redis client", zap.Error(err))
+		}
 	}
 
 	app.zapLogger.Info("application shutdown completed")
@@ -557,25 +1576,45 @@ func (app *Application) wrapTranscriptionChannelWithHealthTracking(originalCh <-
 		defer close(healthCh)
 		for segment := range originalCh {
 			// Track when we receive this segment for performance monitoring
-			receiveTime := time.Now()
+			receiveTime := app.currentTime()
 
 			// Update transcription health tracking
 			app.updateTranscriptionHealth()
+			if app.rateDetector != nil {
+				app.rateDetector.Record(segment.Text, receiveTime)
+			}
 
 			// Update performance metrics (estimate processing started 5 seconds ago based on audio chunk duration)
 			processingStartTime := receiveTime.Add(-time.Duration(segment.EndMS-segment.StartMS) * time.Millisecond)
 			app.updateTranscriptionPerformance(segment, processingStartTime)
 
+			// Tag the transcription with the station it was heard on
+			if station := app.config.GetStationMetadata(); !station.IsZero() {
+				segment.Station = station.Fields()
+			}
+
+			// What is stored or shown is scrubbed; the parser still gets the original text
+			stored := app.scrubSegment(segment)
 			if app.config.GetDebugMode() {
 				app.zapLogger.Info("🎙️ TRANSCRIPTION RECEIVED",
-					zap.String("text", segment.Text),
+					zap.String("text", stored.Text),
 					zap.Int("start_ms", segment.StartMS),
 					zap.Int("end_ms", segment.EndMS),
-					zap.Float32("confidence", segment.Confidence))
+					zap.Float32("confidence", segment.Confidence),
+					zap.Float32("no_speech_prob", segment.NoSpeechProb),
+					zap.String("language", segment.Language))
 
-				// Also write transcription to debug log file
-				app.writeTranscriptionToDebugFile(segment)
 			}
+			// Keep every transcription in the debug file in debug mode, and when it is the
+			// deployment's only output because the parser is disabled
+			if app.config.GetDebugMode() || !app.config.GetParserEnabled() {
+				app.writeTranscriptionToDebugFile(stored)
+			}
+			app.storeTranscript(stored)
+			if app.observer != nil {
+				app.observer.OnTranscription(stored)
+			}
+			app.publishLive(api.KindTranscript, stored)
 			healthCh <- segment
 		}
 	}()
@@ -590,8 +1629,27 @@ func (app *Application) wrapBufferedContextChannelWithHealthTracking(originalCh
 	go func() {
 		defer close(healthCh)
 		for context := range originalCh {
+			// Gap markers carry no text; pass them on so the parser knows text is missing
+			if context.IsGap() {
+				app.recordTranscriptionGap(context.Gap())
+				app.zapLogger.Warn("no audio was transcribed for a while; text is missing from the transcript",
+					zap.Duration("gap", context.Gap()),
+					zap.Time("since", context.CapturedAt))
+				healthCh <- context
+				continue
+			}
+
 			// Update buffered context health tracking
 			app.updateBufferedContextHealth()
+			app.observeAdBreakText(context)
+			app.observeCalendarText(context)
+			app.parserShadow.Observe(context)
+			if context.Truncated {
+				app.recordTruncatedContext()
+				app.zapLogger.Warn("transcription text exceeded the buffer limits and was truncated",
+					zap.Int("start_ms", context.StartMS),
+					zap.Int("length", len(context.Text)))
+			}
 
 			if app.config.GetDebugMode() {
 				app.zapLogger.Info("📝 BUFFERED CONTEXT",
@@ -616,14 +1674,44 @@ func (app *Application) wrapContestCueChannelWithHealthTracking(originalCh chan
 			// Update contest cue health tracking
 			app.updateContestCueHealth()
 
+			// Drop repeats of a cue already emitted, e.g. from overlapping transcription chunks
+			if app.cueDedup != nil && app.cueDedup.IsDuplicate(cue.ContentHash) {
+				app.zapLogger.Debug("suppressing duplicate contest cue",
+					zap.String("cue_id", cue.CueID),
+					zap.Strings("trace_ids", cue.TraceIDs),
+					zap.String("content_hash", cue.ContentHash))
+				if app.notifier != nil {
+					app.notifier.NoteDuplicateCue()
+				}
+				continue
+			}
+			keep, notify := app.flagAdBreakCue(&cue)
+			if !keep {
+				continue
+			}
+			app.annotateClockDrift(&cue)
+			app.scrubCue(&cue)
+			app.recordCueLatency(cue)
+
 			if app.config.GetDebugMode() {
 				app.zapLogger.Info("🏆 CONTEST CUE DETECTED",
 					zap.String("cue_id", cue.CueID),
+					zap.Strings("trace_ids", cue.TraceIDs),
 					zap.String("contest_type", cue.ContestType),
 					zap.String("timestamp", cue.Timestamp),
 					zap.Any("details", cue.Details))
 			}
-			healthCh <- cue
+			for _, cue := range app.fanOutCue(cue) {
+				if notify {
+					app.dispatchNotification(notifier.NewCueNotification(cue))
+					app.exportCalendarCue(cue)
+				}
+				if app.observer != nil {
+					app.observer.OnContestCue(cue)
+				}
+				app.publishLive(api.KindCue, cue)
+				healthCh <- cue
+			}
 		}
 	}()
 
@@ -3,16 +3,21 @@ package app
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 
 	"radiocontestwinner/internal/buffer"
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/logger"
 	"radiocontestwinner/internal/parser"
 	"radiocontestwinner/internal/transcriber"
+	"radiocontestwinner/internal/version"
 )
 
 func TestNewApplication(t *testing.T) {
@@ -669,7 +674,7 @@ func TestApplication_ChannelWrappers(t *testing.T) {
 				CueID:       "test-cue-id",
 				ContestType: "CQ WW DX",
 				Timestamp:   time.Now().Format(time.RFC3339),
-				Details:     map[string]interface{}{"call_sign": "W1ABC", "exchange": "599 001"},
+				Details:     parser.CueDetails{Extensions: map[string]interface{}{"call_sign": "W1ABC", "exchange": "599 001"}},
 			}
 			close(originalCh)
 		}()
@@ -677,9 +682,77 @@ func TestApplication_ChannelWrappers(t *testing.T) {
 		select {
 		case cue := <-wrappedCh:
 			assert.Equal(t, "CQ WW DX", cue.ContestType)
-			assert.Equal(t, "W1ABC", cue.Details["call_sign"])
+			assert.Equal(t, "W1ABC", cue.Details.Extensions["call_sign"])
 		case <-time.After(100 * time.Millisecond):
 			t.Fatal("Timeout waiting for contest cue")
 		}
 	})
 }
+
+func TestApplication_VersionsInHealthStatus(t *testing.T) {
+	t.Run("should report the build before versions are detected", func(t *testing.T) {
+		app, err := NewApplication()
+		require.NoError(t, err)
+
+		healthStatus := app.getPipelineHealthStatus()
+
+		assert.Equal(t, version.Version, healthStatus["version"])
+		assert.NotEmpty(t, healthStatus["commit"])
+		assert.NotContains(t, healthStatus, "ffmpeg_version")
+	})
+
+	t.Run("should report the detected tool versions", func(t *testing.T) {
+		app, err := NewApplication()
+		require.NoError(t, err)
+
+		app.detectVersions(context.Background())
+
+		healthStatus := app.getPipelineHealthStatus()
+		assert.Contains(t, healthStatus, "ffmpeg_version")
+	})
+}
+
+func TestApplication_LogDeliveryInHealthStatus(t *testing.T) {
+	t.Run("should degrade health while a log sink holds undelivered cues", func(t *testing.T) {
+		// Arrange
+		app, err := NewApplication()
+		require.NoError(t, err)
+		blocker := filepath.Join(t.TempDir(), "not-a-dir")
+		require.NoError(t, os.WriteFile(blocker, nil, 0644))
+		cfg := config.NewConfiguration()
+		cfg.SetLogSinks([]config.LogSink{{Type: "file", Target: filepath.Join(blocker, "cues.log")}})
+		cfg.SetLogRetryBackoffMS(1)
+		app.logOutput, err = logger.NewLogOutput(cfg, zap.NewNop())
+		require.NoError(t, err)
+		cueCh := make(chan parser.ContestCue, 1)
+		cueCh <- *parser.NewContestCue("CASH", parser.CueDetails{Keyword: "CASH", Number: "55555"})
+		close(cueCh)
+
+		// Act
+		app.logOutput.ProcessContestCues(cueCh)
+		healthStatus := app.getPipelineHealthStatus()
+
+		// Assert
+		assert.Equal(t, true, healthStatus["degraded"])
+		delivery, ok := healthStatus["log_delivery"].(logger.DeliveryStatus)
+		require.True(t, ok)
+		require.Len(t, delivery.Sinks, 1)
+		assert.Equal(t, 1, delivery.Sinks[0].Pending)
+	})
+}
+
+func TestNewApplication_OfflineMode(t *testing.T) {
+	t.Run("should refuse to start with integrations that need the network", func(t *testing.T) {
+		// Arrange
+		t.Setenv("OFFLINE_MODE", "true")
+		t.Setenv("NOTIFIER_WEBHOOK_URL", "https://hooks.example.com/cues")
+
+		// Act
+		_, err := NewApplication()
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "offline_mode")
+		assert.Contains(t, err.Error(), "notifier webhook")
+	})
+}
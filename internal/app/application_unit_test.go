@@ -8,7 +8,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"radiocontestwinner/internal/buffer"
+	"radiocontestwinner/internal/config"
 	"radiocontestwinner/internal/parser"
+	"radiocontestwinner/internal/stream"
 	"radiocontestwinner/internal/transcriber"
 )
 
@@ -100,10 +102,11 @@ func TestApplication_TranscriptionPerformanceMetrics(t *testing.T) {
 
 		// Should update performance metrics
 		assert.Greater(t, app.pipelineHealth.totalAudioDurationMS, int64(0))
-		assert.Greater(t, app.pipelineHealth.averageLatencyMS, float64(0))
+		assert.Equal(t, int64(1), app.pipelineHealth.transcriptionLatency.Snapshot().Count)
+		assert.Greater(t, app.pipelineHealth.transcriptionLatency.Percentile(50), time.Duration(0))
 	})
 
-	t.Run("should calculate moving average latency", func(t *testing.T) {
+	t.Run("should record latency percentiles", func(t *testing.T) {
 		segment := transcriber.TranscriptionSegment{
 			Text:       "Test",
 			StartMS:    0,
@@ -111,15 +114,18 @@ func TestApplication_TranscriptionPerformanceMetrics(t *testing.T) {
 			Confidence: 0.8,
 		}
 
-		// Record multiple latencies to test averaging
+		// Record multiple latencies to test the percentiles
 		for i := 0; i < 3; i++ {
 			startTime := time.Now().Add(-time.Duration(i*10+50) * time.Millisecond)
 			app.updateTranscriptionPerformance(segment, startTime)
 		}
 
-		// Should have a reasonable average latency
-		assert.Greater(t, app.pipelineHealth.averageLatencyMS, 0.0)
-		assert.Less(t, app.pipelineHealth.averageLatencyMS, 1000.0) // Should be less than 1 second
+		// Should have reasonable latencies
+		latency := app.pipelineHealth.transcriptionLatency.Snapshot()
+		assert.Equal(t, int64(4), latency.Count)
+		assert.Greater(t, latency.Mean, time.Duration(0))
+		assert.LessOrEqual(t, latency.P50, latency.P99)
+		assert.Less(t, latency.P99, time.Second) // Should be less than 1 second
 	})
 }
 
@@ -143,6 +149,9 @@ func TestApplication_HealthStatus(t *testing.T) {
 			"total_transcriptions", "total_contest_cues", "last_transcription_time",
 			"last_buffered_context_time", "last_contest_cue_time", "average_latency_ms",
 			"total_audio_duration_ms", "current_backlog_size", "is_real_time",
+			"stream_connected_time_sec", "stream_disconnects", "stream_longest_outage_sec",
+			"stream_bytes_received", "agc_enabled", "audio_input_level_dbfs",
+			"audio_output_level_dbfs", "agc_gain_db",
 		}
 
 		for _, field := range expectedFields {
@@ -294,4 +303,41 @@ func TestApplication_HealthFileWritingFunc(t *testing.T) {
 			assert.Contains(t, err.Error(), "permission denied")
 		}
 	})
-}
\ No newline at end of file
+}
+// TestStreamURLs tests choosing between stream URLs and sound card capture
+func TestStreamURLs(t *testing.T) {
+	t.Run("should use the stream URLs when no device is configured", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetStreamURLs([]string{"https://primary.example.com/stream.aac"})
+
+		assert.Equal(t, []string{"https://primary.example.com/stream.aac"}, streamURLs(cfg))
+	})
+
+	t.Run("should capture from the configured device instead", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetStreamDeviceName("hw:1,0")
+		cfg.SetStreamDeviceDriver("alsa")
+
+		urls := streamURLs(cfg)
+
+		require.Len(t, urls, 1)
+		device, ok, err := stream.ParseDeviceURL(urls[0])
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, stream.DeviceSource{Driver: "alsa", Name: "hw:1,0", SampleRate: 48000, Channels: 2}, device)
+	})
+
+	t.Run("should receive the configured SDR station", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetStreamSDRFrequency("101.1M")
+		cfg.SetStreamSDRGain(40)
+
+		urls := streamURLs(cfg)
+
+		require.Len(t, urls, 1)
+		sdr, ok, err := stream.ParseSDRURL(urls[0])
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, stream.SDRSource{Frequency: "101.1M", Modulation: "wbfm", Gain: 40, SampleRate: 48000}, sdr)
+	})
+}
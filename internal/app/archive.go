@@ -0,0 +1,76 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/archive"
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/notifier"
+)
+
+// nightlyArchive exports transcripts, cue logs, and audio snippets to object storage each night
+type nightlyArchive struct {
+	exporter *archive.Exporter
+	schedule notifier.DigestSchedule
+}
+
+// newNightlyArchive creates the nightly archive export, or returns nil when it is disabled
+func newNightlyArchive(cfg *config.Configuration, logger *zap.Logger) (*nightlyArchive, error) {
+	if !cfg.GetArchiveEnabled() {
+		return nil, nil
+	}
+	schedule, err := notifier.ParseDigestSchedule(notifier.DigestDaily, cfg.GetArchiveDailyAt(), cfg.GetArchiveTimezone())
+	if err != nil {
+		return nil, fmt.Errorf("archive schedule: %w", err)
+	}
+	location := time.Local
+	if timezone := cfg.GetArchiveTimezone(); timezone != "" {
+		// Already validated by the schedule
+		location, _ = time.LoadLocation(timezone)
+	}
+	client, err := archive.NewS3Client(archive.S3Options{
+		Endpoint:        cfg.GetArchiveEndpoint(),
+		Region:          cfg.GetArchiveRegion(),
+		Bucket:          cfg.GetArchiveBucket(),
+		AccessKeyID:     cfg.GetArchiveAccessKeyID(),
+		SecretAccessKey: cfg.GetArchiveSecretAccessKey(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	exporter := archive.NewExporter(client, archive.Config{
+		Prefix:        cfg.GetArchivePrefix(),
+		RetentionDays: cfg.GetArchiveRetentionDays(),
+		StatePath:     cfg.GetArchiveStatePath(),
+		Location:      location,
+		Sources:       archiveSources(cfg),
+	}, logger)
+	return &nightlyArchive{exporter: exporter, schedule: schedule}, nil
+}
+
+// archiveSources returns the files the nightly export uploads: rotated debug transcript logs
+// (the live one is still being written), cue log files with their rotated copies, and saved
+// audio snippets
+func archiveSources(cfg *config.Configuration) []archive.Source {
+	var sources []archive.Source
+	if path := cfg.GetDebugTranscriptsPath(); path != "" {
+		sources = append(sources, archive.Source{Kind: archive.KindTranscripts, Globs: []string{path + ".[0-9]*"}})
+	}
+	var cueGlobs []string
+	for _, sink := range cfg.GetLogSinks() {
+		if sink.Type == "file" && sink.Target != "" {
+			cueGlobs = append(cueGlobs, sink.Target, sink.Target+".[0-9]*")
+		}
+	}
+	if len(cueGlobs) > 0 {
+		sources = append(sources, archive.Source{Kind: archive.KindCues, Globs: cueGlobs})
+	}
+	if dir := cfg.GetArchiveAudioDir(); dir != "" {
+		sources = append(sources, archive.Source{Kind: archive.KindAudio, Globs: []string{filepath.Join(dir, "*")}})
+	}
+	return sources
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/archive"
+	"radiocontestwinner/internal/config"
+)
+
+func TestNewNightlyArchive(t *testing.T) {
+	t.Run("should be nil when the archive is disabled", func(t *testing.T) {
+		nightly, err := newNightlyArchive(config.NewConfiguration(), zap.NewNop())
+
+		require.NoError(t, err)
+		assert.Nil(t, nightly)
+	})
+
+	t.Run("should fail without a bucket", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetArchiveEnabled(true)
+
+		_, err := newNightlyArchive(cfg, zap.NewNop())
+
+		assert.ErrorContains(t, err, "bucket")
+	})
+}
+
+func TestArchiveSources(t *testing.T) {
+	t.Run("should archive rotated transcripts, file cue logs, and audio snippets", func(t *testing.T) {
+		// Arrange
+		cfg := config.NewConfiguration()
+		cfg.SetDebugTranscriptsPath("/logs/transcriptions_debug.log")
+		cfg.SetLogSinks([]config.LogSink{{Type: "file", Target: "/data/cues.jsonl"}, {Type: "stdout"}})
+		cfg.SetArchiveAudioDir("/data/snippets")
+
+		// Act
+		sources := archiveSources(cfg)
+
+		// Assert
+		assert.Equal(t, []archive.Source{
+			{Kind: archive.KindTranscripts, Globs: []string{"/logs/transcriptions_debug.log.[0-9]*"}},
+			{Kind: archive.KindCues, Globs: []string{"/data/cues.jsonl", "/data/cues.jsonl.[0-9]*"}},
+			{Kind: archive.KindAudio, Globs: []string{"/data/snippets/*"}},
+		}, sources)
+	})
+}
@@ -0,0 +1,130 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// channelGauge samples the depth of one pipeline channel
+type channelGauge struct {
+	name       string
+	depth      func() int
+	capacity   int
+	aboveSince time.Time // Zero while the channel is below the watermark
+	warned     bool      // Whether the current episode above the watermark has been logged
+}
+
+// backlogMonitor samples pipeline channel depths and warns about channels that stay
+// above the high watermark, which means their consumer is not keeping up
+type backlogMonitor struct {
+	mu            sync.Mutex
+	gauges        []*channelGauge
+	watermarkPct  int
+	sustainPeriod time.Duration
+	logger        *zap.Logger
+}
+
+// newBacklogMonitor creates a backlogMonitor warning when a channel stays at or above
+// watermarkPct of its capacity for sustainPeriod
+func newBacklogMonitor(watermarkPct int, sustainPeriod time.Duration, logger *zap.Logger) *backlogMonitor {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &backlogMonitor{watermarkPct: watermarkPct, sustainPeriod: sustainPeriod, logger: logger}
+}
+
+// track registers a channel by name, replacing a channel of the same name from an earlier pipeline start
+func (m *backlogMonitor) track(name string, depth func() int, capacity int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	gauge := &channelGauge{name: name, depth: depth, capacity: capacity}
+	for i, g := range m.gauges {
+		if g.name == name {
+			m.gauges[i] = gauge
+			return
+		}
+	}
+	m.gauges = append(m.gauges, gauge)
+}
+
+// capacities returns the capacity of every tracked channel by name
+func (m *backlogMonitor) capacities() map[string]int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	capacities := make(map[string]int, len(m.gauges))
+	for _, g := range m.gauges {
+		capacities[g.name] = g.capacity
+	}
+	return capacities
+}
+
+// sample reads every channel depth, logs watermark transitions, and returns the depths and their total
+func (m *backlogMonitor) sample(now time.Time) (map[string]int, int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	depths := make(map[string]int, len(m.gauges))
+	total := 0
+	for _, g := range m.gauges {
+		depth := g.depth()
+		depths[g.name] = depth
+		total += depth
+
+		if g.capacity <= 0 || depth*100 < g.capacity*m.watermarkPct {
+			if g.warned {
+				m.logger.Info("pipeline channel backlog drained",
+					zap.String("channel", g.name),
+					zap.Int("depth", depth),
+					zap.Int("capacity", g.capacity),
+					zap.Duration("above_watermark_for", now.Sub(g.aboveSince)))
+			}
+			g.aboveSince = time.Time{}
+			g.warned = false
+			continue
+		}
+
+		if g.aboveSince.IsZero() {
+			g.aboveSince = now
+		}
+		if !g.warned && now.Sub(g.aboveSince) >= m.sustainPeriod {
+			g.warned = true
+			m.logger.Warn("⚠️ PIPELINE BACKLOG: channel above high watermark",
+				zap.String("channel", g.name),
+				zap.Int("depth", depth),
+				zap.Int("capacity", g.capacity),
+				zap.Int("high_watermark_pct", m.watermarkPct),
+				zap.Duration("above_watermark_for", now.Sub(g.aboveSince)))
+		}
+	}
+	return depths, total
+}
+
+// monitorChannelBacklog samples pipeline channel depths into the health status every second until ctx is cancelled
+func (app *Application) monitorChannelBacklog(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			app.recordChannelBacklog(now)
+		}
+	}
+}
+
+// recordChannelBacklog stores the current channel depths in the pipeline health
+func (app *Application) recordChannelBacklog(now time.Time) {
+	depths, total := app.backlog.sample(now)
+
+	app.pipelineHealth.mu.Lock()
+	defer app.pipelineHealth.mu.Unlock()
+	app.pipelineHealth.channelDepths = depths
+	app.pipelineHealth.currentBacklogSize = total
+}
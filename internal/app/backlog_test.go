@@ -0,0 +1,77 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestBacklogMonitor_Sample(t *testing.T) {
+	t.Run("should warn once a channel stays above the watermark and note when it drains", func(t *testing.T) {
+		// Arrange
+		core, logs := observer.New(zapcore.InfoLevel)
+		monitor := newBacklogMonitor(80, 30*time.Second, zap.New(core))
+		ch := make(chan int, 10)
+		idle := make(chan int, 10)
+		monitor.track("transcription", func() int { return len(ch) }, cap(ch))
+		monitor.track("contest_cue", func() int { return len(idle) }, cap(idle))
+		start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		for i := 0; i < 9; i++ {
+			ch <- i
+		}
+
+		// Act & Assert
+		depths, total := monitor.sample(start)
+		assert.Equal(t, map[string]int{"transcription": 9, "contest_cue": 0}, depths)
+		assert.Equal(t, 9, total)
+		assert.Equal(t, 0, logs.Len(), "should not warn before the sustain period")
+
+		monitor.sample(start.Add(31 * time.Second))
+		monitor.sample(start.Add(32 * time.Second))
+		warnings := logs.FilterLevelExact(zapcore.WarnLevel).All()
+		require.Len(t, warnings, 1, "should warn once per episode")
+		assert.Equal(t, "transcription", warnings[0].ContextMap()["channel"])
+
+		for len(ch) > 0 {
+			<-ch
+		}
+		monitor.sample(start.Add(40 * time.Second))
+		assert.Equal(t, 1, logs.FilterMessage("pipeline channel backlog drained").Len())
+	})
+
+	t.Run("should restart the sustain period when a channel dips below the watermark", func(t *testing.T) {
+		core, logs := observer.New(zapcore.WarnLevel)
+		monitor := newBacklogMonitor(50, 10*time.Second, zap.New(core))
+		depth := 8
+		monitor.track("buffered_context", func() int { return depth }, 10)
+		start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+		monitor.sample(start)
+		depth = 2
+		monitor.sample(start.Add(5 * time.Second))
+		depth = 8
+		monitor.sample(start.Add(12 * time.Second))
+
+		assert.Equal(t, 0, logs.Len())
+	})
+}
+
+func TestApplication_RecordChannelBacklog(t *testing.T) {
+	app, err := NewApplication()
+	require.NoError(t, err)
+	ch := make(chan int, 100)
+	ch <- 1
+	ch <- 2
+	app.backlog.track("transcription", func() int { return len(ch) }, cap(ch))
+
+	app.recordChannelBacklog(time.Now())
+
+	status := app.getPipelineHealthStatus()
+	assert.Equal(t, 2, status["current_backlog_size"])
+	assert.Equal(t, map[string]int{"transcription": 2}, status["channel_depths"])
+}
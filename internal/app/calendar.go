@@ -0,0 +1,70 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/buffer"
+	"radiocontestwinner/internal/calendar"
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/parser"
+)
+
+// newCalendarExporter creates the exporter of contest sessions as calendar events, or returns
+// nil when no ICS file or CalDAV calendar is configured
+func newCalendarExporter(cfg *config.Configuration, location *time.Location) *calendar.Exporter {
+	if !cfg.GetCalendarEnabled() {
+		return nil
+	}
+	return calendar.NewExporter(calendar.Config{
+		ICSPath:       cfg.GetCalendarICSPath(),
+		CalDAVURL:     cfg.GetCalendarCalDAVURL(),
+		Username:      cfg.GetCalendarCalDAVUsername(),
+		Password:      cfg.GetCalendarCalDAVPassword(),
+		DefaultWindow: time.Duration(cfg.GetCalendarDefaultWindowMin()) * time.Minute,
+		Reminder:      time.Duration(cfg.GetCalendarReminderMin()) * time.Minute,
+		Retention:     time.Duration(cfg.GetCalendarRetentionDays()) * 24 * time.Hour,
+		Location:      location,
+	})
+}
+
+// observeCalendarText checks a buffered context for the entry deadline of recent contest sessions
+func (app *Application) observeCalendarText(bc buffer.BufferedContext) {
+	if app.calendar == nil {
+		return
+	}
+	at := bc.CapturedAt
+	if at.IsZero() {
+		at = app.currentTime()
+	}
+	if updated := app.calendar.ObserveText(at, bc.Text); len(updated) > 0 {
+		app.publishCalendar(updated)
+	}
+}
+
+// exportCalendarCue adds a notified cue to its contest session's calendar event
+func (app *Application) exportCalendarCue(cue parser.ContestCue) {
+	if app.calendar == nil {
+		return
+	}
+	if event, changed := app.calendar.AddCue(cue); changed {
+		app.publishCalendar([]calendar.Event{event})
+	}
+}
+
+// publishCalendar writes new or changed calendar events in the background. Like notifications,
+// only the leader of redundant instances publishes.
+func (app *Application) publishCalendar(events []calendar.Event) {
+	if !app.isLeader() {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := app.calendar.Publish(ctx, events); err != nil {
+			app.zapLogger.Warn("failed to publish contest calendar events", zap.Error(err))
+		}
+	}()
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/buffer"
+	"radiocontestwinner/internal/calendar"
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/parser"
+)
+
+func TestNewCalendarExporter(t *testing.T) {
+	t.Run("should be nil when no calendar is configured", func(t *testing.T) {
+		assert.Nil(t, newCalendarExporter(config.NewConfiguration(), nil))
+	})
+
+	t.Run("should be created for an ICS file", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetCalendarICSPath("/data/contests.ics")
+
+		assert.NotNil(t, newCalendarExporter(cfg, time.UTC))
+	})
+}
+
+func TestApplication_CalendarExport(t *testing.T) {
+	t.Run("should export notified cues with the deadline heard after them", func(t *testing.T) {
+		// Arrange
+		app, err := NewApplication()
+		require.NoError(t, err)
+		app.calendar = calendar.NewExporter(calendar.Config{Location: time.UTC})
+		heardAt := time.Date(2025, 3, 4, 15, 0, 0, 0, time.UTC)
+		cue := parser.NewContestCue("CASH", parser.CueDetails{
+			Keyword:      "CASH",
+			Number:       "72881",
+			OriginalText: "Text CASH to 72881",
+		})
+		cue.Timing = parser.NewCueTiming(heardAt, time.Time{}, heardAt)
+
+		// Act
+		app.observeCalendarText(buffer.BufferedContext{Text: "Text CASH to 72881", CapturedAt: heardAt})
+		app.exportCalendarCue(*cue)
+		app.observeCalendarText(buffer.BufferedContext{Text: "you have 20 minutes", CapturedAt: heardAt.Add(3 * time.Second)})
+
+		// Assert
+		events := app.calendar.Events()
+		require.Len(t, events, 1)
+		assert.Equal(t, "Text CASH to 72881", events[0].Summary())
+		assert.Equal(t, heardAt.Add(3*time.Second).Add(20*time.Minute), events[0].End)
+		assert.True(t, events[0].DeadlineHeard)
+	})
+}
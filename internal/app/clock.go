@@ -0,0 +1,100 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/notifier"
+	"radiocontestwinner/internal/parser"
+)
+
+// runClockChecks measures the clock's drift from NTP time now and then every interval until ctx is cancelled
+func (app *Application) runClockChecks(ctx context.Context, interval time.Duration) {
+	app.checkClock(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			app.checkClock(ctx)
+		}
+	}
+}
+
+// checkClock measures the clock's drift from NTP time and notifies when it moves out of or back
+// into the allowed offset
+func (app *Application) checkClock(ctx context.Context) {
+	status, changed := app.clockMonitor.Check(ctx)
+	if status.Error != "" {
+		app.zapLogger.Warn("failed to check clock against NTP server",
+			zap.String("server", status.Server),
+			zap.String("error", status.Error))
+		return
+	}
+	app.zapLogger.Debug("checked clock against NTP server",
+		zap.String("server", status.Server),
+		zap.Duration("offset", status.Offset),
+		zap.Duration("round_trip", status.RoundTrip))
+	if !changed {
+		return
+	}
+
+	fields := map[string]interface{}{
+		"server":          status.Server,
+		"clock_offset_ms": status.Offset.Milliseconds(),
+		"max_offset_ms":   app.config.GetNTPMaxOffsetMS(),
+	}
+	if !status.InSync {
+		app.zapLogger.Warn("⚠️ CLOCK DRIFT: system clock is off from NTP time; cue timestamps are inaccurate",
+			zap.String("server", status.Server),
+			zap.Duration("offset", status.Offset))
+		app.dispatchNotification(notifier.NewAlertNotification(notifier.SeverityWarning,
+			"Clock drift",
+			fmt.Sprintf("System clock is %s off from %s; cue timestamps may fall outside contest windows",
+				status.Offset.Round(time.Millisecond), status.Server),
+			fields))
+		return
+	}
+	app.zapLogger.Info("system clock back in sync with NTP time", zap.Duration("offset", status.Offset))
+	app.dispatchNotification(notifier.NewAlertNotification(notifier.SeverityInfo,
+		"Clock back in sync",
+		fmt.Sprintf("System clock is within %s of %s", status.Offset.Abs().Round(time.Millisecond), status.Server),
+		fields))
+}
+
+// annotateClockDrift records the last measured clock offset on the cue so its timestamp can be audited
+func (app *Application) annotateClockDrift(cue *parser.ContestCue) {
+	if app.clockMonitor == nil || cue.Timing == nil {
+		return
+	}
+	status := app.clockMonitor.Status()
+	if !status.Checked() {
+		return
+	}
+	cue.Timing.ClockOffsetMS = status.Offset.Milliseconds()
+	cue.Timing.ClockCheckedAt = status.CheckedAt.UTC()
+}
+
+// addClockStatus adds the clock drift audit to the health status and reports whether the clock
+// has drifted beyond the allowed offset
+func (app *Application) addClockStatus(status map[string]interface{}) bool {
+	if app.clockMonitor == nil {
+		return false
+	}
+	clockStatus := app.clockMonitor.Status()
+	status["clock_in_sync"] = clockStatus.InSync
+	if clockStatus.Checked() {
+		status["clock_offset_ms"] = clockStatus.Offset.Milliseconds()
+		status["clock_checked_at"] = clockStatus.CheckedAt.Format(time.RFC3339)
+	}
+	if clockStatus.Error != "" {
+		status["clock_check_error"] = clockStatus.Error
+	}
+	return !clockStatus.InSync
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/clock"
+	"radiocontestwinner/internal/notifier"
+	"radiocontestwinner/internal/parser"
+)
+
+// startSkewedNTPServer answers SNTP requests with a clock skew ahead of the local clock
+func startSkewedNTPServer(t *testing.T, skew time.Duration) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 48)
+		for {
+			_, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			response := make([]byte, 48)
+			response[0], response[1] = 0x24, 2 // Version 4, server mode, stratum 2
+			copy(response[24:32], buf[40:48])
+			now := time.Now().Add(skew)
+			binary.BigEndian.PutUint32(response[32:], uint32(now.Unix()+2208988800))
+			binary.BigEndian.PutUint32(response[36:], uint32(int64(now.Nanosecond())<<32/int64(time.Second)))
+			copy(response[40:48], response[32:40])
+			conn.WriteTo(response, addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func TestApplication_ClockDrift(t *testing.T) {
+	newClockApp := func(t *testing.T, skew time.Duration) (*Application, *channelNotifier) {
+		app, err := NewApplication()
+		require.NoError(t, err)
+		alerts := &channelNotifier{ch: make(chan notifier.Notification, 10)}
+		app.notifier = notifier.NewDispatcher(nil, alerts)
+		app.clockMonitor = clock.NewMonitor(clock.MonitorConfig{
+			Server:    startSkewedNTPServer(t, skew),
+			MaxOffset: 500 * time.Millisecond,
+			Timeout:   2 * time.Second,
+		})
+		return app, alerts
+	}
+
+	t.Run("should annotate cues with the measured clock offset", func(t *testing.T) {
+		// Arrange
+		app, _ := newClockApp(t, -2*time.Second)
+		app.checkClock(context.Background())
+		cue := parser.NewContestCue("CASH", parser.CueDetails{Keyword: "CASH", Number: "55555"})
+		cue.Timing = parser.NewCueTiming(time.Time{}, time.Time{}, time.Now())
+
+		// Act
+		app.annotateClockDrift(cue)
+
+		// Assert
+		assert.InDelta(t, 2000, cue.Timing.ClockOffsetMS, 100)
+		assert.False(t, cue.Timing.ClockCheckedAt.IsZero())
+	})
+
+	t.Run("should degrade health and alert when the clock drifts", func(t *testing.T) {
+		app, alerts := newClockApp(t, 3*time.Second)
+
+		app.checkClock(context.Background())
+
+		healthStatus := app.getPipelineHealthStatus()
+		assert.Equal(t, false, healthStatus["clock_in_sync"])
+		assert.InDelta(t, -3000, healthStatus["clock_offset_ms"], 100)
+		assert.True(t, healthStatus["degraded"].(bool))
+		select {
+		case n := <-alerts.ch:
+			assert.Equal(t, "Clock drift", n.Title)
+		case <-time.After(time.Second):
+			t.Fatal("expected notification for clock drift")
+		}
+	})
+
+	t.Run("should stay healthy when the clock is in sync", func(t *testing.T) {
+		app, _ := newClockApp(t, 0)
+
+		app.checkClock(context.Background())
+
+		healthStatus := app.getPipelineHealthStatus()
+		assert.Equal(t, true, healthStatus["clock_in_sync"])
+		assert.False(t, healthStatus["degraded"].(bool))
+	})
+}
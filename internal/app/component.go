@@ -0,0 +1,113 @@
+package app
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Pipeline component names beyond the supervised ones
+const (
+	ComponentContextBuffer = "context_buffer"
+	ComponentContestParser = "contest_parser"
+	ComponentLogOutput     = "log_output"
+)
+
+// componentStopTimeout bounds how long Shutdown waits for the components to stop
+const componentStopTimeout = 10 * time.Second
+
+// Component is a pipeline stage whose lifecycle the Application manages
+type Component interface {
+	Name() string
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+	Healthy() error // nil while the component is working
+}
+
+// pipelineOrder is the order components start in, following the audio through the pipeline;
+// they stop in reverse
+var pipelineOrder = []string{
+	ComponentStream,
+	ComponentFFmpeg,
+	ComponentTranscription,
+	ComponentContextBuffer,
+	ComponentContestParser,
+	ComponentLogOutput,
+}
+
+// componentRegistry holds the application's components by name. The zero value is ready to use.
+type componentRegistry struct {
+	mu         sync.Mutex
+	components map[string]Component
+}
+
+// register adds c, replacing any component of the same name, such as an FFmpeg process
+// replaced by a restart
+func (r *componentRegistry) register(c Component) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.components == nil {
+		r.components = make(map[string]Component)
+	}
+	r.components[c.Name()] = c
+}
+
+// ordered returns the components in pipeline order, followed by any others sorted by name
+func (r *componentRegistry) ordered() []Component {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ordered := make([]Component, 0, len(r.components))
+	known := make(map[string]bool, len(pipelineOrder))
+	for _, name := range pipelineOrder {
+		known[name] = true
+		if c, ok := r.components[name]; ok {
+			ordered = append(ordered, c)
+		}
+	}
+
+	var others []string
+	for name := range r.components {
+		if !known[name] {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	for _, name := range others {
+		ordered = append(ordered, r.components[name])
+	}
+	return ordered
+}
+
+// stopAll stops every component in reverse pipeline order, logging the ones that fail
+func (r *componentRegistry) stopAll(ctx context.Context, logger *zap.Logger) {
+	components := r.ordered()
+	for i := len(components) - 1; i >= 0; i-- {
+		if err := components[i].Stop(ctx); err != nil {
+			logger.Error("error stopping component", zap.String("component", components[i].Name()), zap.Error(err))
+		}
+	}
+}
+
+// health returns "ok" or the problem reported by each component, by name
+func (r *componentRegistry) health() map[string]string {
+	status := make(map[string]string)
+	for _, c := range r.ordered() {
+		if err := c.Healthy(); err != nil {
+			status[c.Name()] = err.Error()
+		} else {
+			status[c.Name()] = "ok"
+		}
+	}
+	return status
+}
+
+// startComponent registers c, so it is stopped on shutdown and reported in the health status,
+// and starts it
+func (app *Application) startComponent(ctx context.Context, c Component) error {
+	app.components.register(c)
+	return c.Start(ctx)
+}
@@ -0,0 +1,115 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// fakeComponent records lifecycle calls into a shared log
+type fakeComponent struct {
+	name    string
+	calls   *[]string
+	healthy error
+	stopErr error
+}
+
+func (f *fakeComponent) Name() string { return f.name }
+
+func (f *fakeComponent) Start(ctx context.Context) error {
+	*f.calls = append(*f.calls, "start "+f.name)
+	return nil
+}
+
+func (f *fakeComponent) Stop(ctx context.Context) error {
+	*f.calls = append(*f.calls, "stop "+f.name)
+	return f.stopErr
+}
+
+func (f *fakeComponent) Healthy() error { return f.healthy }
+
+func TestComponentRegistry(t *testing.T) {
+	t.Run("should stop components in reverse pipeline order regardless of registration order", func(t *testing.T) {
+		// Arrange
+		var calls []string
+		var registry componentRegistry
+		for _, name := range []string{ComponentLogOutput, "extra", ComponentStream, ComponentContextBuffer, ComponentFFmpeg} {
+			registry.register(&fakeComponent{name: name, calls: &calls})
+		}
+
+		// Act
+		registry.stopAll(context.Background(), zap.NewNop())
+
+		// Assert
+		assert.Equal(t, []string{
+			"stop extra",
+			"stop " + ComponentLogOutput,
+			"stop " + ComponentContextBuffer,
+			"stop " + ComponentFFmpeg,
+			"stop " + ComponentStream,
+		}, calls)
+	})
+
+	t.Run("should keep stopping after a component fails to stop", func(t *testing.T) {
+		// Arrange
+		var calls []string
+		var registry componentRegistry
+		registry.register(&fakeComponent{name: ComponentStream, calls: &calls})
+		registry.register(&fakeComponent{name: ComponentFFmpeg, calls: &calls, stopErr: errors.New("wait failed")})
+
+		// Act
+		registry.stopAll(context.Background(), zap.NewNop())
+
+		// Assert
+		assert.Equal(t, []string{"stop " + ComponentFFmpeg, "stop " + ComponentStream}, calls)
+	})
+
+	t.Run("should replace a component registered under the same name", func(t *testing.T) {
+		// Arrange
+		var calls []string
+		var registry componentRegistry
+		registry.register(&fakeComponent{name: ComponentFFmpeg, calls: &calls, healthy: errors.New("ffmpeg process not running")})
+
+		// Act
+		registry.register(&fakeComponent{name: ComponentFFmpeg, calls: &calls})
+
+		// Assert
+		assert.Len(t, registry.ordered(), 1)
+		assert.Equal(t, map[string]string{ComponentFFmpeg: "ok"}, registry.health())
+	})
+
+	t.Run("should report each component's problem in its health", func(t *testing.T) {
+		// Arrange
+		var calls []string
+		var registry componentRegistry
+		registry.register(&fakeComponent{name: ComponentStream, calls: &calls, healthy: errors.New("stream not connected")})
+		registry.register(&fakeComponent{name: ComponentContestParser, calls: &calls})
+
+		// Act
+		health := registry.health()
+
+		// Assert
+		assert.Equal(t, map[string]string{
+			ComponentStream:        "stream not connected",
+			ComponentContestParser: "ok",
+		}, health)
+	})
+}
+
+func TestApplication_StartComponent(t *testing.T) {
+	// Arrange
+	var calls []string
+	app := &Application{}
+	component := &fakeComponent{name: ComponentContextBuffer, calls: &calls}
+
+	// Act
+	err := app.startComponent(context.Background(), component)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"start " + ComponentContextBuffer}, calls)
+	assert.Contains(t, app.components.health(), ComponentContextBuffer)
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrControlUnsupported is returned when pause and resume signals are not available on this platform
+var ErrControlUnsupported = errors.New("pause and resume signals are not supported on this platform")
+
+// Paused reports whether transcription is paused
+func (app *Application) Paused() bool {
+	return app.transcriptionEngine != nil && app.transcriptionEngine.Paused()
+}
+
+// SetPaused pauses or resumes the pipeline while the application runs. While paused the stream
+// stays connected and audio keeps being decoded and discarded, so resuming is immediate, but
+// nothing is transcribed; e.g. during ad-swap testing or to free the GPU for another job.
+func (app *Application) SetPaused(paused bool) {
+	if app.transcriptionEngine == nil {
+		return
+	}
+
+	// Resuming a warm standby, by promotion or by the resume command, takes it out of standby
+	if !paused {
+		app.standby.Store(false)
+	}
+
+	app.pipelineHealth.mu.Lock()
+	if app.transcriptionEngine.Paused() == paused {
+		app.pipelineHealth.mu.Unlock()
+		return
+	}
+	app.transcriptionEngine.SetPaused(paused)
+	if paused {
+		app.pipelineHealth.pausedAt = app.currentTime()
+	} else {
+		app.pipelineHealth.pausedAt = time.Time{}
+		app.pipelineHealth.resumedAt = app.currentTime()
+	}
+	app.pipelineHealth.mu.Unlock()
+
+	// The word rate drops to zero while paused, so judge it afresh afterwards
+	if !paused && app.rateDetector != nil {
+		app.rateDetector.Reset()
+	}
+
+	if paused {
+		app.zapLogger.Info("pipeline paused; stream stays connected but audio is not transcribed")
+	} else {
+		app.zapLogger.Info("pipeline resumed",
+			zap.Int64("chunks_skipped_total", app.transcriptionEngine.SkippedChunks()))
+	}
+	if err := app.writeHealthStatusFile(); err != nil {
+		app.zapLogger.Warn("failed to write health status file", zap.Error(err))
+	}
+}
+
+// HandleControlSignals pauses the pipeline on SIGUSR1 and resumes it on SIGUSR2 until ctx is
+// cancelled. It returns immediately where those signals don't exist.
+func (app *Application) HandleControlSignals(ctx context.Context) {
+	if pauseSignal == nil {
+		return
+	}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, pauseSignal, resumeSignal)
+	defer signal.Stop(signals)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-signals:
+			app.SetPaused(sig == pauseSignal)
+		}
+	}
+}
+
+// SendControlSignal asks the running instance with process ID pid to pause or resume
+func SendControlSignal(pid int, pause bool) error {
+	if pauseSignal == nil {
+		return ErrControlUnsupported
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return fmt.Errorf("failed to find process %d: %w", pid, err)
+	}
+	sig := resumeSignal
+	if pause {
+		sig = pauseSignal
+	}
+	if err := process.Signal(sig); err != nil {
+		return fmt.Errorf("failed to signal process %d: %w", pid, err)
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package app
+
+import "os"
+
+// Pause and resume signals don't exist on this platform
+var (
+	pauseSignal  os.Signal
+	resumeSignal os.Signal
+)
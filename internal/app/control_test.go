@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplication_SetPaused(t *testing.T) {
+	t.Run("should pause transcription and report it in the health status", func(t *testing.T) {
+		// Arrange
+		app, err := NewApplication()
+		require.NoError(t, err)
+
+		// Act
+		app.SetPaused(true)
+
+		// Assert
+		assert.True(t, app.Paused())
+		assert.True(t, app.transcriptionEngine.Paused())
+		status := app.HealthStatus()
+		assert.Equal(t, true, status["paused"])
+		assert.Equal(t, true, status["transcription_healthy"])
+	})
+
+	t.Run("should resume transcription", func(t *testing.T) {
+		app, err := NewApplication()
+		require.NoError(t, err)
+		app.SetPaused(true)
+
+		app.SetPaused(false)
+
+		assert.False(t, app.Paused())
+		assert.Equal(t, false, app.HealthStatus()["paused"])
+	})
+}
+
+func TestApplication_HandleControlSignals(t *testing.T) {
+	if pauseSignal == nil {
+		t.Skip("pause and resume signals are not supported on this platform")
+	}
+
+	t.Run("should pause and resume on signals", func(t *testing.T) {
+		// Arrange
+		app, err := NewApplication()
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go app.HandleControlSignals(ctx)
+		time.Sleep(50 * time.Millisecond) // Let the handler register
+
+		// Act
+		require.NoError(t, SendControlSignal(os.Getpid(), true))
+
+		// Assert
+		assert.Eventually(t, app.Paused, 2*time.Second, 10*time.Millisecond)
+
+		require.NoError(t, SendControlSignal(os.Getpid(), false))
+		assert.Eventually(t, func() bool { return !app.Paused() }, 2*time.Second, 10*time.Millisecond)
+	})
+}
@@ -0,0 +1,14 @@
+//go:build unix
+
+package app
+
+import (
+	"os"
+	"syscall"
+)
+
+// Signals that pause and resume a running instance
+var (
+	pauseSignal  os.Signal = syscall.SIGUSR1
+	resumeSignal os.Signal = syscall.SIGUSR2
+)
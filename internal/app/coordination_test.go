@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/coordination"
+	"radiocontestwinner/internal/notifier"
+	"radiocontestwinner/internal/parser"
+)
+
+func TestApplication_Coordination(t *testing.T) {
+	t.Run("should notify as a standalone instance when coordination is disabled", func(t *testing.T) {
+		// Arrange
+		app, err := NewApplication()
+		require.NoError(t, err)
+		cues := &channelNotifier{ch: make(chan notifier.Notification, 1)}
+		app.notifier = notifier.NewDispatcher(nil, cues)
+
+		// Act
+		app.dispatchNotification(notifier.NewCueNotification(*parser.NewContestCue("CASH", parser.CueDetails{})))
+
+		// Assert
+		assert.Equal(t, "standalone", app.getPipelineHealthStatus()["coordination_role"])
+		select {
+		case <-cues.ch:
+		case <-time.After(time.Second):
+			t.Fatal("expected notification")
+		}
+	})
+
+	t.Run("should only notify from the leader instance", func(t *testing.T) {
+		// Arrange - two instances sharing a lock file
+		lockFile := filepath.Join(t.TempDir(), "leader.lock")
+		leader, err := NewApplication()
+		require.NoError(t, err)
+		follower, err := NewApplication()
+		require.NoError(t, err)
+		leader.elector = coordination.NewElector(coordination.NewFileLock(lockFile), 10*time.Millisecond, nil)
+		follower.elector = coordination.NewElector(coordination.NewFileLock(lockFile), 10*time.Millisecond, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go leader.elector.Run(ctx)
+		require.Eventually(t, leader.elector.IsLeader, time.Second, 5*time.Millisecond)
+		go follower.elector.Run(ctx)
+
+		leaderCues := &channelNotifier{ch: make(chan notifier.Notification, 1)}
+		followerCues := &channelNotifier{ch: make(chan notifier.Notification, 1)}
+		leader.notifier = notifier.NewDispatcher(nil, leaderCues)
+		follower.notifier = notifier.NewDispatcher(nil, followerCues)
+		cue := parser.NewContestCue("CASH", parser.CueDetails{Keyword: "CASH", Number: "55555"})
+
+		// Act
+		leader.dispatchNotification(notifier.NewCueNotification(*cue))
+		follower.dispatchNotification(notifier.NewCueNotification(*cue))
+
+		// Assert
+		assert.Equal(t, "leader", leader.getPipelineHealthStatus()["coordination_role"])
+		assert.Equal(t, "follower", follower.getPipelineHealthStatus()["coordination_role"])
+		select {
+		case <-leaderCues.ch:
+		case <-time.After(time.Second):
+			t.Fatal("expected the leader to notify")
+		}
+		select {
+		case <-followerCues.ch:
+			t.Fatal("follower should not notify")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// debugTranscriptWriter appends debug-mode transcriptions to a file through a buffer, keeping
+// only every Nth one and rotating the file at a maximum size, so long debug sessions neither
+// exhaust the disk nor open the file once per segment
+type debugTranscriptWriter struct {
+	path          string
+	sampleEvery   int64 // Write one of every sampleEvery records; 1 writes all
+	maxBytes      int64 // Rotate before the file would exceed this; 0 never rotates
+	maxBackups    int   // Rotated files kept as path.1 (newest) through path.N
+	flushInterval time.Duration
+
+	mu    sync.Mutex
+	file  *os.File
+	buf   *bufio.Writer
+	size  int64
+	seen  int64
+	dirty bool
+}
+
+// newDebugTranscriptWriter creates a writer for path; the file is opened on the first write
+func newDebugTranscriptWriter(path string, sampleEvery int, maxBytes int64, maxBackups int, flushInterval time.Duration) *debugTranscriptWriter {
+	if sampleEvery < 1 {
+		sampleEvery = 1
+	}
+	if flushInterval <= 0 {
+		flushInterval = 5 * time.Second
+	}
+	return &debugTranscriptWriter{
+		path:          path,
+		sampleEvery:   int64(sampleEvery),
+		maxBytes:      maxBytes,
+		maxBackups:    maxBackups,
+		flushInterval: flushInterval,
+	}
+}
+
+// Write buffers one JSON line unless sampling skips it. It reports whether the record was kept.
+func (w *debugTranscriptWriter) Write(record []byte) (bool, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.seen++
+	if (w.seen-1)%w.sampleEvery != 0 {
+		return false, nil
+	}
+
+	line := append(record[:len(record):len(record)], '\n')
+	if w.file != nil && w.maxBytes > 0 && w.size > 0 && w.size+int64(len(line)) > w.maxBytes {
+		if err := w.rotateLocked(); err != nil {
+			return false, err
+		}
+	}
+	if w.file == nil {
+		if err := w.openLocked(); err != nil {
+			return false, err
+		}
+	}
+
+	n, err := w.buf.Write(line)
+	w.size += int64(n)
+	if err != nil {
+		return false, fmt.Errorf("failed to write debug transcription: %w", err)
+	}
+	w.dirty = true
+	return true, nil
+}
+
+// openLocked opens the file for appending, creating its directory
+func (w *debugTranscriptWriter) openLocked() error {
+	if err := os.MkdirAll(filepath.Dir(w.path), 0755); err != nil {
+		return fmt.Errorf("failed to create debug log directory: %w", err)
+	}
+	file, err := os.OpenFile(w.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open debug transcription log: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat debug transcription log: %w", err)
+	}
+	w.file = file
+	w.buf = bufio.NewWriterSize(file, 64*1024)
+	w.size = info.Size()
+	return nil
+}
+
+// rotateLocked closes the file and shifts it to path.1, path.1 to path.2, and so on, dropping
+// the oldest; without backups the file is simply truncated
+func (w *debugTranscriptWriter) rotateLocked() error {
+	if err := w.closeLocked(); err != nil {
+		return err
+	}
+	if w.maxBackups < 1 {
+		return os.Remove(w.path)
+	}
+	os.Remove(fmt.Sprintf("%s.%d", w.path, w.maxBackups))
+	for i := w.maxBackups - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", w.path, i), fmt.Sprintf("%s.%d", w.path, i+1))
+	}
+	if err := os.Rename(w.path, w.path+".1"); err != nil {
+		return fmt.Errorf("failed to rotate debug transcription log: %w", err)
+	}
+	return nil
+}
+
+// Flush writes buffered records to the file
+func (w *debugTranscriptWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.flushLocked()
+}
+
+func (w *debugTranscriptWriter) flushLocked() error {
+	if w.buf == nil || !w.dirty {
+		return nil
+	}
+	w.dirty = false
+	if err := w.buf.Flush(); err != nil {
+		return fmt.Errorf("failed to flush debug transcription log: %w", err)
+	}
+	return nil
+}
+
+// Close flushes and closes the file; a later write reopens it
+func (w *debugTranscriptWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.closeLocked()
+}
+
+func (w *debugTranscriptWriter) closeLocked() error {
+	if w.file == nil {
+		return nil
+	}
+	flushErr := w.flushLocked()
+	closeErr := w.file.Close()
+	w.file, w.buf, w.size = nil, nil, 0
+	if flushErr != nil {
+		return flushErr
+	}
+	return closeErr
+}
+
+// Run flushes buffered records every flush interval and closes the file when ctx is cancelled
+func (w *debugTranscriptWriter) Run(ctx context.Context, onError func(error)) {
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := w.Close(); err != nil {
+				onError(err)
+			}
+			return
+		case <-ticker.C:
+			if err := w.Flush(); err != nil {
+				onError(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readLines returns the lines of the file at path, or none when it doesn't exist
+func readLines(t *testing.T, path string) []string {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	require.NoError(t, err)
+	return strings.Fields(string(data))
+}
+
+func TestDebugTranscriptWriter(t *testing.T) {
+	t.Run("should buffer records until flushed", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "logs", "transcriptions_debug.log")
+		writer := newDebugTranscriptWriter(path, 1, 0, 0, time.Second)
+
+		// Act
+		kept, err := writer.Write([]byte(`{"text":"one"}`))
+		require.NoError(t, err)
+
+		// Assert
+		assert.True(t, kept)
+		assert.Empty(t, readLines(t, path))
+		require.NoError(t, writer.Flush())
+		assert.Equal(t, []string{`{"text":"one"}`}, readLines(t, path))
+		require.NoError(t, writer.Close())
+	})
+
+	t.Run("should keep only every Nth record", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "transcriptions_debug.log")
+		writer := newDebugTranscriptWriter(path, 3, 0, 0, time.Second)
+
+		for i := 1; i <= 7; i++ {
+			_, err := writer.Write([]byte(fmt.Sprintf(`{"n":%d}`, i)))
+			require.NoError(t, err)
+		}
+		require.NoError(t, writer.Close())
+
+		assert.Equal(t, []string{`{"n":1}`, `{"n":4}`, `{"n":7}`}, readLines(t, path))
+	})
+
+	t.Run("should rotate at the maximum size and keep the configured backups", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "transcriptions_debug.log")
+		writer := newDebugTranscriptWriter(path, 1, 20, 2, time.Second)
+
+		for i := 1; i <= 8; i++ {
+			_, err := writer.Write([]byte(fmt.Sprintf(`{"n":%d}`, i))) // 8 bytes per line
+			require.NoError(t, err)
+		}
+		require.NoError(t, writer.Close())
+
+		assert.Equal(t, []string{`{"n":7}`, `{"n":8}`}, readLines(t, path))
+		assert.Equal(t, []string{`{"n":5}`, `{"n":6}`}, readLines(t, path+".1"))
+		assert.Equal(t, []string{`{"n":3}`, `{"n":4}`}, readLines(t, path+".2"))
+		assert.NoFileExists(t, path+".3")
+	})
+
+	t.Run("should flush periodically and close when cancelled", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "transcriptions_debug.log")
+		writer := newDebugTranscriptWriter(path, 1, 0, 0, 20*time.Millisecond)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			writer.Run(ctx, func(err error) { t.Error(err) })
+			close(done)
+		}()
+
+		_, err := writer.Write([]byte(`{"text":"one"}`))
+		require.NoError(t, err)
+
+		assert.Eventually(t, func() bool { return len(readLines(t, path)) == 1 }, time.Second, 10*time.Millisecond)
+		cancel()
+		<-done
+	})
+}
@@ -0,0 +1,205 @@
+//go:build e2e
+
+package app
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// End-to-end test running the real pipeline (stream -> FFmpeg -> Whisper -> parser -> log)
+// against a recorded fixture. Run with: go test -tags e2e ./internal/app -run TestE2E_RecordedFixture
+//
+// Requires ffmpeg, whisper-cli, and a Whisper model (E2E_WHISPER_MODEL_PATH, default
+// models/ggml-tiny.en.bin at the repository root). TestMain generates the fixture with
+// scripts/e2e_fixture.sh (espeak-ng and ffmpeg) when it is missing. Missing tools fail the run
+// rather than skip it, so an e2e run never passes without covering anything.
+
+// e2eFixtureDir holds the manifest and the generated recording
+const e2eFixtureDir = "testdata/e2e"
+
+// e2eManifest describes the recorded fixture and the cues it must produce
+type e2eManifest struct {
+	Fixture      string   `json:"fixture"`
+	Script       string   `json:"script"`
+	Allowlist    []string `json:"allowlist"`
+	ExpectedCues []struct {
+		Keyword   string `json:"keyword"`
+		Shortcode string `json:"shortcode"`
+	} `json:"expected_cues"`
+}
+
+// TestMain generates the recorded fixture before the e2e tests when it is missing
+func TestMain(m *testing.M) {
+	if err := ensureE2EFixture(); err != nil {
+		fmt.Fprintf(os.Stderr, "e2e fixture: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+// readE2EManifest loads the description of the recorded fixture
+func readE2EManifest() (e2eManifest, error) {
+	var manifest e2eManifest
+	data, err := os.ReadFile(filepath.Join(e2eFixtureDir, "manifest.json"))
+	if err != nil {
+		return manifest, err
+	}
+	err = json.Unmarshal(data, &manifest)
+	return manifest, err
+}
+
+// ensureE2EFixture runs scripts/e2e_fixture.sh unless the fixture named by the manifest exists
+func ensureE2EFixture() error {
+	manifest, err := readE2EManifest()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(e2eFixtureDir, manifest.Fixture)); err == nil {
+		return nil
+	}
+	for _, tool := range []string{"espeak-ng", "ffmpeg"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			return fmt.Errorf("%s is missing and %s is not installed to generate it", manifest.Fixture, tool)
+		}
+	}
+
+	cmd := exec.Command("bash", filepath.Join("..", "..", "scripts", "e2e_fixture.sh"))
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("scripts/e2e_fixture.sh: %w", err)
+	}
+	return nil
+}
+
+func TestE2E_RecordedFixture(t *testing.T) {
+	t.Run("should detect the scripted contest cue in the recorded fixture", func(t *testing.T) {
+		// Arrange
+		manifest, err := readE2EManifest()
+		require.NoError(t, err)
+		audio, err := os.ReadFile(filepath.Join(e2eFixtureDir, manifest.Fixture))
+		require.NoError(t, err)
+
+		_, err = exec.LookPath("ffmpeg")
+		require.NoError(t, err, "ffmpeg not installed")
+		require.True(t, whisperBinaryAvailable(), "whisper-cli not installed")
+		modelPath := os.Getenv("E2E_WHISPER_MODEL_PATH")
+		if modelPath == "" {
+			modelPath = filepath.Join("..", "..", "models", "ggml-tiny.en.bin")
+		}
+		modelPath, err = filepath.Abs(modelPath)
+		require.NoError(t, err)
+		_, err = os.Stat(modelPath)
+		require.NoError(t, err, "Whisper model not found at %s - set E2E_WHISPER_MODEL_PATH or run radiocontestwinner init -model tiny.en -download", modelPath)
+
+		server := NewMockAudioServer(audio, 10*time.Millisecond)
+		defer server.Close()
+
+		logPath := filepath.Join(t.TempDir(), "contest_cues.log")
+		t.Setenv("CONFIG_PATH", "")
+		t.Setenv("STREAM_URL", server.URL())
+		t.Setenv("WHISPER_MODEL_PATH", modelPath)
+		t.Setenv("WHISPER_BACKEND_PRIORITY", "binary")
+		t.Setenv("ALLOWLIST_NUMBERS", strings.Join(manifest.Allowlist, ","))
+		t.Setenv("LOG_FILE_PATH", logPath)
+		t.Setenv("DEBUG_MODE", "true")
+
+		app, err := NewApplication()
+		require.NoError(t, err)
+		defer app.Shutdown()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+		defer cancel()
+		done := make(chan error, 1)
+		go func() { done <- app.Run(ctx) }()
+
+		// Act
+		var cues []map[string]interface{}
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+	WaitLoop:
+		for {
+			select {
+			case err := <-done:
+				require.NoError(t, err)
+				break WaitLoop
+			case <-ctx.Done():
+				break WaitLoop
+			case <-ticker.C:
+				cues = readLoggedCues(t, logPath)
+				if containsExpectedCues(cues, manifest) {
+					break WaitLoop
+				}
+			}
+		}
+		cancel()
+
+		// Assert
+		for _, expected := range manifest.ExpectedCues {
+			assert.True(t, containsCue(cues, expected.Keyword, expected.Shortcode),
+				"expected cue %s -> %s in %v (script: %q)", expected.Keyword, expected.Shortcode, cues, manifest.Script)
+		}
+	})
+}
+
+// whisperBinaryAvailable reports whether the whisper-cli binary the binary backend runs can be found
+func whisperBinaryAvailable() bool {
+	if _, err := os.Stat("/usr/local/bin/whisper-cli"); err == nil {
+		return true
+	}
+	_, err := exec.LookPath("whisper-cli")
+	return err == nil
+}
+
+// readLoggedCues parses the JSON lines written to the contest cue log
+func readLoggedCues(t *testing.T, path string) []map[string]interface{} {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var cues []map[string]interface{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var cue map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &cue); err != nil {
+			t.Logf("skipping unparseable cue log line: %s", scanner.Text())
+			continue
+		}
+		cues = append(cues, cue)
+	}
+	return cues
+}
+
+func containsExpectedCues(cues []map[string]interface{}, manifest e2eManifest) bool {
+	for _, expected := range manifest.ExpectedCues {
+		if !containsCue(cues, expected.Keyword, expected.Shortcode) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsCue(cues []map[string]interface{}, keyword, shortcode string) bool {
+	for _, cue := range cues {
+		cueKeyword, _ := cue["keyword"].(string)
+		cueShortcode, _ := cue["shortcode"].(string)
+		if strings.EqualFold(cueKeyword, keyword) && cueShortcode == shortcode {
+			return true
+		}
+	}
+	return false
+}
@@ -123,9 +123,9 @@ func TestE2E_TranscriptionSegmentValidation(t *testing.T) {
 func TestE2E_ContestCueValidation(t *testing.T) {
 	skipE2EInCI(t)
 	t.Run("should validate ContestCue schema compliance", func(t *testing.T) {
-		details := map[string]interface{}{
-			"keyword":   "CONTEST",
-			"shortcode": "12345",
+		details := parser.CueDetails{
+			Keyword: "CONTEST",
+			Number:  "12345",
 		}
 
 		cue := parser.NewContestCue("text-to-win", details)
@@ -136,7 +136,7 @@ func TestE2E_ContestCueValidation(t *testing.T) {
 		assert.NotEmpty(t, cue.CueID)
 		assert.NotEmpty(t, cue.ContestType)
 		assert.NotEmpty(t, cue.Timestamp)
-		assert.NotNil(t, cue.Details)
+		assert.NotEmpty(t, cue.Details.Keyword)
 	})
 
 	t.Run("should reject invalid ContestCue values", func(t *testing.T) {
@@ -151,7 +151,7 @@ func TestE2E_ContestCueValidation(t *testing.T) {
 					CueID:       "",
 					ContestType: "text-to-win",
 					Timestamp:   time.Now().Format(time.RFC3339),
-					Details:     map[string]interface{}{"test": "value"},
+					Details:     parser.CueDetails{Extensions: map[string]interface{}{"test": "value"}},
 				},
 				expectError: true,
 			},
@@ -161,7 +161,7 @@ func TestE2E_ContestCueValidation(t *testing.T) {
 					CueID:       "test123",
 					ContestType: "",
 					Timestamp:   time.Now().Format(time.RFC3339),
-					Details:     map[string]interface{}{"test": "value"},
+					Details:     parser.CueDetails{Extensions: map[string]interface{}{"test": "value"}},
 				},
 				expectError: true,
 			},
@@ -171,17 +171,16 @@ func TestE2E_ContestCueValidation(t *testing.T) {
 					CueID:       "test123",
 					ContestType: "text-to-win",
 					Timestamp:   "",
-					Details:     map[string]interface{}{"test": "value"},
+					Details:     parser.CueDetails{Extensions: map[string]interface{}{"test": "value"}},
 				},
 				expectError: true,
 			},
 			{
-				name: "nil Details",
+				name: "empty Details",
 				cue: parser.ContestCue{
 					CueID:       "test123",
 					ContestType: "text-to-win",
 					Timestamp:   time.Now().Format(time.RFC3339),
-					Details:     nil,
 				},
 				expectError: true,
 			},
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/health"
+	"radiocontestwinner/internal/notifier"
+)
+
+// newBudgetTracker creates the tracker escalating sustained failures and tallying the daily
+// failure budget; nil when escalation is disabled
+func newBudgetTracker(cfg *config.Configuration, location *time.Location) *health.BudgetTracker {
+	if !cfg.GetEscalationEnabled() {
+		return nil
+	}
+	return health.NewBudgetTracker(health.BudgetConfig{
+		WarnAfter:     time.Duration(cfg.GetEscalationWarnAfterSec()) * time.Second,
+		PageAfter:     time.Duration(cfg.GetEscalationPageAfterSec()) * time.Second,
+		BudgetPercent: cfg.GetEscalationDailyBudgetPct(),
+		Location:      location,
+	})
+}
+
+// checkFailureBudget records the pipeline's health against the failure budget and escalates a
+// sustained failure: it is logged at first, then sent as a warning, then as a page
+func (app *Application) checkFailureBudget(now time.Time, healthStatus map[string]interface{}) {
+	if app.failureBudget == nil {
+		return
+	}
+
+	// A paused pipeline is deliberately idle, not failing
+	healthy := app.Paused() || app.isSystemHealthy(healthStatus)
+	transition := app.failureBudget.Observe(healthy, now)
+	status := transition.Status
+
+	fields := map[string]interface{}{
+		"level":            string(transition.To),
+		"budget_used_pct":  math.Round(status.BudgetUsed*10) / 10,
+		"unhealthy_today":  status.Unhealthy.Round(time.Second).String(),
+		"stream_connected": healthStatus["stream_connected"],
+	}
+	if !status.FailingSince.IsZero() {
+		fields["failing_since"] = status.FailingSince.UTC().Format(time.RFC3339)
+	}
+
+	switch {
+	case transition.Recovered():
+		app.zapLogger.Info("pipeline recovered",
+			zap.String("from_level", string(transition.From)),
+			zap.Float64("budget_used_pct", status.BudgetUsed))
+		// Failures that were only logged recover quietly too
+		if transition.From != health.LevelFailing {
+			app.dispatchNotification(notifier.NewAlertNotification(notifier.SeverityInfo,
+				"Pipeline recovered",
+				fmt.Sprintf("Pipeline healthy again; %.1f%% of today's failure budget used", status.BudgetUsed),
+				fields))
+		}
+	case transition.Escalated():
+		failingFor := now.Sub(status.FailingSince).Round(time.Second)
+		switch transition.To {
+		case health.LevelFailing:
+			app.zapLogger.Warn("pipeline unhealthy, escalating if it persists",
+				zap.Any("stream_connected", healthStatus["stream_connected"]),
+				zap.Any("transcription_healthy", healthStatus["transcription_healthy"]))
+		case health.LevelWarning:
+			app.zapLogger.Warn("pipeline unhealthy for a sustained period", zap.Duration("failing_for", failingFor))
+			app.dispatchNotification(notifier.NewAlertNotification(notifier.SeverityWarning,
+				"Pipeline unhealthy",
+				fmt.Sprintf("Pipeline has been unhealthy for %s", failingFor),
+				fields))
+		case health.LevelPage:
+			app.zapLogger.Error("🚨 PIPELINE OUTAGE: unhealthy long enough to page", zap.Duration("failing_for", failingFor))
+			app.dispatchNotification(notifier.NewAlertNotification(notifier.SeverityCritical,
+				"Pipeline outage",
+				fmt.Sprintf("Pipeline has been unhealthy for %s and needs attention", failingFor),
+				fields))
+		}
+	}
+
+	if transition.BudgetExhausted {
+		app.zapLogger.Warn("daily failure budget exhausted",
+			zap.String("day", status.Day),
+			zap.Duration("unhealthy", status.Unhealthy))
+		app.dispatchNotification(notifier.NewAlertNotification(notifier.SeverityWarning,
+			"Failure budget exhausted",
+			fmt.Sprintf("Pipeline has been unhealthy for %s today (%.1f%% of observed time), over the daily budget",
+				status.Unhealthy.Round(time.Second), status.UnhealthyPercent),
+			fields))
+	}
+}
+
+// failureBudgetStatus formats the failure level and daily budget for health output
+func failureBudgetStatus(status health.BudgetStatus) map[string]interface{} {
+	budget := map[string]interface{}{
+		"level":             string(status.Level),
+		"day":               status.Day,
+		"unhealthy_sec":     int64(status.Unhealthy / time.Second),
+		"unhealthy_percent": math.Round(status.UnhealthyPercent*100) / 100,
+		"budget_used_pct":   math.Round(status.BudgetUsed*10) / 10,
+		"exhausted":         status.Exhausted,
+	}
+	if !status.FailingSince.IsZero() {
+		budget["failing_since"] = status.FailingSince.UTC().Format(time.RFC3339)
+	}
+	return budget
+}
@@ -0,0 +1,103 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/health"
+	"radiocontestwinner/internal/notifier"
+)
+
+func TestNewBudgetTracker(t *testing.T) {
+	t.Run("should leave escalation off when disabled", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetEscalationEnabled(false)
+
+		assert.Nil(t, newBudgetTracker(cfg, nil))
+	})
+}
+
+func TestApplication_FailureEscalation(t *testing.T) {
+	app, err := NewApplication()
+	require.NoError(t, err)
+
+	alerts := &channelNotifier{ch: make(chan notifier.Notification, 10)}
+	app.notifier = notifier.NewDispatcher(nil, alerts)
+	app.failureBudget = health.NewBudgetTracker(health.BudgetConfig{
+		WarnAfter:     10 * time.Minute,
+		PageAfter:     30 * time.Minute,
+		BudgetPercent: 1,
+	})
+
+	unhealthy := map[string]interface{}{
+		"stream_connected":        false,
+		"audio_processing_active": true,
+	}
+	start := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	expectAlert := func(t *testing.T, severity notifier.Severity, title string) {
+		t.Helper()
+		select {
+		case n := <-alerts.ch:
+			assert.Equal(t, notifier.KindAlert, n.Kind)
+			assert.Equal(t, severity, n.Severity)
+			assert.Equal(t, title, n.Title)
+		case <-time.After(time.Second):
+			t.Fatalf("expected %q notification", title)
+		}
+	}
+	expectNone := func(t *testing.T) {
+		t.Helper()
+		select {
+		case n := <-alerts.ch:
+			t.Fatalf("unexpected notification %q", n.Title)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	t.Run("should only log the first failure", func(t *testing.T) {
+		app.checkFailureBudget(start, unhealthy)
+
+		expectNone(t)
+		budget := app.getPipelineHealthStatus()["failure_budget"].(map[string]interface{})
+		assert.Equal(t, "failing", budget["level"])
+	})
+
+	t.Run("should warn after a sustained failure", func(t *testing.T) {
+		app.checkFailureBudget(start.Add(10*time.Minute), unhealthy)
+
+		expectAlert(t, notifier.SeverityWarning, "Pipeline unhealthy")
+	})
+
+	t.Run("should page and report the exhausted budget after a long outage", func(t *testing.T) {
+		app.checkFailureBudget(start.Add(30*time.Minute), unhealthy)
+
+		severities := map[string]notifier.Severity{}
+		for i := 0; i < 2; i++ {
+			select {
+			case n := <-alerts.ch:
+				severities[n.Title] = n.Severity
+			case <-time.After(time.Second):
+				t.Fatal("expected page and budget notifications")
+			}
+		}
+		assert.Equal(t, map[string]notifier.Severity{
+			"Pipeline outage":          notifier.SeverityCritical,
+			"Failure budget exhausted": notifier.SeverityWarning,
+		}, severities)
+	})
+
+	t.Run("should notify recovery after an escalated failure", func(t *testing.T) {
+		app.checkFailureBudget(start.Add(31*time.Minute), map[string]interface{}{})
+
+		expectAlert(t, notifier.SeverityInfo, "Pipeline recovered")
+		budget := app.getPipelineHealthStatus()["failure_budget"].(map[string]interface{})
+		assert.Equal(t, "ok", budget["level"])
+		assert.Equal(t, int64(31*60), budget["unhealthy_sec"])
+		assert.Equal(t, true, budget["exhausted"])
+	})
+}
@@ -0,0 +1,26 @@
+package app
+
+import (
+	"fmt"
+	"os"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/feature"
+)
+
+// newFeatureSet creates the feature flags of this instance from the config. Percentage rollouts
+// are decided by the coordination instance ID, or the hostname, so a restart keeps its flags.
+func newFeatureSet(cfg *config.Configuration) (*feature.Set, error) {
+	rollouts, err := feature.ParseRollouts(cfg.GetFeatureFlags())
+	if err != nil {
+		return nil, fmt.Errorf("invalid feature flags: %w", err)
+	}
+	instance := cfg.GetCoordinationInstanceID()
+	if instance == "" {
+		instance, _ = os.Hostname()
+	}
+	if tenant := cfg.GetTenantName(); tenant != "" {
+		instance += "/" + tenant
+	}
+	return feature.NewSet(instance, rollouts), nil
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/feature"
+)
+
+func TestNewFeatureSet(t *testing.T) {
+	t.Run("should enable the configured flags and report them in health", func(t *testing.T) {
+		// Arrange
+		cfg := config.NewConfiguration()
+		cfg.SetFeatureFlags(map[string]interface{}{feature.AutoTune: true, "vad": false})
+
+		// Act
+		app, err := NewApplicationWithConfig(cfg)
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, app.features.Enabled(feature.AutoTune))
+		assert.Equal(t, map[string]bool{"auto_tune": true, "vad": false}, app.getPipelineHealthStatus()["feature_flags"])
+	})
+
+	t.Run("should reject invalid flag values", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetFeatureFlags(map[string]interface{}{"vad": "sometimes"})
+
+		_, err := newFeatureSet(cfg)
+
+		assert.ErrorContains(t, err, "invalid feature flags")
+	})
+}
@@ -0,0 +1,135 @@
+package app
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/buffer"
+	"radiocontestwinner/internal/dedup"
+	"radiocontestwinner/internal/parser"
+)
+
+// newClockedApp creates an application whose clock reads *now
+func newClockedApp(t *testing.T, now *time.Time) *Application {
+	t.Helper()
+	app, err := NewApplication()
+	require.NoError(t, err)
+	app.now = func() time.Time { return *now }
+	return app
+}
+
+func TestApplication_HealthStaleness(t *testing.T) {
+	t.Run("should report transcription unhealthy once it has been silent for two minutes", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		app := newClockedApp(t, &now)
+		app.updateTranscriptionHealth()
+
+		// Act & Assert
+		now = now.Add(119 * time.Second)
+		assert.Equal(t, true, app.getPipelineHealthStatus()["transcription_healthy"])
+
+		now = now.Add(time.Second)
+		status := app.getPipelineHealthStatus()
+		assert.Equal(t, false, status["transcription_healthy"])
+		assert.Equal(t, "2m0s", status["time_since_last_transcription"])
+	})
+
+	t.Run("should allow two minutes after resuming before reporting silence", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		app := newClockedApp(t, &now)
+		app.updateTranscriptionHealth()
+		app.SetPaused(true)
+		now = now.Add(time.Hour)
+		assert.Equal(t, true, app.getPipelineHealthStatus()["transcription_healthy"])
+
+		// Act
+		app.SetPaused(false)
+
+		// Assert
+		now = now.Add(time.Minute)
+		assert.Equal(t, true, app.getPipelineHealthStatus()["transcription_healthy"])
+		now = now.Add(time.Minute)
+		assert.Equal(t, false, app.getPipelineHealthStatus()["transcription_healthy"])
+	})
+}
+
+func TestApplication_Heartbeat(t *testing.T) {
+	t.Run("should write the health file stamped with the application's clock", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		app := newClockedApp(t, &now)
+		app.healthFile = filepath.Join(t.TempDir(), "health.json")
+		app.updateTranscriptionHealth()
+		now = now.Add(5 * time.Minute)
+
+		// Act
+		app.heartbeat()
+
+		// Assert
+		data, err := os.ReadFile(app.healthFile)
+		require.NoError(t, err)
+		var status map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &status))
+		assert.Equal(t, "2025-01-01T12:05:00Z", status["health_check_timestamp"])
+		assert.Equal(t, false, status["transcription_healthy"])
+	})
+}
+
+func TestApplication_CueDeduplicationWindow(t *testing.T) {
+	t.Run("should pass a repeated cue again once the dedup window has passed", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		app := newClockedApp(t, &now)
+		app.cueDedup = dedup.NewWindow(dedup.NewMemoryStoreWithClock(func() time.Time { return now }), time.Minute, nil)
+		cue := parser.NewContestCue("keyword_contest", parser.CueDetails{Keyword: "CASH", Number: "55555"})
+		cue.SetContentHash(now, time.Hour)
+		deliver := func() int {
+			in := make(chan parser.ContestCue, 1)
+			in <- *cue
+			close(in)
+			delivered := 0
+			for range app.wrapContestCueChannelWithHealthTracking(in) {
+				delivered++
+			}
+			return delivered
+		}
+
+		// Act & Assert
+		assert.Equal(t, 1, deliver())
+		now = now.Add(30 * time.Second)
+		assert.Equal(t, 0, deliver(), "should drop the repeat within the window")
+		now = now.Add(time.Minute)
+		assert.Equal(t, 1, deliver(), "should deliver the cue again after the window")
+	})
+}
+
+func TestApplication_TranscriptionGaps(t *testing.T) {
+	t.Run("should count gap markers and pass them on to the parser", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		app := newClockedApp(t, &now)
+		originalCh := make(chan buffer.BufferedContext, 1)
+		wrappedCh := app.wrapBufferedContextChannelWithHealthTracking(originalCh)
+
+		// Act
+		originalCh <- buffer.BufferedContext{GapMS: 185000, CapturedAt: now.Add(-185 * time.Second)}
+		close(originalCh)
+		forwarded := <-wrappedCh
+
+		// Assert
+		assert.True(t, forwarded.IsGap())
+		status := app.getPipelineHealthStatus()
+		assert.Equal(t, int64(1), status["transcription_gaps"])
+		assert.Equal(t, int64(185), status["last_transcription_gap_sec"])
+		// A gap is not buffered context activity
+		assert.Equal(t, time.Time{}.Format(time.RFC3339), status["last_buffered_context_time"])
+	})
+}
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/notifier"
+	"radiocontestwinner/internal/transcriber"
+)
+
+// newIdleMode creates the idle mode reducing transcription to sampling through long silences;
+// nil when it is disabled
+func newIdleMode(cfg *config.Configuration, zapLogger *zap.Logger) (*transcriber.IdleMode, error) {
+	if !cfg.GetTranscriptionIdleEnabled() {
+		return nil, nil
+	}
+	// The idle window is a daily HH:MM range like quiet hours, in the display timezone
+	window, err := notifier.ParseQuietHours(cfg.GetTranscriptionIdleStart(), cfg.GetTranscriptionIdleEnd(), cfg.GetTimezone())
+	if err != nil {
+		return nil, fmt.Errorf("invalid transcription idle window: %w", err)
+	}
+	idleConfig := transcriber.IdleConfig{
+		After:          time.Duration(cfg.GetTranscriptionIdleAfterSec()) * time.Second,
+		SampleInterval: time.Duration(cfg.GetTranscriptionIdleSampleIntervalSec()) * time.Second,
+		SilenceDBFS:    cfg.GetTranscriptionIdleSilenceDBFS(),
+	}
+	if window.Enabled() {
+		idleConfig.Window = window.Active
+	}
+	return transcriber.NewIdleMode(idleConfig, time.Now(), zapLogger), nil
+}
+
+// idleStatus returns the transcription idle mode state; never idle when idle mode is disabled
+func (app *Application) idleStatus() transcriber.IdleStatus {
+	if app.transcriptionEngine == nil {
+		return transcriber.IdleStatus{}
+	}
+	if idle := app.transcriptionEngine.IdleMode(); idle != nil {
+		return idle.Status()
+	}
+	return transcriber.IdleStatus{}
+}
+
+// idleModeStatus formats the idle mode state for health output
+func idleModeStatus(status transcriber.IdleStatus) map[string]interface{} {
+	health := map[string]interface{}{
+		"active":         status.Idle,
+		"skipped_chunks": status.SkippedChunks,
+	}
+	if status.Idle {
+		health["since"] = status.Since.UTC().Format(time.RFC3339)
+	}
+	return health
+}
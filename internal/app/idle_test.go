@@ -0,0 +1,48 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
+)
+
+func TestNewIdleMode(t *testing.T) {
+	t.Run("should leave idle mode off unless enabled", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+
+		idle, err := newIdleMode(cfg, zap.NewNop())
+
+		require.NoError(t, err)
+		assert.Nil(t, idle)
+	})
+
+	t.Run("should reject an invalid idle window", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetTranscriptionIdleEnabled(true)
+		cfg.SetTranscriptionIdleWindow("25:00", "06:00")
+
+		_, err := newIdleMode(cfg, zap.NewNop())
+
+		assert.ErrorContains(t, err, "invalid transcription idle window")
+	})
+
+	t.Run("should report idle mode in health when enabled", func(t *testing.T) {
+		// Arrange
+		cfg := config.NewConfiguration()
+		cfg.SetTranscriptionIdleEnabled(true)
+		cfg.SetTranscriptionIdleWindow("00:00", "06:00")
+
+		// Act
+		app, err := NewApplicationWithConfig(cfg)
+
+		// Assert
+		require.NoError(t, err)
+		require.NotNil(t, app.transcriptionEngine.IdleMode())
+		assert.Equal(t, map[string]interface{}{"active": false, "skipped_chunks": int64(0)},
+			app.getPipelineHealthStatus()["idle_mode"])
+	})
+}
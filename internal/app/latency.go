@@ -0,0 +1,87 @@
+package app
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/health"
+	"radiocontestwinner/internal/parser"
+)
+
+// recordCueLatency adds an emitted cue's end-to-end latency, from audio capture to emission, to
+// the cue latency histogram. Cues whose capture time is unknown are skipped.
+func (app *Application) recordCueLatency(cue parser.ContestCue) {
+	if cue.Timing == nil || cue.Timing.AudioCapturedAt.IsZero() {
+		return
+	}
+	app.pipelineHealth.cueLatency.Record(time.Duration(cue.Timing.LatencyMS) * time.Millisecond)
+}
+
+// latencySLO compares the cue latency percentile with the configured SLO
+type latencySLO struct {
+	Percentile      float64
+	Target          time.Duration
+	Actual          time.Duration // Cue latency at Percentile
+	WithinTargetPct float64       // Share of cues emitted within Target
+	Met             bool          // True until a cue has been recorded
+}
+
+// cueLatencySLO evaluates the recorded cue latencies against the SLO
+func (app *Application) cueLatencySLO() latencySLO {
+	slo := latencySLO{
+		Percentile: app.config.GetLatencySLOPercentile(),
+		Target:     time.Duration(app.config.GetLatencySLOCueTargetSec()) * time.Second,
+	}
+	slo.Actual = app.pipelineHealth.cueLatency.Percentile(slo.Percentile)
+	slo.WithinTargetPct = app.pipelineHealth.cueLatency.Within(slo.Target)
+	slo.Met = slo.Actual <= slo.Target
+	return slo
+}
+
+// checkLatencySLO logs when the cue latency SLO starts or stops being met
+func (app *Application) checkLatencySLO() {
+	slo := app.cueLatencySLO()
+	if slo.Met != app.latencySLOMissed {
+		return
+	}
+	app.latencySLOMissed = !slo.Met
+
+	fields := []zap.Field{
+		zap.Float64("percentile", slo.Percentile),
+		zap.Int64("latency_ms", slo.Actual.Milliseconds()),
+		zap.Int64("target_ms", slo.Target.Milliseconds()),
+		zap.Float64("within_target_pct", slo.WithinTargetPct),
+	}
+	if slo.Met {
+		app.zapLogger.Info("cue latency SLO met again", fields...)
+	} else {
+		app.zapLogger.Warn("cue latency SLO missed", fields...)
+	}
+}
+
+// latencyStatus formats latency percentiles for health output
+func latencyStatus(snapshot health.LatencySnapshot) map[string]interface{} {
+	return map[string]interface{}{
+		"count":   snapshot.Count,
+		"mean_ms": snapshot.Mean.Milliseconds(),
+		"p50_ms":  snapshot.P50.Milliseconds(),
+		"p95_ms":  snapshot.P95.Milliseconds(),
+		"p99_ms":  snapshot.P99.Milliseconds(),
+		"max_ms":  snapshot.Max.Milliseconds(),
+	}
+}
+
+// cueLatencyStatus formats the cue latency percentiles and SLO for health output
+func (app *Application) cueLatencyStatus() map[string]interface{} {
+	status := latencyStatus(app.pipelineHealth.cueLatency.Snapshot())
+	slo := app.cueLatencySLO()
+	status["slo"] = map[string]interface{}{
+		"percentile":        slo.Percentile,
+		"target_ms":         slo.Target.Milliseconds(),
+		"actual_ms":         slo.Actual.Milliseconds(),
+		"within_target_pct": slo.WithinTargetPct,
+		"met":               slo.Met,
+	}
+	return status
+}
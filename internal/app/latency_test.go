@@ -0,0 +1,96 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/api"
+	"radiocontestwinner/internal/parser"
+)
+
+// cueHeardAgo returns a cue whose audio was captured latency before it was emitted
+func cueHeardAgo(latency time.Duration) parser.ContestCue {
+	emittedAt := time.Now()
+	return parser.ContestCue{Timing: parser.NewCueTiming(emittedAt.Add(-latency), time.Time{}, emittedAt)}
+}
+
+func TestApplication_CueLatencySLO(t *testing.T) {
+	app, err := NewApplication()
+	require.NoError(t, err)
+	app.config.SetLatencySLOCueTargetSec(10)
+
+	t.Run("should meet the SLO before any cue is recorded", func(t *testing.T) {
+		status := app.getPipelineHealthStatus()["cue_latency"].(map[string]interface{})
+
+		assert.Equal(t, int64(0), status["count"])
+		assert.Equal(t, true, status["slo"].(map[string]interface{})["met"])
+	})
+
+	t.Run("should skip cues whose capture time is unknown", func(t *testing.T) {
+		app.recordCueLatency(parser.ContestCue{})
+
+		assert.Equal(t, int64(0), app.pipelineHealth.cueLatency.Snapshot().Count)
+	})
+
+	t.Run("should report percentiles and meet the SLO while cues are fast", func(t *testing.T) {
+		// Arrange
+		for i := 0; i < 19; i++ {
+			app.recordCueLatency(cueHeardAgo(4 * time.Second))
+		}
+		app.recordCueLatency(cueHeardAgo(40 * time.Second))
+
+		// Act
+		app.checkLatencySLO()
+		status := app.getPipelineHealthStatus()["cue_latency"].(map[string]interface{})
+
+		// Assert
+		assert.Equal(t, int64(20), status["count"])
+		assert.InEpsilon(t, 4000, status["p50_ms"], 0.02)
+		assert.InEpsilon(t, 4000, status["p95_ms"], 0.02)
+		assert.InEpsilon(t, 40000, status["p99_ms"], 0.02)
+		slo := status["slo"].(map[string]interface{})
+		assert.Equal(t, true, slo["met"])
+		assert.Equal(t, 95.0, slo["within_target_pct"])
+		assert.False(t, app.latencySLOMissed)
+	})
+
+	t.Run("should miss the SLO once slow cues reach the percentile", func(t *testing.T) {
+		// Arrange
+		for i := 0; i < 5; i++ {
+			app.recordCueLatency(cueHeardAgo(40 * time.Second))
+		}
+
+		// Act
+		app.checkLatencySLO()
+
+		// Assert
+		assert.True(t, app.latencySLOMissed)
+		slo := app.cueLatencyStatus()["slo"].(map[string]interface{})
+		assert.Equal(t, false, slo["met"])
+		assert.Equal(t, int64(10000), slo["target_ms"])
+	})
+
+	t.Run("should expose latency percentiles and the SLO as metrics", func(t *testing.T) {
+		// Arrange
+		server := api.NewServer("127.0.0.1:0", api.NewHub(), zap.NewNop())
+		server.ServeMetrics(app.metrics)
+		rec := httptest.NewRecorder()
+
+		// Act
+		server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		// Assert
+		body := rec.Body.String()
+		assert.Contains(t, body, "# TYPE radiocontestwinner_cue_latency_seconds summary\n")
+		assert.Contains(t, body, "radiocontestwinner_cue_latency_seconds_count 25\n")
+		assert.Contains(t, body, `radiocontestwinner_cue_latency_slo_target_seconds{quantile="0.95"} 10`)
+		assert.Contains(t, body, "radiocontestwinner_cue_latency_slo_met 0\n")
+		assert.Contains(t, body, "# TYPE radiocontestwinner_transcription_latency_seconds summary\n")
+	})
+}
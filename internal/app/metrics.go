@@ -0,0 +1,191 @@
+package app
+
+import (
+	"sort"
+	"strconv"
+
+	"radiocontestwinner/internal/api"
+	"radiocontestwinner/internal/health"
+	"radiocontestwinner/internal/version"
+)
+
+// metricsPrefix names the metrics served at GET /metrics
+const metricsPrefix = "radiocontestwinner_"
+
+// metrics collects the samples served at GET /metrics. In a multi-tenant deployment each
+// tenant serves its own samples, labelled with the tenant name.
+func (app *Application) metrics() []api.Metric {
+	app.pipelineHealth.mu.RLock()
+	transcriptions := app.pipelineHealth.totalTranscriptions
+	cues := app.pipelineHealth.totalContestCues
+	depths := app.pipelineHealth.channelDepths
+	backlog := app.pipelineHealth.currentBacklogSize
+	versions := app.pipelineHealth.versions
+	app.pipelineHealth.mu.RUnlock()
+
+	metrics := []api.Metric{
+		{Name: metricsPrefix + "transcriptions_total", Help: "Transcriptions produced.", Type: api.MetricCounter, Value: float64(transcriptions)},
+		{Name: metricsPrefix + "contest_cues_total", Help: "Contest cues detected, including duplicates.", Type: api.MetricCounter, Value: float64(cues)},
+	}
+	metrics = append(metrics, latencyMetrics(metricsPrefix+"transcription_latency_seconds",
+		"Processing latency of each transcription.", app.pipelineHealth.transcriptionLatency.Snapshot())...)
+	metrics = append(metrics, latencyMetrics(metricsPrefix+"cue_latency_seconds",
+		"End-to-end latency of each cue, from audio capture to emission.", app.pipelineHealth.cueLatency.Snapshot())...)
+	metrics = append(metrics, app.latencySLOMetrics()...)
+	metrics = append(metrics, app.streamMetrics()...)
+	metrics = append(metrics, app.backlogMetrics(depths, backlog)...)
+	metrics = append(metrics, app.audioLevelMetrics()...)
+	metrics = append(metrics, app.usageMetrics()...)
+	metrics = append(metrics, buildInfoMetric(versions))
+
+	if tenant := app.config.GetTenantName(); tenant != "" {
+		for i := range metrics {
+			metrics[i].Labels = withLabel(metrics[i].Labels, "tenant", tenant)
+		}
+	}
+	return metrics
+}
+
+// latencySLOMetrics exposes the cue latency SLO target and whether it is met
+func (app *Application) latencySLOMetrics() []api.Metric {
+	slo := app.cueLatencySLO()
+	return []api.Metric{
+		{Name: metricsPrefix + "cue_latency_slo_target_seconds", Help: "Cue latency the SLO percentile must stay within.", Type: api.MetricGauge,
+			Labels: map[string]string{"quantile": quantile(slo.Percentile)}, Value: slo.Target.Seconds()},
+		{Name: metricsPrefix + "cue_latency_slo_within_target_ratio", Help: "Share of cues emitted within the SLO target.", Type: api.MetricGauge,
+			Value: slo.WithinTargetPct / 100},
+		{Name: metricsPrefix + "cue_latency_slo_met", Help: "1 while the cue latency SLO is met.", Type: api.MetricGauge,
+			Value: boolValue(slo.Met)},
+	}
+}
+
+// streamMetrics exposes the stream listening statistics since start
+func (app *Application) streamMetrics() []api.Metric {
+	stats := app.streamConnector.ListeningStats()
+	return []api.Metric{
+		{Name: metricsPrefix + "stream_connected", Help: "1 while the stream is connected.", Type: api.MetricGauge, Value: boolValue(stats.Connected)},
+		{Name: metricsPrefix + "stream_connected_seconds_total", Help: "Time the stream has been connected.", Type: api.MetricCounter, Value: stats.ConnectedTime.Seconds()},
+		{Name: metricsPrefix + "stream_disconnects_total", Help: "Established stream connections lost or closed.", Type: api.MetricCounter, Value: float64(stats.Disconnects)},
+		{Name: metricsPrefix + "stream_longest_outage_seconds", Help: "Longest stream outage, including one in progress.", Type: api.MetricGauge, Value: stats.LongestOutage.Seconds()},
+		{Name: metricsPrefix + "stream_received_bytes_total", Help: "Stream bytes received.", Type: api.MetricCounter, Value: float64(stats.BytesReceived)},
+	}
+}
+
+// backlogMetrics exposes the depth and capacity of each pipeline channel as last sampled
+func (app *Application) backlogMetrics(depths map[string]int, backlog int) []api.Metric {
+	metrics := []api.Metric{
+		{Name: metricsPrefix + "pipeline_backlog_items", Help: "Items queued across all pipeline channels.", Type: api.MetricGauge, Value: float64(backlog)},
+	}
+	for i, channel := range sortedKeys(depths) {
+		metric := api.Metric{Name: metricsPrefix + "pipeline_channel_depth", Labels: map[string]string{"channel": channel}, Value: float64(depths[channel])}
+		if i == 0 {
+			metric.Help, metric.Type = "Items queued in a pipeline channel.", api.MetricGauge
+		}
+		metrics = append(metrics, metric)
+	}
+	capacities := app.backlog.capacities()
+	for i, channel := range sortedKeys(capacities) {
+		metric := api.Metric{Name: metricsPrefix + "pipeline_channel_capacity", Labels: map[string]string{"channel": channel}, Value: float64(capacities[channel])}
+		if i == 0 {
+			metric.Help, metric.Type = "Capacity of a pipeline channel.", api.MetricGauge
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
+
+// audioLevelMetrics exposes the audio levels before and after automatic gain control
+func (app *Application) audioLevelMetrics() []api.Metric {
+	levels := app.gainControl.Levels()
+	return []api.Metric{
+		{Name: metricsPrefix + "agc_enabled", Help: "1 when automatic gain control is enabled.", Type: api.MetricGauge, Value: boolValue(app.gainControl.Enabled())},
+		{Name: metricsPrefix + "audio_input_level_dbfs", Help: "Smoothed RMS level of the decoded audio.", Type: api.MetricGauge, Value: levels.InputDBFS},
+		{Name: metricsPrefix + "audio_output_level_dbfs", Help: "Smoothed RMS level of the audio passed to transcription.", Type: api.MetricGauge, Value: levels.OutputDBFS},
+		{Name: metricsPrefix + "agc_gain_db", Help: "Gain currently applied by automatic gain control.", Type: api.MetricGauge, Value: levels.GainDB},
+	}
+}
+
+// usageMetrics exposes the seconds of audio each transcription backend has transcribed, as
+// recorded in the usage ledger
+func (app *Application) usageMetrics() []api.Metric {
+	if app.usageLedger == nil {
+		return nil
+	}
+	totals := app.usageLedger.Totals()
+	var metrics []api.Metric
+	for i, backend := range sortedKeys(totals) {
+		metric := api.Metric{Name: metricsPrefix + "transcribed_audio_seconds_total", Labels: map[string]string{"backend": backend}, Value: totals[backend]}
+		if i == 0 {
+			metric.Help, metric.Type = "Seconds of audio transcribed by each backend.", api.MetricCounter
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
+
+// buildInfoMetric exposes the build metadata and tool versions as labels of a constant gauge.
+// Tool versions are empty until the model has loaded.
+func buildInfoMetric(versions version.Info) api.Metric {
+	if versions.Version == "" {
+		versions = version.Get()
+	}
+	return api.Metric{
+		Name: metricsPrefix + "build_info",
+		Help: "Build metadata and tool versions; always 1.",
+		Type: api.MetricGauge,
+		Labels: map[string]string{
+			"version":             versions.Version,
+			"commit":              versions.Commit,
+			"build_date":          versions.BuildDate,
+			"go_version":          versions.GoVersion,
+			"platform":            versions.Platform,
+			"whisper_cpp_version": versions.WhisperCpp,
+			"ffmpeg_version":      versions.FFmpeg,
+		},
+		Value: 1,
+	}
+}
+
+// latencyMetrics exposes a latency histogram as a summary of its common percentiles
+func latencyMetrics(name, help string, snapshot health.LatencySnapshot) []api.Metric {
+	return []api.Metric{
+		{Name: name, Help: help, Type: api.MetricSummary, Labels: map[string]string{"quantile": "0.5"}, Value: snapshot.P50.Seconds()},
+		{Name: name, Labels: map[string]string{"quantile": "0.95"}, Value: snapshot.P95.Seconds()},
+		{Name: name, Labels: map[string]string{"quantile": "0.99"}, Value: snapshot.P99.Seconds()},
+		{Name: name, Suffix: "_sum", Value: snapshot.Sum.Seconds()},
+		{Name: name, Suffix: "_count", Value: float64(snapshot.Count)},
+	}
+}
+
+// quantile formats a percentile as a Prometheus quantile, e.g. 95 as "0.95"
+func quantile(percentile float64) string {
+	return strconv.FormatFloat(percentile/100, 'g', -1, 64)
+}
+
+// boolValue is 1 for true and 0 for false
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// withLabel returns a copy of labels with name set to value
+func withLabel(labels map[string]string, name, value string) map[string]string {
+	copied := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		copied[k] = v
+	}
+	copied[name] = value
+	return copied
+}
+
+// sortedKeys returns the keys of m in order, so samples are served in a stable order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,119 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/api"
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/usage"
+	"radiocontestwinner/internal/version"
+)
+
+// newMetricsApp creates an application that keeps usage in memory
+func newMetricsApp(t *testing.T) *Application {
+	t.Helper()
+	cfg := config.NewConfiguration()
+	cfg.SetUsagePath("")
+	app, err := NewApplicationWithConfig(cfg)
+	require.NoError(t, err)
+	return app
+}
+
+// scrapeMetrics returns the body of GET /metrics for app
+func scrapeMetrics(t *testing.T, app *Application) string {
+	t.Helper()
+	server := api.NewServer("127.0.0.1:0", api.NewHub(), zap.NewNop())
+	server.ServeMetrics(app.metrics)
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	return rec.Body.String()
+}
+
+func TestApplication_Metrics(t *testing.T) {
+	t.Run("should expose the stream listening statistics", func(t *testing.T) {
+		// Act
+		body := scrapeMetrics(t, newMetricsApp(t))
+
+		// Assert
+		assert.Contains(t, body, "radiocontestwinner_stream_connected 0\n")
+		assert.Contains(t, body, "# TYPE radiocontestwinner_stream_disconnects_total counter\n")
+		assert.Contains(t, body, "# TYPE radiocontestwinner_stream_longest_outage_seconds gauge\n")
+		assert.Contains(t, body, "radiocontestwinner_stream_received_bytes_total 0\n")
+	})
+
+	t.Run("should expose the pipeline channel depths and capacities", func(t *testing.T) {
+		// Arrange
+		app := newMetricsApp(t)
+		app.backlog.track("transcription", func() int { return 3 }, 10)
+		app.recordChannelBacklog(time.Now())
+
+		// Act
+		body := scrapeMetrics(t, app)
+
+		// Assert
+		assert.Contains(t, body, "radiocontestwinner_pipeline_backlog_items 3\n")
+		assert.Contains(t, body, `radiocontestwinner_pipeline_channel_depth{channel="transcription"} 3`)
+		assert.Contains(t, body, `radiocontestwinner_pipeline_channel_capacity{channel="transcription"} 10`)
+	})
+
+	t.Run("should expose the audio levels before and after gain control", func(t *testing.T) {
+		// Act
+		body := scrapeMetrics(t, newMetricsApp(t))
+
+		// Assert
+		assert.Contains(t, body, "radiocontestwinner_agc_enabled 0\n")
+		assert.Contains(t, body, "# TYPE radiocontestwinner_audio_input_level_dbfs gauge\n")
+		assert.Contains(t, body, "# TYPE radiocontestwinner_audio_output_level_dbfs gauge\n")
+		assert.Contains(t, body, "radiocontestwinner_agc_gain_db 0\n")
+	})
+
+	t.Run("should expose the build metadata as labels", func(t *testing.T) {
+		// Act
+		body := scrapeMetrics(t, newMetricsApp(t))
+
+		// Assert
+		assert.Contains(t, body, "# TYPE radiocontestwinner_build_info gauge\n")
+		assert.Contains(t, body, `version="`+version.Version+`"`)
+		assert.Contains(t, body, `go_version="`)
+	})
+
+	t.Run("should label every sample with the tenant", func(t *testing.T) {
+		// Arrange
+		tenants, err := NewTenants(newTenantsConfig(t))
+		require.NoError(t, err)
+
+		// Act
+		metrics := tenants.apps[0].metrics()
+
+		// Assert
+		require.NotEmpty(t, metrics)
+		for _, metric := range metrics {
+			assert.Equal(t, "kiss", metric.Labels["tenant"], metric.Name)
+		}
+		assert.Contains(t, scrapeMetrics(t, tenants.apps[1]), `radiocontestwinner_transcriptions_total{tenant="wxyz"} 0`)
+		assert.NotContains(t, scrapeMetrics(t, newMetricsApp(t)), "tenant=")
+	})
+
+	t.Run("should expose the audio transcribed by each backend", func(t *testing.T) {
+		// Arrange
+		app := newMetricsApp(t)
+		app.usageLedger.Record(usage.BackendBinaryGPU, 30)
+		app.usageLedger.Record(usage.BackendAPI, 5)
+
+		// Act
+		body := scrapeMetrics(t, app)
+
+		// Assert
+		assert.Contains(t, body, "# TYPE radiocontestwinner_transcribed_audio_seconds_total counter\n")
+		assert.Contains(t, body, `radiocontestwinner_transcribed_audio_seconds_total{backend="api"} 5`)
+		assert.Contains(t, body, `radiocontestwinner_transcribed_audio_seconds_total{backend="binary_gpu"} 30`)
+	})
+}
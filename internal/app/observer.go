@@ -0,0 +1,51 @@
+package app
+
+import (
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/parser"
+	"radiocontestwinner/internal/transcriber"
+)
+
+// PipelineObserver receives transcriptions and contest cues as they flow through the pipeline,
+// e.g. to show them on an operator console. Calls are made from pipeline goroutines and must
+// not block.
+type PipelineObserver interface {
+	OnTranscription(segment transcriber.TranscriptionSegment)
+	OnContestCue(cue parser.ContestCue)
+}
+
+// SetObserver attaches an observer to the pipeline; call before Run
+func (app *Application) SetObserver(observer PipelineObserver) {
+	app.observer = observer
+}
+
+// HealthStatus returns the current pipeline health status
+func (app *Application) HealthStatus() map[string]interface{} {
+	return app.getPipelineHealthStatus()
+}
+
+// DebugMode returns whether debug logging is enabled
+func (app *Application) DebugMode() bool {
+	return app.config.GetDebugMode()
+}
+
+// SetDebugMode turns debug logging on or off while the application runs
+func (app *Application) SetDebugMode(enabled bool) {
+	app.config.SetDebugMode(enabled)
+}
+
+// MaintenanceMode returns whether notifications are suppressed for maintenance
+func (app *Application) MaintenanceMode() bool {
+	return app.notifier != nil && app.notifier.Maintenance()
+}
+
+// SetMaintenanceMode turns notification suppression for maintenance on or off while the
+// application runs; cues are still logged and stored
+func (app *Application) SetMaintenanceMode(enabled bool) {
+	if app.notifier == nil {
+		return
+	}
+	app.notifier.SetMaintenance(enabled)
+	app.zapLogger.Info("maintenance mode changed", zap.Bool("enabled", enabled))
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/parser"
+	"radiocontestwinner/internal/transcriber"
+)
+
+// recordingObserver records what the pipeline reports
+type recordingObserver struct {
+	mu       sync.Mutex
+	segments []transcriber.TranscriptionSegment
+	cues     []parser.ContestCue
+}
+
+func (o *recordingObserver) OnTranscription(segment transcriber.TranscriptionSegment) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.segments = append(o.segments, segment)
+}
+
+func (o *recordingObserver) OnContestCue(cue parser.ContestCue) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.cues = append(o.cues, cue)
+}
+
+func TestApplication_Observer(t *testing.T) {
+	t.Run("should report transcriptions and cues to the observer", func(t *testing.T) {
+		// Arrange
+		app, err := NewApplication()
+		require.NoError(t, err)
+		observer := &recordingObserver{}
+		app.SetObserver(observer)
+
+		segments := make(chan transcriber.TranscriptionSegment, 1)
+		segments <- transcriber.TranscriptionSegment{Text: "text CASH to 55555", StartMS: 0, EndMS: 5000, Confidence: 0.9}
+		close(segments)
+		cues := make(chan parser.ContestCue, 1)
+		cues <- *parser.NewContestCue("CASH", parser.CueDetails{Keyword: "CASH", Number: "55555"})
+		close(cues)
+
+		// Act
+		for range app.wrapTranscriptionChannelWithHealthTracking(segments) {
+		}
+		for range app.wrapContestCueChannelWithHealthTracking(cues) {
+		}
+
+		// Assert
+		require.Len(t, observer.segments, 1)
+		assert.Equal(t, "text CASH to 55555", observer.segments[0].Text)
+		require.Len(t, observer.cues, 1)
+		assert.Equal(t, "CASH", observer.cues[0].ContestType)
+	})
+
+	t.Run("should toggle debug mode at runtime", func(t *testing.T) {
+		// Arrange
+		app, err := NewApplication()
+		require.NoError(t, err)
+		initial := app.DebugMode()
+
+		// Act
+		app.SetDebugMode(!initial)
+
+		// Assert
+		assert.Equal(t, !initial, app.DebugMode())
+		assert.Contains(t, app.HealthStatus(), "stream_connected")
+	})
+}
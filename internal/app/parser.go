@@ -0,0 +1,89 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/parser"
+	"radiocontestwinner/internal/program"
+)
+
+// newContestParser creates a contest parser from the parser settings and allowlist of cfg. Cues
+// record the label and source of the allowlist entry they matched, for audits, and entries
+// limited to an active window follow station time through daylight saving changes.
+func newContestParser(cfg *config.Configuration, location *time.Location, programs *program.Schedule,
+	substitutions *parser.SubstitutionDictionary, zapLogger *zap.Logger) (*parser.ContestParser, error) {
+	allowlistEntries := allowlistEntries(cfg)
+	if err := parser.ValidateAllowlistEntries(allowlistEntries); err != nil {
+		return nil, fmt.Errorf("invalid allowlist: %w", err)
+	}
+	contestParser := parser.NewContestParserWithLogger(cfg.GetAllowlist(), zapLogger)
+	contestParser.SetAllowlistEntries(allowlistEntries, location)
+	if err := contestParser.ConfigureNormalization(cfg.GetNormalizationSteps(), cfg.GetNormalizationHomophones()); err != nil {
+		return nil, fmt.Errorf("failed to configure text normalization: %w", err)
+	}
+
+	contestParser.SetSubstitutions(substitutions)
+	contestParser.SetCueHashBucket(time.Duration(cfg.GetCueHashBucketSec()) * time.Second)
+	contestParser.SetKeywordFilter(parser.NewKeywordFilter(cfg.GetKeywordMinLength(), cfg.GetKeywordMaxLength(), cfg.GetKeywordStopWords()))
+	if canonical := cfg.GetKeywordCanonical(); len(canonical) > 0 {
+		contestParser.SetKeywordCanonicalizer(parser.NewKeywordCanonicalizer(canonical))
+	}
+	contestParser.SetMinConfidence(cfg.GetParserMinConfidence())
+
+	// Stations in other languages announce cues with their own trigger words
+	patternWords, err := parser.PatternWordsForLanguage(cfg.GetParserPatternLanguage())
+	if err != nil {
+		return nil, fmt.Errorf("invalid parser pattern: %w", err)
+	}
+	if words := cfg.GetParserPatternTextWords(); len(words) > 0 {
+		patternWords.Text = words
+	}
+	if words := cfg.GetParserPatternToWords(); len(words) > 0 {
+		patternWords.To = words
+	}
+	if err := contestParser.SetPatternWords(patternWords); err != nil {
+		return nil, err
+	}
+
+	// Name the show each cue was heard in, and relax or tighten matching during scheduled shows
+	if programs != nil {
+		contestParser.SetProgramSchedule(programs)
+	}
+	return contestParser, nil
+}
+
+// allowlistEntries returns the allowlist of cfg as parser entries
+func allowlistEntries(cfg *config.Configuration) []parser.AllowlistEntry {
+	var entries []parser.AllowlistEntry
+	for _, entry := range cfg.GetAllowlistEntries() {
+		entries = append(entries, parser.AllowlistEntry{
+			Value:    entry.Number,
+			Label:    entry.Label,
+			Source:   entry.Source,
+			Modified: entry.Modified,
+			Days:     entry.Days,
+			Start:    entry.Start,
+			End:      entry.End,
+			Expires:  entry.Expires,
+		})
+	}
+	return entries
+}
+
+// newSubstitutions loads the ASR substitution dictionary from the inline config and the optional
+// substitution file
+func newSubstitutions(cfg *config.Configuration) (*parser.SubstitutionDictionary, error) {
+	substitutions := cfg.GetSubstitutions()
+	if path := cfg.GetSubstitutionFile(); path != "" {
+		fileEntries, err := parser.LoadSubstitutionFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load substitutions: %w", err)
+		}
+		substitutions = parser.MergeSubstitutions(substitutions, fileEntries)
+	}
+	return parser.NewSubstitutionDictionary(substitutions), nil
+}
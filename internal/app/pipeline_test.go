@@ -357,7 +357,7 @@ func TestPipeline_ContestCueDetection(t *testing.T) {
 
 						// Validate details
 						for key, expectedValue := range tc.expectedDetails {
-							actualValue, exists := receivedCue.Details[key]
+							actualValue, exists := receivedCue.Details.Get(key)
 							assert.True(t, exists, "Expected detail key %s should exist", key)
 							assert.Equal(t, expectedValue, actualValue, "Detail value for %s should match", key)
 						}
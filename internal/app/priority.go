@@ -0,0 +1,42 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/priority"
+)
+
+// applyProcessPriority applies the configured niceness, GOMAXPROCS, and child CPU affinity.
+// Settings the operating system does not support are logged and skipped; invalid settings fail.
+func applyProcessPriority(cfg *config.Configuration, logger *zap.Logger) error {
+	if n := cfg.GetProcessGOMAXPROCS(); n > 0 {
+		runtime.GOMAXPROCS(n)
+		logger.Info("limited Go scheduler CPUs", zap.Int("gomaxprocs", n))
+	}
+
+	if nice := cfg.GetProcessNice(); nice != 0 {
+		if err := priority.SetNice(nice); err != nil {
+			if !errors.Is(err, priority.ErrUnsupported) {
+				return err
+			}
+			logger.Warn("process niceness is not supported on this platform", zap.Int("nice", nice))
+		} else {
+			logger.Info("set process niceness", zap.Int("nice", nice))
+		}
+	}
+
+	cpus, err := priority.ParseCPUList(cfg.GetProcessCPUAffinity())
+	if err != nil {
+		return fmt.Errorf("invalid process.cpu_affinity: %w", err)
+	}
+	priority.SetChildAffinity(cpus)
+	if len(cpus) > 0 {
+		logger.Info("pinning FFmpeg and Whisper processes to CPUs", zap.Ints("cpus", cpus))
+	}
+	return nil
+}
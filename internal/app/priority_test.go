@@ -0,0 +1,42 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/priority"
+)
+
+func TestApplyProcessPriority(t *testing.T) {
+	t.Run("should configure child process affinity", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetProcessCPUAffinity("0")
+		defer priority.SetChildAffinity(nil)
+
+		err := applyProcessPriority(cfg, zap.NewNop())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{0}, priority.ChildAffinity())
+	})
+
+	t.Run("should reject an invalid CPU list", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetProcessCPUAffinity("0-x")
+
+		err := applyProcessPriority(cfg, zap.NewNop())
+
+		assert.ErrorContains(t, err, "process.cpu_affinity")
+	})
+
+	t.Run("should reject niceness outside the valid range", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetProcessNice(40)
+
+		err := applyProcessPriority(cfg, zap.NewNop())
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/program"
+)
+
+// scheduleFetchTimeout bounds fetching the remote program schedule at startup
+const scheduleFetchTimeout = 10 * time.Second
+
+// newProgramSchedule creates the station's program schedule from the configured programs, replaced
+// by the remote schedule when one is configured. It returns nil when there is no schedule. A
+// remote schedule that cannot be fetched at startup is not fatal; it is retried on refresh.
+func newProgramSchedule(cfg *config.Configuration, location *time.Location, zapLogger *zap.Logger) (*program.Schedule, error) {
+	configured := cfg.GetSchedulePrograms()
+	url := cfg.GetScheduleURL()
+	if len(configured) == 0 && url == "" {
+		return nil, nil
+	}
+
+	programs := make([]program.Program, 0, len(configured))
+	for _, p := range configured {
+		programs = append(programs, program.Program{
+			Name:            p.Name,
+			Days:            p.Days,
+			Start:           p.Start,
+			End:             p.End,
+			ContestHeavy:    p.ContestHeavy,
+			ConfidenceBoost: p.ConfidenceBoost,
+		})
+	}
+	schedule, err := program.NewSchedule(programs, location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid program schedule: %w", err)
+	}
+
+	if url != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), scheduleFetchTimeout)
+		defer cancel()
+		if err := schedule.Refresh(ctx, &http.Client{}, url); err != nil {
+			zapLogger.Warn("failed to fetch program schedule, using the configured programs until it is refreshed",
+				zap.String("url", url),
+				zap.Error(err))
+		}
+	}
+	zapLogger.Info("loaded program schedule", zap.Int("programs", schedule.Len()))
+	return schedule, nil
+}
+
+// addProgramStatus adds the show on the air to the health status
+func (app *Application) addProgramStatus(status map[string]interface{}) {
+	if app.programs == nil {
+		return
+	}
+	if show, ok := app.programs.At(app.currentTime()); ok {
+		status["program"] = show.Name
+		status["program_contest_heavy"] = show.ContestHeavy
+	}
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
+)
+
+func TestNewProgramSchedule(t *testing.T) {
+	t.Run("should be nil without programs or a schedule URL", func(t *testing.T) {
+		schedule, err := newProgramSchedule(config.NewConfiguration(), time.UTC, zap.NewNop())
+
+		require.NoError(t, err)
+		assert.Nil(t, schedule)
+	})
+
+	t.Run("should reject invalid configured programs", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetSchedulePrograms([]config.ScheduledProgram{{Name: "Morning Drive", Start: "6am", End: "10:00"}})
+
+		_, err := newProgramSchedule(cfg, time.UTC, zap.NewNop())
+
+		assert.ErrorContains(t, err, "invalid program schedule")
+	})
+
+	t.Run("should replace the configured programs with the remote schedule", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `[{"name":"Remote Drive","start":"06:00","end":"10:00"}]`)
+		}))
+		defer server.Close()
+		cfg := config.NewConfiguration()
+		cfg.SetSchedulePrograms([]config.ScheduledProgram{{Name: "Morning Drive", Start: "06:00", End: "10:00"}})
+		cfg.SetScheduleURL(server.URL)
+
+		// Act
+		schedule, err := newProgramSchedule(cfg, time.UTC, zap.NewNop())
+
+		// Assert
+		require.NoError(t, err)
+		show, ok := schedule.At(time.Date(2025, 6, 2, 7, 0, 0, 0, time.UTC))
+		assert.True(t, ok)
+		assert.Equal(t, "Remote Drive", show.Name)
+	})
+
+	t.Run("should keep the configured programs when the remote schedule is unavailable", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		cfg := config.NewConfiguration()
+		cfg.SetSchedulePrograms([]config.ScheduledProgram{{Name: "Morning Drive", Start: "06:00", End: "10:00", ContestHeavy: true}})
+		cfg.SetScheduleURL(server.URL)
+
+		// Act
+		schedule, err := newProgramSchedule(cfg, time.UTC, zap.NewNop())
+
+		// Assert
+		require.NoError(t, err)
+		app := &Application{programs: schedule, now: func() time.Time { return time.Date(2025, 6, 2, 7, 0, 0, 0, time.UTC) }}
+		status := map[string]interface{}{}
+		app.addProgramStatus(status)
+		assert.Equal(t, "Morning Drive", status["program"])
+		assert.Equal(t, true, status["program_contest_heavy"])
+	})
+}
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/anomaly"
+	"radiocontestwinner/internal/health"
+	"radiocontestwinner/internal/notifier"
+)
+
+// channelNotifier forwards notifications to a channel so tests can wait on async delivery
+type channelNotifier struct {
+	ch chan notifier.Notification
+}
+
+func (c *channelNotifier) Name() string { return "channel" }
+
+func (c *channelNotifier) Notify(ctx context.Context, n notifier.Notification) error {
+	c.ch <- n
+	return nil
+}
+
+func TestApplication_TranscriptionRateAnomaly(t *testing.T) {
+	app, err := NewApplication()
+	require.NoError(t, err)
+
+	alerts := &channelNotifier{ch: make(chan notifier.Notification, 10)}
+	app.notifier = notifier.NewDispatcher(nil, alerts)
+	app.rateDetector = anomaly.NewRateDetector(anomaly.RateDetectorConfig{
+		Window:         time.Minute,
+		BaselineWindow: 5 * time.Minute,
+		LowRatio:       0.25,
+		HighRatio:      4.0,
+		MinBaselineWPM: 10,
+	})
+
+	start := time.Now().Add(-10 * time.Minute)
+	busy := strings.Repeat("word ", 20)
+	for at := start; at.Before(start.Add(6 * time.Minute)); at = at.Add(10 * time.Second) {
+		app.rateDetector.Record(busy, at)
+	}
+
+	t.Run("should report normal rate in health status", func(t *testing.T) {
+		app.checkTranscriptionRate(start.Add(6 * time.Minute))
+
+		healthStatus := app.getPipelineHealthStatus()
+		assert.Equal(t, "normal", healthStatus["transcription_rate_state"])
+		assert.False(t, healthStatus["degraded"].(bool))
+
+		select {
+		case n := <-alerts.ch:
+			assert.Equal(t, "Transcription rate recovered", n.Title)
+		case <-time.After(time.Second):
+			t.Fatal("expected notification for transition to normal")
+		}
+	})
+
+	t.Run("should flag collapsed rate as degraded and notify", func(t *testing.T) {
+		app.checkTranscriptionRate(start.Add(7 * time.Minute))
+
+		healthStatus := app.getPipelineHealthStatus()
+		assert.Equal(t, "collapsed", healthStatus["transcription_rate_state"])
+		assert.True(t, healthStatus["degraded"].(bool))
+
+		// Degraded is reported separately from unhealthy
+		healthStatus["healthy"] = app.isSystemHealthy(healthStatus)
+		assert.True(t, healthStatus["healthy"].(bool))
+		assert.Equal(t, "degraded", health.State(healthStatus))
+
+		select {
+		case n := <-alerts.ch:
+			assert.Equal(t, notifier.KindAlert, n.Kind)
+			assert.Equal(t, notifier.SeverityWarning, n.Severity)
+			assert.Equal(t, "collapsed", n.Fields["state"])
+		case <-time.After(time.Second):
+			t.Fatal("expected anomaly notification")
+		}
+	})
+}
+
+// statusNotifier forwards status updates to a channel so tests can wait on async delivery
+type statusNotifier struct {
+	channelNotifier
+	statuses chan notifier.Status
+}
+
+func (s *statusNotifier) NotifyStatus(ctx context.Context, status notifier.Status) error {
+	s.statuses <- status
+	return nil
+}
+
+func TestApplication_DispatchStatus(t *testing.T) {
+	// Arrange
+	app, err := NewApplication()
+	require.NoError(t, err)
+	status := &statusNotifier{statuses: make(chan notifier.Status, 1)}
+	app.notifier = notifier.NewDispatcher(nil, status)
+
+	// Act
+	app.dispatchStatus(map[string]interface{}{"stream_connected": true, "healthy": true, "degraded": true})
+
+	// Assert
+	select {
+	case got := <-status.statuses:
+		assert.True(t, got.StreamConnected)
+		assert.False(t, got.PipelineHealthy)
+		assert.Equal(t, "degraded", got.State)
+	case <-time.After(time.Second):
+		t.Fatal("expected status update")
+	}
+}
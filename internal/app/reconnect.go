@@ -0,0 +1,40 @@
+package app
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/stream"
+)
+
+// newReconnectThrottle creates the stream reconnect throttle, or returns nil when neither a
+// reconnect rate limit nor a cooldown is configured
+func newReconnectThrottle(cfg *config.Configuration, zapLogger *zap.Logger) *stream.ReconnectThrottle {
+	maxPerMinute := cfg.GetStreamReconnectMaxPerMinute()
+	cooldownAfter := cfg.GetStreamReconnectCooldownAfterFailures()
+	if maxPerMinute <= 0 && cooldownAfter <= 0 {
+		return nil
+	}
+	return stream.NewReconnectThrottle(stream.ThrottleOptions{
+		MaxPerMinute:          maxPerMinute,
+		CooldownAfterFailures: cooldownAfter,
+		Cooldown:              time.Duration(cfg.GetStreamReconnectCooldownSec()) * time.Second,
+		Jitter:                cfg.GetRestartJitter(),
+		StatePath:             cfg.GetStreamReconnectStateFile(),
+	}, zapLogger)
+}
+
+// addReconnectStatus adds the stream reconnect cooldown to the health status
+func (app *Application) addReconnectStatus(status map[string]interface{}) {
+	if app.reconnectThrottle == nil {
+		return
+	}
+	until := app.reconnectThrottle.CooldownUntil()
+	status["stream_reconnect_cooldown"] = !until.IsZero()
+	if !until.IsZero() {
+		status["stream_reconnect_cooldown_until"] = until.Format(time.RFC3339)
+	}
+	status["stream_consecutive_failures"] = app.reconnectThrottle.ConsecutiveFailures()
+}
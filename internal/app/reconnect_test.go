@@ -0,0 +1,43 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
+)
+
+func TestNewReconnectThrottle(t *testing.T) {
+	t.Run("should be nil without a rate limit or cooldown", func(t *testing.T) {
+		assert.Nil(t, newReconnectThrottle(config.NewConfiguration(), zap.NewNop()))
+	})
+
+	t.Run("should report the reconnect cooldown in the health status", func(t *testing.T) {
+		// Arrange
+		cfg := config.NewConfiguration()
+		cfg.SetStreamReconnectCooldownAfterFailures(2)
+		cfg.SetStreamReconnectStateFile(filepath.Join(t.TempDir(), "reconnect.json"))
+		throttle := newReconnectThrottle(cfg, zap.NewNop())
+		require.NotNil(t, throttle)
+		app := &Application{reconnectThrottle: throttle}
+
+		// Act
+		for i := 0; i < 2; i++ {
+			require.NoError(t, throttle.Wait(context.Background()))
+			throttle.Record(errors.New("connection refused"))
+		}
+
+		// Assert
+		status := map[string]interface{}{}
+		app.addReconnectStatus(status)
+		assert.Equal(t, true, status["stream_reconnect_cooldown"])
+		assert.NotEmpty(t, status["stream_reconnect_cooldown_until"])
+		assert.Equal(t, 0, status["stream_consecutive_failures"])
+	})
+}
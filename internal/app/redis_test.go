@@ -0,0 +1,78 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/dedup"
+	"radiocontestwinner/internal/parser"
+	"radiocontestwinner/internal/redis"
+	"radiocontestwinner/internal/redis/redistest"
+	"radiocontestwinner/internal/transcriber"
+)
+
+func TestApplication_CueDeduplication(t *testing.T) {
+	t.Run("should drop repeated cues within the dedup window", func(t *testing.T) {
+		// Arrange
+		app, err := NewApplication()
+		require.NoError(t, err)
+		app.cueDedup = dedup.NewWindow(dedup.NewMemoryStore(), time.Minute, nil)
+		at := time.Now()
+		first := parser.NewContestCue("keyword_contest", parser.CueDetails{Keyword: "CASH", Number: "55555"})
+		first.SetContentHash(at, time.Minute)
+		repeat := parser.NewContestCue("keyword_contest", parser.CueDetails{Keyword: "CASH", Number: "55555"})
+		repeat.ContentHash = first.ContentHash
+		other := parser.NewContestCue("keyword_contest", parser.CueDetails{Keyword: "ROCK", Number: "55555"})
+		other.SetContentHash(at, time.Minute)
+
+		in := make(chan parser.ContestCue, 3)
+		in <- *first
+		in <- *repeat
+		in <- *other
+		close(in)
+
+		// Act
+		var out []parser.ContestCue
+		for cue := range app.wrapContestCueChannelWithHealthTracking(in) {
+			out = append(out, cue)
+		}
+
+		// Assert
+		require.Len(t, out, 2)
+		assert.Equal(t, first.CueID, out[0].CueID)
+		assert.Equal(t, other.CueID, out[1].CueID)
+	})
+}
+
+func TestApplication_TranscriptHistory(t *testing.T) {
+	t.Run("should store recent transcripts in Redis", func(t *testing.T) {
+		// Arrange
+		server := redistest.NewServer(t)
+		app, err := NewApplication()
+		require.NoError(t, err)
+		client, err := redis.NewClient(redis.Options{Address: server.Addr()})
+		require.NoError(t, err)
+		defer client.Close()
+		app.transcripts = redis.NewCappedList(client, "radiocontestwinner:transcripts", 10)
+
+		in := make(chan transcriber.TranscriptionSegment, 1)
+		in <- transcriber.TranscriptionSegment{Text: "text CASH to 55555", StartMS: 0, EndMS: 5000, Confidence: 0.9}
+		close(in)
+
+		// Act
+		for range app.wrapTranscriptionChannelWithHealthTracking(in) {
+		}
+
+		// Assert
+		require.Eventually(t, func() bool {
+			return len(server.List("radiocontestwinner:transcripts")) == 1
+		}, time.Second, 10*time.Millisecond)
+		var stored transcriber.TranscriptionSegment
+		require.NoError(t, json.Unmarshal([]byte(server.List("radiocontestwinner:transcripts")[0]), &stored))
+		assert.Equal(t, "text CASH to 55555", stored.Text)
+	})
+}
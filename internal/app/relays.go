@@ -0,0 +1,228 @@
+package app
+
+import (
+	"context"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/parser"
+	"radiocontestwinner/internal/stream"
+)
+
+// Relay stream states reported in health status
+const (
+	relayUnchecked   = "unchecked"
+	relayDuplicate   = "duplicate"
+	relayDistinct    = "distinct"
+	relayUnreachable = "unreachable"
+)
+
+// primaryFingerprintChunks covers a few minutes of a typical 128 kbps stream, so relays running
+// behind the monitored stream by that much are still matched
+const primaryFingerprintChunks = 4096
+
+// minRelayChunks is the fewest chunks a relay sample needs for a meaningful comparison
+const minRelayChunks = 8
+
+// relayGuard detects configured relay streams that carry byte-identical copies of the monitored
+// stream, as network affiliates often do. Duplicates are not transcribed a second time; their
+// labels receive a copy of every cue detected on the monitored stream instead.
+type relayGuard struct {
+	primary    *stream.Fingerprint
+	relays     []config.StreamRelay
+	similarity float64
+	sample     time.Duration
+	logger     *zap.Logger
+	open       func(ctx context.Context, url string) (io.ReadCloser, error)
+
+	mu     sync.Mutex
+	states map[string]string // Relay label to state
+}
+
+// newRelayGuard creates a relayGuard treating relays as duplicates when at least similarity of a
+// sample lasting sample matches the monitored stream
+func newRelayGuard(relays []config.StreamRelay, similarity float64, sample time.Duration, logger *zap.Logger) *relayGuard {
+	states := make(map[string]string, len(relays))
+	for _, relay := range relays {
+		states[relay.Label] = relayUnchecked
+	}
+	return &relayGuard{
+		primary:    stream.NewFingerprint(primaryFingerprintChunks),
+		relays:     relays,
+		similarity: similarity,
+		sample:     sample,
+		logger:     logger,
+		open: func(ctx context.Context, url string) (io.ReadCloser, error) {
+			connector := stream.NewStreamConnectorWithLogger(url, logger)
+			if err := connector.Connect(ctx); err != nil {
+				return nil, err
+			}
+			return connector, nil
+		},
+		states: states,
+	}
+}
+
+// Tap returns the writer the monitored stream's bytes are copied to
+func (g *relayGuard) Tap() io.Writer {
+	return g.primary
+}
+
+// Run checks every relay now and then every interval until ctx is cancelled
+func (g *relayGuard) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = 10 * time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for _, relay := range g.relays {
+			g.check(ctx, relay)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check fingerprints a sample of relay and compares it with the monitored stream
+func (g *relayGuard) check(ctx context.Context, relay config.StreamRelay) {
+	sampled := stream.NewFingerprint(primaryFingerprintChunks)
+	sampleCtx, cancel := context.WithTimeout(ctx, g.sample)
+	body, err := g.open(sampleCtx, relay.URL)
+	if err == nil {
+		io.Copy(sampled, body) // Ends when the sample period is over
+		body.Close()
+	}
+	cancel()
+	if ctx.Err() != nil {
+		return
+	}
+	if sampled.Len() < minRelayChunks {
+		g.logger.Warn("relay stream could not be sampled",
+			zap.String("relay", relay.Label), zap.String("url", relay.URL), zap.Error(err))
+		g.setState(relay.Label, relayUnreachable)
+		return
+	}
+
+	// A relay ahead of the monitored stream matches once the monitored stream catches up
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(g.sample):
+	}
+
+	similarity := g.primary.Similarity(sampled)
+	state := relayDistinct
+	if similarity >= g.similarity {
+		state = relayDuplicate
+	}
+	if g.setState(relay.Label, state) {
+		if state == relayDuplicate {
+			g.logger.Info("relay stream duplicates the monitored stream; fanning out cues instead of transcribing it",
+				zap.String("relay", relay.Label), zap.Float64("similarity", similarity))
+		} else {
+			g.logger.Warn("relay stream differs from the monitored stream and is not monitored; run a separate instance for it",
+				zap.String("relay", relay.Label), zap.Float64("similarity", similarity))
+		}
+	}
+}
+
+// setState records a relay's state and reports whether it changed
+func (g *relayGuard) setState(label, state string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	changed := g.states[label] != state
+	g.states[label] = state
+	return changed
+}
+
+// States returns each relay's state by label
+func (g *relayGuard) States() map[string]string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	states := make(map[string]string, len(g.states))
+	for label, state := range g.states {
+		states[label] = state
+	}
+	return states
+}
+
+// DuplicateLabels returns the labels of relays currently known to duplicate the monitored stream
+func (g *relayGuard) DuplicateLabels() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var labels []string
+	for label, state := range g.states {
+		if state == relayDuplicate {
+			labels = append(labels, label)
+		}
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// fanOut returns cue labelled with the monitored station followed by a copy for each duplicate relay
+func (g *relayGuard) fanOut(cue parser.ContestCue, station string, now time.Time) []parser.ContestCue {
+	cues := []parser.ContestCue{withStation(cue, station)}
+	for _, label := range g.DuplicateLabels() {
+		relayed := withStationMetadata(withStation(cue, label), g.relayStation(label))
+		relayed.CueID = parser.NewUUIDv7(now)
+		cues = append(cues, relayed)
+	}
+	return cues
+}
+
+// relayStation returns the configured station metadata of the relay with label
+func (g *relayGuard) relayStation(label string) config.StationMetadata {
+	for _, relay := range g.relays {
+		if relay.Label == label {
+			return relay.Station
+		}
+	}
+	return config.StationMetadata{}
+}
+
+// withStation returns a copy of cue with a station detail; an empty station leaves cue unchanged
+func withStation(cue parser.ContestCue, station string) parser.ContestCue {
+	if station == "" {
+		return cue
+	}
+	cue.Details = cue.Details.Clone()
+	cue.Details.Set("station", station)
+	return cue
+}
+
+// stationDetailKeys maps station metadata fields to the cue details they are recorded in
+var stationDetailKeys = map[string]string{
+	"call_letters": "station_call_letters",
+	"market":       "station_market",
+	"frequency":    "station_frequency",
+	"timezone":     "station_timezone",
+}
+
+// withStationMetadata returns a copy of cue whose station_* details describe station, replacing
+// any station metadata already attached (e.g. the monitored station's on a relay copy)
+func withStationMetadata(cue parser.ContestCue, station config.StationMetadata) parser.ContestCue {
+	details := cue.Details.Clone()
+	details.Delete("station_name")
+	for _, key := range stationDetailKeys {
+		details.Delete(key)
+	}
+
+	for field, value := range station.Fields() {
+		details.Set(stationDetailKeys[field], value)
+	}
+	if name := station.DisplayName(); name != "" {
+		details.Set("station_name", name)
+	}
+	cue.Details = details
+	return cue
+}
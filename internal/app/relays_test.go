@@ -0,0 +1,134 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/parser"
+)
+
+// relayFeed returns reproducible pseudo-random bytes standing in for a compressed stream
+func relayFeed(seed int64, n int) []byte {
+	data := make([]byte, n)
+	rand.New(rand.NewSource(seed)).Read(data)
+	return data
+}
+
+// newTestRelayGuard creates a relayGuard whose relays are served from bodies by URL
+func newTestRelayGuard(relays []config.StreamRelay, bodies map[string][]byte) *relayGuard {
+	guard := newRelayGuard(relays, 0.8, time.Millisecond, zap.NewNop())
+	guard.open = func(ctx context.Context, url string) (io.ReadCloser, error) {
+		body, ok := bodies[url]
+		if !ok {
+			return nil, errors.New("connection refused")
+		}
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return guard
+}
+
+func TestRelayGuard_Check(t *testing.T) {
+	t.Run("should classify relays by comparing them with the monitored stream", func(t *testing.T) {
+		// Arrange
+		feed := relayFeed(1, 256*1024)
+		relays := []config.StreamRelay{
+			{Label: "KAAA", URL: "http://affiliate/stream"},
+			{Label: "KBBB", URL: "http://other/stream"},
+			{Label: "KCCC", URL: "http://down/stream"},
+		}
+		guard := newTestRelayGuard(relays, map[string][]byte{
+			"http://affiliate/stream": feed[50000:150000],
+			"http://other/stream":     relayFeed(2, 100000),
+		})
+		guard.Tap().Write(feed)
+
+		// Act
+		for _, relay := range relays {
+			guard.check(context.Background(), relay)
+		}
+
+		// Assert
+		assert.Equal(t, map[string]string{
+			"KAAA": relayDuplicate,
+			"KBBB": relayDistinct,
+			"KCCC": relayUnreachable,
+		}, guard.States())
+		assert.Equal(t, []string{"KAAA"}, guard.DuplicateLabels())
+	})
+
+	t.Run("should report relays as unchecked before the first check", func(t *testing.T) {
+		guard := newTestRelayGuard([]config.StreamRelay{{Label: "KAAA", URL: "http://affiliate/stream"}}, nil)
+
+		assert.Equal(t, map[string]string{"KAAA": relayUnchecked}, guard.States())
+		assert.Empty(t, guard.DuplicateLabels())
+	})
+}
+
+func TestRelayGuard_FanOut(t *testing.T) {
+	t.Run("should copy cues to each duplicate relay label", func(t *testing.T) {
+		// Arrange
+		guard := newTestRelayGuard([]config.StreamRelay{{Label: "KAAA"}, {Label: "KBBB"}}, nil)
+		guard.setState("KAAA", relayDuplicate)
+		guard.setState("KBBB", relayDistinct)
+		cue := parser.ContestCue{CueID: "cue-1", ContestType: "keyword", Details: parser.CueDetails{Keyword: "WIN"}}
+
+		// Act
+		cues := guard.fanOut(cue, "KMAIN", time.Now())
+
+		// Assert
+		require.Len(t, cues, 2)
+		assert.Equal(t, "cue-1", cues[0].CueID)
+		assert.Equal(t, "KMAIN", cues[0].Details.Extensions["station"])
+		assert.NotEqual(t, "cue-1", cues[1].CueID)
+		assert.Equal(t, "KAAA", cues[1].Details.Extensions["station"])
+		assert.Equal(t, "WIN", cues[1].Details.Keyword)
+		assert.NotContains(t, cue.Details.Extensions, "station")
+	})
+
+	t.Run("should leave cues unlabelled without a station label", func(t *testing.T) {
+		guard := newTestRelayGuard(nil, nil)
+		cue := parser.ContestCue{CueID: "cue-1", Details: parser.CueDetails{}}
+
+		cues := guard.fanOut(cue, "", time.Now())
+
+		assert.Equal(t, []parser.ContestCue{cue}, cues)
+	})
+}
+
+func TestWithStationMetadata(t *testing.T) {
+	t.Run("should attach station details and replace the monitored station's on relay copies", func(t *testing.T) {
+		// Arrange
+		guard := newTestRelayGuard([]config.StreamRelay{
+			{Label: "KAAA", Station: config.StationMetadata{CallLetters: "KAAA", Market: "Waco"}},
+			{Label: "KBBB"},
+		}, nil)
+		guard.setState("KAAA", relayDuplicate)
+		guard.setState("KBBB", relayDuplicate)
+		main := config.StationMetadata{CallLetters: "KXYZ", Market: "Austin", Frequency: "101.5", Timezone: "America/Chicago"}
+		cue := withStationMetadata(parser.ContestCue{CueID: "cue-1", Details: parser.CueDetails{Keyword: "WIN"}}, main)
+
+		// Act
+		cues := guard.fanOut(cue, "KMAIN", time.Now())
+
+		// Assert
+		require.Len(t, cues, 3)
+		assert.Equal(t, "KXYZ 101.5 Austin", cues[0].Details.Extensions["station_name"])
+		assert.Equal(t, "America/Chicago", cues[0].Details.Extensions["station_timezone"])
+		assert.Equal(t, "KAAA Waco", cues[1].Details.Extensions["station_name"])
+		assert.Equal(t, "Waco", cues[1].Details.Extensions["station_market"])
+		assert.NotContains(t, cues[1].Details.Extensions, "station_frequency")
+		assert.NotContains(t, cues[2].Details.Extensions, "station_name")
+		assert.NotContains(t, cues[2].Details.Extensions, "station_call_letters")
+		assert.Equal(t, "WIN", cues[2].Details.Keyword)
+	})
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"fmt"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/parser"
+	"radiocontestwinner/internal/scrub"
+	"radiocontestwinner/internal/transcriber"
+)
+
+// newScrubber creates the scrubber masking personal data and profanity in stored and outbound
+// text; nil when scrubbing is disabled
+func newScrubber(cfg *config.Configuration) (*scrub.Scrubber, error) {
+	if !cfg.GetScrubEnabled() {
+		return nil, nil
+	}
+	scrubber, err := scrub.New(scrub.Config{
+		PhoneNumbers: cfg.GetScrubPhoneNumbers(),
+		Names:        cfg.GetScrubNames(),
+		Introduced:   cfg.GetScrubIntroducedNames(),
+		Profanity:    cfg.GetScrubProfanity(),
+		Words:        cfg.GetScrubProfanityWords(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid scrub configuration: %w", err)
+	}
+	return scrubber, nil
+}
+
+// scrubSegment returns the segment as it may be stored or shown: with its text scrubbed when
+// scrubbing is enabled. The pipeline keeps matching contests against the original.
+func (app *Application) scrubSegment(segment transcriber.TranscriptionSegment) transcriber.TranscriptionSegment {
+	if app.scrubber == nil {
+		return segment
+	}
+	segment.Text = app.scrubber.Scrub(segment.Text)
+	// Word timings would still spell out what the text masks
+	segment.Words = nil
+	return segment
+}
+
+// scrubCue masks the transcription text a cue carries before it is logged or sent out, keeping
+// the contest number it matched
+func (app *Application) scrubCue(cue *parser.ContestCue) {
+	if app.scrubber == nil {
+		return
+	}
+	number := cue.Details.Number
+	cue.Details = cue.Details.Clone()
+	cue.Details.OriginalText = app.scrubber.Scrub(cue.Details.OriginalText, number)
+	cue.Details.ReconstructedText = app.scrubber.Scrub(cue.Details.ReconstructedText, number)
+	if corrected := cue.Details.String("corrected_from"); corrected != "" {
+		cue.Details.Set("corrected_from", app.scrubber.Scrub(corrected, number))
+	}
+}
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/api"
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/parser"
+	"radiocontestwinner/internal/transcriber"
+)
+
+func TestApplication_Scrub(t *testing.T) {
+	t.Run("should scrub what is shown but pass the original text on to the parser", func(t *testing.T) {
+		// Arrange
+		cfg := config.NewConfiguration()
+		cfg.SetScrubEnabled(true)
+		cfg.SetScrubNames([]string{"Jane Doe"})
+		app, err := NewApplicationWithConfig(cfg)
+		require.NoError(t, err)
+		app.live = api.NewHub()
+		sub := app.live.Subscribe([]string{api.KindTranscript})
+		defer sub.Close()
+
+		text := "Jane Doe, call 512-555-1234 or text CASH to 55555, damn shit"
+		segments := make(chan transcriber.TranscriptionSegment, 1)
+		segments <- transcriber.TranscriptionSegment{Text: text, EndMS: 5000,
+			Words: []transcriber.Word{{Text: "Jane"}}}
+		close(segments)
+
+		// Act
+		var passed []transcriber.TranscriptionSegment
+		for segment := range app.wrapTranscriptionChannelWithHealthTracking(segments) {
+			passed = append(passed, segment)
+		}
+
+		// Assert
+		require.Len(t, passed, 1)
+		assert.Equal(t, text, passed[0].Text)
+		var shown transcriber.TranscriptionSegment
+		require.NoError(t, json.Unmarshal((<-sub.Events()).Data, &shown))
+		assert.Equal(t, "[name], call [phone] or text CASH to 55555, damn s***", shown.Text)
+		assert.Empty(t, shown.Words)
+	})
+
+	t.Run("should scrub cue text but keep the matched number", func(t *testing.T) {
+		// Arrange
+		cfg := config.NewConfiguration()
+		cfg.SetScrubEnabled(true)
+		app, err := NewApplicationWithConfig(cfg)
+		require.NoError(t, err)
+		original := parser.CueDetails{Keyword: "WIN", Number: "5551234",
+			OriginalText: "text WIN to 5551234, my name is Jane, call 555-9876"}
+		cues := make(chan parser.ContestCue, 1)
+		cues <- *parser.NewContestCue("WIN", original)
+		close(cues)
+
+		// Act
+		var out []parser.ContestCue
+		for cue := range app.wrapContestCueChannelWithHealthTracking(cues) {
+			out = append(out, cue)
+		}
+
+		// Assert
+		require.Len(t, out, 1)
+		assert.Equal(t, "text WIN to 5551234, my name is [name], call [phone]", out[0].Details.OriginalText)
+		assert.Equal(t, "5551234", out[0].Details.Number)
+	})
+
+	t.Run("should leave text alone unless enabled", func(t *testing.T) {
+		scrubber, err := newScrubber(config.NewConfiguration())
+
+		require.NoError(t, err)
+		assert.Nil(t, scrubber)
+	})
+
+	t.Run("should reject an invalid profanity list", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetScrubEnabled(true)
+		cfg.SetScrubProfanityWords([]string{"da*rn"})
+
+		_, err := newScrubber(cfg)
+
+		assert.ErrorContains(t, err, "invalid scrub configuration")
+	})
+}
@@ -0,0 +1,209 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/buffer"
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/parser"
+	"radiocontestwinner/internal/program"
+)
+
+// parserShadowQueue is how many contexts may wait for the shadow parsers before they are skipped
+const parserShadowQueue = 100
+
+// parserShadow runs a candidate parser configuration alongside the active one. It sees the
+// contexts the pipeline's parser sees, but only logs and reports the cues it would create.
+type parserShadow struct {
+	shadow     *parser.Shadow
+	source     string // Candidate config file
+	reportFile string // Where the diff report is written; empty when not written
+	logger     *zap.Logger
+
+	contexts chan buffer.BufferedContext
+	skipped  atomic.Int64 // Contexts not evaluated because the queue was full
+	running  atomic.Bool
+	cancel   context.CancelFunc
+	done     sync.WaitGroup
+}
+
+// newParserShadow creates the shadow evaluation of the candidate config file; nil when none is
+// configured. The active side is a separate parser built from cfg with the pipeline's program
+// schedule and substitutions, so the shadow never affects the parser in the pipeline.
+func newParserShadow(cfg *config.Configuration, location *time.Location, programs *program.Schedule,
+	substitutions *parser.SubstitutionDictionary, zapLogger *zap.Logger) (*parserShadow, error) {
+	path := cfg.GetParserShadowConfigFile()
+	if path == "" {
+		return nil, nil
+	}
+	candidateCfg, err := config.NewConfigurationFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load shadow parser config: %w", err)
+	}
+
+	active, err := newContestParser(cfg, location, programs, substitutions, zap.NewNop())
+	if err != nil {
+		return nil, err
+	}
+	candidateSubstitutions, err := newSubstitutions(candidateCfg)
+	if err != nil {
+		return nil, fmt.Errorf("shadow parser config: %w", err)
+	}
+	candidatePrograms, err := newProgramSchedule(candidateCfg, location, zapLogger)
+	if err != nil {
+		return nil, fmt.Errorf("shadow parser config: %w", err)
+	}
+	candidate, err := newContestParser(candidateCfg, location, candidatePrograms, candidateSubstitutions, zap.NewNop())
+	if err != nil {
+		return nil, fmt.Errorf("shadow parser config: %w", err)
+	}
+
+	return &parserShadow{
+		shadow:     parser.NewShadow(active, candidate),
+		source:     path,
+		reportFile: cfg.GetParserShadowReportFile(),
+		logger:     zapLogger,
+		contexts:   make(chan buffer.BufferedContext, parserShadowQueue),
+	}, nil
+}
+
+// Name identifies the shadow parser among the application's components
+func (ps *parserShadow) Name() string {
+	return "parser_shadow"
+}
+
+// Start evaluates queued contexts in the background until Stop
+func (ps *parserShadow) Start(ctx context.Context) error {
+	ctx, ps.cancel = context.WithCancel(ctx)
+	ps.running.Store(true)
+	ps.done.Add(1)
+	go func() {
+		defer ps.done.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case bc := <-ps.contexts:
+				ps.compare(&bc)
+			}
+		}
+	}()
+	ps.logger.Info("evaluating candidate parser config in shadow", zap.String("config_file", ps.source))
+	return nil
+}
+
+// Stop ends the evaluation and logs its summary
+func (ps *parserShadow) Stop(ctx context.Context) error {
+	if !ps.running.Swap(false) {
+		return nil
+	}
+	ps.cancel()
+	ps.done.Wait()
+
+	report := ps.shadow.Report()
+	ps.logger.Info("shadow parser summary",
+		zap.String("config_file", ps.source),
+		zap.Int64("contexts", report.Contexts),
+		zap.Int64("agreed", report.Agreed),
+		zap.Int64("active_cues", report.ActiveCues),
+		zap.Int64("candidate_cues", report.CandidateCues),
+		zap.Int64("added", report.Added),
+		zap.Int64("removed", report.Removed))
+	return ps.writeReport(report)
+}
+
+// Healthy returns an error unless the shadow parser has been started and not yet stopped
+func (ps *parserShadow) Healthy() error {
+	if !ps.running.Load() {
+		return errors.New("shadow parser not running")
+	}
+	return nil
+}
+
+// Observe queues a context for the shadow parsers without blocking the pipeline. A nil
+// parserShadow ignores it.
+func (ps *parserShadow) Observe(bc buffer.BufferedContext) {
+	if ps == nil {
+		return
+	}
+	select {
+	case ps.contexts <- bc:
+	default:
+		ps.skipped.Add(1)
+	}
+}
+
+// compare parses one context with both configurations, logging the candidate's would-be cues
+// and any disagreement
+func (ps *parserShadow) compare(bc *buffer.BufferedContext) {
+	diff := ps.shadow.Compare(bc)
+	for _, cue := range diff.Candidate {
+		ps.logger.Info("shadow parser would create cue",
+			zap.String("cue", cue),
+			zap.Strings("trace_ids", diff.TraceIDs))
+	}
+	if !diff.Differs() {
+		return
+	}
+
+	ps.logger.Info("shadow parser disagrees with the active config",
+		zap.Strings("added", diff.Added),
+		zap.Strings("removed", diff.Removed),
+		zap.String("text", diff.Text),
+		zap.Strings("trace_ids", diff.TraceIDs))
+	if err := ps.writeReport(ps.shadow.Report()); err != nil {
+		ps.logger.Warn("failed to write shadow parser report", zap.Error(err))
+	}
+}
+
+// writeReport atomically writes the diff report to the report file, when one is configured
+func (ps *parserShadow) writeReport(report parser.ShadowReport) error {
+	if ps.reportFile == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(struct {
+		ConfigFile string    `json:"config_file"`
+		UpdatedAt  time.Time `json:"updated_at"`
+		parser.ShadowReport
+	}{ps.source, time.Now().UTC(), report}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal shadow parser report: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(ps.reportFile), 0755); err != nil {
+		return fmt.Errorf("failed to create shadow parser report directory: %w", err)
+	}
+	tempFile := ps.reportFile + ".tmp"
+	if err := os.WriteFile(tempFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write shadow parser report: %w", err)
+	}
+	if err := os.Rename(tempFile, ps.reportFile); err != nil {
+		return fmt.Errorf("failed to rename shadow parser report: %w", err)
+	}
+	return nil
+}
+
+// shadowStatus formats the shadow comparison for health output
+func (ps *parserShadow) shadowStatus() map[string]interface{} {
+	report := ps.shadow.Report()
+	return map[string]interface{}{
+		"config_file":    ps.source,
+		"contexts":       report.Contexts,
+		"agreed":         report.Agreed,
+		"active_cues":    report.ActiveCues,
+		"candidate_cues": report.CandidateCues,
+		"added":          report.Added,
+		"removed":        report.Removed,
+		"skipped":        ps.skipped.Load(),
+	}
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/buffer"
+	"radiocontestwinner/internal/config"
+)
+
+func TestNewParserShadow(t *testing.T) {
+	t.Run("should leave shadow evaluation off without a candidate config", func(t *testing.T) {
+		shadow, err := newParserShadow(config.NewConfiguration(), nil, nil, nil, zap.NewNop())
+
+		require.NoError(t, err)
+		assert.Nil(t, shadow)
+	})
+
+	t.Run("should reject a missing candidate config", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetParserShadowConfigFile(filepath.Join(t.TempDir(), "missing.yaml"))
+
+		_, err := newParserShadow(cfg, nil, nil, nil, zap.NewNop())
+
+		assert.ErrorContains(t, err, "failed to load shadow parser config")
+	})
+}
+
+func TestParserShadow_Compare(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	candidateFile := filepath.Join(dir, "candidate.yaml")
+	require.NoError(t, os.WriteFile(candidateFile, []byte("allowlist:\n  numbers: [\"72881\", \"55555\"]\n"), 0644))
+	reportFile := filepath.Join(dir, "shadow.json")
+
+	cfg := config.NewConfiguration()
+	cfg.SetAllowlistEntries([]config.AllowlistEntry{{Number: "72881"}})
+	cfg.SetParserShadowConfigFile(candidateFile)
+	cfg.SetParserShadowReportFile(reportFile)
+	substitutions, err := newSubstitutions(cfg)
+	require.NoError(t, err)
+	shadow, err := newParserShadow(cfg, nil, nil, substitutions, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, shadow.Start(context.Background()))
+
+	// Act
+	shadow.Observe(buffer.BufferedContext{Text: "Text CASH to 72881 or text WIN to 55555"})
+	require.Eventually(t, func() bool {
+		return shadow.shadowStatus()["contexts"] == int64(1)
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, shadow.Stop(context.Background()))
+
+	// Assert
+	status := shadow.shadowStatus()
+	assert.Equal(t, int64(1), status["active_cues"])
+	assert.Equal(t, int64(2), status["candidate_cues"])
+	assert.Equal(t, int64(1), status["added"])
+
+	data, err := os.ReadFile(reportFile)
+	require.NoError(t, err)
+	var report map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.Equal(t, candidateFile, report["config_file"])
+	assert.Equal(t, float64(1), report["added"])
+	assert.Len(t, report["recent"], 1)
+}
+
+func TestParserShadow_Nil(t *testing.T) {
+	t.Run("should ignore contexts", func(t *testing.T) {
+		var shadow *parserShadow
+
+		assert.NotPanics(t, func() { shadow.Observe(buffer.BufferedContext{Text: "Text CASH to 72881"}) })
+	})
+}
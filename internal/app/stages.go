@@ -0,0 +1,81 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/transcriber"
+)
+
+// disabledStages returns the pipeline stages switched off in the configuration, in pipeline order
+func (app *Application) disabledStages() []string {
+	var disabled []string
+	if !app.config.GetTranscriptionEnabled() {
+		disabled = append(disabled, ComponentStream, ComponentFFmpeg, ComponentTranscription)
+	}
+	if !app.config.GetParserEnabled() {
+		disabled = append(disabled, ComponentContextBuffer, ComponentContestParser)
+	}
+	if !app.config.GetParserEnabled() || !app.config.GetLogOutputEnabled() {
+		disabled = append(disabled, ComponentLogOutput)
+	}
+	return disabled
+}
+
+// replayTranscriptions stands in for the stream while transcription is disabled, sending the
+// transcriptions stored in the replay file, as written to the debug transcripts file. The channel
+// is closed at the end of the file or when ctx ends; without a replay file it stays open and idle.
+func (app *Application) replayTranscriptions(ctx context.Context) (<-chan transcriber.TranscriptionSegment, error) {
+	path := app.config.GetTranscriptionReplayFile()
+	if path == "" {
+		app.zapLogger.Warn("transcription is disabled and no replay file is configured; the pipeline has no input")
+		return make(chan transcriber.TranscriptionSegment), nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open transcription replay file: %w", err)
+	}
+	app.zapLogger.Info("transcription is disabled; replaying stored transcriptions", zap.String("path", path))
+
+	segments := make(chan transcriber.TranscriptionSegment, 100)
+	go func() {
+		defer close(segments)
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		replayed, skipped := 0, 0
+		for scanner.Scan() {
+			var segment transcriber.TranscriptionSegment
+			if err := json.Unmarshal(scanner.Bytes(), &segment); err != nil || segment.Text == "" {
+				skipped++
+				continue
+			}
+			select {
+			case segments <- segment:
+				replayed++
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			app.zapLogger.Error("failed to read transcription replay file", zap.String("path", path), zap.Error(err))
+		}
+		app.zapLogger.Info("finished replaying stored transcriptions",
+			zap.String("path", path),
+			zap.Int("replayed", replayed),
+			zap.Int("skipped", skipped))
+	}()
+	return segments, nil
+}
+
+// drain consumes ch until it is closed, for a stage whose downstream stages are disabled
+func drain[T any](ch <-chan T) {
+	for range ch {
+	}
+}
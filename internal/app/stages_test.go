@@ -0,0 +1,107 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/transcriber"
+)
+
+// newStagesTestApp creates an Application from YAML settings, writing its outputs to dir
+func newStagesTestApp(t *testing.T, dir, settings string) *Application {
+	t.Helper()
+	configFile := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(settings), 0644))
+	cfg, err := config.NewConfigurationFromFile(configFile)
+	require.NoError(t, err)
+	app, err := NewApplicationWithConfig(cfg)
+	require.NoError(t, err)
+	app.healthFile = filepath.Join(dir, "health.json")
+	return app
+}
+
+func TestApplication_DisabledStages(t *testing.T) {
+	t.Run("should run every stage by default", func(t *testing.T) {
+		app, err := NewApplication()
+		require.NoError(t, err)
+
+		assert.Empty(t, app.disabledStages())
+		assert.NotContains(t, app.HealthStatus(), "disabled_stages")
+	})
+
+	t.Run("should parse replayed transcriptions without transcribing", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		replayFile := filepath.Join(dir, "transcriptions_debug.log")
+		require.NoError(t, os.WriteFile(replayFile, []byte(
+			`{"timestamp":"2025-06-02T08:00:00Z","text":"Text CASH to 72881 now","start_ms":0,"end_ms":5000,"confidence":0.9}`+"\n"+
+				"not a transcription\n"), 0644))
+		cuesFile := filepath.Join(dir, "cues.log")
+		app := newStagesTestApp(t, dir, fmt.Sprintf(`transcription:
+  enabled: false
+  replay_file: %q
+allowlist:
+  numbers: ["72881"]
+log:
+  sinks:
+    - type: file
+      target: %q
+`, replayFile, cuesFile))
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Act
+		require.NoError(t, app.startPipeline(ctx))
+
+		// Assert
+		assert.Eventually(t, func() bool {
+			data, err := os.ReadFile(cuesFile)
+			return err == nil && len(data) > 0
+		}, 5*time.Second, 20*time.Millisecond, "the replayed cue should be logged")
+		status := app.HealthStatus()
+		assert.Equal(t, []string{ComponentStream, ComponentFFmpeg, ComponentTranscription}, status["disabled_stages"])
+		assert.Equal(t, true, status["transcription_healthy"])
+		assert.Error(t, app.streamConnector.Healthy(), "the stream should not be connected")
+	})
+
+	t.Run("should archive transcriptions when the parser is disabled", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		transcriptsFile := filepath.Join(dir, "transcriptions_debug.log")
+		app := newStagesTestApp(t, dir, fmt.Sprintf(`parser:
+  enabled: false
+debug_transcripts:
+  path: %q
+`, transcriptsFile))
+		segments := make(chan transcriber.TranscriptionSegment, 1)
+		segments <- transcriber.TranscriptionSegment{Text: "Traffic on the hour", EndMS: 5000}
+		close(segments)
+
+		// Act
+		drain(app.wrapTranscriptionChannelWithHealthTracking(segments))
+		require.NoError(t, app.debugTranscripts.Flush())
+
+		// Assert
+		data, err := os.ReadFile(transcriptsFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "Traffic on the hour")
+		assert.Equal(t, []string{ComponentContextBuffer, ComponentContestParser, ComponentLogOutput}, app.disabledStages())
+	})
+
+	t.Run("should fail to start without the replay file", func(t *testing.T) {
+		dir := t.TempDir()
+		app := newStagesTestApp(t, dir, fmt.Sprintf("transcription:\n  enabled: false\n  replay_file: %q\n", filepath.Join(dir, "missing.log")))
+
+		err := app.startPipeline(context.Background())
+
+		assert.ErrorContains(t, err, "replay file")
+	})
+}
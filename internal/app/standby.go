@@ -0,0 +1,56 @@
+package app
+
+import (
+	"go.uber.org/zap"
+)
+
+// Standby reports whether this instance is a warm standby waiting to be promoted
+func (app *Application) Standby() bool {
+	return app.standby.Load()
+}
+
+// Promote takes this instance out of warm standby and starts transcribing. It does nothing
+// when the instance is not a standby.
+func (app *Application) Promote(reason string) {
+	if !app.standby.Load() {
+		return
+	}
+	app.zapLogger.Info("promoting warm standby", zap.String("reason", reason))
+	app.SetPaused(false)
+}
+
+// startStandby puts a standby instance into warm standby before it campaigns for leadership.
+// With coordination enabled it is promoted when it takes the leader lock and drops back into
+// standby when it loses it; without coordination it waits for an operator to promote it.
+func (app *Application) startStandby() {
+	if !app.config.GetCoordinationStandby() {
+		return
+	}
+	if app.elector != nil {
+		app.elector.OnChange(app.onLeadershipChange)
+		if app.elector.IsLeader() {
+			return
+		}
+	}
+	app.enterStandby()
+}
+
+// onLeadershipChange promotes a standby that gained leadership and demotes one that lost it,
+// so only the leader spends GPU time transcribing
+func (app *Application) onLeadershipChange(leader bool) {
+	if leader {
+		app.Promote("acquired the leader lock")
+		return
+	}
+	app.enterStandby()
+}
+
+// enterStandby pauses transcription while the stream and FFmpeg keep running, so promotion
+// resumes transcribing within one chunk
+func (app *Application) enterStandby() {
+	if app.transcriptionEngine == nil || app.standby.Swap(true) {
+		return
+	}
+	app.zapLogger.Info("entering warm standby; stream and FFmpeg stay running but audio is not transcribed")
+	app.SetPaused(true)
+}
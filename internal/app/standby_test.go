@@ -0,0 +1,105 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/coordination"
+)
+
+// newStandbyTestApp creates an Application whose health file, rewritten on every pause and
+// resume, lives in a temporary directory
+func newStandbyTestApp(t *testing.T) *Application {
+	t.Helper()
+	app, err := NewApplication()
+	require.NoError(t, err)
+	app.healthFile = filepath.Join(t.TempDir(), "health.json")
+	return app
+}
+
+func TestApplication_Standby(t *testing.T) {
+	t.Run("should not enter standby unless configured", func(t *testing.T) {
+		app := newStandbyTestApp(t)
+
+		app.startStandby()
+
+		assert.False(t, app.Standby())
+		assert.False(t, app.Paused())
+		assert.Equal(t, false, app.HealthStatus()["standby"])
+	})
+
+	t.Run("should idle the transcriber until promoted", func(t *testing.T) {
+		// Arrange
+		app := newStandbyTestApp(t)
+		app.config.SetCoordinationStandby(true)
+
+		// Act
+		app.startStandby()
+
+		// Assert
+		assert.True(t, app.Standby())
+		assert.True(t, app.Paused())
+		assert.Equal(t, true, app.HealthStatus()["standby"])
+
+		app.Promote("test")
+		assert.False(t, app.Standby())
+		assert.False(t, app.Paused())
+	})
+
+	t.Run("should leave standby when resumed", func(t *testing.T) {
+		app := newStandbyTestApp(t)
+		app.config.SetCoordinationStandby(true)
+		app.startStandby()
+
+		app.SetPaused(false)
+
+		assert.False(t, app.Standby())
+		assert.False(t, app.Paused())
+	})
+
+	t.Run("should take over transcription when the leader goes away", func(t *testing.T) {
+		// Arrange - a leader and a standby sharing a lock file
+		lockFile := filepath.Join(t.TempDir(), "leader.lock")
+		leader := newStandbyTestApp(t)
+		standby := newStandbyTestApp(t)
+		leader.elector = coordination.NewElector(coordination.NewFileLock(lockFile), 10*time.Millisecond, nil)
+		standby.elector = coordination.NewElector(coordination.NewFileLock(lockFile), 10*time.Millisecond, nil)
+		standby.config.SetCoordinationStandby(true)
+
+		leaderCtx, stopLeader := context.WithCancel(context.Background())
+		defer stopLeader()
+		go leader.elector.Run(leaderCtx)
+		require.Eventually(t, leader.elector.IsLeader, time.Second, 5*time.Millisecond)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		standby.startStandby()
+		go standby.elector.Run(ctx)
+		time.Sleep(50 * time.Millisecond)
+		require.True(t, standby.Standby(), "should stay in standby while another instance leads")
+
+		// Act
+		stopLeader()
+
+		// Assert
+		assert.Eventually(t, func() bool { return !standby.Standby() && !standby.Paused() }, 2*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("should return to standby when it loses leadership", func(t *testing.T) {
+		app := newStandbyTestApp(t)
+		app.config.SetCoordinationStandby(true)
+		app.startStandby()
+		app.onLeadershipChange(true)
+		require.False(t, app.Paused())
+
+		app.onLeadershipChange(false)
+
+		assert.True(t, app.Standby())
+		assert.True(t, app.Paused())
+	})
+}
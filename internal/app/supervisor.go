@@ -0,0 +1,212 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/retry"
+)
+
+// Supervised pipeline component names used for restart policies
+const (
+	ComponentStream        = "stream"
+	ComponentFFmpeg        = "ffmpeg"
+	ComponentTranscription = "transcription"
+)
+
+// ErrRestartEscalation is returned by Run when a component exhausts its restart policy
+// and escalation to a full application restart is configured
+var ErrRestartEscalation = errors.New("component restart policy exhausted, escalating to application restart")
+
+// RestartPolicy controls how a failed pipeline component is restarted
+type RestartPolicy struct {
+	MaxRestarts   int           // Consecutive restarts allowed before giving up
+	Backoff       time.Duration // Delay before the first restart, doubled for each consecutive restart
+	MaxBackoff    time.Duration // Upper bound for the restart delay
+	ResetAfter    time.Duration // Running this long without failure resets the restart counter
+	EscalateToApp bool          // Request a full application restart once restarts are exhausted
+	Jitter        float64       // Fraction of each restart delay randomized either way, 0 to 1
+}
+
+// restartPolicyFromConfig reads the restart policy for a component from configuration
+func restartPolicyFromConfig(cfg *config.Configuration, component string) RestartPolicy {
+	return RestartPolicy{
+		MaxRestarts:   cfg.GetRestartMaxRestarts(component),
+		Backoff:       time.Duration(cfg.GetRestartBackoffMS(component)) * time.Millisecond,
+		MaxBackoff:    time.Duration(cfg.GetRestartMaxBackoffMS(component)) * time.Millisecond,
+		ResetAfter:    time.Duration(cfg.GetRestartResetAfterSec(component)) * time.Second,
+		EscalateToApp: cfg.GetRestartEscalate(component),
+		Jitter:        cfg.GetRestartJitter(),
+	}
+}
+
+// Supervisor restarts failed pipeline components according to their restart policies
+type Supervisor struct {
+	logger      *zap.Logger
+	policies    map[string]RestartPolicy
+	mu          sync.Mutex
+	restarts    map[string]int
+	lastFailure map[string]time.Time
+	escalations chan string
+}
+
+// NewSupervisor creates a new Supervisor with the given per-component policies
+func NewSupervisor(logger *zap.Logger, policies map[string]RestartPolicy) *Supervisor {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &Supervisor{
+		logger:      logger,
+		policies:    policies,
+		restarts:    make(map[string]int),
+		lastFailure: make(map[string]time.Time),
+		escalations: make(chan string, 1),
+	}
+}
+
+// NewSupervisorFromConfig creates a Supervisor with policies for the stream, FFmpeg, and transcription components
+func NewSupervisorFromConfig(cfg *config.Configuration, logger *zap.Logger) *Supervisor {
+	policies := make(map[string]RestartPolicy)
+	for _, component := range []string{ComponentStream, ComponentFFmpeg, ComponentTranscription} {
+		policies[component] = restartPolicyFromConfig(cfg, component)
+	}
+	return NewSupervisor(logger, policies)
+}
+
+// Escalations returns a channel that receives the name of a component whose restart
+// policy was exhausted with escalation enabled
+func (s *Supervisor) Escalations() <-chan string {
+	return s.escalations
+}
+
+// RestartCounts returns the current consecutive restart count per component
+func (s *Supervisor) RestartCounts() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counts := make(map[string]int, len(s.restarts))
+	for component, count := range s.restarts {
+		counts[component] = count
+	}
+	return counts
+}
+
+// Restart attempts to restart a failed component following its policy, returning nil once
+// restartFn succeeds or an error when the policy is exhausted or the context is cancelled
+func (s *Supervisor) Restart(ctx context.Context, component string, restartFn func(ctx context.Context) error) error {
+	policy := s.policies[component]
+
+	s.mu.Lock()
+	if last, ok := s.lastFailure[component]; ok && policy.ResetAfter > 0 && time.Since(last) > policy.ResetAfter {
+		s.restarts[component] = 0
+	}
+	s.lastFailure[component] = time.Now()
+	s.mu.Unlock()
+
+	var lastErr error
+	for {
+		s.mu.Lock()
+		attempt := s.restarts[component] + 1
+		if attempt > policy.MaxRestarts {
+			s.mu.Unlock()
+			return s.exhausted(component, policy, lastErr)
+		}
+		s.restarts[component] = attempt
+		s.mu.Unlock()
+
+		delay := policy.Backoff * time.Duration(1<<(attempt-1))
+		if policy.MaxBackoff > 0 && (delay > policy.MaxBackoff || delay <= 0) {
+			delay = policy.MaxBackoff
+		}
+		delay = retry.Jittered(delay, policy.Jitter)
+
+		s.logger.Warn("restarting failed pipeline component",
+			zap.String("component", component),
+			zap.Int("attempt", attempt),
+			zap.Int("max_restarts", policy.MaxRestarts),
+			zap.Duration("backoff", delay))
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("restart of %s cancelled: %w", component, ctx.Err())
+		case <-time.After(delay):
+		}
+
+		if lastErr = restartFn(ctx); lastErr == nil {
+			s.logger.Info("pipeline component restarted successfully",
+				zap.String("component", component),
+				zap.Int("attempt", attempt))
+			return nil
+		}
+
+		s.logger.Warn("pipeline component restart failed",
+			zap.String("component", component),
+			zap.Int("attempt", attempt),
+			zap.Error(lastErr))
+	}
+}
+
+// exhausted reports a component whose restart policy has run out and escalates if configured
+func (s *Supervisor) exhausted(component string, policy RestartPolicy, lastErr error) error {
+	s.logger.Error("pipeline component exceeded restart policy",
+		zap.String("component", component),
+		zap.Int("max_restarts", policy.MaxRestarts),
+		zap.Bool("escalate_to_app", policy.EscalateToApp),
+		zap.Error(lastErr))
+
+	if policy.EscalateToApp {
+		select {
+		case s.escalations <- component:
+		default:
+			// An escalation is already pending
+		}
+	}
+
+	if lastErr != nil {
+		return fmt.Errorf("%s exceeded %d restarts: %w", component, policy.MaxRestarts, lastErr)
+	}
+	return fmt.Errorf("%s exceeded %d restarts", component, policy.MaxRestarts)
+}
+
+// supervisedReader wraps a pipeline reader and restarts its source through the
+// supervisor when reads fail, so downstream consumers see an uninterrupted stream
+type supervisedReader struct {
+	mu         sync.Mutex // Serializes reads so a replaced consumer and its successor never read concurrently
+	ctx        context.Context
+	component  string
+	supervisor *Supervisor
+	source     func() io.Reader
+	restart    func(ctx context.Context) error
+}
+
+// Read implements io.Reader, restarting the source on read errors
+func (r *supervisedReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for {
+		n, err := r.source().Read(p)
+		if n > 0 || err == nil {
+			return n, nil
+		}
+
+		if r.ctx.Err() != nil {
+			return 0, err
+		}
+
+		r.supervisor.logger.Warn("pipeline component read failed",
+			zap.String("component", r.component),
+			zap.Error(err))
+
+		if restartErr := r.supervisor.Restart(r.ctx, r.component, r.restart); restartErr != nil {
+			return 0, err
+		}
+	}
+}
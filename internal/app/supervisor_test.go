@@ -0,0 +1,218 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/transcriber"
+)
+
+func fastPolicy(maxRestarts int, escalate bool) RestartPolicy {
+	return RestartPolicy{
+		MaxRestarts:   maxRestarts,
+		Backoff:       time.Millisecond,
+		MaxBackoff:    5 * time.Millisecond,
+		ResetAfter:    time.Hour,
+		EscalateToApp: escalate,
+	}
+}
+
+func TestSupervisor_Restart(t *testing.T) {
+	t.Run("should retry until the restart succeeds", func(t *testing.T) {
+		// Arrange
+		s := NewSupervisor(nil, map[string]RestartPolicy{ComponentStream: fastPolicy(3, false)})
+		calls := 0
+
+		// Act
+		err := s.Restart(context.Background(), ComponentStream, func(ctx context.Context) error {
+			calls++
+			if calls < 2 {
+				return errors.New("still down")
+			}
+			return nil
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, 2, s.RestartCounts()[ComponentStream])
+	})
+
+	t.Run("should give up and escalate when restarts are exhausted", func(t *testing.T) {
+		// Arrange
+		s := NewSupervisor(nil, map[string]RestartPolicy{ComponentFFmpeg: fastPolicy(2, true)})
+		calls := 0
+
+		// Act
+		err := s.Restart(context.Background(), ComponentFFmpeg, func(ctx context.Context) error {
+			calls++
+			return errors.New("ffmpeg crashed")
+		})
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ffmpeg exceeded 2 restarts")
+		assert.Equal(t, 2, calls)
+		select {
+		case component := <-s.Escalations():
+			assert.Equal(t, ComponentFFmpeg, component)
+		default:
+			t.Fatal("expected escalation")
+		}
+	})
+
+	t.Run("should not escalate when escalation is disabled", func(t *testing.T) {
+		// Arrange
+		s := NewSupervisor(nil, map[string]RestartPolicy{ComponentFFmpeg: fastPolicy(1, false)})
+
+		// Act
+		err := s.Restart(context.Background(), ComponentFFmpeg, func(ctx context.Context) error {
+			return errors.New("ffmpeg crashed")
+		})
+
+		// Assert
+		assert.Error(t, err)
+		select {
+		case <-s.Escalations():
+			t.Fatal("did not expect escalation")
+		default:
+		}
+	})
+
+	t.Run("should reset the restart count after a stable period", func(t *testing.T) {
+		// Arrange
+		policy := fastPolicy(1, false)
+		policy.ResetAfter = 10 * time.Millisecond
+		s := NewSupervisor(nil, map[string]RestartPolicy{ComponentStream: policy})
+		ok := func(ctx context.Context) error { return nil }
+		require.NoError(t, s.Restart(context.Background(), ComponentStream, ok))
+
+		// Act
+		time.Sleep(20 * time.Millisecond)
+		err := s.Restart(context.Background(), ComponentStream, ok)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("should stop when the context is cancelled", func(t *testing.T) {
+		// Arrange
+		policy := fastPolicy(3, false)
+		policy.Backoff = time.Hour
+		policy.MaxBackoff = time.Hour
+		s := NewSupervisor(nil, map[string]RestartPolicy{ComponentStream: policy})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// Act
+		err := s.Restart(ctx, ComponentStream, func(ctx context.Context) error { return nil })
+
+		// Assert
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestNewSupervisorFromConfig(t *testing.T) {
+	cfg := config.NewConfiguration()
+
+	s := NewSupervisorFromConfig(cfg, nil)
+
+	for _, component := range []string{ComponentStream, ComponentFFmpeg, ComponentTranscription} {
+		policy := s.policies[component]
+		assert.Equal(t, 5, policy.MaxRestarts, component)
+		assert.Equal(t, time.Second, policy.Backoff, component)
+		assert.Equal(t, 30*time.Second, policy.MaxBackoff, component)
+		assert.True(t, policy.EscalateToApp, component)
+	}
+}
+
+func TestSupervisedReader_Read(t *testing.T) {
+	t.Run("should restart the source and continue reading", func(t *testing.T) {
+		// Arrange
+		s := NewSupervisor(nil, map[string]RestartPolicy{ComponentStream: fastPolicy(2, false)})
+		var source io.Reader = bytes.NewReader([]byte("first"))
+		reader := &supervisedReader{
+			ctx:        context.Background(),
+			component:  ComponentStream,
+			supervisor: s,
+			source:     func() io.Reader { return source },
+			restart: func(ctx context.Context) error {
+				source = bytes.NewReader([]byte("second"))
+				return nil
+			},
+		}
+
+		// Act
+		buf := make([]byte, 16)
+		n1, err1 := reader.Read(buf)
+		first := string(buf[:n1])
+		n2, err2 := reader.Read(buf)
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.Equal(t, "first", first)
+		assert.NoError(t, err2)
+		assert.Equal(t, "second", string(buf[:n2]))
+	})
+
+	t.Run("should return the read error once restarts are exhausted", func(t *testing.T) {
+		// Arrange
+		s := NewSupervisor(nil, map[string]RestartPolicy{ComponentStream: fastPolicy(1, false)})
+		reader := &supervisedReader{
+			ctx:        context.Background(),
+			component:  ComponentStream,
+			supervisor: s,
+			source:     func() io.Reader { return bytes.NewReader(nil) },
+			restart:    func(ctx context.Context) error { return nil },
+		}
+
+		// Act
+		_, err := reader.Read(make([]byte, 16))
+
+		// Assert
+		assert.ErrorIs(t, err, io.EOF)
+	})
+}
+
+func TestApplication_SuperviseTranscription(t *testing.T) {
+	app, err := NewApplication()
+	require.NoError(t, err)
+	app.supervisor = NewSupervisor(nil, map[string]RestartPolicy{ComponentTranscription: fastPolicy(1, true)})
+
+	t.Run("should forward segments and give up after the restart policy is exhausted", func(t *testing.T) {
+		// Arrange
+		initialCh := make(chan transcriber.TranscriptionSegment, 1)
+		initialCh <- transcriber.TranscriptionSegment{Text: "hello", StartMS: 0, EndMS: 1000, Confidence: 0.9}
+		close(initialCh)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		// Act - the restarted engine reads from an empty source and times out
+		app.config.SetTranscriptionTimeoutSec(0)
+		outputCh := app.superviseTranscription(ctx, bytes.NewReader(nil), initialCh)
+
+		// Assert
+		segment, ok := <-outputCh
+		require.True(t, ok)
+		assert.Equal(t, "hello", segment.Text)
+
+		for range outputCh {
+		}
+		select {
+		case component := <-app.supervisor.Escalations():
+			assert.Equal(t, ComponentTranscription, component)
+		case <-time.After(time.Second):
+			t.Fatal("expected escalation after transcription restarts were exhausted")
+		}
+	})
+}
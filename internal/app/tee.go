@@ -0,0 +1,44 @@
+package app
+
+import (
+	"fmt"
+	"io"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/processor"
+)
+
+// newPCMTee creates the tee copying decoded PCM to external consumers; nil when no target is set
+func newPCMTee(cfg *config.Configuration, zapLogger *zap.Logger) (*processor.PCMTee, error) {
+	target := cfg.GetAudioTeeTarget()
+	if target == "" {
+		return nil, nil
+	}
+	tee, err := processor.NewPCMTee(processor.TeeConfig{
+		Target:      target,
+		BufferBytes: cfg.GetAudioTeeBufferKB() * 1024,
+	}, zapLogger)
+	if err != nil {
+		return nil, fmt.Errorf("invalid audio tee configuration: %w", err)
+	}
+	return tee, nil
+}
+
+// newAudioProcessor creates an FFmpeg audio processor decoding input, teeing its PCM when
+// configured
+func (app *Application) newAudioProcessor(input io.Reader) *processor.AudioProcessor {
+	audioProcessor := processor.NewAudioProcessor(input, app.zapLogger)
+	audioProcessor.SetTee(app.pcmTee)
+	return audioProcessor
+}
+
+// teeStatus formats the PCM tee's consumers and throughput for health output
+func teeStatus(stats processor.TeeStats) map[string]interface{} {
+	return map[string]interface{}{
+		"consumers":     stats.Consumers,
+		"bytes_written": stats.BytesWritten,
+		"bytes_dropped": stats.BytesDropped,
+	}
+}
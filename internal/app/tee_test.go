@@ -0,0 +1,44 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/config"
+)
+
+func TestApplication_PCMTee(t *testing.T) {
+	t.Run("should not tee without a target", func(t *testing.T) {
+		app, err := NewApplicationWithConfig(config.NewConfiguration())
+
+		require.NoError(t, err)
+		assert.Nil(t, app.pcmTee)
+		assert.NotContains(t, app.getPipelineHealthStatus(), "audio_tee")
+	})
+
+	t.Run("should report the tee in health status", func(t *testing.T) {
+		// Arrange
+		cfg := config.NewConfiguration()
+		cfg.SetAudioTeeTarget("unix:" + t.TempDir() + "/pcm.sock")
+
+		// Act
+		app, err := NewApplicationWithConfig(cfg)
+		require.NoError(t, err)
+
+		// Assert
+		require.NotNil(t, app.pcmTee)
+		assert.Equal(t, map[string]interface{}{"consumers": 0, "bytes_written": uint64(0), "bytes_dropped": uint64(0)},
+			app.getPipelineHealthStatus()["audio_tee"])
+	})
+
+	t.Run("should reject an unknown target kind", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetAudioTeeTarget("tcp:localhost:9000")
+
+		_, err := NewApplicationWithConfig(cfg)
+
+		assert.ErrorContains(t, err, "invalid audio tee configuration")
+	})
+}
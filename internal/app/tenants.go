@@ -0,0 +1,152 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/health"
+	"radiocontestwinner/internal/logger"
+)
+
+// Tenants runs one application per tenant of a multi-tenant deployment in a single process.
+// Each tenant has its own streams, allowlists, notifiers, and outputs, and writes its own health
+// file; Tenants also writes a combined health file at the usual location for the -health check.
+type Tenants struct {
+	apps       []*Application
+	names      []string
+	zapLogger  *zap.Logger
+	healthFile string
+}
+
+// NewTenants creates the applications of every tenant defined in cfg
+func NewTenants(cfg *config.Configuration) (*Tenants, error) {
+	configs, err := cfg.TenantConfigurations()
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenants: %w", err)
+	}
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("no tenants defined")
+	}
+
+	if err := cfg.ValidateOfflineMode(); err != nil {
+		return nil, err
+	}
+
+	// Process-wide settings come from the top level, not from any one tenant
+	tenants := &Tenants{zapLogger: logger.NewLogger(), healthFile: health.DefaultFile}
+	if err := applyProcessPriority(cfg, tenants.zapLogger); err != nil {
+		return nil, err
+	}
+	for _, tenantCfg := range configs {
+		application, err := NewApplicationWithConfig(tenantCfg)
+		if err != nil {
+			return nil, fmt.Errorf("tenant %s: %w", tenantCfg.GetTenantName(), err)
+		}
+		tenants.apps = append(tenants.apps, application)
+		tenants.names = append(tenants.names, tenantCfg.GetTenantName())
+	}
+	return tenants, nil
+}
+
+// Names returns the tenant names in the order they run
+func (t *Tenants) Names() []string {
+	return t.names
+}
+
+// Run runs every tenant until ctx is cancelled. A tenant that fails is logged and left stopped
+// so it does not take the others down; the errors of all failed tenants are returned.
+func (t *Tenants) Run(ctx context.Context) error {
+	t.zapLogger.Info("starting tenants", zap.Strings("tenants", t.names))
+
+	go t.startHeartbeat(ctx)
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(t.apps))
+	for i, application := range t.apps {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := application.Run(ctx); err != nil {
+				t.zapLogger.Error("tenant stopped with an error",
+					zap.String("tenant", t.names[i]),
+					zap.Error(err))
+				errs[i] = fmt.Errorf("tenant %s: %w", t.names[i], err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// HandleControlSignals pauses and resumes every tenant on the control signals until ctx is cancelled
+func (t *Tenants) HandleControlSignals(ctx context.Context) {
+	for _, application := range t.apps {
+		go application.HandleControlSignals(ctx)
+	}
+}
+
+// Shutdown shuts every tenant down, returning the errors of those that failed to
+func (t *Tenants) Shutdown() error {
+	var errs []error
+	for i, application := range t.apps {
+		if err := application.Shutdown(); err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s: %w", t.names[i], err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// startHeartbeat writes the combined health file every 30 seconds until ctx is cancelled
+func (t *Tenants) startHeartbeat(ctx context.Context) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		if err := writeHealthFile(t.healthFile, t.healthSnapshot()); err != nil {
+			t.zapLogger.Warn("failed to write combined health status file", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// healthSnapshot combines the health of every tenant: healthy when all are healthy, degraded
+// when any is degraded, and paused only when all are paused
+func (t *Tenants) healthSnapshot() map[string]interface{} {
+	healthy, degraded, paused := true, false, true
+	statuses := make(map[string]interface{}, len(t.apps))
+	for i, application := range t.apps {
+		status := application.healthSnapshot()
+		statuses[t.names[i]] = status
+		if ok, _ := status["healthy"].(bool); !ok {
+			healthy = false
+		}
+		if ok, _ := status["degraded"].(bool); ok {
+			degraded = true
+		}
+		if ok, _ := status["paused"].(bool); !ok {
+			paused = false
+		}
+	}
+
+	combined := map[string]interface{}{
+		"health_check_timestamp": time.Now().Format(time.RFC3339),
+		"healthy":                healthy,
+		"degraded":               degraded,
+		"paused":                 paused,
+		"pid":                    os.Getpid(),
+		"tenants":                statuses,
+	}
+	combined["status"] = health.State(combined)
+	return combined
+}
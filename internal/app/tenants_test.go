@@ -0,0 +1,96 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/parser"
+)
+
+// newTenantsConfig loads a config defining two tenants writing under a temporary directory
+func newTenantsConfig(t *testing.T) *config.Configuration {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	yaml := fmt.Sprintf(`
+log:
+  file_path: %[1]s/cues.log
+debug_transcripts:
+  path: %[1]s/transcriptions.log
+notifier:
+  queue:
+    dir: ""
+tenants:
+  kiss:
+    allowlist:
+      numbers: ["72881"]
+  wxyz:
+    stream:
+      url: https://wxyz.example.com/stream
+`, dir)
+	require.NoError(t, os.WriteFile(path, []byte(yaml), 0644))
+	cfg, err := config.NewConfigurationFromFile(path)
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestNewTenants(t *testing.T) {
+	t.Run("should create an application per tenant with its own config", func(t *testing.T) {
+		// Act
+		tenants, err := NewTenants(newTenantsConfig(t))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"kiss", "wxyz"}, tenants.Names())
+		require.Len(t, tenants.apps, 2)
+		assert.Equal(t, []string{"72881"}, tenants.apps[0].config.GetAllowlist())
+		assert.Equal(t, "https://wxyz.example.com/stream", tenants.apps[1].config.GetStreamURL())
+		assert.Equal(t, "/tmp/radiocontestwinner-health-kiss.json", tenants.apps[0].healthFile)
+	})
+
+	t.Run("should fail without tenants", func(t *testing.T) {
+		_, err := NewTenants(config.NewConfiguration())
+
+		assert.ErrorContains(t, err, "no tenants defined")
+	})
+}
+
+func TestTenants_HealthSnapshot(t *testing.T) {
+	t.Run("should combine the health of every tenant", func(t *testing.T) {
+		// Arrange
+		tenants, err := NewTenants(newTenantsConfig(t))
+		require.NoError(t, err)
+
+		// Act
+		status := tenants.healthSnapshot()
+
+		// Assert
+		assert.Equal(t, os.Getpid(), status["pid"])
+		assert.Contains(t, status, "healthy")
+		assert.Contains(t, status, "health_check_timestamp")
+		perTenant, ok := status["tenants"].(map[string]interface{})
+		require.True(t, ok)
+		require.Contains(t, perTenant, "kiss")
+		assert.Equal(t, "kiss", perTenant["kiss"].(map[string]interface{})["tenant"])
+	})
+}
+
+func TestApplication_TenantCueLabel(t *testing.T) {
+	t.Run("should label cues with the tenant", func(t *testing.T) {
+		tenants, err := NewTenants(newTenantsConfig(t))
+		require.NoError(t, err)
+		cue := parser.NewContestCue("CASH", parser.CueDetails{Keyword: "CASH", Number: "72881"})
+
+		cues := tenants.apps[0].fanOutCue(*cue)
+
+		require.Len(t, cues, 1)
+		assert.Equal(t, "kiss", cues[0].Details.Extensions["tenant"])
+		assert.NotContains(t, cue.Details.Extensions, "tenant")
+	})
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/usage"
+)
+
+// usageSaveInterval is how often transcription usage is saved to its file
+const usageSaveInterval = time.Minute
+
+// newUsageLedger opens the ledger of audio transcribed per backend. An unreadable usage file is
+// not fatal: usage is then counted in memory and the file is replaced on the next save.
+func newUsageLedger(cfg *config.Configuration, location *time.Location, zapLogger *zap.Logger) *usage.Ledger {
+	ledger, err := usage.Open(cfg.GetUsagePath(), location)
+	if err != nil {
+		zapLogger.Warn("failed to load transcription usage, starting from zero",
+			zap.String("path", cfg.GetUsagePath()),
+			zap.Error(err))
+		ledger, _ = usage.Open("", location)
+	}
+	return ledger
+}
+
+// transcriptionUsage reports the seconds of audio each backend transcribed today and overall
+func (app *Application) transcriptionUsage() map[string]interface{} {
+	today := app.usageLedger.Today()
+	return map[string]interface{}{
+		"date":      today.Date,
+		"today_sec": today.Seconds,
+		"total_sec": app.usageLedger.Totals(),
+	}
+}
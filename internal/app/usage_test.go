@@ -0,0 +1,66 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/usage"
+)
+
+func TestApplication_TranscriptionUsage(t *testing.T) {
+	t.Run("should report usage per backend in the health status", func(t *testing.T) {
+		// Arrange
+		cfg := config.NewConfiguration()
+		cfg.SetUsagePath("")
+		app, err := NewApplicationWithConfig(cfg)
+		require.NoError(t, err)
+
+		// Act
+		app.usageLedger.Record(usage.BackendBinaryGPU, 30)
+		app.usageLedger.Record(usage.BackendAPI, 5)
+		status := app.getPipelineHealthStatus()
+
+		// Assert
+		usageStatus, ok := status["transcription_usage"].(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, time.Now().Format("2006-01-02"), usageStatus["date"])
+		assert.Equal(t, map[string]float64{usage.BackendBinaryGPU: 30, usage.BackendAPI: 5}, usageStatus["today_sec"])
+		assert.Equal(t, map[string]float64{usage.BackendBinaryGPU: 30, usage.BackendAPI: 5}, usageStatus["total_sec"])
+	})
+
+	t.Run("should start from zero when the usage file is unreadable", func(t *testing.T) {
+		// Arrange
+		cfg := config.NewConfiguration()
+		cfg.SetUsagePath(t.TempDir()) // A directory cannot be read as a file
+
+		// Act
+		ledger := newUsageLedger(cfg, time.UTC, zaptest.NewLogger(t))
+
+		// Assert
+		require.NotNil(t, ledger)
+		assert.Empty(t, ledger.Days())
+	})
+
+	t.Run("should keep earlier days from the usage file", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "usage.json")
+		saved, err := usage.Open(path, time.UTC)
+		require.NoError(t, err)
+		saved.Record(usage.BackendService, 60)
+		require.NoError(t, saved.Save())
+		cfg := config.NewConfiguration()
+		cfg.SetUsagePath(path)
+
+		// Act
+		ledger := newUsageLedger(cfg, time.UTC, zaptest.NewLogger(t))
+
+		// Assert
+		assert.Equal(t, map[string]float64{usage.BackendService: 60}, ledger.Totals())
+	})
+}
@@ -0,0 +1,32 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/transcriber"
+)
+
+// warmUpTranscription transcribes the configured warm-up clip, or built-in silence, so a
+// transcription backend that loaded but cannot run stops startup instead of the first live chunk
+func (app *Application) warmUpTranscription(ctx context.Context) error {
+	if !app.config.GetWhisperWarmupEnabled() {
+		return nil
+	}
+
+	clip := transcriber.SilenceWarmupClip()
+	if path := app.config.GetWhisperWarmupClip(); path != "" {
+		var err error
+		if clip, err = transcriber.LoadWarmupClip(path, app.config.GetWhisperWarmupExpect()); err != nil {
+			return err
+		}
+	}
+
+	app.zapLogger.Info("warming up transcription backend",
+		zap.String("backend", app.transcriptionEngine.ActiveBackend()),
+		zap.String("clip", app.config.GetWhisperWarmupClip()))
+	_, err := app.transcriptionEngine.WarmUp(ctx, clip, time.Duration(app.config.GetWhisperWarmupTimeoutSec())*time.Second)
+	return err
+}
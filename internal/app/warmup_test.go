@@ -0,0 +1,48 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/transcriber"
+)
+
+func TestApplication_WarmUpTranscription(t *testing.T) {
+	newWarmupApp := func(cfg *config.Configuration) *Application {
+		return &Application{
+			config:              cfg,
+			zapLogger:           zap.NewNop(),
+			transcriptionEngine: transcriber.NewTranscriptionEngineWithConfig(zap.NewNop(), cfg),
+		}
+	}
+
+	t.Run("should skip the warm-up when disabled", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetWhisperWarmupEnabled(false)
+		cfg.SetWhisperWarmupClip(filepath.Join(t.TempDir(), "missing.wav"), "")
+
+		err := newWarmupApp(cfg).warmUpTranscription(context.Background())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail when the warm-up clip cannot be read", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetWhisperWarmupClip(filepath.Join(t.TempDir(), "missing.wav"), "")
+
+		err := newWarmupApp(cfg).warmUpTranscription(context.Background())
+
+		assert.ErrorContains(t, err, "warm-up clip")
+	})
+
+	t.Run("should fail when the model was never loaded", func(t *testing.T) {
+		err := newWarmupApp(config.NewConfiguration()).warmUpTranscription(context.Background())
+
+		assert.ErrorContains(t, err, "not loaded")
+	})
+}
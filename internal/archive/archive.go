@@ -0,0 +1,290 @@
+// Package archive exports transcripts, cue logs, and saved audio snippets to object storage
+// (S3, Google Cloud Storage, or MinIO) once a night, so the local disk only has to hold recent
+// history. Objects older than the retention period are deleted from the bucket.
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Kinds of exported files; each is stored under its own key prefix
+const (
+	KindTranscripts = "transcripts"
+	KindCues        = "cues"
+	KindAudio       = "audio"
+)
+
+// Storage is the object store files are exported to
+type Storage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	List(ctx context.Context, prefix string) ([]Object, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Source is a set of files exported under one kind
+type Source struct {
+	Kind  string
+	Globs []string // Files matching any pattern are exported
+}
+
+// Config configures an Exporter
+type Config struct {
+	Prefix        string         // Key prefix in the bucket, e.g. "radiocontestwinner/station-a"
+	RetentionDays int            // Objects under the prefix older than this are deleted; 0 keeps them forever
+	StatePath     string         // Records which files were exported so they are not uploaded again
+	Location      *time.Location // Time zone of the date in object keys; nil uses local time
+	Sources       []Source
+}
+
+// Result summarizes an export
+type Result struct {
+	Uploaded int
+	Skipped  int // Already exported and unchanged
+	Deleted  int // Expired objects removed
+}
+
+// Exporter uploads new and changed files to object storage and expires old objects
+type Exporter struct {
+	storage Storage
+	config  Config
+	logger  *zap.Logger
+	now     func() time.Time
+}
+
+// stateFile is the on-disk record of exported files
+type stateFile struct {
+	Exported map[string]string `json:"exported"` // Fingerprint -> object key
+}
+
+// NewExporter creates an Exporter writing to storage
+func NewExporter(storage Storage, config Config, logger *zap.Logger) *Exporter {
+	if config.Location == nil {
+		config.Location = time.Local
+	}
+	config.Prefix = strings.Trim(config.Prefix, "/")
+	return &Exporter{storage: storage, config: config, logger: logger, now: time.Now}
+}
+
+// Export uploads every source file not exported before in its current form, then deletes
+// expired objects. Files are identified by size and modification time rather than name, so
+// rotated logs that were only renamed are not uploaded again.
+func (e *Exporter) Export(ctx context.Context) (Result, error) {
+	var result Result
+	state, err := e.loadState()
+	if err != nil {
+		return result, err
+	}
+
+	now := e.now()
+	day := now.In(e.config.Location).Format("2006/01/02")
+	seen := map[string]string{}
+	var errs []error
+	for _, source := range e.config.Sources {
+		for _, file := range matchFiles(source.Globs) {
+			info, err := os.Stat(file)
+			if err != nil || !info.Mode().IsRegular() {
+				continue
+			}
+			fingerprint := fmt.Sprintf("%s:%d:%d", source.Kind, info.Size(), info.ModTime().UnixNano())
+			if key, ok := state.Exported[fingerprint]; ok {
+				seen[fingerprint] = key
+				result.Skipped++
+				continue
+			}
+			key := e.objectKey(source.Kind, day, filepath.Base(file))
+			if err := e.upload(ctx, file, key, info.Size()); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			e.logger.Info("archived file", zap.String("path", file), zap.String("key", key))
+			seen[fingerprint] = key
+			result.Uploaded++
+		}
+	}
+
+	// Forget files that no longer exist locally so the state stays small
+	if err := e.saveState(stateFile{Exported: seen}); err != nil {
+		errs = append(errs, err)
+	}
+
+	deleted, err := e.expire(ctx, now)
+	result.Deleted = deleted
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return result, errors.Join(errs...)
+}
+
+// Run exports at each time next returns until ctx is cancelled. Exports only happen while
+// export reports true, so followers in a multi-instance deployment do not upload the same
+// files; nil always exports.
+func (e *Exporter) Run(ctx context.Context, next func(time.Time) time.Time, export func() bool) {
+	for {
+		timer := time.NewTimer(time.Until(next(e.now())))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		if export != nil && !export() {
+			continue
+		}
+		result, err := e.Export(ctx)
+		if err != nil {
+			e.logger.Warn("archive export failed", zap.Error(err))
+		}
+		e.logger.Info("archive export finished",
+			zap.Int("uploaded", result.Uploaded),
+			zap.Int("skipped", result.Skipped),
+			zap.Int("deleted", result.Deleted))
+	}
+}
+
+// objectKey returns the key a file of kind exported on day is stored under. Names are made
+// unique within the day, since a rotated file keeps the name of the one it replaced.
+func (e *Exporter) objectKey(kind, day, name string) string {
+	stamp := e.now().In(e.config.Location).Format("150405")
+	parts := []string{kind, day, stamp + "-" + name}
+	if e.config.Prefix != "" {
+		parts = append([]string{e.config.Prefix}, parts...)
+	}
+	return path.Join(parts...)
+}
+
+func (e *Exporter) upload(ctx context.Context, file, key string, size int64) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", file, err)
+	}
+	defer f.Close()
+	return e.storage.Put(ctx, key, f, size, contentType(file))
+}
+
+// expire deletes objects under the prefix older than the retention period
+func (e *Exporter) expire(ctx context.Context, now time.Time) (int, error) {
+	if e.config.RetentionDays <= 0 {
+		return 0, nil
+	}
+	prefix := ""
+	if e.config.Prefix != "" {
+		prefix = e.config.Prefix + "/"
+	}
+	objects, err := e.storage.List(ctx, prefix)
+	if err != nil {
+		return 0, err
+	}
+	cutoff := now.AddDate(0, 0, -e.config.RetentionDays)
+	deleted := 0
+	var errs []error
+	for _, object := range objects {
+		if !object.LastModified.Before(cutoff) {
+			continue
+		}
+		if err := e.storage.Delete(ctx, object.Key); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		deleted++
+	}
+	return deleted, errors.Join(errs...)
+}
+
+// loadState reads the exported-file record; a missing file means nothing was exported yet
+func (e *Exporter) loadState() (stateFile, error) {
+	state := stateFile{Exported: map[string]string{}}
+	if e.config.StatePath == "" {
+		return state, nil
+	}
+	data, err := os.ReadFile(e.config.StatePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("failed to read archive state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("failed to parse archive state %s: %w", e.config.StatePath, err)
+	}
+	if state.Exported == nil {
+		state.Exported = map[string]string{}
+	}
+	return state, nil
+}
+
+// saveState atomically writes the exported-file record
+func (e *Exporter) saveState(state stateFile) error {
+	if e.config.StatePath == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal archive state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(e.config.StatePath), 0755); err != nil {
+		return fmt.Errorf("failed to create archive state directory: %w", err)
+	}
+	tmp := e.config.StatePath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write archive state: %w", err)
+	}
+	if err := os.Rename(tmp, e.config.StatePath); err != nil {
+		return fmt.Errorf("failed to replace archive state: %w", err)
+	}
+	return nil
+}
+
+// matchFiles returns the files matching any of globs, sorted and without duplicates
+func matchFiles(globs []string) []string {
+	unique := map[string]bool{}
+	for _, glob := range globs {
+		if glob == "" {
+			continue
+		}
+		matches, _ := filepath.Glob(glob)
+		for _, match := range matches {
+			unique[match] = true
+		}
+	}
+	files := make([]string, 0, len(unique))
+	for file := range unique {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// contentType guesses the MIME type of an exported file from its name
+func contentType(file string) string {
+	name := strings.ToLower(filepath.Base(file))
+	// Rotated logs end in their generation number, e.g. transcriptions_debug.log.2
+	if ext := filepath.Ext(name); ext != "" && strings.Trim(ext, ".0123456789") == "" {
+		name = strings.TrimSuffix(name, ext)
+	}
+	switch filepath.Ext(name) {
+	case ".json", ".jsonl", ".ndjson":
+		return "application/json"
+	case ".log", ".txt":
+		return "text/plain; charset=utf-8"
+	case ".wav":
+		return "audio/wav"
+	case ".mp3":
+		return "audio/mpeg"
+	case ".ogg", ".opus":
+		return "audio/ogg"
+	default:
+		return "application/octet-stream"
+	}
+}
@@ -0,0 +1,246 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeStorage keeps objects in memory
+type fakeStorage struct {
+	mu      sync.Mutex
+	objects map[string]Object
+	bodies  map[string]string
+	types   map[string]string
+	putErr  error
+}
+
+func newFakeStorage() *fakeStorage {
+	return &fakeStorage{objects: map[string]Object{}, bodies: map[string]string{}, types: map[string]string{}}
+}
+
+func (f *fakeStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	if f.putErr != nil {
+		return f.putErr
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.objects[key] = Object{Key: key, Size: size, LastModified: time.Now()}
+	f.bodies[key] = string(data)
+	f.types[key] = contentType
+	return nil
+}
+
+func (f *fakeStorage) List(ctx context.Context, prefix string) ([]Object, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var objects []Object
+	for key, object := range f.objects {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, object)
+		}
+	}
+	return objects, nil
+}
+
+func (f *fakeStorage) Delete(ctx context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.objects, key)
+	delete(f.bodies, key)
+	return nil
+}
+
+func (f *fakeStorage) keys() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var keys []string
+	for key := range f.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func newTestExporter(storage Storage, dir string, retentionDays int) *Exporter {
+	e := NewExporter(storage, Config{
+		Prefix:        "/rcw/station-a/",
+		RetentionDays: retentionDays,
+		StatePath:     filepath.Join(dir, "state", "archive_state.json"),
+		Location:      time.UTC,
+		Sources: []Source{
+			{Kind: KindTranscripts, Globs: []string{filepath.Join(dir, "transcriptions_debug.log.[0-9]*")}},
+			{Kind: KindCues, Globs: []string{filepath.Join(dir, "contests.log"), filepath.Join(dir, "contests.log.[0-9]*")}},
+			{Kind: KindAudio, Globs: []string{filepath.Join(dir, "audio", "*")}},
+		},
+	}, zap.NewNop())
+	e.now = func() time.Time { return time.Date(2025, 6, 1, 3, 0, 0, 0, time.UTC) }
+	return e
+}
+
+func TestExporter_Export(t *testing.T) {
+	t.Run("should upload rotated transcripts, cue logs, and audio under dated keys", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "transcriptions_debug.log"), "live")
+		writeFile(t, filepath.Join(dir, "transcriptions_debug.log.1"), "rotated")
+		writeFile(t, filepath.Join(dir, "contests.log"), `{"cue_id":"a"}`)
+		writeFile(t, filepath.Join(dir, "audio", "cue-a.wav"), "RIFF")
+		storage := newFakeStorage()
+		e := newTestExporter(storage, dir, 0)
+
+		// Act
+		result, err := e.Export(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Uploaded)
+		assert.Equal(t, []string{
+			"rcw/station-a/audio/2025/06/01/030000-cue-a.wav",
+			"rcw/station-a/cues/2025/06/01/030000-contests.log",
+			"rcw/station-a/transcripts/2025/06/01/030000-transcriptions_debug.log.1",
+		}, storage.keys())
+		assert.Equal(t, "rotated", storage.bodies["rcw/station-a/transcripts/2025/06/01/030000-transcriptions_debug.log.1"])
+		assert.Equal(t, "audio/wav", storage.types["rcw/station-a/audio/2025/06/01/030000-cue-a.wav"])
+		assert.Equal(t, "text/plain; charset=utf-8", storage.types["rcw/station-a/transcripts/2025/06/01/030000-transcriptions_debug.log.1"])
+	})
+
+	t.Run("should not upload files again after they are only renamed by rotation", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		rotated := filepath.Join(dir, "transcriptions_debug.log.1")
+		writeFile(t, rotated, "rotated")
+		storage := newFakeStorage()
+		e := newTestExporter(storage, dir, 0)
+		_, err := e.Export(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, os.Rename(rotated, filepath.Join(dir, "transcriptions_debug.log.2")))
+
+		// Act
+		e = newTestExporter(storage, dir, 0)
+		result, err := e.Export(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Uploaded)
+		assert.Equal(t, 1, result.Skipped)
+		assert.Len(t, storage.keys(), 1)
+	})
+
+	t.Run("should upload a cue log again once it has grown", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		cues := filepath.Join(dir, "contests.log")
+		writeFile(t, cues, "one\n")
+		storage := newFakeStorage()
+		e := newTestExporter(storage, dir, 0)
+		_, err := e.Export(context.Background())
+		require.NoError(t, err)
+		writeFile(t, cues, "one\ntwo\n")
+		e.now = func() time.Time { return time.Date(2025, 6, 2, 3, 0, 0, 0, time.UTC) }
+
+		// Act
+		result, err := e.Export(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Uploaded)
+		assert.Equal(t, "one\ntwo\n", storage.bodies["rcw/station-a/cues/2025/06/02/030000-contests.log"])
+	})
+
+	t.Run("should delete objects older than the retention period", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		storage := newFakeStorage()
+		storage.objects["rcw/station-a/cues/2025/04/01/030000-contests.log"] = Object{
+			Key: "rcw/station-a/cues/2025/04/01/030000-contests.log", LastModified: time.Date(2025, 4, 1, 3, 0, 0, 0, time.UTC)}
+		storage.objects["rcw/station-a/cues/2025/05/30/030000-contests.log"] = Object{
+			Key: "rcw/station-a/cues/2025/05/30/030000-contests.log", LastModified: time.Date(2025, 5, 30, 3, 0, 0, 0, time.UTC)}
+		storage.objects["other/old.log"] = Object{Key: "other/old.log", LastModified: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+		e := newTestExporter(storage, dir, 30)
+
+		// Act
+		result, err := e.Export(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Deleted)
+		assert.Equal(t, []string{"other/old.log", "rcw/station-a/cues/2025/05/30/030000-contests.log"}, storage.keys())
+	})
+
+	t.Run("should retry files that failed to upload on the next export", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "contests.log"), "one\n")
+		storage := newFakeStorage()
+		storage.putErr = errors.New("bucket unavailable")
+		e := newTestExporter(storage, dir, 0)
+
+		// Act
+		_, err := e.Export(context.Background())
+		storage.putErr = nil
+		result, retryErr := e.Export(context.Background())
+
+		// Assert
+		assert.ErrorContains(t, err, "bucket unavailable")
+		require.NoError(t, retryErr)
+		assert.Equal(t, 1, result.Uploaded)
+	})
+}
+
+func TestExporter_Run(t *testing.T) {
+	t.Run("should only export while allowed to", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "contests.log"), "one\n")
+		storage := newFakeStorage()
+		e := newTestExporter(storage, dir, 0)
+		e.now = time.Now
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		runs := make(chan bool, 10)
+		allowed := false
+		next := func(t time.Time) time.Time { return t.Add(5 * time.Millisecond) }
+
+		// Act
+		go e.Run(ctx, next, func() bool {
+			runs <- allowed
+			result := allowed
+			allowed = true
+			return result
+		})
+
+		// Assert
+		assert.False(t, <-runs)
+		assert.Empty(t, storage.keys())
+		assert.True(t, <-runs)
+		assert.Eventually(t, func() bool { return len(storage.keys()) == 1 }, time.Second, 5*time.Millisecond)
+	})
+}
+
+func TestContentType(t *testing.T) {
+	assert.Equal(t, "application/json", contentType("/data/cues.json.3"))
+	assert.Equal(t, "audio/mpeg", contentType("snippet.MP3"))
+	assert.Equal(t, "application/octet-stream", contentType("notes"))
+}
@@ -0,0 +1,284 @@
+package archive
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// unsignedPayload lets uploads stream from disk without hashing the file first
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// S3Options configures an S3Client
+type S3Options struct {
+	Endpoint        string // e.g. https://s3.us-east-1.amazonaws.com, https://storage.googleapis.com, http://minio:9000
+	Region          string // Signing region; GCS and MinIO accept us-east-1 and auto
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	Timeout         time.Duration // Per request, including the upload; default 5 minutes
+}
+
+// S3Client is a minimal client for the S3 API, signing requests with AWS Signature Version 4.
+// It addresses buckets path-style, which S3, MinIO, and Google Cloud Storage's
+// S3-interoperable XML API (with HMAC keys) all accept.
+type S3Client struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+	now       func() time.Time
+}
+
+// Object is a stored object listed by List
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// NewS3Client creates an S3Client for the bucket at the endpoint
+func NewS3Client(opts S3Options) (*S3Client, error) {
+	if opts.Bucket == "" {
+		return nil, fmt.Errorf("archive bucket is required")
+	}
+	if opts.AccessKeyID == "" || opts.SecretAccessKey == "" {
+		return nil, fmt.Errorf("archive access key ID and secret access key are required")
+	}
+	endpoint, err := url.Parse(strings.TrimRight(opts.Endpoint, "/"))
+	if err != nil || endpoint.Host == "" || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		return nil, fmt.Errorf("invalid archive endpoint %q", opts.Endpoint)
+	}
+	region := opts.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Minute
+	}
+	return &S3Client{
+		endpoint:  endpoint,
+		region:    region,
+		bucket:    opts.Bucket,
+		accessKey: opts.AccessKeyID,
+		secretKey: opts.SecretAccessKey,
+		client:    &http.Client{Timeout: timeout},
+		now:       time.Now,
+	}, nil
+}
+
+// Put uploads size bytes from body as the object key
+func (c *S3Client) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	req, err := c.newRequest(ctx, http.MethodPut, key, nil, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Delete removes the object key; deleting a missing object succeeds
+func (c *S3Client) Delete(ctx context.Context, key string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// List returns every object whose key starts with prefix
+func (c *S3Client) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		req, err := c.newRequest(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", prefix, err)
+		}
+
+		var page struct {
+			Contents []struct {
+				Key          string    `xml:"Key"`
+				Size         int64     `xml:"Size"`
+				LastModified time.Time `xml:"LastModified"`
+			} `xml:"Contents"`
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+		}
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode listing of %s: %w", prefix, err)
+		}
+		for _, content := range page.Contents {
+			objects = append(objects, Object{Key: content.Key, Size: content.Size, LastModified: content.LastModified})
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+// newRequest builds a signed request for the object key, or the bucket when key is empty
+func (c *S3Client) newRequest(ctx context.Context, method, key string, query url.Values, body io.Reader) (*http.Request, error) {
+	target := *c.endpoint
+	target.Path = strings.TrimRight(target.Path, "/") + "/" + c.bucket
+	if key != "" {
+		target.Path += "/" + key
+	}
+	target.RawPath = escapePath(target.Path)
+	target.RawQuery = canonicalQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	c.sign(req)
+	return req, nil
+}
+
+// do sends req, turning error responses into errors. The caller closes the body.
+func (c *S3Client) do(req *http.Request) (*http.Response, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("storage error %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return resp, nil
+}
+
+// sign adds AWS Signature Version 4 headers to req
+func (c *S3Client) sign(req *http.Request) {
+	now := c.now().UTC()
+	date := now.Format("20060102")
+	stamp := now.Format("20060102T150405Z")
+
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", stamp)
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	signedHeaders := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	var canonicalHeaders strings.Builder
+	for _, name := range signedHeaders {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.URL.Host
+		}
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", name, strings.TrimSpace(value))
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		strings.Join(signedHeaders, ";"),
+		unsignedPayload,
+	}, "\n")
+
+	scope := date + "/" + c.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		stamp,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+c.secretKey), date)
+	key = hmacSHA256(key, c.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.accessKey, scope, strings.Join(signedHeaders, ";"), signature))
+}
+
+// escapePath percent-encodes each segment of path as SigV4 requires, keeping the slashes
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = uriEncode(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// canonicalQuery encodes query sorted by name, as SigV4 requires
+func canonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var parts []string
+	for _, name := range names {
+		for _, value := range query[name] {
+			parts = append(parts, uriEncode(name)+"="+uriEncode(value))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode percent-encodes everything but unreserved characters
+func uriEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if (ch >= 'A' && ch <= 'Z') || (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9') ||
+			ch == '-' || ch == '_' || ch == '.' || ch == '~' {
+			b.WriteByte(ch)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", ch)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,133 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestS3Client(t *testing.T, handler http.HandlerFunc) *S3Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client, err := NewS3Client(S3Options{
+		Endpoint:        server.URL,
+		Bucket:          "archive",
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	})
+	require.NoError(t, err)
+	client.now = func() time.Time { return time.Date(2025, 6, 1, 3, 0, 0, 0, time.UTC) }
+	return client
+}
+
+func TestNewS3Client(t *testing.T) {
+	t.Run("should require a bucket and credentials", func(t *testing.T) {
+		_, err := NewS3Client(S3Options{Endpoint: "https://storage.googleapis.com", AccessKeyID: "id", SecretAccessKey: "secret"})
+		assert.ErrorContains(t, err, "bucket")
+
+		_, err = NewS3Client(S3Options{Endpoint: "https://storage.googleapis.com", Bucket: "archive"})
+		assert.ErrorContains(t, err, "access key")
+	})
+
+	t.Run("should reject endpoints that are not URLs", func(t *testing.T) {
+		_, err := NewS3Client(S3Options{Endpoint: "minio:9000", Bucket: "archive", AccessKeyID: "id", SecretAccessKey: "secret"})
+
+		assert.ErrorContains(t, err, "invalid archive endpoint")
+	})
+}
+
+func TestS3Client_Put(t *testing.T) {
+	t.Run("should upload a signed path-style request", func(t *testing.T) {
+		// Arrange
+		var got *http.Request
+		var body string
+		client := newTestS3Client(t, func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			got, body = r, string(data)
+		})
+
+		// Act
+		err := client.Put(context.Background(), "rcw/cues/2025/06/01/030000-contests log.json", strings.NewReader("{}\n"), 3, "application/json")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, http.MethodPut, got.Method)
+		assert.Equal(t, "/archive/rcw/cues/2025/06/01/030000-contests%20log.json", got.URL.EscapedPath())
+		assert.Equal(t, "{}\n", body)
+		assert.Equal(t, "application/json", got.Header.Get("Content-Type"))
+		assert.Equal(t, "UNSIGNED-PAYLOAD", got.Header.Get("X-Amz-Content-Sha256"))
+		assert.Equal(t, "20250601T030000Z", got.Header.Get("X-Amz-Date"))
+		assert.Regexp(t, `^AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20250601/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=[0-9a-f]{64}$`,
+			got.Header.Get("Authorization"))
+	})
+
+	t.Run("should report storage errors", func(t *testing.T) {
+		// Arrange
+		client := newTestS3Client(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, "<Error><Code>SignatureDoesNotMatch</Code></Error>")
+		})
+
+		// Act
+		err := client.Put(context.Background(), "key", strings.NewReader("x"), 1, "")
+
+		// Assert
+		assert.ErrorContains(t, err, "storage error 403")
+		assert.ErrorContains(t, err, "SignatureDoesNotMatch")
+	})
+}
+
+func TestS3Client_List(t *testing.T) {
+	t.Run("should follow continuation tokens", func(t *testing.T) {
+		// Arrange
+		var queries []string
+		client := newTestS3Client(t, func(w http.ResponseWriter, r *http.Request) {
+			queries = append(queries, r.URL.RawQuery)
+			if r.URL.Query().Get("continuation-token") == "" {
+				fmt.Fprint(w, `<ListBucketResult><Contents><Key>rcw/a.log</Key><Size>4</Size><LastModified>2025-05-01T03:00:00.000Z</LastModified></Contents>`+
+					`<IsTruncated>true</IsTruncated><NextContinuationToken>page 2</NextContinuationToken></ListBucketResult>`)
+				return
+			}
+			fmt.Fprint(w, `<ListBucketResult><Contents><Key>rcw/b.log</Key><Size>5</Size><LastModified>2025-05-02T03:00:00.000Z</LastModified></Contents>`+
+				`<IsTruncated>false</IsTruncated></ListBucketResult>`)
+		})
+
+		// Act
+		objects, err := client.List(context.Background(), "rcw/")
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, objects, 2)
+		assert.Equal(t, "rcw/a.log", objects[0].Key)
+		assert.Equal(t, int64(5), objects[1].Size)
+		assert.Equal(t, time.Date(2025, 5, 2, 3, 0, 0, 0, time.UTC), objects[1].LastModified)
+		assert.Equal(t, []string{"list-type=2&prefix=rcw%2F", "continuation-token=page%202&list-type=2&prefix=rcw%2F"}, queries)
+	})
+}
+
+func TestS3Client_Delete(t *testing.T) {
+	t.Run("should delete the object", func(t *testing.T) {
+		// Arrange
+		var got string
+		client := newTestS3Client(t, func(w http.ResponseWriter, r *http.Request) {
+			got = r.Method + " " + r.URL.Path
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		// Act
+		err := client.Delete(context.Background(), "rcw/a.log")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "DELETE /archive/rcw/a.log", got)
+	})
+}
@@ -0,0 +1,96 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScaffold(t *testing.T) {
+	t.Run("should create config and working directories", func(t *testing.T) {
+		// Arrange
+		dir := filepath.Join(t.TempDir(), "rcw")
+
+		// Act
+		result, err := Scaffold(ScaffoldOptions{Dir: dir})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "config.yaml"), result.ConfigPath)
+		assert.Equal(t, filepath.Join(dir, "models", "ggml-base.en.bin"), result.ModelPath)
+		for _, sub := range []string{"models", "logs", "data"} {
+			info, err := os.Stat(filepath.Join(dir, sub))
+			require.NoError(t, err)
+			assert.True(t, info.IsDir())
+		}
+		content, err := os.ReadFile(result.ConfigPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `model_path: "./models/ggml-base.en.bin"`)
+	})
+
+	t.Run("should point the config at the chosen model", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+
+		// Act
+		result, err := Scaffold(ScaffoldOptions{Dir: dir, Model: "tiny.en"})
+
+		// Assert
+		require.NoError(t, err)
+		content, err := os.ReadFile(result.ConfigPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `model_path: "./models/ggml-tiny.en.bin"`)
+		assert.NotContains(t, string(content), `model_path: "./models/ggml-base.en.bin"`)
+	})
+
+	t.Run("should refuse to overwrite an existing config unless forced", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		configPath := filepath.Join(dir, "config.yaml")
+		require.NoError(t, os.WriteFile(configPath, []byte("custom"), 0644))
+
+		// Act
+		_, err := Scaffold(ScaffoldOptions{Dir: dir})
+		content, _ := os.ReadFile(configPath)
+		_, forcedErr := Scaffold(ScaffoldOptions{Dir: dir, Force: true})
+		forced, _ := os.ReadFile(configPath)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, "custom", string(content))
+		assert.NoError(t, forcedErr)
+		assert.NotEqual(t, "custom", string(forced))
+	})
+
+	t.Run("should reject an empty directory", func(t *testing.T) {
+		// Act
+		_, err := Scaffold(ScaffoldOptions{})
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject model names that are not plain file names", func(t *testing.T) {
+		// Act
+		_, err := Scaffold(ScaffoldOptions{Dir: t.TempDir(), Model: "../evil"})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestFFmpegGuidance(t *testing.T) {
+	t.Run("should give platform specific install guidance", func(t *testing.T) {
+		assert.Contains(t, ffmpegGuidanceFor("darwin"), "brew install ffmpeg")
+		assert.Contains(t, ffmpegGuidanceFor("windows"), "PATH")
+		assert.Contains(t, ffmpegGuidanceFor("plan9"), "ffmpeg.org")
+	})
+
+	t.Run("should return guidance for the current platform", func(t *testing.T) {
+		assert.True(t, strings.Contains(FFmpegGuidance(), "ffmpeg"))
+	})
+}
@@ -0,0 +1,34 @@
+package bootstrap
+
+import (
+	"os/exec"
+	"runtime"
+)
+
+// ffmpegGuidance gives install instructions for a static FFmpeg build per operating system
+var ffmpegGuidance = map[string]string{
+	"linux": "Install FFmpeg with your package manager (apt install ffmpeg, dnf install ffmpeg),\n" +
+		"or download a static build from https://johnvansickle.com/ffmpeg/ and put the\n" +
+		"ffmpeg binary on your PATH.",
+	"darwin": "Install FFmpeg with Homebrew (brew install ffmpeg), or download a static build\n" +
+		"from https://evermeet.cx/ffmpeg/ and put the ffmpeg binary on your PATH.",
+	"windows": "Download a static build from https://www.gyan.dev/ffmpeg/builds/, extract it,\n" +
+		"and add its bin folder to your PATH.",
+}
+
+// FindFFmpeg returns the path of the ffmpeg binary the audio processor will run
+func FindFFmpeg() (string, error) {
+	return exec.LookPath("ffmpeg")
+}
+
+// FFmpegGuidance returns how to install FFmpeg on this operating system
+func FFmpegGuidance() string {
+	return ffmpegGuidanceFor(runtime.GOOS)
+}
+
+func ffmpegGuidanceFor(goos string) string {
+	if guidance, ok := ffmpegGuidance[goos]; ok {
+		return guidance
+	}
+	return "Download FFmpeg from https://ffmpeg.org/download.html and put the ffmpeg binary on your PATH."
+}
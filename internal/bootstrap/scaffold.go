@@ -0,0 +1,77 @@
+package bootstrap
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"radiocontestwinner/configs"
+)
+
+// DefaultModel is the Whisper model scaffolded configs point at
+const DefaultModel = "base.en"
+
+// modelNameRegex restricts model names to what whisper.cpp publishes (e.g. base.en, large-v3-q5_0)
+var modelNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// ModelFileName returns the ggml file name for a Whisper model name
+func ModelFileName(model string) string {
+	return "ggml-" + model + ".bin"
+}
+
+// ScaffoldOptions configures Scaffold
+type ScaffoldOptions struct {
+	Dir   string // Directory to create the config in
+	Model string // Whisper model name the config points at (empty uses DefaultModel)
+	Force bool   // Overwrite an existing config.yaml
+}
+
+// ScaffoldResult lists what Scaffold created
+type ScaffoldResult struct {
+	ConfigPath string
+	ModelsDir  string
+	ModelPath  string
+}
+
+// Scaffold creates a working directory for running without Docker: config.yaml from the
+// bundled example, plus the models, logs, and data directories the example config refers to.
+// Paths in the example are relative, so the application is run from Dir.
+func Scaffold(opts ScaffoldOptions) (*ScaffoldResult, error) {
+	if opts.Dir == "" {
+		return nil, fmt.Errorf("directory cannot be empty")
+	}
+	model := opts.Model
+	if model == "" {
+		model = DefaultModel
+	}
+	if !modelNameRegex.MatchString(model) {
+		return nil, fmt.Errorf("invalid model name %q", model)
+	}
+
+	for _, sub := range []string{"models", "logs", "data"} {
+		if err := os.MkdirAll(filepath.Join(opts.Dir, sub), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create %s directory: %w", sub, err)
+		}
+	}
+
+	result := &ScaffoldResult{
+		ConfigPath: filepath.Join(opts.Dir, "config.yaml"),
+		ModelsDir:  filepath.Join(opts.Dir, "models"),
+		ModelPath:  filepath.Join(opts.Dir, "models", ModelFileName(model)),
+	}
+
+	if _, err := os.Stat(result.ConfigPath); err == nil && !opts.Force {
+		return nil, fmt.Errorf("%s already exists (use -force to overwrite)", result.ConfigPath)
+	}
+
+	config := bytes.Replace(configs.Example,
+		[]byte(`model_path: "./models/`+ModelFileName(DefaultModel)+`"`),
+		[]byte(`model_path: "./models/`+ModelFileName(model)+`"`), 1)
+	if err := os.WriteFile(result.ConfigPath, config, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write config: %w", err)
+	}
+
+	return result, nil
+}
@@ -1,6 +1,11 @@
 package buffer
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+
+	"radiocontestwinner/internal/transcriber"
+)
 
 // BufferedContext represents a collection of TranscriptionSegments that have been
 // combined to form a more coherent sentence or phrase for easier parsing
@@ -8,10 +13,37 @@ type BufferedContext struct {
 	Text    string `json:"text"`
 	StartMS int    `json:"start_ms"`
 	EndMS   int    `json:"end_ms"`
+
+	CapturedAt    time.Time `json:"captured_at,omitzero"`    // Capture time of the earliest segment's audio
+	TranscribedAt time.Time `json:"transcribed_at,omitzero"` // Transcription completion time of the latest segment
+
+	Confidence    float32 `json:"confidence,omitempty"`     // Lowest confidence of the combined segments
+	CorrectedFrom string  `json:"corrected_from,omitempty"` // Original text when an LLM corrected Text
+	Truncated     bool    `json:"truncated,omitempty"`      // Whether Text was cut to a size limit
+
+	Words []transcriber.Word `json:"words,omitempty"` // Timed words of the combined segments, when the backend reports them
+
+	TraceIDs []string `json:"trace_ids,omitempty"` // IDs of the audio chunks the combined segments came from, oldest first
+
+	GapMS int `json:"gap_ms,omitempty"` // On gap markers, how long no audio was transcribed; CapturedAt is when the gap began
+}
+
+// IsGap reports whether bc is a gap marker rather than transcribed text. A gap marker carries
+// no text; it tells consumers that the contexts before and after it are not contiguous.
+func (bc *BufferedContext) IsGap() bool {
+	return bc.GapMS > 0
+}
+
+// Gap returns how long no audio was transcribed before the context following a gap marker
+func (bc *BufferedContext) Gap() time.Duration {
+	return time.Duration(bc.GapMS) * time.Millisecond
 }
 
 // Validate checks if the BufferedContext has valid values
 func (bc *BufferedContext) Validate() error {
+	if bc.IsGap() {
+		return nil
+	}
 	if bc.Text == "" {
 		return fmt.Errorf("text cannot be empty")
 	}
@@ -2,8 +2,12 @@ package buffer
 
 import (
 	"context"
+	"errors"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"radiocontestwinner/internal/transcriber"
 )
@@ -15,8 +19,29 @@ type ContextBuffer struct {
 	inputCh          <-chan transcriber.TranscriptionSegment
 	outputCh         chan<- BufferedContext
 	buffer           []transcriber.TranscriptionSegment
+
+	noSpeechThreshold float32 // Segments at or above this no-speech probability are dropped; 0 keeps all
+	droppedNoSpeech   int64
+
+	maxContextBytes  int  // Longest text of one BufferedContext; 0 leaves it unbounded
+	maxBufferedBytes int  // Most segment text held awaiting a flush; 0 leaves it unbounded
+	bufferedBytes    int  // Segment text currently held
+	bufferTruncated  bool // Whether a held segment's text was cut
+	truncations      int64
+
+	gapThreshold time.Duration // Audio time without segments that is reported as a gap; 0 disables
+	lastAudioEnd time.Time     // Capture time of the end of the latest segment's audio
+	gaps         int64
+
+	lifecycleMu sync.Mutex
+	cancel      context.CancelFunc // Stops processing started by Start; nil until started
+	done        chan struct{}      // Closed once processing has stopped
+	running     atomic.Bool
 }
 
+// truncationMarker ends text cut to a size limit
+const truncationMarker = "…"
+
 // NewContextBuffer creates a new ContextBuffer instance
 func NewContextBuffer(bufferDurationMS int, inputCh <-chan transcriber.TranscriptionSegment, outputCh chan<- BufferedContext) *ContextBuffer {
 	return &ContextBuffer{
@@ -27,9 +52,128 @@ func NewContextBuffer(bufferDurationMS int, inputCh <-chan transcriber.Transcrip
 	}
 }
 
-// Start begins processing segments with the configured buffer duration
+// SetNoSpeechThreshold drops segments whose no-speech probability is at or above threshold,
+// such as text hallucinated over music or silence. Zero disables dropping; segments from
+// backends that do not report the probability are always kept.
+func (cb *ContextBuffer) SetNoSpeechThreshold(threshold float32) {
+	cb.noSpeechThreshold = threshold
+}
+
+// DroppedNoSpeech returns how many segments were dropped as no-speech
+func (cb *ContextBuffer) DroppedNoSpeech() int64 {
+	return atomic.LoadInt64(&cb.droppedNoSpeech)
+}
+
+// SetTextLimits bounds the text the buffer emits and retains: maxContextBytes caps the text of
+// each BufferedContext, and maxBufferedBytes caps the segment text held awaiting a flush, which
+// is flushed early when the next segment would exceed it. Text over a limit is cut and ends with
+// an ellipsis. Zero leaves a limit unbounded.
+func (cb *ContextBuffer) SetTextLimits(maxContextBytes, maxBufferedBytes int) {
+	cb.maxContextBytes = maxContextBytes
+	cb.maxBufferedBytes = maxBufferedBytes
+}
+
+// Truncations returns how many contexts had their text cut to a size limit
+func (cb *ContextBuffer) Truncations() int64 {
+	return atomic.LoadInt64(&cb.truncations)
+}
+
+// SetGapThreshold reports a gap when the audio of a segment was captured at least threshold after
+// the end of the previous segment's audio, as when transcription stalls or is paused and resumes.
+// The held segments are flushed and a gap marker is sent before the segment, so text on either
+// side of the gap is never combined. Zero disables gap detection; segments without a capture
+// time are never treated as a gap.
+func (cb *ContextBuffer) SetGapThreshold(threshold time.Duration) {
+	cb.gapThreshold = threshold
+}
+
+// Gaps returns how many gap markers were sent
+func (cb *ContextBuffer) Gaps() int64 {
+	return atomic.LoadInt64(&cb.gaps)
+}
+
+// detectGap returns the audio time missing before segment, or 0 when it follows on from the
+// previous segment, and advances the end of the audio heard so far
+func (cb *ContextBuffer) detectGap(segment transcriber.TranscriptionSegment) time.Duration {
+	if segment.CapturedAt.IsZero() {
+		return 0
+	}
+	var gap time.Duration
+	if cb.gapThreshold > 0 && !cb.lastAudioEnd.IsZero() {
+		if missing := segment.CapturedAt.Sub(cb.lastAudioEnd); missing >= cb.gapThreshold {
+			gap = missing
+		}
+	}
+	if end := segment.CapturedAt.Add(time.Duration(segment.EndMS-segment.StartMS) * time.Millisecond); end.After(cb.lastAudioEnd) {
+		cb.lastAudioEnd = end
+	}
+	return gap
+}
+
+// truncateText cuts text to at most maxBytes, on a character boundary and ending with the
+// truncation marker; it reports whether text was cut
+func truncateText(text string, maxBytes int) (string, bool) {
+	if maxBytes <= 0 || len(text) <= maxBytes {
+		return text, false
+	}
+	cut := max(maxBytes-len(truncationMarker), 0)
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut] + truncationMarker, true
+}
+
+// isNoSpeech reports whether a segment should be dropped as no-speech
+func (cb *ContextBuffer) isNoSpeech(segment transcriber.TranscriptionSegment) bool {
+	return cb.noSpeechThreshold > 0 && segment.NoSpeechProb >= cb.noSpeechThreshold
+}
+
+// Name identifies the context buffer among the application's components
+func (cb *ContextBuffer) Name() string {
+	return "context_buffer"
+}
+
+// Start begins processing segments with the configured buffer duration until ctx is cancelled
+// or Stop is called
 func (cb *ContextBuffer) Start(ctx context.Context) error {
-	go cb.processSegments(ctx)
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	cb.lifecycleMu.Lock()
+	cb.cancel, cb.done = cancel, done
+	cb.lifecycleMu.Unlock()
+
+	cb.running.Store(true)
+	go func() {
+		defer close(done)
+		defer cb.running.Store(false)
+		cb.processSegments(ctx)
+	}()
+	return nil
+}
+
+// Stop ends processing, flushing any held segments, and waits for it to finish or ctx to end
+func (cb *ContextBuffer) Stop(ctx context.Context) error {
+	cb.lifecycleMu.Lock()
+	cancel, done := cb.cancel, cb.done
+	cb.lifecycleMu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+
+	cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Healthy returns an error unless segments are being processed
+func (cb *ContextBuffer) Healthy() error {
+	if !cb.running.Load() {
+		return errors.New("context buffer not running")
+	}
 	return nil
 }
 
@@ -56,8 +200,37 @@ func (cb *ContextBuffer) processSegments(ctx context.Context) {
 				return
 			}
 
+			// Never combine text across a gap; no-speech segments still count as audio heard
+			gapStart := cb.lastAudioEnd
+			if gap := cb.detectGap(segment); gap > 0 {
+				if len(cb.buffer) > 0 {
+					cb.flushBuffer()
+					timer.Stop()
+				}
+				atomic.AddInt64(&cb.gaps, 1)
+				cb.send(BufferedContext{GapMS: int(gap.Milliseconds()), CapturedAt: gapStart})
+			}
+
+			if cb.isNoSpeech(segment) {
+				atomic.AddInt64(&cb.droppedNoSpeech, 1)
+				continue
+			}
+
+			// Keep the held text within its cap, flushing early rather than dropping speech
+			if cb.maxBufferedBytes > 0 {
+				var truncated bool
+				if segment.Text, truncated = truncateText(segment.Text, cb.maxBufferedBytes); truncated {
+					cb.bufferTruncated = true
+				}
+				if len(cb.buffer) > 0 && cb.bufferedBytes+len(segment.Text) > cb.maxBufferedBytes {
+					cb.flushBuffer()
+					timer.Stop()
+				}
+			}
+
 			// Add segment to buffer
 			cb.buffer = append(cb.buffer, segment)
+			cb.bufferedBytes += len(segment.Text)
 
 			// Start timer if this is the first segment
 			if len(cb.buffer) == 1 {
@@ -85,27 +258,63 @@ func (cb *ContextBuffer) flushBuffer() {
 	for _, segment := range cb.buffer {
 		textParts = append(textParts, segment.Text)
 	}
-	combinedText := strings.Join(textParts, " ")
+	combinedText, truncated := truncateText(strings.Join(textParts, " "), cb.maxContextBytes)
+	truncated = truncated || cb.bufferTruncated
+	if truncated {
+		atomic.AddInt64(&cb.truncations, 1)
+	}
 
 	// Use earliest StartMS and latest EndMS
 	startMS := cb.buffer[0].StartMS
 	endMS := cb.buffer[len(cb.buffer)-1].EndMS
 
+	// Track when the oldest audio was captured and when the newest segment was transcribed
+	var capturedAt, transcribedAt time.Time
+	var words []transcriber.Word
+	var traceIDs []string
+	confidence := cb.buffer[0].Confidence
+	for _, segment := range cb.buffer {
+		confidence = min(confidence, segment.Confidence)
+		words = append(words, segment.Words...)
+		// Segments of one chunk arrive together, so a repeated ID follows its first occurrence
+		if segment.TraceID != "" && (len(traceIDs) == 0 || traceIDs[len(traceIDs)-1] != segment.TraceID) {
+			traceIDs = append(traceIDs, segment.TraceID)
+		}
+		if !segment.CapturedAt.IsZero() && (capturedAt.IsZero() || segment.CapturedAt.Before(capturedAt)) {
+			capturedAt = segment.CapturedAt
+		}
+		if segment.TranscribedAt.After(transcribedAt) {
+			transcribedAt = segment.TranscribedAt
+		}
+	}
+
 	// Create BufferedContext
 	bufferedContext := BufferedContext{
-		Text:    combinedText,
-		StartMS: startMS,
-		EndMS:   endMS,
+		Text:          combinedText,
+		StartMS:       startMS,
+		EndMS:         endMS,
+		CapturedAt:    capturedAt,
+		TranscribedAt: transcribedAt,
+		Confidence:    confidence,
+		Truncated:     truncated,
+		Words:         words,
+		TraceIDs:      traceIDs,
 	}
 
-	// Send to output channel
+	cb.send(bufferedContext)
+
+	// Clear buffer
+	cb.buffer = cb.buffer[:0]
+	cb.bufferedBytes = 0
+	cb.bufferTruncated = false
+}
+
+// send passes a context on without blocking the buffer
+func (cb *ContextBuffer) send(bufferedContext BufferedContext) {
 	select {
 	case cb.outputCh <- bufferedContext:
 		// Successfully sent
 	default:
 		// Output channel full, could log warning here
 	}
-
-	// Clear buffer
-	cb.buffer = cb.buffer[:0]
 }
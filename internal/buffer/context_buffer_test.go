@@ -42,6 +42,82 @@ func TestContextBuffer_Start_Stop(t *testing.T) {
 	time.Sleep(10 * time.Millisecond) // Allow goroutine to stop
 }
 
+func TestContextBuffer_StopFlushesAndReportsUnhealthy(t *testing.T) {
+	// Arrange
+	inputCh := make(chan transcriber.TranscriptionSegment, 10)
+	outputCh := make(chan BufferedContext, 10)
+	cb := NewContextBuffer(int(time.Hour.Milliseconds()), inputCh, outputCh)
+	assert.Error(t, cb.Healthy())
+	assert.NoError(t, cb.Start(context.Background()))
+	assert.NoError(t, cb.Healthy())
+	inputCh <- transcriber.TranscriptionSegment{Text: "held until stop", StartMS: 0, EndMS: 1000}
+
+	// Act
+	assert.Eventually(t, func() bool { return len(inputCh) == 0 }, time.Second, time.Millisecond)
+	err := cb.Stop(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Error(t, cb.Healthy())
+	select {
+	case context := <-outputCh:
+		assert.Equal(t, "held until stop", context.Text)
+	default:
+		t.Fatal("expected the held segment to be flushed on stop")
+	}
+}
+
+func TestContextBuffer_CombinesWords(t *testing.T) {
+	// Arrange
+	inputCh := make(chan transcriber.TranscriptionSegment, 10)
+	outputCh := make(chan BufferedContext, 10)
+	cb := NewContextBuffer(50, inputCh, outputCh)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.NoError(t, cb.Start(ctx))
+
+	// Act
+	inputCh <- transcriber.TranscriptionSegment{Text: "Text WIN", StartMS: 0, EndMS: 1000,
+		Words: []transcriber.Word{{Text: "Text", StartMS: 0, EndMS: 400}, {Text: "WIN", StartMS: 400, EndMS: 1000}}}
+	inputCh <- transcriber.TranscriptionSegment{Text: "to 12345", StartMS: 1000, EndMS: 2000,
+		Words: []transcriber.Word{{Text: "to", StartMS: 1000, EndMS: 1200}, {Text: "12345", StartMS: 1200, EndMS: 2000}}}
+
+	// Assert
+	select {
+	case context := <-outputCh:
+		assert.Equal(t, "Text WIN to 12345", context.Text)
+		if assert.Len(t, context.Words, 4) {
+			assert.Equal(t, "12345", context.Words[3].Text)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a buffered context")
+	}
+}
+
+func TestContextBuffer_CombinesTraceIDs(t *testing.T) {
+	// Arrange
+	inputCh := make(chan transcriber.TranscriptionSegment, 10)
+	outputCh := make(chan BufferedContext, 10)
+	cb := NewContextBuffer(50, inputCh, outputCh)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.NoError(t, cb.Start(ctx))
+
+	// Act
+	inputCh <- transcriber.TranscriptionSegment{Text: "Text WIN", StartMS: 0, EndMS: 1000, TraceID: "chunk-1"}
+	inputCh <- transcriber.TranscriptionSegment{Text: "to", StartMS: 1000, EndMS: 1500, TraceID: "chunk-1"}
+	inputCh <- transcriber.TranscriptionSegment{Text: "12345", StartMS: 1500, EndMS: 2000, TraceID: "chunk-2"}
+
+	// Assert
+	select {
+	case context := <-outputCh:
+		assert.Equal(t, "Text WIN to 12345", context.Text)
+		assert.Equal(t, []string{"chunk-1", "chunk-2"}, context.TraceIDs)
+	case <-time.After(time.Second):
+		t.Fatal("expected a buffered context")
+	}
+}
+
 func TestContextBuffer_ProcessSingleSegment(t *testing.T) {
 	// Arrange
 	bufferDurationMS := 100
@@ -114,11 +190,46 @@ func TestContextBuffer_ProcessMultipleSegments(t *testing.T) {
 		assert.Equal(t, "Hello world", result.Text)
 		assert.Equal(t, 1000, result.StartMS)
 		assert.Equal(t, 1400, result.EndMS)
+		assert.Equal(t, float32(0.8), result.Confidence, "should keep the lowest segment confidence")
 	case <-time.After(300 * time.Millisecond):
 		t.Fatal("Expected output within timeout")
 	}
 }
 
+func TestContextBuffer_Timing(t *testing.T) {
+	t.Run("should carry the earliest capture and latest transcription times", func(t *testing.T) {
+		// Arrange
+		inputCh := make(chan transcriber.TranscriptionSegment, 10)
+		outputCh := make(chan BufferedContext, 10)
+		cb := NewContextBuffer(150, inputCh, outputCh)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		inputCh <- transcriber.TranscriptionSegment{
+			Text: "Text POTA", StartMS: 0, EndMS: 500,
+			CapturedAt: base, TranscribedAt: base.Add(3 * time.Second),
+		}
+		inputCh <- transcriber.TranscriptionSegment{
+			Text: "to 1234", StartMS: 500, EndMS: 1000,
+			CapturedAt: base.Add(500 * time.Millisecond), TranscribedAt: base.Add(4 * time.Second),
+		}
+		close(inputCh)
+
+		// Act
+		assert.NoError(t, cb.Start(ctx))
+
+		// Assert
+		select {
+		case result := <-outputCh:
+			assert.Equal(t, base, result.CapturedAt)
+			assert.Equal(t, base.Add(4*time.Second), result.TranscribedAt)
+		case <-time.After(300 * time.Millisecond):
+			t.Fatal("Expected output within timeout")
+		}
+	})
+}
+
 func TestContextBuffer_BufferTimeout(t *testing.T) {
 	// Arrange
 	bufferDurationMS := 50 // Very short timeout
@@ -153,3 +264,284 @@ func TestContextBuffer_BufferTimeout(t *testing.T) {
 		t.Fatal("Expected output within timeout")
 	}
 }
+
+func TestContextBuffer_NoSpeechThreshold(t *testing.T) {
+	// Arrange
+	inputCh := make(chan transcriber.TranscriptionSegment, 10)
+	outputCh := make(chan BufferedContext, 10)
+	cb := NewContextBuffer(50, inputCh, outputCh)
+	cb.SetNoSpeechThreshold(0.6)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	segments := []transcriber.TranscriptionSegment{
+		{Text: "Text WIN", StartMS: 0, EndMS: 1000, Confidence: 0.9, NoSpeechProb: 0.1},
+		{Text: "Thank you.", StartMS: 1000, EndMS: 2000, Confidence: 0.3, NoSpeechProb: 0.9},
+		{Text: "to 12345", StartMS: 2000, EndMS: 3000, Confidence: 0.9},
+	}
+
+	// Act
+	err := cb.Start(ctx)
+	assert.NoError(t, err)
+	for _, segment := range segments {
+		inputCh <- segment
+	}
+	close(inputCh)
+
+	// Assert
+	select {
+	case result := <-outputCh:
+		assert.Equal(t, "Text WIN to 12345", result.Text)
+		assert.Equal(t, int64(1), cb.DroppedNoSpeech())
+	case <-time.After(time.Second):
+		t.Fatal("Expected output within timeout")
+	}
+}
+
+func TestContextBuffer_NoSpeechThresholdDisabled(t *testing.T) {
+	// Arrange
+	inputCh := make(chan transcriber.TranscriptionSegment, 10)
+	outputCh := make(chan BufferedContext, 10)
+	cb := NewContextBuffer(50, inputCh, outputCh)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Act
+	err := cb.Start(ctx)
+	assert.NoError(t, err)
+	inputCh <- transcriber.TranscriptionSegment{Text: "Thank you.", StartMS: 0, EndMS: 1000, Confidence: 0.3, NoSpeechProb: 0.9}
+	close(inputCh)
+
+	// Assert
+	select {
+	case result := <-outputCh:
+		assert.Equal(t, "Thank you.", result.Text)
+		assert.Equal(t, int64(0), cb.DroppedNoSpeech())
+	case <-time.After(time.Second):
+		t.Fatal("Expected output within timeout")
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	t.Run("should leave text within the limit unchanged", func(t *testing.T) {
+		text, truncated := truncateText("Text WIN to 12345", 17)
+
+		assert.Equal(t, "Text WIN to 12345", text)
+		assert.False(t, truncated)
+	})
+
+	t.Run("should cut long text and end it with an ellipsis", func(t *testing.T) {
+		text, truncated := truncateText("Text WIN to 12345", 11)
+
+		assert.Equal(t, "Text WIN…", text)
+		assert.LessOrEqual(t, len(text), 11)
+		assert.True(t, truncated)
+	})
+
+	t.Run("should not split a character", func(t *testing.T) {
+		text, _ := truncateText("café café café", 7)
+
+		assert.Equal(t, "caf…", text)
+	})
+
+	t.Run("should leave text unbounded without a limit", func(t *testing.T) {
+		text, truncated := truncateText("Text WIN to 12345", 0)
+
+		assert.Equal(t, "Text WIN to 12345", text)
+		assert.False(t, truncated)
+	})
+}
+
+func TestContextBuffer_TextLimits(t *testing.T) {
+	t.Run("should truncate contexts longer than the context limit", func(t *testing.T) {
+		// Arrange
+		inputCh := make(chan transcriber.TranscriptionSegment, 10)
+		outputCh := make(chan BufferedContext, 10)
+		cb := NewContextBuffer(50, inputCh, outputCh)
+		cb.SetTextLimits(20, 0)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Act
+		assert.NoError(t, cb.Start(ctx))
+		inputCh <- transcriber.TranscriptionSegment{Text: "Text WIN to 12345", StartMS: 0, EndMS: 1000}
+		inputCh <- transcriber.TranscriptionSegment{Text: "for your chance", StartMS: 1000, EndMS: 2000}
+		close(inputCh)
+
+		// Assert
+		select {
+		case result := <-outputCh:
+			assert.Equal(t, "Text WIN to 12345…", result.Text)
+			assert.True(t, result.Truncated)
+			assert.Equal(t, int64(1), cb.Truncations())
+		case <-time.After(time.Second):
+			t.Fatal("Expected output within timeout")
+		}
+	})
+
+	t.Run("should flush early rather than hold more than the buffered limit", func(t *testing.T) {
+		// Arrange
+		inputCh := make(chan transcriber.TranscriptionSegment, 10)
+		outputCh := make(chan BufferedContext, 10)
+		cb := NewContextBuffer(10000, inputCh, outputCh)
+		cb.SetTextLimits(0, 20)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Act
+		assert.NoError(t, cb.Start(ctx))
+		inputCh <- transcriber.TranscriptionSegment{Text: "Text WIN to 12345", StartMS: 0, EndMS: 1000}
+		inputCh <- transcriber.TranscriptionSegment{Text: "for your chance", StartMS: 1000, EndMS: 2000}
+
+		// Assert
+		select {
+		case result := <-outputCh:
+			assert.Equal(t, "Text WIN to 12345", result.Text)
+			assert.False(t, result.Truncated)
+		case <-time.After(time.Second):
+			t.Fatal("Expected an early flush within timeout")
+		}
+		close(inputCh)
+		select {
+		case result := <-outputCh:
+			assert.Equal(t, "for your chance", result.Text)
+		case <-time.After(time.Second):
+			t.Fatal("Expected output within timeout")
+		}
+		assert.Equal(t, int64(0), cb.Truncations())
+	})
+
+	t.Run("should truncate a single segment larger than the buffered limit", func(t *testing.T) {
+		// Arrange
+		inputCh := make(chan transcriber.TranscriptionSegment, 10)
+		outputCh := make(chan BufferedContext, 10)
+		cb := NewContextBuffer(50, inputCh, outputCh)
+		cb.SetTextLimits(0, 12)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Act
+		assert.NoError(t, cb.Start(ctx))
+		inputCh <- transcriber.TranscriptionSegment{Text: "thank you thank you thank you", StartMS: 0, EndMS: 1000}
+		close(inputCh)
+
+		// Assert
+		select {
+		case result := <-outputCh:
+			assert.Equal(t, "thank you…", result.Text)
+			assert.True(t, result.Truncated)
+			assert.Equal(t, int64(1), cb.Truncations())
+		case <-time.After(time.Second):
+			t.Fatal("Expected output within timeout")
+		}
+	})
+}
+
+func TestContextBuffer_GapDetection(t *testing.T) {
+	start := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	// receive returns the next context from outputCh
+	receive := func(t *testing.T, outputCh <-chan BufferedContext) BufferedContext {
+		t.Helper()
+		select {
+		case result := <-outputCh:
+			return result
+		case <-time.After(time.Second):
+			t.Fatal("Expected output within timeout")
+			return BufferedContext{}
+		}
+	}
+
+	t.Run("should flush and send a gap marker when audio resumes after the threshold", func(t *testing.T) {
+		// Arrange
+		inputCh := make(chan transcriber.TranscriptionSegment, 10)
+		outputCh := make(chan BufferedContext, 10)
+		cb := NewContextBuffer(10000, inputCh, outputCh)
+		cb.SetGapThreshold(30 * time.Second)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Act
+		assert.NoError(t, cb.Start(ctx))
+		inputCh <- transcriber.TranscriptionSegment{Text: "Text WIN", StartMS: 0, EndMS: 2000, CapturedAt: start}
+		inputCh <- transcriber.TranscriptionSegment{Text: "to 12345", StartMS: 0, EndMS: 2000, CapturedAt: start.Add(3 * time.Minute)}
+		close(inputCh)
+
+		// Assert
+		before := receive(t, outputCh)
+		assert.Equal(t, "Text WIN", before.Text)
+		assert.False(t, before.IsGap())
+
+		marker := receive(t, outputCh)
+		assert.True(t, marker.IsGap())
+		assert.Empty(t, marker.Text)
+		assert.Equal(t, 3*time.Minute-2*time.Second, marker.Gap())
+		assert.Equal(t, start.Add(2*time.Second), marker.CapturedAt)
+		assert.NoError(t, marker.Validate())
+
+		after := receive(t, outputCh)
+		assert.Equal(t, "to 12345", after.Text)
+		assert.Equal(t, int64(1), cb.Gaps())
+	})
+
+	t.Run("should combine segments that follow on within the threshold", func(t *testing.T) {
+		// Arrange
+		inputCh := make(chan transcriber.TranscriptionSegment, 10)
+		outputCh := make(chan BufferedContext, 10)
+		cb := NewContextBuffer(50, inputCh, outputCh)
+		cb.SetGapThreshold(30 * time.Second)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Act
+		assert.NoError(t, cb.Start(ctx))
+		inputCh <- transcriber.TranscriptionSegment{Text: "Text WIN", StartMS: 0, EndMS: 2000, CapturedAt: start}
+		inputCh <- transcriber.TranscriptionSegment{Text: "to 12345", StartMS: 0, EndMS: 2000, CapturedAt: start.Add(20 * time.Second)}
+		close(inputCh)
+
+		// Assert
+		assert.Equal(t, "Text WIN to 12345", receive(t, outputCh).Text)
+		assert.Equal(t, int64(0), cb.Gaps())
+	})
+
+	t.Run("should count dropped no-speech segments as audio heard", func(t *testing.T) {
+		// Arrange
+		inputCh := make(chan transcriber.TranscriptionSegment, 10)
+		outputCh := make(chan BufferedContext, 10)
+		cb := NewContextBuffer(50, inputCh, outputCh)
+		cb.SetGapThreshold(30 * time.Second)
+		cb.SetNoSpeechThreshold(0.6)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Act
+		assert.NoError(t, cb.Start(ctx))
+		inputCh <- transcriber.TranscriptionSegment{Text: "Text WIN", StartMS: 0, EndMS: 2000, CapturedAt: start}
+		inputCh <- transcriber.TranscriptionSegment{Text: "la la", StartMS: 0, EndMS: 2000, NoSpeechProb: 0.9, CapturedAt: start.Add(25 * time.Second)}
+		inputCh <- transcriber.TranscriptionSegment{Text: "to 12345", StartMS: 0, EndMS: 2000, CapturedAt: start.Add(50 * time.Second)}
+		close(inputCh)
+
+		// Assert
+		assert.Equal(t, "Text WIN to 12345", receive(t, outputCh).Text)
+		assert.Equal(t, int64(0), cb.Gaps())
+	})
+
+	t.Run("should not detect gaps when disabled", func(t *testing.T) {
+		// Arrange
+		inputCh := make(chan transcriber.TranscriptionSegment, 10)
+		outputCh := make(chan BufferedContext, 10)
+		cb := NewContextBuffer(50, inputCh, outputCh)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Act
+		assert.NoError(t, cb.Start(ctx))
+		inputCh <- transcriber.TranscriptionSegment{Text: "Text WIN", StartMS: 0, EndMS: 2000, CapturedAt: start}
+		inputCh <- transcriber.TranscriptionSegment{Text: "to 12345", StartMS: 0, EndMS: 2000, CapturedAt: start.Add(time.Hour)}
+		close(inputCh)
+
+		// Assert
+		assert.Equal(t, "Text WIN to 12345", receive(t, outputCh).Text)
+		assert.Equal(t, int64(0), cb.Gaps())
+	})
+}
@@ -0,0 +1,287 @@
+// Package calendar exports contest sessions as calendar events, so entry deadlines show up on
+// a phone's calendar. A session is a contest keyword and number announced on a station; repeats
+// heard while its entry window is open belong to the same session. The window ends at the
+// deadline heard in the transcript around the cue ("you have 20 minutes to text"), or after a
+// default length when none is heard. Events are written to an ICS file to subscribe to and/or
+// pushed to a CalDAV calendar.
+package calendar
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"radiocontestwinner/internal/parser"
+)
+
+// followWindow is how long before and after a cue the transcript is searched for its deadline
+const followWindow = time.Minute
+
+// Config configures the exporter
+type Config struct {
+	ICSPath string // ICS file rewritten with every retained event; empty writes no file
+
+	CalDAVURL string // Calendar collection URL events are PUT into; empty pushes nothing
+	Username  string
+	Password  string
+	Timeout   time.Duration // Per CalDAV request
+
+	DefaultWindow time.Duration  // Entry window assumed when no deadline is heard
+	Reminder      time.Duration  // Alarm this long before the deadline; 0 adds no alarm
+	Retention     time.Duration  // How long after their deadline events stay in the ICS file
+	Location      *time.Location // Zone of clock-time deadlines and times in event text; nil uses local time
+}
+
+// Event is the calendar event of one contest session
+type Event struct {
+	UID           string    `json:"uid"`
+	Keyword       string    `json:"keyword"`
+	Number        string    `json:"number"`
+	Station       string    `json:"station,omitempty"`
+	Start         time.Time `json:"start"` // When the first cue of the session was heard
+	End           time.Time `json:"end"`   // The entry deadline
+	DeadlineHeard bool      `json:"deadline_heard"`
+	Transcript    string    `json:"transcript"` // Text of the first cue
+	Cues          int       `json:"cues"`       // Cues heard during the session
+	Updated       time.Time `json:"updated"`
+}
+
+// Summary is the event title, e.g. "Text CASH to 72881 (KXYZ)"
+func (e Event) Summary() string {
+	summary := fmt.Sprintf("Text %s to %s", e.Keyword, e.Number)
+	if e.Station != "" {
+		summary += " (" + e.Station + ")"
+	}
+	return summary
+}
+
+// heardText is transcript text and when it was heard
+type heardText struct {
+	at   time.Time
+	text string
+}
+
+// Exporter turns contest cues into calendar events. It is safe for concurrent use.
+type Exporter struct {
+	config Config
+	client *http.Client
+	now    func() time.Time
+
+	mu     sync.Mutex
+	events []*Event    // Oldest first
+	recent []heardText // Transcript heard within followWindow
+	fileMu sync.Mutex  // Serializes ICS file writes
+}
+
+// NewExporter creates an exporter
+func NewExporter(config Config) *Exporter {
+	if config.DefaultWindow <= 0 {
+		config.DefaultWindow = 15 * time.Minute
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	if config.Location == nil {
+		config.Location = time.Local
+	}
+	return &Exporter{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+		now:    time.Now,
+	}
+}
+
+// ObserveText records transcript text heard at the given time. A deadline in it completes the
+// sessions started within the last minute whose deadline had not been heard; their updated
+// events are returned to publish.
+func (e *Exporter) ObserveText(at time.Time, text string) []Event {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	cutoff := at.Add(-followWindow)
+	kept := e.recent[:0]
+	for _, heard := range e.recent {
+		if heard.at.After(cutoff) {
+			kept = append(kept, heard)
+		}
+	}
+	e.recent = append(kept, heardText{at: at, text: text})
+
+	var updated []Event
+	deadline, ok := InferDeadline(text, at, e.config.Location)
+	if !ok {
+		return nil
+	}
+	for _, event := range e.events {
+		if !event.DeadlineHeard && !at.Before(event.Start) && at.Sub(event.Start) <= followWindow {
+			event.End, event.DeadlineHeard, event.Updated = deadline, true, e.now()
+			updated = append(updated, *event)
+		}
+	}
+	return updated
+}
+
+// AddCue adds a cue to its contest session, starting a session when none is open for its keyword,
+// number, and station. It returns the session's event and whether it is new or changed.
+func (e *Exporter) AddCue(cue parser.ContestCue) (Event, bool) {
+	heardAt := cue.HeardAt()
+	if heardAt.IsZero() {
+		heardAt = e.now()
+	}
+	keyword := strings.ToUpper(cue.Details.Keyword)
+	number := cue.Details.Number
+	station := cue.Details.String("station_name")
+	text := cue.Details.OriginalText
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.prune()
+
+	for _, event := range e.events {
+		if event.Keyword == keyword && event.Number == number && event.Station == station &&
+			!heardAt.Before(event.Start) && heardAt.Before(event.End) {
+			event.Cues++
+			if deadline, ok := InferDeadline(text, heardAt, e.config.Location); ok && !event.DeadlineHeard {
+				event.End, event.DeadlineHeard, event.Updated = deadline, true, e.now()
+				return *event, true
+			}
+			return *event, false
+		}
+	}
+
+	event := &Event{
+		UID:        cue.CueID + "@radiocontestwinner",
+		Keyword:    keyword,
+		Number:     number,
+		Station:    station,
+		Start:      heardAt,
+		End:        heardAt.Add(e.config.DefaultWindow),
+		Transcript: text,
+		Cues:       1,
+		Updated:    e.now(),
+	}
+	// The deadline is often announced just before the cue, or in the cue's own text
+	if deadline, ok := e.recentDeadline(heardAt, text); ok {
+		event.End, event.DeadlineHeard = deadline, true
+	}
+	e.events = append(e.events, event)
+	return *event, true
+}
+
+// recentDeadline finds a deadline in the cue text or in the transcript heard shortly before it
+func (e *Exporter) recentDeadline(heardAt time.Time, text string) (time.Time, bool) {
+	if deadline, ok := InferDeadline(text, heardAt, e.config.Location); ok {
+		return deadline, true
+	}
+	for i := len(e.recent) - 1; i >= 0; i-- {
+		heard := e.recent[i]
+		if heardAt.Sub(heard.at) > followWindow {
+			break
+		}
+		if deadline, ok := InferDeadline(heard.text, heard.at, e.config.Location); ok && deadline.After(heardAt) {
+			return deadline, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// prune forgets events whose deadline passed more than the retention ago
+func (e *Exporter) prune() {
+	if e.config.Retention <= 0 {
+		return
+	}
+	cutoff := e.now().Add(-e.config.Retention)
+	kept := e.events[:0]
+	for _, event := range e.events {
+		if event.End.After(cutoff) {
+			kept = append(kept, event)
+		}
+	}
+	e.events = kept
+}
+
+// Events returns the retained events, oldest first
+func (e *Exporter) Events() []Event {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	events := make([]Event, len(e.events))
+	for i, event := range e.events {
+		events[i] = *event
+	}
+	return events
+}
+
+// Publish rewrites the ICS file with every retained event and pushes the given new or changed
+// events to CalDAV. It returns the errors of the outputs that failed.
+func (e *Exporter) Publish(ctx context.Context, changed []Event) error {
+	var errs []error
+	if e.config.ICSPath != "" {
+		if err := e.writeICSFile(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if e.config.CalDAVURL != "" {
+		for _, event := range changed {
+			if err := e.putCalDAV(ctx, event); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// writeICSFile atomically replaces the ICS file with the retained events
+func (e *Exporter) writeICSFile() error {
+	events := e.Events()
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Start.Before(events[j].Start) })
+	data := e.encode(events...)
+
+	e.fileMu.Lock()
+	defer e.fileMu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(e.config.ICSPath), 0755); err != nil {
+		return fmt.Errorf("failed to create calendar directory: %w", err)
+	}
+	tmp := e.config.ICSPath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write calendar file: %w", err)
+	}
+	if err := os.Rename(tmp, e.config.ICSPath); err != nil {
+		return fmt.Errorf("failed to replace calendar file: %w", err)
+	}
+	return nil
+}
+
+// putCalDAV creates or replaces the event's resource in the CalDAV calendar
+func (e *Exporter) putCalDAV(ctx context.Context, event Event) error {
+	endpoint := strings.TrimSuffix(e.config.CalDAVURL, "/") + "/" + url.PathEscape(event.UID) + ".ics"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, bytes.NewReader(e.encode(event)))
+	if err != nil {
+		return fmt.Errorf("failed to create CalDAV request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/calendar; charset=utf-8")
+	if e.config.Username != "" {
+		req.SetBasicAuth(e.config.Username, e.config.Password)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("CalDAV request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("CalDAV server returned status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package calendar
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/parser"
+)
+
+// newCue creates a cue heard at heardAt
+func newCue(keyword, number, text string, heardAt time.Time) parser.ContestCue {
+	cue := parser.NewContestCue(keyword, parser.CueDetails{
+		Keyword:      keyword,
+		Number:       number,
+		OriginalText: text,
+		Extensions:   map[string]interface{}{"station_name": "KXYZ"},
+	})
+	cue.Timing = parser.NewCueTiming(heardAt, time.Time{}, heardAt)
+	return *cue
+}
+
+// newTestExporter creates an exporter whose clock reads *now
+func newTestExporter(config Config, now *time.Time) *Exporter {
+	config.Location = time.UTC
+	exporter := NewExporter(config)
+	exporter.now = func() time.Time { return *now }
+	return exporter
+}
+
+func TestExporter_AddCue(t *testing.T) {
+	t.Run("should start a session ending at the deadline heard with the cue", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2025, 3, 4, 15, 0, 0, 0, time.UTC)
+		exporter := newTestExporter(Config{}, &now)
+		exporter.ObserveText(now.Add(-10*time.Second), "Our thousand dollar keyword is here and you have 20 minutes")
+
+		// Act
+		event, changed := exporter.AddCue(newCue("cash", "72881", "Text CASH to 72881", now))
+
+		// Assert
+		assert.True(t, changed)
+		assert.Equal(t, "Text CASH to 72881 (KXYZ)", event.Summary())
+		assert.Equal(t, now, event.Start)
+		assert.Equal(t, now.Add(-10*time.Second).Add(20*time.Minute), event.End)
+		assert.True(t, event.DeadlineHeard)
+	})
+
+	t.Run("should assume the default window without a deadline", func(t *testing.T) {
+		now := time.Date(2025, 3, 4, 15, 0, 0, 0, time.UTC)
+		exporter := newTestExporter(Config{DefaultWindow: 10 * time.Minute}, &now)
+
+		event, _ := exporter.AddCue(newCue("CASH", "72881", "Text CASH to 72881", now))
+
+		assert.Equal(t, now.Add(10*time.Minute), event.End)
+		assert.False(t, event.DeadlineHeard)
+	})
+
+	t.Run("should add repeats to the open session", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2025, 3, 4, 15, 0, 0, 0, time.UTC)
+		exporter := newTestExporter(Config{}, &now)
+		first, _ := exporter.AddCue(newCue("CASH", "72881", "Text CASH to 72881", now))
+
+		// Act
+		repeat, changed := exporter.AddCue(newCue("CASH", "72881", "again, text CASH to 72881", now.Add(2*time.Minute)))
+		later, _ := exporter.AddCue(newCue("CASH", "72881", "Text CASH to 72881", now.Add(time.Hour)))
+
+		// Assert
+		assert.False(t, changed)
+		assert.Equal(t, first.UID, repeat.UID)
+		assert.Equal(t, 2, repeat.Cues)
+		assert.NotEqual(t, first.UID, later.UID)
+		assert.Len(t, exporter.Events(), 2)
+	})
+
+	t.Run("should forget events past the retention", func(t *testing.T) {
+		now := time.Date(2025, 3, 4, 15, 0, 0, 0, time.UTC)
+		exporter := newTestExporter(Config{Retention: 24 * time.Hour}, &now)
+		exporter.AddCue(newCue("CASH", "72881", "Text CASH to 72881", now))
+
+		now = now.Add(48 * time.Hour)
+		exporter.AddCue(newCue("ROCK", "55555", "Text ROCK to 55555", now))
+
+		events := exporter.Events()
+		require.Len(t, events, 1)
+		assert.Equal(t, "ROCK", events[0].Keyword)
+	})
+}
+
+func TestExporter_ObserveText(t *testing.T) {
+	t.Run("should complete a session with a deadline heard just after its cue", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2025, 3, 4, 15, 0, 0, 0, time.UTC)
+		exporter := newTestExporter(Config{}, &now)
+		event, _ := exporter.AddCue(newCue("CASH", "72881", "Text CASH to 72881", now))
+
+		// Act
+		updated := exporter.ObserveText(now.Add(5*time.Second), "you've got fifteen minutes, good luck")
+		late := exporter.ObserveText(now.Add(5*time.Minute), "you have 30 minutes to enter the other contest")
+
+		// Assert
+		require.Len(t, updated, 1)
+		assert.Equal(t, event.UID, updated[0].UID)
+		assert.Equal(t, now.Add(5*time.Second).Add(15*time.Minute), updated[0].End)
+		assert.True(t, updated[0].DeadlineHeard)
+		assert.Empty(t, late)
+	})
+}
+
+func TestExporter_Publish(t *testing.T) {
+	t.Run("should write every retained event to the ICS file", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2025, 3, 4, 15, 0, 0, 0, time.UTC)
+		path := filepath.Join(t.TempDir(), "calendar", "contests.ics")
+		exporter := newTestExporter(Config{ICSPath: path, Reminder: 5 * time.Minute}, &now)
+		event, _ := exporter.AddCue(newCue("CASH", "72881", "Text CASH to 72881, you have 20 minutes", now))
+
+		// Act
+		err := exporter.Publish(context.Background(), []Event{event})
+
+		// Assert
+		require.NoError(t, err)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		ics := string(data)
+		assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n"))
+		assert.Contains(t, ics, "UID:"+event.UID+"\r\n")
+		assert.Contains(t, ics, "DTSTART:20250304T150000Z\r\n")
+		assert.Contains(t, ics, "DTEND:20250304T152000Z\r\n")
+		assert.Contains(t, ics, "SUMMARY:Text CASH to 72881 (KXYZ)\r\n")
+		assert.Contains(t, ics, "TRIGGER;RELATED=END:-PT5M\r\n")
+		assert.Contains(t, ics, `"Text CASH to 72881\, you have 20`)
+		for _, line := range strings.Split(ics, "\r\n") {
+			assert.LessOrEqual(t, len(line), maxLineOctets, line)
+		}
+	})
+
+	t.Run("should put changed events to the CalDAV calendar", func(t *testing.T) {
+		// Arrange
+		var path, contentType, body string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, pass, _ := r.BasicAuth()
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "me", user)
+			assert.Equal(t, "secret", pass)
+			data, _ := io.ReadAll(r.Body)
+			path, contentType, body = r.URL.Path, r.Header.Get("Content-Type"), string(data)
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+		now := time.Date(2025, 3, 4, 15, 0, 0, 0, time.UTC)
+		exporter := newTestExporter(Config{CalDAVURL: server.URL + "/calendars/me/contests/", Username: "me", Password: "secret"}, &now)
+		event, _ := exporter.AddCue(newCue("CASH", "72881", "Text CASH to 72881", now))
+
+		// Act
+		err := exporter.Publish(context.Background(), []Event{event})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "/calendars/me/contests/"+event.UID+".ics", path)
+		assert.Equal(t, "text/calendar; charset=utf-8", contentType)
+		assert.Contains(t, body, "UID:"+event.UID)
+	})
+
+	t.Run("should report CalDAV errors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+		}))
+		defer server.Close()
+		now := time.Date(2025, 3, 4, 15, 0, 0, 0, time.UTC)
+		exporter := newTestExporter(Config{CalDAVURL: server.URL}, &now)
+		event, _ := exporter.AddCue(newCue("CASH", "72881", "Text CASH to 72881", now))
+
+		err := exporter.Publish(context.Background(), []Event{event})
+
+		assert.ErrorContains(t, err, "CalDAV server returned status 403")
+	})
+}
+
+func TestWriteFolded(t *testing.T) {
+	t.Run("should fold long lines without splitting characters", func(t *testing.T) {
+		var buf bytes.Buffer
+		content := "DESCRIPTION:" + strings.Repeat("é", 80)
+
+		writeFolded(&buf, content)
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+		require.Greater(t, len(lines), 1)
+		unfolded := lines[0]
+		for _, line := range lines[1:] {
+			assert.True(t, strings.HasPrefix(line, " "))
+			unfolded += line[1:]
+		}
+		assert.Equal(t, content, unfolded)
+		for _, line := range lines {
+			assert.LessOrEqual(t, len(line), maxLineOctets)
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package calendar
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxDeadline bounds inferred deadlines; anything further out is not an entry window
+const maxDeadline = 24 * time.Hour
+
+// numberWords are the spelled numbers transcriptions use for entry windows
+var numberWords = map[string]int{
+	"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6, "seven": 7,
+	"eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14,
+	"fifteen": 15, "sixteen": 16, "seventeen": 17, "eighteen": 18, "nineteen": 19, "twenty": 20,
+	"thirty": 30, "forty": 40, "fifty": 50, "sixty": 60, "ninety": 90,
+}
+
+// amountPattern matches a spoken length of time such as "20 minutes", "twenty five minutes",
+// "an hour", or "half an hour"
+const amountPattern = `(half an hour|(?:\d+|[a-z]+(?:[ -][a-z]+)?)\s+(?:minutes?|mins?|hours?))`
+
+var (
+	// Relative windows: "you have 20 minutes to text", "within the next ten minutes",
+	// "15 minutes left to enter"
+	relativeDeadlinePatterns = []*regexp.Regexp{
+		regexp.MustCompile(`\byou(?:'ve got| have got|'ve| have| got)\s+(?:only\s+|just\s+|about\s+)?` + amountPattern + `\b`),
+		regexp.MustCompile(`\b(?:within|in) the next\s+` + amountPattern + `\b`),
+		regexp.MustCompile(`\b` + amountPattern + `\s+(?:left|remaining|to (?:text|enter|call))\b`),
+	}
+	// "before the top of the hour", "until the top of the hour"
+	topOfHourPattern = regexp.MustCompile(`\b(?:before|by|until|till) the top of the hour\b`)
+	// Clock times: "by 3 pm", "before 3:30 p.m.", "until four o'clock"
+	clockDeadlinePattern = regexp.MustCompile(`\b(?:by|before|until|till)\s+(\d{1,2}|[a-z]+)(?::(\d{2}))?\s*(a\.?m\.?|p\.?m\.?|o'clock)`)
+)
+
+// InferDeadline finds the entry deadline announced in text heard at heardAt, such as "you have
+// 20 minutes to text" or "before the top of the hour". Clock times are read in loc, or in
+// heardAt's zone when loc is nil. The first deadline mentioned in the text wins.
+func InferDeadline(text string, heardAt time.Time, loc *time.Location) (time.Time, bool) {
+	if loc == nil {
+		loc = heardAt.Location()
+	}
+	text = strings.ToLower(text)
+
+	var deadline time.Time
+	first := -1
+	consider := func(index int, candidate time.Time) {
+		if candidate.After(heardAt) && candidate.Sub(heardAt) <= maxDeadline && (first == -1 || index < first) {
+			first, deadline = index, candidate
+		}
+	}
+
+	for _, pattern := range relativeDeadlinePatterns {
+		for _, match := range pattern.FindAllStringSubmatchIndex(text, -1) {
+			if amount, ok := parseAmount(text[match[2]:match[3]]); ok {
+				consider(match[0], heardAt.Add(amount))
+				break
+			}
+		}
+	}
+	if match := topOfHourPattern.FindStringIndex(text); match != nil {
+		local := heardAt.In(loc)
+		consider(match[0], time.Date(local.Year(), local.Month(), local.Day(), local.Hour()+1, 0, 0, 0, loc))
+	}
+	for _, match := range clockDeadlinePattern.FindAllStringSubmatchIndex(text, -1) {
+		minute := 0
+		if match[4] != -1 {
+			minute, _ = strconv.Atoi(text[match[4]:match[5]])
+		}
+		if at, ok := nextClockTime(heardAt.In(loc), text[match[2]:match[3]], minute, text[match[6]:match[7]]); ok {
+			consider(match[0], at)
+			break
+		}
+	}
+	return deadline, first != -1
+}
+
+// parseAmount converts a spoken length of time matched by amountPattern to a duration
+func parseAmount(amount string) (time.Duration, bool) {
+	if amount == "half an hour" {
+		return 30 * time.Minute, true
+	}
+	fields := strings.Fields(strings.ReplaceAll(amount, "-", " "))
+	unit := fields[len(fields)-1]
+	count, ok := parseNumber(fields[:len(fields)-1])
+	if !ok || count <= 0 {
+		return 0, false
+	}
+	if strings.HasPrefix(unit, "hour") {
+		return time.Duration(count) * time.Hour, true
+	}
+	return time.Duration(count) * time.Minute, true
+}
+
+// parseNumber reads digits or spelled numbers up to ninety-nine, such as "twenty five"
+func parseNumber(words []string) (int, bool) {
+	if len(words) == 1 {
+		if n, err := strconv.Atoi(words[0]); err == nil {
+			return n, true
+		}
+	}
+	total := 0
+	for i, word := range words {
+		n, ok := numberWords[word]
+		if !ok || (i > 0 && (total%10 != 0 || total < 20 || n >= 10)) {
+			return 0, false
+		}
+		total += n
+	}
+	return total, len(words) > 0
+}
+
+// nextClockTime returns the first time after local at the spoken hour and minute. Without a.m.
+// or p.m. the nearer of the two is used.
+func nextClockTime(local time.Time, hourText string, minute int, suffix string) (time.Time, bool) {
+	hour, ok := parseNumber([]string{hourText})
+	if !ok || hour < 1 || hour > 12 || minute > 59 {
+		return time.Time{}, false
+	}
+	hour %= 12
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+	var candidates []int
+	switch suffix[0] {
+	case 'a':
+		candidates = []int{hour}
+	case 'p':
+		candidates = []int{hour + 12}
+	default:
+		candidates = []int{hour, hour + 12}
+	}
+	for _, dayOffset := range []int{0, 1} {
+		for _, h := range candidates {
+			if at := day.AddDate(0, 0, dayOffset).Add(time.Duration(h)*time.Hour + time.Duration(minute)*time.Minute); at.After(local) {
+				return at, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,66 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferDeadline(t *testing.T) {
+	heardAt := time.Date(2025, 3, 4, 15, 12, 0, 0, time.UTC)
+
+	t.Run("should read relative entry windows", func(t *testing.T) {
+		cases := map[string]time.Duration{
+			"You have 20 minutes to text CASH to 72881":        20 * time.Minute,
+			"you've got twenty five minutes, go go go":         25 * time.Minute,
+			"text it within the next ten minutes":              10 * time.Minute,
+			"only 15 minutes left to enter":                    15 * time.Minute,
+			"you have half an hour to get your entry in":       30 * time.Minute,
+			"You have an hour to text WIN to 55555":            time.Hour,
+			"you have 5 minutes then 20 minutes later we call": 5 * time.Minute,
+		}
+		for text, window := range cases {
+			deadline, ok := InferDeadline(text, heardAt, time.UTC)
+
+			assert.True(t, ok, text)
+			assert.Equal(t, heardAt.Add(window), deadline, text)
+		}
+	})
+
+	t.Run("should read the top of the hour", func(t *testing.T) {
+		deadline, ok := InferDeadline("Text ROCK to 55555 before the top of the hour", heardAt, time.UTC)
+
+		assert.True(t, ok)
+		assert.Equal(t, time.Date(2025, 3, 4, 16, 0, 0, 0, time.UTC), deadline)
+	})
+
+	t.Run("should read clock times in the station's zone", func(t *testing.T) {
+		chicago, err := time.LoadLocation("America/Chicago")
+		if err != nil {
+			t.Skip("time zone database unavailable")
+		}
+		at := time.Date(2025, 3, 4, 14, 50, 0, 0, chicago)
+
+		deadline, ok := InferDeadline("get your text in by 3:30 p.m.", at, chicago)
+		assert.True(t, ok)
+		assert.Equal(t, time.Date(2025, 3, 4, 15, 30, 0, 0, chicago), deadline)
+
+		deadline, ok = InferDeadline("lines are open until four o'clock", at, chicago)
+		assert.True(t, ok)
+		assert.Equal(t, time.Date(2025, 3, 4, 16, 0, 0, 0, chicago), deadline)
+	})
+
+	t.Run("should ignore text without an entry window", func(t *testing.T) {
+		for _, text := range []string{
+			"The keyword is coming up in about 10 minutes so stay right here",
+			"Traffic on I-35 is backed up, give yourself an extra 15 minutes",
+			"Text CASH to 72881 right now",
+			"",
+		} {
+			_, ok := InferDeadline(text, heardAt, time.UTC)
+
+			assert.False(t, ok, text)
+		}
+	})
+}
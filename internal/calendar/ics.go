@@ -0,0 +1,84 @@
+package calendar
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// icsTimeLayout is the UTC date-time form of RFC 5545
+const icsTimeLayout = "20060102T150405Z"
+
+// maxLineOctets is the longest content line RFC 5545 allows before folding
+const maxLineOctets = 75
+
+// encode renders events as an iCalendar (RFC 5545) document
+func (e *Exporter) encode(events ...Event) []byte {
+	var buf bytes.Buffer
+	line := func(content string) {
+		writeFolded(&buf, content)
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//radiocontestwinner//Contest deadlines//EN")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:Radio contest deadlines")
+	for _, event := range events {
+		line("BEGIN:VEVENT")
+		line("UID:" + escapeText(event.UID))
+		line("DTSTAMP:" + event.Updated.UTC().Format(icsTimeLayout))
+		line("DTSTART:" + event.Start.UTC().Format(icsTimeLayout))
+		line("DTEND:" + event.End.UTC().Format(icsTimeLayout))
+		line("SUMMARY:" + escapeText(event.Summary()))
+		line("DESCRIPTION:" + escapeText(e.description(event)))
+		line("CATEGORIES:Radio contest")
+		if e.config.Reminder > 0 {
+			line("BEGIN:VALARM")
+			line("ACTION:DISPLAY")
+			line("DESCRIPTION:" + escapeText(event.Summary()+" before "+event.End.In(e.config.Location).Format("3:04 PM")))
+			line(fmt.Sprintf("TRIGGER;RELATED=END:-PT%dM", int(e.config.Reminder.Minutes())))
+			line("END:VALARM")
+		}
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return buf.Bytes()
+}
+
+// description explains where the event came from and how its deadline was found
+func (e *Exporter) description(event Event) string {
+	heard := "Heard at " + event.Start.In(e.config.Location).Format("3:04 PM")
+	if event.Station != "" {
+		heard = "Heard on " + event.Station + " at " + event.Start.In(e.config.Location).Format("3:04 PM")
+	}
+	deadline := fmt.Sprintf("No deadline heard on air; assuming %d minutes.", int(event.End.Sub(event.Start).Minutes()))
+	if event.DeadlineHeard {
+		deadline = "Deadline heard on air: " + event.End.In(e.config.Location).Format("3:04 PM") + "."
+	}
+	return fmt.Sprintf("%s: %q\n%s\nCues heard: %d", heard, event.Transcript, deadline, event.Cues)
+}
+
+// escapeText escapes a TEXT property value
+func escapeText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`).Replace(text)
+}
+
+// writeFolded writes a content line ending in CRLF, folding it at 75 octets without splitting
+// a character
+func writeFolded(buf *bytes.Buffer, content string) {
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		buf.WriteString(content[:cut])
+		buf.WriteString("\r\n ")
+		content = content[cut:]
+		limit = maxLineOctets - 1 // Continuation lines start with a space
+	}
+	buf.WriteString(content)
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,80 @@
+package clock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MonitorConfig configures the clock drift monitor
+type MonitorConfig struct {
+	Server    string        // NTP server, host or host:port
+	MaxOffset time.Duration // Largest offset still considered in sync
+	Timeout   time.Duration // Per-query timeout
+}
+
+// Status is the outcome of the most recent clock check
+type Status struct {
+	Server    string
+	Offset    time.Duration // How far the local clock is ahead of NTP time; negative when behind
+	RoundTrip time.Duration
+	CheckedAt time.Time // Zero until a check succeeded
+	Error     string    // Error of the most recent check, empty when it succeeded
+	InSync    bool      // Whether the last known offset is within MaxOffset
+}
+
+// Checked reports whether the offset was ever measured
+func (s Status) Checked() bool {
+	return !s.CheckedAt.IsZero()
+}
+
+// Monitor periodically measures the local clock's offset from an NTP server, so cue
+// timestamps can be trusted, or corrected, when proving an entry was sent in time
+type Monitor struct {
+	config MonitorConfig
+	query  func(ctx context.Context, server string) (Measurement, error)
+
+	mu     sync.Mutex
+	status Status
+}
+
+// NewMonitor creates a clock drift monitor
+func NewMonitor(config MonitorConfig) *Monitor {
+	return &Monitor{
+		config: config,
+		query:  QueryNTP,
+		status: Status{Server: config.Server, InSync: true},
+	}
+}
+
+// Check queries the NTP server once and returns the updated status and whether InSync changed.
+// A failed query keeps the last measured offset, which is still the best estimate.
+func (m *Monitor) Check(ctx context.Context) (Status, bool) {
+	if m.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.config.Timeout)
+		defer cancel()
+	}
+	measurement, err := m.query(ctx, m.config.Server)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	wasInSync := m.status.InSync
+	if err != nil {
+		m.status.Error = err.Error()
+		return m.status, false
+	}
+	m.status.Error = ""
+	m.status.Offset = measurement.Offset
+	m.status.RoundTrip = measurement.RoundTrip
+	m.status.CheckedAt = measurement.At
+	m.status.InSync = m.config.MaxOffset <= 0 || measurement.Offset.Abs() <= m.config.MaxOffset
+	return m.status, m.status.InSync != wasInSync
+}
+
+// Status returns the result of the most recent check
+func (m *Monitor) Status() Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status
+}
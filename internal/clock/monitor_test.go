@@ -0,0 +1,63 @@
+package clock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMonitor(offsets ...interface{}) *Monitor {
+	monitor := NewMonitor(MonitorConfig{Server: "ntp.test", MaxOffset: 500 * time.Millisecond})
+	monitor.query = func(ctx context.Context, server string) (Measurement, error) {
+		next := offsets[0]
+		offsets = offsets[1:]
+		if err, ok := next.(error); ok {
+			return Measurement{}, err
+		}
+		return Measurement{Server: server, Offset: next.(time.Duration), At: time.Now()}, nil
+	}
+	return monitor
+}
+
+func TestMonitor_Check(t *testing.T) {
+	t.Run("should report a small offset as in sync", func(t *testing.T) {
+		// Arrange
+		monitor := newTestMonitor(120 * time.Millisecond)
+
+		// Act
+		status, changed := monitor.Check(context.Background())
+
+		// Assert
+		assert.True(t, status.InSync)
+		assert.False(t, changed)
+		assert.True(t, status.Checked())
+		assert.Equal(t, 120*time.Millisecond, monitor.Status().Offset)
+	})
+
+	t.Run("should report drift beyond the maximum and its recovery", func(t *testing.T) {
+		monitor := newTestMonitor(-2*time.Second, 10*time.Millisecond)
+
+		status, changed := monitor.Check(context.Background())
+		assert.False(t, status.InSync)
+		assert.True(t, changed)
+
+		status, changed = monitor.Check(context.Background())
+		assert.True(t, status.InSync)
+		assert.True(t, changed)
+	})
+
+	t.Run("should keep the last offset when a check fails", func(t *testing.T) {
+		monitor := newTestMonitor(2*time.Second, errors.New("timeout"))
+		monitor.Check(context.Background())
+
+		status, changed := monitor.Check(context.Background())
+
+		assert.False(t, changed)
+		assert.False(t, status.InSync)
+		assert.Equal(t, 2*time.Second, status.Offset)
+		assert.Equal(t, "timeout", status.Error)
+	})
+}
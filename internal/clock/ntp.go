@@ -0,0 +1,104 @@
+package clock
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+)
+
+// ntpEpochOffset is the number of seconds between the NTP epoch (1900) and the Unix epoch (1970)
+const ntpEpochOffset = 2208988800
+
+// ntpPacketSize is the size of an SNTP request and response without extensions
+const ntpPacketSize = 48
+
+// Measurement is the result of one NTP query
+type Measurement struct {
+	Server    string
+	Offset    time.Duration // How far the local clock is ahead of the server; negative when behind
+	RoundTrip time.Duration
+	At        time.Time // Local time the response was received
+}
+
+// QueryNTP asks an NTP server (host or host:port) for the time using SNTP (RFC 4330) and
+// measures the local clock's offset from it
+func QueryNTP(ctx context.Context, server string) (Measurement, error) {
+	address := server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		address = net.JoinHostPort(server, "123")
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", address)
+	if err != nil {
+		return Measurement{}, fmt.Errorf("failed to reach NTP server %s: %w", server, err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	request := make([]byte, ntpPacketSize)
+	request[0] = 0x23 // Leap indicator 0, version 4, mode 3 (client)
+	sent := time.Now()
+	putNTPTime(request[40:], sent) // Transmit timestamp, echoed back as the originate timestamp
+	if _, err := conn.Write(request); err != nil {
+		return Measurement{}, fmt.Errorf("failed to query NTP server %s: %w", server, err)
+	}
+
+	response := make([]byte, ntpPacketSize)
+	n, err := conn.Read(response)
+	received := time.Now()
+	if err != nil {
+		return Measurement{}, fmt.Errorf("no response from NTP server %s: %w", server, err)
+	}
+	measurement, err := parseNTPResponse(response[:n], request[40:48], sent, received)
+	if err != nil {
+		return Measurement{}, fmt.Errorf("invalid response from NTP server %s: %w", server, err)
+	}
+	measurement.Server = server
+	return measurement, nil
+}
+
+// parseNTPResponse computes the clock offset from an SNTP response to a request sent at sent
+// carrying originate as its transmit timestamp
+func parseNTPResponse(response, originate []byte, sent, received time.Time) (Measurement, error) {
+	if len(response) < ntpPacketSize {
+		return Measurement{}, fmt.Errorf("short packet of %d bytes", len(response))
+	}
+	if mode := response[0] & 0x07; mode != 4 {
+		return Measurement{}, fmt.Errorf("unexpected mode %d", mode)
+	}
+	if leap := response[0] >> 6; leap == 3 {
+		return Measurement{}, fmt.Errorf("server clock is not synchronized")
+	}
+	if stratum := response[1]; stratum == 0 || stratum > 15 {
+		return Measurement{}, fmt.Errorf("unusable stratum %d", stratum)
+	}
+	if string(response[24:32]) != string(originate) {
+		return Measurement{}, fmt.Errorf("response does not answer this request")
+	}
+
+	serverReceived := ntpTime(response[32:40])
+	serverTransmitted := ntpTime(response[40:48])
+
+	// Standard NTP offset and delay, from the local clock's point of view
+	offset := (serverReceived.Sub(sent) + serverTransmitted.Sub(received)) / 2
+	roundTrip := received.Sub(sent) - serverTransmitted.Sub(serverReceived)
+	return Measurement{Offset: -offset, RoundTrip: roundTrip, At: received}, nil
+}
+
+// ntpTime decodes a 64-bit NTP timestamp
+func ntpTime(b []byte) time.Time {
+	seconds := int64(binary.BigEndian.Uint32(b[0:4])) - ntpEpochOffset
+	fraction := int64(binary.BigEndian.Uint32(b[4:8]))
+	return time.Unix(seconds, fraction*int64(time.Second)>>32)
+}
+
+// putNTPTime encodes t as a 64-bit NTP timestamp
+func putNTPTime(b []byte, t time.Time) {
+	binary.BigEndian.PutUint32(b[0:4], uint32(t.Unix()+ntpEpochOffset))
+	binary.BigEndian.PutUint32(b[4:8], uint32(int64(t.Nanosecond())<<32/int64(time.Second)))
+}
@@ -0,0 +1,91 @@
+package clock
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startFakeNTPServer answers SNTP requests with a clock skew ahead of the local clock
+func startFakeNTPServer(t *testing.T, skew time.Duration, stratum byte) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, ntpPacketSize)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if n < ntpPacketSize {
+				continue
+			}
+			response := make([]byte, ntpPacketSize)
+			response[0] = 0x24 // Version 4, mode 4 (server)
+			response[1] = stratum
+			copy(response[24:32], buf[40:48])
+			now := time.Now().Add(skew)
+			putNTPTime(response[32:40], now)
+			putNTPTime(response[40:48], now)
+			conn.WriteTo(response, addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func TestQueryNTP(t *testing.T) {
+	t.Run("should measure how far the local clock is behind the server", func(t *testing.T) {
+		// Arrange
+		server := startFakeNTPServer(t, 3*time.Second, 2)
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		// Act
+		measurement, err := QueryNTP(ctx, server)
+
+		// Assert
+		require.NoError(t, err)
+		assert.InDelta(t, -3*time.Second, measurement.Offset, float64(100*time.Millisecond))
+		assert.Equal(t, server, measurement.Server)
+		assert.False(t, measurement.At.IsZero())
+	})
+
+	t.Run("should reject unsynchronized servers", func(t *testing.T) {
+		server := startFakeNTPServer(t, 0, 0)
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		_, err := QueryNTP(ctx, server)
+
+		assert.ErrorContains(t, err, "stratum")
+	})
+
+	t.Run("should time out when the server does not answer", func(t *testing.T) {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer conn.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		_, err = QueryNTP(ctx, conn.LocalAddr().String())
+
+		assert.ErrorContains(t, err, "no response")
+	})
+}
+
+func TestNTPTime(t *testing.T) {
+	t.Run("should round-trip timestamps", func(t *testing.T) {
+		at := time.Date(2025, 6, 1, 12, 30, 15, 250_000_000, time.UTC)
+		b := make([]byte, 8)
+
+		putNTPTime(b, at)
+
+		assert.WithinDuration(t, at, ntpTime(b), time.Microsecond)
+	})
+}
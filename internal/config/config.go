@@ -2,21 +2,36 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 // Configuration provides type-safe access to application settings
 type Configuration struct {
-	viper *viper.Viper
+	viper  *viper.Viper
+	tenant string // Tenant this configuration was derived for; empty outside multi-tenant deployments
+
+	// Runtime debug mode override; stored atomically because it can be toggled while the pipeline runs
+	debugMode atomic.Pointer[bool]
+
+	// Where the allowlist was configured and when that source last changed, recorded in cues
+	allowlistSource   string
+	allowlistModified time.Time
 }
 
 // NewConfiguration creates a new Configuration instance with default settings
 func NewConfiguration() *Configuration {
 	v := viper.New()
 	v.SetDefault("stream.url", "https://ais-sa1.streamon.fm:443/7346_48k.aac")
-	v.SetDefault("whisper.model_path", "/app/models/ggml-base.en.bin")
+	// Note: whisper.model_path default is handled in GetWhisperModelPath() so it follows paths.model_dir
 	v.SetDefault("buffer.duration_ms", 2500)
 	v.SetDefault("transcription.chunk_duration_sec", 5) // Smaller chunks for streaming
 	v.SetDefault("transcription.overlap_sec", 1)        // Smaller overlap for speed
@@ -37,7 +52,7 @@ func NewConfigurationFromFile(configFile string) (*Configuration, error) {
 	v := viper.New()
 	v.SetConfigFile(configFile)
 	v.SetDefault("stream.url", "https://ais-sa1.streamon.fm:443/7346_48k.aac")
-	v.SetDefault("whisper.model_path", "/app/models/ggml-base.en.bin")
+	// Note: whisper.model_path default is handled in GetWhisperModelPath() so it follows paths.model_dir
 	v.SetDefault("buffer.duration_ms", 2500)
 	v.SetDefault("transcription.chunk_duration_sec", 5) // Smaller chunks for streaming
 	v.SetDefault("transcription.overlap_sec", 1)        // Smaller overlap for speed
@@ -57,12 +72,70 @@ func NewConfigurationFromFile(configFile string) (*Configuration, error) {
 
 	// Map specific environment variables
 	v.BindEnv("stream.url", "STREAM_URL")
+	v.BindEnv("stream.urls", "STREAM_URLS")
+	v.BindEnv("stream.reconnect.max_per_minute", "STREAM_RECONNECT_MAX_PER_MINUTE")
+	v.BindEnv("stream.reconnect.cooldown_after_failures", "STREAM_RECONNECT_COOLDOWN_AFTER_FAILURES")
+	v.BindEnv("stream.reconnect.cooldown_sec", "STREAM_RECONNECT_COOLDOWN_SEC")
+	v.BindEnv("stream.device.name", "STREAM_DEVICE")
+	v.BindEnv("stream.device.driver", "STREAM_DEVICE_DRIVER")
+	v.BindEnv("stream.device.sample_rate", "STREAM_DEVICE_SAMPLE_RATE")
+	v.BindEnv("stream.device.channels", "STREAM_DEVICE_CHANNELS")
+	v.BindEnv("stream.sdr.frequency", "SDR_FREQUENCY")
+	v.BindEnv("stream.sdr.gain", "SDR_GAIN")
+	v.BindEnv("stream.sdr.device_index", "SDR_DEVICE_INDEX")
+	v.BindEnv("stream.sdr.ppm", "SDR_PPM")
+	v.BindEnv("stream.label", "STREAM_LABEL")
+	v.BindEnv("stream.relays", "STREAM_RELAYS")
+	v.BindEnv("process.nice", "PROCESS_NICE")
+	v.BindEnv("process.gomaxprocs", "GOMAXPROCS")
+	v.BindEnv("process.cpu_affinity", "PROCESS_CPU_AFFINITY")
 	v.BindEnv("whisper.model_path", "WHISPER_MODEL_PATH")
 	v.BindEnv("whisper.model_name", "WHISPER_MODEL")
 	v.BindEnv("buffer.duration_ms", "BUFFER_DURATION_MS")
 	v.BindEnv("allowlist.numbers", "ALLOWLIST_NUMBERS")
+	v.BindEnv("allowlist.expiry.warn_days", "ALLOWLIST_EXPIRY_WARN_DAYS")
+	v.BindEnv("allowlist.expiry.notify", "ALLOWLIST_EXPIRY_NOTIFY")
 	v.BindEnv("debug_mode", "DEBUG_MODE")
 	v.BindEnv("log.file_path", "LOG_FILE_PATH")
+	v.BindEnv("paths.temp_dir", "TEMP_DIR")
+	v.BindEnv("paths.log_dir", "LOG_DIR")
+	v.BindEnv("paths.model_dir", "MODEL_DIR")
+	v.BindEnv("log.sinks", "LOG_SINKS")
+	v.BindEnv("restart.jitter", "RESTART_JITTER")
+	v.BindEnv("coordination.mode", "COORDINATION_MODE")
+	v.BindEnv("coordination.standby", "COORDINATION_STANDBY")
+	v.BindEnv("coordination.lock_file", "COORDINATION_LOCK_FILE")
+	v.BindEnv("coordination.instance_id", "INSTANCE_ID")
+	v.BindEnv("redis.address", "REDIS_ADDRESS")
+	v.BindEnv("redis.password", "REDIS_PASSWORD")
+	v.BindEnv("redis.enabled", "REDIS_ENABLED")
+	v.BindEnv("dedup.window_sec", "DEDUP_WINDOW_SEC")
+	v.BindEnv("parser.keyword.stop_words", "KEYWORD_STOP_WORDS")
+	v.BindEnv("parser.keyword.canonical", "PARSER_KEYWORD_CANONICAL")
+	v.BindEnv("parser.min_confidence", "PARSER_MIN_CONFIDENCE")
+	v.BindEnv("parser.pattern.language", "PARSER_PATTERN_LANGUAGE")
+	v.BindEnv("parser.pattern.text_words", "PARSER_PATTERN_TEXT_WORDS")
+	v.BindEnv("parser.pattern.to_words", "PARSER_PATTERN_TO_WORDS")
+	v.BindEnv("parser.shadow.config_file", "PARSER_SHADOW_CONFIG_FILE")
+	v.BindEnv("parser.shadow.report_file", "PARSER_SHADOW_REPORT_FILE")
+	v.BindEnv("schedule.url", "SCHEDULE_URL")
+	v.BindEnv("schedule.refresh_interval_sec", "SCHEDULE_REFRESH_INTERVAL_SEC")
+	v.BindEnv("audio.agc.enabled", "AGC_ENABLED")
+	v.BindEnv("audio.tee.target", "AUDIO_TEE_TARGET")
+	v.BindEnv("buffer.no_speech_threshold", "NO_SPEECH_THRESHOLD")
+	v.BindEnv("buffer.max_context_bytes", "BUFFER_MAX_CONTEXT_BYTES")
+	v.BindEnv("buffer.max_buffered_bytes", "BUFFER_MAX_BUFFERED_BYTES")
+	v.BindEnv("buffer.gap_threshold_sec", "BUFFER_GAP_THRESHOLD_SEC")
+	v.BindEnv("transcription.enabled", "TRANSCRIPTION_ENABLED")
+	v.BindEnv("transcription.replay_file", "TRANSCRIPTION_REPLAY_FILE")
+	v.BindEnv("parser.enabled", "PARSER_ENABLED")
+	v.BindEnv("logoutput.enabled", "LOGOUTPUT_ENABLED")
+	v.BindEnv("transcription.translate", "TRANSCRIPTION_TRANSLATE")
+	v.BindEnv("transcription.language", "TRANSCRIPTION_LANGUAGE")
+	v.BindEnv("transcription.idle.enabled", "TRANSCRIPTION_IDLE_ENABLED")
+	v.BindEnv("transcription.idle.start", "TRANSCRIPTION_IDLE_START")
+	v.BindEnv("transcription.idle.end", "TRANSCRIPTION_IDLE_END")
+	v.BindEnv("scrub.enabled", "SCRUB_ENABLED")
 	// GPU configuration environment variables (new format)
 	v.BindEnv("gpu.enabled", "GPU_ENABLED")
 	v.BindEnv("gpu.auto_detect", "GPU_AUTO_DETECT")
@@ -72,18 +145,105 @@ func NewConfigurationFromFile(configFile string) (*Configuration, error) {
 	v.BindEnv("whisper.cublas_auto_detect", "WHISPER_CUBLAS_AUTO_DETECT")
 	v.BindEnv("whisper.gpu_device_id", "WHISPER_GPU_DEVICE_ID")
 	v.BindEnv("whisper.threads", "WHISPER_THREADS")
+	v.BindEnv("whisper.service_endpoints", "WHISPER_SERVICE_ENDPOINTS")
+	v.BindEnv("whisper.service_path", "WHISPER_SERVICE_PATH")
+	v.BindEnv("whisper.service_health_path", "WHISPER_SERVICE_HEALTH_PATH")
+	v.BindEnv("whisper.service_upload", "WHISPER_SERVICE_UPLOAD")
+	v.BindEnv("whisper.service_file_field", "WHISPER_SERVICE_FILE_FIELD")
+	v.BindEnv("whisper.service_api_key", "WHISPER_SERVICE_API_KEY")
+	v.BindEnv("whisper.api_base_url", "WHISPER_API_BASE_URL")
+	v.BindEnv("whisper.api_model", "WHISPER_API_MODEL")
+	v.BindEnv("whisper.api_timeout_sec", "WHISPER_API_TIMEOUT_SEC")
+	v.BindEnv("whisper.backend_priority", "WHISPER_BACKEND_PRIORITY")
+	v.BindEnv("notifier.webhook.url", "NOTIFIER_WEBHOOK_URL")
+	v.BindEnv("notifier.webhook.secret", "NOTIFIER_WEBHOOK_SECRET")
+	v.BindEnv("notifier.desktop.enabled", "NOTIFIER_DESKTOP_ENABLED")
+	v.BindEnv("notifier.desktop.click_url", "NOTIFIER_DESKTOP_CLICK_URL")
+	v.BindEnv("notifier.queue.dir", "NOTIFIER_QUEUE_DIR")
+	v.BindEnv("notifier.quiet_hours.start", "QUIET_HOURS_START")
+	v.BindEnv("notifier.quiet_hours.end", "QUIET_HOURS_END")
+	v.BindEnv("notifier.quiet_hours.timezone", "QUIET_HOURS_TIMEZONE")
+	v.BindEnv("notifier.maintenance", "MAINTENANCE_MODE")
+	v.BindEnv("notifier.digest.schedule", "DIGEST_SCHEDULE")
+	v.BindEnv("notifier.digest.channel", "DIGEST_CHANNEL")
+	v.BindEnv("log.schema_version", "LOG_SCHEMA_VERSION")
+	v.BindEnv("whisper.warmup.enabled", "WHISPER_WARMUP")
+	v.BindEnv("whisper.warmup.clip", "WHISPER_WARMUP_CLIP")
+	v.BindEnv("whisper.warmup.expect", "WHISPER_WARMUP_EXPECT")
+	v.BindEnv("whisper.download.mirrors", "WHISPER_DOWNLOAD_MIRRORS")
+	v.BindEnv("whisper.verify_model", "WHISPER_VERIFY_MODEL")
+	v.BindEnv("whisper.binary_timeout_sec", "WHISPER_BINARY_TIMEOUT_SEC")
+	v.BindEnv("ntp.enabled", "NTP_ENABLED")
+	v.BindEnv("ntp.server", "NTP_SERVER")
+	v.BindEnv("debug_transcripts.path", "DEBUG_TRANSCRIPTS_PATH")
+	v.BindEnv("debug_transcripts.sample_every", "DEBUG_TRANSCRIPTS_SAMPLE_EVERY")
+	v.BindEnv("log.queue_size", "LOG_QUEUE_SIZE")
+	v.BindEnv("stream.station.call_letters", "STATION_CALL_LETTERS")
+	v.BindEnv("stream.station.market", "STATION_MARKET")
+	v.BindEnv("stream.station.frequency", "STATION_FREQUENCY")
+	v.BindEnv("stream.station.timezone", "STATION_TIMEZONE")
+	v.BindEnv("timezone", "TIMEZONE")
+	v.BindEnv("offline_mode", "OFFLINE_MODE")
+	v.BindEnv("correction.enabled", "CORRECTION_ENABLED")
+	v.BindEnv("correction.endpoint", "CORRECTION_ENDPOINT")
+	v.BindEnv("correction.model", "CORRECTION_MODEL")
+	v.BindEnv("correction.api_key", "CORRECTION_API_KEY")
+	v.BindEnv("correction.confidence_threshold", "CORRECTION_CONFIDENCE_THRESHOLD")
+	v.BindEnv("correction.max_requests_per_minute", "CORRECTION_MAX_REQUESTS_PER_MINUTE")
+	v.BindEnv("correction.daily_token_budget", "CORRECTION_DAILY_TOKEN_BUDGET")
+	v.BindEnv("ad_detection.enabled", "AD_DETECTION_ENABLED")
+	v.BindEnv("ad_detection.action", "AD_DETECTION_ACTION")
+	v.BindEnv("ad_detection.ad_phrases", "AD_DETECTION_AD_PHRASES")
+	v.BindEnv("ad_detection.program_phrases", "AD_DETECTION_PROGRAM_PHRASES")
+	v.BindEnv("calendar.ics_path", "CALENDAR_ICS_PATH")
+	v.BindEnv("calendar.caldav.url", "CALDAV_URL")
+	v.BindEnv("calendar.caldav.username", "CALDAV_USERNAME")
+	v.BindEnv("calendar.caldav.password", "CALDAV_PASSWORD")
+	v.BindEnv("usage.path", "USAGE_PATH")
+	v.BindEnv("archive.enabled", "ARCHIVE_ENABLED")
+	v.BindEnv("api.enabled", "API_ENABLED")
+	v.BindEnv("api.address", "API_ADDRESS")
+	v.BindEnv("api.pprof", "API_PPROF")
+	v.BindEnv("api.token", "API_TOKEN")
+	v.BindEnv("features.flags", "FEATURE_FLAGS")
+	v.BindEnv("features.url", "FEATURES_URL")
+	v.BindEnv("features.refresh_interval_sec", "FEATURES_REFRESH_INTERVAL_SEC")
+	v.BindEnv("archive.endpoint", "ARCHIVE_ENDPOINT")
+	v.BindEnv("archive.region", "ARCHIVE_REGION")
+	v.BindEnv("archive.bucket", "ARCHIVE_BUCKET")
+	v.BindEnv("archive.prefix", "ARCHIVE_PREFIX")
+	v.BindEnv("archive.access_key_id", "AWS_ACCESS_KEY_ID")
+	v.BindEnv("archive.secret_access_key", "AWS_SECRET_ACCESS_KEY")
+	v.BindEnv("archive.retention_days", "ARCHIVE_RETENTION_DAYS")
+	v.BindEnv("archive.audio_dir", "ARCHIVE_AUDIO_DIR")
+	v.BindEnv("archive.state_path", "ARCHIVE_STATE_PATH")
+	for _, name := range []string{"webhook", "sheets", "mqtt", "redis", "desktop"} {
+		v.BindEnv("notifier."+name+".filter", "NOTIFIER_"+strings.ToUpper(name)+"_FILTER")
+	}
+	v.BindEnv("notifier.sheets.spreadsheet_id", "SHEETS_SPREADSHEET_ID")
+	v.BindEnv("notifier.sheets.credentials_file", "GOOGLE_APPLICATION_CREDENTIALS")
+	v.BindEnv("notifier.mqtt.broker", "MQTT_BROKER")
+	v.BindEnv("notifier.mqtt.username", "MQTT_USERNAME")
+	v.BindEnv("notifier.mqtt.password", "MQTT_PASSWORD")
 
 	if err := v.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("failed to read config file %s: %w", configFile, err)
 	}
 
+	// Decrypt enc: values, e.g. stream auth tokens and API keys committed to an ops repo
+	if err := decryptSettings(v); err != nil {
+		return nil, fmt.Errorf("failed to decrypt config file %s: %w", configFile, err)
+	}
+
 	// Validate buffer duration
 	bufferDuration := v.GetInt("buffer.duration_ms")
 	if bufferDuration < 1000 || bufferDuration > 10000 {
 		return nil, fmt.Errorf("buffer duration must be between 1000 and 10000 milliseconds, got %d", bufferDuration)
 	}
 
-	return &Configuration{viper: v}, nil
+	cfg := &Configuration{viper: v}
+	cfg.setAllowlistOrigin(configFile)
+	return cfg, nil
 }
 
 // NewConfigurationFromEnv creates a Configuration instance that reads from environment variables
@@ -110,19 +270,164 @@ func NewConfigurationFromEnv() (*Configuration, error) {
 
 	// Map specific environment variables
 	v.BindEnv("stream.url", "STREAM_URL")
+	v.BindEnv("stream.urls", "STREAM_URLS")
+	v.BindEnv("stream.reconnect.max_per_minute", "STREAM_RECONNECT_MAX_PER_MINUTE")
+	v.BindEnv("stream.reconnect.cooldown_after_failures", "STREAM_RECONNECT_COOLDOWN_AFTER_FAILURES")
+	v.BindEnv("stream.reconnect.cooldown_sec", "STREAM_RECONNECT_COOLDOWN_SEC")
+	v.BindEnv("stream.device.name", "STREAM_DEVICE")
+	v.BindEnv("stream.device.driver", "STREAM_DEVICE_DRIVER")
+	v.BindEnv("stream.device.sample_rate", "STREAM_DEVICE_SAMPLE_RATE")
+	v.BindEnv("stream.device.channels", "STREAM_DEVICE_CHANNELS")
+	v.BindEnv("stream.sdr.frequency", "SDR_FREQUENCY")
+	v.BindEnv("stream.sdr.gain", "SDR_GAIN")
+	v.BindEnv("stream.sdr.device_index", "SDR_DEVICE_INDEX")
+	v.BindEnv("stream.sdr.ppm", "SDR_PPM")
+	v.BindEnv("stream.label", "STREAM_LABEL")
+	v.BindEnv("stream.relays", "STREAM_RELAYS")
+	v.BindEnv("process.nice", "PROCESS_NICE")
+	v.BindEnv("process.gomaxprocs", "GOMAXPROCS")
+	v.BindEnv("process.cpu_affinity", "PROCESS_CPU_AFFINITY")
 	v.BindEnv("whisper.model_path", "WHISPER_MODEL_PATH")
 	v.BindEnv("whisper.model_name", "WHISPER_MODEL")
 	v.BindEnv("buffer.duration_ms", "BUFFER_DURATION_MS")
 	v.BindEnv("allowlist.numbers", "ALLOWLIST_NUMBERS")
+	v.BindEnv("allowlist.expiry.warn_days", "ALLOWLIST_EXPIRY_WARN_DAYS")
+	v.BindEnv("allowlist.expiry.notify", "ALLOWLIST_EXPIRY_NOTIFY")
 	v.BindEnv("debug_mode", "DEBUG_MODE")
 	v.BindEnv("log.file_path", "LOG_FILE_PATH")
+	v.BindEnv("paths.temp_dir", "TEMP_DIR")
+	v.BindEnv("paths.log_dir", "LOG_DIR")
+	v.BindEnv("paths.model_dir", "MODEL_DIR")
+	v.BindEnv("log.sinks", "LOG_SINKS")
+	v.BindEnv("restart.jitter", "RESTART_JITTER")
+	v.BindEnv("coordination.mode", "COORDINATION_MODE")
+	v.BindEnv("coordination.standby", "COORDINATION_STANDBY")
+	v.BindEnv("coordination.lock_file", "COORDINATION_LOCK_FILE")
+	v.BindEnv("coordination.instance_id", "INSTANCE_ID")
+	v.BindEnv("redis.address", "REDIS_ADDRESS")
+	v.BindEnv("redis.password", "REDIS_PASSWORD")
+	v.BindEnv("redis.enabled", "REDIS_ENABLED")
+	v.BindEnv("dedup.window_sec", "DEDUP_WINDOW_SEC")
+	v.BindEnv("parser.keyword.stop_words", "KEYWORD_STOP_WORDS")
+	v.BindEnv("parser.keyword.canonical", "PARSER_KEYWORD_CANONICAL")
+	v.BindEnv("parser.min_confidence", "PARSER_MIN_CONFIDENCE")
+	v.BindEnv("parser.pattern.language", "PARSER_PATTERN_LANGUAGE")
+	v.BindEnv("parser.pattern.text_words", "PARSER_PATTERN_TEXT_WORDS")
+	v.BindEnv("parser.pattern.to_words", "PARSER_PATTERN_TO_WORDS")
+	v.BindEnv("parser.shadow.config_file", "PARSER_SHADOW_CONFIG_FILE")
+	v.BindEnv("parser.shadow.report_file", "PARSER_SHADOW_REPORT_FILE")
+	v.BindEnv("schedule.url", "SCHEDULE_URL")
+	v.BindEnv("schedule.refresh_interval_sec", "SCHEDULE_REFRESH_INTERVAL_SEC")
+	v.BindEnv("audio.agc.enabled", "AGC_ENABLED")
+	v.BindEnv("audio.tee.target", "AUDIO_TEE_TARGET")
+	v.BindEnv("buffer.no_speech_threshold", "NO_SPEECH_THRESHOLD")
+	v.BindEnv("buffer.max_context_bytes", "BUFFER_MAX_CONTEXT_BYTES")
+	v.BindEnv("buffer.max_buffered_bytes", "BUFFER_MAX_BUFFERED_BYTES")
+	v.BindEnv("buffer.gap_threshold_sec", "BUFFER_GAP_THRESHOLD_SEC")
+	v.BindEnv("transcription.enabled", "TRANSCRIPTION_ENABLED")
+	v.BindEnv("transcription.replay_file", "TRANSCRIPTION_REPLAY_FILE")
+	v.BindEnv("parser.enabled", "PARSER_ENABLED")
+	v.BindEnv("logoutput.enabled", "LOGOUTPUT_ENABLED")
+	v.BindEnv("transcription.translate", "TRANSCRIPTION_TRANSLATE")
+	v.BindEnv("transcription.language", "TRANSCRIPTION_LANGUAGE")
+	v.BindEnv("transcription.idle.enabled", "TRANSCRIPTION_IDLE_ENABLED")
+	v.BindEnv("transcription.idle.start", "TRANSCRIPTION_IDLE_START")
+	v.BindEnv("transcription.idle.end", "TRANSCRIPTION_IDLE_END")
+	v.BindEnv("scrub.enabled", "SCRUB_ENABLED")
 	// GPU configuration environment variables
 	v.BindEnv("whisper.cublas_enabled", "WHISPER_CUBLAS")
 	v.BindEnv("whisper.cublas_auto_detect", "WHISPER_CUBLAS_AUTO_DETECT")
 	v.BindEnv("whisper.gpu_device_id", "WHISPER_GPU_DEVICE_ID")
 	v.BindEnv("whisper.threads", "WHISPER_THREADS")
+	v.BindEnv("whisper.service_endpoints", "WHISPER_SERVICE_ENDPOINTS")
+	v.BindEnv("whisper.service_path", "WHISPER_SERVICE_PATH")
+	v.BindEnv("whisper.service_health_path", "WHISPER_SERVICE_HEALTH_PATH")
+	v.BindEnv("whisper.service_upload", "WHISPER_SERVICE_UPLOAD")
+	v.BindEnv("whisper.service_file_field", "WHISPER_SERVICE_FILE_FIELD")
+	v.BindEnv("whisper.service_api_key", "WHISPER_SERVICE_API_KEY")
+	v.BindEnv("whisper.api_base_url", "WHISPER_API_BASE_URL")
+	v.BindEnv("whisper.api_model", "WHISPER_API_MODEL")
+	v.BindEnv("whisper.api_timeout_sec", "WHISPER_API_TIMEOUT_SEC")
+	v.BindEnv("whisper.backend_priority", "WHISPER_BACKEND_PRIORITY")
+	v.BindEnv("notifier.webhook.url", "NOTIFIER_WEBHOOK_URL")
+	v.BindEnv("notifier.webhook.secret", "NOTIFIER_WEBHOOK_SECRET")
+	v.BindEnv("notifier.desktop.enabled", "NOTIFIER_DESKTOP_ENABLED")
+	v.BindEnv("notifier.desktop.click_url", "NOTIFIER_DESKTOP_CLICK_URL")
+	v.BindEnv("notifier.queue.dir", "NOTIFIER_QUEUE_DIR")
+	v.BindEnv("notifier.quiet_hours.start", "QUIET_HOURS_START")
+	v.BindEnv("notifier.quiet_hours.end", "QUIET_HOURS_END")
+	v.BindEnv("notifier.quiet_hours.timezone", "QUIET_HOURS_TIMEZONE")
+	v.BindEnv("notifier.maintenance", "MAINTENANCE_MODE")
+	v.BindEnv("notifier.digest.schedule", "DIGEST_SCHEDULE")
+	v.BindEnv("notifier.digest.channel", "DIGEST_CHANNEL")
+	v.BindEnv("log.schema_version", "LOG_SCHEMA_VERSION")
+	v.BindEnv("whisper.warmup.enabled", "WHISPER_WARMUP")
+	v.BindEnv("whisper.warmup.clip", "WHISPER_WARMUP_CLIP")
+	v.BindEnv("whisper.warmup.expect", "WHISPER_WARMUP_EXPECT")
+	v.BindEnv("whisper.download.mirrors", "WHISPER_DOWNLOAD_MIRRORS")
+	v.BindEnv("whisper.verify_model", "WHISPER_VERIFY_MODEL")
+	v.BindEnv("whisper.binary_timeout_sec", "WHISPER_BINARY_TIMEOUT_SEC")
+	v.BindEnv("ntp.enabled", "NTP_ENABLED")
+	v.BindEnv("ntp.server", "NTP_SERVER")
+	v.BindEnv("debug_transcripts.path", "DEBUG_TRANSCRIPTS_PATH")
+	v.BindEnv("debug_transcripts.sample_every", "DEBUG_TRANSCRIPTS_SAMPLE_EVERY")
+	v.BindEnv("log.queue_size", "LOG_QUEUE_SIZE")
+	v.BindEnv("stream.station.call_letters", "STATION_CALL_LETTERS")
+	v.BindEnv("stream.station.market", "STATION_MARKET")
+	v.BindEnv("stream.station.frequency", "STATION_FREQUENCY")
+	v.BindEnv("stream.station.timezone", "STATION_TIMEZONE")
+	v.BindEnv("timezone", "TIMEZONE")
+	v.BindEnv("offline_mode", "OFFLINE_MODE")
+	v.BindEnv("correction.enabled", "CORRECTION_ENABLED")
+	v.BindEnv("correction.endpoint", "CORRECTION_ENDPOINT")
+	v.BindEnv("correction.model", "CORRECTION_MODEL")
+	v.BindEnv("correction.api_key", "CORRECTION_API_KEY")
+	v.BindEnv("correction.confidence_threshold", "CORRECTION_CONFIDENCE_THRESHOLD")
+	v.BindEnv("correction.max_requests_per_minute", "CORRECTION_MAX_REQUESTS_PER_MINUTE")
+	v.BindEnv("correction.daily_token_budget", "CORRECTION_DAILY_TOKEN_BUDGET")
+	v.BindEnv("ad_detection.enabled", "AD_DETECTION_ENABLED")
+	v.BindEnv("ad_detection.action", "AD_DETECTION_ACTION")
+	v.BindEnv("ad_detection.ad_phrases", "AD_DETECTION_AD_PHRASES")
+	v.BindEnv("ad_detection.program_phrases", "AD_DETECTION_PROGRAM_PHRASES")
+	v.BindEnv("calendar.ics_path", "CALENDAR_ICS_PATH")
+	v.BindEnv("calendar.caldav.url", "CALDAV_URL")
+	v.BindEnv("calendar.caldav.username", "CALDAV_USERNAME")
+	v.BindEnv("calendar.caldav.password", "CALDAV_PASSWORD")
+	v.BindEnv("usage.path", "USAGE_PATH")
+	v.BindEnv("archive.enabled", "ARCHIVE_ENABLED")
+	v.BindEnv("api.enabled", "API_ENABLED")
+	v.BindEnv("api.address", "API_ADDRESS")
+	v.BindEnv("api.pprof", "API_PPROF")
+	v.BindEnv("api.token", "API_TOKEN")
+	v.BindEnv("features.flags", "FEATURE_FLAGS")
+	v.BindEnv("features.url", "FEATURES_URL")
+	v.BindEnv("features.refresh_interval_sec", "FEATURES_REFRESH_INTERVAL_SEC")
+	v.BindEnv("archive.endpoint", "ARCHIVE_ENDPOINT")
+	v.BindEnv("archive.region", "ARCHIVE_REGION")
+	v.BindEnv("archive.bucket", "ARCHIVE_BUCKET")
+	v.BindEnv("archive.prefix", "ARCHIVE_PREFIX")
+	v.BindEnv("archive.access_key_id", "AWS_ACCESS_KEY_ID")
+	v.BindEnv("archive.secret_access_key", "AWS_SECRET_ACCESS_KEY")
+	v.BindEnv("archive.retention_days", "ARCHIVE_RETENTION_DAYS")
+	v.BindEnv("archive.audio_dir", "ARCHIVE_AUDIO_DIR")
+	v.BindEnv("archive.state_path", "ARCHIVE_STATE_PATH")
+	for _, name := range []string{"webhook", "sheets", "mqtt", "redis", "desktop"} {
+		v.BindEnv("notifier."+name+".filter", "NOTIFIER_"+strings.ToUpper(name)+"_FILTER")
+	}
+	v.BindEnv("notifier.sheets.spreadsheet_id", "SHEETS_SPREADSHEET_ID")
+	v.BindEnv("notifier.sheets.credentials_file", "GOOGLE_APPLICATION_CREDENTIALS")
+	v.BindEnv("notifier.mqtt.broker", "MQTT_BROKER")
+	v.BindEnv("notifier.mqtt.username", "MQTT_USERNAME")
+	v.BindEnv("notifier.mqtt.password", "MQTT_PASSWORD")
+
+	// Decrypt enc: values passed through the environment
+	if err := decryptSettings(v); err != nil {
+		return nil, fmt.Errorf("failed to decrypt config: %w", err)
+	}
 
-	return &Configuration{viper: v}, nil
+	cfg := &Configuration{viper: v}
+	cfg.setAllowlistOrigin("")
+	return cfg, nil
 }
 
 // GetStreamURL returns the configured stream URL
@@ -130,6 +435,459 @@ func (c *Configuration) GetStreamURL() string {
 	return c.viper.GetString("stream.url")
 }
 
+// GetStreamURLs returns the stream URLs in failover priority order. stream.urls takes
+// precedence; otherwise the single stream.url is used.
+func (c *Configuration) GetStreamURLs() []string {
+	// A plain string comes from the comma-separated STREAM_URLS environment variable
+	var urls []string
+	if raw, ok := c.viper.Get("stream.urls").(string); ok {
+		urls = strings.Split(raw, ",")
+	} else {
+		urls = c.viper.GetStringSlice("stream.urls")
+	}
+
+	result := make([]string, 0, len(urls))
+	for _, url := range urls {
+		if trimmed := strings.TrimSpace(url); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	if len(result) == 0 {
+		return []string{c.GetStreamURL()}
+	}
+	return result
+}
+
+// SetStreamURLs sets the stream URLs in failover priority order
+func (c *Configuration) SetStreamURLs(urls []string) {
+	c.viper.Set("stream.urls", urls)
+}
+
+// GetStreamFailoverThreshold returns the consecutive failures on the active URL before failing over
+func (c *Configuration) GetStreamFailoverThreshold() int {
+	if c.viper.IsSet("stream.failover_threshold") {
+		return c.viper.GetInt("stream.failover_threshold")
+	}
+	return 3
+}
+
+// GetStreamReconnectMaxPerMinute returns how many stream connection attempts are allowed in any
+// minute, across retries and restarts; 0 means no limit
+func (c *Configuration) GetStreamReconnectMaxPerMinute() int {
+	return c.viper.GetInt("stream.reconnect.max_per_minute")
+}
+
+// SetStreamReconnectMaxPerMinute sets how many stream connection attempts are allowed in any minute
+func (c *Configuration) SetStreamReconnectMaxPerMinute(attempts int) {
+	c.viper.Set("stream.reconnect.max_per_minute", attempts)
+}
+
+// GetStreamReconnectCooldownAfterFailures returns the consecutive failed connection attempts after
+// which reconnects pause for the cooldown; 0 disables the cooldown
+func (c *Configuration) GetStreamReconnectCooldownAfterFailures() int {
+	return c.viper.GetInt("stream.reconnect.cooldown_after_failures")
+}
+
+// SetStreamReconnectCooldownAfterFailures sets the failed attempts after which reconnects pause
+func (c *Configuration) SetStreamReconnectCooldownAfterFailures(failures int) {
+	c.viper.Set("stream.reconnect.cooldown_after_failures", failures)
+}
+
+// GetStreamReconnectCooldownSec returns how long reconnects pause after repeated failures
+func (c *Configuration) GetStreamReconnectCooldownSec() int {
+	if c.viper.IsSet("stream.reconnect.cooldown_sec") {
+		return c.viper.GetInt("stream.reconnect.cooldown_sec")
+	}
+	return 300
+}
+
+// SetStreamReconnectCooldownSec sets how long reconnects pause after repeated failures
+func (c *Configuration) SetStreamReconnectCooldownSec(seconds int) {
+	c.viper.Set("stream.reconnect.cooldown_sec", seconds)
+}
+
+// GetStreamReconnectStateFile returns the file keeping recent connection attempts and the
+// cooldown, so the limits hold across application restarts
+func (c *Configuration) GetStreamReconnectStateFile() string {
+	if c.viper.IsSet("stream.reconnect.state_file") {
+		return c.viper.GetString("stream.reconnect.state_file")
+	}
+	return "./data/stream_reconnect.json"
+}
+
+// SetStreamReconnectStateFile sets the file keeping recent connection attempts and the cooldown
+func (c *Configuration) SetStreamReconnectStateFile(path string) {
+	c.viper.Set("stream.reconnect.state_file", path)
+}
+
+// GetStreamPrimaryProbeIntervalSec returns how often the primary URL is probed while on a backup
+func (c *Configuration) GetStreamPrimaryProbeIntervalSec() int {
+	if c.viper.IsSet("stream.primary_probe_interval_sec") {
+		return c.viper.GetInt("stream.primary_probe_interval_sec")
+	}
+	return 300
+}
+
+// GetStreamExpectedCodec returns the codec the stream must use ("aac" matches the FFmpeg input
+// format); empty or "any" disables the codec check
+func (c *Configuration) GetStreamExpectedCodec() string {
+	if c.viper.IsSet("stream.expected_codec") {
+		return c.viper.GetString("stream.expected_codec")
+	}
+	return "aac"
+}
+
+// SetStreamExpectedCodec sets the codec the stream must use
+func (c *Configuration) SetStreamExpectedCodec(codec string) {
+	c.viper.Set("stream.expected_codec", codec)
+}
+
+// GetStreamExpectedSampleRate returns the required stream sample rate in Hz (0 accepts any rate)
+func (c *Configuration) GetStreamExpectedSampleRate() int {
+	return c.viper.GetInt("stream.expected_sample_rate")
+}
+
+// GetStreamFormatProbeBytes returns how many bytes are read to detect the stream format
+func (c *Configuration) GetStreamFormatProbeBytes() int {
+	if c.viper.IsSet("stream.format_probe_bytes") {
+		return c.viper.GetInt("stream.format_probe_bytes")
+	}
+	return 8192
+}
+
+// GetStreamDeviceName returns the sound card to capture from instead of the stream URLs (empty disables capture)
+func (c *Configuration) GetStreamDeviceName() string {
+	return c.viper.GetString("stream.device.name")
+}
+
+// SetStreamDeviceName sets the sound card to capture from
+func (c *Configuration) SetStreamDeviceName(name string) {
+	c.viper.Set("stream.device.name", name)
+}
+
+// GetStreamDeviceDriver returns the FFmpeg capture driver (empty uses the operating system default)
+func (c *Configuration) GetStreamDeviceDriver() string {
+	return c.viper.GetString("stream.device.driver")
+}
+
+// SetStreamDeviceDriver sets the FFmpeg capture driver
+func (c *Configuration) SetStreamDeviceDriver(driver string) {
+	c.viper.Set("stream.device.driver", driver)
+}
+
+// GetStreamDeviceSampleRate returns the sample rate in Hz audio is captured at
+func (c *Configuration) GetStreamDeviceSampleRate() int {
+	if c.viper.IsSet("stream.device.sample_rate") {
+		return c.viper.GetInt("stream.device.sample_rate")
+	}
+	return 48000
+}
+
+// SetStreamDeviceSampleRate sets the capture sample rate in Hz
+func (c *Configuration) SetStreamDeviceSampleRate(rate int) {
+	c.viper.Set("stream.device.sample_rate", rate)
+}
+
+// GetStreamDeviceChannels returns the number of channels audio is captured with
+func (c *Configuration) GetStreamDeviceChannels() int {
+	if c.viper.IsSet("stream.device.channels") {
+		return c.viper.GetInt("stream.device.channels")
+	}
+	return 2
+}
+
+// SetStreamDeviceChannels sets the number of capture channels
+func (c *Configuration) SetStreamDeviceChannels(channels int) {
+	c.viper.Set("stream.device.channels", channels)
+}
+
+// GetStreamSDRFrequency returns the frequency an RTL-SDR receiver tunes to instead of the stream URLs,
+// in rtl_fm notation such as 101.1M (empty disables SDR reception)
+func (c *Configuration) GetStreamSDRFrequency() string {
+	return c.viper.GetString("stream.sdr.frequency")
+}
+
+// SetStreamSDRFrequency sets the frequency the RTL-SDR receiver tunes to
+func (c *Configuration) SetStreamSDRFrequency(frequency string) {
+	c.viper.Set("stream.sdr.frequency", frequency)
+}
+
+// GetStreamSDRModulation returns the rtl_fm demodulation mode
+func (c *Configuration) GetStreamSDRModulation() string {
+	if c.viper.IsSet("stream.sdr.modulation") {
+		return c.viper.GetString("stream.sdr.modulation")
+	}
+	return "wbfm"
+}
+
+// GetStreamSDRGain returns the tuner gain in dB (0 uses automatic gain)
+func (c *Configuration) GetStreamSDRGain() float64 {
+	return c.viper.GetFloat64("stream.sdr.gain")
+}
+
+// SetStreamSDRGain sets the tuner gain in dB
+func (c *Configuration) SetStreamSDRGain(gain float64) {
+	c.viper.Set("stream.sdr.gain", gain)
+}
+
+// GetStreamSDRSampleRate returns the sample rate in Hz the demodulated audio is produced at
+func (c *Configuration) GetStreamSDRSampleRate() int {
+	if c.viper.IsSet("stream.sdr.sample_rate") {
+		return c.viper.GetInt("stream.sdr.sample_rate")
+	}
+	return 48000
+}
+
+// GetStreamSDRDeviceIndex returns which RTL-SDR dongle to use when several are plugged in
+func (c *Configuration) GetStreamSDRDeviceIndex() int {
+	return c.viper.GetInt("stream.sdr.device_index")
+}
+
+// GetStreamSDRPPM returns the tuner frequency correction in parts per million
+func (c *Configuration) GetStreamSDRPPM() int {
+	return c.viper.GetInt("stream.sdr.ppm")
+}
+
+// GetStreamLabel returns the station label added to cues when relay streams are configured
+func (c *Configuration) GetStreamLabel() string {
+	return c.viper.GetString("stream.label")
+}
+
+// SetStreamLabel sets the station label of the monitored stream
+func (c *Configuration) SetStreamLabel(label string) {
+	c.viper.Set("stream.label", label)
+}
+
+// StationMetadata describes the station a stream carries. It is attached to every cue and
+// transcription from the stream so notifications name the station rather than a URL.
+type StationMetadata struct {
+	CallLetters string // e.g. KXYZ
+	Market      string // e.g. Austin
+	Frequency   string // e.g. 101.5
+	Timezone    string // IANA name, e.g. America/Chicago
+}
+
+// IsZero reports whether no station metadata is set
+func (m StationMetadata) IsZero() bool {
+	return m == StationMetadata{}
+}
+
+// DisplayName returns the call letters, frequency, and market joined for display, e.g. "KXYZ 101.5 Austin"
+func (m StationMetadata) DisplayName() string {
+	var parts []string
+	for _, part := range []string{m.CallLetters, m.Frequency, m.Market} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// Fields returns the set metadata keyed call_letters, market, frequency, and timezone
+func (m StationMetadata) Fields() map[string]string {
+	fields := make(map[string]string, 4)
+	for key, value := range map[string]string{
+		"call_letters": m.CallLetters,
+		"market":       m.Market,
+		"frequency":    m.Frequency,
+		"timezone":     m.Timezone,
+	} {
+		if value != "" {
+			fields[key] = value
+		}
+	}
+	return fields
+}
+
+// stationMetadataFromMap reads station metadata from a config map with call_letters, market,
+// frequency, and timezone keys
+func stationMetadataFromMap(m map[string]interface{}) StationMetadata {
+	field := func(key string) string {
+		if value, ok := m[key]; ok && value != nil {
+			return strings.TrimSpace(fmt.Sprint(value))
+		}
+		return ""
+	}
+	return StationMetadata{
+		CallLetters: field("call_letters"),
+		Market:      field("market"),
+		Frequency:   field("frequency"),
+		Timezone:    field("timezone"),
+	}
+}
+
+// GetStationMetadata returns the metadata of the station on the monitored stream
+func (c *Configuration) GetStationMetadata() StationMetadata {
+	return StationMetadata{
+		CallLetters: strings.TrimSpace(c.viper.GetString("stream.station.call_letters")),
+		Market:      strings.TrimSpace(c.viper.GetString("stream.station.market")),
+		Frequency:   strings.TrimSpace(c.viper.GetString("stream.station.frequency")),
+		Timezone:    strings.TrimSpace(c.viper.GetString("stream.station.timezone")),
+	}
+}
+
+// SetStationMetadata sets the metadata of the station on the monitored stream
+func (c *Configuration) SetStationMetadata(m StationMetadata) {
+	c.viper.Set("stream.station.call_letters", m.CallLetters)
+	c.viper.Set("stream.station.market", m.Market)
+	c.viper.Set("stream.station.frequency", m.Frequency)
+	c.viper.Set("stream.station.timezone", m.Timezone)
+}
+
+// StreamRelay is another configured station stream that may be a relay of the monitored one
+type StreamRelay struct {
+	Label   string
+	URL     string
+	Station StationMetadata // Attached to cues copied to the relay; zero when not configured
+}
+
+// GetStreamRelays returns the streams checked for being byte-identical relays of the monitored
+// stream. stream.relays entries are either maps with label and url keys, plus optional station
+// metadata keys, or "label=url" strings (the comma-separated STREAM_RELAYS environment variable
+// uses the string form).
+func (c *Configuration) GetStreamRelays() []StreamRelay {
+	var entries []interface{}
+	switch raw := c.viper.Get("stream.relays").(type) {
+	case []StreamRelay:
+		return raw
+	case string:
+		for _, spec := range strings.Split(raw, ",") {
+			entries = append(entries, spec)
+		}
+	case []interface{}:
+		entries = raw
+	case []string:
+		for _, spec := range raw {
+			entries = append(entries, spec)
+		}
+	}
+
+	var relays []StreamRelay
+	for _, entry := range entries {
+		var relay StreamRelay
+		switch e := entry.(type) {
+		case string:
+			label, url, ok := strings.Cut(strings.TrimSpace(e), "=")
+			if !ok {
+				continue
+			}
+			relay = StreamRelay{Label: strings.TrimSpace(label), URL: strings.TrimSpace(url)}
+		case map[string]interface{}:
+			label, _ := e["label"].(string)
+			url, _ := e["url"].(string)
+			relay = StreamRelay{Label: strings.TrimSpace(label), URL: strings.TrimSpace(url), Station: stationMetadataFromMap(e)}
+		}
+		if relay.Label != "" && relay.URL != "" {
+			relays = append(relays, relay)
+		}
+	}
+	return relays
+}
+
+// SetStreamRelays sets the streams checked for being relays of the monitored stream
+func (c *Configuration) SetStreamRelays(relays []StreamRelay) {
+	c.viper.Set("stream.relays", relays)
+}
+
+// GetStreamRelayCheckIntervalSec returns how often relay streams are compared with the monitored stream
+func (c *Configuration) GetStreamRelayCheckIntervalSec() int {
+	if c.viper.IsSet("stream.relay_check_interval_sec") {
+		return c.viper.GetInt("stream.relay_check_interval_sec")
+	}
+	return 600
+}
+
+// GetStreamRelaySampleSec returns how many seconds of a relay stream are fingerprinted per check
+func (c *Configuration) GetStreamRelaySampleSec() int {
+	if c.viper.IsSet("stream.relay_sample_sec") {
+		return c.viper.GetInt("stream.relay_sample_sec")
+	}
+	return 20
+}
+
+// GetStreamDuplicateSimilarity returns the fraction of matching audio above which a relay is
+// treated as a duplicate of the monitored stream
+func (c *Configuration) GetStreamDuplicateSimilarity() float64 {
+	if c.viper.IsSet("stream.duplicate_similarity") {
+		return c.viper.GetFloat64("stream.duplicate_similarity")
+	}
+	return 0.8
+}
+
+// GetProcessNice returns the niceness the application and its child processes run at (0 leaves it unchanged)
+func (c *Configuration) GetProcessNice() int {
+	return c.viper.GetInt("process.nice")
+}
+
+// SetProcessNice sets the niceness the application and its child processes run at
+func (c *Configuration) SetProcessNice(nice int) {
+	c.viper.Set("process.nice", nice)
+}
+
+// GetProcessGOMAXPROCS returns how many CPUs may run Go code at once (0 uses the Go default)
+func (c *Configuration) GetProcessGOMAXPROCS() int {
+	return c.viper.GetInt("process.gomaxprocs")
+}
+
+// GetProcessCPUAffinity returns the CPU list FFmpeg and Whisper child processes are pinned to,
+// such as "0-3,6" (empty leaves them unpinned)
+func (c *Configuration) GetProcessCPUAffinity() string {
+	return c.viper.GetString("process.cpu_affinity")
+}
+
+// SetProcessCPUAffinity sets the CPU list FFmpeg and Whisper child processes are pinned to
+func (c *Configuration) SetProcessCPUAffinity(cpus string) {
+	c.viper.Set("process.cpu_affinity", cpus)
+}
+
+// GetPathsTempDir returns the scratch directory audio chunks are written to for transcription
+func (c *Configuration) GetPathsTempDir() string {
+	if c.viper.IsSet("paths.temp_dir") {
+		return c.viper.GetString("paths.temp_dir")
+	}
+	return filepath.Join(os.TempDir(), "whisper")
+}
+
+// SetPathsTempDir sets the scratch directory audio chunks are written to for transcription
+func (c *Configuration) SetPathsTempDir(dir string) {
+	c.viper.Set("paths.temp_dir", dir)
+}
+
+// GetPathsLogDir returns the directory debug logs such as the transcription log are written to
+func (c *Configuration) GetPathsLogDir() string {
+	if c.viper.IsSet("paths.log_dir") {
+		return c.viper.GetString("paths.log_dir")
+	}
+	return defaultAppDir("logs")
+}
+
+// SetPathsLogDir sets the directory debug logs are written to
+func (c *Configuration) SetPathsLogDir(dir string) {
+	c.viper.Set("paths.log_dir", dir)
+}
+
+// GetPathsModelDir returns the directory Whisper models are loaded from and downloaded to
+func (c *Configuration) GetPathsModelDir() string {
+	if c.viper.IsSet("paths.model_dir") {
+		return c.viper.GetString("paths.model_dir")
+	}
+	return defaultAppDir("models")
+}
+
+// SetPathsModelDir sets the directory Whisper models are loaded from and downloaded to
+func (c *Configuration) SetPathsModelDir(dir string) {
+	c.viper.Set("paths.model_dir", dir)
+}
+
+// defaultAppDir returns the default location of the named data directory: under /app on Linux,
+// where the container image keeps it, and relative to the working directory elsewhere
+func defaultAppDir(name string) string {
+	if runtime.GOOS == "linux" {
+		return filepath.Join("/app", name)
+	}
+	return name
+}
+
 // GetWhisperModelPath returns the configured Whisper model path
 func (c *Configuration) GetWhisperModelPath() string {
 	// Check if model path was explicitly set (not using default)
@@ -140,11 +898,11 @@ func (c *Configuration) GetWhisperModelPath() string {
 	// If model name is set, construct path
 	modelName := c.viper.GetString("whisper.model_name")
 	if modelName != "" {
-		return fmt.Sprintf("/app/models/ggml-%s.bin", modelName)
+		return filepath.Join(c.GetPathsModelDir(), fmt.Sprintf("ggml-%s.bin", modelName))
 	}
 
 	// Return default
-	return "/app/models/ggml-base.en.bin"
+	return filepath.Join(c.GetPathsModelDir(), "ggml-base.en.bin")
 }
 
 // GetWhisperModelName returns the configured Whisper model name
@@ -157,132 +915,2643 @@ func (c *Configuration) GetBufferDurationMS() int {
 	return c.viper.GetInt("buffer.duration_ms")
 }
 
-// GetTranscriptionChunkDurationSec returns the configured transcription chunk duration in seconds
-func (c *Configuration) GetTranscriptionChunkDurationSec() int {
-	return c.viper.GetInt("transcription.chunk_duration_sec")
+// GetBufferNoSpeechThreshold returns the no-speech probability at or above which segments are
+// dropped before buffering; 0 keeps every segment
+func (c *Configuration) GetBufferNoSpeechThreshold() float64 {
+	if c.viper.IsSet("buffer.no_speech_threshold") {
+		return c.viper.GetFloat64("buffer.no_speech_threshold")
+	}
+	return 0.6
 }
 
-// GetTranscriptionOverlapSec returns the configured transcription overlap duration in seconds
-func (c *Configuration) GetTranscriptionOverlapSec() int {
-	return c.viper.GetInt("transcription.overlap_sec")
+// SetBufferNoSpeechThreshold sets the no-speech probability at or above which segments are dropped
+func (c *Configuration) SetBufferNoSpeechThreshold(threshold float64) {
+	c.viper.Set("buffer.no_speech_threshold", threshold)
 }
 
-// GetAllowlist returns the configured allowlist of numbers
-func (c *Configuration) GetAllowlist() []string {
-	// Check if we have an array (from config file)
-	allowlistSlice := c.viper.GetStringSlice("allowlist.numbers")
+// GetBufferMaxContextBytes returns the longest text of one buffered context, past which it is
+// truncated; 0 leaves it unbounded
+func (c *Configuration) GetBufferMaxContextBytes() int {
+	if c.viper.IsSet("buffer.max_context_bytes") {
+		return c.viper.GetInt("buffer.max_context_bytes")
+	}
+	return 4096
+}
 
-	// If we have exactly one element that contains commas, it's likely from environment variable
-	if len(allowlistSlice) == 1 && strings.Contains(allowlistSlice[0], ",") {
-		// Split comma-separated values and trim spaces
-		numbers := strings.Split(allowlistSlice[0], ",")
-		result := make([]string, 0, len(numbers))
-		for _, num := range numbers {
-			trimmed := strings.TrimSpace(num)
-			if trimmed != "" {
-				result = append(result, trimmed)
-			}
-		}
-		return result
+// SetBufferMaxContextBytes sets the longest text of one buffered context
+func (c *Configuration) SetBufferMaxContextBytes(maxBytes int) {
+	c.viper.Set("buffer.max_context_bytes", maxBytes)
+}
+
+// GetBufferMaxBufferedBytes returns the most transcription text the context buffer holds before
+// flushing early; 0 leaves it unbounded
+func (c *Configuration) GetBufferMaxBufferedBytes() int {
+	if c.viper.IsSet("buffer.max_buffered_bytes") {
+		return c.viper.GetInt("buffer.max_buffered_bytes")
 	}
+	return 16384
+}
 
-	// Return the slice as-is (could be empty, single element, or multiple elements)
-	return allowlistSlice
+// SetBufferMaxBufferedBytes sets the most transcription text the context buffer holds
+func (c *Configuration) SetBufferMaxBufferedBytes(maxBytes int) {
+	c.viper.Set("buffer.max_buffered_bytes", maxBytes)
 }
 
-// GetDebugMode returns whether debug mode is enabled
-func (c *Configuration) GetDebugMode() bool {
-	return c.viper.GetBool("debug_mode")
+// GetBufferGapThresholdSec returns how many seconds of audio without transcription the context
+// buffer reports as a gap; 0 disables gap detection
+func (c *Configuration) GetBufferGapThresholdSec() int {
+	if c.viper.IsSet("buffer.gap_threshold_sec") {
+		return c.viper.GetInt("buffer.gap_threshold_sec")
+	}
+	return 60
 }
 
-// SetDebugMode sets the debug mode state
-func (c *Configuration) SetDebugMode(enabled bool) {
-	c.viper.Set("debug_mode", enabled)
+// SetBufferGapThresholdSec sets how many seconds without transcription are reported as a gap
+func (c *Configuration) SetBufferGapThresholdSec(seconds int) {
+	c.viper.Set("buffer.gap_threshold_sec", seconds)
 }
 
-// GetLogFilePath returns the configured log file path
-func (c *Configuration) GetLogFilePath() string {
-	return c.viper.GetString("log.file_path")
+// Pipeline Stage Switches
+// Stages can be disabled for partial deployments, such as a transcription archiver without the
+// parser or a parser-only worker replaying stored transcriptions
+
+// GetTranscriptionEnabled returns whether the stream is connected and transcribed; when false the
+// pipeline reads transcriptions from the replay file instead
+func (c *Configuration) GetTranscriptionEnabled() bool {
+	if c.viper.IsSet("transcription.enabled") {
+		return c.viper.GetBool("transcription.enabled")
+	}
+	return true
 }
 
-// GetTranscriptionTimeoutSec returns the configured transcription timeout in seconds
-func (c *Configuration) GetTranscriptionTimeoutSec() int {
-	return c.viper.GetInt("transcription.timeout_sec")
+// SetTranscriptionEnabled enables or disables the stream, FFmpeg, and transcription stages
+func (c *Configuration) SetTranscriptionEnabled(enabled bool) {
+	c.viper.Set("transcription.enabled", enabled)
 }
 
-// SetTranscriptionTimeoutSec sets the transcription timeout in seconds
-func (c *Configuration) SetTranscriptionTimeoutSec(timeoutSec int) {
-	c.viper.Set("transcription.timeout_sec", timeoutSec)
+// GetTranscriptionReplayFile returns the file of stored transcriptions, in the debug transcripts
+// format, read in place of the stream while transcription is disabled
+func (c *Configuration) GetTranscriptionReplayFile() string {
+	return c.viper.GetString("transcription.replay_file")
 }
 
-// GPU Configuration Methods
+// SetTranscriptionReplayFile sets the file of stored transcriptions read while transcription is disabled
+func (c *Configuration) SetTranscriptionReplayFile(path string) {
+	c.viper.Set("transcription.replay_file", path)
+}
 
-// GetCUBLASEnabled returns whether CUDA acceleration is enabled
-func (c *Configuration) GetCUBLASEnabled() bool {
-	// Check new gpu.enabled format first, then fall back to whisper.cublas_enabled
-	if c.viper.IsSet("gpu.enabled") {
-		return c.viper.GetBool("gpu.enabled")
-	}
-	if c.viper.IsSet("whisper.cublas_enabled") {
-		return c.viper.GetBool("whisper.cublas_enabled")
+// GetParserEnabled returns whether transcriptions are buffered and parsed for contest cues
+func (c *Configuration) GetParserEnabled() bool {
+	if c.viper.IsSet("parser.enabled") {
+		return c.viper.GetBool("parser.enabled")
 	}
-	// Default to true to enable GPU acceleration by default
 	return true
 }
 
-// SetCUBLASEnabled sets whether CUDA acceleration is enabled
-func (c *Configuration) SetCUBLASEnabled(enabled bool) {
-	// Set both formats for compatibility
-	c.viper.Set("gpu.enabled", enabled)
-	c.viper.Set("whisper.cublas_enabled", enabled)
+// SetParserEnabled enables or disables the context buffer and contest parser stages
+func (c *Configuration) SetParserEnabled(enabled bool) {
+	c.viper.Set("parser.enabled", enabled)
 }
 
-// GetCUBLASAutoDetect returns whether GPU auto-detection is enabled
-func (c *Configuration) GetCUBLASAutoDetect() bool {
-	// Check new gpu.auto_detect format first, then fall back to whisper.cublas_auto_detect
-	if c.viper.IsSet("gpu.auto_detect") {
-		return c.viper.GetBool("gpu.auto_detect")
-	}
-	if c.viper.IsSet("whisper.cublas_auto_detect") {
-		return c.viper.GetBool("whisper.cublas_auto_detect")
+// GetLogOutputEnabled returns whether contest cues are written to the log sinks; cues are still
+// notified when it is disabled
+func (c *Configuration) GetLogOutputEnabled() bool {
+	if c.viper.IsSet("logoutput.enabled") {
+		return c.viper.GetBool("logoutput.enabled")
 	}
-	// Default to true to enable auto-detection by default
 	return true
 }
 
-// SetCUBLASAutoDetect sets whether GPU auto-detection is enabled
-func (c *Configuration) SetCUBLASAutoDetect(autoDetect bool) {
-	// Set both formats for compatibility
-	c.viper.Set("gpu.auto_detect", autoDetect)
-	c.viper.Set("whisper.cublas_auto_detect", autoDetect)
+// SetLogOutputEnabled enables or disables the log output stage
+func (c *Configuration) SetLogOutputEnabled(enabled bool) {
+	c.viper.Set("logoutput.enabled", enabled)
 }
 
-// GetGPUDeviceID returns the configured GPU device ID
-func (c *Configuration) GetGPUDeviceID() int {
-	// Check new gpu.device_id format first, then fall back to whisper.gpu_device_id
-	if c.viper.IsSet("gpu.device_id") {
-		return c.viper.GetInt("gpu.device_id")
-	}
-	if c.viper.IsSet("whisper.gpu_device_id") {
-		return c.viper.GetInt("whisper.gpu_device_id")
-	}
-	// Default to device 0
-	return 0
+// GetTranscriptionChunkDurationSec returns the configured transcription chunk duration in seconds
+func (c *Configuration) GetTranscriptionChunkDurationSec() int {
+	return c.viper.GetInt("transcription.chunk_duration_sec")
 }
 
-// SetGPUDeviceID sets the GPU device ID
-func (c *Configuration) SetGPUDeviceID(deviceID int) {
-	// Set both formats for compatibility
-	c.viper.Set("gpu.device_id", deviceID)
-	c.viper.Set("whisper.gpu_device_id", deviceID)
+// GetTranscriptionOverlapSec returns the configured transcription overlap duration in seconds
+func (c *Configuration) GetTranscriptionOverlapSec() int {
+	return c.viper.GetInt("transcription.overlap_sec")
 }
 
-// GetWhisperThreads returns the configured number of threads
-func (c *Configuration) GetWhisperThreads() int {
-	return c.viper.GetInt("whisper.threads")
+// GetTranscriptionTranslate returns whether Whisper translates speech to English, keeping the
+// original-language text alongside the translation
+func (c *Configuration) GetTranscriptionTranslate() bool {
+	return c.viper.GetBool("transcription.translate")
 }
 
-// SetWhisperThreads sets the number of threads
+// SetTranscriptionTranslate enables or disables translation to English
+func (c *Configuration) SetTranscriptionTranslate(enabled bool) {
+	c.viper.Set("transcription.translate", enabled)
+}
+
+// GetTranscriptionLanguage returns the spoken language Whisper is told to expect. It defaults to
+// "en", or to "auto" detection when translating.
+func (c *Configuration) GetTranscriptionLanguage() string {
+	if language := c.viper.GetString("transcription.language"); language != "" {
+		return language
+	}
+	if c.GetTranscriptionTranslate() {
+		return "auto"
+	}
+	return "en"
+}
+
+// GetTranscriptionAutoTuneEnabled returns whether the chunk duration is auto-tuned from observed latency
+func (c *Configuration) GetTranscriptionAutoTuneEnabled() bool {
+	return c.viper.GetBool("transcription.auto_tune.enabled")
+}
+
+// SetTranscriptionAutoTuneEnabled enables or disables chunk duration auto-tuning
+func (c *Configuration) SetTranscriptionAutoTuneEnabled(enabled bool) {
+	c.viper.Set("transcription.auto_tune.enabled", enabled)
+}
+
+// GetTranscriptionAutoTuneMinChunkSec returns the smallest chunk duration the auto-tuner may choose
+func (c *Configuration) GetTranscriptionAutoTuneMinChunkSec() int {
+	if c.viper.IsSet("transcription.auto_tune.min_chunk_duration_sec") {
+		return c.viper.GetInt("transcription.auto_tune.min_chunk_duration_sec")
+	}
+	return 3
+}
+
+// GetTranscriptionAutoTuneMaxChunkSec returns the largest chunk duration the auto-tuner may choose
+func (c *Configuration) GetTranscriptionAutoTuneMaxChunkSec() int {
+	if c.viper.IsSet("transcription.auto_tune.max_chunk_duration_sec") {
+		return c.viper.GetInt("transcription.auto_tune.max_chunk_duration_sec")
+	}
+	return 15
+}
+
+// GetTranscriptionAutoTuneEvaluationChunks returns how many chunks are averaged before each tuning decision
+func (c *Configuration) GetTranscriptionAutoTuneEvaluationChunks() int {
+	if c.viper.IsSet("transcription.auto_tune.evaluation_chunks") {
+		return c.viper.GetInt("transcription.auto_tune.evaluation_chunks")
+	}
+	return 5
+}
+
+// GetTranscriptionIdleEnabled returns whether transcription drops to periodic sampling through long
+// stretches without speech
+func (c *Configuration) GetTranscriptionIdleEnabled() bool {
+	return c.viper.GetBool("transcription.idle.enabled")
+}
+
+// SetTranscriptionIdleEnabled enables or disables the idle mode
+func (c *Configuration) SetTranscriptionIdleEnabled(enabled bool) {
+	c.viper.Set("transcription.idle.enabled", enabled)
+}
+
+// GetTranscriptionIdleAfterSec returns how long no speech must be heard before idle mode starts
+func (c *Configuration) GetTranscriptionIdleAfterSec() int {
+	if c.viper.IsSet("transcription.idle.after_sec") {
+		return c.viper.GetInt("transcription.idle.after_sec")
+	}
+	return 900
+}
+
+// GetTranscriptionIdleSampleIntervalSec returns how often an audible chunk is transcribed while idle
+func (c *Configuration) GetTranscriptionIdleSampleIntervalSec() int {
+	if c.viper.IsSet("transcription.idle.sample_interval_sec") {
+		return c.viper.GetInt("transcription.idle.sample_interval_sec")
+	}
+	return 120
+}
+
+// GetTranscriptionIdleSilenceDBFS returns the chunk level below which audio counts as silence while idle
+func (c *Configuration) GetTranscriptionIdleSilenceDBFS() float64 {
+	if c.viper.IsSet("transcription.idle.silence_dbfs") {
+		return c.viper.GetFloat64("transcription.idle.silence_dbfs")
+	}
+	return -50
+}
+
+// GetTranscriptionIdleStart returns when, as "HH:MM" in the display timezone, idle mode may begin
+// each day (empty with the end allows it at any time)
+func (c *Configuration) GetTranscriptionIdleStart() string {
+	return c.viper.GetString("transcription.idle.start")
+}
+
+// GetTranscriptionIdleEnd returns when, as "HH:MM" in the display timezone, idle mode must end each day
+func (c *Configuration) GetTranscriptionIdleEnd() string {
+	return c.viper.GetString("transcription.idle.end")
+}
+
+// SetTranscriptionIdleWindow sets the daily window, as "HH:MM", in which idle mode may run
+func (c *Configuration) SetTranscriptionIdleWindow(start, end string) {
+	c.viper.Set("transcription.idle.start", start)
+	c.viper.Set("transcription.idle.end", end)
+}
+
+// Allowlist sources recorded in cues as allowlist_source
+const (
+	AllowlistSourceFile = "file" // allowlist.numbers in the config file
+	AllowlistSourceEnv  = "env"  // ALLOWLIST_NUMBERS
+	AllowlistSourceAPI  = "api"  // Set at runtime with SetAllowlistEntries
+)
+
+// AllowlistEntry is one allowlisted number or pattern with where it was configured
+type AllowlistEntry struct {
+	Number   string
+	Label    string    // Optional note, e.g. the contest or sponsor
+	Source   string    // AllowlistSourceFile, AllowlistSourceEnv, or AllowlistSourceAPI; empty for defaults
+	Modified time.Time // When the source last changed: the config file's modification time, or when the entries were set; zero when unknown
+	Days     []string  // Days the entry is active: mon..sun, weekdays, or weekends; empty is every day
+	Start    string    // "HH:MM" station time the entry becomes active; empty with End is all day
+	End      string    // "HH:MM" station time the entry stops being active; before Start when it runs past midnight
+	Expires  string    // "YYYY-MM-DD" station date of the entry's last day; empty never expires
+}
+
+// GetAllowlist returns the configured allowlist of numbers
+func (c *Configuration) GetAllowlist() []string {
+	entries := c.GetAllowlistEntries()
+	numbers := make([]string, len(entries))
+	for i, entry := range entries {
+		numbers[i] = entry.Number
+	}
+	return numbers
+}
+
+// GetAllowlistEntries returns the configured allowlist with labels and provenance. In the config
+// file an entry is a number, or a mapping with number, label, an optional active window of days,
+// start, and end in station time, and an optional expires date.
+func (c *Configuration) GetAllowlistEntries() []AllowlistEntry {
+	var entries []AllowlistEntry
+	add := func(entry AllowlistEntry) {
+		if entry.Number = strings.TrimSpace(entry.Number); entry.Number != "" {
+			entry.Label = strings.TrimSpace(entry.Label)
+			entry.Source, entry.Modified = c.allowlistSource, c.allowlistModified
+			entries = append(entries, entry)
+		}
+	}
+
+	// Entries from the config file may carry labels and active windows
+	if items, ok := c.viper.Get("allowlist.numbers").([]interface{}); ok {
+		for _, item := range items {
+			if fields, ok := item.(map[string]interface{}); ok {
+				add(AllowlistEntry{
+					Number:  fmt.Sprint(fields["number"]),
+					Label:   labelString(fields["label"]),
+					Days:    scheduleDays(fields["days"]),
+					Start:   strings.TrimSpace(labelString(fields["start"])),
+					End:     strings.TrimSpace(labelString(fields["end"])),
+					Expires: expiryDate(fields["expires"]),
+				})
+				continue
+			}
+			add(AllowlistEntry{Number: fmt.Sprint(item)})
+		}
+		return entries
+	}
+
+	allowlistSlice := c.viper.GetStringSlice("allowlist.numbers")
+
+	// If we have exactly one element that contains commas, it's likely from environment variable
+	if len(allowlistSlice) == 1 && strings.Contains(allowlistSlice[0], ",") {
+		allowlistSlice = strings.Split(allowlistSlice[0], ",")
+	}
+	for _, number := range allowlistSlice {
+		add(AllowlistEntry{Number: number})
+	}
+	return entries
+}
+
+// labelString returns an allowlist entry field such as its label, "" when it is unset
+func labelString(label interface{}) string {
+	if label == nil {
+		return ""
+	}
+	return fmt.Sprint(label)
+}
+
+// expiryDate returns an allowlist entry's expires date as "YYYY-MM-DD"; YAML may have parsed an
+// unquoted date as a timestamp
+func expiryDate(expires interface{}) string {
+	if date, ok := expires.(time.Time); ok {
+		return date.Format("2006-01-02")
+	}
+	return strings.TrimSpace(labelString(expires))
+}
+
+// SetAllowlistEntries replaces the allowlist at runtime; cues record the entries as set through the API
+func (c *Configuration) SetAllowlistEntries(entries []AllowlistEntry) {
+	items := make([]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		item := map[string]interface{}{"number": entry.Number, "label": entry.Label}
+		if len(entry.Days) > 0 || entry.Start != "" || entry.End != "" {
+			days := make([]interface{}, len(entry.Days))
+			for i, day := range entry.Days {
+				days[i] = day
+			}
+			item["days"], item["start"], item["end"] = days, entry.Start, entry.End
+		}
+		if entry.Expires != "" {
+			item["expires"] = entry.Expires
+		}
+		items = append(items, item)
+	}
+	c.viper.Set("allowlist.numbers", items)
+	c.allowlistSource, c.allowlistModified = AllowlistSourceAPI, time.Now()
+}
+
+// setAllowlistOrigin records where the loaded allowlist came from: ALLOWLIST_NUMBERS when set,
+// otherwise configFile, whose tenants inherit the origin
+func (c *Configuration) setAllowlistOrigin(configFile string) {
+	if os.Getenv("ALLOWLIST_NUMBERS") != "" {
+		c.allowlistSource = AllowlistSourceEnv
+		return
+	}
+	if configFile == "" {
+		return
+	}
+	c.allowlistSource = AllowlistSourceFile
+	if info, err := os.Stat(configFile); err == nil {
+		c.allowlistModified = info.ModTime()
+	}
+}
+
+// GetAllowlistExpiryWarnDays returns how many days before an allowlist entry expires the daily
+// check starts warning about it; 0 warns only about entries already expired
+func (c *Configuration) GetAllowlistExpiryWarnDays() int {
+	if c.viper.IsSet("allowlist.expiry.warn_days") {
+		return c.viper.GetInt("allowlist.expiry.warn_days")
+	}
+	return 14
+}
+
+// SetAllowlistExpiryWarnDays sets how many days before expiry allowlist entries are warned about
+func (c *Configuration) SetAllowlistExpiryWarnDays(days int) {
+	c.viper.Set("allowlist.expiry.warn_days", days)
+}
+
+// GetAllowlistExpiryNotify returns whether expiring and expired allowlist entries are also sent
+// to the notifiers, not only logged
+func (c *Configuration) GetAllowlistExpiryNotify() bool {
+	return c.viper.GetBool("allowlist.expiry.notify")
+}
+
+// SetAllowlistExpiryNotify sets whether allowlist expiry warnings are sent to the notifiers
+func (c *Configuration) SetAllowlistExpiryNotify(notify bool) {
+	c.viper.Set("allowlist.expiry.notify", notify)
+}
+
+// GetDebugMode returns whether debug mode is enabled
+func (c *Configuration) GetDebugMode() bool {
+	if enabled := c.debugMode.Load(); enabled != nil {
+		return *enabled
+	}
+	return c.viper.GetBool("debug_mode")
+}
+
+// SetDebugMode sets the debug mode state; safe to call while the pipeline is running
+func (c *Configuration) SetDebugMode(enabled bool) {
+	c.debugMode.Store(&enabled)
+}
+
+// GetDebugTranscriptsPath returns the file every transcription is written to in debug mode
+func (c *Configuration) GetDebugTranscriptsPath() string {
+	if c.viper.IsSet("debug_transcripts.path") {
+		return c.viper.GetString("debug_transcripts.path")
+	}
+	return filepath.Join(c.GetPathsLogDir(), "transcriptions_debug.log")
+}
+
+// SetDebugTranscriptsPath sets the file transcriptions are written to in debug mode
+func (c *Configuration) SetDebugTranscriptsPath(path string) {
+	c.viper.Set("debug_transcripts.path", path)
+}
+
+// GetDebugTranscriptsSampleEvery returns N where only every Nth transcription is written to the
+// debug file; 1 writes all of them
+func (c *Configuration) GetDebugTranscriptsSampleEvery() int {
+	if c.viper.IsSet("debug_transcripts.sample_every") {
+		return c.viper.GetInt("debug_transcripts.sample_every")
+	}
+	return 1
+}
+
+// SetDebugTranscriptsSampleEvery sets how many transcriptions are seen per one written to the debug file
+func (c *Configuration) SetDebugTranscriptsSampleEvery(n int) {
+	c.viper.Set("debug_transcripts.sample_every", n)
+}
+
+// GetDebugTranscriptsMaxSizeMB returns the size at which the debug transcription file is rotated (0 disables rotation)
+func (c *Configuration) GetDebugTranscriptsMaxSizeMB() int {
+	if c.viper.IsSet("debug_transcripts.max_size_mb") {
+		return c.viper.GetInt("debug_transcripts.max_size_mb")
+	}
+	return 100
+}
+
+// GetDebugTranscriptsMaxBackups returns how many rotated debug transcription files are kept
+func (c *Configuration) GetDebugTranscriptsMaxBackups() int {
+	if c.viper.IsSet("debug_transcripts.max_backups") {
+		return c.viper.GetInt("debug_transcripts.max_backups")
+	}
+	return 3
+}
+
+// GetDebugTranscriptsFlushIntervalSec returns how long buffered debug transcriptions may wait before reaching the file
+func (c *Configuration) GetDebugTranscriptsFlushIntervalSec() int {
+	if c.viper.IsSet("debug_transcripts.flush_interval_sec") {
+		return c.viper.GetInt("debug_transcripts.flush_interval_sec")
+	}
+	return 5
+}
+
+// GetLogFilePath returns the configured log file path
+func (c *Configuration) GetLogFilePath() string {
+	return c.viper.GetString("log.file_path")
+}
+
+// LogSink describes one destination contest cues are written to
+type LogSink struct {
+	Type          string // file, stdout, syslog, or http
+	Target        string // File path, syslog address (e.g. udp://host:514), or HTTP URL
+	Format        string // json or text; empty uses the sink type's default
+	SchemaVersion string // JSON cue record schema version to pin the sink to; empty uses log.schema_version
+	Timezone      string // Time zone of text timestamps; empty uses timezone. JSON stays UTC.
+}
+
+// GetLogSinks returns the destinations contest cues are written to. log.sinks entries are
+// either maps with type, target, and format keys or "type[:target][#format]" strings (the
+// comma-separated LOG_SINKS environment variable uses the string form). Without log.sinks,
+// cues are written as JSON to log.file_path.
+func (c *Configuration) GetLogSinks() []LogSink {
+	var sinks []LogSink
+	var entries []interface{}
+	switch raw := c.viper.Get("log.sinks").(type) {
+	case []LogSink:
+		sinks = append(sinks, raw...)
+	case string:
+		for _, spec := range strings.Split(raw, ",") {
+			entries = append(entries, spec)
+		}
+	case []interface{}:
+		entries = raw
+	case []string:
+		for _, spec := range raw {
+			entries = append(entries, spec)
+		}
+	}
+
+	for _, entry := range entries {
+		switch e := entry.(type) {
+		case string:
+			if sink, ok := parseLogSinkSpec(e); ok {
+				sinks = append(sinks, sink)
+			}
+		case map[string]interface{}:
+			field := func(keys ...string) string {
+				for _, key := range keys {
+					if value, ok := e[key]; ok && value != nil {
+						return strings.TrimSpace(fmt.Sprint(value))
+					}
+				}
+				return ""
+			}
+			sink := LogSink{
+				Type:          strings.ToLower(field("type")),
+				Target:        field("target", "path", "url", "address"),
+				Format:        strings.ToLower(field("format")),
+				SchemaVersion: field("schema_version"),
+				Timezone:      field("timezone"),
+			}
+			sinks = append(sinks, sink)
+		}
+	}
+
+	if len(sinks) == 0 {
+		return []LogSink{{Type: "file", Target: c.GetLogFilePath(), Format: "json", SchemaVersion: c.GetLogSchemaVersion(), Timezone: c.GetTimezone()}}
+	}
+	for i := range sinks {
+		if sinks[i].Type == "file" && sinks[i].Target == "" {
+			sinks[i].Target = c.GetLogFilePath()
+		}
+		if sinks[i].SchemaVersion == "" {
+			sinks[i].SchemaVersion = c.GetLogSchemaVersion()
+		}
+		if sinks[i].Timezone == "" {
+			sinks[i].Timezone = c.GetTimezone()
+		}
+	}
+	return sinks
+}
+
+// SetLogSinks sets the destinations contest cues are written to
+func (c *Configuration) SetLogSinks(sinks []LogSink) {
+	c.viper.Set("log.sinks", sinks)
+}
+
+// parseLogSinkSpec parses a "type[:target][#format]" sink string. A bare http(s) URL is an http sink.
+func parseLogSinkSpec(spec string) (LogSink, bool) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return LogSink{}, false
+	}
+
+	var sink LogSink
+	if i := strings.LastIndex(spec, "#"); i >= 0 {
+		sink.Format = strings.ToLower(strings.TrimSpace(spec[i+1:]))
+		spec = spec[:i]
+	}
+
+	lower := strings.ToLower(spec)
+	if strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") {
+		sink.Type = "http"
+		sink.Target = spec
+		return sink, true
+	}
+
+	sinkType, target, _ := strings.Cut(spec, ":")
+	sink.Type = strings.ToLower(strings.TrimSpace(sinkType))
+	sink.Target = strings.TrimSpace(target)
+	return sink, true
+}
+
+// GetTimezone returns the IANA time zone human-facing output (text cue logs, notifications, the
+// console) shows times in, falling back to the station's timezone. Empty leaves times in UTC
+// (logs and notifications) or the server's zone (console).
+func (c *Configuration) GetTimezone() string {
+	if tz := strings.TrimSpace(c.viper.GetString("timezone")); tz != "" {
+		return tz
+	}
+	return c.GetStationMetadata().Timezone
+}
+
+// SetTimezone sets the time zone human-facing output shows times in
+func (c *Configuration) SetTimezone(tz string) {
+	c.viper.Set("timezone", tz)
+}
+
+// GetLogSchemaVersion returns the schema version JSON cue records are written in, e.g. "1.0" to keep
+// consumers that reject unknown fields working (empty uses the current version)
+func (c *Configuration) GetLogSchemaVersion() string {
+	return c.viper.GetString("log.schema_version")
+}
+
+// SetLogSchemaVersion sets the schema version JSON cue records are written in
+func (c *Configuration) SetLogSchemaVersion(version string) {
+	c.viper.Set("log.schema_version", version)
+}
+
+// GetLogHTTPTimeoutSec returns the request timeout in seconds for http log sinks
+func (c *Configuration) GetLogHTTPTimeoutSec() int {
+	if c.viper.IsSet("log.http_timeout_sec") {
+		return c.viper.GetInt("log.http_timeout_sec")
+	}
+	return 5
+}
+
+// GetLogQueueSize returns how many contest cues may wait for each log sink before they are dropped for it
+func (c *Configuration) GetLogQueueSize() int {
+	if c.viper.IsSet("log.queue_size") {
+		return c.viper.GetInt("log.queue_size")
+	}
+	return 1000
+}
+
+// SetLogQueueSize sets how many contest cues may wait for each log sink
+func (c *Configuration) SetLogQueueSize(size int) {
+	c.viper.Set("log.queue_size", size)
+}
+
+// GetLogBatchSize returns the most contest cues written to a sink at once
+func (c *Configuration) GetLogBatchSize() int {
+	if c.viper.IsSet("log.batch_size") {
+		return c.viper.GetInt("log.batch_size")
+	}
+	return 100
+}
+
+// GetLogFsyncIntervalMS returns how often file sinks are fsynced and failed writes are retried
+func (c *Configuration) GetLogFsyncIntervalMS() int {
+	if c.viper.IsSet("log.fsync_interval_ms") {
+		return c.viper.GetInt("log.fsync_interval_ms")
+	}
+	return 1000
+}
+
+// GetLogFsyncPolicy returns when file sinks are fsynced: "always" after every batch written,
+// "interval" every log.fsync_interval_ms, or "never", leaving it to the operating system
+func (c *Configuration) GetLogFsyncPolicy() string {
+	if c.viper.IsSet("log.fsync_policy") {
+		return c.viper.GetString("log.fsync_policy")
+	}
+	return "interval"
+}
+
+// SetLogFsyncPolicy sets when file sinks are fsynced
+func (c *Configuration) SetLogFsyncPolicy(policy string) {
+	c.viper.Set("log.fsync_policy", policy)
+}
+
+// GetLogRetryAttempts returns how many times a failed sink write is retried before the cues are
+// held for the next retry interval
+func (c *Configuration) GetLogRetryAttempts() int {
+	if c.viper.IsSet("log.retry_attempts") {
+		return c.viper.GetInt("log.retry_attempts")
+	}
+	return 3
+}
+
+// GetLogRetryBackoffMS returns the delay before the first retry of a failed sink write; it doubles per attempt
+func (c *Configuration) GetLogRetryBackoffMS() int {
+	if c.viper.IsSet("log.retry_backoff_ms") {
+		return c.viper.GetInt("log.retry_backoff_ms")
+	}
+	return 200
+}
+
+// SetLogRetryBackoffMS sets the delay before the first retry of a failed sink write
+func (c *Configuration) SetLogRetryBackoffMS(ms int) {
+	c.viper.Set("log.retry_backoff_ms", ms)
+}
+
+// GetLogMaxPendingCues returns how many undelivered cues are held per failing sink before the oldest are dropped
+func (c *Configuration) GetLogMaxPendingCues() int {
+	if c.viper.IsSet("log.max_pending_cues") {
+		return c.viper.GetInt("log.max_pending_cues")
+	}
+	return 10000
+}
+
+// GetTranscriptionTimeoutSec returns the configured transcription timeout in seconds
+func (c *Configuration) GetTranscriptionTimeoutSec() int {
+	return c.viper.GetInt("transcription.timeout_sec")
+}
+
+// SetTranscriptionTimeoutSec sets the transcription timeout in seconds
+func (c *Configuration) SetTranscriptionTimeoutSec(timeoutSec int) {
+	c.viper.Set("transcription.timeout_sec", timeoutSec)
+}
+
+// Offline Mode Methods
+
+// GetOfflineMode returns whether outbound network calls other than the configured stream are
+// disabled: no model downloads, no OpenAI API or Whisper service backends, and no NTP checks
+func (c *Configuration) GetOfflineMode() bool {
+	return c.viper.GetBool("offline_mode")
+}
+
+// SetOfflineMode sets whether outbound network calls other than the configured stream are disabled
+func (c *Configuration) SetOfflineMode(offline bool) {
+	c.viper.Set("offline_mode", offline)
+}
+
+// ValidateOfflineMode returns an error naming every configured integration that needs the network
+// when offline mode is enabled, so a restricted deployment fails at startup instead of at the
+// first notification
+func (c *Configuration) ValidateOfflineMode() error {
+	if !c.GetOfflineMode() {
+		return nil
+	}
+
+	var conflicts []string
+	if c.GetWebhookURL() != "" {
+		conflicts = append(conflicts, "notifier webhook")
+	}
+	if c.GetSheetsSpreadsheetID() != "" {
+		conflicts = append(conflicts, "Google Sheets notifier")
+	}
+	if c.GetMQTTBroker() != "" {
+		conflicts = append(conflicts, "MQTT broker")
+	}
+	if c.GetRedisEnabled() {
+		conflicts = append(conflicts, "Redis")
+	}
+	if c.GetCoordinationMode() == "redis" {
+		conflicts = append(conflicts, "Redis coordination")
+	}
+	for _, sink := range c.GetLogSinks() {
+		// A syslog sink without a target writes to the local syslog daemon
+		if sink.Type == "http" || (sink.Type == "syslog" && sink.Target != "") {
+			conflicts = append(conflicts, sink.Type+" log sink "+sink.Target)
+		}
+	}
+	if len(c.GetWhisperDownloadMirrors()) > 0 {
+		conflicts = append(conflicts, "whisper model download mirrors")
+	}
+	if c.GetCorrectionEnabled() {
+		conflicts = append(conflicts, "LLM correction endpoint")
+	}
+	if c.GetCalendarCalDAVURL() != "" {
+		conflicts = append(conflicts, "CalDAV calendar")
+	}
+	if c.GetFeaturesURL() != "" {
+		conflicts = append(conflicts, "remote feature flags")
+	}
+	if c.GetScheduleURL() != "" {
+		conflicts = append(conflicts, "remote program schedule")
+	}
+	if c.GetArchiveEnabled() {
+		conflicts = append(conflicts, "nightly archive export")
+	}
+
+	if len(conflicts) > 0 {
+		return fmt.Errorf("offline_mode is enabled but these settings need network access: %s", strings.Join(conflicts, ", "))
+	}
+	return nil
+}
+
+// GPU Configuration Methods
+
+// GetCUBLASEnabled returns whether CUDA acceleration is enabled
+func (c *Configuration) GetCUBLASEnabled() bool {
+	// Check new gpu.enabled format first, then fall back to whisper.cublas_enabled
+	if c.viper.IsSet("gpu.enabled") {
+		return c.viper.GetBool("gpu.enabled")
+	}
+	if c.viper.IsSet("whisper.cublas_enabled") {
+		return c.viper.GetBool("whisper.cublas_enabled")
+	}
+	// Default to true to enable GPU acceleration by default
+	return true
+}
+
+// SetCUBLASEnabled sets whether CUDA acceleration is enabled
+func (c *Configuration) SetCUBLASEnabled(enabled bool) {
+	// Set both formats for compatibility
+	c.viper.Set("gpu.enabled", enabled)
+	c.viper.Set("whisper.cublas_enabled", enabled)
+}
+
+// GetCUBLASAutoDetect returns whether GPU auto-detection is enabled
+func (c *Configuration) GetCUBLASAutoDetect() bool {
+	// Check new gpu.auto_detect format first, then fall back to whisper.cublas_auto_detect
+	if c.viper.IsSet("gpu.auto_detect") {
+		return c.viper.GetBool("gpu.auto_detect")
+	}
+	if c.viper.IsSet("whisper.cublas_auto_detect") {
+		return c.viper.GetBool("whisper.cublas_auto_detect")
+	}
+	// Default to true to enable auto-detection by default
+	return true
+}
+
+// SetCUBLASAutoDetect sets whether GPU auto-detection is enabled
+func (c *Configuration) SetCUBLASAutoDetect(autoDetect bool) {
+	// Set both formats for compatibility
+	c.viper.Set("gpu.auto_detect", autoDetect)
+	c.viper.Set("whisper.cublas_auto_detect", autoDetect)
+}
+
+// GetGPUDeviceID returns the configured GPU device ID
+func (c *Configuration) GetGPUDeviceID() int {
+	// Check new gpu.device_id format first, then fall back to whisper.gpu_device_id
+	if c.viper.IsSet("gpu.device_id") {
+		return c.viper.GetInt("gpu.device_id")
+	}
+	if c.viper.IsSet("whisper.gpu_device_id") {
+		return c.viper.GetInt("whisper.gpu_device_id")
+	}
+	// Default to device 0
+	return 0
+}
+
+// SetGPUDeviceID sets the GPU device ID
+func (c *Configuration) SetGPUDeviceID(deviceID int) {
+	// Set both formats for compatibility
+	c.viper.Set("gpu.device_id", deviceID)
+	c.viper.Set("whisper.gpu_device_id", deviceID)
+}
+
+// GetWhisperThreads returns the configured number of threads
+func (c *Configuration) GetWhisperThreads() int {
+	return c.viper.GetInt("whisper.threads")
+}
+
+// SetWhisperThreads sets the number of threads
 func (c *Configuration) SetWhisperThreads(threads int) {
 	c.viper.Set("whisper.threads", threads)
 }
+
+// GetWhisperServiceEndpoints returns the candidate Whisper HTTP service endpoints, probed in order
+// when discovering (or re-discovering) the service
+func (c *Configuration) GetWhisperServiceEndpoints() []string {
+	// A plain string comes from the comma-separated WHISPER_SERVICE_ENDPOINTS environment variable
+	var endpoints []string
+	if raw, ok := c.viper.Get("whisper.service_endpoints").(string); ok {
+		endpoints = strings.Split(raw, ",")
+	} else {
+		endpoints = c.viper.GetStringSlice("whisper.service_endpoints")
+	}
+
+	result := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		if trimmed := strings.TrimRight(strings.TrimSpace(endpoint), "/"); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	if len(result) == 0 {
+		return []string{"http://localhost:9000", "http://whisper:9000", "http://127.0.0.1:9000"}
+	}
+	return result
+}
+
+// SetWhisperServiceEndpoints sets the candidate Whisper HTTP service endpoints
+func (c *Configuration) SetWhisperServiceEndpoints(endpoints []string) {
+	c.viper.Set("whisper.service_endpoints", endpoints)
+}
+
+// GetWhisperServicePath returns the path transcription requests are posted to on a Whisper HTTP
+// service, e.g. "/asr" for whisper-asr-webservice or "/inference" for the whisper.cpp server
+func (c *Configuration) GetWhisperServicePath() string {
+	if c.viper.IsSet("whisper.service_path") {
+		return "/" + strings.TrimLeft(c.viper.GetString("whisper.service_path"), "/")
+	}
+	return "/transcribe"
+}
+
+// SetWhisperServicePath sets the path transcription requests are posted to
+func (c *Configuration) SetWhisperServicePath(path string) {
+	c.viper.Set("whisper.service_path", path)
+}
+
+// GetWhisperServiceHealthPath returns the path a Whisper HTTP service is health checked at
+func (c *Configuration) GetWhisperServiceHealthPath() string {
+	if c.viper.IsSet("whisper.service_health_path") {
+		return "/" + strings.TrimLeft(c.viper.GetString("whisper.service_health_path"), "/")
+	}
+	return "/health"
+}
+
+// SetWhisperServiceHealthPath sets the path a Whisper HTTP service is health checked at
+func (c *Configuration) SetWhisperServiceHealthPath(path string) {
+	c.viper.Set("whisper.service_health_path", path)
+}
+
+// GetWhisperServiceUpload returns how audio is sent to a Whisper HTTP service: "raw" posts the
+// WAV bytes as the request body, "multipart" uploads them as a multipart/form-data file
+func (c *Configuration) GetWhisperServiceUpload() string {
+	if c.viper.IsSet("whisper.service_upload") {
+		return strings.ToLower(strings.TrimSpace(c.viper.GetString("whisper.service_upload")))
+	}
+	return "raw"
+}
+
+// SetWhisperServiceUpload sets how audio is sent to a Whisper HTTP service
+func (c *Configuration) SetWhisperServiceUpload(upload string) {
+	c.viper.Set("whisper.service_upload", upload)
+}
+
+// GetWhisperServiceFileField returns the form field multipart uploads carry the audio in, e.g.
+// "audio_file" for whisper-asr-webservice
+func (c *Configuration) GetWhisperServiceFileField() string {
+	if c.viper.IsSet("whisper.service_file_field") {
+		return c.viper.GetString("whisper.service_file_field")
+	}
+	return "file"
+}
+
+// SetWhisperServiceFileField sets the form field multipart uploads carry the audio in
+func (c *Configuration) SetWhisperServiceFileField(field string) {
+	c.viper.Set("whisper.service_file_field", field)
+}
+
+// GetWhisperServiceAPIKey returns the key sent as a bearer token to a Whisper HTTP service
+// (empty sends none)
+func (c *Configuration) GetWhisperServiceAPIKey() string {
+	return c.viper.GetString("whisper.service_api_key")
+}
+
+// SetWhisperServiceAPIKey sets the key sent as a bearer token to a Whisper HTTP service
+func (c *Configuration) SetWhisperServiceAPIKey(key string) {
+	c.viper.Set("whisper.service_api_key", key)
+}
+
+// GetWhisperServiceHeaders returns extra headers sent with every request to a Whisper HTTP
+// service, such as an API key header a proxy in front of it expects
+func (c *Configuration) GetWhisperServiceHeaders() map[string]string {
+	return c.viper.GetStringMapString("whisper.service_headers")
+}
+
+// SetWhisperServiceHeaders sets extra headers sent with every request to a Whisper HTTP service
+func (c *Configuration) SetWhisperServiceHeaders(headers map[string]string) {
+	c.viper.Set("whisper.service_headers", headers)
+}
+
+// GetWhisperAPIBaseURL returns the base URL of the OpenAI-compatible API used as the last
+// transcription fallback
+func (c *Configuration) GetWhisperAPIBaseURL() string {
+	if c.viper.IsSet("whisper.api_base_url") {
+		return strings.TrimRight(c.viper.GetString("whisper.api_base_url"), "/")
+	}
+	return "https://api.openai.com/v1"
+}
+
+// SetWhisperAPIBaseURL sets the base URL of the OpenAI-compatible transcription API
+func (c *Configuration) SetWhisperAPIBaseURL(url string) {
+	c.viper.Set("whisper.api_base_url", url)
+}
+
+// GetWhisperAPIModel returns the model the transcription API is asked to use
+func (c *Configuration) GetWhisperAPIModel() string {
+	if c.viper.IsSet("whisper.api_model") {
+		return c.viper.GetString("whisper.api_model")
+	}
+	return "whisper-1"
+}
+
+// GetWhisperAPITimeoutSec returns how long one transcription API request, including the upload,
+// may take before it is abandoned and retried
+func (c *Configuration) GetWhisperAPITimeoutSec() int {
+	if c.viper.IsSet("whisper.api_timeout_sec") {
+		return c.viper.GetInt("whisper.api_timeout_sec")
+	}
+	return 30
+}
+
+// SetWhisperAPITimeoutSec sets how long one transcription API request may take
+func (c *Configuration) SetWhisperAPITimeoutSec(seconds int) {
+	c.viper.Set("whisper.api_timeout_sec", seconds)
+}
+
+// GetWhisperBackendPriority returns the order in which transcription backends ("binary",
+// "service", "api") are considered; the first available backend is used
+func (c *Configuration) GetWhisperBackendPriority() []string {
+	// A plain string comes from the comma-separated WHISPER_BACKEND_PRIORITY environment variable
+	var backends []string
+	if raw, ok := c.viper.Get("whisper.backend_priority").(string); ok {
+		backends = strings.Split(raw, ",")
+	} else {
+		backends = c.viper.GetStringSlice("whisper.backend_priority")
+	}
+
+	result := make([]string, 0, len(backends))
+	for _, backend := range backends {
+		if trimmed := strings.ToLower(strings.TrimSpace(backend)); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	if len(result) == 0 {
+		return []string{"binary", "service", "api"}
+	}
+	return result
+}
+
+// SetWhisperBackendPriority sets the order in which transcription backends are considered
+func (c *Configuration) SetWhisperBackendPriority(backends []string) {
+	c.viper.Set("whisper.backend_priority", backends)
+}
+
+// GetWhisperHealthCheckIntervalSec returns how often the active transcription backend is health checked
+func (c *Configuration) GetWhisperHealthCheckIntervalSec() int {
+	if c.viper.IsSet("whisper.health_check_interval_sec") {
+		return c.viper.GetInt("whisper.health_check_interval_sec")
+	}
+	return 30
+}
+
+// GetWhisperWarmupEnabled returns whether a short clip is transcribed after loading the model to
+// verify the backend works before the pipeline starts
+func (c *Configuration) GetWhisperWarmupEnabled() bool {
+	if c.viper.IsSet("whisper.warmup.enabled") {
+		return c.viper.GetBool("whisper.warmup.enabled")
+	}
+	return true
+}
+
+// SetWhisperWarmupEnabled sets whether the transcription backend is warmed up at startup
+func (c *Configuration) SetWhisperWarmupEnabled(enabled bool) {
+	c.viper.Set("whisper.warmup.enabled", enabled)
+}
+
+// GetWhisperWarmupTimeoutSec returns how long the warm-up transcription may take, including
+// loading the model onto the GPU
+func (c *Configuration) GetWhisperWarmupTimeoutSec() int {
+	if c.viper.IsSet("whisper.warmup.timeout_sec") {
+		return c.viper.GetInt("whisper.warmup.timeout_sec")
+	}
+	return 120
+}
+
+// GetWhisperWarmupClip returns the 16 kHz mono WAV clip transcribed at warm-up (empty uses built-in silence)
+func (c *Configuration) GetWhisperWarmupClip() string {
+	return c.viper.GetString("whisper.warmup.clip")
+}
+
+// GetWhisperWarmupExpect returns a phrase the warm-up clip's transcription must contain (empty accepts any text)
+func (c *Configuration) GetWhisperWarmupExpect() string {
+	return c.viper.GetString("whisper.warmup.expect")
+}
+
+// SetWhisperWarmupClip sets the warm-up clip and the phrase its transcription must contain
+func (c *Configuration) SetWhisperWarmupClip(path, expect string) {
+	c.viper.Set("whisper.warmup.clip", path)
+	c.viper.Set("whisper.warmup.expect", expect)
+}
+
+// GetWhisperDownloadMirrors returns base URLs to download missing models from, tried in order
+// before the HuggingFace repository. Each mirror must serve ggml-<model>.bin under its base URL.
+func (c *Configuration) GetWhisperDownloadMirrors() []string {
+	// A plain string comes from the comma-separated WHISPER_DOWNLOAD_MIRRORS environment variable
+	var mirrors []string
+	if raw, ok := c.viper.Get("whisper.download.mirrors").(string); ok {
+		mirrors = strings.Split(raw, ",")
+	} else {
+		mirrors = c.viper.GetStringSlice("whisper.download.mirrors")
+	}
+
+	result := make([]string, 0, len(mirrors))
+	for _, mirror := range mirrors {
+		if trimmed := strings.TrimRight(strings.TrimSpace(mirror), "/"); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
+// SetWhisperDownloadMirrors sets the base URLs to download missing models from
+func (c *Configuration) SetWhisperDownloadMirrors(mirrors []string) {
+	c.viper.Set("whisper.download.mirrors", mirrors)
+}
+
+// GetWhisperModelChecksums returns the expected SHA256 (hex) of models, keyed by model name such
+// as "base.en", checked after downloading and at startup. Models without an entry are not hashed.
+func (c *Configuration) GetWhisperModelChecksums() map[string]string {
+	checksums := make(map[string]string)
+	for model, sum := range c.viper.GetStringMapString("whisper.download.checksums") {
+		if sum = strings.ToLower(strings.TrimSpace(sum)); sum != "" {
+			checksums[strings.ToLower(model)] = sum
+		}
+	}
+	return checksums
+}
+
+// SetWhisperModelChecksums sets the expected SHA256 of downloaded models, keyed by model name
+func (c *Configuration) SetWhisperModelChecksums(checksums map[string]string) {
+	c.viper.Set("whisper.download.checksums", checksums)
+}
+
+// GetWhisperVerifyModel returns whether the model file is checked for corruption before loading
+func (c *Configuration) GetWhisperVerifyModel() bool {
+	if c.viper.IsSet("whisper.verify_model") {
+		return c.viper.GetBool("whisper.verify_model")
+	}
+	return true
+}
+
+// SetWhisperVerifyModel sets whether the model file is checked for corruption before loading
+func (c *Configuration) SetWhisperVerifyModel(verify bool) {
+	c.viper.Set("whisper.verify_model", verify)
+}
+
+// GetWhisperBinaryTimeoutSec returns how long one whisper-cli run may take before it is killed;
+// 0 lets it run indefinitely
+func (c *Configuration) GetWhisperBinaryTimeoutSec() int {
+	if c.viper.IsSet("whisper.binary_timeout_sec") {
+		return c.viper.GetInt("whisper.binary_timeout_sec")
+	}
+	return 300
+}
+
+// SetWhisperBinaryTimeoutSec sets how long one whisper-cli run may take
+func (c *Configuration) SetWhisperBinaryTimeoutSec(seconds int) {
+	c.viper.Set("whisper.binary_timeout_sec", seconds)
+}
+
+// Anomaly Detection Methods
+
+// GetAnomalyDetectionEnabled returns whether transcription rate anomaly detection is enabled
+func (c *Configuration) GetAnomalyDetectionEnabled() bool {
+	if c.viper.IsSet("anomaly.enabled") {
+		return c.viper.GetBool("anomaly.enabled")
+	}
+	return true
+}
+
+// GetAnomalyWindowSec returns the window in seconds used to compute the current words/minute rate
+func (c *Configuration) GetAnomalyWindowSec() int {
+	if c.viper.IsSet("anomaly.window_sec") {
+		return c.viper.GetInt("anomaly.window_sec")
+	}
+	return 120
+}
+
+// GetAnomalyBaselineWindowSec returns the window in seconds used to compute the rolling baseline rate
+func (c *Configuration) GetAnomalyBaselineWindowSec() int {
+	if c.viper.IsSet("anomaly.baseline_window_sec") {
+		return c.viper.GetInt("anomaly.baseline_window_sec")
+	}
+	return 900
+}
+
+// GetAnomalyLowRatio returns the current/baseline ratio at or below which output is considered collapsed
+func (c *Configuration) GetAnomalyLowRatio() float64 {
+	if c.viper.IsSet("anomaly.low_ratio") {
+		return c.viper.GetFloat64("anomaly.low_ratio")
+	}
+	return 0.25
+}
+
+// GetAnomalyHighRatio returns the current/baseline ratio at or above which output is considered exploded
+func (c *Configuration) GetAnomalyHighRatio() float64 {
+	if c.viper.IsSet("anomaly.high_ratio") {
+		return c.viper.GetFloat64("anomaly.high_ratio")
+	}
+	return 4.0
+}
+
+// GetAnomalyMinBaselineWPM returns the minimum baseline words/minute required before judging anomalies
+func (c *Configuration) GetAnomalyMinBaselineWPM() float64 {
+	if c.viper.IsSet("anomaly.min_baseline_wpm") {
+		return c.viper.GetFloat64("anomaly.min_baseline_wpm")
+	}
+	return 20
+}
+
+// Failure Escalation Methods
+
+// GetEscalationEnabled returns whether sustained unhealthy periods escalate to notifier alerts
+func (c *Configuration) GetEscalationEnabled() bool {
+	if c.viper.IsSet("escalation.enabled") {
+		return c.viper.GetBool("escalation.enabled")
+	}
+	return true
+}
+
+// SetEscalationEnabled sets whether sustained unhealthy periods escalate to notifier alerts
+func (c *Configuration) SetEscalationEnabled(enabled bool) {
+	c.viper.Set("escalation.enabled", enabled)
+}
+
+// GetEscalationWarnAfterSec returns how long the pipeline must stay unhealthy before a warning is sent
+func (c *Configuration) GetEscalationWarnAfterSec() int {
+	if c.viper.IsSet("escalation.warn_after_sec") {
+		return c.viper.GetInt("escalation.warn_after_sec")
+	}
+	return 600
+}
+
+// GetEscalationPageAfterSec returns how long the pipeline must stay unhealthy before a page-priority alert is sent
+func (c *Configuration) GetEscalationPageAfterSec() int {
+	if c.viper.IsSet("escalation.page_after_sec") {
+		return c.viper.GetInt("escalation.page_after_sec")
+	}
+	return 1800
+}
+
+// GetEscalationDailyBudgetPct returns the percentage of each day the pipeline may spend unhealthy
+func (c *Configuration) GetEscalationDailyBudgetPct() float64 {
+	if c.viper.IsSet("escalation.daily_budget_pct") {
+		return c.viper.GetFloat64("escalation.daily_budget_pct")
+	}
+	return 1.0
+}
+
+// Latency SLO Methods
+
+// GetLatencySLOCueTargetSec returns the end-to-end cue latency, from audio capture to emission,
+// the SLO percentile of cues must stay within
+func (c *Configuration) GetLatencySLOCueTargetSec() int {
+	if c.viper.IsSet("latency_slo.cue_target_sec") {
+		return c.viper.GetInt("latency_slo.cue_target_sec")
+	}
+	return 30
+}
+
+// SetLatencySLOCueTargetSec sets the end-to-end cue latency the SLO percentile must stay within
+func (c *Configuration) SetLatencySLOCueTargetSec(seconds int) {
+	c.viper.Set("latency_slo.cue_target_sec", seconds)
+}
+
+// GetLatencySLOPercentile returns the percentile of cue latencies held to the SLO target
+func (c *Configuration) GetLatencySLOPercentile() float64 {
+	if c.viper.IsSet("latency_slo.percentile") {
+		return c.viper.GetFloat64("latency_slo.percentile")
+	}
+	return 95
+}
+
+// Clock Drift Methods
+
+// GetNTPEnabled returns whether the system clock is periodically checked against an NTP server.
+// Offline mode always disables the check.
+func (c *Configuration) GetNTPEnabled() bool {
+	if c.GetOfflineMode() {
+		return false
+	}
+	if c.viper.IsSet("ntp.enabled") {
+		return c.viper.GetBool("ntp.enabled")
+	}
+	return true
+}
+
+// SetNTPEnabled sets whether the system clock is periodically checked against an NTP server
+func (c *Configuration) SetNTPEnabled(enabled bool) {
+	c.viper.Set("ntp.enabled", enabled)
+}
+
+// GetNTPServer returns the NTP server, host or host:port, the clock is checked against
+func (c *Configuration) GetNTPServer() string {
+	if c.viper.IsSet("ntp.server") {
+		return c.viper.GetString("ntp.server")
+	}
+	return "pool.ntp.org"
+}
+
+// SetNTPServer sets the NTP server the clock is checked against
+func (c *Configuration) SetNTPServer(server string) {
+	c.viper.Set("ntp.server", server)
+}
+
+// GetNTPCheckIntervalSec returns how often the clock is checked against the NTP server
+func (c *Configuration) GetNTPCheckIntervalSec() int {
+	if c.viper.IsSet("ntp.check_interval_sec") {
+		return c.viper.GetInt("ntp.check_interval_sec")
+	}
+	return 900
+}
+
+// GetNTPMaxOffsetMS returns the largest clock offset from NTP time, in milliseconds, that is still
+// considered in sync; a larger drift degrades health
+func (c *Configuration) GetNTPMaxOffsetMS() int {
+	if c.viper.IsSet("ntp.max_offset_ms") {
+		return c.viper.GetInt("ntp.max_offset_ms")
+	}
+	return 500
+}
+
+// GetNTPTimeoutSec returns how long a single NTP query may take
+func (c *Configuration) GetNTPTimeoutSec() int {
+	if c.viper.IsSet("ntp.timeout_sec") {
+		return c.viper.GetInt("ntp.timeout_sec")
+	}
+	return 5
+}
+
+// Audio Gain Control Methods
+
+// GetAGCEnabled returns whether automatic gain control normalizes the decoded audio level
+func (c *Configuration) GetAGCEnabled() bool {
+	return c.viper.GetBool("audio.agc.enabled")
+}
+
+// SetAGCEnabled sets whether automatic gain control normalizes the decoded audio level
+func (c *Configuration) SetAGCEnabled(enabled bool) {
+	c.viper.Set("audio.agc.enabled", enabled)
+}
+
+// GetAGCTargetDBFS returns the RMS level in dBFS automatic gain control steers towards
+func (c *Configuration) GetAGCTargetDBFS() float64 {
+	if c.viper.IsSet("audio.agc.target_dbfs") {
+		return c.viper.GetFloat64("audio.agc.target_dbfs")
+	}
+	return -20
+}
+
+// GetAGCMaxGainDB returns the largest boost or cut in dB automatic gain control applies
+func (c *Configuration) GetAGCMaxGainDB() float64 {
+	if c.viper.IsSet("audio.agc.max_gain_db") {
+		return c.viper.GetFloat64("audio.agc.max_gain_db")
+	}
+	return 20
+}
+
+// Audio Tee Methods
+
+// GetAudioTeeTarget returns where decoded PCM is copied for external consumers: "unix:PATH" to
+// serve a socket, "fifo:PATH" for a named pipe, or "exec:COMMAND" for a secondary process.
+// Empty disables the tee.
+func (c *Configuration) GetAudioTeeTarget() string {
+	return c.viper.GetString("audio.tee.target")
+}
+
+// SetAudioTeeTarget sets where decoded PCM is copied for external consumers
+func (c *Configuration) SetAudioTeeTarget(target string) {
+	c.viper.Set("audio.tee.target", target)
+}
+
+// GetAudioTeeBufferKB returns how much PCM in KB is queued for each tee consumer before its audio is dropped
+func (c *Configuration) GetAudioTeeBufferKB() int {
+	if c.viper.IsSet("audio.tee.buffer_kb") {
+		return c.viper.GetInt("audio.tee.buffer_kb")
+	}
+	return 256
+}
+
+// Pipeline Backlog Methods
+
+// GetChannelHighWatermarkPct returns the channel fill percentage considered a backlog
+func (c *Configuration) GetChannelHighWatermarkPct() int {
+	if c.viper.IsSet("pipeline.channel_high_watermark_pct") {
+		return c.viper.GetInt("pipeline.channel_high_watermark_pct")
+	}
+	return 80
+}
+
+// SetChannelHighWatermarkPct sets the channel fill percentage considered a backlog
+func (c *Configuration) SetChannelHighWatermarkPct(pct int) {
+	c.viper.Set("pipeline.channel_high_watermark_pct", pct)
+}
+
+// GetChannelHighWatermarkSec returns how long a channel must stay above the watermark before a warning is logged
+func (c *Configuration) GetChannelHighWatermarkSec() int {
+	if c.viper.IsSet("pipeline.channel_high_watermark_sec") {
+		return c.viper.GetInt("pipeline.channel_high_watermark_sec")
+	}
+	return 30
+}
+
+// SetChannelHighWatermarkSec sets how long a channel must stay above the watermark before a warning is logged
+func (c *Configuration) SetChannelHighWatermarkSec(seconds int) {
+	c.viper.Set("pipeline.channel_high_watermark_sec", seconds)
+}
+
+// Parser Normalization Methods
+
+// GetNormalizationSteps returns the ordered text normalization steps applied before pattern matching
+func (c *Configuration) GetNormalizationSteps() []string {
+	if c.viper.IsSet("parser.normalization.steps") {
+		return c.viper.GetStringSlice("parser.normalization.steps")
+	}
+	return []string{"spelled_letters"}
+}
+
+// SetNormalizationSteps sets the ordered text normalization steps
+func (c *Configuration) SetNormalizationSteps(steps []string) {
+	c.viper.Set("parser.normalization.steps", steps)
+}
+
+// GetNormalizationHomophones returns the homophone map used by the homophones step (nil uses built-in defaults)
+func (c *Configuration) GetNormalizationHomophones() map[string]string {
+	if c.viper.IsSet("parser.normalization.homophones") {
+		return c.viper.GetStringMapString("parser.normalization.homophones")
+	}
+	return nil
+}
+
+// GetKeywordMinLength returns the shortest pattern keyword accepted, in characters
+func (c *Configuration) GetKeywordMinLength() int {
+	if c.viper.IsSet("parser.keyword.min_length") {
+		return c.viper.GetInt("parser.keyword.min_length")
+	}
+	return 2
+}
+
+// SetKeywordMinLength sets the shortest pattern keyword accepted
+func (c *Configuration) SetKeywordMinLength(length int) {
+	c.viper.Set("parser.keyword.min_length", length)
+}
+
+// GetKeywordMaxLength returns the longest pattern keyword accepted, in characters (0 means no limit)
+func (c *Configuration) GetKeywordMaxLength() int {
+	return c.viper.GetInt("parser.keyword.max_length")
+}
+
+// SetKeywordMaxLength sets the longest pattern keyword accepted
+func (c *Configuration) SetKeywordMaxLength(length int) {
+	c.viper.Set("parser.keyword.max_length", length)
+}
+
+// GetKeywordStopWords returns words never accepted as pattern keywords (nil uses built-in defaults)
+func (c *Configuration) GetKeywordStopWords() []string {
+	if !c.viper.IsSet("parser.keyword.stop_words") {
+		return nil
+	}
+
+	// A comma-separated string comes from the KEYWORD_STOP_WORDS environment variable
+	if value, ok := c.viper.Get("parser.keyword.stop_words").(string); ok {
+		words := []string{}
+		for _, word := range strings.Split(value, ",") {
+			if trimmed := strings.TrimSpace(word); trimmed != "" {
+				words = append(words, trimmed)
+			}
+		}
+		return words
+	}
+	return c.viper.GetStringSlice("parser.keyword.stop_words")
+}
+
+// GetKeywordCanonical returns the map of keyword variants to canonical contest names, e.g.
+// pota: "POTA Contest". Variants match ignoring case and punctuation, so "P-O-T-A" and "pota"
+// are the same variant. The PARSER_KEYWORD_CANONICAL environment variable uses the
+// comma-separated "variant=Contest Name" form.
+func (c *Configuration) GetKeywordCanonical() map[string]string {
+	if value, ok := c.viper.Get("parser.keyword.canonical").(string); ok {
+		canonical := map[string]string{}
+		for _, pair := range strings.Split(value, ",") {
+			variant, name, ok := strings.Cut(pair, "=")
+			if variant, name = strings.TrimSpace(variant), strings.TrimSpace(name); ok && variant != "" && name != "" {
+				canonical[variant] = name
+			}
+		}
+		return canonical
+	}
+	return c.viper.GetStringMapString("parser.keyword.canonical")
+}
+
+// SetKeywordCanonical sets the map of keyword variants to canonical contest names
+func (c *Configuration) SetKeywordCanonical(canonical map[string]string) {
+	c.viper.Set("parser.keyword.canonical", canonical)
+}
+
+// SetKeywordStopWords sets the words never accepted as pattern keywords
+func (c *Configuration) SetKeywordStopWords(words []string) {
+	c.viper.Set("parser.keyword.stop_words", words)
+}
+
+// GetSubstitutions returns inline phrase -> replacement ASR corrections from configuration
+func (c *Configuration) GetSubstitutions() map[string]string {
+	return c.viper.GetStringMapString("parser.substitutions.entries")
+}
+
+// GetSubstitutionFile returns the path of a hot-reloadable substitution file (empty disables it)
+func (c *Configuration) GetSubstitutionFile() string {
+	return c.viper.GetString("parser.substitutions.file")
+}
+
+// SetSubstitutionFile sets the path of the substitution file
+func (c *Configuration) SetSubstitutionFile(path string) {
+	c.viper.Set("parser.substitutions.file", path)
+}
+
+// GetSubstitutionReloadIntervalSec returns how often the substitution file is checked for changes
+func (c *Configuration) GetSubstitutionReloadIntervalSec() int {
+	if c.viper.IsSet("parser.substitutions.reload_interval_sec") {
+		return c.viper.GetInt("parser.substitutions.reload_interval_sec")
+	}
+	return 30
+}
+
+// GetCueHashBucketSec returns the time window in seconds cue content hashes are bucketed into
+func (c *Configuration) GetCueHashBucketSec() int {
+	if c.viper.IsSet("parser.cue_hash_bucket_sec") {
+		return c.viper.GetInt("parser.cue_hash_bucket_sec")
+	}
+	return 60
+}
+
+// SetCueHashBucketSec sets the time window in seconds cue content hashes are bucketed into
+func (c *Configuration) SetCueHashBucketSec(seconds int) {
+	c.viper.Set("parser.cue_hash_bucket_sec", seconds)
+}
+
+// GetParserPatternLanguage returns the language whose trigger words the contest pattern uses,
+// e.g. "en" for "Text [KEYWORD] to [NUMBER]" or "es" for "Texto [KEYWORD] al [NUMBER]"
+func (c *Configuration) GetParserPatternLanguage() string {
+	if c.viper.IsSet("parser.pattern.language") {
+		return c.viper.GetString("parser.pattern.language")
+	}
+	return "en"
+}
+
+// SetParserPatternLanguage sets the language whose trigger words the contest pattern uses
+func (c *Configuration) SetParserPatternLanguage(language string) {
+	c.viper.Set("parser.pattern.language", language)
+}
+
+// GetParserPatternTextWords returns the words starting a contest cue in place of the language's
+// own (nil keeps them)
+func (c *Configuration) GetParserPatternTextWords() []string {
+	return c.phraseList("parser.pattern.text_words")
+}
+
+// SetParserPatternTextWords sets the words starting a contest cue
+func (c *Configuration) SetParserPatternTextWords(words []string) {
+	c.viper.Set("parser.pattern.text_words", words)
+}
+
+// GetParserPatternToWords returns the words joining a cue's keyword to its number in place of the
+// language's own (nil keeps them)
+func (c *Configuration) GetParserPatternToWords() []string {
+	return c.phraseList("parser.pattern.to_words")
+}
+
+// SetParserPatternToWords sets the words joining a cue's keyword to its number
+func (c *Configuration) SetParserPatternToWords(words []string) {
+	c.viper.Set("parser.pattern.to_words", words)
+}
+
+// GetParserMinConfidence returns the transcription confidence below which buffered contexts
+// produce no cues; 0 disables the check. Scheduled programs can lower or raise it.
+func (c *Configuration) GetParserMinConfidence() float64 {
+	return c.viper.GetFloat64("parser.min_confidence")
+}
+
+// SetParserMinConfidence sets the transcription confidence below which contexts produce no cues
+func (c *Configuration) SetParserMinConfidence(confidence float64) {
+	c.viper.Set("parser.min_confidence", confidence)
+}
+
+// GetParserShadowConfigFile returns the candidate config file whose parser settings are evaluated
+// in shadow against the active ones; empty disables shadow evaluation
+func (c *Configuration) GetParserShadowConfigFile() string {
+	return c.viper.GetString("parser.shadow.config_file")
+}
+
+// SetParserShadowConfigFile sets the candidate config file evaluated in shadow
+func (c *Configuration) SetParserShadowConfigFile(path string) {
+	c.viper.Set("parser.shadow.config_file", path)
+}
+
+// GetParserShadowReportFile returns where the shadow diff report is written as JSON; empty keeps
+// it to the log and health status
+func (c *Configuration) GetParserShadowReportFile() string {
+	return c.viper.GetString("parser.shadow.report_file")
+}
+
+// SetParserShadowReportFile sets where the shadow diff report is written
+func (c *Configuration) SetParserShadowReportFile(path string) {
+	c.viper.Set("parser.shadow.report_file", path)
+}
+
+// Program Schedule Methods
+
+// ScheduledProgram is a show on the station's program schedule
+type ScheduledProgram struct {
+	Name            string
+	Days            []string // mon..sun, weekdays, or weekends; empty airs every day
+	Start           string   // "HH:MM" station time
+	End             string   // "HH:MM" station time; before Start when the show runs past midnight
+	ContestHeavy    bool
+	ConfidenceBoost float64 // Subtracted from parser.min_confidence while the show is on the air
+}
+
+// GetSchedulePrograms returns the station's program schedule. schedule.programs entries are maps
+// with name, days, start, end, contest_heavy, and confidence_boost keys.
+func (c *Configuration) GetSchedulePrograms() []ScheduledProgram {
+	var entries []interface{}
+	switch raw := c.viper.Get("schedule.programs").(type) {
+	case []ScheduledProgram:
+		return raw
+	case []interface{}:
+		entries = raw
+	}
+
+	var programs []ScheduledProgram
+	for _, entry := range entries {
+		e, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		text := func(key string) string {
+			if value, ok := e[key]; ok && value != nil {
+				return strings.TrimSpace(fmt.Sprint(value))
+			}
+			return ""
+		}
+		program := ScheduledProgram{Name: text("name"), Days: scheduleDays(e["days"]), Start: text("start"), End: text("end")}
+		program.ContestHeavy, _ = strconv.ParseBool(text("contest_heavy"))
+		program.ConfidenceBoost, _ = strconv.ParseFloat(text("confidence_boost"), 64)
+		if program.Name != "" {
+			programs = append(programs, program)
+		}
+	}
+	return programs
+}
+
+// scheduleDays returns the days of a schedule entry, given as a list or a comma-separated string
+func scheduleDays(value interface{}) []string {
+	var days []string
+	switch value := value.(type) {
+	case string:
+		for _, day := range strings.Split(value, ",") {
+			if day = strings.TrimSpace(day); day != "" {
+				days = append(days, day)
+			}
+		}
+	case []interface{}:
+		for _, day := range value {
+			days = append(days, strings.TrimSpace(fmt.Sprint(day)))
+		}
+	}
+	return days
+}
+
+// SetSchedulePrograms sets the station's program schedule
+func (c *Configuration) SetSchedulePrograms(programs []ScheduledProgram) {
+	c.viper.Set("schedule.programs", programs)
+}
+
+// GetScheduleURL returns the URL of a JSON program schedule replacing schedule.programs (empty
+// uses the configured programs only)
+func (c *Configuration) GetScheduleURL() string {
+	return c.viper.GetString("schedule.url")
+}
+
+// SetScheduleURL sets the URL of the JSON program schedule
+func (c *Configuration) SetScheduleURL(url string) {
+	c.viper.Set("schedule.url", url)
+}
+
+// GetScheduleRefreshIntervalSec returns how often the remote program schedule is fetched again
+func (c *Configuration) GetScheduleRefreshIntervalSec() int {
+	if c.viper.IsSet("schedule.refresh_interval_sec") {
+		return c.viper.GetInt("schedule.refresh_interval_sec")
+	}
+	return 3600
+}
+
+// LLM Correction Methods
+
+// GetCorrectionEnabled returns whether low-confidence buffered contexts are sent to an LLM to
+// correct transcription errors before pattern matching
+func (c *Configuration) GetCorrectionEnabled() bool {
+	return c.viper.GetBool("correction.enabled")
+}
+
+// SetCorrectionEnabled sets whether low-confidence buffered contexts are corrected by an LLM
+func (c *Configuration) SetCorrectionEnabled(enabled bool) {
+	c.viper.Set("correction.enabled", enabled)
+}
+
+// GetCorrectionEndpoint returns the OpenAI-compatible chat completions URL of the correction LLM
+func (c *Configuration) GetCorrectionEndpoint() string {
+	if c.viper.IsSet("correction.endpoint") {
+		return strings.TrimSpace(c.viper.GetString("correction.endpoint"))
+	}
+	return "http://localhost:11434/v1/chat/completions"
+}
+
+// SetCorrectionEndpoint sets the chat completions URL of the correction LLM
+func (c *Configuration) SetCorrectionEndpoint(endpoint string) {
+	c.viper.Set("correction.endpoint", endpoint)
+}
+
+// GetCorrectionModel returns the model name sent to the correction endpoint
+func (c *Configuration) GetCorrectionModel() string {
+	if c.viper.IsSet("correction.model") {
+		return c.viper.GetString("correction.model")
+	}
+	return "llama3.2"
+}
+
+// GetCorrectionAPIKey returns the bearer token for the correction endpoint (empty sends none)
+func (c *Configuration) GetCorrectionAPIKey() string {
+	return c.viper.GetString("correction.api_key")
+}
+
+// GetCorrectionPrompt returns the system prompt sent with each context; empty uses the built-in prompt
+func (c *Configuration) GetCorrectionPrompt() string {
+	return c.viper.GetString("correction.prompt")
+}
+
+// GetCorrectionConfidenceThreshold returns the confidence below which a buffered context is corrected
+func (c *Configuration) GetCorrectionConfidenceThreshold() float64 {
+	if c.viper.IsSet("correction.confidence_threshold") {
+		return c.viper.GetFloat64("correction.confidence_threshold")
+	}
+	return 0.6
+}
+
+// SetCorrectionConfidenceThreshold sets the confidence below which a buffered context is corrected
+func (c *Configuration) SetCorrectionConfidenceThreshold(threshold float64) {
+	c.viper.Set("correction.confidence_threshold", threshold)
+}
+
+// GetCorrectionTimeoutMS returns how long a correction may take before the uncorrected text is used
+func (c *Configuration) GetCorrectionTimeoutMS() int {
+	if c.viper.IsSet("correction.timeout_ms") {
+		return c.viper.GetInt("correction.timeout_ms")
+	}
+	return 5000
+}
+
+// GetCorrectionMaxRequestsPerMinute returns how many corrections may be requested per minute (0 for no limit)
+func (c *Configuration) GetCorrectionMaxRequestsPerMinute() int {
+	if c.viper.IsSet("correction.max_requests_per_minute") {
+		return c.viper.GetInt("correction.max_requests_per_minute")
+	}
+	return 10
+}
+
+// GetCorrectionDailyTokenBudget returns how many LLM tokens corrections may use per day (0 for no limit)
+func (c *Configuration) GetCorrectionDailyTokenBudget() int {
+	if c.viper.IsSet("correction.daily_token_budget") {
+		return c.viper.GetInt("correction.daily_token_budget")
+	}
+	return 50000
+}
+
+// SetCorrectionDailyTokenBudget sets how many LLM tokens corrections may use per day
+func (c *Configuration) SetCorrectionDailyTokenBudget(budget int) {
+	c.viper.Set("correction.daily_token_budget", budget)
+}
+
+// Ad Break Detection Methods
+
+// GetAdDetectionEnabled returns whether commercial breaks are detected so contest mentions in ads
+// are not reported like on-air contests
+func (c *Configuration) GetAdDetectionEnabled() bool {
+	return c.viper.GetBool("ad_detection.enabled")
+}
+
+// SetAdDetectionEnabled sets whether commercial breaks are detected
+func (c *Configuration) SetAdDetectionEnabled(enabled bool) {
+	c.viper.Set("ad_detection.enabled", enabled)
+}
+
+// GetAdDetectionAction returns what happens to cues heard during a commercial break: suppress
+// drops them, downrank logs them flagged as heard during a break without notifying
+func (c *Configuration) GetAdDetectionAction() string {
+	if action := strings.ToLower(strings.TrimSpace(c.viper.GetString("ad_detection.action"))); action != "" {
+		return action
+	}
+	return "downrank"
+}
+
+// SetAdDetectionAction sets what happens to cues heard during a commercial break
+func (c *Configuration) SetAdDetectionAction(action string) {
+	c.viper.Set("ad_detection.action", action)
+}
+
+// GetAdDetectionAdPhrases returns the phrases marking transcribed audio as a commercial, or nil
+// for the built-in list
+func (c *Configuration) GetAdDetectionAdPhrases() []string {
+	return c.phraseList("ad_detection.ad_phrases")
+}
+
+// SetAdDetectionAdPhrases sets the phrases marking transcribed audio as a commercial
+func (c *Configuration) SetAdDetectionAdPhrases(phrases []string) {
+	c.viper.Set("ad_detection.ad_phrases", phrases)
+}
+
+// GetAdDetectionProgramPhrases returns the station IDs, jingles, and presenter phrases marking the
+// end of a commercial break
+func (c *Configuration) GetAdDetectionProgramPhrases() []string {
+	return c.phraseList("ad_detection.program_phrases")
+}
+
+// SetAdDetectionProgramPhrases sets the phrases marking the end of a commercial break
+func (c *Configuration) SetAdDetectionProgramPhrases(phrases []string) {
+	c.viper.Set("ad_detection.program_phrases", phrases)
+}
+
+// phraseList returns a list of phrases, or nil when key is unset. A plain string comes from a
+// comma-separated environment variable.
+func (c *Configuration) phraseList(key string) []string {
+	if !c.viper.IsSet(key) {
+		return nil
+	}
+	var phrases []string
+	if raw, ok := c.viper.Get(key).(string); ok {
+		phrases = strings.Split(raw, ",")
+	} else {
+		phrases = c.viper.GetStringSlice(key)
+	}
+	result := []string{}
+	for _, phrase := range phrases {
+		if trimmed := strings.TrimSpace(phrase); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
+// GetAdDetectionHoldSec returns how long a break lasts after the last ad phrase was heard
+func (c *Configuration) GetAdDetectionHoldSec() int {
+	if c.viper.IsSet("ad_detection.hold_sec") {
+		return c.viper.GetInt("ad_detection.hold_sec")
+	}
+	return 90
+}
+
+// GetAdDetectionLoudnessDBFS returns the mean level at or above which steady audio is treated as
+// a commercial; 0 disables loudness detection
+func (c *Configuration) GetAdDetectionLoudnessDBFS() float64 {
+	return c.viper.GetFloat64("ad_detection.loudness_dbfs")
+}
+
+// SetAdDetectionLoudnessDBFS sets the mean level at or above which steady audio is treated as a commercial
+func (c *Configuration) SetAdDetectionLoudnessDBFS(dbfs float64) {
+	c.viper.Set("ad_detection.loudness_dbfs", dbfs)
+}
+
+// GetAdDetectionLoudnessRangeDB returns the largest level spread still counted as steady ad audio
+func (c *Configuration) GetAdDetectionLoudnessRangeDB() float64 {
+	if c.viper.IsSet("ad_detection.loudness_range_db") {
+		return c.viper.GetFloat64("ad_detection.loudness_range_db")
+	}
+	return 3
+}
+
+// GetAdDetectionLoudnessWindowSec returns how long the loudness signature must hold to mark a break
+func (c *Configuration) GetAdDetectionLoudnessWindowSec() int {
+	if c.viper.IsSet("ad_detection.loudness_window_sec") {
+		return c.viper.GetInt("ad_detection.loudness_window_sec")
+	}
+	return 20
+}
+
+// Calendar Export Configuration Methods
+
+// GetCalendarEnabled reports whether contest sessions are exported as calendar events, which
+// happens when an ICS file or a CalDAV calendar is configured
+func (c *Configuration) GetCalendarEnabled() bool {
+	return c.GetCalendarICSPath() != "" || c.GetCalendarCalDAVURL() != ""
+}
+
+// GetCalendarICSPath returns the ICS file contest sessions are written to; empty writes no file
+func (c *Configuration) GetCalendarICSPath() string {
+	return c.viper.GetString("calendar.ics_path")
+}
+
+// SetCalendarICSPath sets the ICS file contest sessions are written to
+func (c *Configuration) SetCalendarICSPath(path string) {
+	c.viper.Set("calendar.ics_path", path)
+}
+
+// GetCalendarCalDAVURL returns the CalDAV calendar collection events are pushed to; empty pushes nothing
+func (c *Configuration) GetCalendarCalDAVURL() string {
+	return c.viper.GetString("calendar.caldav.url")
+}
+
+// SetCalendarCalDAVURL sets the CalDAV calendar collection events are pushed to
+func (c *Configuration) SetCalendarCalDAVURL(url string) {
+	c.viper.Set("calendar.caldav.url", url)
+}
+
+// GetCalendarCalDAVUsername returns the CalDAV basic auth username
+func (c *Configuration) GetCalendarCalDAVUsername() string {
+	return c.viper.GetString("calendar.caldav.username")
+}
+
+// GetCalendarCalDAVPassword returns the CalDAV basic auth password
+func (c *Configuration) GetCalendarCalDAVPassword() string {
+	return c.viper.GetString("calendar.caldav.password")
+}
+
+// GetCalendarDefaultWindowMin returns the entry window in minutes assumed when no deadline is heard
+func (c *Configuration) GetCalendarDefaultWindowMin() int {
+	if c.viper.IsSet("calendar.default_window_min") {
+		return c.viper.GetInt("calendar.default_window_min")
+	}
+	return 15
+}
+
+// GetCalendarReminderMin returns how many minutes before a deadline events alert; 0 adds no alarm
+func (c *Configuration) GetCalendarReminderMin() int {
+	if c.viper.IsSet("calendar.reminder_min") {
+		return c.viper.GetInt("calendar.reminder_min")
+	}
+	return 5
+}
+
+// GetCalendarRetentionDays returns how many days after their deadline events stay in the ICS file
+func (c *Configuration) GetCalendarRetentionDays() int {
+	if c.viper.IsSet("calendar.retention_days") {
+		return c.viper.GetInt("calendar.retention_days")
+	}
+	return 7
+}
+
+// Usage Accounting Configuration Methods
+
+// GetUsagePath returns the file recording the audio each transcription backend transcribes per
+// day; empty keeps the totals in memory only
+func (c *Configuration) GetUsagePath() string {
+	if c.viper.IsSet("usage.path") {
+		return c.viper.GetString("usage.path")
+	}
+	return "./data/usage.json"
+}
+
+// SetUsagePath sets the file recording transcription usage
+func (c *Configuration) SetUsagePath(path string) {
+	c.viper.Set("usage.path", path)
+}
+
+// Archive Configuration Methods
+
+// GetArchiveEnabled returns whether transcripts, cue logs, and audio snippets are exported to
+// object storage nightly
+func (c *Configuration) GetArchiveEnabled() bool {
+	return c.viper.GetBool("archive.enabled")
+}
+
+// SetArchiveEnabled sets whether the nightly archive export runs
+func (c *Configuration) SetArchiveEnabled(enabled bool) {
+	c.viper.Set("archive.enabled", enabled)
+}
+
+// GetArchiveEndpoint returns the S3-compatible endpoint archives are uploaded to, e.g.
+// https://storage.googleapis.com or http://minio:9000; empty uses AWS S3 in the archive region
+func (c *Configuration) GetArchiveEndpoint() string {
+	if endpoint := c.viper.GetString("archive.endpoint"); endpoint != "" {
+		return endpoint
+	}
+	return "https://s3." + c.GetArchiveRegion() + ".amazonaws.com"
+}
+
+// GetArchiveRegion returns the region archive requests are signed for
+func (c *Configuration) GetArchiveRegion() string {
+	if region := c.viper.GetString("archive.region"); region != "" {
+		return region
+	}
+	return "us-east-1"
+}
+
+// GetArchiveBucket returns the bucket archives are uploaded to
+func (c *Configuration) GetArchiveBucket() string {
+	return c.viper.GetString("archive.bucket")
+}
+
+// SetArchiveBucket sets the bucket archives are uploaded to
+func (c *Configuration) SetArchiveBucket(bucket string) {
+	c.viper.Set("archive.bucket", bucket)
+}
+
+// GetArchivePrefix returns the key prefix archived objects are stored under
+func (c *Configuration) GetArchivePrefix() string {
+	if c.viper.IsSet("archive.prefix") {
+		return c.viper.GetString("archive.prefix")
+	}
+	return "radiocontestwinner"
+}
+
+// SetArchivePrefix sets the key prefix archived objects are stored under
+func (c *Configuration) SetArchivePrefix(prefix string) {
+	c.viper.Set("archive.prefix", prefix)
+}
+
+// GetArchiveAccessKeyID returns the access key ID (an HMAC key for Google Cloud Storage)
+func (c *Configuration) GetArchiveAccessKeyID() string {
+	return c.viper.GetString("archive.access_key_id")
+}
+
+// GetArchiveSecretAccessKey returns the secret for the archive access key
+func (c *Configuration) GetArchiveSecretAccessKey() string {
+	return c.viper.GetString("archive.secret_access_key")
+}
+
+// GetArchiveDailyAt returns the time of day the archive export runs as "HH:MM"
+func (c *Configuration) GetArchiveDailyAt() string {
+	if c.viper.IsSet("archive.daily_at") {
+		return c.viper.GetString("archive.daily_at")
+	}
+	return "03:00"
+}
+
+// GetArchiveTimezone returns the IANA time zone the export is scheduled and dated in (empty uses
+// the local zone)
+func (c *Configuration) GetArchiveTimezone() string {
+	return c.viper.GetString("archive.timezone")
+}
+
+// GetArchiveRetentionDays returns how many days archived objects are kept before the export
+// deletes them; 0 keeps them forever
+func (c *Configuration) GetArchiveRetentionDays() int {
+	return c.viper.GetInt("archive.retention_days")
+}
+
+// SetArchiveRetentionDays sets how many days archived objects are kept
+func (c *Configuration) SetArchiveRetentionDays(days int) {
+	c.viper.Set("archive.retention_days", days)
+}
+
+// GetArchiveAudioDir returns the directory of saved audio snippets to archive; empty archives none
+func (c *Configuration) GetArchiveAudioDir() string {
+	return c.viper.GetString("archive.audio_dir")
+}
+
+// SetArchiveAudioDir sets the directory of saved audio snippets to archive
+func (c *Configuration) SetArchiveAudioDir(dir string) {
+	c.viper.Set("archive.audio_dir", dir)
+}
+
+// GetArchiveStatePath returns the file recording which files were already archived
+func (c *Configuration) GetArchiveStatePath() string {
+	if c.viper.IsSet("archive.state_path") {
+		return c.viper.GetString("archive.state_path")
+	}
+	return "./data/archive_state.json"
+}
+
+// SetArchiveStatePath sets the file recording which files were already archived
+func (c *Configuration) SetArchiveStatePath(path string) {
+	c.viper.Set("archive.state_path", path)
+}
+
+// API Configuration Methods
+
+// GetAPIEnabled returns whether the running instance serves its API, which `tail` connects to
+func (c *Configuration) GetAPIEnabled() bool {
+	return c.viper.GetBool("api.enabled")
+}
+
+// SetAPIEnabled sets whether the API is served
+func (c *Configuration) SetAPIEnabled(enabled bool) {
+	c.viper.Set("api.enabled", enabled)
+}
+
+// GetAPIAddress returns where the API listens: host:port, or unix:/path for a unix socket
+func (c *Configuration) GetAPIAddress() string {
+	if address := c.viper.GetString("api.address"); address != "" {
+		return address
+	}
+	return "unix:/tmp/radiocontestwinner.sock"
+}
+
+// SetAPIAddress sets where the API listens
+func (c *Configuration) SetAPIAddress(address string) {
+	c.viper.Set("api.address", address)
+}
+
+// GetAPIPprof returns whether the API serves Go runtime profiles under /debug/pprof/
+func (c *Configuration) GetAPIPprof() bool {
+	return c.viper.GetBool("api.pprof")
+}
+
+// SetAPIPprof sets whether the API serves runtime profiles
+func (c *Configuration) SetAPIPprof(enabled bool) {
+	c.viper.Set("api.pprof", enabled)
+}
+
+// GetAPIToken returns the bearer token every API request must carry; empty leaves the API open
+func (c *Configuration) GetAPIToken() string {
+	return c.viper.GetString("api.token")
+}
+
+// SetAPIToken sets the bearer token API requests must carry
+func (c *Configuration) SetAPIToken(token string) {
+	c.viper.Set("api.token", token)
+}
+
+// ValidateAPI returns an error when the API would serve transcripts and cues without a token on
+// a TCP address other hosts can reach, or runtime profiles (which include the command line)
+// without a token on any TCP address, so an exposed instance fails at startup instead
+func (c *Configuration) ValidateAPI() error {
+	if !c.GetAPIEnabled() || c.GetAPIToken() != "" {
+		return nil
+	}
+	address := c.GetAPIAddress()
+	if strings.HasPrefix(address, "unix:") {
+		return nil
+	}
+	if c.GetAPIPprof() {
+		return fmt.Errorf("api.pprof serves the command line and memory of the process; set api.token (env: API_TOKEN) or listen on a unix socket")
+	}
+	if !isLoopbackAddress(address) {
+		return fmt.Errorf("api.address %s accepts connections from other hosts; set api.token (env: API_TOKEN) or listen on a loopback address or unix socket", address)
+	}
+	return nil
+}
+
+// isLoopbackAddress reports whether a host:port address only accepts local connections
+func isLoopbackAddress(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Feature Flag Configuration Methods
+
+// GetFeatureFlags returns the feature flags set for this deployment: true, false, or the
+// percentage of instances to enable the flag on. The FEATURE_FLAGS environment variable sets
+// them as a comma-separated list such as "auto_tune=true,vad=25".
+func (c *Configuration) GetFeatureFlags() map[string]interface{} {
+	if raw, ok := c.viper.Get("features.flags").(string); ok {
+		flags := map[string]interface{}{}
+		for _, entry := range strings.Split(raw, ",") {
+			name, value, _ := strings.Cut(entry, "=")
+			if name = strings.TrimSpace(name); name != "" {
+				flags[name] = strings.TrimSpace(value)
+			}
+		}
+		return flags
+	}
+	return c.viper.GetStringMap("features.flags")
+}
+
+// SetFeatureFlags sets the feature flags of this deployment
+func (c *Configuration) SetFeatureFlags(flags map[string]interface{}) {
+	c.viper.Set("features.flags", flags)
+}
+
+// GetFeaturesURL returns the URL of a JSON document of feature flags overriding features.flags
+// (empty disables remote flags)
+func (c *Configuration) GetFeaturesURL() string {
+	return c.viper.GetString("features.url")
+}
+
+// SetFeaturesURL sets the URL of the remote feature flags document
+func (c *Configuration) SetFeaturesURL(url string) {
+	c.viper.Set("features.url", url)
+}
+
+// GetFeaturesRefreshIntervalSec returns how often the remote feature flags are fetched again
+func (c *Configuration) GetFeaturesRefreshIntervalSec() int {
+	if c.viper.IsSet("features.refresh_interval_sec") {
+		return c.viper.GetInt("features.refresh_interval_sec")
+	}
+	return 300
+}
+
+// Scrub Configuration Methods
+
+// GetScrubEnabled returns whether phone numbers, names, and profanity are masked in stored
+// transcriptions and outbound cues and notifications
+func (c *Configuration) GetScrubEnabled() bool {
+	return c.viper.GetBool("scrub.enabled")
+}
+
+// SetScrubEnabled enables or disables scrubbing
+func (c *Configuration) SetScrubEnabled(enabled bool) {
+	c.viper.Set("scrub.enabled", enabled)
+}
+
+// GetScrubPhoneNumbers returns whether phone numbers other than the matched contest number are masked
+func (c *Configuration) GetScrubPhoneNumbers() bool {
+	if c.viper.IsSet("scrub.phone_numbers") {
+		return c.viper.GetBool("scrub.phone_numbers")
+	}
+	return true
+}
+
+// GetScrubNames returns the names masked wherever they appear, e.g. regular callers
+func (c *Configuration) GetScrubNames() []string {
+	return c.viper.GetStringSlice("scrub.names")
+}
+
+// SetScrubNames sets the names masked wherever they appear
+func (c *Configuration) SetScrubNames(names []string) {
+	c.viper.Set("scrub.names", names)
+}
+
+// GetScrubIntroducedNames returns whether names given on air after phrases such as "my name is"
+// are masked
+func (c *Configuration) GetScrubIntroducedNames() bool {
+	if c.viper.IsSet("scrub.introduced_names") {
+		return c.viper.GetBool("scrub.introduced_names")
+	}
+	return true
+}
+
+// GetScrubProfanity returns whether profanity is masked
+func (c *Configuration) GetScrubProfanity() bool {
+	if c.viper.IsSet("scrub.profanity") {
+		return c.viper.GetBool("scrub.profanity")
+	}
+	return true
+}
+
+// GetScrubProfanityWords returns the profanity list (empty uses the built-in list)
+func (c *Configuration) GetScrubProfanityWords() []string {
+	return c.viper.GetStringSlice("scrub.profanity_words")
+}
+
+// SetScrubProfanityWords sets the profanity list
+func (c *Configuration) SetScrubProfanityWords(words []string) {
+	c.viper.Set("scrub.profanity_words", words)
+}
+
+// Coordination Configuration Methods
+
+// GetCoordinationMode returns how redundant instances elect the leader that sends notifications:
+// none (every instance notifies), file, or redis
+func (c *Configuration) GetCoordinationMode() string {
+	if c.viper.IsSet("coordination.mode") {
+		if mode := strings.ToLower(strings.TrimSpace(c.viper.GetString("coordination.mode"))); mode != "" {
+			return mode
+		}
+	}
+	return "none"
+}
+
+// SetCoordinationMode sets the leader election mode
+func (c *Configuration) SetCoordinationMode(mode string) {
+	c.viper.Set("coordination.mode", mode)
+}
+
+// GetCoordinationLockFile returns the lock file used by the file coordination mode
+func (c *Configuration) GetCoordinationLockFile() string {
+	if c.viper.IsSet("coordination.lock_file") {
+		return c.viper.GetString("coordination.lock_file")
+	}
+	return "./data/leader.lock"
+}
+
+// SetCoordinationLockFile sets the lock file used by the file coordination mode
+func (c *Configuration) SetCoordinationLockFile(path string) {
+	c.viper.Set("coordination.lock_file", path)
+}
+
+// GetCoordinationKey returns the Redis key holding the leader lock
+func (c *Configuration) GetCoordinationKey() string {
+	if c.viper.IsSet("coordination.key") {
+		return c.viper.GetString("coordination.key")
+	}
+	return "radiocontestwinner:leader"
+}
+
+// GetCoordinationLeaseTTLSec returns how long a leader keeps the lock without renewing it
+func (c *Configuration) GetCoordinationLeaseTTLSec() int {
+	if c.viper.IsSet("coordination.lease_ttl_sec") {
+		return c.viper.GetInt("coordination.lease_ttl_sec")
+	}
+	return 15
+}
+
+// SetCoordinationLeaseTTLSec sets how long a leader keeps the lock without renewing it
+func (c *Configuration) SetCoordinationLeaseTTLSec(seconds int) {
+	c.viper.Set("coordination.lease_ttl_sec", seconds)
+}
+
+// GetCoordinationInstanceID returns this instance's identity in the leader lock (empty uses hostname-pid)
+func (c *Configuration) GetCoordinationInstanceID() string {
+	return c.viper.GetString("coordination.instance_id")
+}
+
+// GetCoordinationStandby returns whether this instance runs as a warm standby: connected to the
+// stream with FFmpeg running but not transcribing until it takes the leader lock or is promoted
+func (c *Configuration) GetCoordinationStandby() bool {
+	return c.viper.GetBool("coordination.standby")
+}
+
+// SetCoordinationStandby sets whether this instance runs as a warm standby
+func (c *Configuration) SetCoordinationStandby(standby bool) {
+	c.viper.Set("coordination.standby", standby)
+}
+
+// Redis Configuration Methods
+
+// GetRedisAddress returns the Redis server address (redis://[:password@]host:port[/db] or host:port)
+func (c *Configuration) GetRedisAddress() string {
+	if c.viper.IsSet("redis.address") {
+		return c.viper.GetString("redis.address")
+	}
+	return "localhost:6379"
+}
+
+// SetRedisAddress sets the Redis server address
+func (c *Configuration) SetRedisAddress(address string) {
+	c.viper.Set("redis.address", address)
+}
+
+// GetRedisPassword returns the Redis password (empty disables AUTH)
+func (c *Configuration) GetRedisPassword() string {
+	return c.viper.GetString("redis.password")
+}
+
+// GetRedisDB returns the Redis database number
+func (c *Configuration) GetRedisDB() int {
+	return c.viper.GetInt("redis.db")
+}
+
+// GetRedisEnabled returns whether cues, the dedup window, and recent transcripts are shared through Redis
+func (c *Configuration) GetRedisEnabled() bool {
+	return c.viper.GetBool("redis.enabled")
+}
+
+// SetRedisEnabled sets whether cues, the dedup window, and recent transcripts are shared through Redis
+func (c *Configuration) SetRedisEnabled(enabled bool) {
+	c.viper.Set("redis.enabled", enabled)
+}
+
+// GetRedisKeyPrefix returns the prefix for every Redis key and channel this application uses
+func (c *Configuration) GetRedisKeyPrefix() string {
+	if c.viper.IsSet("redis.key_prefix") {
+		return c.viper.GetString("redis.key_prefix")
+	}
+	return "radiocontestwinner"
+}
+
+// GetRedisPublishCues returns whether notifications are published on Redis pub/sub channels
+func (c *Configuration) GetRedisPublishCues() bool {
+	if c.viper.IsSet("redis.publish_cues") {
+		return c.viper.GetBool("redis.publish_cues")
+	}
+	return true
+}
+
+// GetRedisTranscriptHistory returns how many recent transcripts are kept in Redis (0 disables)
+func (c *Configuration) GetRedisTranscriptHistory() int {
+	if c.viper.IsSet("redis.transcript_history") {
+		return c.viper.GetInt("redis.transcript_history")
+	}
+	return 200
+}
+
+// SetRedisTranscriptHistory sets how many recent transcripts are kept in Redis
+func (c *Configuration) SetRedisTranscriptHistory(count int) {
+	c.viper.Set("redis.transcript_history", count)
+}
+
+// Dedup Configuration Methods
+
+// GetDedupWindowSec returns how long a cue's content hash suppresses repeats (0 disables deduplication)
+func (c *Configuration) GetDedupWindowSec() int {
+	return c.viper.GetInt("dedup.window_sec")
+}
+
+// SetDedupWindowSec sets how long a cue's content hash suppresses repeats
+func (c *Configuration) SetDedupWindowSec(seconds int) {
+	c.viper.Set("dedup.window_sec", seconds)
+}
+
+// Notifier Configuration Methods
+
+// GetWebhookURL returns the URL notifications are posted to (empty disables the webhook notifier)
+func (c *Configuration) GetWebhookURL() string {
+	return c.viper.GetString("notifier.webhook.url")
+}
+
+// SetWebhookURL sets the URL notifications are posted to
+func (c *Configuration) SetWebhookURL(url string) {
+	c.viper.Set("notifier.webhook.url", url)
+}
+
+// GetWebhookSecret returns the shared secret used to sign webhook payloads (empty disables signing)
+func (c *Configuration) GetWebhookSecret() string {
+	return c.viper.GetString("notifier.webhook.secret")
+}
+
+// SetWebhookSecret sets the shared secret used to sign webhook payloads
+func (c *Configuration) SetWebhookSecret(secret string) {
+	c.viper.Set("notifier.webhook.secret", secret)
+}
+
+// GetWebhookTimeoutSec returns the webhook request timeout in seconds
+func (c *Configuration) GetWebhookTimeoutSec() int {
+	if c.viper.IsSet("notifier.webhook.timeout_sec") {
+		return c.viper.GetInt("notifier.webhook.timeout_sec")
+	}
+	return 10
+}
+
+// GetNotifierDesktopEnabled returns whether notifications are also shown on the desktop of the
+// machine running the binary
+func (c *Configuration) GetNotifierDesktopEnabled() bool {
+	return c.viper.GetBool("notifier.desktop.enabled")
+}
+
+// SetNotifierDesktopEnabled sets whether notifications are shown on the desktop
+func (c *Configuration) SetNotifierDesktopEnabled(enabled bool) {
+	c.viper.Set("notifier.desktop.enabled", enabled)
+}
+
+// GetNotifierDesktopClickURL returns the URL clicking a desktop notification opens, such as a
+// dashboard; {cue_id}, {keyword}, and {number} are replaced from the cue. Empty opens the cue's
+// transcript context instead.
+func (c *Configuration) GetNotifierDesktopClickURL() string {
+	return c.viper.GetString("notifier.desktop.click_url")
+}
+
+// SetNotifierDesktopClickURL sets the URL clicking a desktop notification opens
+func (c *Configuration) SetNotifierDesktopClickURL(url string) {
+	c.viper.Set("notifier.desktop.click_url", url)
+}
+
+// GetNotifierFilter returns the filter expression choosing which cues the notifier named name
+// (webhook, sheets, mqtt, redis, or desktop) receives; empty sends it every cue
+func (c *Configuration) GetNotifierFilter(name string) string {
+	return strings.TrimSpace(c.viper.GetString("notifier." + name + ".filter"))
+}
+
+// SetNotifierFilter sets the filter expression of the notifier named name
+func (c *Configuration) SetNotifierFilter(name, expr string) {
+	c.viper.Set("notifier."+name+".filter", expr)
+}
+
+// GetNotifierQueueDir returns the directory failed notification deliveries are queued in for
+// retry (empty disables the queue)
+func (c *Configuration) GetNotifierQueueDir() string {
+	if c.viper.IsSet("notifier.queue.dir") {
+		return c.viper.GetString("notifier.queue.dir")
+	}
+	return "./data/notifier_queue"
+}
+
+// SetNotifierQueueDir sets the directory failed notification deliveries are queued in
+func (c *Configuration) SetNotifierQueueDir(dir string) {
+	c.viper.Set("notifier.queue.dir", dir)
+}
+
+// GetNotifierQueueMaxAgeSec returns how long a failed delivery is retried before it is discarded
+func (c *Configuration) GetNotifierQueueMaxAgeSec() int {
+	if c.viper.IsSet("notifier.queue.max_age_sec") {
+		return c.viper.GetInt("notifier.queue.max_age_sec")
+	}
+	return 3600
+}
+
+// GetNotifierQueueRetryIntervalSec returns the delay before the first retry of a failed delivery,
+// doubled after each further failure
+func (c *Configuration) GetNotifierQueueRetryIntervalSec() int {
+	if c.viper.IsSet("notifier.queue.retry_interval_sec") {
+		return c.viper.GetInt("notifier.queue.retry_interval_sec")
+	}
+	return 30
+}
+
+// GetQuietHoursStart returns when daily quiet hours begin as "HH:MM" (empty disables quiet hours)
+func (c *Configuration) GetQuietHoursStart() string {
+	return c.viper.GetString("notifier.quiet_hours.start")
+}
+
+// GetQuietHoursEnd returns when daily quiet hours end as "HH:MM"
+func (c *Configuration) GetQuietHoursEnd() string {
+	return c.viper.GetString("notifier.quiet_hours.end")
+}
+
+// SetQuietHours sets the daily quiet hours window as "HH:MM" times
+func (c *Configuration) SetQuietHours(start, end string) {
+	c.viper.Set("notifier.quiet_hours.start", start)
+	c.viper.Set("notifier.quiet_hours.end", end)
+}
+
+// GetQuietHoursTimezone returns the IANA time zone quiet hours are in (empty uses the local zone)
+func (c *Configuration) GetQuietHoursTimezone() string {
+	return c.viper.GetString("notifier.quiet_hours.timezone")
+}
+
+// GetQuietHoursIncludeAlerts returns whether health alerts are suppressed during quiet hours too
+func (c *Configuration) GetQuietHoursIncludeAlerts() bool {
+	return c.viper.GetBool("notifier.quiet_hours.include_alerts")
+}
+
+// GetMaintenanceMode returns whether the application starts with all notifications suppressed
+func (c *Configuration) GetMaintenanceMode() bool {
+	return c.viper.GetBool("notifier.maintenance")
+}
+
+// SetMaintenanceMode sets whether the application starts with all notifications suppressed
+func (c *Configuration) SetMaintenanceMode(enabled bool) {
+	c.viper.Set("notifier.maintenance", enabled)
+}
+
+// GetDigestSchedule returns how often summary digests are sent: "hourly", "daily", or empty to disable them
+func (c *Configuration) GetDigestSchedule() string {
+	return c.viper.GetString("notifier.digest.schedule")
+}
+
+// SetDigestSchedule sets how often summary digests are sent
+func (c *Configuration) SetDigestSchedule(schedule string) {
+	c.viper.Set("notifier.digest.schedule", schedule)
+}
+
+// GetDigestDailyAt returns the time of day daily digests are sent as "HH:MM"
+func (c *Configuration) GetDigestDailyAt() string {
+	if c.viper.IsSet("notifier.digest.daily_at") {
+		return c.viper.GetString("notifier.digest.daily_at")
+	}
+	return "08:00"
+}
+
+// GetDigestTimezone returns the IANA time zone daily digests are scheduled in (empty uses the local zone)
+func (c *Configuration) GetDigestTimezone() string {
+	return c.viper.GetString("notifier.digest.timezone")
+}
+
+// GetDigestChannel returns the notifier digests are sent to, e.g. "webhook" (empty sends them to every notifier)
+func (c *Configuration) GetDigestChannel() string {
+	return c.viper.GetString("notifier.digest.channel")
+}
+
+// SetDigestChannel sets the notifier digests are sent to
+func (c *Configuration) SetDigestChannel(channel string) {
+	c.viper.Set("notifier.digest.channel", channel)
+}
+
+// GetSheetsSpreadsheetID returns the Google Sheet cues are appended to (empty disables the sheets notifier)
+func (c *Configuration) GetSheetsSpreadsheetID() string {
+	return c.viper.GetString("notifier.sheets.spreadsheet_id")
+}
+
+// SetSheetsSpreadsheetID sets the Google Sheet cues are appended to
+func (c *Configuration) SetSheetsSpreadsheetID(id string) {
+	c.viper.Set("notifier.sheets.spreadsheet_id", id)
+}
+
+// GetSheetsSheetName returns the worksheet (tab) name rows are appended to
+func (c *Configuration) GetSheetsSheetName() string {
+	if c.viper.IsSet("notifier.sheets.sheet_name") {
+		return c.viper.GetString("notifier.sheets.sheet_name")
+	}
+	return "Sheet1"
+}
+
+// GetSheetsCredentialsFile returns the path of the Google service-account JSON key
+func (c *Configuration) GetSheetsCredentialsFile() string {
+	return c.viper.GetString("notifier.sheets.credentials_file")
+}
+
+// SetSheetsCredentialsFile sets the path of the Google service-account JSON key
+func (c *Configuration) SetSheetsCredentialsFile(path string) {
+	c.viper.Set("notifier.sheets.credentials_file", path)
+}
+
+// GetMQTTBroker returns the MQTT broker address (empty disables the MQTT notifier)
+func (c *Configuration) GetMQTTBroker() string {
+	return c.viper.GetString("notifier.mqtt.broker")
+}
+
+// SetMQTTBroker sets the MQTT broker address
+func (c *Configuration) SetMQTTBroker(broker string) {
+	c.viper.Set("notifier.mqtt.broker", broker)
+}
+
+// GetMQTTClientID returns the MQTT client ID, also used as the Home Assistant device identifier
+func (c *Configuration) GetMQTTClientID() string {
+	if c.viper.IsSet("notifier.mqtt.client_id") {
+		return c.viper.GetString("notifier.mqtt.client_id")
+	}
+	return "radiocontestwinner"
+}
+
+// GetMQTTUsername returns the MQTT username
+func (c *Configuration) GetMQTTUsername() string {
+	return c.viper.GetString("notifier.mqtt.username")
+}
+
+// GetMQTTPassword returns the MQTT password
+func (c *Configuration) GetMQTTPassword() string {
+	return c.viper.GetString("notifier.mqtt.password")
+}
+
+// GetMQTTTopicPrefix returns the base topic for cues, alerts, and status
+func (c *Configuration) GetMQTTTopicPrefix() string {
+	if c.viper.IsSet("notifier.mqtt.topic_prefix") {
+		return c.viper.GetString("notifier.mqtt.topic_prefix")
+	}
+	return "radiocontestwinner"
+}
+
+// GetMQTTHomeAssistantDiscovery returns whether Home Assistant MQTT discovery messages are published
+func (c *Configuration) GetMQTTHomeAssistantDiscovery() bool {
+	if c.viper.IsSet("notifier.mqtt.homeassistant.discovery") {
+		return c.viper.GetBool("notifier.mqtt.homeassistant.discovery")
+	}
+	return true
+}
+
+// GetMQTTHomeAssistantDiscoveryPrefix returns the Home Assistant discovery topic prefix
+func (c *Configuration) GetMQTTHomeAssistantDiscoveryPrefix() string {
+	if c.viper.IsSet("notifier.mqtt.homeassistant.discovery_prefix") {
+		return c.viper.GetString("notifier.mqtt.homeassistant.discovery_prefix")
+	}
+	return "homeassistant"
+}
+
+// Restart Policy Methods
+// Policies are configured per pipeline component under restart.<component> (stream, ffmpeg, transcription)
+
+// GetRestartMaxRestarts returns the consecutive restarts allowed for a component before giving up
+func (c *Configuration) GetRestartMaxRestarts(component string) int {
+	key := "restart." + component + ".max_restarts"
+	if c.viper.IsSet(key) {
+		return c.viper.GetInt(key)
+	}
+	return 5
+}
+
+// GetRestartBackoffMS returns the initial restart delay in milliseconds for a component
+func (c *Configuration) GetRestartBackoffMS(component string) int {
+	key := "restart." + component + ".backoff_ms"
+	if c.viper.IsSet(key) {
+		return c.viper.GetInt(key)
+	}
+	return 1000
+}
+
+// GetRestartMaxBackoffMS returns the maximum restart delay in milliseconds for a component
+func (c *Configuration) GetRestartMaxBackoffMS(component string) int {
+	key := "restart." + component + ".max_backoff_ms"
+	if c.viper.IsSet(key) {
+		return c.viper.GetInt(key)
+	}
+	return 30000
+}
+
+// GetRestartResetAfterSec returns how long a component must run without failing before its restart count resets
+func (c *Configuration) GetRestartResetAfterSec(component string) int {
+	key := "restart." + component + ".reset_after_sec"
+	if c.viper.IsSet(key) {
+		return c.viper.GetInt(key)
+	}
+	return 600
+}
+
+// GetRestartEscalate returns whether exhausting a component's restarts escalates to a full application restart
+func (c *Configuration) GetRestartEscalate(component string) bool {
+	key := "restart." + component + ".escalate"
+	if c.viper.IsSet(key) {
+		return c.viper.GetBool(key)
+	}
+	return true
+}
+
+// GetRestartJitter returns the fraction (0 to 1) by which every component restart and stream
+// reconnect delay is randomized either way, so instances that failed together do not retry together
+func (c *Configuration) GetRestartJitter() float64 {
+	if c.viper.IsSet("restart.jitter") {
+		return c.viper.GetFloat64("restart.jitter")
+	}
+	return 0.2
+}
+
+// SetRestartJitter sets the fraction by which restart and reconnect delays are randomized
+func (c *Configuration) SetRestartJitter(jitter float64) {
+	c.viper.Set("restart.jitter", jitter)
+}
@@ -3,9 +3,12 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConfiguration_GetStreamURL(t *testing.T) {
@@ -533,6 +536,142 @@ func TestConfiguration_GetAllowlist(t *testing.T) {
 	})
 }
 
+func TestConfiguration_GetAllowlistEntries(t *testing.T) {
+	t.Run("should read labeled entries with the config file as their source", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		configFile := filepath.Join(tmpDir, "config.yaml")
+		configContent := `allowlist:
+  numbers:
+    - "73"
+    - number: "72881"
+      label: Morning cash
+    - 55*
+`
+		require.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
+		modified := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+		require.NoError(t, os.Chtimes(configFile, modified, modified))
+
+		// Act
+		cfg, err := NewConfigurationFromFile(configFile)
+
+		// Assert
+		require.NoError(t, err)
+		entries := cfg.GetAllowlistEntries()
+		require.Len(t, entries, 3)
+		assert.Equal(t, "72881", entries[1].Number)
+		assert.Equal(t, "Morning cash", entries[1].Label)
+		for _, entry := range entries {
+			assert.Equal(t, AllowlistSourceFile, entry.Source)
+			assert.True(t, modified.Equal(entry.Modified))
+		}
+		assert.Equal(t, []string{"73", "72881", "55*"}, cfg.GetAllowlist())
+	})
+
+	t.Run("should read active windows of labeled entries", func(t *testing.T) {
+		// Arrange
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		configContent := `allowlist:
+  numbers:
+    - number: "72881"
+      label: Morning cash
+      days: [weekdays]
+      start: "06:00"
+      end: "10:00"
+    - number: "55555"
+      days: sat, sun
+`
+		require.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
+
+		// Act
+		cfg, err := NewConfigurationFromFile(configFile)
+
+		// Assert
+		require.NoError(t, err)
+		entries := cfg.GetAllowlistEntries()
+		require.Len(t, entries, 2)
+		assert.Equal(t, []string{"weekdays"}, entries[0].Days)
+		assert.Equal(t, "06:00", entries[0].Start)
+		assert.Equal(t, "10:00", entries[0].End)
+		assert.Equal(t, []string{"sat", "sun"}, entries[1].Days)
+		assert.Empty(t, entries[1].Start)
+	})
+
+	t.Run("should read expiry dates whether quoted or not", func(t *testing.T) {
+		// Arrange
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		configContent := `allowlist:
+  numbers:
+    - number: "72881"
+      expires: 2026-11-30
+    - number: "55555"
+      expires: "2026-12-31"
+    - "73"
+`
+		require.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
+
+		// Act
+		cfg, err := NewConfigurationFromFile(configFile)
+
+		// Assert
+		require.NoError(t, err)
+		entries := cfg.GetAllowlistEntries()
+		require.Len(t, entries, 3)
+		assert.Equal(t, "2026-11-30", entries[0].Expires)
+		assert.Equal(t, "2026-12-31", entries[1].Expires)
+		assert.Empty(t, entries[2].Expires)
+	})
+
+	t.Run("should record the environment as the source of ALLOWLIST_NUMBERS", func(t *testing.T) {
+		t.Setenv("ALLOWLIST_NUMBERS", "73,146")
+
+		cfg, err := NewConfigurationFromEnv()
+
+		require.NoError(t, err)
+		assert.Equal(t, []AllowlistEntry{
+			{Number: "73", Source: AllowlistSourceEnv},
+			{Number: "146", Source: AllowlistSourceEnv},
+		}, cfg.GetAllowlistEntries())
+	})
+
+	t.Run("should record entries set at runtime as set through the API", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		cfg.SetAllowlistEntries([]AllowlistEntry{{Number: "99999", Label: "Weekend", Days: []string{"weekends"}, Expires: "2026-12-31"}})
+
+		entries := cfg.GetAllowlistEntries()
+		require.Len(t, entries, 1)
+		assert.Equal(t, "Weekend", entries[0].Label)
+		assert.Equal(t, []string{"weekends"}, entries[0].Days)
+		assert.Equal(t, "2026-12-31", entries[0].Expires)
+		assert.Equal(t, AllowlistSourceAPI, entries[0].Source)
+		assert.WithinDuration(t, time.Now(), entries[0].Modified, time.Minute)
+	})
+}
+
+func TestConfiguration_AllowlistExpiry(t *testing.T) {
+	t.Run("should warn two weeks ahead without notifying by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, 14, cfg.GetAllowlistExpiryWarnDays())
+		assert.False(t, cfg.GetAllowlistExpiryNotify())
+	})
+
+	t.Run("should read the warning settings from the environment", func(t *testing.T) {
+		// Arrange
+		t.Setenv("ALLOWLIST_EXPIRY_WARN_DAYS", "30")
+		t.Setenv("ALLOWLIST_EXPIRY_NOTIFY", "true")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 30, cfg.GetAllowlistExpiryWarnDays())
+		assert.True(t, cfg.GetAllowlistExpiryNotify())
+	})
+}
+
 func TestConfiguration_GetTranscriptionChunkDurationSec(t *testing.T) {
 	t.Run("should return default transcription chunk duration", func(t *testing.T) {
 		// Arrange
@@ -943,3 +1082,2223 @@ func TestConfiguration_EnvironmentVariableMappingEdgeCases(t *testing.T) {
 		assert.Equal(t, 2500, duration) // Default value since RADIO_ prefix doesn't apply to explicitly bound vars
 	})
 }
+
+func TestConfiguration_KeywordFilter(t *testing.T) {
+	t.Run("should return default keyword filter settings", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, 2, cfg.GetKeywordMinLength())
+		assert.Equal(t, 0, cfg.GetKeywordMaxLength())
+		assert.Nil(t, cfg.GetKeywordStopWords(), "should fall back to the parser's default stop words")
+	})
+
+	t.Run("should return configured keyword filter settings", func(t *testing.T) {
+		cfg := NewConfiguration()
+		cfg.SetKeywordMinLength(3)
+		cfg.SetKeywordMaxLength(12)
+		cfg.SetKeywordStopWords([]string{"the", "win"})
+
+		assert.Equal(t, 3, cfg.GetKeywordMinLength())
+		assert.Equal(t, 12, cfg.GetKeywordMaxLength())
+		assert.Equal(t, []string{"the", "win"}, cfg.GetKeywordStopWords())
+	})
+
+	t.Run("should load stop words from a comma-separated environment variable", func(t *testing.T) {
+		// Arrange
+		os.Setenv("KEYWORD_STOP_WORDS", "the, us ,now")
+		defer os.Unsetenv("KEYWORD_STOP_WORDS")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"the", "us", "now"}, cfg.GetKeywordStopWords())
+	})
+
+	t.Run("should map keyword variants to canonical contest names", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		assert.NoError(t, os.WriteFile(path, []byte(`
+parser:
+  keyword:
+    canonical:
+      pota: POTA Contest
+      P-O-T-A: POTA Contest
+`), 0644))
+
+		// Act
+		cfg, err := NewConfigurationFromFile(path)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"pota": "POTA Contest", "p-o-t-a": "POTA Contest"}, cfg.GetKeywordCanonical())
+	})
+
+	t.Run("should load keyword variants from the environment", func(t *testing.T) {
+		// Arrange
+		os.Setenv("PARSER_KEYWORD_CANONICAL", "pota=POTA Contest, cash = Cash Blast,broken")
+		defer os.Unsetenv("PARSER_KEYWORD_CANONICAL")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"pota": "POTA Contest", "cash": "Cash Blast"}, cfg.GetKeywordCanonical())
+	})
+}
+
+func TestConfiguration_AGC(t *testing.T) {
+	t.Run("should leave gain control off by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.False(t, cfg.GetAGCEnabled())
+		assert.Equal(t, -20.0, cfg.GetAGCTargetDBFS())
+		assert.Equal(t, 20.0, cfg.GetAGCMaxGainDB())
+	})
+
+	t.Run("should enable gain control from the environment", func(t *testing.T) {
+		os.Setenv("AGC_ENABLED", "true")
+		defer os.Unsetenv("AGC_ENABLED")
+
+		cfg, err := NewConfigurationFromEnv()
+
+		assert.NoError(t, err)
+		assert.True(t, cfg.GetAGCEnabled())
+	})
+}
+
+func TestConfiguration_AudioTee(t *testing.T) {
+	t.Run("should leave the tee off by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Empty(t, cfg.GetAudioTeeTarget())
+		assert.Equal(t, 256, cfg.GetAudioTeeBufferKB())
+	})
+
+	t.Run("should read the tee target from the environment", func(t *testing.T) {
+		os.Setenv("AUDIO_TEE_TARGET", "fifo:/tmp/pcm")
+		defer os.Unsetenv("AUDIO_TEE_TARGET")
+
+		cfg, err := NewConfigurationFromEnv()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "fifo:/tmp/pcm", cfg.GetAudioTeeTarget())
+	})
+}
+
+func TestConfiguration_ChannelHighWatermark(t *testing.T) {
+	t.Run("should return default channel watermark settings", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, 80, cfg.GetChannelHighWatermarkPct())
+		assert.Equal(t, 30, cfg.GetChannelHighWatermarkSec())
+	})
+
+	t.Run("should return configured channel watermark settings", func(t *testing.T) {
+		cfg := NewConfiguration()
+		cfg.SetChannelHighWatermarkPct(50)
+		cfg.SetChannelHighWatermarkSec(10)
+
+		assert.Equal(t, 50, cfg.GetChannelHighWatermarkPct())
+		assert.Equal(t, 10, cfg.GetChannelHighWatermarkSec())
+	})
+}
+
+func TestConfiguration_LatencySLO(t *testing.T) {
+	t.Run("should hold the 95th percentile to 30 seconds by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, 30, cfg.GetLatencySLOCueTargetSec())
+		assert.Equal(t, 95.0, cfg.GetLatencySLOPercentile())
+	})
+
+	t.Run("should load the SLO from config file", func(t *testing.T) {
+		// Arrange
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		configContent := `latency_slo:
+  cue_target_sec: 20
+  percentile: 99`
+		require.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
+
+		// Act
+		cfg, err := NewConfigurationFromFile(configFile)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 20, cfg.GetLatencySLOCueTargetSec())
+		assert.Equal(t, 99.0, cfg.GetLatencySLOPercentile())
+	})
+}
+
+func TestConfiguration_Escalation(t *testing.T) {
+	t.Run("should return default escalation settings", func(t *testing.T) {
+		// Arrange
+		cfg := NewConfiguration()
+
+		// Act & Assert
+		assert.True(t, cfg.GetEscalationEnabled())
+		assert.Equal(t, 600, cfg.GetEscalationWarnAfterSec())
+		assert.Equal(t, 1800, cfg.GetEscalationPageAfterSec())
+		assert.Equal(t, 1.0, cfg.GetEscalationDailyBudgetPct())
+	})
+
+	t.Run("should load escalation settings from config file", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		configFile := filepath.Join(tmpDir, "config.yaml")
+		configContent := `escalation:
+  enabled: false
+  warn_after_sec: 300
+  page_after_sec: 900
+  daily_budget_pct: 0.5`
+		err := os.WriteFile(configFile, []byte(configContent), 0644)
+		assert.NoError(t, err)
+
+		// Act
+		cfg, err := NewConfigurationFromFile(configFile)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, cfg.GetEscalationEnabled())
+		assert.Equal(t, 300, cfg.GetEscalationWarnAfterSec())
+		assert.Equal(t, 900, cfg.GetEscalationPageAfterSec())
+		assert.Equal(t, 0.5, cfg.GetEscalationDailyBudgetPct())
+	})
+}
+
+func TestConfiguration_AnomalyDetection(t *testing.T) {
+	t.Run("should return default anomaly detection settings", func(t *testing.T) {
+		// Arrange
+		cfg := NewConfiguration()
+
+		// Act & Assert
+		assert.True(t, cfg.GetAnomalyDetectionEnabled())
+		assert.Equal(t, 120, cfg.GetAnomalyWindowSec())
+		assert.Equal(t, 900, cfg.GetAnomalyBaselineWindowSec())
+		assert.Equal(t, 0.25, cfg.GetAnomalyLowRatio())
+		assert.Equal(t, 4.0, cfg.GetAnomalyHighRatio())
+		assert.Equal(t, 20.0, cfg.GetAnomalyMinBaselineWPM())
+	})
+
+	t.Run("should load anomaly detection settings from config file", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		configFile := filepath.Join(tmpDir, "config.yaml")
+		configContent := `anomaly:
+  enabled: false
+  window_sec: 60
+  baseline_window_sec: 600
+  low_ratio: 0.1
+  high_ratio: 5
+  min_baseline_wpm: 30`
+		err := os.WriteFile(configFile, []byte(configContent), 0644)
+		assert.NoError(t, err)
+
+		// Act
+		cfg, err := NewConfigurationFromFile(configFile)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, cfg.GetAnomalyDetectionEnabled())
+		assert.Equal(t, 60, cfg.GetAnomalyWindowSec())
+		assert.Equal(t, 600, cfg.GetAnomalyBaselineWindowSec())
+		assert.Equal(t, 0.1, cfg.GetAnomalyLowRatio())
+		assert.Equal(t, 5.0, cfg.GetAnomalyHighRatio())
+		assert.Equal(t, 30.0, cfg.GetAnomalyMinBaselineWPM())
+	})
+}
+
+func TestConfiguration_Normalization(t *testing.T) {
+	t.Run("should default to spelled letter reconstruction only", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, []string{"spelled_letters"}, cfg.GetNormalizationSteps())
+		assert.Nil(t, cfg.GetNormalizationHomophones())
+	})
+
+	t.Run("should load normalization chain from config file", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		configFile := filepath.Join(tmpDir, "config.yaml")
+		configContent := `parser:
+  normalization:
+    steps: ["strip_punctuation", "homophones", "number_words"]
+    homophones:
+      won: one
+      ate: eight
+`
+		assert.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
+
+		// Act
+		cfg, err := NewConfigurationFromFile(configFile)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"strip_punctuation", "homophones", "number_words"}, cfg.GetNormalizationSteps())
+		assert.Equal(t, map[string]string{"won": "one", "ate": "eight"}, cfg.GetNormalizationHomophones())
+	})
+}
+
+func TestConfiguration_Substitutions(t *testing.T) {
+	t.Run("should have no substitutions by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Empty(t, cfg.GetSubstitutions())
+		assert.Empty(t, cfg.GetSubstitutionFile())
+		assert.Equal(t, 30, cfg.GetSubstitutionReloadIntervalSec())
+	})
+
+	t.Run("should load substitutions from config file", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		configFile := filepath.Join(tmpDir, "config.yaml")
+		configContent := `parser:
+  substitutions:
+    file: "/config/substitutions.txt"
+    reload_interval_sec: 5
+    entries:
+      texting: text
+      won twenty three four: "1234"
+`
+		assert.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
+
+		// Act
+		cfg, err := NewConfigurationFromFile(configFile)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"texting": "text", "won twenty three four": "1234"}, cfg.GetSubstitutions())
+		assert.Equal(t, "/config/substitutions.txt", cfg.GetSubstitutionFile())
+		assert.Equal(t, 5, cfg.GetSubstitutionReloadIntervalSec())
+	})
+}
+
+func TestConfiguration_StreamFailover(t *testing.T) {
+	t.Run("should fall back to the single stream URL", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, []string{cfg.GetStreamURL()}, cfg.GetStreamURLs())
+		assert.Equal(t, 3, cfg.GetStreamFailoverThreshold())
+		assert.Equal(t, 300, cfg.GetStreamPrimaryProbeIntervalSec())
+		assert.Equal(t, 0, cfg.GetStreamReconnectMaxPerMinute())
+		assert.Equal(t, 0, cfg.GetStreamReconnectCooldownAfterFailures())
+		assert.Equal(t, 300, cfg.GetStreamReconnectCooldownSec())
+		assert.Equal(t, "./data/stream_reconnect.json", cfg.GetStreamReconnectStateFile())
+	})
+
+	t.Run("should load prioritized stream URLs from config file", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		configFile := filepath.Join(tmpDir, "config.yaml")
+		configContent := `stream:
+  urls:
+    - "https://primary.example.com/stream.aac"
+    - "https://backup.example.com/stream.aac"
+  failover_threshold: 2
+  primary_probe_interval_sec: 60
+`
+		assert.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
+
+		// Act
+		cfg, err := NewConfigurationFromFile(configFile)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"https://primary.example.com/stream.aac", "https://backup.example.com/stream.aac"}, cfg.GetStreamURLs())
+		assert.Equal(t, 2, cfg.GetStreamFailoverThreshold())
+		assert.Equal(t, 60, cfg.GetStreamPrimaryProbeIntervalSec())
+	})
+
+	t.Run("should parse comma-separated STREAM_URLS environment variable", func(t *testing.T) {
+		// Arrange
+		os.Setenv("STREAM_URLS", "https://primary.example.com/a.aac, https://backup.example.com/b.aac")
+		defer os.Unsetenv("STREAM_URLS")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"https://primary.example.com/a.aac", "https://backup.example.com/b.aac"}, cfg.GetStreamURLs())
+	})
+}
+
+func TestConfiguration_StreamFormatValidation(t *testing.T) {
+	t.Run("should expect AAC at any sample rate by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, "aac", cfg.GetStreamExpectedCodec())
+		assert.Equal(t, 0, cfg.GetStreamExpectedSampleRate())
+		assert.Equal(t, 8192, cfg.GetStreamFormatProbeBytes())
+	})
+
+	t.Run("should load format expectations from config file", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		configFile := filepath.Join(tmpDir, "config.yaml")
+		configContent := `stream:
+  url: "https://example.com/stream.mp3"
+  expected_codec: "mp3"
+  expected_sample_rate: 44100
+  format_probe_bytes: 16384
+`
+		assert.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
+
+		// Act
+		cfg, err := NewConfigurationFromFile(configFile)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "mp3", cfg.GetStreamExpectedCodec())
+		assert.Equal(t, 44100, cfg.GetStreamExpectedSampleRate())
+		assert.Equal(t, 16384, cfg.GetStreamFormatProbeBytes())
+	})
+
+	t.Run("should allow disabling the codec check", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		cfg.SetStreamExpectedCodec("any")
+
+		assert.Equal(t, "any", cfg.GetStreamExpectedCodec())
+	})
+}
+
+func TestConfiguration_WhisperWarmup(t *testing.T) {
+	t.Run("should warm up with built-in silence by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.True(t, cfg.GetWhisperWarmupEnabled())
+		assert.Equal(t, 120, cfg.GetWhisperWarmupTimeoutSec())
+		assert.Empty(t, cfg.GetWhisperWarmupClip())
+		assert.Empty(t, cfg.GetWhisperWarmupExpect())
+	})
+
+	t.Run("should read warm-up settings from the environment", func(t *testing.T) {
+		// Arrange
+		os.Setenv("WHISPER_WARMUP", "false")
+		os.Setenv("WHISPER_WARMUP_CLIP", "/app/warmup.wav")
+		os.Setenv("WHISPER_WARMUP_EXPECT", "text win")
+		defer os.Unsetenv("WHISPER_WARMUP")
+		defer os.Unsetenv("WHISPER_WARMUP_CLIP")
+		defer os.Unsetenv("WHISPER_WARMUP_EXPECT")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, cfg.GetWhisperWarmupEnabled())
+		assert.Equal(t, "/app/warmup.wav", cfg.GetWhisperWarmupClip())
+		assert.Equal(t, "text win", cfg.GetWhisperWarmupExpect())
+	})
+}
+
+func TestConfiguration_WhisperDownload(t *testing.T) {
+	t.Run("should have no mirrors or checksums by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Empty(t, cfg.GetWhisperDownloadMirrors())
+		assert.Empty(t, cfg.GetWhisperModelChecksums())
+	})
+
+	t.Run("should read comma-separated mirrors from the environment", func(t *testing.T) {
+		// Arrange
+		os.Setenv("WHISPER_DOWNLOAD_MIRRORS", "https://mirror.example.com/whisper/, https://models.internal")
+		defer os.Unsetenv("WHISPER_DOWNLOAD_MIRRORS")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"https://mirror.example.com/whisper", "https://models.internal"}, cfg.GetWhisperDownloadMirrors())
+	})
+
+	t.Run("should normalize checksums to lowercase", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		cfg.SetWhisperModelChecksums(map[string]string{"base.en": " ABCDEF ", "tiny": ""})
+
+		assert.Equal(t, map[string]string{"base.en": "abcdef"}, cfg.GetWhisperModelChecksums())
+	})
+
+	t.Run("should verify the model by default unless disabled in the environment", func(t *testing.T) {
+		// Arrange
+		assert.True(t, NewConfiguration().GetWhisperVerifyModel())
+		os.Setenv("WHISPER_VERIFY_MODEL", "false")
+		defer os.Unsetenv("WHISPER_VERIFY_MODEL")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, cfg.GetWhisperVerifyModel())
+	})
+
+	t.Run("should bound whisper-cli runs unless overridden in the environment", func(t *testing.T) {
+		// Arrange
+		assert.Equal(t, 300, NewConfiguration().GetWhisperBinaryTimeoutSec())
+		os.Setenv("WHISPER_BINARY_TIMEOUT_SEC", "0")
+		defer os.Unsetenv("WHISPER_BINARY_TIMEOUT_SEC")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 0, cfg.GetWhisperBinaryTimeoutSec())
+	})
+}
+
+func TestConfiguration_OfflineMode(t *testing.T) {
+	t.Run("should be disabled by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.False(t, cfg.GetOfflineMode())
+		assert.NoError(t, cfg.ValidateOfflineMode())
+	})
+
+	t.Run("should read offline mode from the environment and disable NTP checks", func(t *testing.T) {
+		// Arrange
+		os.Setenv("OFFLINE_MODE", "true")
+		os.Setenv("NTP_ENABLED", "true")
+		defer os.Unsetenv("OFFLINE_MODE")
+		defer os.Unsetenv("NTP_ENABLED")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, cfg.GetOfflineMode())
+		assert.False(t, cfg.GetNTPEnabled())
+		assert.NoError(t, cfg.ValidateOfflineMode())
+	})
+
+	t.Run("should name every integration that needs the network", func(t *testing.T) {
+		// Arrange
+		cfg := NewConfiguration()
+		cfg.SetOfflineMode(true)
+		cfg.SetWebhookURL("https://hooks.example.com/cues")
+		cfg.SetLogSinks([]LogSink{{Type: "file", Target: "/tmp/cues.log"}, {Type: "http", Target: "https://logs.example.com"}})
+		cfg.SetWhisperDownloadMirrors([]string{"https://mirror.example.com"})
+
+		// Act
+		err := cfg.ValidateOfflineMode()
+
+		// Assert
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "notifier webhook")
+		assert.Contains(t, err.Error(), "http log sink https://logs.example.com")
+		assert.Contains(t, err.Error(), "whisper model download mirrors")
+		assert.NotContains(t, err.Error(), "file log sink")
+	})
+}
+
+func TestConfiguration_Correction(t *testing.T) {
+	t.Run("should be disabled by default with conservative limits", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.False(t, cfg.GetCorrectionEnabled())
+		assert.Equal(t, "http://localhost:11434/v1/chat/completions", cfg.GetCorrectionEndpoint())
+		assert.Equal(t, 0.6, cfg.GetCorrectionConfidenceThreshold())
+		assert.Equal(t, 5000, cfg.GetCorrectionTimeoutMS())
+		assert.Equal(t, 10, cfg.GetCorrectionMaxRequestsPerMinute())
+		assert.Equal(t, 50000, cfg.GetCorrectionDailyTokenBudget())
+	})
+
+	t.Run("should read correction settings from the environment", func(t *testing.T) {
+		// Arrange
+		os.Setenv("CORRECTION_ENABLED", "true")
+		os.Setenv("CORRECTION_ENDPOINT", "https://llm.example.com/v1/chat/completions")
+		os.Setenv("CORRECTION_API_KEY", "sk-test")
+		os.Setenv("CORRECTION_DAILY_TOKEN_BUDGET", "1000")
+		defer os.Unsetenv("CORRECTION_ENABLED")
+		defer os.Unsetenv("CORRECTION_ENDPOINT")
+		defer os.Unsetenv("CORRECTION_API_KEY")
+		defer os.Unsetenv("CORRECTION_DAILY_TOKEN_BUDGET")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, cfg.GetCorrectionEnabled())
+		assert.Equal(t, "https://llm.example.com/v1/chat/completions", cfg.GetCorrectionEndpoint())
+		assert.Equal(t, "sk-test", cfg.GetCorrectionAPIKey())
+		assert.Equal(t, 1000, cfg.GetCorrectionDailyTokenBudget())
+	})
+
+	t.Run("should conflict with offline mode", func(t *testing.T) {
+		cfg := NewConfiguration()
+		cfg.SetOfflineMode(true)
+		cfg.SetCorrectionEnabled(true)
+
+		err := cfg.ValidateOfflineMode()
+
+		assert.ErrorContains(t, err, "LLM correction endpoint")
+	})
+}
+
+func TestConfiguration_NotifierFilter(t *testing.T) {
+	t.Run("should be empty by default", func(t *testing.T) {
+		assert.Empty(t, NewConfiguration().GetNotifierFilter("webhook"))
+	})
+
+	t.Run("should return each notifier's filter", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		cfg.SetNotifierFilter("mqtt", ` contest_type == "POTA" `)
+
+		assert.Equal(t, `contest_type == "POTA"`, cfg.GetNotifierFilter("mqtt"))
+		assert.Empty(t, cfg.GetNotifierFilter("webhook"))
+	})
+
+	t.Run("should read filters from the environment", func(t *testing.T) {
+		os.Setenv("NOTIFIER_SHEETS_FILTER", `details.number in ["1234"]`)
+		defer os.Unsetenv("NOTIFIER_SHEETS_FILTER")
+
+		cfg, err := NewConfigurationFromEnv()
+
+		assert.NoError(t, err)
+		assert.Equal(t, `details.number in ["1234"]`, cfg.GetNotifierFilter("sheets"))
+	})
+}
+
+func TestConfiguration_Usage(t *testing.T) {
+	t.Run("should record usage under data by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, "./data/usage.json", cfg.GetUsagePath())
+	})
+
+	t.Run("should read the usage file from the environment", func(t *testing.T) {
+		// Arrange
+		os.Setenv("USAGE_PATH", "/var/lib/rcw/usage.json")
+		defer os.Unsetenv("USAGE_PATH")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "/var/lib/rcw/usage.json", cfg.GetUsagePath())
+	})
+
+	t.Run("should allow keeping usage in memory only", func(t *testing.T) {
+		cfg := NewConfiguration()
+		cfg.SetUsagePath("")
+
+		assert.Empty(t, cfg.GetUsagePath())
+	})
+}
+
+func TestConfiguration_ParserPattern(t *testing.T) {
+	t.Run("should use the English trigger words by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, "en", cfg.GetParserPatternLanguage())
+		assert.Nil(t, cfg.GetParserPatternTextWords())
+		assert.Nil(t, cfg.GetParserPatternToWords())
+	})
+
+	t.Run("should read the language and trigger words from the environment", func(t *testing.T) {
+		// Arrange
+		os.Setenv("PARSER_PATTERN_LANGUAGE", "es")
+		os.Setenv("PARSER_PATTERN_TEXT_WORDS", "texto, envía")
+		os.Setenv("PARSER_PATTERN_TO_WORDS", "al")
+		defer os.Unsetenv("PARSER_PATTERN_LANGUAGE")
+		defer os.Unsetenv("PARSER_PATTERN_TEXT_WORDS")
+		defer os.Unsetenv("PARSER_PATTERN_TO_WORDS")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "es", cfg.GetParserPatternLanguage())
+		assert.Equal(t, []string{"texto", "envía"}, cfg.GetParserPatternTextWords())
+		assert.Equal(t, []string{"al"}, cfg.GetParserPatternToWords())
+	})
+}
+
+func TestConfiguration_ParserShadow(t *testing.T) {
+	t.Run("should disable shadow evaluation by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Empty(t, cfg.GetParserShadowConfigFile())
+		assert.Empty(t, cfg.GetParserShadowReportFile())
+	})
+
+	t.Run("should read the candidate config and report file from the environment", func(t *testing.T) {
+		// Arrange
+		os.Setenv("PARSER_SHADOW_CONFIG_FILE", "/etc/rcw/candidate.yaml")
+		os.Setenv("PARSER_SHADOW_REPORT_FILE", "/var/log/rcw/shadow.json")
+		defer os.Unsetenv("PARSER_SHADOW_CONFIG_FILE")
+		defer os.Unsetenv("PARSER_SHADOW_REPORT_FILE")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "/etc/rcw/candidate.yaml", cfg.GetParserShadowConfigFile())
+		assert.Equal(t, "/var/log/rcw/shadow.json", cfg.GetParserShadowReportFile())
+	})
+}
+
+func TestConfiguration_API(t *testing.T) {
+	t.Run("should be disabled on the default unix socket by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.False(t, cfg.GetAPIEnabled())
+		assert.Equal(t, "unix:/tmp/radiocontestwinner.sock", cfg.GetAPIAddress())
+		assert.False(t, cfg.GetAPIPprof())
+	})
+
+	t.Run("should read the API settings from the environment", func(t *testing.T) {
+		// Arrange
+		os.Setenv("API_ENABLED", "true")
+		os.Setenv("API_ADDRESS", "127.0.0.1:8089")
+		os.Setenv("API_TOKEN", "s3cret")
+		defer os.Unsetenv("API_ENABLED")
+		defer os.Unsetenv("API_ADDRESS")
+		defer os.Unsetenv("API_TOKEN")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, cfg.GetAPIEnabled())
+		assert.Equal(t, "127.0.0.1:8089", cfg.GetAPIAddress())
+		assert.Equal(t, "s3cret", cfg.GetAPIToken())
+	})
+
+	t.Run("should reject an address other hosts can reach without a token", func(t *testing.T) {
+		for _, address := range []string{"0.0.0.0:8089", ":8089", "192.168.1.10:8089", "[::]:8089"} {
+			// Arrange
+			cfg := NewConfiguration()
+			cfg.SetAPIEnabled(true)
+			cfg.SetAPIAddress(address)
+
+			// Act
+			err := cfg.ValidateAPI()
+
+			// Assert
+			assert.ErrorContains(t, err, "api.token", address)
+		}
+	})
+
+	t.Run("should reject runtime profiles on a TCP address without a token", func(t *testing.T) {
+		// Arrange
+		cfg := NewConfiguration()
+		cfg.SetAPIEnabled(true)
+		cfg.SetAPIAddress("127.0.0.1:8089")
+		cfg.SetAPIPprof(true)
+
+		// Act
+		err := cfg.ValidateAPI()
+		cfg.SetAPIAddress("unix:/tmp/rcw.sock")
+		socketErr := cfg.ValidateAPI()
+		cfg.SetAPIAddress("127.0.0.1:8089")
+		cfg.SetAPIToken("s3cret")
+		tokenErr := cfg.ValidateAPI()
+
+		// Assert
+		assert.ErrorContains(t, err, "api.pprof")
+		assert.NoError(t, socketErr)
+		assert.NoError(t, tokenErr)
+	})
+
+	t.Run("should accept a token, a loopback address, or a unix socket", func(t *testing.T) {
+		for _, address := range []string{"127.0.0.1:8089", "localhost:8089", "[::1]:8089", "unix:/tmp/rcw.sock"} {
+			cfg := NewConfiguration()
+			cfg.SetAPIEnabled(true)
+			cfg.SetAPIAddress(address)
+			assert.NoError(t, cfg.ValidateAPI(), address)
+		}
+
+		cfg := NewConfiguration()
+		cfg.SetAPIEnabled(true)
+		cfg.SetAPIAddress("0.0.0.0:8089")
+		cfg.SetAPIToken("s3cret")
+		assert.NoError(t, cfg.ValidateAPI())
+	})
+}
+
+func TestConfiguration_FeatureFlags(t *testing.T) {
+	t.Run("should have no flags and no remote document by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Empty(t, cfg.GetFeatureFlags())
+		assert.Empty(t, cfg.GetFeaturesURL())
+		assert.Equal(t, 300, cfg.GetFeaturesRefreshIntervalSec())
+	})
+
+	t.Run("should read the flags as a list from the environment", func(t *testing.T) {
+		// Arrange
+		os.Setenv("FEATURE_FLAGS", "auto_tune=true, vad=25,")
+		os.Setenv("FEATURES_URL", "https://flags.example.com/fleet.json")
+		defer os.Unsetenv("FEATURE_FLAGS")
+		defer os.Unsetenv("FEATURES_URL")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"auto_tune": "true", "vad": "25"}, cfg.GetFeatureFlags())
+		assert.Equal(t, "https://flags.example.com/fleet.json", cfg.GetFeaturesURL())
+	})
+
+	t.Run("should conflict with offline mode only when fetching remote flags", func(t *testing.T) {
+		cfg := NewConfiguration()
+		cfg.SetOfflineMode(true)
+		cfg.SetFeatureFlags(map[string]interface{}{"vad": 25})
+		assert.NoError(t, cfg.ValidateOfflineMode())
+
+		cfg.SetFeaturesURL("https://flags.example.com/fleet.json")
+
+		assert.ErrorContains(t, cfg.ValidateOfflineMode(), "remote feature flags")
+	})
+}
+
+func TestConfiguration_ProgramSchedule(t *testing.T) {
+	t.Run("should have no schedule and no confidence check by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Empty(t, cfg.GetSchedulePrograms())
+		assert.Empty(t, cfg.GetScheduleURL())
+		assert.Equal(t, 3600, cfg.GetScheduleRefreshIntervalSec())
+		assert.Zero(t, cfg.GetParserMinConfidence())
+	})
+
+	t.Run("should read programs from the config file", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		assert.NoError(t, os.WriteFile(path, []byte(`
+parser:
+  min_confidence: 0.6
+schedule:
+  programs:
+    - name: Morning Drive
+      days: [weekdays]
+      start: "06:00"
+      end: "10:00"
+      contest_heavy: true
+      confidence_boost: 0.15
+    - name: Late Night
+      days: sat, sun
+      start: "22:00"
+      end: "02:00"
+    - start: "12:00"
+      end: "13:00"
+`), 0644))
+
+		// Act
+		cfg, err := NewConfigurationFromFile(path)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 0.6, cfg.GetParserMinConfidence())
+		assert.Equal(t, []ScheduledProgram{
+			{Name: "Morning Drive", Days: []string{"weekdays"}, Start: "06:00", End: "10:00", ContestHeavy: true, ConfidenceBoost: 0.15},
+			{Name: "Late Night", Days: []string{"sat", "sun"}, Start: "22:00", End: "02:00"},
+		}, cfg.GetSchedulePrograms())
+	})
+
+	t.Run("should read the remote schedule from the environment", func(t *testing.T) {
+		// Arrange
+		os.Setenv("SCHEDULE_URL", "https://kxyz.example.com/schedule.json")
+		os.Setenv("SCHEDULE_REFRESH_INTERVAL_SEC", "900")
+		defer os.Unsetenv("SCHEDULE_URL")
+		defer os.Unsetenv("SCHEDULE_REFRESH_INTERVAL_SEC")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "https://kxyz.example.com/schedule.json", cfg.GetScheduleURL())
+		assert.Equal(t, 900, cfg.GetScheduleRefreshIntervalSec())
+	})
+
+	t.Run("should conflict with offline mode only when polling a remote schedule", func(t *testing.T) {
+		cfg := NewConfiguration()
+		cfg.SetOfflineMode(true)
+		assert.NoError(t, cfg.ValidateOfflineMode())
+
+		cfg.SetScheduleURL("https://kxyz.example.com/schedule.json")
+
+		assert.ErrorContains(t, cfg.ValidateOfflineMode(), "remote program schedule")
+	})
+}
+
+func TestConfiguration_Archive(t *testing.T) {
+	t.Run("should be disabled by default and export to AWS S3 at 3am", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.False(t, cfg.GetArchiveEnabled())
+		assert.Equal(t, "https://s3.us-east-1.amazonaws.com", cfg.GetArchiveEndpoint())
+		assert.Equal(t, "radiocontestwinner", cfg.GetArchivePrefix())
+		assert.Equal(t, "03:00", cfg.GetArchiveDailyAt())
+		assert.Equal(t, 0, cfg.GetArchiveRetentionDays())
+		assert.Equal(t, "./data/archive_state.json", cfg.GetArchiveStatePath())
+	})
+
+	t.Run("should read the bucket and credentials from the environment", func(t *testing.T) {
+		// Arrange
+		for name, value := range map[string]string{
+			"ARCHIVE_ENABLED":        "true",
+			"ARCHIVE_ENDPOINT":       "https://storage.googleapis.com",
+			"ARCHIVE_BUCKET":         "rcw-archive",
+			"ARCHIVE_RETENTION_DAYS": "90",
+			"AWS_ACCESS_KEY_ID":      "GOOG1EXAMPLE",
+			"AWS_SECRET_ACCESS_KEY":  "hmac-secret",
+		} {
+			os.Setenv(name, value)
+			defer os.Unsetenv(name)
+		}
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, cfg.GetArchiveEnabled())
+		assert.Equal(t, "https://storage.googleapis.com", cfg.GetArchiveEndpoint())
+		assert.Equal(t, "rcw-archive", cfg.GetArchiveBucket())
+		assert.Equal(t, 90, cfg.GetArchiveRetentionDays())
+		assert.Equal(t, "GOOG1EXAMPLE", cfg.GetArchiveAccessKeyID())
+		assert.Equal(t, "hmac-secret", cfg.GetArchiveSecretAccessKey())
+		assert.Equal(t, "[REDACTED]", cfg.RedactedSettings()["archive"].(map[string]interface{})["secret_access_key"])
+	})
+
+	t.Run("should sign for the configured region", func(t *testing.T) {
+		cfg := NewConfiguration()
+		cfg.viper.Set("archive.region", "eu-west-2")
+
+		assert.Equal(t, "https://s3.eu-west-2.amazonaws.com", cfg.GetArchiveEndpoint())
+	})
+
+	t.Run("should conflict with offline mode", func(t *testing.T) {
+		cfg := NewConfiguration()
+		cfg.SetOfflineMode(true)
+		cfg.SetArchiveEnabled(true)
+
+		err := cfg.ValidateOfflineMode()
+
+		assert.ErrorContains(t, err, "nightly archive export")
+	})
+}
+
+func TestConfiguration_Calendar(t *testing.T) {
+	t.Run("should be disabled by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.False(t, cfg.GetCalendarEnabled())
+		assert.Equal(t, 15, cfg.GetCalendarDefaultWindowMin())
+		assert.Equal(t, 5, cfg.GetCalendarReminderMin())
+		assert.Equal(t, 7, cfg.GetCalendarRetentionDays())
+	})
+
+	t.Run("should be enabled by an ICS file or CalDAV calendar", func(t *testing.T) {
+		withFile := NewConfiguration()
+		withFile.SetCalendarICSPath("/data/contests.ics")
+		withCalDAV := NewConfiguration()
+		withCalDAV.SetCalendarCalDAVURL("https://dav.example.com/calendars/me/contests/")
+
+		assert.True(t, withFile.GetCalendarEnabled())
+		assert.True(t, withCalDAV.GetCalendarEnabled())
+	})
+
+	t.Run("should read CalDAV settings from the environment", func(t *testing.T) {
+		// Arrange
+		os.Setenv("CALDAV_URL", "https://dav.example.com/calendars/me/contests/")
+		os.Setenv("CALDAV_USERNAME", "me")
+		os.Setenv("CALDAV_PASSWORD", "secret")
+		defer os.Unsetenv("CALDAV_URL")
+		defer os.Unsetenv("CALDAV_USERNAME")
+		defer os.Unsetenv("CALDAV_PASSWORD")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "https://dav.example.com/calendars/me/contests/", cfg.GetCalendarCalDAVURL())
+		assert.Equal(t, "me", cfg.GetCalendarCalDAVUsername())
+		assert.Equal(t, "secret", cfg.GetCalendarCalDAVPassword())
+	})
+
+	t.Run("should conflict with offline mode only when pushing to CalDAV", func(t *testing.T) {
+		cfg := NewConfiguration()
+		cfg.SetOfflineMode(true)
+		cfg.SetCalendarICSPath("/data/contests.ics")
+		assert.NoError(t, cfg.ValidateOfflineMode())
+
+		cfg.SetCalendarCalDAVURL("https://dav.example.com/calendars/me/contests/")
+
+		assert.ErrorContains(t, cfg.ValidateOfflineMode(), "CalDAV calendar")
+	})
+}
+
+func TestConfiguration_AdDetection(t *testing.T) {
+	t.Run("should be disabled by default and downrank cues", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.False(t, cfg.GetAdDetectionEnabled())
+		assert.Equal(t, "downrank", cfg.GetAdDetectionAction())
+		assert.Nil(t, cfg.GetAdDetectionAdPhrases())
+		assert.Equal(t, 90, cfg.GetAdDetectionHoldSec())
+		assert.Equal(t, 0.0, cfg.GetAdDetectionLoudnessDBFS())
+	})
+
+	t.Run("should read phrases from comma-separated environment variables", func(t *testing.T) {
+		// Arrange
+		os.Setenv("AD_DETECTION_ENABLED", "true")
+		os.Setenv("AD_DETECTION_ACTION", "Suppress")
+		os.Setenv("AD_DETECTION_AD_PHRASES", "call now, restrictions apply,")
+		os.Setenv("AD_DETECTION_PROGRAM_PHRASES", "kiss 108")
+		defer os.Unsetenv("AD_DETECTION_ENABLED")
+		defer os.Unsetenv("AD_DETECTION_ACTION")
+		defer os.Unsetenv("AD_DETECTION_AD_PHRASES")
+		defer os.Unsetenv("AD_DETECTION_PROGRAM_PHRASES")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, cfg.GetAdDetectionEnabled())
+		assert.Equal(t, "suppress", cfg.GetAdDetectionAction())
+		assert.Equal(t, []string{"call now", "restrictions apply"}, cfg.GetAdDetectionAdPhrases())
+		assert.Equal(t, []string{"kiss 108"}, cfg.GetAdDetectionProgramPhrases())
+	})
+}
+
+func TestConfiguration_NTP(t *testing.T) {
+	t.Run("should check the clock against the NTP pool by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.True(t, cfg.GetNTPEnabled())
+		assert.Equal(t, "pool.ntp.org", cfg.GetNTPServer())
+		assert.Equal(t, 900, cfg.GetNTPCheckIntervalSec())
+		assert.Equal(t, 500, cfg.GetNTPMaxOffsetMS())
+		assert.Equal(t, 5, cfg.GetNTPTimeoutSec())
+	})
+
+	t.Run("should read NTP settings from the environment", func(t *testing.T) {
+		// Arrange
+		os.Setenv("NTP_ENABLED", "false")
+		os.Setenv("NTP_SERVER", "time.example.com:123")
+		defer os.Unsetenv("NTP_ENABLED")
+		defer os.Unsetenv("NTP_SERVER")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, cfg.GetNTPEnabled())
+		assert.Equal(t, "time.example.com:123", cfg.GetNTPServer())
+	})
+}
+
+func TestConfiguration_WhisperServiceSupervision(t *testing.T) {
+	t.Run("should default to the local service endpoints", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, []string{"http://localhost:9000", "http://whisper:9000", "http://127.0.0.1:9000"}, cfg.GetWhisperServiceEndpoints())
+		assert.Equal(t, 30, cfg.GetWhisperHealthCheckIntervalSec())
+		assert.Equal(t, []string{"binary", "service", "api"}, cfg.GetWhisperBackendPriority())
+	})
+
+	t.Run("should load backend priority from config file", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		configFile := filepath.Join(tmpDir, "config.yaml")
+		configContent := `whisper:
+  backend_priority: [service, binary, api]
+`
+		assert.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
+
+		// Act
+		cfg, err := NewConfigurationFromFile(configFile)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"service", "binary", "api"}, cfg.GetWhisperBackendPriority())
+	})
+
+	t.Run("should parse comma-separated WHISPER_BACKEND_PRIORITY environment variable", func(t *testing.T) {
+		// Arrange
+		os.Setenv("WHISPER_BACKEND_PRIORITY", "Service, binary")
+		defer os.Unsetenv("WHISPER_BACKEND_PRIORITY")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"service", "binary"}, cfg.GetWhisperBackendPriority())
+	})
+
+	t.Run("should load candidate endpoints from config file", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		configFile := filepath.Join(tmpDir, "config.yaml")
+		configContent := `whisper:
+  service_endpoints:
+    - "http://gpu-box:9000/"
+    - "http://whisper:9000"
+  health_check_interval_sec: 10
+`
+		assert.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
+
+		// Act
+		cfg, err := NewConfigurationFromFile(configFile)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"http://gpu-box:9000", "http://whisper:9000"}, cfg.GetWhisperServiceEndpoints())
+		assert.Equal(t, 10, cfg.GetWhisperHealthCheckIntervalSec())
+	})
+
+	t.Run("should parse comma-separated WHISPER_SERVICE_ENDPOINTS environment variable", func(t *testing.T) {
+		// Arrange
+		os.Setenv("WHISPER_SERVICE_ENDPOINTS", "http://gpu-a:9000, http://gpu-b:9000")
+		defer os.Unsetenv("WHISPER_SERVICE_ENDPOINTS")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"http://gpu-a:9000", "http://gpu-b:9000"}, cfg.GetWhisperServiceEndpoints())
+	})
+}
+
+func TestConfiguration_WhisperServiceRequests(t *testing.T) {
+	t.Run("should default to raw uploads to /transcribe without auth", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, "/transcribe", cfg.GetWhisperServicePath())
+		assert.Equal(t, "/health", cfg.GetWhisperServiceHealthPath())
+		assert.Equal(t, "raw", cfg.GetWhisperServiceUpload())
+		assert.Equal(t, "file", cfg.GetWhisperServiceFileField())
+		assert.Empty(t, cfg.GetWhisperServiceAPIKey())
+		assert.Empty(t, cfg.GetWhisperServiceHeaders())
+	})
+
+	t.Run("should load upload and auth settings from config file", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		configFile := filepath.Join(tmpDir, "config.yaml")
+		configContent := `whisper:
+  service_path: asr
+  service_upload: Multipart
+  service_file_field: audio_file
+  service_api_key: s3cret
+  service_headers:
+    X-Api-Key: proxy-key
+`
+		assert.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
+
+		// Act
+		cfg, err := NewConfigurationFromFile(configFile)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "/asr", cfg.GetWhisperServicePath())
+		assert.Equal(t, "multipart", cfg.GetWhisperServiceUpload())
+		assert.Equal(t, "audio_file", cfg.GetWhisperServiceFileField())
+		assert.Equal(t, "s3cret", cfg.GetWhisperServiceAPIKey())
+		assert.Equal(t, map[string]string{"x-api-key": "proxy-key"}, cfg.GetWhisperServiceHeaders())
+	})
+
+	t.Run("should read the path and API key from the environment", func(t *testing.T) {
+		// Arrange
+		os.Setenv("WHISPER_SERVICE_PATH", "/inference")
+		os.Setenv("WHISPER_SERVICE_API_KEY", "s3cret")
+		defer os.Unsetenv("WHISPER_SERVICE_PATH")
+		defer os.Unsetenv("WHISPER_SERVICE_API_KEY")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "/inference", cfg.GetWhisperServicePath())
+		assert.Equal(t, "s3cret", cfg.GetWhisperServiceAPIKey())
+	})
+}
+
+func TestConfiguration_WhisperAPI(t *testing.T) {
+	t.Run("should default to OpenAI's whisper-1", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, "https://api.openai.com/v1", cfg.GetWhisperAPIBaseURL())
+		assert.Equal(t, "whisper-1", cfg.GetWhisperAPIModel())
+		assert.Equal(t, 30, cfg.GetWhisperAPITimeoutSec())
+	})
+
+	t.Run("should read the API settings from the environment", func(t *testing.T) {
+		// Arrange
+		os.Setenv("WHISPER_API_BASE_URL", "https://llm.example.com/v1/")
+		os.Setenv("WHISPER_API_MODEL", "whisper-large-v3")
+		os.Setenv("WHISPER_API_TIMEOUT_SEC", "90")
+		defer os.Unsetenv("WHISPER_API_BASE_URL")
+		defer os.Unsetenv("WHISPER_API_MODEL")
+		defer os.Unsetenv("WHISPER_API_TIMEOUT_SEC")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "https://llm.example.com/v1", cfg.GetWhisperAPIBaseURL())
+		assert.Equal(t, "whisper-large-v3", cfg.GetWhisperAPIModel())
+		assert.Equal(t, 90, cfg.GetWhisperAPITimeoutSec())
+	})
+}
+
+func TestConfiguration_TranscriptionAutoTune(t *testing.T) {
+	t.Run("should be disabled with default bounds", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.False(t, cfg.GetTranscriptionAutoTuneEnabled())
+		assert.Equal(t, 3, cfg.GetTranscriptionAutoTuneMinChunkSec())
+		assert.Equal(t, 15, cfg.GetTranscriptionAutoTuneMaxChunkSec())
+		assert.Equal(t, 5, cfg.GetTranscriptionAutoTuneEvaluationChunks())
+	})
+
+	t.Run("should load auto-tune settings from config file", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		configFile := filepath.Join(tmpDir, "config.yaml")
+		configContent := `transcription:
+  auto_tune:
+    enabled: true
+    min_chunk_duration_sec: 2
+    max_chunk_duration_sec: 8
+    evaluation_chunks: 3
+`
+		assert.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
+
+		// Act
+		cfg, err := NewConfigurationFromFile(configFile)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, cfg.GetTranscriptionAutoTuneEnabled())
+		assert.Equal(t, 2, cfg.GetTranscriptionAutoTuneMinChunkSec())
+		assert.Equal(t, 8, cfg.GetTranscriptionAutoTuneMaxChunkSec())
+		assert.Equal(t, 3, cfg.GetTranscriptionAutoTuneEvaluationChunks())
+	})
+}
+
+func TestConfiguration_TranscriptionIdle(t *testing.T) {
+	t.Run("should be disabled with default thresholds and no window", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.False(t, cfg.GetTranscriptionIdleEnabled())
+		assert.Equal(t, 900, cfg.GetTranscriptionIdleAfterSec())
+		assert.Equal(t, 120, cfg.GetTranscriptionIdleSampleIntervalSec())
+		assert.Equal(t, -50.0, cfg.GetTranscriptionIdleSilenceDBFS())
+		assert.Empty(t, cfg.GetTranscriptionIdleStart())
+		assert.Empty(t, cfg.GetTranscriptionIdleEnd())
+	})
+
+	t.Run("should load idle settings from config file", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		configFile := filepath.Join(tmpDir, "config.yaml")
+		configContent := `transcription:
+  idle:
+    enabled: true
+    after_sec: 600
+    sample_interval_sec: 60
+    silence_dbfs: -45
+    start: "00:00"
+    end: "05:30"
+`
+		assert.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
+
+		// Act
+		cfg, err := NewConfigurationFromFile(configFile)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, cfg.GetTranscriptionIdleEnabled())
+		assert.Equal(t, 600, cfg.GetTranscriptionIdleAfterSec())
+		assert.Equal(t, 60, cfg.GetTranscriptionIdleSampleIntervalSec())
+		assert.Equal(t, -45.0, cfg.GetTranscriptionIdleSilenceDBFS())
+		assert.Equal(t, "00:00", cfg.GetTranscriptionIdleStart())
+		assert.Equal(t, "05:30", cfg.GetTranscriptionIdleEnd())
+	})
+
+	t.Run("should read the idle switch and window from environment", func(t *testing.T) {
+		t.Setenv("TRANSCRIPTION_IDLE_ENABLED", "true")
+		t.Setenv("TRANSCRIPTION_IDLE_START", "23:00")
+		t.Setenv("TRANSCRIPTION_IDLE_END", "06:00")
+
+		cfg, err := NewConfigurationFromEnv()
+		assert.NoError(t, err)
+
+		assert.True(t, cfg.GetTranscriptionIdleEnabled())
+		assert.Equal(t, "23:00", cfg.GetTranscriptionIdleStart())
+		assert.Equal(t, "06:00", cfg.GetTranscriptionIdleEnd())
+	})
+}
+
+func TestConfiguration_Scrub(t *testing.T) {
+	t.Run("should be disabled, masking everything once enabled", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.False(t, cfg.GetScrubEnabled())
+		assert.True(t, cfg.GetScrubPhoneNumbers())
+		assert.True(t, cfg.GetScrubIntroducedNames())
+		assert.True(t, cfg.GetScrubProfanity())
+		assert.Empty(t, cfg.GetScrubNames())
+		assert.Empty(t, cfg.GetScrubProfanityWords())
+	})
+
+	t.Run("should load scrub settings from config file", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		configFile := filepath.Join(tmpDir, "config.yaml")
+		configContent := `scrub:
+  enabled: true
+  phone_numbers: false
+  names: ["Jane Doe"]
+  profanity_words: ["darn*"]
+`
+		require.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
+
+		// Act
+		cfg, err := NewConfigurationFromFile(configFile)
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, cfg.GetScrubEnabled())
+		assert.False(t, cfg.GetScrubPhoneNumbers())
+		assert.Equal(t, []string{"Jane Doe"}, cfg.GetScrubNames())
+		assert.Equal(t, []string{"darn*"}, cfg.GetScrubProfanityWords())
+	})
+}
+
+func TestConfiguration_TranscriptionTranslate(t *testing.T) {
+	t.Run("should transcribe English without translating by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.False(t, cfg.GetTranscriptionTranslate())
+		assert.Equal(t, "en", cfg.GetTranscriptionLanguage())
+	})
+
+	t.Run("should detect the spoken language when translating", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		cfg.SetTranscriptionTranslate(true)
+
+		assert.Equal(t, "auto", cfg.GetTranscriptionLanguage())
+	})
+
+	t.Run("should load translation settings from environment", func(t *testing.T) {
+		// Arrange
+		t.Setenv("TRANSCRIPTION_TRANSLATE", "true")
+		t.Setenv("TRANSCRIPTION_LANGUAGE", "es")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, cfg.GetTranscriptionTranslate())
+		assert.Equal(t, "es", cfg.GetTranscriptionLanguage())
+	})
+}
+
+func TestConfiguration_PipelineStages(t *testing.T) {
+	t.Run("should enable every pipeline stage by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.True(t, cfg.GetTranscriptionEnabled())
+		assert.Equal(t, "", cfg.GetTranscriptionReplayFile())
+		assert.True(t, cfg.GetParserEnabled())
+		assert.True(t, cfg.GetLogOutputEnabled())
+	})
+
+	t.Run("should load stage switches from environment", func(t *testing.T) {
+		// Arrange
+		t.Setenv("TRANSCRIPTION_ENABLED", "false")
+		t.Setenv("TRANSCRIPTION_REPLAY_FILE", "/app/logs/transcriptions_debug.log")
+		t.Setenv("PARSER_ENABLED", "false")
+		t.Setenv("LOGOUTPUT_ENABLED", "false")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, cfg.GetTranscriptionEnabled())
+		assert.Equal(t, "/app/logs/transcriptions_debug.log", cfg.GetTranscriptionReplayFile())
+		assert.False(t, cfg.GetParserEnabled())
+		assert.False(t, cfg.GetLogOutputEnabled())
+	})
+}
+
+func TestConfiguration_Webhook(t *testing.T) {
+	t.Run("should have webhook disabled by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Empty(t, cfg.GetWebhookURL())
+		assert.Empty(t, cfg.GetWebhookSecret())
+		assert.Equal(t, 10, cfg.GetWebhookTimeoutSec())
+	})
+
+	t.Run("should load webhook URL from environment variable", func(t *testing.T) {
+		// Arrange
+		os.Setenv("NOTIFIER_WEBHOOK_URL", "https://hooks.example.com/cues")
+		defer os.Unsetenv("NOTIFIER_WEBHOOK_URL")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "https://hooks.example.com/cues", cfg.GetWebhookURL())
+	})
+
+	t.Run("should load webhook secret from environment variable", func(t *testing.T) {
+		// Arrange
+		os.Setenv("NOTIFIER_WEBHOOK_SECRET", "s3cret")
+		defer os.Unsetenv("NOTIFIER_WEBHOOK_SECRET")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "s3cret", cfg.GetWebhookSecret())
+	})
+}
+
+func TestConfiguration_Sheets(t *testing.T) {
+	t.Run("should have sheets export disabled by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Empty(t, cfg.GetSheetsSpreadsheetID())
+		assert.Empty(t, cfg.GetSheetsCredentialsFile())
+		assert.Equal(t, "Sheet1", cfg.GetSheetsSheetName())
+	})
+
+	t.Run("should load sheets settings from environment variables", func(t *testing.T) {
+		// Arrange
+		os.Setenv("SHEETS_SPREADSHEET_ID", "sheet-123")
+		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "/secrets/sa.json")
+		defer os.Unsetenv("SHEETS_SPREADSHEET_ID")
+		defer os.Unsetenv("GOOGLE_APPLICATION_CREDENTIALS")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "sheet-123", cfg.GetSheetsSpreadsheetID())
+		assert.Equal(t, "/secrets/sa.json", cfg.GetSheetsCredentialsFile())
+	})
+}
+
+func TestConfiguration_MQTT(t *testing.T) {
+	t.Run("should have mqtt disabled with Home Assistant discovery defaults", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Empty(t, cfg.GetMQTTBroker())
+		assert.Equal(t, "radiocontestwinner", cfg.GetMQTTClientID())
+		assert.Equal(t, "radiocontestwinner", cfg.GetMQTTTopicPrefix())
+		assert.True(t, cfg.GetMQTTHomeAssistantDiscovery())
+		assert.Equal(t, "homeassistant", cfg.GetMQTTHomeAssistantDiscoveryPrefix())
+	})
+
+	t.Run("should load mqtt settings from config file", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		configFile := filepath.Join(tmpDir, "config.yaml")
+		configContent := `notifier:
+  mqtt:
+    broker: "tcp://broker.local:1883"
+    client_id: "rcw_kxyz"
+    topic_prefix: "radio/kxyz"
+    homeassistant:
+      discovery: false
+      discovery_prefix: "ha"
+`
+		assert.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
+
+		// Act
+		cfg, err := NewConfigurationFromFile(configFile)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "tcp://broker.local:1883", cfg.GetMQTTBroker())
+		assert.Equal(t, "rcw_kxyz", cfg.GetMQTTClientID())
+		assert.Equal(t, "radio/kxyz", cfg.GetMQTTTopicPrefix())
+		assert.False(t, cfg.GetMQTTHomeAssistantDiscovery())
+		assert.Equal(t, "ha", cfg.GetMQTTHomeAssistantDiscoveryPrefix())
+	})
+
+	t.Run("should load mqtt credentials from environment variables", func(t *testing.T) {
+		// Arrange
+		os.Setenv("MQTT_BROKER", "tcp://broker.local:1883")
+		os.Setenv("MQTT_USERNAME", "rcw")
+		os.Setenv("MQTT_PASSWORD", "secret")
+		defer os.Unsetenv("MQTT_BROKER")
+		defer os.Unsetenv("MQTT_USERNAME")
+		defer os.Unsetenv("MQTT_PASSWORD")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "tcp://broker.local:1883", cfg.GetMQTTBroker())
+		assert.Equal(t, "rcw", cfg.GetMQTTUsername())
+		assert.Equal(t, "secret", cfg.GetMQTTPassword())
+	})
+}
+
+func TestConfiguration_RestartPolicy(t *testing.T) {
+	t.Run("should return default restart policy for any component", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, 5, cfg.GetRestartMaxRestarts("ffmpeg"))
+		assert.Equal(t, 1000, cfg.GetRestartBackoffMS("ffmpeg"))
+		assert.Equal(t, 30000, cfg.GetRestartMaxBackoffMS("ffmpeg"))
+		assert.Equal(t, 600, cfg.GetRestartResetAfterSec("ffmpeg"))
+		assert.True(t, cfg.GetRestartEscalate("ffmpeg"))
+		assert.Equal(t, 0.2, cfg.GetRestartJitter())
+	})
+
+	t.Run("should load per-component restart policy from config file", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		configFile := filepath.Join(tmpDir, "config.yaml")
+		configContent := `restart:
+  stream:
+    max_restarts: 20
+    backoff_ms: 500
+    max_backoff_ms: 60000
+    reset_after_sec: 300
+    escalate: false`
+		err := os.WriteFile(configFile, []byte(configContent), 0644)
+		assert.NoError(t, err)
+
+		// Act
+		cfg, err := NewConfigurationFromFile(configFile)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 20, cfg.GetRestartMaxRestarts("stream"))
+		assert.Equal(t, 500, cfg.GetRestartBackoffMS("stream"))
+		assert.Equal(t, 60000, cfg.GetRestartMaxBackoffMS("stream"))
+		assert.Equal(t, 300, cfg.GetRestartResetAfterSec("stream"))
+		assert.False(t, cfg.GetRestartEscalate("stream"))
+		// Other components keep defaults
+		assert.Equal(t, 5, cfg.GetRestartMaxRestarts("transcription"))
+	})
+
+	t.Run("should load reconnect storm protection from environment variables", func(t *testing.T) {
+		// Arrange
+		os.Setenv("STREAM_RECONNECT_MAX_PER_MINUTE", "6")
+		os.Setenv("STREAM_RECONNECT_COOLDOWN_AFTER_FAILURES", "20")
+		os.Setenv("STREAM_RECONNECT_COOLDOWN_SEC", "900")
+		os.Setenv("RESTART_JITTER", "0.5")
+		defer os.Unsetenv("STREAM_RECONNECT_MAX_PER_MINUTE")
+		defer os.Unsetenv("STREAM_RECONNECT_COOLDOWN_AFTER_FAILURES")
+		defer os.Unsetenv("STREAM_RECONNECT_COOLDOWN_SEC")
+		defer os.Unsetenv("RESTART_JITTER")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 6, cfg.GetStreamReconnectMaxPerMinute())
+		assert.Equal(t, 20, cfg.GetStreamReconnectCooldownAfterFailures())
+		assert.Equal(t, 900, cfg.GetStreamReconnectCooldownSec())
+		assert.Equal(t, 0.5, cfg.GetRestartJitter())
+	})
+}
+
+func TestConfiguration_LogSinks(t *testing.T) {
+	t.Run("should default to a JSON file sink at log.file_path", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, []LogSink{{Type: "file", Target: "./logs/contest_output.log", Format: "json"}}, cfg.GetLogSinks())
+		assert.Equal(t, 5, cfg.GetLogHTTPTimeoutSec())
+	})
+
+	t.Run("should load structured sinks from config file", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		configFile := filepath.Join(tmpDir, "config.yaml")
+		configContent := `log:
+  file_path: "/var/log/contest.log"
+  sinks:
+    - type: file
+    - type: stdout
+      format: text
+    - type: syslog
+      address: "udp://logs.example.com:514"
+    - type: http
+      url: "https://example.com/cues"
+      format: JSON
+    - "file:/tmp/extra.log#text"
+`
+		err := os.WriteFile(configFile, []byte(configContent), 0644)
+		assert.NoError(t, err)
+
+		// Act
+		cfg, err := NewConfigurationFromFile(configFile)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []LogSink{
+			{Type: "file", Target: "/var/log/contest.log"},
+			{Type: "stdout", Format: "text"},
+			{Type: "syslog", Target: "udp://logs.example.com:514"},
+			{Type: "http", Target: "https://example.com/cues", Format: "json"},
+			{Type: "file", Target: "/tmp/extra.log", Format: "text"},
+		}, cfg.GetLogSinks())
+	})
+
+	t.Run("should parse comma-separated LOG_SINKS environment variable", func(t *testing.T) {
+		// Arrange
+		os.Setenv("LOG_SINKS", "stdout#json, https://example.com/cues, syslog")
+		defer os.Unsetenv("LOG_SINKS")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []LogSink{
+			{Type: "stdout", Format: "json"},
+			{Type: "http", Target: "https://example.com/cues"},
+			{Type: "syslog"},
+		}, cfg.GetLogSinks())
+	})
+
+	t.Run("should return sinks set programmatically", func(t *testing.T) {
+		cfg := NewConfiguration()
+		sinks := []LogSink{{Type: "stdout", Format: "text"}}
+
+		cfg.SetLogSinks(sinks)
+
+		assert.Equal(t, sinks, cfg.GetLogSinks())
+	})
+
+	t.Run("should pin sinks to log.schema_version unless a sink sets its own", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		configFile := filepath.Join(tmpDir, "config.yaml")
+		configContent := `log:
+  schema_version: "1.0"
+  sinks:
+    - type: stdout
+    - type: http
+      url: "https://example.com/cues"
+      schema_version: "1.1"
+`
+		assert.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
+
+		// Act
+		cfg, err := NewConfigurationFromFile(configFile)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []LogSink{
+			{Type: "stdout", SchemaVersion: "1.0"},
+			{Type: "http", Target: "https://example.com/cues", SchemaVersion: "1.1"},
+		}, cfg.GetLogSinks())
+	})
+
+	t.Run("should read the schema version from the environment", func(t *testing.T) {
+		os.Setenv("LOG_SCHEMA_VERSION", "1.0")
+		defer os.Unsetenv("LOG_SCHEMA_VERSION")
+
+		cfg, err := NewConfigurationFromEnv()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1.0", cfg.GetLogSchemaVersion())
+		assert.Equal(t, "1.0", cfg.GetLogSinks()[0].SchemaVersion)
+	})
+}
+
+func TestConfiguration_CueHashBucket(t *testing.T) {
+	t.Run("should default to a one-minute bucket", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, 60, cfg.GetCueHashBucketSec())
+	})
+
+	t.Run("should set the bucket", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		cfg.SetCueHashBucketSec(300)
+
+		assert.Equal(t, 300, cfg.GetCueHashBucketSec())
+	})
+}
+
+func TestConfiguration_Coordination(t *testing.T) {
+	t.Run("should disable coordination by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, "none", cfg.GetCoordinationMode())
+		assert.Equal(t, "./data/leader.lock", cfg.GetCoordinationLockFile())
+		assert.Equal(t, "radiocontestwinner:leader", cfg.GetCoordinationKey())
+		assert.Equal(t, 15, cfg.GetCoordinationLeaseTTLSec())
+		assert.Equal(t, "", cfg.GetCoordinationInstanceID())
+		assert.False(t, cfg.GetCoordinationStandby())
+		assert.Equal(t, "localhost:6379", cfg.GetRedisAddress())
+	})
+
+	t.Run("should load coordination settings from environment variables", func(t *testing.T) {
+		// Arrange
+		os.Setenv("COORDINATION_MODE", "Redis")
+		os.Setenv("INSTANCE_ID", "studio-b")
+		os.Setenv("COORDINATION_STANDBY", "true")
+		os.Setenv("REDIS_ADDRESS", "redis://cache:6379/1")
+		defer os.Unsetenv("COORDINATION_MODE")
+		defer os.Unsetenv("INSTANCE_ID")
+		defer os.Unsetenv("COORDINATION_STANDBY")
+		defer os.Unsetenv("REDIS_ADDRESS")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "redis", cfg.GetCoordinationMode())
+		assert.Equal(t, "studio-b", cfg.GetCoordinationInstanceID())
+		assert.True(t, cfg.GetCoordinationStandby())
+		assert.Equal(t, "redis://cache:6379/1", cfg.GetRedisAddress())
+	})
+}
+
+func TestConfiguration_RedisIntegration(t *testing.T) {
+	t.Run("should leave Redis integration and deduplication off by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.False(t, cfg.GetRedisEnabled())
+		assert.Equal(t, "radiocontestwinner", cfg.GetRedisKeyPrefix())
+		assert.True(t, cfg.GetRedisPublishCues())
+		assert.Equal(t, 200, cfg.GetRedisTranscriptHistory())
+		assert.Equal(t, 0, cfg.GetDedupWindowSec())
+	})
+
+	t.Run("should load Redis integration settings from environment variables", func(t *testing.T) {
+		// Arrange
+		os.Setenv("REDIS_ENABLED", "true")
+		os.Setenv("DEDUP_WINDOW_SEC", "90")
+		defer os.Unsetenv("REDIS_ENABLED")
+		defer os.Unsetenv("DEDUP_WINDOW_SEC")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, cfg.GetRedisEnabled())
+		assert.Equal(t, 90, cfg.GetDedupWindowSec())
+	})
+}
+
+func TestConfiguration_BufferNoSpeechThreshold(t *testing.T) {
+	t.Run("should default to the whisper no-speech threshold", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, 0.6, cfg.GetBufferNoSpeechThreshold())
+	})
+
+	t.Run("should return configured threshold", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		cfg.SetBufferNoSpeechThreshold(0)
+
+		assert.Equal(t, 0.0, cfg.GetBufferNoSpeechThreshold())
+	})
+
+	t.Run("should read threshold from the environment", func(t *testing.T) {
+		os.Setenv("NO_SPEECH_THRESHOLD", "0.8")
+		defer os.Unsetenv("NO_SPEECH_THRESHOLD")
+
+		cfg, err := NewConfigurationFromEnv()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.8, cfg.GetBufferNoSpeechThreshold())
+	})
+}
+
+func TestConfiguration_BufferTextLimits(t *testing.T) {
+	t.Run("should bound buffered text by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, 4096, cfg.GetBufferMaxContextBytes())
+		assert.Equal(t, 16384, cfg.GetBufferMaxBufferedBytes())
+	})
+
+	t.Run("should return configured limits", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		cfg.SetBufferMaxContextBytes(0)
+		cfg.SetBufferMaxBufferedBytes(2048)
+
+		assert.Equal(t, 0, cfg.GetBufferMaxContextBytes())
+		assert.Equal(t, 2048, cfg.GetBufferMaxBufferedBytes())
+	})
+
+	t.Run("should read limits from the environment", func(t *testing.T) {
+		os.Setenv("BUFFER_MAX_CONTEXT_BYTES", "1000")
+		os.Setenv("BUFFER_MAX_BUFFERED_BYTES", "5000")
+		defer os.Unsetenv("BUFFER_MAX_CONTEXT_BYTES")
+		defer os.Unsetenv("BUFFER_MAX_BUFFERED_BYTES")
+
+		cfg, err := NewConfigurationFromEnv()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1000, cfg.GetBufferMaxContextBytes())
+		assert.Equal(t, 5000, cfg.GetBufferMaxBufferedBytes())
+	})
+}
+
+func TestConfiguration_BufferGapThreshold(t *testing.T) {
+	t.Run("should report a minute without transcription as a gap by default", func(t *testing.T) {
+		assert.Equal(t, 60, NewConfiguration().GetBufferGapThresholdSec())
+	})
+
+	t.Run("should return the configured threshold", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		cfg.SetBufferGapThresholdSec(0)
+
+		assert.Equal(t, 0, cfg.GetBufferGapThresholdSec())
+	})
+
+	t.Run("should read the threshold from the environment", func(t *testing.T) {
+		os.Setenv("BUFFER_GAP_THRESHOLD_SEC", "90")
+		defer os.Unsetenv("BUFFER_GAP_THRESHOLD_SEC")
+
+		cfg, err := NewConfigurationFromEnv()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 90, cfg.GetBufferGapThresholdSec())
+	})
+}
+
+func TestConfiguration_DebugModeOverride(t *testing.T) {
+	t.Run("should override configured debug mode at runtime", func(t *testing.T) {
+		cfg := NewConfiguration()
+		cfg.viper.Set("debug_mode", true)
+
+		cfg.SetDebugMode(false)
+
+		assert.False(t, cfg.GetDebugMode())
+	})
+}
+
+func TestConfiguration_StreamDevice(t *testing.T) {
+	t.Run("should default to no device with 48 kHz stereo capture", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Empty(t, cfg.GetStreamDeviceName())
+		assert.Empty(t, cfg.GetStreamDeviceDriver())
+		assert.Equal(t, 48000, cfg.GetStreamDeviceSampleRate())
+		assert.Equal(t, 2, cfg.GetStreamDeviceChannels())
+	})
+
+	t.Run("should return configured device settings", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		cfg.SetStreamDeviceName("hw:1,0")
+		cfg.SetStreamDeviceDriver("pulse")
+		cfg.SetStreamDeviceSampleRate(44100)
+		cfg.SetStreamDeviceChannels(1)
+
+		assert.Equal(t, "hw:1,0", cfg.GetStreamDeviceName())
+		assert.Equal(t, "pulse", cfg.GetStreamDeviceDriver())
+		assert.Equal(t, 44100, cfg.GetStreamDeviceSampleRate())
+		assert.Equal(t, 1, cfg.GetStreamDeviceChannels())
+	})
+
+	t.Run("should read device settings from the environment", func(t *testing.T) {
+		os.Setenv("STREAM_DEVICE", "hw:2,0")
+		os.Setenv("STREAM_DEVICE_SAMPLE_RATE", "32000")
+		defer os.Unsetenv("STREAM_DEVICE")
+		defer os.Unsetenv("STREAM_DEVICE_SAMPLE_RATE")
+
+		cfg, err := NewConfigurationFromEnv()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "hw:2,0", cfg.GetStreamDeviceName())
+		assert.Equal(t, 32000, cfg.GetStreamDeviceSampleRate())
+	})
+}
+
+func TestConfiguration_StreamSDR(t *testing.T) {
+	t.Run("should default to no SDR with automatic gain broadcast FM", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Empty(t, cfg.GetStreamSDRFrequency())
+		assert.Equal(t, "wbfm", cfg.GetStreamSDRModulation())
+		assert.Equal(t, 0.0, cfg.GetStreamSDRGain())
+		assert.Equal(t, 48000, cfg.GetStreamSDRSampleRate())
+		assert.Equal(t, 0, cfg.GetStreamSDRDeviceIndex())
+		assert.Equal(t, 0, cfg.GetStreamSDRPPM())
+	})
+
+	t.Run("should read SDR settings from the environment", func(t *testing.T) {
+		os.Setenv("SDR_FREQUENCY", "101.1M")
+		os.Setenv("SDR_GAIN", "40")
+		os.Setenv("SDR_PPM", "-2")
+		defer os.Unsetenv("SDR_FREQUENCY")
+		defer os.Unsetenv("SDR_GAIN")
+		defer os.Unsetenv("SDR_PPM")
+
+		cfg, err := NewConfigurationFromEnv()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "101.1M", cfg.GetStreamSDRFrequency())
+		assert.Equal(t, 40.0, cfg.GetStreamSDRGain())
+		assert.Equal(t, -2, cfg.GetStreamSDRPPM())
+	})
+}
+
+func TestConfiguration_StreamRelays(t *testing.T) {
+	t.Run("should have no relays by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Empty(t, cfg.GetStreamRelays())
+		assert.Equal(t, 600, cfg.GetStreamRelayCheckIntervalSec())
+		assert.Equal(t, 20, cfg.GetStreamRelaySampleSec())
+		assert.Equal(t, 0.8, cfg.GetStreamDuplicateSimilarity())
+	})
+
+	t.Run("should parse relay maps from the config file", func(t *testing.T) {
+		cfg := NewConfiguration()
+		cfg.viper.Set("stream.relays", []interface{}{
+			map[string]interface{}{"label": "KAAA-FM", "url": "https://affiliate.example.com/stream.aac"},
+			map[string]interface{}{"label": "missing url"},
+		})
+
+		assert.Equal(t, []StreamRelay{{Label: "KAAA-FM", URL: "https://affiliate.example.com/stream.aac"}}, cfg.GetStreamRelays())
+	})
+
+	t.Run("should parse station metadata on relay maps", func(t *testing.T) {
+		cfg := NewConfiguration()
+		cfg.viper.Set("stream.relays", []interface{}{
+			map[string]interface{}{"label": "KAAA-FM", "url": "https://a.example.com/s.aac", "call_letters": "KAAA", "frequency": 98.7},
+		})
+
+		relays := cfg.GetStreamRelays()
+
+		assert.Len(t, relays, 1)
+		assert.Equal(t, StationMetadata{CallLetters: "KAAA", Frequency: "98.7"}, relays[len(relays)-1].Station)
+	})
+
+	t.Run("should parse label=url relays from the environment", func(t *testing.T) {
+		os.Setenv("STREAM_RELAYS", "KAAA=https://a.example.com/s.aac, KBBB=https://b.example.com/s.aac?x=1")
+		os.Setenv("STREAM_LABEL", "KMAIN")
+		defer os.Unsetenv("STREAM_RELAYS")
+		defer os.Unsetenv("STREAM_LABEL")
+
+		cfg, err := NewConfigurationFromEnv()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "KMAIN", cfg.GetStreamLabel())
+		assert.Equal(t, []StreamRelay{
+			{Label: "KAAA", URL: "https://a.example.com/s.aac"},
+			{Label: "KBBB", URL: "https://b.example.com/s.aac?x=1"},
+		}, cfg.GetStreamRelays())
+	})
+}
+
+func TestConfiguration_ProcessPriority(t *testing.T) {
+	t.Run("should leave scheduling unchanged by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, 0, cfg.GetProcessNice())
+		assert.Equal(t, 0, cfg.GetProcessGOMAXPROCS())
+		assert.Empty(t, cfg.GetProcessCPUAffinity())
+	})
+
+	t.Run("should read scheduling settings from the environment", func(t *testing.T) {
+		os.Setenv("PROCESS_NICE", "10")
+		os.Setenv("PROCESS_CPU_AFFINITY", "2-3")
+		defer os.Unsetenv("PROCESS_NICE")
+		defer os.Unsetenv("PROCESS_CPU_AFFINITY")
+
+		cfg, err := NewConfigurationFromEnv()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 10, cfg.GetProcessNice())
+		assert.Equal(t, "2-3", cfg.GetProcessCPUAffinity())
+	})
+}
+
+func TestConfiguration_NotifierQueue(t *testing.T) {
+	t.Run("should queue failed deliveries for an hour by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, "./data/notifier_queue", cfg.GetNotifierQueueDir())
+		assert.Equal(t, 3600, cfg.GetNotifierQueueMaxAgeSec())
+		assert.Equal(t, 30, cfg.GetNotifierQueueRetryIntervalSec())
+	})
+
+	t.Run("should read the queue directory from the environment", func(t *testing.T) {
+		os.Setenv("NOTIFIER_QUEUE_DIR", "/var/lib/radiocontestwinner/queue")
+		defer os.Unsetenv("NOTIFIER_QUEUE_DIR")
+
+		cfg, err := NewConfigurationFromEnv()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "/var/lib/radiocontestwinner/queue", cfg.GetNotifierQueueDir())
+	})
+
+	t.Run("should allow disabling the queue", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		cfg.SetNotifierQueueDir("")
+
+		assert.Empty(t, cfg.GetNotifierQueueDir())
+	})
+}
+
+func TestConfiguration_QuietHours(t *testing.T) {
+	t.Run("should have no quiet hours or maintenance by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Empty(t, cfg.GetQuietHoursStart())
+		assert.Empty(t, cfg.GetQuietHoursEnd())
+		assert.False(t, cfg.GetQuietHoursIncludeAlerts())
+		assert.False(t, cfg.GetMaintenanceMode())
+	})
+
+	t.Run("should read quiet hours from the environment", func(t *testing.T) {
+		os.Setenv("QUIET_HOURS_START", "22:00")
+		os.Setenv("QUIET_HOURS_END", "07:00")
+		os.Setenv("QUIET_HOURS_TIMEZONE", "America/Chicago")
+		defer os.Unsetenv("QUIET_HOURS_START")
+		defer os.Unsetenv("QUIET_HOURS_END")
+		defer os.Unsetenv("QUIET_HOURS_TIMEZONE")
+
+		cfg, err := NewConfigurationFromEnv()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "22:00", cfg.GetQuietHoursStart())
+		assert.Equal(t, "07:00", cfg.GetQuietHoursEnd())
+		assert.Equal(t, "America/Chicago", cfg.GetQuietHoursTimezone())
+	})
+}
+
+func TestConfiguration_Digest(t *testing.T) {
+	t.Run("should disable digests by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Empty(t, cfg.GetDigestSchedule())
+		assert.Empty(t, cfg.GetDigestChannel())
+		assert.Equal(t, "08:00", cfg.GetDigestDailyAt())
+	})
+
+	t.Run("should read the digest schedule and channel from the environment", func(t *testing.T) {
+		os.Setenv("DIGEST_SCHEDULE", "hourly")
+		os.Setenv("DIGEST_CHANNEL", "webhook")
+		defer os.Unsetenv("DIGEST_SCHEDULE")
+		defer os.Unsetenv("DIGEST_CHANNEL")
+
+		cfg, err := NewConfigurationFromEnv()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "hourly", cfg.GetDigestSchedule())
+		assert.Equal(t, "webhook", cfg.GetDigestChannel())
+	})
+}
+
+func TestConfiguration_DebugTranscripts(t *testing.T) {
+	t.Run("should write every transcription with rotation by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, "/app/logs/transcriptions_debug.log", cfg.GetDebugTranscriptsPath())
+		assert.Equal(t, 1, cfg.GetDebugTranscriptsSampleEvery())
+		assert.Equal(t, 100, cfg.GetDebugTranscriptsMaxSizeMB())
+		assert.Equal(t, 3, cfg.GetDebugTranscriptsMaxBackups())
+		assert.Equal(t, 5, cfg.GetDebugTranscriptsFlushIntervalSec())
+	})
+
+	t.Run("should read the path and sampling from the environment", func(t *testing.T) {
+		// Arrange
+		os.Setenv("DEBUG_TRANSCRIPTS_PATH", "/tmp/debug.log")
+		os.Setenv("DEBUG_TRANSCRIPTS_SAMPLE_EVERY", "10")
+		defer os.Unsetenv("DEBUG_TRANSCRIPTS_PATH")
+		defer os.Unsetenv("DEBUG_TRANSCRIPTS_SAMPLE_EVERY")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "/tmp/debug.log", cfg.GetDebugTranscriptsPath())
+		assert.Equal(t, 10, cfg.GetDebugTranscriptsSampleEvery())
+	})
+}
+
+func TestConfiguration_Paths(t *testing.T) {
+	t.Run("should default to the container directories", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, filepath.Join(os.TempDir(), "whisper"), cfg.GetPathsTempDir())
+		if runtime.GOOS == "linux" {
+			assert.Equal(t, "/app/logs", cfg.GetPathsLogDir())
+			assert.Equal(t, "/app/models", cfg.GetPathsModelDir())
+		} else {
+			assert.Equal(t, "logs", cfg.GetPathsLogDir())
+			assert.Equal(t, "models", cfg.GetPathsModelDir())
+		}
+	})
+
+	t.Run("should place default model and debug log paths in the configured directories", func(t *testing.T) {
+		cfg := NewConfiguration()
+		cfg.SetPathsModelDir("/data/models")
+		cfg.SetPathsLogDir("/data/logs")
+
+		assert.Equal(t, "/data/models/ggml-base.en.bin", cfg.GetWhisperModelPath())
+		cfg.viper.Set("whisper.model_name", "small")
+		assert.Equal(t, "/data/models/ggml-small.bin", cfg.GetWhisperModelPath())
+		assert.Equal(t, "/data/logs/transcriptions_debug.log", cfg.GetDebugTranscriptsPath())
+	})
+
+	t.Run("should keep explicit model and debug log paths", func(t *testing.T) {
+		cfg := NewConfiguration()
+		cfg.SetPathsModelDir("/data/models")
+		cfg.SetPathsLogDir("/data/logs")
+		cfg.viper.Set("whisper.model_path", "/opt/model.bin")
+		cfg.SetDebugTranscriptsPath("/var/log/debug.log")
+
+		assert.Equal(t, "/opt/model.bin", cfg.GetWhisperModelPath())
+		assert.Equal(t, "/var/log/debug.log", cfg.GetDebugTranscriptsPath())
+	})
+
+	t.Run("should read the directories from the environment", func(t *testing.T) {
+		// Arrange
+		os.Setenv("TEMP_DIR", "/scratch/whisper")
+		os.Setenv("LOG_DIR", "/volumes/logs")
+		os.Setenv("MODEL_DIR", "/volumes/models")
+		defer os.Unsetenv("TEMP_DIR")
+		defer os.Unsetenv("LOG_DIR")
+		defer os.Unsetenv("MODEL_DIR")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "/scratch/whisper", cfg.GetPathsTempDir())
+		assert.Equal(t, "/volumes/logs", cfg.GetPathsLogDir())
+		assert.Equal(t, "/volumes/models", cfg.GetPathsModelDir())
+		assert.Equal(t, "/volumes/models/ggml-base.en.bin", cfg.GetWhisperModelPath())
+	})
+}
+
+func TestConfiguration_LogDelivery(t *testing.T) {
+	t.Run("should queue and batch cue writes with retries by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.Equal(t, 1000, cfg.GetLogQueueSize())
+		assert.Equal(t, 100, cfg.GetLogBatchSize())
+		assert.Equal(t, 1000, cfg.GetLogFsyncIntervalMS())
+		assert.Equal(t, "interval", cfg.GetLogFsyncPolicy())
+		assert.Equal(t, 3, cfg.GetLogRetryAttempts())
+		assert.Equal(t, 200, cfg.GetLogRetryBackoffMS())
+		assert.Equal(t, 10000, cfg.GetLogMaxPendingCues())
+	})
+
+	t.Run("should read the queue size from the environment", func(t *testing.T) {
+		// Arrange
+		os.Setenv("LOG_QUEUE_SIZE", "50")
+		defer os.Unsetenv("LOG_QUEUE_SIZE")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 50, cfg.GetLogQueueSize())
+	})
+}
+
+func TestConfiguration_StationMetadata(t *testing.T) {
+	t.Run("should have no station metadata by default", func(t *testing.T) {
+		cfg := NewConfiguration()
+
+		assert.True(t, cfg.GetStationMetadata().IsZero())
+	})
+
+	t.Run("should read station metadata from the environment", func(t *testing.T) {
+		// Arrange
+		os.Setenv("STATION_CALL_LETTERS", "KXYZ")
+		os.Setenv("STATION_MARKET", "Austin")
+		os.Setenv("STATION_FREQUENCY", "101.5")
+		os.Setenv("STATION_TIMEZONE", "America/Chicago")
+		defer os.Unsetenv("STATION_CALL_LETTERS")
+		defer os.Unsetenv("STATION_MARKET")
+		defer os.Unsetenv("STATION_FREQUENCY")
+		defer os.Unsetenv("STATION_TIMEZONE")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		station := cfg.GetStationMetadata()
+		assert.Equal(t, StationMetadata{CallLetters: "KXYZ", Market: "Austin", Frequency: "101.5", Timezone: "America/Chicago"}, station)
+		assert.Equal(t, "KXYZ 101.5 Austin", station.DisplayName())
+		assert.Equal(t, map[string]string{"call_letters": "KXYZ", "market": "Austin", "frequency": "101.5", "timezone": "America/Chicago"}, station.Fields())
+	})
+
+	t.Run("should leave unset parts out of the display name", func(t *testing.T) {
+		assert.Equal(t, "KXYZ Austin", StationMetadata{CallLetters: "KXYZ", Market: "Austin"}.DisplayName())
+	})
+}
+
+func TestConfiguration_Timezone(t *testing.T) {
+	t.Run("should fall back to the station timezone", func(t *testing.T) {
+		cfg := NewConfiguration()
+		assert.Empty(t, cfg.GetTimezone())
+
+		cfg.SetStationMetadata(StationMetadata{Timezone: "America/Chicago"})
+		assert.Equal(t, "America/Chicago", cfg.GetTimezone())
+
+		cfg.SetTimezone("America/New_York")
+		assert.Equal(t, "America/New_York", cfg.GetTimezone())
+	})
+
+	t.Run("should read the timezone from the environment and apply it to log sinks", func(t *testing.T) {
+		// Arrange
+		os.Setenv("TIMEZONE", "America/Denver")
+		defer os.Unsetenv("TIMEZONE")
+
+		// Act
+		cfg, err := NewConfigurationFromEnv()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "America/Denver", cfg.GetTimezone())
+		assert.Equal(t, "America/Denver", cfg.GetLogSinks()[0].Timezone)
+	})
+}
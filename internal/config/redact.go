@@ -0,0 +1,118 @@
+package config
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// redacted replaces secret values in RedactedSettings
+const redacted = "[REDACTED]"
+
+// secretKeyWords mark setting names whose values are secrets
+var secretKeyWords = []string{"secret", "password", "token", "api_key", "apikey"}
+
+// secretMaps name settings holding maps whose values are all secrets, such as request headers
+var secretMaps = []string{"whisper.service_headers"}
+
+// RedactedSettings returns the effective configuration, with config file values, environment
+// overrides, and defaults merged, safe to share: secrets are replaced, URLs lose their
+// passwords and query values, and webhook URLs, which carry their token in the path, their path
+func (c *Configuration) RedactedSettings() map[string]interface{} {
+	return redactMap("", c.viper.AllSettings())
+}
+
+// redactMap redacts the settings under the dotted prefix
+func redactMap(prefix string, settings map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(settings))
+	for key, value := range settings {
+		out[key] = redactValue(prefix+key, value)
+	}
+	return out
+}
+
+// redactValue redacts the setting at the dotted key
+func redactValue(key string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if slices.Contains(secretMaps, key) {
+			return redactAll(v)
+		}
+		return redactMap(key+".", v)
+	case map[string]string:
+		if slices.Contains(secretMaps, key) {
+			return redactAll(v)
+		}
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = redactValue(key, item)
+		}
+		return items
+	case []string:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i], _ = redactValue(key, item).(string)
+		}
+		return items
+	case string:
+		if v == "" {
+			return v
+		}
+		if isSecretKey(key) {
+			return redacted
+		}
+		return redactURL(key, v)
+	}
+	if isSecretKey(key) && value != nil {
+		return redacted
+	}
+	return value
+}
+
+// redactAll replaces every value of a secret map
+func redactAll[V any](settings map[string]V) map[string]interface{} {
+	out := make(map[string]interface{}, len(settings))
+	for name := range settings {
+		out[name] = redacted
+	}
+	return out
+}
+
+// isSecretKey reports whether the last element of a dotted setting name marks a secret
+func isSecretKey(key string) bool {
+	name := strings.ToLower(key[strings.LastIndex(key, ".")+1:])
+	for _, word := range secretKeyWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactURL strips credentials from value when it is a URL
+func redactURL(key, value string) string {
+	if !strings.Contains(value, "://") {
+		return value
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" {
+		return value
+	}
+	if u.User != nil {
+		if _, hasPassword := u.User.Password(); hasPassword {
+			u.User = url.UserPassword(u.User.Username(), redacted)
+		}
+	}
+	if u.RawQuery != "" {
+		query := u.Query()
+		for name := range query {
+			query.Set(name, redacted)
+		}
+		u.RawQuery = query.Encode()
+	}
+	if strings.Contains(strings.ToLower(key), "webhook") && u.Path != "" && u.Path != "/" {
+		u.Path = "/" + redacted
+	}
+	return u.String()
+}
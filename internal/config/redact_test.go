@@ -0,0 +1,57 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfiguration_RedactedSettings(t *testing.T) {
+	t.Run("should replace secrets and keep other settings", func(t *testing.T) {
+		// Arrange
+		cfg := NewConfiguration()
+		cfg.viper.Set("redis.password", "hunter2")
+		cfg.viper.Set("notifier.webhook.secret", "s3cret")
+		cfg.viper.Set("notifier.webhook.url", "https://hooks.slack.com/services/T000/B000/XXXX")
+		cfg.viper.Set("redis.address", "redis://:hunter2@cache:6379/0")
+		cfg.SetStreamURLs([]string{"https://radio.example.com/live.aac?token=abc"})
+
+		// Act
+		settings := cfg.RedactedSettings()
+
+		// Assert
+		redis := settings["redis"].(map[string]interface{})
+		assert.Equal(t, "[REDACTED]", redis["password"])
+		assert.NotContains(t, redis["address"], "hunter2")
+		assert.Contains(t, redis["address"], "cache:6379")
+		webhook := settings["notifier"].(map[string]interface{})["webhook"].(map[string]interface{})
+		assert.Equal(t, "[REDACTED]", webhook["secret"])
+		assert.NotContains(t, webhook["url"], "XXXX")
+		assert.Contains(t, webhook["url"], "hooks.slack.com")
+		urls := settings["stream"].(map[string]interface{})["urls"].([]string)
+		assert.Equal(t, "https://radio.example.com/live.aac?token=%5BREDACTED%5D", urls[0])
+		assert.Equal(t, cfg.GetLogFilePath(), settings["log"].(map[string]interface{})["file_path"])
+	})
+
+	t.Run("should redact every Whisper service header", func(t *testing.T) {
+		cfg := NewConfiguration()
+		cfg.SetWhisperServiceAPIKey("s3cret")
+		cfg.SetWhisperServiceHeaders(map[string]string{"x-auth": "proxy-key"})
+
+		settings := cfg.RedactedSettings()
+
+		whisper := settings["whisper"].(map[string]interface{})
+		assert.Equal(t, "[REDACTED]", whisper["service_api_key"])
+		assert.Equal(t, map[string]interface{}{"x-auth": "[REDACTED]"}, whisper["service_headers"])
+	})
+
+	t.Run("should leave empty secrets empty", func(t *testing.T) {
+		cfg := NewConfiguration()
+		cfg.viper.Set("notifier.mqtt.password", "")
+
+		settings := cfg.RedactedSettings()
+
+		mqtt := settings["notifier"].(map[string]interface{})["mqtt"].(map[string]interface{})
+		assert.Equal(t, "", mqtt["password"])
+	})
+}
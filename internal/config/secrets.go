@@ -0,0 +1,173 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// EncryptedPrefix marks an encrypted config value: "enc:" followed by the base64 of a random
+// nonce and the AES-256-GCM ciphertext. Such values can be committed to ops repos and are
+// decrypted when the configuration loads.
+const EncryptedPrefix = "enc:"
+
+// Environment variables holding the key encrypted config values are decrypted with: the
+// base64-encoded 32-byte key itself, or the path of a file containing it
+const (
+	EncryptionKeyEnv     = "CONFIG_ENCRYPTION_KEY"
+	EncryptionKeyFileEnv = "CONFIG_ENCRYPTION_KEY_FILE"
+)
+
+// ErrNoEncryptionKey is returned when an encrypted value is found but no key is configured
+var ErrNoEncryptionKey = errors.New("config contains encrypted values but neither " + EncryptionKeyEnv + " nor " + EncryptionKeyFileEnv + " is set")
+
+// GenerateEncryptionKey returns a new random key, base64-encoded for CONFIG_ENCRYPTION_KEY or a key file
+func GenerateEncryptionKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// LoadEncryptionKey returns the key from CONFIG_ENCRYPTION_KEY or the file named by
+// CONFIG_ENCRYPTION_KEY_FILE, or nil when neither is set
+func LoadEncryptionKey() ([]byte, error) {
+	if encoded := os.Getenv(EncryptionKeyEnv); encoded != "" {
+		return ParseEncryptionKey(encoded)
+	}
+	if path := os.Getenv(EncryptionKeyFileEnv); path != "" {
+		return ReadEncryptionKeyFile(path)
+	}
+	return nil, nil
+}
+
+// ReadEncryptionKeyFile reads a base64-encoded key from a file
+func ReadEncryptionKeyFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read encryption key file: %w", err)
+	}
+	return ParseEncryptionKey(string(data))
+}
+
+// ParseEncryptionKey decodes a base64-encoded 32-byte key
+func ParseEncryptionKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("encryption key is not valid base64: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("encryption key must be 32 bytes, got %d", len(key))
+	}
+	return key, nil
+}
+
+// EncryptValue encrypts plaintext into an "enc:" config value
+func EncryptValue(key []byte, plaintext string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return EncryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptValue decrypts an "enc:" config value
+func DecryptValue(key []byte, value string) (string, error) {
+	if !strings.HasPrefix(value, EncryptedPrefix) {
+		return "", fmt.Errorf("value is not encrypted")
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, EncryptedPrefix))
+	if err != nil {
+		return "", fmt.Errorf("encrypted value is not valid base64: %w", err)
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", fmt.Errorf("encrypted value is truncated")
+	}
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value; wrong key or corrupted value")
+	}
+	return string(plaintext), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// decryptSettings replaces every encrypted value in v, from the config file or the
+// environment, with its plaintext. The key is only required when there is something to decrypt.
+func decryptSettings(v *viper.Viper) error {
+	var key []byte
+	decrypt := func(name, value string) (string, error) {
+		if !strings.HasPrefix(value, EncryptedPrefix) {
+			return value, nil
+		}
+		if key == nil {
+			var err error
+			if key, err = LoadEncryptionKey(); err != nil {
+				return "", err
+			}
+			if key == nil {
+				return "", fmt.Errorf("%s: %w", name, ErrNoEncryptionKey)
+			}
+		}
+		plaintext, err := DecryptValue(key, value)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", name, err)
+		}
+		return plaintext, nil
+	}
+
+	for _, name := range v.AllKeys() {
+		switch value := v.Get(name).(type) {
+		case string:
+			if !strings.HasPrefix(value, EncryptedPrefix) {
+				continue
+			}
+			plaintext, err := decrypt(name, value)
+			if err != nil {
+				return err
+			}
+			v.Set(name, plaintext)
+		case []interface{}:
+			items := make([]interface{}, len(value))
+			changed := false
+			for i, item := range value {
+				items[i] = item
+				if s, ok := item.(string); ok && strings.HasPrefix(s, EncryptedPrefix) {
+					plaintext, err := decrypt(fmt.Sprintf("%s[%d]", name, i), s)
+					if err != nil {
+						return err
+					}
+					items[i] = plaintext
+					changed = true
+				}
+			}
+			if changed {
+				v.Set(name, items)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testEncryptionKey generates a key and returns it decoded and base64-encoded
+func testEncryptionKey(t *testing.T) ([]byte, string) {
+	encoded, err := GenerateEncryptionKey()
+	require.NoError(t, err)
+	key, err := ParseEncryptionKey(encoded)
+	require.NoError(t, err)
+	return key, encoded
+}
+
+func TestEncryptValue(t *testing.T) {
+	t.Run("should decrypt what it encrypted", func(t *testing.T) {
+		// Arrange
+		key, _ := testEncryptionKey(t)
+
+		// Act
+		encrypted, err := EncryptValue(key, "s3cret-token")
+		require.NoError(t, err)
+		plaintext, err := DecryptValue(key, encrypted)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "s3cret-token", plaintext)
+		assert.True(t, len(encrypted) > len(EncryptedPrefix))
+		assert.NotContains(t, encrypted, "s3cret")
+	})
+
+	t.Run("should fail with the wrong key", func(t *testing.T) {
+		key, _ := testEncryptionKey(t)
+		otherKey, _ := testEncryptionKey(t)
+		encrypted, err := EncryptValue(key, "s3cret-token")
+		require.NoError(t, err)
+
+		_, err = DecryptValue(otherKey, encrypted)
+
+		assert.ErrorContains(t, err, "wrong key")
+	})
+
+	t.Run("should reject keys of the wrong size", func(t *testing.T) {
+		_, err := ParseEncryptionKey(base64.StdEncoding.EncodeToString([]byte("short")))
+
+		assert.ErrorContains(t, err, "32 bytes")
+	})
+}
+
+func TestConfiguration_EncryptedValues(t *testing.T) {
+	t.Run("should decrypt encrypted values in the config file", func(t *testing.T) {
+		// Arrange
+		key, encoded := testEncryptionKey(t)
+		t.Setenv(EncryptionKeyEnv, encoded)
+		secret, err := EncryptValue(key, "hunter2")
+		require.NoError(t, err)
+		streamURL, err := EncryptValue(key, "https://radio.example.com/live.aac?token=abc")
+		require.NoError(t, err)
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte(`notifier:
+  webhook:
+    secret: "`+secret+`"
+stream:
+  urls:
+    - "`+streamURL+`"
+    - "https://backup.example.com/live.aac"
+`), 0644))
+
+		// Act
+		cfg, err := NewConfigurationFromFile(configFile)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "hunter2", cfg.GetWebhookSecret())
+		assert.Equal(t, []string{"https://radio.example.com/live.aac?token=abc", "https://backup.example.com/live.aac"}, cfg.GetStreamURLs())
+	})
+
+	t.Run("should read the key from a key file", func(t *testing.T) {
+		key, encoded := testEncryptionKey(t)
+		keyFile := filepath.Join(t.TempDir(), "config.key")
+		require.NoError(t, os.WriteFile(keyFile, []byte(encoded+"\n"), 0600))
+		t.Setenv(EncryptionKeyEnv, "")
+		t.Setenv(EncryptionKeyFileEnv, keyFile)
+		password, err := EncryptValue(key, "mqtt-pass")
+		require.NoError(t, err)
+		t.Setenv("MQTT_PASSWORD", password)
+
+		cfg, err := NewConfigurationFromEnv()
+
+		require.NoError(t, err)
+		assert.Equal(t, "mqtt-pass", cfg.GetMQTTPassword())
+	})
+
+	t.Run("should fail when no key is configured", func(t *testing.T) {
+		key, _ := testEncryptionKey(t)
+		t.Setenv(EncryptionKeyEnv, "")
+		t.Setenv(EncryptionKeyFileEnv, "")
+		secret, err := EncryptValue(key, "hunter2")
+		require.NoError(t, err)
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte("redis:\n  password: \""+secret+"\"\n"), 0644))
+
+		_, err = NewConfigurationFromFile(configFile)
+
+		assert.ErrorIs(t, err, ErrNoEncryptionKey)
+		assert.ErrorContains(t, err, "redis.password")
+	})
+
+	t.Run("should not need a key without encrypted values", func(t *testing.T) {
+		t.Setenv(EncryptionKeyEnv, "")
+		t.Setenv(EncryptionKeyFileEnv, "")
+
+		_, err := NewConfigurationFromEnv()
+
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// tenantNamePattern limits tenant names to ones safe in file paths, Redis keys, and log labels
+var tenantNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// processWideKeys are settings of the whole process that tenants cannot override
+var processWideKeys = []string{"tenants", "process"}
+
+// GetTenantNames returns the names of the tenants defined under tenants, sorted; nil when the
+// process monitors a single deployment
+func (c *Configuration) GetTenantNames() []string {
+	tenants := c.viper.GetStringMap("tenants")
+	if len(tenants) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(tenants))
+	for name := range tenants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetTenantName returns the tenant this configuration belongs to; empty outside multi-tenant deployments
+func (c *Configuration) GetTenantName() string {
+	return c.tenant
+}
+
+// ForTenant returns the configuration of one tenant: the settings outside tenants with the
+// tenant's own settings merged over them. Output files, the notification queue, the
+// coordination lock, the API socket, and the Redis key prefix the tenant does not set itself
+// are moved to per-tenant locations, so tenants sharing the top-level settings never share output.
+func (c *Configuration) ForTenant(name string) (*Configuration, error) {
+	if !tenantNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid tenant name %q: use lowercase letters, digits, '-' and '_'", name)
+	}
+	if !c.viper.IsSet("tenants." + name) {
+		return nil, fmt.Errorf("tenant %q is not defined", name)
+	}
+
+	settings := c.viper.AllSettings()
+	overrides := c.viper.GetStringMap("tenants." + name)
+	for _, key := range processWideKeys {
+		delete(settings, key)
+		delete(overrides, key)
+	}
+	v := viper.New()
+	if err := v.MergeConfigMap(settings); err != nil {
+		return nil, fmt.Errorf("tenant %s: %w", name, err)
+	}
+	if err := v.MergeConfigMap(overrides); err != nil {
+		return nil, fmt.Errorf("tenant %s: %w", name, err)
+	}
+	tenant := &Configuration{viper: v, tenant: name, allowlistSource: c.allowlistSource, allowlistModified: c.allowlistModified}
+
+	// Scope shared outputs to the tenant unless it chose its own
+	tenantOwn := viper.New()
+	tenantOwn.MergeConfigMap(overrides)
+	scope := func(key, path string) {
+		if !tenantOwn.IsSet(key) && path != "" {
+			v.Set(key, filepath.Join(filepath.Dir(path), name, filepath.Base(path)))
+		}
+	}
+	scope("log.file_path", tenant.GetLogFilePath())
+	scope("debug_transcripts.path", tenant.GetDebugTranscriptsPath())
+	scope("coordination.lock_file", tenant.GetCoordinationLockFile())
+	scope("calendar.ics_path", tenant.GetCalendarICSPath())
+	scope("usage.path", tenant.GetUsagePath())
+	scope("archive.state_path", tenant.GetArchiveStatePath())
+	scope("stream.reconnect.state_file", tenant.GetStreamReconnectStateFile())
+	if path, ok := strings.CutPrefix(tenant.GetAPIAddress(), "unix:"); ok && !tenantOwn.IsSet("api.address") {
+		v.Set("api.address", "unix:"+filepath.Join(filepath.Dir(path), name, filepath.Base(path)))
+	}
+	if !tenantOwn.IsSet("archive.prefix") {
+		v.Set("archive.prefix", strings.Trim(tenant.GetArchivePrefix()+"/"+name, "/"))
+	}
+	if dir := tenant.GetNotifierQueueDir(); !tenantOwn.IsSet("notifier.queue.dir") && dir != "" {
+		v.Set("notifier.queue.dir", filepath.Join(dir, name))
+	}
+	if !tenantOwn.IsSet("redis.key_prefix") {
+		v.Set("redis.key_prefix", tenant.GetRedisKeyPrefix()+":"+name)
+	}
+	if !tenantOwn.IsSet("log.sinks") && c.viper.IsSet("log.sinks") {
+		sinks := tenant.GetLogSinks()
+		for i, sink := range sinks {
+			if sink.Type == "file" && sink.Target != "" {
+				sinks[i].Target = filepath.Join(filepath.Dir(sink.Target), name, filepath.Base(sink.Target))
+			}
+		}
+		tenant.SetLogSinks(sinks)
+	}
+	return tenant, nil
+}
+
+// TenantConfigurations returns the configuration of every tenant, in name order. It fails when
+// a tenant is invalid or two tenants would write the same file.
+func (c *Configuration) TenantConfigurations() ([]*Configuration, error) {
+	var tenants []*Configuration
+	owners := map[string]string{} // Output file -> tenant writing it
+	for _, name := range c.GetTenantNames() {
+		tenant, err := c.ForTenant(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, output := range tenant.outputFiles() {
+			if other, ok := owners[output]; ok {
+				return nil, fmt.Errorf("tenants %s and %s both write %s; give each tenant its own", other, name, output)
+			}
+			owners[output] = name
+		}
+		tenants = append(tenants, tenant)
+	}
+	return tenants, nil
+}
+
+// outputFiles returns the files and directories this configuration writes
+func (c *Configuration) outputFiles() []string {
+	var files []string
+	add := func(path string) {
+		if path = strings.TrimSpace(path); path != "" {
+			files = append(files, filepath.Clean(path))
+		}
+	}
+	for _, sink := range c.GetLogSinks() {
+		if sink.Type == "file" {
+			add(sink.Target)
+		}
+	}
+	add(c.GetDebugTranscriptsPath())
+	add(c.GetNotifierQueueDir())
+	add(c.GetCalendarICSPath())
+	add(c.GetUsagePath())
+	add(c.GetArchiveStatePath())
+	if c.GetStreamReconnectMaxPerMinute() > 0 || c.GetStreamReconnectCooldownAfterFailures() > 0 {
+		add(c.GetStreamReconnectStateFile())
+	}
+	if path, ok := strings.CutPrefix(c.GetAPIAddress(), "unix:"); ok && c.GetAPIEnabled() {
+		add(path)
+	}
+	if c.GetCoordinationMode() == "file" {
+		add(c.GetCoordinationLockFile())
+	}
+	return files
+}
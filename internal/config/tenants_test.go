@@ -0,0 +1,160 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loadTenantConfig writes yaml to a config file and loads it
+func loadTenantConfig(t *testing.T, yaml string) *Configuration {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(yaml), 0644))
+	cfg, err := NewConfigurationFromFile(path)
+	require.NoError(t, err)
+	return cfg
+}
+
+const tenantsYAML = `
+stream:
+  url: https://shared.example.com/stream
+allowlist:
+  numbers: ["55555"]
+log:
+  file_path: /data/cues.log
+calendar:
+  ics_path: /data/contests.ics
+process:
+  nice: 5
+tenants:
+  kiss:
+    stream:
+      url: https://kiss.example.com/stream
+    allowlist:
+      numbers: ["72881"]
+    notifier:
+      webhook:
+        url: https://hooks.example.com/kiss
+  wxyz:
+    log:
+      file_path: /data/wxyz/contest.log
+    process:
+      nice: 19
+`
+
+func TestConfiguration_GetTenantNames(t *testing.T) {
+	t.Run("should list tenants in name order", func(t *testing.T) {
+		cfg := loadTenantConfig(t, tenantsYAML)
+
+		assert.Equal(t, []string{"kiss", "wxyz"}, cfg.GetTenantNames())
+	})
+
+	t.Run("should be empty without tenants", func(t *testing.T) {
+		assert.Nil(t, NewConfiguration().GetTenantNames())
+	})
+}
+
+func TestConfiguration_ForTenant(t *testing.T) {
+	t.Run("should merge the tenant's settings over the shared ones", func(t *testing.T) {
+		// Arrange
+		cfg := loadTenantConfig(t, tenantsYAML)
+
+		// Act
+		kiss, err := cfg.ForTenant("kiss")
+		require.NoError(t, err)
+		wxyz, err := cfg.ForTenant("wxyz")
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, "kiss", kiss.GetTenantName())
+		assert.Equal(t, "https://kiss.example.com/stream", kiss.GetStreamURL())
+		assert.Equal(t, []string{"72881"}, kiss.GetAllowlist())
+		assert.Equal(t, "https://hooks.example.com/kiss", kiss.GetWebhookURL())
+		assert.Equal(t, "https://shared.example.com/stream", wxyz.GetStreamURL())
+		assert.Equal(t, []string{"55555"}, wxyz.GetAllowlist())
+		assert.Empty(t, wxyz.GetWebhookURL())
+		assert.Empty(t, wxyz.GetTenantNames())
+	})
+
+	t.Run("should give each tenant its own outputs unless it sets them", func(t *testing.T) {
+		cfg := loadTenantConfig(t, tenantsYAML)
+
+		kiss, err := cfg.ForTenant("kiss")
+		require.NoError(t, err)
+		wxyz, err := cfg.ForTenant("wxyz")
+		require.NoError(t, err)
+
+		assert.Equal(t, "/data/kiss/cues.log", kiss.GetLogFilePath())
+		assert.Equal(t, "/data/wxyz/contest.log", wxyz.GetLogFilePath())
+		assert.Equal(t, "/app/logs/kiss/transcriptions_debug.log", kiss.GetDebugTranscriptsPath())
+		assert.Equal(t, filepath.Join("data", "notifier_queue", "kiss"), kiss.GetNotifierQueueDir())
+		assert.Equal(t, "radiocontestwinner:kiss", kiss.GetRedisKeyPrefix())
+		assert.Equal(t, "/data/kiss/contests.ics", kiss.GetCalendarICSPath())
+		assert.Equal(t, "data/kiss/usage.json", kiss.GetUsagePath())
+		assert.Equal(t, "data/kiss/archive_state.json", kiss.GetArchiveStatePath())
+		assert.Equal(t, "data/kiss/stream_reconnect.json", kiss.GetStreamReconnectStateFile())
+		assert.Equal(t, "radiocontestwinner/kiss", kiss.GetArchivePrefix())
+		assert.Equal(t, "unix:/tmp/kiss/radiocontestwinner.sock", kiss.GetAPIAddress())
+	})
+
+	t.Run("should scope shared file log sinks", func(t *testing.T) {
+		cfg := loadTenantConfig(t, `
+log:
+  sinks:
+    - {type: file, target: /data/cues.jsonl}
+    - {type: stdout}
+tenants:
+  kiss: {}
+`)
+
+		kiss, err := cfg.ForTenant("kiss")
+
+		require.NoError(t, err)
+		sinks := kiss.GetLogSinks()
+		require.Len(t, sinks, 2)
+		assert.Equal(t, "/data/kiss/cues.jsonl", sinks[0].Target)
+		assert.Equal(t, "stdout", sinks[1].Type)
+	})
+
+	t.Run("should reject undefined tenants and unsafe names", func(t *testing.T) {
+		cfg := loadTenantConfig(t, tenantsYAML)
+
+		_, err := cfg.ForTenant("other")
+		assert.ErrorContains(t, err, `tenant "other" is not defined`)
+		_, err = cfg.ForTenant("../kiss")
+		assert.ErrorContains(t, err, "invalid tenant name")
+	})
+}
+
+func TestConfiguration_TenantConfigurations(t *testing.T) {
+	t.Run("should return every tenant", func(t *testing.T) {
+		cfg := loadTenantConfig(t, tenantsYAML)
+
+		tenants, err := cfg.TenantConfigurations()
+
+		require.NoError(t, err)
+		require.Len(t, tenants, 2)
+		assert.Equal(t, "kiss", tenants[0].GetTenantName())
+		assert.Equal(t, "wxyz", tenants[1].GetTenantName())
+	})
+
+	t.Run("should reject tenants writing the same file", func(t *testing.T) {
+		cfg := loadTenantConfig(t, `
+tenants:
+  kiss:
+    log:
+      file_path: /data/cues.log
+  wxyz:
+    log:
+      file_path: /data/cues.log
+`)
+
+		_, err := cfg.TenantConfigurations()
+
+		assert.ErrorContains(t, err, "tenants kiss and wxyz both write /data/cues.log")
+	})
+}
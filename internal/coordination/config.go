@@ -0,0 +1,61 @@
+package coordination
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/redis"
+)
+
+// Coordination modes accepted in configuration
+const (
+	ModeNone  = "none"
+	ModeFile  = "file"
+	ModeRedis = "redis"
+)
+
+// NewElectorFromConfig creates the Elector for the configured coordination mode, or nil when
+// coordination is disabled and this instance should always act as leader
+func NewElectorFromConfig(cfg *config.Configuration, logger *zap.Logger) (*Elector, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("configuration cannot be nil")
+	}
+
+	ttl := time.Duration(cfg.GetCoordinationLeaseTTLSec()) * time.Second
+	if ttl <= 0 {
+		return nil, fmt.Errorf("coordination lease TTL must be positive")
+	}
+	instanceID := cfg.GetCoordinationInstanceID()
+	if instanceID == "" {
+		instanceID = DefaultInstanceID()
+	}
+
+	var lock Lock
+	switch mode := cfg.GetCoordinationMode(); mode {
+	case ModeNone:
+		return nil, nil
+	case ModeFile:
+		if errFileLockUnsupported != nil {
+			return nil, errFileLockUnsupported
+		}
+		lock = NewFileLock(cfg.GetCoordinationLockFile())
+	case ModeRedis:
+		client, err := redis.NewClient(redis.Options{
+			Address:  cfg.GetRedisAddress(),
+			Password: cfg.GetRedisPassword(),
+			DB:       cfg.GetRedisDB(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create redis client for coordination: %w", err)
+		}
+		lock = NewRedisLock(client, cfg.GetCoordinationKey(), instanceID, ttl)
+	default:
+		return nil, fmt.Errorf("unknown coordination mode %q (expected none, file, or redis)", mode)
+	}
+
+	// Renew well within the lease so one missed attempt does not cost leadership
+	return NewElector(lock, ttl/3, logger), nil
+}
@@ -0,0 +1,159 @@
+package coordination
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Roles reported by an Elector
+const (
+	RoleLeader   = "leader"
+	RoleFollower = "follower"
+)
+
+// Lock is a leadership lock shared by redundant instances. Only one holder may own it at a time.
+type Lock interface {
+	// Name identifies the lock backend in logs
+	Name() string
+	// Acquire takes the lock, or renews it if already held. It reports whether this instance holds it.
+	Acquire(ctx context.Context) (bool, error)
+	// Release gives the lock up so another instance can take over immediately
+	Release(ctx context.Context) error
+}
+
+// Elector keeps trying to hold a Lock so that exactly one of several redundant instances acts as
+// leader. Instances start as followers, and a leader that cannot renew its lock steps down.
+type Elector struct {
+	lock     Lock
+	interval time.Duration
+	logger   *zap.Logger
+
+	mu       sync.RWMutex
+	isLeader bool
+	onChange func(leader bool)
+}
+
+// NewElector creates an Elector that attempts to acquire or renew lock every interval
+func NewElector(lock Lock, interval time.Duration, logger *zap.Logger) *Elector {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	return &Elector{
+		lock:     lock,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// OnChange registers a function called whenever this instance gains or loses leadership
+func (e *Elector) OnChange(fn func(leader bool)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.onChange = fn
+}
+
+// IsLeader reports whether this instance currently holds the lock
+func (e *Elector) IsLeader() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.isLeader
+}
+
+// Role returns RoleLeader or RoleFollower
+func (e *Elector) Role() string {
+	if e.IsLeader() {
+		return RoleLeader
+	}
+	return RoleFollower
+}
+
+// Run campaigns for leadership until ctx is cancelled, then releases the lock
+func (e *Elector) Run(ctx context.Context) {
+	e.logger.Info("starting leader election",
+		zap.String("lock", e.lock.Name()),
+		zap.Duration("interval", e.interval))
+
+	e.campaign(ctx)
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			e.resign()
+			return
+		case <-ticker.C:
+			e.campaign(ctx)
+		}
+	}
+}
+
+// campaign makes one attempt to acquire or renew the lock
+func (e *Elector) campaign(ctx context.Context) {
+	attemptCtx, cancel := context.WithTimeout(ctx, e.interval)
+	defer cancel()
+
+	held, err := e.lock.Acquire(attemptCtx)
+	if err != nil {
+		// Without a confirmed lock another instance may take over, so never keep acting as leader
+		e.logger.Warn("leader lock unavailable",
+			zap.String("lock", e.lock.Name()),
+			zap.Error(err))
+		held = false
+	}
+	e.setLeader(held)
+}
+
+// resign releases the lock if held so a follower can take over without waiting for it to expire
+func (e *Elector) resign() {
+	if !e.IsLeader() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), e.interval)
+	defer cancel()
+	if err := e.lock.Release(ctx); err != nil {
+		e.logger.Warn("failed to release leader lock",
+			zap.String("lock", e.lock.Name()),
+			zap.Error(err))
+	}
+	e.setLeader(false)
+}
+
+func (e *Elector) setLeader(leader bool) {
+	e.mu.Lock()
+	changed := e.isLeader != leader
+	e.isLeader = leader
+	onChange := e.onChange
+	e.mu.Unlock()
+
+	if !changed {
+		return
+	}
+	if leader {
+		e.logger.Info("acquired leadership; this instance now sends notifications",
+			zap.String("lock", e.lock.Name()))
+	} else {
+		e.logger.Warn("lost leadership; this instance now only logs cues",
+			zap.String("lock", e.lock.Name()))
+	}
+	if onChange != nil {
+		onChange(leader)
+	}
+}
+
+// DefaultInstanceID identifies this process as hostname-pid
+func DefaultInstanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
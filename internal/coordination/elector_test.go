@@ -0,0 +1,248 @@
+package coordination
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/redis"
+	"radiocontestwinner/internal/redis/redistest"
+)
+
+// scriptedLock returns queued Acquire results
+type scriptedLock struct {
+	mu       sync.Mutex
+	results  []error // nil means acquired
+	released bool
+}
+
+func (l *scriptedLock) Name() string { return "scripted" }
+
+func (l *scriptedLock) Acquire(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.results) == 0 {
+		return true, nil
+	}
+	err := l.results[0]
+	l.results = l.results[1:]
+	return err == nil, err
+}
+
+func (l *scriptedLock) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.released = true
+	return nil
+}
+
+// newRedisServer starts a fake Redis server implementing the lock scripts
+func newRedisServer(t *testing.T) *redistest.Server {
+	t.Helper()
+	server := redistest.NewServer(t)
+	server.HandleScript(renewScript, func(s *redistest.Server, keys, args []string) interface{} {
+		if value, ok := s.Get(keys[0]); ok && value == args[0] {
+			ms, _ := strconv.Atoi(args[1])
+			s.PExpire(keys[0], time.Duration(ms)*time.Millisecond)
+			return int64(1)
+		}
+		return int64(0)
+	})
+	server.HandleScript(releaseScript, func(s *redistest.Server, keys, args []string) interface{} {
+		if value, ok := s.Get(keys[0]); ok && value == args[0] {
+			s.Del(keys[0])
+			return int64(1)
+		}
+		return int64(0)
+	})
+	return server
+}
+
+func newRedisLock(t *testing.T, server *redistest.Server, instanceID string) *RedisLock {
+	t.Helper()
+	client, err := redis.NewClient(redis.Options{Address: server.Addr()})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return NewRedisLock(client, "radiocontestwinner:leader", instanceID, time.Minute)
+}
+
+func TestElector(t *testing.T) {
+	t.Run("should step down when the lock cannot be renewed", func(t *testing.T) {
+		// Arrange
+		lock := &scriptedLock{results: []error{nil, errors.New("redis unreachable"), nil}}
+		elector := NewElector(lock, time.Second, zaptest.NewLogger(t))
+		var changes []bool
+		elector.OnChange(func(leader bool) { changes = append(changes, leader) })
+
+		// Act & Assert
+		assert.Equal(t, RoleFollower, elector.Role(), "should start as a follower")
+
+		elector.campaign(context.Background())
+		assert.True(t, elector.IsLeader())
+
+		elector.campaign(context.Background())
+		assert.False(t, elector.IsLeader(), "should not act as leader without a confirmed lock")
+
+		elector.campaign(context.Background())
+		assert.Equal(t, RoleLeader, elector.Role())
+		assert.Equal(t, []bool{true, false, true}, changes)
+	})
+
+	t.Run("should release the lock when stopped", func(t *testing.T) {
+		// Arrange
+		lock := &scriptedLock{}
+		elector := NewElector(lock, 10*time.Millisecond, zaptest.NewLogger(t))
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+
+		// Act
+		go func() {
+			elector.Run(ctx)
+			close(done)
+		}()
+		require.Eventually(t, elector.IsLeader, time.Second, 5*time.Millisecond)
+		cancel()
+
+		// Assert
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Run did not return after cancellation")
+		}
+		assert.True(t, lock.released)
+		assert.False(t, elector.IsLeader())
+	})
+}
+
+func TestFileLock(t *testing.T) {
+	t.Run("should allow only one holder until it is released", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "locks", "leader.lock")
+		first := NewFileLock(path)
+		second := NewFileLock(path)
+		ctx := context.Background()
+
+		// Act & Assert
+		held, err := first.Acquire(ctx)
+		require.NoError(t, err)
+		assert.True(t, held)
+
+		held, err = first.Acquire(ctx)
+		require.NoError(t, err)
+		assert.True(t, held, "should renew a held lock")
+
+		held, err = second.Acquire(ctx)
+		require.NoError(t, err)
+		assert.False(t, held, "should not grant a held lock to another instance")
+
+		require.NoError(t, first.Release(ctx))
+		held, err = second.Acquire(ctx)
+		require.NoError(t, err)
+		assert.True(t, held, "should take over after release")
+	})
+}
+
+func TestRedisLock(t *testing.T) {
+	t.Run("should allow only one holder and fail over when the lease expires", func(t *testing.T) {
+		// Arrange
+		server := newRedisServer(t)
+		first := newRedisLock(t, server, "instance-a")
+		second := newRedisLock(t, server, "instance-b")
+		ctx := context.Background()
+
+		// Act & Assert
+		held, err := first.Acquire(ctx)
+		require.NoError(t, err)
+		assert.True(t, held)
+		assert.Equal(t, "instance-a", server.Value("radiocontestwinner:leader"))
+
+		held, err = first.Acquire(ctx)
+		require.NoError(t, err)
+		assert.True(t, held, "should renew its own lease")
+
+		held, err = second.Acquire(ctx)
+		require.NoError(t, err)
+		assert.False(t, held)
+
+		server.Expire("radiocontestwinner:leader")
+		held, err = second.Acquire(ctx)
+		require.NoError(t, err)
+		assert.True(t, held, "should take over an expired lease")
+
+		held, err = first.Acquire(ctx)
+		require.NoError(t, err)
+		assert.False(t, held, "the old leader should not renew the new leader's lease")
+	})
+
+	t.Run("should only release its own lease", func(t *testing.T) {
+		// Arrange
+		server := newRedisServer(t)
+		leader := newRedisLock(t, server, "instance-a")
+		other := newRedisLock(t, server, "instance-b")
+		ctx := context.Background()
+		_, err := leader.Acquire(ctx)
+		require.NoError(t, err)
+
+		// Act & Assert
+		require.NoError(t, other.Release(ctx))
+		assert.Equal(t, "instance-a", server.Value("radiocontestwinner:leader"))
+
+		require.NoError(t, leader.Release(ctx))
+		assert.Equal(t, "", server.Value("radiocontestwinner:leader"))
+	})
+
+	t.Run("should report errors when Redis is failing", func(t *testing.T) {
+		server := newRedisServer(t)
+		server.SetFailing(true)
+		lock := newRedisLock(t, server, "instance-a")
+
+		held, err := lock.Acquire(context.Background())
+
+		assert.Error(t, err)
+		assert.False(t, held)
+	})
+}
+
+func TestNewElectorFromConfig(t *testing.T) {
+	t.Run("should be disabled by default", func(t *testing.T) {
+		elector, err := NewElectorFromConfig(config.NewConfiguration(), nil)
+
+		require.NoError(t, err)
+		assert.Nil(t, elector)
+	})
+
+	t.Run("should create file and redis electors", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetCoordinationMode("file")
+		cfg.SetCoordinationLockFile("/tmp/test-leader.lock")
+
+		elector, err := NewElectorFromConfig(cfg, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "file:/tmp/test-leader.lock", elector.lock.Name())
+		assert.Equal(t, 5*time.Second, elector.interval)
+
+		cfg.SetCoordinationMode("redis")
+		cfg.SetRedisAddress("redis://cache.example.com:6380")
+		elector, err = NewElectorFromConfig(cfg, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "redis:cache.example.com:6380/radiocontestwinner:leader", elector.lock.Name())
+	})
+
+	t.Run("should reject unknown modes", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetCoordinationMode("etcd")
+
+		_, err := NewElectorFromConfig(cfg, nil)
+
+		assert.Error(t, err)
+	})
+}
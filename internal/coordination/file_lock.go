@@ -0,0 +1,77 @@
+package coordination
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileLock is a Lock backed by an exclusive flock on a file. It coordinates instances on the
+// same host or sharing a filesystem with working flock semantics (not most NFS mounts). The
+// kernel releases the lock when the holding process exits, so a crashed leader is replaced
+// on the followers' next attempt. File locks are only supported on Unix-like systems.
+type FileLock struct {
+	path string
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileLock creates a FileLock on path; the file and its directory are created on first use
+func NewFileLock(path string) *FileLock {
+	return &FileLock{path: path}
+}
+
+// Name identifies the lock in logs
+func (l *FileLock) Name() string {
+	return "file:" + l.path
+}
+
+// Acquire takes the flock without blocking; holding it already counts as acquired
+func (l *FileLock) Acquire(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file != nil {
+		return true, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return false, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return false, fmt.Errorf("failed to open lock file %s: %w", l.path, err)
+	}
+
+	locked, err := lockFile(file)
+	if err != nil || !locked {
+		file.Close()
+		if err != nil {
+			return false, fmt.Errorf("failed to lock %s: %w", l.path, err)
+		}
+		return false, nil
+	}
+
+	// Record the holder for operators inspecting the file
+	file.Truncate(0)
+	file.WriteAt([]byte(DefaultInstanceID()+"\n"), 0)
+	l.file = file
+	return true, nil
+}
+
+// Release unlocks and closes the file
+func (l *FileLock) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+	unlockFile(l.file)
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
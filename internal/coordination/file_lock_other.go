@@ -0,0 +1,19 @@
+//go:build !unix
+
+package coordination
+
+import (
+	"errors"
+	"os"
+)
+
+// flock isn't available on this platform
+var errFileLockUnsupported = errors.New("file coordination is not supported on this platform (use redis coordination)")
+
+func lockFile(file *os.File) (bool, error) {
+	return false, errFileLockUnsupported
+}
+
+func unlockFile(file *os.File) error {
+	return errFileLockUnsupported
+}
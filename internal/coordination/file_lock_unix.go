@@ -0,0 +1,28 @@
+//go:build unix
+
+package coordination
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// File locks are supported on this platform
+var errFileLockUnsupported error
+
+// lockFile takes an exclusive flock on file without blocking; false when another holder has it
+func lockFile(file *os.File) (bool, error) {
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// unlockFile releases the flock on file
+func unlockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,68 @@
+package coordination
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"radiocontestwinner/internal/redis"
+)
+
+// Scripts make renewing and releasing conditional on still owning the lock, so an instance
+// whose lease expired can never extend or delete the new leader's lock
+const (
+	renewScript   = `if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("pexpire", KEYS[1], ARGV[2]) else return 0 end`
+	releaseScript = `if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("del", KEYS[1]) else return 0 end`
+)
+
+// RedisLock is a Lock backed by a Redis key holding the leader's instance ID with a TTL.
+// A leader that stops renewing (crash, network partition) loses the lock when the TTL lapses.
+type RedisLock struct {
+	client     *redis.Client
+	key        string
+	instanceID string
+	ttl        time.Duration
+}
+
+// NewRedisLock creates a RedisLock on key owned by instanceID while renewed within ttl
+func NewRedisLock(client *redis.Client, key, instanceID string, ttl time.Duration) *RedisLock {
+	return &RedisLock{
+		client:     client,
+		key:        key,
+		instanceID: instanceID,
+		ttl:        ttl,
+	}
+}
+
+// Name identifies the lock in logs
+func (l *RedisLock) Name() string {
+	return "redis:" + l.client.Address() + "/" + l.key
+}
+
+// Acquire renews the key if this instance owns it, otherwise tries to create it
+func (l *RedisLock) Acquire(ctx context.Context) (bool, error) {
+	ttlMS := strconv.FormatInt(l.ttl.Milliseconds(), 10)
+
+	renewed, err := l.client.Int("EVAL", renewScript, "1", l.key, l.instanceID, ttlMS)
+	if err != nil {
+		return false, fmt.Errorf("failed to renew leader lock: %w", err)
+	}
+	if renewed == 1 {
+		return true, nil
+	}
+
+	reply, err := l.client.Do("SET", l.key, l.instanceID, "NX", "PX", ttlMS)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire leader lock: %w", err)
+	}
+	return reply == "OK", nil
+}
+
+// Release deletes the key if this instance still owns it
+func (l *RedisLock) Release(ctx context.Context) error {
+	if _, err := l.client.Int("EVAL", releaseScript, "1", l.key, l.instanceID); err != nil {
+		return fmt.Errorf("failed to release leader lock: %w", err)
+	}
+	return nil
+}
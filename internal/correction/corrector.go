@@ -0,0 +1,262 @@
+// Package correction asks a language model to fix speech recognition errors in low-confidence
+// buffered contexts before pattern matching, so mangled contest keywords and short codes are
+// still detected. Requests are limited per minute and by a daily token budget.
+package correction
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"radiocontestwinner/internal/buffer"
+)
+
+// DefaultPrompt is the system prompt used when Config.Prompt is empty
+const DefaultPrompt = "You correct speech recognition errors in radio broadcast transcripts. " +
+	"Contest announcements ask listeners to text a keyword to a short code number, and those keywords " +
+	"and numbers are often misheard. Reply with only the corrected transcript, changing as little as possible. " +
+	"If nothing needs correcting, reply with the transcript unchanged."
+
+// maxGrowth is how many times longer than the original a correction may be before it is
+// treated as the model answering instead of correcting, and discarded
+const maxGrowth = 2
+
+// Config configures the corrector
+type Config struct {
+	Endpoint             string         // OpenAI-compatible chat completions URL
+	Model                string         // Model name sent with each request
+	APIKey               string         // Bearer token; empty sends no Authorization header
+	Prompt               string         // System prompt; empty uses DefaultPrompt
+	ConfidenceThreshold  float32        // Contexts below this confidence are corrected
+	Timeout              time.Duration  // Per-request timeout; the original text is used when it expires
+	MaxRequestsPerMinute int            // 0 means no limit
+	DailyTokenBudget     int            // Tokens per day; 0 means no limit
+	Location             *time.Location // Time zone whose midnight resets the budget; nil uses UTC
+}
+
+// Status summarizes what the corrector has done, for health reporting
+type Status struct {
+	Corrected       int64  `json:"corrected"`         // Contexts whose text the model changed
+	Unchanged       int64  `json:"unchanged"`         // Contexts the model returned as they were
+	RateLimited     int64  `json:"rate_limited"`      // Contexts skipped by the per-minute limit
+	OverBudget      int64  `json:"over_budget"`       // Contexts skipped because the daily budget was spent
+	Failed          int64  `json:"failed"`            // Requests that failed or returned unusable text
+	TokensUsedToday int    `json:"tokens_used_today"` // Tokens spent against today's budget
+	LastError       string `json:"last_error,omitempty"`
+}
+
+// Corrector sends low-confidence buffered contexts to a language model for correction
+type Corrector struct {
+	config Config
+	client *http.Client
+	now    func() time.Time
+
+	mu        sync.Mutex
+	requests  []time.Time // Request times within the last minute
+	budgetDay string      // Day status.TokensUsedToday was counted on, as YYYY-MM-DD in config.Location
+	status    Status
+}
+
+// NewCorrector creates a corrector
+func NewCorrector(config Config) *Corrector {
+	if config.Prompt == "" {
+		config.Prompt = DefaultPrompt
+	}
+	if config.Location == nil {
+		config.Location = time.UTC
+	}
+	return &Corrector{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+		now:    time.Now,
+	}
+}
+
+// Status returns what the corrector has done so far
+func (c *Corrector) Status() Status {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.resetBudgetIfNewDay()
+	return c.status
+}
+
+// Run corrects contexts from in and sends them to out until in is closed or ctx ends, then
+// closes out. Contexts that are not corrected pass through unchanged.
+func (c *Corrector) Run(ctx context.Context, in <-chan buffer.BufferedContext, out chan<- buffer.BufferedContext) {
+	defer close(out)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case bc, ok := <-in:
+			if !ok {
+				return
+			}
+			select {
+			case out <- c.Correct(ctx, bc):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// Correct returns bc with its text corrected when its confidence is below the threshold and the
+// rate limit and budget allow a request. On any failure bc is returned unchanged.
+func (c *Corrector) Correct(ctx context.Context, bc buffer.BufferedContext) buffer.BufferedContext {
+	if bc.Text == "" || bc.Confidence >= c.config.ConfidenceThreshold {
+		return bc
+	}
+	if !c.allow(estimateTokens(c.config.Prompt) + 2*estimateTokens(bc.Text)) {
+		return bc
+	}
+
+	corrected, tokens, err := c.complete(ctx, bc.Text)
+	if tokens == 0 {
+		tokens = estimateTokens(c.config.Prompt) + estimateTokens(bc.Text) + estimateTokens(corrected)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.resetBudgetIfNewDay()
+	c.status.TokensUsedToday += tokens
+	if err == nil {
+		err = checkCorrection(bc.Text, corrected)
+	}
+	if err != nil {
+		c.status.Failed++
+		c.status.LastError = err.Error()
+		return bc
+	}
+	if corrected == bc.Text {
+		c.status.Unchanged++
+		return bc
+	}
+	c.status.Corrected++
+	bc.CorrectedFrom = bc.Text
+	bc.Text = corrected
+	return bc
+}
+
+// allow reports whether a request estimated to use tokens fits the per-minute limit and the
+// daily budget, and records it against the limit when it does
+func (c *Corrector) allow(tokens int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.resetBudgetIfNewDay()
+
+	if c.config.DailyTokenBudget > 0 && c.status.TokensUsedToday+tokens > c.config.DailyTokenBudget {
+		c.status.OverBudget++
+		return false
+	}
+
+	now := c.now()
+	recent := c.requests[:0]
+	for _, at := range c.requests {
+		if now.Sub(at) < time.Minute {
+			recent = append(recent, at)
+		}
+	}
+	c.requests = recent
+	if c.config.MaxRequestsPerMinute > 0 && len(c.requests) >= c.config.MaxRequestsPerMinute {
+		c.status.RateLimited++
+		return false
+	}
+	c.requests = append(c.requests, now)
+	return true
+}
+
+// resetBudgetIfNewDay starts a new token budget at midnight. Callers hold c.mu.
+func (c *Corrector) resetBudgetIfNewDay() {
+	day := c.now().In(c.config.Location).Format("2006-01-02")
+	if day != c.budgetDay {
+		c.budgetDay = day
+		c.status.TokensUsedToday = 0
+	}
+}
+
+// chatRequest is the body of an OpenAI-compatible chat completions request
+type chatRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	Temperature float64       `json:"temperature"`
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// chatResponse is the part of a chat completions response the corrector reads
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		TotalTokens int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+// complete asks the model to correct text and returns its reply and the tokens it reported using
+func (c *Corrector) complete(ctx context.Context, text string) (string, int, error) {
+	body, err := json.Marshal(chatRequest{
+		Model: c.config.Model,
+		Messages: []chatMessage{
+			{Role: "system", Content: c.config.Prompt},
+			{Role: "user", Content: text},
+		},
+	})
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to encode correction request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create correction request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.APIKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("correction request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+		return "", 0, fmt.Errorf("correction endpoint returned status %d", resp.StatusCode)
+	}
+
+	var result chatResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1024*1024)).Decode(&result); err != nil {
+		return "", 0, fmt.Errorf("failed to decode correction response: %w", err)
+	}
+	if len(result.Choices) == 0 {
+		return "", result.Usage.TotalTokens, fmt.Errorf("correction response has no choices")
+	}
+	return strings.TrimSpace(result.Choices[0].Message.Content), result.Usage.TotalTokens, nil
+}
+
+// checkCorrection rejects replies that cannot be a correction of original
+func checkCorrection(original, corrected string) error {
+	if corrected == "" {
+		return fmt.Errorf("correction endpoint returned empty text")
+	}
+	if len(corrected) > maxGrowth*len(original)+16 {
+		return fmt.Errorf("correction is %d bytes for %d bytes of text; discarded", len(corrected), len(original))
+	}
+	return nil
+}
+
+// estimateTokens approximates the tokens in text, at about four bytes per token
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
@@ -0,0 +1,176 @@
+package correction
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/buffer"
+)
+
+// newTestServer returns an endpoint replying with reply and reporting tokens used, counting requests
+func newTestServer(t *testing.T, reply string, tokens int, requests *int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		var body chatRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Len(t, body.Messages, 2)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{{"message": map[string]string{"role": "assistant", "content": reply}}},
+			"usage":   map[string]int{"total_tokens": tokens},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func lowConfidence(text string) buffer.BufferedContext {
+	return buffer.BufferedContext{Text: text, StartMS: 0, EndMS: 1000, Confidence: 0.4}
+}
+
+func TestCorrector_Correct(t *testing.T) {
+	t.Run("should replace low-confidence text with the correction and keep the original", func(t *testing.T) {
+		// Arrange
+		var requests int32
+		var auth string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			auth = r.Header.Get("Authorization")
+			var body chatRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "test-model", body.Model)
+			assert.Equal(t, DefaultPrompt, body.Messages[0].Content)
+			assert.Equal(t, "text when to five five five", body.Messages[1].Content)
+			w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":" text WIN to 555 \n"}}],"usage":{"total_tokens":42}}`))
+		}))
+		defer server.Close()
+		corrector := NewCorrector(Config{Endpoint: server.URL, Model: "test-model", APIKey: "secret", ConfidenceThreshold: 0.6})
+
+		// Act
+		result := corrector.Correct(context.Background(), lowConfidence("text when to five five five"))
+
+		// Assert
+		assert.Equal(t, "text WIN to 555", result.Text)
+		assert.Equal(t, "text when to five five five", result.CorrectedFrom)
+		assert.Equal(t, "Bearer secret", auth)
+		status := corrector.Status()
+		assert.Equal(t, int64(1), status.Corrected)
+		assert.Equal(t, 42, status.TokensUsedToday)
+	})
+
+	t.Run("should not send contexts at or above the confidence threshold", func(t *testing.T) {
+		var requests int32
+		server := newTestServer(t, "changed", 10, &requests)
+		corrector := NewCorrector(Config{Endpoint: server.URL, ConfidenceThreshold: 0.6})
+		bc := buffer.BufferedContext{Text: "text WIN to 555", EndMS: 1000, Confidence: 0.9}
+
+		result := corrector.Correct(context.Background(), bc)
+
+		assert.Equal(t, bc, result)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("should keep the original text when the correction is unusable", func(t *testing.T) {
+		var requests int32
+		server := newTestServer(t, strings.Repeat("Sure! Here is the corrected transcript. ", 5), 10, &requests)
+		corrector := NewCorrector(Config{Endpoint: server.URL, ConfidenceThreshold: 0.6})
+
+		result := corrector.Correct(context.Background(), lowConfidence("text when to 555"))
+
+		assert.Equal(t, "text when to 555", result.Text)
+		assert.Empty(t, result.CorrectedFrom)
+		assert.Equal(t, int64(1), corrector.Status().Failed)
+	})
+
+	t.Run("should keep the original text when the endpoint fails", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+		corrector := NewCorrector(Config{Endpoint: server.URL, ConfidenceThreshold: 0.6})
+
+		result := corrector.Correct(context.Background(), lowConfidence("text when to 555"))
+
+		assert.Equal(t, "text when to 555", result.Text)
+		status := corrector.Status()
+		assert.Equal(t, int64(1), status.Failed)
+		assert.Contains(t, status.LastError, "status 500")
+	})
+}
+
+func TestCorrector_Limits(t *testing.T) {
+	t.Run("should skip contexts over the per-minute limit until a minute has passed", func(t *testing.T) {
+		// Arrange
+		var requests int32
+		server := newTestServer(t, "text WIN to 555", 10, &requests)
+		corrector := NewCorrector(Config{Endpoint: server.URL, ConfidenceThreshold: 0.6, MaxRequestsPerMinute: 2})
+		now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+		corrector.now = func() time.Time { return now }
+
+		// Act
+		for i := 0; i < 3; i++ {
+			corrector.Correct(context.Background(), lowConfidence("text when to 555"))
+		}
+		now = now.Add(time.Minute)
+		corrector.Correct(context.Background(), lowConfidence("text when to 555"))
+
+		// Assert
+		assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+		assert.Equal(t, int64(1), corrector.Status().RateLimited)
+	})
+
+	t.Run("should stop when the daily token budget is spent and resume the next day", func(t *testing.T) {
+		// Arrange
+		var requests int32
+		server := newTestServer(t, "text WIN to 555", 100, &requests)
+		corrector := NewCorrector(Config{Endpoint: server.URL, ConfidenceThreshold: 0.6, DailyTokenBudget: 150})
+		now := time.Date(2026, 10, 17, 23, 0, 0, 0, time.UTC)
+		corrector.now = func() time.Time { return now }
+
+		// Act
+		corrector.Correct(context.Background(), lowConfidence("text when to 555"))
+		skipped := corrector.Correct(context.Background(), lowConfidence("text when to 555"))
+		now = now.Add(2 * time.Hour)
+		resumed := corrector.Correct(context.Background(), lowConfidence("text when to 555"))
+
+		// Assert
+		assert.Equal(t, "text when to 555", skipped.Text)
+		assert.Equal(t, "text WIN to 555", resumed.Text)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+		status := corrector.Status()
+		assert.Equal(t, int64(1), status.OverBudget)
+		assert.Equal(t, 100, status.TokensUsedToday)
+	})
+}
+
+func TestCorrector_Run(t *testing.T) {
+	t.Run("should pass every context through and close the output", func(t *testing.T) {
+		var requests int32
+		server := newTestServer(t, "text WIN to 555", 10, &requests)
+		corrector := NewCorrector(Config{Endpoint: server.URL, ConfidenceThreshold: 0.6})
+		in := make(chan buffer.BufferedContext, 2)
+		out := make(chan buffer.BufferedContext, 2)
+		in <- lowConfidence("text when to 555")
+		in <- buffer.BufferedContext{Text: "weather next", EndMS: 1000, Confidence: 0.95}
+		close(in)
+
+		corrector.Run(context.Background(), in, out)
+
+		var texts []string
+		for bc := range out {
+			texts = append(texts, bc.Text)
+		}
+		assert.Equal(t, []string{"text WIN to 555", "weather next"}, texts)
+	})
+}
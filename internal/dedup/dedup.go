@@ -0,0 +1,139 @@
+package dedup
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/redis"
+)
+
+// Store remembers keys for a window of time
+type Store interface {
+	// Name identifies the store in logs
+	Name() string
+	// MarkSeen records key for window and reports whether it had already been recorded within its window
+	MarkSeen(key string, window time.Duration) (duplicate bool, err error)
+}
+
+// Window suppresses repeats of the same key within a time window, such as the same contest cue
+// transcribed again from overlapping audio chunks or detected by several instances
+type Window struct {
+	store  Store
+	window time.Duration
+	logger *zap.Logger
+}
+
+// NewWindow creates a Window backed by store
+func NewWindow(store Store, window time.Duration, logger *zap.Logger) *Window {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &Window{store: store, window: window, logger: logger}
+}
+
+// IsDuplicate records key and reports whether it was already seen within the window. Keys are
+// never reported as duplicates when the store fails, since a repeated cue is better than a missed one.
+func (w *Window) IsDuplicate(key string) bool {
+	if key == "" || w.window <= 0 {
+		return false
+	}
+	duplicate, err := w.store.MarkSeen(key, w.window)
+	if err != nil {
+		w.logger.Warn("deduplication store unavailable, allowing cue",
+			zap.String("store", w.store.Name()),
+			zap.String("key", key),
+			zap.Error(err))
+		return false
+	}
+	return duplicate
+}
+
+// MemoryStore is a Store local to this process
+type MemoryStore struct {
+	mu      sync.Mutex
+	expires map[string]time.Time
+	now     func() time.Time
+}
+
+// NewMemoryStore creates an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return NewMemoryStoreWithClock(time.Now)
+}
+
+// NewMemoryStoreWithClock creates an empty MemoryStore that reads the time from now, so window
+// expiry can be tested without waiting
+func NewMemoryStoreWithClock(now func() time.Time) *MemoryStore {
+	return &MemoryStore{expires: make(map[string]time.Time), now: now}
+}
+
+// Name identifies the store in logs
+func (s *MemoryStore) Name() string {
+	return "memory"
+}
+
+// MarkSeen records key for window, pruning expired keys as it goes
+func (s *MemoryStore) MarkSeen(key string, window time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for k, expiry := range s.expires {
+		if now.After(expiry) {
+			delete(s.expires, k)
+		}
+	}
+
+	if _, seen := s.expires[key]; seen {
+		return true, nil
+	}
+	s.expires[key] = now.Add(window)
+	return false, nil
+}
+
+// RedisStore is a Store shared by every instance using the same Redis server and key prefix
+type RedisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisStore creates a RedisStore keeping keys under prefix
+func NewRedisStore(client *redis.Client, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+// Name identifies the store in logs
+func (s *RedisStore) Name() string {
+	return "redis:" + s.client.Address()
+}
+
+// MarkSeen creates the key only if absent, with the window as its TTL
+func (s *RedisStore) MarkSeen(key string, window time.Duration) (bool, error) {
+	reply, err := s.client.String("SET", s.prefix+key, "1", "NX", "PX", fmt.Sprint(window.Milliseconds()))
+	if errors.Is(err, redis.ErrNil) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return reply != "OK", nil
+}
+
+// NewWindowFromConfig creates the cue deduplication Window, or nil when deduplication is disabled.
+// The window is kept in Redis and shared across instances when client is non-nil, in memory otherwise.
+func NewWindowFromConfig(cfg *config.Configuration, client *redis.Client, logger *zap.Logger) *Window {
+	window := time.Duration(cfg.GetDedupWindowSec()) * time.Second
+	if window <= 0 {
+		return nil
+	}
+
+	var store Store = NewMemoryStore()
+	if client != nil {
+		store = NewRedisStore(client, cfg.GetRedisKeyPrefix()+":dedup:")
+	}
+	return NewWindow(store, window, logger)
+}
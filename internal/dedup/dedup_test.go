@@ -0,0 +1,91 @@
+package dedup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/redis"
+	"radiocontestwinner/internal/redis/redistest"
+)
+
+func TestWindow_IsDuplicate(t *testing.T) {
+	t.Run("should suppress repeats within the window and allow them after it", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		store := NewMemoryStoreWithClock(func() time.Time { return now })
+		window := NewWindow(store, time.Minute, zaptest.NewLogger(t))
+
+		// Act & Assert
+		assert.False(t, window.IsDuplicate("abc"))
+		assert.True(t, window.IsDuplicate("abc"))
+		assert.False(t, window.IsDuplicate("def"), "different keys should not collide")
+
+		now = now.Add(2 * time.Minute)
+		assert.False(t, window.IsDuplicate("abc"), "should allow the key again once the window has passed")
+	})
+
+	t.Run("should never report duplicates when disabled or given an empty key", func(t *testing.T) {
+		disabled := NewWindow(NewMemoryStore(), 0, nil)
+		enabled := NewWindow(NewMemoryStore(), time.Minute, nil)
+
+		assert.False(t, disabled.IsDuplicate("abc"))
+		assert.False(t, disabled.IsDuplicate("abc"))
+		assert.False(t, enabled.IsDuplicate(""))
+		assert.False(t, enabled.IsDuplicate(""))
+	})
+}
+
+func TestRedisStore(t *testing.T) {
+	newStore := func(t *testing.T, server *redistest.Server) *RedisStore {
+		client, err := redis.NewClient(redis.Options{Address: server.Addr()})
+		require.NoError(t, err)
+		t.Cleanup(func() { client.Close() })
+		return NewRedisStore(client, "station:dedup:")
+	}
+
+	t.Run("should share the window between instances", func(t *testing.T) {
+		// Arrange
+		server := redistest.NewServer(t)
+		first := NewWindow(newStore(t, server), time.Minute, zaptest.NewLogger(t))
+		second := NewWindow(newStore(t, server), time.Minute, zaptest.NewLogger(t))
+
+		// Act & Assert
+		assert.False(t, first.IsDuplicate("abc"))
+		assert.True(t, second.IsDuplicate("abc"), "another instance should see the key")
+		assert.Equal(t, "1", server.Value("station:dedup:abc"))
+
+		server.Expire("station:dedup:abc")
+		assert.False(t, second.IsDuplicate("abc"))
+	})
+
+	t.Run("should fail open when Redis is failing", func(t *testing.T) {
+		server := redistest.NewServer(t)
+		server.SetFailing(true)
+		window := NewWindow(newStore(t, server), time.Minute, zaptest.NewLogger(t))
+
+		assert.False(t, window.IsDuplicate("abc"))
+		assert.False(t, window.IsDuplicate("abc"))
+	})
+}
+
+func TestNewWindowFromConfig(t *testing.T) {
+	t.Run("should be disabled by default", func(t *testing.T) {
+		assert.Nil(t, NewWindowFromConfig(config.NewConfiguration(), nil, nil))
+	})
+
+	t.Run("should use Redis when a client is given and memory otherwise", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetDedupWindowSec(60)
+		client, err := redis.NewClient(redis.Options{Address: "cache:6379"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "memory", NewWindowFromConfig(cfg, nil, nil).store.Name())
+		assert.Equal(t, "redis:cache:6379", NewWindowFromConfig(cfg, client, nil).store.Name())
+		assert.Equal(t, time.Minute, NewWindowFromConfig(cfg, nil, nil).window)
+	})
+}
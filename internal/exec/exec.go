@@ -0,0 +1,143 @@
+// Package exec starts the FFmpeg, whisper-cli, and helper child processes. It resolves their
+// paths, adds to their environment, bounds how long they run, captures their output, pins them
+// to the configured CPUs, and kills each one together with any processes it spawned, so the
+// processor and transcriber supervise their children the same way on every operating system.
+package exec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"sync/atomic"
+	"time"
+
+	"radiocontestwinner/internal/priority"
+)
+
+// waitDelay is how long Wait waits for the output pipes to close after the process was killed,
+// in case a grandchild that escaped the kill still holds them open
+const waitDelay = 5 * time.Second
+
+// ErrTimeout is returned by Wait when the process was killed for exceeding its timeout
+var ErrTimeout = errors.New("process timed out")
+
+// LookPath resolves name to an executable. Names containing a path separator are checked as
+// given; others are searched on PATH, trying the PATHEXT extensions on Windows.
+func LookPath(name string) (string, error) {
+	return osexec.LookPath(name)
+}
+
+// Cmd is a child process. It embeds os/exec.Cmd for its pipes and fields, but Start, Wait,
+// Run, Output, and CombinedOutput must be called on the Cmd so the process is supervised.
+type Cmd struct {
+	*osexec.Cmd
+
+	// Timeout kills the process once it has run this long; zero leaves it unbounded
+	Timeout time.Duration
+	// OnAffinityError is called when the process could not be pinned to the configured CPUs;
+	// nil ignores the failure
+	OnAffinityError func(err error)
+
+	cancel   context.CancelFunc
+	timer    *time.Timer
+	timedOut atomic.Bool
+}
+
+// Command prepares name, resolved like LookPath, to run with args. The process is killed with
+// its whole tree when ctx is done or the timeout passes.
+func Command(ctx context.Context, name string, args ...string) *Cmd {
+	ctx, cancel := context.WithCancel(ctx)
+	cmd := osexec.CommandContext(ctx, name, args...)
+	cmd.Cancel = func() error {
+		return killTree(cmd.Process)
+	}
+	cmd.WaitDelay = waitDelay
+	return &Cmd{Cmd: cmd, cancel: cancel}
+}
+
+// SetEnv adds vars ("KEY=value") to the environment inherited from this process
+func (c *Cmd) SetEnv(vars ...string) {
+	if c.Env == nil {
+		c.Env = os.Environ()
+	}
+	c.Env = append(c.Env, vars...)
+}
+
+// Start starts the process in its own process group and pins it to the configured CPUs
+func (c *Cmd) Start() error {
+	setProcessGroup(c.Cmd)
+	if err := c.Cmd.Start(); err != nil {
+		c.cancel()
+		return err
+	}
+	if c.Timeout > 0 {
+		c.timer = time.AfterFunc(c.Timeout, func() {
+			c.timedOut.Store(true)
+			c.cancel()
+		})
+	}
+
+	if err := priority.ApplyToChild(c.Process.Pid); err != nil && c.OnAffinityError != nil {
+		c.OnAffinityError(err)
+	}
+	return nil
+}
+
+// Wait waits for the process to exit. It returns an error wrapping ErrTimeout when the
+// process was killed for running too long.
+func (c *Cmd) Wait() error {
+	err := c.Cmd.Wait()
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+	c.cancel()
+	if err != nil && c.timedOut.Load() {
+		return fmt.Errorf("%w after %s: %w", ErrTimeout, c.Timeout, err)
+	}
+	return err
+}
+
+// Run starts the process and waits for it to exit
+func (c *Cmd) Run() error {
+	if err := c.Start(); err != nil {
+		return err
+	}
+	return c.Wait()
+}
+
+// Output runs the process and returns its stdout. Stderr is captured into the returned
+// *os/exec.ExitError when the process fails.
+func (c *Cmd) Output() ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	c.Stdout = &stdout
+	captureStderr := c.Stderr == nil
+	if captureStderr {
+		c.Stderr = &stderr
+	}
+	err := c.Run()
+	var exitErr *osexec.ExitError
+	if captureStderr && errors.As(err, &exitErr) {
+		exitErr.Stderr = stderr.Bytes()
+	}
+	return stdout.Bytes(), err
+}
+
+// CombinedOutput runs the process and returns its stdout and stderr together
+func (c *Cmd) CombinedOutput() ([]byte, error) {
+	var output bytes.Buffer
+	c.Stdout = &output
+	c.Stderr = &output
+	err := c.Run()
+	return output.Bytes(), err
+}
+
+// KillTree kills the process and every process it started. It does nothing before Start.
+func (c *Cmd) KillTree() error {
+	if c.Process == nil {
+		return nil
+	}
+	return killTree(c.Process)
+}
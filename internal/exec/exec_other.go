@@ -0,0 +1,16 @@
+//go:build !unix && !windows
+
+package exec
+
+import (
+	"os"
+	osexec "os/exec"
+)
+
+// setProcessGroup does nothing: process groups are unavailable
+func setProcessGroup(cmd *osexec.Cmd) {}
+
+// killTree kills p; processes it spawned are left running
+func killTree(p *os.Process) error {
+	return p.Kill()
+}
@@ -0,0 +1,26 @@
+//go:build unix
+
+package exec
+
+import (
+	"os"
+	osexec "os/exec"
+	"syscall"
+)
+
+// setProcessGroup starts the process as the leader of a new process group, so killTree
+// reaches the processes it spawns
+func setProcessGroup(cmd *osexec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// killTree kills the process group led by p
+func killTree(p *os.Process) error {
+	if err := syscall.Kill(-p.Pid, syscall.SIGKILL); err != nil && err != syscall.ESRCH {
+		return p.Kill()
+	}
+	return nil
+}
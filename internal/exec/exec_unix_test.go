@@ -0,0 +1,168 @@
+//go:build unix
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmd(t *testing.T) {
+	t.Run("should capture stdout and stderr together", func(t *testing.T) {
+		// Arrange
+		cmd := Command(context.Background(), "sh", "-c", "echo out; echo err >&2")
+
+		// Act
+		output, err := cmd.CombinedOutput()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "out\nerr\n", string(output))
+	})
+
+	t.Run("should attach stderr to the exit error of Output", func(t *testing.T) {
+		// Arrange
+		cmd := Command(context.Background(), "sh", "-c", "echo out; echo broken >&2; exit 3")
+
+		// Act
+		output, err := cmd.Output()
+
+		// Assert
+		assert.Equal(t, "out\n", string(output))
+		var exitErr *osexec.ExitError
+		require.ErrorAs(t, err, &exitErr)
+		assert.Equal(t, 3, exitErr.ExitCode())
+		assert.Equal(t, "broken\n", string(exitErr.Stderr))
+	})
+
+	t.Run("should add to the inherited environment", func(t *testing.T) {
+		// Arrange
+		t.Setenv("EXEC_TEST_INHERITED", "parent")
+		cmd := Command(context.Background(), "sh", "-c", "echo $EXEC_TEST_INHERITED $EXEC_TEST_ADDED")
+		cmd.SetEnv("EXEC_TEST_ADDED=child")
+
+		// Act
+		output, err := cmd.Output()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "parent child\n", string(output))
+	})
+
+	t.Run("should fail to start a missing program", func(t *testing.T) {
+		cmd := Command(context.Background(), "radiocontestwinner-missing-program")
+
+		err := cmd.Run()
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should kill the process and its children after the timeout", func(t *testing.T) {
+		// Arrange
+		pidFile := filepath.Join(t.TempDir(), "child.pid")
+		cmd := Command(context.Background(), "sh", "-c", "sleep 30 & echo $! > "+pidFile+"; wait")
+		cmd.Timeout = 200 * time.Millisecond
+
+		// Act
+		start := time.Now()
+		err := cmd.Run()
+
+		// Assert
+		assert.True(t, errors.Is(err, ErrTimeout), "expected a timeout, got %v", err)
+		assert.Less(t, time.Since(start), 10*time.Second)
+		assertExited(t, pidFile)
+	})
+
+	t.Run("should kill the process tree when the context is cancelled", func(t *testing.T) {
+		// Arrange
+		pidFile := filepath.Join(t.TempDir(), "child.pid")
+		ctx, cancel := context.WithCancel(context.Background())
+		cmd := Command(ctx, "sh", "-c", "sleep 30 & echo $! > "+pidFile+"; wait")
+		require.NoError(t, cmd.Start())
+		waitForFile(t, pidFile)
+
+		// Act
+		cancel()
+		err := cmd.Wait()
+
+		// Assert
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, ErrTimeout))
+		assertExited(t, pidFile)
+	})
+
+	t.Run("should not report an affinity failure when no affinity is configured", func(t *testing.T) {
+		// Arrange
+		var affinityErr error
+		cmd := Command(context.Background(), "true")
+		cmd.OnAffinityError = func(err error) { affinityErr = err }
+
+		// Act
+		err := cmd.Run()
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoError(t, affinityErr)
+	})
+}
+
+func TestLookPath(t *testing.T) {
+	t.Run("should resolve programs on PATH", func(t *testing.T) {
+		path, err := LookPath("sh")
+
+		require.NoError(t, err)
+		assert.True(t, filepath.IsAbs(path))
+	})
+
+	t.Run("should fail for missing programs", func(t *testing.T) {
+		_, err := LookPath("radiocontestwinner-missing-program")
+
+		assert.Error(t, err)
+	})
+}
+
+// waitForFile waits until the shell has written path
+func waitForFile(t *testing.T, path string) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		data, err := os.ReadFile(path)
+		return err == nil && strings.HasSuffix(string(data), "\n")
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// assertExited checks that the process whose pid the shell wrote to pidFile is gone
+func assertExited(t *testing.T, pidFile string) {
+	t.Helper()
+	waitForFile(t, pidFile)
+	data, err := os.ReadFile(pidFile)
+	require.NoError(t, err)
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return exited(pid)
+	}, 5*time.Second, 10*time.Millisecond, "child process %d survived", pid)
+}
+
+// exited reports whether pid is gone or a zombie waiting to be reaped by init
+func exited(pid int) bool {
+	if syscall.Kill(pid, 0) == syscall.ESRCH {
+		return true
+	}
+	stat, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if err != nil {
+		return false
+	}
+	_, fields, _ := strings.Cut(string(stat), ") ")
+	return strings.HasPrefix(fields, "Z")
+}
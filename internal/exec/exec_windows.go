@@ -0,0 +1,27 @@
+//go:build windows
+
+package exec
+
+import (
+	"os"
+	osexec "os/exec"
+	"strconv"
+	"syscall"
+)
+
+// setProcessGroup starts the process in a new process group, so console interrupts meant for
+// this process are not delivered to it
+func setProcessGroup(cmd *osexec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.CreationFlags |= syscall.CREATE_NEW_PROCESS_GROUP
+}
+
+// killTree kills p and its descendants with taskkill, falling back to killing p alone
+func killTree(p *os.Process) error {
+	if err := osexec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(p.Pid)).Run(); err != nil {
+		return p.Kill()
+	}
+	return nil
+}
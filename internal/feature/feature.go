@@ -0,0 +1,214 @@
+// Package feature switches risky behaviors on per deployment, or for a percentage of a fleet,
+// without separate builds. Flags come from the config and optionally from a remote JSON
+// document that is fetched periodically and overrides them.
+package feature
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Flags gating behaviors in this build
+const (
+	AutoTune = "auto_tune" // Tune the transcription chunk duration as with transcription.auto_tune.enabled
+)
+
+// Rollouts maps flag names to the percentage (0 to 100) of instances they are enabled on
+type Rollouts map[string]float64
+
+// ParseRollouts reads flag values as set in the config or a remote document: true or false, or
+// the percentage of instances to enable the flag on, as a number or a string such as "25%"
+func ParseRollouts(values map[string]interface{}) (Rollouts, error) {
+	rollouts := make(Rollouts, len(values))
+	for name, value := range values {
+		percent, err := parsePercent(value)
+		if err != nil {
+			return nil, fmt.Errorf("feature flag %s: %w", name, err)
+		}
+		rollouts[strings.ToLower(name)] = percent
+	}
+	return rollouts, nil
+}
+
+// parsePercent reads one flag value as a percentage of instances
+func parsePercent(value interface{}) (float64, error) {
+	var percent float64
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return 100, nil
+		}
+		return 0, nil
+	case int:
+		percent = float64(v)
+	case int64:
+		percent = float64(v)
+	case float64:
+		percent = v
+	case string:
+		s := strings.TrimSpace(v)
+		if enabled, err := strconv.ParseBool(s); err == nil {
+			return parsePercent(enabled)
+		}
+		parsed, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+		if err != nil {
+			return 0, fmt.Errorf("expected true, false, or a percentage, got %q", v)
+		}
+		percent = parsed
+	default:
+		return 0, fmt.Errorf("expected true, false, or a percentage, got %v", value)
+	}
+	if percent < 0 || percent > 100 {
+		return 0, fmt.Errorf("percentage %v is not between 0 and 100", percent)
+	}
+	return percent, nil
+}
+
+// Set holds the feature flags of one instance. The zero value and a nil Set have every flag off.
+type Set struct {
+	instance string
+
+	mu     sync.RWMutex
+	local  Rollouts
+	remote Rollouts // Overrides local; nil until a remote document is fetched
+}
+
+// NewSet creates the flags of instance, whose identity places it inside or outside each
+// percentage rollout, with the config's rollouts
+func NewSet(instance string, local Rollouts) *Set {
+	return &Set{instance: instance, local: local}
+}
+
+// Enabled reports whether flag is on for this instance. An instance stays on the same side of a
+// rollout while the percentage grows, and different flags split the fleet differently.
+func (s *Set) Enabled(flag string) bool {
+	if s == nil {
+		return false
+	}
+	flag = strings.ToLower(flag)
+	s.mu.RLock()
+	percent, ok := s.remote[flag]
+	if !ok {
+		percent = s.local[flag]
+	}
+	s.mu.RUnlock()
+
+	switch {
+	case percent <= 0:
+		return false
+	case percent >= 100:
+		return true
+	}
+	return bucket(flag, s.instance) < percent
+}
+
+// bucket places an instance in [0, 100) for flag
+func bucket(flag, instance string) float64 {
+	h := fnv.New32a()
+	h.Write([]byte(flag + "/" + instance))
+	return float64(h.Sum32()%10000) / 100
+}
+
+// States returns every known flag with whether it is on for this instance, for health output
+func (s *Set) States() map[string]bool {
+	if s == nil {
+		return map[string]bool{}
+	}
+	s.mu.RLock()
+	names := make([]string, 0, len(s.local)+len(s.remote))
+	for name := range s.local {
+		names = append(names, name)
+	}
+	for name := range s.remote {
+		names = append(names, name)
+	}
+	s.mu.RUnlock()
+
+	sort.Strings(names)
+	states := make(map[string]bool, len(names))
+	for _, name := range names {
+		states[name] = s.Enabled(name)
+	}
+	return states
+}
+
+// Replace sets the rollouts fetched from the remote document, overriding the config's
+func (s *Set) Replace(remote Rollouts) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remote = remote
+}
+
+// Fetch downloads a flags document from url: a JSON object of flag values, or one with the
+// object under "flags"
+func Fetch(ctx context.Context, client *http.Client, url string) (Rollouts, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create feature flags request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feature flags: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch feature flags: status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feature flags: %w", err)
+	}
+
+	var document map[string]interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse feature flags: %w", err)
+	}
+	if flags, ok := document["flags"].(map[string]interface{}); ok {
+		document = flags
+	}
+	return ParseRollouts(document)
+}
+
+// Refresh replaces the remote rollouts with the ones published at url
+func (s *Set) Refresh(ctx context.Context, client *http.Client, url string) error {
+	remote, err := Fetch(ctx, client, url)
+	if err != nil {
+		return err
+	}
+	s.Replace(remote)
+	return nil
+}
+
+// RunRefresh fetches the flags from url now and then every interval until ctx is cancelled. A
+// failed refresh keeps the flags already loaded.
+func (s *Set) RunRefresh(ctx context.Context, url string, interval time.Duration, logger *zap.Logger) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.Refresh(ctx, client, url); err != nil {
+			logger.Warn("failed to refresh feature flags, keeping the current ones",
+				zap.String("url", url),
+				zap.Error(err))
+		} else {
+			logger.Debug("refreshed feature flags", zap.Any("flags", s.States()))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package feature
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParseRollouts(t *testing.T) {
+	t.Run("should read booleans and percentages", func(t *testing.T) {
+		rollouts, err := ParseRollouts(map[string]interface{}{
+			"Auto_Tune": true, "vad": 25, "native": 12.5, "off": "false", "canary": "5%",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, Rollouts{"auto_tune": 100, "vad": 25, "native": 12.5, "off": 0, "canary": 5}, rollouts)
+	})
+
+	t.Run("should reject values that are not a percentage", func(t *testing.T) {
+		_, err := ParseRollouts(map[string]interface{}{"vad": 150})
+		assert.ErrorContains(t, err, "feature flag vad: percentage 150 is not between 0 and 100")
+
+		_, err = ParseRollouts(map[string]interface{}{"vad": "sometimes"})
+		assert.ErrorContains(t, err, `expected true, false, or a percentage, got "sometimes"`)
+	})
+}
+
+func TestSet_Enabled(t *testing.T) {
+	t.Run("should switch flags fully on and off", func(t *testing.T) {
+		set := NewSet("host-1", Rollouts{"auto_tune": 100, "vad": 0})
+
+		assert.True(t, set.Enabled("auto_tune"))
+		assert.True(t, set.Enabled("AUTO_TUNE"))
+		assert.False(t, set.Enabled("vad"))
+		assert.False(t, set.Enabled("unknown"))
+	})
+
+	t.Run("should leave every flag off in a nil set", func(t *testing.T) {
+		var set *Set
+
+		assert.False(t, set.Enabled(AutoTune))
+		assert.Empty(t, set.States())
+	})
+
+	t.Run("should enable a percentage rollout on about that share of instances", func(t *testing.T) {
+		// Arrange
+		enabled := 0
+
+		// Act
+		for i := 0; i < 1000; i++ {
+			if NewSet(fmt.Sprintf("host-%d", i), Rollouts{"vad": 25}).Enabled("vad") {
+				enabled++
+			}
+		}
+
+		// Assert
+		assert.InDelta(t, 250, enabled, 50)
+	})
+
+	t.Run("should keep instances in a rollout as it grows", func(t *testing.T) {
+		for i := 0; i < 200; i++ {
+			instance := fmt.Sprintf("host-%d", i)
+			if NewSet(instance, Rollouts{"vad": 10}).Enabled("vad") {
+				assert.True(t, NewSet(instance, Rollouts{"vad": 50}).Enabled("vad"), instance)
+			}
+		}
+	})
+
+	t.Run("should let remote rollouts override the config", func(t *testing.T) {
+		set := NewSet("host-1", Rollouts{"auto_tune": 100, "vad": 100})
+
+		set.Replace(Rollouts{"auto_tune": 0})
+
+		assert.False(t, set.Enabled("auto_tune"))
+		assert.True(t, set.Enabled("vad"))
+		assert.Equal(t, map[string]bool{"auto_tune": false, "vad": true}, set.States())
+	})
+}
+
+func TestFetch(t *testing.T) {
+	t.Run("should read a flat object or one under flags", func(t *testing.T) {
+		for _, body := range []string{`{"vad": 25, "auto_tune": true}`, `{"flags": {"vad": 25, "auto_tune": true}}`} {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(body))
+			}))
+
+			rollouts, err := Fetch(context.Background(), server.Client(), server.URL)
+			server.Close()
+
+			require.NoError(t, err, body)
+			assert.Equal(t, Rollouts{"vad": 25, "auto_tune": 100}, rollouts)
+		}
+	})
+
+	t.Run("should fail on an error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		_, err := Fetch(context.Background(), server.Client(), server.URL)
+
+		assert.ErrorContains(t, err, "status 404")
+	})
+}
+
+func TestSet_RunRefresh(t *testing.T) {
+	t.Run("should fetch the remote flags immediately and keep them after a failure", func(t *testing.T) {
+		// Arrange
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests > 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Write([]byte(`{"auto_tune": true}`))
+		}))
+		defer server.Close()
+		set := NewSet("host-1", nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+
+		// Act
+		go func() {
+			set.RunRefresh(ctx, server.URL, 10*time.Millisecond, zap.NewNop())
+			close(done)
+		}()
+
+		// Assert
+		require.Eventually(t, func() bool { return set.Enabled(AutoTune) }, time.Second, 5*time.Millisecond)
+		time.Sleep(50 * time.Millisecond)
+		assert.True(t, set.Enabled(AutoTune))
+		cancel()
+		<-done
+	})
+}
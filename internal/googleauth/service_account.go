@@ -0,0 +1,191 @@
+package googleauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultTokenURI = "https://oauth2.googleapis.com/token"
+
+// ServiceAccountKey holds the fields of a Google service-account JSON key file we need
+type ServiceAccountKey struct {
+	ClientEmail  string `json:"client_email"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// TokenSource exchanges a signed service-account JWT for OAuth2 access tokens and caches them
+type TokenSource struct {
+	key       ServiceAccountKey
+	signer    *rsa.PrivateKey
+	scopes    []string
+	client    *http.Client
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+	now       func() time.Time
+}
+
+// LoadServiceAccountKey reads a service-account JSON key file
+func LoadServiceAccountKey(path string) (ServiceAccountKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ServiceAccountKey{}, fmt.Errorf("failed to read service account key %s: %w", path, err)
+	}
+
+	var key ServiceAccountKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return ServiceAccountKey{}, fmt.Errorf("failed to parse service account key %s: %w", path, err)
+	}
+	if key.ClientEmail == "" || key.PrivateKey == "" {
+		return ServiceAccountKey{}, fmt.Errorf("service account key %s is missing client_email or private_key", path)
+	}
+	if key.TokenURI == "" {
+		key.TokenURI = defaultTokenURI
+	}
+	return key, nil
+}
+
+// NewTokenSource creates a TokenSource for the given key and OAuth2 scopes
+func NewTokenSource(key ServiceAccountKey, scopes []string, client *http.Client) (*TokenSource, error) {
+	signer, err := parsePrivateKey(key.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	if key.TokenURI == "" {
+		key.TokenURI = defaultTokenURI
+	}
+	return &TokenSource{
+		key:    key,
+		signer: signer,
+		scopes: scopes,
+		client: client,
+		now:    time.Now,
+	}, nil
+}
+
+// Token returns a valid access token, fetching a new one when the cached token is about to expire
+func (ts *TokenSource) Token(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token != "" && ts.now().Before(ts.expiresAt.Add(-time.Minute)) {
+		return ts.token, nil
+	}
+
+	assertion, err := ts.signedJWT()
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ts.key.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := ts.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("token endpoint returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if result.AccessToken == "" {
+		return "", fmt.Errorf("token response did not include an access token")
+	}
+
+	ts.token = result.AccessToken
+	ts.expiresAt = ts.now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	return ts.token, nil
+}
+
+// signedJWT builds the RS256-signed JWT assertion for the token exchange
+func (ts *TokenSource) signedJWT() (string, error) {
+	now := ts.now()
+	header := map[string]string{"alg": "RS256", "typ": "JWT"}
+	if ts.key.PrivateKeyID != "" {
+		header["kid"] = ts.key.PrivateKeyID
+	}
+	claims := map[string]interface{}{
+		"iss":   ts.key.ClientEmail,
+		"scope": strings.Join(ts.scopes, " "),
+		"aud":   ts.key.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWT header: %w", err)
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWT claims: %w", err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, ts.signer, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign JWT: %w", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parsePrivateKey decodes a PEM-encoded PKCS#8 or PKCS#1 RSA private key
+func parsePrivateKey(pemKey string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil {
+		return nil, fmt.Errorf("service account private key is not valid PEM")
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("service account private key is not an RSA key")
+		}
+		return rsaKey, nil
+	}
+
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse service account private key: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,150 @@
+package googleauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(t *testing.T, tokenURI string) (ServiceAccountKey, *rsa.PrivateKey) {
+	t.Helper()
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	return ServiceAccountKey{
+		ClientEmail:  "exporter@project.iam.gserviceaccount.com",
+		PrivateKeyID: "key-1",
+		PrivateKey:   string(pemKey),
+		TokenURI:     tokenURI,
+	}, privateKey
+}
+
+func TestLoadServiceAccountKey(t *testing.T) {
+	t.Run("should load key file and default the token URI", func(t *testing.T) {
+		// Arrange
+		key, _ := testKey(t, "")
+		data, err := json.Marshal(key)
+		require.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "sa.json")
+		require.NoError(t, os.WriteFile(path, data, 0600))
+
+		// Act
+		loaded, err := LoadServiceAccountKey(path)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, key.ClientEmail, loaded.ClientEmail)
+		assert.Equal(t, defaultTokenURI, loaded.TokenURI)
+	})
+
+	t.Run("should reject key file without private key", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "sa.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"client_email":"a@b"}`), 0600))
+
+		// Act
+		_, err := LoadServiceAccountKey(path)
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("should fail for missing file", func(t *testing.T) {
+		_, err := LoadServiceAccountKey(filepath.Join(t.TempDir(), "missing.json"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestTokenSource_Token(t *testing.T) {
+	t.Run("should exchange a signed JWT and cache the access token", func(t *testing.T) {
+		// Arrange
+		var requests int32
+		var privateKey *rsa.PrivateKey
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.Form.Get("grant_type"))
+
+			parts := strings.Split(r.Form.Get("assertion"), ".")
+			require.Len(t, parts, 3)
+			signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+			require.NoError(t, err)
+			digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+			assert.NoError(t, rsa.VerifyPKCS1v15(&privateKey.PublicKey, crypto.SHA256, digest[:], signature))
+
+			claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+			require.NoError(t, err)
+			var claims map[string]interface{}
+			require.NoError(t, json.Unmarshal(claimsJSON, &claims))
+			assert.Equal(t, "exporter@project.iam.gserviceaccount.com", claims["iss"])
+			assert.Equal(t, "scope-a scope-b", claims["scope"])
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"access_token":"token-123","expires_in":3600}`))
+		}))
+		defer server.Close()
+
+		key, pk := testKey(t, server.URL)
+		privateKey = pk
+		ts, err := NewTokenSource(key, []string{"scope-a", "scope-b"}, server.Client())
+		require.NoError(t, err)
+
+		// Act
+		first, err1 := ts.Token(context.Background())
+		second, err2 := ts.Token(context.Background())
+
+		// Assert
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.Equal(t, "token-123", first)
+		assert.Equal(t, "token-123", second)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("should return error when the token endpoint rejects the assertion", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		key, _ := testKey(t, server.URL)
+		ts, err := NewTokenSource(key, []string{"scope"}, server.Client())
+		require.NoError(t, err)
+
+		// Act
+		_, err = ts.Token(context.Background())
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 400")
+	})
+}
+
+func TestNewTokenSource(t *testing.T) {
+	t.Run("should reject an invalid private key", func(t *testing.T) {
+		_, err := NewTokenSource(ServiceAccountKey{ClientEmail: "a@b", PrivateKey: "not pem"}, nil, nil)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,181 @@
+package health
+
+import (
+	"sync"
+	"time"
+)
+
+// Level is how far a sustained failure has escalated
+type Level string
+
+const (
+	// LevelOK means the pipeline is healthy
+	LevelOK Level = "ok"
+	// LevelFailing means the pipeline is unhealthy but not yet for long enough to alert
+	LevelFailing Level = "failing"
+	// LevelWarning means the failure has lasted long enough to warn about
+	LevelWarning Level = "warning"
+	// LevelPage means the failure has lasted long enough to page someone
+	LevelPage Level = "page"
+)
+
+// BudgetConfig holds the escalation thresholds and daily failure budget used by BudgetTracker
+type BudgetConfig struct {
+	WarnAfter     time.Duration  // Sustained failure before escalating to a warning
+	PageAfter     time.Duration  // Sustained failure before escalating to a page
+	BudgetPercent float64        // Share of each day that may be spent unhealthy
+	Location      *time.Location // Zone whose midnight starts a new day; UTC when nil
+}
+
+// BudgetStatus is a snapshot of the current failure and the day's failure budget
+type BudgetStatus struct {
+	Level            Level         `json:"level"`
+	FailingSince     time.Time     `json:"failing_since"`     // Zero while healthy
+	Day              string        `json:"day"`               // YYYY-MM-DD in the budget's zone
+	Unhealthy        time.Duration `json:"unhealthy"`         // Time spent unhealthy today
+	UnhealthyPercent float64       `json:"unhealthy_percent"` // Share of today's observed time spent unhealthy
+	BudgetUsed       float64       `json:"budget_used"`       // Share of the daily budget used, in percent
+	Exhausted        bool          `json:"exhausted"`
+}
+
+// Transition describes what an observation changed
+type Transition struct {
+	From, To        Level
+	BudgetExhausted bool // The daily budget ran out with this observation
+	Status          BudgetStatus
+}
+
+// Escalated reports whether the failure reached a higher level
+func (tr Transition) Escalated() bool {
+	return tr.To != tr.From && tr.To != LevelOK
+}
+
+// Recovered reports whether the pipeline became healthy after failing
+func (tr Transition) Recovered() bool {
+	return tr.From != LevelOK && tr.To == LevelOK
+}
+
+// BudgetTracker follows the health of the pipeline over time. It escalates a failure as it
+// persists, so brief blips are only logged while real outages page, and it keeps a daily
+// budget of unhealthy time that resets at midnight.
+type BudgetTracker struct {
+	mu     sync.Mutex
+	config BudgetConfig
+
+	level        Level
+	failingSince time.Time
+	lastAt       time.Time
+	lastHealthy  bool
+	dayStart     time.Time
+	observed     time.Duration
+	unhealthy    time.Duration
+	exhausted    bool
+}
+
+// NewBudgetTracker creates a new BudgetTracker with the given configuration
+func NewBudgetTracker(config BudgetConfig) *BudgetTracker {
+	if config.Location == nil {
+		config.Location = time.UTC
+	}
+	return &BudgetTracker{config: config, level: LevelOK}
+}
+
+// Observe records the pipeline's health at now. The time since the previous observation is
+// counted with the health seen then.
+func (bt *BudgetTracker) Observe(healthy bool, now time.Time) Transition {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	bt.account(now)
+	bt.lastAt = now
+	bt.lastHealthy = healthy
+
+	from := bt.level
+	if healthy {
+		bt.level = LevelOK
+		bt.failingSince = time.Time{}
+	} else {
+		if bt.failingSince.IsZero() {
+			bt.failingSince = now
+		}
+		bt.level = bt.escalation(now.Sub(bt.failingSince))
+	}
+
+	exhausted := !bt.exhausted && bt.overBudget()
+	if exhausted {
+		bt.exhausted = true
+	}
+	return Transition{From: from, To: bt.level, BudgetExhausted: exhausted, Status: bt.status()}
+}
+
+// Status returns the current failure level and the day's budget
+func (bt *BudgetTracker) Status() BudgetStatus {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+	return bt.status()
+}
+
+// account adds the time since the previous observation to the day it fell in, starting a
+// new day at midnight
+func (bt *BudgetTracker) account(now time.Time) {
+	local := now.In(bt.config.Location)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, bt.config.Location)
+	if !bt.dayStart.Equal(midnight) {
+		bt.dayStart = midnight
+		bt.observed, bt.unhealthy, bt.exhausted = 0, 0, false
+	}
+	if bt.lastAt.IsZero() || !now.After(bt.lastAt) {
+		return
+	}
+
+	from := bt.lastAt
+	if from.Before(midnight) {
+		from = midnight
+	}
+	elapsed := now.Sub(from)
+	bt.observed += elapsed
+	if !bt.lastHealthy {
+		bt.unhealthy += elapsed
+	}
+}
+
+// escalation returns the level of a failure that has lasted for d
+func (bt *BudgetTracker) escalation(d time.Duration) Level {
+	switch {
+	case bt.config.PageAfter > 0 && d >= bt.config.PageAfter:
+		return LevelPage
+	case bt.config.WarnAfter > 0 && d >= bt.config.WarnAfter:
+		return LevelWarning
+	}
+	return LevelFailing
+}
+
+// allowance returns the unhealthy time allowed per day
+func (bt *BudgetTracker) allowance() time.Duration {
+	return time.Duration(bt.config.BudgetPercent / 100 * float64(24*time.Hour))
+}
+
+// overBudget reports whether today's unhealthy time exceeds the allowance
+func (bt *BudgetTracker) overBudget() bool {
+	return bt.config.BudgetPercent > 0 && bt.unhealthy > bt.allowance()
+}
+
+// status builds the BudgetStatus; bt.mu must be held
+func (bt *BudgetTracker) status() BudgetStatus {
+	status := BudgetStatus{
+		Level:        bt.level,
+		FailingSince: bt.failingSince,
+		Unhealthy:    bt.unhealthy,
+		Exhausted:    bt.exhausted,
+	}
+	if !bt.dayStart.IsZero() {
+		status.Day = bt.dayStart.Format("2006-01-02")
+	}
+	if bt.observed > 0 {
+		status.UnhealthyPercent = float64(bt.unhealthy) / float64(bt.observed) * 100
+	}
+	if allowance := bt.allowance(); allowance > 0 {
+		status.BudgetUsed = float64(bt.unhealthy) / float64(allowance) * 100
+	}
+	return status
+}
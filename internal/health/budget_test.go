@@ -0,0 +1,107 @@
+package health
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudgetTracker_Escalation(t *testing.T) {
+	config := BudgetConfig{WarnAfter: 10 * time.Minute, PageAfter: 30 * time.Minute, BudgetPercent: 1}
+	start := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should only log a brief failure", func(t *testing.T) {
+		// Arrange
+		tracker := NewBudgetTracker(config)
+		tracker.Observe(true, start)
+
+		// Act
+		failing := tracker.Observe(false, start.Add(time.Minute))
+		recovered := tracker.Observe(true, start.Add(3*time.Minute))
+
+		// Assert
+		assert.True(t, failing.Escalated())
+		assert.Equal(t, LevelFailing, failing.To)
+		assert.True(t, recovered.Recovered())
+		assert.Equal(t, LevelFailing, recovered.From)
+	})
+
+	t.Run("should warn and then page as a failure persists", func(t *testing.T) {
+		// Arrange
+		tracker := NewBudgetTracker(config)
+		tracker.Observe(false, start)
+
+		// Act
+		still := tracker.Observe(false, start.Add(5*time.Minute))
+		warn := tracker.Observe(false, start.Add(10*time.Minute))
+		page := tracker.Observe(false, start.Add(30*time.Minute))
+		again := tracker.Observe(false, start.Add(40*time.Minute))
+
+		// Assert
+		assert.False(t, still.Escalated())
+		assert.True(t, warn.Escalated())
+		assert.Equal(t, LevelWarning, warn.To)
+		assert.True(t, page.Escalated())
+		assert.Equal(t, LevelPage, page.To)
+		assert.False(t, again.Escalated())
+		assert.Equal(t, start, again.Status.FailingSince)
+	})
+}
+
+func TestBudgetTracker_Budget(t *testing.T) {
+	start := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should report the share of the day spent unhealthy", func(t *testing.T) {
+		// Arrange
+		tracker := NewBudgetTracker(BudgetConfig{BudgetPercent: 1})
+
+		// Act
+		tracker.Observe(true, start)
+		tracker.Observe(false, start.Add(30*time.Minute))
+		tracker.Observe(true, start.Add(40*time.Minute))
+		status := tracker.Status()
+
+		// Assert
+		assert.Equal(t, "2026-03-02", status.Day)
+		assert.Equal(t, 10*time.Minute, status.Unhealthy)
+		assert.InDelta(t, 25.0, status.UnhealthyPercent, 0.001)
+		assert.InDelta(t, 10.0/14.4*100, status.BudgetUsed, 0.001)
+		assert.False(t, status.Exhausted)
+	})
+
+	t.Run("should report the budget running out once per day", func(t *testing.T) {
+		// Arrange
+		tracker := NewBudgetTracker(BudgetConfig{BudgetPercent: 1})
+		tracker.Observe(false, start)
+
+		// Act
+		under := tracker.Observe(false, start.Add(14*time.Minute))
+		over := tracker.Observe(false, start.Add(15*time.Minute))
+		later := tracker.Observe(false, start.Add(20*time.Minute))
+
+		// Assert
+		assert.False(t, under.BudgetExhausted)
+		assert.True(t, over.BudgetExhausted)
+		assert.False(t, later.BudgetExhausted)
+		assert.True(t, later.Status.Exhausted)
+	})
+
+	t.Run("should start a new budget at midnight in the configured zone", func(t *testing.T) {
+		// Arrange
+		location, err := time.LoadLocation("America/New_York")
+		require.NoError(t, err)
+		tracker := NewBudgetTracker(BudgetConfig{BudgetPercent: 1, Location: location})
+		beforeMidnight := time.Date(2026, 3, 2, 23, 50, 0, 0, location)
+		tracker.Observe(false, beforeMidnight)
+
+		// Act
+		transition := tracker.Observe(false, beforeMidnight.Add(20*time.Minute))
+
+		// Assert
+		assert.Equal(t, "2026-03-03", transition.Status.Day)
+		assert.Equal(t, 10*time.Minute, transition.Status.Unhealthy)
+		assert.Equal(t, beforeMidnight, transition.Status.FailingSince)
+	})
+}
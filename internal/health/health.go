@@ -0,0 +1,153 @@
+// Package health defines what "healthy" means for the application. The heartbeat uses it to
+// decide what it writes to the health file, and the -health check uses it to judge that file,
+// so the two always agree.
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultFile is where the heartbeat writes the health status read by the -health check
+const DefaultFile = "/tmp/radiocontestwinner-health.json"
+
+// MaxAge is how old the health file may get before the writer is considered stuck. The
+// heartbeat rewrites it every 30 seconds.
+const MaxAge = 90 * time.Second
+
+// Overall health states, as written to the status field of the health file
+const (
+	StateHealthy   = "healthy"
+	StateDegraded  = "degraded"
+	StateUnhealthy = "unhealthy"
+)
+
+// TenantFile returns where one tenant's heartbeat writes its health status
+func TenantFile(tenant string) string {
+	return strings.TrimSuffix(DefaultFile, ".json") + "-" + tenant + ".json"
+}
+
+// PipelineHealthy determines whether a pipeline is healthy from its health status fields:
+// once transcription has started it must be healthy, and once audio processing has started
+// the stream must be connected. Missing fields count as not started.
+func PipelineHealthy(status map[string]interface{}) bool {
+	streamConnected, _ := status["stream_connected"].(bool)
+	transcriptionHealthy, _ := status["transcription_healthy"].(bool)
+	audioProcessingActive, _ := status["audio_processing_active"].(bool)
+
+	// If we have started transcribing, transcription must be healthy
+	if count(status["total_transcriptions"]) > 0 && !transcriptionHealthy {
+		return false
+	}
+
+	// If audio processing started, stream should be connected
+	if audioProcessingActive && !streamConnected {
+		return false
+	}
+
+	return true
+}
+
+// count reads a counter written by the application (int64) or decoded from the health file
+// (float64)
+func count(value interface{}) int64 {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case float64:
+		return int64(v)
+	}
+	return 0
+}
+
+// State summarizes a health status as StateHealthy, StateDegraded, or StateUnhealthy from its
+// healthy and degraded fields
+func State(status map[string]interface{}) string {
+	if healthy, _ := status["healthy"].(bool); !healthy {
+		return StateUnhealthy
+	}
+	if degraded, _ := status["degraded"].(bool); degraded {
+		return StateDegraded
+	}
+	return StateHealthy
+}
+
+// Result is the verdict of the -health check
+type Result struct {
+	Passed      bool   // Whether the check passes; degraded and paused instances pass
+	Message     string // One-line verdict, prefixed with HEALTHY, DEGRADED, PAUSED, or UNHEALTHY
+	ShowDetails bool   // Whether the health file contents help explain the verdict
+}
+
+// CheckFile judges the health file at path as of now
+func CheckFile(path string, now time.Time) (Result, []byte) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return fail("Health status file not found (%s)", path), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fail("Failed to read health file: %v", err), nil
+	}
+	return Check(data, now), data
+}
+
+// Check judges health file contents as of now. The file must be fresh and report healthy;
+// a paused or degraded instance passes with a warning.
+func Check(data []byte, now time.Time) Result {
+	var status map[string]interface{}
+	if err := json.Unmarshal(data, &status); err != nil {
+		return fail("Failed to parse health file: %v", err)
+	}
+
+	timestampStr, ok := status["health_check_timestamp"].(string)
+	if !ok {
+		return fail("Health file missing timestamp")
+	}
+	timestamp, err := time.Parse(time.RFC3339, timestampStr)
+	if err != nil {
+		return fail("Invalid timestamp format: %v", err)
+	}
+	age := now.Sub(timestamp)
+	if age > MaxAge {
+		return fail("Health file is stale (last update: %v ago)", age)
+	}
+
+	if _, ok := status["healthy"].(bool); !ok {
+		return fail("Health status missing healthy field")
+	}
+	state := State(status)
+	if state == StateUnhealthy {
+		result := fail("Application reported unhealthy status")
+		result.ShowDetails = true
+		return result
+	}
+
+	// A paused pipeline is deliberately idle, not failing
+	if paused, _ := status["paused"].(bool); paused {
+		return Result{Passed: true, Message: fmt.Sprintf(
+			"PAUSED: Application is connected but transcription is paused since %v (last check: %v ago)",
+			status["paused_since"], age)}
+	}
+
+	// Degraded systems are still running, so the health check passes with a warning. Files
+	// written before the degraded field existed only carry the status string.
+	if written, _ := status["status"].(string); state == StateDegraded || written == StateDegraded {
+		return Result{
+			Passed:      true,
+			Message:     fmt.Sprintf("DEGRADED: Application is running with anomalous transcription output (last check: %v ago)", age),
+			ShowDetails: true,
+		}
+	}
+
+	return Result{Passed: true, Message: fmt.Sprintf("HEALTHY: Application is functioning normally (last check: %v ago)", age)}
+}
+
+// fail returns a failing result with an UNHEALTHY message
+func fail(format string, args ...interface{}) Result {
+	return Result{Message: "UNHEALTHY: " + fmt.Sprintf(format, args...)}
+}
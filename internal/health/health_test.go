@@ -0,0 +1,161 @@
+package health
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelineHealthy(t *testing.T) {
+	t.Run("should be healthy before processing starts", func(t *testing.T) {
+		assert.True(t, PipelineHealthy(map[string]interface{}{}))
+	})
+
+	t.Run("should be unhealthy when transcription has started and is unhealthy", func(t *testing.T) {
+		assert.False(t, PipelineHealthy(map[string]interface{}{
+			"stream_connected":        true,
+			"audio_processing_active": true,
+			"transcription_healthy":   false,
+			"total_transcriptions":    int64(3),
+		}))
+	})
+
+	t.Run("should be unhealthy when audio is processing without a stream", func(t *testing.T) {
+		assert.False(t, PipelineHealthy(map[string]interface{}{
+			"stream_connected":        false,
+			"audio_processing_active": true,
+			"transcription_healthy":   true,
+			"total_transcriptions":    int64(0),
+		}))
+	})
+
+	t.Run("should agree on a status read back from the health file", func(t *testing.T) {
+		// Arrange
+		written := map[string]interface{}{
+			"stream_connected":        true,
+			"audio_processing_active": true,
+			"transcription_healthy":   false,
+			"total_transcriptions":    int64(7),
+		}
+		data, err := json.Marshal(written)
+		require.NoError(t, err)
+
+		// Act
+		var read map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &read))
+
+		// Assert
+		assert.Equal(t, PipelineHealthy(written), PipelineHealthy(read))
+	})
+}
+
+func TestState(t *testing.T) {
+	assert.Equal(t, StateUnhealthy, State(map[string]interface{}{"healthy": false, "degraded": true}))
+	assert.Equal(t, StateDegraded, State(map[string]interface{}{"healthy": true, "degraded": true}))
+	assert.Equal(t, StateHealthy, State(map[string]interface{}{"healthy": true, "degraded": false}))
+	assert.Equal(t, StateUnhealthy, State(map[string]interface{}{}))
+}
+
+func TestCheck(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	encode := func(status map[string]interface{}) []byte {
+		data, err := json.Marshal(status)
+		require.NoError(t, err)
+		return data
+	}
+
+	t.Run("should pass a fresh healthy status", func(t *testing.T) {
+		result := Check(encode(map[string]interface{}{
+			"healthy":                true,
+			"health_check_timestamp": now.Add(-10 * time.Second).Format(time.RFC3339),
+		}), now)
+
+		assert.True(t, result.Passed)
+		assert.Equal(t, "HEALTHY: Application is functioning normally (last check: 10s ago)", result.Message)
+	})
+
+	t.Run("should fail a stale status", func(t *testing.T) {
+		result := Check(encode(map[string]interface{}{
+			"healthy":                true,
+			"health_check_timestamp": now.Add(-MaxAge - time.Second).Format(time.RFC3339),
+		}), now)
+
+		assert.False(t, result.Passed)
+		assert.Contains(t, result.Message, "UNHEALTHY: Health file is stale")
+	})
+
+	t.Run("should fail with details when the application reported unhealthy", func(t *testing.T) {
+		result := Check(encode(map[string]interface{}{
+			"healthy":                false,
+			"health_check_timestamp": now.Format(time.RFC3339),
+		}), now)
+
+		assert.False(t, result.Passed)
+		assert.True(t, result.ShowDetails)
+	})
+
+	t.Run("should pass a degraded status with details", func(t *testing.T) {
+		result := Check(encode(map[string]interface{}{
+			"healthy":                true,
+			"degraded":               true,
+			"health_check_timestamp": now.Format(time.RFC3339),
+		}), now)
+
+		assert.True(t, result.Passed)
+		assert.True(t, result.ShowDetails)
+		assert.Contains(t, result.Message, "DEGRADED:")
+	})
+
+	t.Run("should pass a paused status", func(t *testing.T) {
+		result := Check(encode(map[string]interface{}{
+			"healthy":                true,
+			"paused":                 true,
+			"paused_since":           "2026-10-17T11:30:00Z",
+			"health_check_timestamp": now.Format(time.RFC3339),
+		}), now)
+
+		assert.True(t, result.Passed)
+		assert.Contains(t, result.Message, "PAUSED:")
+	})
+
+	t.Run("should fail a status without a healthy field", func(t *testing.T) {
+		result := Check(encode(map[string]interface{}{
+			"health_check_timestamp": now.Format(time.RFC3339),
+		}), now)
+
+		assert.False(t, result.Passed)
+		assert.Equal(t, "UNHEALTHY: Health status missing healthy field", result.Message)
+	})
+}
+
+func TestCheckFile(t *testing.T) {
+	t.Run("should fail when the file does not exist", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "missing.json")
+
+		result, data := CheckFile(path, time.Now())
+
+		assert.False(t, result.Passed)
+		assert.Nil(t, data)
+		assert.Contains(t, result.Message, "not found")
+	})
+
+	t.Run("should return the file contents with the result", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "health.json")
+		contents := []byte(`{"healthy":true,"health_check_timestamp":"` + time.Now().Format(time.RFC3339) + `"}`)
+		require.NoError(t, os.WriteFile(path, contents, 0644))
+
+		result, data := CheckFile(path, time.Now())
+
+		assert.True(t, result.Passed)
+		assert.Equal(t, contents, data)
+	})
+}
+
+func TestTenantFile(t *testing.T) {
+	assert.Equal(t, "/tmp/radiocontestwinner-health-studio.json", TenantFile("studio"))
+}
@@ -0,0 +1,143 @@
+package health
+
+import (
+	"math"
+	"math/bits"
+	"sync"
+	"time"
+)
+
+// Latencies are recorded in milliseconds in buckets of at most 1/64 (about 1.6%) of their value,
+// like an HDR histogram with two significant digits, so percentiles stay accurate over long runs
+// in constant memory
+const (
+	latencySubBucketBits  = 7
+	latencySubBuckets     = 1 << latencySubBucketBits // Latencies below this many ms get a bucket each
+	latencyHalfSubBuckets = latencySubBuckets / 2     // Buckets per power of two above them
+	latencyMaxBits        = 40                        // Longest recordable latency is 1<<40 ms, about 35 years
+	latencyBuckets        = latencySubBuckets + (latencyMaxBits-latencySubBucketBits)*latencyHalfSubBuckets
+)
+
+// LatencySnapshot summarizes the latencies recorded by a LatencyHistogram
+type LatencySnapshot struct {
+	Count int64         `json:"count"`
+	Sum   time.Duration `json:"sum"`
+	Mean  time.Duration `json:"mean"`
+	P50   time.Duration `json:"p50"`
+	P95   time.Duration `json:"p95"`
+	P99   time.Duration `json:"p99"`
+	Max   time.Duration `json:"max"`
+}
+
+// LatencyHistogram records latencies for percentile queries. It is safe for concurrent use.
+type LatencyHistogram struct {
+	mu     sync.Mutex
+	counts [latencyBuckets]int64
+	count  int64
+	sumMS  int64
+	maxMS  int64
+}
+
+// NewLatencyHistogram creates an empty LatencyHistogram
+func NewLatencyHistogram() *LatencyHistogram {
+	return &LatencyHistogram{}
+}
+
+// Record adds one latency; negative latencies count as zero
+func (h *LatencyHistogram) Record(latency time.Duration) {
+	ms := latency.Milliseconds()
+	if ms < 0 {
+		ms = 0
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.counts[latencyBucket(ms)]++
+	h.count++
+	h.sumMS += ms
+	if ms > h.maxMS {
+		h.maxMS = ms
+	}
+}
+
+// Percentile returns the latency that p percent (0..100) of the recorded latencies are at or
+// below; 0 when nothing is recorded
+func (h *LatencyHistogram) Percentile(p float64) time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.percentile(p)
+}
+
+// Within returns the share, in percent, of the recorded latencies at or below limit; 100 when
+// nothing is recorded. Latencies in the bucket containing limit count as within it.
+func (h *LatencyHistogram) Within(limit time.Duration) float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.count == 0 {
+		return 100
+	}
+	last := latencyBucket(limit.Milliseconds())
+	var within int64
+	for i := 0; i <= last; i++ {
+		within += h.counts[i]
+	}
+	return float64(within) / float64(h.count) * 100
+}
+
+// Snapshot returns the count, sum, mean, maximum, and common percentiles of the recorded latencies
+func (h *LatencyHistogram) Snapshot() LatencySnapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	snapshot := LatencySnapshot{
+		Count: h.count,
+		Sum:   time.Duration(h.sumMS) * time.Millisecond,
+		P50:   h.percentile(50),
+		P95:   h.percentile(95),
+		P99:   h.percentile(99),
+		Max:   time.Duration(h.maxMS) * time.Millisecond,
+	}
+	if h.count > 0 {
+		snapshot.Mean = time.Duration(h.sumMS/h.count) * time.Millisecond
+	}
+	return snapshot
+}
+
+// percentile finds the bucket holding the latency at rank p; h.mu must be held
+func (h *LatencyHistogram) percentile(p float64) time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	rank := int64(math.Ceil(p / 100 * float64(h.count)))
+	if rank < 1 {
+		rank = 1
+	}
+	var seen int64
+	for i, n := range h.counts {
+		if seen += n; seen >= rank {
+			return time.Duration(min(latencyBucketMax(i), h.maxMS)) * time.Millisecond
+		}
+	}
+	return time.Duration(h.maxMS) * time.Millisecond
+}
+
+// latencyBucket returns the bucket of a latency in milliseconds
+func latencyBucket(ms int64) int {
+	if ms < latencySubBuckets {
+		return int(ms)
+	}
+	if ms >= 1<<latencyMaxBits {
+		return latencyBuckets - 1
+	}
+	shift := bits.Len64(uint64(ms)) - latencySubBucketBits
+	top := int(ms >> shift) // latencyHalfSubBuckets..latencySubBuckets-1
+	return latencySubBuckets + (shift-1)*latencyHalfSubBuckets + top - latencyHalfSubBuckets
+}
+
+// latencyBucketMax returns the highest latency in milliseconds that falls in bucket i
+func latencyBucketMax(i int) int64 {
+	if i < latencySubBuckets {
+		return int64(i)
+	}
+	shift := (i-latencySubBuckets)/latencyHalfSubBuckets + 1
+	top := int64((i-latencySubBuckets)%latencyHalfSubBuckets + latencyHalfSubBuckets)
+	return (top+1)<<shift - 1
+}
@@ -0,0 +1,91 @@
+package health
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatencyHistogram(t *testing.T) {
+	t.Run("should report zeros before anything is recorded", func(t *testing.T) {
+		histogram := NewLatencyHistogram()
+
+		assert.Equal(t, LatencySnapshot{}, histogram.Snapshot())
+		assert.Equal(t, 100.0, histogram.Within(time.Second))
+	})
+
+	t.Run("should report percentiles within the bucket precision", func(t *testing.T) {
+		// Arrange: 1ms through 10000ms, once each
+		histogram := NewLatencyHistogram()
+		for ms := 1; ms <= 10000; ms++ {
+			histogram.Record(time.Duration(ms) * time.Millisecond)
+		}
+
+		// Act
+		snapshot := histogram.Snapshot()
+
+		// Assert
+		assert.Equal(t, int64(10000), snapshot.Count)
+		assert.InEpsilon(t, 5000, snapshot.P50.Milliseconds(), 0.02)
+		assert.InEpsilon(t, 9500, snapshot.P95.Milliseconds(), 0.02)
+		assert.InEpsilon(t, 9900, snapshot.P99.Milliseconds(), 0.02)
+		assert.Equal(t, 10*time.Second, snapshot.Max)
+		assert.Equal(t, 50005000*time.Millisecond, snapshot.Sum)
+		assert.Equal(t, 5000*time.Millisecond, snapshot.Mean)
+	})
+
+	t.Run("should keep small latencies exact", func(t *testing.T) {
+		histogram := NewLatencyHistogram()
+		for _, ms := range []int{3, 5, 7, 120} {
+			histogram.Record(time.Duration(ms) * time.Millisecond)
+		}
+
+		assert.Equal(t, 5*time.Millisecond, histogram.Percentile(50))
+		assert.Equal(t, 120*time.Millisecond, histogram.Percentile(100))
+		assert.Equal(t, 3*time.Millisecond, histogram.Percentile(0))
+	})
+
+	t.Run("should not report a percentile above the maximum recorded", func(t *testing.T) {
+		histogram := NewLatencyHistogram()
+
+		histogram.Record(1000 * time.Millisecond)
+
+		assert.Equal(t, time.Second, histogram.Percentile(99))
+	})
+
+	t.Run("should report the share of latencies within a limit", func(t *testing.T) {
+		// Arrange
+		histogram := NewLatencyHistogram()
+		for _, latency := range []time.Duration{2 * time.Second, 8 * time.Second, 20 * time.Second, 40 * time.Second} {
+			histogram.Record(latency)
+		}
+
+		// Act & Assert
+		assert.Equal(t, 75.0, histogram.Within(30*time.Second))
+		assert.Equal(t, 25.0, histogram.Within(5*time.Second))
+	})
+
+	t.Run("should clamp negative and huge latencies", func(t *testing.T) {
+		histogram := NewLatencyHistogram()
+
+		histogram.Record(-time.Second)
+		histogram.Record(100 * 365 * 24 * time.Hour)
+
+		assert.Equal(t, time.Duration(0), histogram.Percentile(50))
+		assert.Equal(t, int64(2), histogram.Snapshot().Count)
+	})
+}
+
+func TestLatencyBuckets(t *testing.T) {
+	t.Run("should place every latency in a bucket whose range contains it", func(t *testing.T) {
+		for _, ms := range []int64{0, 1, 127, 128, 129, 130, 255, 256, 1000, 65535, 1 << 30, 1<<40 - 1} {
+			bucket := latencyBucket(ms)
+			assert.LessOrEqual(t, ms, latencyBucketMax(bucket), ms)
+			if bucket > 0 {
+				assert.Greater(t, ms, latencyBucketMax(bucket-1), ms)
+			}
+			assert.Less(t, bucket, latencyBuckets)
+		}
+	})
+}
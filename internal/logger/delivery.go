@@ -0,0 +1,380 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/parser"
+)
+
+// DeliveryStatus reports how contest cues are reaching the log sinks
+type DeliveryStatus struct {
+	QueueDepth    int          `json:"queue_depth"`    // Cues waiting in the fullest sink queue
+	QueueCapacity int          `json:"queue_capacity"` // Capacity of each sink queue
+	Sinks         []SinkStatus `json:"sinks"`
+}
+
+// SinkStatus reports the delivery state of one sink
+type SinkStatus struct {
+	Name        string    `json:"name"`
+	Written     uint64    `json:"written"`
+	Queued      int       `json:"queued"`   // Cues waiting in the sink's queue
+	Pending     int       `json:"pending"`  // Cues held for retry after failed writes
+	Failures    uint64    `json:"failures"` // Failed write and sync attempts
+	Dropped     uint64    `json:"dropped"`  // Cues lost because they could not be formatted, the queue was full, or too many were pending
+	LastError   string    `json:"last_error,omitempty"`
+	LastErrorAt time.Time `json:"last_error_at,omitzero"`
+	LastWriteAt time.Time `json:"last_write_at,omitzero"`
+}
+
+// Failing reports whether the sink has cues it has not been able to write
+func (s SinkStatus) Failing() bool {
+	return s.Pending > 0
+}
+
+// Degraded reports whether any sink is holding cues it has not been able to write
+func (s DeliveryStatus) Degraded() bool {
+	for _, sink := range s.Sinks {
+		if sink.Failing() {
+			return true
+		}
+	}
+	return false
+}
+
+// sinkDelivery queues cues for one sink, whose own writer goroutine writes them, and holds the
+// cues waiting for retry along with the sink's delivery counters
+type sinkDelivery struct {
+	sink    Sink
+	queue   chan *parser.ContestCue // Cues the writer has not picked up yet
+	done    chan struct{}           // Closed once the writer has drained queue
+	pending []*parser.ContestCue    // Only touched by the writer
+
+	mutex  sync.Mutex // Guards status; never held while writing, so status reads do not wait on the sink
+	status SinkStatus
+}
+
+// deliveryOptions control batching, fsync, and retries of queued cue writes
+type deliveryOptions struct {
+	queueSize     int
+	batchSize     int
+	syncInterval  time.Duration
+	fsyncPolicy   string // FsyncAlways, FsyncInterval, or FsyncNever
+	retryAttempts int
+	retryBackoff  time.Duration
+	maxPending    int
+}
+
+// sinkDeliveries returns the per-sink delivery state set up by startDelivery
+func (lo *LogOutput) sinkDeliveries() []*sinkDelivery {
+	if deliveries := lo.deliveries.Load(); deliveries != nil {
+		return *deliveries
+	}
+	return nil
+}
+
+// startDelivery starts a writer goroutine per sink unless they are already running, after
+// repairing partial output a crash left in the sinks
+func (lo *LogOutput) startDelivery() {
+	lo.deliveryMutex.Lock()
+	defer lo.deliveryMutex.Unlock()
+	if lo.delivering {
+		return
+	}
+
+	lo.recoverSinks()
+	deliveries := make([]*sinkDelivery, len(lo.sinks))
+	for i, sink := range lo.sinks {
+		deliveries[i] = &sinkDelivery{
+			sink:   sink,
+			queue:  make(chan *parser.ContestCue, lo.options.queueSize),
+			done:   make(chan struct{}),
+			status: SinkStatus{Name: sink.Name()},
+		}
+		go lo.runSink(deliveries[i])
+	}
+	lo.deliveries.Store(&deliveries)
+	lo.delivering = true
+}
+
+// stopDelivery closes the sink queues and waits for every writer to drain its queue and make
+// a last attempt at held cues
+func (lo *LogOutput) stopDelivery() {
+	lo.deliveryMutex.Lock()
+	if !lo.delivering {
+		lo.deliveryMutex.Unlock()
+		return
+	}
+	lo.delivering = false
+	deliveries := lo.sinkDeliveries()
+	for _, d := range deliveries {
+		close(d.queue)
+	}
+	lo.deliveryMutex.Unlock()
+
+	for _, d := range deliveries {
+		<-d.done
+	}
+	lo.logger.Debug("log sink writers stopped", zap.Int("sink_count", len(deliveries)))
+}
+
+// dispatch hands cue to every sink's writer without waiting for any of them
+func (lo *LogOutput) dispatch(cue *parser.ContestCue) error {
+	lo.deliveryMutex.RLock()
+	defer lo.deliveryMutex.RUnlock()
+	if !lo.delivering {
+		return fmt.Errorf("log output is closed")
+	}
+	for _, d := range lo.sinkDeliveries() {
+		lo.enqueue(d, cue)
+	}
+	return nil
+}
+
+// enqueue hands cue to the sink's writer. A sink whose queue is full has fallen far behind, so
+// the cue is dropped for that sink rather than holding up ingestion and the other sinks.
+func (lo *LogOutput) enqueue(d *sinkDelivery, cue *parser.ContestCue) {
+	select {
+	case d.queue <- cue:
+		return
+	default:
+	}
+
+	d.mutex.Lock()
+	d.status.Dropped++
+	d.mutex.Unlock()
+	lo.logger.Error("dropped contest cue for log sink whose write queue is full",
+		zap.String("sink", d.sink.Name()),
+		zap.String("cue_id", cue.CueID),
+		zap.Strings("trace_ids", cue.TraceIDs),
+		zap.Int("queue_capacity", cap(d.queue)))
+}
+
+// runSink writes the cues queued for one sink in batches until its queue is closed and
+// drained. Between batches it fsyncs the sink and retries cues held after failed writes.
+func (lo *LogOutput) runSink(d *sinkDelivery) {
+	defer close(d.done)
+	ticker := time.NewTicker(lo.options.syncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case cue, ok := <-d.queue:
+			if !ok {
+				lo.finishSink(d)
+				return
+			}
+			batch := []*parser.ContestCue{cue}
+			closed := false
+		drain:
+			for len(batch) < lo.options.batchSize {
+				select {
+				case next, ok := <-d.queue:
+					if !ok {
+						closed = true
+						break drain
+					}
+					batch = append(batch, next)
+				default:
+					break drain
+				}
+			}
+			lo.deliverToSink(d, batch)
+			if lo.options.fsyncPolicy == FsyncAlways {
+				lo.syncSink(d)
+			}
+			if closed {
+				lo.finishSink(d)
+				return
+			}
+		case <-ticker.C:
+			lo.deliverToSink(d, nil)
+			if lo.options.fsyncPolicy != FsyncNever {
+				lo.syncSink(d)
+			}
+		}
+	}
+}
+
+// deliverToSink writes the sink's pending cues followed by cues. It runs on the sink's own
+// writer, so retries only delay this sink. A sink that was healthy is retried with backoff so
+// transient failures (e.g. an NFS blip) do not hold cues back; a sink that is already failing
+// gets one attempt per batch so its writer keeps up with its queue.
+func (lo *LogOutput) deliverToSink(d *sinkDelivery, cues []*parser.ContestCue) {
+	wasFailing := len(d.pending) > 0
+	d.pending = append(d.pending, cues...)
+	if len(d.pending) == 0 {
+		return
+	}
+	if excess := len(d.pending) - lo.options.maxPending; excess > 0 {
+		d.pending = d.pending[excess:]
+		d.mutex.Lock()
+		d.status.Dropped += uint64(excess)
+		d.mutex.Unlock()
+		lo.logger.Error("dropped oldest undelivered contest cues for failing log sink",
+			zap.String("sink", d.sink.Name()),
+			zap.Int("dropped", excess))
+	}
+
+	attempts := 1
+	if !wasFailing {
+		attempts += lo.options.retryAttempts
+	}
+	backoff := lo.options.retryBackoff
+	for attempt := 1; ; attempt++ {
+		err := lo.writePending(d)
+		if err == nil {
+			if wasFailing {
+				lo.logger.Info("log sink recovered; held contest cues written",
+					zap.String("sink", d.sink.Name()))
+			}
+			return
+		}
+		if attempt >= attempts {
+			lo.logger.Error("failed to write ContestCues to log sink; holding them for retry",
+				zap.String("sink", d.sink.Name()),
+				zap.Int("pending", len(d.pending)),
+				zap.Error(err))
+			return
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// writePending writes the sink's pending cues in batches, dropping cues that can never be
+// formatted, and returns the first write error. Written cues are removed from pending.
+func (lo *LogOutput) writePending(d *sinkDelivery) error {
+	defer func() {
+		d.mutex.Lock()
+		d.status.Pending = len(d.pending)
+		d.mutex.Unlock()
+	}()
+
+	for len(d.pending) > 0 {
+		chunk := d.pending
+		if len(chunk) > lo.options.batchSize {
+			chunk = chunk[:lo.options.batchSize]
+		}
+
+		written, err := writeCues(d.sink, chunk)
+		if written > 0 {
+			d.pending = d.pending[written:]
+			d.mutex.Lock()
+			d.status.Written += uint64(written)
+			d.status.LastWriteAt = time.Now()
+			d.mutex.Unlock()
+		}
+		if err == nil {
+			continue
+		}
+
+		var fmtErr *formatError
+		if errors.As(err, &fmtErr) {
+			lo.logger.Error("dropping ContestCue that cannot be written to log sink",
+				zap.String("sink", d.sink.Name()),
+				zap.String("cue_id", d.pending[0].CueID),
+				zap.Strings("trace_ids", d.pending[0].TraceIDs),
+				zap.Error(err))
+			d.pending = d.pending[1:]
+			d.mutex.Lock()
+			d.status.Dropped++
+			d.mutex.Unlock()
+			continue
+		}
+
+		d.mutex.Lock()
+		d.status.Failures++
+		d.status.LastError = err.Error()
+		d.status.LastErrorAt = time.Now()
+		d.mutex.Unlock()
+		return err
+	}
+	d.pending = nil
+	return nil
+}
+
+// writeCues writes cues to sink, in one call when the sink supports batches, and returns how
+// many of the leading cues were written
+func writeCues(sink Sink, cues []*parser.ContestCue) (int, error) {
+	if batchSink, ok := sink.(BatchSink); ok {
+		return batchSink.WriteBatch(cues)
+	}
+	for i, cue := range cues {
+		if err := sink.Write(cue); err != nil {
+			return i, err
+		}
+	}
+	return len(cues), nil
+}
+
+// recoverSinks repairs partial output a crash left in the sinks before any cue is written
+func (lo *LogOutput) recoverSinks() {
+	for _, sink := range lo.sinks {
+		recoverer, ok := sink.(Recoverer)
+		if !ok {
+			continue
+		}
+		truncated, err := recoverer.Recover()
+		if err != nil {
+			lo.logger.Error("failed to recover log sink",
+				zap.String("sink", sink.Name()),
+				zap.Error(err))
+			continue
+		}
+		if truncated > 0 {
+			lo.logger.Warn("truncated a torn final line left in log sink by an interrupted write",
+				zap.String("sink", sink.Name()),
+				zap.Int64("bytes", truncated))
+		}
+	}
+}
+
+// syncSink flushes a file sink to stable storage
+func (lo *LogOutput) syncSink(d *sinkDelivery) {
+	syncer, ok := d.sink.(Syncer)
+	if !ok {
+		return
+	}
+	if err := syncer.Sync(); err != nil {
+		d.mutex.Lock()
+		d.status.Failures++
+		d.status.LastError = err.Error()
+		d.status.LastErrorAt = time.Now()
+		d.mutex.Unlock()
+		lo.logger.Error("failed to sync log sink",
+			zap.String("sink", d.sink.Name()),
+			zap.Error(err))
+	}
+}
+
+// finishSink makes a last attempt at the sink's held cues and syncs it once its queue is drained
+func (lo *LogOutput) finishSink(d *sinkDelivery) {
+	if len(d.pending) > 0 {
+		if err := lo.writePending(d); err != nil {
+			lo.logger.Error("contest cues could not be written to log sink before shutdown",
+				zap.String("sink", d.sink.Name()),
+				zap.Int("lost", len(d.pending)),
+				zap.Error(err))
+		}
+	}
+	lo.syncSink(d)
+}
+
+// DeliveryStatus returns the deepest sink write queue and each sink's delivery state
+func (lo *LogOutput) DeliveryStatus() DeliveryStatus {
+	status := DeliveryStatus{QueueCapacity: lo.options.queueSize}
+	for _, d := range lo.sinkDeliveries() {
+		d.mutex.Lock()
+		sinkStatus := d.status
+		d.mutex.Unlock()
+		sinkStatus.Queued = len(d.queue)
+		status.QueueDepth = max(status.QueueDepth, sinkStatus.Queued)
+		status.Sinks = append(status.Sinks, sinkStatus)
+	}
+	return status
+}
@@ -0,0 +1,326 @@
+package logger
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/parser"
+)
+
+// flakySink fails its first failures writes, then records every cue it is given
+type flakySink struct {
+	mutex    sync.Mutex
+	failures int
+	block    chan struct{} // When set, writes wait for it to be closed
+	cueIDs   []string
+}
+
+func (s *flakySink) Name() string { return "flaky" }
+
+func (s *flakySink) Write(cue *parser.ContestCue) error {
+	if s.block != nil {
+		<-s.block
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return assert.AnError
+	}
+	s.cueIDs = append(s.cueIDs, cue.CueID)
+	return nil
+}
+
+func (s *flakySink) Close() error { return nil }
+
+func (s *flakySink) written() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string(nil), s.cueIDs...)
+}
+
+func newTestDeliveryOutput(t *testing.T, sinks ...Sink) *LogOutput {
+	t.Helper()
+	cfg := config.NewConfiguration()
+	cfg.SetLogRetryBackoffMS(1)
+	logOutput, err := NewLogOutput(cfg, NewLogger())
+	require.NoError(t, err)
+	logOutput.sinks = sinks
+	return logOutput
+}
+
+func sendCues(inputCh chan<- parser.ContestCue, n int) []string {
+	var ids []string
+	for i := 0; i < n; i++ {
+		cue := testCue()
+		ids = append(ids, cue.CueID)
+		inputCh <- *cue
+	}
+	close(inputCh)
+	return ids
+}
+
+func TestLogOutput_Delivery(t *testing.T) {
+	t.Run("should retry transient sink failures without losing cues", func(t *testing.T) {
+		// Arrange
+		sink := &flakySink{failures: 2}
+		logOutput := newTestDeliveryOutput(t, sink)
+		inputCh := make(chan parser.ContestCue, 5)
+
+		// Act
+		ids := sendCues(inputCh, 5)
+		logOutput.ProcessContestCues(inputCh)
+
+		// Assert
+		assert.Equal(t, ids, sink.written())
+		status := logOutput.DeliveryStatus()
+		require.Len(t, status.Sinks, 1)
+		assert.Equal(t, uint64(5), status.Sinks[0].Written)
+		assert.Equal(t, uint64(2), status.Sinks[0].Failures)
+		assert.Equal(t, 0, status.Sinks[0].Pending)
+		assert.False(t, status.Degraded())
+	})
+
+	t.Run("should hold cues for a failing sink and report them while other sinks keep receiving", func(t *testing.T) {
+		// Arrange
+		healthy := &flakySink{}
+		logOutput := newTestDeliveryOutput(t, &failingSink{}, healthy)
+		inputCh := make(chan parser.ContestCue, 3)
+
+		// Act
+		ids := sendCues(inputCh, 3)
+		logOutput.ProcessContestCues(inputCh)
+
+		// Assert
+		assert.Equal(t, ids, healthy.written())
+		status := logOutput.DeliveryStatus()
+		assert.True(t, status.Degraded())
+		assert.Equal(t, 3, status.Sinks[0].Pending)
+		assert.Contains(t, status.Sinks[0].LastError, assert.AnError.Error())
+		assert.False(t, status.Sinks[0].LastErrorAt.IsZero())
+		assert.False(t, status.Sinks[1].Failing())
+	})
+
+	t.Run("should drop cues that cannot be formatted instead of retrying them", func(t *testing.T) {
+		// Arrange
+		logFile := filepath.Join(t.TempDir(), "cues.log")
+		logOutput := newTestDeliveryOutput(t, NewFileSink(logFile, FormatJSON))
+		inputCh := make(chan parser.ContestCue, 3)
+		inputCh <- *testCue()
+		inputCh <- *parser.NewContestCue("CASH", parser.CueDetails{Keyword: "CASH"})
+		inputCh <- *testCue()
+		close(inputCh)
+
+		// Act
+		logOutput.ProcessContestCues(inputCh)
+
+		// Assert
+		content, err := os.ReadFile(logFile)
+		require.NoError(t, err)
+		assert.Len(t, strings.Split(strings.TrimSpace(string(content)), "\n"), 2)
+		status := logOutput.DeliveryStatus()
+		assert.Equal(t, uint64(1), status.Sinks[0].Dropped)
+		assert.Equal(t, uint64(0), status.Sinks[0].Failures)
+		assert.False(t, status.Degraded())
+	})
+
+	t.Run("should retry a failing sink without delaying the others or its status", func(t *testing.T) {
+		// Arrange
+		retrying := &flakySink{failures: 3}
+		healthy := &flakySink{}
+		cfg := config.NewConfiguration()
+		cfg.SetLogRetryBackoffMS(200)
+		logOutput, err := NewLogOutput(cfg, NewLogger())
+		require.NoError(t, err)
+		logOutput.sinks = []Sink{retrying, healthy}
+		inputCh := make(chan parser.ContestCue)
+		done := make(chan struct{})
+
+		// Act
+		go func() {
+			logOutput.ProcessContestCues(inputCh)
+			close(done)
+		}()
+		inputCh <- *testCue()
+		time.Sleep(50 * time.Millisecond)
+		ids := sendCues(inputCh, 2)
+
+		// Assert
+		assert.Eventually(t, func() bool { return len(healthy.written()) == 3 }, 150*time.Millisecond, 5*time.Millisecond,
+			"the healthy sink should not wait for the other sink's retries")
+		start := time.Now()
+		status := logOutput.DeliveryStatus()
+		assert.Less(t, time.Since(start), 50*time.Millisecond, "status should not wait for a retrying sink")
+		assert.Greater(t, status.Sinks[0].Failures, uint64(0))
+		assert.Empty(t, retrying.written())
+
+		<-done
+		assert.Equal(t, ids, retrying.written()[1:])
+		assert.Equal(t, uint64(3), logOutput.DeliveryStatus().Sinks[0].Written)
+	})
+
+	t.Run("should drop cues for a sink whose queue is full without delaying the others", func(t *testing.T) {
+		// Arrange
+		blocked := &flakySink{block: make(chan struct{})}
+		healthy := &flakySink{}
+		cfg := config.NewConfiguration()
+		cfg.SetLogQueueSize(1)
+		logOutput, err := NewLogOutput(cfg, NewLogger())
+		require.NoError(t, err)
+		logOutput.sinks = []Sink{blocked, healthy}
+		inputCh := make(chan parser.ContestCue)
+		done := make(chan struct{})
+
+		// Act
+		go func() {
+			logOutput.ProcessContestCues(inputCh)
+			close(done)
+		}()
+		var ids []string
+		for i := 0; i < 4; i++ {
+			cue := testCue()
+			ids = append(ids, cue.CueID)
+			inputCh <- *cue
+			time.Sleep(10 * time.Millisecond) // Let the writers pick the cue up
+		}
+		close(inputCh)
+
+		// Assert
+		assert.Equal(t, ids, healthy.written(), "the healthy sink should receive every cue while the other is blocked")
+		status := logOutput.DeliveryStatus()
+		assert.Equal(t, uint64(2), status.Sinks[0].Dropped)
+		assert.Equal(t, 1, status.QueueCapacity)
+
+		close(blocked.block)
+		<-done
+		assert.Equal(t, ids[:2], blocked.written())
+	})
+}
+
+func TestFileSink_WriteBatch(t *testing.T) {
+	t.Run("should append every cue in one batch", func(t *testing.T) {
+		// Arrange
+		logFile := filepath.Join(t.TempDir(), "cues.log")
+		sink := NewFileSink(logFile, FormatJSON)
+		defer sink.Close()
+
+		// Act
+		written, err := sink.WriteBatch([]*parser.ContestCue{testCue(), testCue(), testCue()})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 3, written)
+		require.NoError(t, sink.Sync())
+		content, err := os.ReadFile(logFile)
+		require.NoError(t, err)
+		assert.Len(t, strings.Split(strings.TrimSpace(string(content)), "\n"), 3)
+	})
+
+	t.Run("should reopen the file after it is moved aside", func(t *testing.T) {
+		// Arrange
+		logFile := filepath.Join(t.TempDir(), "cues.log")
+		sink := NewFileSink(logFile, FormatJSON)
+		defer sink.Close()
+		require.NoError(t, sink.Write(testCue()))
+		require.NoError(t, os.Rename(logFile, logFile+".1"))
+
+		// Act
+		err := sink.Write(testCue())
+
+		// Assert
+		require.NoError(t, err)
+		content, err := os.ReadFile(logFile)
+		require.NoError(t, err)
+		assert.Len(t, strings.Split(strings.TrimSpace(string(content)), "\n"), 1)
+	})
+
+	t.Run("should write the cues before one that cannot be formatted", func(t *testing.T) {
+		// Arrange
+		sink := NewFileSink(filepath.Join(t.TempDir(), "cues.log"), FormatJSON)
+		defer sink.Close()
+		bad := parser.NewContestCue("CASH", parser.CueDetails{})
+
+		// Act
+		written, err := sink.WriteBatch([]*parser.ContestCue{testCue(), bad, testCue()})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, 1, written)
+	})
+}
+
+func TestFileSink_Recover(t *testing.T) {
+	t.Run("should truncate a torn final line", func(t *testing.T) {
+		// Arrange
+		logFile := filepath.Join(t.TempDir(), "cues.log")
+		require.NoError(t, os.WriteFile(logFile, []byte("{\"cue_id\":\"a\"}\n{\"cue_id\":\"b\"}\n{\"cue_"), 0644))
+		sink := NewFileSink(logFile, FormatJSON)
+		defer sink.Close()
+
+		// Act
+		truncated, err := sink.Recover()
+		require.NoError(t, sink.Write(testCue()))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), truncated)
+		content, err := os.ReadFile(logFile)
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		require.Len(t, lines, 3)
+		for _, line := range lines {
+			assert.True(t, json.Valid([]byte(line)), "line %q should be valid JSON", line)
+		}
+	})
+
+	t.Run("should leave complete and missing files alone", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		complete := filepath.Join(dir, "complete.log")
+		require.NoError(t, os.WriteFile(complete, []byte("{\"cue_id\":\"a\"}\n"), 0644))
+
+		// Act
+		truncated, err := NewFileSink(complete, FormatJSON).Recover()
+		missingTruncated, missingErr := NewFileSink(filepath.Join(dir, "missing.log"), FormatJSON).Recover()
+
+		// Assert
+		require.NoError(t, err)
+		require.NoError(t, missingErr)
+		assert.Zero(t, truncated)
+		assert.Zero(t, missingTruncated)
+		content, _ := os.ReadFile(complete)
+		assert.Equal(t, "{\"cue_id\":\"a\"}\n", string(content))
+	})
+
+	t.Run("should truncate a file holding only part of a line", func(t *testing.T) {
+		logFile := filepath.Join(t.TempDir(), "cues.log")
+		require.NoError(t, os.WriteFile(logFile, []byte("{\"cue_id\":"), 0644))
+
+		truncated, err := NewFileSink(logFile, FormatJSON).Recover()
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), truncated)
+		info, _ := os.Stat(logFile)
+		assert.Zero(t, info.Size())
+	})
+}
+
+func TestNewLogOutput_FsyncPolicy(t *testing.T) {
+	t.Run("should reject an unknown fsync policy", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetLogFsyncPolicy("sometimes")
+
+		_, err := NewLogOutput(cfg, NewLogger())
+
+		assert.ErrorContains(t, err, "unknown log fsync policy")
+	})
+}
@@ -89,11 +89,11 @@ func TestLogOutput_FormatContestCueAsJSON(t *testing.T) {
 		logOutput, err := NewLogOutput(cfg, logger)
 		assert.NoError(t, err)
 
-		details := map[string]interface{}{
-			"keyword":  "MONEY",
-			"number":   "55555",
-			"start_ms": 1000,
-			"end_ms":   2000,
+		details := parser.CueDetails{
+			Keyword: "MONEY",
+			Number:  "55555",
+			StartMS: 1000,
+			EndMS:   2000,
 		}
 		contestCue := parser.NewContestCue("MONEY", details)
 
@@ -123,11 +123,11 @@ func TestLogOutput_FormatContestCueAsJSON(t *testing.T) {
 		logOutput, err := NewLogOutput(cfg, logger)
 		assert.NoError(t, err)
 
-		details := map[string]interface{}{
-			"keyword":            "CASH",
-			"number":             "12345",
-			"original_text":      "Text CASH to 12345",
-			"reconstructed_text": "Text CASH to 12345",
+		details := parser.CueDetails{
+			Keyword:           "CASH",
+			Number:            "12345",
+			OriginalText:      "Text CASH to 12345",
+			ReconstructedText: "Text CASH to 12345",
 		}
 		contestCue := parser.NewContestCue("CASH", details)
 
@@ -146,6 +146,27 @@ func TestLogOutput_FormatContestCueAsJSON(t *testing.T) {
 		assert.Equal(t, "12345", result["shortcode"])
 	})
 
+	t.Run("should include pipeline latency when timing is known", func(t *testing.T) {
+		// Arrange
+		logOutput, err := NewLogOutput(config.NewConfiguration(), NewLogger())
+		assert.NoError(t, err)
+
+		contestCue := parser.NewContestCue("CASH", parser.CueDetails{Keyword: "CASH", Number: "12345"})
+		capturedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		contestCue.Timing = parser.NewCueTiming(capturedAt, capturedAt.Add(2*time.Second), capturedAt.Add(2500*time.Millisecond))
+
+		// Act
+		jsonBytes, err := logOutput.FormatContestCueAsJSON(contestCue)
+
+		// Assert
+		assert.NoError(t, err)
+
+		var result map[string]interface{}
+		assert.NoError(t, json.Unmarshal(jsonBytes, &result))
+		assert.Equal(t, float64(2500), result["latency_ms"])
+		assert.Equal(t, "2024-05-01T12:00:00Z", result["audio_captured_at"])
+	})
+
 	t.Run("should return error for nil ContestCue", func(t *testing.T) {
 		// Arrange
 		cfg := config.NewConfiguration()
@@ -169,8 +190,8 @@ func TestLogOutput_FormatContestCueAsJSON(t *testing.T) {
 		logOutput, err := NewLogOutput(cfg, logger)
 		assert.NoError(t, err)
 
-		details := map[string]interface{}{
-			"number": "55555",
+		details := parser.CueDetails{
+			Number: "55555",
 		}
 		contestCue := parser.NewContestCue("MONEY", details)
 
@@ -190,8 +211,8 @@ func TestLogOutput_FormatContestCueAsJSON(t *testing.T) {
 		logOutput, err := NewLogOutput(cfg, logger)
 		assert.NoError(t, err)
 
-		details := map[string]interface{}{
-			"keyword": "MONEY",
+		details := parser.CueDetails{
+			Keyword: "MONEY",
 		}
 		contestCue := parser.NewContestCue("MONEY", details)
 
@@ -205,7 +226,7 @@ func TestLogOutput_FormatContestCueAsJSON(t *testing.T) {
 	})
 }
 
-func TestLogOutput_WriteContestCueToFile(t *testing.T) {
+func TestLogOutput_WriteContestCue(t *testing.T) {
 	t.Run("should write ContestCue JSON to log file", func(t *testing.T) {
 		// Arrange
 		tmpDir := t.TempDir()
@@ -217,16 +238,17 @@ func TestLogOutput_WriteContestCueToFile(t *testing.T) {
 		assert.NoError(t, err)
 
 		// Override file path for testing
-		logOutput.filePath = logFile
+		logOutput.sinks = []Sink{NewFileSink(logFile, FormatJSON)}
 
-		details := map[string]interface{}{
-			"keyword": "MONEY",
-			"number":  "55555",
+		details := parser.CueDetails{
+			Keyword: "MONEY",
+			Number:  "55555",
 		}
 		contestCue := parser.NewContestCue("MONEY", details)
 
 		// Act
-		err = logOutput.WriteContestCueToFile(contestCue)
+		err = logOutput.WriteContestCue(contestCue)
+		assert.NoError(t, logOutput.Close())
 
 		// Assert
 		assert.NoError(t, err)
@@ -255,26 +277,27 @@ func TestLogOutput_WriteContestCueToFile(t *testing.T) {
 		logger := NewLogger()
 		logOutput, err := NewLogOutput(cfg, logger)
 		assert.NoError(t, err)
-		logOutput.filePath = logFile
+		logOutput.sinks = []Sink{NewFileSink(logFile, FormatJSON)}
 
 		// Create two different contest cues
-		details1 := map[string]interface{}{
-			"keyword": "MONEY",
-			"number":  "55555",
+		details1 := parser.CueDetails{
+			Keyword: "MONEY",
+			Number:  "55555",
 		}
 		contestCue1 := parser.NewContestCue("MONEY", details1)
 
-		details2 := map[string]interface{}{
-			"keyword": "CASH",
-			"number":  "12345",
+		details2 := parser.CueDetails{
+			Keyword: "CASH",
+			Number:  "12345",
 		}
 		contestCue2 := parser.NewContestCue("CASH", details2)
 
 		// Act
-		err = logOutput.WriteContestCueToFile(contestCue1)
+		err = logOutput.WriteContestCue(contestCue1)
 		assert.NoError(t, err)
-		err = logOutput.WriteContestCueToFile(contestCue2)
+		err = logOutput.WriteContestCue(contestCue2)
 		assert.NoError(t, err)
+		assert.NoError(t, logOutput.Close())
 
 		// Assert
 		content, err := os.ReadFile(logFile)
@@ -307,45 +330,51 @@ func TestLogOutput_WriteContestCueToFile(t *testing.T) {
 		logger := NewLogger()
 		logOutput, err := NewLogOutput(cfg, logger)
 		assert.NoError(t, err)
-		logOutput.filePath = logFile
+		logOutput.sinks = []Sink{NewFileSink(logFile, FormatJSON)}
 
-		details := map[string]interface{}{
-			"keyword": "MONEY",
-			"number":  "55555",
+		details := parser.CueDetails{
+			Keyword: "MONEY",
+			Number:  "55555",
 		}
 		contestCue := parser.NewContestCue("MONEY", details)
 
 		// Act
-		err = logOutput.WriteContestCueToFile(contestCue)
+		err = logOutput.WriteContestCue(contestCue)
+		assert.NoError(t, logOutput.Close())
 
 		// Assert
 		assert.NoError(t, err)
 		assert.FileExists(t, logFile)
 	})
 
-	t.Run("should return error for invalid file path", func(t *testing.T) {
+	t.Run("should report a sink that cannot open its file as failing", func(t *testing.T) {
 		// Arrange
 		cfg := config.NewConfiguration()
+		cfg.SetLogRetryBackoffMS(1)
 		logger := NewLogger()
 		logOutput, err := NewLogOutput(cfg, logger)
 		assert.NoError(t, err)
 
 		// Set invalid file path (path that contains invalid characters for filesystem)
-		logOutput.filePath = "/proc/self/mem/invalid/contest.log"
+		logOutput.sinks = []Sink{NewFileSink("/proc/self/mem/invalid/contest.log", FormatJSON)}
 
-		details := map[string]interface{}{
-			"keyword": "MONEY",
-			"number":  "55555",
+		details := parser.CueDetails{
+			Keyword: "MONEY",
+			Number:  "55555",
 		}
 		contestCue := parser.NewContestCue("MONEY", details)
 
 		// Act
-		err = logOutput.WriteContestCueToFile(contestCue)
+		err = logOutput.WriteContestCue(contestCue)
+		logOutput.Close()
 
 		// Assert
+		assert.NoError(t, err)
+		err = logOutput.Healthy()
 		assert.Error(t, err)
 		// The error message will contain either "failed to create directory" or "failed to open file"
 		assert.True(t, err != nil && (strings.Contains(err.Error(), "failed to create directory") || strings.Contains(err.Error(), "failed to open file")))
+		assert.Equal(t, 1, logOutput.DeliveryStatus().Sinks[0].Pending)
 	})
 
 	t.Run("should return error for nil ContestCue", func(t *testing.T) {
@@ -356,7 +385,7 @@ func TestLogOutput_WriteContestCueToFile(t *testing.T) {
 		assert.NoError(t, err)
 
 		// Act
-		err = logOutput.WriteContestCueToFile(nil)
+		err = logOutput.WriteContestCue(nil)
 
 		// Assert
 		assert.Error(t, err)
@@ -374,20 +403,20 @@ func TestLogOutput_ProcessContestCues(t *testing.T) {
 		logger := NewLogger()
 		logOutput, err := NewLogOutput(cfg, logger)
 		assert.NoError(t, err)
-		logOutput.filePath = logFile
+		logOutput.sinks = []Sink{NewFileSink(logFile, FormatJSON)}
 
 		// Create channel and ContestCues
 		inputCh := make(chan parser.ContestCue, 2)
 
-		details1 := map[string]interface{}{
-			"keyword": "MONEY",
-			"number":  "55555",
+		details1 := parser.CueDetails{
+			Keyword: "MONEY",
+			Number:  "55555",
 		}
 		contestCue1 := parser.NewContestCue("MONEY", details1)
 
-		details2 := map[string]interface{}{
-			"keyword": "CASH",
-			"number":  "12345",
+		details2 := parser.CueDetails{
+			Keyword: "CASH",
+			Number:  "12345",
 		}
 		contestCue2 := parser.NewContestCue("CASH", details2)
 
@@ -431,7 +460,7 @@ func TestLogOutput_ProcessContestCues(t *testing.T) {
 		logger := NewLogger()
 		logOutput, err := NewLogOutput(cfg, logger)
 		assert.NoError(t, err)
-		logOutput.filePath = logFile
+		logOutput.sinks = []Sink{NewFileSink(logFile, FormatJSON)}
 
 		inputCh := make(chan parser.ContestCue)
 
@@ -461,13 +490,13 @@ func TestLogOutput_ProcessContestCues(t *testing.T) {
 		assert.NoError(t, err)
 
 		// Set invalid file path to trigger write errors
-		logOutput.filePath = "/root/invalid/path/contest.log"
+		logOutput.sinks = []Sink{NewFileSink("/root/invalid/path/contest.log", FormatJSON)}
 
 		inputCh := make(chan parser.ContestCue, 2)
 
-		details := map[string]interface{}{
-			"keyword": "MONEY",
-			"number":  "55555",
+		details := parser.CueDetails{
+			Keyword: "MONEY",
+			Number:  "55555",
 		}
 		contestCue := parser.NewContestCue("MONEY", details)
 
@@ -1,11 +1,13 @@
 package logger
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -43,11 +45,19 @@ func NewDevelopmentLogger() (*zap.Logger, error) {
 	return logger, nil
 }
 
-// LogOutput handles writing contest cues to a configured log file
+// LogOutput fans contest cues out to the configured sinks (files, stdout, syslog, HTTP)
 type LogOutput struct {
-	filePath string
-	logger   *zap.Logger
-	mutex    sync.Mutex // For thread-safe file writing
+	sinks         []Sink
+	logger        *zap.Logger
+	schemaVersion string // JSON cue record schema version; empty is current
+
+	// Per-sink writers used by WriteContestCue and ProcessContestCues
+	options       deliveryOptions
+	deliveryMutex sync.RWMutex // Guards starting and stopping the writers
+	delivering    bool
+	deliveries    atomic.Pointer[[]*sinkDelivery]
+
+	inputCh <-chan parser.ContestCue // Cues Start processes; nil until SetInput
 }
 
 // NewLogOutput creates a new LogOutput with configuration dependency
@@ -60,43 +70,132 @@ func NewLogOutput(cfg *config.Configuration, logger *zap.Logger) (*LogOutput, er
 		return nil, fmt.Errorf("logger cannot be nil")
 	}
 
-	filePath := cfg.GetLogFilePath()
+	schemaVersion := cfg.GetLogSchemaVersion()
+	if err := ValidateCueSchemaVersion(schemaVersion); err != nil {
+		return nil, err
+	}
+
+	fsyncPolicy := cfg.GetLogFsyncPolicy()
+	if err := ValidateFsyncPolicy(fsyncPolicy); err != nil {
+		return nil, err
+	}
+
+	httpTimeout := time.Duration(cfg.GetLogHTTPTimeoutSec()) * time.Second
+	var sinks []Sink
+	for _, sinkConfig := range cfg.GetLogSinks() {
+		sink, err := NewSink(sinkConfig, httpTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log sink: %w", err)
+		}
+		sinks = append(sinks, sink)
+	}
+
+	lo := &LogOutput{
+		sinks:         sinks,
+		logger:        logger,
+		schemaVersion: schemaVersion,
+		options: deliveryOptions{
+			queueSize:     cfg.GetLogQueueSize(),
+			batchSize:     cfg.GetLogBatchSize(),
+			syncInterval:  time.Duration(cfg.GetLogFsyncIntervalMS()) * time.Millisecond,
+			fsyncPolicy:   fsyncPolicy,
+			retryAttempts: cfg.GetLogRetryAttempts(),
+			retryBackoff:  time.Duration(cfg.GetLogRetryBackoffMS()) * time.Millisecond,
+			maxPending:    cfg.GetLogMaxPendingCues(),
+		},
+	}
+	if lo.options.queueSize < 1 {
+		lo.options.queueSize = 1
+	}
+	if lo.options.batchSize < 1 {
+		lo.options.batchSize = 1
+	}
+	if lo.options.syncInterval <= 0 {
+		lo.options.syncInterval = time.Second
+	}
+	if lo.options.maxPending < lo.options.batchSize {
+		lo.options.maxPending = lo.options.batchSize
+	}
+	return lo, nil
+}
 
-	return &LogOutput{
-		filePath: filePath,
-		logger:   logger,
-	}, nil
+// Sinks returns the configured sinks
+func (lo *LogOutput) Sinks() []Sink {
+	return lo.sinks
 }
 
-// GetFilePath returns the configured file path
+// GetFilePath returns the path of the first file sink, or an empty string when no file sink is configured
 func (lo *LogOutput) GetFilePath() string {
-	return lo.filePath
+	for _, sink := range lo.sinks {
+		if fileSink, ok := sink.(*FileSink); ok {
+			return fileSink.Path()
+		}
+	}
+	return ""
 }
 
-// FormatContestCueAsJSON formats a ContestCue into the required JSON structure
+// FormatContestCueAsJSON formats a ContestCue into the required JSON structure in the configured schema version
 func (lo *LogOutput) FormatContestCueAsJSON(cue *parser.ContestCue) ([]byte, error) {
+	return formatContestCueAsJSON(cue, lo.schemaVersion)
+}
+
+// formatContestCueAsJSON formats cue as a JSON record in schemaVersion (empty is current) and
+// validates it against that version's schema
+func formatContestCueAsJSON(cue *parser.ContestCue, schemaVersion string) ([]byte, error) {
 	if cue == nil {
 		return nil, fmt.Errorf("ContestCue cannot be nil")
 	}
 
-	// Extract keyword from Details
-	keyword, exists := cue.Details["keyword"]
-	if !exists {
+	if cue.Details.Keyword == "" {
 		return nil, fmt.Errorf("keyword not found in Details")
 	}
-
-	// Extract number (shortcode) from Details
-	number, exists := cue.Details["number"]
-	if !exists {
+	if cue.Details.Number == "" {
 		return nil, fmt.Errorf("number not found in Details")
 	}
 
+	index, err := schemaIndex(schemaVersion)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the required JSON structure
 	output := map[string]interface{}{
 		"contest_type": cue.ContestType,
-		"keyword":      keyword,
-		"shortcode":    number,
+		"keyword":      cue.Details.Keyword,
+		"shortcode":    cue.Details.Number,
 		"timestamp":    cue.Timestamp,
+		"cue_id":       cue.CueID,
+	}
+	if cue.ContentHash != "" {
+		output["content_hash"] = cue.ContentHash
+	}
+	if len(cue.TraceIDs) > 0 {
+		output["trace_ids"] = cue.TraceIDs
+	}
+	if show := cue.Details.String("program_name"); show != "" {
+		output["program"] = show
+	}
+
+	// Include pipeline latency so consumers can tell how stale the cue is
+	if cue.Timing != nil {
+		output["latency_ms"] = cue.Timing.LatencyMS
+		if !cue.Timing.AudioCapturedAt.IsZero() {
+			output["audio_captured_at"] = cue.Timing.AudioCapturedAt.Format(time.RFC3339Nano)
+		}
+		if !cue.Timing.ShortcodeSpokenAt.IsZero() {
+			output["shortcode_spoken_at"] = cue.Timing.ShortcodeSpokenAt.Format(time.RFC3339Nano)
+		}
+		// Clock drift lets an auditor correct the timestamp when proving an entry was in time
+		if !cue.Timing.ClockCheckedAt.IsZero() {
+			output["clock_offset_ms"] = cue.Timing.ClockOffsetMS
+			output["clock_checked_at"] = cue.Timing.ClockCheckedAt.Format(time.RFC3339Nano)
+		}
+	}
+
+	// Keep only the fields of the requested schema version and make sure the record conforms to it
+	shapeRecord(output, index)
+	if err := validateRecord(output, index); err != nil {
+		return nil, fmt.Errorf("ContestCue does not match the output schema: %w", err)
 	}
 
 	// Marshal to JSON
@@ -108,76 +207,106 @@ func (lo *LogOutput) FormatContestCueAsJSON(cue *parser.ContestCue) ([]byte, err
 	return jsonBytes, nil
 }
 
-// WriteContestCueToFile writes a ContestCue to the configured log file in JSON format
-func (lo *LogOutput) WriteContestCueToFile(cue *parser.ContestCue) error {
+// WriteContestCue queues a ContestCue for every sink. Each sink is written by its own goroutine,
+// so a failing or slow sink only delays itself; failed writes are retried and held, and
+// DeliveryStatus reports them. Close waits for the queued cues to be written.
+func (lo *LogOutput) WriteContestCue(cue *parser.ContestCue) error {
 	if cue == nil {
 		return fmt.Errorf("ContestCue cannot be nil")
 	}
 
-	// Format ContestCue as JSON
-	jsonBytes, err := lo.FormatContestCueAsJSON(cue)
-	if err != nil {
-		return fmt.Errorf("failed to format ContestCue as JSON: %w", err)
-	}
+	lo.startDelivery()
+	return lo.dispatch(cue)
+}
 
-	// Thread-safe file writing
-	lo.mutex.Lock()
-	defer lo.mutex.Unlock()
+// Close writes the queued cues and closes every sink
+func (lo *LogOutput) Close() error {
+	lo.stopDelivery()
 
-	// Ensure directory exists
-	dir := filepath.Dir(lo.filePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	var errs []error
+	for _, sink := range lo.sinks {
+		if err := sink.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+		}
 	}
+	return errors.Join(errs...)
+}
 
-	// Open file for appending (create if doesn't exist)
-	file, err := os.OpenFile(lo.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return fmt.Errorf("failed to open file %s: %w", lo.filePath, err)
-	}
-	defer file.Close()
+// SetInput sets the channel Start processes contest cues from
+func (lo *LogOutput) SetInput(inputCh <-chan parser.ContestCue) {
+	lo.inputCh = inputCh
+}
+
+// Name identifies the log output among the application's components
+func (lo *LogOutput) Name() string {
+	return "log_output"
+}
 
-	// Write JSON with newline
-	if _, err := file.Write(append(jsonBytes, '\n')); err != nil {
-		return fmt.Errorf("failed to write ContestCue to file %s: %w", lo.filePath, err)
+// Start processes the contest cues from the channel set by SetInput until it is closed
+func (lo *LogOutput) Start(ctx context.Context) error {
+	if lo.inputCh == nil {
+		return fmt.Errorf("log output input channel not set")
 	}
+	go lo.ProcessContestCues(lo.inputCh)
+	return nil
+}
 
+// Stop writes the queued cues and closes every sink
+func (lo *LogOutput) Stop(ctx context.Context) error {
+	return lo.Close()
+}
+
+// Healthy returns an error while a sink has cues it has not been able to write
+func (lo *LogOutput) Healthy() error {
+	for _, sink := range lo.DeliveryStatus().Sinks {
+		if sink.Failing() {
+			return fmt.Errorf("sink %s failing: %s", sink.Name, sink.LastError)
+		}
+	}
 	return nil
 }
 
-// ProcessContestCues continuously processes ContestCues from the input channel
+// ProcessContestCues continuously processes ContestCues from the input channel. Each sink has a
+// bounded queue written in batches by its own goroutine, so a slow sink only delays itself and
+// never blocks ingestion; cues are dropped for a sink whose queue is full. Torn lines left by a
+// crash are truncated first.
+// Failed writes are retried and held rather than lost; DeliveryStatus reports them. It returns
+// once the channel is closed and the sink queues drained.
 func (lo *LogOutput) ProcessContestCues(inputCh <-chan parser.ContestCue) {
-	lo.logger.Info("starting contest cue processing pipeline")
-	processedCount := 0
-	successCount := 0
+	lo.logger.Info("starting contest cue processing pipeline",
+		zap.Int("queue_size", lo.options.queueSize),
+		zap.Int("batch_size", lo.options.batchSize))
+
+	lo.startDelivery()
 
+	processedCount := 0
 	for cue := range inputCh {
 		processedCount++
-		lo.logger.Debug("processing contest cue",
+		lo.logger.Debug("queueing contest cue",
 			zap.String("cue_id", cue.CueID),
+			zap.Strings("trace_ids", cue.TraceIDs),
 			zap.String("contest_type", cue.ContestType),
 			zap.Int("processed_count", processedCount))
-
-		// Write ContestCue to file
-		if err := lo.WriteContestCueToFile(&cue); err != nil {
-			lo.logger.Error("failed to write ContestCue to file",
-				zap.Error(err),
+		if err := lo.dispatch(&cue); err != nil {
+			lo.logger.Error("dropping contest cue received after log output closed",
 				zap.String("cue_id", cue.CueID),
-				zap.String("contest_type", cue.ContestType),
-				zap.String("file_path", lo.filePath))
-			// Continue processing despite errors
-			continue
+				zap.Strings("trace_ids", cue.TraceIDs))
 		}
-
-		successCount++
-		lo.logger.Debug("ContestCue written to file successfully",
-			zap.String("cue_id", cue.CueID),
-			zap.String("file_path", lo.filePath),
-			zap.Int("success_count", successCount))
 	}
+	lo.stopDelivery()
 
+	status := lo.DeliveryStatus()
+	var written, dropped uint64
+	lost := 0
+	for _, sink := range status.Sinks {
+		written += sink.Written
+		dropped += sink.Dropped
+		lost += sink.Pending
+	}
 	lo.logger.Info("contest cue processing pipeline completed",
 		zap.Int("total_processed", processedCount),
-		zap.Int("successful_writes", successCount),
-		zap.String("file_path", lo.filePath))
+		zap.Uint64("sink_writes", written),
+		zap.Uint64("dropped", dropped),
+		zap.Int("undelivered", lost),
+		zap.Int("sink_count", len(lo.sinks)))
 }
@@ -0,0 +1,270 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSON cue record schema versions. Minor versions only add optional fields, so consumers built
+// for an older minor version keep working; a sink can also be pinned to an older version for
+// consumers that reject fields they don't know.
+const (
+	// CueSchemaV1_0 is the original record: contest type, keyword, shortcode, timestamp, and cue ID.
+	// It carries no schema_version field.
+	CueSchemaV1_0 = "1.0"
+	// CueSchemaV1_1 adds schema_version, content_hash, latency_ms, and audio_captured_at
+	CueSchemaV1_1 = "1.1"
+	// CueSchemaV1_2 adds clock_offset_ms and clock_checked_at
+	CueSchemaV1_2 = "1.2"
+	// CueSchemaV1_3 adds shortcode_spoken_at
+	CueSchemaV1_3 = "1.3"
+	// CueSchemaV1_4 adds trace_ids
+	CueSchemaV1_4 = "1.4"
+	// CueSchemaV1_5 adds program
+	CueSchemaV1_5 = "1.5"
+
+	// CurrentCueSchemaVersion is the version records are written in unless a sink is pinned
+	CurrentCueSchemaVersion = CueSchemaV1_5
+)
+
+// cueSchemaVersions lists every schema version, oldest first
+var cueSchemaVersions = []string{CueSchemaV1_0, CueSchemaV1_1, CueSchemaV1_2, CueSchemaV1_3, CueSchemaV1_4, CueSchemaV1_5}
+
+// schemaField describes one field of the JSON cue record
+type schemaField struct {
+	name        string
+	kind        string // JSON Schema type: string, integer, or array (of strings)
+	format      string // JSON Schema format, e.g. date-time
+	required    bool
+	since       string // First schema version with the field
+	description string
+}
+
+// cueSchemaFields describes the JSON cue record. New fields must be optional and carry the new
+// minor version in since.
+var cueSchemaFields = []schemaField{
+	{name: "schema_version", kind: "string", required: true, since: CueSchemaV1_1, description: "Schema version of this record, MAJOR.MINOR"},
+	{name: "cue_id", kind: "string", required: true, since: CueSchemaV1_0, description: "UUIDv7 of the cue, sortable by creation time"},
+	{name: "contest_type", kind: "string", required: true, since: CueSchemaV1_0, description: "Kind of contest detected"},
+	{name: "keyword", kind: "string", required: true, since: CueSchemaV1_0, description: "Keyword to text"},
+	{name: "shortcode", kind: "string", required: true, since: CueSchemaV1_0, description: "Number to text the keyword to"},
+	{name: "timestamp", kind: "string", format: "date-time", required: true, since: CueSchemaV1_0, description: "When the cue was detected"},
+	{name: "content_hash", kind: "string", since: CueSchemaV1_1, description: "Stable hash of keyword, number, and time bucket for deduplication"},
+	{name: "latency_ms", kind: "integer", since: CueSchemaV1_1, description: "Milliseconds from audio capture to emission"},
+	{name: "audio_captured_at", kind: "string", format: "date-time", since: CueSchemaV1_1, description: "When the cue's audio was captured"},
+	{name: "clock_offset_ms", kind: "integer", since: CueSchemaV1_2, description: "Milliseconds the detecting host's clock was ahead of NTP time (negative when behind)"},
+	{name: "clock_checked_at", kind: "string", format: "date-time", since: CueSchemaV1_2, description: "When the clock offset was last measured"},
+	{name: "shortcode_spoken_at", kind: "string", format: "date-time", since: CueSchemaV1_3, description: "When the shortcode was spoken, from word-level timestamps"},
+	{name: "trace_ids", kind: "array", since: CueSchemaV1_4, description: "IDs of the audio chunks the cue was heard in, matching trace_id in the application logs"},
+	{name: "program", kind: "string", since: CueSchemaV1_5, description: "Show on the air when the cue was heard, from the station's program schedule"},
+}
+
+// CueSchemaVersions returns every cue record schema version, oldest first
+func CueSchemaVersions() []string {
+	return append([]string(nil), cueSchemaVersions...)
+}
+
+// schemaIndex returns the position of version in cueSchemaVersions. An empty version is the
+// current one.
+func schemaIndex(version string) (int, error) {
+	if version == "" {
+		version = CurrentCueSchemaVersion
+	}
+	for i, known := range cueSchemaVersions {
+		if known == version {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown cue schema version %q (expected one of %s)", version, strings.Join(cueSchemaVersions, ", "))
+}
+
+// ValidateCueSchemaVersion checks that version is a known schema version; empty means current
+func ValidateCueSchemaVersion(version string) error {
+	_, err := schemaIndex(version)
+	return err
+}
+
+// fieldsFor returns the fields defined in the schema version at index
+func fieldsFor(index int) []schemaField {
+	var fields []schemaField
+	for _, field := range cueSchemaFields {
+		since, _ := schemaIndex(field.since)
+		if since <= index {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// CueJSONSchema returns the JSON Schema (draft 2020-12) of cue records in version; empty means
+// current. Unknown properties are allowed so records from a newer minor version still validate.
+func CueJSONSchema(version string) ([]byte, error) {
+	index, err := schemaIndex(version)
+	if err != nil {
+		return nil, err
+	}
+
+	properties := make(map[string]interface{})
+	required := []string{}
+	for _, field := range fieldsFor(index) {
+		property := map[string]interface{}{
+			"type":        field.kind,
+			"description": field.description,
+		}
+		if field.format != "" {
+			property["format"] = field.format
+		}
+		if field.kind == "array" {
+			property["items"] = map[string]interface{}{"type": "string"}
+		}
+		if field.name == "schema_version" {
+			property["pattern"] = fmt.Sprintf(`^%s\.[0-9]+$`, majorVersion(cueSchemaVersions[index]))
+		}
+		properties[field.name] = property
+		if field.required {
+			required = append(required, field.name)
+		}
+	}
+
+	schema := map[string]interface{}{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"title":                fmt.Sprintf("Contest cue record %s", cueSchemaVersions[index]),
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": true,
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// majorVersion returns the MAJOR part of a MAJOR.MINOR version
+func majorVersion(version string) string {
+	major, _, _ := strings.Cut(version, ".")
+	return major
+}
+
+// ValidateCueRecord checks a JSON cue record against the schema version it declares. Records
+// without schema_version are checked as 1.0. A newer minor version than this build knows is
+// checked against the newest known version of that major, ignoring fields added since.
+func ValidateCueRecord(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var record map[string]interface{}
+	if err := decoder.Decode(&record); err != nil {
+		return fmt.Errorf("cue record is not a JSON object: %w", err)
+	}
+
+	version := CueSchemaV1_0
+	if raw, ok := record["schema_version"]; ok {
+		declared, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("schema_version must be a string")
+		}
+		version = declared
+	}
+	index, err := compatibleSchemaIndex(version)
+	if err != nil {
+		return err
+	}
+	return validateRecord(record, index)
+}
+
+// compatibleSchemaIndex returns the index of version, or of the newest known version with the
+// same major when version is a newer minor
+func compatibleSchemaIndex(version string) (int, error) {
+	if index, err := schemaIndex(version); err == nil {
+		return index, nil
+	}
+	major, minor, ok := strings.Cut(version, ".")
+	if _, err := strconv.Atoi(minor); !ok || err != nil {
+		return 0, fmt.Errorf("invalid schema_version %q (expected MAJOR.MINOR)", version)
+	}
+	for i := len(cueSchemaVersions) - 1; i >= 0; i-- {
+		if majorVersion(cueSchemaVersions[i]) == major {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported cue schema major version %q", version)
+}
+
+// validateRecord checks the record's fields against the schema version at index
+func validateRecord(record map[string]interface{}, index int) error {
+	for _, field := range fieldsFor(index) {
+		value, ok := record[field.name]
+		if !ok || value == nil {
+			if field.required {
+				return fmt.Errorf("cue record is missing required field %s", field.name)
+			}
+			continue
+		}
+		if err := checkFieldValue(field, value); err != nil {
+			return fmt.Errorf("cue record field %s: %w", field.name, err)
+		}
+	}
+	return nil
+}
+
+// checkFieldValue checks value against the field's type and format
+func checkFieldValue(field schemaField, value interface{}) error {
+	switch field.kind {
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("must be a string, got %T", value)
+		}
+		if field.format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, text); err != nil {
+				return fmt.Errorf("must be an RFC 3339 date-time: %w", err)
+			}
+		}
+	case "integer":
+		switch number := value.(type) {
+		case int, int64:
+		case float64:
+			if number != math.Trunc(number) {
+				return fmt.Errorf("must be an integer, got %v", number)
+			}
+		case json.Number:
+			if _, err := number.Int64(); err != nil {
+				return fmt.Errorf("must be an integer, got %v", number)
+			}
+		default:
+			return fmt.Errorf("must be an integer, got %T", value)
+		}
+	case "array":
+		switch items := value.(type) {
+		case []string:
+		case []interface{}:
+			for _, item := range items {
+				if _, ok := item.(string); !ok {
+					return fmt.Errorf("items must be strings, got %T", item)
+				}
+			}
+		default:
+			return fmt.Errorf("must be an array, got %T", value)
+		}
+	}
+	return nil
+}
+
+// shapeRecord removes fields the schema version at index does not define, so records for a
+// pinned older version contain only what its consumers expect
+func shapeRecord(record map[string]interface{}, index int) {
+	defined := make(map[string]bool)
+	for _, field := range fieldsFor(index) {
+		defined[field.name] = true
+	}
+	for name := range record {
+		if !defined[name] {
+			delete(record, name)
+		}
+	}
+	if defined["schema_version"] {
+		record["schema_version"] = cueSchemaVersions[index]
+	}
+}
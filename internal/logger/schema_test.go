@@ -0,0 +1,232 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/parser"
+)
+
+func TestCueJSONSchema(t *testing.T) {
+	t.Run("should describe the current record with schema_version required", func(t *testing.T) {
+		// Act
+		data, err := CueJSONSchema("")
+
+		// Assert
+		require.NoError(t, err)
+		var schema map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &schema))
+		assert.Equal(t, "object", schema["type"])
+		assert.Equal(t, true, schema["additionalProperties"])
+		assert.Contains(t, schema["required"], "schema_version")
+		assert.Contains(t, schema["properties"], "latency_ms")
+	})
+
+	t.Run("should describe only the original fields for 1.0", func(t *testing.T) {
+		data, err := CueJSONSchema(CueSchemaV1_0)
+
+		require.NoError(t, err)
+		var schema map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &schema))
+		assert.NotContains(t, schema["properties"], "schema_version")
+		assert.NotContains(t, schema["properties"], "content_hash")
+		assert.Len(t, schema["required"], 5)
+	})
+
+	t.Run("should reject unknown versions", func(t *testing.T) {
+		_, err := CueJSONSchema("0.9")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestValidateCueRecord(t *testing.T) {
+	t.Run("should accept every record the formatter writes", func(t *testing.T) {
+		cue := testCue()
+		capturedAt := time.Now()
+		cue.Timing = parser.NewCueTiming(capturedAt, capturedAt, capturedAt.Add(time.Second))
+
+		for _, version := range CueSchemaVersions() {
+			record, err := formatContestCueAsJSON(cue, version)
+			require.NoError(t, err, version)
+
+			assert.NoError(t, ValidateCueRecord(record), version)
+		}
+	})
+
+	t.Run("should treat records without schema_version as 1.0", func(t *testing.T) {
+		err := ValidateCueRecord([]byte(`{"cue_id":"a","contest_type":"CASH","keyword":"CASH","shortcode":"55555","timestamp":"2025-06-01T12:00:00Z"}`))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should accept newer minor versions with unknown fields", func(t *testing.T) {
+		err := ValidateCueRecord([]byte(`{"schema_version":"1.9","cue_id":"a","contest_type":"CASH","keyword":"CASH","shortcode":"55555","timestamp":"2025-06-01T12:00:00Z","added_later":true}`))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject invalid records", func(t *testing.T) {
+		for _, record := range []string{
+			`{"schema_version":"1.1","cue_id":"a","contest_type":"CASH","keyword":"CASH","timestamp":"2025-06-01T12:00:00Z"}`,
+			`{"schema_version":"1.1","cue_id":"a","contest_type":"CASH","keyword":"CASH","shortcode":55555,"timestamp":"2025-06-01T12:00:00Z"}`,
+			`{"schema_version":"1.1","cue_id":"a","contest_type":"CASH","keyword":"CASH","shortcode":"55555","timestamp":"yesterday"}`,
+			`{"schema_version":"1.1","cue_id":"a","contest_type":"CASH","keyword":"CASH","shortcode":"55555","timestamp":"2025-06-01T12:00:00Z","latency_ms":1.5}`,
+			`{"schema_version":"2.0","cue_id":"a","contest_type":"CASH","keyword":"CASH","shortcode":"55555","timestamp":"2025-06-01T12:00:00Z"}`,
+			`[1, 2]`,
+		} {
+			assert.Error(t, ValidateCueRecord([]byte(record)), record)
+		}
+	})
+}
+
+func TestFormatContestCue_SchemaVersion(t *testing.T) {
+	t.Run("should embed the current schema version", func(t *testing.T) {
+		line, err := FormatContestCue(testCue(), FormatJSON)
+
+		require.NoError(t, err)
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(line, &record))
+		assert.Equal(t, CurrentCueSchemaVersion, record["schema_version"])
+	})
+
+	t.Run("should write only the original fields to a sink pinned to 1.0", func(t *testing.T) {
+		// Arrange
+		var out bytes.Buffer
+		sink := NewWriterSink("stdout", &out, FormatJSON)
+		sink.schemaVersion = CueSchemaV1_0
+
+		// Act
+		require.NoError(t, sink.Write(testCue()))
+
+		// Assert
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &record))
+		assert.NotContains(t, record, "schema_version")
+		assert.NotContains(t, record, "content_hash")
+		assert.Equal(t, "55555", record["shortcode"])
+	})
+
+	t.Run("should include when the shortcode was spoken from 1.3", func(t *testing.T) {
+		// Arrange
+		cue := testCue()
+		spokenAt := time.Date(2025, 6, 1, 12, 0, 2, 300_000_000, time.UTC)
+		cue.Timing = parser.NewCueTiming(spokenAt.Add(-2*time.Second), time.Time{}, spokenAt.Add(3*time.Second))
+		cue.Timing.ShortcodeSpokenAt = spokenAt
+
+		// Act
+		current, err := formatContestCueAsJSON(cue, CueSchemaV1_3)
+		require.NoError(t, err)
+		pinned, err := formatContestCueAsJSON(cue, CueSchemaV1_2)
+		require.NoError(t, err)
+
+		// Assert
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(current, &record))
+		assert.Equal(t, "2025-06-01T12:00:02.3Z", record["shortcode_spoken_at"])
+		var pinnedRecord map[string]interface{}
+		require.NoError(t, json.Unmarshal(pinned, &pinnedRecord))
+		assert.NotContains(t, pinnedRecord, "shortcode_spoken_at")
+	})
+
+	t.Run("should include the trace IDs from 1.4", func(t *testing.T) {
+		// Arrange
+		cue := testCue()
+		cue.TraceIDs = []string{"0123456789abcdef", "fedcba9876543210"}
+
+		// Act
+		current, err := formatContestCueAsJSON(cue, CueSchemaV1_4)
+		require.NoError(t, err)
+		pinned, err := formatContestCueAsJSON(cue, CueSchemaV1_3)
+		require.NoError(t, err)
+
+		// Assert
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(current, &record))
+		assert.Equal(t, []interface{}{"0123456789abcdef", "fedcba9876543210"}, record["trace_ids"])
+		assert.NoError(t, ValidateCueRecord(current))
+		var pinnedRecord map[string]interface{}
+		require.NoError(t, json.Unmarshal(pinned, &pinnedRecord))
+		assert.NotContains(t, pinnedRecord, "trace_ids")
+	})
+
+	t.Run("should include the program from 1.5", func(t *testing.T) {
+		// Arrange
+		cue := testCue()
+		cue.Details.Set("program_name", "Morning Drive")
+
+		// Act
+		current, err := formatContestCueAsJSON(cue, CueSchemaV1_5)
+		require.NoError(t, err)
+		pinned, err := formatContestCueAsJSON(cue, CueSchemaV1_4)
+		require.NoError(t, err)
+
+		// Assert
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(current, &record))
+		assert.Equal(t, "Morning Drive", record["program"])
+		var pinnedRecord map[string]interface{}
+		require.NoError(t, json.Unmarshal(pinned, &pinnedRecord))
+		assert.NotContains(t, pinnedRecord, "program")
+	})
+
+	t.Run("should reject trace IDs that are not strings", func(t *testing.T) {
+		record := []byte(`{"schema_version":"1.4","cue_id":"a","contest_type":"t","keyword":"k","shortcode":"1","timestamp":"2025-06-01T12:00:00Z","trace_ids":[1]}`)
+
+		err := ValidateCueRecord(record)
+
+		assert.ErrorContains(t, err, "trace_ids")
+	})
+
+	t.Run("should include the measured clock offset", func(t *testing.T) {
+		cue := testCue()
+		cue.Timing = parser.NewCueTiming(time.Time{}, time.Time{}, time.Now())
+		cue.Timing.ClockOffsetMS = -42
+		cue.Timing.ClockCheckedAt = time.Now().UTC()
+
+		line, err := FormatContestCue(cue, FormatJSON)
+
+		require.NoError(t, err)
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(line, &record))
+		assert.Equal(t, float64(-42), record["clock_offset_ms"])
+		assert.Contains(t, record, "clock_checked_at")
+		assert.NoError(t, ValidateCueRecord(line))
+	})
+
+	t.Run("should reject cues that do not match the schema", func(t *testing.T) {
+		cue := testCue()
+		cue.Timestamp = "not a time"
+
+		_, err := FormatContestCue(cue, FormatJSON)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should pin sinks to the configured schema version", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetLogSchemaVersion(CueSchemaV1_0)
+		cfg.SetLogSinks([]config.LogSink{{Type: SinkHTTP, Target: "http://127.0.0.1:1/cues", SchemaVersion: CueSchemaV1_1}, {Type: SinkStdout}})
+
+		logOutput, err := NewLogOutput(cfg, NewLogger())
+
+		require.NoError(t, err)
+		assert.Equal(t, CueSchemaV1_1, logOutput.Sinks()[0].(*HTTPSink).schemaVersion)
+		assert.Equal(t, CueSchemaV1_0, logOutput.Sinks()[1].(*WriterSink).schemaVersion)
+	})
+
+	t.Run("should reject an unknown configured schema version", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetLogSchemaVersion("3.0")
+
+		_, err := NewLogOutput(cfg, NewLogger())
+
+		assert.Error(t, err)
+	})
+}
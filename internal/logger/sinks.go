@@ -0,0 +1,480 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/parser"
+)
+
+// Sink types and formats accepted in log sink configuration
+const (
+	SinkFile   = "file"
+	SinkStdout = "stdout"
+	SinkSyslog = "syslog"
+	SinkHTTP   = "http"
+
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Sink is a destination contest cues are written to
+type Sink interface {
+	Name() string
+	Write(cue *parser.ContestCue) error
+	Close() error
+}
+
+// BatchSink is a Sink that can write several cues at once. WriteBatch returns how many of the
+// leading cues were written, so a partly failed batch is not written twice.
+type BatchSink interface {
+	Sink
+	WriteBatch(cues []*parser.ContestCue) (int, error)
+}
+
+// Syncer is a Sink whose writes are flushed to stable storage separately
+type Syncer interface {
+	Sync() error
+}
+
+// Recoverer is a Sink that can repair what a crash mid-write left behind. Recover returns how
+// many bytes of partial output it discarded.
+type Recoverer interface {
+	Recover() (int64, error)
+}
+
+// File sink fsync policies accepted in log.fsync_policy
+const (
+	FsyncAlways   = "always"
+	FsyncInterval = "interval"
+	FsyncNever    = "never"
+)
+
+// ValidateFsyncPolicy returns an error for an unknown fsync policy
+func ValidateFsyncPolicy(policy string) error {
+	switch policy {
+	case FsyncAlways, FsyncInterval, FsyncNever:
+		return nil
+	}
+	return fmt.Errorf("unknown log fsync policy %q (expected always, interval, or never)", policy)
+}
+
+// formatError marks a cue that can never be written, so it is not retried
+type formatError struct {
+	err error
+}
+
+func (e *formatError) Error() string { return e.err.Error() }
+func (e *formatError) Unwrap() error { return e.err }
+
+// FormatContestCue formats a ContestCue as a single line in the given format, using the current
+// schema version for JSON
+func FormatContestCue(cue *parser.ContestCue, format string) ([]byte, error) {
+	return formatContestCue(cue, format, "", nil)
+}
+
+// formatContestCue formats a ContestCue as a single line, using schemaVersion for JSON and showing
+// the time in location for text (nil keeps the UTC timestamp)
+func formatContestCue(cue *parser.ContestCue, format, schemaVersion string, location *time.Location) ([]byte, error) {
+	line, err := formatContestCueLine(cue, format, schemaVersion, location)
+	if err != nil {
+		return nil, &formatError{err: err}
+	}
+	return line, nil
+}
+
+// formatContestCueLine does the formatting for formatContestCue
+func formatContestCueLine(cue *parser.ContestCue, format, schemaVersion string, location *time.Location) ([]byte, error) {
+	if cue == nil {
+		return nil, fmt.Errorf("ContestCue cannot be nil")
+	}
+
+	switch format {
+	case FormatJSON:
+		return formatContestCueAsJSON(cue, schemaVersion)
+	case FormatText:
+		line := fmt.Sprintf("%s %s: text %v to %v", cue.FormatTimestamp(location), cue.ContestType, cue.Details.Keyword, cue.Details.Number)
+		if cue.Timing != nil && !cue.Timing.AudioCapturedAt.IsZero() {
+			line += fmt.Sprintf(" (latency %dms)", cue.Timing.LatencyMS)
+		}
+		return []byte(line), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q", format)
+	}
+}
+
+// NewSink creates the sink described by the configuration entry
+func NewSink(cfg config.LogSink, httpTimeout time.Duration) (Sink, error) {
+	format := cfg.Format
+	if format == "" {
+		format = FormatJSON
+		if cfg.Type == SinkStdout || cfg.Type == SinkSyslog {
+			format = FormatText
+		}
+	}
+	if format != FormatJSON && format != FormatText {
+		return nil, fmt.Errorf("log sink %s: unknown format %q (expected json or text)", cfg.Type, format)
+	}
+	if err := ValidateCueSchemaVersion(cfg.SchemaVersion); err != nil {
+		return nil, fmt.Errorf("log sink %s: %w", cfg.Type, err)
+	}
+	var location *time.Location
+	if cfg.Timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(cfg.Timezone); err != nil {
+			return nil, fmt.Errorf("log sink %s: invalid timezone: %w", cfg.Type, err)
+		}
+	}
+
+	switch cfg.Type {
+	case SinkFile:
+		if cfg.Target == "" {
+			return nil, fmt.Errorf("file log sink requires a path")
+		}
+		sink := NewFileSink(cfg.Target, format)
+		sink.schemaVersion = cfg.SchemaVersion
+		sink.location = location
+		return sink, nil
+	case SinkStdout:
+		sink := NewWriterSink(SinkStdout, os.Stdout, format)
+		sink.schemaVersion = cfg.SchemaVersion
+		sink.location = location
+		return sink, nil
+	case SinkSyslog:
+		return newSyslogSink(cfg, format, location)
+	case SinkHTTP:
+		if cfg.Target == "" {
+			return nil, fmt.Errorf("http log sink requires a URL")
+		}
+		sink := NewHTTPSink(cfg.Target, format, httpTimeout)
+		sink.schemaVersion = cfg.SchemaVersion
+		sink.location = location
+		return sink, nil
+	default:
+		return nil, fmt.Errorf("unknown log sink type %q (expected file, stdout, syslog, or http)", cfg.Type)
+	}
+}
+
+// FileSink appends cues to a file, one per line. The file is kept open between writes and
+// reopened after a write error or when it has been moved aside (e.g. by logrotate). Each batch
+// is a single O_APPEND write, and a write that fails partway is cut back to the last complete
+// line, so readers never see half a cue.
+type FileSink struct {
+	path          string
+	format        string
+	schemaVersion string         // JSON cue record schema version; empty is current
+	location      *time.Location // Time zone of text timestamps; nil keeps UTC
+	mutex         sync.Mutex
+	file          *os.File
+	dirty         bool // Written since the last fsync
+}
+
+// NewFileSink creates a FileSink appending to path
+func NewFileSink(path, format string) *FileSink {
+	return &FileSink{path: path, format: format}
+}
+
+// Name returns the sink name used in logs
+func (s *FileSink) Name() string {
+	return "file:" + s.path
+}
+
+// Path returns the file cues are appended to
+func (s *FileSink) Path() string {
+	return s.path
+}
+
+// Write appends the cue to the file, creating the file and its directory if needed
+func (s *FileSink) Write(cue *parser.ContestCue) error {
+	_, err := s.WriteBatch([]*parser.ContestCue{cue})
+	return err
+}
+
+// WriteBatch appends the cues to the file in a single write and returns how many complete
+// lines reached the file. A cue that cannot be formatted stops the batch before it.
+func (s *FileSink) WriteBatch(cues []*parser.ContestCue) (int, error) {
+	var buf bytes.Buffer
+	ends := make([]int, 0, len(cues)) // Offset just past each cue's line
+	var formatErr error
+	for _, cue := range cues {
+		line, err := formatContestCue(cue, s.format, s.schemaVersion, s.location)
+		if err != nil {
+			formatErr = err
+			break
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+		ends = append(ends, buf.Len())
+	}
+	if buf.Len() == 0 {
+		return 0, formatErr
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.openLocked(); err != nil {
+		return 0, err
+	}
+
+	// The size before the write is where a torn batch is cut back to; O_APPEND makes each
+	// write land at the end even if another process appends too
+	var start int64 = -1
+	if info, err := s.file.Stat(); err == nil {
+		start = info.Size()
+	}
+	n, err := s.file.Write(buf.Bytes())
+	written := 0
+	for written < len(ends) && ends[written] <= n {
+		written++
+	}
+	if written > 0 {
+		s.dirty = true
+	}
+	if err != nil {
+		if start >= 0 && n > 0 {
+			complete := 0
+			if written > 0 {
+				complete = ends[written-1]
+			}
+			if complete < n {
+				s.file.Truncate(start + int64(complete))
+			}
+		}
+		// Drop the handle so the next write reopens the file, e.g. after a stale NFS handle
+		s.closeLocked()
+		return written, fmt.Errorf("failed to write ContestCue to file %s: %w", s.path, err)
+	}
+	return written, formatErr
+}
+
+// Recover truncates a torn final line, left when the process or the device died mid-write,
+// so the next cue starts on a line of its own. A missing file needs no recovery.
+func (s *FileSink) Recover() (int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_RDWR, 0)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to open file %s: %w", s.path, err)
+	}
+	defer file.Close()
+
+	end, err := lastLineEnd(file)
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan file %s: %w", s.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan file %s: %w", s.path, err)
+	}
+	torn := info.Size() - end
+	if torn == 0 {
+		return 0, nil
+	}
+	if err := file.Truncate(end); err != nil {
+		return 0, fmt.Errorf("failed to truncate torn line in %s: %w", s.path, err)
+	}
+	if err := file.Sync(); err != nil {
+		return 0, fmt.Errorf("failed to sync file %s: %w", s.path, err)
+	}
+	return torn, nil
+}
+
+// lastLineEnd returns the offset just past the file's last newline, or 0 without one, reading
+// backwards from the end a block at a time
+func lastLineEnd(file *os.File) (int64, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	const blockSize = 4096
+	block := make([]byte, blockSize)
+	for end := info.Size(); end > 0; {
+		start := max(end-blockSize, 0)
+		n, err := file.ReadAt(block[:end-start], start)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return 0, err
+		}
+		if i := bytes.LastIndexByte(block[:n], '\n'); i >= 0 {
+			return start + int64(i) + 1, nil
+		}
+		end = start
+	}
+	return 0, nil
+}
+
+// Sync flushes written cues to stable storage
+func (s *FileSink) Sync() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.file == nil || !s.dirty {
+		return nil
+	}
+	if err := s.file.Sync(); err != nil {
+		s.closeLocked()
+		return fmt.Errorf("failed to sync file %s: %w", s.path, err)
+	}
+	s.dirty = false
+	return nil
+}
+
+// Close syncs and closes the file
+func (s *FileSink) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	var syncErr error
+	if s.dirty {
+		syncErr = s.file.Sync()
+	}
+	return errors.Join(syncErr, s.closeLocked())
+}
+
+// openLocked opens the file unless the open handle still refers to it
+func (s *FileSink) openLocked() error {
+	if s.file != nil {
+		current, err := os.Stat(s.path)
+		open, openErr := s.file.Stat()
+		if err == nil && openErr == nil && os.SameFile(current, open) {
+			return nil
+		}
+		s.closeLocked()
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", s.path, err)
+	}
+	s.file = file
+	return nil
+}
+
+// closeLocked closes the open handle, syncing nothing
+func (s *FileSink) closeLocked() error {
+	err := s.file.Close()
+	s.file = nil
+	s.dirty = false
+	return err
+}
+
+// WriterSink writes cues to an io.Writer such as stdout, one per line
+type WriterSink struct {
+	name          string
+	writer        io.Writer
+	format        string
+	schemaVersion string
+	location      *time.Location
+	mutex         sync.Mutex
+}
+
+// NewWriterSink creates a WriterSink writing to w
+func NewWriterSink(name string, w io.Writer, format string) *WriterSink {
+	return &WriterSink{name: name, writer: w, format: format}
+}
+
+// Name returns the sink name used in logs
+func (s *WriterSink) Name() string {
+	return s.name
+}
+
+// Write writes the cue as a single line
+func (s *WriterSink) Write(cue *parser.ContestCue) error {
+	line, err := formatContestCue(cue, s.format, s.schemaVersion, s.location)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.writer.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write ContestCue to %s: %w", s.name, err)
+	}
+	return nil
+}
+
+// Close is a no-op; the writer is owned by the caller
+func (s *WriterSink) Close() error {
+	return nil
+}
+
+// HTTPSink posts each cue to an HTTP endpoint
+type HTTPSink struct {
+	url           string
+	format        string
+	schemaVersion string
+	location      *time.Location
+	client        *http.Client
+}
+
+// NewHTTPSink creates an HTTPSink posting to url
+func NewHTTPSink(url, format string, timeout time.Duration) *HTTPSink {
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return &HTTPSink{
+		url:    url,
+		format: format,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Name returns the sink name used in logs
+func (s *HTTPSink) Name() string {
+	return "http:" + s.url
+}
+
+// Write posts the cue as the request body
+func (s *HTTPSink) Write(cue *parser.ContestCue) error {
+	body, err := formatContestCue(cue, s.format, s.schemaVersion, s.location)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if s.format == FormatJSON {
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post ContestCue: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("http log sink returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Close releases idle connections
+func (s *HTTPSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,221 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/parser"
+)
+
+// failingSink always fails, optionally after a delay
+type failingSink struct {
+	delay time.Duration
+}
+
+func (s *failingSink) Name() string { return "failing" }
+
+func (s *failingSink) Write(cue *parser.ContestCue) error {
+	time.Sleep(s.delay)
+	return assert.AnError
+}
+
+func (s *failingSink) Close() error { return nil }
+
+func testCue() *parser.ContestCue {
+	return parser.NewContestCue("CASH", parser.CueDetails{Keyword: "CASH", Number: "55555"})
+}
+
+func TestFormatContestCue(t *testing.T) {
+	t.Run("should format a text line", func(t *testing.T) {
+		// Arrange
+		cue := testCue()
+		capturedAt := time.Now()
+		cue.Timing = parser.NewCueTiming(capturedAt, capturedAt, capturedAt.Add(1500*time.Millisecond))
+
+		// Act
+		line, err := FormatContestCue(cue, FormatText)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, cue.Timestamp+" CASH: text CASH to 55555 (latency 1500ms)", string(line))
+	})
+
+	t.Run("should reject unknown formats", func(t *testing.T) {
+		_, err := FormatContestCue(testCue(), "xml")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestNewSink(t *testing.T) {
+	t.Run("should create each sink type with its default format", func(t *testing.T) {
+		tests := []struct {
+			sink     config.LogSink
+			expected string
+		}{
+			{config.LogSink{Type: "file", Target: "/tmp/cues.log"}, "file:/tmp/cues.log"},
+			{config.LogSink{Type: "stdout"}, "stdout"},
+			{config.LogSink{Type: "syslog"}, "syslog"},
+			{config.LogSink{Type: "syslog", Target: "udp://127.0.0.1:514"}, "syslog:udp://127.0.0.1:514"},
+			{config.LogSink{Type: "http", Target: "http://example.invalid/cues"}, "http:http://example.invalid/cues"},
+		}
+
+		for _, tt := range tests {
+			sink, err := NewSink(tt.sink, time.Second)
+
+			require.NoError(t, err, tt.sink)
+			assert.Equal(t, tt.expected, sink.Name())
+		}
+	})
+
+	t.Run("should show text timestamps in the sink timezone", func(t *testing.T) {
+		// Arrange
+		logFile := filepath.Join(t.TempDir(), "cues.log")
+		sink, err := NewSink(config.LogSink{Type: "file", Target: logFile, Format: FormatText, Timezone: "America/Chicago"}, time.Second)
+		require.NoError(t, err)
+		defer sink.Close()
+		cue := testCue()
+		cue.Timestamp = "2026-10-17T19:03:05Z"
+
+		// Act
+		err = sink.Write(cue)
+
+		// Assert
+		require.NoError(t, err)
+		content, err := os.ReadFile(logFile)
+		require.NoError(t, err)
+		assert.Equal(t, "2026-10-17 14:03:05 CDT CASH: text CASH to 55555\n", string(content))
+	})
+
+	t.Run("should reject invalid sink configuration", func(t *testing.T) {
+		invalid := []config.LogSink{
+			{Type: "kafka"},
+			{Type: "stdout", Timezone: "Mars/Olympus"},
+			{Type: "file"},
+			{Type: "http"},
+			{Type: "stdout", Format: "xml"},
+			{Type: "syslog", Target: "host:514"},
+		}
+
+		for _, sink := range invalid {
+			_, err := NewSink(sink, time.Second)
+
+			assert.Error(t, err, sink)
+		}
+	})
+}
+
+func TestLogOutput_FanOut(t *testing.T) {
+	t.Run("should write every cue to all sinks in their own formats", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		jsonFile := filepath.Join(tmpDir, "cues.jsonl")
+		var stdout bytes.Buffer
+
+		var posted []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			posted, _ = io.ReadAll(r.Body)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		cfg := config.NewConfiguration()
+		cfg.SetLogSinks([]config.LogSink{
+			{Type: "file", Target: jsonFile, Format: "json"},
+			{Type: "http", Target: server.URL},
+		})
+		logOutput, err := NewLogOutput(cfg, NewLogger())
+		require.NoError(t, err)
+		logOutput.sinks = append(logOutput.sinks, NewWriterSink("stdout", &stdout, FormatText))
+		cue := testCue()
+
+		// Act
+		err = logOutput.WriteContestCue(cue)
+		require.NoError(t, logOutput.Close())
+
+		// Assert
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(jsonFile)
+		require.NoError(t, err)
+		var fileEntry map[string]interface{}
+		require.NoError(t, json.Unmarshal(content, &fileEntry))
+		assert.Equal(t, "55555", fileEntry["shortcode"])
+
+		var postedEntry map[string]interface{}
+		require.NoError(t, json.Unmarshal(posted, &postedEntry))
+		assert.Equal(t, "CASH", postedEntry["keyword"])
+
+		assert.Equal(t, cue.Timestamp+" CASH: text CASH to 55555\n", stdout.String())
+	})
+
+	t.Run("should keep writing to healthy sinks when one fails", func(t *testing.T) {
+		// Arrange
+		logFile := filepath.Join(t.TempDir(), "cues.log")
+		cfg := config.NewConfiguration()
+		cfg.SetLogRetryBackoffMS(1)
+		logOutput, err := NewLogOutput(cfg, NewLogger())
+		require.NoError(t, err)
+		logOutput.sinks = []Sink{&failingSink{}, NewFileSink(logFile, FormatJSON)}
+
+		// Act
+		err = logOutput.WriteContestCue(testCue())
+		logOutput.Close()
+
+		// Assert
+		require.NoError(t, err)
+		err = logOutput.Healthy()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failing")
+		assert.FileExists(t, logFile)
+	})
+
+	t.Run("should not wait for a slow sink before writing the others", func(t *testing.T) {
+		// Arrange
+		healthy := &flakySink{}
+		cfg := config.NewConfiguration()
+		cfg.SetLogRetryBackoffMS(1)
+		logOutput, err := NewLogOutput(cfg, NewLogger())
+		require.NoError(t, err)
+		logOutput.sinks = []Sink{&failingSink{delay: 200 * time.Millisecond}, &failingSink{delay: 200 * time.Millisecond}, healthy}
+		defer logOutput.Close()
+
+		// Act
+		start := time.Now()
+		err = logOutput.WriteContestCue(testCue())
+		err2 := logOutput.WriteContestCue(testCue())
+
+		// Assert
+		require.NoError(t, err)
+		require.NoError(t, err2)
+		assert.Eventually(t, func() bool { return len(healthy.written()) == 2 }, 150*time.Millisecond, 5*time.Millisecond,
+			"the healthy sink should be written while the slow ones are still failing")
+		assert.Less(t, time.Since(start), 200*time.Millisecond)
+	})
+}
+
+func TestHTTPSink_Write(t *testing.T) {
+	t.Run("should fail on a non-2xx response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		err := NewHTTPSink(server.URL, FormatText, time.Second).Write(testCue())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "503")
+	})
+}
@@ -0,0 +1,104 @@
+//go:build !windows
+
+package logger
+
+import (
+	"fmt"
+	"log/syslog"
+	"net/url"
+	"sync"
+	"time"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/parser"
+)
+
+// syslogTag identifies cue lines in syslog
+const syslogTag = "radiocontestwinner"
+
+// newSyslogSink creates the SyslogSink for a log sink configuration
+func newSyslogSink(cfg config.LogSink, format string, location *time.Location) (Sink, error) {
+	sink, err := NewSyslogSink(cfg.Target, format)
+	if err != nil {
+		return nil, err
+	}
+	sink.schemaVersion = cfg.SchemaVersion
+	sink.location = location
+	return sink, nil
+}
+
+// SyslogSink sends cues to the local syslog daemon or a remote syslog server
+type SyslogSink struct {
+	network       string
+	address       string
+	format        string
+	schemaVersion string
+	location      *time.Location
+	mutex         sync.Mutex
+	writer        *syslog.Writer
+}
+
+// NewSyslogSink creates a SyslogSink. An empty target uses the local syslog daemon; otherwise
+// the target is a "udp://host:port" or "tcp://host:port" address. The connection is made on
+// the first write and re-established after a failure, so an unavailable syslog server does
+// not prevent startup.
+func NewSyslogSink(target, format string) (*SyslogSink, error) {
+	sink := &SyslogSink{format: format}
+	if target == "" {
+		return sink, nil
+	}
+
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "udp" && u.Scheme != "tcp") || u.Host == "" {
+		return nil, fmt.Errorf("invalid syslog address %q (expected udp://host:port or tcp://host:port)", target)
+	}
+	sink.network = u.Scheme
+	sink.address = u.Host
+	return sink, nil
+}
+
+// Name returns the sink name used in logs
+func (s *SyslogSink) Name() string {
+	if s.address == "" {
+		return SinkSyslog
+	}
+	return SinkSyslog + ":" + s.network + "://" + s.address
+}
+
+// Write sends the cue at info priority
+func (s *SyslogSink) Write(cue *parser.ContestCue) error {
+	line, err := formatContestCue(cue, s.format, s.schemaVersion, s.location)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.writer == nil {
+		writer, err := syslog.Dial(s.network, s.address, syslog.LOG_INFO|syslog.LOG_USER, syslogTag)
+		if err != nil {
+			return fmt.Errorf("failed to connect to syslog: %w", err)
+		}
+		s.writer = writer
+	}
+
+	if err := s.writer.Info(string(line)); err != nil {
+		s.writer.Close()
+		s.writer = nil
+		return fmt.Errorf("failed to write ContestCue to syslog: %w", err)
+	}
+	return nil
+}
+
+// Close closes the syslog connection
+func (s *SyslogSink) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.writer == nil {
+		return nil
+	}
+	err := s.writer.Close()
+	s.writer = nil
+	return err
+}
@@ -0,0 +1,39 @@
+//go:build !windows
+
+package logger
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyslogSink_Write(t *testing.T) {
+	t.Run("should send cues to a remote syslog server", func(t *testing.T) {
+		// Arrange
+		listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+
+		sink, err := NewSyslogSink("udp://"+listener.LocalAddr().String(), FormatText)
+		require.NoError(t, err)
+		defer sink.Close()
+
+		// Act
+		err = sink.Write(testCue())
+
+		// Assert
+		require.NoError(t, err)
+		buf := make([]byte, 2048)
+		listener.SetReadDeadline(time.Now().Add(time.Second))
+		n, _, err := listener.ReadFrom(buf)
+		require.NoError(t, err)
+		message := string(buf[:n])
+		assert.True(t, strings.Contains(message, "radiocontestwinner"), message)
+		assert.Contains(t, message, "CASH: text CASH to 55555")
+	})
+}
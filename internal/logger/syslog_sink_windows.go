@@ -0,0 +1,17 @@
+//go:build windows
+
+package logger
+
+import (
+	"errors"
+	"time"
+
+	"radiocontestwinner/internal/config"
+)
+
+// log/syslog doesn't exist on Windows
+var errSyslogUnsupported = errors.New("syslog log sinks are not supported on Windows (use a file, stdout, or http sink)")
+
+func newSyslogSink(cfg config.LogSink, format string, location *time.Location) (Sink, error) {
+	return nil, errSyslogUnsupported
+}
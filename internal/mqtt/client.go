@@ -0,0 +1,360 @@
+package mqtt
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MQTT 3.1.1 control packet types (upper nibble of the fixed header)
+const (
+	packetConnect    byte = 0x10
+	packetConnack    byte = 0x20
+	packetPublish    byte = 0x30
+	packetPingreq    byte = 0xC0
+	packetDisconnect byte = 0xE0
+)
+
+// connackReasons maps CONNACK return codes to readable errors
+var connackReasons = map[byte]string{
+	1: "unacceptable protocol version",
+	2: "identifier rejected",
+	3: "server unavailable",
+	4: "bad username or password",
+	5: "not authorized",
+}
+
+// Message is a single MQTT publication
+type Message struct {
+	Topic   string
+	Payload []byte
+	Retain  bool
+}
+
+// Options configures a Client
+type Options struct {
+	Broker    string // tcp://host:port, ssl://host:port, or host:port
+	ClientID  string
+	Username  string
+	Password  string
+	KeepAlive time.Duration // Interval between PINGREQs (default 60s)
+	Will      *Message      // Last will published by the broker if the connection drops
+	Timeout   time.Duration // Dial and write timeout (default 10s)
+}
+
+// Client is a minimal MQTT 3.1.1 publisher (QoS 0) that connects lazily and
+// reconnects on the next publish after a connection failure
+type Client struct {
+	opts      Options
+	mu        sync.Mutex
+	conn      net.Conn
+	done      chan struct{}
+	onConnect func() []Message
+}
+
+// NewClient creates a new Client; no connection is made until the first Publish
+func NewClient(opts Options) (*Client, error) {
+	if opts.Broker == "" {
+		return nil, fmt.Errorf("broker address cannot be empty")
+	}
+	if opts.ClientID == "" {
+		return nil, fmt.Errorf("client ID cannot be empty")
+	}
+	if opts.KeepAlive <= 0 {
+		opts.KeepAlive = 60 * time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	return &Client{opts: opts}, nil
+}
+
+// OnConnect registers a function returning messages to publish immediately after every
+// (re)connect, e.g. retained discovery and availability messages
+func (c *Client) OnConnect(fn func() []Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onConnect = fn
+}
+
+// Publish sends a QoS 0 message, connecting or reconnecting as needed
+func (c *Client) Publish(msg Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn != nil {
+		if err := c.writeLocked(encodePublish(msg)); err == nil {
+			return nil
+		}
+		c.closeLocked()
+	}
+
+	if err := c.connectLocked(); err != nil {
+		return err
+	}
+	if err := c.writeLocked(encodePublish(msg)); err != nil {
+		c.closeLocked()
+		return fmt.Errorf("failed to publish to %s: %w", msg.Topic, err)
+	}
+	return nil
+}
+
+// Close sends DISCONNECT and closes the connection; the last will is not published
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		return nil
+	}
+	_ = c.writeLocked([]byte{packetDisconnect, 0})
+	c.closeLocked()
+	return nil
+}
+
+// connectLocked dials the broker, performs the CONNECT handshake, and publishes on-connect messages
+func (c *Client) connectLocked() error {
+	conn, err := c.dial()
+	if err != nil {
+		return fmt.Errorf("failed to connect to MQTT broker %s: %w", c.opts.Broker, err)
+	}
+
+	conn.SetDeadline(time.Now().Add(c.opts.Timeout))
+	if _, err := conn.Write(encodeConnect(c.opts)); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to send CONNECT: %w", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	packetType, body, err := readPacket(reader)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to read CONNACK: %w", err)
+	}
+	if packetType != packetConnack || len(body) != 2 {
+		conn.Close()
+		return fmt.Errorf("unexpected packet 0x%02x while waiting for CONNACK", packetType)
+	}
+	if rc := body[1]; rc != 0 {
+		conn.Close()
+		if reason, ok := connackReasons[rc]; ok {
+			return fmt.Errorf("MQTT broker refused connection: %s", reason)
+		}
+		return fmt.Errorf("MQTT broker refused connection: return code %d", rc)
+	}
+	conn.SetDeadline(time.Time{})
+
+	c.conn = conn
+	c.done = make(chan struct{})
+	go c.readLoop(conn, reader, c.done)
+	go c.pingLoop(c.done)
+
+	if c.onConnect != nil {
+		for _, msg := range c.onConnect() {
+			if err := c.writeLocked(encodePublish(msg)); err != nil {
+				c.closeLocked()
+				return fmt.Errorf("failed to publish to %s: %w", msg.Topic, err)
+			}
+		}
+	}
+	return nil
+}
+
+// dial opens a TCP or TLS connection based on the broker URL scheme
+func (c *Client) dial() (net.Conn, error) {
+	address := c.opts.Broker
+	useTLS := false
+	if strings.Contains(address, "://") {
+		u, err := url.Parse(address)
+		if err != nil {
+			return nil, err
+		}
+		switch u.Scheme {
+		case "tcp", "mqtt":
+		case "ssl", "tls", "mqtts":
+			useTLS = true
+		default:
+			return nil, fmt.Errorf("unsupported broker scheme %q", u.Scheme)
+		}
+		address = u.Host
+	}
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		if useTLS {
+			address = net.JoinHostPort(address, "8883")
+		} else {
+			address = net.JoinHostPort(address, "1883")
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: c.opts.Timeout}
+	if useTLS {
+		host, _, _ := net.SplitHostPort(address)
+		return tls.DialWithDialer(dialer, "tcp", address, &tls.Config{ServerName: host})
+	}
+	return dialer.Dial("tcp", address)
+}
+
+// writeLocked writes a packet with the configured write timeout
+func (c *Client) writeLocked(packet []byte) error {
+	if c.conn == nil {
+		return errors.New("not connected")
+	}
+	c.conn.SetWriteDeadline(time.Now().Add(c.opts.Timeout))
+	_, err := c.conn.Write(packet)
+	return err
+}
+
+// closeLocked tears down the current connection and stops its goroutines
+func (c *Client) closeLocked() {
+	if c.conn == nil {
+		return
+	}
+	close(c.done)
+	c.conn.Close()
+	c.conn = nil
+}
+
+// readLoop drains incoming packets (PINGRESP etc.) and drops the connection on error
+func (c *Client) readLoop(conn net.Conn, reader *bufio.Reader, done chan struct{}) {
+	for {
+		if _, _, err := readPacket(reader); err != nil {
+			c.mu.Lock()
+			if c.conn == conn {
+				c.closeLocked()
+			}
+			c.mu.Unlock()
+			return
+		}
+		select {
+		case <-done:
+			return
+		default:
+		}
+	}
+}
+
+// pingLoop keeps the connection alive while it is open
+func (c *Client) pingLoop(done chan struct{}) {
+	ticker := time.NewTicker(c.opts.KeepAlive / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			c.mu.Lock()
+			if c.conn != nil && c.writeLocked([]byte{packetPingreq, 0}) != nil {
+				c.closeLocked()
+			}
+			c.mu.Unlock()
+		}
+	}
+}
+
+// encodeConnect builds a CONNECT packet with a clean session
+func encodeConnect(opts Options) []byte {
+	flags := byte(0x02) // clean session
+	var payload []byte
+	payload = appendString(payload, opts.ClientID)
+	if opts.Will != nil {
+		flags |= 0x04
+		if opts.Will.Retain {
+			flags |= 0x20
+		}
+		payload = appendString(payload, opts.Will.Topic)
+		payload = appendBytes(payload, opts.Will.Payload)
+	}
+	if opts.Username != "" {
+		flags |= 0x80
+		payload = appendString(payload, opts.Username)
+		if opts.Password != "" {
+			flags |= 0x40
+			payload = appendString(payload, opts.Password)
+		}
+	}
+
+	var variable []byte
+	variable = appendString(variable, "MQTT")
+	variable = append(variable, 4, flags)
+	variable = binary.BigEndian.AppendUint16(variable, uint16(opts.KeepAlive/time.Second))
+
+	return encodePacket(packetConnect, append(variable, payload...))
+}
+
+// encodePublish builds a QoS 0 PUBLISH packet
+func encodePublish(msg Message) []byte {
+	header := packetPublish
+	if msg.Retain {
+		header |= 0x01
+	}
+	body := appendString(nil, msg.Topic)
+	return encodePacket(header, append(body, msg.Payload...))
+}
+
+// encodePacket prefixes a body with the fixed header and remaining length
+func encodePacket(header byte, body []byte) []byte {
+	packet := []byte{header}
+	length := len(body)
+	for {
+		digit := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			digit |= 0x80
+		}
+		packet = append(packet, digit)
+		if length == 0 {
+			break
+		}
+	}
+	return append(packet, body...)
+}
+
+// readPacket reads one control packet, returning its type nibble and body
+func readPacket(r *bufio.Reader) (byte, []byte, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	length, multiplier := 0, 1
+	for i := 0; ; i++ {
+		if i == 4 {
+			return 0, nil, errors.New("malformed remaining length")
+		}
+		digit, err := r.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length += int(digit&0x7F) * multiplier
+		if digit&0x80 == 0 {
+			break
+		}
+		multiplier *= 128
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, nil, err
+	}
+	return header & 0xF0, body, nil
+}
+
+// appendString appends a length-prefixed UTF-8 string
+func appendString(b []byte, s string) []byte {
+	return appendBytes(b, []byte(s))
+}
+
+// appendBytes appends length-prefixed binary data
+func appendBytes(b []byte, data []byte) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(data)))
+	return append(b, data...)
+}
@@ -0,0 +1,167 @@
+package mqtt
+
+import (
+	"bufio"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBroker accepts MQTT connections, acknowledges CONNECT with returnCode,
+// and reports every received packet
+type fakeBroker struct {
+	listener   net.Listener
+	returnCode byte
+	connects   chan []byte
+	publishes  chan Message
+}
+
+func newFakeBroker(t *testing.T, returnCode byte) *fakeBroker {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	b := &fakeBroker{
+		listener:   listener,
+		returnCode: returnCode,
+		connects:   make(chan []byte, 10),
+		publishes:  make(chan Message, 10),
+	}
+	go b.serve()
+	t.Cleanup(func() { listener.Close() })
+	return b
+}
+
+func (b *fakeBroker) serve() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+		go b.handle(conn)
+	}
+}
+
+func (b *fakeBroker) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		packetType, body, err := readPacket(reader)
+		if err != nil {
+			return
+		}
+		switch packetType {
+		case packetConnect:
+			b.connects <- body
+			conn.Write([]byte{packetConnack, 2, 0, b.returnCode})
+		case packetPublish:
+			topicLen := int(binary.BigEndian.Uint16(body))
+			b.publishes <- Message{
+				Topic:   string(body[2 : 2+topicLen]),
+				Payload: body[2+topicLen:],
+			}
+		case packetDisconnect:
+			return
+		}
+	}
+}
+
+func (b *fakeBroker) nextPublish(t *testing.T) Message {
+	t.Helper()
+	select {
+	case msg := <-b.publishes:
+		return msg
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for publish")
+		return Message{}
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("should require broker and client ID", func(t *testing.T) {
+		_, err1 := NewClient(Options{ClientID: "id"})
+		_, err2 := NewClient(Options{Broker: "localhost:1883"})
+
+		assert.Error(t, err1)
+		assert.Error(t, err2)
+	})
+}
+
+func TestClient_Publish(t *testing.T) {
+	t.Run("should connect lazily and publish on-connect messages first", func(t *testing.T) {
+		// Arrange
+		broker := newFakeBroker(t, 0)
+		client, err := NewClient(Options{
+			Broker:   "tcp://" + broker.listener.Addr().String(),
+			ClientID: "rcw-test",
+			Username: "user",
+			Password: "secret",
+			Will:     &Message{Topic: "rcw/availability", Payload: []byte("offline"), Retain: true},
+		})
+		require.NoError(t, err)
+		client.OnConnect(func() []Message {
+			return []Message{{Topic: "rcw/availability", Payload: []byte("online"), Retain: true}}
+		})
+		defer client.Close()
+
+		// Act
+		err = client.Publish(Message{Topic: "rcw/cue", Payload: []byte(`{"cue":1}`)})
+
+		// Assert
+		require.NoError(t, err)
+		connect := <-broker.connects
+		assert.Contains(t, string(connect), "rcw-test")
+		assert.Contains(t, string(connect), "rcw/availability")
+		assert.Equal(t, byte(0x02|0x04|0x20|0x80|0x40), connect[7], "connect flags")
+
+		first := broker.nextPublish(t)
+		assert.Equal(t, "rcw/availability", first.Topic)
+		assert.Equal(t, "online", string(first.Payload))
+		second := broker.nextPublish(t)
+		assert.Equal(t, "rcw/cue", second.Topic)
+		assert.Equal(t, `{"cue":1}`, string(second.Payload))
+	})
+
+	t.Run("should return error when the broker refuses the connection", func(t *testing.T) {
+		// Arrange
+		broker := newFakeBroker(t, 4)
+		client, err := NewClient(Options{Broker: broker.listener.Addr().String(), ClientID: "rcw-test"})
+		require.NoError(t, err)
+
+		// Act
+		err = client.Publish(Message{Topic: "rcw/cue"})
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "bad username or password")
+	})
+
+	t.Run("should return error when the broker is unreachable", func(t *testing.T) {
+		// Arrange
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		address := listener.Addr().String()
+		listener.Close()
+		client, err := NewClient(Options{Broker: address, ClientID: "rcw-test", Timeout: time.Second})
+		require.NoError(t, err)
+
+		// Act
+		err = client.Publish(Message{Topic: "rcw/cue"})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestEncodePacket(t *testing.T) {
+	t.Run("should encode multi-byte remaining length", func(t *testing.T) {
+		packet := encodePacket(packetPublish, make([]byte, 321))
+
+		assert.Equal(t, []byte{packetPublish, 0xC1, 0x02}, packet[:3])
+		assert.Len(t, packet, 3+321)
+	})
+}
@@ -0,0 +1,195 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// desktopAppName groups the notifications in the desktop's notification center
+const desktopAppName = "radiocontestwinner"
+
+// desktopClickWait bounds how long a notification waits to be clicked before it is dismissed
+const desktopClickWait = 10 * time.Minute
+
+// maxDesktopContext is the most transcript characters shown in a notification body
+const maxDesktopContext = 200
+
+// commandRunner runs a command and returns its standard output
+type commandRunner func(ctx context.Context, name string, args ...string) ([]byte, error)
+
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).Output()
+}
+
+// DesktopNotifier shows notifications on the desktop of the machine running the binary, with
+// notify-send on Linux and terminal-notifier or osascript on macOS. Clicking a cue notification
+// opens the dashboard URL when one is configured, otherwise the cue's transcript context.
+type DesktopNotifier struct {
+	clickURL   string // Opened on click; {cue_id}, {keyword}, and {number} are replaced from the cue
+	contextDir string // Where transcript context files opened on click are written
+	goos       string
+	tool       string // notify-send, terminal-notifier, or osascript
+	run        commandRunner
+}
+
+// NewDesktopNotifier creates a DesktopNotifier for the current OS. It fails when no supported
+// notification tool is installed.
+func NewDesktopNotifier(clickURL, contextDir string) (*DesktopNotifier, error) {
+	return newDesktopNotifier(runtime.GOOS, exec.LookPath, runCommand, clickURL, contextDir)
+}
+
+func newDesktopNotifier(goos string, lookPath func(string) (string, error), run commandRunner, clickURL, contextDir string) (*DesktopNotifier, error) {
+	var candidates []string
+	switch goos {
+	case "linux", "freebsd", "openbsd", "netbsd":
+		candidates = []string{"notify-send"}
+	case "darwin":
+		// terminal-notifier can open a URL on click; osascript notifications cannot
+		candidates = []string{"terminal-notifier", "osascript"}
+	default:
+		return nil, fmt.Errorf("desktop notifications are not supported on %s", goos)
+	}
+
+	for _, tool := range candidates {
+		if _, err := lookPath(tool); err == nil {
+			if contextDir == "" {
+				contextDir = os.TempDir()
+			}
+			return &DesktopNotifier{clickURL: clickURL, contextDir: contextDir, goos: goos, tool: tool, run: run}, nil
+		}
+	}
+	return nil, fmt.Errorf("desktop notifications need %s, which was not found", strings.Join(candidates, " or "))
+}
+
+// Name returns the notifier name used in logs
+func (d *DesktopNotifier) Name() string {
+	return "desktop"
+}
+
+// Notify shows the notification. When it can be clicked through, notify-send keeps running in
+// the background until the notification is clicked or dismissed.
+func (d *DesktopNotifier) Notify(ctx context.Context, notification Notification) error {
+	body := desktopBody(notification)
+
+	switch d.tool {
+	case "notify-send":
+		args := []string{"--app-name=" + desktopAppName, "--urgency=" + desktopUrgency(notification.Severity)}
+		target, err := d.clickTarget(notification)
+		if err != nil {
+			return err
+		}
+		if target == "" {
+			_, err := d.run(ctx, d.tool, append(args, notification.Title, body)...)
+			return err
+		}
+		args = append(args, "--action=open=Open", "--wait", notification.Title, body)
+		go d.openOnClick(args, target)
+		return nil
+	case "terminal-notifier":
+		args := []string{"-title", notification.Title, "-message", body, "-group", desktopAppName}
+		target, err := d.clickTarget(notification)
+		if err != nil {
+			return err
+		}
+		if target != "" {
+			args = append(args, "-open", target)
+		}
+		_, err = d.run(ctx, d.tool, args...)
+		return err
+	default:
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(body), appleScriptString(notification.Title))
+		_, err := d.run(ctx, d.tool, "-e", script)
+		return err
+	}
+}
+
+// openOnClick waits for the notify-send notification to be clicked and opens target
+func (d *DesktopNotifier) openOnClick(args []string, target string) {
+	ctx, cancel := context.WithTimeout(context.Background(), desktopClickWait)
+	defer cancel()
+	output, err := d.run(ctx, d.tool, args...)
+	if err != nil || strings.TrimSpace(string(output)) != "open" {
+		return
+	}
+	opener := "xdg-open"
+	if d.goos == "darwin" {
+		opener = "open"
+	}
+	d.run(ctx, opener, target)
+}
+
+// clickTarget returns what clicking the notification opens: the click URL for cues and alerts,
+// else for cues a file with the cue's transcript context. It is empty when nothing is opened.
+func (d *DesktopNotifier) clickTarget(notification Notification) (string, error) {
+	cue := notification.Cue
+	if d.clickURL != "" {
+		if cue == nil {
+			return strings.NewReplacer("{cue_id}", "", "{keyword}", "", "{number}", "").Replace(d.clickURL), nil
+		}
+		return strings.NewReplacer(
+			"{cue_id}", url.PathEscape(cue.CueID),
+			"{keyword}", url.PathEscape(cue.Details.Keyword),
+			"{number}", url.PathEscape(cue.Details.Number),
+		).Replace(d.clickURL), nil
+	}
+	if cue == nil {
+		return "", nil
+	}
+	text := cue.Details.OriginalText
+	if text == "" {
+		return "", nil
+	}
+
+	var context strings.Builder
+	fmt.Fprintf(&context, "%s\n%s\n\n", notification.Title, notification.Message)
+	fmt.Fprintf(&context, "Cue ID: %s\nDetected: %s\n", cue.CueID, cue.Timestamp)
+	if station := cue.Details.String("station_name"); station != "" {
+		fmt.Fprintf(&context, "Station: %s\n", station)
+	}
+	fmt.Fprintf(&context, "\nTranscript:\n%s\n", text)
+	if reconstructed := cue.Details.ReconstructedText; reconstructed != "" && reconstructed != text {
+		fmt.Fprintf(&context, "\nAs matched:\n%s\n", reconstructed)
+	}
+
+	path := filepath.Join(d.contextDir, fmt.Sprintf("%s-cue-%s.txt", desktopAppName, cue.CueID))
+	if err := os.WriteFile(path, []byte(context.String()), 0600); err != nil {
+		return "", fmt.Errorf("failed to write cue context: %w", err)
+	}
+	return path, nil
+}
+
+// desktopBody returns the notification text, with the transcript a cue was heard in
+func desktopBody(notification Notification) string {
+	if notification.Cue == nil {
+		return notification.Message
+	}
+	text := notification.Cue.Details.OriginalText
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return notification.Message
+	}
+	if runes := []rune(text); len(runes) > maxDesktopContext {
+		text = strings.TrimSpace(string(runes[:maxDesktopContext])) + "…"
+	}
+	return fmt.Sprintf("%s\n“%s”", notification.Message, text)
+}
+
+// desktopUrgency maps a severity to a notify-send urgency
+func desktopUrgency(severity Severity) string {
+	if severity == SeverityCritical {
+		return "critical"
+	}
+	return "normal"
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
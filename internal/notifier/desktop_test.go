@@ -0,0 +1,192 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/parser"
+)
+
+// fakeCommands records the commands a DesktopNotifier runs, answering each with output
+type fakeCommands struct {
+	mu     sync.Mutex
+	calls  [][]string
+	output map[string]string // Output by command name
+	ran    chan string
+}
+
+func newFakeCommands() *fakeCommands {
+	return &fakeCommands{output: map[string]string{}, ran: make(chan string, 10)}
+}
+
+func (f *fakeCommands) run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, append([]string{name}, args...))
+	output := f.output[name]
+	f.mu.Unlock()
+	f.ran <- name
+	return []byte(output), nil
+}
+
+func (f *fakeCommands) call(i int) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[i]
+}
+
+func installed(tools ...string) func(string) (string, error) {
+	return func(name string) (string, error) {
+		for _, tool := range tools {
+			if tool == name {
+				return "/usr/bin/" + name, nil
+			}
+		}
+		return "", errors.New("not found")
+	}
+}
+
+func desktopCue() parser.ContestCue {
+	return parser.ContestCue{
+		CueID:       "0190a1b2-c3d4-7e5f-8a9b-0c1d2e3f4a5b",
+		ContestType: "WIN",
+		Timestamp:   "2025-06-01T12:00:00Z",
+		Details: parser.CueDetails{
+			Keyword:           "WIN",
+			Number:            "12345",
+			OriginalText:      "Text WIN to one two three four five",
+			ReconstructedText: "Text WIN to 12345",
+		},
+	}
+}
+
+func waitForCommand(t *testing.T, commands *fakeCommands, name string) {
+	t.Helper()
+	for {
+		select {
+		case ran := <-commands.ran:
+			if ran == name {
+				return
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected %s to run", name)
+		}
+	}
+}
+
+func TestNewDesktopNotifier(t *testing.T) {
+	t.Run("should prefer terminal-notifier on macOS", func(t *testing.T) {
+		d, err := newDesktopNotifier("darwin", installed("terminal-notifier", "osascript"), newFakeCommands().run, "", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, "terminal-notifier", d.tool)
+	})
+
+	t.Run("should fail when notify-send is missing", func(t *testing.T) {
+		_, err := newDesktopNotifier("linux", installed(), newFakeCommands().run, "", "")
+
+		assert.ErrorContains(t, err, "notify-send")
+	})
+
+	t.Run("should fail on unsupported systems", func(t *testing.T) {
+		_, err := newDesktopNotifier("windows", installed("notify-send"), newFakeCommands().run, "", "")
+
+		assert.ErrorContains(t, err, "not supported on windows")
+	})
+}
+
+func TestDesktopNotifier_Notify(t *testing.T) {
+	t.Run("should show alerts with notify-send", func(t *testing.T) {
+		// Arrange
+		commands := newFakeCommands()
+		d, err := newDesktopNotifier("linux", installed("notify-send"), commands.run, "", t.TempDir())
+		require.NoError(t, err)
+
+		// Act
+		err = d.Notify(context.Background(), NewAlertNotification(SeverityCritical, "Stream down", "no audio", nil))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"notify-send", "--app-name=radiocontestwinner", "--urgency=critical", "Stream down", "no audio"}, commands.call(0))
+	})
+
+	t.Run("should open the dashboard for the cue when clicked", func(t *testing.T) {
+		// Arrange
+		commands := newFakeCommands()
+		commands.output["notify-send"] = "open\n"
+		d, err := newDesktopNotifier("linux", installed("notify-send"), commands.run, "http://localhost:3000/cues/{cue_id}?n={number}", t.TempDir())
+		require.NoError(t, err)
+
+		// Act
+		err = d.Notify(context.Background(), NewCueNotification(desktopCue()))
+
+		// Assert
+		require.NoError(t, err)
+		waitForCommand(t, commands, "xdg-open")
+		send := commands.call(0)
+		assert.Contains(t, send, "--action=open=Open")
+		assert.Contains(t, send, "--wait")
+		assert.Contains(t, send[len(send)-1], "“Text WIN to one two three four five”")
+		assert.Equal(t, []string{"xdg-open", "http://localhost:3000/cues/0190a1b2-c3d4-7e5f-8a9b-0c1d2e3f4a5b?n=12345"}, commands.call(1))
+	})
+
+	t.Run("should not open anything when the notification is dismissed", func(t *testing.T) {
+		// Arrange
+		commands := newFakeCommands()
+		d, err := newDesktopNotifier("linux", installed("notify-send"), commands.run, "http://localhost:3000", t.TempDir())
+		require.NoError(t, err)
+
+		// Act
+		require.NoError(t, d.Notify(context.Background(), NewCueNotification(desktopCue())))
+
+		// Assert
+		waitForCommand(t, commands, "notify-send")
+		select {
+		case name := <-commands.ran:
+			t.Fatalf("unexpected %s", name)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("should open the cue's transcript context without a click URL", func(t *testing.T) {
+		// Arrange
+		commands := newFakeCommands()
+		dir := t.TempDir()
+		d, err := newDesktopNotifier("darwin", installed("terminal-notifier"), commands.run, "", dir)
+		require.NoError(t, err)
+
+		// Act
+		err = d.Notify(context.Background(), NewCueNotification(desktopCue()))
+
+		// Assert
+		require.NoError(t, err)
+		args := commands.call(0)
+		require.Equal(t, "-open", args[len(args)-2])
+		context, err := os.ReadFile(args[len(args)-1])
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(args[len(args)-1], dir))
+		assert.Contains(t, string(context), "Text WIN to one two three four five")
+		assert.Contains(t, string(context), "As matched:\nText WIN to 12345")
+	})
+
+	t.Run("should quote the text for osascript", func(t *testing.T) {
+		// Arrange
+		commands := newFakeCommands()
+		d, err := newDesktopNotifier("darwin", installed("osascript"), commands.run, "", t.TempDir())
+		require.NoError(t, err)
+
+		// Act
+		err = d.Notify(context.Background(), NewAlertNotification(SeverityWarning, `Backend "service" down`, `C:\path`, nil))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"osascript", "-e", `display notification "C:\\path" with title "Backend \"service\" down"`}, commands.call(0))
+	})
+}
@@ -0,0 +1,175 @@
+package notifier
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Digest schedules
+const (
+	DigestHourly = "hourly"
+	DigestDaily  = "daily"
+)
+
+// maxDigestEntries bounds how many cues and incidents a digest lists; the counts stay exact
+const maxDigestEntries = 100
+
+// DigestSchedule decides when summary digests are sent: at the top of every hour, or daily at
+// a fixed time of day
+type DigestSchedule struct {
+	daily    bool
+	at       int // Minutes after midnight for daily digests
+	location *time.Location
+}
+
+// ParseDigestSchedule parses an hourly or daily schedule. Daily digests are sent at dailyAt
+// ("HH:MM") in the named time zone; empty uses the local zone.
+func ParseDigestSchedule(schedule, dailyAt, timezone string) (DigestSchedule, error) {
+	location := time.Local
+	if timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(timezone); err != nil {
+			return DigestSchedule{}, fmt.Errorf("invalid digest timezone: %w", err)
+		}
+	}
+	switch strings.ToLower(schedule) {
+	case DigestHourly:
+		return DigestSchedule{location: location}, nil
+	case DigestDaily:
+		at, err := parseClock(dailyAt)
+		if err != nil {
+			return DigestSchedule{}, fmt.Errorf("invalid digest time: %w", err)
+		}
+		return DigestSchedule{daily: true, at: at, location: location}, nil
+	default:
+		return DigestSchedule{}, fmt.Errorf("unknown digest schedule %q (use %s or %s)", schedule, DigestHourly, DigestDaily)
+	}
+}
+
+// Next returns the first digest time after t
+func (s DigestSchedule) Next(t time.Time) time.Time {
+	local := t.In(s.location)
+	if !s.daily {
+		return local.Truncate(time.Hour).Add(time.Hour)
+	}
+	next := time.Date(local.Year(), local.Month(), local.Day(), s.at/60, s.at%60, 0, 0, s.location)
+	if !next.After(local) {
+		next = time.Date(local.Year(), local.Month(), local.Day()+1, s.at/60, s.at%60, 0, 0, s.location)
+	}
+	return next
+}
+
+// digestEntry is one cue or incident listed in a digest
+type digestEntry struct {
+	At    time.Time `json:"at"`
+	Title string    `json:"title"`
+	Text  string    `json:"text,omitempty"`
+}
+
+// Digest accumulates what happened since the last summary: cues, duplicate cues that were
+// suppressed, notifications held back by quiet hours or maintenance, and pipeline incidents
+type Digest struct {
+	mu         sync.Mutex
+	since      time.Time
+	cueCount   int
+	cues       []digestEntry
+	incidents  []digestEntry
+	alertCount int
+	duplicates int
+	suppressed int
+}
+
+// NewDigest creates an empty Digest covering the period from since
+func NewDigest(since time.Time) *Digest {
+	return &Digest{since: since}
+}
+
+// Record adds a cue or alert notification
+func (g *Digest) Record(notification Notification, at time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	entry := digestEntry{At: at, Title: notification.Title, Text: notification.Message}
+	switch notification.Kind {
+	case KindCue:
+		g.cueCount++
+		if len(g.cues) < maxDigestEntries {
+			g.cues = append(g.cues, entry)
+		}
+	case KindAlert:
+		g.alertCount++
+		entry.Title = fmt.Sprintf("[%s] %s", notification.Severity, notification.Title)
+		if len(g.incidents) < maxDigestEntries {
+			g.incidents = append(g.incidents, entry)
+		}
+	}
+}
+
+// RecordDuplicate counts a cue suppressed as a duplicate of one already emitted
+func (g *Digest) RecordDuplicate() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.duplicates++
+}
+
+// RecordSuppressed counts a notification held back by quiet hours or maintenance mode
+func (g *Digest) RecordSuppressed() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.suppressed++
+}
+
+// Flush returns the digest notification for the period ending at now and starts a new period
+func (g *Digest) Flush(now time.Time) Notification {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "%d cues, %d duplicates suppressed, %d notifications held back, %d incidents",
+		g.cueCount, g.duplicates, g.suppressed, g.alertCount)
+	writeEntries := func(heading string, entries []digestEntry, total int) {
+		if total == 0 {
+			return
+		}
+		fmt.Fprintf(&message, "\n\n%s:", heading)
+		for _, entry := range entries {
+			fmt.Fprintf(&message, "\n%s  %s", entry.At.Format("2006-01-02 15:04"), entry.Title)
+			if entry.Text != "" {
+				fmt.Fprintf(&message, " - %s", entry.Text)
+			}
+		}
+		if total > len(entries) {
+			fmt.Fprintf(&message, "\n... and %d more", total-len(entries))
+		}
+	}
+	writeEntries("Cues", g.cues, g.cueCount)
+	writeEntries("Incidents", g.incidents, g.alertCount)
+
+	severity := SeverityInfo
+	if g.alertCount > 0 {
+		severity = SeverityWarning
+	}
+	notification := Notification{
+		Kind:      KindDigest,
+		Severity:  severity,
+		Title:     fmt.Sprintf("Digest %s to %s", g.since.Format("2006-01-02 15:04"), now.Format("2006-01-02 15:04")),
+		Message:   message.String(),
+		Timestamp: now.UTC().Format(time.RFC3339),
+		Fields: map[string]interface{}{
+			"period_start":            g.since.UTC().Format(time.RFC3339),
+			"period_end":              now.UTC().Format(time.RFC3339),
+			"cues":                    g.cueCount,
+			"duplicates_suppressed":   g.duplicates,
+			"notifications_held_back": g.suppressed,
+			"incidents":               g.alertCount,
+			"cue_list":                g.cues,
+			"incident_list":           g.incidents,
+		},
+	}
+
+	g.since = now
+	g.cueCount, g.alertCount, g.duplicates, g.suppressed = 0, 0, 0, 0
+	g.cues, g.incidents = nil, nil
+	return notification
+}
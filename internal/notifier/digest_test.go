@@ -0,0 +1,196 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/parser"
+)
+
+func TestDigestSchedule_Next(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, 6, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	t.Run("should send hourly digests at the top of the next hour", func(t *testing.T) {
+		schedule, err := ParseDigestSchedule(DigestHourly, "", "UTC")
+		require.NoError(t, err)
+
+		assert.Equal(t, at(1, 11, 0), schedule.Next(at(1, 10, 25)).UTC())
+		assert.Equal(t, at(1, 12, 0), schedule.Next(at(1, 11, 0)).UTC())
+	})
+
+	t.Run("should send daily digests at the configured time", func(t *testing.T) {
+		schedule, err := ParseDigestSchedule("Daily", "08:30", "UTC")
+		require.NoError(t, err)
+
+		assert.Equal(t, at(1, 8, 30), schedule.Next(at(1, 6, 0)).UTC())
+		assert.Equal(t, at(2, 8, 30), schedule.Next(at(1, 8, 30)).UTC())
+		assert.Equal(t, at(2, 8, 30), schedule.Next(at(1, 20, 0)).UTC())
+	})
+
+	t.Run("should schedule daily digests in their time zone", func(t *testing.T) {
+		schedule, err := ParseDigestSchedule(DigestDaily, "08:00", "America/New_York")
+		require.NoError(t, err)
+
+		// 08:00 in New York (EDT) is 12:00 UTC
+		assert.Equal(t, at(1, 12, 0), schedule.Next(at(1, 3, 0)).UTC())
+	})
+
+	t.Run("should reject invalid schedules", func(t *testing.T) {
+		for _, schedule := range [][3]string{{"weekly", "", ""}, {DigestDaily, "8am", ""}, {DigestHourly, "", "Mars/Olympus"}} {
+			_, err := ParseDigestSchedule(schedule[0], schedule[1], schedule[2])
+
+			assert.Error(t, err, schedule)
+		}
+	})
+}
+
+func TestDigest_Flush(t *testing.T) {
+	start := time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)
+	cue := NewCueNotification(*parser.NewContestCue("keyword", parser.CueDetails{Keyword: "WIN", Number: "12345"}))
+
+	t.Run("should summarise cues, duplicates, held back notifications, and incidents", func(t *testing.T) {
+		// Arrange
+		digest := NewDigest(start)
+		digest.Record(cue, start.Add(time.Hour))
+		digest.Record(NewAlertNotification(SeverityCritical, "stream down", "no audio", nil), start.Add(2*time.Hour))
+		digest.RecordDuplicate()
+		digest.RecordDuplicate()
+		digest.RecordSuppressed()
+
+		// Act
+		notification := digest.Flush(start.Add(24 * time.Hour))
+
+		// Assert
+		assert.Equal(t, KindDigest, notification.Kind)
+		assert.Equal(t, SeverityWarning, notification.Severity)
+		assert.Equal(t, 1, notification.Fields["cues"])
+		assert.Equal(t, 2, notification.Fields["duplicates_suppressed"])
+		assert.Equal(t, 1, notification.Fields["notifications_held_back"])
+		assert.Equal(t, 1, notification.Fields["incidents"])
+		assert.Contains(t, notification.Message, "Text WIN to 12345")
+		assert.Contains(t, notification.Message, "[critical] stream down")
+	})
+
+	t.Run("should start a new period after flushing", func(t *testing.T) {
+		digest := NewDigest(start)
+		digest.Record(cue, start)
+		digest.Flush(start.Add(time.Hour))
+
+		notification := digest.Flush(start.Add(2 * time.Hour))
+
+		assert.Equal(t, SeverityInfo, notification.Severity)
+		assert.Equal(t, 0, notification.Fields["cues"])
+		assert.Equal(t, start.Add(time.Hour).Format(time.RFC3339), notification.Fields["period_start"])
+	})
+
+	t.Run("should bound the listed cues but keep the exact count", func(t *testing.T) {
+		digest := NewDigest(start)
+		for i := 0; i < maxDigestEntries+5; i++ {
+			digest.Record(cue, start)
+		}
+
+		notification := digest.Flush(start.Add(time.Hour))
+
+		assert.Equal(t, maxDigestEntries+5, notification.Fields["cues"])
+		assert.Len(t, notification.Fields["cue_list"], maxDigestEntries)
+		assert.Contains(t, notification.Message, "... and 5 more")
+	})
+}
+
+func TestDispatcher_Digest(t *testing.T) {
+	newDigestDispatcher := func(t *testing.T, channel string) (*Dispatcher, *recordingNotifier, *recordingNotifier) {
+		t.Helper()
+		webhook := &recordingNotifier{name: "webhook"}
+		mqtt := &recordingNotifier{name: "mqtt"}
+		d := NewDispatcher(nil, webhook, mqtt)
+		schedule, err := ParseDigestSchedule(DigestHourly, "", "UTC")
+		require.NoError(t, err)
+		require.NoError(t, d.SetDigest(schedule, channel))
+		return d, webhook, mqtt
+	}
+	cue := NewCueNotification(*parser.NewContestCue("keyword", parser.CueDetails{Keyword: "WIN", Number: "12345"}))
+
+	t.Run("should send the digest only to the chosen channel", func(t *testing.T) {
+		// Arrange
+		d, webhook, mqtt := newDigestDispatcher(t, "webhook")
+		d.Dispatch(context.Background(), cue)
+		d.NoteDuplicateCue()
+
+		// Act
+		err := d.SendDigest(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, webhook.received, 2)
+		assert.Equal(t, KindDigest, webhook.received[1].Kind)
+		assert.Equal(t, 1, webhook.received[1].Fields["cues"])
+		assert.Equal(t, 1, webhook.received[1].Fields["duplicates_suppressed"])
+		require.Len(t, mqtt.received, 1)
+		assert.Equal(t, KindCue, mqtt.received[0].Kind)
+	})
+
+	t.Run("should count cues held back by quiet hours and still send the digest", func(t *testing.T) {
+		d, webhook, _ := newDigestDispatcher(t, "webhook")
+		quiet, err := ParseQuietHours("00:00", "23:59", "UTC")
+		require.NoError(t, err)
+		d.SetQuietHours(quiet, false)
+		d.now = func() time.Time { return time.Date(2025, 6, 1, 2, 0, 0, 0, time.UTC) }
+
+		d.Dispatch(context.Background(), cue)
+		d.SendDigest(context.Background())
+
+		require.Len(t, webhook.received, 1)
+		assert.Equal(t, 1, webhook.received[0].Fields["cues"])
+		assert.Equal(t, 1, webhook.received[0].Fields["notifications_held_back"])
+	})
+
+	t.Run("should hold the digest back during maintenance", func(t *testing.T) {
+		d, webhook, _ := newDigestDispatcher(t, "")
+		d.SetMaintenance(true)
+
+		d.SendDigest(context.Background())
+
+		assert.Empty(t, webhook.received)
+	})
+
+	t.Run("should reject a channel that is not a configured notifier", func(t *testing.T) {
+		d := NewDispatcher(nil, &recordingNotifier{name: "webhook"})
+
+		err := d.SetDigest(DigestSchedule{}, "sheets")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should stay disabled without a schedule", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetWebhookURL("http://127.0.0.1:1/hook")
+		cfg.SetNotifierQueueDir("")
+
+		d, err := NewDispatcherFromConfig(cfg, nil)
+
+		require.NoError(t, err)
+		assert.Nil(t, d.digest)
+		assert.NoError(t, d.SendDigest(context.Background()))
+	})
+
+	t.Run("should configure digests from config", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetWebhookURL("http://127.0.0.1:1/hook")
+		cfg.SetNotifierQueueDir("")
+		cfg.SetDigestSchedule(DigestDaily)
+		cfg.SetDigestChannel("webhook")
+
+		d, err := NewDispatcherFromConfig(cfg, nil)
+
+		require.NoError(t, err)
+		require.NotNil(t, d.digest)
+		assert.Equal(t, "webhook", d.digestChannel)
+	})
+}
@@ -0,0 +1,478 @@
+package notifier
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"radiocontestwinner/internal/parser"
+)
+
+// Filter decides which cues a notifier receives. It is parsed from an expression over the
+// cue's fields, for example
+//
+//	contest_type == "POTA" && details.number in ["1234", "5678"]
+//
+// Fields are contest_type, cue_id, content_hash, timestamp, and details.<key>. Values compare
+// with ==, !=, <, <=, >, >= (numerically when both sides are numbers), in [list], contains, and
+// matches "regexp"; conditions combine with && (and), || (or), ! (not), and parentheses. A
+// field on its own is true when set to a non-empty, non-zero, non-false value. Details that
+// are missing equal nothing.
+type Filter struct {
+	expr string
+	root filterExpr
+}
+
+// ParseFilter parses a filter expression
+func ParseFilter(expr string) (*Filter, error) {
+	tokens, err := tokenizeFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+	}
+	return &Filter{expr: expr, root: root}, nil
+}
+
+// String returns the filter expression
+func (f *Filter) String() string {
+	return f.expr
+}
+
+// Match reports whether the cue passes the filter
+func (f *Filter) Match(cue parser.ContestCue) bool {
+	return f.root.eval(cue)
+}
+
+// filterExpr is a condition over a cue
+type filterExpr interface {
+	eval(cue parser.ContestCue) bool
+}
+
+// filterOperand is a field or literal; ok is false for details the cue does not have
+type filterOperand interface {
+	value(cue parser.ContestCue) (v interface{}, ok bool)
+}
+
+type (
+	andExpr     struct{ left, right filterExpr }
+	orExpr      struct{ left, right filterExpr }
+	notExpr     struct{ expr filterExpr }
+	truthExpr   struct{ operand filterOperand }
+	compareExpr struct {
+		op          string
+		left, right filterOperand
+	}
+	inExpr struct {
+		operand filterOperand
+		list    []filterOperand
+	}
+	matchesExpr struct {
+		operand filterOperand
+		pattern *regexp.Regexp
+	}
+	fieldOperand   struct{ name string }
+	literalOperand struct{ v interface{} }
+)
+
+func (e andExpr) eval(cue parser.ContestCue) bool { return e.left.eval(cue) && e.right.eval(cue) }
+func (e orExpr) eval(cue parser.ContestCue) bool  { return e.left.eval(cue) || e.right.eval(cue) }
+func (e notExpr) eval(cue parser.ContestCue) bool { return !e.expr.eval(cue) }
+
+func (e truthExpr) eval(cue parser.ContestCue) bool {
+	v, ok := e.operand.value(cue)
+	if !ok {
+		return false
+	}
+	switch v := v.(type) {
+	case bool:
+		return v
+	case string:
+		return v != "" && v != "false" && v != "0"
+	}
+	if n, isNumber := toNumber(v); isNumber {
+		return n != 0
+	}
+	return v != nil
+}
+
+func (e compareExpr) eval(cue parser.ContestCue) bool {
+	left, leftOK := e.left.value(cue)
+	right, rightOK := e.right.value(cue)
+	if !leftOK || !rightOK {
+		return e.op == "!="
+	}
+	switch e.op {
+	case "==":
+		return valuesEqual(left, right)
+	case "!=":
+		return !valuesEqual(left, right)
+	case "contains":
+		return strings.Contains(strings.ToLower(toString(left)), strings.ToLower(toString(right)))
+	}
+
+	var cmp int
+	leftNumber, leftIsNumber := toNumber(left)
+	rightNumber, rightIsNumber := toNumber(right)
+	if leftIsNumber && rightIsNumber {
+		switch {
+		case leftNumber < rightNumber:
+			cmp = -1
+		case leftNumber > rightNumber:
+			cmp = 1
+		}
+	} else {
+		cmp = strings.Compare(toString(left), toString(right))
+	}
+	switch e.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default: // >=
+		return cmp >= 0
+	}
+}
+
+func (e inExpr) eval(cue parser.ContestCue) bool {
+	v, ok := e.operand.value(cue)
+	if !ok {
+		return false
+	}
+	for _, item := range e.list {
+		if candidate, _ := item.value(cue); valuesEqual(v, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e matchesExpr) eval(cue parser.ContestCue) bool {
+	v, ok := e.operand.value(cue)
+	return ok && e.pattern.MatchString(toString(v))
+}
+
+func (f fieldOperand) value(cue parser.ContestCue) (interface{}, bool) {
+	switch f.name {
+	case "contest_type":
+		return cue.ContestType, true
+	case "cue_id":
+		return cue.CueID, true
+	case "content_hash":
+		return cue.ContentHash, true
+	case "timestamp":
+		return cue.Timestamp, true
+	}
+	return cue.Details.Get(strings.TrimPrefix(f.name, "details."))
+}
+
+func (l literalOperand) value(parser.ContestCue) (interface{}, bool) { return l.v, true }
+
+// valuesEqual compares two values as numbers when both are numeric and neither is text, so
+// shortcodes such as "0146" only equal the same text, and otherwise as text
+func valuesEqual(a, b interface{}) bool {
+	_, aIsText := a.(string)
+	_, bIsText := b.(string)
+	if !aIsText && !bIsText {
+		if an, ok := toNumber(a); ok {
+			if bn, ok := toNumber(b); ok {
+				return an == bn
+			}
+		}
+	}
+	return toString(a) == toString(b)
+}
+
+// toNumber converts numeric values, and text holding a number, to float64
+func toNumber(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		n, err := strconv.ParseFloat(v, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// toString formats a value as text, with whole numbers formatted without a decimal point
+func toString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// Filter expression tokens
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOp // Operators and punctuation, including the word operators
+)
+
+type filterToken struct {
+	kind tokenKind
+	text string // Operator, identifier, or the unquoted string
+	pos  int
+}
+
+// filterWordOps are the operators spelled as words
+var filterWordOps = map[string]string{"and": "&&", "or": "||", "not": "!", "in": "in", "contains": "contains", "matches": "matches"}
+
+// tokenizeFilter splits an expression into tokens
+func tokenizeFilter(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"' || c == '\'':
+			end := i + 1
+			for end < len(expr) && expr[end] != c {
+				if expr[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(expr) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			text := expr[i+1 : end]
+			if c == '"' {
+				unquoted, err := strconv.Unquote(expr[i : end+1])
+				if err != nil {
+					return nil, fmt.Errorf("invalid string at position %d: %w", i, err)
+				}
+				text = unquoted
+			}
+			tokens = append(tokens, filterToken{kind: tokenString, text: text, pos: i})
+			i = end + 1
+		case c >= '0' && c <= '9' || (c == '-' && i+1 < len(expr) && expr[i+1] >= '0' && expr[i+1] <= '9'):
+			end := i + 1
+			for end < len(expr) && (expr[end] >= '0' && expr[end] <= '9' || expr[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, filterToken{kind: tokenNumber, text: expr[i:end], pos: i})
+			i = end
+		case c == '_' || unicode.IsLetter(rune(c)):
+			end := i + 1
+			for end < len(expr) && (expr[end] == '_' || expr[end] == '.' || unicode.IsLetter(rune(expr[end])) || unicode.IsDigit(rune(expr[end]))) {
+				end++
+			}
+			word := expr[i:end]
+			if op, ok := filterWordOps[word]; ok {
+				tokens = append(tokens, filterToken{kind: tokenOp, text: op, pos: i})
+			} else {
+				tokens = append(tokens, filterToken{kind: tokenIdent, text: word, pos: i})
+			}
+			i = end
+		default:
+			op := ""
+			for _, candidate := range []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")", "[", "]", ","} {
+				if strings.HasPrefix(expr[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected %q at position %d", string(c), i)
+			}
+			tokens = append(tokens, filterToken{kind: tokenOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, filterToken{kind: tokenEOF, text: "end of expression", pos: len(expr)}), nil
+}
+
+// filterParser parses tokens by recursive descent
+type filterParser struct {
+	tokens []filterToken
+	next   int
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.next]
+}
+
+// accept consumes the next token when it is the operator op
+func (p *filterParser) accept(op string) bool {
+	if tok := p.peek(); tok.kind == tokenOp && tok.text == op {
+		p.next++
+		return true
+	}
+	return false
+}
+
+// expect consumes the operator op or fails
+func (p *filterParser) expect(op string) error {
+	if !p.accept(op) {
+		tok := p.peek()
+		return fmt.Errorf("expected %q at position %d, got %q", op, tok.pos, tok.text)
+	}
+	return nil
+}
+
+func (p *filterParser) parseOr() (filterExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (filterExpr, error) {
+	if p.accept("!") {
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{expr}, nil
+	}
+	if p.accept("(") {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return expr, p.expect(")")
+	}
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (filterExpr, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	tok := p.peek()
+	if tok.kind != tokenOp {
+		return truthExpr{left}, nil
+	}
+	switch tok.text {
+	case "==", "!=", "<", "<=", ">", ">=", "contains":
+		p.next++
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return compareExpr{op: tok.text, left: left, right: right}, nil
+	case "in":
+		p.next++
+		list, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return inExpr{operand: left, list: list}, nil
+	case "matches":
+		p.next++
+		patternTok := p.peek()
+		if patternTok.kind != tokenString {
+			return nil, fmt.Errorf("matches needs a quoted pattern at position %d", patternTok.pos)
+		}
+		p.next++
+		pattern, err := regexp.Compile(patternTok.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern at position %d: %w", patternTok.pos, err)
+		}
+		return matchesExpr{operand: left, pattern: pattern}, nil
+	}
+	return truthExpr{left}, nil
+}
+
+func (p *filterParser) parseList() ([]filterOperand, error) {
+	if err := p.expect("["); err != nil {
+		return nil, err
+	}
+	var list []filterOperand
+	for !p.accept("]") {
+		if len(list) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		item, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, item)
+	}
+	return list, nil
+}
+
+func (p *filterParser) parseOperand() (filterOperand, error) {
+	tok := p.peek()
+	switch tok.kind {
+	case tokenString:
+		p.next++
+		return literalOperand{tok.text}, nil
+	case tokenNumber:
+		p.next++
+		n, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", tok.text, tok.pos)
+		}
+		return literalOperand{n}, nil
+	case tokenIdent:
+		p.next++
+		switch tok.text {
+		case "true":
+			return literalOperand{true}, nil
+		case "false":
+			return literalOperand{false}, nil
+		case "contest_type", "cue_id", "content_hash", "timestamp":
+			return fieldOperand{tok.text}, nil
+		}
+		if key := strings.TrimPrefix(tok.text, "details."); key != tok.text && key != "" {
+			return fieldOperand{tok.text}, nil
+		}
+		return nil, fmt.Errorf("unknown field %q at position %d (use contest_type, cue_id, content_hash, timestamp, or details.<key>)", tok.text, tok.pos)
+	}
+	return nil, fmt.Errorf("expected a field or value at position %d, got %q", tok.pos, tok.text)
+}
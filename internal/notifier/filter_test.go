@@ -0,0 +1,69 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/parser"
+)
+
+func TestFilter_Match(t *testing.T) {
+	cue := *parser.NewContestCue("POTA", parser.CueDetails{
+		Keyword:    "POTA",
+		Number:     "1234",
+		Extensions: map[string]interface{}{"station_name": "KXYZ 101.5", "match_index": 0, "allowlist_match": "exact", "ad_break": false},
+	})
+	cue.CueID = "01a149ad-cc79-73cf-821f-26ce1cd1c205"
+
+	cases := []struct {
+		expr    string
+		matches bool
+	}{
+		{`contest_type == "POTA" && details.number in ["1234","5678"]`, true},
+		{`contest_type == "POTA" && details.number in ["5678"]`, false},
+		{`contest_type != "POTA" || details.number == 1234`, true},
+		{`details.number == 0146`, false},
+		{`details.number >= 1000 and details.number < 2000`, true},
+		{`details.match_index == 0`, true},
+		{`!(contest_type == "CASH")`, true},
+		{`not details.ad_break`, true},
+		{`details.station_name contains "kxyz"`, true},
+		{`contest_type matches "^(POTA|SOTA)$"`, true},
+		{`contest_type matches '^CASH'`, false},
+		{`details.allowlist_match`, true},
+		{`details.missing`, false},
+		{`details.missing == ""`, false},
+		{`details.missing != "x"`, true},
+		{`cue_id contains "cc79"`, true},
+		{`contest_type == "CASH" || contest_type == "WIN" || details.keyword == "POTA"`, true},
+	}
+	for _, c := range cases {
+		filter, err := ParseFilter(c.expr)
+		require.NoError(t, err, c.expr)
+
+		assert.Equal(t, c.matches, filter.Match(cue), c.expr)
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	t.Run("should reject malformed expressions", func(t *testing.T) {
+		cases := map[string]string{
+			`contest_type ==`:                "expected a field or value at position 15",
+			`contest_type == "POTA`:          "unterminated string at position 16",
+			`keyword == "POTA"`:              `unknown field "keyword"`,
+			`(contest_type == "POTA"`:        `expected ")"`,
+			`details.number in "1234"`:       `expected "["`,
+			`contest_type matches details.x`: "matches needs a quoted pattern",
+			`contest_type matches "("`:       "invalid pattern",
+			`contest_type == "POTA" extra`:   `unexpected "extra" at position 23`,
+			`contest_type = "POTA"`:          `unexpected "=" at position 13`,
+		}
+		for expr, message := range cases {
+			_, err := ParseFilter(expr)
+
+			assert.ErrorContains(t, err, message, expr)
+		}
+	})
+}
@@ -0,0 +1,198 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"radiocontestwinner/internal/mqtt"
+)
+
+// mqttPublisher is the subset of mqtt.Client used by MQTTNotifier
+type mqttPublisher interface {
+	Publish(msg mqtt.Message) error
+	OnConnect(fn func() []mqtt.Message)
+	Close() error
+}
+
+// MQTTConfig configures an MQTTNotifier
+type MQTTConfig struct {
+	TopicPrefix     string // Base topic for cues, alerts, status, and availability
+	NodeID          string // Home Assistant node/device identifier
+	Discovery       bool   // Publish Home Assistant MQTT discovery messages
+	DiscoveryPrefix string // Home Assistant discovery prefix
+}
+
+// MQTTNotifier publishes notifications and pipeline status to an MQTT broker and,
+// optionally, Home Assistant discovery for a binary sensor per status flag and an
+// event entity for contest cues
+type MQTTNotifier struct {
+	client mqttPublisher
+	cfg    MQTTConfig
+}
+
+// NewMQTTNotifier creates a new MQTTNotifier publishing through an MQTT client
+func NewMQTTNotifier(opts mqtt.Options, cfg MQTTConfig) (*MQTTNotifier, error) {
+	if cfg.TopicPrefix == "" {
+		cfg.TopicPrefix = "radiocontestwinner"
+	}
+	opts.Will = &mqtt.Message{
+		Topic:   cfg.TopicPrefix + "/availability",
+		Payload: []byte("offline"),
+		Retain:  true,
+	}
+
+	client, err := mqtt.NewClient(opts)
+	if err != nil {
+		return nil, err
+	}
+	return newMQTTNotifier(client, cfg), nil
+}
+
+// newMQTTNotifier wires an MQTTNotifier to a publisher and registers its on-connect messages
+func newMQTTNotifier(client mqttPublisher, cfg MQTTConfig) *MQTTNotifier {
+	if cfg.TopicPrefix == "" {
+		cfg.TopicPrefix = "radiocontestwinner"
+	}
+	if cfg.NodeID == "" {
+		cfg.NodeID = "radiocontestwinner"
+	}
+	if cfg.DiscoveryPrefix == "" {
+		cfg.DiscoveryPrefix = "homeassistant"
+	}
+
+	m := &MQTTNotifier{client: client, cfg: cfg}
+	client.OnConnect(m.onConnectMessages)
+	return m
+}
+
+// Name returns the notifier name used in logs
+func (m *MQTTNotifier) Name() string {
+	return "mqtt"
+}
+
+// Notify publishes the notification JSON to <prefix>/cue or <prefix>/alert; cues are
+// also published to the Home Assistant event entity topic
+func (m *MQTTNotifier) Notify(ctx context.Context, notification Notification) error {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	topic := m.cfg.TopicPrefix + "/" + string(notification.Kind)
+	if err := m.client.Publish(mqtt.Message{Topic: topic, Payload: payload}); err != nil {
+		return err
+	}
+
+	if notification.Kind != KindCue || notification.Cue == nil || !m.cfg.Discovery {
+		return nil
+	}
+
+	event := map[string]interface{}{
+		"event_type":   "contest_cue",
+		"cue_id":       notification.Cue.CueID,
+		"content_hash": notification.Cue.ContentHash,
+		"contest_type": notification.Cue.ContestType,
+		"timestamp":    notification.Cue.Timestamp,
+	}
+	for key, value := range notification.Cue.Details.Map() {
+		if _, exists := event[key]; !exists {
+			event[key] = value
+		}
+	}
+	eventPayload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cue event: %w", err)
+	}
+	return m.client.Publish(mqtt.Message{Topic: m.eventTopic(), Payload: eventPayload})
+}
+
+// NotifyStatus publishes the retained pipeline status backing the Home Assistant binary sensors
+func (m *MQTTNotifier) NotifyStatus(ctx context.Context, status Status) error {
+	payload, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed to marshal status: %w", err)
+	}
+	return m.client.Publish(mqtt.Message{Topic: m.statusTopic(), Payload: payload, Retain: true})
+}
+
+// Close disconnects from the broker
+func (m *MQTTNotifier) Close() error {
+	return m.client.Close()
+}
+
+func (m *MQTTNotifier) availabilityTopic() string { return m.cfg.TopicPrefix + "/availability" }
+func (m *MQTTNotifier) statusTopic() string       { return m.cfg.TopicPrefix + "/status" }
+func (m *MQTTNotifier) eventTopic() string        { return m.cfg.TopicPrefix + "/event/cue" }
+
+// onConnectMessages returns the retained availability and discovery messages sent after every connect
+func (m *MQTTNotifier) onConnectMessages() []mqtt.Message {
+	messages := []mqtt.Message{{Topic: m.availabilityTopic(), Payload: []byte("online"), Retain: true}}
+	if !m.cfg.Discovery {
+		return messages
+	}
+	return append(messages, m.discoveryMessages()...)
+}
+
+// discoveryMessages builds the Home Assistant MQTT discovery configs
+func (m *MQTTNotifier) discoveryMessages() []mqtt.Message {
+	device := map[string]interface{}{
+		"identifiers":  []string{m.cfg.NodeID},
+		"name":         "Radio Contest Winner",
+		"manufacturer": "RadioContestWinner",
+		"model":        "Contest cue detector",
+	}
+
+	entities := []struct {
+		component string
+		objectID  string
+		config    map[string]interface{}
+	}{
+		{
+			component: "binary_sensor",
+			objectID:  "stream_connected",
+			config: map[string]interface{}{
+				"name":           "Stream connected",
+				"state_topic":    m.statusTopic(),
+				"value_template": "{{ 'ON' if value_json.stream_connected else 'OFF' }}",
+				"device_class":   "connectivity",
+			},
+		},
+		{
+			component: "binary_sensor",
+			objectID:  "pipeline_healthy",
+			config: map[string]interface{}{
+				"name":           "Pipeline healthy",
+				"state_topic":    m.statusTopic(),
+				"value_template": "{{ 'ON' if value_json.pipeline_healthy else 'OFF' }}",
+				"icon":           "mdi:pipe",
+			},
+		},
+		{
+			component: "event",
+			objectID:  "contest_cue",
+			config: map[string]interface{}{
+				"name":        "Contest cue",
+				"state_topic": m.eventTopic(),
+				"event_types": []string{"contest_cue"},
+				"icon":        "mdi:radio",
+			},
+		},
+	}
+
+	messages := make([]mqtt.Message, 0, len(entities))
+	for _, entity := range entities {
+		entity.config["unique_id"] = m.cfg.NodeID + "_" + entity.objectID
+		entity.config["availability_topic"] = m.availabilityTopic()
+		entity.config["device"] = device
+
+		// Marshalling maps of strings and string slices cannot fail
+		payload, _ := json.Marshal(entity.config)
+		messages = append(messages, mqtt.Message{
+			Topic:   fmt.Sprintf("%s/%s/%s/%s/config", m.cfg.DiscoveryPrefix, entity.component, m.cfg.NodeID, entity.objectID),
+			Payload: payload,
+			Retain:  true,
+		})
+	}
+	return messages
+}
@@ -0,0 +1,147 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/mqtt"
+	"radiocontestwinner/internal/parser"
+)
+
+// fakePublisher records published messages and the registered on-connect hook
+type fakePublisher struct {
+	published []mqtt.Message
+	onConnect func() []mqtt.Message
+	err       error
+	closed    bool
+}
+
+func (f *fakePublisher) Publish(msg mqtt.Message) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.published = append(f.published, msg)
+	return nil
+}
+
+func (f *fakePublisher) OnConnect(fn func() []mqtt.Message) { f.onConnect = fn }
+
+func (f *fakePublisher) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestMQTTNotifier_Discovery(t *testing.T) {
+	t.Run("should publish availability and Home Assistant discovery on connect", func(t *testing.T) {
+		// Arrange
+		publisher := &fakePublisher{}
+		newMQTTNotifier(publisher, MQTTConfig{TopicPrefix: "rcw", NodeID: "rcw_kxyz", Discovery: true})
+
+		// Act
+		messages := publisher.onConnect()
+
+		// Assert
+		topics := make(map[string]mqtt.Message)
+		for _, msg := range messages {
+			assert.True(t, msg.Retain, msg.Topic)
+			topics[msg.Topic] = msg
+		}
+		assert.Equal(t, "online", string(topics["rcw/availability"].Payload))
+		require.Contains(t, topics, "homeassistant/binary_sensor/rcw_kxyz/stream_connected/config")
+		require.Contains(t, topics, "homeassistant/binary_sensor/rcw_kxyz/pipeline_healthy/config")
+		require.Contains(t, topics, "homeassistant/event/rcw_kxyz/contest_cue/config")
+
+		var streamConfig map[string]interface{}
+		require.NoError(t, json.Unmarshal(topics["homeassistant/binary_sensor/rcw_kxyz/stream_connected/config"].Payload, &streamConfig))
+		assert.Equal(t, "rcw/status", streamConfig["state_topic"])
+		assert.Equal(t, "connectivity", streamConfig["device_class"])
+		assert.Equal(t, "rcw_kxyz_stream_connected", streamConfig["unique_id"])
+		assert.Equal(t, "rcw/availability", streamConfig["availability_topic"])
+
+		var eventConfig map[string]interface{}
+		require.NoError(t, json.Unmarshal(topics["homeassistant/event/rcw_kxyz/contest_cue/config"].Payload, &eventConfig))
+		assert.Equal(t, "rcw/event/cue", eventConfig["state_topic"])
+		assert.Equal(t, []interface{}{"contest_cue"}, eventConfig["event_types"])
+	})
+
+	t.Run("should only publish availability when discovery is disabled", func(t *testing.T) {
+		publisher := &fakePublisher{}
+		newMQTTNotifier(publisher, MQTTConfig{Discovery: false})
+
+		messages := publisher.onConnect()
+
+		require.Len(t, messages, 1)
+		assert.Equal(t, "radiocontestwinner/availability", messages[0].Topic)
+	})
+}
+
+func TestMQTTNotifier_Notify(t *testing.T) {
+	cue := parser.ContestCue{
+		CueID:       "cue-1",
+		ContestType: "text_keyword",
+		Timestamp:   "2024-01-01T08:00:00Z",
+		Details:     parser.CueDetails{Keyword: "WIN", Number: "12345"},
+	}
+
+	t.Run("should publish cue and Home Assistant event", func(t *testing.T) {
+		// Arrange
+		publisher := &fakePublisher{}
+		m := newMQTTNotifier(publisher, MQTTConfig{TopicPrefix: "rcw", Discovery: true})
+
+		// Act
+		err := m.Notify(context.Background(), NewCueNotification(cue))
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, publisher.published, 2)
+		assert.Equal(t, "rcw/cue", publisher.published[0].Topic)
+		assert.Equal(t, "rcw/event/cue", publisher.published[1].Topic)
+
+		var event map[string]interface{}
+		require.NoError(t, json.Unmarshal(publisher.published[1].Payload, &event))
+		assert.Equal(t, "contest_cue", event["event_type"])
+		assert.Equal(t, "WIN", event["keyword"])
+		assert.Equal(t, "12345", event["number"])
+	})
+
+	t.Run("should publish alerts without an event", func(t *testing.T) {
+		publisher := &fakePublisher{}
+		m := newMQTTNotifier(publisher, MQTTConfig{TopicPrefix: "rcw", Discovery: true})
+
+		err := m.Notify(context.Background(), NewAlertNotification(SeverityWarning, "title", "message", nil))
+
+		require.NoError(t, err)
+		require.Len(t, publisher.published, 1)
+		assert.Equal(t, "rcw/alert", publisher.published[0].Topic)
+	})
+
+	t.Run("should return publish errors", func(t *testing.T) {
+		publisher := &fakePublisher{err: errors.New("broker down")}
+		m := newMQTTNotifier(publisher, MQTTConfig{})
+
+		err := m.Notify(context.Background(), NewCueNotification(cue))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestMQTTNotifier_NotifyStatus(t *testing.T) {
+	// Arrange
+	publisher := &fakePublisher{}
+	m := newMQTTNotifier(publisher, MQTTConfig{TopicPrefix: "rcw"})
+
+	// Act
+	err := m.NotifyStatus(context.Background(), Status{StreamConnected: true, PipelineHealthy: false, State: "degraded"})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, publisher.published, 1)
+	assert.Equal(t, "rcw/status", publisher.published[0].Topic)
+	assert.True(t, publisher.published[0].Retain)
+	assert.JSONEq(t, `{"stream_connected":true,"pipeline_healthy":false,"state":"degraded","timestamp":""}`, string(publisher.published[0].Payload))
+}
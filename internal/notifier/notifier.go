@@ -0,0 +1,617 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/mqtt"
+	"radiocontestwinner/internal/parser"
+	"radiocontestwinner/internal/redis"
+)
+
+// Kind identifies what a Notification is about
+type Kind string
+
+const (
+	// KindCue is sent for every detected ContestCue
+	KindCue Kind = "cue"
+	// KindAlert is sent for pipeline health problems and recoveries
+	KindAlert Kind = "alert"
+	// KindDigest is the periodic summary of cues, suppressed duplicates, and incidents
+	KindDigest Kind = "digest"
+)
+
+// Severity indicates how urgent a Notification is
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// Notification is the payload delivered to every configured notifier
+type Notification struct {
+	Kind      Kind                   `json:"kind"`
+	Severity  Severity               `json:"severity"`
+	Title     string                 `json:"title"`
+	Message   string                 `json:"message"`
+	Timestamp string                 `json:"timestamp"`
+	Cue       *parser.ContestCue     `json:"cue,omitempty"`
+	Fields    map[string]interface{} `json:"fields,omitempty"`
+}
+
+// NewCueNotification creates a Notification announcing a detected ContestCue
+func NewCueNotification(cue parser.ContestCue) Notification {
+	notification := Notification{
+		Kind:      KindCue,
+		Severity:  SeverityInfo,
+		Title:     fmt.Sprintf("Contest cue detected: %s", cue.ContestType),
+		Message:   fmt.Sprintf("Text %v to %v", cue.Details.Keyword, cue.Details.Number),
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Cue:       &cue,
+	}
+	if cue.Timing != nil {
+		notification.Fields = map[string]interface{}{"latency_ms": cue.Timing.LatencyMS}
+	}
+	// Name the station, e.g. "KXYZ 101.5 Austin", when its metadata is configured
+	if station := cue.Details.String("station_name"); station != "" {
+		notification.Title = fmt.Sprintf("Contest cue detected on %s: %s", station, cue.ContestType)
+		if notification.Fields == nil {
+			notification.Fields = map[string]interface{}{}
+		}
+		notification.Fields["station"] = station
+	}
+	if show := cue.Details.String("program_name"); show != "" {
+		if notification.Fields == nil {
+			notification.Fields = map[string]interface{}{}
+		}
+		notification.Fields["program"] = show
+	}
+	return notification
+}
+
+// NewAlertNotification creates a Notification describing a pipeline health event
+func NewAlertNotification(severity Severity, title, message string, fields map[string]interface{}) Notification {
+	return Notification{
+		Kind:      KindAlert,
+		Severity:  severity,
+		Title:     title,
+		Message:   message,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Fields:    fields,
+	}
+}
+
+// Notifier delivers notifications to an external channel
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// Status is a periodic snapshot of pipeline state for notifiers that expose live status
+type Status struct {
+	StreamConnected bool   `json:"stream_connected"`
+	PipelineHealthy bool   `json:"pipeline_healthy"`
+	State           string `json:"state"`
+	Timestamp       string `json:"timestamp"`
+}
+
+// StatusNotifier is implemented by notifiers that publish pipeline status updates
+type StatusNotifier interface {
+	NotifyStatus(ctx context.Context, status Status) error
+}
+
+// Dispatcher fans a notification out to all configured notifiers
+type Dispatcher struct {
+	notifiers []Notifier
+	logger    *zap.Logger
+	queue     *DeliveryQueue // nil when failed deliveries are not retried
+	now       func() time.Time
+
+	quietHours  QuietHours
+	quietAlerts bool        // Whether alerts are suppressed during quiet hours too
+	maintenance atomic.Bool // Suppresses every notification until turned off
+	suppressed  atomic.Int64
+
+	digest         *Digest // nil when digests are disabled
+	digestSchedule DigestSchedule
+	digestChannel  string // Notifier receiving digests; empty sends them to every notifier
+
+	location *time.Location // Time zone of times in notification text; nil leaves them as they are
+
+	filters map[string]*Filter // Cue filters by notifier name; notifiers without one receive every cue
+}
+
+// NewDispatcher creates a new Dispatcher for the given notifiers
+func NewDispatcher(logger *zap.Logger, notifiers ...Notifier) *Dispatcher {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &Dispatcher{
+		notifiers: notifiers,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// NewDispatcherFromConfig creates a Dispatcher with every notifier enabled in the configuration
+func NewDispatcherFromConfig(cfg *config.Configuration, logger *zap.Logger) (*Dispatcher, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("configuration cannot be nil")
+	}
+
+	var notifiers []Notifier
+
+	if url := cfg.GetWebhookURL(); url != "" {
+		notifiers = append(notifiers, NewWebhookNotifierWithSecret(url, cfg.GetWebhookSecret(), time.Duration(cfg.GetWebhookTimeoutSec())*time.Second))
+	}
+
+	if spreadsheetID := cfg.GetSheetsSpreadsheetID(); spreadsheetID != "" {
+		sheets, err := NewSheetsNotifier(spreadsheetID, cfg.GetSheetsSheetName(), cfg.GetSheetsCredentialsFile(), 10*time.Second)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create sheets notifier: %w", err)
+		}
+		notifiers = append(notifiers, sheets)
+	}
+
+	if broker := cfg.GetMQTTBroker(); broker != "" {
+		mqttNotifier, err := NewMQTTNotifier(mqtt.Options{
+			Broker:   broker,
+			ClientID: cfg.GetMQTTClientID(),
+			Username: cfg.GetMQTTUsername(),
+			Password: cfg.GetMQTTPassword(),
+		}, MQTTConfig{
+			TopicPrefix:     cfg.GetMQTTTopicPrefix(),
+			NodeID:          cfg.GetMQTTClientID(),
+			Discovery:       cfg.GetMQTTHomeAssistantDiscovery(),
+			DiscoveryPrefix: cfg.GetMQTTHomeAssistantDiscoveryPrefix(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create mqtt notifier: %w", err)
+		}
+		notifiers = append(notifiers, mqttNotifier)
+	}
+
+	if cfg.GetRedisEnabled() && cfg.GetRedisPublishCues() {
+		client, err := redis.NewClient(redis.Options{
+			Address:  cfg.GetRedisAddress(),
+			Password: cfg.GetRedisPassword(),
+			DB:       cfg.GetRedisDB(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create redis notifier: %w", err)
+		}
+		notifiers = append(notifiers, NewRedisNotifier(client, cfg.GetRedisKeyPrefix()))
+	}
+
+	if cfg.GetNotifierDesktopEnabled() {
+		desktop, err := NewDesktopNotifier(cfg.GetNotifierDesktopClickURL(), cfg.GetPathsTempDir())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create desktop notifier: %w", err)
+		}
+		notifiers = append(notifiers, desktop)
+	}
+
+	dispatcher := NewDispatcher(logger, notifiers...)
+	for _, n := range notifiers {
+		if expr := cfg.GetNotifierFilter(n.Name()); expr != "" {
+			filter, err := ParseFilter(expr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid notifier.%s.filter: %w", n.Name(), err)
+			}
+			if err := dispatcher.SetFilter(n.Name(), filter); err != nil {
+				return nil, err
+			}
+		}
+	}
+	quietHours, err := ParseQuietHours(cfg.GetQuietHoursStart(), cfg.GetQuietHoursEnd(), cfg.GetQuietHoursTimezone())
+	if err != nil {
+		return nil, err
+	}
+	dispatcher.SetQuietHours(quietHours, cfg.GetQuietHoursIncludeAlerts())
+	dispatcher.SetMaintenance(cfg.GetMaintenanceMode())
+	if tz := cfg.GetTimezone(); tz != "" {
+		location, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone: %w", err)
+		}
+		dispatcher.SetTimezone(location)
+	}
+	if schedule := cfg.GetDigestSchedule(); schedule != "" && len(notifiers) > 0 {
+		digestSchedule, err := ParseDigestSchedule(schedule, cfg.GetDigestDailyAt(), cfg.GetDigestTimezone())
+		if err != nil {
+			return nil, err
+		}
+		if err := dispatcher.SetDigest(digestSchedule, cfg.GetDigestChannel()); err != nil {
+			return nil, err
+		}
+	}
+	if dir := cfg.GetNotifierQueueDir(); dir != "" && len(notifiers) > 0 {
+		queue, err := OpenDeliveryQueue(dir,
+			time.Duration(cfg.GetNotifierQueueMaxAgeSec())*time.Second,
+			time.Duration(cfg.GetNotifierQueueRetryIntervalSec())*time.Second)
+		if err != nil {
+			return nil, err
+		}
+		dispatcher.SetQueue(queue)
+	}
+	return dispatcher, nil
+}
+
+// Enabled reports whether at least one notifier is configured
+func (d *Dispatcher) Enabled() bool {
+	return len(d.notifiers) > 0
+}
+
+// Notifiers returns the configured notifiers
+func (d *Dispatcher) Notifiers() []Notifier {
+	return d.notifiers
+}
+
+// SetQueue stores failed deliveries in queue so RetryQueued can deliver them later
+func (d *Dispatcher) SetQueue(queue *DeliveryQueue) {
+	d.queue = queue
+}
+
+// SetQuietHours suppresses cue notifications, and alerts when includeAlerts is set, during quiet hours
+func (d *Dispatcher) SetQuietHours(quietHours QuietHours, includeAlerts bool) {
+	d.quietHours = quietHours
+	d.quietAlerts = includeAlerts
+}
+
+// SetTimezone shows times in notification text and digests in location, e.g. the station's zone
+// contest deadlines are announced in. Timestamp fields stay UTC.
+func (d *Dispatcher) SetTimezone(location *time.Location) {
+	d.location = location
+}
+
+// localNow returns the current time in the notification time zone
+func (d *Dispatcher) localNow() time.Time {
+	if d.location == nil {
+		return d.now()
+	}
+	return d.now().In(d.location)
+}
+
+// localize adds the cue's time in the notification time zone to a cue notification's message and
+// local_time field
+func (d *Dispatcher) localize(notification Notification) Notification {
+	if d.location == nil || notification.Kind != KindCue || notification.Cue == nil {
+		return notification
+	}
+	local := notification.Cue.FormatTimestamp(d.location)
+	fields := make(map[string]interface{}, len(notification.Fields)+1)
+	for key, value := range notification.Fields {
+		fields[key] = value
+	}
+	fields["local_time"] = local
+	notification.Fields = fields
+	notification.Message = fmt.Sprintf("%s (%s)", notification.Message, local)
+	return notification
+}
+
+// SetMaintenance turns maintenance mode on or off. While on, no notifications are sent.
+func (d *Dispatcher) SetMaintenance(enabled bool) {
+	d.maintenance.Store(enabled)
+}
+
+// Maintenance reports whether maintenance mode is on
+func (d *Dispatcher) Maintenance() bool {
+	return d.maintenance.Load()
+}
+
+// InQuietHours reports whether the quiet hours window is active now
+func (d *Dispatcher) InQuietHours() bool {
+	return d.quietHours.Active(d.now())
+}
+
+// SuppressedNotifications returns how many notifications quiet hours and maintenance mode suppressed
+func (d *Dispatcher) SuppressedNotifications() int64 {
+	return d.suppressed.Load()
+}
+
+// suppresses reports whether notification must not be sent right now
+func (d *Dispatcher) suppresses(notification Notification) bool {
+	if d.Maintenance() {
+		return true
+	}
+	if notification.Kind == KindAlert && !d.quietAlerts {
+		return false
+	}
+	return d.InQuietHours()
+}
+
+// SetDigest sends a summary digest on schedule to the notifier named channel, or to every
+// notifier when channel is empty
+func (d *Dispatcher) SetDigest(schedule DigestSchedule, channel string) error {
+	if channel != "" && len(d.notifiersNamed(channel)) == 0 {
+		return fmt.Errorf("digest channel %q is not a configured notifier", channel)
+	}
+	d.digest = NewDigest(d.now())
+	d.digestSchedule = schedule
+	d.digestChannel = channel
+	return nil
+}
+
+// SetFilter delivers to the notifier named name only the cues passing filter; alerts and
+// digests are not filtered. A nil filter removes the notifier's filter.
+func (d *Dispatcher) SetFilter(name string, filter *Filter) error {
+	if len(d.notifiersNamed(name)) == 0 || name == "" {
+		return fmt.Errorf("filter for %q: not a configured notifier", name)
+	}
+	if filter == nil {
+		delete(d.filters, name)
+		return nil
+	}
+	if d.filters == nil {
+		d.filters = map[string]*Filter{}
+	}
+	d.filters[name] = filter
+	return nil
+}
+
+// filtersOut reports whether the notifier's filter rejects the notification's cue
+func (d *Dispatcher) filtersOut(n Notifier, notification Notification) bool {
+	if notification.Kind != KindCue || notification.Cue == nil {
+		return false
+	}
+	filter, ok := d.filters[n.Name()]
+	return ok && !filter.Match(*notification.Cue)
+}
+
+// notifiersNamed returns the notifiers called name, or every notifier when name is empty
+func (d *Dispatcher) notifiersNamed(name string) []Notifier {
+	if name == "" {
+		return d.notifiers
+	}
+	var named []Notifier
+	for _, n := range d.notifiers {
+		if n.Name() == name {
+			named = append(named, n)
+		}
+	}
+	return named
+}
+
+// NoteDuplicateCue counts a cue suppressed as a duplicate towards the next digest
+func (d *Dispatcher) NoteDuplicateCue() {
+	if d.digest != nil {
+		d.digest.RecordDuplicate()
+	}
+}
+
+// QueuedDeliveries returns how many failed deliveries are waiting to be retried
+func (d *Dispatcher) QueuedDeliveries() int {
+	if d.queue == nil {
+		return 0
+	}
+	return d.queue.Pending()
+}
+
+// Dispatch delivers the notification to every notifier. A failing notifier does not
+// prevent delivery to the others; all failures are returned joined together. With a queue,
+// failed deliveries are also stored for retry. Notifications suppressed by quiet hours or
+// maintenance mode are dropped without error.
+func (d *Dispatcher) Dispatch(ctx context.Context, notification Notification) error {
+	notification = d.localize(notification)
+	if d.digest != nil {
+		d.digest.Record(notification, d.localNow())
+	}
+	if d.suppresses(notification) {
+		d.suppressed.Add(1)
+		if d.digest != nil {
+			d.digest.RecordSuppressed()
+		}
+		d.logger.Info("notification suppressed during quiet hours or maintenance",
+			zap.String("kind", string(notification.Kind)),
+			zap.String("title", notification.Title),
+			zap.Bool("maintenance", d.Maintenance()))
+		return nil
+	}
+	return d.deliver(ctx, notification, d.notifiers)
+}
+
+// deliver sends notification to each of notifiers, queueing failed deliveries for retry
+func (d *Dispatcher) deliver(ctx context.Context, notification Notification, notifiers []Notifier) error {
+	var errs []error
+
+	for _, n := range notifiers {
+		if d.filtersOut(n, notification) {
+			d.logger.Debug("cue filtered out for notifier",
+				zap.String("notifier", n.Name()),
+				zap.String("filter", d.filters[n.Name()].String()),
+				zap.String("title", notification.Title))
+			continue
+		}
+		if err := n.Notify(ctx, notification); err != nil {
+			d.logger.Error("notification delivery failed",
+				zap.String("notifier", n.Name()),
+				zap.String("kind", string(notification.Kind)),
+				zap.String("title", notification.Title),
+				zap.Bool("queued", d.queue != nil),
+				zap.Error(err))
+			errs = append(errs, fmt.Errorf("%s: %w", n.Name(), err))
+			if d.queue != nil {
+				if qerr := d.queue.Enqueue(n.Name(), notification, err); qerr != nil {
+					d.logger.Error("failed to queue notification for retry; it will not be delivered",
+						zap.String("notifier", n.Name()),
+						zap.String("title", notification.Title),
+						zap.Error(qerr))
+				}
+			}
+			continue
+		}
+
+		d.logger.Debug("notification delivered",
+			zap.String("notifier", n.Name()),
+			zap.String("kind", string(notification.Kind)),
+			zap.String("title", notification.Title))
+	}
+
+	return errors.Join(errs...)
+}
+
+// RetryQueued attempts every queued delivery that is due. Deliveries older than the queue's
+// maximum age, or for notifiers no longer configured, are discarded and logged.
+func (d *Dispatcher) RetryQueued(ctx context.Context) (delivered, discarded int) {
+	if d.queue == nil {
+		return 0, 0
+	}
+
+	deliveries, failures := d.queue.load()
+	for name, err := range failures {
+		d.logger.Error("unreadable queued notification", zap.String("file", name), zap.Error(err))
+	}
+
+	byName := make(map[string]Notifier, len(d.notifiers))
+	for _, n := range d.notifiers {
+		byName[n.Name()] = n
+	}
+
+	now := d.queue.now()
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			break
+		}
+		fields := []zap.Field{
+			zap.String("notifier", delivery.Notifier),
+			zap.String("kind", string(delivery.Notification.Kind)),
+			zap.String("title", delivery.Notification.Title),
+			zap.Int("attempts", delivery.Attempts),
+			zap.Time("enqueued_at", delivery.EnqueuedAt),
+		}
+
+		n, ok := byName[delivery.Notifier]
+		switch {
+		case d.queue.expired(delivery, now):
+			d.logger.Error("discarding queued notification older than the maximum age",
+				append(fields, zap.String("last_error", delivery.LastError))...)
+		case !ok:
+			d.logger.Error("discarding queued notification for a notifier that is no longer configured", fields...)
+		case now.Before(delivery.NextAttempt), d.suppresses(delivery.Notification):
+			continue
+		default:
+			if err := n.Notify(ctx, delivery.Notification); err != nil {
+				d.logger.Warn("queued notification delivery failed", append(fields, zap.Error(err))...)
+				if err := d.queue.update(delivery, err); err != nil {
+					d.logger.Error("failed to update queued notification", append(fields, zap.Error(err))...)
+				}
+				continue
+			}
+			d.logger.Info("queued notification delivered", fields...)
+			delivered++
+			if err := d.queue.remove(delivery); err != nil {
+				d.logger.Error("failed to remove delivered notification from queue", append(fields, zap.Error(err))...)
+			}
+			continue
+		}
+
+		discarded++
+		if err := d.queue.remove(delivery); err != nil {
+			d.logger.Error("failed to remove discarded notification from queue", append(fields, zap.Error(err))...)
+		}
+	}
+	return delivered, discarded
+}
+
+// RunQueue retries queued deliveries now, including any left from before a restart, and then
+// every interval until ctx is cancelled
+func (d *Dispatcher) RunQueue(ctx context.Context, interval time.Duration) {
+	if d.queue == nil {
+		return
+	}
+	if interval <= 0 {
+		interval = d.queue.retryInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		d.RetryQueued(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendDigest delivers the digest for the period since the previous one to the digest channel
+// and starts a new period. Quiet hours do not hold digests back; maintenance mode does, and
+// the period then carries over into the next digest.
+func (d *Dispatcher) SendDigest(ctx context.Context) error {
+	if d.digest == nil {
+		return nil
+	}
+	if d.Maintenance() {
+		d.logger.Info("digest held back during maintenance")
+		return nil
+	}
+	return d.deliver(ctx, d.digest.Flush(d.localNow()), d.notifiersNamed(d.digestChannel))
+}
+
+// RunDigest sends a digest at each scheduled time until ctx is cancelled. Digests are only sent
+// while send reports true, so followers in a multi-instance deployment stay silent; nil always sends.
+func (d *Dispatcher) RunDigest(ctx context.Context, send func() bool) {
+	if d.digest == nil {
+		return
+	}
+	for {
+		timer := time.NewTimer(time.Until(d.digestSchedule.Next(d.now())))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		if send != nil && !send() {
+			continue
+		}
+		sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		// Failures are already logged per notifier and queued for retry
+		_ = d.SendDigest(sendCtx)
+		cancel()
+	}
+}
+
+// DispatchStatus delivers a status update to every notifier implementing StatusNotifier
+func (d *Dispatcher) DispatchStatus(ctx context.Context, status Status) error {
+	var errs []error
+
+	for _, n := range d.notifiers {
+		statusNotifier, ok := n.(StatusNotifier)
+		if !ok {
+			continue
+		}
+		if err := statusNotifier.NotifyStatus(ctx, status); err != nil {
+			d.logger.Warn("status update delivery failed",
+				zap.String("notifier", n.Name()),
+				zap.Error(err))
+			errs = append(errs, fmt.Errorf("%s: %w", n.Name(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close releases notifiers holding connections
+func (d *Dispatcher) Close() error {
+	var errs []error
+
+	for _, n := range d.notifiers {
+		if closer, ok := n.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", n.Name(), err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
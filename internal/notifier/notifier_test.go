@@ -0,0 +1,296 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/parser"
+)
+
+// recordingNotifier captures notifications for assertions
+type recordingNotifier struct {
+	name     string
+	err      error
+	received []Notification
+}
+
+func (r *recordingNotifier) Name() string { return r.name }
+
+func (r *recordingNotifier) Notify(ctx context.Context, notification Notification) error {
+	r.received = append(r.received, notification)
+	return r.err
+}
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	t.Run("should deliver to every notifier", func(t *testing.T) {
+		// Arrange
+		first := &recordingNotifier{name: "first"}
+		second := &recordingNotifier{name: "second"}
+		d := NewDispatcher(nil, first, second)
+
+		// Act
+		err := d.Dispatch(context.Background(), NewAlertNotification(SeverityWarning, "title", "message", nil))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, first.received, 1)
+		assert.Len(t, second.received, 1)
+	})
+
+	t.Run("should keep delivering when one notifier fails", func(t *testing.T) {
+		// Arrange
+		failing := &recordingNotifier{name: "failing", err: errors.New("boom")}
+		healthy := &recordingNotifier{name: "healthy"}
+		d := NewDispatcher(nil, failing, healthy)
+
+		// Act
+		err := d.Dispatch(context.Background(), NewAlertNotification(SeverityWarning, "title", "message", nil))
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failing: boom")
+		assert.Len(t, healthy.received, 1)
+	})
+}
+
+func TestDispatcher_SetFilter(t *testing.T) {
+	t.Run("should deliver cues only to notifiers whose filter they pass", func(t *testing.T) {
+		// Arrange
+		pota := &recordingNotifier{name: "pota"}
+		all := &recordingNotifier{name: "all"}
+		d := NewDispatcher(nil, pota, all)
+		filter, err := ParseFilter(`contest_type == "POTA"`)
+		require.NoError(t, err)
+		require.NoError(t, d.SetFilter("pota", filter))
+
+		// Act
+		for _, contestType := range []string{"POTA", "CASH"} {
+			cue := parser.NewContestCue(contestType, parser.CueDetails{Keyword: contestType, Number: "1234"})
+			require.NoError(t, d.Dispatch(context.Background(), NewCueNotification(*cue)))
+		}
+		require.NoError(t, d.Dispatch(context.Background(), NewAlertNotification(SeverityWarning, "title", "message", nil)))
+
+		// Assert
+		require.Len(t, pota.received, 2)
+		assert.Equal(t, "POTA", pota.received[0].Cue.ContestType)
+		assert.Equal(t, KindAlert, pota.received[1].Kind, "alerts should not be filtered")
+		assert.Len(t, all.received, 3)
+	})
+
+	t.Run("should reject filters for notifiers that are not configured", func(t *testing.T) {
+		d := NewDispatcher(nil, &recordingNotifier{name: "webhook"})
+		filter, err := ParseFilter(`contest_type == "POTA"`)
+		require.NoError(t, err)
+
+		assert.ErrorContains(t, d.SetFilter("sheets", filter), `"sheets": not a configured notifier`)
+	})
+}
+
+func TestNewCueNotification(t *testing.T) {
+	// Arrange
+	cue := parser.NewContestCue("CASH", parser.CueDetails{Keyword: "CASH", Number: "55555"})
+
+	// Act
+	n := NewCueNotification(*cue)
+
+	// Assert
+	assert.Equal(t, KindCue, n.Kind)
+	assert.Equal(t, "Text CASH to 55555", n.Message)
+	assert.Equal(t, cue.CueID, n.Cue.CueID)
+	assert.Nil(t, n.Fields)
+}
+
+func TestNewCueNotification_Latency(t *testing.T) {
+	// Arrange
+	cue := parser.NewContestCue("CASH", parser.CueDetails{Keyword: "CASH", Number: "55555"})
+	now := time.Now()
+	cue.Timing = parser.NewCueTiming(now.Add(-6*time.Second), now.Add(-time.Second), now)
+
+	// Act
+	n := NewCueNotification(*cue)
+
+	// Assert
+	assert.Equal(t, int64(6000), n.Fields["latency_ms"])
+	assert.Equal(t, int64(6000), n.Cue.Timing.LatencyMS)
+}
+
+func TestNewCueNotification_Station(t *testing.T) {
+	// Arrange
+	cue := parser.NewContestCue("CASH", parser.CueDetails{Keyword: "CASH", Number: "55555", Extensions: map[string]interface{}{"station_name": "KXYZ 101.5 Austin"}})
+
+	// Act
+	n := NewCueNotification(*cue)
+
+	// Assert
+	assert.Equal(t, "Contest cue detected on KXYZ 101.5 Austin: CASH", n.Title)
+	assert.Equal(t, "KXYZ 101.5 Austin", n.Fields["station"])
+}
+
+func TestNewCueNotification_Program(t *testing.T) {
+	// Arrange
+	cue := parser.NewContestCue("CASH", parser.CueDetails{Keyword: "CASH", Number: "55555", Extensions: map[string]interface{}{"program_name": "Morning Drive"}})
+
+	// Act
+	n := NewCueNotification(*cue)
+
+	// Assert
+	assert.Equal(t, "Morning Drive", n.Fields["program"])
+}
+
+func TestDispatcher_SetTimezone(t *testing.T) {
+	t.Run("should add the local time to cue notifications and keep the UTC timestamp", func(t *testing.T) {
+		// Arrange
+		recorder := &recordingNotifier{name: "recorder"}
+		d := NewDispatcher(nil, recorder)
+		d.SetTimezone(time.FixedZone("CDT", -5*60*60))
+		cue := parser.NewContestCue("CASH", parser.CueDetails{Keyword: "CASH", Number: "55555"})
+		cue.Timestamp = "2026-10-17T19:03:05Z"
+		notification := NewCueNotification(*cue)
+
+		// Act
+		err := d.Dispatch(context.Background(), notification)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, recorder.received, 1)
+		received := recorder.received[0]
+		assert.Equal(t, "Text CASH to 55555 (2026-10-17 14:03:05 CDT)", received.Message)
+		assert.Equal(t, "2026-10-17 14:03:05 CDT", received.Fields["local_time"])
+		assert.Equal(t, notification.Timestamp, received.Timestamp)
+		assert.Equal(t, "2026-10-17 14:03:05 CDT", sheetsRow(received)[6])
+		assert.Nil(t, notification.Fields)
+	})
+
+	t.Run("should leave alerts unchanged", func(t *testing.T) {
+		recorder := &recordingNotifier{name: "recorder"}
+		d := NewDispatcher(nil, recorder)
+		d.SetTimezone(time.UTC)
+
+		err := d.Dispatch(context.Background(), NewAlertNotification(SeverityWarning, "title", "message", nil))
+
+		require.NoError(t, err)
+		assert.Equal(t, "message", recorder.received[0].Message)
+	})
+}
+
+func TestNewDispatcherFromConfig(t *testing.T) {
+	t.Run("should have no notifiers by default", func(t *testing.T) {
+		d, err := NewDispatcherFromConfig(config.NewConfiguration(), nil)
+
+		require.NoError(t, err)
+		assert.False(t, d.Enabled())
+	})
+
+	t.Run("should enable webhook notifier when URL is configured", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetWebhookURL("http://example.invalid/hook")
+		cfg.SetNotifierQueueDir(t.TempDir())
+
+		d, err := NewDispatcherFromConfig(cfg, nil)
+
+		require.NoError(t, err)
+		require.Len(t, d.Notifiers(), 1)
+		assert.Equal(t, "webhook", d.Notifiers()[0].Name())
+	})
+
+	t.Run("should enable mqtt notifier when broker is configured", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetMQTTBroker("tcp://localhost:1883")
+		cfg.SetNotifierQueueDir(t.TempDir())
+
+		d, err := NewDispatcherFromConfig(cfg, nil)
+
+		require.NoError(t, err)
+		require.Len(t, d.Notifiers(), 1)
+		assert.Equal(t, "mqtt", d.Notifiers()[0].Name())
+	})
+
+	t.Run("should queue failed deliveries in the configured directory", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetWebhookURL("http://example.invalid/hook")
+		dir := filepath.Join(t.TempDir(), "queue")
+		cfg.SetNotifierQueueDir(dir)
+
+		d, err := NewDispatcherFromConfig(cfg, nil)
+
+		require.NoError(t, err)
+		assert.NotNil(t, d.queue)
+		assert.DirExists(t, dir)
+	})
+
+	t.Run("should not queue when the queue directory is empty", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetWebhookURL("http://example.invalid/hook")
+		cfg.SetNotifierQueueDir("")
+
+		d, err := NewDispatcherFromConfig(cfg, nil)
+
+		require.NoError(t, err)
+		assert.Nil(t, d.queue)
+	})
+
+	t.Run("should filter notifiers with a configured filter", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetWebhookURL("http://example.invalid/hook")
+		cfg.SetNotifierQueueDir("")
+		cfg.SetNotifierFilter("webhook", `contest_type == "POTA"`)
+
+		d, err := NewDispatcherFromConfig(cfg, nil)
+
+		require.NoError(t, err)
+		require.Contains(t, d.filters, "webhook")
+		assert.Equal(t, `contest_type == "POTA"`, d.filters["webhook"].String())
+	})
+
+	t.Run("should reject invalid filters", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetWebhookURL("http://example.invalid/hook")
+		cfg.SetNotifierQueueDir("")
+		cfg.SetNotifierFilter("webhook", `contest_type ==`)
+
+		_, err := NewDispatcherFromConfig(cfg, nil)
+
+		assert.ErrorContains(t, err, "invalid notifier.webhook.filter")
+	})
+
+	t.Run("should reject nil configuration", func(t *testing.T) {
+		_, err := NewDispatcherFromConfig(nil, nil)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestDispatcher_DispatchStatus(t *testing.T) {
+	t.Run("should deliver status only to status notifiers", func(t *testing.T) {
+		// Arrange
+		plain := &recordingNotifier{name: "plain"}
+		publisher := &fakePublisher{}
+		mqttNotifier := newMQTTNotifier(publisher, MQTTConfig{})
+		d := NewDispatcher(nil, plain, mqttNotifier)
+
+		// Act
+		err := d.DispatchStatus(context.Background(), Status{StreamConnected: true, PipelineHealthy: true, State: "healthy"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, plain.received)
+		assert.Len(t, publisher.published, 1)
+	})
+}
+
+func TestDispatcher_Close(t *testing.T) {
+	publisher := &fakePublisher{}
+	d := NewDispatcher(nil, &recordingNotifier{name: "plain"}, newMQTTNotifier(publisher, MQTTConfig{}))
+
+	err := d.Close()
+
+	assert.NoError(t, err)
+	assert.True(t, publisher.closed)
+}
@@ -0,0 +1,183 @@
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"radiocontestwinner/internal/retry"
+)
+
+// maxRetryBackoff caps the delay between attempts to deliver a queued notification
+const maxRetryBackoff = 10 * time.Minute
+
+// queuedDelivery is a notification one notifier failed to deliver, stored until it succeeds
+type queuedDelivery struct {
+	ID           string       `json:"id"`
+	Notifier     string       `json:"notifier"`
+	Notification Notification `json:"notification"`
+	EnqueuedAt   time.Time    `json:"enqueued_at"`
+	Attempts     int          `json:"attempts"`
+	NextAttempt  time.Time    `json:"next_attempt"`
+	LastError    string       `json:"last_error,omitempty"`
+}
+
+// DeliveryQueue persists failed deliveries as one JSON file each in a directory, so they are
+// retried after a transient outage or a restart. Files are written atomically and removed once
+// delivered or discarded.
+type DeliveryQueue struct {
+	dir           string
+	maxAge        time.Duration
+	retryInterval time.Duration
+	now           func() time.Time
+
+	mu  sync.Mutex
+	seq uint64
+}
+
+// OpenDeliveryQueue opens or creates a queue in dir. Deliveries older than maxAge are discarded;
+// retries start after retryInterval and back off exponentially.
+func OpenDeliveryQueue(dir string, maxAge, retryInterval time.Duration) (*DeliveryQueue, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create notification queue directory: %w", err)
+	}
+	if retryInterval <= 0 {
+		retryInterval = 30 * time.Second
+	}
+	return &DeliveryQueue{
+		dir:           dir,
+		maxAge:        maxAge,
+		retryInterval: retryInterval,
+		now:           time.Now,
+	}, nil
+}
+
+// Enqueue stores a notification that notifierName failed to deliver with cause
+func (q *DeliveryQueue) Enqueue(notifierName string, notification Notification, cause error) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := q.now()
+	q.seq++
+	delivery := queuedDelivery{
+		// Sorts in enqueue order
+		ID:           fmt.Sprintf("%020d-%06d", now.UnixNano(), q.seq%1000000),
+		Notifier:     notifierName,
+		Notification: notification,
+		EnqueuedAt:   now,
+		Attempts:     1,
+		NextAttempt:  now.Add(q.retryInterval),
+	}
+	if cause != nil {
+		delivery.LastError = cause.Error()
+	}
+	return q.save(delivery)
+}
+
+// Pending returns how many deliveries are waiting to be retried
+func (q *DeliveryQueue) Pending() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	names, _ := q.files()
+	return len(names)
+}
+
+// files returns the queued delivery files, oldest first
+func (q *DeliveryQueue) files() ([]string, error) {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// load reads every queued delivery, oldest first. Unreadable files are returned as errors
+// keyed by file name and renamed with a .corrupt suffix so they are kept for inspection but
+// reported only once.
+func (q *DeliveryQueue) load() ([]queuedDelivery, map[string]error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	names, err := q.files()
+	if err != nil {
+		return nil, map[string]error{q.dir: err}
+	}
+	var deliveries []queuedDelivery
+	failures := make(map[string]error)
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(q.dir, name))
+		if err != nil {
+			failures[name] = err
+			continue
+		}
+		var delivery queuedDelivery
+		if err := json.Unmarshal(data, &delivery); err != nil {
+			failures[name] = err
+			os.Rename(filepath.Join(q.dir, name), filepath.Join(q.dir, name+".corrupt"))
+			continue
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, failures
+}
+
+// update records a failed retry of delivery, backing off before the next attempt
+func (q *DeliveryQueue) update(delivery queuedDelivery, cause error) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	backoff := retry.Policy{BaseDelay: q.retryInterval, MaxDelay: maxRetryBackoff}.Delay(delivery.Attempts + 1)
+	delivery.Attempts++
+	delivery.NextAttempt = q.now().Add(backoff)
+	delivery.LastError = cause.Error()
+	return q.save(delivery)
+}
+
+// remove deletes a delivered or discarded delivery
+func (q *DeliveryQueue) remove(delivery queuedDelivery) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	err := os.Remove(q.path(delivery.ID))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// expired reports whether delivery is older than the maximum age
+func (q *DeliveryQueue) expired(delivery queuedDelivery, now time.Time) bool {
+	return q.maxAge > 0 && now.Sub(delivery.EnqueuedAt) > q.maxAge
+}
+
+// save writes delivery atomically so a crash never leaves a truncated file
+func (q *DeliveryQueue) save(delivery queuedDelivery) error {
+	data, err := json.Marshal(delivery)
+	if err != nil {
+		return fmt.Errorf("failed to marshal queued notification: %w", err)
+	}
+	tmp := q.path(delivery.ID) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write queued notification: %w", err)
+	}
+	if err := os.Rename(tmp, q.path(delivery.ID)); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write queued notification: %w", err)
+	}
+	return nil
+}
+
+func (q *DeliveryQueue) path(id string) string {
+	return filepath.Join(q.dir, id+".json")
+}
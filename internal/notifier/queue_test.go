@@ -0,0 +1,134 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestQueue opens a queue in a temporary directory with a controllable clock
+func newTestQueue(t *testing.T, maxAge time.Duration, now *time.Time) *DeliveryQueue {
+	t.Helper()
+	queue, err := OpenDeliveryQueue(t.TempDir(), maxAge, time.Minute)
+	require.NoError(t, err)
+	queue.now = func() time.Time { return *now }
+	return queue
+}
+
+func TestDispatcher_Queue(t *testing.T) {
+	t.Run("should queue a failed delivery and retry it once due", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		webhook := &recordingNotifier{name: "webhook", err: errors.New("connection refused")}
+		healthy := &recordingNotifier{name: "healthy"}
+		d := NewDispatcher(nil, webhook, healthy)
+		d.SetQueue(newTestQueue(t, time.Hour, &now))
+
+		// Act & Assert
+		require.Error(t, d.Dispatch(context.Background(), NewAlertNotification(SeverityWarning, "title", "message", nil)))
+		assert.Equal(t, 1, d.QueuedDeliveries(), "only the failed notifier's delivery is queued")
+
+		delivered, discarded := d.RetryQueued(context.Background())
+		assert.Equal(t, 0, delivered+discarded, "not retried before the retry interval")
+		assert.Len(t, webhook.received, 1)
+
+		now = now.Add(time.Minute)
+		webhook.err = nil
+		delivered, discarded = d.RetryQueued(context.Background())
+		assert.Equal(t, 1, delivered)
+		assert.Equal(t, 0, discarded)
+		assert.Len(t, webhook.received, 2)
+		assert.Equal(t, "title", webhook.received[1].Title)
+		assert.Len(t, healthy.received, 1, "notifiers that succeeded are not retried")
+		assert.Equal(t, 0, d.QueuedDeliveries())
+	})
+
+	t.Run("should back off after each failed retry", func(t *testing.T) {
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		webhook := &recordingNotifier{name: "webhook", err: errors.New("503")}
+		d := NewDispatcher(nil, webhook)
+		d.SetQueue(newTestQueue(t, 24*time.Hour, &now))
+		d.Dispatch(context.Background(), NewAlertNotification(SeverityInfo, "title", "message", nil))
+
+		now = now.Add(time.Minute)
+		d.RetryQueued(context.Background())
+		now = now.Add(time.Minute)
+		d.RetryQueued(context.Background())
+		assert.Len(t, webhook.received, 2, "second retry waits twice the interval")
+
+		now = now.Add(time.Minute)
+		d.RetryQueued(context.Background())
+		assert.Len(t, webhook.received, 3)
+		assert.Equal(t, 1, d.QueuedDeliveries())
+	})
+
+	t.Run("should survive a restart", func(t *testing.T) {
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		dir := t.TempDir()
+		queue, err := OpenDeliveryQueue(dir, time.Hour, time.Minute)
+		require.NoError(t, err)
+		queue.now = func() time.Time { return now }
+		require.NoError(t, queue.Enqueue("webhook", NewAlertNotification(SeverityInfo, "before restart", "", nil), errors.New("timeout")))
+
+		// Act - a new process opens the same directory
+		reopened, err := OpenDeliveryQueue(dir, time.Hour, time.Minute)
+		require.NoError(t, err)
+		reopened.now = func() time.Time { return now.Add(time.Minute) }
+		webhook := &recordingNotifier{name: "webhook"}
+		d := NewDispatcher(nil, webhook)
+		d.SetQueue(reopened)
+		delivered, _ := d.RetryQueued(context.Background())
+
+		// Assert
+		assert.Equal(t, 1, delivered)
+		require.Len(t, webhook.received, 1)
+		assert.Equal(t, "before restart", webhook.received[0].Title)
+	})
+
+	t.Run("should discard deliveries older than the maximum age", func(t *testing.T) {
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		webhook := &recordingNotifier{name: "webhook", err: errors.New("down")}
+		d := NewDispatcher(nil, webhook)
+		d.SetQueue(newTestQueue(t, time.Hour, &now))
+		d.Dispatch(context.Background(), NewAlertNotification(SeverityInfo, "stale", "", nil))
+
+		now = now.Add(2 * time.Hour)
+		delivered, discarded := d.RetryQueued(context.Background())
+
+		assert.Equal(t, 0, delivered)
+		assert.Equal(t, 1, discarded)
+		assert.Len(t, webhook.received, 1, "expired deliveries are not attempted")
+		assert.Equal(t, 0, d.QueuedDeliveries())
+	})
+
+	t.Run("should discard deliveries for notifiers no longer configured", func(t *testing.T) {
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		queue := newTestQueue(t, time.Hour, &now)
+		require.NoError(t, queue.Enqueue("sms", NewAlertNotification(SeverityInfo, "title", "", nil), nil))
+		d := NewDispatcher(nil, &recordingNotifier{name: "webhook"})
+		d.SetQueue(queue)
+
+		_, discarded := d.RetryQueued(context.Background())
+
+		assert.Equal(t, 1, discarded)
+	})
+
+	t.Run("should set aside corrupt queue files", func(t *testing.T) {
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		queue := newTestQueue(t, time.Hour, &now)
+		require.NoError(t, os.WriteFile(filepath.Join(queue.dir, "broken.json"), []byte("{"), 0644))
+		d := NewDispatcher(nil, &recordingNotifier{name: "webhook"})
+		d.SetQueue(queue)
+
+		d.RetryQueued(context.Background())
+
+		assert.Equal(t, 0, d.QueuedDeliveries())
+		assert.FileExists(t, filepath.Join(queue.dir, "broken.json.corrupt"))
+	})
+}
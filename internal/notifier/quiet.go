@@ -0,0 +1,84 @@
+package notifier
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QuietHours is a daily window during which cue notifications are suppressed, so overnight
+// automated contests don't page anyone. Cues are still logged and stored.
+type QuietHours struct {
+	start    int // Minutes after midnight
+	end      int
+	location *time.Location
+	enabled  bool
+}
+
+// ParseQuietHours parses a window from start to end given as "HH:MM" in the named time zone
+// (empty uses the local zone). A window ending before it starts wraps past midnight, e.g.
+// 22:00-07:00. Empty start and end disable quiet hours.
+func ParseQuietHours(start, end, timezone string) (QuietHours, error) {
+	if start == "" && end == "" {
+		return QuietHours{}, nil
+	}
+	startMin, err := parseClock(start)
+	if err != nil {
+		return QuietHours{}, fmt.Errorf("invalid quiet hours start: %w", err)
+	}
+	endMin, err := parseClock(end)
+	if err != nil {
+		return QuietHours{}, fmt.Errorf("invalid quiet hours end: %w", err)
+	}
+	location := time.Local
+	if timezone != "" {
+		if location, err = time.LoadLocation(timezone); err != nil {
+			return QuietHours{}, fmt.Errorf("invalid quiet hours timezone: %w", err)
+		}
+	}
+	return QuietHours{start: startMin, end: endMin, location: location, enabled: startMin != endMin}, nil
+}
+
+// parseClock parses "HH:MM" into minutes after midnight
+func parseClock(clock string) (int, error) {
+	hours, minutes, ok := strings.Cut(strings.TrimSpace(clock), ":")
+	if !ok {
+		return 0, fmt.Errorf("%q is not HH:MM", clock)
+	}
+	h, err := strconv.Atoi(hours)
+	if err != nil || h < 0 || h > 23 {
+		return 0, fmt.Errorf("%q is not HH:MM", clock)
+	}
+	m, err := strconv.Atoi(minutes)
+	if err != nil || m < 0 || m > 59 {
+		return 0, fmt.Errorf("%q is not HH:MM", clock)
+	}
+	return h*60 + m, nil
+}
+
+// Enabled reports whether a quiet window is configured
+func (q QuietHours) Enabled() bool {
+	return q.enabled
+}
+
+// Active reports whether t falls inside the quiet window
+func (q QuietHours) Active(t time.Time) bool {
+	if !q.enabled {
+		return false
+	}
+	local := t.In(q.location)
+	minute := local.Hour()*60 + local.Minute()
+	if q.start < q.end {
+		return minute >= q.start && minute < q.end
+	}
+	return minute >= q.start || minute < q.end
+}
+
+// String returns the window as "HH:MM-HH:MM zone"
+func (q QuietHours) String() string {
+	if !q.enabled {
+		return "disabled"
+	}
+	return fmt.Sprintf("%02d:%02d-%02d:%02d %s", q.start/60, q.start%60, q.end/60, q.end%60, q.location)
+}
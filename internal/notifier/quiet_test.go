@@ -0,0 +1,145 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/parser"
+)
+
+func TestQuietHours_Active(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 6, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	t.Run("should wrap an overnight window past midnight", func(t *testing.T) {
+		quiet, err := ParseQuietHours("22:00", "07:00", "UTC")
+		require.NoError(t, err)
+
+		assert.True(t, quiet.Active(at(23, 30)))
+		assert.True(t, quiet.Active(at(3, 0)))
+		assert.False(t, quiet.Active(at(7, 0)))
+		assert.False(t, quiet.Active(at(12, 0)))
+		assert.True(t, quiet.Active(at(22, 0)))
+	})
+
+	t.Run("should handle a daytime window", func(t *testing.T) {
+		quiet, err := ParseQuietHours("12:00", "13:30", "UTC")
+		require.NoError(t, err)
+
+		assert.True(t, quiet.Active(at(13, 29)))
+		assert.False(t, quiet.Active(at(13, 30)))
+		assert.False(t, quiet.Active(at(11, 59)))
+	})
+
+	t.Run("should evaluate the window in its time zone", func(t *testing.T) {
+		quiet, err := ParseQuietHours("22:00", "07:00", "America/New_York")
+		require.NoError(t, err)
+
+		// 03:00 UTC is 23:00 the previous evening in New York (EDT)
+		assert.True(t, quiet.Active(at(3, 0)))
+		// 12:00 UTC is 08:00 in New York
+		assert.False(t, quiet.Active(at(12, 0)))
+		assert.Equal(t, "22:00-07:00 America/New_York", quiet.String())
+	})
+
+	t.Run("should be disabled without a window", func(t *testing.T) {
+		quiet, err := ParseQuietHours("", "", "")
+		require.NoError(t, err)
+
+		assert.False(t, quiet.Enabled())
+		assert.False(t, quiet.Active(at(3, 0)))
+	})
+
+	t.Run("should reject invalid windows", func(t *testing.T) {
+		for _, window := range [][3]string{{"25:00", "07:00", ""}, {"22:00", "7am", ""}, {"22:00", "", ""}, {"22:00", "07:00", "Mars/Olympus"}} {
+			_, err := ParseQuietHours(window[0], window[1], window[2])
+
+			assert.Error(t, err, window)
+		}
+	})
+}
+
+func TestDispatcher_QuietHours(t *testing.T) {
+	newQuietDispatcher := func(t *testing.T, includeAlerts bool) (*Dispatcher, *recordingNotifier) {
+		t.Helper()
+		recorder := &recordingNotifier{name: "recorder"}
+		d := NewDispatcher(nil, recorder)
+		quiet, err := ParseQuietHours("22:00", "07:00", "UTC")
+		require.NoError(t, err)
+		d.SetQuietHours(quiet, includeAlerts)
+		d.now = func() time.Time { return time.Date(2025, 6, 1, 2, 0, 0, 0, time.UTC) }
+		return d, recorder
+	}
+	cue := NewCueNotification(*parser.NewContestCue("keyword", parser.CueDetails{Keyword: "WIN", Number: "12345"}))
+	alert := NewAlertNotification(SeverityCritical, "stream down", "", nil)
+
+	t.Run("should suppress cues but not alerts during quiet hours", func(t *testing.T) {
+		d, recorder := newQuietDispatcher(t, false)
+
+		assert.NoError(t, d.Dispatch(context.Background(), cue))
+		assert.NoError(t, d.Dispatch(context.Background(), alert))
+
+		require.Len(t, recorder.received, 1)
+		assert.Equal(t, KindAlert, recorder.received[0].Kind)
+		assert.Equal(t, int64(1), d.SuppressedNotifications())
+	})
+
+	t.Run("should suppress alerts too when configured", func(t *testing.T) {
+		d, recorder := newQuietDispatcher(t, true)
+
+		d.Dispatch(context.Background(), alert)
+
+		assert.Empty(t, recorder.received)
+	})
+
+	t.Run("should deliver outside quiet hours", func(t *testing.T) {
+		d, recorder := newQuietDispatcher(t, false)
+		d.now = func() time.Time { return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC) }
+
+		d.Dispatch(context.Background(), cue)
+
+		assert.Len(t, recorder.received, 1)
+		assert.False(t, d.InQuietHours())
+	})
+
+	t.Run("should suppress everything in maintenance mode until turned off", func(t *testing.T) {
+		recorder := &recordingNotifier{name: "recorder"}
+		d := NewDispatcher(nil, recorder)
+
+		d.SetMaintenance(true)
+		d.Dispatch(context.Background(), cue)
+		d.Dispatch(context.Background(), alert)
+		assert.Empty(t, recorder.received)
+
+		d.SetMaintenance(false)
+		d.Dispatch(context.Background(), cue)
+		assert.Len(t, recorder.received, 1)
+	})
+
+	t.Run("should configure quiet hours and maintenance from config", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetQuietHours("22:00", "07:00")
+		cfg.SetMaintenanceMode(true)
+
+		d, err := NewDispatcherFromConfig(cfg, nil)
+
+		require.NoError(t, err)
+		assert.True(t, d.quietHours.Enabled())
+		assert.True(t, d.Maintenance())
+	})
+
+	t.Run("should reject invalid quiet hours in config", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetQuietHours("late", "07:00")
+
+		_, err := NewDispatcherFromConfig(cfg, nil)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,63 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"radiocontestwinner/internal/redis"
+)
+
+// RedisNotifier publishes notifications on Redis pub/sub channels (<prefix>:cues and
+// <prefix>:alerts) and keeps the latest pipeline status in <prefix>:status for dashboards
+type RedisNotifier struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisNotifier creates a RedisNotifier using keys and channels under prefix
+func NewRedisNotifier(client *redis.Client, prefix string) *RedisNotifier {
+	if prefix == "" {
+		prefix = "radiocontestwinner"
+	}
+	return &RedisNotifier{client: client, prefix: prefix}
+}
+
+// Name returns the notifier name used in logs
+func (r *RedisNotifier) Name() string {
+	return "redis"
+}
+
+// Channel returns the pub/sub channel notifications of kind are published on
+func (r *RedisNotifier) Channel(kind Kind) string {
+	return r.prefix + ":" + string(kind) + "s"
+}
+
+// Notify publishes the notification JSON on its kind's channel
+func (r *RedisNotifier) Notify(ctx context.Context, notification Notification) error {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+	if _, err := r.client.Do("PUBLISH", r.Channel(notification.Kind), string(payload)); err != nil {
+		return fmt.Errorf("failed to publish notification: %w", err)
+	}
+	return nil
+}
+
+// NotifyStatus stores the latest pipeline status
+func (r *RedisNotifier) NotifyStatus(ctx context.Context, status Status) error {
+	payload, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed to marshal status: %w", err)
+	}
+	if _, err := r.client.Do("SET", r.prefix+":status", string(payload)); err != nil {
+		return fmt.Errorf("failed to store status: %w", err)
+	}
+	return nil
+}
+
+// Close closes the Redis connection
+func (r *RedisNotifier) Close() error {
+	return r.client.Close()
+}
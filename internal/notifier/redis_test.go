@@ -0,0 +1,88 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/parser"
+	"radiocontestwinner/internal/redis"
+	"radiocontestwinner/internal/redis/redistest"
+)
+
+func newTestRedisNotifier(t *testing.T, server *redistest.Server) *RedisNotifier {
+	t.Helper()
+	client, err := redis.NewClient(redis.Options{Address: server.Addr()})
+	require.NoError(t, err)
+	r := NewRedisNotifier(client, "station")
+	t.Cleanup(func() { r.Close() })
+	return r
+}
+
+func TestRedisNotifier_Notify(t *testing.T) {
+	t.Run("should publish cues and alerts on separate channels", func(t *testing.T) {
+		// Arrange
+		server := redistest.NewServer(t)
+		r := newTestRedisNotifier(t, server)
+		cue := parser.ContestCue{ContestType: "keyword_contest", Details: parser.CueDetails{Keyword: "ROCK", Number: "12345"}}
+
+		// Act
+		require.NoError(t, r.Notify(context.Background(), NewCueNotification(cue)))
+		require.NoError(t, r.Notify(context.Background(), NewAlertNotification(SeverityWarning, "Stream disconnected", "", nil)))
+
+		// Assert
+		published := server.Published()
+		require.Len(t, published, 2)
+		assert.Equal(t, "station:cues", published[0].Channel)
+		assert.Equal(t, "station:alerts", published[1].Channel)
+
+		var decoded Notification
+		require.NoError(t, json.Unmarshal([]byte(published[0].Payload), &decoded))
+		assert.Equal(t, KindCue, decoded.Kind)
+		assert.Equal(t, "ROCK", decoded.Cue.Details.Keyword)
+	})
+
+	t.Run("should return an error when Redis is failing", func(t *testing.T) {
+		server := redistest.NewServer(t)
+		server.SetFailing(true)
+		r := newTestRedisNotifier(t, server)
+
+		err := r.Notify(context.Background(), NewAlertNotification(SeverityInfo, "test", "", nil))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestRedisNotifier_NotifyStatus(t *testing.T) {
+	server := redistest.NewServer(t)
+	r := newTestRedisNotifier(t, server)
+
+	err := r.NotifyStatus(context.Background(), Status{StreamConnected: true, PipelineHealthy: true, State: "healthy"})
+
+	require.NoError(t, err)
+	var status Status
+	require.NoError(t, json.Unmarshal([]byte(server.Value("station:status")), &status))
+	assert.Equal(t, "healthy", status.State)
+}
+
+func TestNewDispatcherFromConfig_Redis(t *testing.T) {
+	t.Run("should add the redis notifier only when Redis is enabled", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+
+		dispatcher, err := NewDispatcherFromConfig(cfg, nil)
+		require.NoError(t, err)
+		assert.False(t, dispatcher.Enabled())
+
+		cfg.SetRedisEnabled(true)
+		cfg.SetNotifierQueueDir(t.TempDir())
+		dispatcher, err = NewDispatcherFromConfig(cfg, nil)
+		require.NoError(t, err)
+		require.Len(t, dispatcher.Notifiers(), 1)
+		assert.Equal(t, "redis", dispatcher.Notifiers()[0].Name())
+		assert.NoError(t, dispatcher.Close())
+	})
+}
@@ -0,0 +1,140 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"radiocontestwinner/internal/googleauth"
+)
+
+const (
+	sheetsScope          = "https://www.googleapis.com/auth/spreadsheets"
+	defaultSheetsBaseURL = "https://sheets.googleapis.com/v4/spreadsheets"
+)
+
+// tokenSource supplies OAuth2 access tokens for Google API requests
+type tokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// SheetsNotifier appends every detected ContestCue as a row to a Google Sheet
+type SheetsNotifier struct {
+	spreadsheetID string
+	sheetName     string
+	baseURL       string
+	tokens        tokenSource
+	client        *http.Client
+}
+
+// NewSheetsNotifier creates a SheetsNotifier authenticating with the service-account key at credentialsFile
+func NewSheetsNotifier(spreadsheetID, sheetName, credentialsFile string, timeout time.Duration) (*SheetsNotifier, error) {
+	if spreadsheetID == "" {
+		return nil, fmt.Errorf("spreadsheet ID cannot be empty")
+	}
+	if credentialsFile == "" {
+		return nil, fmt.Errorf("service account credentials file cannot be empty")
+	}
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	key, err := googleauth.LoadServiceAccountKey(credentialsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Timeout: timeout}
+	tokens, err := googleauth.NewTokenSource(key, []string{sheetsScope}, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSheetsNotifier(spreadsheetID, sheetName, defaultSheetsBaseURL, tokens, client), nil
+}
+
+// newSheetsNotifier wires a SheetsNotifier from its parts
+func newSheetsNotifier(spreadsheetID, sheetName, baseURL string, tokens tokenSource, client *http.Client) *SheetsNotifier {
+	if sheetName == "" {
+		sheetName = "Sheet1"
+	}
+	return &SheetsNotifier{
+		spreadsheetID: spreadsheetID,
+		sheetName:     sheetName,
+		baseURL:       baseURL,
+		tokens:        tokens,
+		client:        client,
+	}
+}
+
+// Name returns the notifier name used in logs
+func (s *SheetsNotifier) Name() string {
+	return "sheets"
+}
+
+// Notify appends a row for cue notifications; alerts are not exported to the sheet
+func (s *SheetsNotifier) Notify(ctx context.Context, notification Notification) error {
+	if notification.Kind != KindCue || notification.Cue == nil {
+		return nil
+	}
+
+	token, err := s.tokens.Token(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to obtain access token: %w", err)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"values": [][]interface{}{sheetsRow(notification)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal sheet row: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/values/%s:append?valueInputOption=USER_ENTERED&insertDataOption=INSERT_ROWS",
+		s.baseURL, url.PathEscape(s.spreadsheetID), url.PathEscape(s.sheetName+"!A1"))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create sheets request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sheets request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("sheets API returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
+
+// sheetsRow flattens a cue notification into spreadsheet columns:
+// detected at, contest type, keyword, number, cue ID, cue timestamp, and the local time when a
+// timezone is configured
+func sheetsRow(notification Notification) []interface{} {
+	cue := notification.Cue
+	keyword, number := cue.Details.Keyword, cue.Details.Number
+	row := []interface{}{
+		notification.Timestamp,
+		cue.ContestType,
+		keyword,
+		number,
+		cue.CueID,
+		cue.Timestamp,
+	}
+	if local, ok := notification.Fields["local_time"].(string); ok {
+		row = append(row, local)
+	}
+	return row
+}
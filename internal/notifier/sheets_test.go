@@ -0,0 +1,124 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/parser"
+)
+
+// staticTokens is a tokenSource returning a fixed token or error
+type staticTokens struct {
+	token string
+	err   error
+}
+
+func (s staticTokens) Token(ctx context.Context) (string, error) { return s.token, s.err }
+
+func TestSheetsNotifier_Notify(t *testing.T) {
+	cue := parser.ContestCue{
+		CueID:       "cue-1",
+		ContestType: "text_keyword",
+		Timestamp:   "2024-01-01T08:00:00Z",
+		Details:     parser.CueDetails{Keyword: "WIN", Number: "12345"},
+	}
+
+	t.Run("should append the cue as a row", func(t *testing.T) {
+		// Arrange
+		var gotPath, gotQuery, gotAuth string
+		var gotBody map[string][][]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.EscapedPath()
+			gotQuery = r.URL.RawQuery
+			gotAuth = r.Header.Get("Authorization")
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&gotBody))
+			w.Write([]byte(`{}`))
+		}))
+		defer server.Close()
+
+		s := newSheetsNotifier("sheet-123", "Cues", server.URL, staticTokens{token: "token-abc"}, server.Client())
+		notification := NewCueNotification(cue)
+
+		// Act
+		err := s.Notify(context.Background(), notification)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "/sheet-123/values/Cues%21A1:append", gotPath)
+		assert.Contains(t, gotQuery, "valueInputOption=USER_ENTERED")
+		assert.Equal(t, "Bearer token-abc", gotAuth)
+		require.Len(t, gotBody["values"], 1)
+		assert.Equal(t, []interface{}{notification.Timestamp, "text_keyword", "WIN", "12345", "cue-1", "2024-01-01T08:00:00Z"}, gotBody["values"][0])
+	})
+
+	t.Run("should ignore alert notifications", func(t *testing.T) {
+		// Arrange
+		called := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		}))
+		defer server.Close()
+
+		s := newSheetsNotifier("sheet-123", "", server.URL, staticTokens{token: "t"}, server.Client())
+
+		// Act
+		err := s.Notify(context.Background(), NewAlertNotification(SeverityWarning, "title", "message", nil))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, called)
+	})
+
+	t.Run("should return error on API failure", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "permission denied", http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		s := newSheetsNotifier("sheet-123", "", server.URL, staticTokens{token: "t"}, server.Client())
+
+		// Act
+		err := s.Notify(context.Background(), NewCueNotification(cue))
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 403")
+	})
+
+	t.Run("should return error when no access token is available", func(t *testing.T) {
+		s := newSheetsNotifier("sheet-123", "", "http://example.invalid", staticTokens{err: errors.New("no token")}, http.DefaultClient)
+
+		err := s.Notify(context.Background(), NewCueNotification(cue))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestNewSheetsNotifier(t *testing.T) {
+	t.Run("should require a spreadsheet ID and credentials", func(t *testing.T) {
+		_, err1 := NewSheetsNotifier("", "Sheet1", "/tmp/sa.json", 0)
+		_, err2 := NewSheetsNotifier("sheet-123", "Sheet1", "", 0)
+
+		assert.Error(t, err1)
+		assert.Error(t, err2)
+	})
+
+	t.Run("should fail through the dispatcher when credentials cannot be loaded", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetSheetsSpreadsheetID("sheet-123")
+		cfg.SetSheetsCredentialsFile("/nonexistent/sa.json")
+
+		_, err := NewDispatcherFromConfig(cfg, nil)
+
+		assert.Error(t, err)
+	})
+}
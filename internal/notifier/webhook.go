@@ -0,0 +1,108 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"radiocontestwinner/internal/retry"
+	"radiocontestwinner/internal/version"
+)
+
+// SignatureHeader carries the HMAC-SHA256 of the request body as "sha256=<hex>"
+const SignatureHeader = "X-RadioContestWinner-Signature"
+
+// WebhookNotifier posts notifications as JSON to an HTTP endpoint
+type WebhookNotifier struct {
+	url    string
+	secret string // Shared secret for payload signing; empty disables signing
+	client *http.Client
+	retry  retry.Policy // Retries for network errors, timeouts, rate limiting, and server errors
+}
+
+// NewWebhookNotifier creates a new WebhookNotifier posting to the given URL
+func NewWebhookNotifier(url string, timeout time.Duration) *WebhookNotifier {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+		retry: retry.Policy{
+			MaxAttempts: 3,
+			BaseDelay:   500 * time.Millisecond,
+			MaxDelay:    5 * time.Second,
+			Jitter:      0.2,
+			Budget:      2 * timeout,
+		},
+	}
+}
+
+// NewWebhookNotifierWithSecret creates a new WebhookNotifier that signs every payload with the shared secret
+func NewWebhookNotifierWithSecret(url, secret string, timeout time.Duration) *WebhookNotifier {
+	w := NewWebhookNotifier(url, timeout)
+	w.secret = secret
+	return w
+}
+
+// SignPayload returns the signature header value for body computed with secret
+func SignPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Name returns the notifier name used in logs
+func (w *WebhookNotifier) Name() string {
+	return "webhook"
+}
+
+// Notify posts the notification to the configured URL, retrying transient failures. Client
+// errors other than request timeouts and rate limiting are not retried.
+func (w *WebhookNotifier) Notify(ctx context.Context, notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	return retry.Do(ctx, w.retry, func(ctx context.Context, attempt int) error {
+		return w.post(ctx, body)
+	})
+}
+
+// post makes a single delivery attempt of body
+func (w *WebhookNotifier) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return retry.Permanent(fmt.Errorf("failed to create webhook request: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", version.UserAgent())
+	if w.secret != "" {
+		req.Header.Set(SignatureHeader, SignPayload(w.secret, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		err := fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, string(respBody))
+		if !retry.RetryableStatus(resp.StatusCode) {
+			return retry.Permanent(err)
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package notifier
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	t.Run("should post notification as JSON", func(t *testing.T) {
+		// Arrange
+		var received Notification
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		w := NewWebhookNotifier(server.URL, time.Second)
+
+		// Act
+		err := w.Notify(context.Background(), NewAlertNotification(SeverityCritical, "Stream down", "no audio", nil))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, KindAlert, received.Kind)
+		assert.Equal(t, SeverityCritical, received.Severity)
+		assert.Equal(t, "Stream down", received.Title)
+	})
+
+	t.Run("should sign the payload with the shared secret", func(t *testing.T) {
+		// Arrange
+		var body []byte
+		var signature string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			signature = r.Header.Get(SignatureHeader)
+			body, _ = io.ReadAll(r.Body)
+		}))
+		defer server.Close()
+
+		w := NewWebhookNotifierWithSecret(server.URL, "s3cret", time.Second)
+
+		// Act
+		err := w.Notify(context.Background(), NewAlertNotification(SeverityInfo, "t", "m", nil))
+
+		// Assert - verify the way a receiving service would
+		require.NoError(t, err)
+		mac := hmac.New(sha256.New, []byte("s3cret"))
+		mac.Write(body)
+		expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+		assert.True(t, hmac.Equal([]byte(expected), []byte(signature)), "signature %q should match %q", signature, expected)
+	})
+
+	t.Run("should not sign when no secret is configured", func(t *testing.T) {
+		// Arrange
+		signed := true
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, signed = r.Header[http.CanonicalHeaderKey(SignatureHeader)]
+		}))
+		defer server.Close()
+
+		w := NewWebhookNotifier(server.URL, time.Second)
+
+		// Act
+		err := w.Notify(context.Background(), NewAlertNotification(SeverityInfo, "t", "m", nil))
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, signed)
+	})
+
+	t.Run("should return error on non-2xx status", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "nope", http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		w := NewWebhookNotifier(server.URL, time.Second)
+
+		// Act
+		err := w.Notify(context.Background(), NewAlertNotification(SeverityInfo, "t", "m", nil))
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 502")
+	})
+
+	t.Run("should retry server errors until the endpoint recovers", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls < 3 {
+				http.Error(w, "busy", http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		w := NewWebhookNotifier(server.URL, time.Second)
+		w.retry.BaseDelay = time.Millisecond
+
+		// Act
+		err := w.Notify(context.Background(), NewAlertNotification(SeverityInfo, "t", "m", nil))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("should not retry client errors", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			http.Error(w, "bad payload", http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		w := NewWebhookNotifier(server.URL, time.Second)
+		w.retry.BaseDelay = time.Millisecond
+
+		// Act
+		err := w.Notify(context.Background(), NewAlertNotification(SeverityInfo, "t", "m", nil))
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 400")
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestSignPayload(t *testing.T) {
+	// Known HMAC-SHA256 vector: key "key", message "The quick brown fox jumps over the lazy dog"
+	signature := SignPayload("key", []byte("The quick brown fox jumps over the lazy dog"))
+
+	assert.Equal(t, "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8", signature)
+}
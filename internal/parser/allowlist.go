@@ -0,0 +1,286 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"radiocontestwinner/internal/program"
+)
+
+// Allowlist match kinds recorded in cue details as allowlist_match
+const (
+	AllowlistMatchExact    = "exact"
+	AllowlistMatchWildcard = "wildcard"
+)
+
+// AllowlistEntry is one allowlisted number or pattern with where it was configured, recorded in
+// the cues it accepts so audits can tell which configuration produced an alert. An entry may be
+// limited to a weekly window of station-local time, such as the morning show running its contest.
+type AllowlistEntry struct {
+	Value    string    // The number or pattern, e.g. "55*"
+	Label    string    // Optional note, e.g. the contest or sponsor
+	Source   string    // Where the entry was configured, e.g. "file" or "env"
+	Modified time.Time // When its source last changed; zero when unknown
+	Days     []string  // Days the entry is active: mon..sun, weekdays, or weekends; empty is every day
+	Start    string    // Station-local "HH:MM" the entry becomes active; empty with End is all day
+	End      string    // Station-local "HH:MM" the entry stops being active; before Start past midnight
+	Expires  string    // Station-local "YYYY-MM-DD" of the entry's last day; empty never expires
+}
+
+// windowed reports whether the entry is limited to an active window
+func (e AllowlistEntry) windowed() bool {
+	return len(e.Days) > 0 || e.Start != "" || e.End != ""
+}
+
+// window parses the entry's active window in location
+func (e AllowlistEntry) window(location *time.Location) (program.Window, error) {
+	start, end := e.Start, e.End
+	if start == "" && end == "" {
+		start, end = "00:00", "24:00"
+	}
+	window, err := program.ParseWindow(e.Days, start, end, location)
+	if err != nil {
+		return program.Window{}, fmt.Errorf("allowlist entry %s: active window %w", e.Value, err)
+	}
+	return window, nil
+}
+
+// expiry parses the entry's expires date; ok is false when it never expires
+func (e AllowlistEntry) expiry() (date time.Time, ok bool, err error) {
+	if e.Expires == "" {
+		return time.Time{}, false, nil
+	}
+	date, err = time.Parse("2006-01-02", e.Expires)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("allowlist entry %s: expires %q is not a YYYY-MM-DD date", e.Value, e.Expires)
+	}
+	return date, true, nil
+}
+
+// AllowlistExpiry is an allowlist entry that expires soon or already has
+type AllowlistExpiry struct {
+	Entry    AllowlistEntry
+	DaysLeft int // Days from today until the entry's last day; negative once it has expired
+}
+
+// Expired reports whether the entry's last day has passed
+func (e AllowlistExpiry) Expired() bool {
+	return e.DaysLeft < 0
+}
+
+// ExpiringAllowlistEntries returns the entries whose last day is at most withinDays after now's
+// date in location (nil uses the local zone), including those already expired, soonest first.
+// Entries without an expiry date or with a malformed one are skipped.
+func ExpiringAllowlistEntries(entries []AllowlistEntry, now time.Time, location *time.Location, withinDays int) []AllowlistExpiry {
+	if location == nil {
+		location = time.Local
+	}
+	local := now.In(location)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+
+	var expiring []AllowlistExpiry
+	for _, entry := range entries {
+		date, ok, err := entry.expiry()
+		if !ok || err != nil {
+			continue
+		}
+		daysLeft := int(date.Sub(today) / (24 * time.Hour))
+		if daysLeft <= withinDays {
+			expiring = append(expiring, AllowlistExpiry{Entry: entry, DaysLeft: daysLeft})
+		}
+	}
+	sort.SliceStable(expiring, func(i, j int) bool {
+		return expiring[i].DaysLeft < expiring[j].DaysLeft
+	})
+	return expiring
+}
+
+// AllowlistMatch describes which allowlist entry accepted a number
+type AllowlistMatch struct {
+	Entry    string    // The allowlist entry as configured, e.g. "55*"
+	Kind     string    // AllowlistMatchExact or AllowlistMatchWildcard
+	Label    string    // The entry's label, if any
+	Source   string    // Where the entry was configured, if known
+	Modified time.Time // When the entry's source last changed, if known
+}
+
+// Allowlist matches shortcodes against exact numbers and wildcard patterns. In a pattern, *
+// matches any run of digits (including none), x, X, or ? match exactly one digit, and a
+// bracketed class such as [0-4] or [137] matches one digit from the class. Patterns must match
+// the whole number, and exact entries take precedence over patterns.
+type Allowlist struct {
+	exact    map[string][]compiledEntry
+	patterns []compiledEntry
+}
+
+// compiledEntry is an allowlist entry ready for matching
+type compiledEntry struct {
+	entry  AllowlistEntry
+	re     *regexp.Regexp  // nil for exact numbers
+	window *program.Window // nil when the entry is always active
+}
+
+// NewAllowlist compiles the allowlist entries. A malformed pattern is matched literally; use
+// ValidateAllowlist to reject such entries up front.
+func NewAllowlist(entries []string) *Allowlist {
+	return NewAllowlistFromEntries(AllowlistEntriesFor(entries), nil)
+}
+
+// NewAllowlistFromEntries compiles allowlist entries carrying labels, provenance, and active
+// windows, which are station-local times in location (nil uses the local zone). The first
+// active entry of several with the same value wins. An entry with a malformed window is always
+// active; use ValidateAllowlistEntries to reject such entries up front.
+func NewAllowlistFromEntries(entries []AllowlistEntry, location *time.Location) *Allowlist {
+	a := &Allowlist{exact: make(map[string][]compiledEntry)}
+	for _, entry := range entries {
+		entry.Value = strings.TrimSpace(entry.Value)
+		if entry.Value == "" {
+			continue
+		}
+		compiled := compiledEntry{entry: entry}
+		if entry.windowed() {
+			if window, err := entry.window(location); err == nil {
+				compiled.window = &window
+			}
+		}
+		if isAllowlistPattern(entry.Value) {
+			if re, err := compileAllowlistPattern(entry.Value); err == nil {
+				compiled.re = re
+				a.patterns = append(a.patterns, compiled)
+				continue
+			}
+		}
+		a.exact[entry.Value] = append(a.exact[entry.Value], compiled)
+	}
+	return a
+}
+
+// AllowlistEntriesFor returns entries without labels or provenance for plain numbers and patterns
+func AllowlistEntriesFor(values []string) []AllowlistEntry {
+	entries := make([]AllowlistEntry, len(values))
+	for i, value := range values {
+		entries[i] = AllowlistEntry{Value: value}
+	}
+	return entries
+}
+
+// ValidateAllowlist returns an error describing the first malformed allowlist pattern
+func ValidateAllowlist(entries []string) error {
+	return ValidateAllowlistEntries(AllowlistEntriesFor(entries))
+}
+
+// ValidateAllowlistEntries returns an error describing the first malformed allowlist pattern,
+// active window, or expiry date
+func ValidateAllowlistEntries(entries []AllowlistEntry) error {
+	for _, entry := range entries {
+		entry.Value = strings.TrimSpace(entry.Value)
+		if isAllowlistPattern(entry.Value) {
+			if _, err := compileAllowlistPattern(entry.Value); err != nil {
+				return err
+			}
+		}
+		if entry.windowed() {
+			if _, err := entry.window(time.UTC); err != nil {
+				return err
+			}
+		}
+		if _, _, err := entry.expiry(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Match returns the entry accepting number at any time, preferring an exact entry over a pattern
+func (a *Allowlist) Match(number string) (AllowlistMatch, bool) {
+	return a.MatchAt(number, time.Time{})
+}
+
+// MatchAt returns the entry accepting number at t, skipping entries outside their active window
+// (the zero time ignores windows). An exact entry is preferred over a pattern.
+func (a *Allowlist) MatchAt(number string, t time.Time) (AllowlistMatch, bool) {
+	if a == nil || number == "" {
+		return AllowlistMatch{}, false
+	}
+	for _, exact := range a.exact[number] {
+		if exact.activeAt(t) {
+			return exact.entry.match(AllowlistMatchExact), true
+		}
+	}
+	for _, pattern := range a.patterns {
+		if pattern.activeAt(t) && pattern.re.MatchString(number) {
+			return pattern.entry.match(AllowlistMatchWildcard), true
+		}
+	}
+	return AllowlistMatch{}, false
+}
+
+// activeAt reports whether the entry accepts numbers at t; the zero time is any time
+func (c compiledEntry) activeAt(t time.Time) bool {
+	return c.window == nil || t.IsZero() || c.window.Contains(t)
+}
+
+// match describes a number accepted by the entry
+func (e AllowlistEntry) match(kind string) AllowlistMatch {
+	return AllowlistMatch{Entry: e.Value, Kind: kind, Label: e.Label, Source: e.Source, Modified: e.Modified}
+}
+
+// isAllowlistPattern reports whether entry uses wildcard syntax rather than a plain number
+func isAllowlistPattern(entry string) bool {
+	return strings.ContainsAny(entry, "*?xX[")
+}
+
+// compileAllowlistPattern translates an allowlist pattern into an anchored regular expression
+func compileAllowlistPattern(entry string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(entry); i++ {
+		c := entry[i]
+		switch {
+		case c >= '0' && c <= '9':
+			b.WriteByte(c)
+		case c == '*':
+			b.WriteString(`\d*`)
+		case c == '?' || c == 'x' || c == 'X':
+			b.WriteString(`\d`)
+		case c == '[':
+			end := strings.IndexByte(entry[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("allowlist pattern %q: unterminated character class", entry)
+			}
+			class := entry[i+1 : i+end]
+			if err := validateDigitClass(class); err != nil {
+				return nil, fmt.Errorf("allowlist pattern %q: %w", entry, err)
+			}
+			b.WriteString("[" + class + "]")
+			i += end
+		default:
+			return nil, fmt.Errorf("allowlist pattern %q: unexpected character %q (use digits, *, x, ?, or [0-9] classes)", entry, c)
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// validateDigitClass checks a character class body holds only digits and ascending digit ranges
+func validateDigitClass(class string) error {
+	if class == "" {
+		return fmt.Errorf("empty character class")
+	}
+	for i := 0; i < len(class); i++ {
+		c := class[i]
+		if c < '0' || c > '9' {
+			return fmt.Errorf("character class [%s] may only contain digits and ranges", class)
+		}
+		if i+2 < len(class) && class[i+1] == '-' {
+			if class[i+2] < '0' || class[i+2] > '9' || class[i+2] < c {
+				return fmt.Errorf("invalid range in character class [%s]", class)
+			}
+			i += 2
+		}
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/buffer"
+)
+
+func TestAllowlist_Match(t *testing.T) {
+	t.Run("should match exact numbers only in full", func(t *testing.T) {
+		allowlist := NewAllowlist([]string{"12345"})
+
+		match, ok := allowlist.Match("12345")
+		assert.True(t, ok)
+		assert.Equal(t, AllowlistMatch{Entry: "12345", Kind: AllowlistMatchExact}, match)
+
+		_, ok = allowlist.Match("123456")
+		assert.False(t, ok)
+	})
+
+	t.Run("should match a trailing star against any run of digits", func(t *testing.T) {
+		allowlist := NewAllowlist([]string{"55*"})
+
+		for _, number := range []string{"55", "555", "55123"} {
+			match, ok := allowlist.Match(number)
+			assert.True(t, ok, number)
+			assert.Equal(t, AllowlistMatch{Entry: "55*", Kind: AllowlistMatchWildcard}, match)
+		}
+		_, ok := allowlist.Match("5455")
+		assert.False(t, ok)
+	})
+
+	t.Run("should match x and ? against exactly one digit", func(t *testing.T) {
+		allowlist := NewAllowlist([]string{"1xx4", "9?"})
+
+		_, ok := allowlist.Match("1234")
+		assert.True(t, ok)
+		_, ok = allowlist.Match("90")
+		assert.True(t, ok)
+		_, ok = allowlist.Match("124")
+		assert.False(t, ok)
+		_, ok = allowlist.Match("12334")
+		assert.False(t, ok)
+	})
+
+	t.Run("should match character classes and ranges", func(t *testing.T) {
+		allowlist := NewAllowlist([]string{"7[0-4]9", "8[13]"})
+
+		_, ok := allowlist.Match("739")
+		assert.True(t, ok)
+		_, ok = allowlist.Match("759")
+		assert.False(t, ok)
+		_, ok = allowlist.Match("83")
+		assert.True(t, ok)
+		_, ok = allowlist.Match("82")
+		assert.False(t, ok)
+	})
+
+	t.Run("should prefer an exact entry over a pattern covering the same number", func(t *testing.T) {
+		allowlist := NewAllowlist([]string{"55*", "5512"})
+
+		match, ok := allowlist.Match("5512")
+
+		assert.True(t, ok)
+		assert.Equal(t, AllowlistMatch{Entry: "5512", Kind: AllowlistMatchExact}, match)
+	})
+}
+
+func TestAllowlist_MatchAt(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	allowlist := NewAllowlistFromEntries([]AllowlistEntry{
+		{Value: "72881", Label: "Morning cash", Days: []string{"weekdays"}, Start: "06:00", End: "10:00"},
+		{Value: "72881", Label: "Drive home", Start: "16:00", End: "19:00"},
+		{Value: "55*", Days: []string{"sun"}},
+		{Value: "12345"},
+	}, newYork)
+
+	t.Run("should accept numbers only while an entry is active in station time", func(t *testing.T) {
+		// Monday 2026-03-09, the day after clocks spring forward: 10:30 UTC is 06:30 EDT
+		match, ok := allowlist.MatchAt("72881", time.Date(2026, 3, 9, 10, 30, 0, 0, time.UTC))
+		assert.True(t, ok)
+		assert.Equal(t, "Morning cash", match.Label)
+
+		_, ok = allowlist.MatchAt("72881", time.Date(2026, 3, 9, 14, 30, 0, 0, time.UTC))
+		assert.False(t, ok, "10:30 EDT is after the morning window")
+	})
+
+	t.Run("should use whichever entry for a number is active", func(t *testing.T) {
+		// 21:00 UTC on 2026-11-02, the day after clocks fall back, is 16:00 EST
+		match, ok := allowlist.MatchAt("72881", time.Date(2026, 11, 2, 21, 0, 0, 0, time.UTC))
+
+		assert.True(t, ok)
+		assert.Equal(t, "Drive home", match.Label)
+	})
+
+	t.Run("should keep a day-limited pattern active all day in station time", func(t *testing.T) {
+		// 03:30 UTC on Monday 2026-11-02 is still Sunday evening in New York
+		_, sunday := allowlist.MatchAt("55123", time.Date(2026, 11, 2, 3, 30, 0, 0, time.UTC))
+		_, monday := allowlist.MatchAt("55123", time.Date(2026, 11, 2, 5, 30, 0, 0, time.UTC))
+
+		assert.True(t, sunday)
+		assert.False(t, monday)
+	})
+
+	t.Run("should ignore active windows when matching at any time", func(t *testing.T) {
+		match, ok := allowlist.Match("72881")
+		assert.True(t, ok)
+		assert.Equal(t, "Morning cash", match.Label)
+
+		_, ok = allowlist.MatchAt("12345", time.Date(2026, 3, 8, 7, 0, 0, 0, time.UTC))
+		assert.True(t, ok, "entries without a window are always active")
+	})
+}
+
+func TestValidateAllowlist(t *testing.T) {
+	t.Run("should accept numbers and well-formed patterns", func(t *testing.T) {
+		assert.NoError(t, ValidateAllowlist([]string{"12345", "55*", "1xx4", "7[0-4]9"}))
+	})
+
+	t.Run("should reject malformed patterns", func(t *testing.T) {
+		for _, entry := range []string{"55[0-", "5[a-c]", "5[9-1]", "5[]", "55*a"} {
+			assert.Error(t, ValidateAllowlist([]string{entry}), entry)
+		}
+	})
+
+	t.Run("should reject malformed active windows", func(t *testing.T) {
+		for _, entry := range []AllowlistEntry{
+			{Value: "72881", Start: "6am", End: "10:00"},
+			{Value: "72881", Start: "06:00"},
+			{Value: "72881", Days: []string{"funday"}},
+		} {
+			assert.ErrorContains(t, ValidateAllowlistEntries([]AllowlistEntry{entry}), "allowlist entry 72881", entry)
+		}
+	})
+
+	t.Run("should reject malformed expiry dates", func(t *testing.T) {
+		for _, expires := range []string{"30/11/2026", "2026-13-01", "next week"} {
+			err := ValidateAllowlistEntries([]AllowlistEntry{{Value: "72881", Expires: expires}})
+			assert.ErrorContains(t, err, "allowlist entry 72881", expires)
+		}
+		assert.NoError(t, ValidateAllowlistEntries([]AllowlistEntry{{Value: "72881", Expires: "2026-11-30"}}))
+	})
+}
+
+func TestExpiringAllowlistEntries(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	entries := []AllowlistEntry{
+		{Value: "11111", Expires: "2026-12-31"},
+		{Value: "22222", Expires: "2026-11-05"},
+		{Value: "33333", Expires: "2026-10-30"},
+		{Value: "44444"},
+		{Value: "55555", Expires: "2026-11-01"},
+	}
+
+	t.Run("should return expired entries and those expiring soon, soonest first", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2026, 11, 1, 15, 0, 0, 0, newYork)
+
+		// Act
+		expiring := ExpiringAllowlistEntries(entries, now, newYork, 7)
+
+		// Assert
+		require.Len(t, expiring, 3)
+		assert.Equal(t, "33333", expiring[0].Entry.Value)
+		assert.Equal(t, -2, expiring[0].DaysLeft)
+		assert.True(t, expiring[0].Expired())
+		assert.Equal(t, "55555", expiring[1].Entry.Value)
+		assert.Equal(t, 0, expiring[1].DaysLeft)
+		assert.False(t, expiring[1].Expired(), "an entry is active through its last day")
+		assert.Equal(t, "22222", expiring[2].Entry.Value)
+		assert.Equal(t, 4, expiring[2].DaysLeft)
+	})
+
+	t.Run("should count days in station time", func(t *testing.T) {
+		// Arrange: still November 1 in New York, already November 2 in UTC
+		now := time.Date(2026, 11, 2, 3, 0, 0, 0, time.UTC)
+
+		// Act
+		expiring := ExpiringAllowlistEntries(entries, now, newYork, 0)
+
+		// Assert
+		require.Len(t, expiring, 2)
+		assert.Equal(t, "55555", expiring[1].Entry.Value)
+		assert.Equal(t, 0, expiring[1].DaysLeft)
+	})
+
+	t.Run("should skip malformed expiry dates", func(t *testing.T) {
+		now := time.Date(2026, 11, 1, 12, 0, 0, 0, time.UTC)
+
+		expiring := ExpiringAllowlistEntries([]AllowlistEntry{{Value: "66666", Expires: "soon"}}, now, time.UTC, 30)
+
+		assert.Empty(t, expiring)
+	})
+}
+
+func TestContestParser_WildcardAllowlist(t *testing.T) {
+	t.Run("should record the matching entry and match kind in cue details", func(t *testing.T) {
+		// Arrange
+		cp := NewContestParser([]string{"12345", "55*"})
+		context := &buffer.BufferedContext{Text: "Text CASH to 55123 and text PRIZE to 12345", CapturedAt: time.Now()}
+
+		// Act
+		cues := cp.CreateContestCues(context)
+
+		// Assert
+		require.Len(t, cues, 2)
+		assert.Equal(t, "55*", cues[0].Details.Extensions["allowlist_entry"])
+		assert.Equal(t, AllowlistMatchWildcard, cues[0].Details.Extensions["allowlist_match"])
+		assert.Equal(t, "12345", cues[1].Details.Extensions["allowlist_entry"])
+		assert.Equal(t, AllowlistMatchExact, cues[1].Details.Extensions["allowlist_match"])
+	})
+
+	t.Run("should record the label and provenance of the matching entry", func(t *testing.T) {
+		// Arrange
+		modified := time.Date(2026, 10, 1, 9, 30, 0, 0, time.FixedZone("EDT", -4*3600))
+		cp := NewContestParser(nil)
+		cp.SetAllowlistEntries([]AllowlistEntry{
+			{Value: "72881", Label: "Morning cash", Source: "file", Modified: modified},
+			{Value: "55*", Source: "env"},
+		}, nil)
+		context := &buffer.BufferedContext{Text: "Text CASH to 72881 and text PRIZE to 55123", CapturedAt: time.Now()}
+
+		// Act
+		cues := cp.CreateContestCues(context)
+
+		// Assert
+		require.Len(t, cues, 2)
+		assert.Equal(t, "Morning cash", cues[0].Details.String("allowlist_label"))
+		assert.Equal(t, "file", cues[0].Details.String("allowlist_source"))
+		assert.Equal(t, "2026-10-01T13:30:00Z", cues[0].Details.String("allowlist_modified"))
+		assert.Equal(t, "55*", cues[1].Details.String("allowlist_entry"))
+		assert.Equal(t, "env", cues[1].Details.String("allowlist_source"))
+		assert.False(t, cues[1].Details.Has("allowlist_label"))
+		assert.False(t, cues[1].Details.Has("allowlist_modified"))
+	})
+
+	t.Run("should only create cues for numbers heard while their entry is active", func(t *testing.T) {
+		// Arrange
+		newYork, err := time.LoadLocation("America/New_York")
+		require.NoError(t, err)
+		cp := NewContestParser(nil)
+		cp.SetAllowlistEntries([]AllowlistEntry{{Value: "72881", Start: "06:00", End: "10:00"}}, newYork)
+		text := "Text CASH to 72881"
+
+		// Act: 06:30 and 05:30 EST on 2026-11-01, after clocks fall back
+		during := cp.CreateContestCues(&buffer.BufferedContext{Text: text, CapturedAt: time.Date(2026, 11, 1, 11, 30, 0, 0, time.UTC)})
+		before := cp.CreateContestCues(&buffer.BufferedContext{Text: text, CapturedAt: time.Date(2026, 11, 1, 10, 30, 0, 0, time.UTC)})
+
+		// Assert
+		assert.Len(t, during, 1)
+		assert.Empty(t, before)
+		assert.Empty(t, cp.MatchUnlistedContestPatterns(text), "an inactive entry is still listed")
+	})
+
+	t.Run("should filter contexts by wildcard entries", func(t *testing.T) {
+		cp := NewContestParser([]string{"1xx4"})
+
+		assert.True(t, cp.FilterByAllowlist(&buffer.BufferedContext{Text: "call 1984 now"}))
+		assert.False(t, cp.FilterByAllowlist(&buffer.BufferedContext{Text: "call 1985 now"}))
+	})
+}
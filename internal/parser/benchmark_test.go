@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"radiocontestwinner/internal/buffer"
+)
+
+// Benchmark tests for pattern matching throughput at high context rates
+
+var benchmarkContexts = []buffer.BufferedContext{
+	{Text: "Text WIN to 12345 for your chance at concert tickets", StartMS: 0, EndMS: 2500},
+	{Text: "That's T I C K E T S, text it to 12345 right now", StartMS: 2500, EndMS: 5000},
+	{Text: "Traffic on the interstate is backed up past exit 73", StartMS: 5000, EndMS: 7500},
+	{Text: "Text P-R-I-Z-E to 12345 before the top of the hour", StartMS: 7500, EndMS: 10000},
+}
+
+// transcriptCorpus is a stretch of transcribed airtime: mostly music talk, weather, traffic, and
+// ads full of numbers that are not shortcodes, with the occasional contest cue in its spoken forms
+var transcriptCorpus = []string{
+	"You're listening to K-I-S-S one oh two point seven, the hottest hits in the city",
+	"Coming up after the break we've got twelve in a row starting with a brand new one from Dua Lipa",
+	"Right now it's 72 degrees downtown with a high of 88 this afternoon and a 20 percent chance of storms",
+	"Traffic on I-35 southbound is backed up from exit 234 to 236 after a two car crash, give yourself an extra 15 minutes",
+	"Call Smith and Associates at 1-800-555-0199, that's 1-800-555-0199, restrictions apply",
+	"Text CASH to 72881 right now for your chance at a thousand dollars, standard message and data rates may apply",
+	"That keyword again is C A S H, C-A-S-H, text it to seven two eight eight one",
+	"Text the word W I N to 72881 before the top of the hour and you could be our next winner",
+	"The nine o'clock thousand dollar keyword is coming up in about 10 minutes so stay right here",
+	"Our 2024 summer concert series kicks off June 14th at the amphitheater, tickets start at 49.99",
+	"Text ROCK to 55555, that's R O C K to 55555, good luck everybody",
+	"It's 8:47 on a Tuesday morning, here's Taylor Swift with Cruel Summer",
+}
+
+// matchContestPatternRegex matches contestPattern as a regular expression, for comparison with the
+// token matcher
+func matchContestPatternRegex(cp *ContestParser, text string) (string, string, bool) {
+	reconstructed := cp.ReconstructSpelledWords(text)
+	matches := regexp.MustCompile(contestPattern).FindStringSubmatch(reconstructed)
+	if len(matches) < 3 {
+		return "", "", false
+	}
+	return matches[1], matches[2], true
+}
+
+func BenchmarkContestParser_MatchContestPattern(b *testing.B) {
+	cp := NewContestParser([]string{"12345"})
+
+	b.Run("token matcher", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			cp.MatchContestPattern(benchmarkContexts[i%len(benchmarkContexts)].Text)
+		}
+	})
+
+	b.Run("regex compiled per call", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			matchContestPatternRegex(cp, benchmarkContexts[i%len(benchmarkContexts)].Text)
+		}
+	})
+}
+
+func BenchmarkContestParser_TranscriptCorpus(b *testing.B) {
+	cp := NewContestParser([]string{"72881", "55555"})
+
+	b.Run("MatchContestPattern", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			cp.MatchContestPattern(transcriptCorpus[i%len(transcriptCorpus)])
+		}
+	})
+
+	b.Run("ReconstructSpelledWords", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			cp.ReconstructSpelledWords(transcriptCorpus[i%len(transcriptCorpus)])
+		}
+	})
+
+	b.Run("ExtractNumbers", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			cp.ExtractNumbers(transcriptCorpus[i%len(transcriptCorpus)])
+		}
+	})
+}
+
+func BenchmarkContestParser_CreateContestCueParallel(b *testing.B) {
+	cp := NewContestParser([]string{"12345"})
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			context := benchmarkContexts[i%len(benchmarkContexts)]
+			cp.CreateContestCue(&context)
+			i++
+		}
+	})
+}
+
+func TestContestParser_PatternMatcher(t *testing.T) {
+	t.Run("should produce the same matches as the contest pattern regex", func(t *testing.T) {
+		cp := NewContestParser([]string{"12345"})
+
+		for _, context := range benchmarkContexts {
+			keyword, number, matched := cp.MatchContestPattern(context.Text)
+			expectedKeyword, expectedNumber, expectedMatched := matchContestPatternRegex(cp, context.Text)
+
+			assert.Equal(t, expectedMatched, matched, context.Text)
+			assert.Equal(t, expectedKeyword, keyword, context.Text)
+			assert.Equal(t, expectedNumber, number, context.Text)
+		}
+	})
+}
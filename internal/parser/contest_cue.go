@@ -1,7 +1,6 @@
 package parser
 
 import (
-	"crypto/rand"
 	"crypto/sha256"
 	"fmt"
 	"sort"
@@ -10,75 +9,133 @@ import (
 
 // ContestCue represents a successfully identified and validated contest cue
 type ContestCue struct {
-	CueID       string                 `json:"cue_id"`
-	ContestType string                 `json:"contest_type"`
-	Timestamp   string                 `json:"timestamp"`
-	Details     map[string]interface{} `json:"details"`
+	CueID       string     `json:"cue_id"`                 // UUIDv7, sortable by creation time
+	ContentHash string     `json:"content_hash,omitempty"` // Stable hash of keyword, number, and time bucket for deduplication
+	ContestType string     `json:"contest_type"`
+	Timestamp   string     `json:"timestamp"`
+	Details     CueDetails `json:"details"`
+	Timing      *CueTiming `json:"timing,omitempty"`
+	TraceIDs    []string   `json:"trace_ids,omitempty"` // IDs of the audio chunks the cue was heard in, oldest first
 }
 
-// NewContestCue creates a new ContestCue with generated CueID and current timestamp
-func NewContestCue(contestType string, details map[string]interface{}) *ContestCue {
-	timestamp := time.Now().UTC().Format(time.RFC3339)
+// CueTiming records when a cue's audio was captured, transcribed, and emitted so downstream
+// consumers can tell how stale the cue is when the pipeline is falling behind
+type CueTiming struct {
+	AudioCapturedAt          time.Time `json:"audio_captured_at,omitzero"`
+	TranscriptionCompletedAt time.Time `json:"transcription_completed_at,omitzero"`
+	EmittedAt                time.Time `json:"emitted_at"`
+	LatencyMS                int64     `json:"latency_ms"`                   // From audio capture to emission; 0 when capture time is unknown
+	ShortcodeSpokenAt        time.Time `json:"shortcode_spoken_at,omitzero"` // When the shortcode was spoken, from word timestamps; zero when unknown
+
+	// Clock drift at detection time, from the last NTP check, so timestamps can be audited
+	ClockOffsetMS  int64     `json:"clock_offset_ms,omitempty"` // Positive when the local clock is ahead of NTP time
+	ClockCheckedAt time.Time `json:"clock_checked_at,omitzero"` // Zero when the clock was never checked
+}
 
-	// Add some randomness for uniqueness while maintaining deterministic deduplication
-	randomBytes := make([]byte, 8)
-	rand.Read(randomBytes)
-	randomSeed := int64(randomBytes[0])<<56 | int64(randomBytes[1])<<48 | int64(randomBytes[2])<<40 | int64(randomBytes[3])<<32 |
-		int64(randomBytes[4])<<24 | int64(randomBytes[5])<<16 | int64(randomBytes[6])<<8 | int64(randomBytes[7])
-	cueID := GenerateCueIDWithSeed(contestType, details, timestamp, randomSeed)
+// NewCueTiming creates timing metadata for a cue emitted at emittedAt. Zero capture or
+// transcription times are left unset.
+func NewCueTiming(capturedAt, transcribedAt, emittedAt time.Time) *CueTiming {
+	timing := &CueTiming{EmittedAt: emittedAt.UTC()}
+	if !capturedAt.IsZero() {
+		timing.AudioCapturedAt = capturedAt.UTC()
+		timing.LatencyMS = emittedAt.Sub(capturedAt).Milliseconds()
+	}
+	if !transcribedAt.IsZero() {
+		timing.TranscriptionCompletedAt = transcribedAt.UTC()
+	}
+	return timing
+}
 
-	return &ContestCue{
-		CueID:       cueID,
-		ContestType: contestType,
-		Timestamp:   timestamp,
-		Details:     details,
+// HeardAt returns when the cue was heard: when its shortcode was spoken if known, otherwise
+// when its audio was captured, otherwise when it was emitted. It is zero without timing.
+func (cc *ContestCue) HeardAt() time.Time {
+	if cc.Timing == nil {
+		return time.Time{}
+	}
+	if !cc.Timing.ShortcodeSpokenAt.IsZero() {
+		return cc.Timing.ShortcodeSpokenAt
 	}
+	if !cc.Timing.AudioCapturedAt.IsZero() {
+		return cc.Timing.AudioCapturedAt
+	}
+	return cc.Timing.EmittedAt
 }
 
-// GenerateCueID generates a unique CueID hash for deduplication
-func GenerateCueID(contestType string, details map[string]interface{}, timestamp string) string {
-	// Create a deterministic hash based on contestType, details, and timestamp
-	h := sha256.New()
-	h.Write([]byte(contestType))
-	h.Write([]byte(timestamp))
+// Age returns how long ago the cue's audio was captured, or since it was emitted when the capture time is unknown
+func (cc *ContestCue) Age(now time.Time) time.Duration {
+	if cc.Timing == nil {
+		return 0
+	}
+	if !cc.Timing.AudioCapturedAt.IsZero() {
+		return now.Sub(cc.Timing.AudioCapturedAt)
+	}
+	return now.Sub(cc.Timing.EmittedAt)
+}
 
-	// Add details to hash in a deterministic way by sorting keys
-	if details != nil {
-		keys := make([]string, 0, len(details))
-		for key := range details {
-			keys = append(keys, key)
-		}
-		sort.Strings(keys)
+// HumanTimeLayout formats timestamps in human-facing output. The zone abbreviation keeps them
+// unambiguous; machine formats use UTC RFC3339 instead.
+const HumanTimeLayout = "2006-01-02 15:04:05 MST"
 
-		for _, key := range keys {
-			h.Write([]byte(key))
-			h.Write([]byte(fmt.Sprintf("%v", details[key])))
-		}
+// FormatTimestamp returns the cue timestamp in loc laid out with HumanTimeLayout. A nil loc, or a
+// timestamp that is not RFC3339, returns Timestamp unchanged.
+func (cc *ContestCue) FormatTimestamp(loc *time.Location) string {
+	if loc == nil {
+		return cc.Timestamp
 	}
+	t, err := time.Parse(time.RFC3339, cc.Timestamp)
+	if err != nil {
+		return cc.Timestamp
+	}
+	return t.In(loc).Format(HumanTimeLayout)
+}
 
-	return fmt.Sprintf("%x", h.Sum(nil))[:16] // Use first 16 characters for readability
+// NewContestCue creates a new ContestCue with a UUIDv7 CueID, current timestamp, and a content
+// hash of the keyword and number in Details bucketed by DefaultCueHashBucket
+func NewContestCue(contestType string, details CueDetails) *ContestCue {
+	now := time.Now().UTC()
+
+	cue := &ContestCue{
+		CueID:       NewUUIDv7(now),
+		ContestType: contestType,
+		Timestamp:   now.Format(time.RFC3339),
+		Details:     details,
+	}
+	cue.SetContentHash(now, DefaultCueHashBucket)
+	return cue
+}
+
+// SetContentHash recomputes ContentHash from the keyword and number in Details for the bucket
+// containing at. Keyword variants mapped to a canonical contest name hash by that name.
+func (cc *ContestCue) SetContentHash(at time.Time, bucket time.Duration) {
+	keyword := cc.Details.Keyword
+	if name := cc.Details.String("contest_name"); name != "" {
+		keyword = name
+	}
+	if keyword == "" || cc.Details.Number == "" {
+		cc.ContentHash = ""
+		return
+	}
+	cc.ContentHash = CueContentHash(keyword, cc.Details.Number, at, bucket)
 }
 
-// GenerateCueIDWithSeed generates a unique CueID hash with optional random seed
-func GenerateCueIDWithSeed(contestType string, details map[string]interface{}, timestamp string, seed int64) string {
-	// Create a deterministic hash based on contestType, details, timestamp, and seed
+// GenerateCueID generates a unique CueID hash for deduplication
+func GenerateCueID(contestType string, details CueDetails, timestamp string) string {
+	// Create a deterministic hash based on contestType, details, and timestamp
 	h := sha256.New()
 	h.Write([]byte(contestType))
 	h.Write([]byte(timestamp))
-	h.Write([]byte(fmt.Sprintf("%d", seed)))
 
 	// Add details to hash in a deterministic way by sorting keys
-	if details != nil {
-		keys := make([]string, 0, len(details))
-		for key := range details {
-			keys = append(keys, key)
-		}
-		sort.Strings(keys)
+	fields := details.Map()
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 
-		for _, key := range keys {
-			h.Write([]byte(key))
-			h.Write([]byte(fmt.Sprintf("%v", details[key])))
-		}
+	for _, key := range keys {
+		h.Write([]byte(key))
+		h.Write([]byte(fmt.Sprintf("%v", fields[key])))
 	}
 
 	return fmt.Sprintf("%x", h.Sum(nil))[:16] // Use first 16 characters for readability
@@ -98,9 +155,5 @@ func (cc *ContestCue) Validate() error {
 		return fmt.Errorf("Timestamp cannot be empty")
 	}
 
-	if cc.Details == nil {
-		return fmt.Errorf("Details cannot be nil")
-	}
-
-	return nil
+	return cc.Details.validate()
 }
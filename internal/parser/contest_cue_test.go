@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -14,9 +15,9 @@ func TestContestCue_Validate(t *testing.T) {
 			CueID:       "test-cue-123",
 			ContestType: "POTA",
 			Timestamp:   "2023-01-01T12:00:00Z",
-			Details: map[string]interface{}{
-				"keyword": "POTA",
-				"number":  "1234",
+			Details: CueDetails{
+				Keyword: "POTA",
+				Number:  "1234",
 			},
 		}
 
@@ -33,9 +34,9 @@ func TestContestCue_Validate(t *testing.T) {
 			CueID:       "",
 			ContestType: "POTA",
 			Timestamp:   "2023-01-01T12:00:00Z",
-			Details: map[string]interface{}{
-				"keyword": "POTA",
-				"number":  "1234",
+			Details: CueDetails{
+				Keyword: "POTA",
+				Number:  "1234",
 			},
 		}
 
@@ -53,9 +54,9 @@ func TestContestCue_Validate(t *testing.T) {
 			CueID:       "test-cue-123",
 			ContestType: "",
 			Timestamp:   "2023-01-01T12:00:00Z",
-			Details: map[string]interface{}{
-				"keyword": "POTA",
-				"number":  "1234",
+			Details: CueDetails{
+				Keyword: "POTA",
+				Number:  "1234",
 			},
 		}
 
@@ -73,9 +74,9 @@ func TestContestCue_Validate(t *testing.T) {
 			CueID:       "test-cue-123",
 			ContestType: "POTA",
 			Timestamp:   "",
-			Details: map[string]interface{}{
-				"keyword": "POTA",
-				"number":  "1234",
+			Details: CueDetails{
+				Keyword: "POTA",
+				Number:  "1234",
 			},
 		}
 
@@ -87,21 +88,21 @@ func TestContestCue_Validate(t *testing.T) {
 		assert.Contains(t, err.Error(), "Timestamp cannot be empty")
 	})
 
-	t.Run("should return error for nil Details", func(t *testing.T) {
+	t.Run("should return error for Details without a number", func(t *testing.T) {
 		// Arrange
 		cue := &ContestCue{
 			CueID:       "test-cue-123",
 			ContestType: "POTA",
 			Timestamp:   "2023-01-01T12:00:00Z",
-			Details:     nil,
+			Details:     CueDetails{Keyword: "POTA"},
 		}
 
 		// Act
 		err := cue.Validate()
 
 		// Assert
-		assert.Error(t, err, "should return error for nil Details")
-		assert.Contains(t, err.Error(), "Details cannot be nil")
+		assert.Error(t, err, "should return error for Details without a number")
+		assert.Contains(t, err.Error(), "Details.Number cannot be empty")
 	})
 }
 
@@ -109,9 +110,9 @@ func TestNewContestCue(t *testing.T) {
 	t.Run("should create ContestCue with generated CueID and current timestamp", func(t *testing.T) {
 		// Arrange
 		contestType := "POTA"
-		details := map[string]interface{}{
-			"keyword": "POTA",
-			"number":  "1234",
+		details := CueDetails{
+			Keyword: "POTA",
+			Number:  "1234",
 		}
 
 		// Act
@@ -130,6 +131,8 @@ func TestNewContestCue(t *testing.T) {
 		// Verify CueID is unique for multiple calls
 		cue2 := NewContestCue(contestType, details)
 		assert.NotEqual(t, cue.CueID, cue2.CueID, "should generate unique CueIDs")
+		assert.Regexp(t, uuidv7Pattern, cue.CueID, "should generate a UUIDv7 CueID")
+		assert.Len(t, cue.ContentHash, 16, "should hash the keyword and number")
 	})
 }
 
@@ -137,9 +140,9 @@ func TestGenerateCueID(t *testing.T) {
 	t.Run("should generate unique CueID based on input parameters", func(t *testing.T) {
 		// Arrange
 		contestType := "POTA"
-		details := map[string]interface{}{
-			"keyword": "POTA",
-			"number":  "1234",
+		details := CueDetails{
+			Keyword: "POTA",
+			Number:  "1234",
 		}
 		timestamp := "2023-01-01T12:00:00Z"
 
@@ -159,3 +162,84 @@ func TestGenerateCueID(t *testing.T) {
 		assert.NotEqual(t, cueID1, cueID4, "should generate different CueID for different timestamp")
 	})
 }
+
+func TestNewCueTiming(t *testing.T) {
+	t.Run("should compute latency from audio capture to emission", func(t *testing.T) {
+		// Arrange
+		emittedAt := time.Date(2024, 5, 1, 12, 0, 10, 0, time.UTC)
+		capturedAt := emittedAt.Add(-7500 * time.Millisecond)
+		transcribedAt := emittedAt.Add(-500 * time.Millisecond)
+
+		// Act
+		timing := NewCueTiming(capturedAt, transcribedAt, emittedAt)
+
+		// Assert
+		assert.Equal(t, capturedAt, timing.AudioCapturedAt)
+		assert.Equal(t, transcribedAt, timing.TranscriptionCompletedAt)
+		assert.Equal(t, emittedAt, timing.EmittedAt)
+		assert.Equal(t, int64(7500), timing.LatencyMS)
+	})
+
+	t.Run("should omit unknown capture and transcription times", func(t *testing.T) {
+		// Arrange
+		cue := NewContestCue("POTA", CueDetails{Number: "1234"})
+		cue.Timing = NewCueTiming(time.Time{}, time.Time{}, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+
+		// Act
+		data, err := json.Marshal(cue.Timing)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"emitted_at":"2024-05-01T12:00:00Z","latency_ms":0}`, string(data))
+	})
+}
+
+func TestContestCue_Age(t *testing.T) {
+	t.Run("should measure age from audio capture", func(t *testing.T) {
+		now := time.Now()
+		cue := &ContestCue{Timing: NewCueTiming(now.Add(-3*time.Second), time.Time{}, now.Add(-time.Second))}
+
+		assert.Equal(t, 3*time.Second, cue.Age(now).Round(time.Millisecond))
+	})
+
+	t.Run("should fall back to emission time and handle missing timing", func(t *testing.T) {
+		now := time.Now()
+		cue := &ContestCue{Timing: NewCueTiming(time.Time{}, time.Time{}, now.Add(-time.Second))}
+
+		assert.Equal(t, time.Second, cue.Age(now).Round(time.Millisecond))
+		assert.Equal(t, time.Duration(0), (&ContestCue{}).Age(now))
+	})
+}
+
+func TestContestCue_HeardAt(t *testing.T) {
+	t.Run("should prefer when the shortcode was spoken", func(t *testing.T) {
+		now := time.Now().UTC()
+		cue := &ContestCue{Timing: NewCueTiming(now.Add(-5*time.Second), time.Time{}, now)}
+		assert.Equal(t, now.Add(-5*time.Second), cue.HeardAt())
+
+		cue.Timing.ShortcodeSpokenAt = now.Add(-3 * time.Second)
+
+		assert.Equal(t, now.Add(-3*time.Second), cue.HeardAt())
+	})
+
+	t.Run("should fall back to emission time and handle missing timing", func(t *testing.T) {
+		now := time.Now().UTC()
+		cue := &ContestCue{Timing: NewCueTiming(time.Time{}, time.Time{}, now)}
+
+		assert.Equal(t, now, cue.HeardAt())
+		assert.True(t, (&ContestCue{}).HeardAt().IsZero())
+	})
+}
+
+func TestContestCue_FormatTimestamp(t *testing.T) {
+	t.Run("should show the timestamp in the given zone with its abbreviation", func(t *testing.T) {
+		cue := &ContestCue{Timestamp: "2026-10-17T19:03:05Z"}
+
+		assert.Equal(t, "2026-10-17 14:03:05 CDT", cue.FormatTimestamp(time.FixedZone("CDT", -5*60*60)))
+	})
+
+	t.Run("should leave the timestamp unchanged without a zone or when unparsable", func(t *testing.T) {
+		assert.Equal(t, "2026-10-17T19:03:05Z", (&ContestCue{Timestamp: "2026-10-17T19:03:05Z"}).FormatTimestamp(nil))
+		assert.Equal(t, "soon", (&ContestCue{Timestamp: "soon"}).FormatTimestamp(time.UTC))
+	})
+}
@@ -1,32 +1,70 @@
 package parser
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
+	"unicode"
 
 	"go.uber.org/zap"
 
 	"radiocontestwinner/internal/buffer"
+	"radiocontestwinner/internal/program"
+	"radiocontestwinner/internal/transcriber"
 )
 
 // ContestParser filters BufferedContext based on number allowlist
 type ContestParser struct {
 	allowlist []string
 	logger    *zap.Logger
-	// Pre-compiled regexes for performance
-	punctuationRegex *regexp.Regexp
-	letterRegex      *regexp.Regexp
+	// Matches contestPattern over the tokens of a text
+	matcher *contestMatcher
+	// Normalization chain applied to text before pattern matching
+	normalizer *Normalizer
+	// Optional ASR correction dictionary applied before the normalization chain
+	substitutions *SubstitutionDictionary
+	// Time window cue content hashes are bucketed into
+	cueHashBucket time.Duration
+	// Optional filter rejecting implausible keywords; nil accepts every keyword
+	keywordFilter *KeywordFilter
+	// Compiled allowlist accepting exact numbers and wildcard patterns
+	allowlistMatcher *Allowlist
+	// Optional map of keyword variants to canonical contest names; nil uses keywords as heard
+	canonicalizer *KeywordCanonicalizer
+	// Optional station program schedule naming the show a cue was heard in
+	programs *program.Schedule
+	// Contexts transcribed below this confidence produce no cues (0 disables the check); the
+	// show on the air can lower or raise it
+	minConfidence float64
+	// Channels Start reads buffered contexts from and sends contest cues to
+	inputCh  <-chan buffer.BufferedContext
+	outputCh chan<- ContestCue
+	running  atomic.Bool
 }
 
+// contestPattern matches "Text [KEYWORD] to [NUMBER]"
+// Case-insensitive matching for "Text" and "to", but preserve case for keyword. SetPatternWords
+// replaces the trigger words, e.g. for Spanish-language stations.
+const contestPattern = `(?i)\btext\s+(\S+)\s+to\s+(\d+)\b`
+
+// defaultMatcher matches the default trigger words and is shared by every parser
+var defaultMatcher, _ = newContestMatcher(PatternWords{Text: []string{"text"}, To: []string{"to"}})
+
 // NewContestParser creates a new ContestParser with the given allowlist
 func NewContestParser(allowlist []string) *ContestParser {
-	return &ContestParser{
+	cp := &ContestParser{
 		allowlist:        allowlist,
+		allowlistMatcher: NewAllowlist(allowlist),
 		logger:           zap.NewNop(), // Default to no-op logger
-		punctuationRegex: regexp.MustCompile(`[^\w]`),
-		letterRegex:      regexp.MustCompile(`[A-Za-z]`),
+		matcher:          defaultMatcher,
 	}
+	cp.normalizer, _ = NewNormalizer(DefaultNormalizationSteps, nil, cp, cp.logger)
+	return cp
 }
 
 // NewContestParserWithLogger creates a new ContestParser with the given allowlist and logger
@@ -34,12 +72,135 @@ func NewContestParserWithLogger(allowlist []string, logger *zap.Logger) *Contest
 	if logger == nil {
 		logger = zap.NewNop() // Use no-op logger if nil is passed
 	}
-	return &ContestParser{
+	cp := &ContestParser{
 		allowlist:        allowlist,
+		allowlistMatcher: NewAllowlist(allowlist),
 		logger:           logger,
-		punctuationRegex: regexp.MustCompile(`[^\w]`),
-		letterRegex:      regexp.MustCompile(`[A-Za-z]`),
+		matcher:          defaultMatcher,
+	}
+	cp.normalizer, _ = NewNormalizer(DefaultNormalizationSteps, nil, cp, logger)
+	return cp
+}
+
+// ConfigureNormalization replaces the normalization chain with the named steps in order
+func (cp *ContestParser) ConfigureNormalization(steps []string, homophones map[string]string) error {
+	normalizer, err := NewNormalizer(steps, homophones, cp, cp.logger)
+	if err != nil {
+		return err
+	}
+	cp.normalizer = normalizer
+	cp.logger.Info("configured text normalization chain",
+		zap.Strings("steps", normalizer.Steps()))
+	return nil
+}
+
+// SetSubstitutions sets the dictionary of ASR corrections applied before normalization
+func (cp *ContestParser) SetSubstitutions(dict *SubstitutionDictionary) {
+	cp.substitutions = dict
+}
+
+// SetAllowlistEntries replaces the allowlist with entries carrying labels and provenance, which
+// cues record alongside the matching entry. Entries with an active window only accept numbers
+// heard during it, in station-local time in location (nil uses the local zone).
+func (cp *ContestParser) SetAllowlistEntries(entries []AllowlistEntry, location *time.Location) {
+	allowlist := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		allowlist = append(allowlist, entry.Value)
+	}
+	cp.allowlist = allowlist
+	cp.allowlistMatcher = NewAllowlistFromEntries(entries, location)
+}
+
+// SetCueHashBucket sets the time window cue content hashes are bucketed into
+func (cp *ContestParser) SetCueHashBucket(bucket time.Duration) {
+	cp.cueHashBucket = bucket
+}
+
+// SetKeywordFilter sets the filter rejecting implausible pattern keywords (nil accepts every keyword)
+func (cp *ContestParser) SetKeywordFilter(filter *KeywordFilter) {
+	cp.keywordFilter = filter
+}
+
+// SetKeywordCanonicalizer sets the map of keyword variants to canonical contest names (nil uses
+// the keyword as heard for the contest type)
+func (cp *ContestParser) SetKeywordCanonicalizer(canonicalizer *KeywordCanonicalizer) {
+	cp.canonicalizer = canonicalizer
+}
+
+// SetPatternWords replaces the trigger words of the contest pattern, e.g. "Texto" and "al" for a
+// Spanish-language station
+func (cp *ContestParser) SetPatternWords(words PatternWords) error {
+	matcher, err := newContestMatcher(words)
+	if err != nil {
+		return fmt.Errorf("invalid contest pattern words: %w", err)
+	}
+	cp.matcher = matcher
+	cp.logger.Info("configured contest pattern trigger words",
+		zap.Strings("text_words", words.Text),
+		zap.Strings("to_words", words.To))
+	return nil
+}
+
+// SetProgramSchedule sets the schedule of shows cues are tagged with (nil disables tagging)
+func (cp *ContestParser) SetProgramSchedule(schedule *program.Schedule) {
+	cp.programs = schedule
+}
+
+// SetMinConfidence sets the transcription confidence below which contexts produce no cues (0
+// disables the check)
+func (cp *ContestParser) SetMinConfidence(confidence float64) {
+	cp.minConfidence = confidence
+}
+
+// programAt returns the show on the air when context was heard
+func (cp *ContestParser) programAt(context *buffer.BufferedContext) (program.Program, bool) {
+	if cp.programs == nil {
+		return program.Program{}, false
+	}
+	return cp.programs.At(heardAt(context))
+}
+
+// heardAt returns when the audio of context was captured, or now when that is unknown
+func heardAt(context *buffer.BufferedContext) time.Time {
+	if context.CapturedAt.IsZero() {
+		return time.Now()
+	}
+	return context.CapturedAt
+}
+
+// confidentEnough reports whether context was transcribed confidently enough to produce cues
+// during show. Contexts without a confidence score are always accepted.
+func (cp *ContestParser) confidentEnough(context *buffer.BufferedContext, show program.Program) bool {
+	if cp.minConfidence <= 0 || context.Confidence <= 0 {
+		return true
+	}
+	threshold := cp.minConfidence - show.ConfidenceBoost
+	if float64(context.Confidence) >= threshold {
+		return true
+	}
+	cp.logger.Debug("ContestCue creation skipped - low transcription confidence",
+		zap.Strings("trace_ids", context.TraceIDs),
+		zap.Float32("confidence", context.Confidence),
+		zap.Float64("min_confidence", threshold),
+		zap.String("program", show.Name))
+	return false
+}
+
+// Normalize applies substitutions and then the configured normalization chain to text
+func (cp *ContestParser) Normalize(text string) string {
+	if cp.substitutions != nil {
+		text = cp.substitutions.Apply(text)
+	}
+	return cp.normalizer.Normalize(text)
+}
+
+// normalizeTokens normalizes text like Normalize and returns the normalized text's tokens from the
+// same pass, for the pattern matcher
+func (cp *ContestParser) normalizeTokens(text string) (string, []token) {
+	if cp.substitutions != nil {
+		text = cp.substitutions.Apply(text)
 	}
+	return cp.normalizer.normalizeTokens(text, tokenize(text))
 }
 
 // FilterByAllowlist checks if the BufferedContext contains any number from the allowlist
@@ -55,12 +216,11 @@ func (cp *ContestParser) FilterByAllowlist(context *buffer.BufferedContext) bool
 	// Extract numbers from the text
 	numbers := cp.ExtractNumbers(context.Text)
 
-	// Check if any extracted number matches allowlist
+	// Check if any extracted number matches an allowlist entry active when it was heard
+	at := heardAt(context)
 	for _, extractedNum := range numbers {
-		for _, allowedNum := range cp.allowlist {
-			if extractedNum == allowedNum {
-				return true
-			}
+		if _, ok := cp.allowlistMatcher.MatchAt(extractedNum, at); ok {
+			return true
 		}
 	}
 
@@ -73,16 +233,13 @@ func (cp *ContestParser) ExtractNumbers(text string) []string {
 		return []string{}
 	}
 
-	// Regular expression to match numbers (including those with leading zeros)
-	numberRegex := regexp.MustCompile(`\d+`)
-	matches := numberRegex.FindAllString(text, -1)
-
-	return matches
+	// Digit runs keep their leading zeros
+	return numbersIn(tokenize(text))
 }
 
 // MatchContestPattern matches the "Text [KEYWORD] to [NUMBER]" pattern in the given text
 // Returns keyword, number, and whether a valid match was found
-// NOTE: This function also applies spelled word reconstruction before pattern matching
+// NOTE: This function also applies the normalization chain before pattern matching
 func (cp *ContestParser) MatchContestPattern(text string) (keyword, number string, matched bool) {
 	// Log the pattern matching attempt
 	cp.logger.Debug("attempting pattern matching",
@@ -97,120 +254,348 @@ func (cp *ContestParser) MatchContestPattern(text string) (keyword, number strin
 		return "", "", false
 	}
 
-	// Apply spelled-out word reconstruction before pattern matching
+	// Apply the normalization chain before pattern matching
 	originalText := text
-	reconstructedText := cp.ReconstructSpelledWords(originalText)
+	reconstructedText, tokens := cp.normalizeTokens(originalText)
 
 	if reconstructedText != originalText {
-		cp.logger.Debug("applied spelled word reconstruction in MatchContestPattern",
+		cp.logger.Debug("applied text normalization in MatchContestPattern",
 			zap.String("original_text", originalText),
 			zap.String("reconstructed_text", reconstructedText))
 	}
 
-	// Create regex pattern for "Text [KEYWORD] to [NUMBER]"
-	// Case-insensitive matching for "Text" and "to", but preserve case for keyword
-	pattern := `(?i)\btext\s+(\S+)\s+to\s+(\d+)\b`
-	regex := regexp.MustCompile(pattern)
+	return cp.matchNormalizedPattern(originalText, reconstructedText, tokens, time.Now())
+}
 
-	matches := regex.FindStringSubmatch(reconstructedText)
-	if len(matches) < 3 {
-		cp.logger.Debug("pattern matching failed - no regex match",
-			zap.String("pattern", pattern),
-			zap.String("original_text", originalText),
-			zap.String("reconstructed_text", reconstructedText))
+// PatternMatch is one "Text [KEYWORD] to [NUMBER]" occurrence in normalized text
+type PatternMatch struct {
+	Keyword string
+	Number  string
+	Start   int // Byte offset of the match in the normalized text
+	End     int // Byte offset just past the match in the normalized text
+}
+
+// MatchAllContestPatterns returns every valid "Text [KEYWORD] to [NUMBER]" occurrence in text, in
+// order, so back-to-back shortcodes read within one context each produce a cue
+func (cp *ContestParser) MatchAllContestPatterns(text string) []PatternMatch {
+	if text == "" || len(cp.allowlist) == 0 {
+		return nil
+	}
+	normalized, tokens := cp.normalizeTokens(text)
+	return cp.matchAllNormalizedPatterns(text, normalized, tokens, time.Now())
+}
+
+// MatchUnlistedContestPatterns returns every "Text [KEYWORD] to [NUMBER]" occurrence in text whose
+// keyword passes the keyword filter but whose number is not allowlisted, so contests missing from
+// the allowlist can be spotted
+func (cp *ContestParser) MatchUnlistedContestPatterns(text string) []PatternMatch {
+	if text == "" {
+		return nil
+	}
+	_, tokens := cp.normalizeTokens(text)
+
+	var matches []PatternMatch
+	for _, match := range cp.matcher.matchAll(tokens) {
+		if cp.keywordFilter != nil && cp.keywordFilter.Check(match.Keyword) != nil {
+			continue
+		}
+		if _, ok := cp.allowlistMatcher.Match(match.Number); ok {
+			continue
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+// matchNormalizedPattern returns the first valid contest pattern match in already-normalized text
+func (cp *ContestParser) matchNormalizedPattern(originalText, reconstructedText string, tokens []token, at time.Time) (keyword, number string, matched bool) {
+	matches := cp.matchAllNormalizedPatterns(originalText, reconstructedText, tokens, at)
+	if len(matches) == 0 {
 		return "", "", false
 	}
+	return matches[0].Keyword, matches[0].Number, true
+}
 
-	extractedKeyword := matches[1]
-	extractedNumber := matches[2]
+// matchAllNormalizedPatterns matches the contest pattern against already-normalized text and its
+// tokens, keeping matches whose keyword passes the keyword filter and whose number is allowlisted
+// by an entry active at the time the text was heard
+func (cp *ContestParser) matchAllNormalizedPatterns(originalText, reconstructedText string, tokens []token, at time.Time) []PatternMatch {
+	// Match "Text [KEYWORD] to [NUMBER]" over the tokens
+	found := cp.matcher.matchAll(tokens)
+	if len(found) == 0 {
+		cp.logger.Debug("pattern matching failed - no pattern match",
+			zap.String("original_text", originalText),
+			zap.String("reconstructed_text", reconstructedText))
+		return nil
+	}
 
-	cp.logger.Debug("pattern regex matched",
-		zap.String("keyword", extractedKeyword),
-		zap.String("number", extractedNumber))
+	var matches []PatternMatch
+	for _, match := range found {
+		if cp.acceptMatch(match, originalText, reconstructedText, at) {
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
 
-	// Validate extracted number against allowlist
-	for _, allowedNum := range cp.allowlist {
-		if extractedNumber == allowedNum {
-			cp.logger.Info("pattern matching successful",
-				zap.String("keyword", extractedKeyword),
-				zap.String("number", extractedNumber),
-				zap.String("original_text", originalText),
-				zap.String("reconstructed_text", reconstructedText))
-			return extractedKeyword, extractedNumber, true
+// acceptMatch checks a pattern match against the keyword filter and the allowlist
+func (cp *ContestParser) acceptMatch(match PatternMatch, originalText, reconstructedText string, at time.Time) bool {
+	cp.logger.Debug("pattern matched",
+		zap.String("keyword", match.Keyword),
+		zap.String("number", match.Number),
+		zap.Int("offset", match.Start))
+
+	// Reject keywords that are too short, too long, or ordinary words
+	if cp.keywordFilter != nil {
+		if err := cp.keywordFilter.Check(match.Keyword); err != nil {
+			cp.logger.Debug("pattern matching failed - keyword rejected",
+				zap.String("keyword", match.Keyword),
+				zap.String("number", match.Number),
+				zap.String("reason", err.Error()))
+			return false
 		}
 	}
 
+	// Validate extracted number against the allowlist entries active when it was heard
+	if allowed, ok := cp.allowlistMatcher.MatchAt(match.Number, at); ok {
+		cp.logger.Info("pattern matching successful",
+			zap.String("keyword", match.Keyword),
+			zap.String("number", match.Number),
+			zap.String("allowlist_entry", allowed.Entry),
+			zap.String("allowlist_match", allowed.Kind),
+			zap.String("original_text", originalText),
+			zap.String("reconstructed_text", reconstructedText))
+		return true
+	}
+
+	if allowed, ok := cp.allowlistMatcher.Match(match.Number); ok {
+		cp.logger.Debug("pattern matching failed - allowlist entry not active",
+			zap.String("keyword", match.Keyword),
+			zap.String("number", match.Number),
+			zap.String("allowlist_entry", allowed.Entry),
+			zap.Time("heard_at", at))
+		return false
+	}
 	cp.logger.Debug("pattern matching failed - number not in allowlist",
-		zap.String("keyword", extractedKeyword),
-		zap.String("number", extractedNumber),
+		zap.String("keyword", match.Keyword),
+		zap.String("number", match.Number),
 		zap.Strings("allowlist", cp.allowlist))
-
-	return "", "", false
+	return false
 }
 
 // CreateContestCue creates a ContestCue from BufferedContext if pattern matches
-// Returns the ContestCue and whether it was successfully created
+// Returns the first ContestCue and whether it was successfully created
 func (cp *ContestParser) CreateContestCue(context *buffer.BufferedContext) (*ContestCue, bool) {
+	cues := cp.CreateContestCues(context)
+	if len(cues) == 0 {
+		return nil, false
+	}
+	return cues[0], true
+}
+
+// CreateContestCues creates a ContestCue for every pattern match in BufferedContext, in the order
+// they were read. Each cue's details record the match offsets within reconstructed_text.
+func (cp *ContestParser) CreateContestCues(context *buffer.BufferedContext) []*ContestCue {
 	if context == nil {
 		cp.logger.Warn("attempted to create ContestCue from nil context")
-		return nil, false
+		return nil
 	}
 
 	cp.logger.Debug("creating ContestCue from context",
+		zap.Strings("trace_ids", context.TraceIDs),
 		zap.String("text", context.Text),
 		zap.Int("start_ms", context.StartMS),
 		zap.Int("end_ms", context.EndMS))
 
-	// Apply spelled-out word reconstruction before pattern matching
+	// Apply the normalization chain before pattern matching
 	originalText := context.Text
-	reconstructedText := cp.ReconstructSpelledWords(originalText)
+	reconstructedText, tokens := cp.normalizeTokens(originalText)
 
 	if reconstructedText != originalText {
-		cp.logger.Debug("applied spelled word reconstruction",
+		cp.logger.Debug("applied text normalization",
 			zap.String("original_text", originalText),
 			zap.String("reconstructed_text", reconstructedText))
 	}
 
-	// Try to match the contest pattern on reconstructed text
-	keyword, number, matched := cp.MatchContestPattern(reconstructedText)
-	if !matched {
+	if reconstructedText == "" || len(cp.allowlist) == 0 {
+		cp.logger.Debug("ContestCue creation failed - empty text or allowlist")
+		return nil
+	}
+
+	show, onAir := cp.programAt(context)
+	if !cp.confidentEnough(context, show) {
+		return nil
+	}
+
+	// Match the contest pattern on the normalized text without normalizing twice
+	matches := cp.matchAllNormalizedPatterns(originalText, reconstructedText, tokens, heardAt(context))
+	if len(matches) == 0 {
 		cp.logger.Debug("ContestCue creation failed - no pattern match",
 			zap.String("original_text", originalText),
 			zap.String("reconstructed_text", reconstructedText))
-		return nil, false
+		return nil
+	}
+
+	var cues []*ContestCue
+	for i, match := range matches {
+		if cue, ok := cp.newCueForMatch(context, match, i, originalText, reconstructedText); ok {
+			if onAir {
+				cue.Details.Set("program_name", show.Name)
+				if show.ContestHeavy {
+					cue.Details.Set("program_contest_heavy", true)
+				}
+			}
+			cues = append(cues, cue)
+		}
 	}
+	return cues
+}
 
-	// Create details map with extracted information
-	details := map[string]interface{}{
-		"keyword":            keyword,
-		"number":             number,
-		"original_text":      originalText,
-		"reconstructed_text": reconstructedText,
-		"start_ms":           context.StartMS,
-		"end_ms":             context.EndMS,
+// newCueForMatch builds and validates the ContestCue for one pattern match
+func (cp *ContestParser) newCueForMatch(context *buffer.BufferedContext, match PatternMatch, index int, originalText, reconstructedText string) (*ContestCue, bool) {
+	// Record the extracted information and where in reconstructed_text it was matched
+	details := CueDetails{
+		Keyword:           match.Keyword,
+		Number:            match.Number,
+		OriginalText:      originalText,
+		ReconstructedText: reconstructedText,
+		StartMS:           context.StartMS,
+		EndMS:             context.EndMS,
+	}
+	details.Set("match_index", index)
+	details.Set("match_start", match.Start)
+	details.Set("match_end", match.End)
+	if context.CorrectedFrom != "" {
+		details.Set("corrected_from", context.CorrectedFrom)
+	}
+	// Record which allowlist entry accepted the number, whether it was a wildcard, and where the
+	// entry was configured
+	if allowed, ok := cp.allowlistMatcher.MatchAt(match.Number, heardAt(context)); ok {
+		details.Set("allowlist_entry", allowed.Entry)
+		details.Set("allowlist_match", allowed.Kind)
+		if allowed.Label != "" {
+			details.Set("allowlist_label", allowed.Label)
+		}
+		if allowed.Source != "" {
+			details.Set("allowlist_source", allowed.Source)
+		}
+		if !allowed.Modified.IsZero() {
+			details.Set("allowlist_modified", allowed.Modified.UTC().Format(time.RFC3339))
+		}
 	}
 
-	// Create ContestCue with the keyword as the contest type
-	cue := NewContestCue(keyword, details)
+	// Create ContestCue with the keyword, or the contest it is a variant of, as the contest type
+	contestType := match.Keyword
+	if name, ok := cp.canonicalizer.Canonical(match.Keyword); ok {
+		contestType = name
+		details.Set("contest_name", name)
+	}
+	cue := NewContestCue(contestType, details)
+	cue.Timing = NewCueTiming(context.CapturedAt, context.TranscribedAt, time.Now())
+	cue.Timing.ShortcodeSpokenAt = shortcodeSpokenAt(context.Words, match)
+	cue.TraceIDs = context.TraceIDs
+
+	// Bucket the content hash by when the audio was heard, so instances with different latency agree
+	hashTime := context.CapturedAt
+	if hashTime.IsZero() {
+		hashTime = cue.Timing.EmittedAt
+	}
+	cue.SetContentHash(hashTime, cp.cueHashBucket)
 
 	// Validate the created cue
 	if err := cue.Validate(); err != nil {
 		cp.logger.Error("ContestCue validation failed",
 			zap.Error(fmt.Errorf("failed to validate ContestCue: %w", err)),
 			zap.String("cue_id", cue.CueID),
+			zap.Strings("trace_ids", cue.TraceIDs),
 			zap.String("contest_type", cue.ContestType))
 		return nil, false
 	}
 
 	cp.logger.Info("ContestCue created successfully",
 		zap.String("cue_id", cue.CueID),
+		zap.Strings("trace_ids", cue.TraceIDs),
+		zap.String("content_hash", cue.ContentHash),
 		zap.String("contest_type", cue.ContestType),
-		zap.String("keyword", keyword),
-		zap.String("number", number))
+		zap.String("keyword", match.Keyword),
+		zap.String("number", match.Number),
+		zap.Int("match_index", index),
+		zap.Int64("latency_ms", cue.Timing.LatencyMS))
 
 	return cue, true
 }
 
+// shortcodeSpokenAt returns when the match's number was spoken, from the first word spelling it
+// after the keyword, or when the keyword was spoken if the number is not among the words (as
+// when it was spoken digit by digit). It is zero without word timestamps.
+func shortcodeSpokenAt(words []transcriber.Word, match PatternMatch) time.Time {
+	keywordAt := -1
+	for i, word := range words {
+		if strings.EqualFold(alphanumeric(word.Text), alphanumeric(match.Keyword)) {
+			keywordAt = i
+			break
+		}
+	}
+	for _, word := range words[max(keywordAt, 0):] {
+		if alphanumeric(word.Text) == match.Number && !word.CapturedAt.IsZero() {
+			return word.CapturedAt.UTC()
+		}
+	}
+	if keywordAt >= 0 && !words[keywordAt].CapturedAt.IsZero() {
+		return words[keywordAt].CapturedAt.UTC()
+	}
+	return time.Time{}
+}
+
+// alphanumeric returns text without the characters that are neither letters nor digits
+func alphanumeric(text string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, text)
+}
+
+// SetChannels sets the channels Start reads buffered contexts from and sends contest cues to
+func (cp *ContestParser) SetChannels(inputCh <-chan buffer.BufferedContext, outputCh chan<- ContestCue) {
+	cp.inputCh = inputCh
+	cp.outputCh = outputCh
+}
+
+// Name identifies the contest parser among the application's components
+func (cp *ContestParser) Name() string {
+	return "contest_parser"
+}
+
+// Start matches contest patterns in the buffered contexts from the input channel set by
+// SetChannels until that channel is closed
+func (cp *ContestParser) Start(ctx context.Context) error {
+	if cp.inputCh == nil || cp.outputCh == nil {
+		return fmt.Errorf("contest parser channels not set")
+	}
+
+	cp.running.Store(true)
+	go func() {
+		defer cp.running.Store(false)
+		cp.ProcessBufferedContextWithPatternMatching(cp.inputCh, cp.outputCh)
+	}()
+	return nil
+}
+
+// Stop returns immediately; processing ends once the input channel is closed
+func (cp *ContestParser) Stop(ctx context.Context) error {
+	return nil
+}
+
+// Healthy returns an error unless buffered contexts are being processed
+func (cp *ContestParser) Healthy() error {
+	if !cp.running.Load() {
+		return errors.New("contest parser not running")
+	}
+	return nil
+}
+
 // ProcessBufferedContextWithPatternMatching processes BufferedContext stream and outputs ContestCue when patterns match
 func (cp *ContestParser) ProcessBufferedContextWithPatternMatching(inputCh <-chan buffer.BufferedContext, outputCh chan<- ContestCue) {
 	defer close(outputCh)
@@ -220,24 +605,34 @@ func (cp *ContestParser) ProcessBufferedContextWithPatternMatching(inputCh <-cha
 	successCount := 0
 
 	for context := range inputCh {
+		// Text is missing before the next context, so it is never matched across the gap
+		if context.IsGap() {
+			cp.logger.Info("transcription gap in buffered context stream",
+				zap.Duration("gap", context.Gap()),
+				zap.Time("since", context.CapturedAt))
+			continue
+		}
+
 		processedCount++
 		cp.logger.Debug("processing buffered context",
 			zap.Int("context_number", processedCount),
+			zap.Strings("trace_ids", context.TraceIDs),
 			zap.String("text", context.Text))
 
-		// Try to create ContestCue from context (includes allowlist filtering and pattern matching)
-		cue, created := cp.CreateContestCue(&context)
-		if created {
+		// Create a ContestCue per pattern match (includes allowlist filtering and pattern matching)
+		for _, cue := range cp.CreateContestCues(&context) {
 			successCount++
 			select {
 			case outputCh <- *cue:
 				cp.logger.Debug("ContestCue sent to output channel",
 					zap.String("cue_id", cue.CueID),
+					zap.Strings("trace_ids", cue.TraceIDs),
 					zap.Int("processed_count", processedCount),
 					zap.Int("success_count", successCount))
 			default:
 				cp.logger.Warn("output channel full, skipping ContestCue",
 					zap.String("cue_id", cue.CueID),
+					zap.Strings("trace_ids", cue.TraceIDs),
 					zap.String("contest_type", cue.ContestType))
 			}
 		}
@@ -264,109 +659,181 @@ func (cp *ContestParser) ProcessBufferedContext(inputCh <-chan buffer.BufferedCo
 	}
 }
 
-// DetectLetterSequences identifies consecutive single letters in text that could be spelled-out words
-// Returns slice of normalized letter sequences (minimum 3 letters)
-func (cp *ContestParser) DetectLetterSequences(text string) []string {
-	if text == "" {
-		return []string{}
+// spelledCharacter returns the character a spelled-out token stands for: a single letter, a single
+// digit, or a digit word such as "three". Letters are upper-cased.
+func (cp *ContestParser) spelledCharacter(token string) (string, bool) {
+	cleanToken := wordChars(token)
+	if len(cleanToken) == 1 && (isLetterByte(cleanToken[0]) || isDigitByte(cleanToken[0])) {
+		return strings.ToUpper(cleanToken), true
+	}
+	if digit, ok := unitWords[strings.ToLower(cleanToken)]; ok {
+		return strconv.Itoa(digit), true
 	}
+	return "", false
+}
 
-	cp.logger.Debug("detecting letter sequences in text",
-		zap.String("text", text))
+// trimSpelledSequence drops digits from both ends of a run of spelled characters so that digits
+// are only kept inside a word (as in call signs like "W 9 X Y Z"), leaving a spoken phone number
+// after a spelled keyword alone. Returns nil when fewer than 3 characters remain.
+func (cp *ContestParser) trimSpelledSequence(tokens []string) []string {
+	start, end := cp.trimSpelledRange(tokens)
+	if end-start < 3 {
+		return nil
+	}
+	return tokens[start:end]
+}
 
-	// Scan word by word to find sequences of single letters
-	words := strings.Fields(text)
-	var currentSequence []string
-	var sequences []string
+// trimSpelledRange returns the part of a run of spelled characters trimSpelledSequence keeps
+func (cp *ContestParser) trimSpelledRange(tokens []string) (start, end int) {
+	start, end = 0, len(tokens)
+	for start < end && !cp.isSpelledLetter(tokens[start]) {
+		start++
+	}
+	for end > start && !cp.isSpelledLetter(tokens[end-1]) {
+		end--
+	}
+	return start, end
+}
+
+// isSpelledLetter reports whether a spelled-out token is a letter rather than a digit
+func (cp *ContestParser) isSpelledLetter(token string) bool {
+	char, ok := cp.spelledCharacter(token)
+	return ok && isLetterByte(char[0])
+}
+
+// spelledSequence is a word spelled out one character at a time, either across tokens ("C A S
+// H") or within one hyphenated token ("C-A-S-H")
+type spelledSequence struct {
+	parts       []string // The characters without punctuation, e.g. "C" or "one"
+	offsets     []int    // Byte offset of each part in the text; nil when a part is split by punctuation
+	first, last int      // Indexes of the first and last tokens of the spelling
+}
+
+// String returns the parts separated by spaces, e.g. "C A S H"
+func (s spelledSequence) String() string {
+	return strings.Join(s.parts, " ")
+}
 
-	for _, word := range words {
-		// Clean word from punctuation
-		cleanWord := cp.punctuationRegex.ReplaceAllString(word, "")
-
-		// Check if it's a single letter
-		if len(cleanWord) == 1 && cp.letterRegex.MatchString(cleanWord) {
-			currentSequence = append(currentSequence, cleanWord)
-		} else {
-			// Not a single letter, check if we have a valid sequence
-			if len(currentSequence) >= 3 {
-				sequence := strings.Join(currentSequence, " ")
-				sequences = append(sequences, sequence)
-				cp.logger.Debug("detected letter sequence",
-					zap.String("sequence", sequence),
-					zap.Int("length", len(currentSequence)))
+// span returns where the spelling is in text, from its first character to its last, and whether
+// only hyphens, commas, and whitespace separate its characters so it can be replaced by the word
+func (s spelledSequence) span(text string) (start, end int, ok bool) {
+	if s.offsets == nil {
+		return 0, 0, false
+	}
+	for i := 1; i < len(s.parts); i++ {
+		for _, c := range []byte(text[s.offsets[i-1]+len(s.parts[i-1]) : s.offsets[i]]) {
+			if c != '-' && c != ',' && !isSpaceByte(c) {
+				return 0, 0, false
 			}
-			currentSequence = []string{}
 		}
 	}
+	last := len(s.parts) - 1
+	return s.offsets[0], s.offsets[last] + len(s.parts[last]), true
+}
 
-	// Check final sequence
-	if len(currentSequence) >= 3 {
-		sequence := strings.Join(currentSequence, " ")
-		sequences = append(sequences, sequence)
-		cp.logger.Debug("detected final letter sequence",
-			zap.String("sequence", sequence),
-			zap.Int("length", len(currentSequence)))
+// addPart appends a character written as raw at offset in the text
+func (s *spelledSequence) addPart(raw string, offset int) {
+	part := wordChars(raw)
+	s.parts = append(s.parts, part)
+	if at := strings.Index(raw, part); at >= 0 && s.offsets != nil {
+		s.offsets = append(s.offsets, offset+at)
+	} else {
+		s.offsets = nil
 	}
+}
 
-	cp.logger.Debug("completed letter sequence detection",
-		zap.Int("total_sequences", len(sequences)))
+// spelledSequences finds the words spelled across tokens and within hyphenated tokens
+func (cp *ContestParser) spelledSequences(tokens []token) (spaced, hyphenated []spelledSequence) {
+	runStart := 0
+	endRun := func(runEnd int) {
+		chars := make([]string, 0, runEnd-runStart)
+		for _, t := range tokens[runStart:runEnd] {
+			chars = append(chars, wordChars(t.text))
+		}
+		start, end := cp.trimSpelledRange(chars)
+		if end-start < 3 {
+			return
+		}
+		sequence := spelledSequence{offsets: []int{}, first: runStart + start, last: runStart + end - 1}
+		for _, t := range tokens[sequence.first : sequence.last+1] {
+			sequence.addPart(t.text, t.start)
+		}
+		spaced = append(spaced, sequence)
+	}
 
-	return sequences
+	for i, t := range tokens {
+		if _, ok := cp.spelledCharacter(t.text); ok {
+			continue
+		}
+		endRun(i)
+		runStart = i + 1
+		if sequence, ok := cp.hyphenatedSequence(t, i); ok {
+			hyphenated = append(hyphenated, sequence)
+		}
+	}
+	endRun(len(tokens))
+	return spaced, hyphenated
 }
 
-// detectHyphenSequences finds hyphen-separated letter sequences in the text
-// Returns slice of space-separated sequences for consistency with DetectLetterSequences
-func (cp *ContestParser) detectHyphenSequences(text string) []string {
-	var sequences []string
+// hyphenatedSequence returns the hyphen-separated characters of a token at index, optionally mixed
+// with digits inside the word ("W-9-X-Y-Z"). The token must spell at least 3 characters starting
+// and ending with letters.
+func (cp *ContestParser) hyphenatedSequence(t token, index int) (spelledSequence, bool) {
+	if strings.Count(t.text, "-") < 2 {
+		return spelledSequence{}, false
+	}
 
-	// Split text into words and check each word for hyphen-separated letters
-	words := strings.Fields(text)
-	for _, word := range words {
-		sequence := cp.detectHyphenatedSequence(word)
-		if sequence != "" {
-			sequences = append(sequences, sequence)
+	sequence := spelledSequence{offsets: []int{}, first: index, last: index}
+	offset := t.start
+	for _, part := range strings.Split(t.text, "-") {
+		if _, ok := cp.spelledCharacter(part); !ok {
+			return spelledSequence{}, false
 		}
+		sequence.addPart(part, offset)
+		offset += len(part) + 1
 	}
 
-	return sequences
+	if start, end := cp.trimSpelledRange(sequence.parts); start != 0 || end != len(sequence.parts) {
+		return spelledSequence{}, false
+	}
+	return sequence, true
 }
 
-// detectHyphenatedSequence checks if a word contains hyphen-separated single letters
+// detectHyphenatedSequence checks if a word contains hyphen-separated single letters, optionally
+// mixed with digits inside the word ("W-9-X-Y-Z").
 // Returns the sequence in space-separated format, or empty string if not a valid sequence
 func (cp *ContestParser) detectHyphenatedSequence(word string) string {
-	// Check if the word contains hyphens
-	if !strings.Contains(word, "-") {
-		return ""
+	if sequence, ok := cp.hyphenatedSequence(token{text: word}, 0); ok {
+		return sequence.String()
 	}
+	return ""
+}
 
-	// Split by hyphens
-	parts := strings.Split(word, "-")
-	if len(parts) < 3 {
-		// Need at least 3 letters for a valid sequence
-		return ""
+// DetectLetterSequences identifies consecutive single letters in text that could be spelled-out words.
+// Letters may be mixed with single digits or digit words inside the word ("K one two three F").
+// Returns slice of normalized letter sequences (minimum 3 characters)
+func (cp *ContestParser) DetectLetterSequences(text string) []string {
+	if text == "" {
+		return []string{}
 	}
 
-	var letters []string
-	for _, part := range parts {
-		// Clean each part from punctuation and check if it's a single letter
-		cleanPart := cp.punctuationRegex.ReplaceAllString(part, "")
-		if len(cleanPart) == 1 && cp.letterRegex.MatchString(cleanPart) {
-			letters = append(letters, cleanPart)
-		} else {
-			// Not a single letter, this is not a valid hyphenated sequence
-			return ""
-		}
+	spaced, _ := cp.spelledSequences(tokenize(text))
+	var sequences []string
+	for _, sequence := range spaced {
+		sequences = append(sequences, sequence.String())
+		cp.logger.Debug("detected letter sequence",
+			zap.String("sequence", sequence.String()),
+			zap.Int("length", len(sequence.parts)))
 	}
 
-	// Only return if we have at least 3 valid letters
-	if len(letters) >= 3 {
-		return strings.Join(letters, " ")
-	}
+	cp.logger.Debug("completed letter sequence detection",
+		zap.Int("total_sequences", len(sequences)))
 
-	return ""
+	return sequences
 }
 
-// ReconstructWord combines a letter sequence into a single word with proper case normalization
+// ReconstructWord combines a letter sequence into a single word with proper case normalization.
+// Digits and digit words in the sequence become digits ("K one two three F" -> "K123F").
 func (cp *ContestParser) ReconstructWord(sequence string) string {
 	if sequence == "" {
 		return ""
@@ -380,10 +847,9 @@ func (cp *ContestParser) ReconstructWord(sequence string) string {
 	var letters []string
 
 	for _, part := range parts {
-		// Remove any punctuation and get just the letter
-		cleanPart := cp.punctuationRegex.ReplaceAllString(part, "")
-		if len(cleanPart) == 1 && cp.letterRegex.MatchString(cleanPart) {
-			letters = append(letters, strings.ToUpper(cleanPart))
+		// Remove any punctuation and get just the letter or digit
+		if char, ok := cp.spelledCharacter(part); ok {
+			letters = append(letters, char)
 		}
 	}
 
@@ -398,66 +864,71 @@ func (cp *ContestParser) ReconstructWord(sequence string) string {
 
 // ReconstructSpelledWords processes text to find and replace spelled-out letter sequences with reconstructed words
 func (cp *ContestParser) ReconstructSpelledWords(text string) string {
+	result, _ := cp.reconstructSpelledTokens(text, tokenize(text))
+	return result
+}
+
+// reconstructSpelledTokens replaces the spelled-out words in text, given as its tokens, and returns
+// the reconstructed text with its tokens. Each spelling is replaced where it was found, so the
+// tokens of the result are updated in place rather than by tokenizing it again.
+func (cp *ContestParser) reconstructSpelledTokens(text string, tokens []token) (string, []token) {
 	if text == "" {
-		return text
+		return text, tokens
+	}
+
+	spaced, hyphenated := cp.spelledSequences(tokens)
+	if len(spaced) == 0 && len(hyphenated) == 0 {
+		return text, tokens
 	}
 
 	cp.logger.Debug("reconstructing spelled words in text",
 		zap.String("original_text", text))
 
-	// Find all letter sequences (both space-separated and hyphen-separated)
-	spaceSequences := cp.DetectLetterSequences(text)
-	hyphenSequences := cp.detectHyphenSequences(text)
-
-	// Combine both types of sequences
-	sequences := append(spaceSequences, hyphenSequences...)
+	// Sequences never share tokens: hyphenated tokens are not spelled characters themselves
+	sequences := append(spaced, hyphenated...)
+	sort.Slice(sequences, func(i, j int) bool { return sequences[i].first < sequences[j].first })
 
-	if len(sequences) == 0 {
-		cp.logger.Debug("no letter sequences found for reconstruction")
-		return text
-	}
-
-	result := text
-
-	// Replace each sequence with reconstructed word
+	var result strings.Builder
+	result.Grow(len(text))
+	reconstructed := make([]token, 0, len(tokens))
+	copied, next := 0, 0 // Bytes of text and tokens already carried over to the result
 	for _, sequence := range sequences {
-		word := cp.ReconstructWord(sequence)
-		if word != "" {
-			// Create pattern to match the sequence in text (handle various spacing/punctuation including hyphens)
-			// Split the sequence into individual letters
-			letters := strings.Fields(sequence)
-			if len(letters) > 0 {
-				// Build a pattern that matches the letters with flexible separators
-				var patternParts []string
-				for i, letter := range letters {
-					// Escape each letter in case it has special regex meaning
-					escapedLetter := regexp.QuoteMeta(letter)
-					patternParts = append(patternParts, escapedLetter)
-
-					// Add separator pattern between letters (but not after the last one)
-					if i < len(letters)-1 {
-						// Match spaces, hyphens, commas, and combinations thereof
-						patternParts = append(patternParts, `\s*[-,\s]*\s*`)
-					}
-				}
-
-				pattern := `\b` + strings.Join(patternParts, "") + `\b`
+		start, end, ok := sequence.span(text)
+		if !ok {
+			continue
+		}
+		word := cp.ReconstructWord(sequence.String())
 
-				regex := regexp.MustCompile(pattern)
-				if regex.MatchString(result) {
-					result = regex.ReplaceAllString(result, word)
-					cp.logger.Debug("replaced spelled sequence with word",
-						zap.String("sequence", sequence),
-						zap.String("word", word),
-						zap.String("pattern", pattern))
-				}
-			}
+		shift := result.Len() - copied
+		for _, t := range tokens[next:sequence.first] {
+			reconstructed = append(reconstructed, token{text: t.text, start: t.start + shift})
 		}
+		first, last := tokens[sequence.first], tokens[sequence.last]
+		result.WriteString(text[copied:start])
+		result.WriteString(word)
+		reconstructed = append(reconstructed, token{
+			text:  first.text[:start-first.start] + word + last.text[end-last.start:],
+			start: first.start + shift,
+		})
+		copied, next = end, sequence.last+1
+
+		cp.logger.Debug("replaced spelled sequence with word",
+			zap.String("sequence", sequence.String()),
+			zap.String("word", word))
+	}
+	if next == 0 {
+		return text, tokens
 	}
 
+	shift := result.Len() - copied
+	for _, t := range tokens[next:] {
+		reconstructed = append(reconstructed, token{text: t.text, start: t.start + shift})
+	}
+	result.WriteString(text[copied:])
+
 	cp.logger.Debug("completed spelled word reconstruction",
 		zap.String("original_text", text),
-		zap.String("reconstructed_text", result))
+		zap.String("reconstructed_text", result.String()))
 
-	return result
+	return result.String(), reconstructed
 }
@@ -1,12 +1,17 @@
 package parser
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	"radiocontestwinner/internal/buffer"
+	"radiocontestwinner/internal/program"
+	"radiocontestwinner/internal/transcriber"
 )
 
 func TestContestParser_FilterByAllowlist(t *testing.T) {
@@ -560,11 +565,54 @@ func TestContestParser_CreateContestCue(t *testing.T) {
 		assert.NotNil(t, cue.Details, "should set Details")
 
 		// Verify Details contains expected fields
-		assert.Equal(t, "POTA", cue.Details["keyword"], "should set keyword in Details")
-		assert.Equal(t, "1234", cue.Details["number"], "should set number in Details")
-		assert.Equal(t, "Text POTA to 1234", cue.Details["original_text"], "should set original text in Details")
-		assert.Equal(t, 1000, cue.Details["start_ms"], "should set start_ms in Details")
-		assert.Equal(t, 2000, cue.Details["end_ms"], "should set end_ms in Details")
+		assert.Equal(t, "POTA", cue.Details.Keyword, "should set keyword in Details")
+		assert.Equal(t, "1234", cue.Details.Number, "should set number in Details")
+		assert.Equal(t, "Text POTA to 1234", cue.Details.OriginalText, "should set original text in Details")
+		assert.Equal(t, 1000, cue.Details.StartMS, "should set start_ms in Details")
+		assert.Equal(t, 2000, cue.Details.EndMS, "should set end_ms in Details")
+	})
+
+	t.Run("should attach pipeline timing from the context", func(t *testing.T) {
+		// Arrange
+		parser := NewContestParser([]string{"1234"})
+		capturedAt := time.Now().Add(-4 * time.Second)
+		transcribedAt := time.Now().Add(-time.Second)
+		context := &buffer.BufferedContext{
+			Text:          "Text POTA to 1234",
+			StartMS:       1000,
+			EndMS:         2000,
+			CapturedAt:    capturedAt,
+			TranscribedAt: transcribedAt,
+		}
+
+		// Act
+		cue, created := parser.CreateContestCue(context)
+
+		// Assert
+		assert.True(t, created)
+		assert.NotNil(t, cue.Timing, "should set Timing")
+		assert.True(t, cue.Timing.AudioCapturedAt.Equal(capturedAt))
+		assert.True(t, cue.Timing.TranscriptionCompletedAt.Equal(transcribedAt))
+		assert.False(t, cue.Timing.EmittedAt.Before(transcribedAt), "should be emitted after transcription")
+		assert.GreaterOrEqual(t, cue.Timing.LatencyMS, int64(4000))
+	})
+
+	t.Run("should hash cue content by capture time bucket", func(t *testing.T) {
+		// Arrange
+		parser := NewContestParser([]string{"1234"})
+		parser.SetCueHashBucket(time.Hour)
+		capturedAt := time.Now().Add(-2 * time.Second)
+		context := &buffer.BufferedContext{Text: "Text POTA to 1234", CapturedAt: capturedAt}
+
+		// Act
+		first, _ := parser.CreateContestCue(context)
+		second, _ := parser.CreateContestCue(context)
+
+		// Assert
+		assert.Equal(t, CueContentHash("POTA", "1234", capturedAt, time.Hour), first.ContentHash)
+		assert.Equal(t, first.ContentHash, second.ContentHash, "should be stable for the same cue")
+		assert.NotEqual(t, first.CueID, second.CueID, "should still give each cue a unique ID")
+		assert.Less(t, first.CueID, second.CueID, "should order IDs by creation")
 	})
 
 	t.Run("should not create ContestCue when pattern does not match", func(t *testing.T) {
@@ -698,9 +746,30 @@ func TestContestParser_ProcessBufferedContextWithPatternMatching(t *testing.T) {
 
 		assert.Len(t, results, 1, "should output one ContestCue")
 		assert.Equal(t, "POTA", results[0].ContestType, "should set correct ContestType")
-		assert.Equal(t, "POTA", results[0].Details["keyword"], "should set keyword in Details")
-		assert.Equal(t, "1234", results[0].Details["number"], "should set number in Details")
-		assert.Equal(t, "Text POTA to 1234", results[0].Details["original_text"], "should set original text")
+		assert.Equal(t, "POTA", results[0].Details.Keyword, "should set keyword in Details")
+		assert.Equal(t, "1234", results[0].Details.Number, "should set number in Details")
+		assert.Equal(t, "Text POTA to 1234", results[0].Details.OriginalText, "should set original text")
+	})
+
+	t.Run("should skip gap markers between contexts", func(t *testing.T) {
+		// Arrange
+		parser := NewContestParser([]string{"1234"})
+		inputCh := make(chan buffer.BufferedContext, 3)
+		outputCh := make(chan ContestCue, 3)
+
+		// Act
+		inputCh <- buffer.BufferedContext{Text: "Text POTA", StartMS: 0, EndMS: 1000}
+		inputCh <- buffer.BufferedContext{GapMS: 180000}
+		inputCh <- buffer.BufferedContext{Text: "to 1234", StartMS: 0, EndMS: 1000}
+		close(inputCh)
+		parser.ProcessBufferedContextWithPatternMatching(inputCh, outputCh)
+
+		// Assert
+		var results []ContestCue
+		for result := range outputCh {
+			results = append(results, result)
+		}
+		assert.Empty(t, results)
 	})
 
 	t.Run("should not output when text contains allowlist number but no pattern", func(t *testing.T) {
@@ -998,6 +1067,30 @@ func TestContestParser_DetectLetterSequences(t *testing.T) {
 		// Assert
 		assert.Empty(t, sequences, "should return empty slice for empty text")
 	})
+
+	t.Run("should detect letters mixed with digits and digit words", func(t *testing.T) {
+		// Arrange
+		parser := NewContestParser([]string{})
+		text := "Text K one two three F or W 9 X Y Z to 1234"
+
+		// Act
+		sequences := parser.DetectLetterSequences(text)
+
+		// Assert
+		assert.Equal(t, []string{"K one two three F", "W 9 X Y Z"}, sequences)
+	})
+
+	t.Run("should leave digits before or after a spelled word out of it", func(t *testing.T) {
+		// Arrange
+		parser := NewContestParser([]string{})
+		text := "Call 8 0 0 R O C K 5 5 5 1 2 or a one two three"
+
+		// Act
+		sequences := parser.DetectLetterSequences(text)
+
+		// Assert
+		assert.Equal(t, []string{"R O C K"}, sequences, "should not absorb spoken numbers or treat counting as a word")
+	})
 }
 
 func TestContestParser_ReconstructWords(t *testing.T) {
@@ -1110,9 +1203,37 @@ func TestContestParser_ReconstructSpelledWords(t *testing.T) {
 		// Assert
 		assert.Equal(t, "Station calling, please spell FIRE to confirm", result, "should replace only spelled sequences")
 	})
+
+	t.Run("should reconstruct alphanumeric words", func(t *testing.T) {
+		// Arrange
+		parser := NewContestParser([]string{})
+		text := "Text K one two three F or W-9-X-Y-Z to 1234"
+
+		// Act
+		result := parser.ReconstructSpelledWords(text)
+
+		// Assert
+		assert.Equal(t, "Text K123F or W9XYZ to 1234", result)
+	})
 }
 
 func TestContestParser_IntegrationSpelledWordReconstruction(t *testing.T) {
+	t.Run("should create ContestCue from spelled-out alphanumeric keyword", func(t *testing.T) {
+		// Arrange
+		parser := NewContestParser([]string{"1234"})
+		context := &buffer.BufferedContext{Text: "Text W 9 X Y Z to 1234", StartMS: 1000, EndMS: 2000}
+
+		// Act
+		cue, created := parser.CreateContestCue(context)
+
+		// Assert
+		assert.True(t, created, "should create ContestCue from spelled-out alphanumeric keyword")
+		if assert.NotNil(t, cue) {
+			assert.Equal(t, "W9XYZ", cue.Details.Keyword)
+			assert.Equal(t, "1234", cue.Details.Number)
+		}
+	})
+
 	t.Run("should create ContestCue from spelled-out keyword", func(t *testing.T) {
 		// Arrange
 		allowlist := []string{"1234"}
@@ -1132,10 +1253,10 @@ func TestContestParser_IntegrationSpelledWordReconstruction(t *testing.T) {
 		assert.Equal(t, "SAND", cue.ContestType, "should use reconstructed keyword as contest type")
 
 		// Verify details contain both original and reconstructed text
-		assert.Equal(t, "SAND", cue.Details["keyword"], "should have reconstructed keyword in details")
-		assert.Equal(t, "1234", cue.Details["number"], "should have correct number in details")
-		assert.Equal(t, "Text S A N D to 1234", cue.Details["original_text"], "should preserve original text")
-		assert.Equal(t, "Text SAND to 1234", cue.Details["reconstructed_text"], "should include reconstructed text")
+		assert.Equal(t, "SAND", cue.Details.Keyword, "should have reconstructed keyword in details")
+		assert.Equal(t, "1234", cue.Details.Number, "should have correct number in details")
+		assert.Equal(t, "Text S A N D to 1234", cue.Details.OriginalText, "should preserve original text")
+		assert.Equal(t, "Text SAND to 1234", cue.Details.ReconstructedText, "should include reconstructed text")
 	})
 
 	t.Run("should handle spelled-out keyword with different case", func(t *testing.T) {
@@ -1155,7 +1276,7 @@ func TestContestParser_IntegrationSpelledWordReconstruction(t *testing.T) {
 		assert.True(t, created, "should create ContestCue with mixed case spelled keyword")
 		assert.NotNil(t, cue, "should return ContestCue")
 		assert.Equal(t, "PARK", cue.ContestType, "should use reconstructed keyword as contest type")
-		assert.Equal(t, "Text PARK to 9876", cue.Details["reconstructed_text"], "should reconstruct to uppercase")
+		assert.Equal(t, "Text PARK to 9876", cue.Details.ReconstructedText, "should reconstruct to uppercase")
 	})
 
 	t.Run("should maintain compatibility with non-spelled keywords", func(t *testing.T) {
@@ -1175,8 +1296,8 @@ func TestContestParser_IntegrationSpelledWordReconstruction(t *testing.T) {
 		assert.True(t, created, "should still work with non-spelled keywords")
 		assert.NotNil(t, cue, "should return ContestCue")
 		assert.Equal(t, "POTA", cue.ContestType, "should use original keyword")
-		assert.Equal(t, "Text POTA to 5555", cue.Details["original_text"], "should have original text")
-		assert.Equal(t, "Text POTA to 5555", cue.Details["reconstructed_text"], "should match original when no reconstruction needed")
+		assert.Equal(t, "Text POTA to 5555", cue.Details.OriginalText, "should have original text")
+		assert.Equal(t, "Text POTA to 5555", cue.Details.ReconstructedText, "should match original when no reconstruction needed")
 	})
 
 	t.Run("should fail when spelled keyword doesn't match pattern after reconstruction", func(t *testing.T) {
@@ -1254,4 +1375,292 @@ func TestContestParser_HyphenatedSequenceDetection(t *testing.T) {
 		result := parser.detectHyphenatedSequence("P-O-123-A")
 		assert.Equal(t, "", result)
 	})
+
+	t.Run("should detect letters mixed with single digits", func(t *testing.T) {
+		result := parser.detectHyphenatedSequence("W-9-X-Y-Z")
+		assert.Equal(t, "W 9 X Y Z", result)
+	})
+
+	t.Run("should return empty for sequences starting or ending with a digit", func(t *testing.T) {
+		assert.Equal(t, "", parser.detectHyphenatedSequence("1-2-3"))
+		assert.Equal(t, "", parser.detectHyphenatedSequence("A-B-C-5"))
+	})
+}
+
+func TestContestParser_MultipleCues(t *testing.T) {
+	t.Run("should return every match with its offsets", func(t *testing.T) {
+		// Arrange
+		parser := NewContestParser([]string{"1234", "5678"})
+		text := "Text POTA to 1234 or text ROCK to 5678"
+
+		// Act
+		matches := parser.MatchAllContestPatterns(text)
+
+		// Assert
+		assert.Equal(t, []PatternMatch{
+			{Keyword: "POTA", Number: "1234", Start: 0, End: 17},
+			{Keyword: "ROCK", Number: "5678", Start: 21, End: 38},
+		}, matches)
+		assert.Equal(t, "Text POTA to 1234", text[matches[0].Start:matches[0].End])
+		assert.Equal(t, "text ROCK to 5678", text[matches[1].Start:matches[1].End])
+	})
+
+	t.Run("should skip matches whose number is not allowlisted", func(t *testing.T) {
+		// Arrange
+		parser := NewContestParser([]string{"5678"})
+
+		// Act
+		matches := parser.MatchAllContestPatterns("Text POTA to 1234 then text ROCK to 5678")
+
+		// Assert
+		if assert.Len(t, matches, 1) {
+			assert.Equal(t, "ROCK", matches[0].Keyword)
+		}
+	})
+
+	t.Run("should create a cue per match in reading order", func(t *testing.T) {
+		// Arrange
+		parser := NewContestParser([]string{"1234", "5678"})
+		context := &buffer.BufferedContext{
+			Text:    "Text POTA to 1234 or text ROCK to 5678",
+			StartMS: 1000,
+			EndMS:   4000,
+		}
+
+		// Act
+		cues := parser.CreateContestCues(context)
+		first, created := parser.CreateContestCue(context)
+
+		// Assert
+		if assert.Len(t, cues, 2) {
+			assert.Equal(t, "POTA", cues[0].ContestType)
+			assert.Equal(t, 0, cues[0].Details.Extensions["match_index"])
+			assert.Equal(t, 0, cues[0].Details.Extensions["match_start"])
+			assert.Equal(t, "ROCK", cues[1].ContestType)
+			assert.Equal(t, 1, cues[1].Details.Extensions["match_index"])
+			assert.Equal(t, 21, cues[1].Details.Extensions["match_start"])
+			assert.Equal(t, 38, cues[1].Details.Extensions["match_end"])
+			assert.NotEqual(t, cues[0].ContentHash, cues[1].ContentHash)
+		}
+		assert.True(t, created)
+		assert.Equal(t, "POTA", first.ContestType)
+	})
+
+	t.Run("should emit every cue from one context through the pipeline", func(t *testing.T) {
+		// Arrange
+		parser := NewContestParser([]string{"1234", "5678"})
+		inputCh := make(chan buffer.BufferedContext, 1)
+		outputCh := make(chan ContestCue, 2)
+		inputCh <- buffer.BufferedContext{Text: "Text POTA to 1234. Text ROCK to 5678.", StartMS: 0, EndMS: 3000}
+		close(inputCh)
+
+		// Act
+		parser.ProcessBufferedContextWithPatternMatching(inputCh, outputCh)
+
+		// Assert
+		var keywords []string
+		for cue := range outputCh {
+			keywords = append(keywords, cue.ContestType)
+		}
+		assert.Equal(t, []string{"POTA", "ROCK"}, keywords)
+	})
+}
+
+func TestContestParser_MatchUnlistedContestPatterns(t *testing.T) {
+	t.Run("should return only matches whose number is not allowlisted", func(t *testing.T) {
+		// Arrange
+		parser := NewContestParser([]string{"5678", "55*"})
+
+		// Act
+		matches := parser.MatchUnlistedContestPatterns("Text POTA to 1234 then text ROCK to 5678 or text WIN to 55123")
+
+		// Assert
+		assert.Equal(t, []PatternMatch{{Keyword: "POTA", Number: "1234", Start: 0, End: 17}}, matches)
+	})
+
+	t.Run("should skip keywords the keyword filter rejects", func(t *testing.T) {
+		// Arrange
+		parser := NewContestParser(nil)
+		parser.SetKeywordFilter(NewKeywordFilter(3, 12, []string{"THE"}))
+
+		// Act
+		matches := parser.MatchUnlistedContestPatterns("text the to 7788 and text CASH to 7788")
+
+		// Assert
+		if assert.Len(t, matches, 1) {
+			assert.Equal(t, "CASH", matches[0].Keyword)
+		}
+	})
+
+	t.Run("should match spelled keywords after normalization", func(t *testing.T) {
+		parser := NewContestParser([]string{"1234"})
+
+		matches := parser.MatchUnlistedContestPatterns("text C-A-S-H to 7788")
+
+		if assert.Len(t, matches, 1) {
+			assert.Equal(t, "CASH", matches[0].Keyword)
+			assert.Equal(t, "7788", matches[0].Number)
+		}
+	})
+}
+
+func TestContestParser_Lifecycle(t *testing.T) {
+	t.Run("should refuse to start without channels", func(t *testing.T) {
+		// Arrange
+		parser := NewContestParser([]string{"1234"})
+
+		// Act
+		err := parser.Start(context.Background())
+
+		// Assert
+		assert.Error(t, err)
+		assert.Error(t, parser.Healthy())
+	})
+
+	t.Run("should process the set channels until the input closes", func(t *testing.T) {
+		// Arrange
+		parser := NewContestParser([]string{"1234"})
+		inputCh := make(chan buffer.BufferedContext, 1)
+		outputCh := make(chan ContestCue, 1)
+		parser.SetChannels(inputCh, outputCh)
+
+		// Act
+		err := parser.Start(context.Background())
+		inputCh <- buffer.BufferedContext{Text: "Text POTA to 1234", StartMS: 0, EndMS: 1000}
+		close(inputCh)
+
+		// Assert
+		assert.NoError(t, err)
+		cue, ok := <-outputCh
+		assert.True(t, ok)
+		assert.Equal(t, "POTA", cue.ContestType)
+		_, ok = <-outputCh
+		assert.False(t, ok, "output should close once the input is drained")
+		assert.Eventually(t, func() bool { return parser.Healthy() != nil }, time.Second, time.Millisecond)
+		assert.NoError(t, parser.Stop(context.Background()))
+	})
+}
+
+func TestContestParser_ShortcodeSpokenAt(t *testing.T) {
+	captured := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	word := func(text string, offsetMS int) transcriber.Word {
+		return transcriber.Word{Text: text, StartMS: offsetMS, CapturedAt: captured.Add(time.Duration(offsetMS) * time.Millisecond)}
+	}
+
+	t.Run("should time the cue by the shortcode after its keyword", func(t *testing.T) {
+		// Arrange
+		parser := NewContestParser([]string{"12345"})
+		context := &buffer.BufferedContext{
+			Text:       "Call 12345 now. Text WIN to 12345.",
+			StartMS:    0,
+			EndMS:      5000,
+			CapturedAt: captured,
+			Words: []transcriber.Word{
+				word("Call", 0), word("12345", 400), word("now.", 900),
+				word("Text", 1500), word("WIN", 1800), word("to", 2100), word("12,345.", 2300),
+			},
+		}
+
+		// Act
+		cue, ok := parser.CreateContestCue(context)
+
+		// Assert
+		require.True(t, ok)
+		assert.Equal(t, captured.Add(2300*time.Millisecond), cue.Timing.ShortcodeSpokenAt)
+		assert.Equal(t, captured, cue.Timing.AudioCapturedAt)
+	})
+
+	t.Run("should fall back to the keyword when the shortcode was spelled out", func(t *testing.T) {
+		words := []transcriber.Word{word("Text", 0), word("win", 300), word("to", 600), word("one", 800)}
+
+		spokenAt := shortcodeSpokenAt(words, PatternMatch{Keyword: "WIN", Number: "12345"})
+
+		assert.Equal(t, captured.Add(300*time.Millisecond), spokenAt)
+	})
+
+	t.Run("should be unknown without word timestamps", func(t *testing.T) {
+		assert.True(t, shortcodeSpokenAt(nil, PatternMatch{Keyword: "WIN", Number: "12345"}).IsZero())
+	})
+}
+
+func TestContestParser_TraceIDs(t *testing.T) {
+	t.Run("should carry the context's trace IDs onto the cue", func(t *testing.T) {
+		// Arrange
+		parser := NewContestParser([]string{"12345"})
+		context := &buffer.BufferedContext{
+			Text:     "Text WIN to 12345",
+			StartMS:  0,
+			EndMS:    2000,
+			TraceIDs: []string{"0123456789abcdef", "fedcba9876543210"},
+		}
+
+		// Act
+		cue, ok := parser.CreateContestCue(context)
+
+		// Assert
+		require.True(t, ok)
+		assert.Equal(t, []string{"0123456789abcdef", "fedcba9876543210"}, cue.TraceIDs)
+	})
+}
+
+func TestContestParser_ProgramSchedule(t *testing.T) {
+	// Monday 07:00 UTC is during morning drive
+	morning := time.Date(2025, 6, 2, 7, 0, 0, 0, time.UTC)
+	evening := time.Date(2025, 6, 2, 19, 0, 0, 0, time.UTC)
+	newParser := func(t *testing.T, minConfidence float64) *ContestParser {
+		schedule, err := program.NewSchedule([]program.Program{
+			{Name: "Morning Drive", Days: []string{"weekdays"}, Start: "06:00", End: "10:00", ContestHeavy: true, ConfidenceBoost: 0.2},
+		}, time.UTC)
+		require.NoError(t, err)
+		parser := NewContestParser([]string{"12345"})
+		parser.SetProgramSchedule(schedule)
+		parser.SetMinConfidence(minConfidence)
+		return parser
+	}
+
+	t.Run("should tag cues with the show on the air when they were heard", func(t *testing.T) {
+		// Arrange
+		parser := newParser(t, 0)
+
+		// Act
+		cue, ok := parser.CreateContestCue(&buffer.BufferedContext{Text: "Text WIN to 12345", CapturedAt: morning})
+
+		// Assert
+		require.True(t, ok)
+		assert.Equal(t, "Morning Drive", cue.Details.Extensions["program_name"])
+		assert.Equal(t, true, cue.Details.Extensions["program_contest_heavy"])
+	})
+
+	t.Run("should not tag cues heard outside scheduled shows", func(t *testing.T) {
+		parser := newParser(t, 0)
+
+		cue, ok := parser.CreateContestCue(&buffer.BufferedContext{Text: "Text WIN to 12345", CapturedAt: evening})
+
+		require.True(t, ok)
+		assert.NotContains(t, cue.Details.Extensions, "program_name")
+	})
+
+	t.Run("should skip contexts below the minimum confidence", func(t *testing.T) {
+		parser := newParser(t, 0.7)
+
+		_, ok := parser.CreateContestCue(&buffer.BufferedContext{Text: "Text WIN to 12345", CapturedAt: evening, Confidence: 0.6})
+
+		assert.False(t, ok)
+	})
+
+	t.Run("should relax the minimum confidence during shows boosting it", func(t *testing.T) {
+		parser := newParser(t, 0.7)
+
+		_, ok := parser.CreateContestCue(&buffer.BufferedContext{Text: "Text WIN to 12345", CapturedAt: morning, Confidence: 0.6})
+
+		assert.True(t, ok)
+	})
+
+	t.Run("should accept contexts without a confidence score", func(t *testing.T) {
+		parser := newParser(t, 0.7)
+
+		_, ok := parser.CreateContestCue(&buffer.BufferedContext{Text: "Text WIN to 12345", CapturedAt: evening})
+
+		assert.True(t, ok)
+	})
 }
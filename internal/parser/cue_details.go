@@ -0,0 +1,145 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CueDetails describes what was heard in a contest cue. Every cue carries the typed fields;
+// optional details added by the parser and later stages (contest name, program, station,
+// ad break, ...) are kept in Extensions.
+//
+// In JSON the extensions sit alongside the typed fields in one flat object, the shape cue
+// consumers have always read.
+type CueDetails struct {
+	Keyword           string `json:"keyword"`
+	Number            string `json:"number"`
+	OriginalText      string `json:"original_text,omitempty"`
+	ReconstructedText string `json:"reconstructed_text,omitempty"`
+	StartMS           int    `json:"start_ms"`
+	EndMS             int    `json:"end_ms"`
+
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// Detail keys of the typed CueDetails fields
+const (
+	DetailKeyword           = "keyword"
+	DetailNumber            = "number"
+	DetailOriginalText      = "original_text"
+	DetailReconstructedText = "reconstructed_text"
+	DetailStartMS           = "start_ms"
+	DetailEndMS             = "end_ms"
+)
+
+// Get returns the detail stored under key, typed field or extension
+func (d CueDetails) Get(key string) (interface{}, bool) {
+	switch key {
+	case DetailKeyword:
+		return d.Keyword, true
+	case DetailNumber:
+		return d.Number, true
+	case DetailOriginalText:
+		return d.OriginalText, true
+	case DetailReconstructedText:
+		return d.ReconstructedText, true
+	case DetailStartMS:
+		return d.StartMS, true
+	case DetailEndMS:
+		return d.EndMS, true
+	}
+	value, ok := d.Extensions[key]
+	return value, ok
+}
+
+// String returns the extension stored under key when it is a string, otherwise ""
+func (d CueDetails) String(key string) string {
+	value, _ := d.Extensions[key].(string)
+	return value
+}
+
+// Has reports whether an extension is stored under key
+func (d CueDetails) Has(key string) bool {
+	_, ok := d.Extensions[key]
+	return ok
+}
+
+// Set stores value as the extension key. Typed fields cannot be set this way.
+func (d *CueDetails) Set(key string, value interface{}) {
+	if d.Extensions == nil {
+		d.Extensions = make(map[string]interface{})
+	}
+	d.Extensions[key] = value
+}
+
+// Delete removes the extension key
+func (d *CueDetails) Delete(key string) {
+	delete(d.Extensions, key)
+}
+
+// Clone returns a copy of d whose extensions can be changed without affecting d. Cues are
+// passed by value, so stages annotating a cue clone its details first.
+func (d CueDetails) Clone() CueDetails {
+	clone := d
+	clone.Extensions = make(map[string]interface{}, len(d.Extensions))
+	for key, value := range d.Extensions {
+		clone.Extensions[key] = value
+	}
+	return clone
+}
+
+// Map returns the details as one flat map, as they appear in JSON
+func (d CueDetails) Map() map[string]interface{} {
+	fields := make(map[string]interface{}, len(d.Extensions)+6)
+	for key, value := range d.Extensions {
+		fields[key] = value
+	}
+	fields[DetailKeyword] = d.Keyword
+	fields[DetailNumber] = d.Number
+	if d.OriginalText != "" {
+		fields[DetailOriginalText] = d.OriginalText
+	}
+	if d.ReconstructedText != "" {
+		fields[DetailReconstructedText] = d.ReconstructedText
+	}
+	fields[DetailStartMS] = d.StartMS
+	fields[DetailEndMS] = d.EndMS
+	return fields
+}
+
+// MarshalJSON writes the typed fields and extensions as one flat object
+func (d CueDetails) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Map())
+}
+
+// UnmarshalJSON reads a flat details object, keeping keys that are not typed fields as extensions
+func (d *CueDetails) UnmarshalJSON(data []byte) error {
+	type typed CueDetails
+	var fields typed
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	var all map[string]interface{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	for _, key := range []string{DetailKeyword, DetailNumber, DetailOriginalText, DetailReconstructedText, DetailStartMS, DetailEndMS} {
+		delete(all, key)
+	}
+	*d = CueDetails(fields)
+	if len(all) > 0 {
+		d.Extensions = all
+	}
+	return nil
+}
+
+// validate checks that the details identify what to text where
+func (d CueDetails) validate() error {
+	if d.Keyword == "" {
+		return fmt.Errorf("Details.Keyword cannot be empty")
+	}
+	if d.Number == "" {
+		return fmt.Errorf("Details.Number cannot be empty")
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCueDetails_JSON(t *testing.T) {
+	t.Run("should write typed fields and extensions as one flat object", func(t *testing.T) {
+		// Arrange
+		details := CueDetails{Keyword: "CASH", Number: "72881", StartMS: 1000, EndMS: 4000}
+		details.Set("program_name", "Morning Drive")
+
+		// Act
+		data, err := json.Marshal(details)
+
+		// Assert
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"keyword":"CASH","number":"72881","start_ms":1000,"end_ms":4000,"program_name":"Morning Drive"}`, string(data))
+	})
+
+	t.Run("should read unknown keys back as extensions", func(t *testing.T) {
+		// Arrange
+		data := `{"keyword":"CASH","number":"72881","original_text":"Text CASH to 72881","start_ms":1000,"end_ms":4000,"station":"KAAA"}`
+
+		// Act
+		var details CueDetails
+		err := json.Unmarshal([]byte(data), &details)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "CASH", details.Keyword)
+		assert.Equal(t, "Text CASH to 72881", details.OriginalText)
+		assert.Equal(t, 4000, details.EndMS)
+		assert.Equal(t, map[string]interface{}{"station": "KAAA"}, details.Extensions)
+	})
+}
+
+func TestCueDetails_Get(t *testing.T) {
+	t.Run("should look up typed fields and extensions by their JSON keys", func(t *testing.T) {
+		details := CueDetails{Keyword: "CASH", StartMS: 1000}
+		details.Set("tenant", "kiss")
+
+		keyword, ok := details.Get("keyword")
+		assert.True(t, ok)
+		assert.Equal(t, "CASH", keyword)
+		start, _ := details.Get("start_ms")
+		assert.Equal(t, 1000, start)
+		tenant, _ := details.Get("tenant")
+		assert.Equal(t, "kiss", tenant)
+		_, ok = details.Get("station")
+		assert.False(t, ok)
+	})
+}
+
+func TestCueDetails_Clone(t *testing.T) {
+	t.Run("should not share extensions with the original", func(t *testing.T) {
+		original := CueDetails{Keyword: "CASH"}
+		original.Set("station", "KMAIN")
+
+		clone := original.Clone()
+		clone.Set("station", "KAAA")
+		clone.Set("tenant", "kiss")
+
+		assert.Equal(t, "KMAIN", original.String("station"))
+		assert.False(t, original.Has("tenant"))
+		assert.Equal(t, "KAAA", clone.String("station"))
+	})
+}
@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCueHashBucket is the time window cues with the same keyword and number share a content hash in
+const DefaultCueHashBucket = time.Minute
+
+// uuidv7State keeps IDs generated in the same millisecond in order within this process
+var uuidv7State struct {
+	mu     sync.Mutex
+	lastMS int64
+	seq    uint16 // 12-bit sub-millisecond sequence (rand_a)
+}
+
+// NewUUIDv7 returns a UUIDv7 (RFC 9562) for t. UUIDv7s sort by creation time as plain strings,
+// so cues from any instance can be ordered without parsing. IDs created in the same
+// millisecond by this process are ordered by a sequence counter in the rand_a field.
+func NewUUIDv7(t time.Time) string {
+	var id [16]byte
+	rand.Read(id[6:])
+
+	ms := t.UnixMilli()
+	uuidv7State.mu.Lock()
+	if ms <= uuidv7State.lastMS {
+		// Same millisecond (or clock went backwards): continue the sequence from the last ID
+		ms = uuidv7State.lastMS
+		uuidv7State.seq++
+		if uuidv7State.seq > 0x0FFF {
+			ms++
+			uuidv7State.seq = 0
+		}
+	} else {
+		uuidv7State.seq = binary.BigEndian.Uint16(id[6:8]) & 0x07FF // Leave headroom for the sequence
+	}
+	uuidv7State.lastMS = ms
+	seq := uuidv7State.seq
+	uuidv7State.mu.Unlock()
+
+	id[0] = byte(ms >> 40)
+	id[1] = byte(ms >> 32)
+	id[2] = byte(ms >> 24)
+	id[3] = byte(ms >> 16)
+	id[4] = byte(ms >> 8)
+	id[5] = byte(ms)
+	id[6] = 0x70 | byte(seq>>8)&0x0F // Version 7
+	id[7] = byte(seq)
+	id[8] = 0x80 | id[8]&0x3F // RFC 9562 variant
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:16])
+}
+
+// UUIDv7Time returns the millisecond timestamp embedded in a UUIDv7
+func UUIDv7Time(id string) (time.Time, error) {
+	hex := strings.ReplaceAll(id, "-", "")
+	if len(hex) != 32 || hex[12] != '7' {
+		return time.Time{}, fmt.Errorf("not a UUIDv7: %q", id)
+	}
+	var ms int64
+	if _, err := fmt.Sscanf(hex[:12], "%x", &ms); err != nil {
+		return time.Time{}, fmt.Errorf("not a UUIDv7: %q", id)
+	}
+	return time.UnixMilli(ms).UTC(), nil
+}
+
+// CueContentHash returns a stable hash of the keyword, number, and the bucket containing at.
+// Every instance hearing the same cue in the same bucket produces the same hash, so it can be
+// used to dedupe cues across restarts and redundant instances. Cues either side of a bucket
+// boundary hash differently.
+func CueContentHash(keyword, number string, at time.Time, bucket time.Duration) string {
+	if bucket <= 0 {
+		bucket = DefaultCueHashBucket
+	}
+	bucketStart := at.UTC().Truncate(bucket).Unix()
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%s|%d", strings.ToUpper(strings.TrimSpace(keyword)), strings.TrimSpace(number), bucketStart)
+	return fmt.Sprintf("%x", h.Sum(nil))[:16] // Use first 16 characters for readability
+}
@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"regexp"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var uuidv7Pattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestNewUUIDv7(t *testing.T) {
+	t.Run("should produce RFC 9562 version 7 UUIDs embedding the time", func(t *testing.T) {
+		// Arrange
+		at := time.Date(2024, 5, 1, 12, 0, 0, 123000000, time.UTC)
+
+		// Act
+		id := NewUUIDv7(at)
+
+		// Assert
+		assert.Regexp(t, uuidv7Pattern, id)
+		embedded, err := UUIDv7Time(id)
+		require.NoError(t, err)
+		assert.True(t, embedded.Equal(at) || embedded.After(at), "embedded time should not precede creation time")
+	})
+
+	t.Run("should sort in creation order, including within one millisecond", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+		var ids []string
+
+		// Act
+		for i := 0; i < 1000; i++ {
+			ids = append(ids, NewUUIDv7(now))
+		}
+		ids = append(ids, NewUUIDv7(now.Add(time.Second)))
+
+		// Assert
+		assert.True(t, sort.StringsAreSorted(ids), "IDs should sort lexically in creation order")
+		seen := make(map[string]bool)
+		for _, id := range ids {
+			assert.False(t, seen[id], "IDs should be unique")
+			seen[id] = true
+		}
+	})
+
+	t.Run("should reject IDs that are not UUIDv7", func(t *testing.T) {
+		_, err := UUIDv7Time("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCueContentHash(t *testing.T) {
+	t.Run("should match for the same cue heard within one bucket", func(t *testing.T) {
+		at := time.Date(2024, 5, 1, 12, 0, 5, 0, time.UTC)
+
+		first := CueContentHash("CASH", "55555", at, time.Minute)
+		second := CueContentHash("cash", " 55555", at.Add(40*time.Second), time.Minute)
+
+		assert.Len(t, first, 16)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("should differ by keyword, number, and bucket", func(t *testing.T) {
+		at := time.Date(2024, 5, 1, 12, 0, 5, 0, time.UTC)
+		base := CueContentHash("CASH", "55555", at, time.Minute)
+
+		assert.NotEqual(t, base, CueContentHash("MONEY", "55555", at, time.Minute))
+		assert.NotEqual(t, base, CueContentHash("CASH", "12345", at, time.Minute))
+		assert.NotEqual(t, base, CueContentHash("CASH", "55555", at.Add(time.Minute), time.Minute))
+		assert.Equal(t, base, CueContentHash("CASH", "55555", at, 0), "should default to a one-minute bucket")
+	})
+}
@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+)
+
+// Fuzz targets for the normalization and matching run on every buffered context. Run one with,
+// for example, go test ./internal/parser -run '^$' -fuzz FuzzContestParser_MatchContestPattern
+
+// addTranscriptSeeds seeds f with the benchmark corpora
+func addTranscriptSeeds(f *testing.F) {
+	for _, text := range transcriptCorpus {
+		f.Add(text)
+	}
+	for _, context := range benchmarkContexts {
+		f.Add(context.Text)
+	}
+	f.Add("")
+	f.Add("T-E-X-T W I N to 7 2 8 8 1")
+}
+
+// isDigits reports whether s is a non-empty run of ASCII digits
+func isDigits(s string) bool {
+	return s != "" && strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' }) == -1
+}
+
+func FuzzContestParser_MatchContestPattern(f *testing.F) {
+	addTranscriptSeeds(f)
+	cp := NewContestParser([]string{"72881", "55555", "12345"})
+
+	f.Fuzz(func(t *testing.T, text string) {
+		keyword, number, matched := cp.MatchContestPattern(text)
+
+		if !matched {
+			if keyword != "" || number != "" {
+				t.Fatalf("unmatched %q returned keyword %q and number %q", text, keyword, number)
+			}
+			return
+		}
+		if strings.TrimSpace(keyword) == "" || strings.ContainsFunc(keyword, unicode.IsSpace) {
+			t.Fatalf("matched %q with keyword %q", text, keyword)
+		}
+		if !isDigits(number) {
+			t.Fatalf("matched %q with non-numeric number %q", text, number)
+		}
+		if k, n, m := cp.MatchContestPattern(text); k != keyword || n != number || !m {
+			t.Fatalf("matching %q is not deterministic: %q/%q then %q/%q", text, keyword, number, k, n)
+		}
+	})
+}
+
+func FuzzContestParser_ReconstructSpelledWords(f *testing.F) {
+	addTranscriptSeeds(f)
+	cp := NewContestParser(nil)
+
+	f.Fuzz(func(t *testing.T, text string) {
+		result := cp.ReconstructSpelledWords(text)
+
+		if text == "" && result != "" {
+			t.Fatalf("reconstructed empty text as %q", result)
+		}
+		if len(result) > len(text) {
+			t.Fatalf("reconstruction grew %q to %q", text, result)
+		}
+		if again := cp.ReconstructSpelledWords(text); again != result {
+			t.Fatalf("reconstructing %q is not deterministic: %q then %q", text, result, again)
+		}
+	})
+}
+
+func FuzzContestParser_ExtractNumbers(f *testing.F) {
+	addTranscriptSeeds(f)
+	cp := NewContestParser(nil)
+
+	f.Fuzz(func(t *testing.T, text string) {
+		rest := text
+		for _, number := range cp.ExtractNumbers(text) {
+			if !isDigits(number) {
+				t.Fatalf("extracted non-numeric %q from %q", number, text)
+			}
+			index := strings.Index(rest, number)
+			if index == -1 {
+				t.Fatalf("extracted %q, which is not in order in %q", number, text)
+			}
+			rest = rest[index+len(number):]
+		}
+	})
+}
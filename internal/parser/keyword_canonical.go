@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+)
+
+// KeywordCanonicalizer maps the spellings a keyword is transcribed in ("POTA", "P-O-T-A", "pota")
+// to one canonical contest name ("POTA Contest"), so variants are not treated as different
+// contests. Variants are compared ignoring case, spaces, and punctuation.
+type KeywordCanonicalizer struct {
+	names map[string]string // Folded variant -> canonical name
+}
+
+// NewKeywordCanonicalizer creates a KeywordCanonicalizer from a map of keyword variants to
+// canonical contest names. Each canonical name is also a variant of itself.
+func NewKeywordCanonicalizer(variants map[string]string) *KeywordCanonicalizer {
+	c := &KeywordCanonicalizer{names: make(map[string]string, len(variants))}
+	for variant, name := range variants {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if folded := foldKeyword(variant); folded != "" {
+			c.names[folded] = name
+		}
+		if folded := foldKeyword(name); folded != "" {
+			if _, ok := c.names[folded]; !ok {
+				c.names[folded] = name
+			}
+		}
+	}
+	return c
+}
+
+// Canonical returns the canonical contest name for keyword, and whether one is configured
+func (c *KeywordCanonicalizer) Canonical(keyword string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	name, ok := c.names[foldKeyword(keyword)]
+	return name, ok
+}
+
+// Len returns how many variants are mapped
+func (c *KeywordCanonicalizer) Len() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.names)
+}
+
+// foldKeyword lowercases keyword and drops everything but letters and digits
+func foldKeyword(keyword string) string {
+	var b strings.Builder
+	for _, r := range keyword {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/buffer"
+)
+
+func TestKeywordCanonicalizer_Canonical(t *testing.T) {
+	t.Run("should match variants ignoring case and punctuation", func(t *testing.T) {
+		c := NewKeywordCanonicalizer(map[string]string{"pota": "POTA Contest", "cash": " Cash Blast "})
+
+		for _, keyword := range []string{"POTA", "pota", "P-O-T-A", "P.O.T.A.", "POTA Contest"} {
+			name, ok := c.Canonical(keyword)
+			assert.True(t, ok, keyword)
+			assert.Equal(t, "POTA Contest", name, keyword)
+		}
+		name, ok := c.Canonical("CASH!")
+		assert.True(t, ok)
+		assert.Equal(t, "Cash Blast", name)
+	})
+
+	t.Run("should not match unmapped keywords", func(t *testing.T) {
+		c := NewKeywordCanonicalizer(map[string]string{"pota": "POTA Contest"})
+
+		_, ok := c.Canonical("POTATO")
+
+		assert.False(t, ok)
+	})
+
+	t.Run("should be usable when nil", func(t *testing.T) {
+		var c *KeywordCanonicalizer
+
+		_, ok := c.Canonical("POTA")
+
+		assert.False(t, ok)
+		assert.Zero(t, c.Len())
+	})
+}
+
+func TestContestParser_KeywordCanonicalizer(t *testing.T) {
+	t.Run("should set the contest type and hash variants alike", func(t *testing.T) {
+		// Arrange
+		parser := NewContestParser([]string{"12345"})
+		parser.SetKeywordCanonicalizer(NewKeywordCanonicalizer(map[string]string{"pota": "POTA Contest"}))
+		heardAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+		// Act
+		upper, ok := parser.CreateContestCue(&buffer.BufferedContext{Text: "Text POTA to 12345", CapturedAt: heardAt})
+		require.True(t, ok)
+		lower, ok := parser.CreateContestCue(&buffer.BufferedContext{Text: "text pota. to 12345", CapturedAt: heardAt})
+		require.True(t, ok)
+
+		// Assert
+		assert.Equal(t, "POTA Contest", upper.ContestType)
+		assert.Equal(t, "POTA Contest", lower.ContestType)
+		assert.Equal(t, "POTA Contest", upper.Details.Extensions["contest_name"])
+		assert.Equal(t, "POTA", upper.Details.Keyword, "the keyword to text should stay as heard")
+		assert.Equal(t, upper.ContentHash, lower.ContentHash)
+	})
+
+	t.Run("should keep the keyword as the contest type when it is not mapped", func(t *testing.T) {
+		parser := NewContestParser([]string{"12345"})
+		parser.SetKeywordCanonicalizer(NewKeywordCanonicalizer(map[string]string{"pota": "POTA Contest"}))
+
+		cue, ok := parser.CreateContestCue(&buffer.BufferedContext{Text: "Text WIN to 12345"})
+
+		require.True(t, ok)
+		assert.Equal(t, "WIN", cue.ContestType)
+		assert.NotContains(t, cue.Details.Extensions, "contest_name")
+	})
+}
@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultKeywordStopWords are common words that show up in the keyword position of
+// "text ... to" phrases in ordinary speech ("text the to 1234", "text us now") but
+// are never real contest keywords
+var DefaultKeywordStopWords = []string{
+	"the", "a", "an", "us", "now", "me", "it", "this", "that", "them", "him", "her",
+	"you", "and", "or", "in", "on", "at", "back", "again",
+}
+
+// DefaultKeywordMinLength is the shortest keyword accepted when no length is configured
+const DefaultKeywordMinLength = 2
+
+// KeywordFilter rejects pattern matches whose keyword is too short, too long, or a stop word
+type KeywordFilter struct {
+	minLength int
+	maxLength int // 0 means no limit
+	stopWords map[string]bool
+}
+
+// NewKeywordFilter creates a KeywordFilter. A maxLength of 0 disables the upper limit; stopWords
+// may be nil to use DefaultKeywordStopWords.
+func NewKeywordFilter(minLength, maxLength int, stopWords []string) *KeywordFilter {
+	if stopWords == nil {
+		stopWords = DefaultKeywordStopWords
+	}
+	f := &KeywordFilter{
+		minLength: minLength,
+		maxLength: maxLength,
+		stopWords: make(map[string]bool, len(stopWords)),
+	}
+	for _, word := range stopWords {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			f.stopWords[word] = true
+		}
+	}
+	return f
+}
+
+// Check returns why keyword should be rejected, or nil when it is acceptable. Surrounding
+// punctuation picked up by the pattern is ignored.
+func (f *KeywordFilter) Check(keyword string) error {
+	word := strings.TrimFunc(keyword, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	length := utf8.RuneCountInString(word)
+
+	if length < f.minLength {
+		return fmt.Errorf("keyword %q is shorter than %d characters", word, f.minLength)
+	}
+	if f.maxLength > 0 && length > f.maxLength {
+		return fmt.Errorf("keyword %q is longer than %d characters", word, f.maxLength)
+	}
+	if f.stopWords[strings.ToLower(word)] {
+		return fmt.Errorf("keyword %q is a stop word", word)
+	}
+	return nil
+}
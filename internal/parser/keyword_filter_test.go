@@ -0,0 +1,55 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"radiocontestwinner/internal/buffer"
+)
+
+func TestKeywordFilter_Check(t *testing.T) {
+	t.Run("should reject short keywords and default stop words", func(t *testing.T) {
+		filter := NewKeywordFilter(DefaultKeywordMinLength, 0, nil)
+
+		assert.NoError(t, filter.Check("CASH"))
+		assert.NoError(t, filter.Check("W9XYZ,"), "should ignore trailing punctuation")
+		assert.Error(t, filter.Check("X"))
+		assert.Error(t, filter.Check("the"))
+		assert.Error(t, filter.Check("Now."), "should match stop words case-insensitively")
+	})
+
+	t.Run("should apply a maximum length and custom stop words", func(t *testing.T) {
+		filter := NewKeywordFilter(1, 6, []string{" Win "})
+
+		assert.NoError(t, filter.Check("X"))
+		assert.NoError(t, filter.Check("the"), "custom stop words should replace the defaults")
+		assert.Error(t, filter.Check("WIN"))
+		assert.Error(t, filter.Check("SUPERCALIFRAGILISTIC"))
+	})
+}
+
+func TestContestParser_KeywordFilter(t *testing.T) {
+	t.Run("should not create cues for rejected keywords", func(t *testing.T) {
+		// Arrange
+		parser := NewContestParser([]string{"1234"})
+		parser.SetKeywordFilter(NewKeywordFilter(DefaultKeywordMinLength, 0, nil))
+
+		// Act
+		_, bogus := parser.CreateContestCue(&buffer.BufferedContext{Text: "I'll text the to 1234 later"})
+		cue, real := parser.CreateContestCue(&buffer.BufferedContext{Text: "Text ROCK to 1234"})
+
+		// Assert
+		assert.False(t, bogus, "should reject stop word keyword")
+		assert.True(t, real)
+		assert.Equal(t, "ROCK", cue.Details.Keyword)
+	})
+
+	t.Run("should accept every keyword without a filter", func(t *testing.T) {
+		parser := NewContestParser([]string{"1234"})
+
+		_, created := parser.CreateContestCue(&buffer.BufferedContext{Text: "text the to 1234"})
+
+		assert.True(t, created)
+	})
+}
@@ -0,0 +1,239 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Normalization step names accepted in configuration
+const (
+	StepLowercase        = "lowercase"
+	StepStripPunctuation = "strip_punctuation"
+	StepSpelledLetters   = "spelled_letters"
+	StepNumberWords      = "number_words"
+	StepHomophones       = "homophones"
+)
+
+// DefaultNormalizationSteps preserves the parser's original behaviour of only reconstructing spelled letters
+var DefaultNormalizationSteps = []string{StepSpelledLetters}
+
+// DefaultHomophones maps common ASR homophones to the number words they usually stand for on air
+var DefaultHomophones = map[string]string{
+	"too": "two",
+	"for": "four",
+}
+
+// Number words recognised by the number_words step
+var (
+	unitWords = map[string]int{
+		"zero": 0, "one": 1, "two": 2, "three": 3, "four": 4,
+		"five": 5, "six": 6, "seven": 7, "eight": 8, "nine": 9,
+	}
+	teenWords = map[string]int{
+		"ten": 10, "eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14,
+		"fifteen": 15, "sixteen": 16, "seventeen": 17, "eighteen": 18, "nineteen": 19,
+	}
+	tensWords = map[string]int{
+		"twenty": 20, "thirty": 30, "forty": 40, "fifty": 50,
+		"sixty": 60, "seventy": 70, "eighty": 80, "ninety": 90,
+	}
+)
+
+// normalizationStep is a single named text transformation
+type normalizationStep struct {
+	name  string
+	apply func(text string) string
+	// Optional form of apply that also updates the tokens of the text, so they need not be rebuilt
+	applyTokens func(text string, tokens []token) (string, []token)
+}
+
+// Normalizer applies an ordered chain of text normalization steps before pattern matching
+type Normalizer struct {
+	steps  []normalizationStep
+	logger *zap.Logger
+}
+
+// NewNormalizer creates a Normalizer running the named steps in order.
+// The parser is used for spelled-letter reconstruction; homophones may be nil to use DefaultHomophones.
+func NewNormalizer(stepNames []string, homophones map[string]string, cp *ContestParser, logger *zap.Logger) (*Normalizer, error) {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	if homophones == nil {
+		homophones = DefaultHomophones
+	}
+
+	n := &Normalizer{logger: logger}
+	for _, name := range stepNames {
+		var apply func(string) string
+		var applyTokens func(string, []token) (string, []token)
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case StepLowercase:
+			apply = strings.ToLower
+		case StepStripPunctuation:
+			apply = stripPunctuation
+		case StepSpelledLetters:
+			apply = cp.ReconstructSpelledWords
+			applyTokens = cp.reconstructSpelledTokens
+		case StepNumberWords:
+			apply = convertNumberWords
+		case StepHomophones:
+			apply = homophoneReplacer(homophones)
+		default:
+			return nil, fmt.Errorf("unknown normalization step %q", name)
+		}
+		n.steps = append(n.steps, normalizationStep{name: strings.ToLower(strings.TrimSpace(name)), apply: apply, applyTokens: applyTokens})
+	}
+
+	return n, nil
+}
+
+// Steps returns the configured step names in order
+func (n *Normalizer) Steps() []string {
+	names := make([]string, len(n.steps))
+	for i, step := range n.steps {
+		names[i] = step.name
+	}
+	return names
+}
+
+// Normalize runs every step in order and returns the normalized text
+func (n *Normalizer) Normalize(text string) string {
+	if text == "" {
+		return text
+	}
+
+	result := text
+	for _, step := range n.steps {
+		before := result
+		result = step.apply(result)
+		if result != before {
+			n.logger.Debug("applied normalization step",
+				zap.String("step", step.name),
+				zap.String("before", before),
+				zap.String("after", result))
+		}
+	}
+	return result
+}
+
+// normalizeTokens runs every step like Normalize, given the text's tokens, and returns the
+// normalized text with its tokens. The text is only tokenized again after a step changes it
+// without updating the tokens itself.
+func (n *Normalizer) normalizeTokens(text string, tokens []token) (string, []token) {
+	for _, step := range n.steps {
+		before := text
+		if step.applyTokens != nil {
+			text, tokens = step.applyTokens(text, tokens)
+		} else if text = step.apply(text); text != before {
+			tokens = tokenize(text)
+		}
+		if text != before {
+			n.logger.Debug("applied normalization step",
+				zap.String("step", step.name),
+				zap.String("before", before),
+				zap.String("after", text))
+		}
+	}
+	return text, tokens
+}
+
+var (
+	digitGroupRegex  = regexp.MustCompile(`(\d)[,.](\d)`)
+	apostropheRegex  = regexp.MustCompile(`['’]`)
+	nonWordRegex     = regexp.MustCompile(`[^\w\s]+`)
+	whitespaceRegex  = regexp.MustCompile(`\s+`)
+	wordBoundaryTrim = " \t\n\r.,!?;:\"'()"
+)
+
+// stripPunctuation replaces punctuation with spaces, dropping apostrophes and digit-group separators
+func stripPunctuation(text string) string {
+	result := text
+	// Repeat so overlapping groups like 1,234,567 are all joined
+	for digitGroupRegex.MatchString(result) {
+		result = digitGroupRegex.ReplaceAllString(result, "$1$2")
+	}
+	result = apostropheRegex.ReplaceAllString(result, "")
+	result = nonWordRegex.ReplaceAllString(result, " ")
+	return strings.TrimSpace(whitespaceRegex.ReplaceAllString(result, " "))
+}
+
+// homophoneReplacer returns a step replacing whole words case-insensitively using the given map
+func homophoneReplacer(homophones map[string]string) func(string) string {
+	lookup := make(map[string]string, len(homophones))
+	for from, to := range homophones {
+		lookup[strings.ToLower(from)] = to
+	}
+
+	return func(text string) string {
+		words := strings.Fields(text)
+		for i, word := range words {
+			core := strings.Trim(word, wordBoundaryTrim)
+			if replacement, ok := lookup[strings.ToLower(core)]; ok && core != "" {
+				words[i] = strings.Replace(word, core, replacement, 1)
+			}
+		}
+		return strings.Join(words, " ")
+	}
+}
+
+// convertNumberWords replaces spoken numbers with digits and joins consecutive spoken
+// numbers into one, e.g. "five five five one two" becomes "55512" and
+// "twenty three four" becomes "234"
+func convertNumberWords(text string) string {
+	words := strings.Fields(text)
+	var output []string
+	var digits strings.Builder
+	var trailing string
+
+	flush := func() {
+		if digits.Len() > 0 {
+			output = append(output, digits.String()+trailing)
+			digits.Reset()
+			trailing = ""
+		}
+	}
+
+	for i := 0; i < len(words); i++ {
+		core := strings.ToLower(strings.Trim(words[i], wordBoundaryTrim))
+		suffix := words[i][len(strings.TrimRight(words[i], wordBoundaryTrim)):]
+
+		value, ok := -1, false
+		if v, isUnit := unitWords[core]; isUnit {
+			value, ok = v, true
+		} else if v, isTeen := teenWords[core]; isTeen {
+			value, ok = v, true
+		} else if v, isTens := tensWords[core]; isTens {
+			value, ok = v, true
+			// "twenty three" is a single number; only join when this word has no trailing punctuation
+			if suffix == "" && i+1 < len(words) {
+				next := strings.ToLower(strings.Trim(words[i+1], wordBoundaryTrim))
+				if unit, nextIsUnit := unitWords[next]; nextIsUnit && unit > 0 {
+					value += unit
+					i++
+					suffix = words[i][len(strings.TrimRight(words[i], wordBoundaryTrim)):]
+				}
+			}
+		}
+
+		if !ok {
+			flush()
+			output = append(output, words[i])
+			continue
+		}
+
+		digits.WriteString(strconv.Itoa(value))
+		// Punctuation after a number word ends the spoken number
+		if suffix != "" {
+			trailing = suffix
+			flush()
+		}
+	}
+	flush()
+
+	return strings.Join(output, " ")
+}
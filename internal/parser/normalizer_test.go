@@ -0,0 +1,97 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/buffer"
+)
+
+func TestNewNormalizer(t *testing.T) {
+	t.Run("should keep configured step order", func(t *testing.T) {
+		n, err := NewNormalizer([]string{"homophones", "Number_Words", "lowercase"}, nil, NewContestParser(nil), nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{StepHomophones, StepNumberWords, StepLowercase}, n.Steps())
+	})
+
+	t.Run("should reject unknown steps", func(t *testing.T) {
+		_, err := NewNormalizer([]string{"lowercase", "soundex"}, nil, NewContestParser(nil), nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "soundex")
+	})
+}
+
+func TestNormalizer_Normalize(t *testing.T) {
+	cp := NewContestParser(nil)
+
+	tests := []struct {
+		name     string
+		steps    []string
+		input    string
+		expected string
+	}{
+		{"lowercase", []string{StepLowercase}, "Text WIN to 12345", "text win to 12345"},
+		{"strip punctuation", []string{StepStripPunctuation}, "Text WIN, to 12,345! Don't wait.", "Text WIN to 12345 Dont wait"},
+		{"spelled letters", []string{StepSpelledLetters}, "text W I N to 12345", "text WIN to 12345"},
+		{"number words", []string{StepNumberWords}, "text WIN to five five five one two", "text WIN to 55512"},
+		{"compound number words", []string{StepNumberWords}, "text WIN to twenty three four.", "text WIN to 234."},
+		{"teen number words", []string{StepNumberWords}, "text WIN to nineteen ninety nine", "text WIN to 1999"},
+		{"homophones", []string{StepHomophones}, "text WIN too for one", "text WIN two four one"},
+		{"homophones then number words", []string{StepHomophones, StepNumberWords}, "text WIN to too for one", "text WIN to 241"},
+		{"empty chain leaves text unchanged", nil, "Text W I N to 12345", "Text W I N to 12345"},
+	}
+
+	for _, tt := range tests {
+		t.Run("should apply "+tt.name, func(t *testing.T) {
+			// Arrange
+			n, err := NewNormalizer(tt.steps, nil, cp, nil)
+			require.NoError(t, err)
+
+			// Act
+			result := n.Normalize(tt.input)
+
+			// Assert
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	t.Run("should use a custom homophone map", func(t *testing.T) {
+		n, err := NewNormalizer([]string{StepHomophones}, map[string]string{"won": "one"}, cp, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, "text WIN to one for", n.Normalize("text WIN to Won for"))
+	})
+}
+
+func TestContestParser_ConfigureNormalization(t *testing.T) {
+	t.Run("should match spoken numbers once number words are enabled", func(t *testing.T) {
+		// Arrange
+		parser := NewContestParser([]string{"55512"})
+		context := &buffer.BufferedContext{Text: "Text W I N to five five five, one two!", StartMS: 0, EndMS: 1000}
+		_, matchedBefore := parser.CreateContestCue(context)
+
+		// Act
+		err := parser.ConfigureNormalization([]string{StepSpelledLetters, StepStripPunctuation, StepNumberWords}, nil)
+		cue, matched := parser.CreateContestCue(context)
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, matchedBefore)
+		require.True(t, matched)
+		assert.Equal(t, "WIN", cue.Details.Keyword)
+		assert.Equal(t, "55512", cue.Details.Number)
+	})
+
+	t.Run("should keep the previous chain when configuration is invalid", func(t *testing.T) {
+		parser := NewContestParser([]string{"12345"})
+
+		err := parser.ConfigureNormalization([]string{"unknown"}, nil)
+
+		assert.Error(t, err)
+		assert.Equal(t, "text WIN to 12345", parser.Normalize("text W I N to 12345"))
+	})
+}
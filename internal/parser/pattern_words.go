@@ -0,0 +1,191 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// PatternWords are the trigger words around the keyword and number of a contest cue, e.g.
+// "Text [KEYWORD] to [NUMBER]" in English or "Texto [KEYWORD] al [NUMBER]" in Spanish. Any of
+// the Text words may start a cue and any of the To words may join the keyword to the number;
+// both match case-insensitively.
+type PatternWords struct {
+	Text []string
+	To   []string
+}
+
+// DefaultPatternLanguage is the language of the built-in "Text [KEYWORD] to [NUMBER]" pattern
+const DefaultPatternLanguage = "en"
+
+// patternLanguages holds the trigger words of each supported language. Spanish lists the
+// unaccented spellings too because transcripts often drop the accents.
+var patternLanguages = map[string]PatternWords{
+	"en": {Text: []string{"text"}, To: []string{"to"}},
+	"es": {Text: []string{"texto", "textea", "envía", "envia", "manda"}, To: []string{"al", "a"}},
+}
+
+// PatternLanguages returns the languages with built-in trigger words, sorted
+func PatternLanguages() []string {
+	languages := make([]string, 0, len(patternLanguages))
+	for language := range patternLanguages {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// PatternWordsForLanguage returns the built-in trigger words of language ("" is English)
+func PatternWordsForLanguage(language string) (PatternWords, error) {
+	if language == "" {
+		language = DefaultPatternLanguage
+	}
+	words, ok := patternLanguages[strings.ToLower(language)]
+	if !ok {
+		return PatternWords{}, fmt.Errorf("unknown pattern language %q (expected one of %s)", language, strings.Join(PatternLanguages(), ", "))
+	}
+	return words, nil
+}
+
+// contestMatcher finds "Text [KEYWORD] to [NUMBER]" in tokenized text. It matches what
+// contestPattern would with the same trigger words: a Text phrase ending a token at a word
+// boundary, the next token as the keyword, a To phrase of whole tokens, and the digits starting
+// the token after it, all case-insensitively. Matching tokens avoids a regex scan per context.
+type contestMatcher struct {
+	text [][]string // Text phrases split into words, longest first as in the regex alternation
+	to   [][]string
+}
+
+// newContestMatcher builds the contest pattern matcher for words. Multi-word phrases such as
+// "send the word" match with any whitespace between their words.
+func newContestMatcher(words PatternWords) (*contestMatcher, error) {
+	text, err := phrases(words.Text)
+	if err != nil {
+		return nil, fmt.Errorf("text words: %w", err)
+	}
+	to, err := phrases(words.To)
+	if err != nil {
+		return nil, fmt.Errorf("to words: %w", err)
+	}
+	return &contestMatcher{text: text, to: to}, nil
+}
+
+// phrases splits words into phrases of one or more words, longest first so a phrase wins over a
+// word it starts with
+func phrases(words []string) ([][]string, error) {
+	var result [][]string
+	for _, word := range words {
+		if fields := strings.Fields(word); len(fields) > 0 {
+			result = append(result, fields)
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("at least one word is required")
+	}
+	sort.SliceStable(result, func(i, j int) bool { return phraseLength(result[i]) > phraseLength(result[j]) })
+	return result, nil
+}
+
+// phraseLength returns the length of phrase as a regular expression, `send\s+the\s+word`, which
+// orders phrases as the contest pattern regex has always ordered them
+func phraseLength(phrase []string) int {
+	length := len(`\s+`) * (len(phrase) - 1)
+	for _, word := range phrase {
+		length += len(regexp.QuoteMeta(word))
+	}
+	return length
+}
+
+// matchAll returns every non-overlapping match in tokens, leftmost first
+func (m *contestMatcher) matchAll(tokens []token) []PatternMatch {
+	var matches []PatternMatch
+	minStart := 0
+	for i := 0; i < len(tokens); i++ {
+		match, next, ok := m.matchAt(tokens, i, minStart)
+		if !ok {
+			continue
+		}
+		matches = append(matches, match)
+		// The rest of the number's token may start the next match
+		minStart, i = match.End, next-1
+	}
+	return matches
+}
+
+// matchAt returns the leftmost match whose Text phrase starts in tokens[i] at or after minStart,
+// preferring earlier phrases at the same offset, and the index of the token holding its number
+func (m *contestMatcher) matchAt(tokens []token, i, minStart int) (PatternMatch, int, bool) {
+	var best PatternMatch
+	bestIndex, found := 0, false
+	for _, phrase := range m.text {
+		start, ok := phraseStart(tokens, i, phrase)
+		if !ok || start < minStart || (found && start >= best.Start) {
+			continue
+		}
+		keywordAt := i + len(phrase)
+		if keywordAt >= len(tokens) {
+			continue
+		}
+		for _, to := range m.to {
+			numberAt := keywordAt + 1 + len(to)
+			if numberAt >= len(tokens) || !wholeTokens(tokens[keywordAt+1:numberAt], to) {
+				continue
+			}
+			number := leadingNumber(tokens[numberAt].text)
+			if number == "" {
+				continue
+			}
+			best = PatternMatch{
+				Keyword: tokens[keywordAt].text,
+				Number:  number,
+				Start:   start,
+				End:     tokens[numberAt].start + len(number),
+			}
+			bestIndex, found = numberAt, true
+			break
+		}
+	}
+	return best, bestIndex, found
+}
+
+// phraseStart returns the offset at which phrase starts if it ends with tokens[i+len(phrase)-1]:
+// its first word ends tokens[i] at a word boundary and the rest are whole tokens
+func phraseStart(tokens []token, i int, phrase []string) (int, bool) {
+	if i+len(phrase) > len(tokens) || !wholeTokens(tokens[i+1:i+len(phrase)], phrase[1:]) {
+		return 0, false
+	}
+	first, word := tokens[i].text, phrase[0]
+	at := len(first) - len(word)
+	if at < 0 || !strings.EqualFold(first[at:], word) {
+		return 0, false
+	}
+	// \b before the word: a word character on exactly one side
+	before := at > 0 && isWordByte(first[at-1])
+	if before == isWordByte(word[0]) {
+		return 0, false
+	}
+	return tokens[i].start + at, true
+}
+
+// wholeTokens reports whether tokens are the words, case-insensitively
+func wholeTokens(tokens []token, words []string) bool {
+	for j, word := range words {
+		if !strings.EqualFold(tokens[j].text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// leadingNumber returns the digits starting s when they end at a word boundary, as \d+\b
+func leadingNumber(s string) string {
+	n := 0
+	for n < len(s) && isDigitByte(s[n]) {
+		n++
+	}
+	if n == 0 || (n < len(s) && isWordByte(s[n])) {
+		return ""
+	}
+	return s[:n]
+}
@@ -0,0 +1,131 @@
+package parser
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/buffer"
+)
+
+func TestPatternWordsForLanguage(t *testing.T) {
+	t.Run("should default to English", func(t *testing.T) {
+		words, err := PatternWordsForLanguage("")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"text"}, words.Text)
+		assert.Equal(t, []string{"to"}, words.To)
+	})
+
+	t.Run("should reject unknown languages", func(t *testing.T) {
+		_, err := PatternWordsForLanguage("xx")
+
+		assert.ErrorContains(t, err, "unknown pattern language")
+	})
+}
+
+func TestContestParser_SetPatternWords(t *testing.T) {
+	t.Run("should detect Spanish cue phrasing", func(t *testing.T) {
+		// Arrange
+		parser := NewContestParser([]string{"72881", "55555"})
+		words, err := PatternWordsForLanguage("es")
+		require.NoError(t, err)
+
+		// Act
+		require.NoError(t, parser.SetPatternWords(words))
+		cues := parser.CreateContestCues(&buffer.BufferedContext{Text: "Texto GANA al 72881 y luego envía PREMIO al 55555", StartMS: 0, EndMS: 4000})
+
+		// Assert
+		require.Len(t, cues, 2)
+		assert.Equal(t, "GANA", cues[0].Details.Keyword)
+		assert.Equal(t, "72881", cues[0].Details.Number)
+		assert.Equal(t, "PREMIO", cues[1].Details.Keyword)
+		_, _, matched := parser.MatchContestPattern("Text CASH to 72881")
+		assert.False(t, matched, "English phrasing should no longer match")
+	})
+
+	t.Run("should match multi-word trigger phrases", func(t *testing.T) {
+		parser := NewContestParser([]string{"72881"})
+		require.NoError(t, parser.SetPatternWords(PatternWords{Text: []string{"text", "send the word"}, To: []string{"to"}}))
+
+		keyword, number, matched := parser.MatchContestPattern("send  the word CASH to 72881")
+
+		assert.True(t, matched)
+		assert.Equal(t, "CASH", keyword)
+		assert.Equal(t, "72881", number)
+	})
+
+	t.Run("should reject empty word lists", func(t *testing.T) {
+		parser := NewContestParser([]string{"72881"})
+
+		err := parser.SetPatternWords(PatternWords{Text: []string{"texto"}, To: []string{" "}})
+
+		assert.ErrorContains(t, err, "to words")
+	})
+}
+
+// contestPatternFor builds contestPattern as a regular expression for words, the reference the
+// token matcher is checked against
+func contestPatternFor(words PatternWords) *regexp.Regexp {
+	alternation := func(words []string) string {
+		var alternatives []string
+		for _, word := range words {
+			fields := strings.Fields(word)
+			for i, field := range fields {
+				fields[i] = regexp.QuoteMeta(field)
+			}
+			alternatives = append(alternatives, strings.Join(fields, `\s+`))
+		}
+		sort.SliceStable(alternatives, func(i, j int) bool { return len(alternatives[i]) > len(alternatives[j]) })
+		return "(?:" + strings.Join(alternatives, "|") + ")"
+	}
+	return regexp.MustCompile(`(?i)\b` + alternation(words.Text) + `\s+(\S+)\s+` + alternation(words.To) + `\s+(\d+)\b`)
+}
+
+func TestContestMatcher_MatchAll(t *testing.T) {
+	texts := append([]string{
+		"TEXT cash TO 72881. Text rock to 55555!",
+		"text\tWIN\nto  72881, or text PRIZE to 12345abc",
+		"retext WIN to 72881 and #text WIN to 72881",
+		"Text WIN to 72881text WIN to 55555",
+		"text text to to 12345 to 5",
+		"text WIN to",
+		"please send the word CASH to 72881 or text the word WIN to 72881",
+		"Envía texto PREMIO al 72881 o mensaje GANA a 55555",
+		"",
+	}, transcriptCorpus...)
+	wordSets := []PatternWords{
+		{Text: []string{"text"}, To: []string{"to"}},
+		{Text: []string{"text", "send the word", "text the word"}, To: []string{"to", "it to"}},
+		{Text: []string{"texto", "mensaje", "envía"}, To: []string{"al", "a"}},
+	}
+
+	for _, words := range wordSets {
+		matcher, err := newContestMatcher(words)
+		require.NoError(t, err)
+		pattern := contestPatternFor(words)
+
+		for _, text := range texts {
+			// Arrange
+			var expected []PatternMatch
+			for _, index := range pattern.FindAllStringSubmatchIndex(text, -1) {
+				expected = append(expected, PatternMatch{
+					Keyword: text[index[2]:index[3]],
+					Number:  text[index[4]:index[5]],
+					Start:   index[0],
+					End:     index[1],
+				})
+			}
+
+			// Act
+			matches := matcher.matchAll(tokenize(text))
+
+			// Assert
+			assert.Equal(t, expected, matches, "%v: %q", words.Text, text)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"radiocontestwinner/internal/buffer"
+)
+
+// maxShadowDiffs is how many of the latest differences a ShadowReport keeps
+const maxShadowDiffs = 50
+
+// ShadowDiff compares the cues the active and candidate configurations find in one context.
+// Cues are identified as "CONTEST_TYPE NUMBER".
+type ShadowDiff struct {
+	At        time.Time `json:"at"`
+	Text      string    `json:"text"`
+	TraceIDs  []string  `json:"trace_ids,omitempty"`
+	Candidate []string  `json:"candidate,omitempty"` // Cues the candidate would send
+	Added     []string  `json:"added,omitempty"`     // Cues only the candidate would send
+	Removed   []string  `json:"removed,omitempty"`   // Cues only the active configuration sends
+}
+
+// Differs reports whether the two configurations disagree on the context
+func (d ShadowDiff) Differs() bool {
+	return len(d.Added) > 0 || len(d.Removed) > 0
+}
+
+// ShadowReport summarizes how a candidate configuration compares to the active one
+type ShadowReport struct {
+	Contexts      int64        `json:"contexts"`       // Contexts evaluated by both
+	Agreed        int64        `json:"agreed"`         // Contexts where both found the same cues
+	ActiveCues    int64        `json:"active_cues"`    // Cues the active configuration found
+	CandidateCues int64        `json:"candidate_cues"` // Cues the candidate found
+	Added         int64        `json:"added"`          // Cues only the candidate found
+	Removed       int64        `json:"removed"`        // Cues only the active configuration found
+	Recent        []ShadowDiff `json:"recent"`         // Latest differences, oldest first
+}
+
+// Shadow evaluates a candidate parser configuration against the active one on the same
+// contexts, so pattern, allowlist, and normalization changes can be validated on live audio
+// before cutover. Neither parser's cues are sent anywhere.
+type Shadow struct {
+	active    *ContestParser
+	candidate *ContestParser
+
+	mu     sync.Mutex
+	report ShadowReport
+}
+
+// NewShadow creates a Shadow comparing candidate to active. Both should be dedicated instances
+// so the shadow never affects the parser in the pipeline.
+func NewShadow(active, candidate *ContestParser) *Shadow {
+	return &Shadow{active: active, candidate: candidate}
+}
+
+// Compare parses the context with both configurations and records how their cues differ
+func (s *Shadow) Compare(context *buffer.BufferedContext) ShadowDiff {
+	activeCues := cueKeys(s.active.CreateContestCues(context))
+	candidateCues := cueKeys(s.candidate.CreateContestCues(context))
+
+	diff := ShadowDiff{
+		At:        heardAt(context),
+		Text:      context.Text,
+		TraceIDs:  context.TraceIDs,
+		Candidate: candidateCues,
+		Added:     missingFrom(candidateCues, activeCues),
+		Removed:   missingFrom(activeCues, candidateCues),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.report.Contexts++
+	s.report.ActiveCues += int64(len(activeCues))
+	s.report.CandidateCues += int64(len(candidateCues))
+	s.report.Added += int64(len(diff.Added))
+	s.report.Removed += int64(len(diff.Removed))
+	if !diff.Differs() {
+		s.report.Agreed++
+		return diff
+	}
+	if len(s.report.Recent) == maxShadowDiffs {
+		s.report.Recent = append(s.report.Recent[:0], s.report.Recent[1:]...)
+	}
+	s.report.Recent = append(s.report.Recent, diff)
+	return diff
+}
+
+// Report returns the comparison so far
+func (s *Shadow) Report() ShadowReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	report := s.report
+	report.Recent = append([]ShadowDiff{}, s.report.Recent...)
+	return report
+}
+
+// cueKeys identifies each cue by its contest type and number, sorted
+func cueKeys(cues []*ContestCue) []string {
+	keys := make([]string, 0, len(cues))
+	for _, cue := range cues {
+		keys = append(keys, cue.ContestType+" "+cue.Details.Number)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// missingFrom returns the keys of a not in b, counting repeats; both must be sorted
+func missingFrom(a, b []string) []string {
+	var missing []string
+	j := 0
+	for _, key := range a {
+		for j < len(b) && b[j] < key {
+			j++
+		}
+		if j < len(b) && b[j] == key {
+			j++
+			continue
+		}
+		missing = append(missing, key)
+	}
+	return missing
+}
@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"radiocontestwinner/internal/buffer"
+)
+
+func TestShadow_Compare(t *testing.T) {
+	t.Run("should report cues only one configuration finds", func(t *testing.T) {
+		// Arrange
+		shadow := NewShadow(NewContestParser([]string{"72881"}), NewContestParser([]string{"72881", "55555"}))
+		context := &buffer.BufferedContext{Text: "Text CASH to 72881 or text WIN to 55555", TraceIDs: []string{"t1"}}
+
+		// Act
+		diff := shadow.Compare(context)
+
+		// Assert
+		assert.True(t, diff.Differs())
+		assert.Equal(t, []string{"WIN 55555"}, diff.Added)
+		assert.Empty(t, diff.Removed)
+		assert.Equal(t, []string{"t1"}, diff.TraceIDs)
+	})
+
+	t.Run("should summarize agreements and differences", func(t *testing.T) {
+		// Arrange
+		shadow := NewShadow(NewContestParser([]string{"72881", "55555"}), NewContestParser([]string{"72881"}))
+
+		// Act
+		shadow.Compare(&buffer.BufferedContext{Text: "Text CASH to 72881 now"})
+		shadow.Compare(&buffer.BufferedContext{Text: "no contest here"})
+		shadow.Compare(&buffer.BufferedContext{Text: "Text WIN to 55555 now"})
+		report := shadow.Report()
+
+		// Assert
+		assert.Equal(t, int64(3), report.Contexts)
+		assert.Equal(t, int64(2), report.Agreed)
+		assert.Equal(t, int64(2), report.ActiveCues)
+		assert.Equal(t, int64(1), report.CandidateCues)
+		assert.Equal(t, int64(1), report.Removed)
+		assert.Zero(t, report.Added)
+		if assert.Len(t, report.Recent, 1) {
+			assert.Equal(t, []string{"WIN 55555"}, report.Recent[0].Removed)
+		}
+	})
+
+	t.Run("should keep only the latest differences", func(t *testing.T) {
+		// Arrange
+		shadow := NewShadow(NewContestParser([]string{"72881"}), NewContestParser([]string{"99999"}))
+
+		// Act
+		for i := 0; i < maxShadowDiffs+5; i++ {
+			shadow.Compare(&buffer.BufferedContext{Text: "Text CASH to 72881"})
+		}
+
+		// Assert
+		assert.Len(t, shadow.Report().Recent, maxShadowDiffs)
+	})
+}
+
+func TestMissingFrom(t *testing.T) {
+	assert.Equal(t, []string{"A 1", "C 3"}, missingFrom([]string{"A 1", "A 1", "B 2", "C 3"}, []string{"A 1", "B 2"}))
+	assert.Nil(t, missingFrom([]string{"A 1"}, []string{"A 1", "B 2"}))
+}
@@ -0,0 +1,268 @@
+package parser
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"go.uber.org/zap"
+)
+
+// substitutionRule replaces one phrase with its correction
+type substitutionRule struct {
+	phrase      string
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// SubstitutionDictionary corrects recurring ASR mis-hearings (e.g. "potta" -> "POTA")
+// with whole-phrase, case-insensitive replacements. Rules can be swapped at runtime.
+type SubstitutionDictionary struct {
+	mu    sync.RWMutex
+	rules []substitutionRule
+}
+
+// NewSubstitutionDictionary creates a dictionary from phrase -> replacement entries
+func NewSubstitutionDictionary(entries map[string]string) *SubstitutionDictionary {
+	d := &SubstitutionDictionary{}
+	d.Update(entries)
+	return d
+}
+
+// Update atomically replaces all rules. Longer phrases are applied first so
+// "won twenty three four" wins over "won".
+func (d *SubstitutionDictionary) Update(entries map[string]string) {
+	rules := make([]substitutionRule, 0, len(entries))
+	for phrase, replacement := range entries {
+		words := strings.Fields(phrase)
+		if len(words) == 0 {
+			continue
+		}
+		quoted := make([]string, len(words))
+		for i, word := range words {
+			quoted[i] = regexp.QuoteMeta(word)
+		}
+
+		pattern := strings.Join(quoted, `\s+`)
+		if isWordRune(firstRune(words[0])) {
+			pattern = `\b` + pattern
+		}
+		if isWordRune(lastRune(words[len(words)-1])) {
+			pattern += `\b`
+		}
+
+		rules = append(rules, substitutionRule{
+			phrase:      strings.Join(words, " "),
+			pattern:     regexp.MustCompile(`(?i)` + pattern),
+			replacement: replacement,
+		})
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		if len(rules[i].phrase) != len(rules[j].phrase) {
+			return len(rules[i].phrase) > len(rules[j].phrase)
+		}
+		return rules[i].phrase < rules[j].phrase
+	})
+
+	d.mu.Lock()
+	d.rules = rules
+	d.mu.Unlock()
+}
+
+// Len returns the number of active rules
+func (d *SubstitutionDictionary) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.rules)
+}
+
+// Entries returns the active rules as phrase -> replacement, with phrases in their normalized form
+func (d *SubstitutionDictionary) Entries() map[string]string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	entries := make(map[string]string, len(d.rules))
+	for _, rule := range d.rules {
+		entries[rule.phrase] = rule.replacement
+	}
+	return entries
+}
+
+// Apply returns text with every matching phrase replaced
+func (d *SubstitutionDictionary) Apply(text string) string {
+	if text == "" {
+		return text
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	result := text
+	for _, rule := range d.rules {
+		result = rule.pattern.ReplaceAllLiteralString(result, rule.replacement)
+	}
+	return result
+}
+
+// LoadSubstitutionFile reads substitutions from a file with one "phrase => replacement"
+// entry per line. Blank lines and lines starting with # are ignored.
+func LoadSubstitutionFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open substitution file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	entries := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		phrase, replacement, found := strings.Cut(line, "=>")
+		phrase = strings.TrimSpace(phrase)
+		if !found || phrase == "" {
+			return nil, fmt.Errorf("invalid substitution on line %d of %s: expected \"phrase => replacement\"", lineNumber, path)
+		}
+		entries[phrase] = strings.TrimSpace(replacement)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read substitution file %s: %w", path, err)
+	}
+
+	return entries, nil
+}
+
+// WatchSubstitutionFile polls path and reloads the dictionary whenever the file changes.
+// Inline entries from configuration are merged underneath the file's entries. A file that
+// fails to load keeps the previous rules active. Blocks until ctx is cancelled.
+func WatchSubstitutionFile(ctx context.Context, path string, inline map[string]string, dict *SubstitutionDictionary, interval time.Duration, logger *zap.Logger) {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	var lastModTime time.Time
+	var lastSize int64
+	if info, err := os.Stat(path); err == nil {
+		lastModTime, lastSize = info.ModTime(), info.Size()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(path)
+			if err != nil {
+				continue
+			}
+			if info.ModTime().Equal(lastModTime) && info.Size() == lastSize {
+				continue
+			}
+			lastModTime, lastSize = info.ModTime(), info.Size()
+
+			entries, err := LoadSubstitutionFile(path)
+			if err != nil {
+				logger.Error("failed to reload substitution file, keeping previous rules",
+					zap.String("path", path),
+					zap.Error(err))
+				continue
+			}
+
+			merged := MergeSubstitutions(inline, entries)
+			added, changed, removed := DiffSubstitutions(dict.Entries(), merged)
+			if len(added)+len(changed)+len(removed) == 0 {
+				logger.Debug("substitution file changed without changing any rules", zap.String("path", path))
+				continue
+			}
+			dict.Update(merged)
+			logger.Info("reloaded substitution dictionary",
+				zap.String("path", path),
+				zap.Int("rules", dict.Len()),
+				zap.Strings("added", added),
+				zap.Strings("changed", changed),
+				zap.Strings("removed", removed))
+		}
+	}
+}
+
+// DiffSubstitutions compares two sets of phrase -> replacement entries and returns the phrases
+// only in next, those whose replacement changed, and those only in previous, each sorted.
+// Phrases are compared in normalized form, as the dictionary matches them.
+func DiffSubstitutions(previous, next map[string]string) (added, changed, removed []string) {
+	normalized := func(entries map[string]string) map[string]string {
+		result := make(map[string]string, len(entries))
+		for phrase, replacement := range entries {
+			if words := strings.Fields(phrase); len(words) > 0 {
+				result[strings.Join(words, " ")] = replacement
+			}
+		}
+		return result
+	}
+	before, after := normalized(previous), normalized(next)
+
+	for phrase, replacement := range after {
+		if old, ok := before[phrase]; !ok {
+			added = append(added, phrase)
+		} else if old != replacement {
+			changed = append(changed, phrase)
+		}
+	}
+	for phrase := range before {
+		if _, ok := after[phrase]; !ok {
+			removed = append(removed, phrase)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(changed)
+	sort.Strings(removed)
+	return added, changed, removed
+}
+
+// MergeSubstitutions combines entry maps, with later maps overriding earlier ones
+func MergeSubstitutions(maps ...map[string]string) map[string]string {
+	merged := make(map[string]string)
+	for _, m := range maps {
+		for phrase, replacement := range m {
+			merged[phrase] = replacement
+		}
+	}
+	return merged
+}
+
+func firstRune(s string) rune {
+	for _, r := range s {
+		return r
+	}
+	return 0
+}
+
+func lastRune(s string) rune {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return 0
+	}
+	return runes[len(runes)-1]
+}
+
+// isWordRune reports whether r matches \w in Go regular expressions
+func isWordRune(r rune) bool {
+	return r == '_' || (r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)))
+}
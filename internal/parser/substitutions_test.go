@@ -0,0 +1,177 @@
+package parser
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/buffer"
+)
+
+func TestSubstitutionDictionary_Apply(t *testing.T) {
+	dict := NewSubstitutionDictionary(map[string]string{
+		"texting":               "text",
+		"potta":                 "POTA",
+		"won twenty three four": "1234",
+		"won":                   "one",
+		"c.q.":                  "CQ",
+	})
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"single word", "Texting WIN to 12345", "text WIN to 12345"},
+		{"case-insensitive match keeps replacement case", "activate Potta now", "activate POTA now"},
+		{"longer phrase before shorter", "text WIN to won  twenty three four", "text WIN to 1234"},
+		{"whole words only", "wonder potta potatoes", "wonder POTA potatoes"},
+		{"phrases ending in punctuation", "calling c.q. contest", "calling CQ contest"},
+		{"no match", "nothing to see", "nothing to see"},
+	}
+
+	for _, tt := range tests {
+		t.Run("should handle "+tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, dict.Apply(tt.input))
+		})
+	}
+}
+
+func TestSubstitutionDictionary_Update(t *testing.T) {
+	// Arrange
+	dict := NewSubstitutionDictionary(map[string]string{"potta": "POTA"})
+
+	// Act
+	dict.Update(map[string]string{"sota": "SOTA"})
+
+	// Assert
+	assert.Equal(t, 1, dict.Len())
+	assert.Equal(t, "potta SOTA", dict.Apply("potta sota"))
+}
+
+func TestLoadSubstitutionFile(t *testing.T) {
+	t.Run("should parse entries and skip comments", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "substitutions.txt")
+		content := "# station KXYZ corrections\n\ntexting => text\n  potta=>POTA  \nwon twenty three four => 1234\n"
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+		// Act
+		entries, err := LoadSubstitutionFile(path)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"texting":               "text",
+			"potta":                 "POTA",
+			"won twenty three four": "1234",
+		}, entries)
+	})
+
+	t.Run("should report malformed lines", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "substitutions.txt")
+		require.NoError(t, os.WriteFile(path, []byte("texting => text\npotta POTA\n"), 0644))
+
+		_, err := LoadSubstitutionFile(path)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line 2")
+	})
+
+	t.Run("should fail for a missing file", func(t *testing.T) {
+		_, err := LoadSubstitutionFile(filepath.Join(t.TempDir(), "missing.txt"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestWatchSubstitutionFile(t *testing.T) {
+	t.Run("should reload the dictionary when the file changes", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "substitutions.txt")
+		require.NoError(t, os.WriteFile(path, []byte("potta => POTA\n"), 0644))
+		inline := map[string]string{"texting": "text"}
+		dict := NewSubstitutionDictionary(MergeSubstitutions(inline, map[string]string{"potta": "POTA"}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go WatchSubstitutionFile(ctx, path, inline, dict, 10*time.Millisecond, nil)
+		time.Sleep(30 * time.Millisecond) // let the watcher record the initial file state
+
+		// Act
+		require.NoError(t, os.WriteFile(path, []byte("potta => POTA\nsota => SOTA\n"), 0644))
+		require.NoError(t, os.Chtimes(path, time.Now().Add(time.Second), time.Now().Add(time.Second)))
+
+		// Assert
+		assert.Eventually(t, func() bool { return dict.Len() == 3 }, 2*time.Second, 10*time.Millisecond)
+		assert.Equal(t, "text SOTA POTA", dict.Apply("texting sota potta"))
+	})
+
+	t.Run("should keep previous rules when the file becomes invalid", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "substitutions.txt")
+		require.NoError(t, os.WriteFile(path, []byte("potta => POTA\n"), 0644))
+		dict := NewSubstitutionDictionary(map[string]string{"potta": "POTA"})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go WatchSubstitutionFile(ctx, path, nil, dict, 10*time.Millisecond, nil)
+		time.Sleep(30 * time.Millisecond) // let the watcher record the initial file state
+
+		// Act
+		require.NoError(t, os.WriteFile(path, []byte("not a rule\n"), 0644))
+		require.NoError(t, os.Chtimes(path, time.Now().Add(time.Second), time.Now().Add(time.Second)))
+		time.Sleep(50 * time.Millisecond)
+
+		// Assert
+		assert.Equal(t, "POTA", dict.Apply("potta"))
+	})
+}
+
+func TestDiffSubstitutions(t *testing.T) {
+	t.Run("should report added, changed, and removed phrases", func(t *testing.T) {
+		previous := map[string]string{"potta": "POTA", "texting": "text", "won": "one"}
+		next := map[string]string{"potta": "POTA", "texting  ": "texts", "sota": "SOTA"}
+
+		added, changed, removed := DiffSubstitutions(previous, next)
+
+		assert.Equal(t, []string{"sota"}, added)
+		assert.Equal(t, []string{"texting"}, changed)
+		assert.Equal(t, []string{"won"}, removed)
+	})
+
+	t.Run("should report nothing for the same rules", func(t *testing.T) {
+		dict := NewSubstitutionDictionary(map[string]string{"won  twenty": "120"})
+
+		added, changed, removed := DiffSubstitutions(dict.Entries(), map[string]string{"won twenty": "120"})
+
+		assert.Empty(t, added)
+		assert.Empty(t, changed)
+		assert.Empty(t, removed)
+	})
+}
+
+func TestContestParser_SetSubstitutions(t *testing.T) {
+	t.Run("should apply substitutions before pattern matching", func(t *testing.T) {
+		// Arrange
+		parser := NewContestParser([]string{"1234"})
+		parser.SetSubstitutions(NewSubstitutionDictionary(map[string]string{
+			"texting":               "text",
+			"won twenty three four": "1234",
+		}))
+		context := &buffer.BufferedContext{Text: "Texting WIN to won twenty three four", StartMS: 0, EndMS: 1000}
+
+		// Act
+		cue, matched := parser.CreateContestCue(context)
+
+		// Assert
+		require.True(t, matched)
+		assert.Equal(t, "WIN", cue.Details.Keyword)
+		assert.Equal(t, "1234", cue.Details.Number)
+	})
+}
@@ -0,0 +1,93 @@
+package parser
+
+import "strings"
+
+// token is one whitespace-separated word of a text. Tokens split on the ASCII whitespace of
+// regular expressions' \s, so matching over tokens finds what the contest pattern regex would.
+type token struct {
+	text  string // The word as written, punctuation included
+	start int    // Byte offset of the word in the text
+}
+
+// end returns the byte offset just past the token
+func (t token) end() int {
+	return t.start + len(t.text)
+}
+
+// tokenize splits text into tokens in a single pass. The letter-sequence detectors, number
+// extraction, and the contest pattern matcher all work from the same tokens.
+func tokenize(text string) []token {
+	tokens := make([]token, 0, len(text)/5+1)
+	start := -1
+	for i := 0; i < len(text); i++ {
+		if isSpaceByte(text[i]) {
+			if start >= 0 {
+				tokens = append(tokens, token{text: text[start:i], start: start})
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{text: text[start:], start: start})
+	}
+	return tokens
+}
+
+// isSpaceByte reports whether b is whitespace as matched by \s
+func isSpaceByte(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\f' || b == '\r'
+}
+
+// isWordByte reports whether b is a word character as matched by \w: an ASCII letter, digit, or
+// underscore. Bytes of multi-byte UTF-8 characters are never word characters, as for \b.
+func isWordByte(b byte) bool {
+	return b == '_' || isDigitByte(b) || isLetterByte(b)
+}
+
+// isDigitByte reports whether b is an ASCII digit
+func isDigitByte(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// isLetterByte reports whether b is an ASCII letter
+func isLetterByte(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+// wordChars returns s without the characters that are not word characters, e.g. "C," -> "C"
+func wordChars(s string) string {
+	for i := 0; i < len(s); i++ {
+		if !isWordByte(s[i]) {
+			var b strings.Builder
+			b.Grow(len(s))
+			b.WriteString(s[:i])
+			for ; i < len(s); i++ {
+				if isWordByte(s[i]) {
+					b.WriteByte(s[i])
+				}
+			}
+			return b.String()
+		}
+	}
+	return s
+}
+
+// numbersIn returns every run of digits in the tokens, in order
+func numbersIn(tokens []token) []string {
+	numbers := []string{}
+	for _, t := range tokens {
+		for i := 0; i < len(t.text); i++ {
+			if !isDigitByte(t.text[i]) {
+				continue
+			}
+			start := i
+			for i < len(t.text) && isDigitByte(t.text[i]) {
+				i++
+			}
+			numbers = append(numbers, t.text[start:i])
+		}
+	}
+	return numbers
+}
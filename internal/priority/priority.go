@@ -0,0 +1,93 @@
+// Package priority lowers the scheduling priority of the application and pins its FFmpeg and
+// Whisper child processes to chosen CPUs, so it can share a machine with other workloads.
+package priority
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrUnsupported is returned when the operating system does not support a setting
+var ErrUnsupported = errors.New("not supported on this operating system")
+
+// childCPUs holds the CPUs child processes are pinned to; empty leaves them unpinned
+var childCPUs struct {
+	mu   sync.Mutex
+	cpus []int
+}
+
+// SetNice sets the niceness of this process, from -20 (highest priority) to 19 (lowest). Child
+// processes started afterwards inherit it.
+func SetNice(nice int) error {
+	if nice < -20 || nice > 19 {
+		return fmt.Errorf("niceness %d is outside -20..19", nice)
+	}
+	if err := setNice(nice); err != nil {
+		return fmt.Errorf("failed to set niceness %d: %w", nice, err)
+	}
+	return nil
+}
+
+// SetChildAffinity pins child processes passed to ApplyToChild to cpus; empty leaves them unpinned
+func SetChildAffinity(cpus []int) {
+	childCPUs.mu.Lock()
+	defer childCPUs.mu.Unlock()
+	childCPUs.cpus = append([]int(nil), cpus...)
+}
+
+// ChildAffinity returns the CPUs child processes are pinned to
+func ChildAffinity() []int {
+	childCPUs.mu.Lock()
+	defer childCPUs.mu.Unlock()
+	return append([]int(nil), childCPUs.cpus...)
+}
+
+// ApplyToChild pins a started child process to the configured CPUs. It does nothing when no
+// affinity is configured.
+func ApplyToChild(pid int) error {
+	cpus := ChildAffinity()
+	if len(cpus) == 0 {
+		return nil
+	}
+	if err := setAffinity(pid, cpus); err != nil {
+		return fmt.Errorf("failed to set CPU affinity of process %d: %w", pid, err)
+	}
+	return nil
+}
+
+// ParseCPUList parses a CPU list such as "0-3,6" into sorted, distinct CPU numbers
+func ParseCPUList(list string) ([]int, error) {
+	seen := make(map[int]bool)
+	for _, part := range strings.Split(list, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		first, last, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(first))
+		if err != nil || start < 0 {
+			return nil, fmt.Errorf("invalid CPU %q in list %q", part, list)
+		}
+		end := start
+		if isRange {
+			end, err = strconv.Atoi(strings.TrimSpace(last))
+			if err != nil || end < start {
+				return nil, fmt.Errorf("invalid CPU range %q in list %q", part, list)
+			}
+		}
+		for cpu := start; cpu <= end; cpu++ {
+			seen[cpu] = true
+		}
+	}
+
+	cpus := make([]int, 0, len(seen))
+	for cpu := range seen {
+		cpus = append(cpus, cpu)
+	}
+	sort.Ints(cpus)
+	return cpus, nil
+}
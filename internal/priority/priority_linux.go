@@ -0,0 +1,43 @@
+package priority
+
+import (
+	"os"
+	"strconv"
+	"syscall"
+	"unsafe"
+)
+
+// setNice renices every thread: Linux keeps niceness per thread, and new threads and child
+// processes inherit it from the thread that creates them
+func setNice(nice int) error {
+	entries, err := os.ReadDir("/proc/self/task")
+	if err != nil {
+		return syscall.Setpriority(syscall.PRIO_PROCESS, 0, nice)
+	}
+	for _, entry := range entries {
+		tid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		if err := syscall.Setpriority(syscall.PRIO_PROCESS, tid, nice); err != nil && err != syscall.ESRCH {
+			return err
+		}
+	}
+	return nil
+}
+
+func setAffinity(pid int, cpus []int) error {
+	// cpu_set_t as a bit mask of 64-bit words
+	var mask [16]uint64
+	for _, cpu := range cpus {
+		if cpu >= len(mask)*64 {
+			return syscall.EINVAL
+		}
+		mask[cpu/64] |= 1 << (uint(cpu) % 64)
+	}
+	_, _, errno := syscall.RawSyscall(syscall.SYS_SCHED_SETAFFINITY, uintptr(pid), unsafe.Sizeof(mask), uintptr(unsafe.Pointer(&mask)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
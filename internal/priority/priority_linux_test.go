@@ -0,0 +1,76 @@
+package priority
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyToChild_Linux(t *testing.T) {
+	t.Run("should pin a child process to the configured CPUs", func(t *testing.T) {
+		// Arrange
+		cmd := exec.Command("sleep", "5")
+		require.NoError(t, cmd.Start())
+		defer func() {
+			cmd.Process.Kill()
+			cmd.Wait()
+		}()
+		SetChildAffinity([]int{0})
+		defer SetChildAffinity(nil)
+
+		// Act
+		err := ApplyToChild(cmd.Process.Pid)
+
+		// Assert
+		require.NoError(t, err)
+		status, err := os.ReadFile("/proc/" + strconv.Itoa(cmd.Process.Pid) + "/status")
+		require.NoError(t, err)
+		assert.Contains(t, string(status), "Cpus_allowed_list:\t0\n")
+	})
+
+	t.Run("should fail for CPUs beyond the mask", func(t *testing.T) {
+		SetChildAffinity([]int{4096})
+		defer SetChildAffinity(nil)
+
+		err := ApplyToChild(os.Getpid())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestSetNice_Linux(t *testing.T) {
+	t.Run("should renice every thread of the process", func(t *testing.T) {
+		// Arrange - keep the current niceness so the test process is unaffected
+		current := threadNice(t, "self")
+
+		// Act
+		err := SetNice(current)
+
+		// Assert
+		require.NoError(t, err)
+		entries, err := os.ReadDir("/proc/self/task")
+		require.NoError(t, err)
+		for _, entry := range entries {
+			assert.Equal(t, current, threadNice(t, "self/task/"+entry.Name()))
+		}
+	})
+}
+
+// threadNice reads the niceness of a /proc entry from field 19 of its stat file
+func threadNice(t *testing.T, path string) int {
+	t.Helper()
+	stat, err := os.ReadFile("/proc/" + path + "/stat")
+	if err != nil {
+		t.Skipf("stat unavailable: %v", err)
+	}
+	// Fields after the parenthesised command name start at field 3
+	fields := strings.Fields(string(stat[strings.LastIndexByte(string(stat), ')')+1:]))
+	nice, err := strconv.Atoi(fields[16])
+	require.NoError(t, err)
+	return nice
+}
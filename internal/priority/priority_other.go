@@ -0,0 +1,11 @@
+//go:build !unix
+
+package priority
+
+func setNice(nice int) error {
+	return ErrUnsupported
+}
+
+func setAffinity(pid int, cpus []int) error {
+	return ErrUnsupported
+}
@@ -0,0 +1,47 @@
+package priority
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCPUList(t *testing.T) {
+	t.Run("should expand ranges into sorted distinct CPUs", func(t *testing.T) {
+		cpus, err := ParseCPUList("6, 0-3,2")
+
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 1, 2, 3, 6}, cpus)
+	})
+
+	t.Run("should return no CPUs for an empty list", func(t *testing.T) {
+		cpus, err := ParseCPUList("")
+
+		require.NoError(t, err)
+		assert.Empty(t, cpus)
+	})
+
+	t.Run("should reject invalid entries", func(t *testing.T) {
+		for _, list := range []string{"a", "3-1", "-1", "0-x"} {
+			_, err := ParseCPUList(list)
+
+			assert.Error(t, err, list)
+		}
+	})
+}
+
+func TestSetNice(t *testing.T) {
+	t.Run("should reject niceness outside the valid range", func(t *testing.T) {
+		assert.Error(t, SetNice(20))
+		assert.Error(t, SetNice(-21))
+	})
+}
+
+func TestApplyToChild(t *testing.T) {
+	t.Run("should do nothing without a configured affinity", func(t *testing.T) {
+		SetChildAffinity(nil)
+
+		assert.NoError(t, ApplyToChild(-1))
+	})
+}
@@ -0,0 +1,14 @@
+//go:build unix && !linux
+
+package priority
+
+import "syscall"
+
+func setNice(nice int) error {
+	return syscall.Setpriority(syscall.PRIO_PROCESS, 0, nice)
+}
+
+// setAffinity is unavailable: macOS and the BSDs offer no portable way to pin another process
+func setAffinity(pid int, cpus []int) error {
+	return ErrUnsupported
+}
@@ -2,11 +2,14 @@ package processor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
-	"os/exec"
+	"sync/atomic"
 
 	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/exec"
 )
 
 // AudioProcessor manages FFmpeg process for audio format conversion
@@ -18,6 +21,8 @@ type AudioProcessor struct {
 	stdout     io.ReadCloser
 	stderr     io.ReadCloser
 	ffmpegPath string
+	tee        *PCMTee     // Optional copy of the decoded PCM for external consumers
+	running    atomic.Bool // Set while the FFmpeg process is started and not yet closed
 }
 
 // NewAudioProcessor creates a new AudioProcessor instance
@@ -29,6 +34,11 @@ func NewAudioProcessor(input io.Reader, logger *zap.Logger) *AudioProcessor {
 	}
 }
 
+// SetTee copies the PCM read from FFmpeg to tee; nil stops copying
+func (a *AudioProcessor) SetTee(tee *PCMTee) {
+	a.tee = tee
+}
+
 // StartFFmpeg initializes and starts the FFmpeg child process
 func (a *AudioProcessor) StartFFmpeg(ctx context.Context) error {
 	a.logger.Info("starting ffmpeg process for audio conversion")
@@ -43,7 +53,10 @@ func (a *AudioProcessor) StartFFmpeg(ctx context.Context) error {
 		"-", // Write to stdout
 	}
 
-	a.cmd = exec.CommandContext(ctx, a.ffmpegPath, args...)
+	a.cmd = exec.Command(ctx, a.ffmpegPath, args...)
+	a.cmd.OnAffinityError = func(err error) {
+		a.logger.Warn("failed to apply CPU affinity to ffmpeg", zap.Error(err))
+	}
 
 	// Set up pipes for communication
 	stdin, err := a.cmd.StdinPipe()
@@ -69,6 +82,8 @@ func (a *AudioProcessor) StartFFmpeg(ctx context.Context) error {
 		return fmt.Errorf("failed to start ffmpeg: %w", err)
 	}
 
+	a.running.Store(true)
+	a.tee.discardPartialSample()
 	a.logger.Info("ffmpeg process started successfully",
 		zap.Int("pid", a.cmd.Process.Pid))
 
@@ -81,18 +96,44 @@ func (a *AudioProcessor) StartFFmpeg(ctx context.Context) error {
 	return nil
 }
 
+// Name identifies the audio processor among the application's components
+func (a *AudioProcessor) Name() string {
+	return "ffmpeg"
+}
+
+// Start starts the FFmpeg child process
+func (a *AudioProcessor) Start(ctx context.Context) error {
+	return a.StartFFmpeg(ctx)
+}
+
+// Stop shuts down the FFmpeg process
+func (a *AudioProcessor) Stop(ctx context.Context) error {
+	return a.Close()
+}
+
+// Healthy returns an error unless the FFmpeg process has been started and not yet closed
+func (a *AudioProcessor) Healthy() error {
+	if !a.running.Load() {
+		return errors.New("ffmpeg process not running")
+	}
+	return nil
+}
+
 // Read implements io.Reader interface, reading converted PCM data from FFmpeg stdout
 func (a *AudioProcessor) Read(p []byte) (n int, err error) {
 	if a.stdout == nil {
 		return 0, fmt.Errorf("ffmpeg process not started")
 	}
 
-	return a.stdout.Read(p)
+	n, err = a.stdout.Read(p)
+	a.tee.Write(p[:n])
+	return n, err
 }
 
 // Close properly shuts down the FFmpeg process and cleans up resources
 func (a *AudioProcessor) Close() error {
 	a.logger.Info("closing audio processor")
+	a.running.Store(false)
 
 	// Close stdin to signal FFmpeg to finish
 	if a.stdin != nil {
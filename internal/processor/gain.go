@@ -0,0 +1,164 @@
+package processor
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// pcmSampleRate is the rate of the 16-bit mono PCM produced by FFmpeg
+const pcmSampleRate = 16000
+
+// silenceFloorDBFS is the level below which audio is treated as silence; the gain is held
+// rather than raised so pauses and noise are not amplified
+const silenceFloorDBFS = -60.0
+
+// Time constants for gain changes: reduce quickly on loud audio, raise slowly on quiet audio
+const (
+	gainAttack  = 100 * time.Millisecond
+	gainRelease = 2 * time.Second
+	levelWindow = time.Second
+)
+
+// GainConfig configures automatic gain control
+type GainConfig struct {
+	Enabled    bool    // Apply gain; when false levels are measured but audio passes through unchanged
+	TargetDBFS float64 // RMS level the gain steers towards
+	MaxGainDB  float64 // Largest boost or cut applied
+}
+
+// GainLevels reports audio levels before and after gain control
+type GainLevels struct {
+	InputDBFS  float64 // Smoothed RMS level of the decoded audio
+	OutputDBFS float64 // Smoothed RMS level passed to transcription
+	GainDB     float64 // Gain currently applied
+}
+
+// GainControl normalizes the level of 16-bit mono PCM so quietly streamed stations reach
+// transcription at a consistent level. It also measures input and output levels.
+type GainControl struct {
+	config GainConfig
+
+	mu     sync.Mutex
+	gain   float64 // Linear gain
+	input  float64 // Smoothed input mean square
+	output float64 // Smoothed output mean square
+}
+
+// NewGainControl creates a GainControl starting at unity gain
+func NewGainControl(config GainConfig) *GainControl {
+	return &GainControl{config: config, gain: 1}
+}
+
+// Enabled reports whether gain is applied
+func (g *GainControl) Enabled() bool {
+	return g.config.Enabled
+}
+
+// Levels returns the current smoothed levels
+func (g *GainControl) Levels() GainLevels {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return GainLevels{
+		InputDBFS:  meanSquareToDBFS(g.input),
+		OutputDBFS: meanSquareToDBFS(g.output),
+		GainDB:     20 * math.Log10(g.gain),
+	}
+}
+
+// Reader returns a reader applying gain control to PCM read from source
+func (g *GainControl) Reader(source io.Reader) io.Reader {
+	return &gainReader{control: g, source: source}
+}
+
+// Process applies gain to a block of whole 16-bit little-endian samples in place
+func (g *GainControl) Process(block []byte) {
+	samples := len(block) / 2
+	if samples == 0 {
+		return
+	}
+
+	var sum float64
+	for i := 0; i < samples; i++ {
+		s := float64(int16(binary.LittleEndian.Uint16(block[2*i:]))) / 32768
+		sum += s * s
+	}
+	inputMS := sum / float64(samples)
+	duration := time.Duration(samples) * time.Second / pcmSampleRate
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.input = smooth(g.input, inputMS, duration, levelWindow)
+	if !g.config.Enabled {
+		g.output = g.input
+		return
+	}
+
+	// Steer towards the gain bringing this block to the target level
+	if level := meanSquareToDBFS(inputMS); level > silenceFloorDBFS {
+		desiredDB := math.Max(-g.config.MaxGainDB, math.Min(g.config.MaxGainDB, g.config.TargetDBFS-level))
+		desired := math.Pow(10, desiredDB/20)
+		tau := gainRelease
+		if desired < g.gain {
+			tau = gainAttack
+		}
+		g.gain = smooth(g.gain, desired, duration, tau)
+	}
+
+	sum = 0
+	for i := 0; i < samples; i++ {
+		s := float64(int16(binary.LittleEndian.Uint16(block[2*i:]))) * g.gain
+		s = math.Max(math.MinInt16, math.Min(math.MaxInt16, s))
+		binary.LittleEndian.PutUint16(block[2*i:], uint16(int16(s)))
+		s /= 32768
+		sum += s * s
+	}
+	g.output = smooth(g.output, sum/float64(samples), duration, levelWindow)
+}
+
+// gainReader applies a GainControl to a PCM stream, holding back a trailing odd byte until
+// the rest of its sample arrives
+type gainReader struct {
+	control  *GainControl
+	source   io.Reader
+	carry    byte
+	hasCarry bool
+}
+
+// Read implements io.Reader
+func (r *gainReader) Read(p []byte) (int, error) {
+	start := 0
+	if r.hasCarry && len(p) > 0 {
+		p[0] = r.carry
+		start = 1
+	}
+
+	n, err := r.source.Read(p[start:])
+	n += start
+	r.hasCarry = false
+
+	whole := n &^ 1
+	if whole < n {
+		r.carry = p[n-1]
+		r.hasCarry = true
+	}
+	r.control.Process(p[:whole])
+	return whole, err
+}
+
+// smooth moves current towards target by the fraction of time constant tau that elapsed
+func smooth(current, target float64, elapsed, tau time.Duration) float64 {
+	alpha := 1 - math.Exp(-float64(elapsed)/float64(tau))
+	return current + (target-current)*alpha
+}
+
+// meanSquareToDBFS converts a mean square sample level to dBFS, floored at -100
+func meanSquareToDBFS(meanSquare float64) float64 {
+	if meanSquare <= 1e-10 {
+		return -100
+	}
+	return 10 * math.Log10(meanSquare)
+}
@@ -0,0 +1,110 @@
+package processor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sinePCM returns seconds of a 440Hz sine at the given peak amplitude (0-1) as 16-bit PCM
+func sinePCM(amplitude float64, seconds float64) []byte {
+	samples := int(seconds * pcmSampleRate)
+	data := make([]byte, 2*samples)
+	for i := 0; i < samples; i++ {
+		s := amplitude * 32767 * math.Sin(2*math.Pi*440*float64(i)/pcmSampleRate)
+		binary.LittleEndian.PutUint16(data[2*i:], uint16(int16(s)))
+	}
+	return data
+}
+
+// processInBlocks feeds data through the control in 100ms blocks
+func processInBlocks(g *GainControl, data []byte) {
+	const block = 2 * pcmSampleRate / 10
+	for start := 0; start < len(data); start += block {
+		g.Process(data[start:min(start+block, len(data))])
+	}
+}
+
+func TestGainControl_Process(t *testing.T) {
+	t.Run("should raise a quiet station towards the target level", func(t *testing.T) {
+		// Arrange - a sine peaking at 1% is about -43 dBFS RMS
+		g := NewGainControl(GainConfig{Enabled: true, TargetDBFS: -20, MaxGainDB: 30})
+		data := sinePCM(0.01, 10)
+
+		// Act
+		processInBlocks(g, data)
+
+		// Assert
+		levels := g.Levels()
+		assert.InDelta(t, -43, levels.InputDBFS, 1)
+		assert.InDelta(t, -20, levels.OutputDBFS, 1.5)
+		assert.InDelta(t, 23, levels.GainDB, 1.5)
+	})
+
+	t.Run("should limit the gain to the maximum", func(t *testing.T) {
+		g := NewGainControl(GainConfig{Enabled: true, TargetDBFS: -20, MaxGainDB: 10})
+
+		processInBlocks(g, sinePCM(0.01, 10))
+
+		assert.InDelta(t, 10, g.Levels().GainDB, 0.5)
+	})
+
+	t.Run("should not amplify silence", func(t *testing.T) {
+		g := NewGainControl(GainConfig{Enabled: true, TargetDBFS: -20, MaxGainDB: 30})
+
+		processInBlocks(g, make([]byte, 2*pcmSampleRate))
+
+		assert.Equal(t, 0.0, g.Levels().GainDB)
+	})
+
+	t.Run("should only measure levels when disabled", func(t *testing.T) {
+		// Arrange
+		g := NewGainControl(GainConfig{Enabled: false, TargetDBFS: -20, MaxGainDB: 30})
+		data := sinePCM(0.01, 3)
+		original := append([]byte(nil), data...)
+
+		// Act
+		processInBlocks(g, data)
+
+		// Assert
+		assert.Equal(t, original, data, "should pass audio through unchanged")
+		levels := g.Levels()
+		assert.InDelta(t, -43, levels.InputDBFS, 1)
+		assert.Equal(t, levels.InputDBFS, levels.OutputDBFS)
+		assert.Equal(t, 0.0, levels.GainDB)
+	})
+}
+
+// oddReader returns data in chunks of an odd number of bytes
+type oddReader struct {
+	data []byte
+}
+
+func (r *oddReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p[:min(len(p), 7)], r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestGainControl_Reader(t *testing.T) {
+	t.Run("should keep samples intact across reads split mid-sample", func(t *testing.T) {
+		// Arrange
+		data := sinePCM(0.5, 0.01)
+		g := NewGainControl(GainConfig{Enabled: false})
+
+		// Act
+		out, err := io.ReadAll(g.Reader(&oddReader{data: append([]byte(nil), data...)}))
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, bytes.Equal(data, out))
+	})
+}
@@ -0,0 +1,357 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/exec"
+)
+
+// Tee target kinds, written before the target as in "unix:/run/pcm.sock"
+const (
+	TeeUnix = "unix" // Serve a unix socket; every connected client receives the PCM
+	TeeFIFO = "fifo" // Write to a named pipe, created if missing, while a reader has it open
+	TeeExec = "exec" // Pipe to the standard input of a command, restarted if it exits
+)
+
+// teeRetryInterval is how long the tee waits before reopening a named pipe or restarting a command
+const teeRetryInterval = time.Second
+
+// TeeConfig configures copying decoded PCM to an external consumer
+type TeeConfig struct {
+	Target      string // "unix:/path/pcm.sock", "fifo:/path/pcm", or "exec:command args"
+	BufferBytes int    // PCM queued for each consumer before its audio is dropped
+}
+
+// TeeStats reports the consumers of a PCMTee and the audio they were sent
+type TeeStats struct {
+	Consumers    int
+	BytesWritten uint64 // PCM written to consumers
+	BytesDropped uint64 // PCM dropped because a consumer fell behind
+}
+
+// PCMTee copies the decoded 16-bit mono PCM to external tools such as loudness monitors or
+// archival encoders, so they need not open a second stream connection. Writes never block:
+// a consumer that falls behind loses audio rather than holding up transcription.
+type PCMTee struct {
+	kind        string
+	target      string
+	bufferBytes int64
+	logger      *zap.Logger
+
+	mu        sync.Mutex
+	consumers map[*teeConsumer]struct{}
+	carry     []byte // Trailing byte of an odd-length write, held until the rest of its sample
+	cancel    context.CancelFunc
+	listener  net.Listener
+	done      sync.WaitGroup
+
+	running atomic.Bool
+	written atomic.Uint64
+	dropped atomic.Uint64
+}
+
+// teeConsumer is one destination of the PCM with its own queue
+type teeConsumer struct {
+	w      io.WriteCloser
+	queue  chan []byte
+	queued atomic.Int64 // Bytes waiting in queue
+	done   chan struct{}
+}
+
+// ParseTeeTarget splits a tee target into its kind and the path or command line
+func ParseTeeTarget(target string) (kind, rest string, err error) {
+	kind, rest, ok := strings.Cut(strings.TrimSpace(target), ":")
+	rest = strings.TrimSpace(rest)
+	if !ok || rest == "" {
+		return "", "", fmt.Errorf("invalid tee target %q (expected unix:PATH, fifo:PATH, or exec:COMMAND)", target)
+	}
+	switch kind {
+	case TeeUnix, TeeFIFO, TeeExec:
+		return kind, rest, nil
+	}
+	return "", "", fmt.Errorf("unknown tee target kind %q (expected %s, %s, or %s)", kind, TeeUnix, TeeFIFO, TeeExec)
+}
+
+// NewPCMTee creates a PCMTee for config; nothing is opened until Start
+func NewPCMTee(config TeeConfig, logger *zap.Logger) (*PCMTee, error) {
+	kind, target, err := ParseTeeTarget(config.Target)
+	if err != nil {
+		return nil, err
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	if config.BufferBytes <= 0 {
+		config.BufferBytes = 256 * 1024
+	}
+	return &PCMTee{
+		kind:        kind,
+		target:      target,
+		bufferBytes: int64(config.BufferBytes),
+		logger:      logger,
+		consumers:   make(map[*teeConsumer]struct{}),
+	}, nil
+}
+
+// Name identifies the tee among the application's components
+func (t *PCMTee) Name() string {
+	return "pcm_tee"
+}
+
+// Start opens the tee target: it listens on the socket, creates the named pipe, or starts the
+// command, and keeps reopening the pipe and restarting the command until Stop
+func (t *PCMTee) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	switch t.kind {
+	case TeeUnix:
+		listener, err := listenUnix(t.target)
+		if err != nil {
+			cancel()
+			return err
+		}
+		t.listener = listener
+		t.run(func() { t.accept(ctx, listener) })
+	case TeeFIFO:
+		if err := makeFIFO(t.target); err != nil {
+			cancel()
+			return err
+		}
+		t.run(func() { t.openFIFO(ctx) })
+	case TeeExec:
+		t.run(func() { t.runCommand(ctx) })
+	}
+
+	t.cancel = cancel
+	t.running.Store(true)
+	t.logger.Info("teeing decoded PCM",
+		zap.String("kind", t.kind),
+		zap.String("target", t.target))
+	return nil
+}
+
+// Stop closes the target and every consumer
+func (t *PCMTee) Stop(ctx context.Context) error {
+	if !t.running.Swap(false) {
+		return nil
+	}
+	t.cancel()
+	if t.listener != nil {
+		t.listener.Close()
+	}
+	t.mu.Lock()
+	for c := range t.consumers {
+		c.w.Close()
+	}
+	t.mu.Unlock()
+	t.done.Wait()
+	return nil
+}
+
+// Healthy returns an error unless the tee has been started and not yet stopped
+func (t *PCMTee) Healthy() error {
+	if !t.running.Load() {
+		return errors.New("pcm tee not running")
+	}
+	return nil
+}
+
+// Stats returns the current consumers and the audio written to and dropped for them
+func (t *PCMTee) Stats() TeeStats {
+	t.mu.Lock()
+	consumers := len(t.consumers)
+	t.mu.Unlock()
+	return TeeStats{
+		Consumers:    consumers,
+		BytesWritten: t.written.Load(),
+		BytesDropped: t.dropped.Load(),
+	}
+}
+
+// Write queues PCM for every consumer without blocking. Only whole samples are queued, so a
+// consumer whose audio is dropped stays sample-aligned. A nil PCMTee discards the PCM.
+func (t *PCMTee) Write(p []byte) {
+	if t == nil || len(p) == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	chunk := make([]byte, 0, len(t.carry)+len(p))
+	chunk = append(append(chunk, t.carry...), p...)
+	whole := len(chunk) &^ 1
+	t.carry = append(t.carry[:0], chunk[whole:]...)
+	chunk = chunk[:whole]
+	if len(chunk) == 0 {
+		return
+	}
+
+	for c := range t.consumers {
+		if c.queued.Load()+int64(len(chunk)) > t.bufferBytes {
+			t.dropped.Add(uint64(len(chunk)))
+			continue
+		}
+		select {
+		case c.queue <- chunk:
+			c.queued.Add(int64(len(chunk)))
+		default:
+			t.dropped.Add(uint64(len(chunk)))
+		}
+	}
+}
+
+// discardPartialSample drops a held odd byte, as when a new FFmpeg process starts its output
+func (t *PCMTee) discardPartialSample() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.carry = t.carry[:0]
+	t.mu.Unlock()
+}
+
+// run starts f on a goroutine Stop waits for
+func (t *PCMTee) run(f func()) {
+	t.done.Add(1)
+	go func() {
+		defer t.done.Done()
+		f()
+	}()
+}
+
+// add starts sending the PCM to w until a write fails or the tee stops
+func (t *PCMTee) add(ctx context.Context, w io.WriteCloser) *teeConsumer {
+	c := &teeConsumer{w: w, queue: make(chan []byte, 256), done: make(chan struct{})}
+	t.mu.Lock()
+	t.consumers[c] = struct{}{}
+	t.mu.Unlock()
+
+	t.run(func() {
+		defer close(c.done)
+		defer func() {
+			t.mu.Lock()
+			delete(t.consumers, c)
+			t.mu.Unlock()
+			w.Close()
+		}()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case chunk := <-c.queue:
+				c.queued.Add(-int64(len(chunk)))
+				if _, err := w.Write(chunk); err != nil {
+					t.logger.Debug("pcm tee consumer closed", zap.String("target", t.target), zap.Error(err))
+					return
+				}
+				t.written.Add(uint64(len(chunk)))
+			}
+		}
+	})
+	return c
+}
+
+// accept adds every client connecting to the socket as a consumer
+func (t *PCMTee) accept(ctx context.Context, listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) || ctx.Err() != nil {
+				return
+			}
+			t.logger.Warn("pcm tee failed to accept a connection", zap.Error(err))
+			if !waitRetry(ctx) {
+				return
+			}
+			continue
+		}
+		t.logger.Info("pcm tee consumer connected", zap.String("target", t.target))
+		t.add(ctx, conn)
+	}
+}
+
+// openFIFO writes to the named pipe whenever a reader has it open
+func (t *PCMTee) openFIFO(ctx context.Context) {
+	for {
+		f, err := openFIFOWriter(t.target)
+		if err == nil {
+			t.logger.Info("pcm tee named pipe opened", zap.String("target", t.target))
+			c := t.add(ctx, f)
+			select {
+			case <-c.done:
+			case <-ctx.Done():
+				return
+			}
+		} else if !errors.Is(err, errNoFIFOReader) {
+			t.logger.Warn("pcm tee failed to open named pipe", zap.String("target", t.target), zap.Error(err))
+		}
+		if !waitRetry(ctx) {
+			return
+		}
+	}
+}
+
+// runCommand pipes the PCM to the command, restarting it whenever it exits
+func (t *PCMTee) runCommand(ctx context.Context) {
+	args := strings.Fields(t.target)
+	for {
+		cmd := exec.Command(ctx, args[0], args[1:]...)
+		stdin, err := cmd.StdinPipe()
+		if err == nil {
+			err = cmd.Start()
+		}
+		if err != nil {
+			t.logger.Warn("pcm tee failed to start command", zap.String("command", t.target), zap.Error(err))
+		} else {
+			t.logger.Info("pcm tee command started",
+				zap.String("command", t.target),
+				zap.Int("pid", cmd.Process.Pid))
+			c := t.add(ctx, stdin)
+			select {
+			case <-c.done:
+			case <-ctx.Done():
+			}
+			if err := cmd.Wait(); err != nil && ctx.Err() == nil {
+				t.logger.Warn("pcm tee command exited", zap.String("command", t.target), zap.Error(err))
+			}
+		}
+		if !waitRetry(ctx) {
+			return
+		}
+	}
+}
+
+// listenUnix listens on a unix socket at path, replacing a socket left behind by a previous run
+func listenUnix(path string) (net.Listener, error) {
+	if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		os.Remove(path)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on pcm tee socket: %w", err)
+	}
+	return listener, nil
+}
+
+// waitRetry waits teeRetryInterval, returning false if ctx ends first
+func waitRetry(ctx context.Context) bool {
+	timer := time.NewTimer(teeRetryInterval)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,19 @@
+//go:build !unix
+
+package processor
+
+import (
+	"errors"
+	"os"
+)
+
+// Named pipes aren't supported on this platform
+var errNoFIFOReader = errors.New("named pipes are not supported on this platform")
+
+func makeFIFO(path string) error {
+	return errNoFIFOReader
+}
+
+func openFIFOWriter(path string) (*os.File, error) {
+	return nil, errNoFIFOReader
+}
@@ -0,0 +1,145 @@
+package processor
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParseTeeTarget(t *testing.T) {
+	t.Run("should split the kind from the path or command", func(t *testing.T) {
+		kind, target, err := ParseTeeTarget("exec:ffmpeg -f s16le -i - out.mp3")
+
+		require.NoError(t, err)
+		assert.Equal(t, TeeExec, kind)
+		assert.Equal(t, "ffmpeg -f s16le -i - out.mp3", target)
+	})
+
+	t.Run("should reject unknown kinds and missing targets", func(t *testing.T) {
+		for _, target := range []string{"tcp:localhost:9000", "unix:", "/tmp/pcm.sock"} {
+			_, _, err := ParseTeeTarget(target)
+			assert.Error(t, err, target)
+		}
+	})
+}
+
+func TestPCMTee_UnixSocket(t *testing.T) {
+	t.Run("should send the PCM to every connected client", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "pcm.sock")
+		tee, err := NewPCMTee(TeeConfig{Target: "unix:" + path}, zap.NewNop())
+		require.NoError(t, err)
+		require.NoError(t, tee.Start(context.Background()))
+		defer tee.Stop(context.Background())
+
+		first, err := net.Dial("unix", path)
+		require.NoError(t, err)
+		defer first.Close()
+		second, err := net.Dial("unix", path)
+		require.NoError(t, err)
+		defer second.Close()
+		require.Eventually(t, func() bool { return tee.Stats().Consumers == 2 }, time.Second, 10*time.Millisecond)
+
+		// Act
+		tee.Write([]byte{1, 2, 3})
+		tee.Write([]byte{4})
+
+		// Assert
+		for _, conn := range []net.Conn{first, second} {
+			received := make([]byte, 4)
+			conn.SetReadDeadline(time.Now().Add(time.Second))
+			_, err := io.ReadFull(conn, received)
+			require.NoError(t, err)
+			assert.Equal(t, []byte{1, 2, 3, 4}, received)
+		}
+		assert.Eventually(t, func() bool { return tee.Stats().BytesWritten == 8 }, time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestPCMTee_FIFO(t *testing.T) {
+	t.Run("should create the named pipe and write to its reader", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "pcm")
+		tee, err := NewPCMTee(TeeConfig{Target: "fifo:" + path}, zap.NewNop())
+		require.NoError(t, err)
+		require.NoError(t, tee.Start(context.Background()))
+		defer tee.Stop(context.Background())
+
+		reader, err := os.OpenFile(path, os.O_RDONLY, 0)
+		require.NoError(t, err)
+		defer reader.Close()
+		require.Eventually(t, func() bool { return tee.Stats().Consumers == 1 }, 3*time.Second, 10*time.Millisecond)
+
+		// Act
+		tee.Write([]byte{5, 6})
+
+		// Assert
+		received := make([]byte, 2)
+		_, err = io.ReadFull(reader, received)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{5, 6}, received)
+	})
+
+	t.Run("should refuse a path that is not a named pipe", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "pcm")
+		require.NoError(t, os.WriteFile(path, nil, 0o600))
+		tee, err := NewPCMTee(TeeConfig{Target: "fifo:" + path}, zap.NewNop())
+		require.NoError(t, err)
+
+		assert.ErrorContains(t, tee.Start(context.Background()), "not a named pipe")
+	})
+}
+
+func TestPCMTee_Exec(t *testing.T) {
+	t.Run("should pipe the PCM to the command's standard input", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "pcm.raw")
+		tee, err := NewPCMTee(TeeConfig{Target: "exec:tee " + path}, zap.NewNop())
+		require.NoError(t, err)
+		require.NoError(t, tee.Start(context.Background()))
+		require.Eventually(t, func() bool { return tee.Stats().Consumers == 1 }, time.Second, 10*time.Millisecond)
+
+		// Act
+		tee.Write([]byte{7, 8, 9, 10})
+		require.Eventually(t, func() bool { return tee.Stats().BytesWritten == 4 }, time.Second, 10*time.Millisecond)
+		require.NoError(t, tee.Stop(context.Background()))
+
+		// Assert
+		written, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{7, 8, 9, 10}, written)
+	})
+}
+
+func TestPCMTee_Write(t *testing.T) {
+	t.Run("should drop whole samples for a consumer that falls behind", func(t *testing.T) {
+		// Arrange
+		tee, err := NewPCMTee(TeeConfig{Target: "unix:/unused", BufferBytes: 4}, zap.NewNop())
+		require.NoError(t, err)
+		consumer := &teeConsumer{queue: make(chan []byte, 8)}
+		tee.consumers[consumer] = struct{}{}
+
+		// Act
+		tee.Write([]byte{1, 2, 3})
+		tee.Write([]byte{4, 5, 6})
+
+		// Assert
+		assert.Equal(t, []byte{1, 2}, <-consumer.queue)
+		assert.Equal(t, uint64(4), tee.Stats().BytesDropped)
+		assert.Empty(t, consumer.queue)
+	})
+
+	t.Run("should discard the PCM when nil", func(t *testing.T) {
+		var tee *PCMTee
+
+		assert.NotPanics(t, func() { tee.Write([]byte{1, 2}) })
+	})
+}
@@ -0,0 +1,37 @@
+//go:build unix
+
+package processor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// errNoFIFOReader reports a named pipe no process has open for reading yet
+var errNoFIFOReader = errors.New("no reader on named pipe")
+
+// makeFIFO creates the named pipe at path unless it already exists
+func makeFIFO(path string) error {
+	info, err := os.Stat(path)
+	if err == nil {
+		if info.Mode()&os.ModeNamedPipe == 0 {
+			return fmt.Errorf("pcm tee path %s exists and is not a named pipe", path)
+		}
+		return nil
+	}
+	if err := syscall.Mkfifo(path, 0o660); err != nil {
+		return fmt.Errorf("failed to create pcm tee named pipe: %w", err)
+	}
+	return nil
+}
+
+// openFIFOWriter opens the named pipe for writing without waiting for a reader
+func openFIFOWriter(path string) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|syscall.O_NONBLOCK, 0)
+	if errors.Is(err, syscall.ENXIO) {
+		return nil, errNoFIFOReader
+	}
+	return f, err
+}
@@ -0,0 +1,252 @@
+// Package program knows the station's program schedule: which show is on the air at a given
+// time. Schedules come from the configuration or a remote JSON document that is fetched
+// periodically, so cues can name the show they were heard in and pattern matching can be
+// relaxed during contest-heavy shows such as morning drive.
+package program
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Program is a show on the station's schedule
+type Program struct {
+	Name            string   `json:"name"`
+	Days            []string `json:"days,omitempty"` // mon..sun, weekdays, or weekends; empty airs every day
+	Start           string   `json:"start"`          // Local time the show starts, "HH:MM"
+	End             string   `json:"end"`            // Local time the show ends, "HH:MM"; before start when it runs past midnight
+	ContestHeavy    bool     `json:"contest_heavy,omitempty"`
+	ConfidenceBoost float64  `json:"confidence_boost,omitempty"` // Lowers the minimum match confidence by this much; negative raises it
+}
+
+// slot is a parsed Program
+type slot struct {
+	program Program
+	window  Window
+}
+
+// Window is a weekly recurring range of station-local wall-clock time, such as a show's airtime.
+// Times are compared on the wall clock in the window's location, so a window keeps its local
+// hours across daylight saving changes. A window starting in the hour skipped when clocks spring
+// forward opens as the clocks jump past its start (one wholly inside that hour stays closed that
+// day), and one covering the hour repeated when they fall back is open through both passes.
+type Window struct {
+	days     [7]bool // Indexed by time.Weekday
+	start    int     // Minutes after midnight
+	end      int
+	location *time.Location
+}
+
+// ParseWindow parses a window from start to end given as "HH:MM" on days (mon..sun, weekdays,
+// or weekends; empty is every day) in location (nil uses the local zone). A window ending
+// before it starts runs past midnight into the next day.
+func ParseWindow(days []string, start, end string, location *time.Location) (Window, error) {
+	if location == nil {
+		location = time.Local
+	}
+	w := Window{location: location}
+	var err error
+	if w.start, err = parseClock(start); err != nil {
+		return Window{}, fmt.Errorf("invalid start: %w", err)
+	}
+	if w.end, err = parseClock(end); err != nil {
+		return Window{}, fmt.Errorf("invalid end: %w", err)
+	}
+	if w.start == w.end {
+		return Window{}, fmt.Errorf("starts and ends at %s", start)
+	}
+	if len(days) == 0 {
+		for i := range w.days {
+			w.days[i] = true
+		}
+		return w, nil
+	}
+	for _, day := range days {
+		weekdays, ok := dayNames[strings.ToLower(strings.TrimSpace(day))]
+		if !ok {
+			return Window{}, fmt.Errorf("unknown day %q", day)
+		}
+		for _, weekday := range weekdays {
+			w.days[weekday] = true
+		}
+	}
+	return w, nil
+}
+
+// Contains reports whether t falls inside the window
+func (w Window) Contains(t time.Time) bool {
+	local := t.In(w.location)
+	minute := local.Hour()*60 + local.Minute()
+	today := local.Weekday()
+	if w.start < w.end {
+		return w.days[today] && minute >= w.start && minute < w.end
+	}
+	// Runs past midnight: the evening part is today's, the early morning part started yesterday
+	yesterday := (today + 6) % 7
+	return (w.days[today] && minute >= w.start) || (w.days[yesterday] && minute < w.end)
+}
+
+// Schedule reports which program is on the air. It is safe for concurrent use, and its programs
+// can be replaced while it is in use.
+type Schedule struct {
+	location *time.Location
+	mu       sync.RWMutex
+	slots    []slot
+}
+
+// NewSchedule creates a Schedule of programs airing at local times in location (nil uses the
+// local zone). It fails when a program's days or times are invalid.
+func NewSchedule(programs []Program, location *time.Location) (*Schedule, error) {
+	if location == nil {
+		location = time.Local
+	}
+	s := &Schedule{location: location}
+	if err := s.Replace(programs); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Replace swaps in a new list of programs, keeping the current ones when any is invalid
+func (s *Schedule) Replace(programs []Program) error {
+	slots := make([]slot, 0, len(programs))
+	for _, p := range programs {
+		parsed, err := parseProgram(p, s.location)
+		if err != nil {
+			return err
+		}
+		slots = append(slots, parsed)
+	}
+	s.mu.Lock()
+	s.slots = slots
+	s.mu.Unlock()
+	return nil
+}
+
+// Len returns how many programs are on the schedule
+func (s *Schedule) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.slots)
+}
+
+// At returns the program on the air at t. When programs overlap, the one listed first wins.
+func (s *Schedule) At(t time.Time) (Program, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, sl := range s.slots {
+		if sl.window.Contains(t) {
+			return sl.program, true
+		}
+	}
+	return Program{}, false
+}
+
+func parseProgram(p Program, location *time.Location) (slot, error) {
+	if strings.TrimSpace(p.Name) == "" {
+		return slot{}, fmt.Errorf("program without a name")
+	}
+	window, err := ParseWindow(p.Days, p.Start, p.End, location)
+	if err != nil {
+		return slot{}, fmt.Errorf("program %s: %w", p.Name, err)
+	}
+	return slot{program: p, window: window}, nil
+}
+
+// dayNames maps the day names a program can air on to weekdays
+var dayNames = map[string][]time.Weekday{
+	"sun": {time.Sunday}, "sunday": {time.Sunday},
+	"mon": {time.Monday}, "monday": {time.Monday},
+	"tue": {time.Tuesday}, "tuesday": {time.Tuesday},
+	"wed": {time.Wednesday}, "wednesday": {time.Wednesday},
+	"thu": {time.Thursday}, "thursday": {time.Thursday},
+	"fri": {time.Friday}, "friday": {time.Friday},
+	"sat": {time.Saturday}, "saturday": {time.Saturday},
+	"weekdays": {time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	"weekends": {time.Saturday, time.Sunday},
+}
+
+// parseClock parses "HH:MM" into minutes after midnight; 24:00 ends a show at midnight
+func parseClock(clock string) (int, error) {
+	hours, minutes, ok := strings.Cut(strings.TrimSpace(clock), ":")
+	h, errH := strconv.Atoi(hours)
+	m, errM := strconv.Atoi(minutes)
+	if !ok || errH != nil || errM != nil || h < 0 || m < 0 || m > 59 || h > 24 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("expected HH:MM, got %q", clock)
+	}
+	return h*60 + m, nil
+}
+
+// Fetch downloads a schedule document from url: a JSON array of programs, or an object with
+// the array under "programs"
+func Fetch(ctx context.Context, client *http.Client, url string) ([]Program, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create schedule request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch schedule: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch schedule: status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schedule: %w", err)
+	}
+
+	var programs []Program
+	if err := json.Unmarshal(data, &programs); err == nil {
+		return programs, nil
+	}
+	var document struct {
+		Programs []Program `json:"programs"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse schedule: %w", err)
+	}
+	return document.Programs, nil
+}
+
+// Refresh replaces the schedule's programs with the ones published at url
+func (s *Schedule) Refresh(ctx context.Context, client *http.Client, url string) error {
+	programs, err := Fetch(ctx, client, url)
+	if err != nil {
+		return err
+	}
+	return s.Replace(programs)
+}
+
+// RunRefresh refreshes the schedule from url every interval until ctx is cancelled. A failed
+// refresh keeps the programs already loaded.
+func (s *Schedule) RunRefresh(ctx context.Context, url string, interval time.Duration, logger *zap.Logger) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := s.Refresh(ctx, client, url); err != nil {
+			logger.Warn("failed to refresh program schedule, keeping the current one",
+				zap.String("url", url),
+				zap.Error(err))
+			continue
+		}
+		logger.Debug("refreshed program schedule", zap.Int("programs", s.Len()))
+	}
+}
@@ -0,0 +1,194 @@
+package program
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// at returns 2025-06-02 (a Monday) plus dayOffset days at hh:mm UTC
+func at(dayOffset, hh, mm int) time.Time {
+	return time.Date(2025, 6, 2+dayOffset, hh, mm, 0, 0, time.UTC)
+}
+
+func testPrograms() []Program {
+	return []Program{
+		{Name: "Morning Drive", Days: []string{"weekdays"}, Start: "06:00", End: "10:00", ContestHeavy: true, ConfidenceBoost: 0.1},
+		{Name: "Late Night", Start: "22:00", End: "02:00"},
+		{Name: "Weekend Countdown", Days: []string{"sat", "Sunday"}, Start: "09:00", End: "12:00"},
+	}
+}
+
+func TestSchedule_At(t *testing.T) {
+	schedule, err := NewSchedule(testPrograms(), time.UTC)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want string
+	}{
+		{"weekday show at its start", at(0, 6, 0), "Morning Drive"},
+		{"weekday show just before its end", at(0, 9, 59), "Morning Drive"},
+		{"nothing scheduled", at(0, 10, 0), ""},
+		{"weekday show not aired on weekends", at(5, 7, 0), ""},
+		{"weekend show", at(6, 10, 30), "Weekend Countdown"},
+		{"overnight show before midnight", at(0, 23, 0), "Late Night"},
+		{"overnight show after midnight", at(1, 1, 30), "Late Night"},
+		{"overnight show ended", at(1, 2, 0), ""},
+	}
+	for _, tt := range tests {
+		t.Run("should find the "+tt.name, func(t *testing.T) {
+			show, ok := schedule.At(tt.at)
+
+			assert.Equal(t, tt.want != "", ok)
+			assert.Equal(t, tt.want, show.Name)
+		})
+	}
+
+	t.Run("should use the schedule's time zone", func(t *testing.T) {
+		chicago, err := time.LoadLocation("America/Chicago")
+		require.NoError(t, err)
+		local, err := NewSchedule(testPrograms(), chicago)
+		require.NoError(t, err)
+
+		// 12:00 UTC is 07:00 in Chicago in June
+		show, ok := local.At(at(0, 12, 0))
+
+		assert.True(t, ok)
+		assert.Equal(t, "Morning Drive", show.Name)
+		assert.True(t, show.ContestHeavy)
+	})
+
+	t.Run("should allow a show running until midnight", func(t *testing.T) {
+		s, err := NewSchedule([]Program{{Name: "Evening", Start: "19:00", End: "24:00"}}, time.UTC)
+		require.NoError(t, err)
+
+		_, late := s.At(at(0, 23, 59))
+		_, after := s.At(at(1, 0, 0))
+
+		assert.True(t, late)
+		assert.False(t, after)
+	})
+}
+
+func TestWindow_Contains(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	utc := func(month time.Month, day, hh, mm int) time.Time {
+		return time.Date(2026, month, day, hh, mm, 0, 0, time.UTC)
+	}
+
+	// Clocks spring forward from 02:00 EST to 03:00 EDT on 2026-03-08 and fall back from
+	// 02:00 EDT to 01:00 EST on 2026-11-01
+	tests := []struct {
+		name       string
+		days       []string
+		start, end string
+		at         time.Time
+		want       bool
+	}{
+		{"morning window the day clocks spring forward", nil, "06:00", "10:00", utc(3, 8, 10, 30), true},
+		{"morning window closed an hour earlier in UTC after spring forward", nil, "06:00", "10:00", utc(3, 8, 14, 15), false},
+		{"morning window the day clocks fall back", nil, "06:00", "10:00", utc(11, 1, 11, 30), true},
+		{"morning window not yet open an hour later in UTC after fall back", nil, "06:00", "10:00", utc(11, 1, 10, 30), false},
+		{"window starting in the skipped hour once clocks jump past it", nil, "02:30", "04:00", utc(3, 8, 7, 0), true},
+		{"window starting in the skipped hour just before the jump", nil, "02:30", "04:00", utc(3, 8, 6, 59), false},
+		{"window over the repeated hour on its first pass", nil, "01:00", "02:00", utc(11, 1, 5, 30), true},
+		{"window over the repeated hour on its second pass", nil, "01:00", "02:00", utc(11, 1, 6, 30), true},
+		{"window over the repeated hour once it is over", nil, "01:00", "02:00", utc(11, 1, 7, 0), false},
+		{"overnight window from the day before the clocks change", []string{"sat"}, "22:00", "02:00", utc(3, 8, 6, 30), true},
+		{"overnight window ended by the clocks springing past its end", []string{"sat"}, "22:00", "02:00", utc(3, 8, 7, 0), false},
+	}
+	for _, tt := range tests {
+		t.Run("should evaluate the "+tt.name+" in station time", func(t *testing.T) {
+			window, err := ParseWindow(tt.days, tt.start, tt.end, newYork)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, window.Contains(tt.at))
+		})
+	}
+}
+
+func TestNewSchedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		program Program
+		want    string
+	}{
+		{"a missing name", Program{Start: "06:00", End: "10:00"}, "without a name"},
+		{"an invalid start", Program{Name: "A", Start: "6am", End: "10:00"}, "invalid start"},
+		{"an invalid end", Program{Name: "A", Start: "06:00", End: "25:00"}, "invalid end"},
+		{"an empty slot", Program{Name: "A", Start: "06:00", End: "06:00"}, "starts and ends"},
+		{"an unknown day", Program{Name: "A", Days: []string{"funday"}, Start: "06:00", End: "10:00"}, "unknown day"},
+	}
+	for _, tt := range tests {
+		t.Run("should reject "+tt.name, func(t *testing.T) {
+			_, err := NewSchedule([]Program{tt.program}, time.UTC)
+
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestSchedule_Refresh(t *testing.T) {
+	t.Run("should load programs from a JSON array or document", func(t *testing.T) {
+		for _, body := range []string{
+			`[{"name":"Morning Drive","days":["mon"],"start":"06:00","end":"10:00","contest_heavy":true}]`,
+			`{"station":"KXYZ","programs":[{"name":"Morning Drive","days":["mon"],"start":"06:00","end":"10:00","contest_heavy":true}]}`,
+		} {
+			// Arrange
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, body)
+			}))
+			schedule, err := NewSchedule(nil, time.UTC)
+			require.NoError(t, err)
+
+			// Act
+			err = schedule.Refresh(context.Background(), server.Client(), server.URL)
+			server.Close()
+
+			// Assert
+			require.NoError(t, err)
+			show, ok := schedule.At(at(0, 8, 0))
+			assert.True(t, ok)
+			assert.True(t, show.ContestHeavy)
+		}
+	})
+
+	t.Run("should keep the current programs when the new schedule is invalid", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `[{"name":"Broken","start":"later","end":"10:00"}]`)
+		}))
+		defer server.Close()
+		schedule, err := NewSchedule(testPrograms(), time.UTC)
+		require.NoError(t, err)
+
+		// Act
+		err = schedule.Refresh(context.Background(), server.Client(), server.URL)
+
+		// Assert
+		assert.ErrorContains(t, err, "invalid start")
+		assert.Equal(t, 3, schedule.Len())
+	})
+
+	t.Run("should report failed fetches", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+		schedule, err := NewSchedule(nil, time.UTC)
+		require.NoError(t, err)
+
+		err = schedule.Refresh(context.Background(), server.Client(), server.URL)
+
+		assert.ErrorContains(t, err, "status 404")
+	})
+}
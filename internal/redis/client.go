@@ -0,0 +1,309 @@
+package redis
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNil is returned by typed helpers when Redis replies with a nil bulk string
+var ErrNil = errors.New("redis: nil reply")
+
+// Error is an error reply sent by the server
+type Error string
+
+func (e Error) Error() string {
+	return "redis: " + string(e)
+}
+
+// Options configures a Client
+type Options struct {
+	Address  string // redis://[:password@]host:port[/db], rediss:// for TLS, or host:port
+	Password string // Overrides a password in Address
+	DB       int    // Overrides a database in Address
+	Timeout  time.Duration
+}
+
+// Client is a minimal RESP2 client that connects lazily and reconnects on the next
+// command after a connection failure. Commands are serialized over one connection.
+type Client struct {
+	network  string
+	address  string
+	useTLS   bool
+	password string
+	db       int
+	timeout  time.Duration
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewClient creates a new Client; no connection is made until the first command
+func NewClient(opts Options) (*Client, error) {
+	if opts.Address == "" {
+		return nil, fmt.Errorf("redis address cannot be empty")
+	}
+	c := &Client{network: "tcp", timeout: opts.Timeout}
+	if c.timeout <= 0 {
+		c.timeout = 5 * time.Second
+	}
+
+	if strings.Contains(opts.Address, "://") {
+		u, err := url.Parse(opts.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redis address %q: %w", opts.Address, err)
+		}
+		switch u.Scheme {
+		case "redis":
+		case "rediss":
+			c.useTLS = true
+		default:
+			return nil, fmt.Errorf("unsupported redis scheme %q (expected redis or rediss)", u.Scheme)
+		}
+		c.address = u.Host
+		if password, ok := u.User.Password(); ok {
+			c.password = password
+		}
+		if db := strings.TrimPrefix(u.Path, "/"); db != "" {
+			n, err := strconv.Atoi(db)
+			if err != nil {
+				return nil, fmt.Errorf("invalid redis database %q", db)
+			}
+			c.db = n
+		}
+	} else {
+		c.address = opts.Address
+	}
+	if !strings.Contains(c.address, ":") {
+		c.address += ":6379"
+	}
+	if opts.Password != "" {
+		c.password = opts.Password
+	}
+	if opts.DB != 0 {
+		c.db = opts.DB
+	}
+	return c, nil
+}
+
+// Address returns the host:port the client connects to
+func (c *Client) Address() string {
+	return c.address
+}
+
+// Do sends a command and returns its reply: string, int64, []interface{}, or nil for a nil
+// reply. Error replies are returned as Error; connection failures close the connection so
+// the next command reconnects.
+func (c *Client) Do(args ...string) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		if err := c.connectLocked(); err != nil {
+			return nil, err
+		}
+	}
+
+	reply, err := c.doLocked(args...)
+	if err != nil {
+		var replyErr Error
+		if !errors.As(err, &replyErr) {
+			c.closeLocked()
+		}
+		return nil, err
+	}
+	return reply, nil
+}
+
+// String sends a command expecting a string reply
+func (c *Client) String(args ...string) (string, error) {
+	reply, err := c.Do(args...)
+	if err != nil {
+		return "", err
+	}
+	switch v := reply.(type) {
+	case nil:
+		return "", ErrNil
+	case string:
+		return v, nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	default:
+		return "", fmt.Errorf("redis: unexpected reply type %T", reply)
+	}
+}
+
+// Int sends a command expecting an integer reply
+func (c *Client) Int(args ...string) (int64, error) {
+	reply, err := c.Do(args...)
+	if err != nil {
+		return 0, err
+	}
+	switch v := reply.(type) {
+	case nil:
+		return 0, ErrNil
+	case int64:
+		return v, nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("redis: unexpected reply type %T", reply)
+	}
+}
+
+// Strings sends a command expecting an array of strings
+func (c *Client) Strings(args ...string) ([]string, error) {
+	reply, err := c.Do(args...)
+	if err != nil {
+		return nil, err
+	}
+	items, ok := reply.([]interface{})
+	if !ok {
+		if reply == nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("redis: unexpected reply type %T", reply)
+	}
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		s, _ := item.(string)
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+// Close closes the connection
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closeLocked()
+	return nil
+}
+
+func (c *Client) connectLocked() error {
+	dialer := &net.Dialer{Timeout: c.timeout}
+	var conn net.Conn
+	var err error
+	if c.useTLS {
+		host, _, _ := net.SplitHostPort(c.address)
+		conn, err = tls.DialWithDialer(dialer, c.network, c.address, &tls.Config{ServerName: host})
+	} else {
+		conn, err = dialer.Dial(c.network, c.address)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to redis at %s: %w", c.address, err)
+	}
+	c.conn = conn
+	c.reader = bufio.NewReader(conn)
+
+	if c.password != "" {
+		if _, err := c.doLocked("AUTH", c.password); err != nil {
+			c.closeLocked()
+			return fmt.Errorf("redis authentication failed: %w", err)
+		}
+	}
+	if c.db != 0 {
+		if _, err := c.doLocked("SELECT", strconv.Itoa(c.db)); err != nil {
+			c.closeLocked()
+			return fmt.Errorf("failed to select redis database %d: %w", c.db, err)
+		}
+	}
+	return nil
+}
+
+func (c *Client) closeLocked() {
+	if c.conn != nil {
+		c.conn.Close()
+		c.conn = nil
+		c.reader = nil
+	}
+}
+
+func (c *Client) doLocked(args ...string) (interface{}, error) {
+	c.conn.SetDeadline(time.Now().Add(c.timeout))
+	if _, err := c.conn.Write(encodeCommand(args)); err != nil {
+		return nil, fmt.Errorf("failed to send redis command: %w", err)
+	}
+	return readReply(c.reader)
+}
+
+// encodeCommand encodes a command as a RESP array of bulk strings
+func encodeCommand(args []string) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	return []byte(b.String())
+}
+
+// readReply reads one RESP2 reply
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("failed to read redis reply: %w", err)
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, fmt.Errorf("redis: empty reply")
+	}
+
+	payload := line[1:]
+	switch line[0] {
+	case '+':
+		return payload, nil
+	case '-':
+		return nil, Error(payload)
+	case ':':
+		n, err := strconv.ParseInt(payload, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid integer reply %q", payload)
+		}
+		return n, nil
+	case '$':
+		size, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid bulk length %q", payload)
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, fmt.Errorf("failed to read redis reply: %w", err)
+		}
+		return string(data[:size]), nil
+	case '*':
+		count, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid array length %q", payload)
+		}
+		if count < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, 0, count)
+		for i := 0; i < count; i++ {
+			item, err := readReply(r)
+			if err != nil {
+				var replyErr Error
+				if !errors.As(err, &replyErr) {
+					return nil, err
+				}
+				item = replyErr
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("redis: unknown reply type %q", line[0])
+	}
+}
@@ -0,0 +1,137 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/redis/redistest"
+)
+
+func TestNewClient(t *testing.T) {
+	t.Run("should parse redis URLs", func(t *testing.T) {
+		client, err := NewClient(Options{Address: "redis://:secret@cache.example.com:6380/2"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "cache.example.com:6380", client.Address())
+		assert.Equal(t, "secret", client.password)
+		assert.Equal(t, 2, client.db)
+	})
+
+	t.Run("should default the port and let options override the URL", func(t *testing.T) {
+		client, err := NewClient(Options{Address: "redis://:secret@cache/2", Password: "other", DB: 3})
+
+		require.NoError(t, err)
+		assert.Equal(t, "cache:6379", client.Address())
+		assert.Equal(t, "other", client.password)
+		assert.Equal(t, 3, client.db)
+	})
+
+	t.Run("should reject invalid addresses", func(t *testing.T) {
+		for _, address := range []string{"", "http://cache:6379", "redis://cache:6379/db"} {
+			_, err := NewClient(Options{Address: address})
+
+			assert.Error(t, err, address)
+		}
+	})
+}
+
+func TestClient_Do(t *testing.T) {
+	t.Run("should send commands and decode replies", func(t *testing.T) {
+		// Arrange
+		server := redistest.NewServer(t)
+		client, err := NewClient(Options{Address: server.Addr(), Password: "secret", DB: 1})
+		require.NoError(t, err)
+		defer client.Close()
+
+		// Act
+		set, setErr := client.String("SET", "greeting", "hello\r\nworld", "NX")
+		get, getErr := client.String("GET", "greeting")
+		length, pushErr := client.Int("LPUSH", "recent", "a", "b")
+		items, rangeErr := client.Strings("LRANGE", "recent", "0", "-1")
+		_, nilErr := client.String("GET", "missing")
+
+		// Assert
+		require.NoError(t, setErr)
+		require.NoError(t, getErr)
+		require.NoError(t, pushErr)
+		require.NoError(t, rangeErr)
+		assert.Equal(t, "OK", set)
+		assert.Equal(t, "hello\r\nworld", get)
+		assert.Equal(t, int64(2), length)
+		assert.Equal(t, []string{"b", "a"}, items)
+		assert.ErrorIs(t, nilErr, ErrNil)
+	})
+
+	t.Run("should return error replies without dropping the connection", func(t *testing.T) {
+		// Arrange
+		server := redistest.NewServer(t)
+		client, err := NewClient(Options{Address: server.Addr()})
+		require.NoError(t, err)
+		defer client.Close()
+
+		// Act
+		_, err = client.Do("NOSUCHCOMMAND")
+
+		// Assert
+		var replyErr Error
+		require.ErrorAs(t, err, &replyErr)
+		assert.Contains(t, replyErr.Error(), "unknown command")
+		_, err = client.String("PING")
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reconnect after the server restarts", func(t *testing.T) {
+		// Arrange
+		server := redistest.NewServer(t)
+		client, err := NewClient(Options{Address: server.Addr(), Timeout: time.Second})
+		require.NoError(t, err)
+		defer client.Close()
+		_, err = client.String("PING")
+		require.NoError(t, err)
+
+		// Act - a closed connection fails once, then the next command reconnects
+		client.mu.Lock()
+		client.conn.Close()
+		client.mu.Unlock()
+		_, firstErr := client.String("PING")
+		_, secondErr := client.String("PING")
+
+		// Assert
+		assert.Error(t, firstErr)
+		assert.NoError(t, secondErr)
+	})
+
+	t.Run("should fail when the server is unreachable", func(t *testing.T) {
+		client, err := NewClient(Options{Address: "127.0.0.1:1", Timeout: 200 * time.Millisecond})
+		require.NoError(t, err)
+
+		_, err = client.Do("PING")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCappedList(t *testing.T) {
+	t.Run("should keep only the newest entries", func(t *testing.T) {
+		// Arrange
+		server := redistest.NewServer(t)
+		client, err := NewClient(Options{Address: server.Addr()})
+		require.NoError(t, err)
+		defer client.Close()
+		list := NewCappedList(client, "station:transcripts", 3)
+
+		// Act
+		for _, value := range []string{"one", "two", "three", "four"} {
+			require.NoError(t, list.Push(value))
+		}
+		recent, err := list.Recent(2)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"four", "three"}, recent)
+		assert.Equal(t, []string{"four", "three", "two"}, server.List("station:transcripts"))
+	})
+}
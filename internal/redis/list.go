@@ -0,0 +1,45 @@
+package redis
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// CappedList is a Redis list holding only the newest entries, e.g. recent transcripts
+type CappedList struct {
+	client *Client
+	key    string
+	max    int
+}
+
+// NewCappedList creates a CappedList on key keeping at most max entries
+func NewCappedList(client *Client, key string, max int) *CappedList {
+	if max < 1 {
+		max = 1
+	}
+	return &CappedList{client: client, key: key, max: max}
+}
+
+// Key returns the list's Redis key
+func (l *CappedList) Key() string {
+	return l.key
+}
+
+// Push prepends value and trims the list to its maximum length
+func (l *CappedList) Push(value string) error {
+	if _, err := l.client.Do("LPUSH", l.key, value); err != nil {
+		return fmt.Errorf("failed to push to %s: %w", l.key, err)
+	}
+	if _, err := l.client.Do("LTRIM", l.key, "0", strconv.Itoa(l.max-1)); err != nil {
+		return fmt.Errorf("failed to trim %s: %w", l.key, err)
+	}
+	return nil
+}
+
+// Recent returns up to n entries, newest first
+func (l *CappedList) Recent(n int) ([]string, error) {
+	if n < 1 {
+		return nil, nil
+	}
+	return l.client.Strings("LRANGE", l.key, "0", strconv.Itoa(n-1))
+}
@@ -0,0 +1,375 @@
+// Package redistest provides an in-memory Redis server for tests
+package redistest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ScriptFunc implements an EVAL script for the fake server. It is called with the server
+// locked and may call Get, Set, Del, and PExpire.
+type ScriptFunc func(s *Server, keys, args []string) interface{}
+
+// Server is an in-memory Redis server supporting the strings, lists, and pub/sub commands the
+// application uses. EVAL scripts must be registered with HandleScript.
+type Server struct {
+	listener net.Listener
+
+	mu          sync.Mutex
+	values      map[string]string
+	expires     map[string]time.Time
+	lists       map[string][]string
+	scripts     map[string]ScriptFunc
+	published   []Message
+	subscribers map[string][]chan Message
+	failing     bool
+}
+
+// Message is a message received by PUBLISH
+type Message struct {
+	Channel string
+	Payload string
+}
+
+// NewServer starts a Server that is closed when the test ends
+func NewServer(t *testing.T) *Server {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	s := &Server{
+		listener:    listener,
+		values:      make(map[string]string),
+		expires:     make(map[string]time.Time),
+		lists:       make(map[string][]string),
+		scripts:     make(map[string]ScriptFunc),
+		subscribers: make(map[string][]chan Message),
+	}
+	go s.serve()
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Addr returns the server's host:port
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Close stops accepting connections
+func (s *Server) Close() {
+	s.listener.Close()
+}
+
+// SetFailing makes every command return an error reply while enabled
+func (s *Server) SetFailing(failing bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failing = failing
+}
+
+// HandleScript registers the implementation used when script is sent with EVAL
+func (s *Server) HandleScript(script string, fn ScriptFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scripts[script] = fn
+}
+
+// Value returns a string key's value, or "" if it is unset or expired (safe to call from tests)
+func (s *Server) Value(key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, _ := s.Get(key)
+	return value
+}
+
+// List returns a copy of a list key (safe to call from tests)
+func (s *Server) List(key string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.lists[key]...)
+}
+
+// Published returns the messages received by PUBLISH (safe to call from tests)
+func (s *Server) Published() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.published...)
+}
+
+// Expire expires a key immediately, as if its TTL had elapsed (safe to call from tests)
+func (s *Server) Expire(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Del(key)
+}
+
+// Get returns a string value; for use inside ScriptFuncs
+func (s *Server) Get(key string) (string, bool) {
+	if deadline, ok := s.expires[key]; ok && time.Now().After(deadline) {
+		s.Del(key)
+	}
+	value, ok := s.values[key]
+	return value, ok
+}
+
+// Set stores a string value with an optional TTL; for use inside ScriptFuncs
+func (s *Server) Set(key, value string, ttl time.Duration) {
+	s.values[key] = value
+	delete(s.expires, key)
+	if ttl > 0 {
+		s.expires[key] = time.Now().Add(ttl)
+	}
+}
+
+// Del deletes a key; for use inside ScriptFuncs
+func (s *Server) Del(key string) bool {
+	_, existed := s.values[key]
+	_, listExisted := s.lists[key]
+	delete(s.values, key)
+	delete(s.expires, key)
+	delete(s.lists, key)
+	return existed || listExisted
+}
+
+// PExpire sets a key's TTL; for use inside ScriptFuncs
+func (s *Server) PExpire(key string, ttl time.Duration) bool {
+	if _, ok := s.Get(key); !ok {
+		return false
+	}
+	s.expires[key] = time.Now().Add(ttl)
+	return true
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		if strings.ToUpper(args[0]) == "SUBSCRIBE" {
+			s.subscribe(conn, reader, args[1:])
+			return
+		}
+		conn.Write(encode(s.execute(args)))
+	}
+}
+
+// subscribe switches the connection to pub/sub mode for the given channels
+func (s *Server) subscribe(conn net.Conn, reader *bufio.Reader, channels []string) {
+	messages := make(chan Message, 100)
+	s.mu.Lock()
+	for i, channel := range channels {
+		s.subscribers[channel] = append(s.subscribers[channel], messages)
+		conn.Write(encode([]interface{}{"subscribe", channel, int64(i + 1)}))
+	}
+	s.mu.Unlock()
+
+	closed := make(chan struct{})
+	go func() {
+		io.Copy(io.Discard, reader)
+		close(closed)
+	}()
+	for {
+		select {
+		case msg := <-messages:
+			if _, err := conn.Write(encode([]interface{}{"message", msg.Channel, msg.Payload})); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+func (s *Server) execute(args []string) interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failing {
+		return fmt.Errorf("ERR server unavailable")
+	}
+
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return "PONG"
+	case "AUTH", "SELECT":
+		return "OK"
+	case "GET":
+		if value, ok := s.Get(args[1]); ok {
+			return value
+		}
+		return nil
+	case "SET":
+		var ttl time.Duration
+		nx := false
+		for i := 3; i < len(args); i++ {
+			switch strings.ToUpper(args[i]) {
+			case "NX":
+				nx = true
+			case "PX":
+				ms, _ := strconv.Atoi(args[i+1])
+				ttl = time.Duration(ms) * time.Millisecond
+				i++
+			case "EX":
+				sec, _ := strconv.Atoi(args[i+1])
+				ttl = time.Duration(sec) * time.Second
+				i++
+			}
+		}
+		if _, exists := s.Get(args[1]); exists && nx {
+			return nil
+		}
+		s.Set(args[1], args[2], ttl)
+		return "OK"
+	case "DEL":
+		var n int64
+		for _, key := range args[1:] {
+			if s.Del(key) {
+				n++
+			}
+		}
+		return n
+	case "PEXPIRE":
+		ms, _ := strconv.Atoi(args[2])
+		if s.PExpire(args[1], time.Duration(ms)*time.Millisecond) {
+			return int64(1)
+		}
+		return int64(0)
+	case "LPUSH":
+		for _, value := range args[2:] {
+			s.lists[args[1]] = append([]string{value}, s.lists[args[1]]...)
+		}
+		return int64(len(s.lists[args[1]]))
+	case "LTRIM":
+		start, _ := strconv.Atoi(args[2])
+		stop, _ := strconv.Atoi(args[3])
+		list := s.lists[args[1]]
+		start, stop = listRange(len(list), start, stop)
+		if start > stop {
+			delete(s.lists, args[1])
+		} else {
+			s.lists[args[1]] = append([]string(nil), list[start:stop+1]...)
+		}
+		return "OK"
+	case "LRANGE":
+		start, _ := strconv.Atoi(args[2])
+		stop, _ := strconv.Atoi(args[3])
+		list := s.lists[args[1]]
+		start, stop = listRange(len(list), start, stop)
+		items := []interface{}{}
+		for i := start; i <= stop; i++ {
+			items = append(items, list[i])
+		}
+		return items
+	case "PUBLISH":
+		msg := Message{Channel: args[1], Payload: args[2]}
+		s.published = append(s.published, msg)
+		for _, subscriber := range s.subscribers[args[1]] {
+			select {
+			case subscriber <- msg:
+			default:
+			}
+		}
+		return int64(len(s.subscribers[args[1]]))
+	case "EVAL":
+		fn, ok := s.scripts[args[1]]
+		if !ok {
+			return fmt.Errorf("NOSCRIPT script not registered with the fake server")
+		}
+		numKeys, _ := strconv.Atoi(args[2])
+		return fn(s, args[3:3+numKeys], args[3+numKeys:])
+	default:
+		return fmt.Errorf("ERR unknown command '%s'", args[0])
+	}
+}
+
+// listRange converts Redis list indexes (negative counts from the end) to a clamped inclusive range
+func listRange(length, start, stop int) (int, int) {
+	if start < 0 {
+		start += length
+	}
+	if stop < 0 {
+		stop += length
+	}
+	if start < 0 {
+		start = 0
+	}
+	if stop >= length {
+		stop = length - 1
+	}
+	return start, stop
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil || line[0] != '*' || count < 1 {
+		return nil, fmt.Errorf("invalid command")
+	}
+	args := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		header, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(header[1:]))
+		if err != nil {
+			return nil, err
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		args = append(args, string(data[:size]))
+	}
+	return args, nil
+}
+
+func encode(reply interface{}) []byte {
+	switch v := reply.(type) {
+	case nil:
+		return []byte("$-1\r\n")
+	case error:
+		return []byte("-" + v.Error() + "\r\n")
+	case string:
+		return []byte(fmt.Sprintf("$%d\r\n%s\r\n", len(v), v))
+	case int64:
+		return []byte(fmt.Sprintf(":%d\r\n", v))
+	case bool:
+		if v {
+			return []byte(":1\r\n")
+		}
+		return []byte(":0\r\n")
+	case []interface{}:
+		out := []byte(fmt.Sprintf("*%d\r\n", len(v)))
+		for _, item := range v {
+			out = append(out, encode(item)...)
+		}
+		return out
+	default:
+		return []byte(fmt.Sprintf("-ERR unsupported reply %T\r\n", reply))
+	}
+}
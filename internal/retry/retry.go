@@ -0,0 +1,141 @@
+// Package retry runs operations again after transient failures, backing off exponentially with
+// jitter until they succeed, the attempts or time budget run out, or the context ends.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// Reasons Do gives up, available with errors.Is on the returned error
+var (
+	ErrAttemptsExhausted = errors.New("maximum retry attempts exceeded")
+	ErrBudgetExhausted   = errors.New("retry budget exhausted")
+)
+
+// Policy describes how an operation is retried
+type Policy struct {
+	MaxAttempts int           // Attempts including the first; 0 or less keeps trying until the budget or context ends
+	BaseDelay   time.Duration // Delay before the first retry
+	MaxDelay    time.Duration // Cap on a single delay; 0 means no cap
+	Multiplier  float64       // Growth of the delay per retry; 0 uses 2
+	Jitter      float64       // Fraction of each delay randomized either way, 0 to 1; spreads out retries from many clients
+	Budget      time.Duration // Total time attempts and delays may take; 0 means no limit
+
+	// OnRetry, when set, is called before waiting delay to make attempt number next after err
+	OnRetry func(next int, delay time.Duration, err error)
+}
+
+// Delay returns the backoff before retry number retry (1 is the first retry), without jitter
+func (p Policy) Delay(retry int) time.Duration {
+	if retry < 1 || p.BaseDelay <= 0 {
+		return 0
+	}
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	delay := float64(p.BaseDelay)
+	for i := 1; i < retry; i++ {
+		delay *= multiplier
+		if p.MaxDelay > 0 && delay >= float64(p.MaxDelay) {
+			return p.MaxDelay
+		}
+	}
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		return p.MaxDelay
+	}
+	return time.Duration(delay)
+}
+
+// jittered spreads delay by up to Jitter of itself either way
+func (p Policy) jittered(delay time.Duration) time.Duration {
+	return Jittered(delay, p.Jitter)
+}
+
+// Jittered spreads delay by up to fraction (0 to 1) of itself either way, so clients that
+// failed together do not retry together
+func Jittered(delay time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 || delay <= 0 {
+		return delay
+	}
+	fraction = min(fraction, 1)
+	return time.Duration(float64(delay) * (1 - fraction + 2*fraction*rand.Float64()))
+}
+
+// Error is returned by Do when it gives up on a retryable failure
+type Error struct {
+	Attempts int   // Attempts made
+	Reason   error // ErrAttemptsExhausted, ErrBudgetExhausted, or the context's error
+	Last     error // Error from the last attempt
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v after %d attempts: %v", e.Reason, e.Attempts, e.Last)
+}
+
+// Unwrap exposes both the reason and the last attempt's error to errors.Is and errors.As
+func (e *Error) Unwrap() []error {
+	return []error{e.Reason, e.Last}
+}
+
+// permanentError marks a failure that retrying cannot fix
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so Do returns it at once instead of retrying
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// Do calls op until it succeeds. It returns a permanent failure from op unwrapped, and an *Error
+// when the attempts or budget run out or ctx ends first. attempt counts from 1.
+func Do(ctx context.Context, policy Policy, op func(ctx context.Context, attempt int) error) error {
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		err := op(ctx, attempt)
+		if err == nil {
+			return nil
+		}
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			return &Error{Attempts: attempt, Reason: ErrAttemptsExhausted, Last: err}
+		}
+		delay := policy.jittered(policy.Delay(attempt))
+		if policy.Budget > 0 && time.Since(start)+delay > policy.Budget {
+			return &Error{Attempts: attempt, Reason: ErrBudgetExhausted, Last: err}
+		}
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt+1, delay, err)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return &Error{Attempts: attempt, Reason: ctx.Err(), Last: err}
+		case <-timer.C:
+		}
+	}
+}
+
+// RetryableStatus reports whether an HTTP response status is worth retrying: request timeouts,
+// rate limiting, and server errors
+func RetryableStatus(code int) bool {
+	return code == http.StatusRequestTimeout || code == http.StatusTooManyRequests || code >= 500
+}
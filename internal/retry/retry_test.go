@@ -0,0 +1,129 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_Delay(t *testing.T) {
+	t.Run("should double from the base delay up to the cap", func(t *testing.T) {
+		policy := Policy{BaseDelay: 100 * time.Millisecond, MaxDelay: 500 * time.Millisecond}
+
+		assert.Equal(t, time.Duration(0), policy.Delay(0))
+		assert.Equal(t, 100*time.Millisecond, policy.Delay(1))
+		assert.Equal(t, 200*time.Millisecond, policy.Delay(2))
+		assert.Equal(t, 400*time.Millisecond, policy.Delay(3))
+		assert.Equal(t, 500*time.Millisecond, policy.Delay(4))
+		assert.Equal(t, 500*time.Millisecond, policy.Delay(60))
+	})
+
+	t.Run("should keep jittered delays within the jitter fraction", func(t *testing.T) {
+		policy := Policy{BaseDelay: time.Second, Jitter: 0.25}
+
+		for i := 0; i < 100; i++ {
+			delay := policy.jittered(policy.Delay(1))
+			assert.GreaterOrEqual(t, delay, 750*time.Millisecond)
+			assert.LessOrEqual(t, delay, 1250*time.Millisecond)
+		}
+	})
+}
+
+func TestDo(t *testing.T) {
+	t.Run("should retry until the operation succeeds", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		var retries []int
+		policy := Policy{MaxAttempts: 5, BaseDelay: time.Millisecond, OnRetry: func(next int, delay time.Duration, err error) {
+			retries = append(retries, next)
+		}}
+
+		// Act
+		err := Do(context.Background(), policy, func(ctx context.Context, attempt int) error {
+			calls++
+			if attempt < 3 {
+				return errors.New("transient")
+			}
+			return nil
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, []int{2, 3}, retries)
+	})
+
+	t.Run("should give up after the maximum attempts with the last error", func(t *testing.T) {
+		// Arrange
+		failure := errors.New("still down")
+		calls := 0
+
+		// Act
+		err := Do(context.Background(), Policy{MaxAttempts: 3}, func(ctx context.Context, attempt int) error {
+			calls++
+			return failure
+		})
+
+		// Assert
+		assert.Equal(t, 3, calls)
+		assert.ErrorIs(t, err, ErrAttemptsExhausted)
+		assert.ErrorIs(t, err, failure)
+		var retryErr *Error
+		require.ErrorAs(t, err, &retryErr)
+		assert.Equal(t, 3, retryErr.Attempts)
+	})
+
+	t.Run("should return permanent errors without retrying", func(t *testing.T) {
+		failure := errors.New("bad request")
+		calls := 0
+
+		err := Do(context.Background(), Policy{MaxAttempts: 5}, func(ctx context.Context, attempt int) error {
+			calls++
+			return Permanent(failure)
+		})
+
+		assert.Equal(t, failure, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should stop when the next delay would exceed the budget", func(t *testing.T) {
+		calls := 0
+
+		err := Do(context.Background(), Policy{BaseDelay: 20 * time.Millisecond, Budget: 50 * time.Millisecond}, func(ctx context.Context, attempt int) error {
+			calls++
+			return errors.New("transient")
+		})
+
+		assert.ErrorIs(t, err, ErrBudgetExhausted)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("should stop waiting when the context ends", func(t *testing.T) {
+		// Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		policy := Policy{BaseDelay: time.Hour, OnRetry: func(int, time.Duration, error) { cancel() }}
+
+		// Act
+		err := Do(ctx, policy, func(ctx context.Context, attempt int) error {
+			return errors.New("transient")
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestRetryableStatus(t *testing.T) {
+	t.Run("should retry timeouts, rate limiting, and server errors only", func(t *testing.T) {
+		for _, code := range []int{408, 429, 500, 502, 503} {
+			assert.True(t, RetryableStatus(code), code)
+		}
+		for _, code := range []int{200, 400, 401, 404} {
+			assert.False(t, RetryableStatus(code), code)
+		}
+	})
+}
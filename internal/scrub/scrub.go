@@ -0,0 +1,142 @@
+// Package scrub masks phone numbers, personal names, and profanity in transcription text before
+// it is stored or sent out, for stations with compliance requirements on retained transcripts.
+// Contest detection still runs on the original text; only what leaves the pipeline is scrubbed.
+package scrub
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Masks replacing scrubbed text; profanity keeps its first letter, e.g. "f***"
+const (
+	PhoneMask = "[phone]"
+	NameMask  = "[name]"
+)
+
+// DefaultProfanity is the word list used when Config.Words is empty. A leading or trailing *
+// matches any letters on that side of the word.
+var DefaultProfanity = []string{
+	"*fuck*", "shit*", "bullshit*", "bitch*", "bastard*", "asshole*", "cunt*", "dick", "dickhead*",
+	"piss", "pissed", "prick*", "slut*", "whore*", "goddamn*",
+}
+
+// phonePattern matches phone numbers of seven or more digits, such as 555-1234, (512) 555-1234,
+// 512.555.1234, or +1 512 555 1234. Five and six digit contest short codes never match.
+var phonePattern = regexp.MustCompile(`(?:\+\d{1,2}[\s.-]?|\b\d[\s.-])?(?:\(\d{3}\)\s?|\b\d{3}[\s.-]?)?\d{3}[\s.-]?\d{4}\b`)
+
+// introductionPattern matches a capitalized name after a phrase introducing someone on air, e.g.
+// "my name is Jane Doe" or "congratulations, Jane"
+var introductionPattern = regexp.MustCompile(`(?i:\bmy name is|\bmy name's|\btalking to|\bspeaking with|\bcongratulations,?)\s+([A-Z][a-z]+(?:\s+[A-Z][a-z]+)?)`)
+
+// Config selects what a Scrubber masks
+type Config struct {
+	PhoneNumbers bool     // Mask phone numbers other than the ones kept per call
+	Names        []string // Names to mask wherever they appear, e.g. regular callers or staff
+	Introduced   bool     // Mask capitalized names following "my name is" and similar phrases
+	Profanity    bool     // Mask profanity
+	Words        []string // Profanity list; empty uses DefaultProfanity
+}
+
+// Scrubber masks personal data and profanity in text. A nil Scrubber leaves text unchanged.
+type Scrubber struct {
+	config    Config
+	names     *regexp.Regexp // nil without configured names
+	profanity *regexp.Regexp // nil when profanity is not masked
+}
+
+// New compiles a Scrubber for config
+func New(config Config) (*Scrubber, error) {
+	s := &Scrubber{config: config}
+	if len(config.Names) > 0 {
+		var alternatives []string
+		for _, name := range config.Names {
+			if name = strings.TrimSpace(name); name != "" {
+				alternatives = append(alternatives, regexp.QuoteMeta(name))
+			}
+		}
+		if len(alternatives) > 0 {
+			s.names = regexp.MustCompile(`(?i)\b(?:` + strings.Join(alternatives, "|") + `)\b`)
+		}
+	}
+	if config.Profanity {
+		words := config.Words
+		if len(words) == 0 {
+			words = DefaultProfanity
+		}
+		re, err := compileWords(words)
+		if err != nil {
+			return nil, err
+		}
+		s.profanity = re
+	}
+	return s, nil
+}
+
+// compileWords builds one case-insensitive pattern matching any of the words as whole words
+func compileWords(words []string) (*regexp.Regexp, error) {
+	var alternatives []string
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		core := strings.Trim(word, "*")
+		if core == "" {
+			continue
+		}
+		if strings.ContainsFunc(core, func(r rune) bool { return r == '*' || r == ' ' }) {
+			return nil, fmt.Errorf("profanity word %q: * is only allowed at either end of a single word", word)
+		}
+		alternative := regexp.QuoteMeta(core)
+		if strings.HasPrefix(word, "*") {
+			alternative = `\pL*` + alternative
+		}
+		if strings.HasSuffix(word, "*") {
+			alternative += `\pL*`
+		}
+		alternatives = append(alternatives, alternative)
+	}
+	if len(alternatives) == 0 {
+		return nil, nil
+	}
+	return regexp.MustCompile(`(?i)\b(?:` + strings.Join(alternatives, "|") + `)\b`), nil
+}
+
+// Scrub returns text with personal data and profanity masked. Numbers in keep, such as the contest
+// short code a cue matched, are never masked as phone numbers.
+func (s *Scrubber) Scrub(text string, keep ...string) string {
+	if s == nil || text == "" {
+		return text
+	}
+	if s.config.PhoneNumbers {
+		text = phonePattern.ReplaceAllStringFunc(text, func(match string) string {
+			digits := strings.Map(func(r rune) rune {
+				if r >= '0' && r <= '9' {
+					return r
+				}
+				return -1
+			}, match)
+			for _, number := range keep {
+				if number != "" && digits == number {
+					return match
+				}
+			}
+			return PhoneMask
+		})
+	}
+	if s.config.Introduced {
+		text = introductionPattern.ReplaceAllStringFunc(text, func(match string) string {
+			name := introductionPattern.FindStringSubmatch(match)[1]
+			return strings.TrimSuffix(match, name) + NameMask
+		})
+	}
+	if s.names != nil {
+		text = s.names.ReplaceAllString(text, NameMask)
+	}
+	if s.profanity != nil {
+		text = s.profanity.ReplaceAllStringFunc(text, func(word string) string {
+			runes := []rune(word)
+			return string(runes[0]) + strings.Repeat("*", len(runes)-1)
+		})
+	}
+	return text
+}
@@ -0,0 +1,84 @@
+package scrub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScrubber_PhoneNumbers(t *testing.T) {
+	scrubber, err := New(Config{PhoneNumbers: true})
+	require.NoError(t, err)
+
+	t.Run("should mask phone numbers in common spoken and written forms", func(t *testing.T) {
+		for text, want := range map[string]string{
+			"call 555-1234 now":                  "call [phone] now",
+			"call (512) 555-1234 now":            "call [phone] now",
+			"call 512.555.1234 now":              "call [phone] now",
+			"call 5125551234 now":                "call [phone] now",
+			"call +1 512 555 1234 now":           "call [phone] now",
+			"call 1-800-555-1234 now":            "call [phone] now",
+			"Text CASH to 72881 for $1,000":      "Text CASH to 72881 for $1,000",
+			"the 9 o'clock news at 104.5 FM now": "the 9 o'clock news at 104.5 FM now",
+		} {
+			assert.Equal(t, want, scrubber.Scrub(text), text)
+		}
+	})
+
+	t.Run("should keep the numbers it is told to keep", func(t *testing.T) {
+		text := "text WIN to 5551234 or call 555-9876"
+
+		assert.Equal(t, "text WIN to 5551234 or call [phone]", scrubber.Scrub(text, "5551234"))
+	})
+}
+
+func TestScrubber_Names(t *testing.T) {
+	t.Run("should mask configured names in any case", func(t *testing.T) {
+		scrubber, err := New(Config{Names: []string{"Jane Doe", "Bob"}})
+		require.NoError(t, err)
+
+		assert.Equal(t, "[name] and [name] called, not Bobby", scrubber.Scrub("jane doe and Bob called, not Bobby"))
+	})
+
+	t.Run("should mask names given in on-air introductions", func(t *testing.T) {
+		scrubber, err := New(Config{Introduced: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, "hi my name is [name] and I won", scrubber.Scrub("hi my name is Jane Doe and I won"))
+		assert.Equal(t, "Congratulations, [name]!", scrubber.Scrub("Congratulations, Jane!"))
+		assert.Equal(t, "we're talking to the winner", scrubber.Scrub("we're talking to the winner"))
+	})
+}
+
+func TestScrubber_Profanity(t *testing.T) {
+	t.Run("should mask profanity keeping the first letter", func(t *testing.T) {
+		scrubber, err := New(Config{Profanity: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, "what the f*** that's b*******, a classic", scrubber.Scrub("what the fuck that's bullshit, a classic"))
+		assert.Equal(t, "Dickens and Scunthorpe stay", scrubber.Scrub("Dickens and Scunthorpe stay"))
+	})
+
+	t.Run("should use a configured word list with wildcards at either end", func(t *testing.T) {
+		scrubber, err := New(Config{Profanity: true, Words: []string{"darn*", "*heck"}})
+		require.NoError(t, err)
+
+		assert.Equal(t, "d***** h***, fuck", scrubber.Scrub("darned heck, fuck"))
+		assert.Equal(t, "o*******", scrubber.Scrub("ohmyheck"))
+	})
+
+	t.Run("should reject a wildcard inside a word", func(t *testing.T) {
+		_, err := New(Config{Profanity: true, Words: []string{"da*rn"}})
+
+		assert.ErrorContains(t, err, "da*rn")
+	})
+}
+
+func TestScrubber_Nil(t *testing.T) {
+	t.Run("should leave text unchanged", func(t *testing.T) {
+		var scrubber *Scrubber
+
+		assert.Equal(t, "call 555-1234", scrubber.Scrub("call 555-1234"))
+	})
+}
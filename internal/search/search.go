@@ -0,0 +1,226 @@
+// Package search finds stored transcriptions and contest cues matching a pattern within a time
+// range, so operators can check what was heard without external tooling.
+package search
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/parser"
+)
+
+// Record kinds
+const (
+	KindTranscript = "transcript"
+	KindCue        = "cue"
+)
+
+// Record is one stored transcription or cue
+type Record struct {
+	File string
+	Line int
+	Kind string    // KindTranscript or KindCue
+	Time time.Time // Zero when the record has no readable timestamp
+	Text string    // Transcribed text, or a cue summary such as "CASH: text WIN to 55555"
+}
+
+// Match is a record matching the search, with neighbouring records from the same file
+type Match struct {
+	Record
+	Before []Record
+	After  []Record
+}
+
+// Options filters records
+type Options struct {
+	Pattern *regexp.Regexp // Matched against Text; nil matches every record
+	Since   time.Time      // Records before Since are skipped; zero means no lower bound
+	Until   time.Time      // Records at or after Until are skipped; zero means no upper bound
+	Context int            // Neighbouring records to include before and after each match
+}
+
+// matches reports whether record passes the filters. Records without a timestamp are kept
+// only when no time range is given.
+func (o Options) matches(record Record) bool {
+	if !o.Since.IsZero() || !o.Until.IsZero() {
+		if record.Time.IsZero() {
+			return false
+		}
+		if !o.Since.IsZero() && record.Time.Before(o.Since) {
+			return false
+		}
+		if !o.Until.IsZero() && !record.Time.Before(o.Until) {
+			return false
+		}
+	}
+	return o.Pattern == nil || o.Pattern.MatchString(record.Text)
+}
+
+// Files returns the stored data the configuration writes: the debug transcription file and its
+// rotated backups, then the file cue log sinks, then those of each tenant. Backups come oldest
+// first so matches read in order.
+func Files(cfg *config.Configuration) []string {
+	var files []string
+	if names := cfg.GetTenantNames(); len(names) > 0 {
+		for _, name := range names {
+			if tenant, err := cfg.ForTenant(name); err == nil {
+				files = append(files, Files(tenant)...)
+			}
+		}
+		return files
+	}
+	files = append(files, TranscriptFiles(cfg)...)
+	for _, sink := range cfg.GetLogSinks() {
+		if sink.Type == "file" && sink.Target != "" {
+			files = append(files, sink.Target)
+		}
+	}
+	return files
+}
+
+// TranscriptFiles returns the debug transcription file of a configuration and its rotated
+// backups, oldest first
+func TranscriptFiles(cfg *config.Configuration) []string {
+	path := cfg.GetDebugTranscriptsPath()
+	if path == "" {
+		return nil
+	}
+	var files []string
+	for i := cfg.GetDebugTranscriptsMaxBackups(); i >= 1; i-- {
+		files = append(files, fmt.Sprintf("%s.%d", path, i))
+	}
+	return append(files, path)
+}
+
+// Search returns the matches in files, in file order. Missing files are skipped.
+func Search(files []string, opts Options) ([]Match, error) {
+	var matches []Match
+	for _, file := range files {
+		fileMatches, err := SearchFile(file, opts)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		matches = append(matches, fileMatches...)
+	}
+	return matches, nil
+}
+
+// SearchFile returns the matches in one JSONL transcription file or JSON or text cue log
+func SearchFile(path string, opts Options) ([]Match, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var matches []Match
+	var before []Record // The last opts.Context records, for the next match
+	var open []int      // Matches still collecting records after them
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		record, ok := parseRecord(scanner.Text())
+		if !ok {
+			continue
+		}
+		record.File = path
+		record.Line = line
+
+		stillOpen := open[:0]
+		for _, i := range open {
+			matches[i].After = append(matches[i].After, record)
+			if len(matches[i].After) < opts.Context {
+				stillOpen = append(stillOpen, i)
+			}
+		}
+		open = stillOpen
+
+		if opts.matches(record) {
+			matches = append(matches, Match{Record: record, Before: append([]Record(nil), before...)})
+			if opts.Context > 0 {
+				open = append(open, len(matches)-1)
+			}
+		}
+		if opts.Context > 0 {
+			before = append(before, record)
+			if len(before) > opts.Context {
+				before = before[1:]
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return matches, nil
+}
+
+// parseRecord reads a transcription or cue from one stored line
+func parseRecord(line string) (Record, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return Record{}, false
+	}
+
+	if strings.HasPrefix(line, "{") {
+		var fields struct {
+			Timestamp   string `json:"timestamp"`
+			Text        string `json:"text"`
+			ContestType string `json:"contest_type"`
+			Keyword     string `json:"keyword"`
+			Shortcode   string `json:"shortcode"`
+		}
+		if err := json.Unmarshal([]byte(line), &fields); err == nil {
+			record := Record{Kind: KindTranscript, Text: fields.Text}
+			if fields.ContestType != "" {
+				record.Kind = KindCue
+				record.Text = fmt.Sprintf("%s: text %s to %s", fields.ContestType, fields.Keyword, fields.Shortcode)
+			}
+			record.Time, _ = time.Parse(time.RFC3339, fields.Timestamp)
+			return record, true
+		}
+	}
+
+	// Text cue logs start with the time in parser.HumanTimeLayout, or RFC3339 without a timezone
+	record := Record{Kind: KindCue, Text: line}
+	parts := strings.SplitN(line, " ", 4)
+	if t, err := time.Parse(time.RFC3339, parts[0]); err == nil && len(parts) > 1 {
+		record.Time = t
+		record.Text = strings.Join(parts[1:], " ")
+	} else if len(parts) == 4 {
+		if t, err := time.Parse(parser.HumanTimeLayout, strings.Join(parts[:3], " ")); err == nil {
+			record.Time = t
+			record.Text = parts[3]
+		}
+	}
+	return record, true
+}
+
+// timeLayouts are the absolute forms ParseTime accepts, in the given time zone unless the value has one
+var timeLayouts = []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02"}
+
+// ParseTime reads a search time bound: RFC3339, a date with an optional time of day in loc
+// ("2026-10-16", "2026-10-16 06:00"), or a duration before now such as "36h"
+func ParseTime(value string, now time.Time, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q: use RFC3339, YYYY-MM-DD [HH:MM[:SS]], or a duration such as 24h", value)
+}
@@ -0,0 +1,172 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/config"
+)
+
+// writeLines writes lines to a file in a temporary directory and returns its path
+func writeLines(t *testing.T, name string, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644))
+	return path
+}
+
+func TestSearchFile(t *testing.T) {
+	transcripts := []string{
+		`{"timestamp":"2026-10-16T05:50:00Z","text":"good morning"}`,
+		`{"timestamp":"2026-10-16T07:10:00Z","text":"text SNOW to 55555"}`,
+		`{"timestamp":"2026-10-16T07:11:00Z","text":"traffic and weather"}`,
+		`{"timestamp":"2026-10-16T13:00:00Z","text":"snow day tomorrow"}`,
+	}
+
+	t.Run("should match the pattern against transcription text", func(t *testing.T) {
+		path := writeLines(t, "transcripts.jsonl", transcripts...)
+
+		matches, err := SearchFile(path, Options{Pattern: regexp.MustCompile("(?i)snow")})
+
+		require.NoError(t, err)
+		require.Len(t, matches, 2)
+		assert.Equal(t, 2, matches[0].Line)
+		assert.Equal(t, KindTranscript, matches[0].Kind)
+		assert.Equal(t, "text SNOW to 55555", matches[0].Text)
+		assert.Equal(t, time.Date(2026, 10, 16, 7, 10, 0, 0, time.UTC), matches[0].Time)
+	})
+
+	t.Run("should only match records within the time range", func(t *testing.T) {
+		path := writeLines(t, "transcripts.jsonl", transcripts...)
+		opts := Options{
+			Pattern: regexp.MustCompile("(?i)snow"),
+			Since:   time.Date(2026, 10, 16, 6, 0, 0, 0, time.UTC),
+			Until:   time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+		}
+
+		matches, err := SearchFile(path, opts)
+
+		require.NoError(t, err)
+		require.Len(t, matches, 1)
+		assert.Equal(t, "text SNOW to 55555", matches[0].Text)
+	})
+
+	t.Run("should include neighbouring records as context", func(t *testing.T) {
+		path := writeLines(t, "transcripts.jsonl", transcripts...)
+
+		matches, err := SearchFile(path, Options{Pattern: regexp.MustCompile("SNOW"), Context: 1})
+
+		require.NoError(t, err)
+		require.Len(t, matches, 1)
+		require.Len(t, matches[0].Before, 1)
+		assert.Equal(t, "good morning", matches[0].Before[0].Text)
+		require.Len(t, matches[0].After, 1)
+		assert.Equal(t, "traffic and weather", matches[0].After[0].Text)
+	})
+
+	t.Run("should read JSON and text cue logs", func(t *testing.T) {
+		path := writeLines(t, "cues.log",
+			`{"contest_type":"CASH","keyword":"SNOW","shortcode":"55555","timestamp":"2026-10-16T07:10:05Z","cue_id":"a"}`,
+			`2026-10-16 02:10:06 CDT CASH: text SNOW to 55555`,
+			`2026-10-16T07:10:07Z CASH: text SNOW to 55555`)
+
+		matches, err := SearchFile(path, Options{Pattern: regexp.MustCompile("text SNOW to 55555")})
+
+		require.NoError(t, err)
+		require.Len(t, matches, 3)
+		for _, match := range matches {
+			assert.Equal(t, KindCue, match.Kind)
+			assert.False(t, match.Time.IsZero(), match.Line)
+		}
+		assert.Equal(t, "CASH: text SNOW to 55555", matches[1].Text)
+	})
+}
+
+func TestSearch(t *testing.T) {
+	t.Run("should search every file and skip missing ones", func(t *testing.T) {
+		first := writeLines(t, "transcripts.jsonl.1", `{"timestamp":"2026-10-15T07:00:00Z","text":"SNOW yesterday"}`)
+		second := writeLines(t, "transcripts.jsonl", `{"timestamp":"2026-10-16T07:00:00Z","text":"SNOW today"}`)
+
+		matches, err := Search([]string{first, "/nonexistent/cues.log", second}, Options{Pattern: regexp.MustCompile("SNOW")})
+
+		require.NoError(t, err)
+		require.Len(t, matches, 2)
+		assert.Equal(t, "SNOW yesterday", matches[0].Text)
+		assert.Equal(t, "SNOW today", matches[1].Text)
+	})
+}
+
+func TestFiles(t *testing.T) {
+	t.Run("should list debug transcripts oldest first, then file cue sinks", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetDebugTranscriptsPath("/data/transcripts.jsonl")
+		cfg.SetLogSinks([]config.LogSink{{Type: "file", Target: "/data/cues.log"}, {Type: "stdout"}})
+
+		files := Files(cfg)
+
+		backups := cfg.GetDebugTranscriptsMaxBackups()
+		require.Len(t, files, backups+2)
+		assert.Equal(t, "/data/transcripts.jsonl.1", files[backups-1])
+		assert.Equal(t, "/data/transcripts.jsonl", files[backups])
+		assert.Equal(t, "/data/cues.log", files[backups+1])
+	})
+}
+
+func TestFiles_Tenants(t *testing.T) {
+	t.Run("should list the files of every tenant", func(t *testing.T) {
+		path := writeLines(t, "config.yaml",
+			"debug_transcripts:",
+			"  path: /data/transcripts.jsonl",
+			"  max_backups: 0",
+			"tenants:",
+			"  kiss: {}",
+			"  wxyz:",
+			"    log:",
+			"      file_path: /wxyz/cues.log")
+		cfg, err := config.NewConfigurationFromFile(path)
+		require.NoError(t, err)
+
+		files := Files(cfg)
+
+		assert.Equal(t, []string{
+			"/data/kiss/transcripts.jsonl", "logs/kiss/contest_output.log",
+			"/data/wxyz/transcripts.jsonl", "/wxyz/cues.log",
+		}, files)
+	})
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	chicago, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	t.Run("should read dates and times in the given time zone", func(t *testing.T) {
+		got, err := ParseTime("2026-10-16 06:00", now, chicago)
+
+		require.NoError(t, err)
+		assert.True(t, got.Equal(time.Date(2026, 10, 16, 11, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("should read RFC3339 and durations ago", func(t *testing.T) {
+		got, err := ParseTime("2026-10-16T06:00:00Z", now, chicago)
+		require.NoError(t, err)
+		assert.True(t, got.Equal(time.Date(2026, 10, 16, 6, 0, 0, 0, time.UTC)))
+
+		got, err = ParseTime("36h", now, chicago)
+		require.NoError(t, err)
+		assert.True(t, got.Equal(now.Add(-36*time.Hour)))
+	})
+
+	t.Run("should reject anything else", func(t *testing.T) {
+		_, err := ParseTime("yesterday", now, chicago)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,140 @@
+package stream
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/exec"
+)
+
+// captureProcess is a running capture pipeline, such as FFmpeg recording a sound card or rtl_fm
+// piped into FFmpeg, read from the last command's stdout as an io.ReadCloser
+type captureProcess struct {
+	source string // Describes what is captured, for errors
+	cmds   []*exec.Cmd
+	stderr []*bytes.Buffer
+	stdout io.ReadCloser
+
+	waitOnce sync.Once
+	waitErr  error
+}
+
+// startCapture starts cmds with each command's stdout piped into the next command's stdin
+func startCapture(logger *zap.Logger, source string, cmds ...*exec.Cmd) (*captureProcess, error) {
+	capture := &captureProcess{source: source, cmds: cmds}
+
+	var pipeEnds []*os.File
+	defer func() {
+		// Both ends belong to the child processes once started
+		for _, f := range pipeEnds {
+			f.Close()
+		}
+	}()
+	for i, cmd := range cmds {
+		stderr := &bytes.Buffer{}
+		cmd.Stderr = stderr
+		capture.stderr = append(capture.stderr, stderr)
+		if i == len(cmds)-1 {
+			break
+		}
+		r, w, err := os.Pipe()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create capture pipe: %w", err)
+		}
+		pipeEnds = append(pipeEnds, r, w)
+		cmd.Stdout = w
+		cmds[i+1].Stdin = r
+	}
+
+	stdout, err := cmds[len(cmds)-1].StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create capture pipe: %w", err)
+	}
+	capture.stdout = stdout
+
+	for i, cmd := range cmds {
+		cmd.OnAffinityError = func(err error) {
+			logger.Warn("failed to apply CPU affinity to capture process", zap.String("command", cmd.Path), zap.Error(err))
+		}
+		if err := cmd.Start(); err != nil {
+			capture.stop(cmds[:i])
+			return nil, fmt.Errorf("failed to start audio capture from %s: %w", source, err)
+		}
+	}
+	return capture, nil
+}
+
+// captureResponse wraps a capture as an HTTP response so retries, failover, format probing, and
+// listening statistics treat local sources and streams alike
+func captureResponse(capture *captureProcess) *http.Response {
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"audio/aac"}},
+		Body:       capture,
+	}
+}
+
+// Read implements io.Reader; when capture stops, the error includes the failing command's diagnostics
+func (c *captureProcess) Read(p []byte) (int, error) {
+	n, err := c.stdout.Read(p)
+	if err == io.EOF {
+		if waitErr := c.wait(); waitErr != nil {
+			return n, fmt.Errorf("audio capture from %s stopped: %w", c.source, waitErr)
+		}
+	}
+	return n, err
+}
+
+// Close stops the capture processes
+func (c *captureProcess) Close() error {
+	c.stop(c.cmds)
+	return nil
+}
+
+// stop kills cmds with any processes they started and reaps them
+func (c *captureProcess) stop(cmds []*exec.Cmd) {
+	for _, cmd := range cmds {
+		cmd.KillTree()
+	}
+	if len(cmds) == len(c.cmds) {
+		c.wait()
+		return
+	}
+	for _, cmd := range cmds {
+		cmd.Wait()
+	}
+}
+
+// wait reaps the capture processes once. It returns the first failure that explained itself on
+// stderr, since the other commands in a pipeline usually fail only as a consequence (e.g. broken pipe).
+func (c *captureProcess) wait() error {
+	c.waitOnce.Do(func() {
+		var silent error
+		for i, cmd := range c.cmds {
+			err := cmd.Wait()
+			if err == nil || c.waitErr != nil {
+				continue
+			}
+			diagnostics := strings.TrimSpace(c.stderr[i].String())
+			if diagnostics == "" {
+				if silent == nil {
+					silent = fmt.Errorf("%s: %w", cmd.Path, err)
+				}
+				continue
+			}
+			c.waitErr = fmt.Errorf("%s: %w: %s", cmd.Path, err, diagnostics)
+		}
+		if c.waitErr == nil {
+			c.waitErr = silent
+		}
+	})
+	return c.waitErr
+}
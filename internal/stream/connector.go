@@ -2,14 +2,29 @@ package stream
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"os"
 	"strconv"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/retry"
+)
+
+// DefaultFailoverThreshold is the number of consecutive connection failures on the active URL before failing over
+const DefaultFailoverThreshold = 3
+
+// Connection backoff limits: the cap on a single wait, and the fraction of each wait randomized so
+// reconnecting clients do not retry in lockstep
+const (
+	maxConnectBackoff    = 30 * time.Second
+	connectBackoffJitter = 0.2
 )
 
 // StreamConnector handles HTTP stream connections and provides io.Reader interface
@@ -21,6 +36,25 @@ type StreamConnector struct {
 	failureCount  int
 	maxRetries    int
 	baseBackoffMs int
+	backoffJitter float64            // Fraction of each retry delay randomized either way
+	throttle      *ReconnectThrottle // Limits connection attempts across retries and restarts; nil when unset
+
+	// Failover across multiple URLs for the same station, in priority order
+	mu                sync.Mutex
+	urls              []string
+	activeIndex       int
+	urlFailures       int            // Consecutive connection failures on the active URL
+	failoverThreshold int            // Failures on the active URL before moving to the next one
+	pendingPrimary    *http.Response // Primary connection established by the probe, swapped in on the next Read
+
+	probed []byte // Bytes consumed by ProbeFormat, replayed by Read before the response body
+
+	captureFFmpeg string // FFmpeg binary for device:// and sdr:// capture; empty uses ffmpeg from PATH
+	captureRTLFM  string // rtl_fm binary for sdr:// capture; empty uses rtl_fm from PATH
+
+	tap io.Writer // Receives a copy of every byte read, e.g. a Fingerprint; nil when unset
+
+	listening listeningTracker
 }
 
 // NewStreamConnector creates a new StreamConnector instance
@@ -41,11 +75,14 @@ func NewStreamConnector(url string) *StreamConnector {
 	}
 
 	return &StreamConnector{
-		url:           url,
-		client:        createStreamingHTTPClient(),
-		logger:        zap.NewNop(), // Default no-op logger
-		maxRetries:    maxRetries,
-		baseBackoffMs: baseBackoffMs,
+		url:               url,
+		client:            createStreamingHTTPClient(),
+		logger:            zap.NewNop(), // Default no-op logger
+		maxRetries:        maxRetries,
+		baseBackoffMs:     baseBackoffMs,
+		backoffJitter:     connectBackoffJitter,
+		urls:              []string{url},
+		failoverThreshold: DefaultFailoverThreshold,
 	}
 }
 
@@ -67,11 +104,114 @@ func NewStreamConnectorWithLogger(url string, logger *zap.Logger) *StreamConnect
 	}
 
 	return &StreamConnector{
-		url:           url,
-		client:        createStreamingHTTPClient(),
-		logger:        logger,
-		maxRetries:    maxRetries,
-		baseBackoffMs: baseBackoffMs,
+		url:               url,
+		client:            createStreamingHTTPClient(),
+		logger:            logger,
+		maxRetries:        maxRetries,
+		baseBackoffMs:     baseBackoffMs,
+		backoffJitter:     connectBackoffJitter,
+		urls:              []string{url},
+		failoverThreshold: DefaultFailoverThreshold,
+	}
+}
+
+// NewStreamConnectorWithFailover creates a StreamConnector for several URLs of the same station in
+// priority order. The first URL is the primary; the connector fails over down the list when the
+// active URL keeps failing and can switch back once the primary recovers (see MonitorPrimary).
+func NewStreamConnectorWithFailover(urls []string, failoverThreshold int, logger *zap.Logger) *StreamConnector {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	primary := ""
+	if len(urls) > 0 {
+		primary = urls[0]
+	}
+
+	s := NewStreamConnectorWithLogger(primary, logger)
+	if len(urls) > 0 {
+		s.urls = append([]string(nil), urls...)
+	}
+	if failoverThreshold > 0 {
+		s.failoverThreshold = failoverThreshold
+	}
+	return s
+}
+
+// ActiveURL returns the URL currently in use
+func (s *StreamConnector) ActiveURL() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.url
+}
+
+// URLs returns the configured stream URLs in priority order
+func (s *StreamConnector) URLs() []string {
+	return append([]string(nil), s.urls...)
+}
+
+// recordConnectResult tracks consecutive failures on the active URL and fails over to the
+// next URL in priority order once the threshold is reached
+func (s *StreamConnector) recordConnectResult(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err == nil {
+		s.urlFailures = 0
+		return
+	}
+
+	s.urlFailures++
+	if len(s.urls) < 2 || s.urlFailures < s.failoverThreshold {
+		return
+	}
+
+	previous := s.url
+	s.activeIndex = (s.activeIndex + 1) % len(s.urls)
+	s.url = s.urls[s.activeIndex]
+	s.urlFailures = 0
+	s.logger.Warn("failing over to next stream URL",
+		zap.String("from_url", previous),
+		zap.String("to_url", s.url),
+		zap.Int("priority", s.activeIndex))
+}
+
+// MonitorPrimary periodically probes the primary URL while running on a backup and, once the
+// primary answers, connects to it so the next Read switches back seamlessly. Blocks until ctx is cancelled.
+func (s *StreamConnector) MonitorPrimary(ctx context.Context, interval time.Duration) {
+	if len(s.urls) < 2 || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			onPrimary := s.activeIndex == 0
+			pending := s.pendingPrimary != nil
+			s.mu.Unlock()
+			if onPrimary || pending {
+				continue
+			}
+
+			resp, err := s.openURL(ctx, s.urls[0])
+			if err != nil {
+				s.logger.Debug("primary stream still unavailable",
+					zap.String("url", s.urls[0]),
+					zap.Error(err))
+				continue
+			}
+
+			s.mu.Lock()
+			s.pendingPrimary = resp
+			s.mu.Unlock()
+			s.logger.Info("primary stream recovered, switching back",
+				zap.String("url", s.urls[0]))
+		}
 	}
 }
 
@@ -99,17 +239,67 @@ func createStreamingHTTPClient() *http.Client {
 	}
 }
 
-// Connect establishes connection to the stream URL
+// SetReconnectThrottle limits connection attempts made by Connect with throttle
+func (s *StreamConnector) SetReconnectThrottle(throttle *ReconnectThrottle) {
+	s.throttle = throttle
+}
+
+// SetBackoffJitter sets the fraction (0 to 1) of each retry delay randomized either way
+func (s *StreamConnector) SetBackoffJitter(jitter float64) {
+	s.backoffJitter = jitter
+}
+
+// Connect establishes connection to the stream URL, waiting first if the reconnect throttle
+// does not allow another attempt yet
 func (s *StreamConnector) Connect(ctx context.Context) error {
+	if err := s.throttle.Wait(ctx); err != nil {
+		return err
+	}
+	url := s.ActiveURL()
+	resp, err := s.openURL(ctx, url)
+	s.throttle.Record(err)
+	s.recordConnectResult(err)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.response = resp
+	s.probed = nil
+	s.mu.Unlock()
+	s.listening.markConnected()
+	return nil
+}
+
+// ListeningStats returns cumulative connection statistics since the connector was created
+func (s *StreamConnector) ListeningStats() ListeningStats {
+	return s.listening.snapshot()
+}
+
+// openURL performs the HTTP request for a stream URL and returns the open response
+func (s *StreamConnector) openURL(ctx context.Context, url string) (*http.Response, error) {
+	if device, ok, err := ParseDeviceURL(url); ok {
+		if err != nil {
+			return nil, err
+		}
+		return s.openDevice(ctx, device)
+	}
+	if sdr, ok, err := ParseSDRURL(url); ok {
+		if err != nil {
+			return nil, err
+		}
+		return s.openSDR(ctx, sdr)
+	}
+
 	s.logger.Info("attempting to connect to stream",
-		zap.String("url", s.url))
+		zap.String("url", url))
 
-	req, err := http.NewRequestWithContext(ctx, "GET", s.url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		s.logger.Error("failed to create HTTP request",
-			zap.String("url", s.url),
+			zap.String("url", url),
 			zap.Error(err))
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set realistic browser User-Agent to avoid being flagged as a bot
@@ -132,44 +322,131 @@ func (s *StreamConnector) Connect(ctx context.Context) error {
 	resp, err := s.client.Do(req)
 	if err != nil {
 		s.logger.Error("failed to connect to stream",
-			zap.String("url", s.url),
+			zap.String("url", url),
 			zap.Error(err))
-		return fmt.Errorf("failed to connect to stream %s: %w", s.url, err)
+		return nil, fmt.Errorf("failed to connect to stream %s: %w", url, err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		resp.Body.Close()
 		s.logger.Error("stream connection failed with non-200 status",
-			zap.String("url", s.url),
+			zap.String("url", url),
 			zap.Int("status_code", resp.StatusCode))
-		return fmt.Errorf("failed to connect to stream %s: status %d", s.url, resp.StatusCode)
+		return nil, fmt.Errorf("failed to connect to stream %s: status %d", url, resp.StatusCode)
 	}
 
 	s.logger.Info("successfully connected to stream",
-		zap.String("url", s.url),
+		zap.String("url", url),
 		zap.Int("status_code", resp.StatusCode),
 		zap.String("content_type", resp.Header.Get("Content-Type")))
 
-	s.response = resp
-	return nil
+	return resp, nil
+}
+
+// SetTap copies every byte subsequently read from the stream to w
+func (s *StreamConnector) SetTap(w io.Writer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tap = w
 }
 
 // Read implements io.Reader interface
 func (s *StreamConnector) Read(p []byte) (n int, err error) {
-	if s.response == nil {
+	s.mu.Lock()
+	tap := s.tap
+	if len(s.probed) > 0 {
+		n = copy(p, s.probed)
+		s.probed = s.probed[n:]
+		s.mu.Unlock()
+		s.listening.addBytes(n)
+		if tap != nil {
+			tap.Write(p[:n])
+		}
+		return n, nil
+	}
+	if s.pendingPrimary != nil {
+		// The primary recovered while running on a backup - swap connections between reads
+		if s.response != nil {
+			s.response.Body.Close()
+		}
+		s.response = s.pendingPrimary
+		s.pendingPrimary = nil
+		s.activeIndex = 0
+		s.url = s.urls[0]
+		s.urlFailures = 0
+	}
+	response := s.response
+	s.mu.Unlock()
+
+	if response == nil {
 		return 0, fmt.Errorf("not connected to stream")
 	}
 
-	return s.response.Body.Read(p)
+	n, err = response.Body.Read(p)
+	s.listening.addBytes(n)
+	if tap != nil && n > 0 {
+		tap.Write(p[:n])
+	}
+	if err != nil {
+		s.listening.markDisconnected()
+	}
+	return n, err
+}
+
+// ProbeFormat reads up to n bytes from the start of the connected stream and detects its audio
+// format. The probed bytes are not lost: Read returns them before continuing with the stream.
+func (s *StreamConnector) ProbeFormat(n int) (AudioFormat, error) {
+	if n <= 0 {
+		n = DefaultProbeBytes
+	}
+
+	s.mu.Lock()
+	response := s.response
+	s.mu.Unlock()
+	if response == nil {
+		return AudioFormat{}, fmt.Errorf("not connected to stream")
+	}
+
+	buf := make([]byte, n)
+	read, err := io.ReadFull(response.Body, buf)
+
+	s.mu.Lock()
+	s.probed = append(s.probed, buf[:read]...)
+	data := append([]byte(nil), s.probed...)
+	s.mu.Unlock()
+
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return AudioFormat{}, fmt.Errorf("failed to read stream header: %w", err)
+	}
+	if read == 0 && len(data) == 0 {
+		return AudioFormat{}, fmt.Errorf("stream ended before any data was received")
+	}
+
+	return DetectFormat(data, response.Header.Get("Content-Type")), nil
 }
 
 // ConnectWithRetry attempts to connect to the stream with automatic retry logic
 func (s *StreamConnector) ConnectWithRetry(ctx context.Context) error {
-	var lastErr error
+	if s.maxRetries <= 0 {
+		return fmt.Errorf("maximum retry attempts exceeded after %d failures", s.maxRetries)
+	}
+
+	policy := retry.Policy{
+		MaxAttempts: s.maxRetries,
+		BaseDelay:   time.Duration(s.baseBackoffMs) * time.Millisecond,
+		MaxDelay:    maxConnectBackoff,
+		Jitter:      s.backoffJitter,
+		OnRetry: func(next int, delay time.Duration, err error) {
+			s.logger.Info("waiting before retry",
+				zap.String("url", s.ActiveURL()),
+				zap.Duration("backoff", delay),
+				zap.Int("next_attempt", next))
+		},
+	}
 
-	for attempt := 1; attempt <= s.maxRetries; attempt++ {
+	err := retry.Do(ctx, policy, func(ctx context.Context, attempt int) error {
 		s.logger.Info("attempting connection",
-			zap.String("url", s.url),
+			zap.String("url", s.ActiveURL()),
 			zap.Int("attempt", attempt),
 			zap.Int("failure_count", s.failureCount))
 
@@ -178,60 +455,77 @@ func (s *StreamConnector) ConnectWithRetry(ctx context.Context) error {
 			// Successful connection - reset failure counter
 			s.failureCount = 0
 			s.logger.Info("connection successful, failure counter reset",
-				zap.String("url", s.url),
+				zap.String("url", s.ActiveURL()),
 				zap.Int("attempt", attempt))
 			return nil
 		}
 
-		lastErr = err
 		s.failureCount++
 		s.logger.Warn("connection attempt failed",
-			zap.String("url", s.url),
+			zap.String("url", s.ActiveURL()),
 			zap.Int("attempt", attempt),
 			zap.Int("failure_count", s.failureCount),
 			zap.Error(err))
+		return err
+	})
+	if err == nil {
+		return nil
+	}
 
-		// If this was the last attempt, don't wait
-		if attempt == s.maxRetries {
-			break
-		}
-
-		// Calculate exponential backoff delay
-		backoffMs := s.baseBackoffMs * (1 << (attempt - 1)) // 2^(attempt-1) * baseBackoff
-		backoffDuration := time.Duration(backoffMs) * time.Millisecond
-
-		s.logger.Info("waiting before retry",
-			zap.String("url", s.url),
-			zap.Duration("backoff", backoffDuration),
-			zap.Int("next_attempt", attempt+1))
-
-		// Wait with context cancellation support
-		select {
-		case <-ctx.Done():
-			// Include the last connection error to preserve error type information
-			if lastErr != nil {
-				return fmt.Errorf("failed to connect to stream after retries: connection cancelled: %w (last error: %v)", ctx.Err(), lastErr)
-			}
-			return fmt.Errorf("connection cancelled: %w", ctx.Err())
-		case <-time.After(backoffDuration):
-			// Continue to next attempt
-		}
+	var retryErr *retry.Error
+	if errors.As(err, &retryErr) && ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+		// Include the last connection error to preserve error type information
+		return fmt.Errorf("failed to connect to stream after retries: connection cancelled: %w (last error: %v)", ctx.Err(), retryErr.Last)
 	}
 
 	s.logger.Error("maximum retry attempts exceeded",
-		zap.String("url", s.url),
+		zap.String("url", s.ActiveURL()),
 		zap.Int("max_retries", s.maxRetries),
 		zap.Int("failure_count", s.failureCount))
 
 	return fmt.Errorf("maximum retry attempts exceeded after %d failures", s.maxRetries)
 }
 
+// Name identifies the stream connector among the application's components
+func (s *StreamConnector) Name() string {
+	return "stream"
+}
+
+// Start connects to the stream, retrying with backoff
+func (s *StreamConnector) Start(ctx context.Context) error {
+	return s.ConnectWithRetry(ctx)
+}
+
+// Stop closes the current connection
+func (s *StreamConnector) Stop(ctx context.Context) error {
+	return s.Close()
+}
+
+// Healthy returns an error while no stream connection is open
+func (s *StreamConnector) Healthy() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.response == nil {
+		return errors.New("stream not connected")
+	}
+	return nil
+}
+
 // Close closes the current connection
 func (s *StreamConnector) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pendingPrimary != nil {
+		s.pendingPrimary.Body.Close()
+		s.pendingPrimary = nil
+	}
+	s.probed = nil
 	if s.response != nil {
 		s.logger.Info("closing stream connection", zap.String("url", s.url))
 		err := s.response.Body.Close()
 		s.response = nil
+		s.listening.markDisconnected()
 		return err
 	}
 	return nil
@@ -161,6 +161,29 @@ func TestStreamConnector_Connect(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "status 404")
 	})
+
+	t.Run("should not contact the stream host while reconnects are cooling down", func(t *testing.T) {
+		// Arrange
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		connector := NewStreamConnector(server.URL)
+		connector.SetReconnectThrottle(NewReconnectThrottle(ThrottleOptions{CooldownAfterFailures: 1, Cooldown: time.Hour}, nil))
+		assert.Error(t, connector.Connect(context.Background()))
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		// Act
+		err := connector.Connect(ctx)
+
+		// Assert
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 1, requests)
+	})
 }
 
 func TestStreamConnector_ConnectWithRetry(t *testing.T) {
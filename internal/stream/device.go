@@ -0,0 +1,160 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/exec"
+)
+
+// DeviceScheme marks stream URLs that capture from a local sound card instead of fetching over HTTP,
+// e.g. device://alsa?name=hw%3A1%2C0&sample_rate=48000&channels=2
+const DeviceScheme = "device"
+
+// Defaults for sound card capture
+const (
+	DefaultDeviceSampleRate = 48000
+	DefaultDeviceChannels   = 2
+)
+
+// captureBitrate is the AAC bitrate the captured audio is encoded at before decoding for transcription
+const captureBitrate = "128k"
+
+// DeviceSource describes a local audio capture device, such as the line input an FM receiver is
+// plugged into. Capture runs through FFmpeg and is encoded as ADTS AAC, so it feeds the same
+// decoding and transcription pipeline as an internet stream.
+type DeviceSource struct {
+	Driver     string // FFmpeg input device: alsa, pulse, avfoundation, or dshow
+	Name       string // Device name, e.g. hw:1,0 (ALSA), default (PulseAudio), :0 (macOS), Line In (Windows)
+	SampleRate int
+	Channels   int
+}
+
+// DefaultDeviceDriver returns the FFmpeg capture driver for this operating system
+func DefaultDeviceDriver() string {
+	return defaultDeviceDriverFor(runtime.GOOS)
+}
+
+func defaultDeviceDriverFor(goos string) string {
+	switch goos {
+	case "darwin":
+		return "avfoundation"
+	case "windows":
+		return "dshow"
+	default:
+		return "alsa"
+	}
+}
+
+// URL returns the stream URL that captures from this device
+func (d DeviceSource) URL() string {
+	query := url.Values{}
+	query.Set("name", d.Name)
+	if d.SampleRate > 0 {
+		query.Set("sample_rate", strconv.Itoa(d.SampleRate))
+	}
+	if d.Channels > 0 {
+		query.Set("channels", strconv.Itoa(d.Channels))
+	}
+	return (&url.URL{Scheme: DeviceScheme, Host: d.Driver, RawQuery: query.Encode()}).String()
+}
+
+// ParseDeviceURL parses a device:// stream URL. It reports false for any other URL.
+func ParseDeviceURL(raw string) (DeviceSource, bool, error) {
+	if !strings.HasPrefix(raw, DeviceScheme+"://") {
+		return DeviceSource{}, false, nil
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return DeviceSource{}, true, fmt.Errorf("invalid device URL %q: %w", raw, err)
+	}
+
+	query := parsed.Query()
+	device := DeviceSource{
+		Driver:     parsed.Host,
+		Name:       query.Get("name"),
+		SampleRate: DefaultDeviceSampleRate,
+		Channels:   DefaultDeviceChannels,
+	}
+	if device.Driver == "" {
+		device.Driver = DefaultDeviceDriver()
+	}
+	if device.Name == "" {
+		return DeviceSource{}, true, fmt.Errorf("device URL %q has no device name", raw)
+	}
+	for key, target := range map[string]*int{"sample_rate": &device.SampleRate, "channels": &device.Channels} {
+		value := query.Get(key)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return DeviceSource{}, true, fmt.Errorf("invalid %s %q in device URL", key, value)
+		}
+		*target = n
+	}
+	return device, true, nil
+}
+
+// ffmpegArgs returns the FFmpeg arguments capturing from the device and writing ADTS AAC to stdout
+func (d DeviceSource) ffmpegArgs() []string {
+	args := []string{"-hide_banner", "-loglevel", "error", "-f", d.Driver}
+
+	// ALSA and PulseAudio accept the capture format as input options; other drivers use the
+	// device's native format and are resampled on output
+	input := d.Name
+	switch d.Driver {
+	case "alsa", "pulse":
+		args = append(args, "-sample_rate", strconv.Itoa(d.SampleRate), "-channels", strconv.Itoa(d.Channels))
+	case "avfoundation":
+		if !strings.Contains(input, ":") {
+			input = ":" + input // Audio-only input
+		}
+	case "dshow":
+		if !strings.HasPrefix(input, "audio=") {
+			input = "audio=" + input
+		}
+	}
+
+	return append(args,
+		"-i", input,
+		"-ar", strconv.Itoa(d.SampleRate),
+		"-ac", strconv.Itoa(d.Channels),
+		"-c:a", "aac",
+		"-b:a", captureBitrate,
+		"-f", "adts",
+		"pipe:1",
+	)
+}
+
+// openDevice starts capturing from a device. The capture is returned as an HTTP response so
+// retries, failover, format probing, and listening statistics treat devices and streams alike.
+func (s *StreamConnector) openDevice(ctx context.Context, device DeviceSource) (*http.Response, error) {
+	s.logger.Info("starting audio capture from device",
+		zap.String("driver", device.Driver),
+		zap.String("device", device.Name),
+		zap.Int("sample_rate", device.SampleRate),
+		zap.Int("channels", device.Channels))
+
+	capture, err := startCapture(s.logger, fmt.Sprintf("%s device %q", device.Driver, device.Name),
+		exec.Command(ctx, s.ffmpegPath(), device.ffmpegArgs()...))
+	if err != nil {
+		return nil, err
+	}
+	return captureResponse(capture), nil
+}
+
+// ffmpegPath returns the FFmpeg binary used for device and SDR capture
+func (s *StreamConnector) ffmpegPath() string {
+	if s.captureFFmpeg != "" {
+		return s.captureFFmpeg
+	}
+	return "ffmpeg"
+}
@@ -0,0 +1,147 @@
+package stream
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCommand writes an executable script standing in for FFmpeg or rtl_fm during capture
+func fakeCommand(t *testing.T, script string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "command")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755))
+	return path
+}
+
+func TestDeviceSource_URL(t *testing.T) {
+	t.Run("should round-trip through ParseDeviceURL", func(t *testing.T) {
+		// Arrange
+		device := DeviceSource{Driver: "alsa", Name: "hw:1,0", SampleRate: 44100, Channels: 1}
+
+		// Act
+		parsed, ok, err := ParseDeviceURL(device.URL())
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, device, parsed)
+	})
+
+	t.Run("should apply defaults for omitted settings", func(t *testing.T) {
+		parsed, ok, err := ParseDeviceURL("device://?name=default")
+
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, DefaultDeviceDriver(), parsed.Driver)
+		assert.Equal(t, DefaultDeviceSampleRate, parsed.SampleRate)
+		assert.Equal(t, DefaultDeviceChannels, parsed.Channels)
+	})
+
+	t.Run("should ignore non-device URLs", func(t *testing.T) {
+		_, ok, err := ParseDeviceURL("https://example.com/stream.aac")
+
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("should reject invalid device URLs", func(t *testing.T) {
+		for _, raw := range []string{
+			"device://alsa",
+			"device://alsa?name=hw:0&sample_rate=fast",
+			"device://alsa?name=hw:0&channels=0",
+		} {
+			_, ok, err := ParseDeviceURL(raw)
+
+			assert.True(t, ok, raw)
+			assert.Error(t, err, raw)
+		}
+	})
+}
+
+func TestDeviceSource_FFmpegArgs(t *testing.T) {
+	t.Run("should pass the capture format to ALSA", func(t *testing.T) {
+		args := DeviceSource{Driver: "alsa", Name: "hw:1,0", SampleRate: 48000, Channels: 2}.ffmpegArgs()
+
+		assert.Equal(t, []string{
+			"-hide_banner", "-loglevel", "error", "-f", "alsa",
+			"-sample_rate", "48000", "-channels", "2", "-i", "hw:1,0",
+			"-ar", "48000", "-ac", "2", "-c:a", "aac", "-b:a", "128k", "-f", "adts", "pipe:1",
+		}, args)
+	})
+
+	t.Run("should select an audio-only avfoundation input", func(t *testing.T) {
+		args := DeviceSource{Driver: "avfoundation", Name: "0", SampleRate: 48000, Channels: 2}.ffmpegArgs()
+
+		assert.Contains(t, args, ":0")
+		assert.NotContains(t, args, "-sample_rate")
+	})
+
+	t.Run("should name a dshow audio device", func(t *testing.T) {
+		args := DeviceSource{Driver: "dshow", Name: "Line In", SampleRate: 48000, Channels: 2}.ffmpegArgs()
+
+		assert.Contains(t, args, "audio=Line In")
+	})
+}
+
+func TestDefaultDeviceDriver(t *testing.T) {
+	t.Run("should pick the capture driver for each operating system", func(t *testing.T) {
+		assert.Equal(t, "avfoundation", defaultDeviceDriverFor("darwin"))
+		assert.Equal(t, "dshow", defaultDeviceDriverFor("windows"))
+		assert.Equal(t, "alsa", defaultDeviceDriverFor("linux"))
+	})
+}
+
+func TestStreamConnector_DeviceCapture(t *testing.T) {
+	t.Run("should stream captured audio like an AAC stream", func(t *testing.T) {
+		// Arrange
+		payload := adtsFrames(10, 3, 2, 128)
+		payloadPath := filepath.Join(t.TempDir(), "capture.aac")
+		require.NoError(t, os.WriteFile(payloadPath, payload, 0644))
+
+		device := DeviceSource{Driver: "alsa", Name: "hw:1,0", SampleRate: 48000, Channels: 2}
+		connector := NewStreamConnector(device.URL())
+		connector.captureFFmpeg = fakeCommand(t, "cat "+payloadPath+"\n")
+		require.NoError(t, connector.Connect(context.Background()))
+		defer connector.Close()
+
+		// Act
+		format, err := connector.ProbeFormat(512)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, CodecAAC, format.Codec)
+
+		read, err := io.ReadAll(connector)
+		require.NoError(t, err)
+		assert.Equal(t, payload, read)
+	})
+
+	t.Run("should report FFmpeg diagnostics when capture fails", func(t *testing.T) {
+		device := DeviceSource{Driver: "alsa", Name: "hw:9,0", SampleRate: 48000, Channels: 2}
+		connector := NewStreamConnector(device.URL())
+		connector.captureFFmpeg = fakeCommand(t, "echo 'cannot open audio device hw:9,0' >&2\nexit 1\n")
+		require.NoError(t, connector.Connect(context.Background()))
+		defer connector.Close()
+
+		_, err := io.ReadAll(connector)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot open audio device hw:9,0")
+	})
+
+	t.Run("should fail to connect when FFmpeg is missing", func(t *testing.T) {
+		device := DeviceSource{Driver: "alsa", Name: "hw:1,0", SampleRate: 48000, Channels: 2}
+		connector := NewStreamConnector(device.URL())
+		connector.captureFFmpeg = filepath.Join(t.TempDir(), "missing-ffmpeg")
+
+		err := connector.Connect(context.Background())
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,134 @@
+package stream
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// toggleServer serves data while up and 503 while down
+func toggleServer(t *testing.T, data string, up *atomic.Bool) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !up.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "audio/aac")
+		w.Write([]byte(data))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNewStreamConnectorWithFailover(t *testing.T) {
+	t.Run("should start on the primary URL", func(t *testing.T) {
+		urls := []string{"http://primary.example/stream", "http://backup.example/stream"}
+
+		connector := NewStreamConnectorWithFailover(urls, 0, nil)
+
+		assert.Equal(t, "http://primary.example/stream", connector.ActiveURL())
+		assert.Equal(t, urls, connector.URLs())
+		assert.Equal(t, DefaultFailoverThreshold, connector.failoverThreshold)
+	})
+}
+
+func TestStreamConnector_Failover(t *testing.T) {
+	t.Run("should fail over in priority order after repeated failures", func(t *testing.T) {
+		// Arrange
+		var primaryUp, backup1Up, backup2Up atomic.Bool
+		backup2Up.Store(true)
+		primary := toggleServer(t, "primary", &primaryUp)
+		backup1 := toggleServer(t, "backup1", &backup1Up)
+		backup2 := toggleServer(t, "backup2", &backup2Up)
+
+		connector := NewStreamConnectorWithFailover([]string{primary.URL, backup1.URL, backup2.URL}, 2, nil)
+		connector.maxRetries = 6
+		connector.baseBackoffMs = 0
+
+		// Act
+		err := connector.ConnectWithRetry(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, backup2.URL, connector.ActiveURL())
+		data, _ := io.ReadAll(connector)
+		assert.Equal(t, "backup2", string(data))
+	})
+
+	t.Run("should stay on the active URL below the failure threshold", func(t *testing.T) {
+		// Arrange
+		var primaryUp, backupUp atomic.Bool
+		backupUp.Store(true)
+		primary := toggleServer(t, "primary", &primaryUp)
+		backup := toggleServer(t, "backup", &backupUp)
+		connector := NewStreamConnectorWithFailover([]string{primary.URL, backup.URL}, 3, nil)
+
+		// Act
+		err1 := connector.Connect(context.Background())
+		err2 := connector.Connect(context.Background())
+
+		// Assert
+		assert.Error(t, err1)
+		assert.Error(t, err2)
+		assert.Equal(t, primary.URL, connector.ActiveURL())
+	})
+}
+
+func TestStreamConnector_MonitorPrimary(t *testing.T) {
+	t.Run("should switch back to the primary once it recovers", func(t *testing.T) {
+		// Arrange
+		var primaryUp, backupUp atomic.Bool
+		backupUp.Store(true)
+		primary := toggleServer(t, "primary", &primaryUp)
+		backup := toggleServer(t, "backup", &backupUp)
+
+		connector := NewStreamConnectorWithFailover([]string{primary.URL, backup.URL}, 1, nil)
+		connector.maxRetries = 2
+		connector.baseBackoffMs = 0
+		require.NoError(t, connector.ConnectWithRetry(context.Background()))
+		require.Equal(t, backup.URL, connector.ActiveURL())
+		defer connector.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go connector.MonitorPrimary(ctx, 10*time.Millisecond)
+
+		// Act
+		primaryUp.Store(true)
+		assert.Eventually(t, func() bool {
+			connector.mu.Lock()
+			defer connector.mu.Unlock()
+			return connector.pendingPrimary != nil
+		}, 2*time.Second, 10*time.Millisecond)
+		data, err := io.ReadAll(connector)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "primary", string(data))
+		assert.Equal(t, primary.URL, connector.ActiveURL())
+	})
+
+	t.Run("should return immediately with a single URL", func(t *testing.T) {
+		connector := NewStreamConnector("http://only.example/stream")
+
+		done := make(chan struct{})
+		go func() {
+			connector.MonitorPrimary(context.Background(), time.Millisecond)
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("MonitorPrimary should not run without backup URLs")
+		}
+	})
+}
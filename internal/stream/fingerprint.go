@@ -0,0 +1,122 @@
+package stream
+
+import (
+	"hash/fnv"
+	"sync"
+)
+
+// Chunk size bounds for content-defined chunking. Boundaries fall where the rolling hash matches
+// chunkMask, giving chunks of about 1 KiB that start at the same bytes regardless of where a
+// listener joined the stream.
+const (
+	minChunkBytes = 64
+	maxChunkBytes = 8192
+	chunkMask     = 1<<10 - 1
+)
+
+// gearTable holds the per-byte values of the rolling gear hash
+var gearTable = func() [256]uint64 {
+	var table [256]uint64
+	state := uint64(0x9E3779B97F4A7C15)
+	for i := range table {
+		// splitmix64 gives a fixed, well-mixed table
+		state += 0x9E3779B97F4A7C15
+		z := state
+		z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+		z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+		table[i] = z ^ (z >> 31)
+	}
+	return table
+}()
+
+// Fingerprint keeps hashes of the most recent content-defined chunks of a byte stream. Two
+// relays of the same feed produce the same chunks even when they started at different points,
+// so comparing fingerprints detects byte-identical streams without decoding audio. It
+// implements io.Writer and is safe for concurrent use.
+type Fingerprint struct {
+	mu       sync.Mutex
+	capacity int
+	ring     []uint64 // Chunk hashes, oldest overwritten first
+	next     int
+	counts   map[uint64]int // Occurrences of each hash in ring
+	rolling  uint64
+	chunk    []byte // Bytes of the chunk in progress
+	started  bool   // Whether a chunk boundary has been seen; the partial first chunk is discarded
+}
+
+// NewFingerprint creates a Fingerprint remembering the last capacity chunks
+func NewFingerprint(capacity int) *Fingerprint {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &Fingerprint{capacity: capacity, counts: make(map[uint64]int, capacity)}
+}
+
+// Write adds stream bytes to the fingerprint
+func (f *Fingerprint) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, b := range p {
+		f.rolling = f.rolling<<1 + gearTable[b]
+		f.chunk = append(f.chunk, b)
+		size := len(f.chunk)
+		if size < minChunkBytes {
+			continue
+		}
+		if f.rolling&chunkMask == 0 || size >= maxChunkBytes {
+			if f.started {
+				f.add(f.chunk)
+			}
+			f.started = true
+			f.chunk = f.chunk[:0]
+		}
+	}
+	return len(p), nil
+}
+
+// add records a completed chunk
+func (f *Fingerprint) add(chunk []byte) {
+	h := fnv.New64a()
+	h.Write(chunk)
+	sum := h.Sum64()
+
+	if len(f.ring) < f.capacity {
+		f.ring = append(f.ring, sum)
+	} else {
+		old := f.ring[f.next]
+		if f.counts[old]--; f.counts[old] == 0 {
+			delete(f.counts, old)
+		}
+		f.ring[f.next] = sum
+		f.next = (f.next + 1) % f.capacity
+	}
+	f.counts[sum]++
+}
+
+// Len returns how many chunks the fingerprint holds
+func (f *Fingerprint) Len() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.ring)
+}
+
+// Similarity returns the fraction of other's chunks that also appear in f, from 0 to 1. It is 0
+// when other holds no chunks.
+func (f *Fingerprint) Similarity(other *Fingerprint) float64 {
+	other.mu.Lock()
+	hashes := append([]uint64(nil), other.ring...)
+	other.mu.Unlock()
+	if len(hashes) == 0 {
+		return 0
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	matched := 0
+	for _, sum := range hashes {
+		if f.counts[sum] > 0 {
+			matched++
+		}
+	}
+	return float64(matched) / float64(len(hashes))
+}
@@ -0,0 +1,63 @@
+package stream
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// randomStream returns n reproducible pseudo-random bytes standing in for compressed audio
+func randomStream(seed int64, n int) []byte {
+	data := make([]byte, n)
+	rand.New(rand.NewSource(seed)).Read(data)
+	return data
+}
+
+func TestFingerprint_Similarity(t *testing.T) {
+	t.Run("should match a relay that joined the same feed at a different point", func(t *testing.T) {
+		// Arrange
+		feed := randomStream(1, 256*1024)
+		primary := NewFingerprint(4096)
+		relay := NewFingerprint(4096)
+
+		// Act
+		primary.Write(feed)
+		for _, part := range [][]byte{feed[77777:100000], feed[100000:200000]} {
+			relay.Write(part) // Arbitrary write sizes must not matter
+		}
+
+		// Assert
+		assert.Greater(t, relay.Len(), 50)
+		assert.Greater(t, primary.Similarity(relay), 0.95)
+	})
+
+	t.Run("should not match a different feed", func(t *testing.T) {
+		primary := NewFingerprint(4096)
+		other := NewFingerprint(4096)
+
+		primary.Write(randomStream(1, 128*1024))
+		other.Write(randomStream(2, 128*1024))
+
+		assert.Less(t, primary.Similarity(other), 0.05)
+	})
+
+	t.Run("should forget chunks beyond its capacity", func(t *testing.T) {
+		feed := randomStream(3, 512*1024)
+		primary := NewFingerprint(16)
+		early := NewFingerprint(4096)
+
+		early.Write(feed[:64*1024])
+		primary.Write(feed)
+
+		assert.Equal(t, 16, primary.Len())
+		assert.Less(t, primary.Similarity(early), 0.05)
+	})
+
+	t.Run("should report no similarity to an empty fingerprint", func(t *testing.T) {
+		primary := NewFingerprint(16)
+		primary.Write(randomStream(4, 64*1024))
+
+		assert.Equal(t, 0.0, primary.Similarity(NewFingerprint(16)))
+	})
+}
@@ -0,0 +1,237 @@
+package stream
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Codec names reported by DetectFormat
+const (
+	CodecAAC      = "aac"
+	CodecMP3      = "mp3"
+	CodecMPEG     = "mpeg" // MPEG audio layer I/II
+	CodecOgg      = "ogg"
+	CodecOpus     = "opus"
+	CodecVorbis   = "vorbis"
+	CodecFLAC     = "flac"
+	CodecHTML     = "html"
+	CodecPlaylist = "playlist"
+	CodecUnknown  = "unknown"
+)
+
+// DefaultProbeBytes is how much of the stream is inspected to detect its format
+const DefaultProbeBytes = 8192
+
+// AudioFormat describes the audio format detected at the start of a stream
+type AudioFormat struct {
+	Codec       string
+	Profile     string // e.g. "LC" for AAC
+	SampleRate  int    // Hz, 0 when unknown
+	Channels    int    // 0 when unknown
+	BitrateKbps int    // 0 when unknown
+	ContentType string // HTTP Content-Type reported by the server
+}
+
+// String returns a short human-readable description such as "MP3 128k 44100Hz"
+func (f AudioFormat) String() string {
+	parts := []string{strings.ToUpper(f.Codec)}
+	if f.Profile != "" {
+		parts = append(parts, f.Profile)
+	}
+	if f.BitrateKbps > 0 {
+		parts = append(parts, fmt.Sprintf("%dk", f.BitrateKbps))
+	}
+	if f.SampleRate > 0 {
+		parts = append(parts, fmt.Sprintf("%dHz", f.SampleRate))
+	}
+	switch f.Channels {
+	case 1:
+		parts = append(parts, "mono")
+	case 2:
+		parts = append(parts, "stereo")
+	}
+	return strings.Join(parts, " ")
+}
+
+var (
+	adtsSampleRates = []int{96000, 88200, 64000, 48000, 44100, 32000, 24000, 22050, 16000, 12000, 11025, 8000, 7350}
+	aacProfiles     = []string{"Main", "LC", "SSR", "LTP"}
+
+	mpegSampleRates = map[int][]int{
+		3: {44100, 48000, 32000}, // MPEG-1
+		2: {22050, 24000, 16000}, // MPEG-2
+		0: {11025, 12000, 8000},  // MPEG-2.5
+	}
+	mp3BitratesV1 = []int{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320}
+	mp3BitratesV2 = []int{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160}
+)
+
+// DetectFormat inspects the first bytes of a stream and identifies its audio format.
+// Frame-based formats (AAC ADTS, MP3) are only reported once two consecutive frame headers agree.
+func DetectFormat(data []byte, contentType string) AudioFormat {
+	format := AudioFormat{Codec: CodecUnknown, ContentType: contentType}
+
+	trimmed := bytes.ToLower(bytes.TrimSpace(data))
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("<!doctype")), bytes.HasPrefix(trimmed, []byte("<html")), bytes.HasPrefix(trimmed, []byte("<?xml")):
+		format.Codec = CodecHTML
+		return format
+	case bytes.HasPrefix(trimmed, []byte("#extm3u")), bytes.HasPrefix(trimmed, []byte("[playlist]")):
+		format.Codec = CodecPlaylist
+		return format
+	case bytes.HasPrefix(data, []byte("fLaC")):
+		format.Codec = CodecFLAC
+		return format
+	case bytes.HasPrefix(data, []byte("OggS")):
+		format.Codec = CodecOgg
+		if bytes.Contains(data, []byte("OpusHead")) {
+			format.Codec = CodecOpus
+		} else if bytes.Contains(data, []byte("\x01vorbis")) {
+			format.Codec = CodecVorbis
+		}
+		return format
+	}
+
+	data = skipID3(data)
+	for offset := 0; offset+7 <= len(data); offset++ {
+		if data[offset] != 0xFF || data[offset+1]&0xE0 != 0xE0 {
+			continue
+		}
+		if detected, ok := parseADTS(data, offset); ok {
+			detected.ContentType = contentType
+			return detected
+		}
+		if detected, ok := parseMPEGAudio(data, offset); ok {
+			detected.ContentType = contentType
+			return detected
+		}
+	}
+
+	return format
+}
+
+// skipID3 skips a leading ID3v2 tag, which MP3 streams sometimes start with
+func skipID3(data []byte) []byte {
+	if len(data) < 10 || !bytes.HasPrefix(data, []byte("ID3")) {
+		return data
+	}
+	size := int(data[6]&0x7F)<<21 | int(data[7]&0x7F)<<14 | int(data[8]&0x7F)<<7 | int(data[9]&0x7F)
+	if 10+size >= len(data) {
+		return nil
+	}
+	return data[10+size:]
+}
+
+// parseADTS reads an AAC ADTS header at offset and confirms it with the following frame header
+func parseADTS(data []byte, offset int) (AudioFormat, bool) {
+	h := data[offset:]
+	if h[1]&0xF6 != 0xF0 { // 12-bit sync plus layer 00
+		return AudioFormat{}, false
+	}
+
+	rateIndex := int(h[2]>>2) & 0x0F
+	if rateIndex >= len(adtsSampleRates) {
+		return AudioFormat{}, false
+	}
+	frameLength := int(h[3]&0x03)<<11 | int(h[4])<<3 | int(h[5]>>5)
+	if frameLength < 7 || !confirmNextFrame(data, offset, frameLength, func(next []byte) bool {
+		return next[1]&0xF6 == 0xF0 && int(next[2]>>2)&0x0F == rateIndex
+	}) {
+		return AudioFormat{}, false
+	}
+
+	// Each ADTS frame carries 1024 samples, so the frame length gives the bitrate
+	sampleRate := adtsSampleRates[rateIndex]
+	return AudioFormat{
+		Codec:       CodecAAC,
+		Profile:     aacProfiles[int(h[2]>>6)],
+		SampleRate:  sampleRate,
+		Channels:    int(h[2]&0x01)<<2 | int(h[3]>>6),
+		BitrateKbps: frameLength * 8 * sampleRate / 1024 / 1000,
+	}, true
+}
+
+// parseMPEGAudio reads an MPEG audio frame header at offset and confirms it with the following frame header
+func parseMPEGAudio(data []byte, offset int) (AudioFormat, bool) {
+	h := data[offset:]
+	version := int(h[1]>>3) & 0x03
+	layer := int(h[1]>>1) & 0x03
+	bitrateIndex := int(h[2] >> 4)
+	rateIndex := int(h[2]>>2) & 0x03
+	if version == 1 || layer == 0 || bitrateIndex == 0 || bitrateIndex == 15 || rateIndex == 3 {
+		return AudioFormat{}, false
+	}
+
+	sampleRate := mpegSampleRates[version][rateIndex]
+	codec := CodecMPEG
+	bitrate := 0
+	frameLength := 0
+	padding := int(h[2]>>1) & 0x01
+	if layer == 1 { // Layer III
+		codec = CodecMP3
+		if version == 3 {
+			bitrate = mp3BitratesV1[bitrateIndex]
+			frameLength = 144*bitrate*1000/sampleRate + padding
+		} else {
+			bitrate = mp3BitratesV2[bitrateIndex]
+			frameLength = 72*bitrate*1000/sampleRate + padding
+		}
+	}
+
+	if frameLength > 0 && !confirmNextFrame(data, offset, frameLength, func(next []byte) bool {
+		return next[1]&0xFE == h[1]&0xFE && int(next[2]>>2)&0x03 == rateIndex
+	}) {
+		return AudioFormat{}, false
+	}
+	if frameLength == 0 {
+		// Layer I/II frame sizes are not computed, so only a header at the very start is trusted
+		return AudioFormat{Codec: codec, SampleRate: sampleRate}, offset == 0
+	}
+
+	channels := 2
+	if h[3]>>6 == 3 {
+		channels = 1
+	}
+	return AudioFormat{
+		Codec:       codec,
+		SampleRate:  sampleRate,
+		Channels:    channels,
+		BitrateKbps: bitrate,
+	}, true
+}
+
+// confirmNextFrame checks the header of the frame following the one at offset. When the probe
+// data ends before the next frame, a lone header is only trusted at the very start of the data.
+func confirmNextFrame(data []byte, offset, frameLength int, matches func(header []byte) bool) bool {
+	next := offset + frameLength
+	if next+4 > len(data) {
+		return offset == 0
+	}
+	return data[next] == 0xFF && matches(data[next:])
+}
+
+// ValidateFormat checks a detected format against the expected codec and sample rate.
+// An empty or "any" expected codec and a zero expected sample rate skip those checks.
+// Formats that are never audio (HTML pages, playlists) always fail.
+func ValidateFormat(format AudioFormat, expectedCodec string, expectedSampleRate int) error {
+	switch format.Codec {
+	case CodecHTML:
+		return fmt.Errorf("stream returned an HTML page instead of audio (content type %q)", format.ContentType)
+	case CodecPlaylist:
+		return fmt.Errorf("stream URL points to a playlist, not an audio stream; use one of the URLs inside it")
+	case CodecUnknown:
+		return nil
+	}
+
+	expectedCodec = strings.ToLower(strings.TrimSpace(expectedCodec))
+	if expectedCodec != "" && expectedCodec != "any" && format.Codec != expectedCodec {
+		return fmt.Errorf("stream is %s, expected %s", format, strings.ToUpper(expectedCodec))
+	}
+
+	if expectedSampleRate > 0 && format.SampleRate > 0 && format.SampleRate != expectedSampleRate {
+		return fmt.Errorf("stream is %s, expected %dHz sample rate", format, expectedSampleRate)
+	}
+
+	return nil
+}
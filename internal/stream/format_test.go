@@ -0,0 +1,188 @@
+package stream
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// adtsFrames builds count AAC LC ADTS frames of frameLength bytes
+func adtsFrames(count, rateIndex, channels, frameLength int) []byte {
+	var data []byte
+	for i := 0; i < count; i++ {
+		frame := make([]byte, frameLength)
+		frame[0] = 0xFF
+		frame[1] = 0xF1
+		frame[2] = byte(1<<6 | rateIndex<<2 | channels>>2)
+		frame[3] = byte((channels&3)<<6 | frameLength>>11)
+		frame[4] = byte(frameLength >> 3)
+		frame[5] = byte((frameLength&7)<<5 | 0x1F)
+		frame[6] = 0xFC
+		data = append(data, frame...)
+	}
+	return data
+}
+
+// mp3Frames builds count MPEG-1 Layer III frames at 128 kbps, 44.1 kHz, stereo
+func mp3Frames(count int) []byte {
+	var data []byte
+	for i := 0; i < count; i++ {
+		frame := make([]byte, 417)
+		copy(frame, []byte{0xFF, 0xFB, 0x90, 0x00})
+		data = append(data, frame...)
+	}
+	return data
+}
+
+func TestDetectFormat(t *testing.T) {
+	t.Run("should detect AAC ADTS with sample rate, channels and bitrate", func(t *testing.T) {
+		format := DetectFormat(adtsFrames(4, 3, 2, 128), "audio/aac")
+
+		assert.Equal(t, CodecAAC, format.Codec)
+		assert.Equal(t, "LC", format.Profile)
+		assert.Equal(t, 48000, format.SampleRate)
+		assert.Equal(t, 2, format.Channels)
+		assert.Equal(t, 48, format.BitrateKbps)
+		assert.Equal(t, "audio/aac", format.ContentType)
+		assert.Equal(t, "AAC LC 48k 48000Hz stereo", format.String())
+	})
+
+	t.Run("should detect MP3 frames", func(t *testing.T) {
+		format := DetectFormat(mp3Frames(3), "audio/mpeg")
+
+		assert.Equal(t, CodecMP3, format.Codec)
+		assert.Equal(t, 44100, format.SampleRate)
+		assert.Equal(t, 128, format.BitrateKbps)
+		assert.Equal(t, "MP3 128k 44100Hz stereo", format.String())
+	})
+
+	t.Run("should skip a leading ID3 tag", func(t *testing.T) {
+		tag := append([]byte("ID3\x04\x00\x00\x00\x00\x00\x0A"), make([]byte, 10)...)
+
+		format := DetectFormat(append(tag, mp3Frames(2)...), "")
+
+		assert.Equal(t, CodecMP3, format.Codec)
+	})
+
+	t.Run("should find frames after joining mid-stream", func(t *testing.T) {
+		data := append([]byte{0x12, 0x34, 0x56}, adtsFrames(4, 4, 1, 200)...)
+
+		format := DetectFormat(data, "")
+
+		assert.Equal(t, CodecAAC, format.Codec)
+		assert.Equal(t, 44100, format.SampleRate)
+		assert.Equal(t, 1, format.Channels)
+	})
+
+	t.Run("should identify container formats and non-audio responses", func(t *testing.T) {
+		tests := []struct {
+			data     string
+			expected string
+		}{
+			{"OggS\x00\x02....OpusHead", CodecOpus},
+			{"OggS\x00\x02....\x01vorbis", CodecVorbis},
+			{"fLaC\x00\x00\x00\x22", CodecFLAC},
+			{"  <!DOCTYPE html><html><body>Stream offline</body></html>", CodecHTML},
+			{"#EXTM3U\nhttps://example.com/stream.aac\n", CodecPlaylist},
+			{"[playlist]\nFile1=https://example.com/stream.aac\n", CodecPlaylist},
+			{"partial audio data", CodecUnknown},
+		}
+
+		for _, tt := range tests {
+			assert.Equal(t, tt.expected, DetectFormat([]byte(tt.data), "").Codec, tt.data)
+		}
+	})
+}
+
+func TestValidateFormat(t *testing.T) {
+	t.Run("should describe a codec mismatch", func(t *testing.T) {
+		format := DetectFormat(mp3Frames(3), "audio/mpeg")
+
+		err := ValidateFormat(format, "aac", 0)
+
+		require.Error(t, err)
+		assert.Equal(t, "stream is MP3 128k 44100Hz stereo, expected AAC", err.Error())
+	})
+
+	t.Run("should describe a sample rate mismatch", func(t *testing.T) {
+		format := DetectFormat(adtsFrames(4, 4, 2, 200), "")
+
+		err := ValidateFormat(format, "aac", 48000)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expected 48000Hz sample rate")
+	})
+
+	t.Run("should accept matching, unchecked and undetected formats", func(t *testing.T) {
+		aac := DetectFormat(adtsFrames(4, 3, 2, 128), "")
+		mp3 := DetectFormat(mp3Frames(3), "")
+
+		assert.NoError(t, ValidateFormat(aac, "AAC", 48000))
+		assert.NoError(t, ValidateFormat(mp3, "any", 0))
+		assert.NoError(t, ValidateFormat(mp3, "", 0))
+		assert.NoError(t, ValidateFormat(AudioFormat{Codec: CodecUnknown}, "aac", 48000))
+	})
+
+	t.Run("should always reject HTML pages and playlists", func(t *testing.T) {
+		assert.Error(t, ValidateFormat(AudioFormat{Codec: CodecHTML, ContentType: "text/html"}, "any", 0))
+		assert.Error(t, ValidateFormat(AudioFormat{Codec: CodecPlaylist}, "", 0))
+	})
+}
+
+func TestStreamConnector_ProbeFormat(t *testing.T) {
+	t.Run("should detect the format without losing the probed bytes", func(t *testing.T) {
+		// Arrange
+		payload := adtsFrames(10, 3, 2, 128)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "audio/aac")
+			w.Write(payload)
+		}))
+		defer server.Close()
+
+		connector := NewStreamConnector(server.URL)
+		require.NoError(t, connector.Connect(context.Background()))
+		defer connector.Close()
+
+		// Act
+		format, err := connector.ProbeFormat(512)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, CodecAAC, format.Codec)
+		assert.Equal(t, "audio/aac", format.ContentType)
+
+		read, err := io.ReadAll(connector)
+		require.NoError(t, err)
+		assert.Equal(t, payload, read)
+	})
+
+	t.Run("should probe a stream shorter than the probe size", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html>gone</html>"))
+		}))
+		defer server.Close()
+
+		connector := NewStreamConnector(server.URL)
+		require.NoError(t, connector.Connect(context.Background()))
+		defer connector.Close()
+
+		format, err := connector.ProbeFormat(DefaultProbeBytes)
+
+		require.NoError(t, err)
+		assert.Equal(t, CodecHTML, format.Codec)
+	})
+
+	t.Run("should fail when not connected", func(t *testing.T) {
+		connector := NewStreamConnector("http://example.com/stream")
+
+		_, err := connector.ProbeFormat(DefaultProbeBytes)
+
+		assert.Error(t, err)
+	})
+}
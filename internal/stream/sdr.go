@@ -0,0 +1,160 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/exec"
+)
+
+// SDRScheme marks stream URLs that tune an RTL-SDR receiver with rtl_fm, e.g.
+// sdr://101.1M?gain=40&ppm=-2
+const SDRScheme = "sdr"
+
+// Defaults for SDR reception
+const (
+	DefaultSDRModulation = "wbfm" // Broadcast FM
+	DefaultSDRSampleRate = 48000
+)
+
+// sdrFrequencyPattern matches rtl_fm frequencies such as 101100000, 101.1M, or 1.6e6
+var sdrFrequencyPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?([eE][0-9]+)?[kKMG]?$`)
+
+// SDRSource describes an off-air station received with an RTL-SDR dongle. rtl_fm tunes and
+// demodulates the signal; its raw PCM is encoded to ADTS AAC by FFmpeg so it feeds the same
+// decoding and transcription pipeline as an internet stream.
+type SDRSource struct {
+	Frequency   string  // Station frequency in rtl_fm notation, e.g. 101.1M
+	Modulation  string  // rtl_fm demodulation: wbfm, fm, am, usb, or lsb
+	Gain        float64 // Tuner gain in dB; 0 uses automatic gain
+	SampleRate  int     // Demodulated audio sample rate in Hz
+	DeviceIndex int     // Dongle index when several are plugged in
+	PPM         int     // Tuner frequency correction in parts per million
+}
+
+// URL returns the stream URL that receives this station
+func (s SDRSource) URL() string {
+	query := url.Values{}
+	if s.Modulation != "" {
+		query.Set("modulation", s.Modulation)
+	}
+	if s.Gain != 0 {
+		query.Set("gain", strconv.FormatFloat(s.Gain, 'f', -1, 64))
+	}
+	if s.SampleRate > 0 {
+		query.Set("sample_rate", strconv.Itoa(s.SampleRate))
+	}
+	if s.DeviceIndex != 0 {
+		query.Set("device", strconv.Itoa(s.DeviceIndex))
+	}
+	if s.PPM != 0 {
+		query.Set("ppm", strconv.Itoa(s.PPM))
+	}
+	return (&url.URL{Scheme: SDRScheme, Host: s.Frequency, RawQuery: query.Encode()}).String()
+}
+
+// ParseSDRURL parses an sdr:// stream URL. It reports false for any other URL.
+func ParseSDRURL(raw string) (SDRSource, bool, error) {
+	if !strings.HasPrefix(raw, SDRScheme+"://") {
+		return SDRSource{}, false, nil
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return SDRSource{}, true, fmt.Errorf("invalid SDR URL %q: %w", raw, err)
+	}
+
+	query := parsed.Query()
+	sdr := SDRSource{
+		Frequency:  parsed.Host,
+		Modulation: query.Get("modulation"),
+		SampleRate: DefaultSDRSampleRate,
+	}
+	if !sdrFrequencyPattern.MatchString(sdr.Frequency) {
+		return SDRSource{}, true, fmt.Errorf("SDR URL %q has no valid frequency", raw)
+	}
+	if sdr.Modulation == "" {
+		sdr.Modulation = DefaultSDRModulation
+	}
+	if value := query.Get("gain"); value != "" {
+		gain, err := strconv.ParseFloat(value, 64)
+		if err != nil || gain < 0 {
+			return SDRSource{}, true, fmt.Errorf("invalid gain %q in SDR URL", value)
+		}
+		sdr.Gain = gain
+	}
+	for key, target := range map[string]*int{"sample_rate": &sdr.SampleRate, "device": &sdr.DeviceIndex, "ppm": &sdr.PPM} {
+		value := query.Get(key)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || (key == "sample_rate" && n <= 0) || (key == "device" && n < 0) {
+			return SDRSource{}, true, fmt.Errorf("invalid %s %q in SDR URL", key, value)
+		}
+		*target = n
+	}
+	return sdr, true, nil
+}
+
+// rtlFMArgs returns the rtl_fm arguments tuning the station and writing demodulated PCM to stdout
+func (s SDRSource) rtlFMArgs() []string {
+	// -M sets modulation defaults (wbfm implies 32 kHz output), so the output rate follows it
+	args := []string{"-f", s.Frequency, "-M", s.Modulation, "-r", strconv.Itoa(s.SampleRate)}
+	if s.Gain > 0 {
+		args = append(args, "-g", strconv.FormatFloat(s.Gain, 'f', -1, 64))
+	}
+	if s.DeviceIndex != 0 {
+		args = append(args, "-d", strconv.Itoa(s.DeviceIndex))
+	}
+	if s.PPM != 0 {
+		args = append(args, "-p", strconv.Itoa(s.PPM))
+	}
+	return append(args, "-")
+}
+
+// ffmpegArgs returns the FFmpeg arguments encoding rtl_fm's mono 16-bit PCM as ADTS AAC
+func (s SDRSource) ffmpegArgs() []string {
+	return []string{
+		"-hide_banner", "-loglevel", "error",
+		"-f", "s16le", "-ar", strconv.Itoa(s.SampleRate), "-ac", "1",
+		"-i", "pipe:0",
+		"-c:a", "aac",
+		"-b:a", captureBitrate,
+		"-f", "adts",
+		"pipe:1",
+	}
+}
+
+// openSDR starts rtl_fm piped into FFmpeg. A dongle that is unplugged or busy ends the capture
+// with rtl_fm's diagnostics, and the connector's retries respawn it like a dropped stream.
+func (s *StreamConnector) openSDR(ctx context.Context, sdr SDRSource) (*http.Response, error) {
+	s.logger.Info("starting SDR reception",
+		zap.String("frequency", sdr.Frequency),
+		zap.String("modulation", sdr.Modulation),
+		zap.Float64("gain_db", sdr.Gain),
+		zap.Int("sample_rate", sdr.SampleRate),
+		zap.Int("device_index", sdr.DeviceIndex))
+
+	capture, err := startCapture(s.logger, fmt.Sprintf("SDR on %s", sdr.Frequency),
+		exec.Command(ctx, s.rtlFMPath(), sdr.rtlFMArgs()...),
+		exec.Command(ctx, s.ffmpegPath(), sdr.ffmpegArgs()...))
+	if err != nil {
+		return nil, err
+	}
+	return captureResponse(capture), nil
+}
+
+// rtlFMPath returns the rtl_fm binary used for SDR reception
+func (s *StreamConnector) rtlFMPath() string {
+	if s.captureRTLFM != "" {
+		return s.captureRTLFM
+	}
+	return "rtl_fm"
+}
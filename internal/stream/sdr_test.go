@@ -0,0 +1,119 @@
+package stream
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSDRSource_URL(t *testing.T) {
+	t.Run("should round-trip through ParseSDRURL", func(t *testing.T) {
+		// Arrange
+		sdr := SDRSource{Frequency: "101.1M", Modulation: "wbfm", Gain: 42.1, SampleRate: 32000, DeviceIndex: 1, PPM: -2}
+
+		// Act
+		parsed, ok, err := ParseSDRURL(sdr.URL())
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, sdr, parsed)
+	})
+
+	t.Run("should apply defaults for omitted settings", func(t *testing.T) {
+		parsed, ok, err := ParseSDRURL("sdr://96.5M")
+
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, SDRSource{Frequency: "96.5M", Modulation: DefaultSDRModulation, SampleRate: DefaultSDRSampleRate}, parsed)
+	})
+
+	t.Run("should ignore non-SDR URLs", func(t *testing.T) {
+		_, ok, err := ParseSDRURL("device://alsa?name=hw%3A1%2C0")
+
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("should reject invalid SDR URLs", func(t *testing.T) {
+		for _, raw := range []string{
+			"sdr://",
+			"sdr://FM101",
+			"sdr://101.1M?gain=loud",
+			"sdr://101.1M?sample_rate=0",
+			"sdr://101.1M?device=-1",
+		} {
+			_, ok, err := ParseSDRURL(raw)
+
+			assert.True(t, ok, raw)
+			assert.Error(t, err, raw)
+		}
+	})
+}
+
+func TestSDRSource_Args(t *testing.T) {
+	t.Run("should tune rtl_fm with manual gain and correction", func(t *testing.T) {
+		sdr := SDRSource{Frequency: "101.1M", Modulation: "wbfm", Gain: 40, SampleRate: 48000, DeviceIndex: 1, PPM: -2}
+
+		assert.Equal(t, []string{"-f", "101.1M", "-M", "wbfm", "-r", "48000", "-g", "40", "-d", "1", "-p", "-2", "-"}, sdr.rtlFMArgs())
+	})
+
+	t.Run("should leave gain automatic when unset", func(t *testing.T) {
+		sdr := SDRSource{Frequency: "101.1M", Modulation: "wbfm", SampleRate: 48000}
+
+		assert.NotContains(t, sdr.rtlFMArgs(), "-g")
+	})
+
+	t.Run("should encode mono PCM at the demodulated rate", func(t *testing.T) {
+		args := SDRSource{Frequency: "101.1M", Modulation: "wbfm", SampleRate: 32000}.ffmpegArgs()
+
+		assert.Equal(t, []string{
+			"-hide_banner", "-loglevel", "error", "-f", "s16le", "-ar", "32000", "-ac", "1", "-i", "pipe:0",
+			"-c:a", "aac", "-b:a", "128k", "-f", "adts", "pipe:1",
+		}, args)
+	})
+}
+
+func TestStreamConnector_SDRReception(t *testing.T) {
+	t.Run("should pipe rtl_fm through FFmpeg into the stream", func(t *testing.T) {
+		// Arrange
+		payload := adtsFrames(10, 3, 2, 128)
+		payloadPath := filepath.Join(t.TempDir(), "demodulated.aac")
+		require.NoError(t, os.WriteFile(payloadPath, payload, 0644))
+
+		connector := NewStreamConnector(SDRSource{Frequency: "101.1M", Modulation: "wbfm", SampleRate: 48000}.URL())
+		connector.captureRTLFM = fakeCommand(t, "cat "+payloadPath+"\n")
+		connector.captureFFmpeg = fakeCommand(t, "cat\n")
+		require.NoError(t, connector.Connect(context.Background()))
+		defer connector.Close()
+
+		// Act
+		format, err := connector.ProbeFormat(512)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, CodecAAC, format.Codec)
+
+		read, err := io.ReadAll(connector)
+		require.NoError(t, err)
+		assert.Equal(t, payload, read)
+	})
+
+	t.Run("should report rtl_fm diagnostics when the dongle is missing", func(t *testing.T) {
+		connector := NewStreamConnector(SDRSource{Frequency: "101.1M", Modulation: "wbfm", SampleRate: 48000}.URL())
+		connector.captureRTLFM = fakeCommand(t, "echo 'No supported devices found.' >&2\nexit 1\n")
+		connector.captureFFmpeg = fakeCommand(t, "cat\n")
+		require.NoError(t, connector.Connect(context.Background()))
+		defer connector.Close()
+
+		_, err := io.ReadAll(connector)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "No supported devices found.")
+	})
+}
@@ -0,0 +1,94 @@
+package stream
+
+import (
+	"sync"
+	"time"
+)
+
+// ListeningStats summarizes stream reliability since the connector was created
+type ListeningStats struct {
+	Connected     bool          // Whether a connection is currently open
+	ConnectedTime time.Duration // Cumulative time connected, including the current session
+	Disconnects   int           // Times an established connection was lost or closed
+	LongestOutage time.Duration // Longest gap between a disconnect and the next connection, including one in progress
+	BytesReceived int64         // Stream bytes delivered to readers
+}
+
+// listeningTracker accumulates ListeningStats from connection events
+type listeningTracker struct {
+	mu                sync.Mutex
+	connected         bool
+	connectedSince    time.Time
+	disconnectedSince time.Time // Zero until the first disconnect
+	stats             ListeningStats
+	now               func() time.Time
+}
+
+// markConnected records an established connection, closing any outage in progress
+func (t *listeningTracker) markConnected() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.connected {
+		return
+	}
+	now := t.clock()
+	t.connected = true
+	t.connectedSince = now
+	if !t.disconnectedSince.IsZero() {
+		if outage := now.Sub(t.disconnectedSince); outage > t.stats.LongestOutage {
+			t.stats.LongestOutage = outage
+		}
+		t.disconnectedSince = time.Time{}
+	}
+}
+
+// markDisconnected records the loss of the current connection, if any
+func (t *listeningTracker) markDisconnected() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.connected {
+		return
+	}
+	now := t.clock()
+	t.connected = false
+	t.stats.ConnectedTime += now.Sub(t.connectedSince)
+	t.stats.Disconnects++
+	t.disconnectedSince = now
+}
+
+// addBytes records stream bytes delivered to a reader
+func (t *listeningTracker) addBytes(n int) {
+	if n <= 0 {
+		return
+	}
+	t.mu.Lock()
+	t.stats.BytesReceived += int64(n)
+	t.mu.Unlock()
+}
+
+// snapshot returns the current stats, counting the session or outage in progress
+func (t *listeningTracker) snapshot() ListeningStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := t.stats
+	stats.Connected = t.connected
+	now := t.clock()
+	if t.connected {
+		stats.ConnectedTime += now.Sub(t.connectedSince)
+	} else if !t.disconnectedSince.IsZero() {
+		if outage := now.Sub(t.disconnectedSince); outage > stats.LongestOutage {
+			stats.LongestOutage = outage
+		}
+	}
+	return stats
+}
+
+func (t *listeningTracker) clock() time.Time {
+	if t.now != nil {
+		return t.now()
+	}
+	return time.Now()
+}
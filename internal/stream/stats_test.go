@@ -0,0 +1,82 @@
+package stream
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListeningTracker(t *testing.T) {
+	t.Run("should accumulate connected time, disconnects, and the longest outage", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		tracker := &listeningTracker{now: func() time.Time { return now }}
+		advance := func(d time.Duration) { now = now.Add(d) }
+
+		// Act
+		tracker.markConnected()
+		advance(10 * time.Minute)
+		tracker.markDisconnected()
+		advance(30 * time.Second)
+		tracker.markConnected()
+		advance(5 * time.Minute)
+		tracker.markDisconnected()
+		advance(10 * time.Second)
+		tracker.markConnected()
+		advance(time.Minute)
+		tracker.addBytes(4096)
+
+		// Assert
+		stats := tracker.snapshot()
+		assert.True(t, stats.Connected)
+		assert.Equal(t, 16*time.Minute, stats.ConnectedTime, "should include the current session")
+		assert.Equal(t, 2, stats.Disconnects)
+		assert.Equal(t, 30*time.Second, stats.LongestOutage)
+		assert.Equal(t, int64(4096), stats.BytesReceived)
+	})
+
+	t.Run("should count an outage in progress", func(t *testing.T) {
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		tracker := &listeningTracker{now: func() time.Time { return now }}
+
+		tracker.markConnected()
+		tracker.markDisconnected()
+		tracker.markDisconnected()
+		now = now.Add(2 * time.Minute)
+
+		stats := tracker.snapshot()
+		assert.False(t, stats.Connected)
+		assert.Equal(t, 1, stats.Disconnects, "repeated disconnect events should count once")
+		assert.Equal(t, 2*time.Minute, stats.LongestOutage)
+	})
+}
+
+func TestStreamConnector_ListeningStats(t *testing.T) {
+	t.Run("should track bytes received and the disconnect when the stream ends", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write(make([]byte, 1000))
+		}))
+		defer server.Close()
+		connector := NewStreamConnector(server.URL)
+		require.NoError(t, connector.Connect(context.Background()))
+
+		// Act
+		_, err := io.ReadAll(connector)
+
+		// Assert
+		require.NoError(t, err)
+		stats := connector.ListeningStats()
+		assert.False(t, stats.Connected)
+		assert.Equal(t, int64(1000), stats.BytesReceived)
+		assert.Equal(t, 1, stats.Disconnects)
+		assert.NoError(t, connector.Close())
+		assert.Equal(t, 1, connector.ListeningStats().Disconnects, "closing a lost connection should not count again")
+	})
+}
@@ -0,0 +1,231 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/retry"
+)
+
+// ThrottleOptions configures a ReconnectThrottle
+type ThrottleOptions struct {
+	MaxPerMinute          int           // Connection attempts allowed in any minute; 0 means no limit
+	CooldownAfterFailures int           // Consecutive failed attempts before reconnects pause; 0 disables the cooldown
+	Cooldown              time.Duration // How long reconnects pause after CooldownAfterFailures failures
+	Jitter                float64       // Fraction of each wait randomized either way, 0 to 1
+	StatePath             string        // File keeping attempts and the cooldown across application restarts; empty keeps them in memory
+}
+
+// ReconnectThrottle protects a stream host from reconnect storms during a long outage. It caps
+// how many connection attempts are made per minute, across the connector's own retries,
+// supervisor restarts, and (with a state file) application restarts, and optionally stops
+// reconnecting for a cooldown period after repeated failures.
+type ReconnectThrottle struct {
+	options ThrottleOptions
+	logger  *zap.Logger
+	now     func() time.Time
+	sleep   func(ctx context.Context, d time.Duration) error
+
+	mu            sync.Mutex
+	attempts      []time.Time // Connection attempts within the last minute, oldest first
+	failures      int         // Consecutive failed attempts
+	cooldownUntil time.Time   // Zero unless reconnects are cooling down
+}
+
+// throttleState is the ReconnectThrottle state kept in its state file
+type throttleState struct {
+	Attempts      []time.Time `json:"attempts"`
+	Failures      int         `json:"consecutive_failures"`
+	CooldownUntil time.Time   `json:"cooldown_until"`
+}
+
+// NewReconnectThrottle creates a ReconnectThrottle, restoring the attempts and cooldown of a
+// previous run from options.StatePath. An unreadable state file is logged and ignored.
+func NewReconnectThrottle(options ThrottleOptions, logger *zap.Logger) *ReconnectThrottle {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	t := &ReconnectThrottle{
+		options: options,
+		logger:  logger,
+		now:     time.Now,
+		sleep:   sleepContext,
+	}
+	if err := t.load(); err != nil {
+		logger.Warn("ignoring stream reconnect state", zap.String("path", options.StatePath), zap.Error(err))
+	}
+	return t
+}
+
+// Wait blocks until another connection attempt is allowed, then counts it. It returns the
+// context's error if ctx ends first. A nil throttle never waits.
+func (t *ReconnectThrottle) Wait(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	logged := false
+	for {
+		delay, reason := t.reserve()
+		if delay <= 0 {
+			return nil
+		}
+		delay = retry.Jittered(delay, t.options.Jitter)
+		if !logged {
+			t.logger.Info("throttling stream reconnect",
+				zap.String("reason", reason),
+				zap.Duration("wait", delay))
+			logged = true
+		}
+		if err := t.sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve counts a connection attempt and returns zero when one is allowed now, or otherwise
+// how long to wait and why
+func (t *ReconnectThrottle) reserve() (time.Duration, string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	if now.Before(t.cooldownUntil) {
+		return t.cooldownUntil.Sub(now), "cooldown"
+	}
+	t.pruneLocked(now)
+	if limit := t.options.MaxPerMinute; limit > 0 && len(t.attempts) >= limit {
+		return t.attempts[0].Add(time.Minute).Sub(now), "rate limit"
+	}
+	t.attempts = append(t.attempts, now)
+	t.saveLocked()
+	return 0, ""
+}
+
+// Record counts the outcome of a connection attempt. Enough consecutive failures start the
+// cooldown; a success ends it.
+func (t *ReconnectThrottle) Record(err error) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err == nil {
+		if t.failures > 0 || !t.cooldownUntil.IsZero() {
+			t.failures = 0
+			t.cooldownUntil = time.Time{}
+			t.saveLocked()
+		}
+		return
+	}
+
+	t.failures++
+	if after := t.options.CooldownAfterFailures; after > 0 && t.failures >= after && t.options.Cooldown > 0 {
+		t.cooldownUntil = t.now().Add(t.options.Cooldown)
+		t.logger.Warn("stream host keeps failing; pausing reconnects",
+			zap.Int("consecutive_failures", t.failures),
+			zap.Duration("cooldown", t.options.Cooldown),
+			zap.Time("until", t.cooldownUntil))
+		t.failures = 0
+	}
+	t.saveLocked()
+}
+
+// CooldownUntil returns when the current reconnect cooldown ends, or the zero time when
+// reconnects are not cooling down
+func (t *ReconnectThrottle) CooldownUntil() time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.now().Before(t.cooldownUntil) {
+		return time.Time{}
+	}
+	return t.cooldownUntil
+}
+
+// ConsecutiveFailures returns the failed connection attempts since the last success or cooldown
+func (t *ReconnectThrottle) ConsecutiveFailures() int {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.failures
+}
+
+// pruneLocked drops attempts older than a minute
+func (t *ReconnectThrottle) pruneLocked(now time.Time) {
+	cutoff := now.Add(-time.Minute)
+	kept := t.attempts[:0]
+	for _, attempt := range t.attempts {
+		if attempt.After(cutoff) {
+			kept = append(kept, attempt)
+		}
+	}
+	t.attempts = kept
+}
+
+// load restores the state of a previous run
+func (t *ReconnectThrottle) load() error {
+	if t.options.StatePath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(t.options.StatePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read stream reconnect state: %w", err)
+	}
+	var state throttleState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("failed to parse stream reconnect state: %w", err)
+	}
+	t.attempts = state.Attempts
+	t.failures = state.Failures
+	t.cooldownUntil = state.CooldownUntil
+	t.pruneLocked(t.now())
+	return nil
+}
+
+// saveLocked atomically writes the state so the limits hold across application restarts
+func (t *ReconnectThrottle) saveLocked() {
+	if t.options.StatePath == "" {
+		return
+	}
+	data, err := json.Marshal(throttleState{Attempts: t.attempts, Failures: t.failures, CooldownUntil: t.cooldownUntil})
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(t.options.StatePath), 0755)
+	}
+	if err == nil {
+		tmp := t.options.StatePath + ".tmp"
+		if err = os.WriteFile(tmp, data, 0644); err == nil {
+			err = os.Rename(tmp, t.options.StatePath)
+		}
+	}
+	if err != nil {
+		t.logger.Debug("failed to save stream reconnect state", zap.String("path", t.options.StatePath), zap.Error(err))
+	}
+}
+
+// sleepContext waits for d or until ctx ends
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,110 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestThrottle creates a ReconnectThrottle on a fake clock that records instead of sleeping
+func newTestThrottle(options ThrottleOptions) (*ReconnectThrottle, *time.Time, *[]time.Duration) {
+	now := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	var waits []time.Duration
+	throttle := NewReconnectThrottle(options, nil)
+	throttle.now = func() time.Time { return now }
+	throttle.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		now = now.Add(d)
+		return nil
+	}
+	return throttle, &now, &waits
+}
+
+func TestReconnectThrottle_Wait(t *testing.T) {
+	t.Run("should never wait for a nil throttle", func(t *testing.T) {
+		var throttle *ReconnectThrottle
+
+		assert.NoError(t, throttle.Wait(context.Background()))
+		throttle.Record(errors.New("refused"))
+		assert.True(t, throttle.CooldownUntil().IsZero())
+	})
+
+	t.Run("should cap connection attempts per minute", func(t *testing.T) {
+		// Arrange
+		throttle, _, waits := newTestThrottle(ThrottleOptions{MaxPerMinute: 3})
+
+		// Act
+		for i := 0; i < 4; i++ {
+			require.NoError(t, throttle.Wait(context.Background()))
+		}
+
+		// Assert
+		assert.Equal(t, []time.Duration{time.Minute}, *waits, "the fourth attempt should wait for the first to age out")
+	})
+
+	t.Run("should cool down after repeated failures until a connection succeeds", func(t *testing.T) {
+		// Arrange
+		throttle, now, waits := newTestThrottle(ThrottleOptions{CooldownAfterFailures: 2, Cooldown: 5 * time.Minute})
+
+		// Act
+		for i := 0; i < 2; i++ {
+			require.NoError(t, throttle.Wait(context.Background()))
+			throttle.Record(errors.New("connection refused"))
+		}
+
+		// Assert
+		assert.Equal(t, now.Add(5*time.Minute), throttle.CooldownUntil())
+		require.NoError(t, throttle.Wait(context.Background()))
+		assert.Equal(t, []time.Duration{5 * time.Minute}, *waits)
+		assert.True(t, throttle.CooldownUntil().IsZero())
+
+		throttle.Record(errors.New("connection refused"))
+		throttle.Record(nil)
+		assert.Equal(t, 0, throttle.ConsecutiveFailures())
+	})
+
+	t.Run("should stop waiting when the context ends", func(t *testing.T) {
+		throttle := NewReconnectThrottle(ThrottleOptions{CooldownAfterFailures: 1, Cooldown: time.Hour}, nil)
+		throttle.Record(errors.New("connection refused"))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := throttle.Wait(ctx)
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestReconnectThrottle_State(t *testing.T) {
+	t.Run("should keep the cooldown and attempts across restarts", func(t *testing.T) {
+		// Arrange
+		statePath := filepath.Join(t.TempDir(), "reconnect.json")
+		options := ThrottleOptions{MaxPerMinute: 2, CooldownAfterFailures: 1, Cooldown: time.Minute, StatePath: statePath}
+		first := NewReconnectThrottle(options, nil)
+		require.NoError(t, first.Wait(context.Background()))
+
+		// Act
+		first.Record(errors.New("connection refused"))
+		restarted := NewReconnectThrottle(options, nil)
+
+		// Assert
+		assert.False(t, restarted.CooldownUntil().IsZero())
+		assert.Len(t, restarted.attempts, 1)
+	})
+
+	t.Run("should ignore an unreadable state file", func(t *testing.T) {
+		statePath := filepath.Join(t.TempDir(), "reconnect.json")
+		require.NoError(t, os.WriteFile(statePath, []byte("not json"), 0644))
+
+		throttle := NewReconnectThrottle(ThrottleOptions{StatePath: statePath}, nil)
+
+		assert.True(t, throttle.CooldownUntil().IsZero())
+		assert.NoError(t, throttle.Wait(context.Background()))
+	})
+}
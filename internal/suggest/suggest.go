@@ -0,0 +1,109 @@
+// Package suggest finds contest numbers heard in stored transcriptions that the allowlist does
+// not accept, so operators can spot new contests to add. A number announced again and again
+// with the same keyword ("text CASH to 7788") is most likely a real contest.
+package suggest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"radiocontestwinner/internal/parser"
+	"radiocontestwinner/internal/search"
+)
+
+// Options filters what is suggested
+type Options struct {
+	Since    time.Time // Transcriptions before Since are skipped; zero means no lower bound
+	Until    time.Time // Transcriptions at or after Until are skipped; zero means no upper bound
+	MinCount int       // Numbers heard fewer times are not suggested
+}
+
+// KeywordCount is how often a keyword was heard with a number
+type KeywordCount struct {
+	Keyword string
+	Count   int
+}
+
+// Suggestion is a number heard in contest patterns that is not allowlisted
+type Suggestion struct {
+	Number    string
+	Count     int            // Contest patterns heard with the number
+	Keywords  []KeywordCount // Most heard first
+	FirstSeen time.Time      // Zero when no matching transcription had a timestamp
+	LastSeen  time.Time
+}
+
+// String describes the suggestion, e.g. "number 7788 seen 14 times with keyword CASH"
+func (s Suggestion) String() string {
+	times := "times"
+	if s.Count == 1 {
+		times = "time"
+	}
+	if len(s.Keywords) == 1 {
+		return fmt.Sprintf("number %s seen %d %s with keyword %s", s.Number, s.Count, times, s.Keywords[0].Keyword)
+	}
+	keywords := make([]string, len(s.Keywords))
+	for i, keyword := range s.Keywords {
+		keywords[i] = fmt.Sprintf("%s (%d)", keyword.Keyword, keyword.Count)
+	}
+	return fmt.Sprintf("number %s seen %d %s with keywords %s", s.Number, s.Count, times, strings.Join(keywords, ", "))
+}
+
+// Suggest scans the transcriptions stored in files for contest patterns whose number cp does
+// not allowlist, and returns the numbers heard at least opts.MinCount times, most heard first.
+// Missing files are skipped; cue logs hold only allowlisted numbers, so their records are ignored.
+func Suggest(files []string, cp *parser.ContestParser, opts Options) ([]Suggestion, error) {
+	records, err := search.Search(files, search.Options{Since: opts.Since, Until: opts.Until})
+	if err != nil {
+		return nil, err
+	}
+
+	byNumber := map[string]*Suggestion{}
+	keywordCounts := map[string]map[string]int{} // Number -> keyword -> count
+	for _, record := range records {
+		if record.Kind != search.KindTranscript {
+			continue
+		}
+		for _, match := range cp.MatchUnlistedContestPatterns(record.Text) {
+			suggestion := byNumber[match.Number]
+			if suggestion == nil {
+				suggestion = &Suggestion{Number: match.Number}
+				byNumber[match.Number] = suggestion
+				keywordCounts[match.Number] = map[string]int{}
+			}
+			suggestion.Count++
+			// Transcriptions capitalize keywords inconsistently
+			keywordCounts[match.Number][strings.ToUpper(match.Keyword)]++
+			if !record.Time.IsZero() {
+				if suggestion.FirstSeen.IsZero() || record.Time.Before(suggestion.FirstSeen) {
+					suggestion.FirstSeen = record.Time
+				}
+				if record.Time.After(suggestion.LastSeen) {
+					suggestion.LastSeen = record.Time
+				}
+			}
+		}
+	}
+
+	var suggestions []Suggestion
+	for number, suggestion := range byNumber {
+		if suggestion.Count < opts.MinCount {
+			continue
+		}
+		for keyword, count := range keywordCounts[number] {
+			suggestion.Keywords = append(suggestion.Keywords, KeywordCount{Keyword: keyword, Count: count})
+		}
+		sort.Slice(suggestion.Keywords, func(i, j int) bool {
+			a, b := suggestion.Keywords[i], suggestion.Keywords[j]
+			return a.Count > b.Count || (a.Count == b.Count && a.Keyword < b.Keyword)
+		})
+		suggestions = append(suggestions, *suggestion)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		return a.Count > b.Count || (a.Count == b.Count && a.Number < b.Number)
+	})
+	return suggestions, nil
+}
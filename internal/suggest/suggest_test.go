@@ -0,0 +1,94 @@
+package suggest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/parser"
+)
+
+// writeLines writes lines to a file in a temporary directory and returns its path
+func writeLines(t *testing.T, name string, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644))
+	return path
+}
+
+func TestSuggest(t *testing.T) {
+	transcripts := []string{
+		`{"timestamp":"2026-10-16T07:00:00Z","text":"text CASH to 7788 right now"}`,
+		`{"timestamp":"2026-10-16T08:00:00Z","text":"good morning"}`,
+		`{"timestamp":"2026-10-16T09:00:00Z","text":"again text cash to 7788"}`,
+		`{"timestamp":"2026-10-16T10:00:00Z","text":"text KASH to 7788 or text WIN to 55555"}`,
+		`{"timestamp":"2026-10-16T11:00:00Z","text":"text PIZZA to 9999"}`,
+		`{"timestamp":"2026-10-17T07:00:00Z","text":"text CASH to 7788"}`,
+	}
+
+	t.Run("should count unlisted numbers with their keywords, most heard first", func(t *testing.T) {
+		// Arrange
+		path := writeLines(t, "transcripts.jsonl", transcripts...)
+		cp := parser.NewContestParser([]string{"55555"})
+
+		// Act
+		suggestions, err := Suggest([]string{path}, cp, Options{})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, suggestions, 2)
+		assert.Equal(t, Suggestion{
+			Number:    "7788",
+			Count:     4,
+			Keywords:  []KeywordCount{{Keyword: "CASH", Count: 3}, {Keyword: "KASH", Count: 1}},
+			FirstSeen: time.Date(2026, 10, 16, 7, 0, 0, 0, time.UTC),
+			LastSeen:  time.Date(2026, 10, 17, 7, 0, 0, 0, time.UTC),
+		}, suggestions[0])
+		assert.Equal(t, "9999", suggestions[1].Number)
+	})
+
+	t.Run("should leave out numbers heard fewer than the minimum times", func(t *testing.T) {
+		path := writeLines(t, "transcripts.jsonl", transcripts...)
+
+		suggestions, err := Suggest([]string{path}, parser.NewContestParser([]string{"55555"}), Options{MinCount: 2})
+
+		require.NoError(t, err)
+		require.Len(t, suggestions, 1)
+		assert.Equal(t, "7788", suggestions[0].Number)
+	})
+
+	t.Run("should only scan transcriptions within the time range", func(t *testing.T) {
+		path := writeLines(t, "transcripts.jsonl", transcripts...)
+
+		suggestions, err := Suggest([]string{path}, parser.NewContestParser(nil), Options{
+			Since: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, suggestions, 1)
+		assert.Equal(t, 1, suggestions[0].Count)
+	})
+
+	t.Run("should ignore cue logs and missing files", func(t *testing.T) {
+		cues := writeLines(t, "cues.jsonl", `{"timestamp":"2026-10-16T07:00:00Z","contest_type":"CASH","keyword":"CASH","shortcode":"7788"}`)
+
+		suggestions, err := Suggest([]string{cues, filepath.Join(t.TempDir(), "missing.jsonl")}, parser.NewContestParser(nil), Options{})
+
+		require.NoError(t, err)
+		assert.Empty(t, suggestions)
+	})
+}
+
+func TestSuggestion_String(t *testing.T) {
+	assert.Equal(t, "number 7788 seen 14 times with keyword CASH",
+		Suggestion{Number: "7788", Count: 14, Keywords: []KeywordCount{{"CASH", 14}}}.String())
+	assert.Equal(t, "number 7788 seen 1 time with keyword CASH",
+		Suggestion{Number: "7788", Count: 1, Keywords: []KeywordCount{{"CASH", 1}}}.String())
+	assert.Equal(t, "number 7788 seen 5 times with keywords CASH (4), KASH (1)",
+		Suggestion{Number: "7788", Count: 5, Keywords: []KeywordCount{{"CASH", 4}, {"KASH", 1}}}.String())
+}
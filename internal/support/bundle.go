@@ -0,0 +1,232 @@
+package support
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/gpu"
+	"radiocontestwinner/internal/redis"
+	"radiocontestwinner/internal/version"
+)
+
+// bundleDir is the directory every file in a bundle is placed under
+const bundleDir = "radiocontestwinner-support"
+
+// Options configures a support bundle
+type Options struct {
+	Config      *config.Configuration
+	Versions    version.Info // Build metadata and tool versions, as shown by -version
+	HealthFile  string       // Health status file written by the running instance
+	LogFiles    []string     // Log files to include the end of; missing files are noted and skipped
+	LogLines    int          // Lines kept from the end of each log file
+	Transcripts int          // Recent transcriptions to include from the shared history
+	Now         time.Time
+}
+
+// bundle accumulates the files of a support bundle and the problems collecting them
+type bundle struct {
+	tw     *tar.Writer
+	now    time.Time
+	files  []string
+	errors []string
+}
+
+// Write collects the state needed to diagnose a bug report into a gzip-compressed tar archive
+// written to w: the effective config with secrets redacted, version and build info, the health
+// status, GPU detection output, the end of the log files, and recent transcriptions. What cannot
+// be collected is listed in errors.txt rather than failing the bundle. It returns the files written.
+func Write(w io.Writer, opts Options) ([]string, error) {
+	if opts.Config == nil {
+		return nil, fmt.Errorf("configuration cannot be nil")
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	gz := gzip.NewWriter(w)
+	b := &bundle{tw: tar.NewWriter(gz), now: opts.Now}
+
+	b.addText("version.txt", fmt.Sprintf("%sCollected: %s\n", opts.Versions, opts.Now.UTC().Format(time.RFC3339)))
+	b.addJSON("config.json", opts.Config.RedactedSettings())
+	b.addHealth(opts.HealthFile)
+	b.addGPU()
+	for _, path := range opts.LogFiles {
+		b.addLog(path, opts.LogLines)
+	}
+	b.addTranscripts(opts.Config, opts.Transcripts)
+	if len(b.errors) > 0 {
+		b.addText("errors.txt", joinLines(b.errors))
+	}
+
+	if err := b.tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write support bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write support bundle: %w", err)
+	}
+	return b.files, nil
+}
+
+// add writes one file into the archive
+func (b *bundle) add(name string, data []byte) {
+	header := &tar.Header{
+		Name:    bundleDir + "/" + name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: b.now,
+	}
+	if err := b.tw.WriteHeader(header); err != nil {
+		b.noteError(name, err)
+		return
+	}
+	if _, err := b.tw.Write(data); err != nil {
+		b.noteError(name, err)
+		return
+	}
+	b.files = append(b.files, name)
+}
+
+func (b *bundle) addText(name, text string) {
+	b.add(name, []byte(text))
+}
+
+func (b *bundle) addJSON(name string, value interface{}) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		b.noteError(name, err)
+		return
+	}
+	b.add(name, append(data, '\n'))
+}
+
+// noteError records a problem collecting name
+func (b *bundle) noteError(name string, err error) {
+	b.errors = append(b.errors, fmt.Sprintf("%s: %v", name, err))
+}
+
+// addHealth includes the health status last written by the running instance
+func (b *bundle) addHealth(path string) {
+	if path == "" {
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		b.noteError("health.json", err)
+		return
+	}
+	b.add("health.json", data)
+}
+
+// addGPU includes the GPU detection result and the raw nvidia-smi output when available
+func (b *bundle) addGPU() {
+	info, err := gpu.NewGPUDetector(zap.NewNop()).DetectGPU()
+	if err != nil {
+		b.noteError("gpu.json", err)
+	} else {
+		b.addJSON("gpu.json", info)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, "nvidia-smi").CombinedOutput()
+	if err != nil && len(output) == 0 {
+		b.noteError("nvidia-smi.txt", err)
+		return
+	}
+	b.add("nvidia-smi.txt", output)
+}
+
+// addLog includes the last lines of a log file
+func (b *bundle) addLog(path string, lines int) {
+	name := "logs/" + filepath.Base(path)
+	data, err := tailFile(path, lines)
+	if err != nil {
+		b.noteError(name, err)
+		return
+	}
+	b.add(name, data)
+}
+
+// addTranscripts includes the most recent transcriptions from the shared Redis history
+func (b *bundle) addTranscripts(cfg *config.Configuration, n int) {
+	const name = "transcripts.jsonl"
+	if n <= 0 {
+		return
+	}
+	if !cfg.GetRedisEnabled() || cfg.GetRedisTranscriptHistory() <= 0 {
+		b.noteError(name, fmt.Errorf("transcript history is disabled (set redis.enabled and redis.transcript_history)"))
+		return
+	}
+	client, err := redis.NewClient(redis.Options{
+		Address:  cfg.GetRedisAddress(),
+		Password: cfg.GetRedisPassword(),
+		DB:       cfg.GetRedisDB(),
+		Timeout:  5 * time.Second,
+	})
+	if err != nil {
+		b.noteError(name, err)
+		return
+	}
+	defer client.Close()
+
+	transcripts, err := redis.NewCappedList(client, cfg.GetRedisKeyPrefix()+":transcripts", cfg.GetRedisTranscriptHistory()).Recent(n)
+	if err != nil {
+		b.noteError(name, err)
+		return
+	}
+	// Oldest first, like a log
+	for i, j := 0, len(transcripts)-1; i < j; i, j = i+1, j-1 {
+		transcripts[i], transcripts[j] = transcripts[j], transcripts[i]
+	}
+	b.addText(name, joinLines(transcripts))
+}
+
+// tailFile returns the last n lines of the file at path, or all of it when n is not positive
+func tailFile(path string, n int) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var lines [][]byte
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, append([]byte(nil), scanner.Bytes()...))
+		if n > 0 && len(lines) > n {
+			lines = lines[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	for _, line := range lines {
+		out.Write(line)
+		out.WriteByte('\n')
+	}
+	return out.Bytes(), nil
+}
+
+func joinLines(lines []string) string {
+	var text bytes.Buffer
+	for _, line := range lines {
+		text.WriteString(line)
+		text.WriteByte('\n')
+	}
+	return text.String()
+}
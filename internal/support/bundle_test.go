@@ -0,0 +1,79 @@
+package support
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/version"
+)
+
+// readBundle returns the files of a support bundle by name
+func readBundle(t *testing.T, data []byte) map[string]string {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	files := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[strings.TrimPrefix(header.Name, bundleDir+"/")] = string(content)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("should collect config, health, version, and the end of the logs", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		healthFile := filepath.Join(dir, "health.json")
+		require.NoError(t, os.WriteFile(healthFile, []byte(`{"healthy":true}`), 0644))
+		logFile := filepath.Join(dir, "app.log")
+		require.NoError(t, os.WriteFile(logFile, []byte("one\ntwo\nthree\nfour\n"), 0644))
+		cfg := config.NewConfiguration()
+		cfg.SetRedisAddress("redis://:hunter2@cache:6379")
+		var out bytes.Buffer
+
+		// Act
+		names, err := Write(&out, Options{
+			Config:      cfg,
+			Versions:    version.Get(),
+			HealthFile:  healthFile,
+			LogFiles:    []string{logFile, filepath.Join(dir, "missing.log")},
+			LogLines:    2,
+			Transcripts: 10,
+		})
+
+		// Assert
+		require.NoError(t, err)
+		files := readBundle(t, out.Bytes())
+		assert.Len(t, files, len(names))
+		assert.Contains(t, files["version.txt"], "Version: "+version.Version)
+		assert.Contains(t, files["version.txt"], "Collected: ")
+		assert.Contains(t, files["config.json"], `"file_path"`)
+		assert.NotContains(t, files["config.json"], "hunter2")
+		assert.Equal(t, `{"healthy":true}`, files["health.json"])
+		assert.Equal(t, "three\nfour\n", files["logs/app.log"])
+		assert.Contains(t, files["errors.txt"], "logs/missing.log")
+		assert.Contains(t, files["errors.txt"], "transcript history is disabled")
+	})
+
+	t.Run("should require a configuration", func(t *testing.T) {
+		_, err := Write(io.Discard, Options{})
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,98 @@
+package transcriber
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// Benchmarks for the WAV write path run before every whisper.cpp invocation
+
+// benchmarkChunk is a 30 second chunk of 16kHz 16-bit mono audio, the default chunk size
+var benchmarkChunk = make([]byte, 30*16000*2)
+
+// saveAudioToWAVTwoWrites reproduces the original write path, allocating the header and writing
+// it and the audio separately, for comparison
+func saveAudioToWAVTwoWrites(w *WhisperCppModel, audioData []byte, filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err := file.Write(w.createWAVHeader(len(audioData))); err != nil {
+		return err
+	}
+	_, err = file.Write(audioData)
+	return err
+}
+
+func BenchmarkSaveAudioToWAV(b *testing.B) {
+	model := NewWhisperCppModel(zap.NewNop())
+	filename := filepath.Join(b.TempDir(), "chunk.wav")
+	b.SetBytes(int64(len(benchmarkChunk)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := model.saveAudioToWAV(benchmarkChunk, filename); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSaveAudioToWAV_TwoWrites(b *testing.B) {
+	model := NewWhisperCppModel(zap.NewNop())
+	filename := filepath.Join(b.TempDir(), "chunk.wav")
+	b.SetBytes(int64(len(benchmarkChunk)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := saveAudioToWAVTwoWrites(model, benchmarkChunk, filename); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkWriteTempWAV(b *testing.B) {
+	model := NewWhisperCppModel(zap.NewNop())
+	model.tempDir = b.TempDir()
+	b.SetBytes(int64(len(benchmarkChunk)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		path, err := model.writeTempWAV(benchmarkChunk)
+		if err != nil {
+			b.Fatal(err)
+		}
+		os.Remove(path)
+	}
+}
+
+func TestWriteTempWAV(t *testing.T) {
+	t.Run("should write the same file as the original write path", func(t *testing.T) {
+		// Arrange
+		model := NewWhisperCppModel(zap.NewNop())
+		model.tempDir = t.TempDir()
+		audio := []byte{1, 2, 3, 4, 5, 6}
+		original := filepath.Join(t.TempDir(), "original.wav")
+		require.NoError(t, saveAudioToWAVTwoWrites(model, audio, original))
+
+		// Act
+		first, err := model.writeTempWAV(audio)
+		require.NoError(t, err)
+		second, err := model.writeTempWAV(audio[:2])
+		require.NoError(t, err)
+
+		// Assert
+		assert.NotEqual(t, first, second)
+		assert.Equal(t, model.tempDir, filepath.Dir(first))
+		want, err := os.ReadFile(original)
+		require.NoError(t, err)
+		got, err := os.ReadFile(first)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+		got, err = os.ReadFile(second)
+		require.NoError(t, err)
+		assert.Len(t, got, wavHeaderSize+2)
+	})
+}
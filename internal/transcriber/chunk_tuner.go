@@ -0,0 +1,115 @@
+package transcriber
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Load thresholds used by ChunkTuner. Load is processing time per second of audio, so a
+// load of 1.0 means transcription only just keeps up with real time.
+const (
+	// ChunkTunerHighLoad is the average load above which chunks grow to amortize per-chunk overhead
+	ChunkTunerHighLoad = 0.8
+	// ChunkTunerLowLoad is the average load below which chunks shrink to reduce latency
+	ChunkTunerLowLoad = 0.3
+)
+
+// ChunkTuner adjusts the transcription chunk duration within bounds based on observed per-chunk
+// latency. When transcription is close to falling behind (GPU/CPU-bound) chunks get bigger, since
+// each Whisper invocation has a fixed overhead; with plenty of headroom chunks get smaller so
+// cues are detected sooner.
+type ChunkTuner struct {
+	mu               sync.Mutex
+	minSec           int
+	maxSec           int
+	current          int
+	evaluationChunks int
+	loads            []float64
+	logger           *zap.Logger
+}
+
+// NewChunkTuner creates a tuner starting at initialSec, clamped to [minSec, maxSec], that decides
+// after every evaluationChunks chunks whether to adjust the duration
+func NewChunkTuner(initialSec, minSec, maxSec, evaluationChunks int, logger *zap.Logger) *ChunkTuner {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	if minSec < 1 {
+		minSec = 1
+	}
+	if maxSec < minSec {
+		maxSec = minSec
+	}
+	if evaluationChunks < 1 {
+		evaluationChunks = 1
+	}
+
+	current := initialSec
+	if current < minSec {
+		current = minSec
+	} else if current > maxSec {
+		current = maxSec
+	}
+
+	return &ChunkTuner{
+		minSec:           minSec,
+		maxSec:           maxSec,
+		current:          current,
+		evaluationChunks: evaluationChunks,
+		logger:           logger,
+	}
+}
+
+// ChunkDurationSec returns the current chunk duration in seconds
+func (t *ChunkTuner) ChunkDurationSec() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.current
+}
+
+// Observe records how long a chunk of audioDuration took to transcribe and returns the chunk
+// duration to use next, along with whether it changed
+func (t *ChunkTuner) Observe(latency, audioDuration time.Duration) (int, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if audioDuration <= 0 {
+		return t.current, false
+	}
+	t.loads = append(t.loads, latency.Seconds()/audioDuration.Seconds())
+	if len(t.loads) < t.evaluationChunks {
+		return t.current, false
+	}
+
+	var total float64
+	for _, load := range t.loads {
+		total += load
+	}
+	avgLoad := total / float64(len(t.loads))
+	t.loads = t.loads[:0]
+
+	previous := t.current
+	switch {
+	case avgLoad > ChunkTunerHighLoad && t.current < t.maxSec:
+		t.current++
+	case avgLoad < ChunkTunerLowLoad && t.current > t.minSec:
+		t.current--
+	default:
+		return t.current, false
+	}
+
+	realTimeRatio := 0.0
+	if avgLoad > 0 {
+		realTimeRatio = 1 / avgLoad
+	}
+	t.logger.Info("adjusted transcription chunk duration",
+		zap.Int("previous_chunk_duration_sec", previous),
+		zap.Int("chunk_duration_sec", t.current),
+		zap.Float64("average_load", avgLoad),
+		zap.Float64("real_time_ratio", realTimeRatio),
+		zap.Int("min_chunk_duration_sec", t.minSec),
+		zap.Int("max_chunk_duration_sec", t.maxSec))
+	return t.current, true
+}
@@ -0,0 +1,129 @@
+package transcriber
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"radiocontestwinner/internal/config"
+)
+
+// chunkRecordingModel records the size of every chunk it is asked to transcribe
+type chunkRecordingModel struct {
+	MockWhisperModel
+	mu     sync.Mutex
+	chunks []int
+}
+
+func (m *chunkRecordingModel) Transcribe(audioData []byte) ([]TranscriptionSegment, error) {
+	m.mu.Lock()
+	m.chunks = append(m.chunks, len(audioData))
+	m.mu.Unlock()
+	return nil, nil
+}
+
+func (m *chunkRecordingModel) chunkSizes() []int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]int(nil), m.chunks...)
+}
+
+func TestNewChunkTuner(t *testing.T) {
+	t.Run("should clamp the initial duration to the bounds", func(t *testing.T) {
+		assert.Equal(t, 3, NewChunkTuner(1, 3, 10, 5, nil).ChunkDurationSec())
+		assert.Equal(t, 10, NewChunkTuner(30, 3, 10, 5, nil).ChunkDurationSec())
+		assert.Equal(t, 5, NewChunkTuner(5, 3, 10, 5, nil).ChunkDurationSec())
+	})
+}
+
+func TestChunkTuner_Observe(t *testing.T) {
+	t.Run("should grow chunks when transcription is close to real time", func(t *testing.T) {
+		tuner := NewChunkTuner(5, 3, 10, 2, zaptest.NewLogger(t))
+
+		_, changed := tuner.Observe(4500*time.Millisecond, 5*time.Second)
+		assert.False(t, changed, "should wait for the evaluation window")
+		duration, changed := tuner.Observe(4600*time.Millisecond, 5*time.Second)
+
+		assert.True(t, changed)
+		assert.Equal(t, 6, duration)
+	})
+
+	t.Run("should shrink chunks when there is headroom", func(t *testing.T) {
+		tuner := NewChunkTuner(5, 3, 10, 1, zaptest.NewLogger(t))
+
+		duration, changed := tuner.Observe(500*time.Millisecond, 5*time.Second)
+
+		assert.True(t, changed)
+		assert.Equal(t, 4, duration)
+	})
+
+	t.Run("should keep the duration in the target load band", func(t *testing.T) {
+		tuner := NewChunkTuner(5, 3, 10, 1, nil)
+
+		duration, changed := tuner.Observe(2500*time.Millisecond, 5*time.Second)
+
+		assert.False(t, changed)
+		assert.Equal(t, 5, duration)
+	})
+
+	t.Run("should not move past the bounds", func(t *testing.T) {
+		fast := NewChunkTuner(3, 3, 10, 1, nil)
+		slow := NewChunkTuner(10, 3, 10, 1, nil)
+
+		_, shrunk := fast.Observe(100*time.Millisecond, 3*time.Second)
+		_, grew := slow.Observe(12*time.Second, 10*time.Second)
+
+		assert.False(t, shrunk)
+		assert.False(t, grew)
+		assert.Equal(t, 3, fast.ChunkDurationSec())
+		assert.Equal(t, 10, slow.ChunkDurationSec())
+	})
+}
+
+func TestTranscriptionEngine_ChunkAutoTuning(t *testing.T) {
+	t.Run("should shrink chunks while transcription has headroom", func(t *testing.T) {
+		// Arrange
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		configContent := `transcription:
+  chunk_duration_sec: 4
+  overlap_sec: 1
+  auto_tune:
+    enabled: true
+    min_chunk_duration_sec: 2
+    evaluation_chunks: 1
+`
+		require.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
+		cfg, err := config.NewConfigurationFromFile(configFile)
+		require.NoError(t, err)
+		engine := NewTranscriptionEngineWithConfig(zaptest.NewLogger(t), cfg)
+		model := &chunkRecordingModel{}
+		engine.model = model
+
+		audio := bytes.NewReader(make([]byte, 12*16000*2))
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Act
+		segments, err := engine.ProcessAudio(ctx, audio)
+		require.NoError(t, err)
+		require.Eventually(t, func() bool { return len(model.chunkSizes()) >= 3 }, 5*time.Second, 10*time.Millisecond)
+		cancel()
+		for range segments {
+		}
+
+		// Assert
+		sizes := model.chunkSizes()
+		assert.Equal(t, 4*16000*2, sizes[0])
+		assert.Equal(t, 3*16000*2, sizes[1])
+		assert.Equal(t, 2*16000*2, sizes[2])
+		assert.Equal(t, 2, engine.ChunkDurationSec())
+	})
+}
@@ -0,0 +1,152 @@
+package transcriber
+
+import (
+	"encoding/binary"
+	"math"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"go.uber.org/zap"
+)
+
+// idleNoSpeechProb is the no-speech probability at or above which a segment is not counted as
+// speech when deciding whether the station has gone quiet
+const idleNoSpeechProb = 0.6
+
+// IdleConfig configures IdleMode
+type IdleConfig struct {
+	After          time.Duration        // Without speech this long, transcription drops to sampling
+	SampleInterval time.Duration        // While idle, one audible chunk is transcribed this often
+	SilenceDBFS    float64              // Chunks quieter than this are silence and skipped while idle
+	Window         func(time.Time) bool // When idle mode may start, e.g. overnight; nil allows any time
+}
+
+// IdleStatus reports the idle mode for health output
+type IdleStatus struct {
+	Idle          bool
+	Since         time.Time // When idle mode started; zero while transcribing at full rate
+	SkippedChunks int64     // Chunks not transcribed while idle
+}
+
+// IdleMode saves transcription work through long stretches without speech, such as overnight
+// dead air or automated music. Once no speech has been transcribed for After, within Window, it
+// only transcribes an audible chunk every SampleInterval and skips silent ones. A chunk turning
+// audible after silence is transcribed straight away, and the first speech heard returns
+// transcription to full rate.
+type IdleMode struct {
+	config IdleConfig
+	logger *zap.Logger
+
+	mu           sync.Mutex
+	lastSpeech   time.Time
+	idle         bool
+	since        time.Time
+	lastSample   time.Time
+	wasSilent    bool
+	skipped      int64
+	totalSkipped int64
+}
+
+// NewIdleMode creates an idle mode that counts from now as the last speech heard
+func NewIdleMode(config IdleConfig, now time.Time, logger *zap.Logger) *IdleMode {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &IdleMode{config: config, logger: logger, lastSpeech: now}
+}
+
+// ShouldTranscribe reports whether the chunk of 16-bit mono PCM read at now should be transcribed
+func (m *IdleMode) ShouldTranscribe(now time.Time, chunk []byte) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	inWindow := m.config.Window == nil || m.config.Window(now)
+	if !m.idle {
+		if !inWindow || now.Sub(m.lastSpeech) < m.config.After {
+			return true
+		}
+		m.idle, m.since, m.lastSample, m.skipped = true, now, now, 0
+		m.logger.Info("no speech heard, entering idle mode",
+			zap.Duration("silent_for", now.Sub(m.lastSpeech)),
+			zap.Duration("sample_interval", m.config.SampleInterval))
+	} else if !inWindow {
+		m.exitLocked(now, "idle window ended")
+		return true
+	}
+
+	silent := ChunkLevelDBFS(chunk) < m.config.SilenceDBFS
+	wasSilent := m.wasSilent
+	m.wasSilent = silent
+	switch {
+	case silent:
+	case wasSilent, now.Sub(m.lastSample) >= m.config.SampleInterval:
+		m.lastSample = now
+		return true
+	}
+	m.skipped++
+	m.totalSkipped++
+	return false
+}
+
+// Observe records what a transcribed chunk contained; speech returns transcription to full rate
+func (m *IdleMode) Observe(now time.Time, segments []TranscriptionSegment) {
+	if !hasSpeech(segments) {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastSpeech = now
+	if m.idle {
+		m.exitLocked(now, "speech resumed")
+	}
+}
+
+// exitLocked returns transcription to full rate
+func (m *IdleMode) exitLocked(now time.Time, reason string) {
+	m.logger.Info("leaving idle mode, transcribing at full rate",
+		zap.String("reason", reason),
+		zap.Duration("idle_for", now.Sub(m.since)),
+		zap.Int64("skipped_chunks", m.skipped))
+	m.idle, m.since, m.wasSilent = false, time.Time{}, false
+}
+
+// Status returns the current idle state
+func (m *IdleMode) Status() IdleStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return IdleStatus{Idle: m.idle, Since: m.since, SkippedChunks: m.totalSkipped}
+}
+
+// hasSpeech reports whether any segment holds spoken words rather than a non-speech marker such
+// as "[BLANK_AUDIO]" or "(music)", or text the backend thinks is probably not speech
+func hasSpeech(segments []TranscriptionSegment) bool {
+	for _, segment := range segments {
+		if segment.NoSpeechProb >= idleNoSpeechProb {
+			continue
+		}
+		text := strings.TrimSpace(segment.Text)
+		if text == "" || strings.IndexAny(text, "[(*♪") == 0 {
+			continue
+		}
+		if strings.IndexFunc(text, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ChunkLevelDBFS returns the RMS level of 16-bit little-endian mono PCM in dBFS; -inf for silence
+func ChunkLevelDBFS(pcm []byte) float64 {
+	samples := len(pcm) / 2
+	if samples == 0 {
+		return math.Inf(-1)
+	}
+	var sum float64
+	for i := 0; i < samples; i++ {
+		s := float64(int16(binary.LittleEndian.Uint16(pcm[2*i:]))) / 32768
+		sum += s * s
+	}
+	return 10 * math.Log10(sum/float64(samples))
+}
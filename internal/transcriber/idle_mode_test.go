@@ -0,0 +1,116 @@
+package transcriber
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// toneChunk returns a second of 16-bit PCM at a constant amplitude (0 for digital silence)
+func toneChunk(amplitude int16) []byte {
+	chunk := make([]byte, 32000)
+	for i := 0; i < len(chunk)/2; i++ {
+		sample := amplitude
+		if i%2 == 1 {
+			sample = -amplitude
+		}
+		binary.LittleEndian.PutUint16(chunk[2*i:], uint16(sample))
+	}
+	return chunk
+}
+
+var (
+	silentChunk  = toneChunk(0)
+	audibleChunk = toneChunk(8000) // About -12 dBFS
+)
+
+func TestChunkLevelDBFS(t *testing.T) {
+	t.Run("should measure the RMS level of the chunk", func(t *testing.T) {
+		assert.InDelta(t, -12.3, ChunkLevelDBFS(audibleChunk), 0.1)
+		assert.True(t, math.IsInf(ChunkLevelDBFS(silentChunk), -1))
+		assert.True(t, math.IsInf(ChunkLevelDBFS(nil), -1))
+	})
+}
+
+func TestIdleMode(t *testing.T) {
+	start := time.Date(2026, 10, 17, 1, 0, 0, 0, time.UTC)
+	config := IdleConfig{After: 10 * time.Minute, SampleInterval: 2 * time.Minute, SilenceDBFS: -50}
+	speech := []TranscriptionSegment{{Text: "Text CASH to 72881"}}
+	music := []TranscriptionSegment{{Text: "[Music]"}, {Text: "♪ ♪"}, {Text: "thank you", NoSpeechProb: 0.9}}
+
+	t.Run("should transcribe every chunk until no speech is heard for a while", func(t *testing.T) {
+		// Arrange
+		idle := NewIdleMode(config, start, zap.NewNop())
+
+		// Act & Assert
+		assert.True(t, idle.ShouldTranscribe(start.Add(5*time.Minute), silentChunk))
+		idle.Observe(start.Add(5*time.Minute), speech)
+		assert.True(t, idle.ShouldTranscribe(start.Add(14*time.Minute), silentChunk))
+		assert.False(t, idle.Status().Idle)
+
+		assert.False(t, idle.ShouldTranscribe(start.Add(15*time.Minute), silentChunk))
+		status := idle.Status()
+		assert.True(t, status.Idle)
+		assert.Equal(t, start.Add(15*time.Minute), status.Since)
+		assert.Equal(t, int64(1), status.SkippedChunks)
+	})
+
+	t.Run("should sample audible chunks periodically while idle and skip silent ones", func(t *testing.T) {
+		// Arrange
+		idle := NewIdleMode(config, start, zap.NewNop())
+		now := start.Add(10 * time.Minute)
+		require.False(t, idle.ShouldTranscribe(now, silentChunk))
+
+		// Act
+		var transcribed []bool
+		for i := 1; i <= 8; i++ {
+			now = now.Add(30 * time.Second)
+			transcribed = append(transcribed, idle.ShouldTranscribe(now, audibleChunk))
+			idle.Observe(now, music)
+		}
+
+		// Assert: the first audible chunk after silence, then one every two minutes
+		assert.Equal(t, []bool{true, false, false, false, true, false, false, false}, transcribed)
+		assert.True(t, idle.Status().Idle)
+	})
+
+	t.Run("should return to full rate as soon as speech is heard", func(t *testing.T) {
+		// Arrange
+		idle := NewIdleMode(config, start, zap.NewNop())
+		now := start.Add(10 * time.Minute)
+		require.False(t, idle.ShouldTranscribe(now, silentChunk))
+
+		// Act
+		now = now.Add(time.Second)
+		require.True(t, idle.ShouldTranscribe(now, audibleChunk))
+		idle.Observe(now, speech)
+
+		// Assert
+		assert.False(t, idle.Status().Idle)
+		assert.True(t, idle.ShouldTranscribe(now.Add(time.Second), audibleChunk))
+		assert.True(t, idle.ShouldTranscribe(now.Add(2*time.Second), silentChunk))
+		assert.Equal(t, int64(1), idle.Status().SkippedChunks)
+	})
+
+	t.Run("should only idle within the window", func(t *testing.T) {
+		// Arrange
+		windowed := config
+		windowed.Window = func(t time.Time) bool { return t.Hour() < 5 }
+		idle := NewIdleMode(windowed, start, zap.NewNop())
+		require.False(t, idle.ShouldTranscribe(start.Add(time.Hour), silentChunk))
+
+		// Act
+		morning := time.Date(2026, 10, 17, 5, 0, 0, 0, time.UTC)
+		transcribed := idle.ShouldTranscribe(morning, silentChunk)
+
+		// Assert
+		assert.True(t, transcribed)
+		assert.False(t, idle.Status().Idle)
+		assert.True(t, idle.ShouldTranscribe(morning.Add(time.Minute), silentChunk))
+	})
+}
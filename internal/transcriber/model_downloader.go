@@ -1,23 +1,60 @@
 package transcriber
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/retry"
+	"radiocontestwinner/internal/version"
 )
 
-// ModelDownloader handles downloading Whisper models from HuggingFace
+// DownloadProgress reports how far a model download has got
+type DownloadProgress struct {
+	Model      string    `json:"model"`
+	Source     string    `json:"source"` // URL being downloaded from
+	Downloaded int64     `json:"downloaded_bytes"`
+	Total      int64     `json:"total_bytes"` // 0 when the server does not report a size
+	Done       bool      `json:"done"`
+	Error      string    `json:"error,omitempty"` // Set when every source failed
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Percent returns the share of the model downloaded so far, or 0 when the size is unknown
+func (p DownloadProgress) Percent() float64 {
+	if p.Total <= 0 {
+		return 0
+	}
+	return float64(p.Downloaded) / float64(p.Total) * 100
+}
+
+// ModelDownloader handles downloading Whisper models from HuggingFace or configured mirrors.
+// Interrupted downloads are kept as <model path>.part and resumed on the next attempt.
 type ModelDownloader struct {
 	logger    *zap.Logger
 	modelsDir string
 	client    *http.Client
 	baseURL   string
+	mirrors   []string          // Base URLs tried in order before baseURL
+	checksums map[string]string // Expected SHA256 (hex) by model name
+	offline   bool              // Offline mode: missing models are an error instead of a download
+	retry     retry.Policy      // Retries for interrupted downloads and server errors
+
+	progressInterval time.Duration
+	onProgress       func(DownloadProgress)
+
+	mu       sync.Mutex
+	progress *DownloadProgress // Latest progress of the current or last download; nil before any
 }
 
 // NewModelDownloader creates a new model downloader instance
@@ -29,7 +66,67 @@ func NewModelDownloader(logger *zap.Logger, modelsDir string) *ModelDownloader {
 			Timeout: 10 * time.Minute, // Long timeout for large model downloads
 		},
 		baseURL: "https://huggingface.co/ggerganov/whisper.cpp/resolve/main",
+		retry: retry.Policy{
+			MaxAttempts: 3,
+			BaseDelay:   2 * time.Second,
+			MaxDelay:    30 * time.Second,
+			Jitter:      0.2,
+		},
+		progressInterval: 10 * time.Second,
+	}
+}
+
+// NewModelDownloaderWithConfig creates a model downloader using the configured mirrors, checksums, and offline mode
+func NewModelDownloaderWithConfig(logger *zap.Logger, modelsDir string, cfg *config.Configuration) *ModelDownloader {
+	d := NewModelDownloader(logger, modelsDir)
+	d.mirrors = cfg.GetWhisperDownloadMirrors()
+	d.checksums = cfg.GetWhisperModelChecksums()
+	d.offline = cfg.GetOfflineMode()
+	return d
+}
+
+// SetProgressCallback sets a function called with the download progress whenever it is logged
+// and when a download finishes or fails
+func (d *ModelDownloader) SetProgressCallback(fn func(DownloadProgress)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onProgress = fn
+}
+
+// Progress returns the progress of the current or last download, and false if nothing was downloaded
+func (d *ModelDownloader) Progress() (DownloadProgress, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.progress == nil {
+		return DownloadProgress{}, false
 	}
+	return *d.progress, true
+}
+
+// reportProgress records progress and passes it to the progress callback
+func (d *ModelDownloader) reportProgress(progress DownloadProgress) {
+	progress.UpdatedAt = time.Now()
+	d.mu.Lock()
+	d.progress = &progress
+	onProgress := d.onProgress
+	d.mu.Unlock()
+	if onProgress != nil {
+		onProgress(progress)
+	}
+}
+
+// sources returns the base URLs to download from in order: the mirrors, then HuggingFace
+func (d *ModelDownloader) sources() []string {
+	sources := make([]string, 0, len(d.mirrors)+1)
+	seen := make(map[string]bool)
+	for _, source := range append(append([]string{}, d.mirrors...), d.baseURL) {
+		source = strings.TrimRight(source, "/")
+		if source != "" && !seen[source] {
+			seen[source] = true
+			sources = append(sources, source)
+		}
+	}
+	return sources
 }
 
 // GetAvailableModels returns a list of commonly available Whisper models
@@ -59,6 +156,10 @@ func (d *ModelDownloader) EnsureModelExists(modelName, modelPath string) error {
 		return nil
 	}
 
+	if d.offline {
+		return fmt.Errorf("model %s not found at %s and offline_mode prevents downloading it; copy the model there first", modelName, modelPath)
+	}
+
 	d.logger.Info("model not found locally, attempting download",
 		zap.String("model", modelName),
 		zap.String("path", modelPath))
@@ -72,75 +173,185 @@ func (d *ModelDownloader) EnsureModelExists(modelName, modelPath string) error {
 	return d.downloadModel(modelName, modelPath)
 }
 
-// downloadModel downloads a model from HuggingFace
+// downloadModel downloads a model from each source in turn until one succeeds, retrying
+// transient failures against a source before moving on to the next
 func (d *ModelDownloader) downloadModel(modelName, modelPath string) error {
-	// Construct download URL
-	url := fmt.Sprintf("%s/ggml-%s.bin", d.baseURL, modelName)
+	var failures []error
+	for _, source := range d.sources() {
+		// Construct download URL
+		url := fmt.Sprintf("%s/ggml-%s.bin", source, modelName)
 
-	d.logger.Info("downloading model from HuggingFace",
-		zap.String("model", modelName),
-		zap.String("url", url),
-		zap.String("destination", modelPath))
+		d.logger.Info("downloading model",
+			zap.String("model", modelName),
+			zap.String("url", url),
+			zap.String("destination", modelPath))
+
+		policy := d.retry
+		policy.OnRetry = func(next int, delay time.Duration, err error) {
+			d.logger.Warn("model download failed, retrying",
+				zap.String("model", modelName),
+				zap.String("url", url),
+				zap.Int("next_attempt", next),
+				zap.Duration("backoff", delay),
+				zap.Error(err))
+		}
+
+		var written int64
+		err := retry.Do(context.Background(), policy, func(ctx context.Context, attempt int) error {
+			var err error
+			written, err = d.downloadOnce(ctx, url, modelPath, modelName)
+			return err
+		})
+		if err == nil {
+			d.reportProgress(DownloadProgress{Model: modelName, Source: url, Downloaded: written, Total: written, Done: true})
+			d.logger.Info("model download completed successfully",
+				zap.String("model", modelName),
+				zap.String("path", modelPath),
+				zap.String("url", url),
+				zap.Int64("bytes", written))
+			return nil
+		}
+
+		d.logger.Warn("model download from source failed",
+			zap.String("model", modelName),
+			zap.String("url", url),
+			zap.Error(err))
+		failures = append(failures, fmt.Errorf("%s: %w", url, err))
+	}
+
+	err := fmt.Errorf("failed to download model %s: %w", modelName, errors.Join(failures...))
+	progress, _ := d.Progress()
+	d.reportProgress(DownloadProgress{Model: modelName, Source: progress.Source, Downloaded: progress.Downloaded, Total: progress.Total, Error: err.Error()})
+	return err
+}
+
+// downloadOnce makes a single attempt to download url to modelPath, resuming a partial download
+// left by an earlier attempt, and returns the model size. Failures retrying cannot fix are
+// marked permanent.
+func (d *ModelDownloader) downloadOnce(ctx context.Context, url, modelPath, modelName string) (int64, error) {
+	partFile := modelPath + ".part"
+	var offset int64
+	if info, err := os.Stat(partFile); err == nil {
+		offset = info.Size()
+	}
 
 	// Create HTTP request
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create download request: %w", err)
+		return 0, retry.Permanent(fmt.Errorf("failed to create download request: %w", err))
 	}
 
 	// Set headers for better download experience
-	req.Header.Set("User-Agent", "RadioContestWinner/3.1 (Go HTTP Client)")
+	req.Header.Set("User-Agent", version.UserAgent())
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
 
 	// Execute request
 	resp, err := d.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to download model: %w", err)
+		return 0, fmt.Errorf("failed to download model: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// Check response status
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to download model: HTTP %d", resp.StatusCode)
+	// Check response status; a server ignoring the range sends the whole model again
+	flags := os.O_CREATE | os.O_WRONLY
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		flags |= os.O_APPEND
+		d.logger.Info("resuming model download",
+			zap.String("model", modelName),
+			zap.Int64("offset", offset))
+	case resp.StatusCode == http.StatusOK:
+		flags |= os.O_TRUNC
+		offset = 0
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// The partial file is no use to this source; start over on the next attempt
+		os.Remove(partFile)
+		return 0, fmt.Errorf("failed to resume model download: HTTP %d", resp.StatusCode)
+	default:
+		err := fmt.Errorf("failed to download model: HTTP %d", resp.StatusCode)
+		if !retry.RetryableStatus(resp.StatusCode) {
+			return 0, retry.Permanent(err)
+		}
+		return 0, err
 	}
 
-	// Create temporary file for atomic download
-	tempFile := modelPath + ".tmp"
-	defer os.Remove(tempFile) // Clean up temp file if something goes wrong
-
-	// Create output file
-	out, err := os.Create(tempFile)
+	// Write to the partial file, renamed once complete and verified
+	out, err := os.OpenFile(partFile, flags, 0644)
 	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
+		return 0, retry.Permanent(fmt.Errorf("failed to create output file: %w", err))
 	}
 	defer out.Close()
 
+	total := int64(0)
+	if resp.ContentLength > 0 {
+		total = offset + resp.ContentLength
+	}
+	progress := DownloadProgress{Model: modelName, Source: url, Downloaded: offset, Total: total}
+
 	// Copy with progress logging
-	written, err := d.copyWithProgress(out, resp.Body, resp.ContentLength, modelName)
+	written, err := d.copyWithProgress(out, resp.Body, progress)
 	if err != nil {
-		return fmt.Errorf("failed to download model data: %w", err)
+		return offset + written, fmt.Errorf("failed to download model data: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		return offset + written, fmt.Errorf("failed to write model data: %w", err)
 	}
 
-	// Atomically move temp file to final location
-	if err := os.Rename(tempFile, modelPath); err != nil {
-		return fmt.Errorf("failed to move downloaded model to final location: %w", err)
+	if err := d.verifyChecksum(modelName, partFile); err != nil {
+		// A corrupt file cannot be resumed; the next source starts from scratch
+		os.Remove(partFile)
+		return offset + written, retry.Permanent(err)
 	}
 
-	d.logger.Info("model download completed successfully",
-		zap.String("model", modelName),
-		zap.String("path", modelPath),
-		zap.Int64("bytes", written))
+	// Atomically move the complete file to its final location
+	if err := os.Rename(partFile, modelPath); err != nil {
+		return offset + written, retry.Permanent(fmt.Errorf("failed to move downloaded model to final location: %w", err))
+	}
+
+	return offset + written, nil
+}
+
+// verifyChecksum checks path against the configured SHA256 for modelName, if there is one
+func (d *ModelDownloader) verifyChecksum(modelName, path string) error {
+	expected := d.checksums[strings.ToLower(modelName)]
+	if expected == "" {
+		return nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open downloaded model: %w", err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat downloaded model: %w", err)
+	}
+
+	actual, err := fileSHA256(file, info.Size())
+	if err != nil {
+		return fmt.Errorf("failed to checksum downloaded model: %w", err)
+	}
+	if actual != strings.ToLower(expected) {
+		return fmt.Errorf("checksum mismatch for model %s: expected sha256 %s, got %s", modelName, expected, actual)
+	}
 
+	d.logger.Info("model checksum verified", zap.String("model", modelName))
 	return nil
 }
 
-// copyWithProgress copies data from src to dst with progress logging
-func (d *ModelDownloader) copyWithProgress(dst io.Writer, src io.Reader, totalSize int64, modelName string) (int64, error) {
+// copyWithProgress copies data from src to dst, logging and reporting progress periodically.
+// progress holds the model, source, bytes already downloaded, and total size.
+func (d *ModelDownloader) copyWithProgress(dst io.Writer, src io.Reader, progress DownloadProgress) (int64, error) {
 	const bufferSize = 32 * 1024 // 32KB buffer
 	buffer := make([]byte, bufferSize)
 
 	var written int64
+	start := progress.Downloaded
 	lastLogTime := time.Now()
-	logInterval := 10 * time.Second
+	d.reportProgress(progress)
 
 	for {
 		nr, er := src.Read(buffer)
@@ -162,19 +373,20 @@ func (d *ModelDownloader) copyWithProgress(dst io.Writer, src io.Reader, totalSi
 
 			// Log progress periodically
 			now := time.Now()
-			if now.Sub(lastLogTime) >= logInterval {
-				if totalSize > 0 {
-					percentage := float64(written) / float64(totalSize) * 100
+			if now.Sub(lastLogTime) >= d.progressInterval {
+				progress.Downloaded = start + written
+				if progress.Total > 0 {
 					d.logger.Info("download progress",
-						zap.String("model", modelName),
-						zap.Int64("downloaded", written),
-						zap.Int64("total", totalSize),
-						zap.Float64("percentage", percentage))
+						zap.String("model", progress.Model),
+						zap.Int64("downloaded", progress.Downloaded),
+						zap.Int64("total", progress.Total),
+						zap.Float64("percentage", progress.Percent()))
 				} else {
 					d.logger.Info("download progress",
-						zap.String("model", modelName),
-						zap.Int64("downloaded", written))
+						zap.String("model", progress.Model),
+						zap.Int64("downloaded", progress.Downloaded))
 				}
+				d.reportProgress(progress)
 				lastLogTime = now
 			}
 		}
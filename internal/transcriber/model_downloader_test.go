@@ -1,12 +1,21 @@
 package transcriber
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+
+	"radiocontestwinner/internal/config"
 )
 
 func TestModelDownloader(t *testing.T) {
@@ -89,4 +98,280 @@ func TestModelDownloader(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to download")
 	})
-}
\ No newline at end of file
+}
+
+func TestModelDownloader_Retry(t *testing.T) {
+	t.Run("should retry server errors until the download succeeds", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls < 3 {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte("model bytes"))
+		}))
+		defer server.Close()
+
+		tempDir := t.TempDir()
+		downloader := NewModelDownloader(zap.NewNop(), tempDir)
+		downloader.baseURL = server.URL
+		downloader.retry.BaseDelay = time.Millisecond
+		modelPath := filepath.Join(tempDir, "ggml-tiny.bin")
+
+		// Act
+		err := downloader.EnsureModelExists("tiny", modelPath)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+		content, err := os.ReadFile(modelPath)
+		assert.NoError(t, err)
+		assert.Equal(t, "model bytes", string(content))
+	})
+
+	t.Run("should not retry a missing model", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			http.NotFound(w, r)
+		}))
+		defer server.Close()
+
+		tempDir := t.TempDir()
+		downloader := NewModelDownloader(zap.NewNop(), tempDir)
+		downloader.baseURL = server.URL
+		downloader.retry.BaseDelay = time.Millisecond
+
+		// Act
+		err := downloader.EnsureModelExists("missing", filepath.Join(tempDir, "ggml-missing.bin"))
+
+		// Assert
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "HTTP 404")
+		assert.Equal(t, 1, calls)
+	})
+}
+
+// modelServer serves content for any path, honoring Range requests, and records the Range headers seen
+func modelServer(t *testing.T, content []byte, ranges *[]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ranges != nil {
+			*ranges = append(*ranges, r.Header.Get("Range"))
+		}
+		http.ServeContent(w, r, "model.bin", time.Time{}, bytes.NewReader(content))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestModelDownloader_Sources(t *testing.T) {
+	t.Run("should fall back from a failing mirror to the next source", func(t *testing.T) {
+		// Arrange
+		blocked := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+		}))
+		defer blocked.Close()
+		mirror := modelServer(t, []byte("mirrored model"), nil)
+
+		tempDir := t.TempDir()
+		downloader := NewModelDownloader(zap.NewNop(), tempDir)
+		downloader.mirrors = []string{blocked.URL, mirror.URL + "/"}
+		downloader.baseURL = blocked.URL
+		modelPath := filepath.Join(tempDir, "ggml-tiny.bin")
+
+		// Act
+		err := downloader.EnsureModelExists("tiny", modelPath)
+
+		// Assert
+		require.NoError(t, err)
+		content, err := os.ReadFile(modelPath)
+		require.NoError(t, err)
+		assert.Equal(t, "mirrored model", string(content))
+		progress, ok := downloader.Progress()
+		require.True(t, ok)
+		assert.True(t, progress.Done)
+		assert.Equal(t, mirror.URL+"/ggml-tiny.bin", progress.Source)
+	})
+
+	t.Run("should report every source when all of them fail", func(t *testing.T) {
+		// Arrange
+		missing := httptest.NewServer(http.NotFoundHandler())
+		defer missing.Close()
+
+		tempDir := t.TempDir()
+		downloader := NewModelDownloader(zap.NewNop(), tempDir)
+		downloader.mirrors = []string{missing.URL + "/mirror"}
+		downloader.baseURL = missing.URL
+
+		// Act
+		err := downloader.EnsureModelExists("tiny", filepath.Join(tempDir, "ggml-tiny.bin"))
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), missing.URL+"/mirror/ggml-tiny.bin")
+		assert.Contains(t, err.Error(), missing.URL+"/ggml-tiny.bin")
+		progress, ok := downloader.Progress()
+		require.True(t, ok)
+		assert.False(t, progress.Done)
+		assert.NotEmpty(t, progress.Error)
+	})
+}
+
+func TestModelDownloader_Resume(t *testing.T) {
+	t.Run("should resume from a partial download", func(t *testing.T) {
+		// Arrange
+		var ranges []string
+		server := modelServer(t, []byte("0123456789"), &ranges)
+
+		tempDir := t.TempDir()
+		downloader := NewModelDownloader(zap.NewNop(), tempDir)
+		downloader.baseURL = server.URL
+		modelPath := filepath.Join(tempDir, "ggml-tiny.bin")
+		require.NoError(t, os.WriteFile(modelPath+".part", []byte("01234"), 0644))
+
+		// Act
+		err := downloader.EnsureModelExists("tiny", modelPath)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bytes=5-"}, ranges)
+		content, err := os.ReadFile(modelPath)
+		require.NoError(t, err)
+		assert.Equal(t, "0123456789", string(content))
+		assert.NoFileExists(t, modelPath+".part")
+	})
+
+	t.Run("should start over when the server ignores the range", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("fresh model"))
+		}))
+		defer server.Close()
+
+		tempDir := t.TempDir()
+		downloader := NewModelDownloader(zap.NewNop(), tempDir)
+		downloader.baseURL = server.URL
+		modelPath := filepath.Join(tempDir, "ggml-tiny.bin")
+		require.NoError(t, os.WriteFile(modelPath+".part", []byte("stale partial data"), 0644))
+
+		// Act
+		err := downloader.EnsureModelExists("tiny", modelPath)
+
+		// Assert
+		require.NoError(t, err)
+		content, err := os.ReadFile(modelPath)
+		require.NoError(t, err)
+		assert.Equal(t, "fresh model", string(content))
+	})
+}
+
+func TestModelDownloader_Checksum(t *testing.T) {
+	sum := sha256.Sum256([]byte("genuine model"))
+	checksum := hex.EncodeToString(sum[:])
+
+	t.Run("should accept a download matching the configured checksum", func(t *testing.T) {
+		server := modelServer(t, []byte("genuine model"), nil)
+		tempDir := t.TempDir()
+		downloader := NewModelDownloader(zap.NewNop(), tempDir)
+		downloader.baseURL = server.URL
+		downloader.checksums = map[string]string{"tiny": checksum}
+		modelPath := filepath.Join(tempDir, "ggml-tiny.bin")
+
+		err := downloader.EnsureModelExists("tiny", modelPath)
+
+		require.NoError(t, err)
+		assert.FileExists(t, modelPath)
+	})
+
+	t.Run("should discard a download that does not match and try the next source", func(t *testing.T) {
+		// Arrange
+		tampered := modelServer(t, []byte("tampered model"), nil)
+		genuine := modelServer(t, []byte("genuine model"), nil)
+		tempDir := t.TempDir()
+		downloader := NewModelDownloader(zap.NewNop(), tempDir)
+		downloader.mirrors = []string{tampered.URL}
+		downloader.baseURL = genuine.URL
+		downloader.checksums = map[string]string{"tiny": checksum}
+		modelPath := filepath.Join(tempDir, "ggml-tiny.bin")
+
+		// Act
+		err := downloader.EnsureModelExists("tiny", modelPath)
+
+		// Assert
+		require.NoError(t, err)
+		content, err := os.ReadFile(modelPath)
+		require.NoError(t, err)
+		assert.Equal(t, "genuine model", string(content))
+	})
+
+	t.Run("should fail when no source has a matching model", func(t *testing.T) {
+		server := modelServer(t, []byte("tampered model"), nil)
+		tempDir := t.TempDir()
+		downloader := NewModelDownloader(zap.NewNop(), tempDir)
+		downloader.baseURL = server.URL
+		downloader.checksums = map[string]string{"tiny": checksum}
+		modelPath := filepath.Join(tempDir, "ggml-tiny.bin")
+
+		err := downloader.EnsureModelExists("tiny", modelPath)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "checksum mismatch")
+		assert.NoFileExists(t, modelPath)
+		assert.NoFileExists(t, modelPath+".part")
+	})
+}
+
+func TestModelDownloader_ProgressCallback(t *testing.T) {
+	t.Run("should report progress while downloading and when done", func(t *testing.T) {
+		// Arrange
+		server := modelServer(t, bytes.Repeat([]byte("m"), 100*1024), nil)
+		tempDir := t.TempDir()
+		downloader := NewModelDownloader(zap.NewNop(), tempDir)
+		downloader.baseURL = server.URL
+		downloader.progressInterval = 0
+		var reports []DownloadProgress
+		downloader.SetProgressCallback(func(progress DownloadProgress) {
+			reports = append(reports, progress)
+		})
+
+		// Act
+		err := downloader.EnsureModelExists("tiny", filepath.Join(tempDir, "ggml-tiny.bin"))
+
+		// Assert
+		require.NoError(t, err)
+		require.Greater(t, len(reports), 2)
+		assert.Equal(t, int64(100*1024), reports[0].Total)
+		last := reports[len(reports)-1]
+		assert.True(t, last.Done)
+		assert.Equal(t, int64(100*1024), last.Downloaded)
+		assert.Equal(t, float64(100), last.Percent())
+	})
+}
+
+func TestModelDownloader_OfflineMode(t *testing.T) {
+	t.Run("should fail without downloading a missing model", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+		}))
+		defer server.Close()
+		cfg := config.NewConfiguration()
+		cfg.SetOfflineMode(true)
+		tempDir := t.TempDir()
+		downloader := NewModelDownloaderWithConfig(zap.NewNop(), tempDir, cfg)
+		downloader.baseURL = server.URL
+
+		// Act
+		err := downloader.EnsureModelExists("tiny", filepath.Join(tempDir, "ggml-tiny.bin"))
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "offline_mode prevents downloading it")
+		assert.Zero(t, calls)
+	})
+}
@@ -0,0 +1,138 @@
+package transcriber
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ggmlMagic starts every ggml Whisper model: the little-endian uint32 0x67676d6c
+var ggmlMagic = []byte{0x6c, 0x6d, 0x67, 0x67}
+
+// ggmlHeaderSize covers the magic and the eleven int32 hyperparameters that follow it
+const ggmlHeaderSize = 4 + 11*4
+
+// ModelIntegrityError reports a model file that whisper.cpp could not load, such as one
+// truncated by an interrupted copy or overwritten with an error page
+type ModelIntegrityError struct {
+	Path   string
+	Model  string // Model name when known, e.g. "base.en"
+	Reason string
+}
+
+func (e *ModelIntegrityError) Error() string {
+	command := "radiocontestwinner model download"
+	if e.Model != "" {
+		command += " -model " + e.Model
+	}
+	return fmt.Sprintf("model corrupted: %s %s; re-download with `%s`", e.Path, e.Reason, command)
+}
+
+// ggmlHeader holds the hyperparameters of a ggml Whisper model
+type ggmlHeader struct {
+	NVocab      int32
+	AudioCtx    int32
+	AudioState  int32
+	AudioHead   int32
+	AudioLayers int32
+	TextCtx     int32
+	TextState   int32
+	TextHead    int32
+	TextLayers  int32
+	NMels       int32
+	FType       int32
+}
+
+// minSize returns a lower bound on the size of a model with these hyperparameters: the token
+// embedding and the attention and MLP weights of every layer, at the smallest size per weight
+// its type allows. The convolutions, biases, and vocabulary only add to it.
+func (h ggmlHeader) minSize() int64 {
+	audioState, textState := int64(h.AudioState), int64(h.TextState)
+	weights := int64(h.NVocab)*textState +
+		int64(h.AudioLayers)*12*audioState*audioState + // self-attention and MLP
+		int64(h.TextLayers)*16*textState*textState // self-attention, cross-attention, and MLP
+
+	// The file stores the quantization version times 1000 plus the weight type
+	switch h.FType % 1000 {
+	case 0: // f32
+		return weights * 4
+	case 1: // f16
+		return weights * 2
+	}
+	return weights / 2 // Quantized types take more than 4 bits per weight
+}
+
+// VerifyModelFile checks that path is a complete ggml Whisper model before whisper.cpp loads
+// it: the magic bytes, plausible hyperparameters, a size at least what they require, and the
+// SHA256 when expectedSHA256 is set. model names the model in the error, and may be empty.
+func VerifyModelFile(path, model, expectedSHA256 string) error {
+	corrupted := func(format string, args ...interface{}) error {
+		return &ModelIntegrityError{Path: path, Model: model, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open model: %w", err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat model: %w", err)
+	}
+
+	header := make([]byte, ggmlHeaderSize)
+	if _, err := io.ReadFull(file, header); err != nil {
+		return corrupted("is too small to be a Whisper model (%d bytes)", info.Size())
+	}
+	if !bytes.Equal(header[:4], ggmlMagic) {
+		return corrupted("is not a ggml Whisper model (starts with %q)", header[:4])
+	}
+	var hparams ggmlHeader
+	if err := binary.Read(bytes.NewReader(header[4:]), binary.LittleEndian, &hparams); err != nil {
+		return corrupted("has an unreadable header: %v", err)
+	}
+	if hparams.NVocab <= 0 || hparams.AudioState <= 0 || hparams.AudioLayers <= 0 ||
+		hparams.TextState <= 0 || hparams.TextLayers <= 0 || (hparams.NMels != 80 && hparams.NMels != 128) {
+		return corrupted("has an invalid header (vocab %d, audio state %d x %d layers, text state %d x %d layers, %d mels)",
+			hparams.NVocab, hparams.AudioState, hparams.AudioLayers, hparams.TextState, hparams.TextLayers, hparams.NMels)
+	}
+	if minSize := hparams.minSize(); info.Size() < minSize {
+		return corrupted("is truncated (%d bytes, its header requires at least %d)", info.Size(), minSize)
+	}
+
+	if expectedSHA256 == "" {
+		return nil
+	}
+	actual, err := fileSHA256(file, info.Size())
+	if err != nil {
+		return fmt.Errorf("failed to checksum model: %w", err)
+	}
+	if actual != strings.ToLower(expectedSHA256) {
+		return corrupted("does not match its checksum (expected sha256 %s, got %s)", expectedSHA256, actual)
+	}
+	return nil
+}
+
+// fileSHA256 returns the hex SHA256 of the size bytes of file. The file is memory-mapped where
+// possible, so verifying a multi-gigabyte model does not copy it through a read buffer.
+func fileSHA256(file *os.File, size int64) (string, error) {
+	hash := sha256.New()
+	if data, unmap, err := mapFile(file, size); err == nil {
+		defer unmap()
+		hash.Write(data)
+		return hex.EncodeToString(hash.Sum(nil)), nil
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
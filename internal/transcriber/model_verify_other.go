@@ -0,0 +1,13 @@
+//go:build !unix
+
+package transcriber
+
+import (
+	"errors"
+	"os"
+)
+
+// mapFile is not supported on this platform; files are read instead
+func mapFile(file *os.File, size int64) ([]byte, func(), error) {
+	return nil, nil, errors.New("memory-mapped files are not supported on this platform")
+}
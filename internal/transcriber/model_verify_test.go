@@ -0,0 +1,99 @@
+package transcriber
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testModelHeader describes a tiny but well-formed ggml Whisper model
+var testModelHeader = ggmlHeader{
+	NVocab: 100, AudioCtx: 1500, AudioState: 8, AudioHead: 1, AudioLayers: 1,
+	TextCtx: 448, TextState: 8, TextHead: 1, TextLayers: 1, NMels: 80, FType: 1,
+}
+
+// writeTestModel writes a model at path that passes verification and returns its contents
+func writeTestModel(t *testing.T, path string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	buf.Write(ggmlMagic)
+	require.NoError(t, binary.Write(&buf, binary.LittleEndian, testModelHeader))
+	buf.Write(make([]byte, testModelHeader.minSize()))
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+	return buf.Bytes()
+}
+
+func TestVerifyModelFile(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("should accept a well-formed model", func(t *testing.T) {
+		path := filepath.Join(dir, "ggml-ok.bin")
+		writeTestModel(t, path)
+
+		assert.NoError(t, VerifyModelFile(path, "ok", ""))
+	})
+
+	t.Run("should reject a file without the ggml magic bytes", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(dir, "ggml-html.bin")
+		require.NoError(t, os.WriteFile(path, bytes.Repeat([]byte("<html>error page</html>"), 10), 0644))
+
+		// Act
+		err := VerifyModelFile(path, "base.en", "")
+
+		// Assert
+		var integrityErr *ModelIntegrityError
+		require.ErrorAs(t, err, &integrityErr)
+		assert.ErrorContains(t, err, "model corrupted")
+		assert.ErrorContains(t, err, "not a ggml Whisper model")
+		assert.ErrorContains(t, err, "radiocontestwinner model download -model base.en")
+	})
+
+	t.Run("should reject a truncated model", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(dir, "ggml-truncated.bin")
+		data := writeTestModel(t, path)
+		require.NoError(t, os.WriteFile(path, data[:len(data)/2], 0644))
+
+		// Act
+		err := VerifyModelFile(path, "", "")
+
+		// Assert
+		assert.ErrorContains(t, err, "is truncated")
+	})
+
+	t.Run("should reject a file too small to hold a header", func(t *testing.T) {
+		path := filepath.Join(dir, "ggml-empty.bin")
+		require.NoError(t, os.WriteFile(path, nil, 0644))
+
+		assert.ErrorContains(t, VerifyModelFile(path, "", ""), "too small")
+	})
+
+	t.Run("should reject an implausible header", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(dir, "ggml-garbled.bin")
+		data := writeTestModel(t, path)
+		binary.LittleEndian.PutUint32(data[4+9*4:], 3) // n_mels
+		require.NoError(t, os.WriteFile(path, data, 0644))
+
+		// Act & Assert
+		assert.ErrorContains(t, VerifyModelFile(path, "", ""), "invalid header")
+	})
+
+	t.Run("should check the checksum when one is given", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(dir, "ggml-sum.bin")
+		sum := sha256.Sum256(writeTestModel(t, path))
+
+		// Act & Assert
+		assert.NoError(t, VerifyModelFile(path, "sum", hex.EncodeToString(sum[:])))
+		assert.ErrorContains(t, VerifyModelFile(path, "sum", "00ff"), "does not match its checksum")
+	})
+}
@@ -0,0 +1,21 @@
+//go:build unix
+
+package transcriber
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// mapFile maps the size bytes of file read-only, returning the data and a function unmapping it
+func mapFile(file *os.File, size int64) ([]byte, func(), error) {
+	if size <= 0 || int64(int(size)) != size {
+		return nil, nil, errors.New("file size cannot be mapped")
+	}
+	data, err := syscall.Mmap(int(file.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() { syscall.Munmap(data) }, nil
+}
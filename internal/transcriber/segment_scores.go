@@ -0,0 +1,139 @@
+package transcriber
+
+import (
+	"math"
+	"strings"
+)
+
+// defaultSegmentConfidence is used when a backend reports no probabilities for a segment
+const defaultSegmentConfidence = 0.85
+
+// whisperToken is a token in whisper.cpp full JSON output (--output-json-full), which also
+// turns on token-level timestamps
+type whisperToken struct {
+	Text    string  `json:"text"`
+	P       float64 `json:"p"`
+	Offsets struct {
+		From int `json:"from"`
+		To   int `json:"to"`
+	} `json:"offsets"`
+}
+
+// isSpecialToken reports whether token is a special token such as [_BEG_] or [_TT_150]
+func isSpecialToken(token whisperToken) bool {
+	return strings.HasPrefix(token.Text, "[_")
+}
+
+// tokenWords joins a segment's text tokens into words. A token starting with a space begins a
+// new word; the others continue the current one, as "123" and "45" make up "12345". A word's
+// probability is the mean of its tokens'.
+func tokenWords(tokens []whisperToken) []Word {
+	var words []Word
+	var tokenCount int
+	var probSum float64
+	finish := func() {
+		if len(words) > 0 {
+			words[len(words)-1].Probability = clampProbability(probSum / float64(tokenCount))
+		}
+	}
+
+	for _, token := range tokens {
+		if isSpecialToken(token) || strings.TrimSpace(token.Text) == "" {
+			continue
+		}
+		if len(words) == 0 || strings.HasPrefix(token.Text, " ") {
+			finish()
+			words = append(words, Word{
+				Text:    strings.TrimSpace(token.Text),
+				StartMS: token.Offsets.From,
+				EndMS:   token.Offsets.To,
+			})
+			tokenCount, probSum = 0, 0
+		} else {
+			word := &words[len(words)-1]
+			word.Text += token.Text
+			word.EndMS = max(word.EndMS, token.Offsets.To)
+		}
+		tokenCount++
+		probSum += token.P
+	}
+	finish()
+	return words
+}
+
+// verboseWord is a word in verbose_json output with word timestamps, as from faster-whisper,
+// whisper-asr-webservice, and the OpenAI API
+type verboseWord struct {
+	Word        string   `json:"word"`
+	Start       float64  `json:"start"`
+	End         float64  `json:"end"`
+	Probability *float64 `json:"probability"`
+}
+
+// verboseWords converts verbose_json words, timed in seconds, to Words
+func verboseWords(words []verboseWord) []Word {
+	var converted []Word
+	for _, word := range words {
+		text := strings.TrimSpace(word.Word)
+		if text == "" {
+			continue
+		}
+		converted = append(converted, Word{
+			Text:        text,
+			StartMS:     int(word.Start * 1000),
+			EndMS:       int(word.End * 1000),
+			Probability: optionalProbability(word.Probability),
+		})
+	}
+	return converted
+}
+
+// wordsBetween returns the words starting at or after startMS and before endMS
+func wordsBetween(words []Word, startMS, endMS int) []Word {
+	var between []Word
+	for _, word := range words {
+		if word.StartMS >= startMS && word.StartMS < endMS {
+			between = append(between, word)
+		}
+	}
+	return between
+}
+
+// tokenConfidence returns the mean probability of a segment's text tokens, skipping special
+// tokens such as [_BEG_] and [_TT_150]
+func tokenConfidence(tokens []whisperToken) float32 {
+	var sum float64
+	count := 0
+	for _, token := range tokens {
+		if isSpecialToken(token) {
+			continue
+		}
+		sum += token.P
+		count++
+	}
+	if count == 0 {
+		return defaultSegmentConfidence
+	}
+	return clampProbability(sum / float64(count))
+}
+
+// logprobConfidence converts a segment's average token log probability (verbose_json
+// avg_logprob) into a 0-1 confidence
+func logprobConfidence(avgLogprob *float64) float32 {
+	if avgLogprob == nil {
+		return defaultSegmentConfidence
+	}
+	return clampProbability(math.Exp(*avgLogprob))
+}
+
+// optionalProbability returns a reported probability, or 0 when the backend did not report it
+func optionalProbability(p *float64) float32 {
+	if p == nil {
+		return 0
+	}
+	return clampProbability(*p)
+}
+
+func clampProbability(p float64) float32 {
+	return float32(math.Max(0, math.Min(1, p)))
+}
@@ -0,0 +1,97 @@
+package transcriber
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenConfidence(t *testing.T) {
+	t.Run("should average text token probabilities ignoring special tokens", func(t *testing.T) {
+		tokens := []whisperToken{
+			{Text: "[_BEG_]", P: 0.1},
+			{Text: " Text", P: 0.9},
+			{Text: " WIN", P: 0.7},
+			{Text: "[_TT_150]", P: 0.2},
+		}
+
+		assert.InDelta(t, 0.8, tokenConfidence(tokens), 0.0001)
+	})
+
+	t.Run("should fall back to the default without tokens", func(t *testing.T) {
+		assert.Equal(t, float32(defaultSegmentConfidence), tokenConfidence(nil))
+		assert.Equal(t, float32(defaultSegmentConfidence), tokenConfidence([]whisperToken{{Text: "[_BEG_]", P: 1}}))
+	})
+}
+
+func TestTokenWords(t *testing.T) {
+	t.Run("should join tokens into timed words", func(t *testing.T) {
+		// Arrange
+		token := func(text string, p float64, from, to int) whisperToken {
+			tok := whisperToken{Text: text, P: p}
+			tok.Offsets.From, tok.Offsets.To = from, to
+			return tok
+		}
+		tokens := []whisperToken{
+			token("[_BEG_]", 1, 0, 0),
+			token(" Text", 0.9, 0, 300),
+			token(" WIN", 0.8, 300, 600),
+			token(" to", 0.9, 600, 700),
+			token(" 123", 0.6, 700, 1000),
+			token("45", 0.8, 1000, 1400),
+			token("[_TT_70]", 1, 1400, 1400),
+		}
+
+		// Act
+		words := tokenWords(tokens)
+
+		// Assert
+		require.Len(t, words, 4)
+		assert.Equal(t, Word{Text: "Text", StartMS: 0, EndMS: 300, Probability: 0.9}, words[0])
+		assert.Equal(t, "12345", words[3].Text)
+		assert.Equal(t, 700, words[3].StartMS)
+		assert.Equal(t, 1400, words[3].EndMS)
+		assert.InDelta(t, 0.7, words[3].Probability, 0.0001)
+	})
+
+	t.Run("should return no words without text tokens", func(t *testing.T) {
+		assert.Empty(t, tokenWords([]whisperToken{{Text: "[_BEG_]"}}))
+	})
+}
+
+func TestVerboseWords(t *testing.T) {
+	p := 0.75
+	words := verboseWords([]verboseWord{
+		{Word: " Text", Start: 1.2, End: 1.5, Probability: &p},
+		{Word: " ", Start: 1.5, End: 1.5},
+		{Word: " 55555", Start: 2.0, End: 2.8},
+	})
+
+	assert.Equal(t, []Word{
+		{Text: "Text", StartMS: 1200, EndMS: 1500, Probability: 0.75},
+		{Text: "55555", StartMS: 2000, EndMS: 2800},
+	}, words)
+}
+
+func TestLogprobConfidence(t *testing.T) {
+	t.Run("should convert average log probability to a probability", func(t *testing.T) {
+		zero, negative := 0.0, -0.6931
+
+		assert.InDelta(t, 1.0, logprobConfidence(&zero), 0.0001)
+		assert.InDelta(t, 0.5, logprobConfidence(&negative), 0.0001)
+	})
+
+	t.Run("should fall back to the default when not reported", func(t *testing.T) {
+		assert.Equal(t, float32(defaultSegmentConfidence), logprobConfidence(nil))
+	})
+}
+
+func TestOptionalProbability(t *testing.T) {
+	t.Run("should return zero when not reported and clamp reported values", func(t *testing.T) {
+		high := 1.2
+
+		assert.Equal(t, float32(0), optionalProbability(nil))
+		assert.Equal(t, float32(1), optionalProbability(&high))
+	})
+}
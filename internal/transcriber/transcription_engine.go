@@ -2,13 +2,18 @@ package transcriber
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
 
 	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/feature"
 	"radiocontestwinner/internal/performance"
 )
 
@@ -20,14 +25,30 @@ type WhisperModel interface {
 	GetGPUStatus() (bool, int)
 }
 
+// backendMonitor is implemented by models that can health check and fail over between transcription backends
+type backendMonitor interface {
+	MonitorBackends(ctx context.Context, interval time.Duration)
+	ActiveBackend() string
+}
+
 // TranscriptionEngine manages the Whisper.cpp model and processes audio streams
 type TranscriptionEngine struct {
 	logger             *zap.Logger
 	model              WhisperModel
 	config             *config.Configuration
 	performanceMonitor *performance.PerformanceMonitor
+	chunkTuner         *ChunkTuner  // Kept across ProcessAudio calls so tuning survives restarts
+	features           *feature.Set // Runtime feature flags; nil leaves every flag off
+	idle               *IdleMode    // Drops to sampling through long silences; nil always transcribes
+	paused             atomic.Bool  // While set, audio is read and discarded instead of transcribed
+	skippedChunks      atomic.Int64
+	loaded             atomic.Bool  // Set once a model is loaded, until the engine is closed
+	streamBytes        atomic.Int64 // Audio read so far, kept across ProcessAudio calls so segment times never restart at 0
 }
 
+// pcmBytesPerMS is the size of a millisecond of the 16kHz 16-bit mono audio being transcribed
+const pcmBytesPerMS = 16000 * 2 / 1000
+
 // NewTranscriptionEngine creates a new TranscriptionEngine instance
 func NewTranscriptionEngine(logger *zap.Logger) *TranscriptionEngine {
 	config := config.NewConfiguration()
@@ -70,15 +91,139 @@ func (te *TranscriptionEngine) LoadModel(modelPath string) error {
 	if err := te.model.LoadModel(modelPath); err != nil {
 		return fmt.Errorf("failed to load Whisper model from %s: %w", modelPath, err)
 	}
+	te.loaded.Store(true)
 
 	te.logger.Info("Whisper model loaded successfully", zap.String("path", modelPath))
 	return nil
 }
 
+// Name identifies the transcription engine among the application's components
+func (te *TranscriptionEngine) Name() string {
+	return "transcription"
+}
+
+// Start loads the configured Whisper model
+func (te *TranscriptionEngine) Start(ctx context.Context) error {
+	if te.config == nil {
+		return fmt.Errorf("transcription engine has no configuration")
+	}
+	return te.LoadModel(te.config.GetWhisperModelPath())
+}
+
+// Stop closes the Whisper model
+func (te *TranscriptionEngine) Stop(ctx context.Context) error {
+	return te.Close()
+}
+
+// Healthy returns an error until a Whisper model is loaded
+func (te *TranscriptionEngine) Healthy() error {
+	if !te.loaded.Load() {
+		return errors.New("whisper model not loaded")
+	}
+	return nil
+}
+
+// MonitorBackends health checks the model's transcription backend every interval, re-discovering
+// and failing over as needed, until ctx is cancelled. It returns immediately for models without backends.
+func (te *TranscriptionEngine) MonitorBackends(ctx context.Context, interval time.Duration) {
+	if monitor, ok := te.model.(backendMonitor); ok {
+		monitor.MonitorBackends(ctx, interval)
+	}
+}
+
+// ActiveBackend returns the model's active transcription backend, or "" if it has none
+func (te *TranscriptionEngine) ActiveBackend() string {
+	if monitor, ok := te.model.(backendMonitor); ok {
+		return monitor.ActiveBackend()
+	}
+	return ""
+}
+
+// WhisperBinary returns the whisper-cli binary the model runs, or "" when it runs none
+func (te *TranscriptionEngine) WhisperBinary() string {
+	if model, ok := te.model.(*WhisperCppModel); ok {
+		return model.WhisperBinary()
+	}
+	return ""
+}
+
+// SetUsageRecorder sets a function called with the seconds of audio each transcription backend
+// transcribes
+func (te *TranscriptionEngine) SetUsageRecorder(record func(backend string, seconds float64)) {
+	if model, ok := te.model.(*WhisperCppModel); ok {
+		model.SetUsageRecorder(record)
+	}
+}
+
+// SetFeatures sets the feature flags checked each time transcription starts
+func (te *TranscriptionEngine) SetFeatures(features *feature.Set) {
+	te.features = features
+}
+
+// SetIdleMode sets the idle mode that reduces transcription to sampling through long silences
+func (te *TranscriptionEngine) SetIdleMode(idle *IdleMode) {
+	te.idle = idle
+}
+
+// IdleMode returns the idle mode, or nil when it is disabled
+func (te *TranscriptionEngine) IdleMode() *IdleMode {
+	return te.idle
+}
+
+// SetDownloadProgressCallback sets a function called with progress while a missing model downloads
+func (te *TranscriptionEngine) SetDownloadProgressCallback(fn func(DownloadProgress)) {
+	if model, ok := te.model.(*WhisperCppModel); ok && model.modelDownloader != nil {
+		model.modelDownloader.SetProgressCallback(fn)
+	}
+}
+
+// ModelDownloadProgress returns the progress of the current or last model download, and false
+// if no model was downloaded
+func (te *TranscriptionEngine) ModelDownloadProgress() (DownloadProgress, bool) {
+	if model, ok := te.model.(*WhisperCppModel); ok && model.modelDownloader != nil {
+		return model.modelDownloader.Progress()
+	}
+	return DownloadProgress{}, false
+}
+
+// SetPaused pauses or resumes transcription. While paused, audio keeps being read so the stream
+// and decoder stay live without building a backlog, but chunks are discarded untranscribed.
+func (te *TranscriptionEngine) SetPaused(paused bool) {
+	te.paused.Store(paused)
+}
+
+// Paused reports whether transcription is paused
+func (te *TranscriptionEngine) Paused() bool {
+	return te.paused.Load()
+}
+
+// SkippedChunks returns how many audio chunks were discarded while paused
+func (te *TranscriptionEngine) SkippedChunks() int64 {
+	return te.skippedChunks.Load()
+}
+
+// ChunkDurationSec returns the chunk duration in use, which changes over time when auto-tuning is enabled
+func (te *TranscriptionEngine) ChunkDurationSec() int {
+	if te.chunkTuner != nil {
+		return te.chunkTuner.ChunkDurationSec()
+	}
+	return te.config.GetTranscriptionChunkDurationSec()
+}
+
 // ProcessAudio processes audio data from the reader and outputs transcription segments to a channel
 func (te *TranscriptionEngine) ProcessAudio(ctx context.Context, audioReader io.Reader) (<-chan TranscriptionSegment, error) {
 	te.logger.Info("starting audio processing for transcription")
 
+	if te.chunkTuner == nil && (te.config.GetTranscriptionAutoTuneEnabled() || te.features.Enabled(feature.AutoTune)) {
+		// Chunks must stay longer than the overlap carried between them
+		minSec := te.config.GetTranscriptionAutoTuneMinChunkSec()
+		if overlap := te.config.GetTranscriptionOverlapSec(); minSec <= overlap {
+			minSec = overlap + 1
+		}
+		te.chunkTuner = NewChunkTuner(te.config.GetTranscriptionChunkDurationSec(), minSec,
+			te.config.GetTranscriptionAutoTuneMaxChunkSec(), te.config.GetTranscriptionAutoTuneEvaluationChunks(), te.logger)
+	}
+
 	segmentChan := make(chan TranscriptionSegment)
 
 	go func() {
@@ -98,6 +243,10 @@ func (te *TranscriptionEngine) ProcessAudio(ctx context.Context, audioReader io.
 		// Use configurable chunk duration for more responsive transcription
 		chunkDurationSec := te.config.GetTranscriptionChunkDurationSec()
 		overlapSec := te.config.GetTranscriptionOverlapSec()
+		tuner := te.chunkTuner
+		if tuner != nil {
+			chunkDurationSec = tuner.ChunkDurationSec()
+		}
 		chunkSize := chunkDurationSec * 16000 * 2 // configurable seconds * 16kHz * 2 bytes per sample
 		overlapSize := overlapSec * 16000 * 2     // overlap size in bytes
 		stepSize := chunkSize - overlapSize       // step size without overlap
@@ -149,12 +298,13 @@ func (te *TranscriptionEngine) ProcessAudio(ctx context.Context, audioReader io.
 
 			// Read audio data with timeout
 			bytesRead, err := io.ReadFull(audioReader, readBuffer[:readSize])
+			te.streamBytes.Add(int64(bytesRead))
 			if err != nil {
 				if err == io.EOF || err == io.ErrUnexpectedEOF {
 					if bytesRead > 0 {
 						// Process the final partial chunk
 						totalBytes := overlapSize + bytesRead
-						if !firstChunk {
+						if !firstChunk && !te.Paused() {
 							segments := te.processAudioChunk(buffer[:totalBytes], chunkCount, segmentChan, ctx)
 							totalSegments += segments
 							chunkCount++
@@ -201,6 +351,14 @@ func (te *TranscriptionEngine) ProcessAudio(ctx context.Context, audioReader io.
 			// Save overlap for next iteration
 			copy(overlapBuffer, buffer[chunkSize-overlapSize:chunkSize])
 
+			if te.Paused() {
+				te.skippedChunks.Add(1)
+				continue
+			}
+			if te.idle != nil && !te.idle.ShouldTranscribe(time.Now(), buffer) {
+				continue
+			}
+
 			te.logger.Debug("processing audio chunk",
 				zap.Int("chunk_number", chunkCount),
 				zap.Int("bytes_read", bytesRead),
@@ -210,6 +368,17 @@ func (te *TranscriptionEngine) ProcessAudio(ctx context.Context, audioReader io.
 			segments := te.processAudioChunk(buffer, chunkCount, segmentChan, ctx)
 			totalSegments += segments
 
+			// Resize subsequent chunks when the tuner adjusts the duration; the overlap is unchanged
+			if tuner != nil {
+				latency := te.performanceMonitor.GetMetrics().LastProcessingTime
+				if newDurationSec, changed := tuner.Observe(latency, time.Duration(chunkDurationSec)*time.Second); changed {
+					chunkDurationSec = newDurationSec
+					chunkSize = chunkDurationSec * 16000 * 2
+					stepSize = chunkSize - overlapSize
+					buffer = make([]byte, chunkSize)
+				}
+			}
+
 			if chunkCount%10 == 0 {
 				te.logger.Info("audio processing progress",
 					zap.Int("chunks_processed", chunkCount),
@@ -221,8 +390,20 @@ func (te *TranscriptionEngine) ProcessAudio(ctx context.Context, audioReader io.
 	return segmentChan, nil
 }
 
+// newTraceID returns a random ID for an audio chunk, 16 hex digits
+func newTraceID() string {
+	var id [8]byte
+	rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
 // processAudioChunk processes a single chunk of audio data through Whisper
 func (te *TranscriptionEngine) processAudioChunk(audioData []byte, chunkNumber int, segmentChan chan<- TranscriptionSegment, ctx context.Context) int {
+	traceID := newTraceID()
+
+	// The chunk has just been fully read, so its audio began one chunk duration ago (16kHz, 16-bit mono)
+	captureStart := time.Now().UTC().Add(-time.Duration(len(audioData)) * time.Second / (16000 * 2))
+
 	// Get GPU status for performance monitoring
 	useGPU, deviceID := te.model.GetGPUStatus()
 
@@ -238,12 +419,36 @@ func (te *TranscriptionEngine) processAudioChunk(audioData []byte, chunkNumber i
 	if err != nil {
 		te.logger.Error("transcription failed for chunk",
 			zap.Error(err),
-			zap.Int("chunk_number", chunkNumber))
+			zap.Int("chunk_number", chunkNumber),
+			zap.String("trace_id", traceID))
 		return 0
 	}
 
+	if te.idle != nil {
+		te.idle.Observe(time.Now(), segments)
+	}
+
+	// Whisper times segments from the start of the chunk; rebase them onto the stream so
+	// contexts and cues from different chunks never share a time range
+	offsetMS := te.chunkOffsetMS(len(audioData))
+	transcribedAt := time.Now().UTC()
+	for i := range segments {
+		segments[i].CapturedAt = captureStart.Add(time.Duration(segments[i].StartMS) * time.Millisecond)
+		segments[i].TranscribedAt = transcribedAt
+		segments[i].TraceID = traceID
+		segments[i].StartMS += offsetMS
+		segments[i].EndMS += offsetMS
+		for j := range segments[i].Words {
+			word := &segments[i].Words[j]
+			word.CapturedAt = captureStart.Add(time.Duration(word.StartMS) * time.Millisecond)
+			word.StartMS += offsetMS
+			word.EndMS += offsetMS
+		}
+	}
+
 	te.logger.Debug("transcribed audio chunk",
 		zap.Int("chunk_number", chunkNumber),
+		zap.String("trace_id", traceID),
 		zap.Int("segments_found", len(segments)))
 
 	// Send segments to channel
@@ -266,14 +471,16 @@ func (te *TranscriptionEngine) processAudioChunk(audioData []byte, chunkNumber i
 						"end_ms":     segment.EndMS,
 						"confidence": segment.Confidence,
 					}),
-					zap.Int("chunk_number", chunkNumber))
+					zap.Int("chunk_number", chunkNumber),
+					zap.String("trace_id", traceID))
 
 				te.logger.Info("🎙️ TRANSCRIPTION COMPLETED",
 					zap.String("text", segment.Text),
 					zap.Int("start_ms", segment.StartMS),
 					zap.Int("end_ms", segment.EndMS),
 					zap.Float32("confidence", segment.Confidence),
-					zap.Int("chunk_number", chunkNumber))
+					zap.Int("chunk_number", chunkNumber),
+					zap.String("trace_id", traceID))
 			}
 		}
 	}
@@ -281,9 +488,26 @@ func (te *TranscriptionEngine) processAudioChunk(audioData []byte, chunkNumber i
 	return sentCount
 }
 
+// chunkOffsetMS returns the stream offset, in milliseconds, at which a chunk of chunkBytes that
+// ends with the audio read most recently begins. Overlapping chunks start before the previous
+// chunk ended, as their audio does.
+func (te *TranscriptionEngine) chunkOffsetMS(chunkBytes int) int {
+	start := te.streamBytes.Load() - int64(chunkBytes)
+	if start < 0 {
+		return 0
+	}
+	return int(start / pcmBytesPerMS)
+}
+
+// StreamOffsetMS returns how much audio, in milliseconds, has been read from the stream
+func (te *TranscriptionEngine) StreamOffsetMS() int64 {
+	return te.streamBytes.Load() / pcmBytesPerMS
+}
+
 // Close cleans up resources and closes the Whisper model
 func (te *TranscriptionEngine) Close() error {
 	te.logger.Info("closing transcription engine")
+	te.loaded.Store(false)
 
 	if te.model != nil {
 		if err := te.model.Close(); err != nil {
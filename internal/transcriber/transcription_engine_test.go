@@ -140,6 +140,40 @@ func TestTranscriptionEngine_ProcessAudio(t *testing.T) {
 		assert.Equal(t, expectedSegments, receivedSegments)
 	})
 
+	t.Run("should stamp segments with capture and transcription times", func(t *testing.T) {
+		// Arrange
+		engine := NewTranscriptionEngine(zaptest.NewLogger(t))
+		engine.model = &MockWhisperModel{
+			segments: []TranscriptionSegment{
+				{Text: "Text POTA", StartMS: 0, EndMS: 1000, Confidence: 0.9},
+				{Text: "to 1234", StartMS: 1000, EndMS: 2000, Confidence: 0.9},
+			},
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		before := time.Now()
+
+		// Act
+		segmentChan, err := engine.ProcessAudio(ctx, strings.NewReader("fake audio data"))
+		assert.NoError(t, err)
+
+		var receivedSegments []TranscriptionSegment
+		for segment := range segmentChan {
+			receivedSegments = append(receivedSegments, segment)
+		}
+
+		// Assert
+		assert.Len(t, receivedSegments, 2)
+		for _, segment := range receivedSegments {
+			assert.False(t, segment.CapturedAt.IsZero(), "should set CapturedAt")
+			assert.False(t, segment.TranscribedAt.Before(before), "should set TranscribedAt")
+		}
+		assert.Equal(t, time.Second, receivedSegments[1].CapturedAt.Sub(receivedSegments[0].CapturedAt),
+			"should offset capture time by segment start")
+		assert.Len(t, receivedSegments[0].TraceID, 16, "should tag segments with their chunk's trace ID")
+		assert.Equal(t, receivedSegments[0].TraceID, receivedSegments[1].TraceID)
+	})
+
 	t.Run("should handle transcription errors gracefully", func(t *testing.T) {
 		// Arrange
 		logger := zaptest.NewLogger(t)
@@ -223,6 +257,60 @@ func TestTranscriptionEngine_ProcessAudio(t *testing.T) {
 	})
 }
 
+func TestTranscriptionEngine_Paused(t *testing.T) {
+	t.Run("should discard audio without transcribing while paused", func(t *testing.T) {
+		// Arrange
+		engine := NewTranscriptionEngine(zaptest.NewLogger(t))
+		engine.model = &MockWhisperModel{segments: []TranscriptionSegment{{Text: "Hello world"}}}
+		engine.SetPaused(true)
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		// Act
+		segmentChan, err := engine.ProcessAudio(ctx, strings.NewReader("fake audio data"))
+
+		// Assert
+		assert.NoError(t, err)
+		var received []TranscriptionSegment
+		for segment := range segmentChan {
+			received = append(received, segment)
+		}
+		assert.Empty(t, received)
+		assert.True(t, engine.Paused())
+	})
+}
+
+func TestTranscriptionEngine_StreamOffsets(t *testing.T) {
+	t.Run("should rebase segment times onto the stream so chunks never collide", func(t *testing.T) {
+		// Arrange - a 10s chunk followed by one overlapping it by 2s
+		engine := NewTranscriptionEngine(zaptest.NewLogger(t))
+		engine.model = &MockWhisperModel{segments: []TranscriptionSegment{{
+			Text:    "Text CASH to 72881",
+			StartMS: 1000,
+			EndMS:   4000,
+			Words:   []Word{{Text: "CASH", StartMS: 1500, EndMS: 2000}},
+		}}}
+		chunk := make([]byte, 10*16000*2)
+		segmentChan := make(chan TranscriptionSegment, 2)
+
+		// Act
+		engine.streamBytes.Add(int64(len(chunk)))
+		engine.processAudioChunk(chunk, 0, segmentChan, context.Background())
+		engine.streamBytes.Add(8 * 16000 * 2)
+		engine.processAudioChunk(chunk, 1, segmentChan, context.Background())
+
+		// Assert
+		first, second := <-segmentChan, <-segmentChan
+		assert.Equal(t, 1000, first.StartMS)
+		assert.Equal(t, 4000, first.EndMS)
+		assert.Equal(t, 9000, second.StartMS)
+		assert.Equal(t, 12000, second.EndMS)
+		assert.Equal(t, 9500, second.Words[0].StartMS)
+		assert.Equal(t, 10000, second.Words[0].EndMS)
+		assert.Equal(t, int64(18000), engine.StreamOffsetMS())
+	})
+}
+
 func TestTranscriptionEngine_Close(t *testing.T) {
 	t.Run("should close engine and cleanup resources", func(t *testing.T) {
 		// Arrange
@@ -1,6 +1,9 @@
 package transcriber
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // TranscriptionSegment represents a single, raw transcribed segment of audio as output by the Whisper.cpp model
 type TranscriptionSegment struct {
@@ -8,6 +11,37 @@ type TranscriptionSegment struct {
 	StartMS    int     `json:"start_ms"`
 	EndMS      int     `json:"end_ms"`
 	Confidence float32 `json:"confidence"`
+
+	// Speech probabilities reported by the backend; zero when not reported
+	NoSpeechProb float32 `json:"no_speech_prob,omitempty"` // Probability the segment's audio contains no speech
+	Language     string  `json:"language,omitempty"`       // Language the backend transcribed in, e.g. "en"
+	LanguageProb float32 `json:"language_prob,omitempty"`  // Probability of the detected language
+
+	// Text as spoken, when Text is its English translation; empty when not translating
+	OriginalText string `json:"original_text,omitempty"`
+
+	// Words of Text with their own timing, when the backend reports word-level timestamps
+	Words []Word `json:"words,omitempty"`
+
+	// ID of the audio chunk the segment was transcribed from, carried on to the contexts and cues
+	// built from it and logged at each stage so one chunk can be followed through the pipeline
+	TraceID string `json:"trace_id,omitempty"`
+
+	// Pipeline timing, used to report how stale a resulting cue is
+	CapturedAt    time.Time `json:"captured_at,omitzero"`    // Approximate wall-clock time the segment's audio was captured
+	TranscribedAt time.Time `json:"transcribed_at,omitzero"` // When transcription of the segment's chunk completed
+
+	// Configured metadata of the station the audio came from, e.g. call_letters and market
+	Station map[string]string `json:"station,omitempty"`
+}
+
+// Word is one word of a segment's text. StartMS and EndMS share the segment's time base.
+type Word struct {
+	Text        string    `json:"text"`
+	StartMS     int       `json:"start_ms"`
+	EndMS       int       `json:"end_ms"`
+	Probability float32   `json:"probability,omitempty"` // Zero when not reported
+	CapturedAt  time.Time `json:"captured_at,omitzero"`  // Approximate wall-clock time the word was spoken
 }
 
 // Validate checks if the TranscriptionSegment has valid values
@@ -28,5 +62,13 @@ func (ts *TranscriptionSegment) Validate() error {
 		return fmt.Errorf("confidence must be between 0.0 and 1.0")
 	}
 
+	if ts.NoSpeechProb < 0.0 || ts.NoSpeechProb > 1.0 {
+		return fmt.Errorf("no_speech_prob must be between 0.0 and 1.0")
+	}
+
+	if ts.LanguageProb < 0.0 || ts.LanguageProb > 1.0 {
+		return fmt.Errorf("language_prob must be between 0.0 and 1.0")
+	}
+
 	return nil
 }
@@ -133,3 +133,23 @@ func TestTranscriptionSegment_Validation(t *testing.T) {
 		})
 	}
 }
+
+func TestTranscriptionSegment_SpeechProbabilities(t *testing.T) {
+	t.Run("should reject out of range speech probabilities", func(t *testing.T) {
+		noSpeech := TranscriptionSegment{Text: "Hi", StartMS: 0, EndMS: 1000, Confidence: 0.9, NoSpeechProb: 1.5}
+		language := TranscriptionSegment{Text: "Hi", StartMS: 0, EndMS: 1000, Confidence: 0.9, LanguageProb: -0.1}
+
+		assert.Error(t, noSpeech.Validate())
+		assert.Error(t, language.Validate())
+	})
+
+	t.Run("should omit unreported probabilities from JSON", func(t *testing.T) {
+		segment := TranscriptionSegment{Text: "Hi", StartMS: 0, EndMS: 1000, Confidence: 0.9}
+
+		data, err := json.Marshal(segment)
+
+		assert.NoError(t, err)
+		assert.NotContains(t, string(data), "no_speech_prob")
+		assert.NotContains(t, string(data), "language")
+	})
+}
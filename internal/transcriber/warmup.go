@@ -0,0 +1,146 @@
+package transcriber
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// warmupSilenceSec is the length of the built-in warm-up clip
+const warmupSilenceSec = 2
+
+// WarmupClip is audio transcribed once after LoadModel to prove the backend works. Expect, when
+// set, is a phrase the transcription must contain.
+type WarmupClip struct {
+	Audio  []byte // 16 kHz 16-bit mono PCM
+	Expect string
+}
+
+// SilenceWarmupClip returns the built-in clip: a couple of seconds of silence, which exercises
+// the whole backend (binary start-up, model and GPU kernel loading) without expecting any text
+func SilenceWarmupClip() WarmupClip {
+	return WarmupClip{Audio: make([]byte, warmupSilenceSec*16000*2)}
+}
+
+// LoadWarmupClip reads a warm-up clip from a 16 kHz 16-bit mono WAV file, or a file of raw PCM
+// in that format, that should transcribe to a text containing expect
+func LoadWarmupClip(path, expect string) (WarmupClip, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return WarmupClip{}, fmt.Errorf("failed to read warm-up clip: %w", err)
+	}
+	if bytes.HasPrefix(data, []byte("RIFF")) {
+		if data, err = wavPCM(data); err != nil {
+			return WarmupClip{}, fmt.Errorf("invalid warm-up clip %s: %w", path, err)
+		}
+	}
+	if len(data) == 0 {
+		return WarmupClip{}, fmt.Errorf("warm-up clip %s contains no audio", path)
+	}
+	return WarmupClip{Audio: data, Expect: expect}, nil
+}
+
+// wavPCM returns the samples of a WAV file, which must be 16 kHz 16-bit mono PCM
+func wavPCM(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[8:12]) != "WAVE" {
+		return nil, fmt.Errorf("not a WAVE file")
+	}
+	formatChecked := false
+	for offset := 12; offset+8 <= len(data); {
+		id := string(data[offset : offset+4])
+		size := int(binary.LittleEndian.Uint32(data[offset+4 : offset+8]))
+		body := offset + 8
+		if body+size > len(data) {
+			size = len(data) - body
+		}
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return nil, fmt.Errorf("truncated fmt chunk")
+			}
+			audioFormat := binary.LittleEndian.Uint16(data[body:])
+			channels := binary.LittleEndian.Uint16(data[body+2:])
+			sampleRate := binary.LittleEndian.Uint32(data[body+4:])
+			bits := binary.LittleEndian.Uint16(data[body+14:])
+			if audioFormat != 1 || channels != 1 || sampleRate != 16000 || bits != 16 {
+				return nil, fmt.Errorf("audio must be 16 kHz 16-bit mono PCM, got format %d, %d channels, %d Hz, %d bits",
+					audioFormat, channels, sampleRate, bits)
+			}
+			formatChecked = true
+		case "data":
+			if !formatChecked {
+				return nil, fmt.Errorf("data chunk before fmt chunk")
+			}
+			return data[body : body+size], nil
+		}
+		offset = body + size + size%2 // Chunks are word aligned
+	}
+	return nil, fmt.Errorf("no data chunk")
+}
+
+// WarmUp transcribes clip to verify the loaded model's backend actually works, so a broken
+// binary, missing GPU libraries, or an unusable model fail at startup rather than on the first
+// live chunk. It returns how long the transcription took.
+func (te *TranscriptionEngine) WarmUp(ctx context.Context, clip WarmupClip, timeout time.Duration) (time.Duration, error) {
+	if te.model == nil {
+		return 0, fmt.Errorf("whisper model not initialized")
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	type result struct {
+		segments []TranscriptionSegment
+		err      error
+	}
+	done := make(chan result, 1)
+	start := time.Now()
+	go func() {
+		segments, err := te.model.Transcribe(clip.Audio)
+		done <- result{segments, err}
+	}()
+
+	var r result
+	select {
+	case <-ctx.Done():
+		return time.Since(start), fmt.Errorf("warm-up transcription did not finish: %w", ctx.Err())
+	case r = <-done:
+	}
+	elapsed := time.Since(start)
+	if r.err != nil {
+		return elapsed, fmt.Errorf("warm-up transcription failed: %w", r.err)
+	}
+
+	var text strings.Builder
+	for _, segment := range r.segments {
+		text.WriteString(segment.Text)
+		text.WriteString(" ")
+	}
+	transcript := strings.TrimSpace(text.String())
+	if clip.Expect != "" && !strings.Contains(normalizeWarmupText(transcript), normalizeWarmupText(clip.Expect)) {
+		return elapsed, fmt.Errorf("warm-up transcription %q does not contain %q", transcript, clip.Expect)
+	}
+
+	te.logger.Info("transcription backend warmed up",
+		zap.String("backend", te.ActiveBackend()),
+		zap.Duration("elapsed", elapsed),
+		zap.String("text", transcript))
+	return elapsed, nil
+}
+
+// normalizeWarmupText lowercases text and reduces it to words, so punctuation and spacing in
+// the transcription don't matter
+func normalizeWarmupText(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '\'')
+	})
+	return " " + strings.Join(words, " ") + " "
+}
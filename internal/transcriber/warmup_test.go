@@ -0,0 +1,112 @@
+package transcriber
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// blockingWhisperModel never finishes transcribing until released
+type blockingWhisperModel struct {
+	MockWhisperModel
+	release chan struct{}
+}
+
+func (m *blockingWhisperModel) Transcribe(audioData []byte) ([]TranscriptionSegment, error) {
+	<-m.release
+	return nil, nil
+}
+
+func TestTranscriptionEngine_WarmUp(t *testing.T) {
+	t.Run("should succeed when the backend transcribes the silence clip", func(t *testing.T) {
+		// Arrange
+		engine := NewTranscriptionEngine(zaptest.NewLogger(t))
+		engine.model = &MockWhisperModel{}
+
+		// Act
+		_, err := engine.WarmUp(context.Background(), SilenceWarmupClip(), time.Second)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail when the backend cannot transcribe", func(t *testing.T) {
+		engine := NewTranscriptionEngine(zaptest.NewLogger(t))
+		engine.model = &MockWhisperModel{transcribeError: errors.New("libcublas.so.12: cannot open shared object file")}
+
+		_, err := engine.WarmUp(context.Background(), SilenceWarmupClip(), time.Second)
+
+		assert.ErrorContains(t, err, "libcublas")
+	})
+
+	t.Run("should fail when the backend does not finish in time", func(t *testing.T) {
+		engine := NewTranscriptionEngine(zaptest.NewLogger(t))
+		model := &blockingWhisperModel{release: make(chan struct{})}
+		defer close(model.release)
+		engine.model = model
+
+		_, err := engine.WarmUp(context.Background(), SilenceWarmupClip(), 50*time.Millisecond)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("should check the transcription contains the expected phrase", func(t *testing.T) {
+		engine := NewTranscriptionEngine(zaptest.NewLogger(t))
+		engine.model = &MockWhisperModel{segments: []TranscriptionSegment{{Text: "Text WIN,"}, {Text: "to 12345."}}}
+
+		_, err := engine.WarmUp(context.Background(), WarmupClip{Audio: []byte{0, 0}, Expect: "text win to 12345"}, time.Second)
+		assert.NoError(t, err)
+
+		_, err = engine.WarmUp(context.Background(), WarmupClip{Audio: []byte{0, 0}, Expect: "text cash"}, time.Second)
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadWarmupClip(t *testing.T) {
+	t.Run("should read the samples of a 16 kHz mono WAV file", func(t *testing.T) {
+		// Arrange
+		samples := []byte{1, 0, 2, 0, 3, 0, 4, 0}
+		path := filepath.Join(t.TempDir(), "clip.wav")
+		wav := append(NewWhisperCppModel(zaptest.NewLogger(t)).createWAVHeader(len(samples)), samples...)
+		require.NoError(t, os.WriteFile(path, wav, 0644))
+
+		// Act
+		clip, err := LoadWarmupClip(path, "hello")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, samples, clip.Audio)
+		assert.Equal(t, "hello", clip.Expect)
+	})
+
+	t.Run("should reject WAV files in another format", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "clip.wav")
+		wav := NewWhisperCppModel(zaptest.NewLogger(t)).createWAVHeader(4)
+		wav[24] = 0x44 // 44.1 kHz
+		wav[25] = 0xAC
+		require.NoError(t, os.WriteFile(path, append(wav, 0, 0, 0, 0), 0644))
+
+		_, err := LoadWarmupClip(path, "")
+
+		assert.ErrorContains(t, err, "16 kHz")
+	})
+
+	t.Run("should accept raw PCM and reject missing files", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "clip.pcm")
+		require.NoError(t, os.WriteFile(path, []byte{0, 0, 0, 0}, 0644))
+
+		clip, err := LoadWarmupClip(path, "")
+		assert.NoError(t, err)
+		assert.Len(t, clip.Audio, 4)
+
+		_, err = LoadWarmupClip(filepath.Join(t.TempDir(), "missing.wav"), "")
+		assert.Error(t, err)
+	})
+}
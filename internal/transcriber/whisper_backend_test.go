@@ -0,0 +1,705 @@
+package transcriber
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/usage"
+)
+
+// whisperService serves /health and a fixed /transcribe response
+func whisperService(t *testing.T, text string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			w.WriteHeader(http.StatusOK)
+		case "/transcribe":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{"text": text})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newServiceModel creates a model loaded on the service backend with the given candidate endpoints
+func newServiceModel(t *testing.T, active string, candidates ...string) *WhisperCppModel {
+	t.Helper()
+	cfg := config.NewConfiguration()
+	cfg.SetWhisperServiceEndpoints(candidates)
+
+	model := NewWhisperCppModelWithConfig(zaptest.NewLogger(t), cfg)
+	model.apiEndpoint = active
+	model.backend = BackendService
+	model.isLoaded = true
+	return model
+}
+
+func TestWhisperCppModel_CheckBackends(t *testing.T) {
+	t.Run("should re-discover another candidate endpoint when the service goes away", func(t *testing.T) {
+		// Arrange
+		primary := whisperService(t, "primary")
+		secondary := whisperService(t, "secondary")
+		model := newServiceModel(t, primary.URL, primary.URL, secondary.URL)
+		primary.Close()
+
+		// Act
+		model.CheckBackends()
+
+		// Assert
+		assert.Equal(t, BackendService, model.ActiveBackend())
+		assert.Equal(t, secondary.URL, model.apiEndpoint)
+	})
+
+	t.Run("should fail over to the API when no service endpoint is healthy", func(t *testing.T) {
+		// Arrange
+		t.Setenv("OPENAI_API_KEY", "sk-test")
+		service := whisperService(t, "unused")
+		model := newServiceModel(t, service.URL, service.URL)
+		service.Close()
+
+		// Act
+		model.CheckBackends()
+
+		// Assert
+		assert.Equal(t, BackendAPI, model.ActiveBackend())
+	})
+
+	t.Run("should not fail over to the API without an API key", func(t *testing.T) {
+		// Arrange
+		t.Setenv("OPENAI_API_KEY", "")
+		service := whisperService(t, "unused")
+		model := newServiceModel(t, service.URL, service.URL)
+		service.Close()
+
+		// Act
+		model.CheckBackends()
+
+		// Assert
+		assert.Equal(t, BackendService, model.ActiveBackend())
+		assert.False(t, model.backendHealthy(BackendAPI))
+		assert.Error(t, model.activate(BackendAPI))
+	})
+
+	t.Run("should switch back to the service once it becomes available", func(t *testing.T) {
+		// Arrange
+		t.Setenv("OPENAI_API_KEY", "sk-test")
+		service := whisperService(t, "recovered")
+		model := newServiceModel(t, "", service.URL)
+		model.backend = BackendAPI
+
+		// Act
+		model.CheckBackends()
+
+		// Assert
+		assert.Equal(t, BackendService, model.ActiveBackend())
+		assert.Equal(t, service.URL, model.apiEndpoint)
+	})
+
+	t.Run("should keep a healthy service endpoint", func(t *testing.T) {
+		service := whisperService(t, "healthy")
+		other := whisperService(t, "other")
+		model := newServiceModel(t, service.URL, other.URL, service.URL)
+
+		model.CheckBackends()
+
+		assert.Equal(t, BackendService, model.ActiveBackend())
+		assert.Equal(t, service.URL, model.apiEndpoint)
+	})
+}
+
+func TestWhisperCppModel_TranscribeFailover(t *testing.T) {
+	t.Run("should re-discover the service and retry when a request fails", func(t *testing.T) {
+		// Arrange
+		dead := whisperService(t, "dead")
+		alive := whisperService(t, "from the new endpoint")
+		model := newServiceModel(t, dead.URL, dead.URL, alive.URL)
+		dead.Close()
+
+		// Act
+		segments, err := model.Transcribe([]byte("test audio data"))
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+		assert.Equal(t, "from the new endpoint", segments[0].Text)
+		assert.Equal(t, alive.URL, model.apiEndpoint)
+	})
+
+	t.Run("should return service errors without failing over while the service is healthy", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/health" {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+		model := newServiceModel(t, server.URL, server.URL)
+
+		// Act
+		_, err := model.Transcribe([]byte("test audio data"))
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, BackendService, model.ActiveBackend())
+	})
+}
+
+func TestWhisperCppModel_UsageAccounting(t *testing.T) {
+	t.Run("should record the seconds of audio the service transcribes", func(t *testing.T) {
+		// Arrange
+		service := whisperService(t, "hello")
+		model := newServiceModel(t, service.URL, service.URL)
+		recorded := map[string]float64{}
+		model.SetUsageRecorder(func(backend string, seconds float64) { recorded[backend] += seconds })
+
+		// Act
+		_, err := model.Transcribe(make([]byte, 64000))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, map[string]float64{usage.BackendService: 2}, recorded)
+	})
+
+	t.Run("should not record failed transcriptions", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/health" {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+		model := newServiceModel(t, server.URL, server.URL)
+		recorded := map[string]float64{}
+		model.SetUsageRecorder(func(backend string, seconds float64) { recorded[backend] += seconds })
+
+		// Act
+		_, err := model.Transcribe(make([]byte, 32000))
+
+		// Assert
+		assert.Error(t, err)
+		assert.Empty(t, recorded)
+	})
+
+	t.Run("should not record mock transcriptions from the API backend without a key", func(t *testing.T) {
+		// Arrange
+		model := NewWhisperCppModelWithConfig(zaptest.NewLogger(t), config.NewConfiguration())
+		model.backend = BackendAPI
+		model.apiKey = ""
+		model.isLoaded = true
+		recorded := map[string]float64{}
+		model.SetUsageRecorder(func(backend string, seconds float64) { recorded[backend] += seconds })
+
+		// Act
+		_, err := model.Transcribe(make([]byte, 32000))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, recorded)
+	})
+
+	t.Run("should split the binary backend by GPU use", func(t *testing.T) {
+		model := NewWhisperCppModelWithConfig(zaptest.NewLogger(t), config.NewConfiguration())
+		recorded := map[string]float64{}
+		model.SetUsageRecorder(func(backend string, seconds float64) { recorded[backend] += seconds })
+
+		model.useGPU = true
+		model.accountUsage(BackendBinary, make([]byte, 32000))
+		model.useGPU = false
+		model.accountUsage(BackendBinary, make([]byte, 16000))
+
+		assert.Equal(t, map[string]float64{usage.BackendBinaryGPU: 1, usage.BackendBinaryCPU: 0.5}, recorded)
+	})
+}
+
+func TestWhisperCppModel_MonitorBackends(t *testing.T) {
+	t.Run("should stop when the context is cancelled", func(t *testing.T) {
+		model := newServiceModel(t, "")
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+
+		go func() {
+			model.MonitorBackends(ctx, 10*time.Millisecond)
+			close(done)
+		}()
+		cancel()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("MonitorBackends did not return after cancellation")
+		}
+	})
+}
+
+func TestTranscriptionEngine_ActiveBackend(t *testing.T) {
+	t.Run("should report the model's backend", func(t *testing.T) {
+		engine := NewTranscriptionEngine(zaptest.NewLogger(t))
+		model := engine.model.(*WhisperCppModel)
+		model.backend = BackendService
+
+		assert.Equal(t, BackendService, engine.ActiveBackend())
+	})
+
+	t.Run("should report no backend for models without one", func(t *testing.T) {
+		engine := &TranscriptionEngine{}
+
+		assert.Equal(t, "", engine.ActiveBackend())
+	})
+}
+
+func TestWhisperCppModel_LoadModelBackendPriority(t *testing.T) {
+	t.Run("should prefer the service when it is listed first", func(t *testing.T) {
+		// Arrange
+		service := whisperService(t, "central gpu")
+		cfg := config.NewConfiguration()
+		cfg.SetWhisperServiceEndpoints([]string{service.URL})
+		cfg.SetWhisperBackendPriority([]string{"service", "binary", "api"})
+		model := NewWhisperCppModelWithConfig(zaptest.NewLogger(t), cfg)
+
+		// Act
+		err := model.LoadModel("/nonexistent/ggml-base.en.bin")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, BackendService, model.ActiveBackend())
+		assert.Equal(t, service.URL, model.apiEndpoint)
+	})
+
+	t.Run("should fail when no listed backend is available", func(t *testing.T) {
+		// Arrange
+		service := whisperService(t, "unused")
+		cfg := config.NewConfiguration()
+		cfg.SetWhisperServiceEndpoints([]string{service.URL})
+		cfg.SetWhisperBackendPriority([]string{"service"})
+		model := NewWhisperCppModelWithConfig(zaptest.NewLogger(t), cfg)
+		service.Close()
+
+		// Act
+		err := model.LoadModel("/nonexistent/ggml-base.en.bin")
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no transcription backend available")
+	})
+
+	t.Run("should reject unknown and duplicate backends", func(t *testing.T) {
+		for _, priority := range [][]string{{"service", "gpu"}, {"api", "api"}} {
+			cfg := config.NewConfiguration()
+			cfg.SetWhisperBackendPriority(priority)
+			model := NewWhisperCppModelWithConfig(zaptest.NewLogger(t), cfg)
+
+			err := model.LoadModel("/nonexistent/ggml-base.en.bin")
+
+			assert.Error(t, err, priority)
+		}
+	})
+
+	t.Run("should fail over in the configured order", func(t *testing.T) {
+		// Arrange
+		t.Setenv("OPENAI_API_KEY", "sk-test")
+		service := whisperService(t, "unused")
+		model := newServiceModel(t, service.URL, service.URL)
+		model.priority = []string{BackendService, BackendAPI, BackendBinary}
+		service.Close()
+
+		// Act
+		next, err := model.failover(BackendService)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, BackendAPI, next)
+	})
+}
+
+func TestWhisperCppModel_TranscriptionRetry(t *testing.T) {
+	t.Run("should retry a transcription that fails with a server error", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls == 1 {
+				http.Error(w, "warming up", http.StatusServiceUnavailable)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"text": "after retry"})
+		}))
+		defer server.Close()
+		model := newServiceModel(t, server.URL)
+		model.retry.BaseDelay = time.Millisecond
+
+		// Act
+		segments, err := model.transcribeWithService([]byte("test audio data"), false)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+		assert.Equal(t, "after retry", segments[0].Text)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("should not retry a rejected request", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			http.Error(w, "unsupported audio", http.StatusUnprocessableEntity)
+		}))
+		defer server.Close()
+		model := newServiceModel(t, server.URL)
+		model.retry.BaseDelay = time.Millisecond
+
+		// Act
+		_, err := model.transcribeWithService([]byte("test audio data"), false)
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "transcription service error 422")
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestWhisperCppModel_OfflineMode(t *testing.T) {
+	t.Run("should neither probe the service nor fall back to the API", func(t *testing.T) {
+		if _, ok := FindWhisperBinary(); ok {
+			t.Skip("whisper.cpp binary installed; the binary backend would load")
+		}
+		// Arrange
+		probes := 0
+		service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			probes++
+		}))
+		defer service.Close()
+		cfg := config.NewConfiguration()
+		cfg.SetOfflineMode(true)
+		cfg.SetWhisperServiceEndpoints([]string{service.URL})
+		model := NewWhisperCppModelWithConfig(zaptest.NewLogger(t), cfg)
+
+		// Act
+		err := model.LoadModel("/nonexistent/ggml-base.en.bin")
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "offline_mode requires the whisper.cpp binary")
+		assert.Zero(t, probes)
+	})
+
+	t.Run("should reject a backend priority without the binary", func(t *testing.T) {
+		cfg := config.NewConfiguration()
+		cfg.SetOfflineMode(true)
+		cfg.SetWhisperBackendPriority([]string{"service", "api"})
+		model := NewWhisperCppModelWithConfig(zaptest.NewLogger(t), cfg)
+
+		err := model.LoadModel("/nonexistent/ggml-base.en.bin")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "offline_mode allows only the binary transcription backend")
+	})
+}
+
+func TestWhisperCppModel_TranslateMode(t *testing.T) {
+	t.Run("should keep the spoken text alongside the English translation", func(t *testing.T) {
+		// Arrange
+		var tasks []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			task := r.URL.Query().Get("task")
+			tasks = append(tasks, task)
+			text := "Envía FIESTA al 12345"
+			if task == "translate" {
+				text = "Text FIESTA to 12345"
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"language": "es",
+				"segments": []map[string]interface{}{{"text": text, "start": 0.0, "end": 2.0}},
+			})
+		}))
+		t.Cleanup(server.Close)
+		model := newServiceModel(t, server.URL)
+		model.config.SetTranscriptionTranslate(true)
+
+		// Act
+		segments, err := model.transcribeWith(BackendService, make([]byte, 64000))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"", "translate"}, tasks)
+		require.Len(t, segments, 1)
+		assert.Equal(t, "Text FIESTA to 12345", segments[0].Text)
+		assert.Equal(t, "Envía FIESTA al 12345", segments[0].OriginalText)
+		assert.Equal(t, "es", segments[0].Language)
+	})
+}
+
+func TestAttachOriginalText(t *testing.T) {
+	t.Run("should assign each original segment to the translation it is centred in", func(t *testing.T) {
+		// Arrange
+		translated := []TranscriptionSegment{
+			{Text: "Good morning", StartMS: 0, EndMS: 2000},
+			{Text: "Text RADIO to 12345", StartMS: 2000, EndMS: 5000},
+		}
+		original := []TranscriptionSegment{
+			{Text: "Buenos", StartMS: 0, EndMS: 800, Language: "es"},
+			{Text: "días", StartMS: 800, EndMS: 1900, Language: "es"},
+			{Text: "Envía RADIO al 12345", StartMS: 1900, EndMS: 5200, Language: "es"},
+		}
+
+		// Act
+		segments := attachOriginalText(translated, original)
+
+		// Assert
+		assert.Equal(t, "Buenos días", segments[0].OriginalText)
+		assert.Equal(t, "Envía RADIO al 12345", segments[1].OriginalText)
+		assert.Equal(t, "es", segments[1].Language)
+	})
+
+	t.Run("should give the last translation original text centred past its end", func(t *testing.T) {
+		// Arrange
+		translated := []TranscriptionSegment{{Text: "Hello", StartMS: 0, EndMS: 1000}}
+		original := []TranscriptionSegment{{Text: "Hola", StartMS: 900, EndMS: 1500}}
+
+		// Act
+		segments := attachOriginalText(translated, original)
+
+		// Assert
+		assert.Equal(t, "Hola", segments[0].OriginalText)
+	})
+}
+
+func TestWhisperCppModel_ServiceWordTimestamps(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("word_timestamps"))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"segments": []map[string]interface{}{{
+				"text":  " Text WIN to 12345",
+				"start": 1.0,
+				"end":   3.0,
+				"words": []map[string]interface{}{
+					{"word": " Text", "start": 1.0, "end": 1.3, "probability": 0.9},
+					{"word": " WIN", "start": 1.3, "end": 1.7, "probability": 0.8},
+					{"word": " to", "start": 1.7, "end": 1.9, "probability": 0.9},
+					{"word": " 12345", "start": 2.1, "end": 3.0, "probability": 0.7},
+				},
+			}},
+		})
+	}))
+	t.Cleanup(server.Close)
+	model := newServiceModel(t, server.URL)
+
+	// Act
+	segments, err := model.transcribeWithService(make([]byte, 32000), false)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, segments, 1)
+	require.Len(t, segments[0].Words, 4)
+	assert.Equal(t, "12345", segments[0].Words[3].Text)
+	assert.Equal(t, 2100, segments[0].Words[3].StartMS)
+}
+
+func TestWhisperCppModel_ServiceUpload(t *testing.T) {
+	t.Run("should upload a WAV file with auth headers to the configured path", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/asr", r.URL.Path)
+			assert.Equal(t, "json", r.URL.Query().Get("output"))
+			assert.Equal(t, "Bearer s3cret", r.Header.Get("Authorization"))
+			assert.Equal(t, "proxy-key", r.Header.Get("X-Api-Key"))
+			file, header, err := r.FormFile("audio_file")
+			if assert.NoError(t, err) {
+				defer file.Close()
+				assert.Equal(t, "audio.wav", header.Filename)
+				assert.Equal(t, int64(44+3200), header.Size)
+			}
+			assert.Equal(t, "verbose_json", r.FormValue("response_format"))
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]string{"text": "Text WIN to 12345"})
+		}))
+		t.Cleanup(server.Close)
+		model := newServiceModel(t, server.URL)
+		model.config.SetWhisperServicePath("asr")
+		model.config.SetWhisperServiceUpload("multipart")
+		model.config.SetWhisperServiceFileField("audio_file")
+		model.config.SetWhisperServiceAPIKey("s3cret")
+		model.config.SetWhisperServiceHeaders(map[string]string{"x-api-key": "proxy-key"})
+
+		// Act
+		segments, err := model.transcribeWithService(make([]byte, 3200), false)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+		assert.Equal(t, "Text WIN to 12345", segments[0].Text)
+	})
+
+	t.Run("should send auth headers with health checks", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/ready" || r.Header.Get("Authorization") != "Bearer s3cret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(server.Close)
+		model := newServiceModel(t, "")
+		model.config.SetWhisperServiceHealthPath("/ready")
+
+		// Act
+		withoutKey := model.probeService(server.URL)
+		model.config.SetWhisperServiceAPIKey("s3cret")
+		withKey := model.probeService(server.URL)
+
+		// Assert
+		assert.False(t, withoutKey)
+		assert.True(t, withKey)
+	})
+
+	t.Run("should reject an unknown upload format", func(t *testing.T) {
+		model := newServiceModel(t, "http://localhost:9000")
+		model.config.SetWhisperServiceUpload("chunked")
+
+		assert.ErrorContains(t, model.loadWithService(), "whisper.service_upload")
+	})
+}
+
+func TestParseServiceResponse(t *testing.T) {
+	audio := make([]byte, 64000)
+
+	t.Run("should read the whisper.cpp transcription layout", func(t *testing.T) {
+		body := []byte(`{"result":{"language":"en"},"transcription":[{"text":" Text WIN to 12345","offsets":{"from":0,"to":2000},
+			"tokens":[{"text":" Text","p":0.9,"offsets":{"from":0,"to":400}},{"text":" WIN","p":0.7,"offsets":{"from":400,"to":900}}]}]}`)
+
+		segments, err := parseServiceResponse(body, "application/json", audio)
+
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+		assert.Equal(t, "Text WIN to 12345", segments[0].Text)
+		assert.Equal(t, 2000, segments[0].EndMS)
+		assert.Equal(t, "en", segments[0].Language)
+		assert.InDelta(t, 0.8, segments[0].Confidence, 0.001)
+		assert.Len(t, segments[0].Words, 2)
+	})
+
+	t.Run("should accept a plain text response", func(t *testing.T) {
+		segments, err := parseServiceResponse([]byte(" Text WIN to 12345\n"), "text/plain; charset=utf-8", audio)
+
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+		assert.Equal(t, "Text WIN to 12345", segments[0].Text)
+		assert.Equal(t, 2000, segments[0].EndMS)
+	})
+
+	t.Run("should return no segments for an empty response", func(t *testing.T) {
+		segments, err := parseServiceResponse([]byte(""), "text/plain", audio)
+
+		require.NoError(t, err)
+		assert.Empty(t, segments)
+	})
+}
+
+func TestWhisperCppModel_TranscribeWithAPI(t *testing.T) {
+	newAPIModel := func(t *testing.T, handler http.HandlerFunc) *WhisperCppModel {
+		t.Helper()
+		server := httptest.NewServer(handler)
+		t.Cleanup(server.Close)
+		model := NewWhisperCppModelWithConfig(zaptest.NewLogger(t), config.NewConfiguration())
+		model.config.SetWhisperAPIBaseURL(server.URL + "/v1/")
+		model.apiKey = "sk-test"
+		model.retry.MaxAttempts = 1
+		return model
+	}
+
+	t.Run("should upload the audio and read segment and word timestamps", func(t *testing.T) {
+		// Arrange
+		model := newAPIModel(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/audio/transcriptions", r.URL.Path)
+			assert.Equal(t, "Bearer sk-test", r.Header.Get("Authorization"))
+			file, header, err := r.FormFile("file")
+			if assert.NoError(t, err) {
+				defer file.Close()
+				assert.Equal(t, int64(44+64000), header.Size)
+			}
+			assert.Equal(t, "whisper-1", r.FormValue("model"))
+			assert.Equal(t, "verbose_json", r.FormValue("response_format"))
+			assert.Equal(t, "en", r.FormValue("language"))
+			assert.Equal(t, []string{"segment", "word"}, r.MultipartForm.Value["timestamp_granularities[]"])
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"language":"english","text":"Hello there. Text WIN to 12345.","segments":[
+				{"text":" Hello there.","start":0.0,"end":0.8,"avg_logprob":-0.1},
+				{"text":" Text WIN to 12345.","start":0.8,"end":2.0,"avg_logprob":-0.2}],
+				"words":[{"word":"Hello","start":0.0,"end":0.4},{"word":"there","start":0.4,"end":0.8},
+				{"word":"Text","start":0.8,"end":1.1},{"word":"WIN","start":1.1,"end":1.4},
+				{"word":"to","start":1.4,"end":1.5},{"word":"12345","start":1.5,"end":2.0}]}`))
+		})
+
+		// Act
+		segments, err := model.transcribeWithAPI(make([]byte, 64000), false)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, segments, 2)
+		assert.Equal(t, 800, segments[1].StartMS)
+		assert.Equal(t, 2000, segments[1].EndMS)
+		assert.InDelta(t, 0.819, segments[1].Confidence, 0.001)
+		require.Len(t, segments[1].Words, 4)
+		assert.Equal(t, "12345", segments[1].Words[3].Text)
+		assert.Len(t, segments[0].Words, 2)
+	})
+
+	t.Run("should use the translations endpoint without word timestamps", func(t *testing.T) {
+		// Arrange
+		model := newAPIModel(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/audio/translations", r.URL.Path)
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			assert.Empty(t, r.MultipartForm.Value["timestamp_granularities[]"])
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"text":"Hello"}`))
+		})
+
+		// Act
+		segments, err := model.transcribeWithAPI(make([]byte, 32000), true)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+		assert.Equal(t, "Hello", segments[0].Text)
+	})
+
+	t.Run("should abandon a request that exceeds the timeout", func(t *testing.T) {
+		// Arrange
+		release := make(chan struct{})
+		model := newAPIModel(t, func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		})
+		t.Cleanup(func() { close(release) })
+		model.config.SetWhisperAPITimeoutSec(1)
+
+		// Act
+		start := time.Now()
+		_, err := model.transcribeWithAPI(make([]byte, 32000), false)
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "API request failed")
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+}
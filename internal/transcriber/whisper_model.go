@@ -2,23 +2,41 @@ package transcriber
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"net/url"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
 	"radiocontestwinner/internal/config"
+	"radiocontestwinner/internal/exec"
 	"radiocontestwinner/internal/gpu"
+	"radiocontestwinner/internal/retry"
+	"radiocontestwinner/internal/usage"
 )
 
+// Transcription backends
+const (
+	BackendBinary  = "binary"
+	BackendService = "service"
+	BackendAPI     = "api"
+)
+
+// DefaultBackendPriority is the order backends are considered in unless whisper.backend_priority is set
+var DefaultBackendPriority = []string{BackendBinary, BackendService, BackendAPI}
+
 // WhisperCppModel implements the WhisperModel interface using real Whisper.cpp
 // This implementation can use either:
 // 1. A local Whisper.cpp binary (most reliable)
@@ -39,6 +57,17 @@ type WhisperCppModel struct {
 	useGPU         bool
 	gpuDeviceID    int
 	modelDownloader *ModelDownloader // For automatic model downloading
+
+	// Runtime backend selection; guarded by mu once MonitorBackends is running
+	mu                 sync.RWMutex
+	backend            string       // Active backend (BackendBinary, BackendService, or BackendAPI)
+	requestedModelPath string       // Model path passed to LoadModel, used when failing over to the binary
+	priority           []string     // Backend priority order validated by LoadModel
+	healthClient       *http.Client // Short-timeout client for service health probes
+
+	retry retry.Policy // Retries for transcription requests that fail with network or server errors
+
+	recordUsage func(backend string, seconds float64) // Called with the audio each backend transcribes; nil records nothing
 }
 
 // NewWhisperCppModel creates a new instance of the real Whisper.cpp model
@@ -48,18 +77,27 @@ func NewWhisperCppModel(logger *zap.Logger) *WhisperCppModel {
 
 // NewWhisperCppModelWithConfig creates a new instance with configuration
 func NewWhisperCppModelWithConfig(logger *zap.Logger, cfg *config.Configuration) *WhisperCppModel {
-	tempDir := "/tmp/whisper"
+	tempDir := cfg.GetPathsTempDir()
 	os.MkdirAll(tempDir, 0755)
 
 	model := &WhisperCppModel{
 		logger:          logger,
 		tempDir:         tempDir,
 		client:          &http.Client{Timeout: 30 * time.Second},
+		healthClient:    &http.Client{Timeout: 5 * time.Second},
 		modelType:       "base",                            // Default model
 		whisperBin:      "/usr/local/bin/whisper-cli",      // Pre-built binary path from container
 		config:          cfg,
 		gpuDetector:     gpu.NewGPUDetector(logger),
-		modelDownloader: NewModelDownloader(logger, "/app/models"), // Container models directory
+		modelDownloader: NewModelDownloaderWithConfig(logger, cfg.GetPathsModelDir(), cfg),
+		// Keep retries short so a struggling backend does not hold up the live stream
+		retry: retry.Policy{
+			MaxAttempts: 3,
+			BaseDelay:   250 * time.Millisecond,
+			MaxDelay:    time.Second,
+			Jitter:      0.2,
+			Budget:      5 * time.Second,
+		},
 	}
 
 	// Initialize GPU configuration
@@ -133,21 +171,106 @@ func (w *WhisperCppModel) LoadModel(modelPath string) error {
 		return fmt.Errorf("model path cannot be empty")
 	}
 
-	// Determine the transcription method to use
-	if w.isWhisperBinaryAvailable() {
-		w.logger.Info("using Whisper.cpp binary for transcription")
-		return w.loadWithBinary(modelPath)
-	} else if w.isWhisperServiceAvailable() {
-		w.logger.Info("using Whisper HTTP service for transcription")
-		return w.loadWithService()
-	} else {
-		w.logger.Warn("falling back to OpenAI Whisper API")
-		return w.loadWithAPI()
+	priority, err := validateBackendPriority(w.config.GetWhisperBackendPriority())
+	if err != nil {
+		return err
+	}
+	// Offline, only the local binary can transcribe without calling out
+	offline := w.config.GetOfflineMode()
+	if offline {
+		if !slices.Contains(priority, BackendBinary) {
+			return fmt.Errorf("offline_mode allows only the binary transcription backend, which whisper.backend_priority does not include")
+		}
+		priority = []string{BackendBinary}
+	}
+	w.requestedModelPath = modelPath
+	w.priority = priority
+
+	// English-only models ignore the translate task and transcribe foreign speech as garbled English
+	if w.config.GetTranscriptionTranslate() && strings.Contains(filepath.Base(modelPath), ".en.") {
+		w.logger.Warn("transcription.translate needs a multilingual model, but an English-only model is configured",
+			zap.String("path", modelPath))
+	}
+
+	// Use the first available backend in priority order
+	for i, backend := range priority {
+		switch backend {
+		case BackendBinary:
+			if !w.isWhisperBinaryAvailable() {
+				continue
+			}
+			w.logger.Info("using Whisper.cpp binary for transcription")
+			err = w.loadWithBinary(modelPath)
+		case BackendService:
+			if !w.isWhisperServiceAvailable() {
+				continue
+			}
+			w.logger.Info("using Whisper HTTP service for transcription")
+			err = w.loadWithService()
+		case BackendAPI:
+			if i == 0 {
+				w.logger.Info("using OpenAI Whisper API for transcription")
+			} else {
+				w.logger.Warn("falling back to OpenAI Whisper API")
+			}
+			err = w.loadWithAPI()
+		}
+		if err != nil {
+			return err
+		}
+
+		w.mu.Lock()
+		w.backend = backend
+		w.mu.Unlock()
+		return nil
+	}
+
+	if offline {
+		return fmt.Errorf("no transcription backend available: offline_mode requires the whisper.cpp binary, which was not found")
+	}
+	return fmt.Errorf("no transcription backend available (priority: %s)", strings.Join(priority, ", "))
+}
+
+// validateBackendPriority checks configured backend names, rejecting unknown names and duplicates
+func validateBackendPriority(backends []string) ([]string, error) {
+	if len(backends) == 0 {
+		return DefaultBackendPriority, nil
+	}
+
+	seen := make(map[string]bool, len(backends))
+	for _, backend := range backends {
+		switch backend {
+		case BackendBinary, BackendService, BackendAPI:
+		default:
+			return nil, fmt.Errorf("unknown transcription backend %q in whisper.backend_priority", backend)
+		}
+		if seen[backend] {
+			return nil, fmt.Errorf("transcription backend %q listed more than once in whisper.backend_priority", backend)
+		}
+		seen[backend] = true
+	}
+	return backends, nil
+}
+
+// backendOrder returns the backend priority order used for failover and switching back
+func (w *WhisperCppModel) backendOrder() []string {
+	if len(w.priority) == 0 {
+		return DefaultBackendPriority
 	}
+	return w.priority
 }
 
 // isWhisperBinaryAvailable checks if whisper.cpp binary is available
 func (w *WhisperCppModel) isWhisperBinaryAvailable() bool {
+	path, ok := w.findWhisperBinary()
+	if ok {
+		w.whisperBin = path
+	}
+	return ok
+}
+
+// findWhisperBinary returns the first whisper-cli binary found in the known locations
+func (w *WhisperCppModel) findWhisperBinary() (string, bool) {
 	// Check multiple possible locations for whisper-cli binary (prioritize container paths)
 	possiblePaths := []string{
 		"/usr/local/bin/whisper-cli",  // Pre-built container binary (primary)
@@ -160,39 +283,78 @@ func (w *WhisperCppModel) isWhisperBinaryAvailable() bool {
 	}
 
 	for _, path := range possiblePaths {
-		if _, err := exec.LookPath(path); err == nil {
-			w.whisperBin = path
-			return true
-		}
-		if _, err := os.Stat(path); err == nil {
-			w.whisperBin = path
-			return true
+		if binaryExists(path) {
+			return path, true
 		}
 	}
-	return false
+	return "", false
+}
+
+// FindWhisperBinary returns the whisper-cli binary the binary backend would use
+func FindWhisperBinary() (string, bool) {
+	return (&WhisperCppModel{}).findWhisperBinary()
+}
+
+// WhisperBinary returns the whisper-cli binary in use, or "" when there is none
+func (w *WhisperCppModel) WhisperBinary() string {
+	w.mu.RLock()
+	bin := w.whisperBin
+	w.mu.RUnlock()
+	if !binaryExists(bin) {
+		return ""
+	}
+	return bin
+}
+
+// binaryExists reports whether path is an executable on PATH or an existing file
+func binaryExists(path string) bool {
+	if path == "" {
+		return false
+	}
+	if _, err := exec.LookPath(path); err == nil {
+		return true
+	}
+	_, err := os.Stat(path)
+	return err == nil
 }
 
 // isWhisperServiceAvailable checks if a local Whisper HTTP service is running
 func (w *WhisperCppModel) isWhisperServiceAvailable() bool {
-	// Check common Whisper service endpoints
-	endpoints := []string{
-		"http://localhost:9000",
-		"http://whisper:9000",
-		"http://127.0.0.1:9000",
+	endpoint, ok := w.discoverServiceEndpoint()
+	if ok {
+		w.mu.Lock()
+		w.apiEndpoint = endpoint
+		w.mu.Unlock()
 	}
+	return ok
+}
 
-	for _, endpoint := range endpoints {
-		resp, err := w.client.Get(endpoint + "/health")
-		if err == nil && resp.StatusCode == 200 {
-			resp.Body.Close()
-			w.apiEndpoint = endpoint
-			return true
-		}
-		if resp != nil {
-			resp.Body.Close()
+// discoverServiceEndpoint returns the first configured candidate endpoint whose health check passes
+func (w *WhisperCppModel) discoverServiceEndpoint() (string, bool) {
+	for _, endpoint := range w.config.GetWhisperServiceEndpoints() {
+		if w.probeService(endpoint) {
+			return endpoint, true
 		}
 	}
-	return false
+	return "", false
+}
+
+// probeService checks the health endpoint of a Whisper HTTP service
+func (w *WhisperCppModel) probeService(endpoint string) bool {
+	if endpoint == "" {
+		return false
+	}
+	req, err := http.NewRequest("GET", endpoint+w.config.GetWhisperServiceHealthPath(), nil)
+	if err != nil {
+		return false
+	}
+	w.setServiceHeaders(req.Header)
+	resp, err := w.healthClient.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
 }
 
 // loadWithBinary configures for using whisper.cpp binary
@@ -211,7 +373,7 @@ func (w *WhisperCppModel) loadWithBinary(modelPath string) error {
 		// Attempt to download the model
 		if err := w.modelDownloader.EnsureModelExists(modelName, modelPath); err != nil {
 			// If download fails, check for built-in fallback model from container
-			fallbackPath := "/app/models/ggml-base.en.bin"
+			fallbackPath := filepath.Join(w.config.GetPathsModelDir(), "ggml-base.en.bin")
 			if modelName != "base.en" {
 				if _, fallbackErr := os.Stat(fallbackPath); fallbackErr == nil {
 					w.logger.Warn("model download failed, using built-in base.en model as fallback",
@@ -233,14 +395,38 @@ func (w *WhisperCppModel) loadWithBinary(modelPath string) error {
 		return fmt.Errorf("model file still not accessible after download attempt: %s", modelPath)
 	}
 
+	// Fail fast on a truncated or corrupted model rather than on whisper.cpp errors mid-run
+	if err := w.verifyModel(modelPath); err != nil {
+		return err
+	}
+
 	w.modelPath = modelPath
 	w.isLoaded = true
 	w.logger.Info("Whisper.cpp binary model configured", zap.String("path", modelPath))
 	return nil
 }
 
+// verifyModel checks the model file for corruption before it is loaded, unless disabled
+func (w *WhisperCppModel) verifyModel(modelPath string) error {
+	if !w.config.GetWhisperVerifyModel() {
+		return nil
+	}
+	modelName := w.extractModelNameFromPath(modelPath)
+	checksum := w.config.GetWhisperModelChecksums()[strings.ToLower(modelName)]
+	if err := VerifyModelFile(modelPath, modelName, checksum); err != nil {
+		return err
+	}
+	w.logger.Info("model file verified",
+		zap.String("path", modelPath),
+		zap.Bool("checksum_verified", checksum != ""))
+	return nil
+}
+
 // loadWithService configures for using HTTP service
 func (w *WhisperCppModel) loadWithService() error {
+	if upload := w.config.GetWhisperServiceUpload(); upload != serviceUploadRaw && upload != serviceUploadMultipart {
+		return fmt.Errorf("unknown whisper.service_upload %q (expected %s or %s)", upload, serviceUploadRaw, serviceUploadMultipart)
+	}
 	w.isLoaded = true
 	w.logger.Info("Whisper HTTP service configured", zap.String("endpoint", w.apiEndpoint))
 	return nil
@@ -258,47 +444,325 @@ func (w *WhisperCppModel) loadWithAPI() error {
 	return nil
 }
 
+// ActiveBackend returns the transcription backend currently in use
+func (w *WhisperCppModel) ActiveBackend() string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.backend != "" {
+		return w.backend
+	}
+	// Infer the backend from what's configured when LoadModel wasn't used
+	if w.whisperBin != "" && w.modelPath != "" {
+		return BackendBinary
+	} else if w.apiEndpoint != "" {
+		return BackendService
+	}
+	return BackendAPI
+}
+
 // Transcribe processes audio data and returns transcription segments
 func (w *WhisperCppModel) Transcribe(audioData []byte) ([]TranscriptionSegment, error) {
-	if !w.isLoaded {
+	w.mu.RLock()
+	loaded := w.isLoaded
+	w.mu.RUnlock()
+	if !loaded {
 		return nil, fmt.Errorf("whisper model not loaded")
 	}
 
 	w.logger.Debug("starting transcription", zap.Int("audio_bytes", len(audioData)))
 
-	// Choose transcription method based on what's available
-	if w.whisperBin != "" && w.modelPath != "" {
-		return w.transcribeWithBinary(audioData)
-	} else if w.apiEndpoint != "" {
-		return w.transcribeWithService(audioData)
-	} else {
-		return w.transcribeWithAPI(audioData)
+	backend := w.ActiveBackend()
+	segments, err := w.transcribeWith(backend, audioData)
+	if err == nil {
+		w.accountUsage(backend, audioData)
+	}
+	if err == nil || w.backendHealthy(backend) {
+		return segments, err
+	}
+
+	// The backend went away between health checks - recover and retry this chunk once
+	w.logger.Warn("transcription backend unavailable",
+		zap.String("backend", backend),
+		zap.Error(err))
+	next, recoverErr := w.recoverBackend(backend)
+	if recoverErr != nil {
+		return nil, err
+	}
+	segments, err = w.transcribeWith(next, audioData)
+	if err == nil {
+		w.accountUsage(next, audioData)
+	}
+	return segments, err
+}
+
+// SetUsageRecorder sets a function called with the seconds of audio each transcription
+// backend transcribes, split as in package usage
+func (w *WhisperCppModel) SetUsageRecorder(record func(backend string, seconds float64)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.recordUsage = record
+}
+
+// accountUsage records audio transcribed by backend. Mock transcriptions, returned by the API
+// backend when it has no key, transcribe nothing.
+func (w *WhisperCppModel) accountUsage(backend string, audioData []byte) {
+	w.mu.RLock()
+	record, useGPU, apiKey := w.recordUsage, w.useGPU, w.apiKey
+	w.mu.RUnlock()
+	if record == nil {
+		return
+	}
+
+	seconds := float64(len(audioData)) / 32000.0 // 16 kHz mono 16-bit PCM
+	switch backend {
+	case BackendBinary:
+		if useGPU {
+			record(usage.BackendBinaryGPU, seconds)
+		} else {
+			record(usage.BackendBinaryCPU, seconds)
+		}
+	case BackendService:
+		record(usage.BackendService, seconds)
+	default:
+		if apiKey != "" {
+			record(usage.BackendAPI, seconds)
+		}
+	}
+}
+
+// transcribeWith transcribes audio with the given backend. In translate mode the audio is
+// transcribed as spoken and translated to English, and the translation keeps the spoken text.
+func (w *WhisperCppModel) transcribeWith(backend string, audioData []byte) ([]TranscriptionSegment, error) {
+	if !w.config.GetTranscriptionTranslate() {
+		return w.runTask(backend, audioData, false)
+	}
+
+	original, err := w.runTask(backend, audioData, false)
+	if err != nil {
+		return nil, err
+	}
+	translated, err := w.runTask(backend, audioData, true)
+	if err != nil {
+		return nil, err
+	}
+	return attachOriginalText(translated, original), nil
+}
+
+// runTask transcribes audio with the given backend, translating it to English when translate is set
+func (w *WhisperCppModel) runTask(backend string, audioData []byte, translate bool) ([]TranscriptionSegment, error) {
+	switch backend {
+	case BackendBinary:
+		return w.transcribeWithBinary(audioData, translate)
+	case BackendService:
+		return w.transcribeWithService(audioData, translate)
+	default:
+		return w.transcribeWithAPI(audioData, translate)
+	}
+}
+
+// attachOriginalText sets the OriginalText of each translated segment to the spoken text of the
+// original segments centred within it. Whisper segments the two passes independently, so an
+// original segment belongs to the translated segment its midpoint falls in.
+func attachOriginalText(translated, original []TranscriptionSegment) []TranscriptionSegment {
+	for i := range translated {
+		var parts []string
+		for _, seg := range original {
+			mid := (seg.StartMS + seg.EndMS) / 2
+			last := i == len(translated)-1
+			if mid >= translated[i].StartMS && (mid < translated[i].EndMS || last) {
+				parts = append(parts, strings.TrimSpace(seg.Text))
+			}
+		}
+		translated[i].OriginalText = strings.Join(parts, " ")
+		if translated[i].Language == "" && len(original) > 0 {
+			translated[i].Language = original[0].Language
+			translated[i].LanguageProb = original[0].LanguageProb
+		}
+	}
+	return translated
+}
+
+// backendHealthy reports whether a backend is currently usable
+func (w *WhisperCppModel) backendHealthy(backend string) bool {
+	w.mu.RLock()
+	bin, modelPath, endpoint := w.whisperBin, w.modelPath, w.apiEndpoint
+	w.mu.RUnlock()
+
+	switch backend {
+	case BackendBinary:
+		if _, err := os.Stat(modelPath); err != nil {
+			return false
+		}
+		return binaryExists(bin)
+	case BackendService:
+		return w.probeService(endpoint)
+	case BackendAPI:
+		// Without a key the API backend only produces mock transcriptions
+		return os.Getenv("OPENAI_API_KEY") != ""
+	default:
+		return true
+	}
+}
+
+// activate switches to a backend if it is available. The binary backend requires the model
+// file to already exist; models are not downloaded at runtime. The API backend requires an
+// OpenAI API key.
+func (w *WhisperCppModel) activate(backend string) error {
+	switch backend {
+	case BackendBinary:
+		bin, ok := w.findWhisperBinary()
+		if !ok {
+			return fmt.Errorf("whisper.cpp binary not found")
+		}
+		if _, err := os.Stat(w.requestedModelPath); err != nil {
+			return fmt.Errorf("model file not accessible: %s", w.requestedModelPath)
+		}
+		w.mu.Lock()
+		w.whisperBin = bin
+		w.modelPath = w.requestedModelPath
+		w.mu.Unlock()
+	case BackendService:
+		endpoint, ok := w.discoverServiceEndpoint()
+		if !ok {
+			return fmt.Errorf("no Whisper HTTP service endpoint is healthy")
+		}
+		w.mu.Lock()
+		w.apiEndpoint = endpoint
+		w.mu.Unlock()
+	case BackendAPI:
+		apiKey := os.Getenv("OPENAI_API_KEY")
+		if apiKey == "" {
+			return fmt.Errorf("OPENAI_API_KEY is not set")
+		}
+		w.mu.Lock()
+		w.apiKey = apiKey
+		w.mu.Unlock()
+	default:
+		return fmt.Errorf("unknown transcription backend %q", backend)
+	}
+
+	w.mu.Lock()
+	w.backend = backend
+	w.isLoaded = true
+	w.mu.Unlock()
+	return nil
+}
+
+// failover switches from a failed backend to the first other available one in priority order
+func (w *WhisperCppModel) failover(from string) (string, error) {
+	for _, backend := range w.backendOrder() {
+		if backend == from {
+			continue
+		}
+		if err := w.activate(backend); err == nil {
+			w.logger.Warn("transcription backend failed over",
+				zap.String("from", from),
+				zap.String("to", backend))
+			return backend, nil
+		}
+	}
+	return "", fmt.Errorf("no transcription backend available")
+}
+
+// recoverBackend replaces an unavailable backend. A service is first re-discovered among the
+// candidate endpoints; otherwise transcription fails over to the next available backend.
+func (w *WhisperCppModel) recoverBackend(failed string) (string, error) {
+	if failed == BackendService {
+		if endpoint, ok := w.discoverServiceEndpoint(); ok {
+			w.mu.Lock()
+			previous := w.apiEndpoint
+			w.apiEndpoint = endpoint
+			w.mu.Unlock()
+			w.logger.Info("re-discovered Whisper HTTP service",
+				zap.String("previous_endpoint", previous),
+				zap.String("endpoint", endpoint))
+			return BackendService, nil
+		}
+	}
+	return w.failover(failed)
+}
+
+// CheckBackends health checks the active backend and recovers it when unavailable. While
+// healthy, a higher-priority backend that has become available again is switched back to.
+func (w *WhisperCppModel) CheckBackends() {
+	active := w.ActiveBackend()
+
+	if !w.backendHealthy(active) {
+		w.logger.Warn("transcription backend failed health check",
+			zap.String("backend", active))
+		if _, err := w.recoverBackend(active); err != nil {
+			w.logger.Error("no transcription backend available", zap.Error(err))
+		}
+		return
+	}
+
+	for _, backend := range w.backendOrder() {
+		if backend == active {
+			return
+		}
+		if err := w.activate(backend); err == nil {
+			w.logger.Info("switched back to higher-priority transcription backend",
+				zap.String("from", active),
+				zap.String("to", backend))
+			return
+		}
+	}
+}
+
+// MonitorBackends runs CheckBackends every interval until ctx is cancelled
+func (w *WhisperCppModel) MonitorBackends(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.mu.RLock()
+			loaded := w.isLoaded
+			w.mu.RUnlock()
+			if loaded {
+				w.CheckBackends()
+			}
+		}
 	}
 }
 
 // transcribeWithBinary uses whisper.cpp binary for transcription
-func (w *WhisperCppModel) transcribeWithBinary(audioData []byte) ([]TranscriptionSegment, error) {
+func (w *WhisperCppModel) transcribeWithBinary(audioData []byte, translate bool) ([]TranscriptionSegment, error) {
 	// Save audio to temporary WAV file
-	tempFile := filepath.Join(w.tempDir, fmt.Sprintf("audio_%d.wav", time.Now().UnixNano()))
-	defer os.Remove(tempFile)
-
-	if err := w.saveAudioToWAV(audioData, tempFile); err != nil {
+	tempFile, err := w.writeTempWAV(audioData)
+	if err != nil {
 		return nil, fmt.Errorf("failed to save audio: %w", err)
 	}
+	defer os.Remove(tempFile)
+
+	w.mu.RLock()
+	bin, modelPath := w.whisperBin, w.modelPath
+	w.mu.RUnlock()
 
 	// Get configuration values
 	threads := w.config.GetWhisperThreads()
 	useGPU := w.useGPU
 	deviceID := w.gpuDeviceID
 
-	// Build command arguments with JSON output for better timing
+	// Build command arguments with full JSON output for timing and token probabilities
 	args := []string{
-		"-m", w.modelPath,
+		"-m", modelPath,
 		"-f", tempFile,
-		"--output-json",
+		"--output-json-full",
 		"--output-file", tempFile + ".out",
 		"--threads", strconv.Itoa(threads),
-		"--language", "en",
+		"--language", w.config.GetTranscriptionLanguage(),
+	}
+	if translate {
+		args = append(args, "--translate")
 	}
 
 	// Add GPU-specific arguments
@@ -315,7 +779,8 @@ func (w *WhisperCppModel) transcribeWithBinary(audioData []byte) ([]Transcriptio
 	}
 
 	// Run whisper.cpp binary
-	cmd := exec.Command(w.whisperBin, args...)
+	cmd := exec.Command(context.Background(), bin, args...)
+	cmd.Timeout = time.Duration(w.config.GetWhisperBinaryTimeoutSec()) * time.Second
 
 	// Log the command being executed for debugging
 	w.logger.Debug("whisper command details",
@@ -328,7 +793,7 @@ func (w *WhisperCppModel) transcribeWithBinary(audioData []byte) ([]Transcriptio
 	outputFile := tempFile + ".out.json"
 	defer os.Remove(outputFile)
 
-	output, err := cmd.CombinedOutput()
+	output, err := w.runWhisperCommand(cmd)
 
 	// Always log the output for debugging
 	w.logger.Debug("whisper command completed",
@@ -358,12 +823,18 @@ func (w *WhisperCppModel) transcribeWithBinary(audioData []byte) ([]Transcriptio
 
 	// Parse JSON response with segments
 	var result struct {
-		Text          string `json:"text"`
-		Language      string `json:"language"`
-		Segments      []struct {
-			Text  string  `json:"text"`
-			Start float64 `json:"start"`
-			End   float64 `json:"end"`
+		Text     string `json:"text"`
+		Language string `json:"language"`
+		Result   struct {
+			Language string `json:"language"`
+		} `json:"result"`
+		Segments []struct {
+			Text         string        `json:"text"`
+			Start        float64       `json:"start"`
+			End          float64       `json:"end"`
+			AvgLogprob   *float64      `json:"avg_logprob"`
+			NoSpeechProb *float64      `json:"no_speech_prob"`
+			Words        []verboseWord `json:"words"`
 		} `json:"segments"`
 		Transcription []struct {
 			Text    string `json:"text"`
@@ -371,6 +842,8 @@ func (w *WhisperCppModel) transcribeWithBinary(audioData []byte) ([]Transcriptio
 				From int `json:"from"`
 				To   int `json:"to"`
 			} `json:"offsets"`
+			Tokens       []whisperToken `json:"tokens"`
+			NoSpeechProb *float64       `json:"no_speech_prob"`
 		} `json:"transcription"`
 	}
 
@@ -378,25 +851,36 @@ func (w *WhisperCppModel) transcribeWithBinary(audioData []byte) ([]Transcriptio
 		return nil, fmt.Errorf("failed to parse whisper JSON output: %w", err)
 	}
 
+	language := result.Result.Language
+	if language == "" {
+		language = result.Language
+	}
+
 	var segments []TranscriptionSegment
 	if len(result.Segments) > 0 {
 		// Use actual segments with timing from whisper.cpp (legacy format)
 		for _, seg := range result.Segments {
 			segments = append(segments, TranscriptionSegment{
-				Text:       strings.TrimSpace(seg.Text),
-				StartMS:    int(seg.Start * 1000),
-				EndMS:      int(seg.End * 1000),
-				Confidence: 0.85,
+				Text:         strings.TrimSpace(seg.Text),
+				StartMS:      int(seg.Start * 1000),
+				EndMS:        int(seg.End * 1000),
+				Confidence:   logprobConfidence(seg.AvgLogprob),
+				NoSpeechProb: optionalProbability(seg.NoSpeechProb),
+				Language:     language,
+				Words:        verboseWords(seg.Words),
 			})
 		}
 	} else if len(result.Transcription) > 0 {
-		// Use transcription array with offsets (new format)
+		// Use transcription array with offsets (new format); confidence is the mean token probability
 		for _, trans := range result.Transcription {
 			segments = append(segments, TranscriptionSegment{
-				Text:       strings.TrimSpace(trans.Text),
-				StartMS:    trans.Offsets.From,
-				EndMS:      trans.Offsets.To,
-				Confidence: 0.85,
+				Text:         strings.TrimSpace(trans.Text),
+				StartMS:      trans.Offsets.From,
+				EndMS:        trans.Offsets.To,
+				Confidence:   tokenConfidence(trans.Tokens),
+				NoSpeechProb: optionalProbability(trans.NoSpeechProb),
+				Language:     language,
+				Words:        tokenWords(trans.Tokens),
 			})
 		}
 	} else if result.Text != "" {
@@ -405,7 +889,8 @@ func (w *WhisperCppModel) transcribeWithBinary(audioData []byte) ([]Transcriptio
 			Text:       strings.TrimSpace(result.Text),
 			StartMS:    0,
 			EndMS:      int(float64(len(audioData)) / 32000.0 * 1000), // Approximate duration
-			Confidence: 0.85,
+			Confidence: defaultSegmentConfidence,
+			Language:   language,
 		})
 	}
 
@@ -427,118 +912,348 @@ func (w *WhisperCppModel) transcribeWithBinary(audioData []byte) ([]Transcriptio
 	return segments, nil
 }
 
+// runWhisperCommand runs whisper-cli and returns its combined output. It is pinned to the
+// configured CPUs once started and killed with its children when it exceeds the timeout.
+func (w *WhisperCppModel) runWhisperCommand(cmd *exec.Cmd) ([]byte, error) {
+	cmd.OnAffinityError = func(err error) {
+		w.logger.Warn("failed to apply CPU affinity to whisper-cli", zap.Error(err))
+	}
+	return cmd.CombinedOutput()
+}
+
+// Ways audio is sent to the Whisper HTTP service (whisper.service_upload)
+const (
+	serviceUploadRaw       = "raw"       // WAV bytes as the request body
+	serviceUploadMultipart = "multipart" // multipart/form-data file upload, as whisper-asr-webservice and the whisper.cpp server expect
+)
+
 // transcribeWithService uses HTTP service for transcription
-func (w *WhisperCppModel) transcribeWithService(audioData []byte) ([]TranscriptionSegment, error) {
-	// Write the audio data as form field
-	req, err := http.NewRequest("POST", w.apiEndpoint+"/transcribe", bytes.NewReader(audioData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+func (w *WhisperCppModel) transcribeWithService(audioData []byte, translate bool) ([]TranscriptionSegment, error) {
+	w.mu.RLock()
+	endpoint := w.apiEndpoint
+	w.mu.RUnlock()
+
+	// Ask for JSON with word timestamps; servers that don't support a parameter ignore it
+	query := url.Values{"word_timestamps": {"true"}, "output": {"json"}}
+	if translate {
+		query.Set("task", "translate")
 	}
+	target := endpoint + w.config.GetWhisperServicePath() + "?" + query.Encode()
 
-	req.Header.Set("Content-Type", "audio/wav")
-	req.Header.Set("Accept", "application/json")
+	payload, contentType, err := w.servicePayload(audioData, translate)
+	if err != nil {
+		return nil, err
+	}
 
-	resp, err := w.client.Do(req)
+	resp, err := w.doWithRetry(func() (*http.Request, error) {
+		req, err := http.NewRequest("POST", target, bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("Accept", "application/json")
+		w.setServiceHeaders(req.Header)
+		return req, nil
+	}, 0, "transcription request failed", "transcription service error")
 	if err != nil {
-		return nil, fmt.Errorf("transcription request failed: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("transcription service error %d: %s", resp.StatusCode, string(body))
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	segments, err := parseServiceResponse(body, resp.Header.Get("Content-Type"), audioData)
+	if err != nil {
+		return nil, err
 	}
 
+	w.logger.Info("transcription completed with service", zap.Int("segments", len(segments)))
+	return segments, nil
+}
+
+// servicePayload returns the request body carrying audioData to the Whisper HTTP service and its
+// content type, in the configured upload format
+func (w *WhisperCppModel) servicePayload(audioData []byte, translate bool) ([]byte, string, error) {
+	switch upload := w.config.GetWhisperServiceUpload(); upload {
+	case serviceUploadRaw:
+		return audioData, "audio/wav", nil
+	case serviceUploadMultipart:
+		var buf bytes.Buffer
+		form := multipart.NewWriter(&buf)
+		file, err := form.CreateFormFile(w.config.GetWhisperServiceFileField(), "audio.wav")
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to create multipart upload: %w", err)
+		}
+		file.Write(w.createWAVHeader(len(audioData)))
+		file.Write(audioData)
+		// Options the whisper.cpp server reads from the form rather than the query
+		form.WriteField("response_format", "verbose_json")
+		if translate {
+			form.WriteField("translate", "true")
+		}
+		if err := form.Close(); err != nil {
+			return nil, "", fmt.Errorf("failed to create multipart upload: %w", err)
+		}
+		return buf.Bytes(), form.FormDataContentType(), nil
+	default:
+		return nil, "", fmt.Errorf("unknown whisper.service_upload %q (expected %s or %s)", upload, serviceUploadRaw, serviceUploadMultipart)
+	}
+}
+
+// setServiceHeaders adds the configured API key and extra headers to a request to the Whisper
+// HTTP service
+func (w *WhisperCppModel) setServiceHeaders(header http.Header) {
+	if key := w.config.GetWhisperServiceAPIKey(); key != "" {
+		header.Set("Authorization", "Bearer "+key)
+	}
+	for name, value := range w.config.GetWhisperServiceHeaders() {
+		header.Set(name, value)
+	}
+}
+
+// parseServiceResponse converts a Whisper HTTP service response to segments. It accepts the
+// verbose_json layout (faster-whisper, whisper-asr-webservice, the whisper.cpp server), the
+// whisper.cpp CLI layout with a transcription array, a bare {"text": ...}, and plain text when
+// the response is not declared as JSON.
+func parseServiceResponse(body []byte, contentType string, audioData []byte) ([]TranscriptionSegment, error) {
+	chunkMS := int(float64(len(audioData)) / 32000.0 * 1000)
+
+	trimmed := bytes.TrimSpace(body)
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType != "application/json" && (len(trimmed) == 0 || trimmed[0] != '{') {
+		if len(trimmed) == 0 {
+			return nil, nil
+		}
+		return []TranscriptionSegment{{
+			Text:       string(trimmed),
+			StartMS:    0,
+			EndMS:      chunkMS,
+			Confidence: defaultSegmentConfidence,
+		}}, nil
+	}
+
+	// Language probability is reported by whisper.cpp server (detected_language_probability) and
+	// faster-whisper (language_probability)
 	var result struct {
-		Text     string `json:"text"`
-		Language string `json:"language"`
+		Text                        string   `json:"text"`
+		Language                    string   `json:"language"`
+		LanguageProbability         *float64 `json:"language_probability"`
+		DetectedLanguageProbability *float64 `json:"detected_language_probability"`
+		Result                      struct {
+			Language string `json:"language"`
+		} `json:"result"`
 		Segments []struct {
-			Text  string  `json:"text"`
-			Start float64 `json:"start"`
-			End   float64 `json:"end"`
+			Text         string        `json:"text"`
+			Start        float64       `json:"start"`
+			End          float64       `json:"end"`
+			AvgLogprob   *float64      `json:"avg_logprob"`
+			NoSpeechProb *float64      `json:"no_speech_prob"`
+			Words        []verboseWord `json:"words"`
 		} `json:"segments"`
+		Transcription []struct {
+			Text    string `json:"text"`
+			Offsets struct {
+				From int `json:"from"`
+				To   int `json:"to"`
+			} `json:"offsets"`
+			Tokens []whisperToken `json:"tokens"`
+		} `json:"transcription"`
+		Words []verboseWord `json:"words"` // Top-level words, as from the OpenAI API layout
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := json.Unmarshal(trimmed, &result); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
+	language := result.Language
+	if language == "" {
+		language = result.Result.Language
+	}
+	languageProb := optionalProbability(result.LanguageProbability)
+	if result.DetectedLanguageProbability != nil {
+		languageProb = optionalProbability(result.DetectedLanguageProbability)
+	}
+
 	var segments []TranscriptionSegment
 	if len(result.Segments) > 0 {
+		// The OpenAI API reports words at the top level rather than per segment
+		allWords := verboseWords(result.Words)
 		for _, seg := range result.Segments {
+			segment := TranscriptionSegment{
+				Text:         seg.Text,
+				StartMS:      int(seg.Start * 1000),
+				EndMS:        int(seg.End * 1000),
+				Confidence:   logprobConfidence(seg.AvgLogprob),
+				NoSpeechProb: optionalProbability(seg.NoSpeechProb),
+				Language:     language,
+				LanguageProb: languageProb,
+				Words:        verboseWords(seg.Words),
+			}
+			if segment.Words == nil {
+				segment.Words = wordsBetween(allWords, segment.StartMS, segment.EndMS)
+			}
+			segments = append(segments, segment)
+		}
+	} else if len(result.Transcription) > 0 {
+		for _, trans := range result.Transcription {
 			segments = append(segments, TranscriptionSegment{
-				Text:       seg.Text,
-				StartMS:    int(seg.Start * 1000),
-				EndMS:      int(seg.End * 1000),
-				Confidence: 0.85,
+				Text:         strings.TrimSpace(trans.Text),
+				StartMS:      trans.Offsets.From,
+				EndMS:        trans.Offsets.To,
+				Confidence:   tokenConfidence(trans.Tokens),
+				Language:     language,
+				LanguageProb: languageProb,
+				Words:        tokenWords(trans.Tokens),
 			})
 		}
 	} else if result.Text != "" {
 		segments = append(segments, TranscriptionSegment{
-			Text:       result.Text,
-			StartMS:    0,
-			EndMS:      int(float64(len(audioData)) / 32000.0 * 1000),
-			Confidence: 0.85,
+			Text:         result.Text,
+			StartMS:      0,
+			EndMS:        chunkMS,
+			Confidence:   defaultSegmentConfidence,
+			Language:     language,
+			LanguageProb: languageProb,
+			Words:        verboseWords(result.Words),
 		})
 	}
-
-	w.logger.Info("transcription completed with service", zap.Int("segments", len(segments)))
 	return segments, nil
 }
 
 // transcribeWithAPI uses OpenAI Whisper API for transcription
-func (w *WhisperCppModel) transcribeWithAPI(audioData []byte) ([]TranscriptionSegment, error) {
-	if w.apiKey == "" {
+func (w *WhisperCppModel) transcribeWithAPI(audioData []byte, translate bool) ([]TranscriptionSegment, error) {
+	w.mu.RLock()
+	apiKey := w.apiKey
+	w.mu.RUnlock()
+
+	if apiKey == "" {
 		w.logger.Warn("no API key available, returning mock transcription")
 		return w.generateMockTranscription(audioData), nil
 	}
 
-	// Save audio to temporary file for API upload
-	tempFile := filepath.Join(w.tempDir, fmt.Sprintf("audio_%d.wav", time.Now().UnixNano()))
-	defer os.Remove(tempFile)
-
-	if err := w.saveAudioToWAV(audioData, tempFile); err != nil {
-		return nil, fmt.Errorf("failed to save audio: %w", err)
+	// The translations endpoint always translates to English
+	target := w.config.GetWhisperAPIBaseURL() + "/audio/transcriptions"
+	if translate {
+		target = w.config.GetWhisperAPIBaseURL() + "/audio/translations"
 	}
 
-	// Create multipart form for OpenAI API
-	var buf bytes.Buffer
-	req, err := http.NewRequest("POST", "https://api.openai.com/v1/audio/transcriptions", &buf)
+	payload, contentType, err := w.apiPayload(audioData, translate)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+w.apiKey)
-	req.Header.Set("Content-Type", "multipart/form-data")
-
-	resp, err := w.client.Do(req)
+	timeout := time.Duration(w.config.GetWhisperAPITimeoutSec()) * time.Second
+	resp, err := w.doWithRetry(func() (*http.Request, error) {
+		req, err := http.NewRequest("POST", target, bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+		req.Header.Set("Content-Type", contentType)
+		return req, nil
+	}, timeout, "API request failed", "API error")
 	if err != nil {
-		return nil, fmt.Errorf("API request failed: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API response: %w", err)
 	}
-
-	var result struct {
-		Text string `json:"text"`
+	segments, err := parseServiceResponse(body, resp.Header.Get("Content-Type"), audioData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode API response: %w", err)
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode API response: %w", err)
+	w.logger.Info("transcription completed with OpenAI API", zap.Int("segments", len(segments)))
+	return segments, nil
+}
+
+// apiPayload returns the multipart/form-data upload of audioData as a WAV file for the
+// transcription API, asking for verbose_json with segment and word timestamps, and its content type
+func (w *WhisperCppModel) apiPayload(audioData []byte, translate bool) ([]byte, string, error) {
+	var buf bytes.Buffer
+	form := multipart.NewWriter(&buf)
+	file, err := form.CreateFormFile("file", "audio.wav")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create API upload: %w", err)
+	}
+	file.Write(w.createWAVHeader(len(audioData)))
+	file.Write(audioData)
+
+	form.WriteField("model", w.config.GetWhisperAPIModel())
+	form.WriteField("response_format", "verbose_json")
+	// Translations report segments only; transcriptions can time words too
+	if !translate {
+		form.WriteField("timestamp_granularities[]", "segment")
+		form.WriteField("timestamp_granularities[]", "word")
+		if language := w.config.GetTranscriptionLanguage(); language != "auto" {
+			form.WriteField("language", language)
+		}
+	}
+	if err := form.Close(); err != nil {
+		return nil, "", fmt.Errorf("failed to create API upload: %w", err)
 	}
+	return buf.Bytes(), form.FormDataContentType(), nil
+}
 
-	segment := TranscriptionSegment{
-		Text:       result.Text,
-		StartMS:    0,
-		EndMS:      int(float64(len(audioData)) / 32000.0 * 1000),
-		Confidence: 0.90, // OpenAI API typically has high confidence
+// doWithRetry sends the request built by newRequest, rebuilding and resending it after network
+// errors and retryable status codes. A positive timeout bounds each attempt, including reading
+// the response body. requestFailed and statusFailed prefix the errors for failed requests and
+// non-OK responses. The caller must close the returned response body.
+func (w *WhisperCppModel) doWithRetry(newRequest func() (*http.Request, error), timeout time.Duration, requestFailed, statusFailed string) (*http.Response, error) {
+	policy := w.retry
+	policy.OnRetry = func(next int, delay time.Duration, err error) {
+		w.logger.Warn("transcription request failed, retrying",
+			zap.Int("next_attempt", next),
+			zap.Duration("backoff", delay),
+			zap.Error(err))
 	}
 
-	w.logger.Info("transcription completed with OpenAI API", zap.String("text", result.Text))
-	return []TranscriptionSegment{segment}, nil
+	var resp *http.Response
+	err := retry.Do(context.Background(), policy, func(ctx context.Context, attempt int) error {
+		req, err := newRequest()
+		if err != nil {
+			return retry.Permanent(fmt.Errorf("failed to create request: %w", err))
+		}
+
+		cancel := context.CancelFunc(func() {})
+		if timeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+		}
+		r, err := w.client.Do(req.WithContext(ctx))
+		if err != nil {
+			cancel()
+			return fmt.Errorf("%s: %w", requestFailed, err)
+		}
+		r.Body = cancelOnClose{ReadCloser: r.Body, cancel: cancel}
+		if r.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(r.Body)
+			r.Body.Close()
+			err := fmt.Errorf("%s %d: %s", statusFailed, r.StatusCode, string(body))
+			if !retry.RetryableStatus(r.StatusCode) {
+				return retry.Permanent(err)
+			}
+			return err
+		}
+		resp = r
+		return nil
+	})
+	return resp, err
+}
+
+// cancelOnClose releases a request's timeout once its response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
 }
 
 // generateMockTranscription provides fallback when no real transcription is available
@@ -565,34 +1280,67 @@ func (w *WhisperCppModel) generateMockTranscription(audioData []byte) []Transcri
 	}}
 }
 
+// wavHeaderSize is the size of the canonical PCM WAV header
+const wavHeaderSize = 44
+
+// wavBuffers holds the buffers WAV files are assembled in. Chunks are all about the same size,
+// so after the first few transcriptions writing a chunk allocates nothing.
+var wavBuffers = sync.Pool{New: func() interface{} { return new([]byte) }}
+
+// writeTempWAV saves PCM audio data as a uniquely named WAV file in the temp directory and
+// returns its path; the caller removes it
+func (w *WhisperCppModel) writeTempWAV(audioData []byte) (string, error) {
+	file, err := os.CreateTemp(w.tempDir, "audio_*.wav")
+	if err != nil {
+		return "", err
+	}
+	if err := writeWAV(file, audioData); err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+	return file.Name(), nil
+}
+
 // saveAudioToWAV saves PCM audio data as a WAV file
 func (w *WhisperCppModel) saveAudioToWAV(audioData []byte, filename string) error {
-	// Create WAV header for 16-bit PCM mono at 16kHz
-	header := w.createWAVHeader(len(audioData))
-
 	file, err := os.Create(filename)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	return writeWAV(file, audioData)
+}
 
-	// Write WAV header
-	if _, err := file.Write(header); err != nil {
-		return err
+// writeWAV writes the header and audio to file in a single write from a pooled buffer, rather
+// than a write each, and closes it. Copying the chunk costs less than the second syscall; see
+// BenchmarkSaveAudioToWAV.
+func writeWAV(file *os.File, audioData []byte) error {
+	buf := wavBuffers.Get().(*[]byte)
+	defer wavBuffers.Put(buf)
+	size := wavHeaderSize + len(audioData)
+	if cap(*buf) < size {
+		*buf = make([]byte, size)
 	}
+	data := (*buf)[:size]
+	putWAVHeader(data[:wavHeaderSize], len(audioData))
+	copy(data[wavHeaderSize:], audioData)
 
-	// Write audio data
-	if _, err := file.Write(audioData); err != nil {
+	if _, err := file.Write(data); err != nil {
+		file.Close()
 		return err
 	}
-
-	return nil
+	return file.Close()
 }
 
 // createWAVHeader creates a WAV file header for the given data size
 func (w *WhisperCppModel) createWAVHeader(dataSize int) []byte {
-	header := make([]byte, 44)
+	header := make([]byte, wavHeaderSize)
+	putWAVHeader(header, dataSize)
+	return header
+}
 
+// putWAVHeader writes the header of 16-bit PCM mono audio at 16kHz with dataSize bytes of
+// samples into header
+func putWAVHeader(header []byte, dataSize int) {
 	// RIFF header
 	copy(header[0:4], "RIFF")
 	writeUint32 := func(val uint32, offset int) {
@@ -614,13 +1362,17 @@ func (w *WhisperCppModel) createWAVHeader(dataSize int) []byte {
 	writeUint16(16, 34)    // Bits per sample
 	copy(header[36:40], "data")
 	writeUint32(uint32(dataSize), 40) // Data size
-
-	return header
 }
 
 // extractModelNameFromPath extracts the model name from a file path
 // e.g., "/app/models/ggml-base.en.bin" -> "base.en"
 func (w *WhisperCppModel) extractModelNameFromPath(modelPath string) string {
+	return ModelNameFromPath(modelPath)
+}
+
+// ModelNameFromPath returns the model name of a ggml model file, e.g. "base.en" for
+// "ggml-base.en.bin", or "" when the file is not named that way
+func ModelNameFromPath(modelPath string) string {
 	fileName := filepath.Base(modelPath)
 
 	// Remove "ggml-" prefix and ".bin" suffix
@@ -647,11 +1399,14 @@ func (w *WhisperCppModel) Close() error {
 		os.RemoveAll(w.tempDir)
 	}
 
+	w.mu.Lock()
 	w.isLoaded = false
 	w.modelPath = ""
 	w.whisperBin = ""
 	w.apiEndpoint = ""
 	w.apiKey = ""
+	w.backend = ""
+	w.mu.Unlock()
 
 	w.logger.Info("Whisper.cpp model closed successfully")
 	return nil
@@ -42,8 +42,7 @@ func TestWhisperModelAutoDownload(t *testing.T) {
 		defer os.RemoveAll(tempDir)
 
 		modelPath := filepath.Join(tempDir, "ggml-base.en.bin")
-		err = os.WriteFile(modelPath, []byte("dummy model content"), 0644)
-		assert.NoError(t, err)
+		writeTestModel(t, modelPath)
 
 		// Create model with custom models directory
 		cfg := config.NewConfiguration()
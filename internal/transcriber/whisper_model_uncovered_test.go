@@ -5,12 +5,15 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
+
+	"radiocontestwinner/internal/config"
 )
 
 func TestWhisperCppModel_NewWhisperCppModel(t *testing.T) {
@@ -94,9 +97,9 @@ func TestWhisperCppModel_loadWithBinary(t *testing.T) {
 		require.NoError(t, err)
 		defer os.Remove(tempFile.Name())
 
-		// Write some dummy data to the file
-		tempFile.Write([]byte("dummy model data"))
+		// Write a minimal well-formed model to the file
 		tempFile.Close()
+		writeTestModel(t, tempFile.Name())
 
 		// Act
 		err = model.loadWithBinary(tempFile.Name())
@@ -106,6 +109,33 @@ func TestWhisperCppModel_loadWithBinary(t *testing.T) {
 		assert.Equal(t, tempFile.Name(), model.modelPath)
 		assert.True(t, model.isLoaded)
 	})
+
+	t.Run("should fail fast on a corrupted model", func(t *testing.T) {
+		// Arrange
+		model := NewWhisperCppModel(zaptest.NewLogger(t))
+		modelPath := filepath.Join(t.TempDir(), "ggml-base.en.bin")
+		require.NoError(t, os.WriteFile(modelPath, []byte("dummy model data"), 0644))
+
+		// Act
+		err := model.loadWithBinary(modelPath)
+
+		// Assert
+		assert.ErrorContains(t, err, "model corrupted")
+		assert.ErrorContains(t, err, "model download -model base.en")
+		assert.False(t, model.isLoaded)
+	})
+
+	t.Run("should skip verification when disabled", func(t *testing.T) {
+		// Arrange
+		cfg := config.NewConfiguration()
+		cfg.SetWhisperVerifyModel(false)
+		model := NewWhisperCppModelWithConfig(zaptest.NewLogger(t), cfg)
+		modelPath := filepath.Join(t.TempDir(), "ggml-base.en.bin")
+		require.NoError(t, os.WriteFile(modelPath, []byte("dummy model data"), 0644))
+
+		// Act & Assert
+		assert.NoError(t, model.loadWithBinary(modelPath))
+	})
 }
 
 func TestWhisperCppModel_loadWithService(t *testing.T) {
@@ -129,12 +159,46 @@ func TestWhisperCppModel_transcribeWithBinary(t *testing.T) {
 		model := NewWhisperCppModel(logger)
 
 		// Act
-		segments, err := model.transcribeWithBinary([]byte("test audio data"))
+		segments, err := model.transcribeWithBinary([]byte("test audio data"), false)
 
 		// Assert
 		assert.Error(t, err) // Should error when binary not available
 		assert.Nil(t, segments)
 	})
+
+	t.Run("should read token probabilities from full JSON output", func(t *testing.T) {
+		// Arrange
+		logger := zaptest.NewLogger(t)
+		model := NewWhisperCppModel(logger)
+		model.tempDir = t.TempDir()
+		output := `{"result": {"language": "en"}, "transcription": [{"text": " Text WIN to 12345",
+			"offsets": {"from": 0, "to": 2000},
+			"tokens": [{"text": "[_BEG_]", "p": 0.2}, {"text": " Text", "p": 0.9}, {"text": " WIN", "p": 0.7}]}]}`
+		script := filepath.Join(t.TempDir(), "whisper-cli")
+		require.NoError(t, os.WriteFile(script, []byte(`#!/bin/sh
+while [ $# -gt 0 ]; do
+  [ "$1" = "--output-json-full" ] && full=1
+  [ "$1" = "--output-file" ] && out="$2"
+  shift
+done
+[ -n "$full" ] || exit 1
+cat > "$out.json" <<'JSON'
+`+output+`
+JSON
+`), 0755))
+		model.whisperBin = script
+
+		// Act
+		segments, err := model.transcribeWithBinary(make([]byte, 32000), false)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+		assert.Equal(t, "Text WIN to 12345", segments[0].Text)
+		assert.InDelta(t, 0.8, segments[0].Confidence, 0.0001)
+		assert.Equal(t, "en", segments[0].Language)
+		assert.Equal(t, float32(0), segments[0].NoSpeechProb)
+	})
 }
 
 func TestWhisperCppModel_transcribeWithService(t *testing.T) {
@@ -144,7 +208,7 @@ func TestWhisperCppModel_transcribeWithService(t *testing.T) {
 		model := NewWhisperCppModel(logger)
 
 		// Act
-		segments, err := model.transcribeWithService([]byte("test audio data"))
+		segments, err := model.transcribeWithService([]byte("test audio data"), false)
 
 		// Assert
 		assert.Error(t, err) // Should error when service not available
@@ -188,7 +252,7 @@ func TestWhisperCppModel_transcribeWithService(t *testing.T) {
 		model.client = server.Client()
 
 		// Act
-		segments, err := model.transcribeWithService([]byte("test audio data"))
+		segments, err := model.transcribeWithService([]byte("test audio data"), false)
 
 		// Assert
 		require.NoError(t, err)
@@ -222,7 +286,7 @@ func TestWhisperCppModel_transcribeWithService(t *testing.T) {
 		model.client = server.Client()
 
 		// Act
-		segments, err := model.transcribeWithService([]byte("test audio data"))
+		segments, err := model.transcribeWithService([]byte("test audio data"), false)
 
 		// Assert
 		require.NoError(t, err)
@@ -233,6 +297,55 @@ func TestWhisperCppModel_transcribeWithService(t *testing.T) {
 		assert.Equal(t, float32(0.85), segments[0].Confidence)
 	})
 
+	t.Run("should surface verbose_json probabilities per segment", func(t *testing.T) {
+		// Arrange
+		logger := zaptest.NewLogger(t)
+		model := NewWhisperCppModel(logger)
+
+		response := map[string]interface{}{
+			"text":                          "Text WIN to 12345",
+			"language":                      "en",
+			"detected_language_probability": 0.97,
+			"segments": []map[string]interface{}{
+				{
+					"text":           "Text WIN to 12345",
+					"start":          0.0,
+					"end":            2.0,
+					"avg_logprob":    -0.1054,
+					"no_speech_prob": 0.02,
+				},
+				{
+					"text":           "Thank you.",
+					"start":          2.0,
+					"end":            4.0,
+					"avg_logprob":    -1.2,
+					"no_speech_prob": 0.91,
+				},
+			},
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(response)
+		}))
+		defer server.Close()
+
+		model.apiEndpoint = server.URL
+		model.client = server.Client()
+
+		// Act
+		segments, err := model.transcribeWithService([]byte("test audio data"), false)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, segments, 2)
+		assert.InDelta(t, 0.9, segments[0].Confidence, 0.001)
+		assert.InDelta(t, 0.02, segments[0].NoSpeechProb, 0.0001)
+		assert.Equal(t, "en", segments[0].Language)
+		assert.InDelta(t, 0.97, segments[0].LanguageProb, 0.0001)
+		assert.InDelta(t, 0.91, segments[1].NoSpeechProb, 0.0001)
+	})
+
 	t.Run("should handle service error responses", func(t *testing.T) {
 		// Arrange
 		logger := zaptest.NewLogger(t)
@@ -249,7 +362,7 @@ func TestWhisperCppModel_transcribeWithService(t *testing.T) {
 		model.client = server.Client()
 
 		// Act
-		segments, err := model.transcribeWithService([]byte("test audio data"))
+		segments, err := model.transcribeWithService([]byte("test audio data"), false)
 
 		// Assert
 		require.Error(t, err)
@@ -274,7 +387,7 @@ func TestWhisperCppModel_transcribeWithService(t *testing.T) {
 		model.client = server.Client()
 
 		// Act
-		segments, err := model.transcribeWithService([]byte("test audio data"))
+		segments, err := model.transcribeWithService([]byte("test audio data"), false)
 
 		// Assert
 		require.Error(t, err)
@@ -294,7 +407,7 @@ func TestWhisperCppModel_transcribeWithService(t *testing.T) {
 		}
 
 		// Act
-		segments, err := model.transcribeWithService([]byte("test audio data"))
+		segments, err := model.transcribeWithService([]byte("test audio data"), false)
 
 		// Assert
 		require.Error(t, err)
@@ -373,7 +486,7 @@ func TestWhisperCppModel_transcribeWithAPI(t *testing.T) {
 		model.apiKey = "" // No API key
 
 		// Act
-		segments, err := model.transcribeWithAPI([]byte("test audio data"))
+		segments, err := model.transcribeWithAPI([]byte("test audio data"), false)
 
 		// Assert
 		require.NoError(t, err)
@@ -389,7 +502,7 @@ func TestWhisperCppModel_transcribeWithAPI(t *testing.T) {
 		model.apiKey = "test-api-key"
 
 		// Act - This will attempt to call the real API but will fail and likely fall back
-		segments, err := model.transcribeWithAPI([]byte("test audio data"))
+		segments, err := model.transcribeWithAPI([]byte("test audio data"), false)
 
 		// Assert - Should get some result (either API success or fallback)
 		// The important thing is that we've covered the API path
@@ -407,7 +520,7 @@ func TestWhisperCppModel_transcribeWithAPI(t *testing.T) {
 		model.apiKey = "invalid-api-key" // This will cause authentication error
 
 		// Act - This will fail with API authentication error
-		_, err := model.transcribeWithAPI([]byte("test audio data"))
+		_, err := model.transcribeWithAPI([]byte("test audio data"), false)
 
 		// Assert - Should handle the error appropriately
 		// In real test environment, this will likely fail with API error
@@ -428,7 +541,7 @@ func TestWhisperCppModel_transcribeWithAPI(t *testing.T) {
 		}
 
 		// Act
-		segments, err := model.transcribeWithAPI([]byte("test audio data"))
+		segments, err := model.transcribeWithAPI([]byte("test audio data"), false)
 
 		// Assert
 		require.Error(t, err)
@@ -0,0 +1,435 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"radiocontestwinner/internal/parser"
+	"radiocontestwinner/internal/transcriber"
+)
+
+// Limits on what the console keeps in memory
+const (
+	maxTranscripts = 200
+	maxCues        = 50
+	maxCueRows     = 8
+)
+
+// Terminal size used until SetSize is called
+const (
+	defaultWidth  = 100
+	defaultHeight = 40
+)
+
+// ANSI escape sequences used to draw the console
+const (
+	clearToEOL = "\x1b[K"
+	clearToEnd = "\x1b[J"
+	cursorHome = "\x1b[H"
+	hideCursor = "\x1b[?25l"
+	showCursor = "\x1b[?25h"
+	styleAlert = "\x1b[1;33m"
+	styleGood  = "\x1b[32m"
+	styleBad   = "\x1b[31m"
+	styleDim   = "\x1b[2m"
+	styleReset = "\x1b[0m"
+)
+
+// channelGaugeW is the width of each pipeline channel gauge
+const channelGaugeW = 10
+
+// Source is the application the console monitors
+type Source interface {
+	HealthStatus() map[string]interface{}
+	DebugMode() bool
+	SetDebugMode(enabled bool)
+	MaintenanceMode() bool
+	SetMaintenanceMode(enabled bool)
+	Paused() bool
+	SetPaused(paused bool)
+}
+
+type transcriptLine struct {
+	at   time.Time
+	text string
+}
+
+type cueEntry struct {
+	cue          parser.ContestCue
+	at           time.Time
+	acknowledged bool
+}
+
+// Console is a terminal operator console showing live transcription, detected cues, and
+// pipeline health, with hotkeys to acknowledge cues and toggle debug logging. It implements
+// app.PipelineObserver.
+type Console struct {
+	source Source
+	now    func() time.Time
+
+	mu          sync.Mutex
+	transcripts []transcriptLine
+	cues        []cueEntry // Oldest first
+	width       int
+	height      int
+	message     string         // Feedback for the last hotkey
+	location    *time.Location // Zone times are shown in; nil uses the server's
+}
+
+// NewConsole creates a Console monitoring source
+func NewConsole(source Source) *Console {
+	return &Console{
+		source: source,
+		now:    time.Now,
+		width:  defaultWidth,
+		height: defaultHeight,
+	}
+}
+
+// clock formats t as a time of day in the console's zone; the caller holds mu
+func (c *Console) clock(t time.Time) string {
+	if c.location != nil {
+		t = t.In(c.location)
+	}
+	return t.Format("15:04:05")
+}
+
+// SetLocation shows times in location, e.g. the station's zone, instead of the server's
+func (c *Console) SetLocation(location *time.Location) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.location = location
+}
+
+// SetSize sets the terminal size the console renders for
+func (c *Console) SetSize(width, height int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if width > 0 {
+		c.width = width
+	}
+	if height > 0 {
+		c.height = height
+	}
+}
+
+// OnTranscription adds a segment to the transcription scroll
+func (c *Console) OnTranscription(segment transcriber.TranscriptionSegment) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.transcripts = append(c.transcripts, transcriptLine{at: c.now(), text: segment.Text})
+	if len(c.transcripts) > maxTranscripts {
+		c.transcripts = c.transcripts[len(c.transcripts)-maxTranscripts:]
+	}
+}
+
+// OnContestCue adds a detected cue awaiting acknowledgement
+func (c *Console) OnContestCue(cue parser.ContestCue) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cues = append(c.cues, cueEntry{cue: cue, at: c.now()})
+	if len(c.cues) > maxCues {
+		c.cues = c.cues[len(c.cues)-maxCues:]
+	}
+}
+
+// Unacknowledged returns how many cues have not been acknowledged
+func (c *Console) Unacknowledged() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.unacknowledged()
+}
+
+func (c *Console) unacknowledged() int {
+	count := 0
+	for _, entry := range c.cues {
+		if !entry.acknowledged {
+			count++
+		}
+	}
+	return count
+}
+
+// HandleKey applies a hotkey and reports whether the console should quit: a acknowledges the
+// oldest pending cue, A acknowledges all, d toggles debug, m toggles maintenance mode, q quits
+func (c *Console) HandleKey(key byte) bool {
+	switch key {
+	case 'a':
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for i := range c.cues {
+			if !c.cues[i].acknowledged {
+				c.cues[i].acknowledged = true
+				c.message = "Acknowledged " + cueSummary(c.cues[i].cue)
+				return false
+			}
+		}
+		c.message = "No cues to acknowledge"
+	case 'A':
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		count := 0
+		for i := range c.cues {
+			if !c.cues[i].acknowledged {
+				c.cues[i].acknowledged = true
+				count++
+			}
+		}
+		c.message = fmt.Sprintf("Acknowledged %d cues", count)
+	case 'd':
+		enabled := !c.source.DebugMode()
+		c.source.SetDebugMode(enabled)
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.message = "Debug logging " + onOff(enabled)
+	case 'm':
+		enabled := !c.source.MaintenanceMode()
+		c.source.SetMaintenanceMode(enabled)
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if enabled {
+			c.message = "Maintenance mode on: notifications suppressed"
+		} else {
+			c.message = "Maintenance mode off"
+		}
+	case 'p':
+		paused := !c.source.Paused()
+		c.source.SetPaused(paused)
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if paused {
+			c.message = "Pipeline paused: stream connected, transcription stopped"
+		} else {
+			c.message = "Pipeline resumed"
+		}
+	case 'q':
+		return true
+	}
+	return false
+}
+
+// Run draws the console to output every refresh and on each key read from input until ctx is
+// cancelled or q is pressed. The key reader blocks on input and is left behind on return, which
+// is fine for stdin at process exit.
+func (c *Console) Run(ctx context.Context, input io.Reader, output io.Writer, refresh time.Duration) error {
+	done := make(chan struct{})
+	defer close(done)
+	keys := make(chan byte)
+	go readKeys(input, keys, done)
+
+	fmt.Fprint(output, hideCursor)
+	defer fmt.Fprint(output, showCursor+styleReset+"\n")
+
+	ticker := time.NewTicker(refresh)
+	defer ticker.Stop()
+
+	c.draw(output)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case key, ok := <-keys:
+			if !ok {
+				// Input closed (e.g. not a terminal); keep displaying without hotkeys
+				keys = nil
+				continue
+			}
+			if c.HandleKey(key) {
+				return nil
+			}
+			c.draw(output)
+		case <-ticker.C:
+			c.draw(output)
+		}
+	}
+}
+
+// readKeys sends each byte read from input to keys, closing keys when input ends
+func readKeys(input io.Reader, keys chan<- byte, done <-chan struct{}) {
+	defer close(keys)
+	buf := make([]byte, 16)
+	for {
+		n, err := input.Read(buf)
+		for _, b := range buf[:n] {
+			select {
+			case keys <- b:
+			case <-done:
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// draw redraws the console in place, overwriting the previous frame
+func (c *Console) draw(output io.Writer) {
+	var b strings.Builder
+	b.WriteString(cursorHome)
+	for _, line := range strings.Split(c.Render(), "\n") {
+		b.WriteString(line)
+		b.WriteString(clearToEOL + "\n")
+	}
+	b.WriteString(clearToEnd)
+	io.WriteString(output, b.String())
+}
+
+// Render returns the current console frame
+func (c *Console) Render() string {
+	health := c.source.HealthStatus()
+	debug := c.source.DebugMode()
+	maintenance := c.source.MaintenanceMode()
+	paused := c.source.Paused()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var lines []string
+	add := func(line string) { lines = append(lines, line) }
+
+	// Header and health gauges
+	now, nowLayout := c.now(), "2006-01-02 15:04:05"
+	if c.location != nil {
+		now, nowLayout = now.In(c.location), nowLayout+" MST"
+	}
+	header := styleAlert + "RADIO CONTEST WINNER" + styleReset + " operator console  " + styleDim + now.Format(nowLayout) + styleReset
+	if paused {
+		header += "  " + styleAlert + "PAUSED" + styleReset
+	}
+	add(header)
+	add(fmt.Sprintf("Stream %s  FFmpeg %s  Transcription %s  Backend %v  Debug %s",
+		indicator(health, "stream_connected"), indicator(health, "audio_processing_active"),
+		indicator(health, "transcription_healthy"), valueOr(health, "transcription_backend", "-"), onOff(debug)))
+	add(fmt.Sprintf("Latency %v ms  Real-time %.2fx  Input %v dBFS  Cues %v  Disconnects %v",
+		valueOr(health, "average_latency_ms", 0), floatValue(health, "real_time_ratio"),
+		valueOr(health, "audio_input_level_dbfs", "-"), valueOr(health, "total_contest_cues", 0),
+		valueOr(health, "stream_disconnects", 0)))
+	add("Backlog " + channelGauges(health))
+	add("Notifications " + notificationState(health, maintenance))
+
+	// Detected cues, newest first
+	add("")
+	add(fmt.Sprintf("── Contest cues (%d unacknowledged) %s", c.unacknowledged(), strings.Repeat("─", 20)))
+	if len(c.cues) == 0 {
+		add(styleDim + "  none yet" + styleReset)
+	}
+	for i := len(c.cues) - 1; i >= 0 && len(c.cues)-i <= maxCueRows; i-- {
+		entry := c.cues[i]
+		line := fmt.Sprintf("%s  %s", c.clock(entry.at), truncate(cueSummary(entry.cue), c.width-14))
+		if entry.acknowledged {
+			add(styleDim + "   " + line + "  (ack)" + styleReset)
+		} else {
+			add(styleAlert + " ! " + line + styleReset)
+		}
+	}
+
+	// Footer is two lines; the transcription scroll gets what is left
+	add("")
+	add("── Live transcription " + strings.Repeat("─", 33))
+	rows := max(0, c.height-len(lines)-2)
+	start := max(0, len(c.transcripts)-rows)
+	for _, line := range c.transcripts[start:] {
+		add(fmt.Sprintf("%s  %s", c.clock(line.at), truncate(line.text, c.width-10)))
+	}
+
+	add("")
+	footer := "[a] acknowledge  [A] acknowledge all  [d] toggle debug  [m] maintenance  [p] pause  [q] quit"
+	if c.message != "" {
+		footer += "   " + styleDim + c.message + styleReset
+	}
+	add(footer)
+	return strings.Join(lines, "\n")
+}
+
+// cueSummary describes a cue in one line
+func cueSummary(cue parser.ContestCue) string {
+	keyword, number := cue.Details.Keyword, cue.Details.Number
+	if keyword == "" {
+		keyword = cue.ContestType
+	}
+	return fmt.Sprintf("%s -> %s", keyword, number)
+}
+
+// notificationState describes whether notifications are being sent
+func notificationState(health map[string]interface{}, maintenance bool) string {
+	suppressed := valueOr(health, "notifications_suppressed", 0)
+	switch {
+	case maintenance:
+		return fmt.Sprintf("%sMAINTENANCE (suppressed)%s  Suppressed %v", styleAlert, styleReset, suppressed)
+	case health["quiet_hours_active"] == true:
+		return fmt.Sprintf("%squiet hours (cues suppressed)%s  Suppressed %v", styleDim, styleReset, suppressed)
+	default:
+		return fmt.Sprintf("%son%s  Suppressed %v", styleGood, styleReset, suppressed)
+	}
+}
+
+// indicator shows a boolean health field as a coloured word
+func indicator(health map[string]interface{}, key string) string {
+	if ok, _ := health[key].(bool); ok {
+		return styleGood + "up" + styleReset
+	}
+	return styleBad + "down" + styleReset
+}
+
+// channelGauges draws a bar per pipeline channel showing how full it is
+func channelGauges(health map[string]interface{}) string {
+	depths, _ := health["channel_depths"].(map[string]int)
+	if len(depths) == 0 {
+		return styleDim + "-" + styleReset
+	}
+	names := make([]string, 0, len(depths))
+	for name := range depths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Pipeline channels are buffered to 100 items
+	const capacity = 100
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		filled := min(channelGaugeW, (depths[name]*channelGaugeW+capacity-1)/capacity)
+		parts = append(parts, fmt.Sprintf("%s [%s%s] %d", name,
+			strings.Repeat("#", filled), strings.Repeat(".", channelGaugeW-filled), depths[name]))
+	}
+	return strings.Join(parts, "  ")
+}
+
+func valueOr(health map[string]interface{}, key string, fallback interface{}) interface{} {
+	if value, ok := health[key]; ok && value != nil && value != "" {
+		return value
+	}
+	return fallback
+}
+
+func floatValue(health map[string]interface{}, key string) float64 {
+	value, _ := health[key].(float64)
+	return value
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+	return "off"
+}
+
+// truncate shortens s to at most width runes
+func truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width == 1 {
+		return "…"
+	}
+	return string(runes[:width-1]) + "…"
+}
@@ -0,0 +1,259 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"radiocontestwinner/internal/parser"
+	"radiocontestwinner/internal/transcriber"
+)
+
+// fakeSource is a Source with fixed health
+type fakeSource struct {
+	mu          sync.Mutex
+	health      map[string]interface{}
+	debug       bool
+	maintenance bool
+	paused      bool
+}
+
+func (s *fakeSource) HealthStatus() map[string]interface{} { return s.health }
+
+func (s *fakeSource) DebugMode() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.debug
+}
+
+func (s *fakeSource) SetDebugMode(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.debug = enabled
+}
+
+func (s *fakeSource) MaintenanceMode() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.maintenance
+}
+
+func (s *fakeSource) SetMaintenanceMode(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maintenance = enabled
+}
+
+func (s *fakeSource) Paused() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.paused
+}
+
+func (s *fakeSource) SetPaused(paused bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.paused = paused
+}
+
+func newTestConsole() (*Console, *fakeSource) {
+	source := &fakeSource{health: map[string]interface{}{
+		"stream_connected":      true,
+		"transcription_healthy": false,
+		"transcription_backend": "binary",
+		"channel_depths":        map[string]int{"transcription": 50, "contest_cue": 0},
+	}}
+	console := NewConsole(source)
+	console.now = func() time.Time { return time.Date(2026, 3, 1, 8, 30, 0, 0, time.UTC) }
+	return console, source
+}
+
+func newCue(keyword, number string) parser.ContestCue {
+	return *parser.NewContestCue(keyword, parser.CueDetails{Keyword: keyword, Number: number})
+}
+
+func TestConsole_Render(t *testing.T) {
+	t.Run("should show health, cues, and transcription", func(t *testing.T) {
+		// Arrange
+		console, _ := newTestConsole()
+		console.OnTranscription(transcriber.TranscriptionSegment{Text: "text WINNER to 12345"})
+		console.OnContestCue(newCue("WINNER", "12345"))
+
+		// Act
+		frame := console.Render()
+
+		// Assert
+		assert.Contains(t, frame, "Stream "+styleGood+"up")
+		assert.Contains(t, frame, "Transcription "+styleBad+"down")
+		assert.Contains(t, frame, "Backend binary")
+		assert.Contains(t, frame, "transcription [#####.....] 50")
+		assert.Contains(t, frame, "Contest cues (1 unacknowledged)")
+		assert.Contains(t, frame, " ! 08:30:00  WINNER -> 12345")
+		assert.Contains(t, frame, "08:30:00  text WINNER to 12345")
+	})
+
+	t.Run("should show times in the configured zone", func(t *testing.T) {
+		// Arrange
+		console, _ := newTestConsole()
+		console.SetLocation(time.FixedZone("CST", -6*60*60))
+		console.OnTranscription(transcriber.TranscriptionSegment{Text: "text WINNER to 12345"})
+
+		// Act
+		frame := console.Render()
+
+		// Assert
+		assert.Contains(t, frame, "2026-03-01 02:30:00 CST")
+		assert.Contains(t, frame, "02:30:00  text WINNER to 12345")
+	})
+
+	t.Run("should keep only the newest transcription lines that fit", func(t *testing.T) {
+		// Arrange
+		console, _ := newTestConsole()
+		console.SetSize(80, 16)
+		for i := 0; i < 30; i++ {
+			console.OnTranscription(transcriber.TranscriptionSegment{Text: "line " + string(rune('A'+i))})
+		}
+
+		// Act
+		frame := console.Render()
+
+		// Assert
+		assert.LessOrEqual(t, len(strings.Split(frame, "\n")), 16)
+		assert.Contains(t, frame, "line "+string(rune('A'+29)))
+		assert.NotContains(t, frame, "line A\n")
+	})
+
+	t.Run("should truncate long lines to the terminal width", func(t *testing.T) {
+		// Arrange
+		console, _ := newTestConsole()
+		console.SetSize(30, 40)
+		console.OnTranscription(transcriber.TranscriptionSegment{Text: strings.Repeat("x", 100)})
+
+		// Act
+		frame := console.Render()
+
+		// Assert
+		assert.Contains(t, frame, strings.Repeat("x", 19)+"…")
+		assert.NotContains(t, frame, strings.Repeat("x", 21))
+	})
+}
+
+func TestConsole_HandleKey(t *testing.T) {
+	t.Run("should acknowledge the oldest pending cue", func(t *testing.T) {
+		// Arrange
+		console, _ := newTestConsole()
+		console.OnContestCue(newCue("WINNER", "12345"))
+		console.OnContestCue(newCue("ROCK", "5678"))
+
+		// Act
+		quit := console.HandleKey('a')
+
+		// Assert
+		assert.False(t, quit)
+		assert.Equal(t, 1, console.Unacknowledged())
+		frame := console.Render()
+		assert.Contains(t, frame, "WINNER -> 12345  (ack)")
+		assert.Contains(t, frame, " ! 08:30:00  ROCK -> 5678")
+		assert.Contains(t, frame, "Acknowledged WINNER -> 12345")
+	})
+
+	t.Run("should acknowledge all cues", func(t *testing.T) {
+		// Arrange
+		console, _ := newTestConsole()
+		console.OnContestCue(newCue("WINNER", "12345"))
+		console.OnContestCue(newCue("ROCK", "5678"))
+
+		// Act
+		console.HandleKey('A')
+
+		// Assert
+		assert.Equal(t, 0, console.Unacknowledged())
+	})
+
+	t.Run("should toggle debug mode", func(t *testing.T) {
+		// Arrange
+		console, source := newTestConsole()
+
+		// Act
+		console.HandleKey('d')
+
+		// Assert
+		assert.True(t, source.DebugMode())
+		assert.Contains(t, console.Render(), "Debug on")
+	})
+
+	t.Run("should toggle maintenance mode", func(t *testing.T) {
+		// Arrange
+		console, source := newTestConsole()
+
+		// Act
+		console.HandleKey('m')
+
+		// Assert
+		assert.True(t, source.MaintenanceMode())
+		assert.Contains(t, console.Render(), "MAINTENANCE")
+
+		console.HandleKey('m')
+		assert.False(t, source.MaintenanceMode())
+	})
+
+	t.Run("should pause and resume the pipeline", func(t *testing.T) {
+		// Arrange
+		console, source := newTestConsole()
+
+		// Act
+		console.HandleKey('p')
+
+		// Assert
+		assert.True(t, source.Paused())
+		assert.Contains(t, console.Render(), "PAUSED")
+
+		console.HandleKey('p')
+		assert.False(t, source.Paused())
+		assert.Contains(t, console.Render(), "Pipeline resumed")
+	})
+
+	t.Run("should quit on q", func(t *testing.T) {
+		// Arrange
+		console, _ := newTestConsole()
+
+		// Act & Assert
+		assert.True(t, console.HandleKey('q'))
+		assert.False(t, console.HandleKey('x'))
+	})
+}
+
+func TestConsole_Run(t *testing.T) {
+	t.Run("should draw and return when q is pressed", func(t *testing.T) {
+		// Arrange
+		console, _ := newTestConsole()
+		var output bytes.Buffer
+
+		// Act
+		err := console.Run(context.Background(), strings.NewReader("dq"), &output, time.Hour)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Contains(t, output.String(), hideCursor)
+		assert.Contains(t, output.String(), "Debug on")
+		assert.Contains(t, output.String(), showCursor)
+	})
+
+	t.Run("should keep running without input until cancelled", func(t *testing.T) {
+		// Arrange
+		console, _ := newTestConsole()
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		// Act
+		err := console.Run(ctx, strings.NewReader(""), &bytes.Buffer{}, 10*time.Millisecond)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+}
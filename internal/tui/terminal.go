@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// EnterRawMode switches the terminal on f to unbuffered, unechoed input so hotkeys are read
+// without Enter. It uses stty, so it works on Linux and macOS; elsewhere it returns an error and
+// keys are read a line at a time. The returned function restores the previous settings.
+func EnterRawMode(f *os.File) (func(), error) {
+	saved, err := stty(f, "-g")
+	if err != nil {
+		return nil, fmt.Errorf("terminal does not support raw input: %w", err)
+	}
+	if _, err := stty(f, "-icanon", "-echo", "min", "1"); err != nil {
+		return nil, fmt.Errorf("failed to enable raw input: %w", err)
+	}
+	return func() { stty(f, strings.TrimSpace(saved)) }, nil
+}
+
+// Size returns the width and height of the terminal on f
+func Size(f *os.File) (int, int, error) {
+	out, err := stty(f, "size")
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read terminal size: %w", err)
+	}
+	fields := strings.Fields(out)
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("unexpected terminal size %q", out)
+	}
+	rows, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid terminal rows %q: %w", fields[0], err)
+	}
+	cols, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid terminal columns %q: %w", fields[1], err)
+	}
+	return cols, rows, nil
+}
+
+// stty runs stty against the terminal on f
+func stty(f *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = f
+	out, err := cmd.Output()
+	return string(out), err
+}
@@ -0,0 +1,268 @@
+// Package usage accounts for the audio each transcription backend transcribes, per day. It is
+// used for capacity planning and to keep an eye on what falling back to the OpenAI API costs.
+// The ledger is kept in a small JSON file so totals survive restarts and can be reported by the
+// usage command while the application runs.
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Backends usage is split by. The binary backend is split by whether whisper.cpp runs on the GPU.
+const (
+	BackendBinaryGPU = "binary_gpu"
+	BackendBinaryCPU = "binary_cpu"
+	BackendService   = "service"
+	BackendAPI       = "api"
+)
+
+// Backends lists the backends in report order
+var Backends = []string{BackendBinaryGPU, BackendBinaryCPU, BackendService, BackendAPI}
+
+// dateLayout is the form of the day keys in the ledger file
+const dateLayout = "2006-01-02"
+
+// Day is the audio transcribed on one day, in seconds per backend
+type Day struct {
+	Date    string             `json:"date"`
+	Seconds map[string]float64 `json:"seconds"`
+}
+
+// Total returns the seconds transcribed on the day by all backends
+func (d Day) Total() float64 {
+	var total float64
+	for _, seconds := range d.Seconds {
+		total += seconds
+	}
+	return total
+}
+
+// ledgerFile is the on-disk form of the ledger
+type ledgerFile struct {
+	Days map[string]map[string]float64 `json:"days"` // Date -> backend -> seconds
+}
+
+// Ledger records seconds of audio transcribed per day and backend. It is safe for concurrent use.
+type Ledger struct {
+	path     string
+	location *time.Location
+	now      func() time.Time
+
+	mu    sync.Mutex
+	days  map[string]map[string]float64
+	dirty bool // Whether there are records not yet saved
+}
+
+// Open opens the ledger saved at path, starting an empty one when the file does not exist yet.
+// Days are counted in location, or local time when it is nil. An empty path keeps the ledger in
+// memory only.
+func Open(path string, location *time.Location) (*Ledger, error) {
+	if location == nil {
+		location = time.Local
+	}
+	ledger := &Ledger{path: path, location: location, now: time.Now, days: map[string]map[string]float64{}}
+	if path == "" {
+		return ledger, nil
+	}
+	file, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if file.Days != nil {
+		ledger.days = file.Days
+	}
+	return ledger, nil
+}
+
+// readFile reads a ledger file; a missing file is an empty ledger
+func readFile(path string) (ledgerFile, error) {
+	var file ledgerFile
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return file, nil
+	}
+	if err != nil {
+		return file, fmt.Errorf("failed to read usage file: %w", err)
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return file, fmt.Errorf("failed to parse usage file %s: %w", path, err)
+	}
+	return file, nil
+}
+
+// Record adds seconds of audio transcribed by backend today
+func (l *Ledger) Record(backend string, seconds float64) {
+	if seconds <= 0 {
+		return
+	}
+	date := l.now().In(l.location).Format(dateLayout)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.days[date] == nil {
+		l.days[date] = map[string]float64{}
+	}
+	l.days[date][backend] += seconds
+	l.dirty = true
+}
+
+// Today returns the audio transcribed so far today
+func (l *Ledger) Today() Day {
+	date := l.now().In(l.location).Format(dateLayout)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return Day{Date: date, Seconds: copySeconds(l.days[date])}
+}
+
+// Totals returns the seconds transcribed by each backend over every recorded day
+func (l *Ledger) Totals() map[string]float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	totals := map[string]float64{}
+	for _, seconds := range l.days {
+		for backend, s := range seconds {
+			totals[backend] += s
+		}
+	}
+	return totals
+}
+
+// Days returns every recorded day, oldest first
+func (l *Ledger) Days() []Day {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return sortedDays(l.days)
+}
+
+// Save atomically writes the ledger to its file when it has unsaved records
+func (l *Ledger) Save() error {
+	if l.path == "" {
+		return nil
+	}
+	l.mu.Lock()
+	if !l.dirty {
+		l.mu.Unlock()
+		return nil
+	}
+	data, err := json.MarshalIndent(ledgerFile{Days: l.days}, "", "  ")
+	l.dirty = false
+	l.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal usage: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return fmt.Errorf("failed to create usage directory: %w", err)
+	}
+	tmp := l.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write usage file: %w", err)
+	}
+	if err := os.Rename(tmp, l.path); err != nil {
+		return fmt.Errorf("failed to replace usage file: %w", err)
+	}
+	return nil
+}
+
+// Run saves the ledger every interval and once more when ctx is done
+func (l *Ledger) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := l.Save(); err != nil {
+				onError(err)
+			}
+			return
+		case <-ticker.C:
+			if err := l.Save(); err != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// Load reads the days recorded in the ledger file at path, oldest first
+func Load(path string) ([]Day, error) {
+	file, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return sortedDays(file.Days), nil
+}
+
+// sortedDays copies days into a slice, oldest first
+func sortedDays(days map[string]map[string]float64) []Day {
+	result := make([]Day, 0, len(days))
+	for date, seconds := range days {
+		result = append(result, Day{Date: date, Seconds: copySeconds(seconds)})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Date < result[j].Date })
+	return result
+}
+
+// copySeconds copies a backend -> seconds map
+func copySeconds(seconds map[string]float64) map[string]float64 {
+	copied := make(map[string]float64, len(seconds))
+	for backend, s := range seconds {
+		copied[backend] = s
+	}
+	return copied
+}
+
+// WriteReport writes a table of the minutes each backend transcribed per day, with a total row
+func WriteReport(out io.Writer, days []Day) error {
+	if len(days) == 0 {
+		_, err := fmt.Fprintln(out, "No transcription usage recorded")
+		return err
+	}
+
+	header := fmt.Sprintf("%-10s", "DATE")
+	for _, backend := range Backends {
+		header += fmt.Sprintf("  %10s", backend)
+	}
+	header += fmt.Sprintf("  %10s", "total")
+	if _, err := fmt.Fprintln(out, header); err != nil {
+		return err
+	}
+
+	totals := Day{Date: "TOTAL", Seconds: map[string]float64{}}
+	row := func(day Day) error {
+		line := fmt.Sprintf("%-10s", day.Date)
+		for _, backend := range Backends {
+			line += fmt.Sprintf("  %10s", formatMinutes(day.Seconds[backend]))
+		}
+		line += fmt.Sprintf("  %10s", formatMinutes(day.Total()))
+		_, err := fmt.Fprintln(out, line)
+		return err
+	}
+	for _, day := range days {
+		for backend, seconds := range day.Seconds {
+			totals.Seconds[backend] += seconds
+		}
+		if err := row(day); err != nil {
+			return err
+		}
+	}
+	if err := row(totals); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(out, "Minutes of audio transcribed per backend")
+	return err
+}
+
+// formatMinutes formats seconds as minutes with one decimal
+func formatMinutes(seconds float64) string {
+	return fmt.Sprintf("%.1f", seconds/60)
+}
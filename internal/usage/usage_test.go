@@ -0,0 +1,122 @@
+package usage
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedger_Record(t *testing.T) {
+	t.Run("should split seconds by day and backend", func(t *testing.T) {
+		// Arrange
+		ledger, err := Open("", time.UTC)
+		require.NoError(t, err)
+		now := time.Date(2026, 10, 16, 23, 59, 0, 0, time.UTC)
+		ledger.now = func() time.Time { return now }
+
+		// Act
+		ledger.Record(BackendBinaryGPU, 30)
+		ledger.Record(BackendAPI, 5)
+		now = now.Add(2 * time.Minute)
+		ledger.Record(BackendBinaryGPU, 10)
+		ledger.Record(BackendAPI, 0)
+
+		// Assert
+		days := ledger.Days()
+		require.Len(t, days, 2)
+		assert.Equal(t, Day{Date: "2026-10-16", Seconds: map[string]float64{BackendBinaryGPU: 30, BackendAPI: 5}}, days[0])
+		assert.Equal(t, Day{Date: "2026-10-17", Seconds: map[string]float64{BackendBinaryGPU: 10}}, days[1])
+		assert.Equal(t, days[1], ledger.Today())
+		assert.Equal(t, map[string]float64{BackendBinaryGPU: 40, BackendAPI: 5}, ledger.Totals())
+	})
+
+	t.Run("should count days in the ledger's location", func(t *testing.T) {
+		zone := time.FixedZone("UTC-5", -5*60*60)
+		ledger, err := Open("", zone)
+		require.NoError(t, err)
+		ledger.now = func() time.Time { return time.Date(2026, 10, 17, 2, 0, 0, 0, time.UTC) }
+
+		ledger.Record(BackendService, 1)
+
+		assert.Equal(t, "2026-10-16", ledger.Today().Date)
+	})
+}
+
+func TestLedger_Save(t *testing.T) {
+	t.Run("should keep totals across reopening", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "data", "usage.json")
+		ledger, err := Open(path, time.UTC)
+		require.NoError(t, err)
+		ledger.now = func() time.Time { return time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC) }
+		ledger.Record(BackendBinaryCPU, 12.5)
+
+		// Act
+		require.NoError(t, ledger.Save())
+		reopened, err := Open(path, time.UTC)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, ledger.Days(), reopened.Days())
+		days, err := Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, ledger.Days(), days)
+	})
+
+	t.Run("should save on shutdown", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "usage.json")
+		ledger, err := Open(path, time.UTC)
+		require.NoError(t, err)
+		ledger.Record(BackendAPI, 3)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		ledger.Run(ctx, time.Hour, func(err error) { t.Error(err) })
+
+		days, err := Load(path)
+		require.NoError(t, err)
+		require.Len(t, days, 1)
+		assert.Equal(t, 3.0, days[0].Seconds[BackendAPI])
+	})
+
+	t.Run("should load a missing file as empty", func(t *testing.T) {
+		days, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+
+		assert.NoError(t, err)
+		assert.Empty(t, days)
+	})
+}
+
+func TestWriteReport(t *testing.T) {
+	t.Run("should write minutes per backend with a total row", func(t *testing.T) {
+		// Arrange
+		var out bytes.Buffer
+		days := []Day{
+			{Date: "2026-10-16", Seconds: map[string]float64{BackendBinaryGPU: 3600, BackendAPI: 90}},
+			{Date: "2026-10-17", Seconds: map[string]float64{BackendBinaryGPU: 600}},
+		}
+
+		// Act
+		require.NoError(t, WriteReport(&out, days))
+
+		// Assert
+		lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+		require.Len(t, lines, 5)
+		assert.Equal(t, "DATE        binary_gpu  binary_cpu     service         api       total", string(lines[0]))
+		assert.Equal(t, "2026-10-16        60.0         0.0         0.0         1.5        61.5", string(lines[1]))
+		assert.Equal(t, "TOTAL             70.0         0.0         0.0         1.5        71.5", string(lines[3]))
+	})
+
+	t.Run("should say when nothing was recorded", func(t *testing.T) {
+		var out bytes.Buffer
+
+		require.NoError(t, WriteReport(&out, nil))
+
+		assert.Equal(t, "No transcription usage recorded\n", out.String())
+	})
+}
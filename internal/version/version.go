@@ -0,0 +1,161 @@
+package version
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+// Build metadata, injected at build time:
+//
+//	go build -ldflags "-X radiocontestwinner/internal/version.Version=3.2.0 \
+//	    -X radiocontestwinner/internal/version.Commit=$(git rev-parse --short HEAD) \
+//	    -X radiocontestwinner/internal/version.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./cmd/radiocontestwinner
+//
+// Commit and BuildDate fall back to the VCS information Go embeds when building from a checkout.
+var (
+	Version   = "3.1"
+	Commit    = ""
+	BuildDate = ""
+)
+
+// unknown is reported for versions that could not be determined
+const unknown = "unknown"
+
+// Info describes this build and the external tools it runs
+type Info struct {
+	Version    string `json:"version"`
+	Commit     string `json:"commit"`
+	BuildDate  string `json:"build_date"`
+	GoVersion  string `json:"go_version"`
+	Platform   string `json:"platform"`
+	WhisperCpp string `json:"whisper_cpp_version,omitempty"`
+	FFmpeg     string `json:"ffmpeg_version,omitempty"`
+}
+
+// Get returns the build metadata of this binary
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if build, ok := debug.ReadBuildInfo(); ok {
+		modified := false
+		for _, setting := range build.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildDate == "" {
+					info.BuildDate = setting.Value
+				}
+			case "vcs.modified":
+				modified = setting.Value == "true"
+			}
+		}
+		if modified && Commit == "" && info.Commit != "" {
+			info.Commit += "-dirty"
+		}
+	}
+	if info.Commit == "" {
+		info.Commit = unknown
+	}
+	if info.BuildDate == "" {
+		info.BuildDate = unknown
+	}
+	return info
+}
+
+// Detect returns the build metadata along with the versions of the whisper.cpp and FFmpeg
+// binaries at the given paths, run to ask for them. An empty path skips that tool.
+func Detect(ctx context.Context, whisperBinary, ffmpegBinary string) Info {
+	info := Get()
+	if whisperBinary != "" {
+		info.WhisperCpp = WhisperCppVersion(ctx, whisperBinary)
+	}
+	if ffmpegBinary != "" {
+		info.FFmpeg = FFmpegVersion(ctx, ffmpegBinary)
+	}
+	return info
+}
+
+// FFmpegVersion returns the version ffmpeg -version reports, e.g. "4.4.2-0ubuntu0.22.04.1"
+func FFmpegVersion(ctx context.Context, path string) string {
+	output, err := runVersionCommand(ctx, path, "-version")
+	if err != nil {
+		return unknown
+	}
+	return parseFFmpegVersion(output)
+}
+
+// parseFFmpegVersion extracts the version from the first line of ffmpeg -version output
+func parseFFmpegVersion(output string) string {
+	fields := strings.Fields(firstLine(output))
+	if len(fields) >= 3 && fields[0] == "ffmpeg" && fields[1] == "version" {
+		return fields[2]
+	}
+	return unknown
+}
+
+// WhisperCppVersion returns the version whisper-cli --version reports. Builds without that
+// flag fall back to WHISPER_CPP_VERSION, which the Docker image sets to the whisper.cpp commit
+// it was built from.
+func WhisperCppVersion(ctx context.Context, path string) string {
+	if output, err := runVersionCommand(ctx, path, "--version"); err == nil {
+		if line := firstLine(output); line != "" && strings.ContainsAny(line, "0123456789") {
+			return line
+		}
+	}
+	if env := os.Getenv("WHISPER_CPP_VERSION"); env != "" {
+		return env
+	}
+	return unknown
+}
+
+// runVersionCommand runs path with arg, giving up after a few seconds
+func runVersionCommand(ctx context.Context, path, arg string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, path, arg).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run %s %s: %w", path, arg, err)
+	}
+	return string(output), nil
+}
+
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	return strings.TrimSpace(line)
+}
+
+// String formats the metadata for -version output
+func (i Info) String() string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "Radio Contest Winner\n")
+	fmt.Fprintf(&text, "Version: %s\n", i.Version)
+	fmt.Fprintf(&text, "Commit: %s\n", i.Commit)
+	fmt.Fprintf(&text, "Built: %s\n", i.BuildDate)
+	fmt.Fprintf(&text, "Go: %s %s\n", i.GoVersion, i.Platform)
+	if i.WhisperCpp != "" {
+		fmt.Fprintf(&text, "whisper.cpp: %s\n", i.WhisperCpp)
+	}
+	if i.FFmpeg != "" {
+		fmt.Fprintf(&text, "FFmpeg: %s\n", i.FFmpeg)
+	}
+	return text.String()
+}
+
+// UserAgent returns the User-Agent header for outgoing HTTP requests
+func UserAgent() string {
+	return "RadioContestWinner/" + Version + " (Go HTTP Client)"
+}
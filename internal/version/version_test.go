@@ -0,0 +1,91 @@
+package version
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	t.Run("should prefer injected build metadata", func(t *testing.T) {
+		// Arrange
+		defer func(version, commit, date string) { Version, Commit, BuildDate = version, commit, date }(Version, Commit, BuildDate)
+		Version, Commit, BuildDate = "3.2.0", "abc1234", "2025-06-01T12:00:00Z"
+
+		// Act
+		info := Get()
+
+		// Assert
+		assert.Equal(t, "3.2.0", info.Version)
+		assert.Equal(t, "abc1234", info.Commit)
+		assert.Equal(t, "2025-06-01T12:00:00Z", info.BuildDate)
+		assert.Equal(t, runtime.Version(), info.GoVersion)
+		assert.Contains(t, info.String(), "Version: 3.2.0")
+		assert.Equal(t, "RadioContestWinner/3.2.0 (Go HTTP Client)", UserAgent())
+	})
+
+	t.Run("should never leave commit or build date empty", func(t *testing.T) {
+		info := Get()
+
+		assert.NotEmpty(t, info.Commit)
+		assert.NotEmpty(t, info.BuildDate)
+	})
+}
+
+func TestParseFFmpegVersion(t *testing.T) {
+	t.Run("should read the version from the banner", func(t *testing.T) {
+		output := "ffmpeg version 4.4.2-0ubuntu0.22.04.1 Copyright (c) 2000-2021 the FFmpeg developers\nbuilt with gcc 11\n"
+
+		assert.Equal(t, "4.4.2-0ubuntu0.22.04.1", parseFFmpegVersion(output))
+		assert.Equal(t, "unknown", parseFFmpegVersion("command not found"))
+	})
+}
+
+func TestDetect(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell scripts as fake binaries")
+	}
+
+	// fakeBinary writes an executable script printing output
+	fakeBinary := func(t *testing.T, output string, exitCode int) string {
+		path := filepath.Join(t.TempDir(), "tool")
+		script := fmt.Sprintf("#!/bin/sh\necho '%s'\nexit %d\n", output, exitCode)
+		require.NoError(t, os.WriteFile(path, []byte(script), 0755))
+		return path
+	}
+
+	t.Run("should ask the tools for their versions", func(t *testing.T) {
+		// Act
+		info := Detect(context.Background(),
+			fakeBinary(t, "whisper.cpp v1.7.5", 0),
+			fakeBinary(t, "ffmpeg version 6.1.1 Copyright (c) 2000-2023", 0))
+
+		// Assert
+		assert.Equal(t, "whisper.cpp v1.7.5", info.WhisperCpp)
+		assert.Equal(t, "6.1.1", info.FFmpeg)
+	})
+
+	t.Run("should fall back to WHISPER_CPP_VERSION when whisper-cli has no --version", func(t *testing.T) {
+		t.Setenv("WHISPER_CPP_VERSION", "5527454")
+
+		info := Detect(context.Background(), fakeBinary(t, "error: unknown argument: --version", 1), "")
+
+		assert.Equal(t, "5527454", info.WhisperCpp)
+		assert.Empty(t, info.FFmpeg)
+	})
+
+	t.Run("should report missing tools as unknown", func(t *testing.T) {
+		t.Setenv("WHISPER_CPP_VERSION", "")
+
+		info := Detect(context.Background(), "/nonexistent/whisper-cli", "/nonexistent/ffmpeg")
+
+		assert.Equal(t, "unknown", info.WhisperCpp)
+		assert.Equal(t, "unknown", info.FFmpeg)
+	})
+}
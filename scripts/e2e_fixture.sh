@@ -0,0 +1,19 @@
+#!/bin/bash
+
+# Generates the AAC fixture used by the end-to-end pipeline test (go test -tags e2e ./internal/app)
+# Requires espeak-ng (text to speech) and ffmpeg
+
+set -e
+
+DIR="$(cd "$(dirname "$0")/.." && pwd)/internal/app/testdata/e2e"
+SCRIPT=$(sed -n 's/.*"script": "\(.*\)",/\1/p' "$DIR/manifest.json")
+WAV=$(mktemp --suffix=.wav)
+trap 'rm -f "$WAV"' EXIT
+
+echo "Synthesizing: $SCRIPT"
+espeak-ng -s 150 -w "$WAV" "$SCRIPT"
+
+# Stations stream ADTS AAC; pad with silence so the last words fill a transcription chunk
+ffmpeg -y -loglevel error -i "$WAV" -af "apad=pad_dur=5" -ar 44100 -ac 2 -c:a aac -b:a 64k -f adts "$DIR/contest_cue.aac"
+
+echo "Wrote $DIR/contest_cue.aac"